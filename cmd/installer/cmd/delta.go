@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg/delta"
+)
+
+var deltaOutputDir string
+
+// deltaCmd represents the delta command.
+var deltaCmd = &cobra.Command{
+	Use:   "delta <base> <target>",
+	Short: "Create the binary delta of the boot asset against the previous version",
+	Long: `The delta is written to <output>/<target name>-<sha256 of the base>.delta,
+so it can be published next to the target asset for the upgrades with the assets URL.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runDeltaCmd(args[0], args[1]); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	deltaCmd.Flags().StringVar(&deltaOutputDir, "output", ".", "The directory to write the delta to")
+	rootCmd.AddCommand(deltaCmd)
+}
+
+func runDeltaCmd(basePath, targetPath string) error {
+	base, err := ioutil.ReadFile(basePath)
+	if err != nil {
+		return err
+	}
+
+	target, err := ioutil.ReadFile(targetPath)
+	if err != nil {
+		return err
+	}
+
+	baseDigest := sha256.Sum256(base)
+
+	path := filepath.Join(deltaOutputDir, filepath.Base(targetPath)+"-"+hex.EncodeToString(baseDigest[:])+".delta")
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+
+	if err = delta.Create(w, base, target); err != nil {
+		return err
+	}
+
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("written delta %s: %s (target %s)", path, humanize.Bytes(uint64(info.Size())), humanize.Bytes(uint64(len(target))))
+
+	return f.Close()
+}
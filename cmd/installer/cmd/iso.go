@@ -5,6 +5,7 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,6 +16,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/talos-systems/talos/cmd/installer/pkg"
+	"github.com/talos-systems/talos/cmd/installer/pkg/install"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 var cfg = []byte(`set default=0
@@ -96,7 +99,18 @@ func runISOCmd() error {
 		return err
 	}
 
-	if err := ioutil.WriteFile(cfgPath, cfg, 0o666); err != nil {
+	rootHash, err := install.RootfsRootHash(options.Arch)
+	if err != nil {
+		return err
+	}
+
+	isoCfg := cfg
+
+	if rootHash != "" {
+		isoCfg = bytes.Replace(cfg, []byte("talos.platform=metal"), []byte(fmt.Sprintf("talos.platform=metal %s=%s", constants.KernelParamVerityRootHash, rootHash)), 1)
+	}
+
+	if err := ioutil.WriteFile(cfgPath, isoCfg, 0o666); err != nil {
 		return err
 	}
 
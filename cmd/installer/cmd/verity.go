@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/internal/pkg/mount"
+	"github.com/talos-systems/talos/internal/pkg/verity"
+)
+
+var rootHashArg string
+
+// verityCmd represents the verity command.
+var verityCmd = &cobra.Command{
+	Use:   "verity <rootfs.sqsh>",
+	Short: "Append dm-verity hash tree to the rootfs squashfs image",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runVerityCmd(args[0]); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	verityCmd.Flags().StringVar(&rootHashArg, "roothash", "rootfs.roothash", "The path to write the root hash to")
+	rootCmd.AddCommand(verityCmd)
+}
+
+func runVerityCmd(path string) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer f.Close()
+
+	size, err := mount.SquashfsDataSize(f)
+	if err != nil {
+		return err
+	}
+
+	// drop the hash tree if the image was already processed
+	if err = f.Truncate(size); err != nil {
+		return err
+	}
+
+	// salt is derived from the image contents to keep the build reproducible
+	h := sha256.New()
+
+	if _, err = io.Copy(h, io.NewSectionReader(f, 0, size)); err != nil {
+		return err
+	}
+
+	rootHash, _, err := verity.Format(f, size, h.Sum(nil))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("rootfs root hash %x", rootHash)
+
+	return ioutil.WriteFile(rootHashArg, []byte(hex.EncodeToString(rootHash)+"\n"), 0o644)
+}
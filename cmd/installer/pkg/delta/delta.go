@@ -0,0 +1,333 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package delta implements binary deltas of the boot assets against the previously installed version.
+//
+// Delta consists of the header followed by the zstd-compressed stream of operations:
+// copy a range of the base file, or append the literal data.
+package delta
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// BlockSize is the size of the base blocks matched in the target.
+const BlockSize = 4096
+
+var magic = [8]byte{'T', 'L', 'D', 'E', 'L', 'T', 'A', '1'}
+
+const (
+	opCopy    byte = 'C'
+	opLiteral byte = 'L'
+)
+
+// Header describes the delta.
+type Header struct {
+	// BaseDigest is the sha256 digest of the base the delta should be applied to.
+	BaseDigest [sha256.Size]byte
+	// TargetDigest is the sha256 digest of the result.
+	TargetDigest [sha256.Size]byte
+	// TargetSize is the size of the result.
+	TargetSize uint64
+}
+
+// ErrBaseMismatch is returned when the delta is applied to the wrong base.
+var ErrBaseMismatch = errors.New("delta base digest mismatch")
+
+// Create writes the delta which builds the target from the base.
+func Create(w io.Writer, base, target []byte) error {
+	header := Header{
+		BaseDigest:   sha256.Sum256(base),
+		TargetDigest: sha256.Sum256(target),
+		TargetSize:   uint64(len(target)),
+	}
+
+	if err := writeHeader(w, &header); err != nil {
+		return err
+	}
+
+	enc, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(enc)
+
+	if err = encodeOps(bw, base, target); err != nil {
+		enc.Close() //nolint:errcheck
+
+		return err
+	}
+
+	if err = bw.Flush(); err != nil {
+		enc.Close() //nolint:errcheck
+
+		return err
+	}
+
+	return enc.Close()
+}
+
+// Apply writes the target built from the base and the delta.
+//
+// The target digest is verified once the delta is applied, so the output should be discarded on error.
+func Apply(w io.Writer, base []byte, delta io.Reader) error {
+	header, err := ReadHeader(delta)
+	if err != nil {
+		return err
+	}
+
+	if sha256.Sum256(base) != header.BaseDigest {
+		return ErrBaseMismatch
+	}
+
+	dec, err := zstd.NewReader(delta)
+	if err != nil {
+		return err
+	}
+
+	defer dec.Close()
+
+	h := sha256.New()
+	out := io.MultiWriter(w, h)
+	r := bufio.NewReader(dec)
+
+	var written uint64
+
+	for {
+		op, err := r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return err
+		}
+
+		var n uint64
+
+		switch op {
+		case opCopy:
+			var offset, length uint64
+
+			if offset, err = binary.ReadUvarint(r); err != nil {
+				return err
+			}
+
+			if length, err = binary.ReadUvarint(r); err != nil {
+				return err
+			}
+
+			if offset > uint64(len(base)) || length > uint64(len(base))-offset {
+				return fmt.Errorf("delta copy out of base bounds: offset %d, length %d", offset, length)
+			}
+
+			if _, err = out.Write(base[offset : offset+length]); err != nil {
+				return err
+			}
+
+			n = length
+		case opLiteral:
+			var length uint64
+
+			if length, err = binary.ReadUvarint(r); err != nil {
+				return err
+			}
+
+			if length > header.TargetSize-written {
+				return fmt.Errorf("delta literal exceeds target size")
+			}
+
+			if _, err = io.CopyN(out, r, int64(length)); err != nil {
+				return err
+			}
+
+			n = length
+		default:
+			return fmt.Errorf("unexpected delta operation %q", op)
+		}
+
+		written += n
+
+		if written > header.TargetSize {
+			return fmt.Errorf("delta exceeds target size")
+		}
+	}
+
+	if written != header.TargetSize {
+		return fmt.Errorf("delta target size mismatch: %d != %d", written, header.TargetSize)
+	}
+
+	if !bytes.Equal(h.Sum(nil), header.TargetDigest[:]) {
+		return fmt.Errorf("delta target digest mismatch")
+	}
+
+	return nil
+}
+
+// ReadHeader reads and validates the delta header.
+func ReadHeader(r io.Reader) (*Header, error) {
+	var m [len(magic)]byte
+
+	if _, err := io.ReadFull(r, m[:]); err != nil {
+		return nil, fmt.Errorf("error reading delta header: %w", err)
+	}
+
+	if m != magic {
+		return nil, fmt.Errorf("unsupported delta format")
+	}
+
+	var header Header
+
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("error reading delta header: %w", err)
+	}
+
+	return &header, nil
+}
+
+func writeHeader(w io.Writer, header *Header) error {
+	if _, err := w.Write(magic[:]); err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, header)
+}
+
+// encodeOps matches base blocks at any offset of the target using the rolling checksum (like rsync),
+// extending each match as far as possible.
+//
+//nolint:gocyclo
+func encodeOps(w *bufio.Writer, base, target []byte) error {
+	index := make(map[uint32][]int, len(base)/BlockSize)
+
+	for offset := 0; offset+BlockSize <= len(base); offset += BlockSize {
+		sum := newChecksum(base[offset : offset+BlockSize]).value()
+		index[sum] = append(index[sum], offset)
+	}
+
+	var literalStart int
+
+	flushLiteral := func(end int) error {
+		if end == literalStart {
+			return nil
+		}
+
+		if err := writeOp(w, opLiteral, uint64(end-literalStart)); err != nil {
+			return err
+		}
+
+		_, err := w.Write(target[literalStart:end])
+
+		return err
+	}
+
+	pos := 0
+
+	if len(index) == 0 || len(target) < BlockSize {
+		return flushLiteral(len(target))
+	}
+
+	sum := newChecksum(target[:BlockSize])
+
+	for {
+		matchOffset, matchLength := -1, 0
+
+		for _, offset := range index[sum.value()] {
+			if !bytes.Equal(base[offset:offset+BlockSize], target[pos:pos+BlockSize]) {
+				continue
+			}
+
+			length := BlockSize
+
+			for offset+length < len(base) && pos+length < len(target) && base[offset+length] == target[pos+length] {
+				length++
+			}
+
+			if length > matchLength {
+				matchOffset, matchLength = offset, length
+			}
+		}
+
+		if matchOffset >= 0 {
+			if err := flushLiteral(pos); err != nil {
+				return err
+			}
+
+			if err := writeOp(w, opCopy, uint64(matchOffset), uint64(matchLength)); err != nil {
+				return err
+			}
+
+			pos += matchLength
+			literalStart = pos
+
+			if pos+BlockSize > len(target) {
+				break
+			}
+
+			sum = newChecksum(target[pos : pos+BlockSize])
+
+			continue
+		}
+
+		if pos+BlockSize >= len(target) {
+			break
+		}
+
+		sum.roll(target[pos], target[pos+BlockSize])
+		pos++
+	}
+
+	return flushLiteral(len(target))
+}
+
+func writeOp(w *bufio.Writer, op byte, args ...uint64) error {
+	if err := w.WriteByte(op); err != nil {
+		return err
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+
+	for _, arg := range args {
+		if _, err := w.Write(buf[:binary.PutUvarint(buf[:], arg)]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checksum is the rsync rolling checksum of the block.
+type checksum struct {
+	a, b uint32
+}
+
+func newChecksum(block []byte) checksum {
+	var s checksum
+
+	for i, c := range block {
+		s.a += uint32(c)
+		s.b += uint32(len(block)-i) * uint32(c)
+	}
+
+	return s
+}
+
+// roll moves the checksum window one byte forward.
+func (s *checksum) roll(out, in byte) {
+	s.a += uint32(in) - uint32(out)
+	s.b += s.a - BlockSize*uint32(out)
+}
+
+func (s checksum) value() uint32 {
+	return s.a&0xffff | s.b<<16
+}
@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package delta_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg/delta"
+)
+
+func randomBytes(rnd *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	rnd.Read(b) //nolint:errcheck
+
+	return b
+}
+
+func TestCreateApply(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+
+	base := randomBytes(rnd, 64*delta.BlockSize+123)
+
+	for _, tt := range []struct {
+		name   string
+		target []byte
+	}{
+		{
+			name:   "same",
+			target: base,
+		},
+		{
+			name:   "empty",
+			target: nil,
+		},
+		{
+			name:   "small",
+			target: []byte("talos"),
+		},
+		{
+			name:   "unrelated",
+			target: randomBytes(rnd, 10*delta.BlockSize),
+		},
+		{
+			name: "shifted",
+			// unaligned insertions and changes in the middle
+			target: bytes.Join([][]byte{
+				randomBytes(rnd, 17),
+				base[:20*delta.BlockSize],
+				randomBytes(rnd, 1000),
+				base[30*delta.BlockSize+5:],
+				base[:3*delta.BlockSize],
+			}, nil),
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			var d bytes.Buffer
+
+			require.NoError(t, delta.Create(&d, base, tt.target))
+
+			if len(tt.target) > 10*delta.BlockSize && bytes.Contains(tt.target, base[:delta.BlockSize]) {
+				assert.Less(t, d.Len(), len(tt.target)/4)
+			}
+
+			var out bytes.Buffer
+
+			require.NoError(t, delta.Apply(&out, base, bytes.NewReader(d.Bytes())))
+			assert.Equal(t, tt.target, out.Bytes())
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+
+	base := randomBytes(rnd, 8*delta.BlockSize)
+	target := append(append([]byte(nil), base...), 'x')
+
+	var d bytes.Buffer
+
+	require.NoError(t, delta.Create(&d, base, target))
+
+	otherBase := append([]byte(nil), base...)
+	otherBase[0]++
+
+	assert.ErrorIs(t, delta.Apply(&bytes.Buffer{}, otherBase, bytes.NewReader(d.Bytes())), delta.ErrBaseMismatch)
+
+	assert.Error(t, delta.Apply(&bytes.Buffer{}, base, bytes.NewReader([]byte("not a delta at all, just some text"))))
+
+	corrupted := append([]byte(nil), d.Bytes()...)
+	corrupted[len(corrupted)-8]++
+
+	assert.Error(t, delta.Apply(&bytes.Buffer{}, base, bytes.NewReader(corrupted)))
+}
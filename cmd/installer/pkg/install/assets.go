@@ -0,0 +1,193 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg/delta"
+	"github.com/talos-systems/talos/pkg/download"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// errAssetNotFound is returned when the asset is not available at the assets URL.
+var errAssetNotFound = errors.New("asset not found")
+
+// bootAssets builds the list of the boot assets to be installed under the label.
+func bootAssets(opts *Options, label string) []*Asset {
+	assets := []*Asset{
+		{
+			Source:      fmt.Sprintf(constants.KernelAssetPath, opts.Arch),
+			Destination: filepath.Join(constants.BootMountPoint, label, constants.KernelAsset),
+		},
+		{
+			Source:      fmt.Sprintf(constants.InitramfsAssetPath, opts.Arch),
+			Destination: filepath.Join(constants.BootMountPoint, label, constants.InitramfsAsset),
+		},
+	}
+
+	if opts.AssetsURL != "" {
+		for _, asset := range assets {
+			asset.URL = assetURL(opts.AssetsURL, opts.Arch, filepath.Base(asset.Source))
+		}
+	}
+
+	return assets
+}
+
+// assetURL returns the URL of the asset for the architecture: <assets URL>/<arch>/<asset>.
+func assetURL(assetsURL, arch, name string) string {
+	return strings.TrimRight(assetsURL, "/") + "/" + arch + "/" + name
+}
+
+// deltaURL returns the URL of the asset delta against the base with the specified digest: <asset URL>-<sha256>.delta.
+func deltaURL(url string, baseDigest [sha256.Size]byte) string {
+	return url + "-" + hex.EncodeToString(baseDigest[:]) + ".delta"
+}
+
+// setDeltaBase sets the assets of the currently installed label as the base for the deltas.
+func (m *Manifest) setDeltaBase(current string) {
+	for _, targets := range m.Targets {
+		for _, target := range targets {
+			for _, asset := range target.Assets {
+				if asset.URL == "" {
+					continue
+				}
+
+				asset.Base = filepath.Join(constants.BootMountPoint, current, filepath.Base(asset.Destination))
+			}
+		}
+	}
+}
+
+// Save installs the asset to the destination.
+//
+// If the asset URL is set, the delta against the base is tried first, then the asset
+// shipped in the installer image, and the full asset downloaded from the URL as the last resort.
+func (a *Asset) Save() error {
+	if err := os.MkdirAll(filepath.Dir(a.Destination), os.ModeDir); err != nil {
+		return err
+	}
+
+	if a.URL != "" && a.Base != "" {
+		err := a.saveFromDelta()
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("delta for %s is not available, falling back to the full asset: %s", a.Destination, err)
+	}
+
+	if _, err := os.Stat(a.Source); err == nil || a.URL == "" {
+		return a.saveFromSource()
+	}
+
+	return a.saveFromURL()
+}
+
+func (a *Asset) saveFromSource() (err error) {
+	var (
+		sourceFile *os.File
+		destFile   *os.File
+	)
+
+	if sourceFile, err = os.Open(a.Source); err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer sourceFile.Close()
+
+	if destFile, err = os.Create(a.Destination); err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer destFile.Close()
+
+	log.Printf("copying %s to %s\n", sourceFile.Name(), destFile.Name())
+
+	if _, err = io.Copy(destFile, sourceFile); err != nil {
+		log.Printf("failed to copy %s to %s\n", sourceFile.Name(), destFile.Name())
+
+		return err
+	}
+
+	if err = destFile.Close(); err != nil {
+		log.Printf("failed to close %s", destFile.Name())
+
+		return err
+	}
+
+	if err = sourceFile.Close(); err != nil {
+		log.Printf("failed to close %s", sourceFile.Name())
+
+		return err
+	}
+
+	return nil
+}
+
+func (a *Asset) saveFromDelta() error {
+	base, err := ioutil.ReadFile(a.Base)
+	if err != nil {
+		return err
+	}
+
+	url := deltaURL(a.URL, sha256.Sum256(base))
+
+	d, err := download.Download(context.Background(), url, download.WithErrorOnNotFound(errAssetNotFound))
+	if err != nil {
+		return err
+	}
+
+	// the delta is applied in memory, so that the destination is not touched if the delta is broken
+	var buf bytes.Buffer
+
+	if err = delta.Apply(&buf, base, bytes.NewReader(d)); err != nil {
+		return fmt.Errorf("error applying delta %q: %w", url, err)
+	}
+
+	log.Printf("building %s from delta %s (%s downloaded instead of %s)\n", a.Destination, url, humanize.Bytes(uint64(len(d))), humanize.Bytes(uint64(buf.Len())))
+
+	return ioutil.WriteFile(a.Destination, buf.Bytes(), 0o644)
+}
+
+func (a *Asset) saveFromURL() error {
+	log.Printf("downloading %s to %s\n", a.URL, a.Destination)
+
+	contents, err := download.Download(context.Background(), a.URL, download.WithErrorOnNotFound(errAssetNotFound))
+	if err != nil {
+		return fmt.Errorf("error downloading asset %q: %w", a.URL, err)
+	}
+
+	return ioutil.WriteFile(a.Destination, contents, 0o644)
+}
+
+// fetchRootfsRootHash downloads the rootfs root hash from the assets URL, empty string is returned if it's not available.
+func fetchRootfsRootHash(assetsURL, arch string) (string, error) {
+	contents, err := download.Download(context.Background(), assetURL(assetsURL, arch, constants.RootfsRootHashAsset), download.WithErrorOnNotFound(errAssetNotFound))
+	if err != nil {
+		if errors.Is(err, errAssetNotFound) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(contents)), nil
+}
@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/installer/pkg/delta"
+	"github.com/talos-systems/talos/cmd/installer/pkg/install"
+)
+
+func TestAssetSave(t *testing.T) {
+	dir := t.TempDir()
+
+	base := bytes.Repeat([]byte("talos v0.13 kernel "), 10000)
+	target := append(append([]byte(nil), base...), []byte("talos v0.14 kernel")...)
+	baseDigest := sha256.Sum256(base)
+
+	var d bytes.Buffer
+
+	require.NoError(t, delta.Create(&d, base, target))
+
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/vmlinuz-" + hex.EncodeToString(baseDigest[:]) + ".delta":
+			w.Write(d.Bytes()) //nolint:errcheck
+		case "/vmlinuz":
+			w.Write(target) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	basePath := filepath.Join(dir, "base")
+	require.NoError(t, ioutil.WriteFile(basePath, base, 0o644))
+
+	otherBase := []byte("talos v0.12 kernel")
+	otherBaseDigest := sha256.Sum256(otherBase)
+
+	otherBasePath := filepath.Join(dir, "other")
+	require.NoError(t, ioutil.WriteFile(otherBasePath, otherBase, 0o644))
+
+	for _, tt := range []struct {
+		name              string
+		base              string
+		expectedRequested []string
+	}{
+		{
+			name:              "delta",
+			base:              basePath,
+			expectedRequested: []string{"/vmlinuz-" + hex.EncodeToString(baseDigest[:]) + ".delta"},
+		},
+		{
+			name:              "no delta",
+			base:              otherBasePath,
+			expectedRequested: []string{"/vmlinuz-" + hex.EncodeToString(otherBaseDigest[:]) + ".delta", "/vmlinuz"},
+		},
+		{
+			name:              "no base",
+			expectedRequested: []string{"/vmlinuz"},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			requested = nil
+			mu.Unlock()
+
+			asset := &install.Asset{
+				Source:      filepath.Join(dir, "missing"),
+				Destination: filepath.Join(dir, tt.name, "vmlinuz"),
+				URL:         srv.URL + "/vmlinuz",
+				Base:        tt.base,
+			}
+
+			require.NoError(t, asset.Save())
+
+			contents, err := ioutil.ReadFile(asset.Destination)
+			require.NoError(t, err)
+
+			assert.Equal(t, target, contents)
+
+			mu.Lock()
+			assert.Equal(t, tt.expectedRequested, requested)
+			mu.Unlock()
+		})
+	}
+}
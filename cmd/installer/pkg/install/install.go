@@ -37,6 +37,7 @@ type Options struct {
 	Force             bool
 	Zero              bool
 	LegacyBIOSSupport bool
+	AssetsURL         string
 }
 
 // Install installs Talos.
@@ -55,6 +56,22 @@ func Install(p runtime.Platform, seq runtime.Sequence, opts *Options) (err error
 		return err
 	}
 
+	// root hash is passed via the kernel args, so that it's covered by the boot measurements
+	rootHash, err := RootfsRootHash(opts.Arch)
+	if err != nil {
+		return fmt.Errorf("error reading rootfs root hash: %w", err)
+	}
+
+	if rootHash == "" && opts.AssetsURL != "" {
+		if rootHash, err = fetchRootfsRootHash(opts.AssetsURL, opts.Arch); err != nil {
+			return fmt.Errorf("error downloading rootfs root hash: %w", err)
+		}
+	}
+
+	if rootHash != "" {
+		cmdline.Append(constants.KernelParamVerityRootHash, rootHash)
+	}
+
 	if err = cmdline.AppendAll(opts.ExtraKernelArgs, procfs.WithOverwriteArgs("console")); err != nil {
 		return err
 	}
@@ -107,6 +124,10 @@ func NewInstaller(cmdline *procfs.Cmdline, seq runtime.Sequence, opts *Options)
 		return nil, fmt.Errorf("failed to create installation manifest: %w", err)
 	}
 
+	if seq == runtime.SequenceUpgrade && i.Current != "" {
+		i.manifest.setDeltaBase(i.Current)
+	}
+
 	return i, nil
 }
 
@@ -123,16 +123,7 @@ func NewManifest(label string, sequence runtime.Sequence, bootPartitionFound boo
 	if opts.Bootloader {
 		bootTarget = BootTarget(opts.Disk, &Target{
 			PreserveContents: bootPartitionFound,
-			Assets: []*Asset{
-				{
-					Source:      fmt.Sprintf(constants.KernelAssetPath, opts.Arch),
-					Destination: filepath.Join(constants.BootMountPoint, label, constants.KernelAsset),
-				},
-				{
-					Source:      fmt.Sprintf(constants.InitramfsAssetPath, opts.Arch),
-					Destination: filepath.Join(constants.BootMountPoint, label, constants.InitramfsAsset),
-				},
-			},
+			Assets:           bootAssets(opts, label),
 		})
 	}
 
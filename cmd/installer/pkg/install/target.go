@@ -59,6 +59,11 @@ type Target struct {
 type Asset struct {
 	Source      string
 	Destination string
+
+	// URL to download the asset (or the delta against the Base) from, if set.
+	URL string
+	// Base is the previously installed version of the asset.
+	Base string
 }
 
 // PreserveSource instructs Talos where to look for source files to preserve.
@@ -233,55 +238,7 @@ func (t *Target) Format() error {
 // Save copies the assets to the bootloader partition.
 func (t *Target) Save() (err error) {
 	for _, asset := range t.Assets {
-		asset := asset
-
-		err = func() error {
-			var (
-				sourceFile *os.File
-				destFile   *os.File
-			)
-
-			if sourceFile, err = os.Open(asset.Source); err != nil {
-				return err
-			}
-			//nolint:errcheck
-			defer sourceFile.Close()
-
-			if err = os.MkdirAll(filepath.Dir(asset.Destination), os.ModeDir); err != nil {
-				return err
-			}
-
-			if destFile, err = os.Create(asset.Destination); err != nil {
-				return err
-			}
-
-			//nolint:errcheck
-			defer destFile.Close()
-
-			log.Printf("copying %s to %s\n", sourceFile.Name(), destFile.Name())
-
-			if _, err = io.Copy(destFile, sourceFile); err != nil {
-				log.Printf("failed to copy %s to %s\n", sourceFile.Name(), destFile.Name())
-
-				return err
-			}
-
-			if err = destFile.Close(); err != nil {
-				log.Printf("failed to close %s", destFile.Name())
-
-				return err
-			}
-
-			if err = sourceFile.Close(); err != nil {
-				log.Printf("failed to close %s", sourceFile.Name())
-
-				return err
-			}
-
-			return nil
-		}()
-
-		if err != nil {
+		if err = asset.Save(); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package install
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// RootfsRootHash returns the dm-verity root hash of the rootfs shipped in the initramfs.
+//
+// If the installer image doesn't carry the root hash, empty string is returned.
+func RootfsRootHash(arch string) (string, error) {
+	contents, err := ioutil.ReadFile(fmt.Sprintf(constants.RootfsRootHashAssetPath, arch))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(contents)), nil
+}
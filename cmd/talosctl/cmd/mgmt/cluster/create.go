@@ -33,6 +33,8 @@ import (
 	"github.com/talos-systems/talos/pkg/images"
 	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/configpatcher"
 	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/bundle"
@@ -112,6 +114,7 @@ var (
 	configPatchWorker         string
 	badRTC                    bool
 	extraBootKernelArgs       string
+	clusterSpecPath           string
 )
 
 // createCmd represents the cluster up command.
@@ -121,17 +124,41 @@ var createCmd = &cobra.Command{
 	Long:  ``,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if clusterSpecPath != "" && (cmd.Flags().Changed("masters") || cmd.Flags().Changed("workers")) {
+			return fmt.Errorf("--masters and --workers can't be used with --spec")
+		}
+
 		return cli.WithContext(context.Background(), create)
 	},
 }
 
 //nolint:gocyclo,cyclop
 func create(ctx context.Context) (err error) {
+	var masterSpecs, workerSpecs []helpers.NodeSpec
+
+	if clusterSpecPath != "" {
+		var spec *helpers.ClusterSpec
+
+		spec, err = helpers.LoadClusterSpec(clusterSpecPath)
+		if err != nil {
+			return err
+		}
+
+		masterSpecs, workerSpecs = spec.Expand()
+		masters, workers = len(masterSpecs), len(workerSpecs)
+
+		for _, nodeSpec := range spec.Nodes {
+			if len(nodeSpec.Disks) > 0 && len(clusterDisks) > 0 {
+				return fmt.Errorf("--user-disk can't be used with the disks in --spec")
+			}
+		}
+	}
+
 	if masters < 1 {
 		return fmt.Errorf("number of masters can't be less than 1")
 	}
 
-	nanoCPUs, err := parseCPUShare()
+	nanoCPUs, err := parseCPUShare(clusterCpus)
 	if err != nil {
 		return fmt.Errorf("error parsing --cpus: %s", err)
 	}
@@ -545,12 +572,31 @@ func create(ctx context.Context) (err error) {
 		}
 
 		nodeReq.Config = cfg
+
+		if masterSpecs != nil {
+			if err = applyNodeSpec(&nodeReq, masterSpecs[i]); err != nil {
+				return err
+			}
+		}
+
 		request.Nodes = append(request.Nodes, nodeReq)
 	}
 
+	// workers are numbered separately in each pool
+	poolNodes := map[string]int{}
+
 	for i := 1; i <= workers; i++ {
 		name := fmt.Sprintf("%s-worker-%d", clusterName, i)
 
+		var pool string
+
+		if workerSpecs != nil && workerSpecs[i-1].Pool != "" {
+			pool = workerSpecs[i-1].Pool
+			poolNodes[pool]++
+
+			name = fmt.Sprintf("%s-%s-%d", clusterName, pool, poolNodes[pool])
+		}
+
 		cfg := configBundle.Worker()
 
 		nodeIPs := make([]net.IP, len(cidrs))
@@ -565,19 +611,27 @@ func create(ctx context.Context) (err error) {
 			}
 		}
 
-		request.Nodes = append(request.Nodes,
-			provision.NodeRequest{
-				Name:                name,
-				Type:                machine.TypeWorker,
-				IPs:                 nodeIPs,
-				Memory:              memory,
-				NanoCPUs:            nanoCPUs,
-				Disks:               disks,
-				Config:              cfg,
-				SkipInjectingConfig: skipInjectingConfig,
-				BadRTC:              badRTC,
-				ExtraKernelArgs:     extraKernelArgs,
-			})
+		nodeReq := provision.NodeRequest{
+			Name:                name,
+			Type:                machine.TypeWorker,
+			Pool:                pool,
+			IPs:                 nodeIPs,
+			Memory:              memory,
+			NanoCPUs:            nanoCPUs,
+			Disks:               disks,
+			Config:              cfg,
+			SkipInjectingConfig: skipInjectingConfig,
+			BadRTC:              badRTC,
+			ExtraKernelArgs:     extraKernelArgs,
+		}
+
+		if workerSpecs != nil {
+			if err = applyNodeSpec(&nodeReq, workerSpecs[i-1]); err != nil {
+				return err
+			}
+		}
+
+		request.Nodes = append(request.Nodes, nodeReq)
 	}
 
 	cluster, err := provisioner.Create(ctx, request, provisionOptions...)
@@ -705,10 +759,88 @@ func mergeKubeconfig(ctx context.Context, clusterAccess *access.Adapter) error {
 	return merger.Write(kubeconfigPath)
 }
 
-func parseCPUShare() (int64, error) {
-	cpu, ok := new(big.Rat).SetString(clusterCpus)
+// applyNodeSpec overrides the node request defaults with the cluster spec node resources, boot assets and config patches.
+//
+//nolint:gocyclo
+func applyNodeSpec(nodeReq *provision.NodeRequest, spec helpers.NodeSpec) error {
+	if spec.CPUs != "" {
+		nanoCPUs, err := parseCPUShare(spec.CPUs)
+		if err != nil {
+			return fmt.Errorf("error parsing node %q cpus: %s", nodeReq.Name, err)
+		}
+
+		nodeReq.NanoCPUs = nanoCPUs
+	}
+
+	if spec.Memory != 0 {
+		nodeReq.Memory = int64(spec.Memory) * 1024 * 1024
+	}
+
+	if len(spec.Disks) > 0 {
+		nodeReq.Disks = make([]*provision.Disk, len(spec.Disks))
+
+		for i, size := range spec.Disks {
+			nodeReq.Disks[i] = &provision.Disk{
+				Size: uint64(size) * 1024 * 1024,
+			}
+		}
+	}
+
+	nodeReq.ExtraNICs = spec.ExtraNICs
+	nodeReq.Image = spec.Image
+	nodeReq.KernelPath = spec.VmlinuzPath
+	nodeReq.InitramfsPath = spec.InitramfsPath
+	nodeReq.ISOPath = spec.ISOPath
+
+	patch, err := spec.ConfigPatch()
+	if err != nil {
+		return err
+	}
+
+	if spec.InstallImage == "" && patch == nil {
+		return nil
+	}
+
+	// config is shared between the nodes of the same type, so it is copied before patching
+	cfgBytes, err := nodeReq.Config.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if patch != nil {
+		cfgBytes, err = configpatcher.JSON6902(cfgBytes, patch)
+		if err != nil {
+			return fmt.Errorf("error patching node %q config: %w", nodeReq.Name, err)
+		}
+	}
+
+	cfg, err := configloader.NewFromBytes(cfgBytes)
+	if err != nil {
+		return err
+	}
+
+	v1alpha1Config, ok := cfg.(*v1alpha1.Config)
+	if !ok {
+		return fmt.Errorf("unsupported config type %T for node %q", cfg, nodeReq.Name)
+	}
+
+	if spec.InstallImage != "" {
+		if v1alpha1Config.MachineConfig.MachineInstall == nil {
+			v1alpha1Config.MachineConfig.MachineInstall = &v1alpha1.InstallConfig{}
+		}
+
+		v1alpha1Config.MachineConfig.MachineInstall.InstallImage = spec.InstallImage
+	}
+
+	nodeReq.Config = v1alpha1Config
+
+	return nil
+}
+
+func parseCPUShare(cpus string) (int64, error) {
+	cpu, ok := new(big.Rat).SetString(cpus)
 	if !ok {
-		return 0, fmt.Errorf("failed to parsing as a rational number: %s", clusterCpus)
+		return 0, fmt.Errorf("failed to parsing as a rational number: %s", cpus)
 	}
 
 	nano := cpu.Mul(cpu, big.NewRat(1e9, 1))
@@ -848,6 +980,7 @@ func init() {
 	createCmd.Flags().StringVar(&configPatchWorker, "config-patch-worker", "", "patch generated machineconfigs (applied to 'worker' type)")
 	createCmd.Flags().BoolVar(&badRTC, "bad-rtc", false, "launch VM with bad RTC state (QEMU only)")
 	createCmd.Flags().StringVar(&extraBootKernelArgs, "extra-boot-kernel-args", "", "add extra kernel args to the initial boot from vmlinuz and initramfs (QEMU only)")
+	createCmd.Flags().StringVar(&clusterSpecPath, "spec", "", "path to the cluster spec YAML file with the per-node resources and Talos versions (overrides --masters and --workers)")
 
 	Cmd.AddCommand(createCmd)
 }
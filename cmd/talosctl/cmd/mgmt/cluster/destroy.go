@@ -10,9 +10,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/provision"
 	"github.com/talos-systems/talos/pkg/provision/providers"
 )
 
+var preserveState bool
+
 // destroyCmd represents the cluster destroy command.
 var destroyCmd = &cobra.Command{
 	Use:   "destroy",
@@ -37,9 +40,11 @@ func destroy(ctx context.Context) error {
 		return err
 	}
 
-	return provisioner.Destroy(ctx, cluster)
+	return provisioner.Destroy(ctx, cluster, provision.WithPreserveState(preserveState))
 }
 
 func init() {
+	destroyCmd.Flags().BoolVar(&preserveState, "preserve-state", false, "stop the cluster keeping the node disks and state, so that it can be started again with \"cluster start\"")
+
 	Cmd.AddCommand(destroyCmd)
 }
@@ -0,0 +1,47 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cluster
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/provision"
+	"github.com/talos-systems/talos/pkg/provision/providers"
+)
+
+// startCmd represents the cluster start command.
+var startCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Starts a local docker-based or QEMU-based kubernetes cluster stopped with `cluster destroy --preserve-state`",
+	Long:  `Cluster nodes are started with the preserved state, so the cluster is restored (e.g. after the host reboot).`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cli.WithContext(context.Background(), start)
+	},
+}
+
+func start(ctx context.Context) error {
+	provisioner, err := providers.Factory(ctx, provisionerName)
+	if err != nil {
+		return err
+	}
+
+	defer provisioner.Close() //nolint:errcheck
+
+	cluster, err := provisioner.Reflect(ctx, clusterName, stateDir)
+	if err != nil {
+		return err
+	}
+
+	return provisioner.Start(ctx, cluster, provision.WithSelfExecutable(os.Args[0]))
+}
+
+func init() {
+	Cmd.AddCommand(startCmd)
+}
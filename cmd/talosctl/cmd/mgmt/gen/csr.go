@@ -21,17 +21,22 @@ import (
 )
 
 var genCSRCmdFlags struct {
-	key   string
-	ip    string
-	roles []string
+	key        string
+	commonName string
+	ips        []string
+	dnsNames   []string
+	roles      []string
 }
 
 // genCSRCmd represents the `gen csr` command.
 var genCSRCmd = &cobra.Command{
 	Use:   "csr",
 	Short: "Generates a CSR using an Ed25519 private key",
-	Long:  ``,
-	Args:  cobra.NoArgs,
+	Long: `Generates a CSR using an Ed25519 private key.
+
+The CSR can be signed by an external CA to issue the admin (client) certificate with the specified roles,
+or the apid server certificate (.machine.apiTLS.serverCertificate) for the specified IP addresses and DNS names.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		keyBytes, err := ioutil.ReadFile(genCSRCmdFlags.key)
 		if err != nil {
@@ -50,9 +55,19 @@ var genCSRCmd = &cobra.Command{
 
 		opts := []x509.Option{}
 
-		parsed := net.ParseIP(genCSRCmdFlags.ip)
-		if parsed == nil {
-			return fmt.Errorf("invalid IP: %s", genCSRCmdFlags.ip)
+		if len(genCSRCmdFlags.ips) == 0 && len(genCSRCmdFlags.dnsNames) == 0 {
+			return fmt.Errorf("at least one IP address or DNS name is required")
+		}
+
+		ips := make([]net.IP, 0, len(genCSRCmdFlags.ips))
+
+		for _, ip := range genCSRCmdFlags.ips {
+			parsed := net.ParseIP(ip)
+			if parsed == nil {
+				return fmt.Errorf("invalid IP: %s", ip)
+			}
+
+			ips = append(ips, parsed)
 		}
 
 		roles, unknownRoles := role.Parse(genCSRCmdFlags.roles)
@@ -60,9 +75,13 @@ var genCSRCmd = &cobra.Command{
 			return fmt.Errorf("unknown roles: %s", strings.Join(unknownRoles, ", "))
 		}
 
-		ips := []net.IP{parsed}
 		opts = append(opts, x509.Organization(roles.Strings()...))
 		opts = append(opts, x509.IPAddresses(ips))
+		opts = append(opts, x509.DNSNames(genCSRCmdFlags.dnsNames))
+
+		if genCSRCmdFlags.commonName != "" {
+			opts = append(opts, x509.CommonName(genCSRCmdFlags.commonName))
+		}
 
 		csr, err := x509.NewCertificateSigningRequest(keyEC, opts...)
 		if err != nil {
@@ -80,8 +99,9 @@ var genCSRCmd = &cobra.Command{
 func init() {
 	genCSRCmd.Flags().StringVar(&genCSRCmdFlags.key, "key", "", "path to the PEM encoded EC or RSA PRIVATE KEY")
 	cli.Should(cobra.MarkFlagRequired(genCSRCmd.Flags(), "key"))
-	genCSRCmd.Flags().StringVar(&genCSRCmdFlags.ip, "ip", "", "generate the certificate for this IP address")
-	cli.Should(cobra.MarkFlagRequired(genCSRCmd.Flags(), "ip"))
+	genCSRCmd.Flags().StringSliceVar(&genCSRCmdFlags.ips, "ip", nil, "generate the certificate for these IP addresses")
+	genCSRCmd.Flags().StringSliceVar(&genCSRCmdFlags.dnsNames, "dns-names", nil, "generate the certificate for these DNS names")
+	genCSRCmd.Flags().StringVar(&genCSRCmdFlags.commonName, "common-name", "", "the certificate common name")
 	genCSRCmd.Flags().StringSliceVar(&genCSRCmdFlags.roles, "roles", role.MakeSet(role.Admin).Strings(), "roles")
 
 	Cmd.AddCommand(genCSRCmd)
@@ -0,0 +1,149 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mgmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
+	"github.com/talos-systems/talos/pkg/machinery/config/schema"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+var machineConfigSchemaCmdFlags struct {
+	version string
+	output  string
+}
+
+// machineConfigCmd represents the machineconfig command.
+var machineConfigCmd = &cobra.Command{
+	Use:     "machineconfig",
+	Aliases: []string{"mc"},
+	Short:   "Machine config related commands",
+	Long:    ``,
+}
+
+// machineConfigSchemaCmd represents the machineconfig schema command.
+var machineConfigSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Generate JSON Schema for the machine config",
+	Long: `Generates a JSON Schema (draft-07) document describing the machine config of the specified version.
+
+The schema can be used to validate machine configs in IDEs (e.g. with the YAML language server)
+and in CI pipelines before the config is applied.`,
+	Example: `  talosctl machineconfig schema --output talos-config.schema.json`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := schema.Generate(machineConfigSchemaCmdFlags.version)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		data = append(data, '\n')
+
+		if machineConfigSchemaCmdFlags.output == "" {
+			_, err = os.Stdout.Write(data)
+
+			return err
+		}
+
+		if err = os.WriteFile(machineConfigSchemaCmdFlags.output, data, 0o644); err != nil {
+			return fmt.Errorf("error writing schema: %w", err)
+		}
+
+		return nil
+	},
+}
+
+var machineConfigUpgradeCmdFlags struct {
+	output string
+	force  bool
+}
+
+// machineConfigUpgradeCmd represents the machineconfig upgrade command.
+var machineConfigUpgradeCmd = &cobra.Command{
+	Use:   "upgrade <config>",
+	Short: "Convert the machine config to the newest schema",
+	Long: `Converts the deprecated fields of the machine config to their replacements in the newest config schema.
+
+The converted fields are listed in the comments on top of the resulting config.
+Conversions which drop some configuration are refused unless --force is set.`,
+	Example: `  talosctl machineconfig upgrade controlplane.yaml --output controlplane-upgraded.yaml`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := configloader.NewFromFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		v1alpha1Config, ok := cfg.(*v1alpha1.Config)
+		if !ok {
+			return fmt.Errorf("unsupported config type %T", cfg)
+		}
+
+		upgraded, conversions := v1alpha1Config.Upgrade()
+
+		var lossy []string
+
+		for _, conversion := range conversions {
+			if conversion.Lossy {
+				lossy = append(lossy, conversion.String())
+			}
+		}
+
+		if len(lossy) > 0 && !machineConfigUpgradeCmdFlags.force {
+			return fmt.Errorf("conversion drops some configuration, use --force to proceed:\n\t%s", strings.Join(lossy, "\n\t"))
+		}
+
+		data, err := upgraded.Bytes(encoder.WithComments(encoder.CommentsDisabled))
+		if err != nil {
+			return err
+		}
+
+		var out bytes.Buffer
+
+		for _, conversion := range conversions {
+			fmt.Fprintf(&out, "# %s\n", conversion)
+		}
+
+		out.Write(data)
+
+		if machineConfigUpgradeCmdFlags.output == "" {
+			_, err = os.Stdout.Write(out.Bytes())
+
+			return err
+		}
+
+		if err = os.WriteFile(machineConfigUpgradeCmdFlags.output, out.Bytes(), 0o600); err != nil {
+			return fmt.Errorf("error writing config: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	machineConfigSchemaCmd.Flags().StringVar(&machineConfigSchemaCmdFlags.version, "version", "v1alpha1",
+		fmt.Sprintf("the machine config version to generate the schema for, one of: %s", strings.Join(schema.Versions(), ", ")))
+	machineConfigSchemaCmd.Flags().StringVarP(&machineConfigSchemaCmdFlags.output, "output", "o", "", "write the schema to the file instead of stdout")
+
+	machineConfigUpgradeCmd.Flags().StringVarP(&machineConfigUpgradeCmdFlags.output, "output", "o", "", "write the converted config to the file instead of stdout")
+	machineConfigUpgradeCmd.Flags().BoolVar(&machineConfigUpgradeCmdFlags.force, "force", false, "proceed with the conversions which drop some configuration")
+
+	machineConfigCmd.AddCommand(machineConfigSchemaCmd, machineConfigUpgradeCmd)
+	addCommand(machineConfigCmd)
+}
@@ -9,12 +9,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/talos-systems/talos/internal/pkg/tui/installer"
 	"github.com/talos-systems/talos/pkg/cli"
 	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/capability"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
@@ -25,6 +28,7 @@ var applyConfigCmdFlags struct {
 	interactive      bool
 	onReboot         bool
 	immediate        bool
+	autoRevert       time.Duration
 }
 
 // applyConfigCmd represents the applyConfiguration command.
@@ -109,15 +113,39 @@ var applyConfigCmd = &cobra.Command{
 				return install.Run(conn)
 			}
 
-			resp, err := c.ApplyConfiguration(ctx, &machineapi.ApplyConfigurationRequest{
+			req := &machineapi.ApplyConfigurationRequest{
 				Data:      cfgBytes,
 				OnReboot:  applyConfigCmdFlags.onReboot,
 				Immediate: applyConfigCmdFlags.immediate,
-			})
+			}
+
+			if applyConfigCmdFlags.autoRevert > 0 {
+				// older nodes silently ignore the revert timeout, so refuse to apply the config without the safety net
+				supported, err := c.SupportsFeature(ctx, capability.ApplyConfigRevert)
+				if err != nil {
+					return fmt.Errorf("error checking node capabilities: %w", err)
+				}
+
+				if !supported {
+					return fmt.Errorf("--auto-revert is not supported by the node")
+				}
+
+				req.RevertTimeout = durationpb.New(applyConfigCmdFlags.autoRevert)
+			}
+
+			resp, err := c.ApplyConfiguration(ctx, req)
 			for _, m := range resp.GetMessages() {
 				for _, w := range m.GetWarnings() {
 					cli.Warning("%s", w)
 				}
+
+				for _, d := range m.GetDeprecations() {
+					if d.GetReplacement() != "" {
+						cli.Warning("%s: %s, use %s instead", d.GetPath(), d.GetMessage(), d.GetReplacement())
+					} else {
+						cli.Warning("%s: %s", d.GetPath(), d.GetMessage())
+					}
+				}
 			}
 			if err != nil {
 				return fmt.Errorf("error applying new configuration: %s", err)
@@ -135,5 +163,6 @@ func init() {
 	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.interactive, "interactive", false, "apply the config using text based interactive mode")
 	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.onReboot, "on-reboot", false, "apply the config on reboot")
 	applyConfigCmd.Flags().BoolVar(&applyConfigCmdFlags.immediate, "immediate", false, "apply the config immediately (without a reboot)")
+	applyConfigCmd.Flags().DurationVar(&applyConfigCmdFlags.autoRevert, "auto-revert", 0, "revert the config if the node becomes unhealthy within the duration (immediate mode only)")
 	addCommand(applyConfigCmd)
 }
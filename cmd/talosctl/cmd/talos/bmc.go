@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var bmcCmdFlags struct {
+	bootDevice  string
+	persistent  bool
+	powerAction string
+}
+
+var bmcBootDevices = map[string]machine.BMCControlRequest_BootDevice{
+	"pxe":   machine.BMCControlRequest_PXE,
+	"disk":  machine.BMCControlRequest_DISK,
+	"cdrom": machine.BMCControlRequest_CDROM,
+	"bios":  machine.BMCControlRequest_BIOS,
+}
+
+var bmcPowerActions = map[string]machine.BMCControlRequest_PowerAction{
+	"cycle": machine.BMCControlRequest_POWER_CYCLE,
+	"reset": machine.BMCControlRequest_HARD_RESET,
+	"off":   machine.BMCControlRequest_POWER_OFF,
+}
+
+// bmcCmd represents the bmc command.
+var bmcCmd = &cobra.Command{
+	Use:   "bmc",
+	Short: "Set the boot device or control the power of a node via its BMC",
+	Long: `Set the boot device or control the power of a node via its own BMC over IPMI.
+
+BMC power control should be enabled in the machine configuration (machine.bmc.allowPowerControl).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := &machine.BMCControlRequest{
+			Persistent: bmcCmdFlags.persistent,
+		}
+
+		if bmcCmdFlags.bootDevice != "" {
+			bootDevice, ok := bmcBootDevices[bmcCmdFlags.bootDevice]
+			if !ok {
+				return fmt.Errorf("unsupported boot device %q", bmcCmdFlags.bootDevice)
+			}
+
+			req.BootDevice = bootDevice
+		}
+
+		if bmcCmdFlags.powerAction != "" {
+			powerAction, ok := bmcPowerActions[bmcCmdFlags.powerAction]
+			if !ok {
+				return fmt.Errorf("unsupported power action %q", bmcCmdFlags.powerAction)
+			}
+
+			req.PowerAction = powerAction
+		}
+
+		if req.BootDevice == machine.BMCControlRequest_NO_OVERRIDE && req.PowerAction == machine.BMCControlRequest_NOOP {
+			return fmt.Errorf("either --boot-device or --power should be specified")
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			if _, err := c.BMCControl(ctx, req); err != nil {
+				return fmt.Errorf("error executing BMC control: %s", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+func init() {
+	bmcCmd.Flags().StringVar(&bmcCmdFlags.bootDevice, "boot-device", "", "set the boot device: \"pxe\", \"disk\", \"cdrom\", \"bios\"")
+	bmcCmd.Flags().BoolVar(&bmcCmdFlags.persistent, "persistent", false, "apply the boot device to all the following boots, not only the next one")
+	bmcCmd.Flags().StringVar(&bmcCmdFlags.powerAction, "power", "", "run the power action: \"cycle\", \"reset\", \"off\"")
+	addCommand(bmcCmd)
+}
@@ -0,0 +1,79 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var capabilitiesCmdFlags struct {
+	methods bool
+}
+
+// capabilitiesCmd represents the capabilities command.
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "List the API features supported by the nodes",
+	Long: `Lists the API features (and optionally the API methods) supported by the nodes.
+
+Nodes running older Talos versions don't implement the Capabilities API, the request fails for them with the Unimplemented error.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			var remotePeer peer.Peer
+
+			resp, err := c.Capabilities(ctx, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error getting capabilities: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			defaultNode := client.AddrFromPeer(&remotePeer)
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+			fmt.Fprintln(w, "NODE\tKIND\tNAME")
+
+			for _, msg := range resp.Messages {
+				node := defaultNode
+
+				if msg.Metadata != nil {
+					node = msg.Metadata.Hostname
+				}
+
+				for _, feature := range msg.Features {
+					fmt.Fprintf(w, "%s\tfeature\t%s\n", node, feature)
+				}
+
+				if capabilitiesCmdFlags.methods {
+					for _, method := range msg.Methods {
+						fmt.Fprintf(w, "%s\tmethod\t%s\n", node, method)
+					}
+				}
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+func init() {
+	capabilitiesCmd.Flags().BoolVar(&capabilitiesCmdFlags.methods, "methods", false, "list the supported API methods as well")
+
+	addCommand(capabilitiesCmd)
+}
@@ -38,10 +38,17 @@ var configCmd = &cobra.Command{
 	Long:  ``,
 }
 
-func openConfigAndContext(context string) (*clientconfig.Config, error) {
+// openConfigAndContext opens the config and resolves the context name.
+//
+// If the context is not specified, context set with `--context` is used, falling back to the current context.
+func openConfigAndContext(context string) (*clientconfig.Config, string, error) {
 	c, err := clientconfig.Open(Talosconfig)
 	if err != nil {
-		return nil, fmt.Errorf("error reading config: %w", err)
+		return nil, "", fmt.Errorf("error reading config: %w", err)
+	}
+
+	if context == "" {
+		context = Cmdcontext
 	}
 
 	if context == "" {
@@ -49,14 +56,14 @@ func openConfigAndContext(context string) (*clientconfig.Config, error) {
 	}
 
 	if context == "" {
-		return nil, fmt.Errorf("no context is set")
+		return nil, "", fmt.Errorf("no context is set")
 	}
 
 	if _, ok := c.Contexts[context]; !ok {
-		return nil, fmt.Errorf("context %q is not defined", context)
+		return nil, "", fmt.Errorf("context %q is not defined", context)
 	}
 
-	return c, nil
+	return c, context, nil
 }
 
 // configEndpointCmd represents the `config endpoint` command.
@@ -64,10 +71,10 @@ var configEndpointCmd = &cobra.Command{
 	Use:     "endpoint <endpoint>...",
 	Aliases: []string{"endpoints"},
 	Short:   "Set the endpoint(s) for the current context",
-	Long:    ``,
+	Long:    "Context other than the current one can be selected with `--context`.",
 	Args:    cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := openConfigAndContext("")
+		c, context, err := openConfigAndContext("")
 		if err != nil {
 			return err
 		}
@@ -76,7 +83,7 @@ var configEndpointCmd = &cobra.Command{
 			args[i] = strings.TrimSpace(args[i])
 		}
 
-		c.Contexts[c.Context].Endpoints = args
+		c.Contexts[context].Endpoints = args
 		if err := c.Save(Talosconfig); err != nil {
 			return fmt.Errorf("error writing config: %w", err)
 		}
@@ -90,10 +97,10 @@ var configNodeCmd = &cobra.Command{
 	Use:     "node <endpoint>...",
 	Aliases: []string{"nodes"},
 	Short:   "Set the node(s) for the current context",
-	Long:    ``,
+	Long:    "Context other than the current one can be selected with `--context`.",
 	Args:    cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := openConfigAndContext("")
+		c, context, err := openConfigAndContext("")
 		if err != nil {
 			return err
 		}
@@ -102,7 +109,7 @@ var configNodeCmd = &cobra.Command{
 			args[i] = strings.TrimSpace(args[i])
 		}
 
-		c.Contexts[c.Context].Nodes = args
+		c.Contexts[context].Nodes = args
 		if err := c.Save(Talosconfig); err != nil {
 			return fmt.Errorf("error writing config: %w", err)
 		}
@@ -119,9 +126,7 @@ var configContextCmd = &cobra.Command{
 	Long:    ``,
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		context := args[0]
-
-		c, err := openConfigAndContext(context)
+		c, context, err := openConfigAndContext(args[0])
 		if err != nil {
 			return err
 		}
@@ -271,6 +276,165 @@ var configMergeCmd = &cobra.Command{
 	},
 }
 
+// configImportCmdFlags represents the `config import` command flags.
+var configImportCmdFlags struct {
+	keyring bool
+}
+
+// configImportCmd represents the `config import` command.
+var configImportCmd = &cobra.Command{
+	Use:   "import <from>",
+	Short: "Import contexts from another client configuration file",
+	Long: `Contexts with the same name are renamed while importing, current context is not changed (unless it is not set).
+
+Use '-' to read the client configuration file from stdin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := clientconfig.Open(Talosconfig)
+		if err != nil {
+			return fmt.Errorf("error reading config: %w", err)
+		}
+
+		var imported *clientconfig.Config
+
+		if args[0] == "-" {
+			imported, err = clientconfig.ReadFrom(os.Stdin)
+		} else {
+			imported, err = clientconfig.Open(args[0])
+		}
+
+		if err != nil {
+			return fmt.Errorf("error reading config: %w", err)
+		}
+
+		if configImportCmdFlags.keyring {
+			for name, context := range imported.Contexts {
+				if err = context.StoreKey(); err != nil {
+					return fmt.Errorf("error storing key of context %q: %w", name, err)
+				}
+			}
+		}
+
+		currentContext := c.Context
+
+		renames := c.Merge(imported)
+		for _, rename := range renames {
+			fmt.Printf("renamed talosconfig context %s\n", rename.String())
+		}
+
+		if currentContext != "" {
+			c.Context = currentContext
+		}
+
+		if err := c.Save(Talosconfig); err != nil {
+			return fmt.Errorf("error writing config: %s", err)
+		}
+
+		return nil
+	},
+}
+
+// configExportCmdFlags represents the `config export` command flags.
+var configExportCmdFlags struct {
+	output string
+}
+
+// configExportCmd represents the `config export` command.
+var configExportCmd = &cobra.Command{
+	Use:   "export [<context>]",
+	Short: "Export a context as a standalone client configuration file",
+	Long: `Exported file contains the private key, even if it is stored in the keyring.
+
+If the context is not specified, the current context is exported.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var name string
+
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		c, context, err := openConfigAndContext(name)
+		if err != nil {
+			return err
+		}
+
+		exported, err := c.Export(context)
+		if err != nil {
+			return err
+		}
+
+		if configExportCmdFlags.output != "" {
+			return exported.Save(configExportCmdFlags.output)
+		}
+
+		b, err := exported.Bytes()
+		if err != nil {
+			return err
+		}
+
+		_, err = os.Stdout.Write(b)
+
+		return err
+	},
+}
+
+// configKeyringCmd represents the `config keyring` command.
+var configKeyringCmd = &cobra.Command{
+	Use:   "keyring",
+	Short: "Manage the context keys stored in the OS keyring",
+	Long: `Private keys of the contexts can be stored in the OS keyring instead of the client configuration file.
+
+Keychain is used on macOS, Secret Service (via 'secret-tool') on Linux.`,
+}
+
+// configKeyringStoreCmd represents the `config keyring store` command.
+var configKeyringStoreCmd = &cobra.Command{
+	Use:   "store [<context>...]",
+	Short: "Move the context keys to the OS keyring",
+	Long:  "If no contexts are specified, the current context is used.",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateContexts(args, (*clientconfig.Context).StoreKey)
+	},
+}
+
+// configKeyringRestoreCmd represents the `config keyring restore` command.
+var configKeyringRestoreCmd = &cobra.Command{
+	Use:   "restore [<context>...]",
+	Short: "Move the context keys from the OS keyring back to the client configuration file",
+	Long:  "If no contexts are specified, the current context is used.",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateContexts(args, (*clientconfig.Context).RestoreKey)
+	},
+}
+
+// updateContexts applies the update to the contexts (current context by default) and saves the config.
+func updateContexts(contexts []string, update func(*clientconfig.Context) error) error {
+	if len(contexts) == 0 {
+		contexts = []string{""}
+	}
+
+	for _, name := range contexts {
+		c, context, err := openConfigAndContext(name)
+		if err != nil {
+			return err
+		}
+
+		if err = update(c.Contexts[context]); err != nil {
+			return fmt.Errorf("error updating context %q: %w", context, err)
+		}
+
+		// save after each context to keep the config in sync with the keyring
+		if err = c.Save(Talosconfig); err != nil {
+			return fmt.Errorf("error writing config: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // configNewCmdFlags represents the `config new` command flags.
 var configNewCmdFlags struct {
 	roles  []string
@@ -394,11 +558,13 @@ var configInfoCmd = &cobra.Command{
 	Short: "Show information about the current context",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := openConfigAndContext("")
+		c, context, err := openConfigAndContext("")
 		if err != nil {
 			return err
 		}
 
+		c.Context = context
+
 		res, err := configInfoCommand(c, time.Now())
 		if err != nil {
 			return err
@@ -418,10 +584,18 @@ func init() {
 		configAddCmd,
 		configGetContextsCmd,
 		configMergeCmd,
+		configImportCmd,
+		configExportCmd,
+		configKeyringCmd,
 		configNewCmd,
 		configInfoCmd,
 	)
 
+	configKeyringCmd.AddCommand(
+		configKeyringStoreCmd,
+		configKeyringRestoreCmd,
+	)
+
 	configAddCmd.Flags().StringVar(&configAddCmdFlags.ca, "ca", "", "the path to the CA certificate")
 	configAddCmd.Flags().StringVar(&configAddCmdFlags.crt, "crt", "", "the path to the certificate")
 	configAddCmd.Flags().StringVar(&configAddCmdFlags.key, "key", "", "the path to the key")
@@ -429,6 +603,10 @@ func init() {
 	cli.Should(configAddCmd.MarkFlagRequired("crt"))
 	cli.Should(configAddCmd.MarkFlagRequired("key"))
 
+	configImportCmd.Flags().BoolVar(&configImportCmdFlags.keyring, "keyring", false, "store the keys of the imported contexts in the OS keyring")
+
+	configExportCmd.Flags().StringVarP(&configExportCmdFlags.output, "output", "o", "", "path to write the exported config to (stdout by default)")
+
 	configNewCmd.Flags().StringSliceVar(&configNewCmdFlags.roles, "roles", role.MakeSet(role.Admin).Strings(), "roles")
 	configNewCmd.Flags().DurationVar(&configNewCmdFlags.crtTTL, "crt-ttl", 87600*time.Hour, "certificate TTL")
 
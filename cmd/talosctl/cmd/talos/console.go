@@ -0,0 +1,81 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var consoleCmdFlags struct {
+	follow bool
+	tail   bool
+}
+
+// consoleCmd represents the console command.
+var consoleCmd = &cobra.Command{
+	Use:   "console",
+	Short: "Retrieve the node console output",
+	Long: `Retrieve the node console output: kernel and Talos messages as they are printed to the node console.
+
+This provides access to the console on platforms which have no IPMI or cloud provider console.
+If more than one node is specified, lines are prefixed with the node name.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			stream, err := c.Console(ctx, consoleCmdFlags.follow, consoleCmdFlags.tail)
+			if err != nil {
+				return fmt.Errorf("error getting console output: %w", err)
+			}
+
+			defaultNode := client.RemotePeer(stream.Context())
+
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					if err == io.EOF || client.StatusCode(err) == codes.Canceled {
+						break
+					}
+
+					return fmt.Errorf("error reading from stream: %w", err)
+				}
+
+				node := defaultNode
+				if resp.Metadata != nil {
+					node = resp.Metadata.Hostname
+
+					if resp.Metadata.Error != "" {
+						fmt.Fprintf(os.Stderr, "%s: %s\n", node, resp.Metadata.Error)
+					}
+				}
+
+				if resp.Bytes == nil {
+					continue
+				}
+
+				if len(Nodes) > 1 {
+					fmt.Printf("%s: %s", node, resp.Bytes)
+				} else {
+					os.Stdout.Write(resp.Bytes) //nolint:errcheck
+				}
+			}
+
+			return nil
+		})
+	},
+}
+
+func init() {
+	addCommand(consoleCmd)
+	consoleCmd.Flags().BoolVarP(&consoleCmdFlags.follow, "follow", "f", false, "specify if the console output should be streamed")
+	consoleCmd.Flags().BoolVarP(&consoleCmdFlags.tail, "tail", "", false, "specify if only new messages should be sent (makes sense only when combined with --follow)")
+}
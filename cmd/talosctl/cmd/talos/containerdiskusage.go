@@ -0,0 +1,159 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	criconstants "github.com/containerd/cri/pkg/constants"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+var containerUsageCmdFlags struct {
+	system    bool
+	snapshots bool
+	humanize  bool
+	output    string
+}
+
+// containerUsageCmd represents the container-usage command.
+var containerUsageCmd = &cobra.Command{
+	Use:     "container-usage",
+	Aliases: []string{"cu"},
+	Short:   "Retrieve disk usage of the container images and writable layers",
+	Long: `Attributes disk usage of the containerd snapshotter to the images and to the writable layers of the containers.
+
+Layers size is the size of all image layers, unique layers size is the size of the layers which are not shared with other images,
+so it's the space which is reclaimed when the image is removed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch containerUsageCmdFlags.output {
+		case "table", "json":
+		default:
+			return fmt.Errorf("unknown output format: %q", containerUsageCmdFlags.output)
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			namespace := criconstants.K8sContainerdNamespace
+			if containerUsageCmdFlags.system {
+				namespace = constants.SystemContainerdNamespace
+			}
+
+			var remotePeer peer.Peer
+
+			resp, err := c.ContainerDiskUsage(ctx, namespace, grpc.Peer(&remotePeer))
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error getting container disk usage: %s", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			if containerUsageCmdFlags.output == "json" {
+				for _, msg := range resp.Messages {
+					b, err := protojson.Marshal(msg)
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("%s\n", b)
+				}
+
+				return nil
+			}
+
+			return containerUsageRender(&remotePeer, resp)
+		})
+	},
+}
+
+func containerUsageRender(remotePeer *peer.Peer, resp *machineapi.ContainerDiskUsageResponse) error {
+	defaultNode := client.AddrFromPeer(remotePeer)
+
+	stringifySize := func(s int64) string {
+		if containerUsageCmdFlags.humanize {
+			return humanize.Bytes(uint64(s))
+		}
+
+		return fmt.Sprintf("%d", s)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(w, "NODE\tIMAGE\tCONTENT\tLAYERS\tUNIQUE LAYERS")
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		for _, image := range msg.Images {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", node, image.Name, stringifySize(image.ContentSize), stringifySize(image.LayersSize), stringifySize(image.UniqueLayersSize))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "NODE\tPOD\tCONTAINER\tIMAGE\tWRITABLE LAYER")
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		for _, container := range msg.Containers {
+			pod := container.Id
+			if container.PodName != "" {
+				pod = container.PodNamespace + "/" + container.PodName
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", node, pod, container.ContainerName, container.Image, stringifySize(container.Size))
+		}
+	}
+
+	if containerUsageCmdFlags.snapshots {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "NODE\tSNAPSHOT\tKIND\tSIZE\tINODES\tIMAGES")
+
+		for _, msg := range resp.Messages {
+			node := defaultNode
+
+			if msg.Metadata != nil {
+				node = msg.Metadata.Hostname
+			}
+
+			for _, snapshot := range msg.Snapshots {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\n", node, snapshot.Name, snapshot.Kind, stringifySize(snapshot.Size), snapshot.Inodes, len(snapshot.Images))
+			}
+		}
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	containerUsageCmd.Flags().BoolVarP(&containerUsageCmdFlags.system, "system", "s", false, "use the system containerd namespace instead of k8s.io")
+	containerUsageCmd.Flags().BoolVar(&containerUsageCmdFlags.snapshots, "snapshots", false, "list all snapshotter layers")
+	containerUsageCmd.Flags().BoolVarP(&containerUsageCmdFlags.humanize, "humanize", "H", false, "humanize sizes in the output")
+	containerUsageCmd.Flags().StringVarP(&containerUsageCmdFlags.output, "output", "o", "table", "output format (table, json)")
+
+	addCommand(containerUsageCmd)
+}
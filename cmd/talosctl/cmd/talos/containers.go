@@ -24,13 +24,21 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
+var containersCmdFlags struct {
+	stats         bool
+	podNamespaces []string
+}
+
 // containersCmd represents the processes command.
 var containersCmd = &cobra.Command{
 	Use:     "containers",
 	Aliases: []string{"c"},
 	Short:   "List containers",
-	Long:    ``,
-	Args:    cobra.NoArgs,
+	Long: `Lists the containers running on the nodes.
+
+With --kubernetes, pod containers are listed directly via the CRI along with the restart counts,
+so the command can be used to triage the node even if the Kubernetes API server is down.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return WithClient(func(ctx context.Context, c *client.Client) error {
 			var (
@@ -48,7 +56,12 @@ var containersCmd = &cobra.Command{
 
 			var remotePeer peer.Peer
 
-			resp, err := c.Containers(ctx, namespace, driver, grpc.Peer(&remotePeer))
+			resp, err := c.ContainersWithOptions(ctx, &machineapi.ContainersRequest{
+				Namespace:     namespace,
+				Driver:        driver,
+				PodNamespaces: containersCmdFlags.podNamespaces,
+				Stats:         containersCmdFlags.stats,
+			}, grpc.Peer(&remotePeer))
 			if err != nil {
 				if resp == nil {
 					return fmt.Errorf("error getting container list: %s", err)
@@ -64,7 +77,18 @@ var containersCmd = &cobra.Command{
 
 func containerRender(remotePeer *peer.Peer, resp *machineapi.ContainersResponse) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "NODE\tNAMESPACE\tID\tIMAGE\tPID\tSTATUS")
+
+	header := "NODE\tNAMESPACE\tID\tIMAGE\tPID\tSTATUS"
+
+	if kubernetes {
+		header += "\tRESTARTS"
+	}
+
+	if containersCmdFlags.stats {
+		header += "\tMEMORY(MB)\tCPU"
+	}
+
+	fmt.Fprintln(w, header)
 
 	defaultNode := client.AddrFromPeer(remotePeer)
 
@@ -88,7 +112,21 @@ func containerRender(remotePeer *peer.Peer, resp *machineapi.ContainersResponse)
 				node = resp.Metadata.Hostname
 			}
 
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", node, p.Namespace, display, p.Image, p.Pid, p.Status)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s", node, p.Namespace, display, p.Image, p.Pid, p.Status)
+
+			if kubernetes {
+				if p.Id != p.PodId {
+					fmt.Fprintf(w, "\t%d", p.RestartCount)
+				} else {
+					fmt.Fprint(w, "\t")
+				}
+			}
+
+			if containersCmdFlags.stats {
+				fmt.Fprintf(w, "\t%.2f\t%d", float64(p.MemoryUsage)*1e-6, p.CpuUsage)
+			}
+
+			fmt.Fprintln(w)
 		}
 	}
 
@@ -97,6 +135,8 @@ func containerRender(remotePeer *peer.Peer, resp *machineapi.ContainersResponse)
 
 func init() {
 	containersCmd.Flags().BoolVarP(&kubernetes, "kubernetes", "k", false, "use the k8s.io containerd namespace")
+	containersCmd.Flags().BoolVar(&containersCmdFlags.stats, "stats", false, "show the container memory and CPU usage")
+	containersCmd.Flags().StringSliceVar(&containersCmdFlags.podNamespaces, "pod-namespace", nil, "list only the pods in the Kubernetes namespaces (with --kubernetes)")
 
 	containersCmd.Flags().BoolP("use-cri", "c", false, "use the CRI driver")
 	containersCmd.Flags().MarkHidden("use-cri") //nolint:errcheck
@@ -0,0 +1,157 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+var controllersPauseCmdFlags struct {
+	duration time.Duration
+}
+
+// controllersCmd represents the controllers command.
+var controllersCmd = &cobra.Command{
+	Use:   "controllers",
+	Short: "Pause and resume the controllers reconciliation",
+	Long:  ``,
+}
+
+var controllersPauseCmd = &cobra.Command{
+	Use:   "pause <controller|group>...",
+	Short: "Pause the controllers reconciliation for the emergency manual intervention",
+	Long: `Pause the controllers reconciliation for the emergency manual intervention.
+
+Controllers are selected by the name (network.LinkSpecController) or by the group (network).
+Paused controllers are stopped and resumed automatically when the duration expires.
+Pause is not persisted across reboots.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			resp, err := c.PauseControllers(ctx, &machine.PauseControllersRequest{
+				Controllers: args,
+				Duration:    durationpb.New(controllersPauseCmdFlags.duration),
+			})
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error pausing controllers: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tPAUSED UNTIL\tCONTROLLERS")
+
+			for _, msg := range resp.GetMessages() {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", msg.GetMetadata().GetHostname(), msg.GetPausedUntil().AsTime().Local().Format(time.RFC3339), strings.Join(msg.GetControllers(), ","))
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+var controllersResumeCmd = &cobra.Command{
+	Use:   "resume [<controller|group>...]",
+	Short: "Resume the paused controllers",
+	Long:  `Resume the paused controllers, all paused controllers are resumed if no controllers are specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			resp, err := c.ResumeControllers(ctx, &machine.ResumeControllersRequest{
+				Controllers: args,
+			})
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error resuming controllers: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tCONTROLLERS")
+
+			for _, msg := range resp.GetMessages() {
+				fmt.Fprintf(w, "%s\t%s\n", msg.GetMetadata().GetHostname(), strings.Join(msg.GetControllers(), ","))
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+// warnPausedControllers prints a banner if the controllers reconciliation is paused on any of the nodes.
+func warnPausedControllers(ctx context.Context, c *client.Client) {
+	listClient, err := c.Resources.List(ctx, runtime.NamespaceName, runtime.ControllerStatusType)
+	if err != nil {
+		return
+	}
+
+	var paused []string
+
+	for {
+		msg, err := listClient.Recv()
+		if err != nil {
+			break
+		}
+
+		// nodes running older Talos versions don't report the controller status
+		if msg.Metadata.GetError() != "" || msg.Resource == nil {
+			continue
+		}
+
+		var spec runtime.ControllerStatusSpec
+
+		b, err := yaml.Marshal(msg.Resource.Spec())
+		if err != nil {
+			continue
+		}
+
+		if err = yaml.Unmarshal(b, &spec); err != nil || spec.PausedUntil.IsZero() {
+			continue
+		}
+
+		controller := msg.Resource.Metadata().ID()
+		if node := msg.Metadata.GetHostname(); node != "" {
+			controller = node + ": " + controller
+		}
+
+		paused = append(paused, fmt.Sprintf("%s is paused until %s", controller, spec.PausedUntil.Local().Format(time.RFC3339)))
+	}
+
+	if len(paused) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "!!! CONTROLLERS RECONCILIATION IS PAUSED !!!")
+
+	for _, line := range paused {
+		fmt.Fprintf(os.Stderr, "    %s\n", line)
+	}
+
+	fmt.Fprintln(os.Stderr, "!!! use 'talosctl controllers resume' to resume the controllers !!!")
+}
+
+func init() {
+	controllersPauseCmd.Flags().DurationVar(&controllersPauseCmdFlags.duration, "duration", 15*time.Minute, "resume the controllers automatically after the duration")
+
+	controllersCmd.AddCommand(controllersPauseCmd, controllersResumeCmd)
+	addCommand(controllersCmd)
+}
@@ -18,6 +18,10 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
+var copyCmdFlags struct {
+	insecure bool
+}
+
 // cpCmd represents the cp command.
 var cpCmd = &cobra.Command{
 	Use:     "copy <src-path> -|<local-path>",
@@ -33,56 +37,65 @@ ownership and access mode for the files in extract mode, while  streamed .tar ar
 captures ownership and permission bits.`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return WithClient(func(ctx context.Context, c *client.Client) error {
-			if err := helpers.FailIfMultiNodes(ctx, "copy"); err != nil {
-				return err
-			}
+		if copyCmdFlags.insecure {
+			return WithClientMaintenance(nil, copyArchive(args))
+		}
 
-			r, errCh, err := c.Copy(ctx, args[0])
-			if err != nil {
-				return fmt.Errorf("error copying: %w", err)
-			}
+		return WithClient(copyArchive(args))
+	},
+}
 
-			var wg sync.WaitGroup
+func copyArchive(args []string) func(ctx context.Context, c *client.Client) error {
+	return func(ctx context.Context, c *client.Client) error {
+		if err := helpers.FailIfMultiNodes(ctx, "copy"); err != nil {
+			return err
+		}
 
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				for err := range errCh {
-					fmt.Fprintln(os.Stderr, err.Error())
-				}
-			}()
+		r, errCh, err := c.Copy(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("error copying: %w", err)
+		}
 
-			defer wg.Wait()
+		var wg sync.WaitGroup
 
-			localPath := args[1]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for err := range errCh {
+				fmt.Fprintln(os.Stderr, err.Error())
+			}
+		}()
 
-			if localPath == "-" {
-				_, err = io.Copy(os.Stdout, r)
+		defer wg.Wait()
 
-				return err
-			}
+		localPath := args[1]
+
+		if localPath == "-" {
+			_, err = io.Copy(os.Stdout, r)
 
-			localPath = filepath.Clean(localPath)
+			return err
+		}
 
-			fi, err := os.Stat(localPath)
-			if err == nil && !fi.IsDir() {
-				return fmt.Errorf("local path %q should be a directory", args[1])
+		localPath = filepath.Clean(localPath)
+
+		fi, err := os.Stat(localPath)
+		if err == nil && !fi.IsDir() {
+			return fmt.Errorf("local path %q should be a directory", args[1])
+		}
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return fmt.Errorf("failed to stat local path: %w", err)
 			}
-			if err != nil {
-				if !os.IsNotExist(err) {
-					return fmt.Errorf("failed to stat local path: %w", err)
-				}
-				if err = os.MkdirAll(localPath, 0o777); err != nil {
-					return fmt.Errorf("error creating local path %q: %w", localPath, err)
-				}
+			if err = os.MkdirAll(localPath, 0o777); err != nil {
+				return fmt.Errorf("error creating local path %q: %w", localPath, err)
 			}
+		}
 
-			return helpers.ExtractTarGz(localPath, r)
-		})
-	},
+		return helpers.ExtractTarGz(localPath, r)
+	}
 }
 
 func init() {
+	cpCmd.Flags().BoolVarP(&copyCmdFlags.insecure, "insecure", "i", false, "copy boot diagnostics snapshot using the insecure (encrypted with no auth) maintenance service")
 	addCommand(cpCmd)
 }
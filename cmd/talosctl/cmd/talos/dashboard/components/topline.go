@@ -44,5 +44,35 @@ func (widget *TopLine) Update(node string, data *data.Data) {
 			len(nodeData.CPUsInfo.GetCpuInfo()),
 			len(nodeData.Processes.GetProcesses()),
 		)
+
+		if len(nodeData.HealthChecks) > 0 {
+			healthy := 0
+
+			for _, check := range nodeData.HealthChecks {
+				if check.Healthy {
+					healthy++
+				}
+			}
+
+			color := "green"
+			if healthy < len(nodeData.HealthChecks) {
+				color = "red"
+			}
+
+			widget.Text += fmt.Sprintf(", [%d/%d health checks passing](fg:%s)", healthy, len(nodeData.HealthChecks), color)
+		}
+
+		if len(nodeData.PausedControllers) > 0 {
+			var until time.Time
+
+			for _, pausedUntil := range nodeData.PausedControllers {
+				if pausedUntil.After(until) {
+					until = pausedUntil
+				}
+			}
+
+			widget.Text += fmt.Sprintf(", [RECONCILIATION PAUSED: %d controllers until %s](fg:white,bg:red,mod:bold)",
+				len(nodeData.PausedControllers), until.Local().Format(time.Kitchen))
+		}
 	}
 }
@@ -4,7 +4,12 @@
 
 package data
 
-import "github.com/talos-systems/talos/pkg/machinery/api/machine"
+import (
+	"time"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
 
 // Node represents data gathered from a single node.
 type Node struct {
@@ -19,6 +24,12 @@ type Node struct {
 	DiskStats   *machine.DiskStats
 	Processes   *machine.Process
 
+	// User-defined health checks status by name.
+	HealthChecks map[string]runtime.HealthCheckStatusSpec
+
+	// Paused controllers with the pause deadline by name.
+	PausedControllers map[string]time.Time
+
 	// These fields are calculated as diff with Node data from previous pol.
 	SystemStatDiff  *machine.SystemStat
 	NetDevStatsDiff *machine.NetworkDeviceStats
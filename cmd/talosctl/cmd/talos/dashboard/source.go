@@ -6,14 +6,18 @@ package dashboard
 
 import (
 	"context"
+	"io"
 	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
+	yaml "gopkg.in/yaml.v3"
 
 	"github.com/talos-systems/talos/cmd/talosctl/cmd/talos/dashboard/data"
 	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
 )
 
 // APISource provides monitoring data via Talos API.
@@ -274,6 +278,108 @@ func (source *APISource) gather() *data.Data {
 
 			return nil
 		},
+		func() error {
+			listClient, err := source.Resources.List(source.ctx, runtime.NamespaceName, runtime.HealthCheckStatusType)
+			if err != nil {
+				return err
+			}
+
+			for {
+				msg, err := listClient.Recv()
+				if err != nil {
+					if err == io.EOF || client.StatusCode(err) == codes.Canceled {
+						return nil
+					}
+
+					return err
+				}
+
+				// nodes running older Talos versions don't support health checks
+				if msg.Metadata.GetError() != "" || msg.Resource == nil {
+					continue
+				}
+
+				var spec runtime.HealthCheckStatusSpec
+
+				b, err := yaml.Marshal(msg.Resource.Spec())
+				if err != nil {
+					return err
+				}
+
+				if err = yaml.Unmarshal(b, &spec); err != nil {
+					return err
+				}
+
+				node := msg.Metadata.GetHostname()
+
+				resultLock.Lock()
+
+				if _, ok := result.Nodes[node]; !ok {
+					result.Nodes[node] = &data.Node{}
+				}
+
+				if result.Nodes[node].HealthChecks == nil {
+					result.Nodes[node].HealthChecks = map[string]runtime.HealthCheckStatusSpec{}
+				}
+
+				result.Nodes[node].HealthChecks[msg.Resource.Metadata().ID()] = spec
+
+				resultLock.Unlock()
+			}
+		},
+		func() error {
+			listClient, err := source.Resources.List(source.ctx, runtime.NamespaceName, runtime.ControllerStatusType)
+			if err != nil {
+				return err
+			}
+
+			for {
+				msg, err := listClient.Recv()
+				if err != nil {
+					if err == io.EOF || client.StatusCode(err) == codes.Canceled {
+						return nil
+					}
+
+					return err
+				}
+
+				// nodes running older Talos versions don't report the controller status
+				if msg.Metadata.GetError() != "" || msg.Resource == nil {
+					continue
+				}
+
+				var spec runtime.ControllerStatusSpec
+
+				b, err := yaml.Marshal(msg.Resource.Spec())
+				if err != nil {
+					return err
+				}
+
+				if err = yaml.Unmarshal(b, &spec); err != nil {
+					return err
+				}
+
+				if spec.PausedUntil.IsZero() {
+					continue
+				}
+
+				node := msg.Metadata.GetHostname()
+
+				resultLock.Lock()
+
+				if _, ok := result.Nodes[node]; !ok {
+					result.Nodes[node] = &data.Node{}
+				}
+
+				if result.Nodes[node].PausedControllers == nil {
+					result.Nodes[node].PausedControllers = map[string]time.Time{}
+				}
+
+				result.Nodes[node].PausedControllers[msg.Resource.Metadata().ID()] = spec.PausedUntil
+
+				resultLock.Unlock()
+			}
+		},
 	}
 
 	var eg errgroup.Group
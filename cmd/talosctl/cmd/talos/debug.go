@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var debugNodeCmdFlags struct {
+	tty bool
+}
+
+// debugCmd represents the debug command.
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Debug the nodes",
+	Long:  ``,
+}
+
+var debugNodeCmd = &cobra.Command{
+	Use:   "node <image> [-- <command> [<arg>...]]",
+	Short: "Run a privileged debug container on the node",
+	Long: `Run a short-lived privileged container from the specified image on the node.
+
+The container runs in the host network, PID, IPC and UTS namespaces, has access to the host devices,
+and the host root filesystem is mounted at /host.
+Image entrypoint is used if the command is not specified.
+The container is removed when the command exits or talosctl disconnects.
+
+This command requires the os:debug role, which is not implied by os:admin.`,
+	Example: `  talosctl -n 172.20.0.2 debug node docker.io/library/alpine:3.15 -- sh`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("tty") {
+			debugNodeCmdFlags.tty = term.IsTerminal(int(os.Stdin.Fd()))
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			if err := helpers.FailIfMultiNodes(ctx, "debug node"); err != nil {
+				return err
+			}
+
+			return debugNode(ctx, c, args[0], args[1:])
+		})
+	},
+}
+
+//nolint:gocyclo
+func debugNode(ctx context.Context, c *client.Client, image string, args []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.Debug(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting debug container: %w", err)
+	}
+
+	req := &machine.DebugRequest{
+		Image: image,
+		Args:  args,
+		Tty:   debugNodeCmdFlags.tty,
+	}
+
+	if debugNodeCmdFlags.tty {
+		if width, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			req.TtyWidth, req.TtyHeight = uint32(width), uint32(height)
+		}
+
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+			if err != nil {
+				return fmt.Errorf("error switching terminal to raw mode: %w", err)
+			}
+
+			//nolint:errcheck
+			defer term.Restore(int(os.Stdin.Fd()), oldState)
+		}
+	}
+
+	if err = stream.Send(req); err != nil {
+		return fmt.Errorf("error starting debug container: %w", err)
+	}
+
+	go func() {
+		buf := make([]byte, 4096)
+
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				if stream.Send(&machine.DebugRequest{Stdin: buf[:n]}) != nil {
+					return
+				}
+			}
+
+			if err != nil {
+				stream.Send(&machine.DebugRequest{StdinClose: true}) //nolint:errcheck
+				stream.CloseSend()                                   //nolint:errcheck
+
+				return
+			}
+		}
+	}()
+
+	var exitCode uint32
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return fmt.Errorf("error reading debug container output: %w", err)
+		}
+
+		if resp.Metadata != nil && resp.Metadata.Error != "" {
+			return errors.New(resp.Metadata.Error)
+		}
+
+		os.Stdout.Write(resp.Stdout) //nolint:errcheck
+		os.Stderr.Write(resp.Stderr) //nolint:errcheck
+
+		if resp.Exited {
+			exitCode = resp.ExitCode
+		}
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("debug container exited with code %d", exitCode)
+	}
+
+	return nil
+}
+
+func init() {
+	debugNodeCmd.Flags().BoolVarP(&debugNodeCmdFlags.tty, "tty", "t", false, "allocate a TTY for the container (defaults to true if stdin is a terminal)")
+
+	debugCmd.AddCommand(debugNodeCmd)
+	addCommand(debugCmd)
+}
@@ -6,13 +6,10 @@ package talos
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
-	"io"
 	"os"
 	"strconv"
 	"strings"
-	"sync"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -153,39 +150,15 @@ var etcdSnapshotCmd = &cobra.Command{
 
 			defer dest.Close() //nolint:errcheck
 
-			r, errCh, err := c.EtcdSnapshot(ctx, &machine.EtcdSnapshotRequest{})
+			size, err := c.EtcdSnapshotToWriter(ctx, dest)
 			if err != nil {
-				return fmt.Errorf("error reading file: %w", err)
-			}
-
-			defer r.Close() //nolint:errcheck
-
-			var wg sync.WaitGroup
-
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				for err := range errCh {
-					fmt.Fprintln(os.Stderr, err.Error())
-				}
-			}()
-
-			defer wg.Wait()
-
-			size, err := io.Copy(dest, r)
-			if err != nil {
-				return fmt.Errorf("error reading: %w", err)
+				return err
 			}
 
 			if err = dest.Sync(); err != nil {
 				return fmt.Errorf("failed to fsync: %w", err)
 			}
 
-			// this check is from https://github.com/etcd-io/etcd/blob/client/v3.5.0-alpha.0/client/v3/snapshot/v3_snapshot.go#L46
-			if (size % 512) != sha256.Size {
-				return fmt.Errorf("sha256 checksum not found (size %d)", size)
-			}
-
 			if err = os.Rename(partPath, dbPath); err != nil {
 				return fmt.Errorf("error renaming to final location: %w", err)
 			}
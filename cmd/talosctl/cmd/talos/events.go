@@ -86,8 +86,22 @@ var eventsCmd = &cobra.Command{
 						args = []interface{}{"error", msg.GetError()}
 					case *machine.ConfigValidationErrorEvent:
 						args = []interface{}{"error", msg.GetError()}
+					case *machine.ConfigAppliedEvent:
+						args = []interface{}{"config", fmt.Sprintf("applied: %s", msg.GetMode())}
+					case *machine.ConfigRevertedEvent:
+						args = []interface{}{"config", fmt.Sprintf("reverted: %s", msg.GetReason())}
 					case *machine.AddressEvent:
 						args = []interface{}{msg.GetHostname(), fmt.Sprintf("ADDRESSES: %s", strings.Join(msg.GetAddresses(), ","))}
+					case *machine.ControllerFailureEvent:
+						if msg.GetWedged() {
+							args = []interface{}{msg.GetController(), fmt.Sprintf("wedged: %s", msg.GetError())}
+						} else {
+							args = []interface{}{msg.GetController(), fmt.Sprintf("failed (crashes: %d): %s", msg.GetCrashes(), msg.GetError())}
+						}
+					case *machine.StaticPodRecoveryEvent:
+						args = []interface{}{msg.GetPod(), fmt.Sprintf("recovered (%s): %s", msg.GetReason(), strings.Join(msg.GetActions(), ", "))}
+					case *machine.DrainEvent:
+						args = []interface{}{msg.GetNode(), drainProgress(msg)}
 					default:
 						// We haven't implemented the handling of this event yet.
 						continue
@@ -24,6 +24,7 @@ var getCmdFlags struct {
 	namespace string
 	output    string
 	watch     bool
+	fields    []string
 }
 
 // getCmd represents the get (resources) command.
@@ -45,9 +46,28 @@ var getCmd = &cobra.Command{
 //nolint:gocyclo,cyclop
 func getResources(args []string) func(ctx context.Context, c *client.Client) error {
 	return func(ctx context.Context, c *client.Client) error {
-		out, err := output.NewWriter(getCmdFlags.output)
-		if err != nil {
-			return err
+		var (
+			out output.Writer
+			err error
+		)
+
+		if len(getCmdFlags.fields) > 0 {
+			if getCmdFlags.watch {
+				return fmt.Errorf("--fields is not supported with --watch")
+			}
+
+			if getCmdFlags.output != "yaml" && getCmdFlags.output != "json" {
+				return fmt.Errorf("--fields is supported only with yaml and json output")
+			}
+		}
+
+		if getCmdFlags.output == "aggregate" {
+			out = output.NewAggregate(Nodes...)
+		} else {
+			out, err = output.NewWriter(getCmdFlags.output)
+			if err != nil {
+				return err
+			}
 		}
 
 		resourceType := args[0]
@@ -124,14 +144,15 @@ func getResources(args []string) func(ctx context.Context, c *client.Client) err
 			return nil
 		}
 
-		return helpers.ForEachResource(ctx, c, printOut, getCmdFlags.namespace, args...)
+		return helpers.ForEachResourceFields(ctx, c, printOut, getCmdFlags.namespace, getCmdFlags.fields, args...)
 	}
 }
 
 func init() {
 	getCmd.Flags().StringVar(&getCmdFlags.namespace, "namespace", "", "resource namespace (default is to use default namespace per resource)")
-	getCmd.Flags().StringVarP(&getCmdFlags.output, "output", "o", "table", "output mode (table, yaml)")
+	getCmd.Flags().StringVarP(&getCmdFlags.output, "output", "o", "table", "output mode (table, yaml, json, aggregate)")
 	getCmd.Flags().BoolVarP(&getCmdFlags.watch, "watch", "w", false, "watch resource changes")
+	getCmd.Flags().StringSliceVar(&getCmdFlags.fields, "fields", nil, "resource spec fields to fetch as dot-separated paths (yaml and json output only)")
 	getCmd.Flags().BoolVarP(&getCmdFlags.insecure, "insecure", "i", false, "get resources using the insecure (encrypted with no auth) maintenance service")
 	addCommand(getCmd)
 }
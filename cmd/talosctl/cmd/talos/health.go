@@ -8,18 +8,26 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 
 	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/cli"
 	"github.com/talos-systems/talos/pkg/cluster"
 	"github.com/talos-systems/talos/pkg/cluster/check"
 	"github.com/talos-systems/talos/pkg/cluster/sonobuoy"
 	clusterapi "github.com/talos-systems/talos/pkg/machinery/api/cluster"
 	"github.com/talos-systems/talos/pkg/machinery/client"
+	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 )
 
@@ -64,6 +72,7 @@ var healthCmdFlags struct {
 	forceEndpoint      string
 	runOnServer        bool
 	runE2E             bool
+	talosconfigDir     string
 }
 
 // healthCmd represents the health command.
@@ -73,6 +82,10 @@ var healthCmd = &cobra.Command{
 	Long:  ``,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if healthCmdFlags.talosconfigDir != "" && healthCmdFlags.runE2E {
+			return fmt.Errorf("--run-e2e is not supported with --talosconfig-dir")
+		}
+
 		if err := runHealth(); err != nil {
 			return err
 		}
@@ -86,6 +99,10 @@ var healthCmd = &cobra.Command{
 }
 
 func runHealth() error {
+	if healthCmdFlags.talosconfigDir != "" {
+		return healthMultiCluster(healthCmdFlags.talosconfigDir)
+	}
+
 	if healthCmdFlags.runOnServer {
 		return WithClient(healthOnServer)
 	}
@@ -93,7 +110,18 @@ func runHealth() error {
 	return WithClientNoNodes(healthOnClient)
 }
 
+// warnPausedClusterControllers checks the cluster nodes for paused controllers, if the nodes are known.
+func warnPausedClusterControllers(ctx context.Context, c *client.Client) {
+	if nodes := healthCmdFlags.clusterState.Nodes(); len(nodes) > 0 {
+		ctx = client.WithNodes(ctx, nodes...)
+	}
+
+	warnPausedControllers(ctx, c)
+}
+
 func healthOnClient(ctx context.Context, c *client.Client) error {
+	warnPausedClusterControllers(ctx, c)
+
 	clientProvider := &cluster.ConfigClientProvider{
 		DefaultClient: c,
 	}
@@ -124,16 +152,25 @@ func healthOnServer(ctx context.Context, c *client.Client) error {
 		return err
 	}
 
+	warnPausedClusterControllers(ctx, c)
+
 	controlPlaneNodes := healthCmdFlags.clusterState.ControlPlaneNodes
 	if healthCmdFlags.clusterState.InitNode != "" {
 		controlPlaneNodes = append(controlPlaneNodes, healthCmdFlags.clusterState.InitNode)
 	}
 
-	healthCheckClient, err := c.ClusterHealthCheck(ctx, healthCmdFlags.clusterWaitTimeout, &clusterapi.ClusterInfo{
+	return serverHealthCheck(ctx, c, &clusterapi.ClusterInfo{
 		ControlPlaneNodes: controlPlaneNodes,
 		WorkerNodes:       healthCmdFlags.clusterState.WorkerNodes,
 		ForceEndpoint:     healthCmdFlags.forceEndpoint,
+	}, func(msg string) {
+		fmt.Fprintln(os.Stderr, msg)
 	})
+}
+
+// serverHealthCheck runs the health check on the node, progress messages are passed to the callback.
+func serverHealthCheck(ctx context.Context, c *client.Client, clusterInfo *clusterapi.ClusterInfo, progress func(msg string)) error {
+	healthCheckClient, err := c.ClusterHealthCheck(ctx, healthCmdFlags.clusterWaitTimeout, clusterInfo)
 	if err != nil {
 		return err
 	}
@@ -156,8 +193,175 @@ func healthOnServer(ctx context.Context, c *client.Client) error {
 			return fmt.Errorf("healthcheck error: %s", msg.GetMetadata().GetError())
 		}
 
-		fmt.Fprintln(os.Stderr, msg.GetMessage())
+		progress(msg.GetMessage())
+	}
+}
+
+// healthTarget is a cluster (talosconfig context) checked by the multi-cluster health check.
+type healthTarget struct {
+	path        string
+	contextName string
+	config      *clientconfig.Config
+
+	// err is set if the talosconfig can't be loaded
+	err error
+}
+
+func (target *healthTarget) String() string {
+	if target.contextName == "" {
+		return filepath.Base(target.path)
+	}
+
+	return filepath.Base(target.path) + "/" + target.contextName
+}
+
+// loadHealthTargets loads all contexts from the talosconfig files in the directory.
+func loadHealthTargets(dir string) ([]*healthTarget, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*healthTarget
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		cfg, err := clientconfig.Open(path)
+		if err == nil && len(cfg.Contexts) == 0 {
+			err = fmt.Errorf("no contexts found")
+		}
+
+		if err != nil {
+			targets = append(targets, &healthTarget{
+				path: path,
+				err:  fmt.Errorf("error loading talosconfig: %w", err),
+			})
+
+			continue
+		}
+
+		contextNames := make([]string, 0, len(cfg.Contexts))
+
+		for name := range cfg.Contexts {
+			contextNames = append(contextNames, name)
+		}
+
+		sort.Strings(contextNames)
+
+		for _, name := range contextNames {
+			targets = append(targets, &healthTarget{
+				path:        path,
+				contextName: name,
+				config:      cfg,
+			})
+		}
 	}
+
+	return targets, nil
+}
+
+// healthResult is the result of the health check of a single cluster.
+type healthResult struct {
+	node     string
+	message  string
+	duration time.Duration
+	err      error
+}
+
+// checkTarget runs the server-side health check against the first node of the context (or the endpoint if nodes are not set).
+func checkTarget(ctx context.Context, target *healthTarget) healthResult {
+	if target.err != nil {
+		return healthResult{err: target.err}
+	}
+
+	start := time.Now()
+
+	c, err := client.New(ctx, client.WithConfig(target.config), client.WithContextName(target.contextName))
+	if err != nil {
+		return healthResult{err: fmt.Errorf("error constructing client: %w", err)}
+	}
+
+	//nolint:errcheck
+	defer c.Close()
+
+	var result healthResult
+
+	if nodes := target.config.Contexts[target.contextName].Nodes; len(nodes) > 0 {
+		result.node = nodes[0]
+		ctx = client.WithNodes(ctx, result.node)
+	} else if endpoints := target.config.Contexts[target.contextName].Endpoints; len(endpoints) > 0 {
+		result.node = endpoints[0]
+	}
+
+	result.err = serverHealthCheck(ctx, c, &clusterapi.ClusterInfo{}, func(msg string) {
+		result.message = msg
+	})
+	result.duration = time.Since(start)
+
+	return result
+}
+
+func healthMultiCluster(dir string) error {
+	targets, err := loadHealthTargets(dir)
+	if err != nil {
+		return fmt.Errorf("error reading talosconfig directory: %w", err)
+	}
+
+	if len(targets) == 0 {
+		return fmt.Errorf("no talosconfig files found in %q", dir)
+	}
+
+	return cli.WithContext(context.Background(), func(ctx context.Context) error {
+		results := make([]healthResult, len(targets))
+
+		var wg sync.WaitGroup
+
+		for i := range targets {
+			i := i
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				results[i] = checkTarget(ctx, targets[i])
+			}()
+		}
+
+		wg.Wait()
+
+		unhealthy := 0
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "CLUSTER\tNODE\tSTATUS\tDURATION\tMESSAGE")
+
+		for i, result := range results {
+			status, message := "OK", result.message
+
+			if result.err != nil {
+				unhealthy++
+
+				status, message = "FAILED", result.err.Error()
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", targets[i], result.node, status, result.duration.Round(time.Second), message)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if unhealthy > 0 {
+			return fmt.Errorf("%d of %d clusters are not healthy", unhealthy, len(targets))
+		}
+
+		return nil
+	})
 }
 
 func runE2E() error {
@@ -196,4 +400,6 @@ func init() {
 	healthCmd.Flags().StringVar(&healthCmdFlags.forceEndpoint, "k8s-endpoint", "", "use endpoint instead of kubeconfig default")
 	healthCmd.Flags().BoolVar(&healthCmdFlags.runOnServer, "server", true, "run server-side check")
 	healthCmd.Flags().BoolVar(&healthCmdFlags.runE2E, "run-e2e", false, "run Kubernetes e2e test")
+	healthCmd.Flags().StringVar(&healthCmdFlags.talosconfigDir, "talosconfig-dir", "",
+		"check all clusters (contexts) from the talosconfig files in the directory concurrently and print the aggregated status")
 }
@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos //nolint:testpackage // to test unexported function
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/crypto/x509"
+
+	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
+)
+
+func TestLoadHealthTargets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	newConfig := func(contextName string) *clientconfig.Config {
+		return clientconfig.NewConfig(contextName, []string{"10.5.0.2"}, []byte("ca"), &x509.PEMEncodedCertificateAndKey{
+			Crt: []byte("crt"),
+			Key: []byte("key"),
+		})
+	}
+
+	edge := newConfig("edge-2")
+	edge.Merge(newConfig("edge-1"))
+
+	require.NoError(t, edge.Save(filepath.Join(dir, "edge")))
+	require.NoError(t, newConfig("core").Save(filepath.Join(dir, "core")))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "broken"), []byte("contexts: ["), 0o600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".hidden"), []byte("contexts: ["), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o700))
+
+	targets, err := loadHealthTargets(dir)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(targets))
+
+	for _, target := range targets {
+		names = append(names, target.String())
+	}
+
+	assert.Equal(t, []string{"broken", "core/core", "edge/edge-1", "edge/edge-2"}, names)
+
+	assert.Error(t, targets[0].err)
+
+	for _, target := range targets[1:] {
+		assert.NoError(t, target.err)
+		assert.Contains(t, target.config.Contexts, target.contextName)
+	}
+}
@@ -25,6 +25,7 @@ var inspectCmd = &cobra.Command{
 
 var inspectDependenciesCmdFlags struct {
 	withResources bool
+	output        string
 }
 
 // inspectDependenciesCmd represents the inspect dependencies command.
@@ -37,6 +38,12 @@ Pipe the output of the command through the "dot" program (part of graphviz packa
 to render the graph:
 
   talosctl inspect dependencies | dot -Tpng > graph.png
+
+Controllers which are waiting to be restarted after a crash are highlighted in red,
+controllers which crashed before or reported an error are highlighted in orange.
+
+The graph with the controller health (crash counts and last errors) can be also dumped
+as JSON ("--output json") or as mermaid flowchart ("--output mermaid").
 `,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -54,7 +61,16 @@ to render the graph:
 				cli.Warning("%s", err)
 			}
 
-			return cli.RenderGraph(ctx, c, resp, os.Stdout, inspectDependenciesCmdFlags.withResources)
+			switch inspectDependenciesCmdFlags.output {
+			case "dot":
+				return cli.RenderGraph(ctx, c, resp, os.Stdout, inspectDependenciesCmdFlags.withResources)
+			case "json":
+				return cli.RenderGraphJSON(resp, os.Stdout)
+			case "mermaid":
+				return cli.RenderGraphMermaid(resp, os.Stdout)
+			default:
+				return fmt.Errorf("unsupported output format %q", inspectDependenciesCmdFlags.output)
+			}
 		})
 	},
 }
@@ -63,5 +79,6 @@ func init() {
 	addCommand(inspectCmd)
 
 	inspectCmd.AddCommand(inspectDependenciesCmd)
-	inspectDependenciesCmd.Flags().BoolVar(&inspectDependenciesCmdFlags.withResources, "with-resources", false, "display live resource information with dependencies")
+	inspectDependenciesCmd.Flags().BoolVar(&inspectDependenciesCmdFlags.withResources, "with-resources", false, "display live resource information with dependencies (dot output only)")
+	inspectDependenciesCmd.Flags().StringVarP(&inspectDependenciesCmdFlags.output, "output", "o", "dot", "output format (dot, json, mermaid)")
 }
@@ -0,0 +1,284 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/spf13/cobra"
+	"github.com/talos-systems/go-retry/retry"
+	"google.golang.org/grpc/metadata"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/cluster"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	machinetype "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+// kubeletCmd represents the kubelet command.
+var kubeletCmd = &cobra.Command{
+	Use:   "kubelet",
+	Short: "Manage kubelet",
+	Long:  ``,
+}
+
+var kubeletRepairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Remove kubelet client credentials and restart kubelet to bootstrap it again",
+	Long: `Remove kubelet kubeconfig and client certificates and restart kubelet.
+
+Kubelet goes through the bootstrap flow again and requests a new client certificate,
+which recovers the nodes with kubelet client certificates expired while the node was offline.
+Node reboot is not required.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			resp, err := c.KubeletRepair(ctx, &machine.KubeletRepairRequest{})
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error repairing kubelet: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tREMOVED FILES")
+
+			for _, msg := range resp.GetMessages() {
+				fmt.Fprintf(w, "%s\t%s\n", msg.GetMetadata().GetHostname(), strings.Join(msg.GetRemovedFiles(), ","))
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+var kubeletRegenerateCmdFlags struct {
+	wait        bool
+	waitTimeout time.Duration
+}
+
+var kubeletRegenerateCmd = &cobra.Command{
+	Use:   "regenerate",
+	Short: "Regenerate kubelet credentials and restart kubelet after the cluster CA rotation",
+	Long: `Remove kubelet credentials issued by the cluster CA along with the cluster CA certificate and restart kubelet.
+
+Kubelet writes the cluster CA certificate from the current machine configuration and goes through the bootstrap flow again
+with the bootstrap kubeconfig kept up to date by Talos, so that it trusts and is trusted by the rotated cluster CA.
+Nodes are processed one by one, control plane nodes first: kubelet on a control plane node is restarted once the control plane components
+are running with the secrets rendered from the current machine configuration, and worker nodes are processed after that.
+With --wait (default), the next node is processed only once the Kubernetes node of the previous one reports Ready again
+after kubelet restart; the kubeconfig to watch the nodes is requested from the first control plane node (or the endpoint if there is none).
+Node reboot is not required.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			controlPlaneNodes, workerNodes, err := nodesByMachineType(ctx, c, Nodes)
+			if err != nil {
+				return err
+			}
+
+			var k8sProvider cluster.K8sProvider
+
+			if kubeletRegenerateCmdFlags.wait {
+				clientProvider := &cluster.ConfigClientProvider{
+					DefaultClient: c,
+				}
+				defer clientProvider.Close() //nolint:errcheck
+
+				kubernetesClient := &cluster.KubernetesClient{
+					ClientProvider: clientProvider,
+				}
+				defer kubernetesClient.K8sClose() //nolint:errcheck
+
+				if _, err = kubernetesClient.K8sClient(kubeconfigContext(ctx, controlPlaneNodes)); err != nil {
+					return fmt.Errorf("error building Kubernetes client: %w", err)
+				}
+
+				k8sProvider = kubernetesClient
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tREMOVED FILES")
+
+			// workers bootstrap against the control plane, so the control plane nodes go first
+			for _, node := range append(controlPlaneNodes, workerNodes...) {
+				if err = kubeletRegenerate(ctx, c, k8sProvider, w, node); err != nil {
+					w.Flush() //nolint:errcheck
+
+					return err
+				}
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+// kubeletRegenerate regenerates kubelet credentials on the node and waits for the node to be Ready if the Kubernetes provider is set.
+func kubeletRegenerate(ctx context.Context, c *client.Client, k8sProvider cluster.K8sProvider, w io.Writer, node string) error {
+	nodeCtx := client.WithNodes(ctx, node)
+
+	var (
+		nodename      string
+		lastHeartbeat time.Time
+		err           error
+	)
+
+	if k8sProvider != nil {
+		if nodename, err = kubernetesNodename(nodeCtx, c); err != nil {
+			return fmt.Errorf("error getting Kubernetes nodename of node %q: %w", node, err)
+		}
+
+		if _, lastHeartbeat, err = nodeReady(ctx, k8sProvider, nodename); err != nil {
+			return fmt.Errorf("error getting Kubernetes node %q: %w", nodename, err)
+		}
+	}
+
+	resp, err := c.KubeletRegenerate(nodeCtx, &machine.KubeletRegenerateRequest{})
+	if err != nil {
+		return fmt.Errorf("error regenerating kubelet on node %q: %w", node, err)
+	}
+
+	for _, msg := range resp.GetMessages() {
+		fmt.Fprintf(w, "%s\t%s\n", msg.GetMetadata().GetHostname(), strings.Join(msg.GetRemovedFiles(), ","))
+	}
+
+	if k8sProvider == nil {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "waiting for Kubernetes node %q to be Ready\n", nodename)
+
+	// the node stays Ready until the node monitor grace period is over, so wait for the restarted kubelet to report the status
+	err = retry.Constant(kubeletRegenerateCmdFlags.waitTimeout, retry.WithUnits(5*time.Second)).RetryWithContext(ctx, func(ctx context.Context) error {
+		ready, heartbeat, err := nodeReady(ctx, k8sProvider, nodename)
+		if err != nil {
+			return retry.ExpectedError(err)
+		}
+
+		if !ready || !heartbeat.After(lastHeartbeat) {
+			return retry.ExpectedErrorf("node %q is not Ready", nodename)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for Kubernetes node %q to be Ready: %w", nodename, err)
+	}
+
+	return nil
+}
+
+// kubeconfigContext returns the context to request the kubeconfig from the first control plane node or the endpoint.
+func kubeconfigContext(ctx context.Context, controlPlaneNodes []string) context.Context {
+	if len(controlPlaneNodes) > 0 {
+		return client.WithNodes(ctx, controlPlaneNodes[0])
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	md = md.Copy()
+	md.Delete("nodes")
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// kubernetesNodename returns the Kubernetes nodename of the node.
+func kubernetesNodename(ctx context.Context, c *client.Client) (string, error) {
+	responses, err := c.Resources.Get(ctx, k8s.ControlPlaneNamespaceName, k8s.NodenameType, k8s.NodenameID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range responses {
+		if r.Resource == nil {
+			continue
+		}
+
+		spec, _ := r.Resource.(*resource.Any).Value().(map[string]interface{}) //nolint:errcheck
+
+		if nodename, _ := spec["nodename"].(string); nodename != "" { //nolint:errcheck
+			return nodename, nil
+		}
+	}
+
+	return "", fmt.Errorf("nodename is not set")
+}
+
+// nodeReady returns whether the Kubernetes node is Ready along with the last heartbeat of the Ready condition.
+func nodeReady(ctx context.Context, k8sProvider cluster.K8sProvider, nodename string) (bool, time.Time, error) {
+	clientset, err := k8sProvider.K8sClient(ctx)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(ctx, nodename, metav1.GetOptions{})
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue, cond.LastHeartbeatTime.Time, nil
+		}
+	}
+
+	return false, time.Time{}, nil
+}
+
+// nodesByMachineType splits the nodes into control plane and worker nodes.
+func nodesByMachineType(ctx context.Context, c *client.Client, nodes []string) ([]string, []string, error) {
+	var controlPlaneNodes, workerNodes []string
+
+	for _, node := range nodes {
+		responses, err := c.Resources.Get(client.WithNodes(ctx, node), config.NamespaceName, config.MachineTypeType, config.MachineTypeID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting machine type of node %q: %w", node, err)
+		}
+
+		for _, r := range responses {
+			if r.Resource == nil {
+				continue
+			}
+
+			value, _ := r.Resource.(*resource.Any).Value().(string) //nolint:errcheck
+
+			machineType, err := machinetype.ParseType(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing machine type of node %q: %w", node, err)
+			}
+
+			if machineType == machinetype.TypeWorker {
+				workerNodes = append(workerNodes, node)
+			} else {
+				controlPlaneNodes = append(controlPlaneNodes, node)
+			}
+		}
+	}
+
+	return controlPlaneNodes, workerNodes, nil
+}
+
+func init() {
+	kubeletRegenerateCmd.Flags().BoolVar(&kubeletRegenerateCmdFlags.wait, "wait", true, "wait for the Kubernetes node to be Ready before proceeding to the next node")
+	kubeletRegenerateCmd.Flags().DurationVar(&kubeletRegenerateCmdFlags.waitTimeout, "wait-timeout", 10*time.Minute, "timeout to wait for each Kubernetes node to be Ready")
+	kubeletCmd.AddCommand(kubeletRegenerateCmd)
+	kubeletCmd.AddCommand(kubeletRepairCmd)
+	addCommand(kubeletCmd)
+}
@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+)
+
+// Aggregate outputs resources as a table with a column per node.
+//
+// Each cell contains the values of the resource print columns, or the short hash
+// of the resource spec if the resource doesn't define any print columns,
+// so that differences between the nodes stand out.
+type Aggregate struct {
+	table          *helpers.NodeTable
+	dynamicColumns []dynamicColumn
+}
+
+// NewAggregate initializes aggregate resource output.
+func NewAggregate(nodes ...string) *Aggregate {
+	return &Aggregate{
+		table: helpers.NewNodeTable("ID", nodes...),
+	}
+}
+
+// WriteHeader implements output.Writer interface.
+func (a *Aggregate) WriteHeader(definition resource.Resource, withEvents bool) error {
+	if withEvents {
+		return fmt.Errorf("aggregate output doesn't support watching resources")
+	}
+
+	resourceDefinitionSpec := definition.(*resource.Any).Value().(map[string]interface{}) //nolint:errcheck,forcetypeassert
+
+	var err error
+
+	_, a.dynamicColumns, err = parseDynamicColumns(resourceDefinitionSpec)
+
+	return err
+}
+
+// WriteResource implements output.Writer interface.
+func (a *Aggregate) WriteResource(node string, r resource.Resource, event state.EventType) error {
+	value := r.(*resource.Any).Value() //nolint:errcheck,forcetypeassert
+
+	var cell string
+
+	if len(a.dynamicColumns) == 0 {
+		spec, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		hash := sha256.Sum256(spec)
+
+		cell = hex.EncodeToString(hash[:])[:8]
+	} else {
+		values := make([]string, 0, len(a.dynamicColumns))
+
+		for _, dynamicColumn := range a.dynamicColumns {
+			v, err := dynamicColumn(value)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, v)
+		}
+
+		cell = strings.Join(values, " ")
+	}
+
+	a.table.Add(r.Metadata().ID(), node, cell)
+
+	return nil
+}
+
+// Flush implements output.Writer interface.
+func (a *Aggregate) Flush() error {
+	return a.table.Write(os.Stdout)
+}
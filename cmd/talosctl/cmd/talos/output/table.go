@@ -47,18 +47,43 @@ func (table *Table) WriteHeader(definition resource.Resource, withEvents bool) e
 
 	table.displayType = resourceDefinitionSpec["displayType"].(string) //nolint:errcheck,forcetypeassert
 
+	names, columns, err := parseDynamicColumns(resourceDefinitionSpec)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		fields = append(fields, strings.ToUpper(name))
+	}
+
+	table.dynamicColumns = columns
+
+	fields = append([]string{"NODE"}, fields...)
+
+	_, err = fmt.Fprintln(&table.w, strings.Join(fields, "\t"))
+
+	return err
+}
+
+// parseDynamicColumns builds the columns from the resource definition print columns.
+func parseDynamicColumns(resourceDefinitionSpec map[string]interface{}) ([]string, []dynamicColumn, error) {
+	var (
+		names   []string
+		columns []dynamicColumn
+	)
+
 	for _, col := range resourceDefinitionSpec["printColumns"].([]interface{}) {
 		column := col.(map[string]interface{}) //nolint:errcheck,forcetypeassert
 		name := column["name"].(string)        //nolint:errcheck,forcetypeassert
 
-		fields = append(fields, strings.ToUpper(name))
+		names = append(names, name)
 
 		expr := jsonpath.New(name)
 		if err := expr.Parse(column["jsonPath"].(string)); err != nil {
-			return fmt.Errorf("error parsing column %q jsonpath: %w", name, err)
+			return nil, nil, fmt.Errorf("error parsing column %q jsonpath: %w", name, err)
 		}
 
-		table.dynamicColumns = append(table.dynamicColumns, func(val interface{}) (string, error) {
+		columns = append(columns, func(val interface{}) (string, error) {
 			var buf bytes.Buffer
 
 			if e := expr.Execute(&buf, val); e != nil {
@@ -69,11 +94,7 @@ func (table *Table) WriteHeader(definition resource.Resource, withEvents bool) e
 		})
 	}
 
-	fields = append([]string{"NODE"}, fields...)
-
-	_, err := fmt.Fprintln(&table.w, strings.Join(fields, "\t"))
-
-	return err
+	return names, columns, nil
 }
 
 // WriteResource implements output.Writer interface.
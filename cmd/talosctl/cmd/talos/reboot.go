@@ -33,6 +33,15 @@ var rebootCmd = &cobra.Command{
 				opts = append(opts, client.WithPowerCycle)
 			}
 
+			ignoreInhibitors, err := cmd.Flags().GetBool("ignore-inhibitors")
+			if err != nil {
+				return fmt.Errorf("error getting input value for --ignore-inhibitors flag: %s", err)
+			}
+
+			if ignoreInhibitors {
+				opts = append(opts, client.WithIgnoreInhibitors)
+			}
+
 			if err := c.Reboot(ctx, opts...); err != nil {
 				return fmt.Errorf("error executing reboot: %s", err)
 			}
@@ -44,5 +53,6 @@ var rebootCmd = &cobra.Command{
 
 func init() {
 	rebootCmd.Flags().StringP("mode", "m", "default", "select the reboot mode: \"default\", \"powercyle\" (skips kexec)")
+	rebootCmd.Flags().Bool("ignore-inhibitors", false, "reboot without waiting for the shutdown inhibitors set by the workloads on the node")
 	addCommand(rebootCmd)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/talos-systems/crypto/x509"
 
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
 	"github.com/talos-systems/talos/pkg/cli"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
@@ -77,6 +78,13 @@ func WithClient(action func(context.Context, *client.Client) error) error {
 			return fmt.Errorf("nodes are not set for the command: please use `--nodes` flag or configuration file to set the nodes to run the command against")
 		}
 
+		nodes, err := helpers.ResolveNodes(ctx, c, Nodes)
+		if err != nil {
+			return err
+		}
+
+		Nodes = nodes
+
 		ctx = client.WithNodes(ctx, Nodes...)
 
 		return action(ctx, c)
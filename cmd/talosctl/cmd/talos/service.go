@@ -14,10 +14,16 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
 	"github.com/talos-systems/talos/pkg/cli"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
+var serviceCmdFlags struct {
+	aggregate bool
+}
+
 // serviceCmd represents the service command.
 var serviceCmd = &cobra.Command{
 	Use:     "service [<id> [start|stop|restart|status]]",
@@ -69,14 +75,20 @@ func serviceList(ctx context.Context, c *client.Client) error {
 			return fmt.Errorf("error listing services: %w", err)
 		}
 
-		cli.Warning("%s", err)
+		if !serviceCmdFlags.aggregate {
+			cli.Warning("%s", err)
+		}
+	}
+
+	defaultNode := client.AddrFromPeer(&remotePeer)
+
+	if serviceCmdFlags.aggregate {
+		return serviceListAggregate(resp, err, defaultNode)
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 	fmt.Fprintln(w, "NODE\tSERVICE\tSTATE\tHEALTH\tLAST CHANGE\tLAST EVENT")
 
-	defaultNode := client.AddrFromPeer(&remotePeer)
-
 	for _, msg := range resp.Messages {
 		for _, s := range msg.Services {
 			svc := cli.ServiceInfoWrapper{ServiceInfo: s}
@@ -94,6 +106,34 @@ func serviceList(ctx context.Context, c *client.Client) error {
 	return w.Flush()
 }
 
+func serviceListAggregate(resp *machineapi.ServiceListResponse, respErr error, defaultNode string) error {
+	table := helpers.NewNodeTable("SERVICE", Nodes...)
+
+	if err := table.AddErrors(respErr); err != nil {
+		cli.Warning("%s", err)
+	}
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		for _, s := range msg.Services {
+			svc := cli.ServiceInfoWrapper{ServiceInfo: s}
+
+			table.Add(svc.Id, node, fmt.Sprintf("%s/%s", svc.State, svc.HealthStatus()))
+		}
+	}
+
+	if err := table.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	return table.Err()
+}
+
 func serviceInfo(ctx context.Context, c *client.Client, id string) error {
 	var remotePeer peer.Peer
 
@@ -206,5 +246,6 @@ func serviceRestart(ctx context.Context, c *client.Client, id string) error {
 }
 
 func init() {
+	serviceCmd.Flags().BoolVar(&serviceCmdFlags.aggregate, "aggregate", false, "list services as a table with a column per node")
 	addCommand(serviceCmd)
 }
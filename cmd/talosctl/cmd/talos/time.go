@@ -15,22 +15,30 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
 	"github.com/talos-systems/talos/pkg/cli"
 	timeapi "github.com/talos-systems/talos/pkg/machinery/api/time"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
 var timeCmdFlags struct {
-	ntpServer string
+	ntpServer     string
+	aggregate     bool
+	skew          bool
+	skewThreshold time.Duration
 }
 
 // timeCmd represents the time command.
 var timeCmd = &cobra.Command{
-	Use:   "time [--check server]",
+	Use:   "time [--check server] [--skew]",
 	Short: "Gets current server time",
 	Long:  ``,
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if timeCmdFlags.skew && timeCmdFlags.aggregate {
+			return fmt.Errorf("--skew and --aggregate can't be used together")
+		}
+
 		return WithClient(func(ctx context.Context, c *client.Client) error {
 			var (
 				resp       *timeapi.TimeResponse
@@ -49,7 +57,17 @@ var timeCmd = &cobra.Command{
 					return fmt.Errorf("error fetching time: %w", err)
 				}
 
-				cli.Warning("%s", err)
+				if !timeCmdFlags.aggregate {
+					cli.Warning("%s", err)
+				}
+			}
+
+			if timeCmdFlags.aggregate {
+				return timeAggregate(resp, err, client.AddrFromPeer(&remotePeer))
+			}
+
+			if timeCmdFlags.skew {
+				return timeSkew(resp, client.AddrFromPeer(&remotePeer))
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
@@ -84,7 +102,97 @@ var timeCmd = &cobra.Command{
 	},
 }
 
+func timeAggregate(resp *timeapi.TimeResponse, respErr error, defaultNode string) error {
+	table := helpers.NewNodeTable("", Nodes...)
+
+	if err := table.AddErrors(respErr); err != nil {
+		cli.Warning("%s", err)
+	}
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		if !msg.Localtime.IsValid() {
+			return fmt.Errorf("error parsing local time")
+		}
+
+		if !msg.Remotetime.IsValid() {
+			return fmt.Errorf("error parsing remote time")
+		}
+
+		localtime := msg.Localtime.AsTime()
+		remotetime := msg.Remotetime.AsTime()
+
+		table.Add("NTP-SERVER", node, msg.Server)
+		table.Add("NODE-TIME", node, localtime.String())
+		table.Add("NTP-SERVER-TIME", node, remotetime.String())
+		table.Add("OFFSET", node, localtime.Sub(remotetime).String())
+	}
+
+	if err := table.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	return table.Err()
+}
+
+func timeSkew(resp *timeapi.TimeResponse, defaultNode string) error {
+	offsets := make([]helpers.ClockOffset, 0, len(resp.Messages))
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		offset := helpers.ClockOffset{
+			Node:   node,
+			Server: msg.Server,
+		}
+
+		switch {
+		case msg.Offset.IsValid():
+			offset.Offset = msg.Offset.AsDuration()
+		case msg.Localtime.IsValid() && msg.Remotetime.IsValid():
+			// older nodes don't report the offset corrected for the round-trip delay
+			offset.Offset = msg.Localtime.AsTime().Sub(msg.Remotetime.AsTime())
+		default:
+			return fmt.Errorf("error parsing time of node %q", node)
+		}
+
+		offsets = append(offsets, offset)
+	}
+
+	skews := helpers.ComputeClockSkew(offsets, timeCmdFlags.skewThreshold)
+
+	if err := helpers.WriteClockSkew(os.Stdout, skews); err != nil {
+		return err
+	}
+
+	var exceeding int
+
+	for _, skew := range skews {
+		if skew.Exceeds {
+			exceeding++
+		}
+	}
+
+	if exceeding > 0 {
+		return fmt.Errorf("%d of %d nodes exceed the clock skew threshold %s", exceeding, len(skews), timeCmdFlags.skewThreshold)
+	}
+
+	return nil
+}
+
 func init() {
 	timeCmd.Flags().StringVarP(&timeCmdFlags.ntpServer, "check", "c", "", "checks server time against specified ntp server")
+	timeCmd.Flags().BoolVar(&timeCmdFlags.aggregate, "aggregate", false, "print time as a table with a column per node")
+	timeCmd.Flags().BoolVar(&timeCmdFlags.skew, "skew", false, "print the clock offset of each node to the NTP server and the skew between the nodes")
+	timeCmd.Flags().DurationVar(&timeCmdFlags.skewThreshold, "skew-threshold", 500*time.Millisecond, "flag the nodes with the clock offset or skew above the threshold (with --skew)")
 	addCommand(timeCmd)
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -16,13 +17,16 @@ import (
 	"google.golang.org/grpc/peer"
 
 	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
 var (
-	upgradeImage string
-	preserve     bool
-	stage        bool
+	upgradeImage     string
+	preserve         bool
+	stage            bool
+	ignoreInhibitors bool
+	upgradeWait      bool
 )
 
 // upgradeCmd represents the processes command.
@@ -41,6 +45,8 @@ func init() {
 	upgradeCmd.Flags().BoolVarP(&preserve, "preserve", "p", false, "preserve data")
 	upgradeCmd.Flags().BoolVarP(&stage, "stage", "s", false, "stage the upgrade to perform it after a reboot")
 	upgradeCmd.Flags().BoolVarP(&force, "force", "f", false, "force the upgrade (skip checks on etcd health and members, might lead to data loss)")
+	upgradeCmd.Flags().BoolVar(&ignoreInhibitors, "ignore-inhibitors", false, "upgrade without waiting for the shutdown inhibitors set by the workloads on the node")
+	upgradeCmd.Flags().BoolVar(&upgradeWait, "wait", false, "wait for the upgrade to proceed, reporting the node drain progress until the node reboots")
 	addCommand(upgradeCmd)
 }
 
@@ -48,9 +54,17 @@ func upgrade() error {
 	return WithClient(func(ctx context.Context, c *client.Client) error {
 		var remotePeer peer.Peer
 
+		started := time.Now()
+
 		// TODO: See if we can validate version and prevent starting upgrades to
 		// an unknown version
-		resp, err := c.Upgrade(ctx, upgradeImage, preserve, stage, force, grpc.Peer(&remotePeer))
+		resp, err := c.UpgradeWithOptions(ctx, &machine.UpgradeRequest{
+			Image:            upgradeImage,
+			Preserve:         preserve,
+			Stage:            stage,
+			Force:            force,
+			IgnoreInhibitors: ignoreInhibitors,
+		}, grpc.Peer(&remotePeer))
 		if err != nil {
 			if resp == nil {
 				return fmt.Errorf("error performing upgrade: %s", err)
@@ -74,6 +88,89 @@ func upgrade() error {
 			fmt.Fprintf(w, "%s\t%s\t%s\t\n", node, msg.Ack, time.Now())
 		}
 
-		return w.Flush()
+		if err = w.Flush(); err != nil {
+			return err
+		}
+
+		if !upgradeWait {
+			return nil
+		}
+
+		return watchUpgrade(ctx, c, time.Since(started))
 	})
 }
+
+// watchUpgrade prints the upgrade progress until the upgrade sequence finishes or the node goes away for the reboot.
+//
+//nolint:gocyclo
+func watchUpgrade(ctx context.Context, c *client.Client, since time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	err := c.EventsWatch(ctx, func(ch <-chan client.Event) {
+		defer cancel()
+
+		for {
+			var (
+				event client.Event
+				ok    bool
+			)
+
+			select {
+			case event, ok = <-ch:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			switch msg := event.Payload.(type) {
+			case *machine.DrainEvent:
+				fmt.Printf("%s: drain %s\n", event.Node, drainProgress(msg))
+			case *machine.PhaseEvent:
+				if msg.GetAction() == machine.PhaseEvent_START {
+					fmt.Printf("%s: phase %s\n", event.Node, msg.GetPhase())
+				}
+			case *machine.SequenceEvent:
+				if msg.GetSequence() != "upgrade" && msg.GetSequence() != "stageUpgrade" {
+					continue
+				}
+
+				if msg.GetError() != nil {
+					fmt.Printf("%s: upgrade failed: %s\n", event.Node, msg.GetError().GetMessage())
+
+					return
+				}
+
+				if msg.GetAction() == machine.SequenceEvent_STOP {
+					return
+				}
+			}
+		}
+	}, client.WithTailDuration(since+time.Second))
+	if err != nil {
+		// the node is expected to go away while rebooting into the new version
+		cli.Warning("stopped watching the upgrade: %s", err)
+	}
+
+	return nil
+}
+
+// drainProgress formats the node drain progress reported by the drain event.
+func drainProgress(msg *machine.DrainEvent) string {
+	elapsed := time.Duration(msg.GetElapsedSeconds()) * time.Second
+	timeout := time.Duration(msg.GetTimeoutSeconds()) * time.Second
+
+	progress := fmt.Sprintf("%s: %d pods remaining (%s/%s)", msg.GetPhase(), len(msg.GetRemainingPods()), elapsed, timeout)
+
+	if blocked := msg.GetBlockedPods(); len(blocked) > 0 {
+		progress += fmt.Sprintf(", blocked by disruption budget: %s", strings.Join(blocked, ", "))
+	}
+
+	if msg.GetError() != "" {
+		progress += fmt.Sprintf(": %s", msg.GetError())
+	}
+
+	return progress
+}
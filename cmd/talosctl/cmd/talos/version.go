@@ -7,6 +7,7 @@ package talos
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -14,7 +15,9 @@ import (
 	"google.golang.org/grpc/peer"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
 	"github.com/talos-systems/talos/pkg/cli"
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 	"github.com/talos-systems/talos/pkg/version"
 )
@@ -24,6 +27,7 @@ var versionCmdFlags struct {
 	clientOnly   bool
 	shortVersion bool
 	json         bool
+	aggregate    bool
 }
 
 // versionCmd represents the `talosctl version` command.
@@ -57,11 +61,18 @@ var versionCmd = &cobra.Command{
 				if resp == nil {
 					return fmt.Errorf("error getting version: %s", err)
 				}
-				cli.Warning("%s", err)
+
+				if !versionCmdFlags.aggregate {
+					cli.Warning("%s", err)
+				}
 			}
 
 			defaultNode := client.AddrFromPeer(&remotePeer)
 
+			if versionCmdFlags.aggregate {
+				return versionAggregate(resp, err, defaultNode)
+			}
+
 			for _, msg := range resp.Messages {
 				node := defaultNode
 
@@ -96,9 +107,44 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+func versionAggregate(resp *machineapi.VersionResponse, respErr error, defaultNode string) error {
+	table := helpers.NewNodeTable("", Nodes...)
+
+	if err := table.AddErrors(respErr); err != nil {
+		cli.Warning("%s", err)
+	}
+
+	for _, msg := range resp.Messages {
+		node := defaultNode
+
+		if msg.Metadata != nil {
+			node = msg.Metadata.Hostname
+		}
+
+		var enabledFeatures []string
+		if msg.Features.GetRbac() {
+			enabledFeatures = append(enabledFeatures, "RBAC")
+		}
+
+		table.Add("Tag", node, msg.Version.GetTag())
+		table.Add("SHA", node, msg.Version.GetSha())
+		table.Add("Built", node, msg.Version.GetBuilt())
+		table.Add("Go version", node, msg.Version.GetGoVersion())
+		table.Add("OS/Arch", node, fmt.Sprintf("%s/%s", msg.Version.GetOs(), msg.Version.GetArch()))
+		table.Add("Enabled", node, strings.Join(enabledFeatures, ", "))
+	}
+
+	if err := table.Write(os.Stdout); err != nil {
+		return err
+	}
+
+	return table.Err()
+}
+
 func init() {
 	versionCmd.Flags().BoolVar(&versionCmdFlags.shortVersion, "short", false, "Print the short version")
 	versionCmd.Flags().BoolVar(&versionCmdFlags.clientOnly, "client", false, "Print client version only")
+	versionCmd.Flags().BoolVar(&versionCmdFlags.aggregate, "aggregate", false, "Print server versions as a table with a column per node")
 
 	// TODO remove when https://github.com/talos-systems/talos/issues/907 is implemented
 	versionCmd.Flags().BoolVar(&versionCmdFlags.json, "json", false, "")
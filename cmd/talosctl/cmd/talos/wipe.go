@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package talos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/talos-systems/talos/pkg/cli"
+	"github.com/talos-systems/talos/pkg/machinery/api/storage"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+var wipeDiskCmdFlags struct {
+	method string
+}
+
+// wipeCmd represents the wipe command.
+var wipeCmd = &cobra.Command{
+	Use:   "wipe",
+	Short: "Wipe disks",
+	Long:  ``,
+}
+
+var wipeDiskCmd = &cobra.Command{
+	Use:   "disk <device names>...",
+	Short: "Wipe disks which are not used by the system",
+	Long: `Wipe disks which are not used by the system, e.g. to repurpose the storage drives.
+
+Disks which are mounted, used as RAID members or held by other devices are refused,
+the system disk can't be wiped. Wipe methods:
+
+  - fast: discard the disk contents and zero out the partition tables
+  - zeroes: overwrite the whole disk with zeroes
+  - secure: secure erase the whole disk (requires the disk support)`,
+	Example: `  talosctl wipe disk sdb --method zeroes`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		method, ok := storage.BlockDeviceWipeRequest_Method_value[strings.ToUpper(wipeDiskCmdFlags.method)]
+		if !ok {
+			return fmt.Errorf("unsupported wipe method %q (expected fast, zeroes or secure)", wipeDiskCmdFlags.method)
+		}
+
+		return WithClient(func(ctx context.Context, c *client.Client) error {
+			resp, err := c.BlockDeviceWipe(ctx, &storage.BlockDeviceWipeRequest{
+				Devices: args,
+				Method:  storage.BlockDeviceWipeRequest_Method(method),
+			})
+			if err != nil {
+				if resp == nil {
+					return fmt.Errorf("error wiping disks: %w", err)
+				}
+
+				cli.Warning("%s", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "NODE\tWIPED DISKS")
+
+			for _, msg := range resp.GetMessages() {
+				fmt.Fprintf(w, "%s\t%s\n", msg.GetMetadata().GetHostname(), strings.Join(msg.GetDevices(), ","))
+			}
+
+			return w.Flush()
+		})
+	},
+}
+
+func init() {
+	wipeDiskCmd.Flags().StringVar(&wipeDiskCmdFlags.method, "method", "fast", "wipe method to use: fast, zeroes or secure")
+	wipeCmd.AddCommand(wipeDiskCmd)
+	addCommand(wipeCmd)
+}
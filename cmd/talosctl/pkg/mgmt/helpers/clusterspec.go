@@ -0,0 +1,209 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+)
+
+// ClusterSpec describes the heterogeneous cluster nodes (`talosctl cluster create --spec`).
+type ClusterSpec struct {
+	Nodes []NodeSpec `yaml:"nodes"`
+}
+
+// poolNameRegexp is the format of the worker pool name (used as part of the node names).
+var poolNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// NodeSpec describes a group of the nodes with the same resources and Talos version.
+//
+// Zero values are replaced with the defaults from the command line flags.
+type NodeSpec struct {
+	// Type is either controlplane or worker.
+	Type string `yaml:"type"`
+	// Pool is the worker pool name, nodes are named `<cluster>-<pool>-<n>`.
+	//
+	// Node groups with the same pool name belong to the same pool.
+	Pool string `yaml:"pool,omitempty"`
+	// ConfigPatches are the RFC6902 JSON patches applied to the machine config of the nodes in the group.
+	ConfigPatches []map[string]interface{} `yaml:"configPatches,omitempty"`
+	// Count of the nodes in the group, defaults to 1.
+	Count int `yaml:"count,omitempty"`
+	// CPUs is the share of CPUs (e.g. "1.5").
+	CPUs string `yaml:"cpus,omitempty"`
+	// Memory in MiB.
+	Memory int `yaml:"memory,omitempty"`
+	// Disks sizes in MiB, the first disk is the system disk (VM only).
+	Disks []int `yaml:"disks,omitempty"`
+	// ExtraNICs is the number of additional network interfaces not connected to the cluster network (QEMU only).
+	ExtraNICs int `yaml:"extraNICs,omitempty"`
+
+	// Image is the Talos node image (docker only).
+	Image string `yaml:"image,omitempty"`
+	// InstallImage is the Talos installer image.
+	InstallImage string `yaml:"installImage,omitempty"`
+	// VmlinuzPath and InitramfsPath are the kernel and initramfs boot assets (VM only).
+	VmlinuzPath   string `yaml:"vmlinuzPath,omitempty"`
+	InitramfsPath string `yaml:"initramfsPath,omitempty"`
+	// ISOPath is the ISO boot asset (VM only).
+	ISOPath string `yaml:"isoPath,omitempty"`
+}
+
+// MachineType returns the parsed node type.
+func (spec NodeSpec) MachineType() (machine.Type, error) {
+	switch spec.Type {
+	case machine.TypeControlPlane.String():
+		return machine.TypeControlPlane, nil
+	case machine.TypeWorker.String():
+		return machine.TypeWorker, nil
+	default:
+		return machine.TypeUnknown, fmt.Errorf("unsupported node type %q, expected %q or %q", spec.Type, machine.TypeControlPlane, machine.TypeWorker)
+	}
+}
+
+// ConfigPatch returns the decoded config patches of the node group.
+func (spec NodeSpec) ConfigPatch() (jsonpatch.Patch, error) {
+	if len(spec.ConfigPatches) == 0 {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(spec.ConfigPatches)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding config patches: %w", err)
+	}
+
+	patch, err := jsonpatch.DecodePatch(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding config patches: %w", err)
+	}
+
+	for i, op := range patch {
+		switch op.Kind() {
+		case "add", "remove", "replace", "move", "copy", "test":
+		default:
+			return nil, fmt.Errorf("config patch %d: unsupported op %q", i, op.Kind())
+		}
+
+		if _, err = op.Path(); err != nil {
+			return nil, fmt.Errorf("config patch %d: %w", i, err)
+		}
+	}
+
+	return patch, nil
+}
+
+// LoadClusterSpec loads and validates the cluster spec from the YAML file.
+func LoadClusterSpec(path string) (*ClusterSpec, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster spec: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(contents))
+	dec.KnownFields(true)
+
+	var spec ClusterSpec
+
+	if err = dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("error decoding cluster spec: %w", err)
+	}
+
+	if err = spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// Validate checks the cluster spec for errors.
+func (spec *ClusterSpec) Validate() error {
+	var (
+		errs    *multierror.Error
+		masters int
+	)
+
+	for i, node := range spec.Nodes {
+		typ, err := node.MachineType()
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("node group %d: %w", i, err))
+		}
+
+		if node.Count < 0 {
+			errs = multierror.Append(errs, fmt.Errorf("node group %d: count can't be negative", i))
+		}
+
+		if typ == machine.TypeControlPlane {
+			masters += node.count()
+		}
+
+		if node.Pool != "" {
+			if typ != machine.TypeWorker {
+				errs = multierror.Append(errs, fmt.Errorf("node group %d: pool can only be set for the worker nodes", i))
+			}
+
+			if !poolNameRegexp.MatchString(node.Pool) || node.Pool == "master" {
+				errs = multierror.Append(errs, fmt.Errorf("node group %d: invalid pool name %q", i, node.Pool))
+			}
+		}
+
+		if _, err = node.ConfigPatch(); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("node group %d: %w", i, err))
+		}
+
+		if node.Memory < 0 || node.ExtraNICs < 0 {
+			errs = multierror.Append(errs, fmt.Errorf("node group %d: memory and extra NICs can't be negative", i))
+		}
+
+		for _, size := range node.Disks {
+			if size <= 0 {
+				errs = multierror.Append(errs, fmt.Errorf("node group %d: disk size should be positive", i))
+			}
+		}
+
+		if (node.VmlinuzPath == "") != (node.InitramfsPath == "") {
+			errs = multierror.Append(errs, fmt.Errorf("node group %d: vmlinuzPath and initramfsPath should be set together", i))
+		}
+	}
+
+	if masters == 0 {
+		errs = multierror.Append(errs, fmt.Errorf("cluster spec should have at least one controlplane node"))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (spec NodeSpec) count() int {
+	if spec.Count == 0 {
+		return 1
+	}
+
+	return spec.Count
+}
+
+// Expand returns the spec for each control plane and worker node, in the order of the node groups.
+func (spec *ClusterSpec) Expand() (masters, workers []NodeSpec) {
+	for _, node := range spec.Nodes {
+		typ, _ := node.MachineType() //nolint:errcheck
+
+		for i := 0; i < node.count(); i++ {
+			if typ == machine.TypeControlPlane {
+				masters = append(masters, node)
+			} else {
+				workers = append(workers, node)
+			}
+		}
+	}
+
+	return masters, workers
+}
@@ -0,0 +1,118 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/mgmt/helpers"
+)
+
+func TestLoadClusterSpec(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "spec.yaml")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`nodes:
+  - type: controlplane
+    count: 3
+    cpus: "2"
+    memory: 2048
+  - type: worker
+    memory: 4096
+    disks: [6144, 10240]
+    extraNICs: 1
+  - type: worker
+    vmlinuzPath: _out/vmlinuz-v0.13.0
+    initramfsPath: _out/initramfs-v0.13.0.xz
+    installImage: ghcr.io/talos-systems/installer:v0.13.0
+`), 0o644))
+
+	spec, err := helpers.LoadClusterSpec(path)
+	require.NoError(t, err)
+
+	masters, workers := spec.Expand()
+	require.Len(t, masters, 3)
+	require.Len(t, workers, 2)
+
+	assert.Equal(t, "2", masters[2].CPUs)
+	assert.Equal(t, []int{6144, 10240}, workers[0].Disks)
+	assert.Equal(t, 1, workers[0].ExtraNICs)
+	assert.Equal(t, "ghcr.io/talos-systems/installer:v0.13.0", workers[1].InstallImage)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`nodes:
+  - type: init
+  - type: worker
+    disks: [0]
+    vmlinuzPath: _out/vmlinuz
+`), 0o644))
+
+	_, err = helpers.LoadClusterSpec(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "node group 0: unsupported node type \"init\"")
+	assert.Contains(t, err.Error(), "node group 1: disk size should be positive")
+	assert.Contains(t, err.Error(), "node group 1: vmlinuzPath and initramfsPath should be set together")
+	assert.Contains(t, err.Error(), "cluster spec should have at least one controlplane node")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`nodes:
+  - type: controlplane
+  - type: worker
+    pool: ingress
+    count: 2
+    configPatches:
+      - op: add
+        path: /machine/nodeLabels
+        value:
+          example.com/pool: ingress
+  - type: worker
+    pool: ingress
+`), 0o644))
+
+	spec, err = helpers.LoadClusterSpec(path)
+	require.NoError(t, err)
+
+	_, workers = spec.Expand()
+	require.Len(t, workers, 3)
+
+	assert.Equal(t, "ingress", workers[2].Pool)
+
+	patch, err := workers[0].ConfigPatch()
+	require.NoError(t, err)
+	require.Len(t, patch, 1)
+	assert.Equal(t, "add", patch[0].Kind())
+
+	patch, err = workers[2].ConfigPatch()
+	require.NoError(t, err)
+	assert.Nil(t, patch)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`nodes:
+  - type: controlplane
+    pool: cp
+  - type: worker
+    pool: GPU
+    configPatches:
+      - op: merge
+        path: /machine
+`), 0o644))
+
+	_, err = helpers.LoadClusterSpec(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "node group 0: pool can only be set for the worker nodes")
+	assert.Contains(t, err.Error(), "node group 1: invalid pool name \"GPU\"")
+	assert.Contains(t, err.Error(), "node group 1: config patch 0: unsupported op \"merge\"")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`nodes:
+  - type: controlplane
+    cpu: 2
+`), 0o644))
+
+	_, err = helpers.LoadClusterSpec(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// NodeTable aggregates the values reported by the nodes into the table with a column per node.
+//
+// Rows are rendered in the order they were first added, missing values are rendered as "-",
+// and failed nodes are marked as "ERROR".
+type NodeTable struct {
+	header string
+	nodes  []string
+	rows   []string
+	values map[string]map[string]string
+	errors map[string]error
+}
+
+// NewNodeTable creates a NodeTable with the first column header and the expected nodes.
+func NewNodeTable(header string, nodes ...string) *NodeTable {
+	table := &NodeTable{
+		header: header,
+		values: map[string]map[string]string{},
+		errors: map[string]error{},
+	}
+
+	for _, node := range nodes {
+		table.addNode(node)
+	}
+
+	return table
+}
+
+func (table *NodeTable) addNode(node string) {
+	for _, n := range table.nodes {
+		if n == node {
+			return
+		}
+	}
+
+	table.nodes = append(table.nodes, node)
+}
+
+// Add sets the value of the row for the node.
+func (table *NodeTable) Add(row, node, value string) {
+	table.addNode(node)
+
+	if _, ok := table.values[row]; !ok {
+		table.rows = append(table.rows, row)
+		table.values[row] = map[string]string{}
+	}
+
+	table.values[row][node] = value
+}
+
+// AddErrors records per-node errors returned by the client along with the partial response.
+//
+// Errors which can't be attributed to a node are returned back.
+func (table *NodeTable) AddErrors(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if multiErr, ok := err.(*multierror.Error); ok { //nolint:errorlint
+		errs = multiErr.Errors
+	} else {
+		errs = []error{err}
+	}
+
+	var result *multierror.Error
+
+	for _, e := range errs {
+		var nodeErr *client.NodeError
+
+		if !errors.As(e, &nodeErr) {
+			result = multierror.Append(result, e)
+
+			continue
+		}
+
+		table.addNode(nodeErr.Node)
+		table.errors[nodeErr.Node] = nodeErr.Err
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Write renders the table.
+func (table *NodeTable) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(append([]string{table.header}, table.nodes...), "\t"))
+
+	for _, row := range table.rows {
+		values := []string{row}
+
+		for _, node := range table.nodes {
+			value, ok := table.values[row][node]
+
+			switch {
+			case table.errors[node] != nil:
+				value = "ERROR"
+			case !ok:
+				value = "-"
+			}
+
+			values = append(values, value)
+		}
+
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// Err returns an error summarizing the failed nodes, if any.
+func (table *NodeTable) Err() error {
+	if len(table.errors) == 0 {
+		return nil
+	}
+
+	var result *multierror.Error
+
+	for _, node := range table.nodes {
+		if err, ok := table.errors[node]; ok {
+			result = multierror.Append(result, &client.NodeError{Node: node, Err: err})
+		}
+	}
+
+	result.ErrorFormat = func(errs []error) string {
+		lines := make([]string, 0, len(errs))
+
+		for _, err := range errs {
+			lines = append(lines, "\t"+err.Error())
+		}
+
+		return fmt.Sprintf("%d of %d nodes failed:\n%s", len(errs), len(table.nodes), strings.Join(lines, "\n"))
+	}
+
+	return result
+}
@@ -0,0 +1,48 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+func TestNodeTable(t *testing.T) {
+	table := helpers.NewNodeTable("SERVICE", "10.5.0.2", "10.5.0.3", "10.5.0.4")
+
+	// errors which are not attributed to a node are returned back
+	err := table.AddErrors(multierror.Append(nil,
+		&client.NodeError{Node: "10.5.0.4", Err: errors.New("connection refused")},
+		errors.New("connection closed"),
+	))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection closed")
+	assert.NotContains(t, err.Error(), "10.5.0.4")
+
+	table.Add("apid", "10.5.0.2", "Running/OK")
+	table.Add("apid", "10.5.0.3", "Running/OK")
+	table.Add("etcd", "10.5.0.2", "Running/OK")
+
+	var buf strings.Builder
+
+	require.NoError(t, table.Write(&buf))
+
+	assert.Equal(t, strings.Join([]string{
+		"SERVICE   10.5.0.2     10.5.0.3     10.5.0.4",
+		"apid      Running/OK   Running/OK   ERROR",
+		"etcd      Running/OK   -            ERROR",
+		"",
+	}, "\n"), buf.String())
+
+	assert.EqualError(t, table.Err(), "1 of 3 nodes failed:\n\t10.5.0.4: connection refused")
+}
@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// ClockOffset is the node clock offset relative to the NTP server (node time - server time).
+type ClockOffset struct {
+	Node   string
+	Server string
+	Offset time.Duration
+}
+
+// ClockSkew is the clock skew of the node against the NTP server and the other nodes.
+type ClockSkew struct {
+	ClockOffset
+
+	// MaxSkew is the largest skew to the other nodes, MaxSkewNode is the node it was observed with.
+	MaxSkew     time.Duration
+	MaxSkewNode string
+
+	Exceeds bool
+}
+
+// ComputeClockSkew computes the pairwise clock skew of the nodes from their offsets to the NTP servers.
+//
+// Node is flagged if its offset to the NTP server or the skew to any other node exceeds the threshold.
+// Skew between the nodes synced to the different NTP servers includes the difference between the servers.
+func ComputeClockSkew(offsets []ClockOffset, threshold time.Duration) []ClockSkew {
+	result := make([]ClockSkew, len(offsets))
+
+	for i, offset := range offsets {
+		result[i].ClockOffset = offset
+
+		for j, other := range offsets {
+			if i == j {
+				continue
+			}
+
+			if skew := absDuration(offset.Offset - other.Offset); skew > result[i].MaxSkew || result[i].MaxSkewNode == "" {
+				result[i].MaxSkew = skew
+				result[i].MaxSkewNode = other.Node
+			}
+		}
+
+		result[i].Exceeds = absDuration(offset.Offset) > threshold || result[i].MaxSkew > threshold
+	}
+
+	return result
+}
+
+// WriteClockSkew renders the clock skew report.
+func WriteClockSkew(w io.Writer, skews []ClockSkew) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(tw, "NODE\tNTP-SERVER\tOFFSET\tMAX-SKEW\tMAX-SKEW-NODE\tSTATUS")
+
+	for _, skew := range skews {
+		status := "OK"
+		if skew.Exceeds {
+			status = "EXCEEDS THRESHOLD"
+		}
+
+		maxSkew, maxSkewNode := "-", "-"
+		if skew.MaxSkewNode != "" {
+			maxSkew, maxSkewNode = skew.MaxSkew.String(), skew.MaxSkewNode
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", skew.Node, skew.Server, formatOffset(skew.Offset), maxSkew, maxSkewNode, status)
+	}
+
+	return tw.Flush()
+}
+
+func formatOffset(d time.Duration) string {
+	if d > 0 {
+		return "+" + d.String()
+	}
+
+	return d.String()
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+)
+
+func TestComputeClockSkew(t *testing.T) {
+	skews := helpers.ComputeClockSkew([]helpers.ClockOffset{
+		{Node: "10.5.0.2", Server: "pool.ntp.org", Offset: 2 * time.Millisecond},
+		{Node: "10.5.0.3", Server: "pool.ntp.org", Offset: -3 * time.Millisecond},
+		{Node: "10.5.0.4", Server: "pool.ntp.org", Offset: 400 * time.Millisecond},
+	}, 500*time.Millisecond)
+
+	require.Len(t, skews, 3)
+
+	assert.Equal(t, 398*time.Millisecond, skews[0].MaxSkew)
+	assert.Equal(t, "10.5.0.4", skews[0].MaxSkewNode)
+	assert.False(t, skews[0].Exceeds)
+
+	assert.Equal(t, 403*time.Millisecond, skews[1].MaxSkew)
+	assert.False(t, skews[1].Exceeds)
+
+	assert.Equal(t, 403*time.Millisecond, skews[2].MaxSkew)
+	assert.Equal(t, "10.5.0.3", skews[2].MaxSkewNode)
+	assert.False(t, skews[2].Exceeds)
+
+	// skew between the nodes exceeds the threshold even though each offset is within it
+	skews = helpers.ComputeClockSkew([]helpers.ClockOffset{
+		{Node: "10.5.0.2", Server: "pool.ntp.org", Offset: 300 * time.Millisecond},
+		{Node: "10.5.0.3", Server: "pool.ntp.org", Offset: -300 * time.Millisecond},
+	}, 500*time.Millisecond)
+
+	assert.True(t, skews[0].Exceeds)
+	assert.True(t, skews[1].Exceeds)
+
+	// single node is checked against the NTP server only
+	skews = helpers.ComputeClockSkew([]helpers.ClockOffset{
+		{Node: "10.5.0.2", Server: "pool.ntp.org", Offset: -time.Second},
+	}, 500*time.Millisecond)
+
+	assert.True(t, skews[0].Exceeds)
+	assert.Empty(t, skews[0].MaxSkewNode)
+
+	var buf strings.Builder
+
+	require.NoError(t, helpers.WriteClockSkew(&buf, skews))
+
+	assert.Equal(t, strings.Join([]string{
+		"NODE       NTP-SERVER     OFFSET   MAX-SKEW   MAX-SKEW-NODE   STATUS",
+		"10.5.0.2   pool.ntp.org   -1s      -          -               EXCEEDS THRESHOLD",
+		"",
+	}, "\n"), buf.String())
+}
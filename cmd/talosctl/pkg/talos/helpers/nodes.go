@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/resources/cluster"
+)
+
+// AllNodes is the special node name which targets all the cluster members.
+const AllNodes = "@all"
+
+// ResolveNodes replaces AllNodes with the addresses of the cluster members.
+//
+// Cluster members are discovered by the node the client is connected to (endpoint).
+func ResolveNodes(ctx context.Context, c *client.Client, nodes []string) ([]string, error) {
+	found := false
+
+	for _, node := range nodes {
+		if node == AllNodes {
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return nodes, nil
+	}
+
+	listClient, err := c.Resources.List(ctx, cluster.NamespaceName, cluster.MemberType)
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster members: %w", err)
+	}
+
+	var members []string
+
+	for {
+		msg, err := listClient.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, fmt.Errorf("error listing cluster members: %w", err)
+		}
+
+		if msg.Metadata.GetError() != "" {
+			return nil, fmt.Errorf("error listing cluster members: %s", msg.Metadata.GetError())
+		}
+
+		if msg.Resource == nil {
+			continue
+		}
+
+		if address := memberAddress(msg.Resource); address != "" {
+			members = append(members, address)
+		}
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no cluster members discovered to resolve %q: is cluster discovery enabled?", AllNodes)
+	}
+
+	return ExpandNodes(nodes, members), nil
+}
+
+// ExpandNodes replaces AllNodes with the list of members removing the duplicates.
+func ExpandNodes(nodes, members []string) []string {
+	result := make([]string, 0, len(nodes)+len(members))
+	seen := map[string]struct{}{}
+
+	add := func(node string) {
+		if _, ok := seen[node]; ok {
+			return
+		}
+
+		seen[node] = struct{}{}
+
+		result = append(result, node)
+	}
+
+	for _, node := range nodes {
+		if node != AllNodes {
+			add(node)
+
+			continue
+		}
+
+		for _, member := range members {
+			add(member)
+		}
+	}
+
+	return result
+}
+
+// memberAddress returns the first address of the cluster member.
+func memberAddress(r resource.Resource) string {
+	anyResource, ok := r.(*resource.Any)
+	if !ok {
+		return ""
+	}
+
+	spec, ok := anyResource.Value().(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	addresses, ok := spec["addresses"].([]interface{})
+	if !ok || len(addresses) == 0 {
+		return ""
+	}
+
+	address, _ := addresses[0].(string) //nolint:errcheck
+
+	return address
+}
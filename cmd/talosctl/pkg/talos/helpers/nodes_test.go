@@ -0,0 +1,21 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package helpers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/cmd/talosctl/pkg/talos/helpers"
+)
+
+func TestExpandNodes(t *testing.T) {
+	members := []string{"172.20.0.2", "172.20.0.3", "172.20.0.4"}
+
+	assert.Equal(t, members, helpers.ExpandNodes([]string{helpers.AllNodes}, members))
+	assert.Equal(t, []string{"172.20.0.3", "172.20.0.2", "172.20.0.4"}, helpers.ExpandNodes([]string{"172.20.0.3", helpers.AllNodes}, members))
+	assert.Equal(t, []string{"10.5.0.2", "172.20.0.2", "172.20.0.3", "172.20.0.4"}, helpers.ExpandNodes([]string{"10.5.0.2", helpers.AllNodes, helpers.AllNodes}, members))
+}
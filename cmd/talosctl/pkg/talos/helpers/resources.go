@@ -12,12 +12,19 @@ import (
 
 	"google.golang.org/grpc/codes"
 
+	resourceapi "github.com/talos-systems/talos/pkg/machinery/api/resource"
 	"github.com/talos-systems/talos/pkg/machinery/client"
 )
 
 // ForEachResource get resources from the controller runtime and run callback using each element.
-//nolint:gocyclo
 func ForEachResource(ctx context.Context, c *client.Client, callback func(ctx context.Context, msg client.ResourceResponse) error, namespace string, args ...string) error {
+	return ForEachResourceFields(ctx, c, callback, namespace, nil, args...)
+}
+
+// ForEachResourceFields is like ForEachResource, but fetches only the listed resource spec fields.
+//
+//nolint:gocyclo
+func ForEachResourceFields(ctx context.Context, c *client.Client, callback func(ctx context.Context, msg client.ResourceResponse) error, namespace string, fields []string, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("not enough arguments: at least 1 is expected")
 	}
@@ -31,7 +38,12 @@ func ForEachResource(ctx context.Context, c *client.Client, callback func(ctx co
 	}
 
 	if resourceID != "" {
-		resp, err := c.Resources.Get(ctx, namespace, resourceType, resourceID)
+		resp, err := c.Resources.GetRequest(ctx, &resourceapi.GetRequest{
+			Namespace: namespace,
+			Type:      resourceType,
+			Id:        resourceID,
+			Fields:    fields,
+		})
 		if err != nil {
 			return err
 		}
@@ -42,7 +54,11 @@ func ForEachResource(ctx context.Context, c *client.Client, callback func(ctx co
 			}
 		}
 	} else {
-		listClient, err := c.Resources.List(ctx, namespace, resourceType)
+		listClient, err := c.Resources.ListRequest(ctx, &resourceapi.ListRequest{
+			Namespace: namespace,
+			Type:      resourceType,
+			Fields:    fields,
+		})
 		if err != nil {
 			return err
 		}
@@ -9,6 +9,7 @@ import (
 	"flag"
 	"log"
 	"regexp"
+	"strings"
 
 	"github.com/cosi-project/runtime/api/v1alpha1"
 	"github.com/cosi-project/runtime/pkg/state"
@@ -19,6 +20,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/talos-systems/talos/internal/app/apid/pkg/addressfilter"
 	apidbackend "github.com/talos-systems/talos/internal/app/apid/pkg/backend"
 	"github.com/talos-systems/talos/internal/app/apid/pkg/director"
 	"github.com/talos-systems/talos/internal/app/apid/pkg/provider"
@@ -29,7 +31,12 @@ import (
 	"github.com/talos-systems/talos/pkg/startup"
 )
 
-var rbacEnabled *bool
+var (
+	rbacEnabled             *bool
+	tlsMinVersion           *string
+	tlsCipherSuites         *string
+	advertisedAddressesOnly *bool
+)
 
 func runDebugServer(ctx context.Context) {
 	const debugAddr = ":9981"
@@ -48,6 +55,9 @@ func Main() {
 	log.SetFlags(log.Lshortfile | log.Ldate | log.Lmicroseconds | log.Ltime)
 
 	rbacEnabled = flag.Bool("enable-rbac", false, "enable RBAC for Talos API")
+	tlsMinVersion = flag.String("tls-min-version", "1.2", "minimum TLS version accepted by the Talos API")
+	tlsCipherSuites = flag.String("tls-cipher-suites", "", "comma-separated list of TLS 1.2 cipher suites accepted by the Talos API")
+	advertisedAddressesOnly = flag.Bool("advertised-addresses-only", false, "accept Talos API connections only on the advertised node addresses")
 
 	flag.Parse()
 
@@ -70,7 +80,21 @@ func Main() {
 		log.Fatalf("failed to create remote certificate provider: %+v", err)
 	}
 
-	serverTLSConfig, err := tlsConfig.ServerConfig()
+	minVersion, err := provider.ParseTLSVersion(*tlsMinVersion)
+	if err != nil {
+		log.Fatalf("failed to parse TLS min version: %v", err)
+	}
+
+	var cipherSuites []uint16
+
+	if *tlsCipherSuites != "" {
+		cipherSuites, err = provider.ParseCipherSuites(strings.Split(*tlsCipherSuites, ","))
+		if err != nil {
+			log.Fatalf("failed to parse TLS cipher suites: %v", err)
+		}
+	}
+
+	serverTLSConfig, err := tlsConfig.ServerConfig(minVersion, cipherSuites)
 	if err != nil {
 		log.Fatalf("failed to create OS-level TLS configuration: %v", err)
 	}
@@ -87,7 +111,9 @@ func Main() {
 
 	// all existing streaming methods
 	for _, methodName := range []string{
+		"/machine.MachineService/Console",
 		"/machine.MachineService/Copy",
+		"/machine.MachineService/Debug",
 		"/machine.MachineService/DiskUsage",
 		"/machine.MachineService/Dmesg",
 		"/machine.MachineService/EtcdSnapshot",
@@ -120,8 +146,7 @@ func Main() {
 			Logger: log.New(log.Writer(), "apid/authz/injector/http ", log.Flags()).Printf,
 		}
 
-		return factory.ListenAndServe(
-			router,
+		opts := []factory.Option{
 			factory.Port(constants.ApidPort),
 			factory.WithDefaultLog(),
 			factory.ServerOptions(
@@ -137,7 +162,22 @@ func Main() {
 			),
 			factory.WithUnaryInterceptor(injector.UnaryInterceptor()),
 			factory.WithStreamInterceptor(injector.StreamInterceptor()),
-		)
+		}
+
+		listener, err := factory.NewListener(opts...)
+		if err != nil {
+			return err
+		}
+
+		if *advertisedAddressesOnly {
+			// accept connections on the same node addresses which are advertised by etcd, kubelet and discovery
+			listener, err = addressfilter.NewListener(context.Background(), listener, resources)
+			if err != nil {
+				return err
+			}
+		}
+
+		return factory.NewServer(router, opts...).Serve(listener)
 	})
 
 	errGroup.Go(func() error {
@@ -0,0 +1,142 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package addressfilter restricts node addresses apid accepts connections on to the advertised node addresses.
+package addressfilter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"inet.af/netaddr"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+// Listener rejects connections to the local addresses which are not advertised.
+//
+// Node addresses are advertised according to .machine.network.advertisedSubnets and .machine.network.ignoredSubnets,
+// so that apid accepts connections on the same addresses which are published for etcd, kubelet and discovery.
+// Connections to the loopback addresses are always accepted, and connections to the link-local and SideroLink addresses
+// (which are never node addresses) are accepted once the advertised addresses are known.
+// Any other connection is rejected until the advertised addresses are known.
+type Listener struct {
+	net.Listener
+
+	mu         sync.Mutex
+	advertised map[netaddr.IP]struct{}
+}
+
+// NewListener wraps the listener and starts watching node addresses.
+func NewListener(ctx context.Context, l net.Listener, resources state.State) (*Listener, error) {
+	listener := &Listener{
+		Listener: l,
+	}
+
+	watchCh := make(chan state.Event)
+
+	if err := resources.Watch(ctx, resource.NewMetadata(network.NamespaceName, network.NodeAddressType,
+		network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s), resource.VersionUndefined), watchCh); err != nil {
+		return nil, fmt.Errorf("error setting up watch: %w", err)
+	}
+
+	go func() {
+		for {
+			var event state.Event
+
+			select {
+			case <-ctx.Done():
+				return
+			case event = <-watchCh:
+			}
+
+			listener.update(event)
+		}
+	}()
+
+	return listener, nil
+}
+
+func (listener *Listener) update(event state.Event) {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	if event.Type == state.Destroyed {
+		// the watch reports the missing resource as destroyed, so the addresses are still unknown,
+		// while the destroyed addresses which were known leave only the always accepted addresses
+		if listener.advertised != nil {
+			listener.advertised = map[netaddr.IP]struct{}{}
+		}
+
+		return
+	}
+
+	nodeAddress, ok := event.Resource.(*network.NodeAddress)
+	if !ok {
+		return
+	}
+
+	listener.advertised = make(map[netaddr.IP]struct{}, len(nodeAddress.TypedSpec().Addresses))
+
+	for _, ip := range nodeAddress.TypedSpec().IPs() {
+		listener.advertised[ip] = struct{}{}
+	}
+}
+
+// Allowed checks whether connections to the local address are accepted.
+func (listener *Listener) Allowed(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return true
+	}
+
+	ip, ok := netaddr.FromStdIP(tcpAddr.IP)
+	if !ok {
+		return false
+	}
+
+	if ip.IsLoopback() {
+		return true
+	}
+
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	if listener.advertised == nil {
+		// advertised addresses are not known yet
+		return false
+	}
+
+	if ip.IsLinkLocalUnicast() || network.IsULA(ip, network.ULASideroLink) {
+		return true
+	}
+
+	_, advertised := listener.advertised[ip]
+
+	return advertised
+}
+
+// Accept implements net.Listener.
+func (listener *Listener) Accept() (net.Conn, error) {
+	for {
+		conn, err := listener.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		if listener.Allowed(conn.LocalAddr()) {
+			return conn, nil
+		}
+
+		log.Printf("rejected connection from %s to the node address %s which is not advertised", conn.RemoteAddr(), conn.LocalAddr())
+
+		conn.Close() //nolint:errcheck
+	}
+}
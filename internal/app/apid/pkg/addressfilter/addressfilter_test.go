@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package addressfilter_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/go-retry/retry"
+	"inet.af/netaddr"
+
+	"github.com/talos-systems/talos/internal/app/apid/pkg/addressfilter"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+func TestListener(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(ctxCancel)
+
+	resources := state.WrapCore(namespaced.NewState(inmem.Build))
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener, err := addressfilter.NewListener(ctx, l, resources)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { listener.Close() }) //nolint:errcheck
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			conn.Write([]byte("ok")) //nolint:errcheck
+			conn.Close()             //nolint:errcheck
+		}
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+
+	defer conn.Close() //nolint:errcheck
+
+	// loopback connections are accepted before the advertised addresses are known
+	b, err := io.ReadAll(conn)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ok", string(b))
+}
+
+func TestListenerAllowed(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(ctxCancel)
+
+	resources := state.WrapCore(namespaced.NewState(inmem.Build))
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener, err := addressfilter.NewListener(ctx, l, resources)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { listener.Close() }) //nolint:errcheck
+
+	allowed := func(ip string) bool {
+		return listener.Allowed(&net.TCPAddr{IP: net.ParseIP(ip), Port: 50000})
+	}
+
+	// node addresses are known before the advertised addresses
+	current := network.NewNodeAddress(network.NamespaceName, network.NodeAddressCurrentID)
+	current.TypedSpec().Addresses = []netaddr.IPPrefix{netaddr.MustParseIPPrefix("10.0.0.2/24"), netaddr.MustParseIPPrefix("192.168.0.2/24")}
+	require.NoError(t, resources.Create(ctx, current))
+
+	// only loopback connections are accepted until the advertised addresses are known
+	time.Sleep(100 * time.Millisecond)
+
+	assert.True(t, allowed("127.0.0.1"))
+	assert.True(t, allowed("::1"))
+	assert.False(t, allowed("10.0.0.2"))
+	assert.False(t, allowed("192.168.0.2"))
+	assert.False(t, allowed("10.0.0.100"))
+	assert.False(t, allowed("fe80::1"))
+
+	advertised := network.NewNodeAddress(network.NamespaceName, network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s))
+	advertised.TypedSpec().Addresses = []netaddr.IPPrefix{netaddr.MustParseIPPrefix("10.0.0.2/24")}
+	require.NoError(t, resources.Create(ctx, advertised))
+
+	require.NoError(t, retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		if !allowed("10.0.0.2") {
+			return retry.ExpectedErrorf("advertised address is rejected")
+		}
+
+		return nil
+	}))
+
+	assert.True(t, allowed("127.0.0.1"))
+	assert.True(t, allowed("::ffff:10.0.0.2"))
+	assert.True(t, allowed("fe80::1"))
+	assert.True(t, allowed(network.ULAPrefix("8a6a7e38", network.ULASideroLink).IP().Next().String()))
+
+	// node address which is not advertised
+	assert.False(t, allowed("192.168.0.2"))
+	// local address which is not a node address (yet)
+	assert.False(t, allowed("10.0.0.100"))
+
+	require.NoError(t, resources.Destroy(ctx, advertised.Metadata()))
+
+	require.NoError(t, retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		if allowed("10.0.0.2") {
+			return retry.ExpectedErrorf("address is still accepted")
+		}
+
+		return nil
+	}))
+
+	assert.True(t, allowed("127.0.0.1"))
+}
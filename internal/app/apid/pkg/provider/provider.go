@@ -8,6 +8,7 @@ package provider
 import (
 	"context"
 	stdlibtls "crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"sync"
@@ -70,31 +71,97 @@ func NewTLSConfig(resources state.State) (*TLSConfig, error) {
 	}, nil
 }
 
-// ServerConfig generates server-side tls.Config.
-func (tlsConfig *TLSConfig) ServerConfig() (*stdlibtls.Config, error) {
-	ca, err := tlsConfig.certificateProvider.GetCA()
+// ParseTLSVersion parses TLS version in the `1.x` format.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.2":
+		return stdlibtls.VersionTLS12, nil
+	case "1.3":
+		return stdlibtls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q", version)
+	}
+}
+
+// ParseCipherSuites parses TLS cipher suite names.
+//
+// Only secure cipher suites (as reported by tls.CipherSuites) are accepted.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	supported := map[string]uint16{}
+
+	for _, suite := range stdlibtls.CipherSuites() {
+		supported[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+
+	for _, name := range names {
+		id, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS cipher suite %q", name)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ServerConfig generates server-side tls.Config accepting TLS versions starting with minVersion.
+//
+// If cipherSuites are empty, default cipher suites are used.
+// Client certificates are verified against the current CA and accepted CAs on each handshake,
+// so the CA changes are picked up without restarting the listener.
+func (tlsConfig *TLSConfig) ServerConfig(minVersion uint16, cipherSuites []uint16) (*stdlibtls.Config, error) {
+	caPool, err := tlsConfig.certificateProvider.GetCAPool()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get root CA: %w", err)
+		return nil, fmt.Errorf("failed to get CA pool: %w", err)
 	}
 
 	return tls.New(
 		tls.WithClientAuthType(tls.Mutual),
-		tls.WithCACertPEM(ca),
 		tls.WithServerCertificateProvider(tlsConfig.certificateProvider),
+		func(cfg *stdlibtls.Config) error {
+			cfg.MinVersion = minVersion
+			cfg.ClientCAs = caPool
+
+			if len(cipherSuites) > 0 {
+				cfg.CipherSuites = cipherSuites
+			}
+
+			cfg.GetConfigForClient = func(*stdlibtls.ClientHelloInfo) (*stdlibtls.Config, error) {
+				pool, err := tlsConfig.certificateProvider.GetCAPool()
+				if err != nil {
+					return nil, err
+				}
+
+				clientCfg := cfg.Clone()
+				clientCfg.GetConfigForClient = nil
+				clientCfg.ClientCAs = pool
+
+				return clientCfg, nil
+			}
+
+			return nil
+		},
 	)
 }
 
 // ClientConfig generates client-side tls.Config.
 func (tlsConfig *TLSConfig) ClientConfig() (*stdlibtls.Config, error) {
-	ca, err := tlsConfig.certificateProvider.GetCA()
+	caPool, err := tlsConfig.certificateProvider.GetCAPool()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get root CA: %w", err)
+		return nil, fmt.Errorf("failed to get CA pool: %w", err)
 	}
 
 	return tls.New(
 		tls.WithClientAuthType(tls.Mutual),
-		tls.WithCACertPEM(ca),
 		tls.WithClientCertificateProvider(tlsConfig.certificateProvider),
+		func(cfg *stdlibtls.Config) error {
+			cfg.RootCAs = caPool
+
+			return nil
+		},
 	)
 }
 
@@ -102,6 +169,7 @@ type certificateProvider struct {
 	mu sync.Mutex
 
 	apiCerts               *secrets.API
+	caPool                 *x509.CertPool
 	clientCert, serverCert *stdlibtls.Certificate
 }
 
@@ -125,6 +193,20 @@ func (p *certificateProvider) Update(apiCerts *secrets.API) error {
 
 	p.clientCert = &clientCert
 
+	caPool := x509.NewCertPool()
+
+	if ok := caPool.AppendCertsFromPEM(p.apiCerts.TypedSpec().CA.Crt); !ok {
+		return fmt.Errorf("failed to parse CA certificate")
+	}
+
+	for _, ca := range p.apiCerts.TypedSpec().AcceptedCAs {
+		if ok := caPool.AppendCertsFromPEM(ca); !ok {
+			return fmt.Errorf("failed to parse accepted CA certificate")
+		}
+	}
+
+	p.caPool = caPool
+
 	return nil
 }
 
@@ -135,6 +217,14 @@ func (p *certificateProvider) GetCA() ([]byte, error) {
 	return p.apiCerts.TypedSpec().CA.Crt, nil
 }
 
+// GetCAPool returns the pool of CA certificates which are trusted: the CA itself and accepted CAs.
+func (p *certificateProvider) GetCAPool() (*x509.CertPool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.caPool, nil
+}
+
 func (p *certificateProvider) GetCertificate(h *stdlibtls.ClientHelloInfo) (*stdlibtls.Certificate, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -4,11 +4,27 @@
 
 package provider_test
 
-import "testing"
+import (
+	"crypto/tls"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/apid/pkg/provider"
+)
+
+func TestParseCipherSuites(t *testing.T) {
+	suites, err := provider.ParseCipherSuites([]string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256}, suites)
+
+	suites, err = provider.ParseCipherSuites(nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, suites)
+
+	_, err = provider.ParseCipherSuites([]string{"TLS_RSA_WITH_RC4_128_SHA"})
+	assert.EqualError(t, err, `unsupported TLS cipher suite "TLS_RSA_WITH_RC4_128_SHA"`)
 }
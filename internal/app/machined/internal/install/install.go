@@ -101,6 +101,11 @@ func RunInstallerContainer(disk, platform, ref string, configBytes []byte, reg c
 		args = append(args, "--board="+*c)
 	}
 
+	// older installers don't support the flag, so it's passed only if set
+	if options.AssetsURL != "" {
+		args = append(args, "--assets-url="+options.AssetsURL)
+	}
+
 	for _, arg := range options.ExtraKernelArgs {
 		args = append(args, "--extra-kernel-arg", arg)
 	}
@@ -200,6 +205,18 @@ func OptionsFromUpgradeRequest(r runtime.Runtime, in *machineapi.UpgradeRequest)
 		WithPull(false),
 		WithUpgrade(true),
 		WithForce(!in.GetPreserve()),
-		WithExtraKernelArgs(r.Config().Machine().Install().ExtraKernelArgs()),
+		WithExtraKernelArgs(ExtraKernelArgs(r.Config().Machine())),
+		WithAssetsURL(r.Config().Machine().Install().AssetsURL()),
 	}
 }
+
+// ExtraKernelArgs builds the list of extra kernel arguments from the machine config, including the real-time profile arguments.
+func ExtraKernelArgs(machine config.MachineConfig) []string {
+	args := append([]string(nil), machine.Install().ExtraKernelArgs()...)
+
+	if rt := machine.Kernel().Realtime(); rt != nil {
+		args = append(args, rt.KernelArgs()...)
+	}
+
+	return args
+}
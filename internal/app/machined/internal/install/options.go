@@ -14,6 +14,7 @@ type Options struct {
 	Upgrade         bool
 	Zero            bool
 	ExtraKernelArgs []string
+	AssetsURL       string
 }
 
 // DefaultInstallOptions returns default options.
@@ -87,3 +88,12 @@ func WithExtraKernelArgs(s []string) Option {
 		return nil
 	}
 }
+
+// WithAssetsURL sets the base URL to download the boot assets and their deltas from.
+func WithAssetsURL(url string) Option {
+	return func(o *Options) error {
+		o.AssetsURL = url
+
+		return nil
+	}
+}
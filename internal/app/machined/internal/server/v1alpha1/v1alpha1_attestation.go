@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/pkg/tpm2"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// maxAttestationNonceSize is the maximum size of the quote qualifying data for SHA-256 keys.
+const maxAttestationNonceSize = 32
+
+// defaultAttestationPCRs are the firmware, bootloader and IMA PCRs.
+var defaultAttestationPCRs = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+// Attestation implements machine.MachineService.
+//
+// PCR values are read after the quote is generated, so they might include extra measurements (e.g. IMA),
+// the verifier should replay the event logs against the quoted PCR digest.
+func (s *Server) Attestation(ctx context.Context, in *machine.AttestationRequest) (*machine.AttestationResponse, error) {
+	if len(in.Nonce) > maxAttestationNonceSize {
+		return nil, status.Errorf(codes.InvalidArgument, "nonce should be at most %d bytes", maxAttestationNonceSize)
+	}
+
+	pcrs := defaultAttestationPCRs
+
+	if len(in.Pcrs) > 0 {
+		pcrs = make([]int, 0, len(in.Pcrs))
+
+		for _, pcr := range in.Pcrs {
+			if pcr > tpm2.MaxPCR {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid PCR index %d", pcr)
+			}
+
+			pcrs = append(pcrs, int(pcr))
+		}
+	}
+
+	tpm, closer, err := tpm2.Open()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, status.Error(codes.FailedPrecondition, "TPM 2.0 device is not available")
+		}
+
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer closer.Close()
+
+	handle, attestationKey, err := tpm.CreateAttestationKey()
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer tpm.Flush(handle)
+
+	quote, err := tpm.Quote(handle, in.Nonce, pcrs)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*machine.PCRValue, 0, len(pcrs))
+
+	for _, pcr := range pcrs {
+		var digest []byte
+
+		digest, err = tpm.ReadPCR(pcr)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, &machine.PCRValue{
+			Index:  uint32(pcr),
+			Digest: digest,
+		})
+	}
+
+	eventLog, err := readOptional(constants.TPMEventLogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	imaLog, err := readOptional(constants.IMAMeasurementsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine.AttestationResponse{
+		Messages: []*machine.Attestation{
+			{
+				Quote:          quote.Quoted,
+				Signature:      quote.Signature,
+				AttestationKey: attestationKey,
+				Pcrs:           values,
+				EventLog:       eventLog,
+				ImaLog:         imaLog,
+			},
+		},
+	}, nil
+}
+
+// readOptional reads the file contents, missing file is not an error.
+func readOptional(path string) ([]byte, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil && os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return contents, err
+}
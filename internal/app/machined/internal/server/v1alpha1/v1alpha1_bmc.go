@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/pkg/ipmi"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+var bmcBootDevices = map[machine.BMCControlRequest_BootDevice]ipmi.BootDevice{
+	machine.BMCControlRequest_PXE:   ipmi.BootDevicePXE,
+	machine.BMCControlRequest_DISK:  ipmi.BootDeviceDisk,
+	machine.BMCControlRequest_CDROM: ipmi.BootDeviceCDROM,
+	machine.BMCControlRequest_BIOS:  ipmi.BootDeviceBIOS,
+}
+
+var bmcPowerActions = map[machine.BMCControlRequest_PowerAction]ipmi.ChassisAction{
+	machine.BMCControlRequest_POWER_CYCLE: ipmi.ChassisPowerCycle,
+	machine.BMCControlRequest_HARD_RESET:  ipmi.ChassisHardReset,
+	machine.BMCControlRequest_POWER_OFF:   ipmi.ChassisPowerOff,
+}
+
+// BMCControl implements machine.MachineService.
+//
+// Boot device is set before the power action, so that the node boots from the new device right away.
+func (s *Server) BMCControl(ctx context.Context, in *machine.BMCControlRequest) (*machine.BMCControlResponse, error) {
+	if !s.Controller.Runtime().Config().Machine().BMC().AllowPowerControl() {
+		return nil, status.Error(codes.FailedPrecondition, "BMC power control is not allowed in the machine configuration")
+	}
+
+	bootDevice, setBootDevice := bmcBootDevices[in.BootDevice]
+	powerAction, runPowerAction := bmcPowerActions[in.PowerAction]
+
+	if !setBootDevice && !runPowerAction {
+		return nil, status.Error(codes.InvalidArgument, "either boot device or power action should be specified")
+	}
+
+	client, closer, err := ipmi.Open()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, status.Error(codes.FailedPrecondition, "IPMI device is not available")
+		}
+
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer closer.Close()
+
+	if setBootDevice {
+		log.Printf("setting BMC boot device to %s (persistent %v)", in.BootDevice, in.Persistent)
+
+		if err = client.SetBootDevice(bootDevice, in.Persistent); err != nil {
+			return nil, err
+		}
+	}
+
+	if runPowerAction {
+		log.Printf("running BMC power action %s", in.PowerAction)
+
+		if err = client.ChassisControl(powerAction); err != nil {
+			return nil, err
+		}
+	}
+
+	return &machine.BMCControlResponse{
+		Messages: []*machine.BMCControl{
+			{},
+		},
+	}, nil
+}
@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/capability"
+)
+
+// modeMethods lists the methods which are available only if the runtime mode supports the capability.
+var modeMethods = map[string]runtime.ModeCapability{
+	"/machine.MachineService/Reboot":   runtime.Reboot,
+	"/machine.MachineService/Rollback": runtime.Rollback,
+	"/machine.MachineService/Shutdown": runtime.Shutdown,
+	"/machine.MachineService/Upgrade":  runtime.Upgrade,
+}
+
+// Capabilities implements the machine.MachineServer interface.
+func (s *Server) Capabilities(ctx context.Context, in *emptypb.Empty) (*machine.CapabilitiesResponse, error) {
+	features := capability.All()
+
+	capabilities := &machine.Capabilities{
+		Methods:  capabilityMethods(s.server.GetServiceInfo(), s.Controller.Runtime().State().Platform().Mode()),
+		Features: make([]string, 0, len(features)),
+	}
+
+	for _, feature := range features {
+		capabilities.Features = append(capabilities.Features, string(feature))
+	}
+
+	return &machine.CapabilitiesResponse{
+		Messages: []*machine.Capabilities{capabilities},
+	}, nil
+}
+
+// capabilityMethods returns the sorted list of the registered methods supported in the runtime mode.
+func capabilityMethods(services map[string]grpc.ServiceInfo, mode runtime.Mode) []string {
+	var methods []string
+
+	for service, info := range services {
+		for _, method := range info.Methods {
+			fullName := "/" + service + "/" + method.Name
+
+			if modeCapability, ok := modeMethods[fullName]; ok && !mode.Supports(modeCapability) {
+				continue
+			}
+
+			methods = append(methods, fullName)
+		}
+	}
+
+	sort.Strings(methods)
+
+	return methods
+}
@@ -0,0 +1,43 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+)
+
+func TestCapabilityMethods(t *testing.T) {
+	services := map[string]grpc.ServiceInfo{
+		"machine.MachineService": {
+			Methods: []grpc.MethodInfo{
+				{Name: "Version"},
+				{Name: "Upgrade"},
+				{Name: "Reboot"},
+			},
+		},
+		"time.TimeService": {
+			Methods: []grpc.MethodInfo{
+				{Name: "Time"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"/machine.MachineService/Reboot",
+		"/machine.MachineService/Upgrade",
+		"/machine.MachineService/Version",
+		"/time.TimeService/Time",
+	}, capabilityMethods(services, runtime.ModeMetal))
+
+	assert.Equal(t, []string{
+		"/machine.MachineService/Version",
+		"/time.TimeService/Time",
+	}, capabilityMethods(services, runtime.ModeContainer))
+}
@@ -0,0 +1,233 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+const (
+	configRevertCheckInterval = 5 * time.Second
+	// configRevertFailureThreshold is the number of consecutive failed health checks which trigger the revert.
+	configRevertFailureThreshold = 3
+	apiReachabilityTimeout       = time.Second
+)
+
+// nodeHealth is the snapshot of the node health used to decide whether the applied config should be reverted.
+type nodeHealth struct {
+	apiReachable bool
+	// services maps service ID to whether the service is running and healthy.
+	services map[string]bool
+}
+
+// regressions returns the reasons the node is less healthy than the baseline.
+//
+// Only the checks which were passing before the config was applied are taken into account.
+func (health nodeHealth) regressions(baseline nodeHealth) []string {
+	var reasons []string
+
+	if baseline.apiReachable && !health.apiReachable {
+		reasons = append(reasons, "API is not reachable")
+	}
+
+	ids := make([]string, 0, len(baseline.services))
+
+	for id, healthy := range baseline.services {
+		if healthy && !health.services[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		reasons = append(reasons, fmt.Sprintf("service %q is not healthy", id))
+	}
+
+	return reasons
+}
+
+func (s *Server) nodeHealth(ctx context.Context) nodeHealth {
+	health := nodeHealth{
+		services: map[string]bool{},
+	}
+
+	for _, svc := range system.Services(s.Controller.Runtime()).List() {
+		info := svc.AsProto()
+
+		health.services[info.GetId()] = info.GetState() == "Running" && (info.GetHealth().GetUnknown() || info.GetHealth().GetHealthy())
+	}
+
+	health.apiReachable = s.apiReachable(ctx)
+
+	return health
+}
+
+// apiReachable checks that apid accepts connections on any of the node addresses.
+func (s *Server) apiReachable(ctx context.Context) bool {
+	res, err := s.Controller.Runtime().State().V1Alpha2().Resources().Get(ctx,
+		resource.NewMetadata(network.NamespaceName, network.NodeAddressType,
+			network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s), resource.VersionUndefined))
+	if err != nil {
+		return false
+	}
+
+	dialer := net.Dialer{
+		Timeout: apiReachabilityTimeout,
+	}
+
+	for _, addr := range res.(*network.NodeAddress).TypedSpec().Addresses {
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(addr.IP().String(), strconv.Itoa(constants.ApidPort)))
+		if err != nil {
+			continue
+		}
+
+		conn.Close() //nolint:errcheck
+
+		return true
+	}
+
+	return false
+}
+
+// startConfigRevert starts watching the node health after the config change, configMu should be held.
+func (s *Server) startConfigRevert(revert *runtime.ConfigRevert, baseline nodeHealth) {
+	s.configRevertCtx, s.configRevertCancel = context.WithDeadline(context.Background(), revert.Deadline)
+
+	log.Printf("config will be reverted if the node becomes unhealthy before %s", revert.Deadline.Format(time.RFC3339))
+
+	go s.watchConfigRevert(s.configRevertCtx, baseline, revert.Layer())
+}
+
+// cancelConfigRevert stops watching the node health, keeping the applied config, configMu should be held.
+func (s *Server) cancelConfigRevert() error {
+	if s.configRevertCancel != nil {
+		s.configRevertCancel()
+
+		s.configRevertCtx, s.configRevertCancel = nil, nil
+	}
+
+	return runtime.RemoveConfigRevert()
+}
+
+// finishConfigRevert stops watching the node health, configMu should be held.
+//
+// False is returned if the watch was superseded by another config change.
+func (s *Server) finishConfigRevert(ctx context.Context) bool {
+	if s.configRevertCtx != ctx {
+		return false
+	}
+
+	s.configRevertCancel()
+
+	s.configRevertCtx, s.configRevertCancel = nil, nil
+
+	return true
+}
+
+// confirmConfigRevert keeps the applied config once the node stayed healthy until the deadline.
+func (s *Server) confirmConfigRevert(ctx context.Context) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	if !s.finishConfigRevert(ctx) {
+		return
+	}
+
+	if err := runtime.RemoveConfigRevert(); err != nil {
+		log.Printf("failed to confirm the applied config: %s", err)
+
+		return
+	}
+
+	log.Printf("node stayed healthy, keeping the applied config")
+}
+
+// revertConfig reverts the API configuration layer to the previous one.
+func (s *Server) revertConfig(ctx context.Context, previous []byte, reason string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	if !s.finishConfigRevert(ctx) {
+		// superseded by another config change
+		return
+	}
+
+	// nil layer removes the API config, if there was none before
+	if err := s.applyConfigImmediate(context.Background(), runtime.ConfigLayers{runtime.ConfigSourceAPI: previous}); err != nil {
+		// the persisted revert is kept, so that the config is reverted on reboot
+		log.Printf("failed to revert the config: %s", err)
+
+		return
+	}
+
+	if err := runtime.RemoveConfigRevert(); err != nil {
+		log.Printf("failed to remove the config revert: %s", err)
+	}
+
+	log.Printf("config reverted: %s", reason)
+
+	s.Controller.Runtime().Events().Publish(&machine.ConfigRevertedEvent{
+		Reason: reason,
+	})
+}
+
+// watchConfigRevert reverts the API configuration layer to the previous one if the node stays unhealthy.
+func (s *Server) watchConfigRevert(ctx context.Context, baseline nodeHealth, previous []byte) {
+	ticker := time.NewTicker(configRevertCheckInterval)
+	defer ticker.Stop()
+
+	failures := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				s.confirmConfigRevert(ctx)
+			}
+
+			return
+		case <-ticker.C:
+		}
+
+		reasons := s.nodeHealth(ctx).regressions(baseline)
+		if len(reasons) == 0 {
+			failures = 0
+
+			continue
+		}
+
+		failures++
+
+		reason := strings.Join(reasons, ", ")
+
+		log.Printf("node is unhealthy after the config change (%d/%d): %s", failures, configRevertFailureThreshold, reason)
+
+		if failures < configRevertFailureThreshold {
+			continue
+		}
+
+		s.revertConfig(ctx, previous, reason)
+
+		return
+	}
+}
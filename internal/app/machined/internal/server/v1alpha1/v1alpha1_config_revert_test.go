@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime //nolint:testpackage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeHealthRegressions(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		baseline nodeHealth
+		health   nodeHealth
+		expected []string
+	}{
+		{
+			name: "healthy",
+			baseline: nodeHealth{
+				apiReachable: true,
+				services:     map[string]bool{"apid": true, "etcd": true},
+			},
+			health: nodeHealth{
+				apiReachable: true,
+				services:     map[string]bool{"apid": true, "etcd": true, "kubelet": false},
+			},
+		},
+		{
+			name: "unhealthy before",
+			baseline: nodeHealth{
+				services: map[string]bool{"etcd": false},
+			},
+			health: nodeHealth{
+				services: map[string]bool{},
+			},
+		},
+		{
+			name: "regressions",
+			baseline: nodeHealth{
+				apiReachable: true,
+				services:     map[string]bool{"apid": true, "etcd": true, "kubelet": true},
+			},
+			health: nodeHealth{
+				services: map[string]bool{"apid": true, "kubelet": false},
+			},
+			expected: []string{
+				"API is not reachable",
+				`service "etcd" is not healthy`,
+				`service "kubelet" is not healthy`,
+			},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.health.regressions(tt.baseline))
+		})
+	}
+}
+
+func TestFinishConfigRevert(t *testing.T) {
+	var s Server
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.configRevertCtx, s.configRevertCancel = ctx, cancel
+
+	superseded, supersededCancel := context.WithCancel(context.Background())
+	defer supersededCancel()
+
+	assert.False(t, s.finishConfigRevert(superseded))
+
+	assert.True(t, s.finishConfigRevert(ctx))
+	assert.Error(t, ctx.Err())
+
+	assert.False(t, s.finishConfigRevert(ctx))
+}
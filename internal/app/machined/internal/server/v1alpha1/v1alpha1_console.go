@@ -0,0 +1,166 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/go-kmsg"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/sessionrecording"
+	"github.com/talos-systems/talos/pkg/chunker/stream"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+// defaultConsoleLogLevel is the kernel default console log level.
+const defaultConsoleLogLevel = 7
+
+// Console implements machine.MachineService.
+//
+// Talos sends its own logs to the kernel log buffer, so the console output is reconstructed
+// from the kernel log filtered by the console log level, in the format used by the kernel console.
+// In container mode there is no kernel console, so machined log is sent instead.
+//
+//nolint:gocyclo
+func (s *Server) Console(req *machine.ConsoleRequest, srv machine.MachineService_ConsoleServer) (retErr error) {
+	ctx := srv.Context()
+
+	id := "console-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	recorder, err := s.startSessionRecording(ctx, "Console", id, map[string]interface{}{
+		"follow": req.Follow,
+		"tail":   req.Tail,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := endSessionRecording(recorder, retErr, nil); err != nil {
+			log.Printf("console session %q: error recording the session: %s", id, err)
+		}
+	}()
+
+	output := recorder.Writer(sessionrecording.StreamStdout)
+
+	if s.Controller.Runtime().State().Platform().Mode() == runtime.ModeContainer {
+		options := []runtime.LogOption{}
+
+		if req.Follow {
+			options = append(options, runtime.WithFollow())
+		}
+
+		if req.Tail {
+			options = append(options, runtime.WithTailLines(0))
+		}
+
+		logR, err := s.Controller.Runtime().Logging().ServiceLog("machined").Reader(options...)
+		if err != nil {
+			return err
+		}
+
+		//nolint:errcheck
+		defer logR.Close()
+
+		for data := range stream.NewChunker(ctx, logR).Read() {
+			if err = srv.Send(&common.Data{Bytes: data}); err != nil {
+				return err
+			}
+
+			output.Write(data) //nolint:errcheck
+		}
+
+		return nil
+	}
+
+	logLevel := consoleLogLevel()
+
+	var options []kmsg.Option
+
+	if req.Follow {
+		options = append(options, kmsg.Follow())
+	}
+
+	if req.Tail {
+		options = append(options, kmsg.FromTail())
+	}
+
+	reader, err := kmsg.NewReader(options...)
+	if err != nil {
+		return fmt.Errorf("error opening /dev/kmsg reader: %w", err)
+	}
+	defer reader.Close() //nolint:errcheck
+
+	ch := reader.Scan(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err = reader.Close(); err != nil {
+				return err
+			}
+		case packet, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if packet.Err != nil {
+				err = srv.Send(&common.Data{
+					Metadata: &common.Metadata{
+						Error: packet.Err.Error(),
+					},
+				})
+			} else {
+				if int(packet.Message.Priority) >= logLevel {
+					continue
+				}
+
+				line := []byte(formatConsoleMessage(packet.Message))
+
+				if err = srv.Send(&common.Data{Bytes: line}); err == nil {
+					output.Write(line) //nolint:errcheck
+				}
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// consoleLogLevel returns the current console log level: messages with lower priority value are printed to the console.
+func consoleLogLevel() int {
+	contents, err := ioutil.ReadFile("/proc/sys/kernel/printk")
+	if err != nil {
+		return defaultConsoleLogLevel
+	}
+
+	fields := strings.Fields(string(contents))
+	if len(fields) == 0 {
+		return defaultConsoleLogLevel
+	}
+
+	level, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return defaultConsoleLogLevel
+	}
+
+	return level
+}
+
+// formatConsoleMessage formats the kernel message the same way kernel prints it to the console.
+func formatConsoleMessage(msg kmsg.Message) string {
+	message := strings.TrimRight(msg.Message, "\n")
+
+	return fmt.Sprintf("[%5d.%06d] %s\n", msg.Clock/1000000, msg.Clock%1000000, message)
+}
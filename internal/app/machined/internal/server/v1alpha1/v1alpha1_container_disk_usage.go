@@ -0,0 +1,285 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	containerdapi "github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshots"
+	criconstants "github.com/containerd/cri/pkg/constants"
+	"github.com/opencontainers/image-spec/identity"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Labels set by the CRI on the pod containers.
+const (
+	criPodNamespaceLabel    = "io.kubernetes.pod.namespace"
+	criPodNameLabel         = "io.kubernetes.pod.name"
+	criContainerNameLabel   = "io.kubernetes.container.name"
+	criContainerKindLabel   = "io.cri-containerd.kind"
+	criContainerKindSandbox = "sandbox"
+)
+
+// snapshotUsage is the snapshot with the disk usage.
+type snapshotUsage struct {
+	info  snapshots.Info
+	usage snapshots.Usage
+}
+
+// imageLayers is the image with the snapshot layers (chain IDs) of the image.
+type imageLayers struct {
+	name        string
+	digest      string
+	contentSize int64
+	layers      []string
+}
+
+// containerSnapshot is the container with the writable layer snapshot.
+type containerSnapshot struct {
+	id            string
+	image         string
+	podNamespace  string
+	podName       string
+	containerName string
+	snapshot      string
+}
+
+// ContainerDiskUsage implements the machine.MachineServer interface.
+func (s *Server) ContainerDiskUsage(ctx context.Context, in *machine.ContainerDiskUsageRequest) (*machine.ContainerDiskUsageResponse, error) {
+	namespace := in.GetNamespace()
+	if namespace == "" {
+		namespace = criconstants.K8sContainerdNamespace
+	}
+
+	addr := constants.CRIContainerdAddress
+	if namespace == constants.SystemContainerdNamespace {
+		addr = constants.SystemContainerdAddress
+	}
+
+	client, err := containerdapi.New(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer client.Close()
+
+	ctx = namespaces.WithNamespace(ctx, namespace)
+
+	snapshotter := containerdapi.DefaultSnapshotter
+
+	snapshotUsages, err := listSnapshotUsages(ctx, client.SnapshotService(snapshotter))
+	if err != nil {
+		return nil, fmt.Errorf("error listing snapshots: %w", err)
+	}
+
+	images, err := listImageLayers(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("error listing images: %w", err)
+	}
+
+	containers, err := listContainerSnapshots(ctx, client, snapshotter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing containers: %w", err)
+	}
+
+	usage := aggregateContainerDiskUsage(snapshotUsages, images, containers)
+	usage.Namespace = namespace
+	usage.Snapshotter = snapshotter
+
+	return &machine.ContainerDiskUsageResponse{
+		Messages: []*machine.ContainerDiskUsage{usage},
+	}, nil
+}
+
+func listSnapshotUsages(ctx context.Context, snapshotter snapshots.Snapshotter) ([]snapshotUsage, error) {
+	var result []snapshotUsage
+
+	err := snapshotter.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+		usage, err := snapshotter.Usage(ctx, info.Name)
+		if err != nil {
+			return fmt.Errorf("error getting snapshot %q usage: %w", info.Name, err)
+		}
+
+		result = append(result, snapshotUsage{
+			info:  info,
+			usage: usage,
+		})
+
+		return nil
+	})
+
+	return result, err
+}
+
+// listImageLayers lists the images deduplicated by the digest, as CRI references the image by the tag, the digest and the image ID.
+func listImageLayers(ctx context.Context, client *containerdapi.Client) ([]imageLayers, error) {
+	images, err := client.ListImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]imageLayers, 0, len(images))
+	byDigest := map[string]int{}
+
+	for _, img := range images {
+		digest := img.Target().Digest.String()
+
+		if idx, ok := byDigest[digest]; ok {
+			if imageNamePriority(img.Name()) > imageNamePriority(result[idx].name) {
+				result[idx].name = img.Name()
+			}
+
+			continue
+		}
+
+		image := imageLayers{
+			name:   img.Name(),
+			digest: digest,
+		}
+
+		// images which are not fully pulled for the node platform don't have the content or the layers
+		if image.contentSize, err = img.Size(ctx); err != nil {
+			log.Printf("error getting image %q size: %s", img.Name(), err)
+		}
+
+		diffIDs, rootfsErr := img.RootFS(ctx)
+		if rootfsErr != nil {
+			log.Printf("error getting image %q layers: %s", img.Name(), rootfsErr)
+		}
+
+		for _, chainID := range identity.ChainIDs(diffIDs) {
+			image.layers = append(image.layers, chainID.String())
+		}
+
+		byDigest[digest] = len(result)
+		result = append(result, image)
+	}
+
+	return result, nil
+}
+
+// imageNamePriority prefers the tagged image reference over the digest reference and the image ID.
+func imageNamePriority(name string) int {
+	switch {
+	case strings.HasPrefix(name, "sha256:"):
+		return 0
+	case strings.Contains(name, "@"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func listContainerSnapshots(ctx context.Context, client *containerdapi.Client, snapshotter string) ([]containerSnapshot, error) {
+	containers, err := client.Containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]containerSnapshot, 0, len(containers))
+
+	for _, container := range containers {
+		info, err := container.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// pod sandbox containers use the pause image and have no writable layer of interest
+		if info.SnapshotKey == "" || info.Snapshotter != snapshotter || info.Labels[criContainerKindLabel] == criContainerKindSandbox {
+			continue
+		}
+
+		result = append(result, containerSnapshot{
+			id:            info.ID,
+			image:         info.Image,
+			podNamespace:  info.Labels[criPodNamespaceLabel],
+			podName:       info.Labels[criPodNameLabel],
+			containerName: info.Labels[criContainerNameLabel],
+			snapshot:      info.SnapshotKey,
+		})
+	}
+
+	return result, nil
+}
+
+// aggregateContainerDiskUsage attributes the snapshot layers to the images and containers.
+//
+// Layer shared by several images is accounted in the layers size of each image, but only in the unique layers size of the image
+// which doesn't share it.
+func aggregateContainerDiskUsage(snapshotUsages []snapshotUsage, images []imageLayers, containers []containerSnapshot) *machine.ContainerDiskUsage {
+	usages := make(map[string]snapshots.Usage, len(snapshotUsages))
+	layerImages := map[string][]string{}
+
+	for _, snapshot := range snapshotUsages {
+		usages[snapshot.info.Name] = snapshot.usage
+	}
+
+	for _, image := range images {
+		for _, layer := range image.layers {
+			layerImages[layer] = append(layerImages[layer], image.name)
+		}
+	}
+
+	result := &machine.ContainerDiskUsage{}
+
+	for _, image := range images {
+		usage := &machine.ImageDiskUsage{
+			Name:        image.name,
+			Digest:      image.digest,
+			ContentSize: image.contentSize,
+			Layers:      image.layers,
+		}
+
+		for _, layer := range image.layers {
+			usage.LayersSize += usages[layer].Size
+
+			if len(layerImages[layer]) == 1 {
+				usage.UniqueLayersSize += usages[layer].Size
+			}
+		}
+
+		result.Images = append(result.Images, usage)
+	}
+
+	for _, snapshot := range snapshotUsages {
+		result.Snapshots = append(result.Snapshots, &machine.SnapshotDiskUsage{
+			Name:   snapshot.info.Name,
+			Parent: snapshot.info.Parent,
+			Kind:   snapshot.info.Kind.String(),
+			Size:   snapshot.usage.Size,
+			Inodes: snapshot.usage.Inodes,
+			Images: layerImages[snapshot.info.Name],
+		})
+	}
+
+	for _, container := range containers {
+		result.Containers = append(result.Containers, &machine.ContainerWritableLayerDiskUsage{
+			Id:            container.id,
+			Image:         container.image,
+			PodNamespace:  container.podNamespace,
+			PodName:       container.podName,
+			ContainerName: container.containerName,
+			Snapshot:      container.snapshot,
+			Size:          usages[container.snapshot].Size,
+			Inodes:        usages[container.snapshot].Inodes,
+		})
+	}
+
+	// biggest consumers first
+	sort.SliceStable(result.Images, func(i, j int) bool { return result.Images[i].LayersSize > result.Images[j].LayersSize })
+	sort.SliceStable(result.Snapshots, func(i, j int) bool { return result.Snapshots[i].Size > result.Snapshots[j].Size })
+	sort.SliceStable(result.Containers, func(i, j int) bool { return result.Containers[i].Size > result.Containers[j].Size })
+
+	return result
+}
@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/snapshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateContainerDiskUsage(t *testing.T) {
+	snapshotUsages := []snapshotUsage{
+		{info: snapshots.Info{Name: "base", Kind: snapshots.KindCommitted}, usage: snapshots.Usage{Size: 100, Inodes: 10}},
+		{info: snapshots.Info{Name: "app", Parent: "base", Kind: snapshots.KindCommitted}, usage: snapshots.Usage{Size: 50, Inodes: 5}},
+		{info: snapshots.Info{Name: "tool", Parent: "base", Kind: snapshots.KindCommitted}, usage: snapshots.Usage{Size: 20, Inodes: 2}},
+		{info: snapshots.Info{Name: "container1", Parent: "app", Kind: snapshots.KindActive}, usage: snapshots.Usage{Size: 1000, Inodes: 3}},
+	}
+
+	images := []imageLayers{
+		{name: "example.com/tool:v1", digest: "sha256:tool", contentSize: 60, layers: []string{"base", "tool"}},
+		{name: "example.com/app:v1", digest: "sha256:app", contentSize: 70, layers: []string{"base", "app"}},
+	}
+
+	containers := []containerSnapshot{
+		{id: "c1", image: "example.com/app:v1", podNamespace: "default", podName: "app-0", containerName: "app", snapshot: "container1"},
+	}
+
+	usage := aggregateContainerDiskUsage(snapshotUsages, images, containers)
+
+	require.Len(t, usage.Images, 2)
+
+	assert.Equal(t, "example.com/app:v1", usage.Images[0].Name)
+	assert.EqualValues(t, 150, usage.Images[0].LayersSize)
+	assert.EqualValues(t, 50, usage.Images[0].UniqueLayersSize)
+	assert.EqualValues(t, 70, usage.Images[0].ContentSize)
+
+	assert.Equal(t, "example.com/tool:v1", usage.Images[1].Name)
+	assert.EqualValues(t, 120, usage.Images[1].LayersSize)
+	assert.EqualValues(t, 20, usage.Images[1].UniqueLayersSize)
+
+	require.Len(t, usage.Snapshots, 4)
+
+	assert.Equal(t, "container1", usage.Snapshots[0].Name)
+	assert.Equal(t, "Active", usage.Snapshots[0].Kind)
+	assert.Empty(t, usage.Snapshots[0].Images)
+
+	assert.Equal(t, "base", usage.Snapshots[1].Name)
+	assert.Equal(t, []string{"example.com/tool:v1", "example.com/app:v1"}, usage.Snapshots[1].Images)
+
+	require.Len(t, usage.Containers, 1)
+
+	assert.Equal(t, "app-0", usage.Containers[0].PodName)
+	assert.EqualValues(t, 1000, usage.Containers[0].Size)
+	assert.EqualValues(t, 3, usage.Containers[0].Inodes)
+}
+
+func TestImageNamePriority(t *testing.T) {
+	assert.Greater(t, imageNamePriority("example.com/app:v1"), imageNamePriority("example.com/app@sha256:1234"))
+	assert.Greater(t, imageNamePriority("example.com/app@sha256:1234"), imageNamePriority("sha256:5678"))
+}
@@ -0,0 +1,253 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/pkg/containers/image"
+	"github.com/talos-systems/talos/internal/pkg/sessionrecording"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// debugHostMountPath is the path the host root filesystem is mounted at in the debug container.
+const debugHostMountPath = "/host"
+
+// Debug implements the machine.MachineServer interface.
+//
+//nolint:gocyclo,cyclop
+func (s *Server) Debug(srv machine.MachineService_DebugServer) (retErr error) {
+	ctx := srv.Context()
+
+	req, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+
+	if req.GetImage() == "" {
+		return status.Error(codes.InvalidArgument, "debug container image is required")
+	}
+
+	client, err := containerd.New(constants.SystemContainerdAddress)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer client.Close()
+
+	containerdctx := namespaces.WithNamespace(ctx, constants.SystemContainerdNamespace)
+
+	// cleanup should happen even if the client is already gone
+	cleanupCtx := namespaces.WithNamespace(context.Background(), constants.SystemContainerdNamespace)
+
+	id := "debug-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	recorder, err := s.startSessionRecording(ctx, "Debug", id, map[string]interface{}{
+		"image": req.GetImage(),
+		"args":  req.GetArgs(),
+		"tty":   req.GetTty(),
+	})
+	if err != nil {
+		return err
+	}
+
+	endFields := map[string]interface{}{}
+
+	defer func() {
+		if err := endSessionRecording(recorder, retErr, endFields); err != nil {
+			log.Printf("debug container %q: error recording the session: %s", id, err)
+		}
+	}()
+
+	log.Printf("debug container %q: starting image %q", id, req.GetImage())
+
+	img, err := image.Pull(containerdctx, s.Controller.Runtime().Config().Machine().Registries(), client, req.GetImage())
+	if err != nil {
+		return fmt.Errorf("error pulling debug image: %w", err)
+	}
+
+	specOpts := append([]oci.SpecOpts{oci.WithImageConfig(img)}, debugSpecOpts(req)...)
+
+	container, err := client.NewContainer(containerdctx, id,
+		containerd.WithImage(img),
+		containerd.WithNewSnapshot(id, img),
+		containerd.WithNewSpec(specOpts...),
+	)
+	if err != nil {
+		return fmt.Errorf("error creating debug container: %w", err)
+	}
+
+	//nolint:errcheck
+	defer container.Delete(cleanupCtx, containerd.WithSnapshotCleanup)
+
+	stdinR, stdinW := io.Pipe()
+
+	//nolint:errcheck
+	defer stdinW.Close()
+
+	var sendMu sync.Mutex
+
+	streams := []cio.Opt{
+		cio.WithStreams(stdinR,
+			io.MultiWriter(&debugOutputWriter{srv: srv, mu: &sendMu}, recorder.Writer(sessionrecording.StreamStdout)),
+			io.MultiWriter(&debugOutputWriter{srv: srv, mu: &sendMu, stderr: true}, recorder.Writer(sessionrecording.StreamStderr)),
+		),
+	}
+
+	if req.GetTty() {
+		streams = append(streams, cio.WithTerminal)
+	}
+
+	task, err := container.NewTask(containerdctx, cio.NewCreator(streams...))
+	if err != nil {
+		return fmt.Errorf("error creating debug task: %w", err)
+	}
+
+	//nolint:errcheck
+	defer task.Delete(cleanupCtx, containerd.WithProcessKill)
+
+	exitCh, err := task.Wait(cleanupCtx)
+	if err != nil {
+		return fmt.Errorf("error waiting for debug task: %w", err)
+	}
+
+	if err = task.Start(containerdctx); err != nil {
+		return fmt.Errorf("error starting debug task: %w", err)
+	}
+
+	go func() {
+		for {
+			in, err := srv.Recv()
+			if err != nil {
+				// client closed the stream or disconnected
+				stdinW.Close() //nolint:errcheck
+
+				return
+			}
+
+			if len(in.GetStdin()) > 0 {
+				recorder.Record(sessionrecording.StreamStdin, in.GetStdin())
+
+				if _, err = stdinW.Write(in.GetStdin()); err != nil {
+					return
+				}
+			}
+
+			if in.GetStdinClose() {
+				stdinW.Close() //nolint:errcheck
+			}
+
+			if in.GetTtyWidth() > 0 && in.GetTtyHeight() > 0 {
+				if err = task.Resize(containerdctx, in.GetTtyWidth(), in.GetTtyHeight()); err != nil {
+					log.Printf("debug container %q: error resizing the terminal: %s", id, err)
+				}
+			}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Printf("debug container %q: client disconnected, removing the container", id)
+
+		return ctx.Err()
+	case exitStatus := <-exitCh:
+		// flush the remaining output
+		task.IO().Wait()
+
+		log.Printf("debug container %q: exited with code %d", id, exitStatus.ExitCode())
+
+		endFields["exitCode"] = exitStatus.ExitCode()
+
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		return srv.Send(&machine.DebugResponse{
+			Exited:   true,
+			ExitCode: exitStatus.ExitCode(),
+		})
+	}
+}
+
+// debugSpecOpts builds the debug container spec: privileged, in the host namespaces, with the host root filesystem mounted.
+func debugSpecOpts(req *machine.DebugRequest) []oci.SpecOpts {
+	specOpts := []oci.SpecOpts{
+		oci.WithHostNamespace(specs.NetworkNamespace),
+		oci.WithHostNamespace(specs.PIDNamespace),
+		oci.WithHostNamespace(specs.IPCNamespace),
+		oci.WithHostNamespace(specs.UTSNamespace),
+		oci.WithHostHostsFile,
+		oci.WithHostResolvconf,
+		oci.WithPrivileged,
+		oci.WithHostDevices,
+		oci.WithAllDevicesAllowed,
+		oci.WithMounts([]specs.Mount{
+			{
+				Type:        "bind",
+				Source:      "/",
+				Destination: debugHostMountPath,
+				Options:     []string{"rbind", "rshared", "rw"},
+			},
+		}),
+	}
+
+	if len(req.GetArgs()) > 0 {
+		specOpts = append(specOpts, oci.WithProcessArgs(req.GetArgs()...))
+	}
+
+	if req.GetTty() {
+		specOpts = append(specOpts, oci.WithTTY)
+
+		if req.GetTtyWidth() > 0 && req.GetTtyHeight() > 0 {
+			specOpts = append(specOpts, oci.WithTTYSize(int(req.GetTtyWidth()), int(req.GetTtyHeight())))
+		}
+	}
+
+	return specOpts
+}
+
+// debugOutputWriter streams the debug container output to the client.
+type debugOutputWriter struct {
+	srv    machine.MachineService_DebugServer
+	mu     *sync.Mutex
+	stderr bool
+}
+
+func (w *debugOutputWriter) Write(p []byte) (int, error) {
+	// the buffer is reused by the caller
+	data := append([]byte(nil), p...)
+
+	resp := &machine.DebugResponse{}
+
+	if w.stderr {
+		resp.Stderr = data
+	} else {
+		resp.Stdout = data
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.srv.Send(resp); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,50 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime //nolint:testpackage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+func TestDebugSpecOpts(t *testing.T) {
+	ctx := namespaces.WithNamespace(context.Background(), "test")
+
+	spec, err := oci.GenerateSpec(ctx, nil, &containers.Container{ID: "debug"}, debugSpecOpts(&machine.DebugRequest{
+		Image:     "alpine",
+		Args:      []string{"/bin/sh"},
+		Tty:       true,
+		TtyWidth:  80,
+		TtyHeight: 25,
+	})...)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/bin/sh"}, spec.Process.Args)
+	assert.True(t, spec.Process.Terminal)
+	assert.Equal(t, &specs.Box{Width: 80, Height: 25}, spec.Process.ConsoleSize)
+
+	for _, ns := range spec.Linux.Namespaces {
+		assert.NotContains(t, []specs.LinuxNamespaceType{specs.NetworkNamespace, specs.PIDNamespace, specs.IPCNamespace, specs.UTSNamespace}, ns.Type)
+	}
+
+	assert.Contains(t, spec.Mounts, specs.Mount{
+		Type:        "bind",
+		Source:      "/",
+		Destination: "/host",
+		Options:     []string{"rbind", "rshared", "rw"},
+	})
+
+	assert.Empty(t, spec.Linux.MaskedPaths)
+	assert.Empty(t, spec.Linux.ReadonlyPaths)
+}
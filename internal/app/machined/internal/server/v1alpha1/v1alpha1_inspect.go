@@ -10,6 +10,7 @@ import (
 
 	"github.com/cosi-project/runtime/pkg/controller"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	inspectapi "github.com/talos-systems/talos/pkg/machinery/api/inspect"
 )
@@ -58,10 +59,29 @@ func (s *InspectServer) ControllerRuntimeDependencies(ctx context.Context, in *e
 		})
 	}
 
+	statuses := s.server.Controller.V1Alpha2().ControllerStatuses()
+	controllers := make([]*inspectapi.ControllerStatus, 0, len(statuses))
+
+	for _, status := range statuses {
+		controllerStatus := &inspectapi.ControllerStatus{
+			ControllerName: status.Name,
+			Running:        status.Running,
+			Crashes:        uint32(status.Crashes),
+			LastError:      status.LastError,
+		}
+
+		if !status.LastErrorTime.IsZero() {
+			controllerStatus.LastErrorTime = timestamppb.New(status.LastErrorTime)
+		}
+
+		controllers = append(controllers, controllerStatus)
+	}
+
 	return &inspectapi.ControllerRuntimeDependenciesResponse{
 		Messages: []*inspectapi.ControllerRuntimeDependency{
 			{
-				Edges: edges,
+				Edges:       edges,
+				Controllers: controllers,
 			},
 		},
 	}, nil
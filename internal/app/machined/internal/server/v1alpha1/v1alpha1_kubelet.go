@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	machinetype "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+// KubeletRepair implements machine.MachineService.
+//
+// Kubelet is stopped, kubelet client credentials are removed and kubelet is started again:
+// kubelet requests a new client certificate using the bootstrap kubeconfig, which is kept up to date by the controller.
+func (s *Server) KubeletRepair(ctx context.Context, in *machine.KubeletRepairRequest) (*machine.KubeletRepairResponse, error) {
+	if _, _, err := system.Services(s.Controller.Runtime()).IsRunning("kubelet"); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, "kubelet service is not loaded")
+	}
+
+	log.Printf("repairing kubelet client credentials")
+
+	removed, err := kubelet.RepairClientCredentials(ctx, system.Services(s.Controller.Runtime()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine.KubeletRepairResponse{
+		Messages: []*machine.KubeletRepair{
+			{
+				RemovedFiles: removed,
+			},
+		},
+	}, nil
+}
+
+// KubeletRegenerate implements machine.MachineService.
+//
+// Kubelet is stopped, kubelet credentials issued by the cluster CA and the cluster CA certificate are removed and kubelet is started again:
+// kubelet PreFunc writes the cluster CA certificate from the current machine configuration, and the bootstrap kubeconfig
+// is kept up to date with the cluster CA by the controller.
+// On control plane nodes, kubelet is restarted only after the control plane static pod secrets are rendered with the current cluster CA,
+// so that kubelet bootstraps against the API server which already trusts the rotated cluster CA.
+func (s *Server) KubeletRegenerate(ctx context.Context, in *machine.KubeletRegenerateRequest) (*machine.KubeletRegenerateResponse, error) {
+	if _, _, err := system.Services(s.Controller.Runtime()).IsRunning("kubelet"); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, "kubelet service is not loaded")
+	}
+
+	if s.Controller.Runtime().Config().Machine().Type() != machinetype.TypeWorker {
+		secretsCtx, secretsCtxCancel := context.WithTimeout(ctx, time.Minute)
+		defer secretsCtxCancel()
+
+		if err := k8s.NewStaticPodSecretsReadyCondition(s.Controller.Runtime().State().V1Alpha2().Resources()).Wait(secretsCtx); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, "control plane secrets are not rendered from the current machine configuration yet")
+		}
+	}
+
+	log.Printf("regenerating kubelet credentials")
+
+	removed, err := kubelet.RegenerateCredentials(ctx, system.Services(s.Controller.Runtime()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &machine.KubeletRegenerateResponse{
+		Messages: []*machine.KubeletRegenerate{
+			{
+				RemovedFiles: removed,
+			},
+		},
+	}, nil
+}
@@ -7,10 +7,12 @@ package runtime
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/AlekSi/pointer"
 	"github.com/prometheus/procfs"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -247,6 +249,150 @@ func (s *Server) NetworkDeviceStats(ctx context.Context, in *emptypb.Empty) (*ma
 	return reply, nil
 }
 
+// SocketStats implements the machine.MachineServer interface.
+func (s *Server) SocketStats(ctx context.Context, in *emptypb.Empty) (*machine.SocketStatsResponse, error) {
+	fs, err := procfs.NewDefaultFS()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := machine.SocketStats{}
+
+	for _, read := range []func() (*procfs.NetSockstat, error){fs.NetSockstat, fs.NetSockstat6} {
+		stat, err := read()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// IPv6 is disabled
+				continue
+			}
+
+			return nil, err
+		}
+
+		if stat.Used != nil {
+			resp.SocketsUsed = uint64(*stat.Used)
+		}
+
+		for _, protocol := range stat.Protocols {
+			resp.Protocols = append(resp.Protocols, &machine.SocketProtocolStat{
+				Protocol:    protocol.Protocol,
+				InUse:       uint64(protocol.InUse),
+				Orphan:      uint64(pointer.GetInt(protocol.Orphan)),
+				TimeWait:    uint64(pointer.GetInt(protocol.TW)),
+				Alloc:       uint64(pointer.GetInt(protocol.Alloc)),
+				MemoryPages: uint64(pointer.GetInt(protocol.Mem)),
+			})
+		}
+	}
+
+	for _, path := range []string{"/proc/net/snmp", "/proc/net/netstat"} {
+		counters, err := readProtocolCounters(path)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Counters = append(resp.Counters, counters...)
+	}
+
+	counters, err := readSNMP6Counters("/proc/net/snmp6")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	resp.Counters = append(resp.Counters, counters...)
+
+	reply := &machine.SocketStatsResponse{
+		Messages: []*machine.SocketStats{
+			&resp,
+		},
+	}
+
+	return reply, nil
+}
+
+// readProtocolCounters parses /proc/net/snmp and /proc/net/netstat: each protocol has a line with counter names followed by a line with values.
+func readProtocolCounters(path string) ([]*machine.NetworkCounter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	var counters []*machine.NetworkCounter
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		names := strings.Fields(scanner.Text())
+
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("missing values line in %q", path)
+		}
+
+		values := strings.Fields(scanner.Text())
+
+		if len(names) != len(values) || len(names) == 0 || names[0] != values[0] {
+			return nil, fmt.Errorf("mismatched names and values lines in %q", path)
+		}
+
+		protocol := strings.TrimSuffix(names[0], ":")
+
+		for i := 1; i < len(names); i++ {
+			value, err := strconv.ParseInt(values[i], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %q: %w", path, err)
+			}
+
+			counters = append(counters, &machine.NetworkCounter{
+				Name:  protocol + "_" + names[i],
+				Value: value,
+			})
+		}
+	}
+
+	return counters, scanner.Err()
+}
+
+// readSNMP6Counters parses /proc/net/snmp6: each line has a counter name prefixed with the protocol (e.g. Udp6InDatagrams) and a value.
+func readSNMP6Counters(path string) ([]*machine.NetworkCounter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	var counters []*machine.NetworkCounter
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		// protocol name ends with '6': Ip6, Icmp6, Udp6, UdpLite6
+		idx := strings.Index(fields[0], "6")
+		if idx < 0 {
+			continue
+		}
+
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %q: %w", path, err)
+		}
+
+		counters = append(counters, &machine.NetworkCounter{
+			Name:  fields[0][:idx+1] + "_" + fields[0][idx+1:],
+			Value: value,
+		})
+	}
+
+	return counters, scanner.Err()
+}
+
 // DiskStats implements the machine.MachineServer interface.
 func (s *Server) DiskStats(ctx context.Context, in *emptypb.Empty) (*machine.DiskStatsResponse, error) {
 	f, err := os.Open("/proc/diskstats")
@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+)
+
+// maxControllerPause limits the pause duration, so that the controllers are not left paused by mistake.
+const maxControllerPause = 24 * time.Hour
+
+// PauseControllers implements machine.MachineService.
+func (s *Server) PauseControllers(ctx context.Context, in *machine.PauseControllersRequest) (*machine.PauseControllersResponse, error) {
+	if len(in.GetControllers()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "controllers to pause should be specified")
+	}
+
+	duration := in.GetDuration().AsDuration()
+	if duration <= 0 || duration > maxControllerPause {
+		return nil, status.Errorf(codes.InvalidArgument, "pause duration should be positive and not longer than %s", maxControllerPause)
+	}
+
+	until := time.Now().Add(duration)
+
+	paused, err := s.Controller.V1Alpha2().PauseControllers(in.GetControllers(), until)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Printf("controllers paused until %s: %s", until.Format(time.RFC3339), strings.Join(paused, ", "))
+
+	return &machine.PauseControllersResponse{
+		Messages: []*machine.PauseControllers{
+			{
+				Controllers: paused,
+				PausedUntil: timestamppb.New(until),
+			},
+		},
+	}, nil
+}
+
+// ResumeControllers implements machine.MachineService.
+func (s *Server) ResumeControllers(ctx context.Context, in *machine.ResumeControllersRequest) (*machine.ResumeControllersResponse, error) {
+	resumed := s.Controller.V1Alpha2().ResumeControllers(in.GetControllers())
+
+	if len(resumed) > 0 {
+		log.Printf("controllers resumed: %s", strings.Join(resumed, ", "))
+	}
+
+	return &machine.ResumeControllersResponse{
+		Messages: []*machine.ResumeControllers{
+			{
+				Controllers: resumed,
+			},
+		},
+	}, nil
+}
@@ -18,7 +18,10 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -50,15 +53,16 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader/grub"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
 	"github.com/talos-systems/talos/internal/app/resources"
-	storaged "github.com/talos-systems/talos/internal/app/storaged"
 	"github.com/talos-systems/talos/internal/pkg/configuration"
 	"github.com/talos-systems/talos/internal/pkg/containers"
 	taloscontainerd "github.com/talos-systems/talos/internal/pkg/containers/containerd"
 	"github.com/talos-systems/talos/internal/pkg/containers/cri"
+	cricontainerd "github.com/talos-systems/talos/internal/pkg/containers/cri/containerd"
 	"github.com/talos-systems/talos/internal/pkg/containers/image"
 	"github.com/talos-systems/talos/internal/pkg/etcd"
 	"github.com/talos-systems/talos/internal/pkg/kubeconfig"
 	"github.com/talos-systems/talos/internal/pkg/mount"
+	"github.com/talos-systems/talos/internal/pkg/upgrade"
 	"github.com/talos-systems/talos/pkg/archiver"
 	"github.com/talos-systems/talos/pkg/chunker"
 	"github.com/talos-systems/talos/pkg/chunker/stream"
@@ -71,9 +75,11 @@ import (
 	timeapi "github.com/talos-systems/talos/pkg/machinery/api/time"
 	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
 	machinetype "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
 	timeresource "github.com/talos-systems/talos/pkg/machinery/resources/time"
 	"github.com/talos-systems/talos/pkg/machinery/role"
 	"github.com/talos-systems/talos/pkg/version"
@@ -97,6 +103,11 @@ type Server struct {
 	Controller runtime.Controller
 
 	server *grpc.Server
+
+	// configMu serializes the config changes via the API and the automatic config revert
+	configMu           sync.Mutex
+	configRevertCtx    context.Context //nolint:containedctx
+	configRevertCancel context.CancelFunc
 }
 
 func (s *Server) checkSupported(feature runtime.ModeCapability) error {
@@ -117,7 +128,7 @@ func (s *Server) Register(obj *grpc.Server) {
 	cluster.RegisterClusterServiceServer(obj, s)
 	resource.RegisterResourceServiceServer(obj, &resources.Server{Resources: s.Controller.Runtime().State().V1Alpha2().Resources()})
 	inspect.RegisterInspectServiceServer(obj, &InspectServer{server: s})
-	storage.RegisterStorageServiceServer(obj, &storaged.Server{})
+	storage.RegisterStorageServiceServer(obj, &StorageServer{server: s})
 	timeapi.RegisterTimeServiceServer(obj, &TimeServer{ConfigProvider: s.Controller.Runtime()})
 }
 
@@ -127,45 +138,90 @@ func (s *Server) Register(obj *grpc.Server) {
 func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfigurationRequest) (*machine.ApplyConfigurationResponse, error) {
 	log.Printf("apply config request: immediate %v, on reboot %v", in.Immediate, in.OnReboot)
 
-	applyDynamicConfig := func() ([]byte, error) {
-		cfg, err := s.Controller.Runtime().ValidateConfig(in.GetData())
-		if err != nil {
-			return nil, err
-		}
+	// applied config is a layer merged on top of the disk and platform configs
+	layers := runtime.ConfigLayers{
+		runtime.ConfigSourceAPI: in.GetData(),
+	}
 
-		err = cfg.ApplyDynamicConfig(ctx, s.Controller.Runtime().State().Platform())
-		if err != nil {
-			return nil, err
-		}
+	if in.GetRevertTimeout() != nil && (!in.Immediate || in.GetRevertTimeout().AsDuration() <= 0) {
+		return nil, status.Error(codes.InvalidArgument, "revert timeout should be positive and is supported only in immediate mode")
+	}
+
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	merged, err := s.Controller.Runtime().MergeConfig(layers)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedCfg, err := configloader.NewFromBytes(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	warnings, err := mergedCfg.Validate(s.Controller.Runtime().State().Platform().Mode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate config: %w", err)
+	}
 
-		return cfg.Bytes()
+	// any config change supersedes the pending automatic revert
+	if err = s.cancelConfigRevert(); err != nil {
+		return nil, err
 	}
 
 	switch {
 	// --immediate
 	case in.Immediate:
-		if err := s.Controller.Runtime().CanApplyImmediate(in.GetData()); err != nil {
+		if err = s.Controller.Runtime().CanApplyImmediate(merged); err != nil {
 			return nil, err
 		}
 
-		cfg, err := applyDynamicConfig()
-		if err != nil {
-			return nil, err
+		var (
+			baseline nodeHealth
+			revert   *runtime.ConfigRevert
+		)
+
+		if in.GetRevertTimeout() != nil {
+			baseline = s.nodeHealth(ctx)
+
+			revert = &runtime.ConfigRevert{
+				Previous: string(s.Controller.Runtime().ConfigLayers()[runtime.ConfigSourceAPI]),
+				Deadline: time.Now().Add(in.GetRevertTimeout().AsDuration()),
+			}
+
+			// the revert is persisted before the config is applied, so that the node boots with the previous config
+			// if it reboots before the applied config is confirmed
+			if err = revert.Save(); err != nil {
+				return nil, err
+			}
 		}
 
-		if err := s.Controller.Runtime().SetConfig(cfg); err != nil {
+		if err = s.applyConfigImmediate(ctx, layers); err != nil {
+			if revert != nil {
+				runtime.RemoveConfigRevert() //nolint:errcheck
+			}
+
 			return nil, err
 		}
 
-		if err := ioutil.WriteFile(constants.ConfigPath, in.GetData(), 0o600); err != nil {
-			return nil, err
+		s.Controller.Runtime().Events().Publish(&machine.ConfigAppliedEvent{
+			Mode: "immediate",
+		})
+
+		if revert != nil {
+			s.startConfigRevert(revert, baseline)
 		}
 	// default (no flags)
 	case !in.OnReboot:
-		if err := s.Controller.Runtime().SetConfig(in.GetData()); err != nil {
+		if err = s.Controller.Runtime().SetConfig(layers); err != nil {
 			return nil, err
 		}
 
+		s.Controller.Runtime().Events().Publish(&machine.ConfigAppliedEvent{
+			Mode: "reboot",
+		})
+
 		go func() {
 			if err := s.Controller.Run(context.Background(), runtime.SequenceApplyConfiguration, in); err != nil {
 				if !runtime.IsRebootError(err) {
@@ -179,23 +235,121 @@ func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfig
 		}()
 	// --no-reboot
 	case in.OnReboot:
-		cfg, err := applyDynamicConfig()
+		var cfg config.Provider
+
+		cfg, err = s.Controller.Runtime().ValidateConfig(merged)
 		if err != nil {
 			return nil, err
 		}
 
-		if err = ioutil.WriteFile(constants.ConfigPath, cfg, 0o600); err != nil {
+		if err = cfg.ApplyDynamicConfig(ctx, s.Controller.Runtime().State().Platform()); err != nil {
+			return nil, err
+		}
+
+		// the layers are merged again on reboot
+		pending := s.Controller.Runtime().ConfigLayers()
+		pending[runtime.ConfigSourceAPI] = in.GetData()
+
+		if err = pending.Save(); err != nil {
+			return nil, err
+		}
+
+		if err = writeConfig(cfg); err != nil {
 			return nil, err
 		}
+
+		s.Controller.Runtime().Events().Publish(&machine.ConfigAppliedEvent{
+			Mode: "on_reboot",
+		})
 	}
 
 	return &machine.ApplyConfigurationResponse{
 		Messages: []*machine.ApplyConfiguration{
-			{},
+			{
+				Warnings:     warnings,
+				Deprecations: configuration.Deprecations(mergedCfg),
+			},
 		},
 	}, nil
 }
 
+// applyConfigImmediate applies the configuration layers without a reboot.
+func (s *Server) applyConfigImmediate(ctx context.Context, layers runtime.ConfigLayers) error {
+	oldConfig := s.Controller.Runtime().Config()
+	oldAPITLS := oldConfig.Machine().APITLS()
+
+	if err := s.Controller.Runtime().SetConfig(layers); err != nil {
+		return err
+	}
+
+	cfg := s.Controller.Runtime().Config()
+
+	if err := cfg.ApplyDynamicConfig(ctx, s.Controller.Runtime().State().Platform()); err != nil {
+		return err
+	}
+
+	if err := s.Controller.Runtime().State().V1Alpha2().SetConfig(cfg); err != nil {
+		return err
+	}
+
+	if err := s.Controller.Runtime().ConfigLayers().Save(); err != nil {
+		return err
+	}
+
+	if err := writeConfig(cfg); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(oldConfig.Machine().CRI(), cfg.Machine().CRI()) {
+		if err := cricontainerd.UpdateCRIConfig(constants.CRIContainerdConfig, oldConfig.Machine(), cfg.Machine()); err != nil {
+			return fmt.Errorf("error updating CRI config: %w", err)
+		}
+
+		go s.restartServices("CRI settings", "cri")
+	}
+
+	if !reflect.DeepEqual(oldAPITLS, cfg.Machine().APITLS()) {
+		// restart in the background, as the response goes back via apid;
+		// apid goes last, so that the apply configuration response is delivered before it's restarted
+		go s.restartServices("API TLS settings", "trustd", "apid")
+	}
+
+	return nil
+}
+
+// writeConfig stores the merged config for reference.
+func writeConfig(cfg config.Provider) error {
+	b, err := cfg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(constants.ConfigPath, b, 0o600)
+}
+
+// restartServices restarts the running services one by one to apply the new settings.
+func (s *Server) restartServices(settings string, ids ...string) {
+	services := system.Services(s.Controller.Runtime())
+
+	for _, id := range ids {
+		if _, running, err := services.IsRunning(id); err != nil || !running {
+			continue
+		}
+
+		log.Printf("restarting %s to apply %s", id, settings)
+
+		if err := services.Stop(context.Background(), id); err != nil {
+			log.Printf("failed to stop %s: %s", id, err)
+
+			continue
+		}
+
+		if err := services.Start(id); err != nil {
+			log.Printf("failed to start %s: %s", id, err)
+		}
+	}
+}
+
 // GenerateConfiguration implements the machine.MachineServer interface.
 func (s *Server) GenerateConfiguration(ctx context.Context, in *machine.GenerateConfigurationRequest) (reply *machine.GenerateConfigurationResponse, err error) {
 	if s.Controller.Runtime().Config().Machine().Type() == machinetype.TypeWorker {
@@ -286,6 +440,14 @@ func (s *Server) Rollback(ctx context.Context, in *machine.RollbackRequest) (*ma
 		return nil, err
 	}
 
+	if err := upgrade.RecordPending(filepath.Join(constants.StateMountPoint, constants.UpgradeHistoryFilename), runtimeresource.UpgradeHistorySpec{
+		Action:      runtimeresource.UpgradeActionRollback,
+		FromVersion: version.Tag,
+		Timestamp:   time.Now().UTC().Truncate(time.Second),
+	}); err != nil {
+		log.Printf("failed to record upgrade history: %s", err)
+	}
+
 	go func() {
 		if err := s.Controller.Run(context.Background(), runtime.SequenceReboot, in, runtime.WithForce(), runtime.WithTakeover()); err != nil {
 			if !runtime.IsRebootError(err) {
@@ -390,16 +552,20 @@ func (s *Server) Upgrade(ctx context.Context, in *machine.UpgradeRequest) (reply
 		return nil, err
 	}
 
-	log.Printf("upgrade request received: preserve %v, staged %v, force %v", in.GetPreserve(), in.GetStage(), in.GetForce())
+	log.Printf("upgrade request received: preserve %v, staged %v, force %v, ignore inhibitors %v", in.GetPreserve(), in.GetStage(), in.GetForce(), in.GetIgnoreInhibitors())
 
 	log.Printf("validating %q", in.GetImage())
 
 	if err = pullAndValidateInstallerImage(ctx, s.Controller.Runtime().Config().Machine().Registries(), in.GetImage()); err != nil {
+		if errors.Is(err, image.ErrOutsideMaintenanceWindow) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
 		return nil, fmt.Errorf("error validating installer image %q: %w", in.GetImage(), err)
 	}
 
 	if s.Controller.Runtime().Config().Machine().Type() != machinetype.TypeWorker && !in.GetForce() {
-		client, err := etcd.NewClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().Endpoint())
+		client, err := etcd.NewSharedClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().InternalEndpoint())
 		if err != nil {
 			return nil, fmt.Errorf("failed to create etcd client: %w", err)
 		}
@@ -1301,7 +1467,8 @@ func pullAndValidateInstallerImage(ctx context.Context, reg config.Registries, r
 		return err
 	}
 
-	img, err := image.Pull(containerdctx, reg, client, ref)
+	// upgrades are the big pulls, so they are restricted to the maintenance windows
+	img, err := image.Pull(containerdctx, reg, client, ref, image.WithMaintenanceWindows())
 	if err != nil {
 		return err
 	}
@@ -1384,17 +1551,36 @@ func (s *Server) Containers(ctx context.Context, in *machine.ContainersRequest)
 	containers := []*machine.ContainerInfo{}
 
 	for _, pod := range pods {
+		podNamespace, podName := splitPodName(pod.Name)
+
+		if len(in.PodNamespaces) > 0 && !containsString(in.PodNamespaces, podNamespace) {
+			continue
+		}
+
 		for _, container := range pod.Containers {
-			container := &machine.ContainerInfo{
-				Namespace: in.Namespace,
-				Id:        container.Display,
-				PodId:     pod.Name,
-				Name:      container.Name,
-				Image:     container.Image,
-				Pid:       container.Pid,
-				Status:    container.Status,
+			info := &machine.ContainerInfo{
+				Namespace:    in.Namespace,
+				Id:           container.Display,
+				PodId:        pod.Name,
+				Name:         container.Name,
+				Image:        container.Image,
+				Pid:          container.Pid,
+				Status:       container.Status,
+				PodNamespace: podNamespace,
+				PodName:      podName,
+				LogPath:      container.LogPath,
 			}
-			containers = append(containers, container)
+
+			if restartCount, err := strconv.ParseUint(container.RestartCount, 10, 32); err == nil {
+				info.RestartCount = uint32(restartCount)
+			}
+
+			if in.Stats && container.Metrics != nil {
+				info.MemoryUsage = container.Metrics.MemoryUsage
+				info.CpuUsage = container.Metrics.CPUUsage
+			}
+
+			containers = append(containers, info)
 		}
 	}
 
@@ -1409,6 +1595,27 @@ func (s *Server) Containers(ctx context.Context, in *machine.ContainersRequest)
 	return reply, nil
 }
 
+// splitPodName splits the pod name reported by the CRI inspector (namespace/name).
+//
+// Namespace is empty for the containers which don't belong to the Kubernetes pods.
+func splitPodName(name string) (namespace, pod string) {
+	if idx := strings.Index(name, "/"); idx > 0 {
+		return name[:idx], name[idx+1:]
+	}
+
+	return "", name
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Stats implements the machine.MachineServer interface.
 func (s *Server) Stats(ctx context.Context, in *machine.StatsRequest) (reply *machine.StatsResponse, err error) {
 	inspector, err := getContainerInspector(ctx, in.Namespace, in.Driver)
@@ -1689,9 +1896,9 @@ func (s *Server) EtcdMemberList(ctx context.Context, in *machine.EtcdMemberListR
 	var client *etcd.Client
 
 	if in.QueryLocal {
-		client, err = etcd.NewLocalClient()
+		client, err = etcd.NewSharedLocalClient()
 	} else {
-		client, err = etcd.NewClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().Endpoint())
+		client, err = etcd.NewSharedClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().InternalEndpoint())
 	}
 
 	if err != nil {
@@ -1739,7 +1946,7 @@ func (s *Server) EtcdMemberList(ctx context.Context, in *machine.EtcdMemberListR
 
 // EtcdRemoveMember implements the machine.MachineServer interface.
 func (s *Server) EtcdRemoveMember(ctx context.Context, in *machine.EtcdRemoveMemberRequest) (reply *machine.EtcdRemoveMemberResponse, err error) {
-	client, err := etcd.NewClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().Endpoint())
+	client, err := etcd.NewSharedClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().InternalEndpoint())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create etcd client: %w", err)
 	}
@@ -1764,7 +1971,7 @@ func (s *Server) EtcdRemoveMember(ctx context.Context, in *machine.EtcdRemoveMem
 
 // EtcdLeaveCluster implements the machine.MachineServer interface.
 func (s *Server) EtcdLeaveCluster(ctx context.Context, in *machine.EtcdLeaveClusterRequest) (reply *machine.EtcdLeaveClusterResponse, err error) {
-	client, err := etcd.NewClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().Endpoint())
+	client, err := etcd.NewSharedClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().InternalEndpoint())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create etcd client: %w", err)
 	}
@@ -1789,7 +1996,7 @@ func (s *Server) EtcdLeaveCluster(ctx context.Context, in *machine.EtcdLeaveClus
 
 // EtcdForfeitLeadership implements the machine.MachineServer interface.
 func (s *Server) EtcdForfeitLeadership(ctx context.Context, in *machine.EtcdForfeitLeadershipRequest) (reply *machine.EtcdForfeitLeadershipResponse, err error) {
-	client, err := etcd.NewClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().Endpoint())
+	client, err := etcd.NewSharedClientFromControlPlaneIPs(ctx, s.Controller.Runtime().Config().Cluster().CA(), s.Controller.Runtime().Config().Cluster().InternalEndpoint())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create etcd client: %w", err)
 	}
@@ -1817,7 +2024,7 @@ func (s *Server) EtcdForfeitLeadership(ctx context.Context, in *machine.EtcdForf
 
 // EtcdSnapshot implements the machine.MachineServer interface.
 func (s *Server) EtcdSnapshot(in *machine.EtcdSnapshotRequest, srv machine.MachineService_EtcdSnapshotServer) error {
-	client, err := etcd.NewLocalClient()
+	client, err := etcd.NewSharedLocalClient()
 	if err != nil {
 		return fmt.Errorf("failed to create etcd client: %w", err)
 	}
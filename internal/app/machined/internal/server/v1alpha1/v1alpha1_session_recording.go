@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/talos-systems/talos/internal/pkg/sessionrecording"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/authz"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+func init() {
+	// Kubernetes bootstrap tokens (`<id>.<secret>`) are redacted from all the transcripts.
+	redactor, err := sessionrecording.RegexpRedactor([]string{`\b[a-z0-9]{6}\.[a-z0-9]{16}\b`})
+	if err != nil {
+		panic(err)
+	}
+
+	sessionrecording.DefaultRedactors.Register(redactor)
+}
+
+// startSessionRecording records the start of the interactive API session to the audit log.
+//
+// The session transcript is recorded if enabled in the machine configuration.
+func (s *Server) startSessionRecording(ctx context.Context, api, session string, fields map[string]interface{}) (*sessionrecording.Recorder, error) {
+	w, err := s.Controller.Runtime().Logging().ServiceLog(constants.AuditLogID).Writer()
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log: %w", err)
+	}
+
+	var cfg config.SessionRecording
+
+	if s.Controller.Runtime().Config() != nil {
+		cfg = s.Controller.Runtime().Config().Machine().Logging().SessionRecording()
+	}
+
+	recorder, err := sessionrecording.NewRecorder(w, api, session, cfg, sessionrecording.DefaultRedactors.Redactors()...)
+	if err != nil {
+		w.Close() //nolint:errcheck
+
+		return nil, fmt.Errorf("error starting session recording: %w", err)
+	}
+
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+
+	fields["roles"] = authz.GetRoles(ctx).Strings()
+
+	if err = recorder.Start(fields); err != nil {
+		recorder.End(nil) //nolint:errcheck
+
+		return nil, fmt.Errorf("error recording session start: %w", err)
+	}
+
+	return recorder, nil
+}
+
+// endSessionRecording records the end of the interactive API session with the session error (if any).
+func endSessionRecording(recorder *sessionrecording.Recorder, sessionErr error, fields map[string]interface{}) error {
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+
+	if sessionErr != nil {
+		fields["error"] = sessionErr.Error()
+	}
+
+	return recorder.End(fields)
+}
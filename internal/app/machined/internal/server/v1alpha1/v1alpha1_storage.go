@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	storaged "github.com/talos-systems/talos/internal/app/storaged"
+	"github.com/talos-systems/talos/pkg/machinery/api/storage"
+)
+
+// StorageServer implements StorageService API.
+//
+// Methods modifying the disks are implemented only here, as the common storage service is also
+// exposed without authentication in the maintenance mode.
+type StorageServer struct {
+	storaged.Server
+
+	server *Server
+}
+
+// BlockDeviceWipe implements storage.StorageService.
+//
+// All the disks are checked before wiping any of them, so that the request either fails or wipes all the disks.
+func (s *StorageServer) BlockDeviceWipe(ctx context.Context, in *storage.BlockDeviceWipeRequest) (*storage.BlockDeviceWipeResponse, error) {
+	if len(in.GetDevices()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "disks to wipe should be specified")
+	}
+
+	var systemDisk string
+
+	if disk := s.server.Controller.Runtime().State().Machine().Disk(); disk != nil {
+		systemDisk = filepath.Base(disk.Device().Name())
+	}
+
+	for _, device := range in.GetDevices() {
+		if device == systemDisk {
+			return nil, status.Errorf(codes.FailedPrecondition, "disk %q is the system disk", device)
+		}
+
+		if err := storaged.CheckDiskNotInUse("/sys/block", "/proc/self/mountinfo", device); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	for _, device := range in.GetDevices() {
+		log.Printf("wiping disk %q, method %s", device, in.GetMethod())
+
+		if err := storaged.WipeDisk(ctx, device, in.GetMethod()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &storage.BlockDeviceWipeResponse{
+		Messages: []*storage.BlockDeviceWipe{
+			{
+				Devices: in.GetDevices(),
+			},
+		},
+	}, nil
+}
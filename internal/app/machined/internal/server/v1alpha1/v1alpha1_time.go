@@ -11,6 +11,7 @@ import (
 
 	"github.com/beevik/ntp"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -65,6 +66,8 @@ func (r *TimeServer) TimeCheck(ctx context.Context, in *timeapi.TimeRequest) (re
 				Server:     in.Server,
 				Localtime:  timestamppb.New(time.Now()),
 				Remotetime: timestamppb.New(rt.Time),
+				// ClockOffset should be added to the local time to get the server time
+				Offset: durationpb.New(-rt.ClockOffset),
 			},
 		},
 	}, nil
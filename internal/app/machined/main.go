@@ -30,7 +30,9 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/services"
+	"github.com/talos-systems/talos/internal/app/policyhook"
 	"github.com/talos-systems/talos/internal/app/trustd"
+	cricontainerd "github.com/talos-systems/talos/internal/pkg/containers/cri/containerd"
 	"github.com/talos-systems/talos/internal/pkg/mount"
 	"github.com/talos-systems/talos/pkg/machinery/api/common"
 	"github.com/talos-systems/talos/pkg/machinery/api/machine"
@@ -256,6 +258,12 @@ func run() error {
 
 	// Boot the machine.
 	if err = c.Run(ctx, runtime.SequenceBoot, nil); err != nil && !errors.Is(err, context.Canceled) {
+		if captureErr := v1alpha1runtime.CaptureBootDiagnostics(ctx, c.Runtime(), runtime.SequenceBoot, err); captureErr != nil {
+			log.Printf("failed to capture boot diagnostics: %s", captureErr)
+		} else {
+			log.Printf("boot diagnostics captured to %s", constants.BootDiagnosticsPath)
+		}
+
 		return err
 	}
 
@@ -292,6 +300,10 @@ func main() {
 	case "/trustd":
 		trustd.Main()
 
+		return
+	case cricontainerd.PolicyHook:
+		policyhook.Main()
+
 		return
 	default:
 	}
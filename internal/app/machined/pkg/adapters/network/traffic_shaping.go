@@ -0,0 +1,228 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// Traffic shaping handles: root HTB qdisc 1:, root class 1:1, default class 1:2, configured classes 1:10, 1:11, ...
+const (
+	trafficShapingMajor        = 1
+	trafficShapingRootClass    = 1
+	trafficShapingDefaultClass = 2
+	trafficShapingFirstClass   = 10
+
+	// lowest HTB priority.
+	trafficShapingDefaultPriority = 7
+
+	// IPv4 and IPv6 filters use different priorities, as the kernel doesn't allow mixing protocols in a single filter chain.
+	trafficShapingIPv4FilterPriority = 1
+	trafficShapingIPv6FilterPriority = 2
+)
+
+// TrafficShaping adapter provides encoding of the traffic shaping configuration to netlink structures.
+//
+//nolint:revive,golint
+func TrafficShaping(cfg config.TrafficShaping) trafficShaping {
+	return trafficShaping{
+		TrafficShaping: cfg,
+	}
+}
+
+type trafficShaping struct {
+	config.TrafficShaping
+}
+
+// Encode converts traffic shaping configuration to HTB qdisc, classes and u32 filters for the link.
+//
+// Classes without the guaranteed bandwidth get 1% of the bandwidth, unclassified traffic gets the bandwidth left (at least 1%).
+func (a trafficShaping) Encode(linkIndex int) (netlink.Qdisc, []netlink.Class, []netlink.Filter, error) {
+	bandwidth := a.Bandwidth()
+	minRate := bandwidth / 100
+
+	root := netlink.MakeHandle(trafficShapingMajor, 0)
+
+	qdisc := netlink.NewHtb(netlink.QdiscAttrs{
+		LinkIndex: linkIndex,
+		Handle:    root,
+		Parent:    netlink.HANDLE_ROOT,
+	})
+	qdisc.Defcls = trafficShapingDefaultClass
+
+	rootClass := netlink.MakeHandle(trafficShapingMajor, trafficShapingRootClass)
+
+	classes := []netlink.Class{
+		netlink.NewHtbClass(netlink.ClassAttrs{
+			LinkIndex: linkIndex,
+			Handle:    rootClass,
+			Parent:    root,
+		}, netlink.HtbClassAttrs{
+			Rate: bandwidth,
+			Ceil: bandwidth,
+		}),
+	}
+
+	var filters []netlink.Filter
+
+	remaining := bandwidth
+
+	for i, class := range a.Classes() {
+		handle := netlink.MakeHandle(trafficShapingMajor, uint16(trafficShapingFirstClass+i))
+
+		rate := class.Guaranteed()
+		if rate == 0 {
+			rate = minRate
+		}
+
+		ceil := class.Limit()
+		if ceil == 0 {
+			ceil = bandwidth
+		}
+
+		if rate < remaining {
+			remaining -= rate
+		} else {
+			remaining = 0
+		}
+
+		classes = append(classes, netlink.NewHtbClass(netlink.ClassAttrs{
+			LinkIndex: linkIndex,
+			Handle:    handle,
+			Parent:    rootClass,
+		}, netlink.HtbClassAttrs{
+			Rate: rate,
+			Ceil: ceil,
+			Prio: uint32(class.Priority()),
+		}))
+
+		classFilters, err := trafficClassFilters(linkIndex, handle, class)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error building filters for traffic class %q: %w", class.Name(), err)
+		}
+
+		filters = append(filters, classFilters...)
+	}
+
+	if remaining < minRate {
+		remaining = minRate
+	}
+
+	classes = append(classes, netlink.NewHtbClass(netlink.ClassAttrs{
+		LinkIndex: linkIndex,
+		Handle:    netlink.MakeHandle(trafficShapingMajor, trafficShapingDefaultClass),
+		Parent:    rootClass,
+	}, netlink.HtbClassAttrs{
+		Rate: remaining,
+		Ceil: bandwidth,
+		Prio: trafficShapingDefaultPriority,
+	}))
+
+	return qdisc, classes, filters, nil
+}
+
+type u32Selector struct {
+	ipv6 bool
+	keys []netlink.TcU32Key
+}
+
+// trafficClassFilters builds u32 filters for each port (source and destination) and subnet (source and destination) of the class.
+//
+// Matching assumes no IPv4 options and no IPv6 extension headers.
+func trafficClassFilters(linkIndex int, classID uint32, class config.TrafficClass) ([]netlink.Filter, error) {
+	var selectors []u32Selector
+
+	for _, port := range class.Ports() {
+		for _, ipv6 := range []bool{false, true} {
+			off := int32(20)
+			if ipv6 {
+				off = 40
+			}
+
+			// source port is the upper half-word, destination port is the lower half-word
+			for _, key := range []netlink.TcU32Key{
+				{Mask: 0xffff0000, Val: uint32(port) << 16, Off: off},
+				{Mask: 0x0000ffff, Val: uint32(port), Off: off},
+			} {
+				selectors = append(selectors, u32Selector{ipv6: ipv6, keys: []netlink.TcU32Key{key}})
+			}
+		}
+	}
+
+	for _, subnet := range class.Subnets() {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, err
+		}
+
+		ipv6 := ipNet.IP.To4() == nil
+
+		ip, mask := ipNet.IP.To4(), net.IP(ipNet.Mask)
+		srcOff, dstOff := int32(12), int32(16)
+
+		if ipv6 {
+			ip = ipNet.IP.To16()
+			srcOff, dstOff = 8, 24
+		}
+
+		for _, off := range []int32{srcOff, dstOff} {
+			var keys []netlink.TcU32Key
+
+			for word := 0; word < len(ip)/4; word++ {
+				m := binary.BigEndian.Uint32(mask[word*4:])
+				if m == 0 {
+					continue
+				}
+
+				keys = append(keys, netlink.TcU32Key{
+					Mask: m,
+					Val:  binary.BigEndian.Uint32(ip[word*4:]) & m,
+					Off:  off + int32(word*4),
+				})
+			}
+
+			// zero-length prefix matches everything
+			if len(keys) == 0 {
+				keys = append(keys, netlink.TcU32Key{Off: off})
+			}
+
+			selectors = append(selectors, u32Selector{ipv6: ipv6, keys: keys})
+		}
+	}
+
+	filters := make([]netlink.Filter, 0, len(selectors))
+
+	for _, selector := range selectors {
+		attrs := netlink.FilterAttrs{
+			LinkIndex: linkIndex,
+			Parent:    netlink.MakeHandle(trafficShapingMajor, 0),
+			Priority:  trafficShapingIPv4FilterPriority,
+			Protocol:  unix.ETH_P_IP,
+		}
+
+		if selector.ipv6 {
+			attrs.Priority = trafficShapingIPv6FilterPriority
+			attrs.Protocol = unix.ETH_P_IPV6
+		}
+
+		filters = append(filters, &netlink.U32{
+			FilterAttrs: attrs,
+			ClassId:     classID,
+			Sel: &netlink.TcU32Sel{
+				Flags: netlink.TC_U32_TERMINAL,
+				Keys:  selector.keys,
+			},
+		})
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	networkadapter "github.com/talos-systems/talos/internal/app/machined/pkg/adapters/network"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestTrafficShaping(t *testing.T) {
+	cfg := &v1alpha1.TrafficShapingConfig{
+		TrafficShapingBandwidth: "1Gbit",
+		TrafficShapingClasses: []v1alpha1.TrafficClassConfig{
+			{
+				TrafficClassName:       "etcd",
+				TrafficClassGuaranteed: "100Mbit",
+				TrafficClassPorts:      []int{2380},
+			},
+			{
+				TrafficClassName:     "registry",
+				TrafficClassLimit:    "400Mbit",
+				TrafficClassPriority: 7,
+				TrafficClassSubnets:  []string{"10.100.0.0/16", "fd00:1::/48"},
+			},
+		},
+	}
+
+	qdisc, classes, filters, err := networkadapter.TrafficShaping(cfg).Encode(3)
+	require.NoError(t, err)
+
+	require.IsType(t, &netlink.Htb{}, qdisc)
+	assert.Equal(t, netlink.MakeHandle(1, 0), qdisc.Attrs().Handle)
+	assert.EqualValues(t, 2, qdisc.(*netlink.Htb).Defcls)
+
+	require.Len(t, classes, 4)
+
+	// rates are encoded in bytes per second
+	for i, expected := range []struct {
+		handle, parent uint32
+		rate, ceil     uint64
+		prio           uint32
+	}{
+		{netlink.MakeHandle(1, 1), netlink.MakeHandle(1, 0), 125_000_000, 125_000_000, 0},
+		{netlink.MakeHandle(1, 10), netlink.MakeHandle(1, 1), 12_500_000, 125_000_000, 0},
+		{netlink.MakeHandle(1, 11), netlink.MakeHandle(1, 1), 1_250_000, 50_000_000, 7},
+		{netlink.MakeHandle(1, 2), netlink.MakeHandle(1, 1), 111_250_000, 125_000_000, 7},
+	} {
+		class := classes[i].(*netlink.HtbClass)
+
+		assert.Equal(t, 3, class.LinkIndex)
+		assert.Equal(t, expected.handle, class.Handle)
+		assert.Equal(t, expected.parent, class.Parent)
+		assert.Equal(t, expected.rate, class.Rate)
+		assert.Equal(t, expected.ceil, class.Ceil)
+		assert.Equal(t, expected.prio, class.Prio)
+	}
+
+	// port: sport & dport for IPv4 and IPv6, subnets: src & dst for IPv4 and IPv6
+	require.Len(t, filters, 8)
+
+	for i, expected := range []struct {
+		classID  uint32
+		protocol uint16
+		keys     []netlink.TcU32Key
+	}{
+		{netlink.MakeHandle(1, 10), unix.ETH_P_IP, []netlink.TcU32Key{{Mask: 0xffff0000, Val: 2380 << 16, Off: 20}}},
+		{netlink.MakeHandle(1, 10), unix.ETH_P_IP, []netlink.TcU32Key{{Mask: 0x0000ffff, Val: 2380, Off: 20}}},
+		{netlink.MakeHandle(1, 10), unix.ETH_P_IPV6, []netlink.TcU32Key{{Mask: 0xffff0000, Val: 2380 << 16, Off: 40}}},
+		{netlink.MakeHandle(1, 10), unix.ETH_P_IPV6, []netlink.TcU32Key{{Mask: 0x0000ffff, Val: 2380, Off: 40}}},
+		{netlink.MakeHandle(1, 11), unix.ETH_P_IP, []netlink.TcU32Key{{Mask: 0xffff0000, Val: 0x0a640000, Off: 12}}},
+		{netlink.MakeHandle(1, 11), unix.ETH_P_IP, []netlink.TcU32Key{{Mask: 0xffff0000, Val: 0x0a640000, Off: 16}}},
+		{netlink.MakeHandle(1, 11), unix.ETH_P_IPV6, []netlink.TcU32Key{{Mask: 0xffffffff, Val: 0xfd000001, Off: 8}, {Mask: 0xffff0000, Val: 0, Off: 12}}},
+		{netlink.MakeHandle(1, 11), unix.ETH_P_IPV6, []netlink.TcU32Key{{Mask: 0xffffffff, Val: 0xfd000001, Off: 24}, {Mask: 0xffff0000, Val: 0, Off: 28}}},
+	} {
+		filter := filters[i].(*netlink.U32)
+
+		assert.Equal(t, expected.classID, filter.ClassId)
+		assert.Equal(t, expected.protocol, filter.Protocol)
+		assert.Equal(t, expected.keys, filter.Sel.Keys)
+	}
+}
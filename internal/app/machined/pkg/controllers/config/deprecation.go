@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+// DeprecationController reports deprecated fields set in the machine configuration.
+type DeprecationController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *DeprecationController) Name() string {
+	return "config.DeprecationController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *DeprecationController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *DeprecationController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: config.MachineConfigDeprecationType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *DeprecationController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		touchedIDs := map[resource.ID]struct{}{}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		} else {
+			for _, deprecation := range cfg.(*config.MachineConfig).Config().Deprecations() {
+				deprecation := deprecation
+
+				if err = r.Modify(ctx, config.NewMachineConfigDeprecation(deprecation.Path), func(r resource.Resource) error {
+					spec := r.(*config.MachineConfigDeprecation).TypedSpec()
+
+					spec.Message = deprecation.Message
+					spec.Replacement = deprecation.Replacement
+
+					return nil
+				}); err != nil {
+					return fmt.Errorf("error updating deprecation: %w", err)
+				}
+
+				touchedIDs[deprecation.Path] = struct{}{}
+
+				logger.Warn("deprecated machine configuration", zap.Stringer("deprecation", deprecation))
+			}
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigDeprecationType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing resources: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+				if err = r.Destroy(ctx, res.Metadata()); err != nil {
+					return fmt.Errorf("error cleaning up deprecation: %w", err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	configctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/config"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+type DeprecationSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *DeprecationSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&configctrl.DeprecationController{}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *DeprecationSuite) assertDeprecations(expected map[resource.ID]config.MachineConfigDeprecationSpec) func() error {
+	return func() error {
+		list, err := suite.state.List(suite.ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigDeprecationType, "", resource.VersionUndefined))
+		if err != nil {
+			return err
+		}
+
+		if len(list.Items) != len(expected) {
+			return retry.ExpectedErrorf("expected %d deprecations, got %d", len(expected), len(list.Items))
+		}
+
+		for _, res := range list.Items {
+			spec, ok := expected[res.Metadata().ID()]
+			if !ok {
+				return retry.ExpectedErrorf("unexpected deprecation %q", res.Metadata().ID())
+			}
+
+			if *res.(*config.MachineConfigDeprecation).TypedSpec() != spec {
+				return retry.ExpectedErrorf("deprecation %q doesn't match", res.Metadata().ID())
+			}
+		}
+
+		return nil
+	}
+}
+
+func (suite *DeprecationSuite) TestReconcile() {
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "join",
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			EtcdConfig: &v1alpha1.EtcdConfig{
+				EtcdSubnet: "10.0.0.0/8",
+			},
+		},
+	})
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertDeprecations(map[resource.ID]config.MachineConfigDeprecationSpec{
+			".machine.type": {
+				Message:     `machine type "join" is deprecated`,
+				Replacement: `"worker"`,
+			},
+			".cluster.etcd.subnet": {
+				Message:     "field is deprecated",
+				Replacement: ".cluster.etcd.advertisedSubnets",
+			},
+		}),
+	))
+
+	// migrated config has no deprecations
+	newCfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "worker",
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+	newCfg.Metadata().SetVersion(cfg.Metadata().Version())
+	newCfg.Metadata().BumpVersion()
+	suite.Require().NoError(suite.state.Update(suite.ctx, cfg.Metadata().Version(), newCfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertDeprecations(map[resource.ID]config.MachineConfigDeprecationSpec{}),
+	))
+}
+
+func (suite *DeprecationSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestDeprecationSuite(t *testing.T) {
+	suite.Run(t, new(DeprecationSuite))
+}
@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+// FeatureStatusController reports effective state of the machine features.
+type FeatureStatusController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *FeatureStatusController) Name() string {
+	return "config.FeatureStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *FeatureStatusController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *FeatureStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: config.FeatureStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *FeatureStatusController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		touchedIDs := map[resource.ID]struct{}{}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		} else {
+			machineConfig := cfg.(*config.MachineConfig).Config().Machine()
+
+			for _, feature := range talosconfig.KnownFeatures {
+				feature := feature
+
+				if err = r.Modify(ctx, config.NewFeatureStatus(feature.Name), func(r resource.Resource) error {
+					spec := r.(*config.FeatureStatus).TypedSpec()
+
+					spec.Enabled, spec.Value = feature.EffectiveState(machineConfig)
+					spec.Deprecated = feature.Deprecated
+					spec.Description = feature.Description
+
+					return nil
+				}); err != nil {
+					return fmt.Errorf("error updating feature status: %w", err)
+				}
+
+				touchedIDs[feature.Name] = struct{}{}
+			}
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(config.NamespaceName, config.FeatureStatusType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing resources: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+				if err = r.Destroy(ctx, res.Metadata()); err != nil {
+					return fmt.Errorf("error cleaning up feature status: %w", err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	configctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/config"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+type FeatureStatusSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *FeatureStatusSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&configctrl.FeatureStatusController{}))
+
+	suite.startRuntime()
+}
+
+func (suite *FeatureStatusSuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *FeatureStatusSuite) assertFeature(id resource.ID, enabled bool, value string) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, resource.NewMetadata(config.NamespaceName, config.FeatureStatusType, id, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		spec := r.(*config.FeatureStatus).TypedSpec()
+
+		if spec.Enabled != enabled || spec.Value != value {
+			return retry.ExpectedErrorf("feature %q: expected %v/%q, got %v/%q", id, enabled, value, spec.Enabled, spec.Value)
+		}
+
+		return nil
+	}
+}
+
+func (suite *FeatureStatusSuite) TestReconcile() {
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineFeatures: &v1alpha1.FeaturesConfig{
+				RBAC:              pointer.ToBool(true),
+				APIDTLSMinVersion: "1.3",
+			},
+			MachineKubelet: &v1alpha1.KubeletConfig{
+				KubeletDefaultRuntimeSeccompProfileEnabled: true,
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	for _, tt := range []struct {
+		id      resource.ID
+		enabled bool
+		value   string
+	}{
+		{"rbac", true, "true"},
+		{"kubeletTrustdCredentials", false, "false"},
+		{"kubeletDefaultRuntimeSeccompProfile", true, "true"}, // enabled via .machine.kubelet
+		{"apidTLSMinVersion", true, "1.3"},
+		{"stableHostname", false, "false"},
+		{"controlPlaneLoadBalancer", false, "false"},
+	} {
+		suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+			suite.assertFeature(tt.id, tt.enabled, tt.value),
+		))
+	}
+
+	suite.Require().NoError(suite.state.Destroy(suite.ctx, cfg.Metadata()))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			list, err := suite.state.List(suite.ctx, resource.NewMetadata(config.NamespaceName, config.FeatureStatusType, "", resource.VersionUndefined))
+			if err != nil {
+				return err
+			}
+
+			if len(list.Items) > 0 {
+				return retry.ExpectedErrorf("expected no feature statuses, got %d", len(list.Items))
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *FeatureStatusSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestFeatureStatusSuite(t *testing.T) {
+	suite.Run(t, new(FeatureStatusSuite))
+}
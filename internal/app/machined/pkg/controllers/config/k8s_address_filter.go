@@ -71,7 +71,7 @@ func (ctrl *K8sAddressFilterController) Run(ctx context.Context, r controller.Ru
 		if cfg != nil {
 			cfgProvider := cfg.(*config.MachineConfig).Config()
 
-			var podCIDRs, serviceCIDRs []netaddr.IPPrefix
+			var podCIDRs, serviceCIDRs, advertisedSubnets, ignoredSubnets []netaddr.IPPrefix
 
 			for _, cidr := range cfgProvider.Cluster().Network().PodCIDRs() {
 				var ipPrefix netaddr.IPPrefix
@@ -95,10 +95,35 @@ func (ctrl *K8sAddressFilterController) Run(ctx context.Context, r controller.Ru
 				serviceCIDRs = append(serviceCIDRs, ipPrefix)
 			}
 
+			for _, cidr := range cfgProvider.Machine().Network().AdvertisedSubnets() {
+				var ipPrefix netaddr.IPPrefix
+
+				ipPrefix, err = netaddr.ParseIPPrefix(cidr)
+				if err != nil {
+					return fmt.Errorf("error parsing advertised subnet: %w", err)
+				}
+
+				advertisedSubnets = append(advertisedSubnets, ipPrefix)
+			}
+
+			for _, cidr := range cfgProvider.Machine().Network().IgnoredSubnets() {
+				var ipPrefix netaddr.IPPrefix
+
+				ipPrefix, err = netaddr.ParseIPPrefix(cidr)
+				if err != nil {
+					return fmt.Errorf("error parsing ignored subnet: %w", err)
+				}
+
+				ignoredSubnets = append(ignoredSubnets, ipPrefix)
+			}
+
 			if err = r.Modify(ctx, network.NewNodeAddressFilter(network.NamespaceName, k8s.NodeAddressFilterNoK8s), func(r resource.Resource) error {
 				spec := r.(*network.NodeAddressFilter).TypedSpec()
 
-				spec.ExcludeSubnets = append(append([]netaddr.IPPrefix(nil), podCIDRs...), serviceCIDRs...)
+				// node addresses which are published (Kubernetes node IP, discovery, etcd advertised addresses)
+				// should match advertised subnets (if set), and they should never include Kubernetes or ignored subnets
+				spec.IncludeSubnets = append([]netaddr.IPPrefix(nil), advertisedSubnets...)
+				spec.ExcludeSubnets = append(append(append([]netaddr.IPPrefix(nil), podCIDRs...), serviceCIDRs...), ignoredSubnets...)
 
 				return nil
 			}); err != nil {
@@ -138,6 +138,55 @@ func (suite *K8sAddressFilterSuite) TestReconcile() {
 	))
 }
 
+func (suite *K8sAddressFilterSuite) TestReconcileAdvertisedSubnets() {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkAdvertisedSubnets: []string{
+					"172.20.0.0/16",
+				},
+				NetworkIgnoredSubnets: []string{
+					"172.20.1.0/24",
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+				ServiceSubnet: []string{
+					"10.200.0.0/22",
+				},
+				PodSubnet: []string{
+					"10.32.0.0/12",
+				},
+			},
+		},
+	})
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertResource(
+			resource.NewMetadata(network.NamespaceName, network.NodeAddressFilterType, k8s.NodeAddressFilterNoK8s, resource.VersionUndefined),
+			func(res resource.Resource) error {
+				spec := res.(*network.NodeAddressFilter).TypedSpec()
+
+				suite.Assert().Equal("[172.20.0.0/16]", fmt.Sprintf("%s", spec.IncludeSubnets))
+				suite.Assert().Equal("[10.32.0.0/12 10.200.0.0/22 172.20.1.0/24]", fmt.Sprintf("%s", spec.ExcludeSubnets))
+
+				return nil
+			},
+		),
+	))
+}
+
 func (suite *K8sAddressFilterSuite) TearDownTest() {
 	suite.T().Log("tear down")
 
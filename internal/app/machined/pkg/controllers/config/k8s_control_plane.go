@@ -165,13 +165,15 @@ func (ctrl *K8sControlPlaneController) manageControllerManagerConfig(ctx context
 
 	return r.Modify(ctx, config.NewK8sControlPlaneControllerManager(), func(r resource.Resource) error {
 		r.(*config.K8sControlPlane).SetControllerManager(config.K8sControlPlaneControllerManagerSpec{
-			Enabled:       !cfgProvider.Machine().Controlplane().ControllerManager().Disabled(),
-			Image:         cfgProvider.Cluster().ControllerManager().Image(),
-			CloudProvider: cloudProvider,
-			PodCIDRs:      cfgProvider.Cluster().Network().PodCIDRs(),
-			ServiceCIDRs:  cfgProvider.Cluster().Network().ServiceCIDRs(),
-			ExtraArgs:     cfgProvider.Cluster().ControllerManager().ExtraArgs(),
-			ExtraVolumes:  convertVolumes(cfgProvider.Cluster().ControllerManager().ExtraVolumes()),
+			Enabled:              !cfgProvider.Machine().Controlplane().ControllerManager().Disabled(),
+			Image:                cfgProvider.Cluster().ControllerManager().Image(),
+			CloudProvider:        cloudProvider,
+			PodCIDRs:             cfgProvider.Cluster().Network().PodCIDRs(),
+			ServiceCIDRs:         cfgProvider.Cluster().Network().ServiceCIDRs(),
+			NodeCIDRMaskSizeIPv4: cfgProvider.Cluster().Network().PodSubnetSizeIPv4(),
+			NodeCIDRMaskSizeIPv6: cfgProvider.Cluster().Network().PodSubnetSizeIPv6(),
+			ExtraArgs:            cfgProvider.Cluster().ControllerManager().ExtraArgs(),
+			ExtraVolumes:         convertVolumes(cfgProvider.Cluster().ControllerManager().ExtraVolumes()),
 		})
 
 		return nil
@@ -223,11 +225,13 @@ func (ctrl *K8sControlPlaneController) manageManifestsConfig(ctx context.Context
 		}
 
 		r.(*config.K8sControlPlane).SetManifests(config.K8sManifestsSpec{
-			Server:        cfgProvider.Cluster().Endpoint().String(),
+			Server:        cfgProvider.Cluster().InternalEndpoint().String(),
 			ClusterDomain: cfgProvider.Cluster().Network().DNSDomain(),
 
 			PodCIDRs: cfgProvider.Cluster().Network().PodCIDRs(),
 
+			StaticBootstrapTokenEnabled: !cfgProvider.Machine().Features().RetireStaticBootstrapTokenEnabled(),
+
 			ProxyEnabled: cfgProvider.Cluster().Proxy().Enabled(),
 			ProxyImage:   cfgProvider.Cluster().Proxy().Image(),
 			ProxyArgs:    proxyArgs,
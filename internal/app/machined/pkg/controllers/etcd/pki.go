@@ -0,0 +1,161 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.uber.org/zap"
+
+	etcdclient "github.com/talos-systems/talos/internal/pkg/etcd"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+// PKIController rotates the certificates of the running etcd member.
+//
+// The etcd service writes the certificates on start, this controller pushes the refreshed certificates
+// into the running member (etcd reloads the certificates on each TLS handshake, so no restart is required).
+// Members rotate the certificates one at a time under an etcd lock, and the member health is verified
+// with the new certificates before the lock is released.
+type PKIController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *PKIController) Name() string {
+	return "etcd.PKIController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *PKIController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.EtcdType,
+			ID:        pointer.ToString(secrets.EtcdID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ServiceType,
+			ID:        pointer.ToString("etcd"),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *PKIController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *PKIController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		etcdSecrets, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.EtcdType, secrets.EtcdID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting etcd secrets: %w", err)
+		}
+
+		// certificates are written by the etcd service itself when it starts
+		etcdService, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, v1alpha1.ServiceType, "etcd", resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting etcd service: %w", err)
+		}
+
+		if !etcdService.(*v1alpha1.Service).Running() {
+			continue
+		}
+
+		etcdCerts := etcdSecrets.(*secrets.Etcd).Certs()
+
+		if etcdclient.PKIUpToDate(etcdCerts) {
+			continue
+		}
+
+		if !etcdService.(*v1alpha1.Service).Healthy() {
+			// etcd lock can't be acquired, the member is not serving anyways
+			if err = etcdclient.WritePKI(etcdCerts); err != nil {
+				return fmt.Errorf("error writing etcd PKI: %w", err)
+			}
+
+			logger.Info("etcd certificates updated")
+
+			continue
+		}
+
+		if err = ctrl.rotate(ctx, logger, etcdCerts); err != nil {
+			return fmt.Errorf("error rotating etcd certificates: %w", err)
+		}
+	}
+}
+
+func (ctrl *PKIController) rotate(ctx context.Context, logger *zap.Logger, etcdCerts *secrets.EtcdCertsSpec) error {
+	etcdClient, err := etcdclient.NewSharedLocalClient()
+	if err != nil {
+		return fmt.Errorf("error creating etcd client: %w", err)
+	}
+
+	defer etcdClient.Close() //nolint:errcheck
+
+	session, err := concurrency.NewSession(etcdClient.Client)
+	if err != nil {
+		return fmt.Errorf("error creating etcd session: %w", err)
+	}
+
+	defer session.Close() //nolint:errcheck
+
+	mutex := concurrency.NewMutex(session, constants.EtcdTalosCertRotationMutex)
+
+	logger.Debug("waiting for mutex")
+
+	if err = mutex.Lock(ctx); err != nil {
+		return fmt.Errorf("error acquiring mutex: %w", err)
+	}
+
+	logger.Debug("mutex acquired")
+
+	defer mutex.Unlock(ctx) //nolint:errcheck
+
+	if err = etcdclient.WritePKI(etcdCerts); err != nil {
+		return fmt.Errorf("error writing etcd PKI: %w", err)
+	}
+
+	// a new client establishes a new TLS connection, so both the member and the client certificates are verified
+	checkClient, err := etcdclient.NewLocalClient()
+	if err != nil {
+		return fmt.Errorf("error creating etcd client with the new certificates: %w", err)
+	}
+
+	defer checkClient.Close() //nolint:errcheck
+
+	if err = checkClient.ValidateQuorum(ctx); err != nil {
+		return fmt.Errorf("etcd member is not healthy with the new certificates: %w", err)
+	}
+
+	logger.Info("etcd certificates rotated")
+
+	return nil
+}
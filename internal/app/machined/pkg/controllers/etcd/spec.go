@@ -0,0 +1,266 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+	"inet.af/netaddr"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/etcd"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+// SpecController computes etcd advertised and listen addresses based on machine configuration and node addresses.
+type SpecController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *SpecController) Name() string {
+	return "etcd.SpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *SpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineTypeType,
+			ID:        pointer.ToString(config.MachineTypeID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.NodeAddressType,
+			ID:        pointer.ToString(network.NodeAddressCurrentID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.NodeAddressType,
+			ID:        pointer.ToString(network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s)),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *SpecController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: etcd.SpecType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *SpecController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				if err = ctrl.teardownAll(ctx, r); err != nil {
+					return fmt.Errorf("error destroying resources: %w", err)
+				}
+
+				continue
+			}
+
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		machineTypeRes, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineTypeType, config.MachineTypeID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting machine type: %w", err)
+		}
+
+		if machineTypeRes.(*config.MachineType).MachineType() == machine.TypeWorker {
+			if err = ctrl.teardownAll(ctx, r); err != nil {
+				return fmt.Errorf("error destroying resources: %w", err)
+			}
+
+			continue
+		}
+
+		currentAddresses, err := r.Get(ctx, resource.NewMetadata(network.NamespaceName, network.NodeAddressType, network.NodeAddressCurrentID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting node addresses: %w", err)
+		}
+
+		publishedAddresses, err := r.Get(ctx,
+			resource.NewMetadata(network.NamespaceName, network.NodeAddressType, network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s), resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting node addresses: %w", err)
+		}
+
+		etcdConfig := cfg.(*config.MachineConfig).Config().Cluster().Etcd()
+
+		advertisedSubnets, err := parseSubnets(etcdConfig.AdvertisedSubnets())
+		if err != nil {
+			return fmt.Errorf("error parsing etcd advertised subnets: %w", err)
+		}
+
+		listenSubnets, err := parseSubnets(etcdConfig.ListenSubnets())
+		if err != nil {
+			return fmt.Errorf("error parsing etcd listen subnets: %w", err)
+		}
+
+		advertisedAddresses := filterAddresses(publishedAddresses.(*network.NodeAddress).TypedSpec().Addresses, advertisedSubnets)
+
+		if len(advertisedAddresses) == 0 {
+			if len(advertisedSubnets) > 0 {
+				logger.Warn("no node addresses match etcd advertised subnets", zap.Strings("subnets", etcdConfig.AdvertisedSubnets()))
+			}
+
+			continue
+		}
+
+		// without explicit subnets, advertise only the first address
+		if len(advertisedSubnets) == 0 {
+			advertisedAddresses = advertisedAddresses[:1]
+		}
+
+		var listenPeerAddresses, listenClientAddresses []netaddr.IP
+
+		if len(listenSubnets) == 0 {
+			// listen on all addresses
+			listenAddress := netaddr.IPv4(0, 0, 0, 0)
+
+			if hasIPv6(filterAddresses(currentAddresses.(*network.NodeAddress).TypedSpec().Addresses, nil)) {
+				listenAddress = netaddr.IPv6Unspecified()
+			}
+
+			listenPeerAddresses = []netaddr.IP{listenAddress}
+			listenClientAddresses = []netaddr.IP{listenAddress}
+		} else {
+			listenPeerAddresses = filterAddresses(currentAddresses.(*network.NodeAddress).TypedSpec().Addresses, listenSubnets)
+
+			if len(listenPeerAddresses) == 0 {
+				logger.Warn("no node addresses match etcd listen subnets", zap.Strings("subnets", etcdConfig.ListenSubnets()))
+
+				continue
+			}
+
+			// local clients (e.g. kube-apiserver) always access etcd via the loopback address
+			listenClientAddresses = append([]netaddr.IP{netaddr.IPv4(127, 0, 0, 1)}, listenPeerAddresses...)
+
+			if hasIPv6(listenPeerAddresses) {
+				listenClientAddresses = append(listenClientAddresses, netaddr.MustParseIP("::1"))
+			}
+		}
+
+		if err = r.Modify(ctx, etcd.NewSpec(etcd.NamespaceName, etcd.SpecID), func(r resource.Resource) error {
+			spec := r.(*etcd.Spec).TypedSpec()
+
+			spec.AdvertisedAddresses = advertisedAddresses
+			spec.ListenPeerAddresses = listenPeerAddresses
+			spec.ListenClientAddresses = listenClientAddresses
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating etcd spec: %w", err)
+		}
+	}
+}
+
+func (ctrl *SpecController) teardownAll(ctx context.Context, r controller.Runtime) error {
+	list, err := r.List(ctx, resource.NewMetadata(etcd.NamespaceName, etcd.SpecType, "", resource.VersionUndefined))
+	if err != nil {
+		return err
+	}
+
+	for _, res := range list.Items {
+		if res.Metadata().Owner() == ctrl.Name() {
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseSubnets(subnets []string) ([]netaddr.IPPrefix, error) {
+	result := make([]netaddr.IPPrefix, 0, len(subnets))
+
+	for _, subnet := range subnets {
+		ipPrefix, err := netaddr.ParseIPPrefix(subnet)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, ipPrefix)
+	}
+
+	return result, nil
+}
+
+// filterAddresses returns addresses which match any of the subnets (all addresses if subnets are empty).
+func filterAddresses(addrs []netaddr.IPPrefix, subnets []netaddr.IPPrefix) []netaddr.IP {
+	result := make([]netaddr.IP, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if len(subnets) == 0 {
+			result = append(result, addr.IP())
+
+			continue
+		}
+
+		for _, subnet := range subnets {
+			if subnet.Contains(addr.IP()) {
+				result = append(result, addr.IP())
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func hasIPv6(addrs []netaddr.IP) bool {
+	for _, addr := range addrs {
+		if addr.Is6() {
+			return true
+		}
+	}
+
+	return false
+}
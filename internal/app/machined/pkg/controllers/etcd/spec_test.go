@@ -0,0 +1,167 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	"inet.af/netaddr"
+
+	etcdctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/etcd"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/etcd"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+type SpecSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *SpecSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&etcdctrl.SpecController{}))
+
+	suite.startRuntime()
+}
+
+func (suite *SpecSuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *SpecSuite) assertSpec(check func(spec *etcd.SpecSpec) error) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, resource.NewMetadata(etcd.NamespaceName, etcd.SpecType, etcd.SpecID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		return check(r.(*etcd.Spec).TypedSpec())
+	}
+}
+
+func (suite *SpecSuite) setup(etcdConfig *v1alpha1.EtcdConfig) {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			EtcdConfig: etcdConfig,
+		},
+	})
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	machineType := config.NewMachineType()
+	machineType.SetMachineType(machine.TypeControlPlane)
+	suite.Require().NoError(suite.state.Create(suite.ctx, machineType))
+
+	current := network.NewNodeAddress(network.NamespaceName, network.NodeAddressCurrentID)
+	current.TypedSpec().Addresses = []netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("10.0.0.5/24"),
+		netaddr.MustParseIPPrefix("172.20.0.2/24"),
+		netaddr.MustParseIPPrefix("192.168.3.4/24"),
+	}
+	suite.Require().NoError(suite.state.Create(suite.ctx, current))
+
+	// 192.168.3.4 is not published
+	published := network.NewNodeAddress(network.NamespaceName, network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s))
+	published.TypedSpec().Addresses = []netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("10.0.0.5/24"),
+		netaddr.MustParseIPPrefix("172.20.0.2/24"),
+	}
+	suite.Require().NoError(suite.state.Create(suite.ctx, published))
+}
+
+func (suite *SpecSuite) TestReconcileDefaults() {
+	suite.setup(nil)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertSpec(func(spec *etcd.SpecSpec) error {
+			suite.Assert().Equal("[10.0.0.5]", fmt.Sprintf("%s", spec.AdvertisedAddresses))
+			suite.Assert().Equal("[0.0.0.0]", fmt.Sprintf("%s", spec.ListenPeerAddresses))
+			suite.Assert().Equal("[0.0.0.0]", fmt.Sprintf("%s", spec.ListenClientAddresses))
+
+			return nil
+		}),
+	))
+}
+
+func (suite *SpecSuite) TestReconcileSubnets() {
+	suite.setup(&v1alpha1.EtcdConfig{
+		EtcdAdvertisedSubnets: []string{"172.20.0.0/16", "192.168.0.0/16"},
+		EtcdListenSubnets:     []string{"172.20.0.0/16", "192.168.0.0/16"},
+	})
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertSpec(func(spec *etcd.SpecSpec) error {
+			suite.Assert().Equal("[172.20.0.2]", fmt.Sprintf("%s", spec.AdvertisedAddresses))
+			suite.Assert().Equal("[172.20.0.2 192.168.3.4]", fmt.Sprintf("%s", spec.ListenPeerAddresses))
+			suite.Assert().Equal("[127.0.0.1 172.20.0.2 192.168.3.4]", fmt.Sprintf("%s", spec.ListenClientAddresses))
+
+			return nil
+		}),
+	))
+}
+
+func (suite *SpecSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestSpecSuite(t *testing.T) {
+	suite.Run(t, new(SpecSuite))
+}
@@ -7,6 +7,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"net"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -65,7 +66,7 @@ func (ctrl *ControlPlaneStaticPodController) Outputs() []controller.Output {
 	return []controller.Output{
 		{
 			Type: k8s.StaticPodType,
-			Kind: controller.OutputExclusive,
+			Kind: controller.OutputShared,
 		},
 	}
 }
@@ -161,6 +162,10 @@ func (ctrl *ControlPlaneStaticPodController) Run(ctx context.Context, r controll
 			}
 
 			for _, res := range list.Items {
+				if res.Metadata().Owner() != ctrl.Name() {
+					continue
+				}
+
 				if _, ok := touchedIDs[res.Metadata().ID()]; ok {
 					continue
 				}
@@ -182,6 +187,10 @@ func (ctrl *ControlPlaneStaticPodController) teardownAll(ctx context.Context, r
 	// TODO: change this to proper teardown sequence
 
 	for _, res := range list.Items {
+		if res.Metadata().Owner() != ctrl.Name() {
+			continue
+		}
+
 		if err = r.Destroy(ctx, res.Metadata()); err != nil {
 			return err
 		}
@@ -221,6 +230,23 @@ func volumes(volumes []config.K8sExtraVolume) []v1.Volume {
 	return result
 }
 
+func podCIDRFamilies(cidrs []string) (hasIPv4, hasIPv6 bool) {
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+
+		if ip.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+	}
+
+	return hasIPv4, hasIPv6
+}
+
 func (ctrl *ControlPlaneStaticPodController) manageAPIServer(ctx context.Context, r controller.Runtime, logger *zap.Logger,
 	configResource *config.K8sControlPlane, secretsVersion string) (string, error) {
 	cfg := configResource.APIServer()
@@ -390,8 +416,10 @@ func (ctrl *ControlPlaneStaticPodController) manageControllerManager(ctx context
 		"--use-service-account-credentials",
 	}
 
+	// range allocator keeps the pod CIDRs pre-assigned to the nodes with .machine.kubelet.podCIDRs
 	builder := argsbuilder.Args{
 		"allocate-node-cidrs":              "true",
+		"cidr-allocator-type":              "RangeAllocator",
 		"bind-address":                     "127.0.0.1",
 		"port":                             "0",
 		"cluster-cidr":                     strings.Join(cfg.PodCIDRs, ","),
@@ -413,6 +441,17 @@ func (ctrl *ControlPlaneStaticPodController) manageControllerManager(ctx context
 		builder.Set("cloud-provider", cfg.CloudProvider)
 	}
 
+	// kube-controller-manager refuses per-family mask size flags if the pod subnets don't contain that family
+	hasIPv4, hasIPv6 := podCIDRFamilies(cfg.PodCIDRs)
+
+	if cfg.NodeCIDRMaskSizeIPv4 > 0 && hasIPv4 {
+		builder.Set("node-cidr-mask-size-ipv4", strconv.Itoa(cfg.NodeCIDRMaskSizeIPv4))
+	}
+
+	if cfg.NodeCIDRMaskSizeIPv6 > 0 && hasIPv6 {
+		builder.Set("node-cidr-mask-size-ipv6", strconv.Itoa(cfg.NodeCIDRMaskSizeIPv6))
+	}
+
 	mergePolicies := argsbuilder.MergePolicies{
 		"service-cluster-ip-range": argsbuilder.MergeAdditive,
 		"controllers":              argsbuilder.MergeAdditive,
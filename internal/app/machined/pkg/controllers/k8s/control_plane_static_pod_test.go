@@ -212,6 +212,44 @@ func (suite *ControlPlaneStaticPodSuite) TestReconcileExtraMounts() {
 	}, apiServerPod.Spec.Containers[0].VolumeMounts[1])
 }
 
+func (suite *ControlPlaneStaticPodSuite) TestReconcileNodeCIDRMaskSize() {
+	secretStatus := k8s.NewSecretsStatus(k8s.ControlPlaneNamespaceName, k8s.StaticPodSecretsStaticPodID)
+	configControllerManager := config.NewK8sControlPlaneControllerManager()
+	configControllerManager.SetControllerManager(config.K8sControlPlaneControllerManagerSpec{
+		Enabled:              true,
+		PodCIDRs:             []string{"10.244.0.0/16"},
+		ServiceCIDRs:         []string{"10.96.0.0/12"},
+		NodeCIDRMaskSizeIPv4: 26,
+		NodeCIDRMaskSizeIPv6: 80,
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, secretStatus))
+	suite.Require().NoError(suite.state.Create(suite.ctx, configControllerManager))
+
+	suite.Assert().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertControlPlaneStaticPods(
+				[]string{
+					"kube-controller-manager",
+				},
+			)
+		},
+	))
+
+	r, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "kube-controller-manager", resource.VersionUndefined))
+	suite.Require().NoError(err)
+
+	controllerManagerPod, err := k8sadapter.StaticPod(r.(*k8s.StaticPod)).Pod()
+	suite.Require().NoError(err)
+
+	suite.Require().NotEmpty(controllerManagerPod.Spec.Containers)
+
+	// IPv6 mask size is skipped, as there's no IPv6 pod subnet
+	suite.Assert().Contains(controllerManagerPod.Spec.Containers[0].Command, "--node-cidr-mask-size-ipv4=26")
+	suite.Assert().NotContains(controllerManagerPod.Spec.Containers[0].Command, "--node-cidr-mask-size-ipv6=80")
+	suite.Assert().Contains(controllerManagerPod.Spec.Containers[0].Command, "--cidr-allocator-type=RangeAllocator")
+}
+
 func (suite *ControlPlaneStaticPodSuite) TestReconcileExtraArgs() {
 	tests := []struct {
 		args        map[string]string
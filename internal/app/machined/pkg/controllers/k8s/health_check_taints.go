@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/conditions"
+	"github.com/talos-systems/talos/pkg/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// node taints are reapplied periodically, as the node might be re-registered.
+const healthCheckTaintsResyncInterval = 5 * time.Minute
+
+// HealthCheckTaintsController taints the Kubernetes node while the user-defined health checks are unhealthy.
+type HealthCheckTaintsController struct {
+	kubernetesClient *kubernetes.Client
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *HealthCheckTaintsController) Name() string {
+	return "k8s.HealthCheckTaintsController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *HealthCheckTaintsController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: runtime.NamespaceName,
+			Type:      runtime.HealthCheckStatusType,
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.NodenameType,
+			ID:        pointer.ToString(k8s.NodenameID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *HealthCheckTaintsController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *HealthCheckTaintsController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	defer func() {
+		if ctrl.kubernetesClient != nil {
+			ctrl.kubernetesClient.Close() //nolint:errcheck
+		}
+
+		ctrl.kubernetesClient = nil
+	}()
+
+	ticker := time.NewTicker(healthCheckTaintsResyncInterval)
+	defer ticker.Stop()
+
+	// health check statuses are updated on every check, taints are applied only if they change
+	var applied []string
+
+	for {
+		resync := false
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+			resync = true
+		}
+
+		nodename, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodenameType, k8s.NodenameID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting nodename: %w", err)
+			}
+
+			continue
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.HealthCheckStatusType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing health check statuses: %w", err)
+		}
+
+		taints := []string{}
+
+		for _, res := range list.Items {
+			spec := res.(*runtime.HealthCheckStatus).TypedSpec()
+
+			if spec.Taint && !spec.Healthy {
+				taints = append(taints, constants.HealthCheckTaintPrefix+res.Metadata().ID())
+			}
+		}
+
+		sort.Strings(taints)
+
+		if !resync && reflect.DeepEqual(taints, applied) {
+			continue
+		}
+
+		if len(taints) == 0 {
+			// nothing to apply, but the taints applied before should be removed if the node is already registered
+			if _, err = os.Stat(constants.KubeletKubeconfig); err != nil {
+				continue
+			}
+		} else if err = conditions.WaitForKubeconfigReady(constants.KubeletKubeconfig).Wait(ctx); err != nil {
+			return err
+		}
+
+		if ctrl.kubernetesClient == nil {
+			ctrl.kubernetesClient, err = kubernetes.NewClientFromKubeletKubeconfig()
+			if err != nil {
+				return fmt.Errorf("error building kubernetes client: %w", err)
+			}
+		}
+
+		if err = ctrl.kubernetesClient.ApplyNodeTaints(ctx, nodename.(*k8s.Nodename).TypedSpec().Nodename, constants.HealthCheckTaintPrefix, taints); err != nil {
+			// reset client connection
+			ctrl.kubernetesClient.Close() //nolint:errcheck
+			ctrl.kubernetesClient = nil
+
+			return fmt.Errorf("error applying node taints: %w", err)
+		}
+
+		if !reflect.DeepEqual(taints, applied) {
+			logger.Info("applied health check node taints", zap.Strings("taints", taints))
+		}
+
+		applied = taints
+	}
+}
@@ -0,0 +1,260 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+// KubeletBootstrapKubeconfigController writes the kubelet bootstrap kubeconfig and keeps it up to date with the cluster bootstrap token,
+// CA and API server endpoint.
+//
+// The controller is the only writer of the bootstrap kubeconfig, kubelet service waits for it to be written before the start.
+// Kubelet reads the bootstrap kubeconfig only on start, so the kubelet which is not bootstrapped yet is restarted
+// to pick up the updated kubeconfig. If the cluster CA changed, kubelet client credentials issued by the previous CA
+// are removed, so that kubelet is bootstrapped again.
+type KubeletBootstrapKubeconfigController struct {
+	// RestartKubelet defaults to restarting the kubelet service.
+	RestartKubelet func(ctx context.Context) error
+	// Now is used to override current time in the tests.
+	Now func() time.Time
+
+	// BootstrapKubeconfigPath defaults to constants.KubeletBootstrapKubeconfig.
+	BootstrapKubeconfigPath string
+	// KubeconfigPath defaults to constants.KubeletKubeconfig.
+	KubeconfigPath string
+	// PKIDir defaults to constants.KubeletPKIDir.
+	PKIDir string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletBootstrapKubeconfigController) Name() string {
+	return "k8s.KubeletBootstrapKubeconfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletBootstrapKubeconfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.KubeletBootstrapTokenType,
+			ID:        pointer.ToString(secrets.KubeletBootstrapTokenID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ServiceType,
+			ID:        pointer.ToString("cri"),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ServiceType,
+			ID:        pointer.ToString("kubelet"),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletBootstrapKubeconfigController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *KubeletBootstrapKubeconfigController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		if err := ctrl.reconcile(ctx, r, logger); err != nil {
+			return err
+		}
+	}
+}
+
+//nolint:gocyclo
+func (ctrl *KubeletBootstrapKubeconfigController) reconcile(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	cfgProvider := cfg.(*config.MachineConfig).Config()
+
+	// standalone kubelet doesn't use the bootstrap kubeconfig, and it can't be rendered without the cluster CA
+	if cfgProvider.Machine().Kubelet().Standalone() || cfgProvider.Cluster().CA() == nil {
+		return nil
+	}
+
+	existing, err := ioutil.ReadFile(ctrl.bootstrapKubeconfigPath())
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading kubelet bootstrap kubeconfig: %w", err)
+	}
+
+	exists := err == nil
+
+	if !exists {
+		// kubelet depends on the CRI, which is started once /etc/kubernetes is mounted, so wait for it before writing there
+		if running, err := ctrl.serviceRunning(ctx, r, "cri"); err != nil || !running {
+			return err
+		}
+	}
+
+	var shortLivedToken *secrets.KubeletBootstrapTokenSpec
+
+	tokenRes, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubeletBootstrapTokenType, secrets.KubeletBootstrapTokenID, resource.VersionUndefined))
+	if err != nil {
+		if !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting kubelet bootstrap token: %w", err)
+		}
+	} else {
+		shortLivedToken = tokenRes.(*secrets.KubeletBootstrapToken).TypedSpec()
+	}
+
+	kubeconfig, err := kubelet.BootstrapKubeconfig(cfgProvider, shortLivedToken, ctrl.now())
+	if err != nil {
+		return fmt.Errorf("error rendering kubelet bootstrap kubeconfig: %w", err)
+	}
+
+	if exists && bytes.Equal(existing, kubeconfig) {
+		return nil
+	}
+
+	// kubelet is started as soon as the bootstrap kubeconfig exists, so it should never see a partially written file
+	tmpPath := ctrl.bootstrapKubeconfigPath() + ".tmp"
+
+	if err = ioutil.WriteFile(tmpPath, kubeconfig, 0o600); err != nil {
+		return fmt.Errorf("error writing kubelet bootstrap kubeconfig: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, ctrl.bootstrapKubeconfigPath()); err != nil {
+		return fmt.Errorf("error writing kubelet bootstrap kubeconfig: %w", err)
+	}
+
+	logger.Info("kubelet bootstrap kubeconfig updated")
+
+	ca, err := kubelet.KubeconfigCA(ctrl.kubeconfigPath())
+	if err != nil {
+		return fmt.Errorf("error reading kubelet kubeconfig: %w", err)
+	}
+
+	if ca != nil && !bytes.Equal(ca, cfgProvider.Cluster().CA().Crt) {
+		removed, err := kubelet.RemoveClientCredentials(ctrl.pkiDir(), ctrl.kubeconfigPath())
+		if err != nil {
+			return fmt.Errorf("error removing stale kubelet client credentials: %w", err)
+		}
+
+		logger.Warn("cluster CA changed, removed kubelet client credentials", zap.Strings("removed", removed))
+
+		ca = nil
+	}
+
+	if ca != nil {
+		// kubelet is already bootstrapped, the bootstrap kubeconfig is used only on the next re-bootstrap
+		return nil
+	}
+
+	if !exists {
+		// kubelet waits for the bootstrap kubeconfig to be written, so it's not running yet
+		return nil
+	}
+
+	if running, err := ctrl.serviceRunning(ctx, r, "kubelet"); err != nil || !running {
+		return err
+	}
+
+	logger.Info("restarting kubelet to bootstrap with the updated kubeconfig")
+
+	if err = ctrl.restartKubelet(ctx); err != nil {
+		// the kubelet is restarted on the next bootstrap kubeconfig update
+		logger.Error("error restarting kubelet", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (ctrl *KubeletBootstrapKubeconfigController) serviceRunning(ctx context.Context, r controller.Runtime, id string) (bool, error) {
+	res, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, v1alpha1.ServiceType, id, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return res.(*v1alpha1.Service).Running(), nil
+}
+
+func (ctrl *KubeletBootstrapKubeconfigController) restartKubelet(ctx context.Context) error {
+	if ctrl.RestartKubelet != nil {
+		return ctrl.RestartKubelet(ctx)
+	}
+
+	return restartKubelet(ctx)
+}
+
+func (ctrl *KubeletBootstrapKubeconfigController) now() time.Time {
+	if ctrl.Now != nil {
+		return ctrl.Now()
+	}
+
+	return time.Now()
+}
+
+func (ctrl *KubeletBootstrapKubeconfigController) bootstrapKubeconfigPath() string {
+	if ctrl.BootstrapKubeconfigPath != "" {
+		return ctrl.BootstrapKubeconfigPath
+	}
+
+	return constants.KubeletBootstrapKubeconfig
+}
+
+func (ctrl *KubeletBootstrapKubeconfigController) kubeconfigPath() string {
+	if ctrl.KubeconfigPath != "" {
+		return ctrl.KubeconfigPath
+	}
+
+	return constants.KubeletKubeconfig
+}
+
+func (ctrl *KubeletBootstrapKubeconfigController) pkiDir() string {
+	if ctrl.PKIDir != "" {
+		return ctrl.PKIDir
+	}
+
+	return constants.KubeletPKIDir
+}
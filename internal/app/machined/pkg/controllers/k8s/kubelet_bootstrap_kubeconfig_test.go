@@ -0,0 +1,307 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/crypto/x509"
+	"github.com/talos-systems/go-retry/retry"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+	v1alpha1resource "github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+type KubeletBootstrapKubeconfigSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	bootstrapKubeconfigPath string
+	kubeconfigPath          string
+	pkiDir                  string
+	recoveries              *kubeletRecoveries
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	dir := suite.T().TempDir()
+
+	suite.bootstrapKubeconfigPath = filepath.Join(dir, "bootstrap-kubeconfig")
+	suite.kubeconfigPath = filepath.Join(dir, "kubeconfig")
+	suite.pkiDir = filepath.Join(dir, "pki")
+	suite.recoveries = &kubeletRecoveries{
+		now: time.Now(),
+	}
+
+	suite.Require().NoError(os.Mkdir(suite.pkiDir, 0o700))
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.KubeletBootstrapKubeconfigController{
+		RestartKubelet:          suite.recoveries.restart,
+		Now:                     suite.recoveries.Now,
+		BootstrapKubeconfigPath: suite.bootstrapKubeconfigPath,
+		KubeconfigPath:          suite.kubeconfigPath,
+		PKIDir:                  suite.pkiDir,
+	}))
+
+	for _, id := range []string{"cri", "kubelet"} {
+		service := v1alpha1resource.NewService(id)
+		service.SetRunning(true)
+
+		suite.Require().NoError(suite.state.Create(suite.ctx, service))
+	}
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) newConfig(ca string) *config.MachineConfig {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	return config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "worker",
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			ClusterCA: &x509.PEMEncodedCertificateAndKey{
+				Crt: []byte(ca),
+			},
+		},
+	})
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) updateConfig(cfg *config.MachineConfig, ca string) {
+	oldVersion := cfg.Metadata().Version()
+
+	cfg.Config().Cluster().(*v1alpha1.ClusterConfig).ClusterCA.Crt = []byte(ca)
+	cfg.Metadata().BumpVersion()
+
+	suite.Require().NoError(suite.state.Update(suite.ctx, oldVersion, cfg))
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) setToken(token string) {
+	parts := strings.SplitN(token, ".", 2)
+
+	spec := secrets.KubeletBootstrapTokenSpec{
+		TokenID:     parts[0],
+		TokenSecret: parts[1],
+		Expiration:  suite.recoveries.Now().Add(time.Hour),
+	}
+
+	existing, err := suite.state.Get(suite.ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubeletBootstrapTokenType, secrets.KubeletBootstrapTokenID, resource.VersionUndefined))
+	if state.IsNotFoundError(err) {
+		tokenRes := secrets.NewKubeletBootstrapToken(secrets.KubeletBootstrapTokenID)
+		*tokenRes.TypedSpec() = spec
+
+		suite.Require().NoError(suite.state.Create(suite.ctx, tokenRes))
+
+		return
+	}
+
+	suite.Require().NoError(err)
+
+	tokenRes := existing.(*secrets.KubeletBootstrapToken)
+	oldVersion := tokenRes.Metadata().Version()
+
+	*tokenRes.TypedSpec() = spec
+	tokenRes.Metadata().BumpVersion()
+
+	suite.Require().NoError(suite.state.Update(suite.ctx, oldVersion, tokenRes))
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) assertBootstrapKubeconfig(token, ca string) func() error {
+	return func() error {
+		contents, err := ioutil.ReadFile(suite.bootstrapKubeconfigPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		if !strings.Contains(string(contents), "token: "+token+"\n") {
+			return retry.ExpectedErrorf("token %q not found in bootstrap kubeconfig", token)
+		}
+
+		if !strings.Contains(string(contents), "certificate-authority-data: "+base64.StdEncoding.EncodeToString([]byte(ca))+"\n") {
+			return retry.ExpectedErrorf("CA not found in bootstrap kubeconfig")
+		}
+
+		return nil
+	}
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) assertRestarts(expected int) func() error {
+	return func() error {
+		if restarts, _ := suite.recoveries.get(); restarts != expected {
+			return retry.ExpectedErrorf("expected %d kubelet restarts, got %d", expected, restarts)
+		}
+
+		return nil
+	}
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) writeKubeconfig(ca string) {
+	suite.Require().NoError(ioutil.WriteFile(suite.kubeconfigPath, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: default-cluster
+  cluster:
+    server: https://foo:6443
+    certificate-authority-data: `+base64.StdEncoding.EncodeToString([]byte(ca))+`
+users:
+- name: default-auth
+  user:
+    client-certificate: `+filepath.Join(suite.pkiDir, "kubelet-client-current.pem")+`
+contexts:
+- context:
+    cluster: default-cluster
+    user: default-auth
+  name: default-context
+current-context: default-context
+`), 0o600))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.pkiDir, "kubelet-client-current.pem"), nil, 0o600))
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) TestNotBootstrapped() {
+	cfg := suite.newConfig("ca1")
+	cfg.Config().Cluster().(*v1alpha1.ClusterConfig).BootstrapToken = "abcdef.0123456789abcdef"
+	cfg.Config().Machine().(*v1alpha1.MachineConfig).MachineFeatures = &v1alpha1.FeaturesConfig{
+		RetireStaticBootstrapToken: pointer.ToBool(true),
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	// kubelet waits for the bootstrap kubeconfig, so it is written before the token is available
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		contents, err := ioutil.ReadFile(suite.bootstrapKubeconfigPath)
+		if err != nil {
+			return retry.ExpectedError(err)
+		}
+
+		if strings.Contains(string(contents), "token:") {
+			return fmt.Errorf("unexpected token in bootstrap kubeconfig")
+		}
+
+		return nil
+	}))
+
+	suite.Assert().NoError(suite.assertRestarts(0)())
+
+	suite.setToken("ghijkl.0123456789abcdef")
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertBootstrapKubeconfig("ghijkl.0123456789abcdef", "ca1")))
+
+	// kubelet is not bootstrapped yet, so it's restarted to pick up the new token
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertRestarts(1)))
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) TestStaticTokenFallback() {
+	cfg := suite.newConfig("ca1")
+	cfg.Config().Cluster().(*v1alpha1.ClusterConfig).BootstrapToken = "abcdef.0123456789abcdef"
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	// static token is used until the short-lived token is available
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertBootstrapKubeconfig("abcdef.0123456789abcdef", "ca1")))
+
+	suite.setToken("ghijkl.0123456789abcdef")
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertBootstrapKubeconfig("ghijkl.0123456789abcdef", "ca1")))
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) TestBootstrapped() {
+	suite.Require().NoError(ioutil.WriteFile(suite.bootstrapKubeconfigPath, []byte("stale"), 0o600))
+	suite.writeKubeconfig("ca1")
+
+	cfg := suite.newConfig("ca1")
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+	suite.setToken("abcdef.0123456789abcdef")
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertBootstrapKubeconfig("abcdef.0123456789abcdef", "ca1")))
+
+	// token rotation doesn't affect the bootstrapped kubelet
+	suite.setToken("ghijkl.0123456789abcdef")
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertBootstrapKubeconfig("ghijkl.0123456789abcdef", "ca1")))
+	suite.Assert().NoError(suite.assertRestarts(0)())
+
+	_, err := os.Stat(suite.kubeconfigPath)
+	suite.Assert().NoError(err)
+
+	// CA rotation invalidates the kubelet client credentials
+	suite.updateConfig(cfg, "ca2")
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertBootstrapKubeconfig("ghijkl.0123456789abcdef", "ca2")))
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertRestarts(1)))
+
+	_, err = os.Stat(suite.kubeconfigPath)
+	suite.Assert().True(os.IsNotExist(err))
+
+	_, err = os.Stat(filepath.Join(suite.pkiDir, "kubelet-client-current.pem"))
+	suite.Assert().True(os.IsNotExist(err))
+}
+
+func (suite *KubeletBootstrapKubeconfigSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKubeletBootstrapKubeconfigSuite(t *testing.T) {
+	suite.Run(t, new(KubeletBootstrapKubeconfigSuite))
+}
@@ -0,0 +1,445 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+	yaml "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/grpc/gen"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+)
+
+// kubeletBootstrapTokenRetryInterval is the delay before retrying to obtain a token after a failure.
+const kubeletBootstrapTokenRetryInterval = 30 * time.Second
+
+// KubeletBootstrapTokenController provides short-lived kubelet bootstrap tokens.
+//
+// Control plane nodes mint the tokens via Kubernetes API: tokens are created as bootstrap token secrets
+// in the kube-system namespace and are refreshed when half of their lifetime has passed; expired secrets
+// are removed by the tokencleaner controller. The current token is passed to trustd. The bootstrap token
+// from the machine configuration is removed from the cluster only once it is retired
+// with `.machine.features.retireStaticBootstrapToken`, so that it is no longer accepted.
+//
+// Worker nodes fetch the current token from trustd running on the control plane nodes.
+type KubeletBootstrapTokenController struct {
+	// V1Alpha1Platform is used to fetch the instance identity document for the trustd requests.
+	V1Alpha1Platform v1alpha1runtime.Platform
+
+	// Now is used to override current time in the tests.
+	Now func() time.Time
+
+	// TrustdTokenPath defaults to constants.TrustdKubeletBootstrapToken.
+	TrustdTokenPath string
+
+	// KubernetesClient is used to override the Kubernetes client built from the admin kubeconfig in the tests.
+	KubernetesClient func(adminKubeconfig string) (kubernetes.Interface, error)
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletBootstrapTokenController) Name() string {
+	return "k8s.KubeletBootstrapTokenController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletBootstrapTokenController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.KubernetesType,
+			ID:        pointer.ToString(secrets.KubernetesID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.EndpointType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletBootstrapTokenController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: secrets.KubeletBootstrapTokenType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *KubeletBootstrapTokenController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	var (
+		timerCh            <-chan time.Time
+		refreshAt          time.Time
+		staticTokenRemoved bool
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-timerCh:
+		}
+
+		timerCh = nil
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		secretsRes, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubernetesType, secrets.KubernetesID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting kubernetes secrets: %w", err)
+		}
+
+		var obtain func(ctx context.Context) (secrets.KubeletBootstrapTokenSpec, error)
+
+		switch {
+		case cfg == nil:
+			// config is not loaded yet
+		case secretsRes != nil:
+			adminKubeconfig := secretsRes.(*secrets.Kubernetes).Certs().AdminKubeconfig
+
+			obtain = func(ctx context.Context) (secrets.KubeletBootstrapTokenSpec, error) {
+				return ctrl.mint(ctx, adminKubeconfig)
+			}
+		case cfg.(*config.MachineConfig).Config().Machine().Type() == machine.TypeWorker && !cfg.(*config.MachineConfig).Config().Machine().Kubelet().Standalone():
+			cfgProvider := cfg.(*config.MachineConfig).Config()
+
+			obtain = func(ctx context.Context) (secrets.KubeletBootstrapTokenSpec, error) {
+				return ctrl.fetch(ctx, r, cfgProvider)
+			}
+		}
+
+		if obtain == nil {
+			// control plane secrets are not ready yet, or the kubelet doesn't need a bootstrap token, remove the token
+			if err = ctrl.teardown(ctx, r); err != nil {
+				return err
+			}
+
+			refreshAt = time.Time{}
+
+			continue
+		}
+
+		if refreshAt.IsZero() {
+			tokenRes, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubeletBootstrapTokenType, secrets.KubeletBootstrapTokenID, resource.VersionUndefined))
+			if err != nil && !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting kubelet bootstrap token: %w", err)
+			}
+
+			if tokenRes != nil {
+				refreshAt = tokenRes.(*secrets.KubeletBootstrapToken).TypedSpec().Expiration.Add(-constants.KubeletBootstrapTokenTTL / 2)
+			}
+		}
+
+		if remaining := refreshAt.Sub(ctrl.now()); remaining > 0 {
+			timerCh = time.After(remaining)
+
+			staticTokenRemoved = ctrl.retireStaticToken(ctx, logger, cfg, secretsRes, staticTokenRemoved)
+
+			continue
+		}
+
+		spec, err := obtain(ctx)
+		if err != nil {
+			logger.Warn("failed to obtain kubelet bootstrap token", zap.Error(err))
+
+			timerCh = time.After(kubeletBootstrapTokenRetryInterval)
+
+			continue
+		}
+
+		if err = r.Modify(ctx, secrets.NewKubeletBootstrapToken(secrets.KubeletBootstrapTokenID), func(r resource.Resource) error {
+			*r.(*secrets.KubeletBootstrapToken).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating kubelet bootstrap token: %w", err)
+		}
+
+		logger.Debug("updated kubelet bootstrap token", zap.String("token_id", spec.TokenID), zap.Time("expiration", spec.Expiration))
+
+		// token fetched from trustd might be close to the refresh time already, so don't refresh more often than on retries
+		refreshAt = spec.Expiration.Add(-constants.KubeletBootstrapTokenTTL / 2)
+
+		if minRefreshAt := ctrl.now().Add(kubeletBootstrapTokenRetryInterval); refreshAt.Before(minRefreshAt) {
+			refreshAt = minRefreshAt
+		}
+
+		timerCh = time.After(refreshAt.Sub(ctrl.now()))
+
+		if secretsRes == nil {
+			continue
+		}
+
+		if err = ctrl.publish(spec); err != nil {
+			return fmt.Errorf("error writing kubelet bootstrap token for trustd: %w", err)
+		}
+
+		staticTokenRemoved = ctrl.retireStaticToken(ctx, logger, cfg, secretsRes, staticTokenRemoved)
+	}
+}
+
+// retireStaticToken removes the bootstrap token from the machine configuration on the control plane nodes once it is retired,
+// and a short-lived token is available.
+//
+// It returns whether the static token is removed.
+func (ctrl *KubeletBootstrapTokenController) retireStaticToken(ctx context.Context, logger *zap.Logger, cfg, secretsRes resource.Resource, removed bool) bool {
+	if secretsRes == nil {
+		return removed
+	}
+
+	cfgProvider := cfg.(*config.MachineConfig).Config()
+
+	if !cfgProvider.Machine().Features().RetireStaticBootstrapTokenEnabled() {
+		// the secret is created again from the manifest
+		return false
+	}
+
+	if removed {
+		return true
+	}
+
+	if err := ctrl.removeStaticToken(ctx, secretsRes.(*secrets.Kubernetes).Certs().AdminKubeconfig, cfgProvider.Cluster().Token().ID()); err != nil {
+		// retried on the next reconcile
+		logger.Warn("failed to remove the static kubelet bootstrap token", zap.Error(err))
+
+		return false
+	}
+
+	logger.Info("removed the static kubelet bootstrap token")
+
+	return true
+}
+
+func (ctrl *KubeletBootstrapTokenController) now() time.Time {
+	if ctrl.Now != nil {
+		return ctrl.Now()
+	}
+
+	return time.Now()
+}
+
+func (ctrl *KubeletBootstrapTokenController) trustdTokenPath() string {
+	if ctrl.TrustdTokenPath != "" {
+		return ctrl.TrustdTokenPath
+	}
+
+	return constants.TrustdKubeletBootstrapToken
+}
+
+func (ctrl *KubeletBootstrapTokenController) kubernetesClient(adminKubeconfig string) (kubernetes.Interface, error) {
+	if ctrl.KubernetesClient != nil {
+		return ctrl.KubernetesClient(adminKubeconfig)
+	}
+
+	kubeconfig, err := clientcmd.BuildConfigFromKubeconfigGetter("", func() (*clientcmdapi.Config, error) {
+		return clientcmd.Load([]byte(adminKubeconfig))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error building Kubernetes client: %w", err)
+	}
+
+	return client, nil
+}
+
+func (ctrl *KubeletBootstrapTokenController) mint(ctx context.Context, adminKubeconfig string) (secrets.KubeletBootstrapTokenSpec, error) {
+	token, err := generate.NewBootstrapToken()
+	if err != nil {
+		return secrets.KubeletBootstrapTokenSpec{}, fmt.Errorf("error generating token: %w", err)
+	}
+
+	parts := strings.SplitN(token, ".", 2)
+
+	spec := secrets.KubeletBootstrapTokenSpec{
+		TokenID:     parts[0],
+		TokenSecret: parts[1],
+		Expiration:  ctrl.now().Add(constants.KubeletBootstrapTokenTTL).UTC().Truncate(time.Second),
+	}
+
+	client, err := ctrl.kubernetesClient(adminKubeconfig)
+	if err != nil {
+		return spec, err
+	}
+
+	_, err = client.CoreV1().Secrets(metav1.NamespaceSystem).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bootstrap-token-" + spec.TokenID,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Type: corev1.SecretTypeBootstrapToken,
+		StringData: map[string]string{
+			"token-id":                       spec.TokenID,
+			"token-secret":                   spec.TokenSecret,
+			"expiration":                     spec.Expiration.Format(time.RFC3339),
+			"usage-bootstrap-authentication": "true",
+			"auth-extra-groups":              "system:bootstrappers:nodes",
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return spec, fmt.Errorf("error creating bootstrap token secret: %w", err)
+	}
+
+	return spec, nil
+}
+
+// removeStaticToken removes the bootstrap token secret created from the machine configuration on cluster bootstrap.
+func (ctrl *KubeletBootstrapTokenController) removeStaticToken(ctx context.Context, adminKubeconfig, tokenID string) error {
+	client, err := ctrl.kubernetesClient(adminKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	err = client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(ctx, "bootstrap-token-"+tokenID, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting bootstrap token secret: %w", err)
+	}
+
+	return nil
+}
+
+// fetch requests the current token from trustd on the control plane nodes.
+func (ctrl *KubeletBootstrapTokenController) fetch(ctx context.Context, r controller.Runtime, cfgProvider talosconfig.Provider) (secrets.KubeletBootstrapTokenSpec, error) {
+	endpointResources, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.EndpointType, "", resource.VersionUndefined))
+	if err != nil {
+		return secrets.KubeletBootstrapTokenSpec{}, fmt.Errorf("error listing endpoints: %w", err)
+	}
+
+	var endpointAddrs k8s.EndpointList
+
+	for _, res := range endpointResources.Items {
+		endpointAddrs = endpointAddrs.Merge(res.(*k8s.Endpoint))
+	}
+
+	remoteGen, err := gen.NewRemoteGenerator(
+		cfgProvider.Machine().Security().Token(),
+		append(endpointAddrs.Strings(), cfgProvider.Cluster().InternalEndpoint().Hostname()),
+		cfgProvider.Machine().Security().CA(),
+	)
+	if err != nil {
+		return secrets.KubeletBootstrapTokenSpec{}, fmt.Errorf("failed creating trustd client: %w", err)
+	}
+
+	defer remoteGen.Close() //nolint:errcheck
+
+	if ctrl.V1Alpha1Platform != nil {
+		document, err := v1alpha1runtime.InstanceIdentityDocument(ctx, ctrl.V1Alpha1Platform)
+		if err != nil {
+			return secrets.KubeletBootstrapTokenSpec{}, fmt.Errorf("failed to fetch instance identity document: %w", err)
+		}
+
+		if document != nil {
+			remoteGen.SetInstanceIdentity(ctrl.V1Alpha1Platform.Name(), document)
+		}
+	}
+
+	token, expiration, err := remoteGen.KubeletBootstrapTokenContext(ctx)
+	if err != nil {
+		return secrets.KubeletBootstrapTokenSpec{}, fmt.Errorf("error fetching token from trustd: %w", err)
+	}
+
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return secrets.KubeletBootstrapTokenSpec{}, fmt.Errorf("malformed token received from trustd")
+	}
+
+	return secrets.KubeletBootstrapTokenSpec{
+		TokenID:     parts[0],
+		TokenSecret: parts[1],
+		Expiration:  expiration.UTC(),
+	}, nil
+}
+
+// publish writes the token for trustd, which serves it to the joining nodes.
+func (ctrl *KubeletBootstrapTokenController) publish(spec secrets.KubeletBootstrapTokenSpec) error {
+	path := ctrl.trustdTokenPath()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	if err := os.Chown(filepath.Dir(path), constants.TrustdUserID, constants.TrustdUserID); err != nil {
+		return err
+	}
+
+	contents, err := yaml.Marshal(&spec)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+
+	if err = ioutil.WriteFile(tmpPath, contents, 0o400); err != nil {
+		return err
+	}
+
+	if err = os.Chown(tmpPath, constants.TrustdUserID, constants.TrustdUserID); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+func (ctrl *KubeletBootstrapTokenController) teardown(ctx context.Context, r controller.Runtime) error {
+	if err := os.Remove(ctrl.trustdTokenPath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing kubelet bootstrap token for trustd: %w", err)
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubeletBootstrapTokenType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing kubelet bootstrap tokens: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return fmt.Errorf("error destroying kubelet bootstrap token: %w", err)
+		}
+	}
+
+	return nil
+}
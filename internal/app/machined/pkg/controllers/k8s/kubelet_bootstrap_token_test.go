@@ -0,0 +1,319 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	yaml "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+)
+
+const staticBootstrapToken = "abcdef.0123456789abcdef"
+
+type KubeletBootstrapTokenSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	client          *fake.Clientset
+	trustdTokenPath string
+
+	nowMu sync.Mutex
+	now   time.Time
+}
+
+func (suite *KubeletBootstrapTokenSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	// static bootstrap token secret created from the manifest on cluster bootstrap
+	suite.client = fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bootstrap-token-abcdef",
+			Namespace: metav1.NamespaceSystem,
+		},
+		Type: corev1.SecretTypeBootstrapToken,
+	})
+
+	suite.trustdTokenPath = filepath.Join(suite.T().TempDir(), "trustd", "kubelet-bootstrap-token")
+	suite.now = time.Now()
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.KubeletBootstrapTokenController{
+		Now: func() time.Time {
+			suite.nowMu.Lock()
+			defer suite.nowMu.Unlock()
+
+			return suite.now
+		},
+		TrustdTokenPath: suite.trustdTokenPath,
+		KubernetesClient: func(string) (kubernetes.Interface, error) {
+			return suite.client, nil
+		},
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *KubeletBootstrapTokenSuite) advanceTime(d time.Duration) {
+	suite.nowMu.Lock()
+	defer suite.nowMu.Unlock()
+
+	suite.now = suite.now.Add(d)
+}
+
+func (suite *KubeletBootstrapTokenSuite) newConfig(features *v1alpha1.FeaturesConfig) *config.MachineConfig {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	return config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType:     "controlplane",
+			MachineFeatures: features,
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			BootstrapToken: staticBootstrapToken,
+		},
+	})
+}
+
+func (suite *KubeletBootstrapTokenSuite) createSecrets() {
+	kubernetesSecrets := secrets.NewKubernetes()
+	kubernetesSecrets.Certs().AdminKubeconfig = "admin"
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, kubernetesSecrets))
+}
+
+// touchConfig triggers the controller reconcile loop.
+func (suite *KubeletBootstrapTokenSuite) touchConfig(cfg *config.MachineConfig) {
+	oldVersion := cfg.Metadata().Version()
+
+	cfg.Metadata().BumpVersion()
+
+	suite.Require().NoError(suite.state.Update(suite.ctx, oldVersion, cfg))
+}
+
+func (suite *KubeletBootstrapTokenSuite) getToken() (*secrets.KubeletBootstrapTokenSpec, error) {
+	res, err := suite.state.Get(suite.ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubeletBootstrapTokenType, secrets.KubeletBootstrapTokenID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil, retry.ExpectedError(err)
+		}
+
+		return nil, err
+	}
+
+	return res.(*secrets.KubeletBootstrapToken).TypedSpec(), nil
+}
+
+func (suite *KubeletBootstrapTokenSuite) assertTokenSecret(spec *secrets.KubeletBootstrapTokenSpec) {
+	secret, err := suite.client.CoreV1().Secrets(metav1.NamespaceSystem).Get(suite.ctx, "bootstrap-token-"+spec.TokenID, metav1.GetOptions{})
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(corev1.SecretTypeBootstrapToken, secret.Type)
+	suite.Assert().Equal(spec.TokenID, secret.StringData["token-id"])
+	suite.Assert().Equal(spec.TokenSecret, secret.StringData["token-secret"])
+	suite.Assert().Equal(spec.Expiration.Format(time.RFC3339), secret.StringData["expiration"])
+	suite.Assert().Equal("system:bootstrappers:nodes", secret.StringData["auth-extra-groups"])
+}
+
+func (suite *KubeletBootstrapTokenSuite) staticTokenExists() bool {
+	_, err := suite.client.CoreV1().Secrets(metav1.NamespaceSystem).Get(suite.ctx, "bootstrap-token-abcdef", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+
+	suite.Require().NoError(err)
+
+	return true
+}
+
+func (suite *KubeletBootstrapTokenSuite) TestMint() {
+	suite.Require().NoError(suite.state.Create(suite.ctx, suite.newConfig(nil)))
+	suite.createSecrets()
+
+	var spec *secrets.KubeletBootstrapTokenSpec
+
+	suite.Require().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			var err error
+
+			spec, err = suite.getToken()
+
+			return err
+		},
+	))
+
+	suite.Assert().Len(spec.TokenID, 6)
+	suite.Assert().Len(spec.TokenSecret, 16)
+	suite.Assert().Equal(suite.now.Add(constants.KubeletBootstrapTokenTTL).UTC().Truncate(time.Second), spec.Expiration)
+
+	suite.assertTokenSecret(spec)
+
+	// the token is passed to trustd
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			contents, err := ioutil.ReadFile(suite.trustdTokenPath)
+			if err != nil {
+				return retry.ExpectedError(err)
+			}
+
+			var published secrets.KubeletBootstrapTokenSpec
+
+			if err = yaml.Unmarshal(contents, &published); err != nil {
+				return err
+			}
+
+			suite.Assert().Equal(spec.Token(), published.Token())
+
+			return nil
+		},
+	))
+
+	// the static token is kept until it's retired
+	suite.Assert().True(suite.staticTokenExists())
+}
+
+func (suite *KubeletBootstrapTokenSuite) TestRotate() {
+	cfg := suite.newConfig(nil)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+	suite.createSecrets()
+
+	var first *secrets.KubeletBootstrapTokenSpec
+
+	suite.Require().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			var err error
+
+			first, err = suite.getToken()
+
+			return err
+		},
+	))
+
+	// the token is not refreshed before half of its lifetime has passed
+	suite.advanceTime(constants.KubeletBootstrapTokenTTL/2 - time.Minute)
+	suite.touchConfig(cfg)
+
+	time.Sleep(500 * time.Millisecond)
+
+	spec, err := suite.getToken()
+	suite.Require().NoError(err)
+	suite.Assert().Equal(first.Token(), spec.Token())
+
+	// the token is refreshed before it expires
+	suite.advanceTime(2 * time.Minute)
+	suite.touchConfig(cfg)
+
+	suite.Require().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			var err error
+
+			spec, err = suite.getToken()
+			if err != nil {
+				return err
+			}
+
+			if spec.Token() == first.Token() {
+				return retry.ExpectedErrorf("token is not rotated yet")
+			}
+
+			return nil
+		},
+	))
+
+	suite.Assert().True(spec.Expiration.After(first.Expiration))
+	suite.assertTokenSecret(spec)
+
+	// the previous token is valid until it expires, expired secrets are removed by the tokencleaner
+	suite.assertTokenSecret(first)
+}
+
+func (suite *KubeletBootstrapTokenSuite) TestRetireStaticToken() {
+	cfg := suite.newConfig(&v1alpha1.FeaturesConfig{
+		RetireStaticBootstrapToken: pointer.ToBool(true),
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+	suite.createSecrets()
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			if suite.staticTokenExists() {
+				return retry.ExpectedErrorf("static token is not removed yet")
+			}
+
+			return nil
+		},
+	))
+
+	// the static token is removed only once the short-lived token is minted
+	spec, err := suite.getToken()
+	suite.Require().NoError(err)
+	suite.assertTokenSecret(spec)
+}
+
+func (suite *KubeletBootstrapTokenSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKubeletBootstrapTokenSuite(t *testing.T) {
+	suite.Run(t, new(KubeletBootstrapTokenSuite))
+}
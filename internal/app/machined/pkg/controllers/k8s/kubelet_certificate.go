@@ -0,0 +1,283 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+const (
+	kubeletCertificateCheckInterval = time.Minute
+	kubeletCertificateRetryInterval = 5 * time.Minute
+
+	// kubeletCertificateRenewalThreshold is the fraction of the certificate lifetime after which the renewal is overdue.
+	//
+	// Kubelet rotates the certificate at the random point between 70% and 90% of the certificate lifetime.
+	kubeletCertificateRenewalThreshold = 0.9
+)
+
+// KubeletCertificateController tracks the expiry of the kubelet client certificate and recovers the kubelet
+// which failed to rotate it.
+//
+// If the node was disconnected (e.g. powered off) when the certificate was due for renewal, kubelet is restarted
+// periodically to retry the rotation right away. Once the certificate has expired, it can't be used to request
+// a new one, so the kubelet is bootstrapped again via the bootstrap token (kubelet client credentials are removed
+// and kubelet is restarted).
+type KubeletCertificateController struct {
+	// RestartKubelet defaults to restarting the kubelet service.
+	RestartKubelet func(ctx context.Context) error
+	// RebootstrapKubelet defaults to kubelet.RepairClientCredentials.
+	RebootstrapKubelet func(ctx context.Context) error
+	// Now is used to override current time in the tests.
+	Now func() time.Time
+
+	// CertificatePath defaults to the kubelet client certificate in constants.KubeletPKIDir.
+	CertificatePath string
+	// CheckInterval defaults to 1 minute.
+	CheckInterval time.Duration
+	// RetryInterval is the minimum interval between the recovery attempts, defaults to 5 minutes.
+	RetryInterval time.Duration
+
+	lastAttempt time.Time
+	lastError   string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletCertificateController) Name() string {
+	return "k8s.KubeletCertificateController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletCertificateController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ServiceType,
+			ID:        pointer.ToString("kubelet"),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletCertificateController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: k8s.KubeletCertificateStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *KubeletCertificateController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.RestartKubelet == nil {
+		ctrl.RestartKubelet = restartKubelet
+	}
+
+	if ctrl.RebootstrapKubelet == nil {
+		ctrl.RebootstrapKubelet = rebootstrapKubelet
+	}
+
+	if ctrl.Now == nil {
+		ctrl.Now = time.Now
+	}
+
+	if ctrl.CertificatePath == "" {
+		ctrl.CertificatePath = filepath.Join(constants.KubeletPKIDir, "kubelet-client-current.pem")
+	}
+
+	if ctrl.CheckInterval == 0 {
+		ctrl.CheckInterval = kubeletCertificateCheckInterval
+	}
+
+	if ctrl.RetryInterval == 0 {
+		ctrl.RetryInterval = kubeletCertificateRetryInterval
+	}
+
+	// the certificate is checked periodically, as the certificate file is not watched
+	ticker := time.NewTicker(ctrl.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		if err := ctrl.reconcile(ctx, r, logger); err != nil {
+			return err
+		}
+	}
+}
+
+//nolint:gocyclo
+func (ctrl *KubeletCertificateController) reconcile(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	cert, err := ctrl.readCertificate()
+	if err != nil {
+		return err
+	}
+
+	if cert == nil {
+		// kubelet is not bootstrapped yet
+		return ctrl.cleanup(ctx, r)
+	}
+
+	now := ctrl.Now()
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	renewalDeadline := cert.NotBefore.Add(time.Duration(float64(lifetime) * kubeletCertificateRenewalThreshold))
+
+	certState := k8s.KubeletCertificateValid
+
+	switch {
+	case !now.Before(cert.NotAfter):
+		certState = k8s.KubeletCertificateExpired
+	case !now.Before(renewalDeadline):
+		certState = k8s.KubeletCertificateRenewalOverdue
+	}
+
+	if certState == k8s.KubeletCertificateValid {
+		ctrl.lastError = ""
+	} else if err = ctrl.recover(ctx, r, logger, certState, now); err != nil {
+		return err
+	}
+
+	return r.Modify(ctx, k8s.NewKubeletCertificateStatus(k8s.ControlPlaneNamespaceName, k8s.KubeletCertificateStatusID), func(res resource.Resource) error {
+		spec := res.(*k8s.KubeletCertificateStatus).TypedSpec()
+
+		spec.State = certState
+		spec.NotBefore = cert.NotBefore
+		spec.NotAfter = cert.NotAfter
+		spec.RenewalDeadline = renewalDeadline
+		spec.LastRecoveryAttempt = ctrl.lastAttempt
+		spec.LastRecoveryError = ctrl.lastError
+
+		return nil
+	})
+}
+
+// recover restarts or re-bootstraps the running kubelet, at most once per retry interval.
+func (ctrl *KubeletCertificateController) recover(ctx context.Context, r controller.Runtime, logger *zap.Logger, certState k8s.KubeletCertificateState, now time.Time) error {
+	kubeletResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, v1alpha1.ServiceType, "kubelet", resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !kubeletResource.(*v1alpha1.Service).Running() {
+		return nil
+	}
+
+	if since := now.Sub(ctrl.lastAttempt); since < ctrl.RetryInterval {
+		logger.Debug("kubelet certificate recovery backed off", zap.String("state", string(certState)), zap.Duration("since", since))
+
+		return nil
+	}
+
+	ctrl.lastAttempt = now
+
+	if certState == k8s.KubeletCertificateExpired {
+		logger.Warn("kubelet client certificate has expired, bootstrapping kubelet again")
+
+		err = ctrl.RebootstrapKubelet(ctx)
+	} else {
+		logger.Warn("kubelet client certificate renewal is overdue, restarting kubelet")
+
+		err = ctrl.RestartKubelet(ctx)
+	}
+
+	if err != nil {
+		// the recovery is retried after the retry interval
+		logger.Error("kubelet certificate recovery failed", zap.Error(err))
+
+		ctrl.lastError = err.Error()
+	} else {
+		ctrl.lastError = ""
+	}
+
+	return nil
+}
+
+// readCertificate returns nil if the certificate doesn't exist.
+func (ctrl *KubeletCertificateController) readCertificate() (*x509.Certificate, error) {
+	contents, err := ioutil.ReadFile(ctrl.CertificatePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error reading kubelet certificate: %w", err)
+	}
+
+	// kubelet stores the certificate and the key in the same file
+	for {
+		var block *pem.Block
+
+		block, contents = pem.Decode(contents)
+		if block == nil {
+			return nil, fmt.Errorf("no certificate found in %q", ctrl.CertificatePath)
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing kubelet certificate: %w", err)
+		}
+
+		return cert, nil
+	}
+}
+
+func (ctrl *KubeletCertificateController) cleanup(ctx context.Context, r controller.Runtime) error {
+	err := r.Destroy(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletCertificateStatusType, k8s.KubeletCertificateStatusID, resource.VersionUndefined))
+	if err != nil && !state.IsNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// restartKubelet restarts the kubelet, so that it retries the certificate rotation immediately.
+func restartKubelet(ctx context.Context) error {
+	if err := system.Services(nil).Stop(ctx, "kubelet"); err != nil {
+		return fmt.Errorf("error stopping kubelet: %w", err)
+	}
+
+	return system.Services(nil).Start("kubelet")
+}
+
+// rebootstrapKubelet removes the kubelet client credentials, so that the kubelet requests a new certificate.
+func rebootstrapKubelet(ctx context.Context) error {
+	_, err := kubelet.RepairClientCredentials(ctx, system.Services(nil))
+
+	return err
+}
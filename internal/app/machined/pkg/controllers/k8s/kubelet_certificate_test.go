@@ -0,0 +1,285 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+type kubeletRecoveries struct {
+	mu           sync.Mutex
+	now          time.Time
+	restarts     int
+	rebootstraps int
+}
+
+func (recoveries *kubeletRecoveries) Now() time.Time {
+	recoveries.mu.Lock()
+	defer recoveries.mu.Unlock()
+
+	return recoveries.now
+}
+
+func (recoveries *kubeletRecoveries) setNow(now time.Time) {
+	recoveries.mu.Lock()
+	defer recoveries.mu.Unlock()
+
+	recoveries.now = now
+}
+
+func (recoveries *kubeletRecoveries) restart(ctx context.Context) error {
+	recoveries.mu.Lock()
+	defer recoveries.mu.Unlock()
+
+	recoveries.restarts++
+
+	return nil
+}
+
+func (recoveries *kubeletRecoveries) rebootstrap(ctx context.Context) error {
+	recoveries.mu.Lock()
+	defer recoveries.mu.Unlock()
+
+	recoveries.rebootstraps++
+
+	return fmt.Errorf("API server is not reachable")
+}
+
+func (recoveries *kubeletRecoveries) get() (int, int) {
+	recoveries.mu.Lock()
+	defer recoveries.mu.Unlock()
+
+	return recoveries.restarts, recoveries.rebootstraps
+}
+
+type KubeletCertificateSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	notBefore  time.Time
+	recoveries *kubeletRecoveries
+}
+
+func (suite *KubeletCertificateSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.notBefore = time.Now().Truncate(time.Second)
+	suite.recoveries = &kubeletRecoveries{
+		now: suite.notBefore.Add(time.Hour),
+	}
+
+	certPath := filepath.Join(suite.T().TempDir(), "kubelet-client-current.pem")
+
+	suite.writeCertificate(certPath)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.KubeletCertificateController{
+		RestartKubelet:     suite.recoveries.restart,
+		RebootstrapKubelet: suite.recoveries.rebootstrap,
+		Now:                suite.recoveries.Now,
+		CertificatePath:    certPath,
+		CheckInterval:      100 * time.Millisecond,
+		RetryInterval:      time.Hour,
+	}))
+
+	kubeletService := v1alpha1.NewService("kubelet")
+	kubeletService.SetRunning(true)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, kubeletService))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+// writeCertificate writes the certificate valid for 100 hours followed by the key, as kubelet does.
+func (suite *KubeletCertificateSuite) writeCertificate(path string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "system:node:node1"},
+		NotBefore:    suite.notBefore,
+		NotAfter:     suite.notBefore.Add(100 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	suite.Require().NoError(err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	suite.Require().NoError(err)
+
+	contents := append(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})...,
+	)
+
+	suite.Require().NoError(ioutil.WriteFile(path, contents, 0o600))
+}
+
+func (suite *KubeletCertificateSuite) assertStatus(check func(*k8s.KubeletCertificateStatusSpec) error) {
+	suite.Require().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			res, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletCertificateStatusType, k8s.KubeletCertificateStatusID, resource.VersionUndefined))
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			return check(res.(*k8s.KubeletCertificateStatus).TypedSpec())
+		},
+	))
+}
+
+func (suite *KubeletCertificateSuite) assertState(expected k8s.KubeletCertificateState) {
+	suite.assertStatus(func(spec *k8s.KubeletCertificateStatusSpec) error {
+		if spec.State != expected {
+			return retry.ExpectedError(fmt.Errorf("expected state %q, got %q", expected, spec.State))
+		}
+
+		return nil
+	})
+}
+
+func (suite *KubeletCertificateSuite) TestValid() {
+	suite.assertStatus(func(spec *k8s.KubeletCertificateStatusSpec) error {
+		if spec.State != k8s.KubeletCertificateValid {
+			return retry.ExpectedError(fmt.Errorf("unexpected state %q", spec.State))
+		}
+
+		suite.Assert().Equal(suite.notBefore.UTC(), spec.NotBefore.UTC())
+		suite.Assert().Equal(suite.notBefore.Add(100*time.Hour).UTC(), spec.NotAfter.UTC())
+		suite.Assert().Equal(suite.notBefore.Add(90*time.Hour).UTC(), spec.RenewalDeadline.UTC())
+
+		return nil
+	})
+
+	time.Sleep(500 * time.Millisecond)
+
+	restarts, rebootstraps := suite.recoveries.get()
+	suite.Assert().Zero(restarts)
+	suite.Assert().Zero(rebootstraps)
+}
+
+func (suite *KubeletCertificateSuite) TestRenewalOverdue() {
+	suite.assertState(k8s.KubeletCertificateValid)
+
+	suite.recoveries.setNow(suite.notBefore.Add(95 * time.Hour))
+
+	suite.assertState(k8s.KubeletCertificateRenewalOverdue)
+
+	// the restart is backed off
+	time.Sleep(500 * time.Millisecond)
+
+	restarts, rebootstraps := suite.recoveries.get()
+	suite.Assert().Equal(1, restarts)
+	suite.Assert().Zero(rebootstraps)
+
+	// the restart is retried after the retry interval
+	suite.recoveries.setNow(suite.notBefore.Add(96*time.Hour + time.Minute))
+
+	suite.Require().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			if restarts, _ = suite.recoveries.get(); restarts != 2 {
+				return retry.ExpectedError(fmt.Errorf("expected 2 restarts, got %d", restarts))
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *KubeletCertificateSuite) TestExpired() {
+	suite.recoveries.setNow(suite.notBefore.Add(1000 * time.Hour))
+
+	suite.assertStatus(func(spec *k8s.KubeletCertificateStatusSpec) error {
+		if spec.State != k8s.KubeletCertificateExpired {
+			return retry.ExpectedError(fmt.Errorf("unexpected state %q", spec.State))
+		}
+
+		suite.Assert().Equal(suite.notBefore.Add(1000*time.Hour), spec.LastRecoveryAttempt)
+		suite.Assert().Equal("API server is not reachable", spec.LastRecoveryError)
+
+		return nil
+	})
+
+	restarts, rebootstraps := suite.recoveries.get()
+	suite.Assert().Zero(restarts)
+	suite.Assert().Equal(1, rebootstraps)
+}
+
+func (suite *KubeletCertificateSuite) TestKubeletNotRunning() {
+	suite.Require().NoError(suite.state.Destroy(suite.ctx, v1alpha1.NewService("kubelet").Metadata()))
+
+	suite.recoveries.setNow(suite.notBefore.Add(1000 * time.Hour))
+
+	suite.assertState(k8s.KubeletCertificateExpired)
+
+	time.Sleep(500 * time.Millisecond)
+
+	restarts, rebootstraps := suite.recoveries.get()
+	suite.Assert().Zero(restarts)
+	suite.Assert().Zero(rebootstraps)
+}
+
+func (suite *KubeletCertificateSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKubeletCertificateSuite(t *testing.T) {
+	suite.Run(t, new(KubeletCertificateSuite))
+}
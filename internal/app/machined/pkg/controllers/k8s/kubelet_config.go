@@ -0,0 +1,586 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/talos-systems/net"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfig "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	runtimeres "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// KubeletConfigController renders the kubelet configuration from the machine configuration.
+type KubeletConfigController struct {
+	// secretsChecksumKey is generated on the controller start, so the checksum can't be used to guess the secret values.
+	secretsChecksumKey []byte
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletConfigController) Name() string {
+	return "k8s.KubeletConfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: k8s.KubeletConfigType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *KubeletConfigController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.secretsChecksumKey == nil {
+		ctrl.secretsChecksumKey = make([]byte, sha256.Size)
+
+		if _, err := rand.Read(ctrl.secretsChecksumKey); err != nil {
+			return fmt.Errorf("error generating secrets checksum key: %w", err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				if err = ctrl.teardownAll(ctx, r); err != nil {
+					return fmt.Errorf("error destroying kubelet config: %w", err)
+				}
+
+				continue
+			}
+
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		cfgProvider := cfg.(*config.MachineConfig).Config()
+
+		spec, err := ctrl.buildSpec(cfgProvider, logger)
+		if err != nil {
+			return err
+		}
+
+		if err = r.Modify(ctx, k8s.NewKubeletConfig(k8s.ControlPlaneNamespaceName, k8s.KubeletID), func(res resource.Resource) error {
+			*res.(*k8s.KubeletConfig).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating kubelet config: %w", err)
+		}
+	}
+}
+
+//nolint:gocyclo
+func (ctrl *KubeletConfigController) buildSpec(cfgProvider talosconfig.Provider, logger *zap.Logger) (k8s.KubeletConfigSpec, error) {
+	kubeletConfig := cfgProvider.Machine().Kubelet()
+
+	spec := k8s.KubeletConfigSpec{
+		Image:                 kubeletConfig.Image(),
+		ExtraArgs:             kubeletConfig.ExtraArgs(),
+		ExtraCapabilities:     kubeletConfig.ExtraCapabilities(),
+		Standalone:            kubeletConfig.Standalone(),
+		CloudProviderExternal: cfgProvider.Cluster().ExternalCloudProvider().Enabled(),
+		NodeLabels:            cfgProvider.Machine().NodeLabels(),
+		NodeTaints:            cfgProvider.Machine().NodeTaints(),
+		PodCIDRs:              kubeletConfig.PodCIDRs(),
+		NodeIPPreferredFamily: kubeletConfig.NodeIP().PreferredFamily(),
+		TrustdCredentials:     cfgProvider.Machine().Features().KubeletTrustdCredentialsEnabled(),
+		// enable debug logs only for the worker nodes
+		DebugLogs: cfgProvider.Debug() && cfgProvider.Machine().Type() == machine.TypeWorker,
+	}
+
+	// standalone kubelet doesn't talk to the API server
+	if !spec.Standalone {
+		spec.APIServerEndpoint = kubelet.APIServerEndpoint(cfgProvider)
+	}
+
+	// only the certificate is used by the kubelet, the key is a secret
+	if ca := cfgProvider.Cluster().CA(); ca != nil {
+		spec.CACert = string(ca.Crt)
+	}
+
+	healthEndpoint, err := kubelet.HealthEndpoint(kubeletConfig)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.HealthEndpoint = healthEndpoint.String()
+
+	// mounts exposing sensitive host paths are rejected by the config validation unless explicitly allowed,
+	// the config which wasn't validated (e.g. stored before the check was introduced) shouldn't break the kubelet
+	for _, mount := range kubeletConfig.ExtraMounts() {
+		if sensitive, unsafe := talosconfig.SensitiveMountSource(mount.Source); unsafe {
+			if !kubeletConfig.AllowUnsafeMounts() {
+				logger.Error("skipping kubelet extra mount exposing sensitive host path", zap.String("source", mount.Source), zap.String("path", sensitive))
+
+				continue
+			}
+
+			logger.Warn("kubelet extra mount exposes sensitive host path to the kubelet", zap.String("source", mount.Source), zap.String("path", sensitive))
+		}
+
+		spec.ExtraMounts = append(spec.ExtraMounts, mount)
+	}
+
+	if credentialProviders := kubeletConfig.CredentialProviders(); credentialProviders != nil {
+		spec.CredentialProviderBinDir = credentialProviders.BinDir()
+	}
+
+	serviceCIDRs := cfgProvider.Cluster().Network().ServiceCIDRs()
+
+	spec.NodeIPValidSubnets = kubeletConfig.NodeIP().ValidSubnets()
+
+	// configure automatically valid subnets for IPv4/IPv6 based on service CIDRs
+	if len(spec.NodeIPValidSubnets) == 0 {
+		spec.NodeIPValidSubnets, err = ipSubnetsFromServiceCIDRs(serviceCIDRs)
+		if err != nil {
+			return spec, err
+		}
+	}
+
+	// anyway filter out pod cidrs, they can't be node IPs
+	for _, cidr := range cfgProvider.Cluster().Network().PodCIDRs() {
+		spec.NodeIPValidSubnets = append(spec.NodeIPValidSubnets, "!"+cidr)
+	}
+
+	// filter out any virtual IPs, they can't be node IPs either
+	for _, device := range cfgProvider.Machine().Network().Devices() {
+		if device.VIPConfig() != nil {
+			spec.NodeIPValidSubnets = append(spec.NodeIPValidSubnets, "!"+device.VIPConfig().IP())
+		}
+
+		for _, vlan := range device.Vlans() {
+			if vlan.VIPConfig() != nil {
+				spec.NodeIPValidSubnets = append(spec.NodeIPValidSubnets, "!"+vlan.VIPConfig().IP())
+			}
+		}
+	}
+
+	// primary node IP family should match the primary service CIDR family
+	if spec.NodeIPPreferredFamily == "" {
+		spec.NodeIPPreferredFamily, err = primaryServiceCIDRFamily(serviceCIDRs)
+		if err != nil {
+			return spec, err
+		}
+	}
+
+	spec.Config, err = renderKubeletConfiguration(cfgProvider)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.SecretsChecksum, err = ctrl.secretsChecksum(cfgProvider)
+	if err != nil {
+		return spec, err
+	}
+
+	return spec, nil
+}
+
+// secretsChecksum returns the keyed checksum of the secrets the kubelet service reads from the machine configuration.
+func (ctrl *KubeletConfigController) secretsChecksum(cfgProvider talosconfig.Provider) (string, error) {
+	registries := cfgProvider.Machine().Registries()
+
+	secrets, err := json.Marshal(struct {
+		CredentialProviders talosconfig.KubeletCredentialProviders
+		RegistryMirrors     map[string]talosconfig.RegistryMirrorConfig
+		RegistryConfig      map[string]talosconfig.RegistryConfig
+		Env                 talosconfig.Env
+	}{
+		CredentialProviders: cfgProvider.Machine().Kubelet().CredentialProviders(),
+		RegistryMirrors:     registries.Mirrors(),
+		RegistryConfig:      registries.Config(),
+		Env:                 cfgProvider.Machine().Env(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error marshaling kubelet secrets: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, ctrl.secretsChecksumKey)
+	mac.Write(secrets) //nolint:errcheck
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (ctrl *KubeletConfigController) teardownAll(ctx context.Context, r controller.Runtime) error {
+	err := r.Destroy(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletConfigType, k8s.KubeletID, resource.VersionUndefined))
+	if err != nil && !state.IsNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func newKubeletConfiguration(clusterDNS []string, dnsDomain string) *kubeletconfig.KubeletConfiguration {
+	f := false
+	t := true
+	oomScoreAdj := int32(constants.KubeletOOMScoreAdj)
+
+	return &kubeletconfig.KubeletConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "kubelet.config.k8s.io/v1beta1",
+			Kind:       "KubeletConfiguration",
+		},
+		StaticPodPath:      constants.ManifestsDirectory,
+		Address:            "0.0.0.0",
+		Port:               constants.KubeletPort,
+		OOMScoreAdj:        &oomScoreAdj,
+		RotateCertificates: true,
+		Authentication: kubeletconfig.KubeletAuthentication{
+			X509: kubeletconfig.KubeletX509Authentication{
+				ClientCAFile: constants.KubernetesCACert,
+			},
+			Webhook: kubeletconfig.KubeletWebhookAuthentication{
+				Enabled: &t,
+			},
+			Anonymous: kubeletconfig.KubeletAnonymousAuthentication{
+				Enabled: &f,
+			},
+		},
+		Authorization: kubeletconfig.KubeletAuthorization{
+			Mode: kubeletconfig.KubeletAuthorizationModeWebhook,
+		},
+		ClusterDomain:       dnsDomain,
+		ClusterDNS:          clusterDNS,
+		SerializeImagePulls: &f,
+		FailSwapOn:          &f,
+		CgroupRoot:          "/",
+		SystemCgroups:       constants.CgroupSystem,
+		SystemReserved: map[string]string{
+			"cpu":               constants.KubeletSystemReservedCPU,
+			"memory":            constants.KubeletSystemReservedMemory,
+			"pid":               constants.KubeletSystemReservedPid,
+			"ephemeral-storage": constants.KubeletSystemReservedEphemeralStorage,
+		},
+		KubeletCgroups: constants.CgroupKubelet,
+	}
+}
+
+// renderKubeletConfiguration builds the kubelet configuration file contents as unstructured object.
+//
+//nolint:gocyclo,cyclop
+func renderKubeletConfiguration(cfgProvider talosconfig.Provider) (map[string]interface{}, error) {
+	kubeletConfig := cfgProvider.Machine().Kubelet()
+
+	dnsServiceIPs, err := cfgProvider.Cluster().Network().DNSServiceIPs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS service IPs: %w", err)
+	}
+
+	dnsServiceIPsString := []string{}
+
+	dnsServiceIPsCustom := kubeletConfig.ClusterDNS()
+	if dnsServiceIPsCustom == nil {
+		for _, dnsIP := range dnsServiceIPs {
+			dnsServiceIPsString = append(dnsServiceIPsString, dnsIP.String())
+		}
+	} else {
+		dnsServiceIPsString = dnsServiceIPsCustom
+	}
+
+	kubeletConfiguration := newKubeletConfiguration(dnsServiceIPsString, cfgProvider.Cluster().Network().DNSDomain())
+
+	kubeletConfiguration.Port = int32(kubeletConfig.Port())
+	kubeletConfiguration.ServerTLSBootstrap = kubeletConfig.EnableServerCertRotation()
+	kubeletConfiguration.HealthzPort = pointer.ToInt32(int32(kubeletConfig.HealthzPort()))
+
+	// standalone kubelet can't delegate the authentication and authorization to the API server,
+	// so only the clients with the certificates issued by the cluster CA are allowed
+	if kubeletConfig.Standalone() {
+		kubeletConfiguration.Authentication.Webhook.Enabled = pointer.ToBool(false)
+		kubeletConfiguration.Authorization.Mode = kubeletconfig.KubeletAuthorizationModeAlwaysAllow
+		kubeletConfiguration.RotateCertificates = false
+	}
+
+	if kubeletConfig.DefaultRuntimeSeccompProfileEnabled() || cfgProvider.Machine().Features().KubeletDefaultRuntimeSeccompProfileEnabled() {
+		seccompDefault := true
+
+		if kubeletConfiguration.FeatureGates == nil {
+			kubeletConfiguration.FeatureGates = map[string]bool{}
+		}
+
+		kubeletConfiguration.FeatureGates["SeccompDefault"] = true
+		kubeletConfiguration.SeccompDefault = &seccompDefault
+	}
+
+	// ephemeral storage usage of the pods is accounted with the project quotas instead of scanning the volumes
+	if cfgProvider.Machine().Ephemeral().ProjectQuota() {
+		if kubeletConfiguration.FeatureGates == nil {
+			kubeletConfiguration.FeatureGates = map[string]bool{}
+		}
+
+		kubeletConfiguration.FeatureGates["LocalStorageCapacityIsolationFSQuotaMonitoring"] = true
+	}
+
+	if kubeletConfig.CredentialProviders() != nil {
+		if kubeletConfiguration.FeatureGates == nil {
+			kubeletConfiguration.FeatureGates = map[string]bool{}
+		}
+
+		kubeletConfiguration.FeatureGates["KubeletCredentialProviders"] = true
+	}
+
+	// there is no systemd-logind on Talos, so the pods are terminated by the machined shutdown sequence with the same grace periods
+	if shutdownGracePeriod := kubeletConfig.ShutdownGracePeriod(); shutdownGracePeriod > 0 {
+		kubeletConfiguration.ShutdownGracePeriod = metav1.Duration{Duration: shutdownGracePeriod}
+		kubeletConfiguration.ShutdownGracePeriodCriticalPods = metav1.Duration{Duration: kubeletConfig.ShutdownGracePeriodCriticalPods()}
+	}
+
+	for resource, value := range kubeletConfig.SystemReserved() {
+		kubeletConfiguration.SystemReserved[resource] = value
+	}
+
+	if kubeReserved := kubeletConfig.KubeReserved(); len(kubeReserved) > 0 {
+		kubeletConfiguration.KubeReserved = kubeReserved
+	}
+
+	kubeletConfiguration.ImageGCHighThresholdPercent = pointer.ToInt32(int32(kubeletConfig.ImageGCHighThresholdPercent()))
+	kubeletConfiguration.ImageGCLowThresholdPercent = pointer.ToInt32(int32(kubeletConfig.ImageGCLowThresholdPercent()))
+	kubeletConfiguration.ContainerLogMaxSize = kubeletConfig.ContainerLogMaxSize()
+	kubeletConfiguration.ContainerLogMaxFiles = pointer.ToInt32(int32(kubeletConfig.ContainerLogMaxFiles()))
+
+	kubeletConfiguration.CPUManagerPolicy = kubeletConfig.CPUManagerPolicy()
+	kubeletConfiguration.TopologyManagerPolicy = kubeletConfig.TopologyManagerPolicy()
+	kubeletConfiguration.MemoryManagerPolicy = kubeletConfig.MemoryManagerPolicy()
+
+	cpuManagerPolicy := kubeletPolicy(kubeletConfig, "cpu-manager-policy", "cpuManagerPolicy", kubeletConfiguration.CPUManagerPolicy)
+	memoryManagerPolicy := kubeletPolicy(kubeletConfig, "memory-manager-policy", "memoryManagerPolicy", kubeletConfiguration.MemoryManagerPolicy)
+
+	if memoryManagerPolicy == kubeletconfig.StaticMemoryManagerPolicy {
+		kubeletConfiguration.ReservedMemory, err = kubeletReservedMemory(kubeletConfiguration.SystemReserved, kubeletConfiguration.KubeReserved, cfgProvider.Machine().HugePages())
+		if err != nil {
+			return nil, fmt.Errorf("error building kubelet reserved memory: %w", err)
+		}
+	}
+
+	kubeletConfiguration.ReservedSystemCPUs = kubeletConfig.ReservedSystemCPUs()
+
+	// system and kube reserved workloads are aligned with the real-time profile housekeeping CPUs
+	if rt := cfgProvider.Machine().Kernel().Realtime(); rt != nil && kubeletConfiguration.ReservedSystemCPUs == "" {
+		kubeletConfiguration.ReservedSystemCPUs = rt.HousekeepingCPUs()
+	}
+
+	if extraConfig := kubeletConfig.ExtraConfig(); len(extraConfig) > 0 {
+		kubeletConfiguration, err = mergeKubeletExtraConfig(kubeletConfiguration, extraConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error merging kubelet extra config: %w", err)
+		}
+	}
+
+	// policies overridden with the extra args are recorded in the configuration, so that it reflects the effective policies
+	kubeletConfiguration.CPUManagerPolicy = cpuManagerPolicy
+	kubeletConfiguration.MemoryManagerPolicy = memoryManagerPolicy
+
+	return toUnstructured(kubeletConfiguration)
+}
+
+// kubeletPolicy returns the effective kubelet policy, as the extra args and extra config override the machine configuration.
+func kubeletPolicy(kubeletConfig talosconfig.Kubelet, arg, field, value string) string {
+	if v, ok := kubeletConfig.ExtraArgs()[arg]; ok {
+		return v
+	}
+
+	if v, ok := kubeletConfig.ExtraConfig()[field].(string); ok {
+		return v
+	}
+
+	return value
+}
+
+// kubeletReservedMemory builds the memory reservations required by the kubelet static memory manager policy.
+//
+// Memory reserved on NUMA node 0 matches the system and kube reserved memory plus the hard eviction threshold,
+// hugepages reserved for the system are reserved on the NUMA nodes they are allocated on.
+func kubeletReservedMemory(systemReserved, kubeReserved map[string]string, hugePages []talosconfig.HugePage) ([]kubeletconfig.MemoryReservation, error) {
+	memory := apiresource.MustParse(constants.KubeletEvictionHardMemoryAvailable)
+
+	for _, reserved := range []map[string]string{systemReserved, kubeReserved} {
+		value, ok := reserved[string(corev1.ResourceMemory)]
+		if !ok {
+			continue
+		}
+
+		quantity, err := apiresource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing reserved memory %q: %w", value, err)
+		}
+
+		memory.Add(quantity)
+	}
+
+	limits := map[int]corev1.ResourceList{
+		0: {
+			corev1.ResourceMemory: memory,
+		},
+	}
+
+	for _, hugePage := range hugePages {
+		if hugePage.SystemReserved() == 0 {
+			continue
+		}
+
+		if limits[hugePage.NUMANode()] == nil {
+			limits[hugePage.NUMANode()] = corev1.ResourceList{}
+		}
+
+		name := corev1.ResourceName(corev1.ResourceHugePagesPrefix + runtimeres.HugePageSizeName(hugePage.Size()))
+
+		limits[hugePage.NUMANode()][name] = *apiresource.NewQuantity(int64(hugePage.Size())*int64(hugePage.SystemReserved()), apiresource.BinarySI)
+	}
+
+	reservations := make([]kubeletconfig.MemoryReservation, 0, len(limits))
+
+	for numaNode, limit := range limits {
+		reservations = append(reservations, kubeletconfig.MemoryReservation{
+			NumaNode: int32(numaNode),
+			Limits:   limit,
+		})
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].NumaNode < reservations[j].NumaNode
+	})
+
+	return reservations, nil
+}
+
+// mergeKubeletExtraConfig merges user-supplied extra config into the kubelet configuration.
+//
+// Nested objects are merged, while any other values replace the generated ones.
+func mergeKubeletExtraConfig(kubeletConfiguration *kubeletconfig.KubeletConfiguration, extraConfig map[string]interface{}) (*kubeletconfig.KubeletConfiguration, error) {
+	merged, err := toUnstructured(kubeletConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeUnstructured(merged, extraConfig)
+
+	encoded, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+
+	var result kubeletconfig.KubeletConfiguration
+
+	if err = decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func mergeUnstructured(dst, src map[string]interface{}) {
+	for key, srcVal := range src {
+		srcMap, srcOk := srcVal.(map[string]interface{})
+		dstMap, dstOk := dst[key].(map[string]interface{})
+
+		if srcOk && dstOk {
+			mergeUnstructured(dstMap, srcMap)
+
+			continue
+		}
+
+		dst[key] = srcVal
+	}
+}
+
+func toUnstructured(kubeletConfiguration *kubeletconfig.KubeletConfiguration) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(kubeletConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+
+	if err = json.Unmarshal(encoded, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func ipSubnetsFromServiceCIDRs(serviceCIDRs []string) ([]string, error) {
+	// automatically configure valid IP subnets based on service CIDRs
+	// if the primary service CIDR is IPv4, primary kubelet node IP should be IPv4 as well, and so on
+	result := make([]string, 0, len(serviceCIDRs))
+
+	for _, cidr := range serviceCIDRs {
+		network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subnet: %w", err)
+		}
+
+		if network.IP.To4() == nil {
+			result = append(result, "::/0")
+		} else {
+			result = append(result, "0.0.0.0/0")
+		}
+	}
+
+	return result, nil
+}
+
+func primaryServiceCIDRFamily(serviceCIDRs []string) (string, error) {
+	if len(serviceCIDRs) == 0 {
+		return talosconfig.NodeIPFamilyIPv4, nil
+	}
+
+	network, err := net.ParseCIDR(serviceCIDRs[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse subnet: %w", err)
+	}
+
+	if network.IP.To4() == nil {
+		return talosconfig.NodeIPFamilyIPv6, nil
+	}
+
+	return talosconfig.NodeIPFamilyIPv4, nil
+}
@@ -0,0 +1,302 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+//nolint:dupl
+package k8s_test
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+type KubeletConfigSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *KubeletConfigSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.KubeletConfigController{}))
+
+	suite.startRuntime()
+}
+
+func (suite *KubeletConfigSuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *KubeletConfigSuite) getKubeletConfig() (*k8s.KubeletConfigSpec, error) {
+	res, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletConfigType, k8s.KubeletID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil, retry.ExpectedError(err)
+		}
+
+		return nil, err
+	}
+
+	return res.(*k8s.KubeletConfig).TypedSpec(), nil
+}
+
+func (suite *KubeletConfigSuite) TestReconcile() {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineKubelet: &v1alpha1.KubeletConfig{
+				KubeletImage: "ghcr.io/talos-systems/kubelet:v1.23.0",
+				KubeletExtraArgs: map[string]string{
+					"cpu-manager-policy": "static",
+				},
+				KubeletExtraConfig: v1alpha1.Unstructured{
+					Object: map[string]interface{}{
+						"serverTLSBootstrap": true,
+					},
+				},
+			},
+			MachineNodeLabels: map[string]string{
+				"foo": "bar",
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+				DNSDomain:     "cluster.local",
+				PodSubnet:     []string{"10.244.0.0/16"},
+				ServiceSubnet: []string{"10.96.0.0/12"},
+			},
+		},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			spec, err := suite.getKubeletConfig()
+			if err != nil {
+				return err
+			}
+
+			suite.Assert().Equal("ghcr.io/talos-systems/kubelet:v1.23.0", spec.Image)
+			suite.Assert().Equal(map[string]string{"cpu-manager-policy": "static"}, spec.ExtraArgs)
+			suite.Assert().Equal(map[string]string{"foo": "bar"}, spec.NodeLabels)
+			suite.Assert().False(spec.Standalone)
+			suite.Assert().Equal([]string{"0.0.0.0/0", "!10.244.0.0/16"}, spec.NodeIPValidSubnets)
+			suite.Assert().Equal("ipv4", spec.NodeIPPreferredFamily)
+			suite.Assert().Equal("https://foo:6443", spec.APIServerEndpoint)
+			suite.Assert().Equal("http://127.0.0.1:10248/healthz", spec.HealthEndpoint)
+
+			suite.Assert().Equal("KubeletConfiguration", spec.Config["kind"])
+			suite.Assert().Equal("cluster.local", spec.Config["clusterDomain"])
+			suite.Assert().Equal([]interface{}{"10.96.0.10"}, spec.Config["clusterDNS"])
+			suite.Assert().Equal(true, spec.Config["serverTLSBootstrap"])
+			suite.Assert().Equal("static", spec.Config["cpuManagerPolicy"])
+
+			return nil
+		},
+	))
+
+	suite.Require().NoError(suite.state.Destroy(suite.ctx, cfg.Metadata()))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			_, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletConfigType, k8s.KubeletID, resource.VersionUndefined))
+			if err == nil {
+				return retry.ExpectedErrorf("kubelet config is not destroyed")
+			}
+
+			if state.IsNotFoundError(err) {
+				return nil
+			}
+
+			return err
+		},
+	))
+}
+
+func (suite *KubeletConfigSuite) TestSensitiveMounts() {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineKubelet: &v1alpha1.KubeletConfig{
+				KubeletImage: "ghcr.io/talos-systems/kubelet:v1.23.0",
+				KubeletExtraMounts: []v1alpha1.ExtraMount{
+					{Mount: specs.Mount{Source: "/var/mnt/data", Destination: "/var/mnt/data", Type: "bind", Options: []string{"bind"}}},
+					{Mount: specs.Mount{Source: "/system/secrets", Destination: "/var/secrets", Type: "bind", Options: []string{"bind"}}},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+				DNSDomain:     "cluster.local",
+				PodSubnet:     []string{"10.244.0.0/16"},
+				ServiceSubnet: []string{"10.96.0.0/12"},
+			},
+		},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	// the mount exposing the sensitive path is skipped, while the rest of the config is still rendered
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			spec, err := suite.getKubeletConfig()
+			if err != nil {
+				return err
+			}
+
+			suite.Require().Len(spec.ExtraMounts, 1)
+			suite.Assert().Equal("/var/mnt/data", spec.ExtraMounts[0].Source)
+
+			return nil
+		},
+	))
+}
+
+func (suite *KubeletConfigSuite) TestSecretsChecksum() {
+	u, err := url.Parse("https://foo:6443")
+	suite.Require().NoError(err)
+
+	machineConfig := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineKubelet: &v1alpha1.KubeletConfig{
+				KubeletImage: "ghcr.io/talos-systems/kubelet:v1.23.0",
+			},
+			MachineRegistries: v1alpha1.RegistriesConfig{
+				RegistryConfig: map[string]*v1alpha1.RegistryConfig{
+					"registry.example.com": {
+						RegistryAuth: &v1alpha1.RegistryAuthConfig{
+							RegistryUsername: "user",
+							RegistryPassword: "password1",
+						},
+					},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+			ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+				DNSDomain:     "cluster.local",
+				PodSubnet:     []string{"10.244.0.0/16"},
+				ServiceSubnet: []string{"10.96.0.0/12"},
+			},
+		},
+	}
+
+	cfg := config.NewMachineConfig(machineConfig)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	var checksum string
+
+	suite.Require().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			spec, err := suite.getKubeletConfig()
+			if err != nil {
+				return err
+			}
+
+			checksum = spec.SecretsChecksum
+
+			return nil
+		},
+	))
+
+	suite.Assert().NotEmpty(checksum)
+	suite.Assert().NotContains(checksum, "password1")
+
+	// the checksum changes with the secrets, so that the kubelet is restarted
+	machineConfig.MachineConfig.MachineRegistries.RegistryConfig["registry.example.com"].RegistryAuth.RegistryPassword = "password2"
+
+	oldVersion := cfg.Metadata().Version()
+	cfg.Metadata().BumpVersion()
+
+	suite.Require().NoError(suite.state.Update(suite.ctx, oldVersion, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			spec, err := suite.getKubeletConfig()
+			if err != nil {
+				return err
+			}
+
+			if spec.SecretsChecksum == checksum {
+				return retry.ExpectedErrorf("secrets checksum is not updated yet")
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *KubeletConfigSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKubeletConfigSuite(t *testing.T) {
+	suite.Run(t, new(KubeletConfigSuite))
+}
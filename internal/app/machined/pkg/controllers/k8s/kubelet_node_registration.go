@@ -0,0 +1,238 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/talos-systems/talos/pkg/conditions"
+	talosk8s "github.com/talos-systems/talos/pkg/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+// the node is re-registered periodically, as the node might be deleted from the cluster.
+const kubeletNodeRegistrationResyncInterval = 5 * time.Minute
+
+// KubeletNodeRegistrationController registers the node with the pre-assigned pod CIDRs via the kubelet credentials.
+//
+// Kubelet doesn't set the pod CIDRs on the node registration, so if the pod CIDRs are set in the machine configuration,
+// kubelet runs with `--register-node=false`, and the node is created by the controller instead.
+// kube-controller-manager range allocator marks the pod CIDRs of the registered node as used.
+type KubeletNodeRegistrationController struct {
+	// NewClient is used to override the Kubernetes client built from the kubelet kubeconfig in the tests.
+	NewClient func(ctx context.Context) (kubernetes.Interface, func(), error)
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletNodeRegistrationController) Name() string {
+	return "k8s.KubeletNodeRegistrationController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletNodeRegistrationController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.KubeletConfigType,
+			ID:        pointer.ToString(k8s.KubeletID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.NodenameType,
+			ID:        pointer.ToString(k8s.NodenameID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletNodeRegistrationController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *KubeletNodeRegistrationController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	ticker := time.NewTicker(kubeletNodeRegistrationResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletConfigType, k8s.KubeletID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting kubelet config: %w", err)
+		}
+
+		cfgSpec := cfg.(*k8s.KubeletConfig).TypedSpec()
+
+		if cfgSpec.Standalone || len(cfgSpec.PodCIDRs) == 0 {
+			// kubelet registers the node itself
+			continue
+		}
+
+		nodename, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodenameType, k8s.NodenameID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting nodename: %w", err)
+		}
+
+		if err = ctrl.register(ctx, logger, nodename.(*k8s.Nodename).TypedSpec().Nodename, cfgSpec); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *KubeletNodeRegistrationController) newClient(ctx context.Context) (kubernetes.Interface, func(), error) {
+	if ctrl.NewClient != nil {
+		return ctrl.NewClient(ctx)
+	}
+
+	// kubelet kubeconfig is written by the kubelet after the TLS bootstrap
+	if err := conditions.WaitForKubeconfigReady(constants.KubeletKubeconfig).Wait(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	client, err := talosk8s.NewClientFromKubeletKubeconfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	return client, func() {
+		client.Close() //nolint:errcheck
+	}, nil
+}
+
+func (ctrl *KubeletNodeRegistrationController) register(ctx context.Context, logger *zap.Logger, nodename string, cfgSpec *k8s.KubeletConfigSpec) error {
+	client, closer, err := ctrl.newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer closer()
+
+	node, err := client.CoreV1().Nodes().Get(ctx, nodename, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error getting node %q: %w", nodename, err)
+	}
+
+	if err == nil {
+		// pod CIDRs are immutable once set
+		if !reflect.DeepEqual(node.Spec.PodCIDRs, cfgSpec.PodCIDRs) {
+			logger.Error("node pod CIDRs don't match .machine.kubelet.podCIDRs, the node should be deleted to be registered with the new pod CIDRs",
+				zap.String("node", nodename), zap.Strings("pod_cidrs", node.Spec.PodCIDRs), zap.Strings("expected_pod_cidrs", cfgSpec.PodCIDRs))
+		}
+
+		return nil
+	}
+
+	_, err = client.CoreV1().Nodes().Create(ctx, kubeletNode(nodename, cfgSpec), metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// registered concurrently, pod CIDRs are checked on the next resync
+			return nil
+		}
+
+		return fmt.Errorf("error registering node %q: %w", nodename, err)
+	}
+
+	logger.Info("registered node with the pre-assigned pod CIDRs", zap.String("node", nodename), zap.Strings("pod_cidrs", cfgSpec.PodCIDRs))
+
+	return nil
+}
+
+// kubeletNode builds the node object the way kubelet does on the node registration.
+func kubeletNode(nodename string, cfgSpec *k8s.KubeletConfigSpec) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodename,
+			Labels: map[string]string{
+				corev1.LabelHostname:      nodename,
+				corev1.LabelOSStable:      runtime.GOOS,
+				corev1.LabelArchStable:    runtime.GOARCH,
+				"beta.kubernetes.io/os":   runtime.GOOS,
+				"beta.kubernetes.io/arch": runtime.GOARCH,
+			},
+			Annotations: map[string]string{
+				"volumes.kubernetes.io/controller-managed-attach-detach": "true",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			PodCIDR:  cfgSpec.PodCIDRs[0],
+			PodCIDRs: append([]string(nil), cfgSpec.PodCIDRs...),
+		},
+	}
+
+	for key, value := range cfgSpec.NodeLabels {
+		node.Labels[key] = value
+	}
+
+	keys := make([]string, 0, len(cfgSpec.NodeTaints))
+
+	for key := range cfgSpec.NodeTaints {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		taint := corev1.Taint{
+			Key: key,
+		}
+
+		// taint value is either `value:effect` or just `effect`
+		parts := strings.SplitN(cfgSpec.NodeTaints[key], ":", 2)
+
+		if len(parts) == 2 {
+			taint.Value = parts[0]
+			taint.Effect = corev1.TaintEffect(parts[1])
+		} else {
+			taint.Effect = corev1.TaintEffect(parts[0])
+		}
+
+		node.Spec.Taints = append(node.Spec.Taints, taint)
+	}
+
+	if cfgSpec.CloudProviderExternal {
+		node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+			Key:    "node.cloudprovider.kubernetes.io/uninitialized",
+			Value:  "true",
+			Effect: corev1.TaintEffectNoSchedule,
+		})
+	}
+
+	return node
+}
@@ -0,0 +1,221 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	cosiruntime "github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+type KubeletNodeRegistrationSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *cosiruntime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	client *fake.Clientset
+}
+
+func (suite *KubeletNodeRegistrationSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = cosiruntime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.client = fake.NewSimpleClientset()
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.KubeletNodeRegistrationController{
+		NewClient: func(context.Context) (kubernetes.Interface, func(), error) {
+			return suite.client, func() {}, nil
+		},
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *KubeletNodeRegistrationSuite) createNodename(nodename string) {
+	res := k8s.NewNodename(k8s.ControlPlaneNamespaceName, k8s.NodenameID)
+	res.TypedSpec().Nodename = nodename
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, res))
+}
+
+func (suite *KubeletNodeRegistrationSuite) getNode(nodename string) (*corev1.Node, error) {
+	node, err := suite.client.CoreV1().Nodes().Get(suite.ctx, nodename, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, retry.ExpectedError(err)
+		}
+
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (suite *KubeletNodeRegistrationSuite) TestRegister() {
+	cfg := k8s.NewKubeletConfig(k8s.ControlPlaneNamespaceName, k8s.KubeletID)
+	cfg.TypedSpec().PodCIDRs = []string{"10.244.4.0/23", "fc00:db8:10::/64"}
+	cfg.TypedSpec().CloudProviderExternal = true
+	cfg.TypedSpec().NodeLabels = map[string]string{
+		"node.kubernetes.io/instance-type": "big",
+	}
+	cfg.TypedSpec().NodeTaints = map[string]string{
+		"dedicated": "big:NoSchedule",
+		"gpu":       "NoExecute",
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.createNodename("worker-1")
+
+	var node *corev1.Node
+
+	suite.Require().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			var err error
+
+			node, err = suite.getNode("worker-1")
+
+			return err
+		},
+	))
+
+	suite.Assert().Equal("10.244.4.0/23", node.Spec.PodCIDR)
+	suite.Assert().Equal([]string{"10.244.4.0/23", "fc00:db8:10::/64"}, node.Spec.PodCIDRs)
+	suite.Assert().Equal(map[string]string{
+		"beta.kubernetes.io/arch":          runtime.GOARCH,
+		"beta.kubernetes.io/os":            runtime.GOOS,
+		"kubernetes.io/arch":               runtime.GOARCH,
+		"kubernetes.io/hostname":           "worker-1",
+		"kubernetes.io/os":                 runtime.GOOS,
+		"node.kubernetes.io/instance-type": "big",
+	}, node.Labels)
+	suite.Assert().Equal([]corev1.Taint{
+		{
+			Key:    "dedicated",
+			Value:  "big",
+			Effect: corev1.TaintEffectNoSchedule,
+		},
+		{
+			Key:    "gpu",
+			Effect: corev1.TaintEffectNoExecute,
+		},
+		{
+			Key:    "node.cloudprovider.kubernetes.io/uninitialized",
+			Value:  "true",
+			Effect: corev1.TaintEffectNoSchedule,
+		},
+	}, node.Spec.Taints)
+}
+
+func (suite *KubeletNodeRegistrationSuite) TestRegistered() {
+	// node registered before is not modified
+	_, err := suite.client.CoreV1().Nodes().Create(suite.ctx, &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-2",
+		},
+		Spec: corev1.NodeSpec{
+			PodCIDR:  "10.244.2.0/24",
+			PodCIDRs: []string{"10.244.2.0/24"},
+		},
+	}, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	cfg := k8s.NewKubeletConfig(k8s.ControlPlaneNamespaceName, k8s.KubeletID)
+	cfg.TypedSpec().PodCIDRs = []string{"10.244.4.0/23"}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.createNodename("worker-2")
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			for _, action := range suite.client.Actions() {
+				if action.GetVerb() == "get" && action.GetResource().Resource == "nodes" {
+					return nil
+				}
+			}
+
+			return retry.ExpectedErrorf("node is not checked yet")
+		},
+	))
+
+	node, err := suite.getNode("worker-2")
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal([]string{"10.244.2.0/24"}, node.Spec.PodCIDRs)
+
+	// the only create is the one above
+	creates := 0
+
+	for _, action := range suite.client.Actions() {
+		if action.GetVerb() == "create" {
+			creates++
+		}
+	}
+
+	suite.Assert().Equal(1, creates)
+}
+
+func (suite *KubeletNodeRegistrationSuite) TestNoPodCIDRs() {
+	cfg := k8s.NewKubeletConfig(k8s.ControlPlaneNamespaceName, k8s.KubeletID)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.createNodename("worker-3")
+
+	// kubelet registers the node itself
+	time.Sleep(500 * time.Millisecond)
+
+	suite.Assert().Empty(suite.client.Actions())
+}
+
+func (suite *KubeletNodeRegistrationSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKubeletNodeRegistrationSuite(t *testing.T) {
+	suite.Run(t, new(KubeletNodeRegistrationSuite))
+}
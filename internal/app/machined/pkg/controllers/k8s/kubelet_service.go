@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+// KubeletServiceController restarts the kubelet service when the kubelet spec changes.
+//
+// Kubelet service reads the kubelet spec on start, so the spec the running kubelet was started with
+// is compared with the current one, and any change to the spec is applied by restarting the kubelet.
+type KubeletServiceController struct {
+	// RestartKubelet defaults to restarting the kubelet service.
+	RestartKubelet func(ctx context.Context) error
+	// StartedSpec defaults to the kubelet spec the kubelet service was started with.
+	StartedSpec func() *k8s.KubeletSpecSpec
+}
+
+// startedSpecProvider is implemented by the kubelet service.
+type startedSpecProvider interface {
+	StartedSpec() *k8s.KubeletSpecSpec
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletServiceController) Name() string {
+	return "k8s.KubeletServiceController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletServiceController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.KubeletSpecType,
+			ID:        pointer.ToString(k8s.KubeletID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ServiceType,
+			ID:        pointer.ToString("kubelet"),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletServiceController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *KubeletServiceController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.RestartKubelet == nil {
+		ctrl.RestartKubelet = restartKubelet
+	}
+
+	if ctrl.StartedSpec == nil {
+		ctrl.StartedSpec = kubeletStartedSpec
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		if err := ctrl.reconcile(ctx, r, logger); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *KubeletServiceController) reconcile(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	kubeletResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, v1alpha1.ServiceType, "kubelet", resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			// kubelet is not running, it picks up the latest spec on start
+			return nil
+		}
+
+		return fmt.Errorf("error getting kubelet service: %w", err)
+	}
+
+	if !kubeletResource.(*v1alpha1.Service).Running() {
+		return nil
+	}
+
+	spec, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletSpecType, k8s.KubeletID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("error getting kubelet spec: %w", err)
+	}
+
+	startedSpec := ctrl.StartedSpec()
+	if startedSpec == nil {
+		return nil
+	}
+
+	if reflect.DeepEqual(*startedSpec, *spec.(*k8s.KubeletSpec).TypedSpec()) {
+		return nil
+	}
+
+	logger.Info("restarting kubelet to apply the updated kubelet spec")
+
+	if err = ctrl.RestartKubelet(ctx); err != nil {
+		// the restart is retried on the next kubelet spec or service update
+		logger.Error("error restarting kubelet", zap.Error(err))
+	}
+
+	return nil
+}
+
+func kubeletStartedSpec() *k8s.KubeletSpecSpec {
+	svc, _, err := system.Services(nil).IsRunning("kubelet")
+	if err != nil {
+		return nil
+	}
+
+	provider, ok := svc.(startedSpecProvider)
+	if !ok {
+		return nil
+	}
+
+	return provider.StartedSpec()
+}
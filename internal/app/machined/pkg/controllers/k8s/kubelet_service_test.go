@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+type KubeletServiceSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	mu          sync.Mutex
+	startedSpec *k8s.KubeletSpecSpec
+	restarts    int
+}
+
+func (suite *KubeletServiceSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.KubeletServiceController{
+		RestartKubelet: func(context.Context) error {
+			suite.mu.Lock()
+			defer suite.mu.Unlock()
+
+			suite.restarts++
+
+			return nil
+		},
+		StartedSpec: func() *k8s.KubeletSpecSpec {
+			suite.mu.Lock()
+			defer suite.mu.Unlock()
+
+			return suite.startedSpec
+		},
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *KubeletServiceSuite) startKubelet(spec k8s.KubeletSpecSpec) {
+	suite.mu.Lock()
+	suite.startedSpec = &spec
+	suite.mu.Unlock()
+
+	svc := v1alpha1.NewService("kubelet")
+	svc.SetRunning(true)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, svc))
+}
+
+func (suite *KubeletServiceSuite) updateSpec(spec k8s.KubeletSpecSpec) {
+	res, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletSpecType, k8s.KubeletID, resource.VersionUndefined))
+	if state.IsNotFoundError(err) {
+		kubeletSpec := k8s.NewKubeletSpec(k8s.ControlPlaneNamespaceName, k8s.KubeletID)
+		*kubeletSpec.TypedSpec() = spec
+
+		suite.Require().NoError(suite.state.Create(suite.ctx, kubeletSpec))
+
+		return
+	}
+
+	suite.Require().NoError(err)
+
+	kubeletSpec := res.(*k8s.KubeletSpec)
+	oldVersion := kubeletSpec.Metadata().Version()
+
+	*kubeletSpec.TypedSpec() = spec
+	kubeletSpec.Metadata().BumpVersion()
+
+	suite.Require().NoError(suite.state.Update(suite.ctx, oldVersion, kubeletSpec))
+}
+
+func (suite *KubeletServiceSuite) getRestarts() int {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+
+	return suite.restarts
+}
+
+func (suite *KubeletServiceSuite) TestSpecUpdatedOnStart() {
+	// kubelet started with the spec which was updated before the kubelet service reported running
+	suite.startKubelet(k8s.KubeletSpecSpec{Image: "kubelet:v1.23.0"})
+	suite.updateSpec(k8s.KubeletSpecSpec{Image: "kubelet:v1.23.1"})
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			if suite.getRestarts() != 1 {
+				return retry.ExpectedErrorf("kubelet is not restarted yet")
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *KubeletServiceSuite) TestSecretsChanged() {
+	spec := k8s.KubeletSpecSpec{Image: "kubelet:v1.23.0", SecretsChecksum: "a"}
+
+	suite.startKubelet(spec)
+	suite.updateSpec(spec)
+
+	// the kubelet is not restarted for the spec it was started with
+	time.Sleep(500 * time.Millisecond)
+
+	suite.Assert().Equal(0, suite.getRestarts())
+
+	spec.SecretsChecksum = "b"
+	suite.updateSpec(spec)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			if suite.getRestarts() != 1 {
+				return retry.ExpectedErrorf("kubelet is not restarted yet")
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *KubeletServiceSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKubeletServiceSuite(t *testing.T) {
+	suite.Run(t, new(KubeletServiceSuite))
+}
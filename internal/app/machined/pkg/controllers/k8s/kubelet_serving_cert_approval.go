@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/talos-systems/talos/pkg/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+)
+
+// kubeletServingCertApprovalInterval is the interval between the checks for the pending kubelet serving CSRs.
+const kubeletServingCertApprovalInterval = 15 * time.Second
+
+// KubeletServingCertApprovalController approves kubelet serving certificate signing requests on the control plane nodes.
+//
+// The requests are approved only if the requested certificate matches the addresses of the node registered in Kubernetes.
+type KubeletServingCertApprovalController struct {
+	kubernetesClient *kubernetes.Client
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletServingCertApprovalController) Name() string {
+	return "k8s.KubeletServingCertApprovalController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletServingCertApprovalController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.KubernetesType,
+			ID:        pointer.ToString(secrets.KubernetesID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletServingCertApprovalController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *KubeletServingCertApprovalController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	defer ctrl.closeClient()
+
+	ticker := time.NewTicker(kubeletServingCertApprovalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+			// admin kubeconfig might have changed
+			ctrl.closeClient()
+		case <-ticker.C:
+		}
+
+		secretsRes, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubernetesType, secrets.KubernetesID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				// not a control plane node (or secrets are not ready yet)
+				ctrl.closeClient()
+
+				continue
+			}
+
+			return fmt.Errorf("error getting kubernetes secrets: %w", err)
+		}
+
+		if ctrl.kubernetesClient == nil {
+			ctrl.kubernetesClient, err = newClientFromKubeconfig(secretsRes.(*secrets.Kubernetes).Certs().AdminKubeconfig)
+			if err != nil {
+				return fmt.Errorf("error building kubernetes client: %w", err)
+			}
+		}
+
+		approved, err := ctrl.kubernetesClient.ApproveKubeletServingCSRs(ctx)
+		if err != nil {
+			// Kubernetes API might be not available yet
+			logger.Debug("failed to approve kubelet serving certificate signing requests", zap.Error(err))
+
+			ctrl.closeClient()
+
+			continue
+		}
+
+		for _, name := range approved {
+			logger.Info("approved kubelet serving certificate signing request", zap.String("csr", name))
+		}
+	}
+}
+
+func (ctrl *KubeletServingCertApprovalController) closeClient() {
+	if ctrl.kubernetesClient != nil {
+		ctrl.kubernetesClient.Close() //nolint:errcheck
+	}
+
+	ctrl.kubernetesClient = nil
+}
+
+func newClientFromKubeconfig(kubeconfig string) (*kubernetes.Client, error) {
+	config, err := clientcmd.BuildConfigFromKubeconfigGetter("", func() (*clientcmdapi.Config, error) {
+		return clientcmd.Load([]byte(kubeconfig))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+
+	config.Timeout = 30 * time.Second
+
+	return kubernetes.NewForConfig(config)
+}
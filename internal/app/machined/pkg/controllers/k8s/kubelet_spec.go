@@ -0,0 +1,361 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	stdnet "net"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	cni "github.com/containerd/go-cni"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/talos-systems/net"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/argsbuilder"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+// KubeletSpecController renders the kubelet arguments and configuration from the kubelet config, nodename and node addresses.
+type KubeletSpecController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *KubeletSpecController) Name() string {
+	return "k8s.KubeletSpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KubeletSpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.KubeletConfigType,
+			ID:        pointer.ToString(k8s.KubeletID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.NodenameType,
+			ID:        pointer.ToString(k8s.NodenameID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.NodeAddressType,
+			ID:        pointer.ToString(network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s)),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KubeletSpecController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: k8s.KubeletSpecType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *KubeletSpecController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletConfigType, k8s.KubeletID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				if err = ctrl.teardownAll(ctx, r); err != nil {
+					return fmt.Errorf("error destroying kubelet spec: %w", err)
+				}
+
+				continue
+			}
+
+			return fmt.Errorf("error getting kubelet config: %w", err)
+		}
+
+		cfgSpec := cfg.(*k8s.KubeletConfig).TypedSpec()
+
+		var nodename string
+
+		// nodename is used only for the node registration
+		if !cfgSpec.Standalone {
+			nodenameResource, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodenameType, k8s.NodenameID, resource.VersionUndefined))
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					continue
+				}
+
+				return fmt.Errorf("error getting nodename: %w", err)
+			}
+
+			nodename = nodenameResource.(*k8s.Nodename).TypedSpec().Nodename
+		}
+
+		var nodeIPs []stdnet.IP
+
+		// if the user supplied node-ip via extra args, no need to pick automatically
+		if _, ok := cfgSpec.ExtraArgs["node-ip"]; !ok {
+			addresses, err := r.Get(ctx, resource.NewMetadata(network.NamespaceName, network.NodeAddressType,
+				network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s), resource.VersionUndefined))
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					continue
+				}
+
+				return fmt.Errorf("error getting node addresses: %w", err)
+			}
+
+			for _, addr := range addresses.(*network.NodeAddress).TypedSpec().Addresses {
+				nodeIPs = append(nodeIPs, addr.IP().IPAddr().IP)
+			}
+		}
+
+		spec, err := buildKubeletSpec(cfgSpec, nodename, nodeIPs, logger)
+		if err != nil {
+			return err
+		}
+
+		if err = r.Modify(ctx, k8s.NewKubeletSpec(k8s.ControlPlaneNamespaceName, k8s.KubeletID), func(res resource.Resource) error {
+			*res.(*k8s.KubeletSpec).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating kubelet spec: %w", err)
+		}
+	}
+}
+
+func (ctrl *KubeletSpecController) teardownAll(ctx context.Context, r controller.Runtime) error {
+	err := r.Destroy(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletSpecType, k8s.KubeletID, resource.VersionUndefined))
+	if err != nil && !state.IsNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
+
+//nolint:gocyclo
+func buildKubeletSpec(cfgSpec *k8s.KubeletConfigSpec, nodename string, nodeIPs []stdnet.IP, logger *zap.Logger) (k8s.KubeletSpecSpec, error) {
+	args := argsbuilder.Args{
+		"container-runtime":          "remote",
+		"container-runtime-endpoint": "unix://" + constants.CRIContainerdAddress,
+		"config":                     constants.KubeletConfig,
+
+		"cert-dir":     constants.KubeletPKIDir,
+		"cni-conf-dir": cni.DefaultNetDir,
+
+		"logging-format": "json",
+	}
+
+	if cfgSpec.Standalone {
+		args["register-node"] = "false"
+	} else {
+		registrationArgs(args, cfgSpec, nodename)
+	}
+
+	// the node with the pre-assigned pod CIDRs is registered by the KubeletNodeRegistrationController
+	if len(cfgSpec.PodCIDRs) > 0 {
+		args["register-node"] = "false"
+	}
+
+	extraMounts := append([]specs.Mount(nil), cfgSpec.ExtraMounts...)
+
+	if cfgSpec.CredentialProviderBinDir != "" {
+		args["image-credential-provider-config"] = constants.KubeletCredentialProviderConfig
+		args["image-credential-provider-bin-dir"] = cfgSpec.CredentialProviderBinDir
+
+		extraMounts = append(extraMounts, specs.Mount{
+			Type:        "bind",
+			Destination: cfgSpec.CredentialProviderBinDir,
+			Source:      cfgSpec.CredentialProviderBinDir,
+			Options:     []string{"bind", "ro"},
+		})
+	}
+
+	// drop the defaults which are not supported by the kubelet image version (e.g. dockershim flags removed in 1.24)
+	version := talosconfig.ParseKubernetesVersionFromImage(cfgSpec.Image)
+
+	for flag := range args {
+		if !talosconfig.KubeletFlagSupported(flag, version) {
+			delete(args, flag)
+		}
+	}
+
+	extraArgs := argsbuilder.Args(cfgSpec.ExtraArgs)
+
+	if !extraArgs.Contains("node-ip") {
+		pickedIPs, err := pickNodeIPs(nodeIPs, cfgSpec.NodeIPValidSubnets, cfgSpec.NodeIPPreferredFamily, logger)
+		if err != nil {
+			return k8s.KubeletSpecSpec{}, err
+		}
+
+		if len(pickedIPs) > 0 {
+			nodeIPsString := make([]string, len(pickedIPs))
+
+			for i := range pickedIPs {
+				nodeIPsString[i] = pickedIPs[i].String()
+			}
+
+			args["node-ip"] = strings.Join(nodeIPsString, ",")
+		}
+	}
+
+	mergePolicies := argsbuilder.MergePolicies{
+		"bootstrap-kubeconfig":       argsbuilder.MergeDenied,
+		"kubeconfig":                 argsbuilder.MergeDenied,
+		"container-runtime":          argsbuilder.MergeDenied,
+		"container-runtime-endpoint": argsbuilder.MergeDenied,
+		"config":                     argsbuilder.MergeDenied,
+		"cert-dir":                   argsbuilder.MergeDenied,
+		"cni-conf-dir":               argsbuilder.MergeDenied,
+		"node-labels":                argsbuilder.MergeAdditive,
+		"register-with-taints":       argsbuilder.MergeAdditive,
+	}
+
+	if cfgSpec.Standalone || len(cfgSpec.PodCIDRs) > 0 {
+		mergePolicies["register-node"] = argsbuilder.MergeDenied
+	}
+
+	if cfgSpec.CredentialProviderBinDir != "" {
+		mergePolicies["image-credential-provider-config"] = argsbuilder.MergeDenied
+		mergePolicies["image-credential-provider-bin-dir"] = argsbuilder.MergeDenied
+	}
+
+	if err := args.Merge(extraArgs, argsbuilder.WithMergePolicies(mergePolicies)); err != nil {
+		return k8s.KubeletSpecSpec{}, err
+	}
+
+	return k8s.KubeletSpecSpec{
+		Image:                cfgSpec.Image,
+		Args:                 args.Args(),
+		ExtraMounts:          extraMounts,
+		ExtraCapabilities:    cfgSpec.ExtraCapabilities,
+		SkipNodeRegistration: cfgSpec.Standalone,
+		ExpectedNodename:     nodename,
+		CACert:               cfgSpec.CACert,
+		APIServerEndpoint:    cfgSpec.APIServerEndpoint,
+		HealthEndpoint:       cfgSpec.HealthEndpoint,
+		TrustdCredentials:    cfgSpec.TrustdCredentials,
+		DebugLogs:            cfgSpec.DebugLogs,
+		SecretsChecksum:      cfgSpec.SecretsChecksum,
+		Config:               cfgSpec.Config,
+	}, nil
+}
+
+// registrationArgs adds the arguments used to register the node with the API server.
+func registrationArgs(args argsbuilder.Args, cfgSpec *k8s.KubeletConfigSpec, nodename string) {
+	args["bootstrap-kubeconfig"] = constants.KubeletBootstrapKubeconfig
+	args["kubeconfig"] = constants.KubeletKubeconfig
+	args["hostname-override"] = nodename
+
+	if cfgSpec.CloudProviderExternal {
+		args["cloud-provider"] = "external"
+	}
+
+	// node labels and taints are applied by the kubelet on node registration
+	if len(cfgSpec.NodeLabels) > 0 {
+		args["node-labels"] = joinSorted(cfgSpec.NodeLabels, func(key, value string) string {
+			return key + "=" + value
+		})
+	}
+
+	if len(cfgSpec.NodeTaints) > 0 {
+		args["register-with-taints"] = joinSorted(cfgSpec.NodeTaints, func(key, value string) string {
+			if !strings.Contains(value, ":") {
+				// taint without a value
+				return key + ":" + value
+			}
+
+			return key + "=" + value
+		})
+	}
+}
+
+// joinSorted formats the map entries sorted by key as a comma-separated list.
+func joinSorted(m map[string]string, format func(key, value string) string) string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	items := make([]string, len(keys))
+
+	for i, key := range keys {
+		items[i] = format(key, m[key])
+	}
+
+	return strings.Join(items, ",")
+}
+
+// pickNodeIPs picks a single IPv4 and a single IPv6 node IP, the IP of the preferred family goes first.
+func pickNodeIPs(ips []stdnet.IP, cidrs []string, preferredFamily string, logger *zap.Logger) ([]stdnet.IP, error) {
+	if len(cidrs) == 0 {
+		return nil, nil
+	}
+
+	ips, err := net.FilterIPs(ips, cidrs)
+	if err != nil {
+		return nil, err
+	}
+
+	// filter down to make sure only one IPv4 and one IPv6 address stays
+	var hasIPv4, hasIPv6 bool
+
+	result := make([]stdnet.IP, 0, 2)
+
+	for _, ip := range ips {
+		switch {
+		case ip.To4() != nil:
+			if !hasIPv4 {
+				result = append(result, ip)
+				hasIPv4 = true
+			} else {
+				logger.Warn("skipped node IP, please use .machine.kubelet.nodeIP to provide explicit subnet for the node IP", zap.Stringer("ip", ip))
+			}
+		case ip.To16() != nil:
+			if !hasIPv6 {
+				result = append(result, ip)
+				hasIPv6 = true
+			} else {
+				logger.Warn("skipped node IP, please use .machine.kubelet.nodeIP to provide explicit subnet for the node IP", zap.Stringer("ip", ip))
+			}
+		}
+	}
+
+	// dual-stack kubelet expects the node IPs in the order of the cluster address families
+	sort.SliceStable(result, func(i, j int) bool {
+		iIPv4 := result[i].To4() != nil
+		jIPv4 := result[j].To4() != nil
+
+		if preferredFamily == talosconfig.NodeIPFamilyIPv6 {
+			return !iIPv4 && jIPv4
+		}
+
+		return iIPv4 && !jIPv4
+	})
+
+	return result, nil
+}
@@ -0,0 +1,242 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+//nolint:dupl
+package k8s_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	"inet.af/netaddr"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+type KubeletSpecSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *KubeletSpecSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.KubeletSpecController{}))
+
+	suite.startRuntime()
+}
+
+func (suite *KubeletSpecSuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *KubeletSpecSuite) getKubeletSpec() (*k8s.KubeletSpecSpec, error) {
+	res, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletSpecType, k8s.KubeletID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil, retry.ExpectedError(err)
+		}
+
+		return nil, err
+	}
+
+	return res.(*k8s.KubeletSpec).TypedSpec(), nil
+}
+
+func (suite *KubeletSpecSuite) TestReconcile() {
+	cfg := k8s.NewKubeletConfig(k8s.ControlPlaneNamespaceName, k8s.KubeletID)
+	cfg.TypedSpec().Image = "ghcr.io/talos-systems/kubelet:v1.24.0"
+	cfg.TypedSpec().ExtraArgs = map[string]string{
+		"node-labels": "role=worker",
+	}
+	cfg.TypedSpec().NodeLabels = map[string]string{
+		"foo": "bar",
+	}
+	cfg.TypedSpec().CredentialProviderBinDir = "/usr/local/lib/kubelet/credentialproviders"
+	cfg.TypedSpec().NodeIPValidSubnets = []string{"10.0.0.0/8", "!10.0.0.3/32"}
+	cfg.TypedSpec().NodeIPPreferredFamily = "ipv4"
+	cfg.TypedSpec().CACert = "ca"
+	cfg.TypedSpec().APIServerEndpoint = "https://foo:6443"
+	cfg.TypedSpec().HealthEndpoint = "http://127.0.0.1:10248/healthz"
+	cfg.TypedSpec().Config = map[string]interface{}{
+		"kind": "KubeletConfiguration",
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	nodename := k8s.NewNodename(k8s.ControlPlaneNamespaceName, k8s.NodenameID)
+	nodename.TypedSpec().Nodename = "foo.com"
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, nodename))
+
+	nodeAddresses := network.NewNodeAddress(network.NamespaceName, network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s))
+	nodeAddresses.TypedSpec().Addresses = []netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("10.0.0.3/24"),
+		netaddr.MustParseIPPrefix("10.0.0.2/24"),
+		netaddr.MustParseIPPrefix("fd00::1/64"),
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, nodeAddresses))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			spec, err := suite.getKubeletSpec()
+			if err != nil {
+				return err
+			}
+
+			suite.Assert().Equal(cfg.TypedSpec().Image, spec.Image)
+			suite.Assert().Equal([]string{
+				"--bootstrap-kubeconfig=/etc/kubernetes/bootstrap-kubeconfig",
+				"--cert-dir=/var/lib/kubelet/pki",
+				"--config=/etc/kubernetes/kubelet.yaml",
+				"--container-runtime=remote",
+				"--container-runtime-endpoint=unix:///run/containerd/containerd.sock",
+				"--hostname-override=foo.com",
+				"--image-credential-provider-bin-dir=/usr/local/lib/kubelet/credentialproviders",
+				"--image-credential-provider-config=/etc/kubernetes/kubelet-credentialproviders.yaml",
+				"--kubeconfig=/etc/kubernetes/kubeconfig-kubelet",
+				"--logging-format=json",
+				"--node-ip=10.0.0.2",
+				"--node-labels=foo=bar,role=worker",
+			}, spec.Args)
+			suite.Assert().Len(spec.ExtraMounts, 1)
+			suite.Assert().Equal("/usr/local/lib/kubelet/credentialproviders", spec.ExtraMounts[0].Source)
+			suite.Assert().False(spec.SkipNodeRegistration)
+			suite.Assert().Equal("foo.com", spec.ExpectedNodename)
+			suite.Assert().Equal("ca", spec.CACert)
+			suite.Assert().Equal("https://foo:6443", spec.APIServerEndpoint)
+			suite.Assert().Equal("http://127.0.0.1:10248/healthz", spec.HealthEndpoint)
+			suite.Assert().Equal(cfg.TypedSpec().Config, spec.Config)
+
+			return nil
+		},
+	))
+}
+
+func (suite *KubeletSpecSuite) TestPodCIDRs() {
+	cfg := k8s.NewKubeletConfig(k8s.ControlPlaneNamespaceName, k8s.KubeletID)
+	cfg.TypedSpec().Image = "ghcr.io/talos-systems/kubelet:v1.23.0"
+	cfg.TypedSpec().PodCIDRs = []string{"10.244.4.0/23"}
+	cfg.TypedSpec().ExtraArgs = map[string]string{
+		"node-ip": "10.0.0.5",
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	nodename := k8s.NewNodename(k8s.ControlPlaneNamespaceName, k8s.NodenameID)
+	nodename.TypedSpec().Nodename = "foo.com"
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, nodename))
+
+	// the node is registered by the controller
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			spec, err := suite.getKubeletSpec()
+			if err != nil {
+				return err
+			}
+
+			suite.Assert().Contains(spec.Args, "--register-node=false")
+			suite.Assert().Contains(spec.Args, "--hostname-override=foo.com")
+			suite.Assert().Contains(spec.Args, "--kubeconfig=/etc/kubernetes/kubeconfig-kubelet")
+			suite.Assert().False(spec.SkipNodeRegistration)
+			suite.Assert().Equal("foo.com", spec.ExpectedNodename)
+
+			return nil
+		},
+	))
+}
+
+func (suite *KubeletSpecSuite) TestStandalone() {
+	cfg := k8s.NewKubeletConfig(k8s.ControlPlaneNamespaceName, k8s.KubeletID)
+	cfg.TypedSpec().Image = "ghcr.io/talos-systems/kubelet:v1.23.0"
+	cfg.TypedSpec().Standalone = true
+	cfg.TypedSpec().ExtraArgs = map[string]string{
+		"node-ip": "10.0.0.5",
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	// neither nodename nor node addresses are required
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			spec, err := suite.getKubeletSpec()
+			if err != nil {
+				return err
+			}
+
+			suite.Assert().Contains(spec.Args, "--register-node=false")
+			suite.Assert().Contains(spec.Args, "--node-ip=10.0.0.5")
+			suite.Assert().Contains(spec.Args, "--cni-conf-dir=/etc/cni/net.d")
+			suite.Assert().NotContains(spec.Args, "--hostname-override=")
+			suite.Assert().True(spec.SkipNodeRegistration)
+			suite.Assert().Empty(spec.ExpectedNodename)
+
+			return nil
+		},
+	))
+
+	suite.Require().NoError(suite.state.Destroy(suite.ctx, cfg.Metadata()))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			_, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletSpecType, k8s.KubeletID, resource.VersionUndefined))
+			if err == nil {
+				return retry.ExpectedErrorf("kubelet spec is not destroyed")
+			}
+
+			if state.IsNotFoundError(err) {
+				return nil
+			}
+
+			return err
+		},
+	))
+}
+
+func (suite *KubeletSpecSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKubeletSpecSuite(t *testing.T) {
+	suite.Run(t, new(KubeletSpecSuite))
+}
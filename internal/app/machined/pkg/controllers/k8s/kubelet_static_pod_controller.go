@@ -24,6 +24,7 @@ import (
 	k8sadapter "github.com/talos-systems/talos/internal/app/machined/pkg/adapters/k8s"
 	"github.com/talos-systems/talos/pkg/kubernetes/kubelet"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
 	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
 	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
@@ -69,6 +70,12 @@ func (ctrl *KubeletStaticPodController) Inputs() []controller.Input {
 			ID:        pointer.ToString(secrets.KubernetesRootID),
 			Kind:      controller.InputWeak,
 		},
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
 	}
 }
 
@@ -131,12 +138,34 @@ func (ctrl *KubeletStaticPodController) Run(ctx context.Context, r controller.Ru
 			continue
 		}
 
+		staticPods, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing static pods: %w", err)
+		}
+
+		for _, staticPod := range staticPods.Items {
+			switch staticPod.Metadata().Phase() {
+			case resource.PhaseRunning:
+				if err = ctrl.writePod(logger, staticPod); err != nil {
+					return fmt.Errorf("error running pod: %w", err)
+				}
+			case resource.PhaseTearingDown:
+				if err = ctrl.teardownPod(logger, staticPod); err != nil {
+					return fmt.Errorf("error tearing down pod: %w", err)
+				}
+			}
+		}
+
+		if err = ctrl.cleanupPods(logger, staticPods.Items); err != nil {
+			return fmt.Errorf("error cleaning up static pods: %w", err)
+		}
+
+		// pod status is fetched from the kubelet with the API server kubelet client certificate,
+		// which is available only on the nodes with the Kubernetes control plane secrets
 		rootSecretResource, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubernetesRootType, secrets.KubernetesRootID, resource.VersionUndefined))
 		if err != nil {
 			if state.IsNotFoundError(err) {
-				if err = ctrl.cleanupPods(logger, nil); err != nil {
-					return fmt.Errorf("error cleaning up static pods: %w", err)
-				}
+				kubeletClient = nil
 
 				continue
 			}
@@ -149,9 +178,7 @@ func (ctrl *KubeletStaticPodController) Run(ctx context.Context, r controller.Ru
 		secretsResource, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubernetesType, secrets.KubernetesID, resource.VersionUndefined))
 		if err != nil {
 			if state.IsNotFoundError(err) {
-				if err = ctrl.cleanupPods(logger, nil); err != nil {
-					return fmt.Errorf("error cleaning up static pods: %w", err)
-				}
+				kubeletClient = nil
 
 				continue
 			}
@@ -169,31 +196,17 @@ func (ctrl *KubeletStaticPodController) Run(ctx context.Context, r controller.Ru
 
 		nodename := nodenameResource.(*k8s.Nodename).TypedSpec().Nodename
 
-		staticPods, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "", resource.VersionUndefined))
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
 		if err != nil {
-			return fmt.Errorf("error listing static pods: %w", err)
+			// machine config should exist if the kubelet is running
+			return fmt.Errorf("error getting config: %w", err)
 		}
 
-		for _, staticPod := range staticPods.Items {
-			switch staticPod.Metadata().Phase() {
-			case resource.PhaseRunning:
-				if err = ctrl.writePod(logger, staticPod); err != nil {
-					return fmt.Errorf("error running pod: %w", err)
-				}
-			case resource.PhaseTearingDown:
-				if err = ctrl.teardownPod(logger, staticPod); err != nil {
-					return fmt.Errorf("error tearing down pod: %w", err)
-				}
-			}
-		}
-
-		if err = ctrl.cleanupPods(logger, staticPods.Items); err != nil {
-			return fmt.Errorf("error cleaning up static pods: %w", err)
-		}
+		kubeletPort := cfg.(*config.MachineConfig).Config().Machine().Kubelet().Port()
 
 		// render static pods first, and attempt to build kubelet client last,
 		// as if kubelet issues certs from the API server, API server should be launched first.
-		kubeletClient, err = kubelet.NewClient(nodename, secrets.APIServerKubeletClient.Crt, secrets.APIServerKubeletClient.Key, rootSecrets.CA.Crt)
+		kubeletClient, err = kubelet.NewClient(nodename, kubeletPort, secrets.APIServerKubeletClient.Crt, secrets.APIServerKubeletClient.Key, rootSecrets.CA.Crt)
 		if err != nil {
 			return fmt.Errorf("error building kubelet client: %w", err)
 		}
@@ -0,0 +1,135 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/talos-systems/go-loadbalancer/loadbalancer"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+// LoadBalancerController runs the node-local Kubernetes API server load balancer.
+//
+// Load balancer health checks the upstreams and proxies the connections only to the healthy ones.
+type LoadBalancerController struct {
+	lb         *loadbalancer.TCP
+	listenAddr string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *LoadBalancerController) Name() string {
+	return "k8s.LoadBalancerController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *LoadBalancerController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.LoadBalancerConfigType,
+			ID:        pointer.ToString(k8s.LoadBalancerConfigID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *LoadBalancerController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *LoadBalancerController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	defer ctrl.stop(logger)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		lbConfig, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.LoadBalancerConfigType, k8s.LoadBalancerConfigID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				ctrl.stop(logger)
+
+				continue
+			}
+
+			return fmt.Errorf("error getting load balancer config: %w", err)
+		}
+
+		spec := lbConfig.(*k8s.LoadBalancerConfig).TypedSpec()
+		listenAddr := net.JoinHostPort(spec.Host, strconv.Itoa(spec.Port))
+
+		if ctrl.lb != nil && ctrl.listenAddr == listenAddr {
+			if err = ctrl.lb.ReconcileRoute(listenAddr, spec.Upstreams); err != nil {
+				return fmt.Errorf("error updating load balancer upstreams: %w", err)
+			}
+
+			continue
+		}
+
+		ctrl.stop(logger)
+
+		if err = ctrl.start(logger, listenAddr, spec.Upstreams); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *LoadBalancerController) start(logger *zap.Logger, listenAddr string, upstreams []string) error {
+	// per-connection messages are logged at the debug level
+	lbLogger, err := zap.NewStdLogAt(logger, zapcore.DebugLevel)
+	if err != nil {
+		return err
+	}
+
+	lb := &loadbalancer.TCP{
+		Logger: lbLogger,
+	}
+
+	if err = lb.AddRoute(listenAddr, upstreams); err != nil {
+		return fmt.Errorf("error adding load balancer route: %w", err)
+	}
+
+	if err = lb.Start(); err != nil {
+		return fmt.Errorf("error starting load balancer: %w", err)
+	}
+
+	ctrl.lb = lb
+	ctrl.listenAddr = listenAddr
+
+	logger.Info("load balancer started", zap.String("address", listenAddr), zap.Strings("upstreams", upstreams))
+
+	return nil
+}
+
+func (ctrl *LoadBalancerController) stop(logger *zap.Logger) {
+	if ctrl.lb == nil {
+		return
+	}
+
+	if err := ctrl.lb.Close(); err != nil {
+		logger.Error("error stopping load balancer", zap.Error(err))
+	}
+
+	logger.Info("load balancer stopped", zap.String("address", ctrl.listenAddr))
+
+	ctrl.lb = nil
+	ctrl.listenAddr = ""
+}
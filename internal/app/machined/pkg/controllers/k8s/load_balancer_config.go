@@ -0,0 +1,161 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+// LoadBalancerConfigController computes the node-local Kubernetes API server load balancer upstreams.
+type LoadBalancerConfigController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *LoadBalancerConfigController) Name() string {
+	return "k8s.LoadBalancerConfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *LoadBalancerConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.EndpointType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *LoadBalancerConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: k8s.LoadBalancerConfigType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *LoadBalancerConfigController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				if err = ctrl.teardownAll(ctx, r); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		cfgProvider := cfg.(*config.MachineConfig).Config()
+
+		if !cfgProvider.Machine().Features().ControlPlaneLoadBalancer().Enabled() {
+			if err = ctrl.teardownAll(ctx, r); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		endpoints, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.EndpointType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing endpoints: %w", err)
+		}
+
+		upstreams := loadBalancerUpstreams(cfgProvider, endpoints.Items)
+
+		if err = r.Modify(ctx, k8s.NewLoadBalancerConfig(k8s.ControlPlaneNamespaceName, k8s.LoadBalancerConfigID), func(r resource.Resource) error {
+			spec := r.(*k8s.LoadBalancerConfig).TypedSpec()
+
+			spec.Host = "127.0.0.1"
+			spec.Port = cfgProvider.Machine().Features().ControlPlaneLoadBalancer().Port()
+			spec.Upstreams = upstreams
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating load balancer config: %w", err)
+		}
+	}
+}
+
+// loadBalancerUpstreams returns the internal cluster endpoint and the API server endpoints of the known control plane nodes.
+func loadBalancerUpstreams(cfgProvider talosconfig.Provider, endpoints []resource.Resource) []string {
+	clusterEndpoint := cfgProvider.Cluster().InternalEndpoint()
+
+	port := clusterEndpoint.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	upstreams := []string{net.JoinHostPort(clusterEndpoint.Hostname(), port)}
+
+	localAPIServerPort := strconv.Itoa(cfgProvider.Cluster().LocalAPIServerPort())
+
+	for _, endpoint := range endpoints {
+		for _, addr := range endpoint.(*k8s.Endpoint).TypedSpec().Addresses {
+			upstreams = append(upstreams, net.JoinHostPort(addr.String(), localAPIServerPort))
+		}
+	}
+
+	sort.Strings(upstreams)
+
+	// deduplicate upstreams, as the endpoints are reported both by kube-apiserver and cluster discovery
+	result := upstreams[:0]
+
+	for i := range upstreams {
+		if i > 0 && upstreams[i] == upstreams[i-1] {
+			continue
+		}
+
+		result = append(result, upstreams[i])
+	}
+
+	return result
+}
+
+func (ctrl *LoadBalancerConfigController) teardownAll(ctx context.Context, r controller.Runtime) error {
+	list, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.LoadBalancerConfigType, "", resource.VersionUndefined))
+	if err != nil {
+		return err
+	}
+
+	for _, res := range list.Items {
+		if err = r.Destroy(ctx, res.Metadata()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+//nolint:dupl
+package k8s_test
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	"inet.af/netaddr"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+type LoadBalancerConfigSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *LoadBalancerConfigSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.LoadBalancerConfigController{}))
+
+	suite.startRuntime()
+}
+
+func (suite *LoadBalancerConfigSuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *LoadBalancerConfigSuite) assertLoadBalancerConfig(expected k8s.LoadBalancerConfigSpec) error {
+	res, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.LoadBalancerConfigType, k8s.LoadBalancerConfigID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return retry.ExpectedError(err)
+		}
+
+		return err
+	}
+
+	spec := *res.(*k8s.LoadBalancerConfig).TypedSpec()
+
+	if !reflect.DeepEqual(spec, expected) {
+		return retry.ExpectedErrorf("expected %v, got %v", expected, spec)
+	}
+
+	return nil
+}
+
+func (suite *LoadBalancerConfigSuite) TestReconcile() {
+	u, err := url.Parse("https://cluster.example.com")
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineFeatures: &v1alpha1.FeaturesConfig{
+				ControlPlaneLoadBalancerSupport: &v1alpha1.ControlPlaneLoadBalancerConfig{
+					ControlPlaneLoadBalancerEnabled: pointer.ToBool(true),
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+			},
+		},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertLoadBalancerConfig(k8s.LoadBalancerConfigSpec{
+				Host:      "127.0.0.1",
+				Port:      7445,
+				Upstreams: []string{"cluster.example.com:443"},
+			})
+		},
+	))
+
+	apiServerEndpoints := k8s.NewEndpoint(k8s.ControlPlaneNamespaceName, k8s.ControlPlaneAPIServerEndpointsID)
+	apiServerEndpoints.TypedSpec().Addresses = []netaddr.IP{netaddr.MustParseIP("10.5.0.2"), netaddr.MustParseIP("10.5.0.3")}
+	suite.Require().NoError(suite.state.Create(suite.ctx, apiServerEndpoints))
+
+	discoveredEndpoints := k8s.NewEndpoint(k8s.ControlPlaneNamespaceName, k8s.ControlPlaneDiscoveredEndpointsID)
+	discoveredEndpoints.TypedSpec().Addresses = []netaddr.IP{netaddr.MustParseIP("10.5.0.3"), netaddr.MustParseIP("fd00::4")}
+	suite.Require().NoError(suite.state.Create(suite.ctx, discoveredEndpoints))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertLoadBalancerConfig(k8s.LoadBalancerConfigSpec{
+				Host:      "127.0.0.1",
+				Port:      7445,
+				Upstreams: []string{"10.5.0.2:6443", "10.5.0.3:6443", "[fd00::4]:6443", "cluster.example.com:443"},
+			})
+		},
+	))
+
+	// disabling the load balancer removes the config
+	oldVersion := cfg.Metadata().Version()
+
+	cfg.Config().Machine().Features().ControlPlaneLoadBalancer().(*v1alpha1.ControlPlaneLoadBalancerConfig).ControlPlaneLoadBalancerEnabled = pointer.ToBool(false)
+	cfg.Metadata().BumpVersion()
+
+	suite.Require().NoError(suite.state.Update(suite.ctx, oldVersion, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			_, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.LoadBalancerConfigType, k8s.LoadBalancerConfigID, resource.VersionUndefined))
+			if err == nil {
+				return retry.ExpectedErrorf("load balancer config still exists")
+			}
+
+			if state.IsNotFoundError(err) {
+				return nil
+			}
+
+			return err
+		},
+	))
+}
+
+func (suite *LoadBalancerConfigSuite) TestReconcileInternalEndpoint() {
+	u, err := url.Parse("https://cluster.example.com")
+	suite.Require().NoError(err)
+
+	internalURL, err := url.Parse("https://10.5.0.1:6443")
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineFeatures: &v1alpha1.FeaturesConfig{
+				ControlPlaneLoadBalancerSupport: &v1alpha1.ControlPlaneLoadBalancerConfig{
+					ControlPlaneLoadBalancerEnabled: pointer.ToBool(true),
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{
+					URL: u,
+				},
+				InternalEndpoint: &v1alpha1.Endpoint{
+					URL: internalURL,
+				},
+			},
+		},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	apiServerEndpoints := k8s.NewEndpoint(k8s.ControlPlaneNamespaceName, k8s.ControlPlaneAPIServerEndpointsID)
+	apiServerEndpoints.TypedSpec().Addresses = []netaddr.IP{netaddr.MustParseIP("10.5.0.2")}
+	suite.Require().NoError(suite.state.Create(suite.ctx, apiServerEndpoints))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertLoadBalancerConfig(k8s.LoadBalancerConfigSpec{
+				Host:      "127.0.0.1",
+				Port:      7445,
+				Upstreams: []string{"10.5.0.1:6443", "10.5.0.2:6443"},
+			})
+		},
+	))
+}
+
+func (suite *LoadBalancerConfigSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestLoadBalancerConfigSuite(t *testing.T) {
+	suite.Run(t, new(LoadBalancerConfigSuite))
+}
@@ -172,7 +172,6 @@ func (ctrl *ManifestController) render(cfg config.K8sManifestsSpec, scrt *secret
 	}
 
 	defaultManifests := []manifestDesc{
-		{"00-kubelet-bootstrapping-token", kubeletBootstrappingToken},
 		{"01-csr-node-bootstrap", csrNodeBootstrapTemplate},
 		{"01-csr-approver-role-binding", csrApproverRoleBindingTemplate},
 		{"01-csr-renewal-role-binding", csrRenewalRoleBindingTemplate},
@@ -180,6 +179,10 @@ func (ctrl *ManifestController) render(cfg config.K8sManifestsSpec, scrt *secret
 		{"11-kube-config-in-cluster", kubeConfigInClusterTemplate},
 	}
 
+	if cfg.StaticBootstrapTokenEnabled {
+		defaultManifests = append(defaultManifests, manifestDesc{"00-kubelet-bootstrapping-token", kubeletBootstrappingToken})
+	}
+
 	if cfg.CoreDNSEnabled {
 		defaultManifests = append(defaultManifests,
 			[]manifestDesc{
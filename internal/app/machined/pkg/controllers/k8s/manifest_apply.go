@@ -8,14 +8,18 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync/atomic"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/cosi-project/runtime/pkg/controller"
 	"github.com/cosi-project/runtime/pkg/resource"
 	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/talos-systems/go-retry/retry"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/sync/errgroup"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +42,15 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
 )
 
+// Manifest apply settings.
+const (
+	// manifestApplyParallelism is the number of objects applied concurrently.
+	manifestApplyParallelism = 8
+
+	manifestApplyObjectTimeout = 5 * time.Minute
+	manifestApplyRetryInterval = time.Second
+)
+
 // ManifestApplyController applies manifests via control plane endpoint.
 type ManifestApplyController struct{}
 
@@ -178,7 +191,7 @@ func (ctrl *ManifestApplyController) Run(ctx context.Context, r controller.Runti
 }
 
 func (ctrl *ManifestApplyController) etcdLock(ctx context.Context, logger *zap.Logger, f func() error) error {
-	etcdClient, err := etcd.NewLocalClient()
+	etcdClient, err := etcd.NewSharedLocalClient()
 	if err != nil {
 		return fmt.Errorf("error creating etcd client: %w", err)
 	}
@@ -207,92 +220,165 @@ func (ctrl *ManifestApplyController) etcdLock(ctx context.Context, logger *zap.L
 	return f()
 }
 
-//nolint:gocyclo
 func (ctrl *ManifestApplyController) apply(ctx context.Context, logger *zap.Logger, mapper *restmapper.DeferredDiscoveryRESTMapper, dyn dynamic.Interface, manifests resource.List) error {
-	// flatten list of objects to be applied
-	objects := make([]*unstructured.Unstructured, 0, len(manifests.Items))
+	// namespaces come first, followed by CRDs and everything else after that, objects within a phase are applied concurrently
+	phases := []struct {
+		name    string
+		objects []*unstructured.Unstructured
+	}{
+		{name: "namespaces"},
+		{name: "crds"},
+		{name: "objects"},
+	}
 
 	for _, manifest := range manifests.Items {
-		objects = append(objects, k8sadapter.Manifest(manifest.(*k8s.Manifest)).Objects()...)
+		for _, obj := range k8sadapter.Manifest(manifest.(*k8s.Manifest)).Objects() {
+			gvk := obj.GroupVersionKind()
+
+			switch {
+			case isNamespace(gvk):
+				phases[0].objects = append(phases[0].objects, obj)
+			case isCRD(gvk):
+				phases[1].objects = append(phases[1].objects, obj)
+			default:
+				phases[2].objects = append(phases[2].objects, obj)
+			}
+		}
 	}
 
-	// sort the list so that namespaces come first, followed by CRDs and everything else after that
-	sort.SliceStable(objects, func(i, j int) bool {
-		objL := objects[i]
-		objR := objects[j]
-
-		gvkL := objL.GroupVersionKind()
-		gvkR := objR.GroupVersionKind()
+	for _, phase := range phases {
+		if len(phase.objects) == 0 {
+			continue
+		}
 
-		if isNamespace(gvkL) {
-			if !isNamespace(gvkR) {
-				return true
-			}
+		start := time.Now()
 
-			return objL.GetName() < objR.GetName()
+		if err := ctrl.applyObjects(ctx, logger.With(zap.String("phase", phase.name)), mapper, dyn, phase.objects); err != nil {
+			return err
 		}
 
-		if isNamespace(gvkR) {
-			return false
-		}
+		logger.Info("applied manifests", zap.String("phase", phase.name), zap.Int("objects", len(phase.objects)), zap.Duration("duration", time.Since(start)))
+	}
 
-		if isCRD(gvkL) {
-			if !isCRD(gvkR) {
-				return true
-			}
+	return nil
+}
 
-			return objL.GetName() < objR.GetName()
-		}
+// applyObjects applies independent objects with bounded parallelism.
+func (ctrl *ManifestApplyController) applyObjects(ctx context.Context, logger *zap.Logger, mapper *restmapper.DeferredDiscoveryRESTMapper, dyn dynamic.Interface,
+	objects []*unstructured.Unstructured,
+) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	objCh := make(chan *unstructured.Unstructured)
+
+	eg.Go(func() error {
+		defer close(objCh)
 
-		if isCRD(gvkR) {
-			return false
+		for _, obj := range objects {
+			select {
+			case objCh <- obj:
+			case <-egCtx.Done():
+				return nil
+			}
 		}
 
-		return false
+		return nil
 	})
 
-	for _, obj := range objects {
-		gvk := obj.GroupVersionKind()
-		objName := fmt.Sprintf("%s/%s/%s/%s", gvk.Group, gvk.Version, gvk.Kind, obj.GetName())
+	var applied int32
 
-		mapping, err := mapper.RESTMapping(obj.GroupVersionKind().GroupKind(), obj.GroupVersionKind().Version)
-		if err != nil {
-			return fmt.Errorf("error creating mapping for object %s: %w", objName, err)
-		}
+	for i := 0; i < manifestApplyParallelism && i < len(objects); i++ {
+		eg.Go(func() error {
+			for obj := range objCh {
+				if err := ctrl.applyObject(egCtx, logger, mapper, dyn, obj); err != nil {
+					return err
+				}
 
-		var dr dynamic.ResourceInterface
-		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
-			// namespaced resources should specify the namespace
-			dr = dyn.Resource(mapping.Resource).Namespace(obj.GetNamespace())
-		} else {
-			// for cluster-wide resources
-			dr = dyn.Resource(mapping.Resource)
-		}
+				logger.Debug("manifest apply progress", zap.Int32("applied", atomic.AddInt32(&applied, 1)), zap.Int("total", len(objects)))
+			}
 
-		_, err = dr.Get(ctx, obj.GetName(), metav1.GetOptions{})
-		if err == nil {
-			// already exists
-			continue
-		}
+			return nil
+		})
+	}
 
-		if !apierrors.IsNotFound(err) {
-			return fmt.Errorf("error checking resource existence: %w", err)
-		}
+	return eg.Wait()
+}
 
-		_, err = dr.Create(ctx, obj, metav1.CreateOptions{
-			FieldManager: "talos",
-		})
-		if err != nil {
-			if apierrors.IsAlreadyExists(err) {
-				// later on we might want to do something here, e.g. do server-side apply, for now do nothing
-			} else {
-				return fmt.Errorf("error creating %s: %w", objName, err)
+// applyObject creates the object if it doesn't exist yet, retrying transient errors with backoff.
+func (ctrl *ManifestApplyController) applyObject(ctx context.Context, logger *zap.Logger, mapper *restmapper.DeferredDiscoveryRESTMapper, dyn dynamic.Interface,
+	obj *unstructured.Unstructured,
+) error {
+	gvk := obj.GroupVersionKind()
+	objName := fmt.Sprintf("%s/%s/%s/%s", gvk.Group, gvk.Version, gvk.Kind, obj.GetName())
+
+	attempt := 0
+
+	return retry.Exponential(manifestApplyObjectTimeout, retry.WithUnits(manifestApplyRetryInterval), retry.WithJitter(manifestApplyRetryInterval)).RetryWithContext(ctx,
+		func(ctx context.Context) error {
+			attempt++
+
+			err := ctrl.createObject(ctx, logger, mapper, dyn, obj, objName)
+			if err == nil {
+				return nil
 			}
-		} else {
-			logger.Sugar().Infof("created %s", objName)
+
+			if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+				// the object will never be accepted
+				return err
+			}
+
+			if meta.IsNoMatchError(err) {
+				// the kind might be defined by the CRD which was just created, refresh the discovery information
+				mapper.Reset()
+			}
+
+			logger.Debug("retrying manifest object", zap.String("object", objName), zap.Int("attempt", attempt), zap.Error(err))
+
+			return retry.ExpectedError(err)
+		})
+}
+
+func (ctrl *ManifestApplyController) createObject(ctx context.Context, logger *zap.Logger, mapper *restmapper.DeferredDiscoveryRESTMapper, dyn dynamic.Interface,
+	obj *unstructured.Unstructured, objName string,
+) error {
+	mapping, err := mapper.RESTMapping(obj.GroupVersionKind().GroupKind(), obj.GroupVersionKind().Version)
+	if err != nil {
+		return fmt.Errorf("error creating mapping for object %s: %w", objName, err)
+	}
+
+	var dr dynamic.ResourceInterface
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		// namespaced resources should specify the namespace
+		dr = dyn.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	} else {
+		// for cluster-wide resources
+		dr = dyn.Resource(mapping.Resource)
+	}
+
+	_, err = dr.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err == nil {
+		// already exists
+		return nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error checking resource existence: %w", err)
+	}
+
+	_, err = dr.Create(ctx, obj, metav1.CreateOptions{
+		FieldManager: "talos",
+	})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// later on we might want to do something here, e.g. do server-side apply, for now do nothing
+			return nil
 		}
+
+		return fmt.Errorf("error creating %s: %w", objName, err)
 	}
 
+	logger.Info("created manifest object", zap.String("object", objName))
+
 	return nil
 }
 
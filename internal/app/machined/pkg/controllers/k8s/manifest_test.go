@@ -94,6 +94,8 @@ var defaultManifestSpec = config.K8sManifestsSpec{
 
 	PodCIDRs: []string{constants.DefaultIPv4PodNet},
 
+	StaticBootstrapTokenEnabled: true,
+
 	ProxyEnabled: true,
 	ProxyImage:   "foo/bar",
 	ProxyArgs: []string{
@@ -250,6 +252,36 @@ func (suite *ManifestSuite) TestReconcileDisablePSP() {
 	))
 }
 
+func (suite *ManifestSuite) TestReconcileRetireStaticBootstrapToken() {
+	rootSecrets := secrets.NewKubernetesRoot(secrets.KubernetesRootID)
+	manifestConfig := config.NewK8sManifests()
+	spec := defaultManifestSpec
+	spec.StaticBootstrapTokenEnabled = false
+	manifestConfig.SetManifests(spec)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, rootSecrets))
+	suite.Require().NoError(suite.state.Create(suite.ctx, manifestConfig))
+
+	suite.Assert().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertManifests(
+				[]string{
+					"01-csr-approver-role-binding",
+					"01-csr-node-bootstrap",
+					"01-csr-renewal-role-binding",
+					"02-kube-system-sa-role-binding",
+					"03-default-pod-security-policy",
+					"05-flannel",
+					"10-kube-proxy",
+					"11-core-dns",
+					"11-core-dns-svc",
+					"11-kube-config-in-cluster",
+				},
+			)
+		},
+	))
+}
+
 func (suite *ManifestSuite) TearDownTest() {
 	suite.T().Log("tear down")
 
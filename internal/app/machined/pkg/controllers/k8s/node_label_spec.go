@@ -0,0 +1,170 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// NodeLabelSpecController builds node labels from the machine config labels, rules and the detected hardware.
+type NodeLabelSpecController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *NodeLabelSpecController) Name() string {
+	return "k8s.NodeLabelSpecController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *NodeLabelSpecController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: runtime.NamespaceName,
+			Type:      runtime.HardwareFactsType,
+			ID:        pointer.ToString(runtime.HardwareFactsID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *NodeLabelSpecController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: k8s.NodeLabelSpecType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *NodeLabelSpecController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		labels := map[string]string{}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		facts, err := r.Get(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.HardwareFactsType, runtime.HardwareFactsID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting hardware facts: %w", err)
+		}
+
+		if cfg != nil {
+			// explicit node labels take precedence over the rules
+			for key, value := range cfg.(*config.MachineConfig).Config().Machine().NodeLabels() {
+				labels[key] = value
+			}
+		}
+
+		if cfg != nil && facts != nil {
+			for _, rule := range cfg.(*config.MachineConfig).Config().Machine().NodeLabelRules() {
+				if _, ok := labels[rule.Label()]; ok {
+					// explicit label or the first matching rule wins
+					continue
+				}
+
+				if matchNodeLabelRule(rule, facts.(*runtime.HardwareFacts).TypedSpec()) {
+					labels[rule.Label()] = rule.Value()
+				}
+			}
+		}
+
+		for key, value := range labels {
+			value := value
+
+			if err = r.Modify(ctx, k8s.NewNodeLabelSpec(key), func(res resource.Resource) error {
+				res.(*k8s.NodeLabelSpec).TypedSpec().Key = key
+				res.(*k8s.NodeLabelSpec).TypedSpec().Value = value
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating node label spec: %w", err)
+			}
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodeLabelSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing resources: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if _, ok := labels[res.Metadata().ID()]; !ok {
+				if err = r.Destroy(ctx, res.Metadata()); err != nil {
+					return fmt.Errorf("error cleaning up node label spec: %w", err)
+				}
+			}
+		}
+	}
+}
+
+// matchNodeLabelRule checks that the hardware satisfies all the rule conditions.
+func matchNodeLabelRule(rule talosconfig.NodeLabelRule, facts *runtime.HardwareFactsSpec) bool {
+	contains := func(list []string, item string) bool {
+		for _, v := range list {
+			if strings.EqualFold(v, item) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if rule.CPUVendor() != "" && !strings.EqualFold(rule.CPUVendor(), facts.CPUVendor) {
+		return false
+	}
+
+	for _, flag := range rule.CPUFlags() {
+		if !contains(facts.CPUFlags, flag) {
+			return false
+		}
+	}
+
+	if rule.GPUVendor() != "" && !contains(facts.GPUVendors, rule.GPUVendor()) {
+		return false
+	}
+
+	if rule.GPUModel() != "" && !contains(facts.GPUModels, rule.GPUModel()) {
+		return false
+	}
+
+	if rule.DiskClass() != "" && !contains(facts.DiskClasses, rule.DiskClass()) {
+		return false
+	}
+
+	if rule.MinNICSpeed() > 0 && facts.NICSpeed < rule.MinNICSpeed() {
+		return false
+	}
+
+	return true
+}
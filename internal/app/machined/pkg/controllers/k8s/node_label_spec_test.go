@@ -0,0 +1,216 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	runtimeres "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type NodeLabelSpecSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *NodeLabelSpecSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.NodeLabelSpecController{}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *NodeLabelSpecSuite) assertLabels(expected map[string]string) func() error {
+	return func() error {
+		list, err := suite.state.List(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodeLabelSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return err
+		}
+
+		labels := map[string]string{}
+
+		for _, res := range list.Items {
+			spec := res.(*k8s.NodeLabelSpec).TypedSpec()
+
+			labels[spec.Key] = spec.Value
+		}
+
+		if len(labels) != len(expected) {
+			return retry.ExpectedErrorf("expected labels %v, got %v", expected, labels)
+		}
+
+		for key, value := range expected {
+			if labels[key] != value {
+				return retry.ExpectedErrorf("expected labels %v, got %v", expected, labels)
+			}
+		}
+
+		return nil
+	}
+}
+
+func (suite *NodeLabelSpecSuite) TestReconcile() {
+	facts := runtimeres.NewHardwareFacts()
+	*facts.TypedSpec() = runtimeres.HardwareFactsSpec{
+		CPUVendor:   "GenuineIntel",
+		CPUFlags:    []string{"avx2", "avx512f", "sse4_2"},
+		GPUVendors:  []string{"NVIDIA"},
+		GPUModels:   []string{"10de:20b0"},
+		DiskClasses: []string{runtimeres.DiskClassNVMe},
+		NICSpeed:    10000,
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, facts))
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNodeLabelRules: []v1alpha1.NodeLabelRuleConfig{
+				{
+					RuleLabel: "example.com/gpu",
+					RuleValue: "amd",
+					RuleMatch: v1alpha1.NodeLabelMatchConfig{
+						MatchGPUVendor: "AMD",
+					},
+				},
+				{
+					RuleLabel: "example.com/gpu",
+					RuleValue: "a100",
+					RuleMatch: v1alpha1.NodeLabelMatchConfig{
+						MatchGPUModel: "10DE:20B0",
+					},
+				},
+				{
+					RuleLabel: "example.com/gpu",
+					RuleValue: "nvidia",
+					RuleMatch: v1alpha1.NodeLabelMatchConfig{
+						MatchGPUVendor: "nvidia",
+					},
+				},
+				{
+					RuleLabel: "example.com/avx512",
+					RuleMatch: v1alpha1.NodeLabelMatchConfig{
+						MatchCPUVendor: "genuineintel",
+						MatchCPUFlags:  []string{"avx512f", "avx2"},
+					},
+				},
+				{
+					RuleLabel: "example.com/amx",
+					RuleMatch: v1alpha1.NodeLabelMatchConfig{
+						MatchCPUFlags: []string{"amx_tile"},
+					},
+				},
+				{
+					RuleLabel: "example.com/fast-storage",
+					RuleMatch: v1alpha1.NodeLabelMatchConfig{
+						MatchDiskClass:   "nvme",
+						MatchMinNICSpeed: 25000,
+					},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertLabels(map[string]string{
+		"example.com/gpu":    "a100",
+		"example.com/avx512": "true",
+	})))
+
+	// faster NIC
+	_, err := suite.state.UpdateWithConflicts(suite.ctx, facts.Metadata(), func(r resource.Resource) error {
+		r.(*runtimeres.HardwareFacts).TypedSpec().NICSpeed = 25000
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertLabels(map[string]string{
+		"example.com/gpu":          "a100",
+		"example.com/avx512":       "true",
+		"example.com/fast-storage": "true",
+	})))
+
+	// explicit labels override the rules
+	_, err = suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineNodeLabels = map[string]string{
+			"example.com/gpu":  "none",
+			"example.com/rack": "r12",
+		}
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertLabels(map[string]string{
+		"example.com/gpu":          "none",
+		"example.com/rack":         "r12",
+		"example.com/avx512":       "true",
+		"example.com/fast-storage": "true",
+	})))
+
+	// rules and labels removed
+	_, err = suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineNodeLabelRules = nil
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineNodeLabels = nil
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertLabels(map[string]string{})))
+}
+
+func (suite *NodeLabelSpecSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestNodeLabelSpecSuite(t *testing.T) {
+	suite.Run(t, new(NodeLabelSpecSuite))
+}
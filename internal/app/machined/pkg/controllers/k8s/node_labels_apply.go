@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/conditions"
+	"github.com/talos-systems/talos/pkg/kubernetes"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+// node labels are reapplied periodically, as the node might be re-registered.
+const nodeLabelsResyncInterval = 5 * time.Minute
+
+// NodeLabelsApplyController applies NodeLabelSpecs to the Kubernetes node via the kubelet credentials.
+type NodeLabelsApplyController struct {
+	kubernetesClient *kubernetes.Client
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *NodeLabelsApplyController) Name() string {
+	return "k8s.NodeLabelsApplyController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *NodeLabelsApplyController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.NodeLabelSpecType,
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.NodenameType,
+			ID:        pointer.ToString(k8s.NodenameID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *NodeLabelsApplyController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *NodeLabelsApplyController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	defer func() {
+		if ctrl.kubernetesClient != nil {
+			ctrl.kubernetesClient.Close() //nolint:errcheck
+		}
+
+		ctrl.kubernetesClient = nil
+	}()
+
+	ticker := time.NewTicker(nodeLabelsResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		nodename, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodenameType, k8s.NodenameID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting nodename: %w", err)
+			}
+
+			continue
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodeLabelSpecType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing node label specs: %w", err)
+		}
+
+		labels := make(map[string]string, len(list.Items))
+
+		for _, res := range list.Items {
+			spec := res.(*k8s.NodeLabelSpec).TypedSpec()
+
+			labels[spec.Key] = spec.Value
+		}
+
+		if len(labels) == 0 {
+			// nothing to apply, but the labels applied before should be removed if the node is already registered
+			if _, err = os.Stat(constants.KubeletKubeconfig); err != nil {
+				continue
+			}
+		} else if err = conditions.WaitForKubeconfigReady(constants.KubeletKubeconfig).Wait(ctx); err != nil {
+			return err
+		}
+
+		if ctrl.kubernetesClient == nil {
+			ctrl.kubernetesClient, err = kubernetes.NewClientFromKubeletKubeconfig()
+			if err != nil {
+				return fmt.Errorf("error building kubernetes client: %w", err)
+			}
+		}
+
+		if err = ctrl.kubernetesClient.ApplyNodeLabels(ctx, nodename.(*k8s.Nodename).TypedSpec().Nodename, labels); err != nil {
+			// reset client connection
+			ctrl.kubernetesClient.Close() //nolint:errcheck
+			ctrl.kubernetesClient = nil
+
+			return fmt.Errorf("error applying node labels: %w", err)
+		}
+	}
+}
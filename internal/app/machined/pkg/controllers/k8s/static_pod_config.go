@@ -0,0 +1,153 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+// StaticPodConfigController manages k8s.StaticPod based on the machine config static pods.
+type StaticPodConfigController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *StaticPodConfigController) Name() string {
+	return "k8s.StaticPodConfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *StaticPodConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *StaticPodConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: k8s.StaticPodType,
+			Kind: controller.OutputShared,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *StaticPodConfigController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		touchedIDs := map[resource.ID]struct{}{}
+
+		if cfg != nil {
+			for _, pod := range cfg.(*config.MachineConfig).Config().Machine().Pods() {
+				pod := pod
+
+				var id resource.ID
+
+				id, err = staticPodID(pod)
+				if err != nil {
+					// skip invalid pods, so that the other pods are still managed
+					logger.Error("invalid static pod", zap.Error(err))
+
+					continue
+				}
+
+				if err = r.Modify(ctx, k8s.NewStaticPod(k8s.ControlPlaneNamespaceName, id), func(r resource.Resource) error {
+					r.(*k8s.StaticPod).TypedSpec().Pod = pod
+
+					return nil
+				}); err != nil {
+					return fmt.Errorf("error modifying static pod: %w", err)
+				}
+
+				touchedIDs[id] = struct{}{}
+			}
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing static pods: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if res.Metadata().Owner() != ctrl.Name() {
+				continue
+			}
+
+			if _, ok := touchedIDs[res.Metadata().ID()]; ok {
+				continue
+			}
+
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up static pod: %w", err)
+			}
+		}
+	}
+}
+
+// staticPodID validates the static pod manifest and builds the resource ID as `<namespace>-<name>`.
+func staticPodID(pod map[string]interface{}) (resource.ID, error) {
+	encoded, err := json.Marshal(pod)
+	if err != nil {
+		return "", fmt.Errorf("error encoding static pod: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+
+	var v1Pod v1.Pod
+
+	if err = decoder.Decode(&v1Pod); err != nil {
+		return "", fmt.Errorf("error decoding static pod %q: %w", v1Pod.Name, err)
+	}
+
+	if v1Pod.APIVersion != "v1" || v1Pod.Kind != "Pod" {
+		return "", fmt.Errorf("static pod %q is not a v1 Pod", v1Pod.Name)
+	}
+
+	if v1Pod.Name == "" {
+		return "", fmt.Errorf("static pod name is empty")
+	}
+
+	if len(v1Pod.Spec.Containers) == 0 {
+		return "", fmt.Errorf("static pod %q has no containers", v1Pod.Name)
+	}
+
+	namespace := v1Pod.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	return fmt.Sprintf("%s-%s", namespace, v1Pod.Name), nil
+}
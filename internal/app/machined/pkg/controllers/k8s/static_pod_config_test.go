@@ -0,0 +1,173 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+)
+
+type StaticPodConfigSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *StaticPodConfigSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.StaticPodConfigController{}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *StaticPodConfigSuite) assertStaticPods(expected []string) func() error {
+	return func() error {
+		list, err := suite.state.List(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "", resource.VersionUndefined))
+		if err != nil {
+			return err
+		}
+
+		ids := []string{}
+
+		for _, res := range list.Items {
+			ids = append(ids, res.Metadata().ID())
+		}
+
+		sort.Strings(ids)
+
+		if len(ids) != len(expected) {
+			return retry.ExpectedErrorf("expected static pods %v, got %v", expected, ids)
+		}
+
+		for i := range ids {
+			if ids[i] != expected[i] {
+				return retry.ExpectedErrorf("expected static pods %v, got %v", expected, ids)
+			}
+		}
+
+		return nil
+	}
+}
+
+func staticPod(namespace, name string) v1alpha1.Unstructured {
+	metadata := map[string]interface{}{
+		"name": name,
+	}
+
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+
+	return v1alpha1.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{
+						"name":  name,
+						"image": "nginx",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (suite *StaticPodConfigSuite) TestReconcile() {
+	invalidPod := staticPod("default", "invalid")
+	invalidPod.Object["spec"].(map[string]interface{})["containerz"] = []interface{}{}
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachinePods: []v1alpha1.Unstructured{
+				staticPod("", "nginx"),
+				staticPod("monitoring", "exporter"),
+				invalidPod,
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStaticPods([]string{"default-nginx", "monitoring-exporter"}),
+	))
+
+	res, err := suite.state.Get(suite.ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "default-nginx", resource.VersionUndefined))
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal("Pod", res.(*k8s.StaticPod).TypedSpec().Pod["kind"])
+
+	// static pods owned by other controllers are not touched
+	controlPlanePod := k8s.NewStaticPod(k8s.ControlPlaneNamespaceName, "kube-apiserver")
+	suite.Require().NoError(suite.state.Create(suite.ctx, controlPlanePod, state.WithCreateOwner("k8s.ControlPlaneStaticPodController")))
+
+	_, err = suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachinePods = []v1alpha1.Unstructured{
+			staticPod("monitoring", "exporter"),
+		}
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStaticPods([]string{"kube-apiserver", "monitoring-exporter"}),
+	))
+}
+
+func (suite *StaticPodConfigSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestStaticPodConfigSuite(t *testing.T) {
+	suite.Run(t, new(StaticPodConfigSuite))
+}
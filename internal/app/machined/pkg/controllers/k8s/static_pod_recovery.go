@@ -0,0 +1,457 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	containerdapi "github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	criconstants "github.com/containerd/cri/pkg/constants"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+	yaml "gopkg.in/yaml.v3"
+	v1 "k8s.io/api/core/v1"
+
+	k8sadapter "github.com/talos-systems/talos/internal/app/machined/pkg/adapters/k8s"
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/containers/image"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+const (
+	staticPodRecoveryCheckInterval = 30 * time.Second
+	staticPodRecoveryBackoff       = 5 * time.Minute
+
+	// staticPodCrashLoopThreshold is the number of container restarts after which the crashlooping pod is recovered.
+	staticPodCrashLoopThreshold = 5
+)
+
+// imageFailureReasons are the container waiting reasons which indicate that the container image is broken.
+var imageFailureReasons = map[string]struct{}{
+	"ErrImagePull":         {},
+	"ImagePullBackOff":     {},
+	"CreateContainerError": {},
+}
+
+// StaticPodRecoveryController recovers the control plane static pods which are missing or crashlooping.
+//
+// The static pod manifest is rewritten from the k8s.StaticPod resource (which is rendered from the machine config)
+// if the manifest is missing or doesn't match the resource. The images of the crashlooping containers or the containers
+// which fail to start are removed and pulled again, as the image content might be corrupted.
+type StaticPodRecoveryController struct {
+	V1Alpha1Events v1alpha1runtime.Publisher
+
+	// PullImage removes the image and pulls it again, defaults to pulling the image via the CRI containerd.
+	PullImage func(ctx context.Context, reg talosconfig.Registries, ref string) error
+
+	// ManifestsDirectory defaults to constants.ManifestsDirectory.
+	ManifestsDirectory string
+	// CheckInterval defaults to 30 seconds.
+	CheckInterval time.Duration
+	// RecoveryBackoff is the minimum interval between the recovery attempts of the same static pod, defaults to 5 minutes.
+	RecoveryBackoff time.Duration
+
+	failingSince map[string]time.Time
+	lastRecovery map[string]time.Time
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *StaticPodRecoveryController) Name() string {
+	return "k8s.StaticPodRecoveryController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *StaticPodRecoveryController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.StaticPodType,
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.StaticPodStatusType,
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: k8s.ControlPlaneNamespaceName,
+			Type:      k8s.NodenameType,
+			ID:        pointer.ToString(k8s.NodenameID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      v1alpha1.ServiceType,
+			ID:        pointer.ToString("kubelet"),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.KubernetesType,
+			ID:        pointer.ToString(secrets.KubernetesID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *StaticPodRecoveryController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *StaticPodRecoveryController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.PullImage == nil {
+		ctrl.PullImage = repullImage
+	}
+
+	if ctrl.ManifestsDirectory == "" {
+		ctrl.ManifestsDirectory = constants.ManifestsDirectory
+	}
+
+	if ctrl.CheckInterval == 0 {
+		ctrl.CheckInterval = staticPodRecoveryCheckInterval
+	}
+
+	if ctrl.RecoveryBackoff == 0 {
+		ctrl.RecoveryBackoff = staticPodRecoveryBackoff
+	}
+
+	if ctrl.failingSince == nil {
+		ctrl.failingSince = map[string]time.Time{}
+	}
+
+	if ctrl.lastRecovery == nil {
+		ctrl.lastRecovery = map[string]time.Time{}
+	}
+
+	// the manifests are checked periodically, as the manifest files are not watched
+	ticker := time.NewTicker(ctrl.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		if err := ctrl.reconcile(ctx, r, logger); err != nil {
+			return err
+		}
+	}
+}
+
+//nolint:gocyclo
+func (ctrl *StaticPodRecoveryController) reconcile(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	// static pods are only written by the k8s.KubeletStaticPodController when the kubelet is running and the secrets are ready
+	kubeletResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, v1alpha1.ServiceType, "kubelet", resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !kubeletResource.(*v1alpha1.Service).Running() {
+		return nil
+	}
+
+	if _, err = r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubernetesType, secrets.KubernetesID, resource.VersionUndefined)); err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	nodenameResource, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodenameType, k8s.NodenameID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	nodename := nodenameResource.(*k8s.Nodename).TypedSpec().Nodename
+
+	cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	registries := cfg.(*config.MachineConfig).Config().Machine().Registries()
+
+	staticPods, err := r.List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing static pods: %w", err)
+	}
+
+	for _, res := range staticPods.Items {
+		if res.Metadata().Phase() != resource.PhaseRunning {
+			continue
+		}
+
+		staticPod := res.(*k8s.StaticPod)
+
+		reason, imageRefs, err := ctrl.diagnose(ctx, r, staticPod, nodename)
+		if err != nil {
+			return err
+		}
+
+		id := staticPod.Metadata().ID()
+
+		if reason == "" {
+			delete(ctrl.failingSince, id)
+
+			continue
+		}
+
+		// the failure should persist, as the manifest might be observed while it's being updated by the k8s.KubeletStaticPodController
+		if _, failing := ctrl.failingSince[id]; !failing {
+			ctrl.failingSince[id] = time.Now()
+		}
+
+		if time.Since(ctrl.failingSince[id]) < ctrl.CheckInterval/2 {
+			continue
+		}
+
+		if since := time.Since(ctrl.lastRecovery[id]); since < ctrl.RecoveryBackoff {
+			logger.Debug("static pod recovery backed off", zap.String("pod", id), zap.String("reason", reason), zap.Duration("since", since))
+
+			continue
+		}
+
+		ctrl.lastRecovery[id] = time.Now()
+
+		logger.Warn("recovering static pod", zap.String("pod", id), zap.String("reason", reason))
+
+		actions, err := ctrl.recoverPod(ctx, staticPod, registries, imageRefs)
+		if err != nil {
+			// the recovery is retried after the backoff
+			logger.Error("static pod recovery failed", zap.String("pod", id), zap.Error(err))
+
+			continue
+		}
+
+		logger.Info("static pod recovered", zap.String("pod", id), zap.Strings("actions", actions))
+
+		ctrl.V1Alpha1Events.Publish(&machine.StaticPodRecoveryEvent{
+			Pod:     id,
+			Reason:  reason,
+			Actions: actions,
+		})
+	}
+
+	return nil
+}
+
+func (ctrl *StaticPodRecoveryController) podPath(staticPod *k8s.StaticPod) string {
+	return filepath.Join(ctrl.ManifestsDirectory, fmt.Sprintf("%s%s.yaml", constants.TalosManifestPrefix, staticPod.Metadata().ID()))
+}
+
+// diagnose returns the reason the static pod should be recovered and the images to re-pull.
+//
+// Empty reason is returned for the healthy static pod.
+//
+//nolint:gocyclo
+func (ctrl *StaticPodRecoveryController) diagnose(ctx context.Context, r controller.Runtime, staticPod *k8s.StaticPod, nodename string) (string, []string, error) {
+	renderedPod, err := yaml.Marshal(staticPod.Spec())
+	if err != nil {
+		return "", nil, err
+	}
+
+	existingPod, err := ioutil.ReadFile(ctrl.podPath(staticPod))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", nil, fmt.Errorf("error reading static pod manifest: %w", err)
+		}
+
+		return "manifest is missing", nil, nil
+	}
+
+	if !bytes.Equal(renderedPod, existingPod) {
+		return "manifest is corrupted", nil, nil
+	}
+
+	pod, err := k8sadapter.StaticPod(staticPod).Pod()
+	if err != nil {
+		return "", nil, fmt.Errorf("error decoding static pod: %w", err)
+	}
+
+	// kubelet suffixes the static pod mirror name with the node name
+	statusID := fmt.Sprintf("%s/%s-%s", pod.Namespace, pod.Name, nodename)
+
+	statusResource, err := r.Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.StaticPodStatusType, statusID, resource.VersionUndefined))
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			// pod status is not reported yet
+			return "", nil, nil
+		}
+
+		return "", nil, err
+	}
+
+	status, err := decodePodStatus(statusResource.(*k8s.StaticPodStatus))
+	if err != nil {
+		return "", nil, fmt.Errorf("error decoding static pod status: %w", err)
+	}
+
+	var (
+		reasons   []string
+		imageRefs []string
+	)
+
+	for _, containerStatus := range status.ContainerStatuses {
+		waiting := containerStatus.State.Waiting
+		if waiting == nil {
+			continue
+		}
+
+		_, imageFailure := imageFailureReasons[waiting.Reason]
+		crashLooping := waiting.Reason == "CrashLoopBackOff" && containerStatus.RestartCount >= staticPodCrashLoopThreshold
+
+		if !imageFailure && !crashLooping {
+			continue
+		}
+
+		reasons = append(reasons, fmt.Sprintf("container %q is in %s", containerStatus.Name, waiting.Reason))
+
+		for _, container := range pod.Spec.Containers {
+			if container.Name == containerStatus.Name {
+				imageRefs = append(imageRefs, container.Image)
+			}
+		}
+	}
+
+	return strings.Join(reasons, ", "), imageRefs, nil
+}
+
+// recoverPod rewrites the static pod manifest and re-pulls the images.
+func (ctrl *StaticPodRecoveryController) recoverPod(ctx context.Context, staticPod *k8s.StaticPod, registries talosconfig.Registries, imageRefs []string) ([]string, error) {
+	var actions []string
+
+	renderedPod, err := yaml.Marshal(staticPod.Spec())
+	if err != nil {
+		return nil, err
+	}
+
+	podPath := ctrl.podPath(staticPod)
+
+	existingPod, err := ioutil.ReadFile(podPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading static pod manifest: %w", err)
+	}
+
+	if !bytes.Equal(renderedPod, existingPod) {
+		if err = ioutil.WriteFile(podPath, renderedPod, 0o600); err != nil {
+			return nil, fmt.Errorf("error writing static pod manifest: %w", err)
+		}
+
+		actions = append(actions, fmt.Sprintf("manifest %q regenerated", podPath))
+	}
+
+	for _, ref := range imageRefs {
+		if err = ctrl.PullImage(ctx, registries, ref); err != nil {
+			return nil, fmt.Errorf("error pulling image %q: %w", ref, err)
+		}
+
+		actions = append(actions, fmt.Sprintf("image %q re-pulled", ref))
+	}
+
+	return actions, nil
+}
+
+func decodePodStatus(r *k8s.StaticPodStatus) (*v1.PodStatus, error) {
+	var status v1.PodStatus
+
+	jsonSerialized, err := json.Marshal(r.TypedSpec().PodStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(jsonSerialized, &status)
+
+	return &status, err
+}
+
+// repullImage removes all the references to the image from the CRI containerd, so that the image content
+// is garbage collected, and pulls the image again.
+func repullImage(ctx context.Context, reg talosconfig.Registries, ref string) error {
+	client, err := containerdapi.New(constants.CRIContainerdAddress)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer client.Close()
+
+	containerdctx := namespaces.WithNamespace(ctx, criconstants.K8sContainerdNamespace)
+
+	img, err := client.ImageService().Get(containerdctx, ref)
+
+	switch {
+	case errdefs.IsNotFound(err):
+	case err != nil:
+		return err
+	default:
+		// CRI keeps the image references by the tag, the digest and the image ID
+		var refs []images.Image
+
+		refs, err = client.ImageService().List(containerdctx, fmt.Sprintf("target.digest==%s", img.Target.Digest))
+		if err != nil {
+			return err
+		}
+
+		for i, r := range refs {
+			var opts []images.DeleteOpt
+
+			if i == len(refs)-1 {
+				opts = append(opts, images.SynchronousDelete())
+			}
+
+			if err = client.ImageService().Delete(containerdctx, r.Name, opts...); err != nil && !errdefs.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	_, err = image.Pull(containerdctx, reg, client, ref)
+
+	return err
+}
@@ -0,0 +1,283 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	yaml "gopkg.in/yaml.v3"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8sadapter "github.com/talos-systems/talos/internal/app/machined/pkg/adapters/k8s"
+	k8sctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	v1alpha1cfg "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/proto"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+type recoveryEvents struct {
+	mu     sync.Mutex
+	events []*machine.StaticPodRecoveryEvent
+	pulled []string
+}
+
+func (events *recoveryEvents) Publish(m proto.Message) {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	events.events = append(events.events, m.(*machine.StaticPodRecoveryEvent))
+}
+
+func (events *recoveryEvents) pullImage(ctx context.Context, reg talosconfig.Registries, ref string) error {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	events.pulled = append(events.pulled, ref)
+
+	return nil
+}
+
+func (events *recoveryEvents) get() ([]*machine.StaticPodRecoveryEvent, []string) {
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	return append([]*machine.StaticPodRecoveryEvent(nil), events.events...), append([]string(nil), events.pulled...)
+}
+
+type StaticPodRecoverySuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	manifestsDir string
+	events       *recoveryEvents
+}
+
+func (suite *StaticPodRecoverySuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.manifestsDir = suite.T().TempDir()
+	suite.events = &recoveryEvents{}
+
+	suite.Require().NoError(suite.runtime.RegisterController(&k8sctrl.StaticPodRecoveryController{
+		V1Alpha1Events:     suite.events,
+		PullImage:          suite.events.pullImage,
+		ManifestsDirectory: suite.manifestsDir,
+		CheckInterval:      100 * time.Millisecond,
+		RecoveryBackoff:    time.Hour,
+	}))
+
+	kubeletService := v1alpha1.NewService("kubelet")
+	kubeletService.SetRunning(true)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, kubeletService))
+	suite.Require().NoError(suite.state.Create(suite.ctx, secrets.NewKubernetes()))
+
+	nodename := k8s.NewNodename(k8s.ControlPlaneNamespaceName, k8s.NodenameID)
+	nodename.TypedSpec().Nodename = "node1"
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, nodename))
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(&v1alpha1cfg.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1cfg.MachineConfig{},
+	})))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *StaticPodRecoverySuite) newStaticPod() *k8s.StaticPod {
+	staticPod := k8s.NewStaticPod(k8s.ControlPlaneNamespaceName, "kube-scheduler")
+
+	suite.Require().NoError(k8sadapter.StaticPod(staticPod).SetPod(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-scheduler",
+			Namespace: "kube-system",
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "kube-scheduler",
+					Image: "k8s.gcr.io/kube-scheduler:v1.23.0",
+				},
+			},
+		},
+	}))
+
+	return staticPod
+}
+
+// writeManifest writes the static pod manifest before the static pod is created, so that it's never observed missing.
+func (suite *StaticPodRecoverySuite) writeManifest(manifest []byte) {
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.manifestsDir, "talos-kube-scheduler.yaml"), manifest, 0o600))
+}
+
+func (suite *StaticPodRecoverySuite) assertRecovered(reason string) *machine.StaticPodRecoveryEvent {
+	var event *machine.StaticPodRecoveryEvent
+
+	suite.Require().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			events, _ := suite.events.get()
+			if len(events) == 0 {
+				return retry.ExpectedError(fmt.Errorf("no recovery events"))
+			}
+
+			event = events[0]
+
+			return nil
+		},
+	))
+
+	suite.Assert().Equal("kube-scheduler", event.GetPod())
+	suite.Assert().Equal(reason, event.GetReason())
+
+	return event
+}
+
+func (suite *StaticPodRecoverySuite) TestMissingManifest() {
+	suite.Require().NoError(suite.state.Create(suite.ctx, suite.newStaticPod()))
+
+	event := suite.assertRecovered("manifest is missing")
+
+	manifestPath := filepath.Join(suite.manifestsDir, "talos-kube-scheduler.yaml")
+
+	suite.Assert().Equal([]string{fmt.Sprintf("manifest %q regenerated", manifestPath)}, event.GetActions())
+
+	manifest, err := ioutil.ReadFile(manifestPath)
+	suite.Require().NoError(err)
+	suite.Assert().Contains(string(manifest), "k8s.gcr.io/kube-scheduler:v1.23.0")
+
+	// recovery is not repeated for the healthy pod
+	time.Sleep(500 * time.Millisecond)
+
+	events, pulled := suite.events.get()
+	suite.Assert().Len(events, 1)
+	suite.Assert().Empty(pulled)
+}
+
+func (suite *StaticPodRecoverySuite) TestCorruptedManifest() {
+	staticPod := suite.newStaticPod()
+
+	suite.writeManifest([]byte("garbage"))
+	suite.Require().NoError(suite.state.Create(suite.ctx, staticPod))
+
+	suite.assertRecovered("manifest is corrupted")
+
+	manifest, err := ioutil.ReadFile(filepath.Join(suite.manifestsDir, "talos-kube-scheduler.yaml"))
+	suite.Require().NoError(err)
+
+	expected, err := yaml.Marshal(staticPod.Spec())
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(expected, manifest)
+}
+
+func (suite *StaticPodRecoverySuite) TestCrashLoop() {
+	staticPod := suite.newStaticPod()
+
+	manifest, err := yaml.Marshal(staticPod.Spec())
+	suite.Require().NoError(err)
+
+	suite.writeManifest(manifest)
+	suite.Require().NoError(suite.state.Create(suite.ctx, staticPod))
+
+	status := k8s.NewStaticPodStatus(k8s.ControlPlaneNamespaceName, "kube-system/kube-scheduler-node1")
+
+	setStatus := func(restartCount int32) {
+		suite.Require().NoError(k8sadapter.StaticPodStatus(status).SetStatus(&v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "kube-scheduler",
+					RestartCount: restartCount,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{
+							Reason: "CrashLoopBackOff",
+						},
+					},
+				},
+			},
+		}))
+	}
+
+	setStatus(1)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, status))
+
+	// a few restarts are not considered a failure
+	time.Sleep(500 * time.Millisecond)
+
+	events, pulled := suite.events.get()
+	suite.Assert().Empty(events)
+	suite.Assert().Empty(pulled)
+
+	setStatus(10)
+
+	old := status.Metadata().Version()
+
+	status.Metadata().BumpVersion()
+
+	suite.Require().NoError(suite.state.Update(suite.ctx, old, status))
+
+	event := suite.assertRecovered(`container "kube-scheduler" is in CrashLoopBackOff`)
+
+	suite.Assert().Equal([]string{`image "k8s.gcr.io/kube-scheduler:v1.23.0" re-pulled`}, event.GetActions())
+
+	// the recovery of the same pod is backed off
+	time.Sleep(500 * time.Millisecond)
+
+	events, pulled = suite.events.get()
+	suite.Assert().Len(events, 1)
+	suite.Assert().Equal([]string{"k8s.gcr.io/kube-scheduler:v1.23.0"}, pulled)
+}
+
+func (suite *StaticPodRecoverySuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestStaticPodRecoverySuite(t *testing.T) {
+	suite.Run(t, new(StaticPodRecoverySuite))
+}
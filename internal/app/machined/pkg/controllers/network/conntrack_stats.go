@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+const conntrackStatsUpdateInterval = 30 * time.Second
+
+// ConntrackStatsController publishes the connection tracking table utilization and drop counters.
+type ConntrackStatsController struct {
+	// ProcPath defaults to /proc.
+	ProcPath string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *ConntrackStatsController) Name() string {
+	return "network.ConntrackStatsController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ConntrackStatsController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ConntrackStatsController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: network.ConntrackStatsType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *ConntrackStatsController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.ProcPath == "" {
+		ctrl.ProcPath = "/proc"
+	}
+
+	ticker := time.NewTicker(conntrackStatsUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		spec, err := ctrl.readStats()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// connection tracking is not enabled in the kernel
+				continue
+			}
+
+			return fmt.Errorf("error reading conntrack stats: %w", err)
+		}
+
+		if err = r.Modify(ctx, network.NewConntrackStats(network.NamespaceName, network.ConntrackStatsID), func(res resource.Resource) error {
+			*res.(*network.ConntrackStats).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating conntrack stats: %w", err)
+		}
+	}
+}
+
+func (ctrl *ConntrackStatsController) readStats() (network.ConntrackStatsSpec, error) {
+	var (
+		spec network.ConntrackStatsSpec
+		err  error
+	)
+
+	for _, param := range []struct {
+		name  string
+		value *uint64
+	}{
+		{"nf_conntrack_count", &spec.Count},
+		{"nf_conntrack_max", &spec.Max},
+		{"nf_conntrack_buckets", &spec.Buckets},
+	} {
+		if *param.value, err = readProcUint(filepath.Join(ctrl.ProcPath, "sys", "net", "netfilter", param.name)); err != nil {
+			return spec, err
+		}
+	}
+
+	if spec.Max > 0 {
+		spec.Utilization = math.Round(float64(spec.Count)/float64(spec.Max)*1000) / 10
+	}
+
+	counters, err := readConntrackCounters(filepath.Join(ctrl.ProcPath, "net", "stat", "nf_conntrack"))
+	if err != nil {
+		return spec, err
+	}
+
+	spec.Drop = counters["drop"]
+	spec.EarlyDrop = counters["early_drop"]
+	spec.InsertFailed = counters["insert_failed"]
+	spec.Invalid = counters["invalid"]
+
+	return spec, nil
+}
+
+func readProcUint(path string) (uint64, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+}
+
+// readConntrackCounters sums per-CPU conntrack counters, columns are named in the header line and values are hex.
+func readConntrackCounters(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(f)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("missing header in %q", path)
+	}
+
+	columns := strings.Fields(scanner.Text())
+	counters := make(map[string]uint64, len(columns))
+
+	for scanner.Scan() {
+		for i, field := range strings.Fields(scanner.Text()) {
+			if i >= len(columns) {
+				break
+			}
+
+			value, err := strconv.ParseUint(field, 16, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %q: %w", path, err)
+			}
+
+			counters[columns[i]] += value
+		}
+	}
+
+	return counters, scanner.Err()
+}
@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	netctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+type ConntrackStatsSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	root string
+}
+
+func (suite *ConntrackStatsSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.root = suite.T().TempDir()
+
+	suite.writeFile("sys/net/netfilter/nf_conntrack_count", "196608\n")
+	suite.writeFile("sys/net/netfilter/nf_conntrack_max", "262144\n")
+	suite.writeFile("sys/net/netfilter/nf_conntrack_buckets", "65536\n")
+	suite.writeFile("net/stat/nf_conntrack",
+		"entries  clashres found new invalid ignore delete chainlength insert insert_failed drop early_drop icmp_error  expect_new expect_create expect_delete search_restart\n"+
+			"00030000  00000000 00000000 00000000 00000010 00000000 00000000 00000000 00000000 00000001 0000000a 00000002 00000000  00000000 00000000 00000000 00000000\n"+
+			"00030000  00000000 00000000 00000000 00000001 00000000 00000000 00000000 00000000 00000000 00000006 00000000 00000000  00000000 00000000 00000000 00000000\n",
+	)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&netctrl.ConntrackStatsController{
+		ProcPath: suite.root,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *ConntrackStatsSuite) writeFile(path, contents string) {
+	path = filepath.Join(suite.root, path)
+
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0o644))
+}
+
+func (suite *ConntrackStatsSuite) TestStats() {
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, resource.NewMetadata(network.NamespaceName, network.ConntrackStatsType, network.ConntrackStatsID, resource.VersionUndefined))
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			suite.Assert().Equal(network.ConntrackStatsSpec{
+				Count:        196608,
+				Max:          262144,
+				Buckets:      65536,
+				Utilization:  75,
+				Drop:         16,
+				EarlyDrop:    2,
+				InsertFailed: 1,
+				Invalid:      17,
+			}, *r.(*network.ConntrackStats).TypedSpec())
+
+			return nil
+		},
+	))
+}
+
+func (suite *ConntrackStatsSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestConntrackStatsSuite(t *testing.T) {
+	suite.Run(t, new(ConntrackStatsSuite))
+}
@@ -19,9 +19,10 @@ import (
 	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/resources/config"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
 )
 
-// HostnameConfigController manages network.HostnameSpec based on machine configuration, kernel cmdline.
+// HostnameConfigController manages network.HostnameSpec based on machine configuration, kernel cmdline and machine identity.
 type HostnameConfigController struct {
 	Cmdline *procfs.Cmdline
 }
@@ -46,6 +47,12 @@ func (ctrl *HostnameConfigController) Inputs() []controller.Input {
 			ID:        pointer.ToString(network.NodeAddressDefaultID),
 			Kind:      controller.InputWeak,
 		},
+		{
+			Namespace: runtime.NamespaceName,
+			Type:      runtime.MachineIdentityType,
+			ID:        pointer.ToString(runtime.MachineIdentityID),
+			Kind:      controller.InputWeak,
+		},
 	}
 }
 
@@ -109,6 +116,13 @@ func (ctrl *HostnameConfigController) Run(ctx context.Context, r controller.Runt
 		if cfgProvider != nil {
 			configHostname := ctrl.parseMachineConfiguration(logger, cfgProvider)
 
+			if configHostname.Hostname == "" && cfgProvider.Machine().Features().StableHostnameEnabled() {
+				configHostname, err = ctrl.getStableHostname(ctx, r)
+				if err != nil {
+					return err
+				}
+			}
+
 			if configHostname.Hostname != "" {
 				specs = append(specs, configHostname)
 			}
@@ -183,6 +197,25 @@ func (ctrl *HostnameConfigController) getDefault(defaultAddr *network.NodeAddres
 	return spec
 }
 
+// getStableHostname returns hostname derived from the machine identity.
+//
+// Stable hostname is put into the machine configuration layer, so that it overrides hostnames coming from DHCP and platform.
+func (ctrl *HostnameConfigController) getStableHostname(ctx context.Context, r controller.Runtime) (spec network.HostnameSpecSpec, err error) {
+	identity, err := r.Get(ctx, runtime.NewMachineIdentity().Metadata())
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return spec, nil
+		}
+
+		return spec, fmt.Errorf("error getting machine identity: %w", err)
+	}
+
+	spec.Hostname = identity.(*runtime.MachineIdentity).TypedSpec().StableHostname
+	spec.ConfigLayer = network.ConfigMachineConfiguration
+
+	return spec, nil
+}
+
 func (ctrl *HostnameConfigController) parseCmdline(logger *zap.Logger) (spec network.HostnameSpecSpec) {
 	if ctrl.Cmdline == nil {
 		return
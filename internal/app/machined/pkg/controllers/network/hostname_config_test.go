@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/cosi-project/runtime/pkg/controller/runtime"
 	"github.com/cosi-project/runtime/pkg/resource"
 	"github.com/cosi-project/runtime/pkg/state"
@@ -30,6 +31,7 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 	"github.com/talos-systems/talos/pkg/machinery/resources/config"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	runtimeres "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
 )
 
 type HostnameConfigSuite struct {
@@ -209,6 +211,64 @@ func (suite *HostnameConfigSuite) TestMachineConfiguration() {
 		}))
 }
 
+func (suite *HostnameConfigSuite) TestStableHostname() {
+	suite.Require().NoError(suite.runtime.RegisterController(&netctrl.HostnameConfigController{}))
+
+	suite.startRuntime()
+
+	identity := runtimeres.NewMachineIdentity()
+	identity.TypedSpec().StableHostname = "talos-0a1b2c3d"
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, identity))
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineFeatures: &v1alpha1.FeaturesConfig{
+				StableHostname: pointer.ToBool(true),
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertHostnames([]string{
+				"configuration/hostname",
+			}, func(r *network.HostnameSpec) error {
+				suite.Assert().Equal("talos-0a1b2c3d", r.TypedSpec().Hostname)
+				suite.Assert().Equal(network.ConfigMachineConfiguration, r.TypedSpec().ConfigLayer)
+
+				return nil
+			})
+		}))
+
+	// explicit hostname takes precedence
+	_, err := suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineNetwork = &v1alpha1.NetworkConfig{
+			NetworkHostname: "foo",
+		}
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertHostnames([]string{
+				"configuration/hostname",
+			}, func(r *network.HostnameSpec) error {
+				if r.TypedSpec().Hostname != "foo" {
+					return retry.ExpectedErrorf("hostname %q", r.TypedSpec().Hostname)
+				}
+
+				return nil
+			})
+		}))
+}
+
 func (suite *HostnameConfigSuite) TearDownTest() {
 	suite.T().Log("tear down")
 
@@ -0,0 +1,242 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/mdlayher/genetlink"
+	"github.com/mdlayher/netlink"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+// LinkTuningController applies NIC queue count, IRQ affinity and RPS/XPS settings from the machine configuration.
+type LinkTuningController struct {
+	// SysPath defaults to /sys.
+	SysPath string
+	// ProcPath defaults to /proc.
+	ProcPath string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *LinkTuningController) Name() string {
+	return "network.LinkTuningController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *LinkTuningController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.LinkStatusType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *LinkTuningController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *LinkTuningController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	if ctrl.ProcPath == "" {
+		ctrl.ProcPath = "/proc"
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		for _, device := range cfg.(*config.MachineConfig).Config().Machine().Network().Devices() {
+			if device.Ignore() || device.Tuning() == nil {
+				continue
+			}
+
+			_, err = r.Get(ctx, resource.NewMetadata(network.NamespaceName, network.LinkStatusType, device.Interface(), resource.VersionUndefined))
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					// link is not up yet, settings are applied when the link shows up
+					continue
+				}
+
+				return fmt.Errorf("error getting link status: %w", err)
+			}
+
+			ctrl.apply(device.Interface(), device.Tuning(), logger.With(zap.String("link", device.Interface())))
+		}
+	}
+}
+
+// apply reconciles the queue count first, as changing it recreates the queues and IRQs.
+func (ctrl *LinkTuningController) apply(link string, tuning talosconfig.DeviceTuning, logger *zap.Logger) {
+	linkPath := filepath.Join(ctrl.SysPath, "class", "net", link)
+
+	if count := tuning.CombinedQueues(); count > 0 && len(linkQueues(linkPath, "rx")) != int(count) {
+		if err := setCombinedChannels(link, count); err != nil {
+			logger.Warn("failed to set queue count", zap.Uint32("queues", count), zap.Error(err))
+		}
+	}
+
+	if cpus, err := kernel.ParseCPUList(tuning.IRQCPUs()); err == nil {
+		// spread the IRQs round-robin, so that each queue is served by a single CPU
+		for i, irq := range LinkIRQs(ctrl.SysPath, link) {
+			cpu := strconv.Itoa(cpus[i%len(cpus)])
+
+			if err := writeIfChanged(filepath.Join(ctrl.ProcPath, "irq", irq, "smp_affinity_list"), cpu, func(a, b string) bool { return a == b }); err != nil {
+				logger.Debug("failed to set IRQ affinity", zap.String("irq", irq), zap.Error(err))
+			}
+		}
+	}
+
+	for _, steering := range []struct {
+		queueType string
+		file      string
+		cpus      string
+	}{
+		{"rx", "rps_cpus", tuning.RPSCPUs()},
+		{"tx", "xps_cpus", tuning.XPSCPUs()},
+	} {
+		cpus, err := kernel.ParseCPUList(steering.cpus)
+		if err != nil {
+			continue
+		}
+
+		mask := kernel.CPUMask(cpus)
+
+		for _, queuePath := range linkQueues(linkPath, steering.queueType) {
+			if err := writeIfChanged(filepath.Join(queuePath, steering.file), mask, sameCPUMask); err != nil {
+				logger.Warn("failed to set packet steering", zap.String("queue", filepath.Base(queuePath)), zap.Error(err))
+			}
+		}
+	}
+}
+
+// LinkIRQs returns the MSI IRQs of the link device sorted numerically.
+func LinkIRQs(sysPath, link string) []string {
+	paths, _ := filepath.Glob(filepath.Join(sysPath, "class", "net", link, "device", "msi_irqs", "[0-9]*")) //nolint:errcheck
+
+	irqs := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		irqs = append(irqs, filepath.Base(path))
+	}
+
+	sort.Slice(irqs, func(i, j int) bool {
+		a, _ := strconv.Atoi(irqs[i]) //nolint:errcheck
+		b, _ := strconv.Atoi(irqs[j]) //nolint:errcheck
+
+		return a < b
+	})
+
+	return irqs
+}
+
+func linkQueues(linkPath, queueType string) []string {
+	paths, _ := filepath.Glob(filepath.Join(linkPath, "queues", queueType+"-[0-9]*")) //nolint:errcheck
+
+	return paths
+}
+
+func writeIfChanged(path, value string, equal func(a, b string) bool) error {
+	current, err := ioutil.ReadFile(path)
+	if err == nil && equal(strings.TrimSpace(string(current)), value) {
+		return nil
+	}
+
+	return ioutil.WriteFile(path, []byte(value), 0o644)
+}
+
+// sameCPUMask compares CPU masks ignoring the group separators and leading zeroes, e.g. 00000000,000000ff equals ff.
+func sameCPUMask(a, b string) bool {
+	normalize := func(mask string) string {
+		mask = strings.TrimLeft(strings.ReplaceAll(mask, ",", ""), "0")
+
+		if mask == "" {
+			return "0"
+		}
+
+		return mask
+	}
+
+	return normalize(a) == normalize(b)
+}
+
+// setCombinedChannels sets the number of the combined NIC channels via ethtool netlink interface.
+func setCombinedChannels(link string, count uint32) error {
+	conn, err := genetlink.Dial(nil)
+	if err != nil {
+		return fmt.Errorf("error dialing genetlink: %w", err)
+	}
+
+	defer conn.Close() //nolint:errcheck
+
+	family, err := conn.GetFamily(unix.ETHTOOL_GENL_NAME)
+	if err != nil {
+		return fmt.Errorf("error getting family information for ethtool: %w", err)
+	}
+
+	encoder := netlink.NewAttributeEncoder()
+	encoder.Nested(unix.ETHTOOL_A_CHANNELS_HEADER, func(nae *netlink.AttributeEncoder) error {
+		nae.String(unix.ETHTOOL_A_HEADER_DEV_NAME, link)
+
+		return nil
+	})
+	encoder.Uint32(unix.ETHTOOL_A_CHANNELS_COMBINED_COUNT, count)
+
+	data, err := encoder.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Execute(genetlink.Message{
+		Header: genetlink.Header{
+			Command: unix.ETHTOOL_MSG_CHANNELS_SET,
+			Version: unix.ETHTOOL_GENL_VERSION,
+		},
+		Data: data,
+	}, family.ID, netlink.Request|netlink.Acknowledge)
+
+	return err
+}
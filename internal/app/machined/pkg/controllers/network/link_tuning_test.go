@@ -0,0 +1,163 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	netctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+type LinkTuningSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	root string
+}
+
+func (suite *LinkTuningSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.root = suite.T().TempDir()
+
+	for _, queue := range []string{"rx-0", "rx-1"} {
+		suite.writeFile(filepath.Join("sys/class/net/eth0/queues", queue, "rps_cpus"), "00000000,00000000\n")
+	}
+
+	for _, queue := range []string{"tx-0", "tx-1"} {
+		suite.writeFile(filepath.Join("sys/class/net/eth0/queues", queue, "xps_cpus"), "00000000,00000000\n")
+	}
+
+	for _, irq := range []string{"9", "10", "11"} {
+		suite.writeFile(filepath.Join("sys/class/net/eth0/device/msi_irqs", irq), "msix\n")
+		suite.writeFile(filepath.Join("proc/irq", irq, "smp_affinity_list"), "0-63\n")
+	}
+
+	suite.Require().NoError(suite.runtime.RegisterController(&netctrl.LinkTuningController{
+		SysPath:  filepath.Join(suite.root, "sys"),
+		ProcPath: filepath.Join(suite.root, "proc"),
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *LinkTuningSuite) writeFile(path, contents string) {
+	path = filepath.Join(suite.root, path)
+
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0o644))
+}
+
+func (suite *LinkTuningSuite) assertFiles(expected map[string]string) func() error {
+	return func() error {
+		for path, value := range expected {
+			contents, err := ioutil.ReadFile(filepath.Join(suite.root, path))
+			if err != nil {
+				return err
+			}
+
+			if strings.TrimSpace(string(contents)) != value {
+				return retry.ExpectedErrorf("unexpected %q contents %q", path, contents)
+			}
+		}
+
+		return nil
+	}
+}
+
+func (suite *LinkTuningSuite) TestReconcile() {
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: "eth0",
+						DeviceDHCP:      true,
+						DeviceTuning: &v1alpha1.DeviceTuningConfig{
+							TuningCombinedQueues: 2,
+							TuningIRQCPUs:        "2-3",
+							TuningRPSCPUs:        "4-7",
+							TuningXPSCPUs:        "0,33",
+						},
+					},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})))
+
+	// link is not up yet, nothing is applied
+	time.Sleep(500 * time.Millisecond)
+
+	suite.Assert().NoError(suite.assertFiles(map[string]string{
+		"proc/irq/9/smp_affinity_list": "0-63",
+	})())
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, network.NewLinkStatus(network.NamespaceName, "eth0")))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertFiles(map[string]string{
+			"proc/irq/9/smp_affinity_list":            "2",
+			"proc/irq/10/smp_affinity_list":           "3",
+			"proc/irq/11/smp_affinity_list":           "2",
+			"sys/class/net/eth0/queues/rx-0/rps_cpus": "f0",
+			"sys/class/net/eth0/queues/rx-1/rps_cpus": "f0",
+			"sys/class/net/eth0/queues/tx-0/xps_cpus": "2,00000001",
+			"sys/class/net/eth0/queues/tx-1/xps_cpus": "2,00000001",
+		}),
+	))
+}
+
+func (suite *LinkTuningSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestLinkTuningSuite(t *testing.T) {
+	suite.Run(t, new(LinkTuningSuite))
+}
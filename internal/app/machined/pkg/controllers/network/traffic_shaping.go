@@ -0,0 +1,196 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+
+	networkadapter "github.com/talos-systems/talos/internal/app/machined/pkg/adapters/network"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+// TrafficShapingController applies egress traffic shaping (tc HTB) from the machine configuration.
+type TrafficShapingController struct {
+	// applied shaping per link name
+	applied map[string]appliedTrafficShaping
+}
+
+type appliedTrafficShaping struct {
+	index       uint32
+	fingerprint string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *TrafficShapingController) Name() string {
+	return "network.TrafficShapingController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *TrafficShapingController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.LinkStatusType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *TrafficShapingController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *TrafficShapingController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	ctrl.applied = map[string]appliedTrafficShaping{}
+
+	nc, err := netlink.NewHandle()
+	if err != nil {
+		return fmt.Errorf("error creating netlink handle: %w", err)
+	}
+
+	defer nc.Delete()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		desired := map[string]talosconfig.TrafficShaping{}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return fmt.Errorf("error getting config: %w", err)
+			}
+		} else {
+			for _, device := range cfg.(*config.MachineConfig).Config().Machine().Network().Devices() {
+				if !device.Ignore() && device.TrafficShaping() != nil {
+					desired[device.Interface()] = device.TrafficShaping()
+				}
+			}
+		}
+
+		for link, applied := range ctrl.applied {
+			if _, ok := desired[link]; ok {
+				continue
+			}
+
+			if err = ctrl.remove(nc, applied.index); err != nil {
+				logger.Warn("failed to remove traffic shaping", zap.String("link", link), zap.Error(err))
+			}
+
+			delete(ctrl.applied, link)
+		}
+
+		for link, shaping := range desired {
+			linkStatus, err := r.Get(ctx, resource.NewMetadata(network.NamespaceName, network.LinkStatusType, link, resource.VersionUndefined))
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					// link is not up yet, shaping is applied when the link shows up
+					delete(ctrl.applied, link)
+
+					continue
+				}
+
+				return fmt.Errorf("error getting link status: %w", err)
+			}
+
+			index := linkStatus.(*network.LinkStatus).TypedSpec().Index
+			fingerprint := fmt.Sprintf("%d %+v", shaping.Bandwidth(), shaping.Classes())
+
+			if applied, ok := ctrl.applied[link]; ok && applied.index == index && applied.fingerprint == fingerprint {
+				continue
+			}
+
+			if err = ctrl.apply(nc, index, shaping); err != nil {
+				logger.Warn("failed to apply traffic shaping", zap.String("link", link), zap.Error(err))
+
+				continue
+			}
+
+			logger.Info("applied traffic shaping", zap.String("link", link))
+
+			ctrl.applied[link] = appliedTrafficShaping{
+				index:       index,
+				fingerprint: fingerprint,
+			}
+		}
+	}
+}
+
+// apply replaces the root qdisc of the link, as it drops all the existing classes and filters.
+func (ctrl *TrafficShapingController) apply(nc *netlink.Handle, index uint32, shaping talosconfig.TrafficShaping) error {
+	qdisc, classes, filters, err := networkadapter.TrafficShaping(shaping).Encode(int(index))
+	if err != nil {
+		return err
+	}
+
+	if err = ctrl.remove(nc, index); err != nil {
+		return fmt.Errorf("error removing qdisc: %w", err)
+	}
+
+	if err = nc.QdiscAdd(qdisc); err != nil {
+		return fmt.Errorf("error adding qdisc: %w", err)
+	}
+
+	for _, class := range classes {
+		if err = nc.ClassAdd(class); err != nil {
+			return fmt.Errorf("error adding class: %w", err)
+		}
+	}
+
+	for _, filter := range filters {
+		if err = nc.FilterAdd(filter); err != nil {
+			return fmt.Errorf("error adding filter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// remove deletes the root qdisc of the link if it was created by the controller, so that the kernel restores the default one.
+func (ctrl *TrafficShapingController) remove(nc *netlink.Handle, index uint32) error {
+	qdiscs, err := nc.QdiscList(&netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: int(index)}})
+	if err != nil {
+		if errors.Is(err, unix.ENODEV) {
+			// link is gone
+			return nil
+		}
+
+		return err
+	}
+
+	for _, qdisc := range qdiscs {
+		if qdisc.Attrs().Parent == netlink.HANDLE_ROOT && qdisc.Attrs().Handle == netlink.MakeHandle(1, 0) {
+			return nc.QdiscDel(qdisc)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	"github.com/vishvananda/netlink"
+
+	netctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+type TrafficShapingSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	link netlink.Link
+}
+
+func (suite *TrafficShapingSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&netctrl.TrafficShapingController{}))
+
+	// ifb link is used as a test link, as it doesn't carry any traffic
+	name := fmt.Sprintf("ifb%02x%02x%02x", rand.Int31()&0xff, rand.Int31()&0xff, rand.Int31()&0xff)
+
+	suite.Require().NoError(netlink.LinkAdd(&netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: name}}))
+
+	suite.link, err = netlink.LinkByName(name)
+	suite.Require().NoError(err)
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *TrafficShapingSuite) assertShaping(expectedClasses, expectedFilters int) func() error {
+	return func() error {
+		qdiscs, err := netlink.QdiscList(suite.link)
+		if err != nil {
+			return err
+		}
+
+		var htb bool
+
+		for _, qdisc := range qdiscs {
+			if qdisc.Attrs().Parent == netlink.HANDLE_ROOT {
+				htb = qdisc.Type() == "htb"
+			}
+		}
+
+		if !htb && expectedClasses > 0 {
+			return retry.ExpectedErrorf("root qdisc is not htb")
+		}
+
+		if htb && expectedClasses == 0 {
+			return retry.ExpectedErrorf("root qdisc is still htb")
+		}
+
+		if expectedClasses == 0 {
+			return nil
+		}
+
+		classes, err := netlink.ClassList(suite.link, netlink.HANDLE_NONE)
+		if err != nil {
+			return err
+		}
+
+		if len(classes) != expectedClasses {
+			return retry.ExpectedErrorf("expected %d classes, got %d", expectedClasses, len(classes))
+		}
+
+		list, err := netlink.FilterList(suite.link, netlink.MakeHandle(1, 0))
+		if err != nil {
+			return err
+		}
+
+		var filters int
+
+		for _, filter := range list {
+			// u32 hash table entries don't have the class ID
+			if u32, ok := filter.(*netlink.U32); ok && u32.ClassId != 0 {
+				filters++
+			}
+		}
+
+		if filters != expectedFilters {
+			return retry.ExpectedErrorf("expected %d filters, got %d", expectedFilters, filters)
+		}
+
+		return nil
+	}
+}
+
+func (suite *TrafficShapingSuite) TestReconcile() {
+	linkStatus := network.NewLinkStatus(network.NamespaceName, suite.link.Attrs().Name)
+	linkStatus.TypedSpec().Index = uint32(suite.link.Attrs().Index)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, linkStatus))
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: suite.link.Attrs().Name,
+						DeviceTrafficShaping: &v1alpha1.TrafficShapingConfig{
+							TrafficShapingBandwidth: "1Gbit",
+							TrafficShapingClasses: []v1alpha1.TrafficClassConfig{
+								{
+									TrafficClassName:       "etcd",
+									TrafficClassGuaranteed: "100Mbit",
+									TrafficClassPorts:      []int{2379, 2380},
+								},
+								{
+									TrafficClassName:     "registry",
+									TrafficClassLimit:    "400Mbit",
+									TrafficClassPriority: 7,
+									TrafficClassSubnets:  []string{"10.100.0.0/16"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	// root, default and two configured classes; 8 port filters and 2 subnet filters
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertShaping(4, 10)))
+
+	_, err := suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineNetwork.NetworkInterfaces[0].DeviceTrafficShaping = nil
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertShaping(0, 0)))
+}
+
+func (suite *TrafficShapingSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+
+	suite.Assert().NoError(netlink.LinkDel(suite.link))
+}
+
+func TestTrafficShapingSuite(t *testing.T) {
+	suite.Run(t, new(TrafficShapingSuite))
+}
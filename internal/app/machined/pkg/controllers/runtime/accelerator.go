@@ -0,0 +1,207 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// accelerators are rescanned periodically to catch up with hotplug and driver (un)binding.
+const acceleratorsUpdateInterval = time.Minute
+
+// PCI class codes (class and subclass).
+const (
+	pciClassVGA                   = 0x0300
+	pciClass3D                    = 0x0302
+	pciClassDisplayOther          = 0x0380
+	pciClassCoprocessor           = 0x0b40
+	pciClassProcessingAccelerator = 0x1200
+)
+
+const pciVendorGoogle = "0x1ae0"
+
+// pciVendors maps PCI vendor IDs of accelerator vendors to the names.
+var pciVendors = map[string]string{
+	"0x1002":        "AMD",
+	"0x10de":        "NVIDIA",
+	"0x10ee":        "Xilinx",
+	"0x1ac1":        "Google",
+	pciVendorGoogle: "Google",
+	"0x1da3":        "Habana Labs",
+	"0x8086":        "Intel",
+}
+
+// acceleratorDrivers maps kernel drivers of accelerators which don't report an accelerator PCI class code.
+var acceleratorDrivers = map[string]string{
+	"apex":       runtime.AcceleratorTypeTPU,
+	"habanalabs": runtime.AcceleratorTypeOther,
+}
+
+// AcceleratorController publishes GPUs, TPUs and other accelerator PCI devices.
+type AcceleratorController struct {
+	// SysPath defaults to /sys.
+	SysPath string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *AcceleratorController) Name() string {
+	return "runtime.AcceleratorController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *AcceleratorController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *AcceleratorController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.AcceleratorType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *AcceleratorController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	ticker := time.NewTicker(acceleratorsUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		if err := ctrl.updateAccelerators(ctx, r); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *AcceleratorController) updateAccelerators(ctx context.Context, r controller.Runtime) error {
+	dir := filepath.Join(ctrl.SysPath, "bus", "pci", "devices")
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error listing PCI devices: %w", err)
+	}
+
+	touchedIDs := make(map[resource.ID]struct{})
+
+	for _, entry := range entries {
+		spec, ok := readAccelerator(filepath.Join(dir, entry.Name()))
+		if !ok {
+			continue
+		}
+
+		if err = r.Modify(ctx, runtime.NewAccelerator(entry.Name()), func(res resource.Resource) error {
+			*res.(*runtime.Accelerator).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating accelerator: %w", err)
+		}
+
+		touchedIDs[entry.Name()] = struct{}{}
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.AcceleratorType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing resources: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up accelerator: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readAccelerator reads the PCI device attributes and returns false if the device is not an accelerator.
+func readAccelerator(path string) (runtime.AcceleratorSpec, bool) {
+	spec := runtime.AcceleratorSpec{
+		Class:    readSysfsAttribute(path, "class"),
+		VendorID: readSysfsAttribute(path, "vendor"),
+		DeviceID: readSysfsAttribute(path, "device"),
+		NUMANode: -1,
+	}
+
+	spec.Vendor = pciVendors[spec.VendorID]
+
+	if driver, err := os.Readlink(filepath.Join(path, "driver")); err == nil {
+		spec.Driver = filepath.Base(driver)
+	}
+
+	if numaNode, err := strconv.Atoi(readSysfsAttribute(path, "numa_node")); err == nil {
+		spec.NUMANode = numaNode
+	}
+
+	class, err := strconv.ParseUint(spec.Class, 0, 32)
+	if err != nil {
+		return spec, false
+	}
+
+	switch class >> 8 {
+	case pciClass3D:
+		spec.Type = runtime.AcceleratorTypeGPU
+	case pciClassVGA, pciClassDisplayOther:
+		// skip BMC and virtual display adapters, only known GPU vendors are reported
+		if spec.Vendor == "" {
+			return spec, false
+		}
+
+		spec.Type = runtime.AcceleratorTypeGPU
+	case pciClassProcessingAccelerator:
+		spec.Type = runtime.AcceleratorTypeOther
+
+		if spec.VendorID == pciVendorGoogle {
+			spec.Type = runtime.AcceleratorTypeTPU
+		}
+	case pciClassCoprocessor:
+		spec.Type = runtime.AcceleratorTypeOther
+	default:
+		var ok bool
+
+		if spec.Type, ok = acceleratorDrivers[spec.Driver]; !ok {
+			return spec, false
+		}
+	}
+
+	return spec, true
+}
+
+func readSysfsAttribute(path, name string) string {
+	contents, err := ioutil.ReadFile(filepath.Join(path, name))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(contents))
+}
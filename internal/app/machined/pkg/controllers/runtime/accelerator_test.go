@@ -0,0 +1,156 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type AcceleratorSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	sysPath string
+}
+
+func (suite *AcceleratorSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.sysPath = suite.T().TempDir()
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.AcceleratorController{
+		SysPath: suite.sysPath,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *AcceleratorSuite) addDevice(address, class, vendor, device, numaNode, driver string) {
+	path := filepath.Join(suite.sysPath, "bus", "pci", "devices", address)
+
+	suite.Require().NoError(os.MkdirAll(path, 0o755))
+
+	for name, contents := range map[string]string{
+		"class":     class,
+		"vendor":    vendor,
+		"device":    device,
+		"numa_node": numaNode,
+	} {
+		suite.Require().NoError(ioutil.WriteFile(filepath.Join(path, name), []byte(contents+"\n"), 0o644))
+	}
+
+	if driver != "" {
+		suite.Require().NoError(os.Symlink(filepath.Join("..", "..", "..", "bus", "pci", "drivers", driver), filepath.Join(path, "driver")))
+	}
+}
+
+func (suite *AcceleratorSuite) assertAccelerators(expected map[resource.ID]runtimeresource.AcceleratorSpec) error {
+	list, err := suite.state.List(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.AcceleratorType, "", resource.VersionUndefined))
+	if err != nil {
+		return err
+	}
+
+	if len(list.Items) != len(expected) {
+		return retry.ExpectedErrorf("expected %d accelerators, got %d", len(expected), len(list.Items))
+	}
+
+	for _, res := range list.Items {
+		spec, ok := expected[res.Metadata().ID()]
+		if !ok {
+			return retry.ExpectedErrorf("unexpected accelerator %q", res.Metadata().ID())
+		}
+
+		suite.Assert().Equal(spec, *res.(*runtimeresource.Accelerator).TypedSpec())
+	}
+
+	return nil
+}
+
+func (suite *AcceleratorSuite) TestReconcile() {
+	// NVIDIA A100
+	suite.addDevice("0000:3b:00.0", "0x030200", "0x10de", "0x20b0", "0", "nvidia")
+	// ASPEED BMC graphics
+	suite.addDevice("0000:03:00.0", "0x030000", "0x1a03", "0x2000", "-1", "ast")
+	// Coral Edge TPU
+	suite.addDevice("0000:04:00.0", "0x088000", "0x1ac1", "0x089a", "1", "apex")
+	// Intel NIC
+	suite.addDevice("0000:18:00.0", "0x020000", "0x8086", "0x1572", "0", "i40e")
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			return suite.assertAccelerators(map[resource.ID]runtimeresource.AcceleratorSpec{
+				"0000:3b:00.0": {
+					Type:     runtimeresource.AcceleratorTypeGPU,
+					Class:    "0x030200",
+					VendorID: "0x10de",
+					DeviceID: "0x20b0",
+					Vendor:   "NVIDIA",
+					Driver:   "nvidia",
+					NUMANode: 0,
+				},
+				"0000:04:00.0": {
+					Type:     runtimeresource.AcceleratorTypeTPU,
+					Class:    "0x088000",
+					VendorID: "0x1ac1",
+					DeviceID: "0x089a",
+					Vendor:   "Google",
+					Driver:   "apex",
+					NUMANode: 1,
+				},
+			})
+		},
+	))
+}
+
+func (suite *AcceleratorSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestAcceleratorSuite(t *testing.T) {
+	suite.Run(t, new(AcceleratorSuite))
+}
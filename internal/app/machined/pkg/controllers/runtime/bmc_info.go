@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/talos-systems/go-smbios/smbios"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/internal/pkg/ipmi"
+	"github.com/talos-systems/talos/pkg/machinery/nethelpers"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// BMCInfoController publishes the node BMC information if the BMC integration is enabled.
+type BMCInfoController struct {
+	// InfoReader reads the BMC information, defaults to reading SMBIOS and querying the BMC via IPMI.
+	InfoReader func() (runtime.BMCInfoSpec, error)
+
+	info *runtime.BMCInfoSpec
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *BMCInfoController) Name() string {
+	return "runtime.BMCInfoController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *BMCInfoController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *BMCInfoController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.BMCInfoType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *BMCInfoController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.InfoReader == nil {
+		ctrl.InfoReader = readBMCInfo
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		if cfg == nil || !cfg.(*config.MachineConfig).Config().Machine().BMC().Enabled() {
+			if err = r.Destroy(ctx, runtime.NewBMCInfo().Metadata()); err != nil && !state.IsNotFoundError(err) {
+				return fmt.Errorf("error cleaning up BMC info: %w", err)
+			}
+
+			continue
+		}
+
+		// BMC information doesn't change at runtime, so it's read only once
+		if ctrl.info == nil {
+			info, err := ctrl.InfoReader()
+			if err != nil {
+				logger.Warn("failed to read BMC information", zap.Error(err))
+
+				continue
+			}
+
+			ctrl.info = &info
+		}
+
+		if err = r.Modify(ctx, runtime.NewBMCInfo(), func(r resource.Resource) error {
+			*r.(*runtime.BMCInfo).TypedSpec() = *ctrl.info
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating BMC info: %w", err)
+		}
+	}
+}
+
+func readBMCInfo() (runtime.BMCInfoSpec, error) {
+	var info runtime.BMCInfoSpec
+
+	s, err := smbios.New()
+	if err != nil {
+		return info, err
+	}
+
+	info.InterfaceType = ipmi.InterfaceType(s)
+	if info.InterfaceType == "" {
+		return info, fmt.Errorf("IPMI device is not described in SMBIOS")
+	}
+
+	client, closer, err := ipmi.Open()
+	if err != nil {
+		return info, fmt.Errorf("error opening IPMI device: %w", err)
+	}
+
+	//nolint:errcheck
+	defer closer.Close()
+
+	if info.IPMIVersion, err = client.IPMIVersion(); err != nil {
+		return info, err
+	}
+
+	channel, err := client.LANChannel()
+	if err != nil {
+		return info, err
+	}
+
+	info.Channel = int(channel)
+
+	address, hardwareAddr, err := client.LANAddress(channel)
+	if err != nil {
+		return info, err
+	}
+
+	info.Address = address
+	info.HardwareAddr = nethelpers.HardwareAddr(hardwareAddr)
+
+	return info, nil
+}
@@ -0,0 +1,162 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+	"inet.af/netaddr"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/nethelpers"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type BMCInfoSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *BMCInfoSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.BMCInfoController{
+		InfoReader: func() (runtimeresource.BMCInfoSpec, error) {
+			return runtimeresource.BMCInfoSpec{
+				InterfaceType: "KCS",
+				IPMIVersion:   "2.0",
+				Channel:       1,
+				Address:       netaddr.MustParseIP("10.5.0.100"),
+				HardwareAddr:  nethelpers.HardwareAddr{0x00, 0x25, 0x90, 0xaa, 0xbb, 0xcc},
+			}, nil
+		},
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *BMCInfoSuite) setBMCEnabled(cfg *config.MachineConfig, enabled bool) *config.MachineConfig {
+	machineConfig := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineBMC: &v1alpha1.BMCConfig{
+				BMCEnabled: enabled,
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	}
+
+	if cfg == nil {
+		cfg = config.NewMachineConfig(machineConfig)
+		suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+		return cfg
+	}
+
+	_, err := suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineBMC.BMCEnabled = enabled
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	return cfg
+}
+
+func (suite *BMCInfoSuite) TestReconcile() {
+	cfg := suite.setBMCEnabled(nil, false)
+
+	// BMC info is not published unless enabled
+	time.Sleep(500 * time.Millisecond)
+
+	_, err := suite.state.Get(suite.ctx, runtimeresource.NewBMCInfo().Metadata())
+	suite.Assert().True(state.IsNotFoundError(err))
+
+	suite.setBMCEnabled(cfg, true)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, runtimeresource.NewBMCInfo().Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			spec := r.(*runtimeresource.BMCInfo).TypedSpec()
+
+			suite.Assert().Equal("2.0", spec.IPMIVersion)
+			suite.Assert().Equal("10.5.0.100", spec.Address.String())
+			suite.Assert().Equal("00:25:90:aa:bb:cc", spec.HardwareAddr.String())
+
+			return nil
+		},
+	))
+
+	suite.setBMCEnabled(cfg, false)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			_, err := suite.state.Get(suite.ctx, runtimeresource.NewBMCInfo().Metadata())
+			if err == nil {
+				return retry.ExpectedErrorf("BMC info is not cleaned up")
+			}
+
+			if state.IsNotFoundError(err) {
+				return nil
+			}
+
+			return err
+		},
+	))
+}
+
+func (suite *BMCInfoSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestBMCInfoSuite(t *testing.T) {
+	suite.Run(t, new(BMCInfoSuite))
+}
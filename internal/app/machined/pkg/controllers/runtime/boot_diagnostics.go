@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/diagnostics"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+// BootDiagnosticsController publishes the summary of the diagnostics snapshot captured on the last boot failure.
+type BootDiagnosticsController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+
+	// Path defaults to constants.BootDiagnosticsPath.
+	Path string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *BootDiagnosticsController) Name() string {
+	return "runtime.BootDiagnosticsController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *BootDiagnosticsController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      runtime.MountStatusType,
+			ID:        pointer.ToString(constants.StatePartitionLabel),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *BootDiagnosticsController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.BootDiagnosticsType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *BootDiagnosticsController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	// boot diagnostics are not captured in container mode
+	if ctrl.V1Alpha1Mode == v1alpha1runtime.ModeContainer {
+		return nil
+	}
+
+	if ctrl.Path == "" {
+		ctrl.Path = constants.BootDiagnosticsPath
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		if _, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, runtime.MountStatusType, constants.StatePartitionLabel, resource.VersionUndefined)); err != nil {
+			if state.IsNotFoundError(err) {
+				// STATE is not mounted, keep the last known summary
+				continue
+			}
+
+			return fmt.Errorf("error reading mount status: %w", err)
+		}
+
+		summary, err := diagnostics.LoadSummary(ctrl.Path)
+		if err != nil {
+			return err
+		}
+
+		if summary == nil {
+			if err = r.Destroy(ctx, runtime.NewBootDiagnostics().Metadata()); err != nil && !state.IsNotFoundError(err) {
+				return fmt.Errorf("error cleaning up boot diagnostics: %w", err)
+			}
+
+			continue
+		}
+
+		if err = r.Modify(ctx, runtime.NewBootDiagnostics(), func(res resource.Resource) error {
+			*res.(*runtime.BootDiagnostics).TypedSpec() = runtime.BootDiagnosticsSpec{
+				Timestamp: summary.Timestamp,
+				Sequence:  summary.Sequence,
+				Error:     summary.Error,
+				Path:      ctrl.Path,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating boot diagnostics: %w", err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	talosruntime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/diagnostics"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+type BootDiagnosticsSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	path string
+}
+
+func (suite *BootDiagnosticsSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.path = filepath.Join(suite.T().TempDir(), "diagnostics")
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.BootDiagnosticsController{
+		V1Alpha1Mode: talosruntime.ModeMetal,
+		Path:         suite.path,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *BootDiagnosticsSuite) TestReconcile() {
+	snapshot, err := diagnostics.NewSnapshot(suite.path)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(snapshot.WriteFile("dmesg.log", strings.NewReader("kernel log")))
+	suite.Require().NoError(snapshot.Commit(diagnostics.Summary{
+		Timestamp: time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC),
+		Sequence:  "boot",
+		Error:     "error running phase 15 in boot sequence: task 1/1: failed, context deadline exceeded",
+	}))
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, runtimeresource.NewMountStatus(v1alpha1.NamespaceName, constants.StatePartitionLabel)))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, runtimeresource.NewBootDiagnostics().Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			spec := r.(*runtimeresource.BootDiagnostics).TypedSpec()
+
+			suite.Assert().Equal("boot", spec.Sequence)
+			suite.Assert().Equal(suite.path, spec.Path)
+			suite.Assert().Equal(time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC), spec.Timestamp)
+
+			return nil
+		},
+	))
+}
+
+func (suite *BootDiagnosticsSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestBootDiagnosticsSuite(t *testing.T) {
+	suite.Run(t, new(BootDiagnosticsSuite))
+}
@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+const controllerStatusUpdateInterval = 10 * time.Second
+
+// ControllerStatusSource provides the health of the controllers.
+type ControllerStatusSource interface {
+	ControllerStatuses() []v1alpha1runtime.ControllerStatus
+}
+
+// ControllerStatusController publishes the health of the controller runtime controllers
+// and reports controller failures to the events stream.
+type ControllerStatusController struct {
+	Source         ControllerStatusSource
+	V1Alpha1Events v1alpha1runtime.Publisher
+
+	// UpdateInterval defaults to 10 seconds.
+	UpdateInterval time.Duration
+
+	reported map[string]v1alpha1runtime.ControllerStatus
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *ControllerStatusController) Name() string {
+	return "runtime.ControllerStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *ControllerStatusController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *ControllerStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.ControllerStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *ControllerStatusController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.UpdateInterval == 0 {
+		ctrl.UpdateInterval = controllerStatusUpdateInterval
+	}
+
+	if ctrl.reported == nil {
+		ctrl.reported = map[string]v1alpha1runtime.ControllerStatus{}
+	}
+
+	ticker := time.NewTicker(ctrl.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		if err := ctrl.updateStatus(ctx, r); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *ControllerStatusController) updateStatus(ctx context.Context, r controller.Runtime) error {
+	for _, status := range ctrl.Source.ControllerStatuses() {
+		status := status
+
+		ctrl.reportFailure(status)
+
+		if err := r.Modify(ctx, runtime.NewControllerStatus(status.Name), func(res resource.Resource) error {
+			*res.(*runtime.ControllerStatus).TypedSpec() = runtime.ControllerStatusSpec{
+				Running:       status.Running,
+				Wedged:        status.Wedged,
+				Crashes:       status.Crashes,
+				PausedUntil:   status.PausedUntil,
+				LastError:     status.LastError,
+				LastErrorTime: status.LastErrorTime,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating controller status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reportFailure publishes an event when the controller crashes or gets wedged since the last update.
+func (ctrl *ControllerStatusController) reportFailure(status v1alpha1runtime.ControllerStatus) {
+	reported := ctrl.reported[status.Name]
+	ctrl.reported[status.Name] = status
+
+	if status.Crashes <= reported.Crashes && (!status.Wedged || reported.Wedged) {
+		return
+	}
+
+	ctrl.V1Alpha1Events.Publish(&machine.ControllerFailureEvent{
+		Controller: status.Name,
+		Error:      status.LastError,
+		Crashes:    uint32(status.Crashes),
+		Wedged:     status.Wedged,
+	})
+}
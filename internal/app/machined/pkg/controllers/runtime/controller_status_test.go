@@ -0,0 +1,210 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/proto"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type mockControllerStatusSource struct {
+	mu       sync.Mutex
+	statuses []v1alpha1runtime.ControllerStatus
+}
+
+func (source *mockControllerStatusSource) ControllerStatuses() []v1alpha1runtime.ControllerStatus {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	return append([]v1alpha1runtime.ControllerStatus(nil), source.statuses...)
+}
+
+func (source *mockControllerStatusSource) set(statuses ...v1alpha1runtime.ControllerStatus) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	source.statuses = statuses
+}
+
+type mockPublisher struct {
+	mu       sync.Mutex
+	messages []proto.Message
+}
+
+func (publisher *mockPublisher) Publish(m proto.Message) {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	publisher.messages = append(publisher.messages, m)
+}
+
+func (publisher *mockPublisher) failureEvents() []*machine.ControllerFailureEvent {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+
+	var events []*machine.ControllerFailureEvent
+
+	for _, m := range publisher.messages {
+		if event, ok := m.(*machine.ControllerFailureEvent); ok {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+type ControllerStatusSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	source *mockControllerStatusSource
+	events *mockPublisher
+}
+
+func (suite *ControllerStatusSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.source = &mockControllerStatusSource{}
+	suite.events = &mockPublisher{}
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.ControllerStatusController{
+		Source:         suite.source,
+		V1Alpha1Events: suite.events,
+		UpdateInterval: 100 * time.Millisecond,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *ControllerStatusSuite) assertStatus(id string, expected runtimeresource.ControllerStatusSpec) {
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, *runtimeresource.NewControllerStatus(id).Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			if spec := *r.(*runtimeresource.ControllerStatus).TypedSpec(); spec != expected {
+				return retry.ExpectedError(fmt.Errorf("unexpected status %+v", spec))
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *ControllerStatusSuite) TestReconcile() {
+	errorTime := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.source.set(
+		v1alpha1runtime.ControllerStatus{
+			Name:    "network.LinkSpecController",
+			Running: true,
+		},
+	)
+
+	suite.assertStatus("network.LinkSpecController", runtimeresource.ControllerStatusSpec{
+		Running: true,
+	})
+
+	suite.source.set(
+		v1alpha1runtime.ControllerStatus{
+			Name:          "network.LinkSpecController",
+			Crashes:       1,
+			PausedUntil:   errorTime.Add(time.Hour),
+			LastError:     "boom",
+			LastErrorTime: errorTime,
+		},
+		v1alpha1runtime.ControllerStatus{
+			Name:          "network.RouteSpecController",
+			Running:       true,
+			Wedged:        true,
+			LastError:     "reconcile event pending",
+			LastErrorTime: errorTime,
+		},
+	)
+
+	suite.assertStatus("network.LinkSpecController", runtimeresource.ControllerStatusSpec{
+		Crashes:       1,
+		PausedUntil:   errorTime.Add(time.Hour),
+		LastError:     "boom",
+		LastErrorTime: errorTime,
+	})
+
+	suite.assertStatus("network.RouteSpecController", runtimeresource.ControllerStatusSpec{
+		Running:       true,
+		Wedged:        true,
+		LastError:     "reconcile event pending",
+		LastErrorTime: errorTime,
+	})
+
+	// failures are reported once
+	time.Sleep(300 * time.Millisecond)
+
+	events := suite.events.failureEvents()
+	suite.Require().Len(events, 2)
+
+	suite.Assert().Equal("network.LinkSpecController", events[0].GetController())
+	suite.Assert().Equal("boom", events[0].GetError())
+	suite.Assert().EqualValues(1, events[0].GetCrashes())
+	suite.Assert().False(events[0].GetWedged())
+
+	suite.Assert().Equal("network.RouteSpecController", events[1].GetController())
+	suite.Assert().True(events[1].GetWedged())
+}
+
+func (suite *ControllerStatusSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestControllerStatusSuite(t *testing.T) {
+	suite.Run(t, new(ControllerStatusSuite))
+}
@@ -0,0 +1,204 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// CPU power status is refreshed periodically, as CPUs might be brought online or offline.
+const cpuPowerUpdateInterval = time.Minute
+
+// CPUPowerController applies CPU frequency governor and idle state limits, and publishes CPU power status.
+type CPUPowerController struct {
+	// SysPath defaults to /sys.
+	SysPath string
+
+	appliedGovernor  string
+	appliedMaxCState int
+	applied          bool
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *CPUPowerController) Name() string {
+	return "runtime.CPUPowerController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *CPUPowerController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *CPUPowerController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.CPUPowerStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *CPUPowerController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	ticker := time.NewTicker(cpuPowerUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		governor, maxCState := "", -1
+
+		if cfg != nil {
+			governor = cfg.(*config.MachineConfig).Config().Machine().Kernel().CPUFrequencyGovernor()
+			maxCState = cfg.(*config.MachineConfig).Config().Machine().Kernel().MaxCState()
+		}
+
+		// settings are applied once per configuration change, failures are visible in the status
+		if !ctrl.applied || governor != ctrl.appliedGovernor || maxCState != ctrl.appliedMaxCState {
+			ctrl.apply(governor, maxCState, logger)
+
+			ctrl.appliedGovernor, ctrl.appliedMaxCState, ctrl.applied = governor, maxCState, true
+		}
+
+		if err = r.Modify(ctx, runtime.NewCPUPowerStatus(), func(res resource.Resource) error {
+			*res.(*runtime.CPUPowerStatus).TypedSpec() = ctrl.status()
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating CPU power status: %w", err)
+		}
+	}
+}
+
+func (ctrl *CPUPowerController) cpuPaths() []string {
+	paths, _ := filepath.Glob(filepath.Join(ctrl.SysPath, "devices", "system", "cpu", "cpu[0-9]*")) //nolint:errcheck
+
+	return paths
+}
+
+// idleStatePaths returns the CPU idle state paths ordered by the state index.
+func idleStatePaths(cpuPath string) []string {
+	paths, _ := filepath.Glob(filepath.Join(cpuPath, "cpuidle", "state[0-9]*")) //nolint:errcheck
+
+	sort.Slice(paths, func(i, j int) bool {
+		return idleStateIndex(paths[i]) < idleStateIndex(paths[j])
+	})
+
+	return paths
+}
+
+func idleStateIndex(path string) int {
+	index, _ := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "state")) //nolint:errcheck
+
+	return index
+}
+
+func (ctrl *CPUPowerController) apply(governor string, maxCState int, logger *zap.Logger) {
+	for _, cpuPath := range ctrl.cpuPaths() {
+		if governor != "" && readSysfsAttribute(cpuPath, "cpufreq/scaling_governor") != governor {
+			if err := ioutil.WriteFile(filepath.Join(cpuPath, "cpufreq", "scaling_governor"), []byte(governor), 0o644); err != nil {
+				logger.Warn("failed to set CPU frequency governor", zap.String("cpu", filepath.Base(cpuPath)), zap.String("governor", governor), zap.Error(err))
+			}
+		}
+
+		if maxCState < 0 {
+			continue
+		}
+
+		for _, statePath := range idleStatePaths(cpuPath) {
+			disable := "0"
+
+			if idleStateIndex(statePath) > maxCState {
+				disable = "1"
+			}
+
+			if readSysfsAttribute(statePath, "disable") == disable {
+				continue
+			}
+
+			if err := ioutil.WriteFile(filepath.Join(statePath, "disable"), []byte(disable), 0o644); err != nil {
+				logger.Warn("failed to limit CPU idle states", zap.String("cpu", filepath.Base(cpuPath)), zap.String("state", filepath.Base(statePath)), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (ctrl *CPUPowerController) status() runtime.CPUPowerStatusSpec {
+	var spec runtime.CPUPowerStatusSpec
+
+	cpuPaths := ctrl.cpuPaths()
+
+	governors := map[string]struct{}{}
+
+	for _, cpuPath := range cpuPaths {
+		if governor := readSysfsAttribute(cpuPath, "cpufreq/scaling_governor"); governor != "" {
+			governors[governor] = struct{}{}
+		}
+	}
+
+	for governor := range governors {
+		spec.Governors = append(spec.Governors, governor)
+	}
+
+	sort.Strings(spec.Governors)
+
+	spec.IdleDriver = readSysfsAttribute(filepath.Join(ctrl.SysPath, "devices", "system", "cpu", "cpuidle"), "current_driver")
+
+	if len(cpuPaths) == 0 {
+		return spec
+	}
+
+	// CPUs are expected to share the drivers and idle states, first CPU is cpu0
+	cpuPath := cpuPaths[0]
+
+	spec.ScalingDriver = readSysfsAttribute(cpuPath, "cpufreq/scaling_driver")
+	spec.AvailableGovernors = strings.Fields(readSysfsAttribute(cpuPath, "cpufreq/scaling_available_governors"))
+
+	for _, statePath := range idleStatePaths(cpuPath) {
+		if readSysfsAttribute(statePath, "disable") == "0" {
+			spec.IdleStates = append(spec.IdleStates, readSysfsAttribute(statePath, "name"))
+		}
+	}
+
+	return spec
+}
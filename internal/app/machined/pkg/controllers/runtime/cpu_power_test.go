@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type CPUPowerSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	sysPath string
+}
+
+func (suite *CPUPowerSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.sysPath = suite.T().TempDir()
+
+	suite.writeFile("devices/system/cpu/cpuidle/current_driver", "intel_idle\n")
+
+	for cpu := 0; cpu < 2; cpu++ {
+		cpuPath := fmt.Sprintf("devices/system/cpu/cpu%d", cpu)
+
+		suite.writeFile(filepath.Join(cpuPath, "cpufreq/scaling_driver"), "intel_cpufreq\n")
+		suite.writeFile(filepath.Join(cpuPath, "cpufreq/scaling_governor"), "schedutil\n")
+		suite.writeFile(filepath.Join(cpuPath, "cpufreq/scaling_available_governors"), "conservative ondemand userspace powersave performance schedutil\n")
+
+		for index, name := range []string{"POLL", "C1", "C1E", "C6"} {
+			suite.writeFile(filepath.Join(cpuPath, fmt.Sprintf("cpuidle/state%d/name", index)), name+"\n")
+			suite.writeFile(filepath.Join(cpuPath, fmt.Sprintf("cpuidle/state%d/disable", index)), "0\n")
+		}
+	}
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.CPUPowerController{
+		SysPath: suite.sysPath,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *CPUPowerSuite) writeFile(path, contents string) {
+	path = filepath.Join(suite.sysPath, path)
+
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0o644))
+}
+
+func (suite *CPUPowerSuite) readFile(path string) string {
+	contents, err := ioutil.ReadFile(filepath.Join(suite.sysPath, path))
+	suite.Require().NoError(err)
+
+	return strings.TrimSpace(string(contents))
+}
+
+func (suite *CPUPowerSuite) assertStatus(expected runtimeresource.CPUPowerStatusSpec) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, runtimeresource.NewCPUPowerStatus().Metadata())
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		if spec := *r.(*runtimeresource.CPUPowerStatus).TypedSpec(); !reflect.DeepEqual(spec, expected) {
+			return retry.ExpectedErrorf("unexpected status %+v", spec)
+		}
+
+		return nil
+	}
+}
+
+func (suite *CPUPowerSuite) TestReconcile() {
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus(runtimeresource.CPUPowerStatusSpec{
+			ScalingDriver:      "intel_cpufreq",
+			Governors:          []string{"schedutil"},
+			AvailableGovernors: []string{"conservative", "ondemand", "userspace", "powersave", "performance", "schedutil"},
+			IdleDriver:         "intel_idle",
+			IdleStates:         []string{"POLL", "C1", "C1E", "C6"},
+		}),
+	))
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineKernel: &v1alpha1.KernelConfig{
+				KernelCPUFrequencyGovernor: "performance",
+				KernelMaxCState:            pointer.ToInt(1),
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus(runtimeresource.CPUPowerStatusSpec{
+			ScalingDriver:      "intel_cpufreq",
+			Governors:          []string{"performance"},
+			AvailableGovernors: []string{"conservative", "ondemand", "userspace", "powersave", "performance", "schedutil"},
+			IdleDriver:         "intel_idle",
+			IdleStates:         []string{"POLL", "C1"},
+		}),
+	))
+
+	suite.Assert().Equal("performance", suite.readFile("devices/system/cpu/cpu1/cpufreq/scaling_governor"))
+	suite.Assert().Equal("1", suite.readFile("devices/system/cpu/cpu1/cpuidle/state3/disable"))
+}
+
+func (suite *CPUPowerSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestCPUPowerSuite(t *testing.T) {
+	suite.Run(t, new(CPUPowerSuite))
+}
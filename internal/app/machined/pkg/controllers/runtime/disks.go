@@ -0,0 +1,315 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// udevMonitorGroup is the netlink multicast group of the events processed by udevd.
+const udevMonitorGroup = 2
+
+// DisksController publishes the block devices with their udev properties as Disk resources.
+type DisksController struct {
+	// SysPath defaults to /sys.
+	SysPath string
+	// UdevDataPath defaults to /run/udev/data.
+	UdevDataPath string
+	// Watch sends a notification on the block device changes, defaults to watching udev events.
+	Watch func(ctx context.Context, notifyCh chan<- struct{}) error
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *DisksController) Name() string {
+	return "runtime.DisksController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *DisksController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *DisksController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.DiskType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *DisksController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	if ctrl.UdevDataPath == "" {
+		ctrl.UdevDataPath = "/run/udev/data"
+	}
+
+	if ctrl.Watch == nil {
+		ctrl.Watch = watchUdevBlockEvents
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// notifications are coalesced, as the disks are rescanned completely on every change
+	notifyCh := make(chan struct{}, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- ctrl.Watch(ctx, notifyCh)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			if err != nil {
+				return fmt.Errorf("error watching block devices: %w", err)
+			}
+
+			return nil
+		case <-r.EventCh():
+		case <-notifyCh:
+		}
+
+		disks, err := ctrl.scan()
+		if err != nil {
+			return fmt.Errorf("error scanning block devices: %w", err)
+		}
+
+		for id, spec := range disks {
+			spec := spec
+
+			if err = r.Modify(ctx, runtime.NewDisk(id), func(res resource.Resource) error {
+				*res.(*runtime.Disk).TypedSpec() = spec
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating disk: %w", err)
+			}
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.DiskType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing disks: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if _, ok := disks[res.Metadata().ID()]; ok {
+				continue
+			}
+
+			logger.Info("disk removed", zap.String("disk", res.Metadata().ID()))
+
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error destroying disk: %w", err)
+			}
+		}
+	}
+}
+
+// scan skips the block devices without the media (e.g. unattached loop devices, empty CD-ROM drives).
+func (ctrl *DisksController) scan() (map[resource.ID]runtime.DiskSpec, error) {
+	dir := filepath.Join(ctrl.SysPath, "block")
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	disks := make(map[resource.ID]runtime.DiskSpec, len(entries))
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		size := sysfsSize(path)
+		if size == 0 {
+			continue
+		}
+
+		properties, symlinks := ctrl.udevProperties(path)
+
+		spec := runtime.DiskSpec{
+			DevPath:            "/dev/" + entry.Name(),
+			Size:               size,
+			ReadOnly:           readSysfsAttribute(path, "ro") == "1",
+			Rotational:         readSysfsAttribute(path, "queue/rotational") == "1",
+			Transport:          properties["ID_BUS"],
+			Model:              properties["ID_MODEL"],
+			Serial:             properties["ID_SERIAL_SHORT"],
+			WWN:                properties["ID_WWN"],
+			Symlinks:           symlinks,
+			PartitionTableType: properties["ID_PART_TABLE_TYPE"],
+			PartitionTableUUID: properties["ID_PART_TABLE_UUID"],
+			FilesystemType:     properties["ID_FS_TYPE"],
+			FilesystemUUID:     properties["ID_FS_UUID"],
+			FilesystemLabel:    properties["ID_FS_LABEL"],
+		}
+
+		// udev doesn't know the model of some devices (e.g. virtio disks)
+		if spec.Model == "" {
+			spec.Model = readSysfsAttribute(path, "device/model")
+		}
+
+		if spec.Serial == "" {
+			spec.Serial = readSysfsAttribute(path, "device/serial")
+		}
+
+		partitions, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, partition := range partitions {
+			partitionPath := filepath.Join(path, partition.Name())
+
+			if _, err = os.Stat(filepath.Join(partitionPath, "partition")); err != nil {
+				continue
+			}
+
+			partitionProperties, _ := ctrl.udevProperties(partitionPath)
+
+			spec.Partitions = append(spec.Partitions, runtime.DiskPartition{
+				DevPath:         "/dev/" + partition.Name(),
+				Size:            sysfsSize(partitionPath),
+				PartitionLabel:  partitionProperties["ID_PART_ENTRY_NAME"],
+				PartitionUUID:   partitionProperties["ID_PART_ENTRY_UUID"],
+				FilesystemType:  partitionProperties["ID_FS_TYPE"],
+				FilesystemUUID:  partitionProperties["ID_FS_UUID"],
+				FilesystemLabel: partitionProperties["ID_FS_LABEL"],
+			})
+		}
+
+		disks[entry.Name()] = spec
+	}
+
+	return disks, nil
+}
+
+// udevProperties reads the device properties and symlinks from the udev database.
+//
+// Devices which are not processed by udev yet have no properties.
+func (ctrl *DisksController) udevProperties(path string) (map[string]string, []string) {
+	properties := map[string]string{}
+
+	f, err := os.Open(filepath.Join(ctrl.UdevDataPath, "b"+readSysfsAttribute(path, "dev")))
+	if err != nil {
+		return properties, nil
+	}
+
+	//nolint:errcheck
+	defer f.Close()
+
+	var symlinks []string
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "E:"):
+			if key, value, ok := splitProperty(line[2:]); ok {
+				properties[key] = value
+			}
+		case strings.HasPrefix(line, "S:"):
+			symlinks = append(symlinks, "/dev/"+line[2:])
+		}
+	}
+
+	return properties, symlinks
+}
+
+func splitProperty(property string) (key, value string, ok bool) {
+	idx := strings.IndexByte(property, '=')
+	if idx < 0 {
+		return "", "", false
+	}
+
+	return property[:idx], property[idx+1:], true
+}
+
+// sysfsSize returns the block device size in bytes (sysfs reports the size in 512-byte sectors).
+func sysfsSize(path string) uint64 {
+	sectors, err := strconv.ParseUint(readSysfsAttribute(path, "size"), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return sectors * 512
+}
+
+// watchUdevBlockEvents listens for the block device events processed by udevd.
+//
+// Events are received after udevd updates its database, so the udev properties are up to date.
+func watchUdevBlockEvents(ctx context.Context, notifyCh chan<- struct{}) error {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return fmt.Errorf("error opening netlink socket: %w", err)
+	}
+
+	if err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: udevMonitorGroup}); err != nil {
+		unix.Close(fd) //nolint:errcheck
+
+		return fmt.Errorf("error binding netlink socket: %w", err)
+	}
+
+	// non-blocking file is managed by the runtime poller, so closing it interrupts the read
+	f := os.NewFile(uintptr(fd), "udev-monitor")
+
+	go func() {
+		<-ctx.Done()
+
+		f.Close() //nolint:errcheck
+	}()
+
+	buf := make([]byte, 64*1024)
+
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("error reading udev events: %w", err)
+		}
+
+		// message is a binary header followed by NUL-separated KEY=VALUE properties
+		for _, property := range bytes.Split(buf[:n], []byte{0}) {
+			if string(property) == "SUBSYSTEM=block" {
+				select {
+				case notifyCh <- struct{}{}:
+				default:
+				}
+
+				break
+			}
+		}
+	}
+}
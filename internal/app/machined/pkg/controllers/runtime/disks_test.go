@@ -0,0 +1,237 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type DisksSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	sysPath      string
+	udevDataPath string
+	hotplugCh    chan struct{}
+}
+
+func (suite *DisksSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.sysPath = suite.T().TempDir()
+	suite.udevDataPath = suite.T().TempDir()
+	suite.hotplugCh = make(chan struct{})
+
+	suite.Require().NoError(os.MkdirAll(filepath.Join(suite.sysPath, "block"), 0o755))
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.DisksController{
+		SysPath:      suite.sysPath,
+		UdevDataPath: suite.udevDataPath,
+		Watch: func(ctx context.Context, notifyCh chan<- struct{}) error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-suite.hotplugCh:
+					select {
+					case notifyCh <- struct{}{}:
+					default:
+					}
+				}
+			}
+		},
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *DisksSuite) writeFile(path, contents string) {
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0o644))
+}
+
+func (suite *DisksSuite) addBlockDevice(path, dev, sectors, udevData string) {
+	suite.writeFile(filepath.Join(path, "dev"), dev+"\n")
+	suite.writeFile(filepath.Join(path, "size"), sectors+"\n")
+
+	if udevData != "" {
+		suite.writeFile(filepath.Join(suite.udevDataPath, "b"+dev), udevData)
+	}
+}
+
+func (suite *DisksSuite) assertDisk(id string, expected runtimeresource.DiskSpec) func() error {
+	return func() error {
+		res, err := suite.state.Get(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.DiskType, id, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		spec := *res.(*runtimeresource.Disk).TypedSpec()
+
+		if !reflect.DeepEqual(spec, expected) {
+			return retry.ExpectedErrorf("unexpected disk %+v", spec)
+		}
+
+		return nil
+	}
+}
+
+func (suite *DisksSuite) assertNoDisk(id string) func() error {
+	return func() error {
+		_, err := suite.state.Get(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.DiskType, id, resource.VersionUndefined))
+		if err == nil {
+			return retry.ExpectedErrorf("disk %q still exists", id)
+		}
+
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+}
+
+func (suite *DisksSuite) TestReconcile() {
+	sda := filepath.Join(suite.sysPath, "block", "sda")
+
+	suite.addBlockDevice(sda, "8:0", "2097152", `S:disk/by-id/wwn-0x5000c500a1b2c3d4
+S:disk/by-path/pci-0000:00:1f.2-ata-1
+E:ID_BUS=ata
+E:ID_MODEL=ST1000NM0055
+E:ID_SERIAL_SHORT=ZBS0ABCD
+E:ID_WWN=0x5000c500a1b2c3d4
+E:ID_PART_TABLE_TYPE=gpt
+E:ID_PART_TABLE_UUID=1c4f6a38-0b8e-4d5c-9f2e-7a3d2b1c0e9f
+`)
+	suite.writeFile(filepath.Join(sda, "ro"), "0\n")
+	suite.writeFile(filepath.Join(sda, "queue", "rotational"), "1\n")
+
+	suite.addBlockDevice(filepath.Join(sda, "sda1"), "8:1", "204800", `E:ID_PART_ENTRY_NAME=data
+E:ID_PART_ENTRY_UUID=8a7b6c5d-4e3f-2a1b-0c9d-8e7f6a5b4c3d
+E:ID_FS_TYPE=xfs
+E:ID_FS_UUID=0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0
+E:ID_FS_LABEL=DATA
+`)
+	suite.writeFile(filepath.Join(sda, "sda1", "partition"), "1\n")
+
+	// loop device without the backing file
+	suite.addBlockDevice(filepath.Join(suite.sysPath, "block", "loop0"), "7:0", "0", "")
+
+	suite.hotplugCh <- struct{}{}
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertDisk("sda", runtimeresource.DiskSpec{
+		DevPath:    "/dev/sda",
+		Size:       1 << 30,
+		Rotational: true,
+		Transport:  "ata",
+		Model:      "ST1000NM0055",
+		Serial:     "ZBS0ABCD",
+		WWN:        "0x5000c500a1b2c3d4",
+		Symlinks: []string{
+			"/dev/disk/by-id/wwn-0x5000c500a1b2c3d4",
+			"/dev/disk/by-path/pci-0000:00:1f.2-ata-1",
+		},
+		PartitionTableType: "gpt",
+		PartitionTableUUID: "1c4f6a38-0b8e-4d5c-9f2e-7a3d2b1c0e9f",
+		Partitions: []runtimeresource.DiskPartition{
+			{
+				DevPath:         "/dev/sda1",
+				Size:            100 << 20,
+				PartitionLabel:  "data",
+				PartitionUUID:   "8a7b6c5d-4e3f-2a1b-0c9d-8e7f6a5b4c3d",
+				FilesystemType:  "xfs",
+				FilesystemUUID:  "0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0",
+				FilesystemLabel: "DATA",
+			},
+		},
+	})))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertNoDisk("loop0")))
+
+	// hotplug a virtio disk unknown to udev yet
+	vda := filepath.Join(suite.sysPath, "block", "vda")
+
+	suite.addBlockDevice(vda, "253:0", "20971520", "")
+	suite.writeFile(filepath.Join(vda, "ro"), "1\n")
+	suite.writeFile(filepath.Join(vda, "device", "serial"), "talos-vda\n")
+
+	suite.hotplugCh <- struct{}{}
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertDisk("vda", runtimeresource.DiskSpec{
+		DevPath:  "/dev/vda",
+		Size:     10 << 30,
+		ReadOnly: true,
+		Serial:   "talos-vda",
+	})))
+
+	// unplug the disk
+	suite.Require().NoError(os.RemoveAll(sda))
+
+	suite.hotplugCh <- struct{}{}
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertNoDisk("sda")))
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertDisk("vda", runtimeresource.DiskSpec{
+		DevPath:  "/dev/vda",
+		Size:     10 << 30,
+		ReadOnly: true,
+		Serial:   "talos-vda",
+	})))
+}
+
+func (suite *DisksSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestDisksSuite(t *testing.T) {
+	suite.Run(t, new(DisksSuite))
+}
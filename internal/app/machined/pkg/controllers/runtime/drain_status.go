@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// DrainStatusController tracks node drain progress via machined events.
+type DrainStatusController struct {
+	V1Alpha1Events v1alpha1runtime.Watcher
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *DrainStatusController) Name() string {
+	return "runtime.DrainStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *DrainStatusController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *DrainStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.DrainStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *DrainStatusController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	errCh := make(chan error, 1)
+
+	// replay all buffered events to catch up with the drain which might be already in progress
+	if err := ctrl.V1Alpha1Events.Watch(func(eventCh <-chan v1alpha1runtime.EventInfo) {
+		errCh <- ctrl.handleEvents(ctx, r, eventCh)
+	}, v1alpha1runtime.WithTailEvents(-1)); err != nil {
+		return err
+	}
+
+	return <-errCh
+}
+
+func (ctrl *DrainStatusController) handleEvents(ctx context.Context, r controller.Runtime, eventCh <-chan v1alpha1runtime.EventInfo) error {
+	for {
+		var event v1alpha1runtime.EventInfo
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+			continue
+		case event = <-eventCh:
+		}
+
+		payload, ok := event.Payload.(*machine.DrainEvent)
+		if !ok {
+			continue
+		}
+
+		if err := r.Modify(ctx, runtime.NewDrainStatus(), func(res resource.Resource) error {
+			*res.(*runtime.DrainStatus).TypedSpec() = runtime.DrainStatusSpec{
+				Node:          payload.Node,
+				Phase:         payload.Phase,
+				RemainingPods: payload.RemainingPods,
+				BlockedPods:   payload.BlockedPods,
+				Started:       event.ID.Time().Add(-time.Duration(payload.ElapsedSeconds) * time.Second).UTC().Truncate(time.Second),
+				Timeout:       time.Duration(payload.TimeoutSeconds) * time.Second,
+				Error:         payload.Error,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating drain status: %w", err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type DrainStatusSuite struct {
+	suite.Suite
+
+	events *v1alpha1.Events
+	state  state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *DrainStatusSuite) SetupTest() {
+	suite.events = v1alpha1.NewEvents(1000, 10)
+
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.DrainStatusController{
+		V1Alpha1Events: suite.events,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *DrainStatusSuite) assertStatus(phase string, remaining, blocked []string) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, runtimeresource.NewDrainStatus().Metadata())
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		spec := r.(*runtimeresource.DrainStatus).TypedSpec()
+
+		if spec.Phase != phase {
+			return retry.ExpectedErrorf("expected phase %q, got %q", phase, spec.Phase)
+		}
+
+		suite.Assert().Equal("worker-1", spec.Node)
+		suite.Assert().Equal(remaining, spec.RemainingPods)
+		suite.Assert().Equal(blocked, spec.BlockedPods)
+		suite.Assert().Equal(10*time.Minute, spec.Timeout)
+		suite.Assert().False(spec.Started.IsZero())
+
+		return nil
+	}
+}
+
+func (suite *DrainStatusSuite) TestDrain() {
+	// other events are ignored
+	suite.events.Publish(&machine.PhaseEvent{
+		Phase:  "drain",
+		Action: machine.PhaseEvent_START,
+	})
+
+	suite.events.Publish(&machine.DrainEvent{
+		Node:           "worker-1",
+		Phase:          runtimeresource.DrainPhaseDraining,
+		RemainingPods:  []string{"default/nginx", "kube-system/coredns"},
+		BlockedPods:    []string{"kube-system/coredns"},
+		ElapsedSeconds: 5,
+		TimeoutSeconds: 600,
+	})
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus(runtimeresource.DrainPhaseDraining, []string{"default/nginx", "kube-system/coredns"}, []string{"kube-system/coredns"}),
+	))
+
+	suite.events.Publish(&machine.DrainEvent{
+		Node:           "worker-1",
+		Phase:          runtimeresource.DrainPhaseDrained,
+		ElapsedSeconds: 12,
+		TimeoutSeconds: 600,
+	})
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus(runtimeresource.DrainPhaseDrained, nil, nil),
+	))
+}
+
+func (suite *DrainStatusSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestDrainStatusSuite(t *testing.T) {
+	suite.Run(t, new(DrainStatusSuite))
+}
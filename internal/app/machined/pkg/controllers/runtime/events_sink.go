@@ -6,7 +6,9 @@ package runtime
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"strings"
 
 	"github.com/AlekSi/pointer"
 	"github.com/cosi-project/runtime/pkg/controller"
@@ -18,15 +20,20 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 )
 
 // EventsSinkController watches events and forwards them to the events sink server
 // if it's configured.
+//
+// If the events sink address has "tls://" prefix, mTLS is used with the node API client certificate,
+// events sink certificate should be signed by the Talos OS CA.
 type EventsSinkController struct {
 	V1Alpha1Events runtime.Watcher
 	Cmdline        *procfs.Cmdline
@@ -52,6 +59,12 @@ func (ctrl *EventsSinkController) Inputs() []controller.Input {
 			ID:        pointer.ToString(network.StatusID),
 			Kind:      controller.InputWeak,
 		},
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.APIType,
+			ID:        pointer.ToString(secrets.APIID),
+			Kind:      controller.InputWeak,
+		},
 	}
 }
 
@@ -79,6 +92,11 @@ func (ctrl *EventsSinkController) Run(ctx context.Context, r controller.Runtime,
 		}
 	}()
 
+	sink := *ctrl.Cmdline.Get(constants.KernelParamEventsSink).First()
+	sink, useTLS := trimTLSScheme(sink)
+
+	var tlsConfig *tls.Config
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -103,14 +121,27 @@ func (ctrl *EventsSinkController) Run(ctx context.Context, r controller.Runtime,
 			continue
 		}
 
+		if useTLS {
+			tlsConfig, err = sinkTLSConfig(ctx, r)
+			if err != nil {
+				// wait for node certificates
+				logger.Debug("waiting for node certificates", zap.Error(err))
+
+				continue
+			}
+		}
+
 		break
 	}
 
 	errCh := make(chan error)
 
-	sink := ctrl.Cmdline.Get(constants.KernelParamEventsSink).First()
+	transportCredentials := grpc.WithInsecure()
+	if useTLS {
+		transportCredentials = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
 
-	conn, err := grpc.DialContext(ctx, *sink, grpc.WithInsecure())
+	conn, err := grpc.DialContext(ctx, sink, transportCredentials)
 	if err != nil {
 		return err
 	}
@@ -190,3 +221,12 @@ func (ctrl *EventsSinkController) handleEvents(ctx context.Context, eventCh <-ch
 		}
 	}
 }
+
+// trimTLSScheme strips optional "tls://" scheme from the sink address.
+func trimTLSScheme(addr string) (string, bool) {
+	if strings.HasPrefix(addr, "tls://") {
+		return strings.TrimPrefix(addr, "tls://"), true
+	}
+
+	return addr, false
+}
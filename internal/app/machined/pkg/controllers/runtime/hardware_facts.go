@@ -0,0 +1,237 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/prometheus/procfs"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// disks are rescanned periodically to catch up with hotplug.
+const hardwareFactsUpdateInterval = time.Minute
+
+// HardwareFactsController summarizes the detected node hardware.
+type HardwareFactsController struct {
+	// SysPath defaults to /sys.
+	SysPath string
+	// ProcPath defaults to /proc.
+	ProcPath string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *HardwareFactsController) Name() string {
+	return "runtime.HardwareFactsController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *HardwareFactsController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: runtime.NamespaceName,
+			Type:      runtime.AcceleratorType,
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.LinkStatusType,
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *HardwareFactsController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.HardwareFactsType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *HardwareFactsController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	if ctrl.ProcPath == "" {
+		ctrl.ProcPath = "/proc"
+	}
+
+	// CPU doesn't change while the node is running
+	cpuVendor, cpuFlags := ctrl.readCPUInfo(logger)
+
+	ticker := time.NewTicker(hardwareFactsUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		gpuVendors, gpuModels, err := ctrl.gpus(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		nicSpeed, err := ctrl.nicSpeed(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		diskClasses := ctrl.diskClasses()
+
+		if err = r.Modify(ctx, runtime.NewHardwareFacts(), func(res resource.Resource) error {
+			*res.(*runtime.HardwareFacts).TypedSpec() = runtime.HardwareFactsSpec{
+				CPUVendor:   cpuVendor,
+				CPUFlags:    cpuFlags,
+				GPUVendors:  gpuVendors,
+				GPUModels:   gpuModels,
+				DiskClasses: diskClasses,
+				NICSpeed:    nicSpeed,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating hardware facts: %w", err)
+		}
+	}
+}
+
+func (ctrl *HardwareFactsController) readCPUInfo(logger *zap.Logger) (string, []string) {
+	fs, err := procfs.NewFS(ctrl.ProcPath)
+	if err != nil {
+		logger.Warn("failed to open procfs", zap.Error(err))
+
+		return "", nil
+	}
+
+	cpus, err := fs.CPUInfo()
+	if err != nil || len(cpus) == 0 {
+		logger.Warn("failed to read CPU info", zap.Error(err))
+
+		return "", nil
+	}
+
+	flags := append([]string(nil), cpus[0].Flags...)
+	sort.Strings(flags)
+
+	return cpus[0].VendorID, flags
+}
+
+func (ctrl *HardwareFactsController) gpus(ctx context.Context, r controller.Runtime) (vendors, models []string, err error) {
+	list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.AcceleratorType, "", resource.VersionUndefined))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing accelerators: %w", err)
+	}
+
+	vendorSet := map[string]struct{}{}
+	modelSet := map[string]struct{}{}
+
+	for _, res := range list.Items {
+		spec := res.(*runtime.Accelerator).TypedSpec()
+
+		if spec.Type != runtime.AcceleratorTypeGPU {
+			continue
+		}
+
+		if spec.Vendor != "" {
+			vendorSet[spec.Vendor] = struct{}{}
+		}
+
+		// same format as lspci -nn
+		modelSet[strings.TrimPrefix(spec.VendorID, "0x")+":"+strings.TrimPrefix(spec.DeviceID, "0x")] = struct{}{}
+	}
+
+	return sortedKeys(vendorSet), sortedKeys(modelSet), nil
+}
+
+func (ctrl *HardwareFactsController) nicSpeed(ctx context.Context, r controller.Runtime) (int, error) {
+	list, err := r.List(ctx, resource.NewMetadata(network.NamespaceName, network.LinkStatusType, "", resource.VersionUndefined))
+	if err != nil {
+		return 0, fmt.Errorf("error listing links: %w", err)
+	}
+
+	var speed int
+
+	for _, res := range list.Items {
+		link := res.(*network.LinkStatus)
+
+		if link.Physical() && link.TypedSpec().SpeedMegabits > speed {
+			speed = link.TypedSpec().SpeedMegabits
+		}
+	}
+
+	return speed, nil
+}
+
+// diskClasses skips virtual (loop, device mapper, etc.) and removable block devices.
+func (ctrl *HardwareFactsController) diskClasses() []string {
+	dir := filepath.Join(ctrl.SysPath, "block")
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	classes := map[string]struct{}{}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if _, err = os.Stat(filepath.Join(path, "device")); err != nil {
+			continue
+		}
+
+		if readSysfsAttribute(path, "removable") == "1" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(entry.Name(), "nvme"):
+			classes[runtime.DiskClassNVMe] = struct{}{}
+		case readSysfsAttribute(path, "queue/rotational") == "1":
+			classes[runtime.DiskClassHDD] = struct{}{}
+		default:
+			classes[runtime.DiskClassSSD] = struct{}{}
+		}
+	}
+
+	return sortedKeys(classes)
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,186 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/nethelpers"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type HardwareFactsSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	sysPath string
+}
+
+func (suite *HardwareFactsSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.sysPath = suite.T().TempDir()
+	procPath := suite.T().TempDir()
+
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(procPath, "cpuinfo"), []byte(`processor	: 0
+vendor_id	: GenuineIntel
+cpu family	: 6
+model name	: Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz
+flags		: fpu vme sse4_2 avx512f avx2
+
+processor	: 1
+vendor_id	: GenuineIntel
+cpu family	: 6
+model name	: Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz
+flags		: fpu vme sse4_2 avx512f avx2
+
+`), 0o644))
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.HardwareFactsController{
+		SysPath:  suite.sysPath,
+		ProcPath: procPath,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *HardwareFactsSuite) addBlockDevice(name string, physical bool, rotational, removable string) {
+	path := filepath.Join(suite.sysPath, "block", name)
+
+	suite.Require().NoError(os.MkdirAll(filepath.Join(path, "queue"), 0o755))
+
+	if physical {
+		suite.Require().NoError(os.MkdirAll(filepath.Join(path, "device"), 0o755))
+	}
+
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(path, "queue", "rotational"), []byte(rotational+"\n"), 0o644))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(path, "removable"), []byte(removable+"\n"), 0o644))
+}
+
+func (suite *HardwareFactsSuite) assertFacts(expected runtimeresource.HardwareFactsSpec) func() error {
+	return func() error {
+		res, err := suite.state.Get(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.HardwareFactsType, runtimeresource.HardwareFactsID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		spec := *res.(*runtimeresource.HardwareFacts).TypedSpec()
+
+		if !reflect.DeepEqual(spec, expected) {
+			return retry.ExpectedErrorf("unexpected hardware facts %+v", spec)
+		}
+
+		return nil
+	}
+}
+
+func (suite *HardwareFactsSuite) TestReconcile() {
+	suite.addBlockDevice("nvme0n1", true, "0", "0")
+	suite.addBlockDevice("sda", true, "1", "0")
+	suite.addBlockDevice("sdb", true, "0", "1")
+	suite.addBlockDevice("loop0", false, "0", "0")
+
+	gpu := runtimeresource.NewAccelerator("0000:3b:00.0")
+	*gpu.TypedSpec() = runtimeresource.AcceleratorSpec{
+		Type:     runtimeresource.AcceleratorTypeGPU,
+		VendorID: "0x10de",
+		DeviceID: "0x20b0",
+		Vendor:   "NVIDIA",
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, gpu))
+
+	tpu := runtimeresource.NewAccelerator("0000:04:00.0")
+	*tpu.TypedSpec() = runtimeresource.AcceleratorSpec{
+		Type:     runtimeresource.AcceleratorTypeTPU,
+		VendorID: "0x1ac1",
+		DeviceID: "0x089a",
+		Vendor:   "Google",
+	}
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, tpu))
+
+	for name, speed := range map[string]int{
+		"eth0": 10000,
+		"eth1": 25000,
+	} {
+		link := network.NewLinkStatus(network.NamespaceName, name)
+		link.TypedSpec().Type = nethelpers.LinkEther
+		link.TypedSpec().SpeedMegabits = speed
+
+		suite.Require().NoError(suite.state.Create(suite.ctx, link))
+	}
+
+	bond := network.NewLinkStatus(network.NamespaceName, "bond0")
+	bond.TypedSpec().Type = nethelpers.LinkEther
+	bond.TypedSpec().Kind = "bond"
+	bond.TypedSpec().SpeedMegabits = 50000
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, bond))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(suite.assertFacts(runtimeresource.HardwareFactsSpec{
+		CPUVendor:   "GenuineIntel",
+		CPUFlags:    []string{"avx2", "avx512f", "fpu", "sse4_2", "vme"},
+		GPUVendors:  []string{"NVIDIA"},
+		GPUModels:   []string{"10de:20b0"},
+		DiskClasses: []string{runtimeresource.DiskClassHDD, runtimeresource.DiskClassNVMe},
+		NICSpeed:    25000,
+	})))
+}
+
+func (suite *HardwareFactsSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestHardwareFactsSuite(t *testing.T) {
+	suite.Run(t, new(HardwareFactsSuite))
+}
@@ -0,0 +1,368 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	containerdapi "github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/namespaces"
+	criconstants "github.com/containerd/cri/pkg/constants"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/internal/pkg/containers/containerd"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// HealthCheckController runs the user-defined health checks from the machine config.
+type HealthCheckController struct {
+	// Exec runs the exec health check command, defaults to running it in the containerd container.
+	Exec func(ctx context.Context, check talosconfig.HealthCheckExec) error
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *HealthCheckController) Name() string {
+	return "runtime.HealthCheckController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *HealthCheckController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *HealthCheckController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.HealthCheckStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+type healthCheckResult struct {
+	check talosconfig.HealthCheck
+	err   error
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *HealthCheckController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.Exec == nil {
+		ctrl.Exec = execHealthCheck
+	}
+
+	var (
+		wg         sync.WaitGroup
+		stopChecks context.CancelFunc = func() {}
+		failures   map[string]int
+	)
+
+	resultCh := make(chan healthCheckResult)
+
+	defer func() {
+		stopChecks()
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+			var checks []talosconfig.HealthCheck
+
+			cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+			if err != nil {
+				if !state.IsNotFoundError(err) {
+					return fmt.Errorf("error getting config: %w", err)
+				}
+			} else {
+				checks = cfg.(*config.MachineConfig).Config().Machine().HealthChecks()
+			}
+
+			// restart the checks, the check goroutines exit without sending results once the context is canceled
+			stopChecks()
+			wg.Wait()
+
+			checksCtx, checksCancel := context.WithCancel(ctx)
+			stopChecks = checksCancel
+
+			// keep the failure counters, so that unrelated config changes don't flip the status
+			prevFailures := failures
+			failures = make(map[string]int, len(checks))
+			touchedIDs := make(map[resource.ID]struct{}, len(checks))
+
+			for _, check := range checks {
+				failures[check.Name()] = prevFailures[check.Name()]
+				touchedIDs[check.Name()] = struct{}{}
+
+				wg.Add(1)
+
+				go func(check talosconfig.HealthCheck) {
+					defer wg.Done()
+
+					ctrl.runCheck(checksCtx, check, resultCh)
+				}(check)
+			}
+
+			list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.HealthCheckStatusType, "", resource.VersionUndefined))
+			if err != nil {
+				return fmt.Errorf("error listing resources: %w", err)
+			}
+
+			for _, res := range list.Items {
+				if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+					if err = r.Destroy(ctx, res.Metadata()); err != nil {
+						return fmt.Errorf("error cleaning up health check status: %w", err)
+					}
+				}
+			}
+		case result := <-resultCh:
+			name := result.check.Name()
+
+			if result.err != nil {
+				failures[name]++
+
+				logger.Debug("health check failed", zap.String("check", name), zap.Int("failures", failures[name]), zap.Error(result.err))
+			} else {
+				failures[name] = 0
+			}
+
+			if err := r.Modify(ctx, runtime.NewHealthCheckStatus(name), func(res resource.Resource) error {
+				spec := res.(*runtime.HealthCheckStatus).TypedSpec()
+
+				spec.Type = healthCheckType(result.check)
+				spec.Healthy = failures[name] < result.check.FailureThreshold()
+				spec.Failures = failures[name]
+				spec.Taint = result.check.Taint()
+				spec.Message = ""
+
+				if result.err != nil {
+					spec.Message = result.err.Error()
+				}
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating health check status: %w", err)
+			}
+		}
+	}
+}
+
+func (ctrl *HealthCheckController) runCheck(ctx context.Context, check talosconfig.HealthCheck, resultCh chan<- healthCheckResult) {
+	ticker := time.NewTicker(check.Interval())
+	defer ticker.Stop()
+
+	for {
+		checkCtx, checkCancel := context.WithTimeout(ctx, check.Timeout())
+		err := ctrl.check(checkCtx, check)
+
+		checkCancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case resultCh <- healthCheckResult{check: check, err: err}:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ctrl *HealthCheckController) check(ctx context.Context, check talosconfig.HealthCheck) error {
+	switch {
+	case check.HTTP() != nil:
+		return httpHealthCheck(ctx, check.HTTP())
+	case check.TCP() != nil:
+		return tcpHealthCheck(ctx, check.TCP())
+	case check.Exec() != nil:
+		return ctrl.Exec(ctx, check.Exec())
+	default:
+		return fmt.Errorf("unsupported health check")
+	}
+}
+
+func healthCheckType(check talosconfig.HealthCheck) string {
+	switch {
+	case check.HTTP() != nil:
+		return runtime.HealthCheckTypeHTTP
+	case check.TCP() != nil:
+		return runtime.HealthCheckTypeTCP
+	default:
+		return runtime.HealthCheckTypeExec
+	}
+}
+
+func httpHealthCheck(ctx context.Context, check talosconfig.HealthCheckHTTP) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.URL(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	//nolint:errcheck
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+
+	if check.ExpectedStatus() != 0 {
+		if resp.StatusCode != check.ExpectedStatus() {
+			return fmt.Errorf("unexpected status %d, expected %d", resp.StatusCode, check.ExpectedStatus())
+		}
+
+		return nil
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func tcpHealthCheck(ctx context.Context, check talosconfig.HealthCheckTCP) error {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", check.Address())
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// execHealthCheck runs the command in the running container task and checks the exit code.
+//
+//nolint:gocyclo
+func execHealthCheck(ctx context.Context, check talosconfig.HealthCheckExec) error {
+	namespace, address := constants.SystemContainerdNamespace, constants.SystemContainerdAddress
+
+	if check.Kubernetes() {
+		namespace, address = criconstants.K8sContainerdNamespace, constants.CRIContainerdAddress
+	}
+
+	inspector, err := containerd.NewInspector(ctx, namespace, containerd.WithContainerdAddress(address))
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer inspector.Close()
+
+	container, err := inspector.Container(check.Container())
+	if err != nil {
+		return err
+	}
+
+	if container == nil {
+		return fmt.Errorf("container %q not found", check.Container())
+	}
+
+	client, err := containerdapi.New(address)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer client.Close()
+
+	nsctx := namespaces.WithNamespace(ctx, namespace)
+
+	ctr, err := client.LoadContainer(nsctx, container.ID)
+	if err != nil {
+		return err
+	}
+
+	spec, err := ctr.Spec(nsctx)
+	if err != nil {
+		return err
+	}
+
+	task, err := ctr.Task(nsctx, nil)
+	if err != nil {
+		return err
+	}
+
+	processSpec := *spec.Process
+	processSpec.Args = check.Command()
+	processSpec.Terminal = false
+
+	var execID [8]byte
+
+	if _, err = rand.Read(execID[:]); err != nil {
+		return err
+	}
+
+	process, err := task.Exec(nsctx, "health-"+hex.EncodeToString(execID[:]), &processSpec, cio.NullIO)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// the check context might be already canceled
+		//nolint:errcheck
+		process.Delete(namespaces.WithNamespace(context.Background(), namespace), containerdapi.WithProcessKill)
+	}()
+
+	statusCh, err := process.Wait(nsctx)
+	if err != nil {
+		return err
+	}
+
+	if err = process.Start(nsctx); err != nil {
+		return err
+	}
+
+	select {
+	case status := <-statusCh:
+		code, _, err := status.Result()
+		if err != nil {
+			return err
+		}
+
+		if code != 0 {
+			return fmt.Errorf("command exited with code %d", code)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,234 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type HealthCheckSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	server     *httptest.Server
+	httpStatus int32
+
+	listener net.Listener
+}
+
+func (suite *HealthCheckSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	atomic.StoreInt32(&suite.httpStatus, http.StatusOK)
+
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(atomic.LoadInt32(&suite.httpStatus)))
+	}))
+
+	suite.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.HealthCheckController{
+		Exec: func(ctx context.Context, check talosconfig.HealthCheckExec) error {
+			return fmt.Errorf("container %q not found", check.Container())
+		},
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *HealthCheckSuite) assertStatus(id string, check func(*runtimeresource.HealthCheckStatusSpec) bool) func() error {
+	return func() error {
+		res, err := suite.state.Get(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.HealthCheckStatusType, id, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		spec := res.(*runtimeresource.HealthCheckStatus).TypedSpec()
+
+		if !check(spec) {
+			return retry.ExpectedErrorf("unexpected health check status %+v", *spec)
+		}
+
+		return nil
+	}
+}
+
+func (suite *HealthCheckSuite) assertNoStatus(id string) error {
+	_, err := suite.state.Get(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.HealthCheckStatusType, id, resource.VersionUndefined))
+	if err == nil {
+		return retry.ExpectedErrorf("health check status %q still exists", id)
+	}
+
+	if state.IsNotFoundError(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (suite *HealthCheckSuite) TestReconcile() {
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineHealthChecks: []v1alpha1.HealthCheckConfig{
+				{
+					CheckName: "app",
+					CheckHTTP: &v1alpha1.HealthCheckHTTPConfig{
+						HTTPURL: suite.server.URL + "/healthz",
+					},
+					CheckInterval:         100 * time.Millisecond,
+					CheckTimeout:          100 * time.Millisecond,
+					CheckFailureThreshold: 2,
+					CheckTaint:            true,
+				},
+				{
+					CheckName: "db",
+					CheckTCP: &v1alpha1.HealthCheckTCPConfig{
+						TCPAddress: suite.listener.Addr().String(),
+					},
+					CheckInterval: 100 * time.Millisecond,
+					CheckTimeout:  100 * time.Millisecond,
+				},
+				{
+					CheckName: "queue",
+					CheckExec: &v1alpha1.HealthCheckExecConfig{
+						ExecContainer: "queue",
+						ExecCommand:   []string{"queue-ctl", "ping"},
+					},
+					CheckInterval:         100 * time.Millisecond,
+					CheckTimeout:          100 * time.Millisecond,
+					CheckFailureThreshold: 2,
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("app", func(spec *runtimeresource.HealthCheckStatusSpec) bool {
+			return spec.Type == runtimeresource.HealthCheckTypeHTTP && spec.Healthy && spec.Taint && spec.Message == ""
+		}),
+	))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("db", func(spec *runtimeresource.HealthCheckStatusSpec) bool {
+			return spec.Type == runtimeresource.HealthCheckTypeTCP && spec.Healthy && !spec.Taint
+		}),
+	))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("queue", func(spec *runtimeresource.HealthCheckStatusSpec) bool {
+			return spec.Type == runtimeresource.HealthCheckTypeExec && !spec.Healthy && spec.Failures >= 2 &&
+				spec.Message == `container "queue" not found`
+		}),
+	))
+
+	atomic.StoreInt32(&suite.httpStatus, http.StatusServiceUnavailable)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("app", func(spec *runtimeresource.HealthCheckStatusSpec) bool {
+			return !spec.Healthy && strings.Contains(spec.Message, "unexpected status 503")
+		}),
+	))
+
+	suite.Require().NoError(suite.listener.Close())
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("db", func(spec *runtimeresource.HealthCheckStatusSpec) bool {
+			return !spec.Healthy && spec.Failures >= 3
+		}),
+	))
+
+	// checks removed from the configuration are cleaned up
+	_, err := suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineHealthChecks = r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineHealthChecks[:1]
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		if err := suite.assertNoStatus("db"); err != nil {
+			return err
+		}
+
+		return suite.assertNoStatus("queue")
+	}))
+
+	atomic.StoreInt32(&suite.httpStatus, http.StatusNoContent)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("app", func(spec *runtimeresource.HealthCheckStatusSpec) bool {
+			return spec.Healthy && spec.Failures == 0
+		}),
+	))
+}
+
+func (suite *HealthCheckSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+
+	suite.server.Close()
+	suite.listener.Close() //nolint:errcheck
+}
+
+func TestHealthCheckSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckSuite))
+}
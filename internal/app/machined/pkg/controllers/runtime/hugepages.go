@@ -0,0 +1,211 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// hugepage usage is refreshed periodically, as free hugepages change with the workloads.
+const hugePagesUpdateInterval = time.Minute
+
+// HugePagesController applies hugepage reservations from the machine configuration and publishes hugepage statuses.
+type HugePagesController struct {
+	// SysPath defaults to /sys.
+	SysPath string
+
+	// applied is the number of hugepages written to each nr_hugepages file
+	applied map[string]int
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *HugePagesController) Name() string {
+	return "runtime.HugePagesController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *HugePagesController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *HugePagesController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.HugePageStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *HugePagesController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	ctrl.applied = map[string]int{}
+
+	ticker := time.NewTicker(hugePagesUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		// requested hugepages by the nr_hugepages path
+		requested := map[string]int{}
+
+		if cfg != nil {
+			for _, hugePage := range cfg.(*config.MachineConfig).Config().Machine().HugePages() {
+				requested[ctrl.hugePagesPath(hugePage.Size()/1024, hugePage.NUMANode())] = hugePage.Count()
+			}
+		}
+
+		ctrl.apply(requested, logger)
+
+		if err = ctrl.updateStatuses(ctx, r, requested); err != nil {
+			return err
+		}
+	}
+}
+
+// apply writes the requested number of hugepages, and releases the hugepages no longer requested.
+//
+// Failures are not retried until the configuration changes, as the achieved reservation is visible in the status.
+func (ctrl *HugePagesController) apply(requested map[string]int, logger *zap.Logger) {
+	for path, count := range requested {
+		if applied, ok := ctrl.applied[path]; ok && applied == count {
+			continue
+		}
+
+		ctrl.write(path, count, logger)
+		ctrl.applied[path] = count
+	}
+
+	for path := range ctrl.applied {
+		if _, ok := requested[path]; !ok {
+			ctrl.write(path, 0, logger)
+			delete(ctrl.applied, path)
+		}
+	}
+}
+
+func (ctrl *HugePagesController) write(path string, count int, logger *zap.Logger) {
+	if err := ioutil.WriteFile(path, []byte(strconv.Itoa(count)), 0o644); err != nil {
+		logger.Warn("failed to reserve hugepages", zap.String("path", path), zap.Int("count", count), zap.Error(err))
+	}
+}
+
+//nolint:gocyclo
+func (ctrl *HugePagesController) updateStatuses(ctx context.Context, r controller.Runtime, requested map[string]int) error {
+	dirs, err := filepath.Glob(filepath.Join(ctrl.SysPath, "kernel", "mm", "hugepages", "hugepages-*kB"))
+	if err != nil {
+		return err
+	}
+
+	nodeDirs, err := filepath.Glob(filepath.Join(ctrl.SysPath, "devices", "system", "node", "node*", "hugepages", "hugepages-*kB"))
+	if err != nil {
+		return err
+	}
+
+	touchedIDs := make(map[resource.ID]struct{})
+
+	for _, dir := range append(dirs, nodeDirs...) {
+		sizeKB, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(dir), "hugepages-"), "kB"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		numaNode := -1
+		size := runtime.HugePageSizeName(sizeKB * 1024)
+		id := size
+
+		if strings.HasPrefix(dir, filepath.Join(ctrl.SysPath, "devices")) {
+			node := filepath.Base(filepath.Dir(filepath.Dir(dir)))
+
+			if numaNode, err = strconv.Atoi(strings.TrimPrefix(node, "node")); err != nil {
+				continue
+			}
+
+			id = size + "-" + node
+		}
+
+		spec := runtime.HugePageStatusSpec{
+			Size:      size,
+			NUMANode:  numaNode,
+			Requested: requested[filepath.Join(dir, "nr_hugepages")],
+		}
+
+		spec.Total, _ = strconv.Atoi(readSysfsAttribute(dir, "nr_hugepages"))  //nolint:errcheck
+		spec.Free, _ = strconv.Atoi(readSysfsAttribute(dir, "free_hugepages")) //nolint:errcheck
+
+		if err = r.Modify(ctx, runtime.NewHugePageStatus(id), func(res resource.Resource) error {
+			*res.(*runtime.HugePageStatus).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating hugepage status: %w", err)
+		}
+
+		touchedIDs[id] = struct{}{}
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.HugePageStatusType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing resources: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up hugepage status: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ctrl *HugePagesController) hugePagesPath(sizeKB uint64, numaNode int) string {
+	dir := fmt.Sprintf("hugepages-%dkB", sizeKB)
+
+	if numaNode < 0 {
+		return filepath.Join(ctrl.SysPath, "kernel", "mm", "hugepages", dir, "nr_hugepages")
+	}
+
+	return filepath.Join(ctrl.SysPath, "devices", "system", "node", fmt.Sprintf("node%d", numaNode), "hugepages", dir, "nr_hugepages")
+}
@@ -0,0 +1,191 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type HugePagesSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	sysPath string
+}
+
+func (suite *HugePagesSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.sysPath = suite.T().TempDir()
+
+	for _, dir := range []string{
+		"kernel/mm/hugepages/hugepages-2048kB",
+		"kernel/mm/hugepages/hugepages-1048576kB",
+		"devices/system/node/node0/hugepages/hugepages-2048kB",
+		"devices/system/node/node0/hugepages/hugepages-1048576kB",
+	} {
+		suite.writeFile(filepath.Join(dir, "nr_hugepages"), "0\n")
+		suite.writeFile(filepath.Join(dir, "free_hugepages"), "0\n")
+	}
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.HugePagesController{
+		SysPath: suite.sysPath,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *HugePagesSuite) writeFile(path, contents string) {
+	path = filepath.Join(suite.sysPath, path)
+
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0o644))
+}
+
+func (suite *HugePagesSuite) readFile(path string) string {
+	contents, err := ioutil.ReadFile(filepath.Join(suite.sysPath, path))
+	suite.Require().NoError(err)
+
+	return strings.TrimSpace(string(contents))
+}
+
+func (suite *HugePagesSuite) assertStatus(id resource.ID, expected runtimeresource.HugePageStatusSpec) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, runtimeresource.NewHugePageStatus(id).Metadata())
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		if spec := *r.(*runtimeresource.HugePageStatus).TypedSpec(); spec != expected {
+			return retry.ExpectedErrorf("unexpected status %+v", spec)
+		}
+
+		return nil
+	}
+}
+
+func (suite *HugePagesSuite) TestReconcile() {
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineHugePages: []v1alpha1.HugePageConfig{
+				{
+					HugePageSize:  "2Mi",
+					HugePageCount: 512,
+				},
+				{
+					HugePageSize:     "1Gi",
+					HugePageCount:    2,
+					HugePageNUMANode: pointer.ToInt(0),
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("2Mi", runtimeresource.HugePageStatusSpec{
+			Size:      "2Mi",
+			NUMANode:  -1,
+			Requested: 512,
+			Total:     512,
+		}),
+	))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("1Gi-node0", runtimeresource.HugePageStatusSpec{
+			Size:      "1Gi",
+			NUMANode:  0,
+			Requested: 2,
+			Total:     2,
+		}),
+	))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("2Mi-node0", runtimeresource.HugePageStatusSpec{
+			Size:     "2Mi",
+			NUMANode: 0,
+		}),
+	))
+
+	// reservations removed from the configuration are released
+	_, err := suite.state.UpdateWithConflicts(suite.ctx, cfg.Metadata(), func(r resource.Resource) error {
+		r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineHugePages = r.(*config.MachineConfig).Config().(*v1alpha1.Config).MachineConfig.MachineHugePages[:1]
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("1Gi-node0", runtimeresource.HugePageStatusSpec{
+			Size:     "1Gi",
+			NUMANode: 0,
+		}),
+	))
+
+	suite.Assert().Equal("512", suite.readFile("kernel/mm/hugepages/hugepages-2048kB/nr_hugepages"))
+	suite.Assert().Equal("0", suite.readFile("devices/system/node/node0/hugepages/hugepages-1048576kB/nr_hugepages"))
+}
+
+func (suite *HugePagesSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestHugePagesSuite(t *testing.T) {
+	suite.Run(t, new(HugePagesSuite))
+}
@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/instanceidentity"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// InstanceIdentityRetryInterval is the interval between the attempts to fetch and verify the instance identity.
+const InstanceIdentityRetryInterval = time.Minute
+
+// InstanceIdentityController verifies the instance identity document provided by the platform and publishes the verified identity.
+type InstanceIdentityController struct {
+	V1Alpha1Platform v1alpha1runtime.Platform
+	// Verifier verifies the instance identity documents, defaults to the verifier configured from the machine config.
+	Verifier *instanceidentity.Verifier
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *InstanceIdentityController) Name() string {
+	return "runtime.InstanceIdentityController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *InstanceIdentityController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *InstanceIdentityController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.InstanceIdentityType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *InstanceIdentityController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if _, ok := ctrl.V1Alpha1Platform.(v1alpha1runtime.InstanceIdentityProvider); !ok {
+		// platform doesn't provide instance identity documents
+		return nil
+	}
+
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-retryCh:
+		}
+
+		retryCh = nil
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		verifier := ctrl.Verifier
+		if verifier == nil {
+			verifier = &instanceidentity.Verifier{
+				AWSCertificates: cfg.(*config.MachineConfig).Config().Cluster().InstanceIdentity().AWSCertificates(),
+			}
+		}
+
+		identity, err := ctrl.verify(ctx, verifier)
+		if err != nil {
+			logger.Warn("failed to verify instance identity", zap.Error(err))
+
+			retryCh = time.After(InstanceIdentityRetryInterval)
+
+			continue
+		}
+
+		if err = r.Modify(ctx, runtime.NewInstanceIdentity(), func(r resource.Resource) error {
+			*r.(*runtime.InstanceIdentity).TypedSpec() = runtime.InstanceIdentitySpec{
+				Platform:     identity.Platform,
+				InstanceID:   identity.InstanceID,
+				InstanceName: identity.InstanceName,
+				AccountID:    identity.AccountID,
+				Region:       identity.Region,
+				Zone:         identity.Zone,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating instance identity: %w", err)
+		}
+	}
+}
+
+func (ctrl *InstanceIdentityController) verify(ctx context.Context, verifier *instanceidentity.Verifier) (*instanceidentity.Identity, error) {
+	document, err := v1alpha1runtime.InstanceIdentityDocument(ctx, ctrl.V1Alpha1Platform)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching instance identity document: %w", err)
+	}
+
+	return verifier.Verify(ctx, ctrl.V1Alpha1Platform.Name(), document)
+}
@@ -0,0 +1,210 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-procfs/procfs"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	talosruntime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/instanceidentity"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type identityPlatform struct {
+	document []byte
+}
+
+func (p *identityPlatform) Name() string { return instanceidentity.PlatformGCP }
+
+func (p *identityPlatform) Configuration(context.Context) ([]byte, error) { return nil, nil }
+
+func (p *identityPlatform) Hostname(context.Context) ([]byte, error) { return nil, nil }
+
+func (p *identityPlatform) Mode() talosruntime.Mode { return talosruntime.ModeCloud }
+
+func (p *identityPlatform) ExternalIPs(context.Context) ([]net.IP, error) { return nil, nil }
+
+func (p *identityPlatform) KernelArgs() procfs.Parameters { return nil }
+
+func (p *identityPlatform) InstanceIdentityDocument(context.Context) ([]byte, error) {
+	return p.document, nil
+}
+
+type InstanceIdentitySuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *InstanceIdentitySuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+}
+
+func (suite *InstanceIdentitySuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *InstanceIdentitySuite) TestReconcile() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "google"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	suite.Require().NoError(err)
+
+	cert, err := x509.ParseCertificate(der)
+	suite.Require().NoError(err)
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "key1"})
+	suite.Require().NoError(err)
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"iss": "https://accounts.google.com",
+		"aud": instanceidentity.GCPAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"google": map[string]interface{}{
+			"compute_engine": map[string]interface{}{
+				"instance_id":   "4567890123456789012",
+				"instance_name": "worker-1",
+				"project_id":    "my-project",
+				"zone":          "europe-west1-b",
+			},
+		},
+	})
+	suite.Require().NoError(err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.InstanceIdentityController{
+		V1Alpha1Platform: &identityPlatform{
+			document: []byte(signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)),
+		},
+		Verifier: &instanceidentity.Verifier{
+			GCPCertificates: func(context.Context) (map[string]*x509.Certificate, error) {
+				return map[string]*x509.Certificate{"key1": cert}, nil
+			},
+		},
+	}))
+
+	suite.startRuntime()
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, runtimeresource.NewInstanceIdentity().Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			suite.Assert().Equal(runtimeresource.InstanceIdentitySpec{
+				Platform:     "gcp",
+				InstanceID:   "4567890123456789012",
+				InstanceName: "worker-1",
+				AccountID:    "my-project",
+				Region:       "europe-west1",
+				Zone:         "europe-west1-b",
+			}, *r.(*runtimeresource.InstanceIdentity).TypedSpec())
+
+			return nil
+		},
+	))
+}
+
+func (suite *InstanceIdentitySuite) TestNotVerified() {
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.InstanceIdentityController{
+		V1Alpha1Platform: &identityPlatform{
+			document: []byte("invalid"),
+		},
+	}))
+
+	suite.startRuntime()
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})))
+
+	time.Sleep(500 * time.Millisecond)
+
+	_, err := suite.state.Get(suite.ctx, runtimeresource.NewInstanceIdentity().Metadata())
+	suite.Assert().True(state.IsNotFoundError(err))
+}
+
+func (suite *InstanceIdentitySuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestInstanceIdentitySuite(t *testing.T) {
+	suite.Run(t, new(InstanceIdentitySuite))
+}
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	netctrl "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/network"
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+// IRQ affinity is re-applied periodically, as IRQs are allocated when the drivers are loaded.
+const irqAffinityUpdateInterval = time.Minute
+
+// IRQAffinityController steers IRQs to the housekeeping CPUs of the real-time profile.
+//
+// IRQs of the links with the explicit IRQ affinity (see network.LinkTuningController) are not touched.
+type IRQAffinityController struct {
+	// ProcPath defaults to /proc.
+	ProcPath string
+	// SysPath defaults to /sys.
+	SysPath string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *IRQAffinityController) Name() string {
+	return "runtime.IRQAffinityController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *IRQAffinityController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *IRQAffinityController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *IRQAffinityController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.ProcPath == "" {
+		ctrl.ProcPath = "/proc"
+	}
+
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	ticker := time.NewTicker(irqAffinityUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
+
+			return fmt.Errorf("error getting config: %w", err)
+		}
+
+		machineConfig := cfg.(*config.MachineConfig).Config().Machine()
+
+		rt := machineConfig.Kernel().Realtime()
+		if rt == nil {
+			continue
+		}
+
+		pinnedIRQs := map[string]struct{}{}
+
+		for _, device := range machineConfig.Network().Devices() {
+			if device.Tuning() == nil || device.Tuning().IRQCPUs() == "" {
+				continue
+			}
+
+			for _, irq := range netctrl.LinkIRQs(ctrl.SysPath, device.Interface()) {
+				pinnedIRQs[irq] = struct{}{}
+			}
+		}
+
+		housekeeping, err := kernel.ParseCPUList(rt.HousekeepingCPUs())
+		if err != nil {
+			return fmt.Errorf("error parsing housekeeping CPUs: %w", err)
+		}
+
+		ctrl.apply(rt.HousekeepingCPUs(), kernel.CPUMask(housekeeping), pinnedIRQs, logger)
+	}
+}
+
+func (ctrl *IRQAffinityController) apply(cpuList, cpuMask string, pinnedIRQs map[string]struct{}, logger *zap.Logger) {
+	irqPath := filepath.Join(ctrl.ProcPath, "irq")
+
+	if readSysfsAttribute(irqPath, "default_smp_affinity") != cpuMask {
+		if err := ioutil.WriteFile(filepath.Join(irqPath, "default_smp_affinity"), []byte(cpuMask), 0o644); err != nil {
+			logger.Warn("failed to set default IRQ affinity", zap.String("cpus", cpuList), zap.Error(err))
+		}
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(irqPath, "[0-9]*")) //nolint:errcheck
+
+	for _, path := range paths {
+		if _, pinned := pinnedIRQs[filepath.Base(path)]; pinned {
+			continue
+		}
+
+		current := readSysfsAttribute(path, "smp_affinity_list")
+		if current == "" || current == cpuList {
+			continue
+		}
+
+		// affinity of the kernel managed IRQs can't be changed, isolcpus=managed_irq takes care of them
+		if err := ioutil.WriteFile(filepath.Join(path, "smp_affinity_list"), []byte(cpuList), 0o644); err != nil {
+			logger.Debug("failed to set IRQ affinity", zap.String("irq", filepath.Base(path)), zap.Error(err))
+		}
+	}
+}
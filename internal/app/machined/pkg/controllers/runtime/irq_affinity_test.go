@@ -0,0 +1,149 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+type IRQAffinitySuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	procPath string
+}
+
+func (suite *IRQAffinitySuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.procPath = suite.T().TempDir()
+
+	suite.writeFile("irq/default_smp_affinity", "ff\n")
+
+	for _, irq := range []string{"0", "24", "25"} {
+		suite.writeFile(filepath.Join("irq", irq, "smp_affinity_list"), "0-7\n")
+	}
+
+	// IRQ 25 belongs to the NIC with the explicit IRQ affinity
+	suite.writeFile("class/net/eth0/device/msi_irqs/25", "msix\n")
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.IRQAffinityController{
+		ProcPath: suite.procPath,
+		SysPath:  suite.procPath,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *IRQAffinitySuite) writeFile(path, contents string) {
+	path = filepath.Join(suite.procPath, path)
+
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0o644))
+}
+
+func (suite *IRQAffinitySuite) readFile(path string) string {
+	contents, err := ioutil.ReadFile(filepath.Join(suite.procPath, path))
+	suite.Require().NoError(err)
+
+	return strings.TrimSpace(string(contents))
+}
+
+func (suite *IRQAffinitySuite) assertFile(path, expected string) func() error {
+	return func() error {
+		if contents := suite.readFile(path); contents != expected {
+			return retry.ExpectedErrorf("unexpected %q contents %q", path, contents)
+		}
+
+		return nil
+	}
+}
+
+func (suite *IRQAffinitySuite) TestReconcile() {
+	suite.Require().NoError(suite.state.Create(suite.ctx, config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineKernel: &v1alpha1.KernelConfig{
+				KernelRealtime: &v1alpha1.RealtimeProfileConfig{
+					RealtimeIsolatedCPUs:     "2-7",
+					RealtimeHousekeepingCPUs: "0-1",
+				},
+			},
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: "eth0",
+						DeviceTuning: &v1alpha1.DeviceTuningConfig{
+							TuningIRQCPUs: "2-7",
+						},
+					},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertFile("irq/default_smp_affinity", "3"),
+	))
+
+	for _, irq := range []string{"0", "24"} {
+		suite.Assert().Equal("0-1", suite.readFile(filepath.Join("irq", irq, "smp_affinity_list")))
+	}
+
+	suite.Assert().Equal("0-7", suite.readFile("irq/25/smp_affinity_list"))
+}
+
+func (suite *IRQAffinitySuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestIRQAffinitySuite(t *testing.T) {
+	suite.Run(t, new(IRQAffinitySuite))
+}
@@ -67,7 +67,20 @@ func (ctrl *KernelParamConfigController) Run(ctx context.Context, r controller.R
 
 			if cfg != nil {
 				c, _ := cfg.(*config.MachineConfig) //nolint:errcheck
+
+				params := map[string]string{}
+
 				for key, value := range c.Config().Machine().Sysctls() {
+					params[key] = value
+				}
+
+				if conntrack := c.Config().Machine().Network().Conntrack(); conntrack != nil {
+					for key, value := range conntrack.KernelParams() {
+						params[key] = value
+					}
+				}
+
+				for key, value := range params {
 					touchedIDs[key] = struct{}{}
 
 					value := value
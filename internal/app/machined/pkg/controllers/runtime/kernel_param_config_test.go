@@ -88,6 +88,38 @@ func (suite *KernelParamConfigSuite) TestReconcileConfig() {
 	))
 }
 
+func (suite *KernelParamConfigSuite) TestReconcileConntrack() {
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.KernelParamConfigController{}))
+
+	suite.startRuntime()
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkConntrack: &v1alpha1.ConntrackConfig{
+					ConntrackMax: 1048576,
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.Assert().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertResource(
+			resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.KernelParamSpecType, "net.netfilter.nf_conntrack_max", resource.VersionUndefined),
+			func(res resource.Resource) bool {
+				return res.(*runtimeresource.KernelParamSpec).TypedSpec().Value == "1048576"
+			},
+		),
+	))
+
+	_, err := suite.state.Get(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.KernelParamSpecType, "net.netfilter.nf_conntrack_buckets", resource.VersionUndefined))
+	suite.Assert().True(state.IsNotFoundError(err))
+}
+
 func TestKernelParamConfigSuite(t *testing.T) {
 	suite.Run(t, new(KernelParamConfigSuite))
 }
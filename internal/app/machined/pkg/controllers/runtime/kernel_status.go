@@ -0,0 +1,302 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+const kernelModulesUpdateInterval = time.Minute
+
+// kernelConfigAuditOptions is the list of kernel build options published as resources.
+var kernelConfigAuditOptions = []string{
+	"CONFIG_BPF_UNPRIV_DEFAULT_OFF",
+	"CONFIG_DEVMEM",
+	"CONFIG_EVM",
+	"CONFIG_FORTIFY_SOURCE",
+	"CONFIG_HARDENED_USERCOPY",
+	"CONFIG_IMA",
+	"CONFIG_IMA_APPRAISE",
+	"CONFIG_INIT_ON_ALLOC_DEFAULT_ON",
+	"CONFIG_INIT_ON_FREE_DEFAULT_ON",
+	"CONFIG_IO_STRICT_DEVMEM",
+	"CONFIG_KEXEC_SIG",
+	"CONFIG_LOCK_DOWN_KERNEL_FORCE_CONFIDENTIALITY",
+	"CONFIG_LOCK_DOWN_KERNEL_FORCE_INTEGRITY",
+	"CONFIG_MODULE_SIG",
+	"CONFIG_MODULE_SIG_ALL",
+	"CONFIG_MODULE_SIG_FORCE",
+	"CONFIG_RANDOMIZE_BASE",
+	"CONFIG_RANDOMIZE_MEMORY",
+	"CONFIG_SECCOMP",
+	"CONFIG_SECCOMP_FILTER",
+	"CONFIG_SECURITY_LOCKDOWN_LSM",
+	"CONFIG_SECURITY_LOCKDOWN_LSM_EARLY",
+	"CONFIG_STACKPROTECTOR_STRONG",
+	"CONFIG_STRICT_DEVMEM",
+	"CONFIG_STRICT_KERNEL_RWX",
+	"CONFIG_STRICT_MODULE_RWX",
+}
+
+// KernelStatusController publishes kernel command line, loaded kernel modules and selected kernel build options.
+type KernelStatusController struct {
+	// ProcPath and SysPath default to /proc and /sys.
+	ProcPath string
+	SysPath  string
+
+	staticPublished bool
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *KernelStatusController) Name() string {
+	return "runtime.KernelStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *KernelStatusController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *KernelStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.KernelCmdlineType,
+			Kind: controller.OutputExclusive,
+		},
+		{
+			Type: runtime.KernelConfigOptionType,
+			Kind: controller.OutputExclusive,
+		},
+		{
+			Type: runtime.KernelModuleStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *KernelStatusController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.ProcPath == "" {
+		ctrl.ProcPath = "/proc"
+	}
+
+	if ctrl.SysPath == "" {
+		ctrl.SysPath = "/sys"
+	}
+
+	ticker := time.NewTicker(kernelModulesUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		// kernel command line and build options don't change until reboot
+		if !ctrl.staticPublished {
+			if err := ctrl.updateCmdline(ctx, r); err != nil {
+				return err
+			}
+
+			if err := ctrl.updateConfig(ctx, r, logger); err != nil {
+				return err
+			}
+
+			ctrl.staticPublished = true
+		}
+
+		if err := ctrl.updateModules(ctx, r); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *KernelStatusController) updateCmdline(ctx context.Context, r controller.Runtime) error {
+	contents, err := ioutil.ReadFile(filepath.Join(ctrl.ProcPath, "cmdline"))
+	if err != nil {
+		return fmt.Errorf("error reading kernel cmdline: %w", err)
+	}
+
+	return r.Modify(ctx, runtime.NewKernelCmdline(), func(res resource.Resource) error {
+		res.(*runtime.KernelCmdline).TypedSpec().Cmdline = strings.TrimSpace(string(contents))
+
+		return nil
+	})
+}
+
+func (ctrl *KernelStatusController) updateConfig(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	config, err := readKernelConfig(filepath.Join(ctrl.ProcPath, "config.gz"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Debug("kernel build configuration is not available")
+
+			return nil
+		}
+
+		return fmt.Errorf("error reading kernel config: %w", err)
+	}
+
+	for _, option := range kernelConfigAuditOptions {
+		value, ok := config[option]
+		if !ok {
+			value = "n"
+		}
+
+		if err = r.Modify(ctx, runtime.NewKernelConfigOption(option), func(res resource.Resource) error {
+			res.(*runtime.KernelConfigOption).TypedSpec().Value = value
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating kernel config option: %w", err)
+		}
+	}
+
+	return nil
+}
+
+//nolint:gocyclo
+func (ctrl *KernelStatusController) updateModules(ctx context.Context, r controller.Runtime) error {
+	f, err := os.Open(filepath.Join(ctrl.ProcPath, "modules"))
+	if err != nil {
+		// kernel might be built without module support
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("error reading kernel modules: %w", err)
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	touchedIDs := make(map[resource.ID]struct{})
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		// name size refcount dependencies state offset
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+
+		name := fields[0]
+
+		var spec runtime.KernelModuleStatusSpec
+
+		spec.Size, _ = strconv.ParseUint(fields[1], 10, 64) //nolint:errcheck
+
+		if fields[3] != "-" {
+			spec.Dependencies = strings.Split(strings.TrimSuffix(fields[3], ","), ",")
+		}
+
+		spec.Parameters = ctrl.readModuleParameters(name)
+
+		if err = r.Modify(ctx, runtime.NewKernelModuleStatus(name), func(res resource.Resource) error {
+			*res.(*runtime.KernelModuleStatus).TypedSpec() = spec
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating kernel module status: %w", err)
+		}
+
+		touchedIDs[name] = struct{}{}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("error reading kernel modules: %w", err)
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.KernelModuleStatusType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing resources: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up kernel module status: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ctrl *KernelStatusController) readModuleParameters(name string) map[string]string {
+	dir := filepath.Join(ctrl.SysPath, "module", name, "parameters")
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil || len(entries) == 0 {
+		return nil
+	}
+
+	parameters := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		// some parameters are write-only, skip them
+		contents, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		parameters[entry.Name()] = strings.TrimSpace(string(contents))
+	}
+
+	return parameters
+}
+
+func readKernelConfig(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	defer gz.Close() //nolint:errcheck
+
+	config := map[string]string{}
+
+	scanner := bufio.NewScanner(gz)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "# CONFIG_") && strings.HasSuffix(line, " is not set"):
+			config[strings.TrimSuffix(strings.TrimPrefix(line, "# "), " is not set")] = "n"
+		case strings.HasPrefix(line, "CONFIG_"):
+			if parts := strings.SplitN(line, "=", 2); len(parts) == 2 {
+				config[parts[0]] = parts[1]
+			}
+		}
+	}
+
+	return config, scanner.Err()
+}
@@ -0,0 +1,179 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type KernelStatusSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	procPath string
+	sysPath  string
+}
+
+func (suite *KernelStatusSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.procPath = suite.T().TempDir()
+	suite.sysPath = suite.T().TempDir()
+}
+
+func (suite *KernelStatusSuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *KernelStatusSuite) writeFile(path, contents string) {
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(contents), 0o644))
+}
+
+func (suite *KernelStatusSuite) assertResource(md *resource.Metadata, check func(res resource.Resource) error) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, *md)
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		return check(r)
+	}
+}
+
+func (suite *KernelStatusSuite) TestReconcile() {
+	suite.writeFile(filepath.Join(suite.procPath, "cmdline"), "init_on_alloc=1 slab_nomerge pti=on lockdown=integrity\n")
+	suite.writeFile(filepath.Join(suite.procPath, "modules"), `nf_tables 249856 0 - Live 0x0000000000000000
+nfnetlink 20480 1 nf_tables, Live 0x0000000000000000
+`)
+	suite.writeFile(filepath.Join(suite.sysPath, "module", "nfnetlink", "parameters", "debug"), "N\n")
+
+	var config bytes.Buffer
+
+	gz := gzip.NewWriter(&config)
+	_, err := gz.Write([]byte(`#
+# Automatically generated file; DO NOT EDIT.
+#
+CONFIG_IMA=y
+CONFIG_SECURITY_LOCKDOWN_LSM=y
+# CONFIG_MODULE_SIG_FORCE is not set
+`))
+	suite.Require().NoError(err)
+	suite.Require().NoError(gz.Close())
+
+	suite.writeFile(filepath.Join(suite.procPath, "config.gz"), config.String())
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.KernelStatusController{
+		ProcPath: suite.procPath,
+		SysPath:  suite.sysPath,
+	}))
+
+	suite.startRuntime()
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertResource(runtimeresource.NewKernelCmdline().Metadata(), func(res resource.Resource) error {
+			suite.Assert().Equal("init_on_alloc=1 slab_nomerge pti=on lockdown=integrity", res.(*runtimeresource.KernelCmdline).TypedSpec().Cmdline)
+
+			return nil
+		}),
+	))
+
+	for option, expected := range map[string]string{
+		"CONFIG_IMA":                   "y",
+		"CONFIG_SECURITY_LOCKDOWN_LSM": "y",
+		"CONFIG_MODULE_SIG_FORCE":      "n",
+		"CONFIG_KEXEC_SIG":             "n",
+	} {
+		expected := expected
+
+		suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+			suite.assertResource(runtimeresource.NewKernelConfigOption(option).Metadata(), func(res resource.Resource) error {
+				suite.Assert().Equal(expected, res.(*runtimeresource.KernelConfigOption).TypedSpec().Value)
+
+				return nil
+			}),
+		))
+	}
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertResource(runtimeresource.NewKernelModuleStatus("nf_tables").Metadata(), func(res resource.Resource) error {
+			spec := res.(*runtimeresource.KernelModuleStatus).TypedSpec()
+
+			suite.Assert().EqualValues(249856, spec.Size)
+			suite.Assert().Empty(spec.Dependencies)
+			suite.Assert().Empty(spec.Parameters)
+
+			return nil
+		}),
+	))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertResource(runtimeresource.NewKernelModuleStatus("nfnetlink").Metadata(), func(res resource.Resource) error {
+			spec := res.(*runtimeresource.KernelModuleStatus).TypedSpec()
+
+			suite.Assert().Equal([]string{"nf_tables"}, spec.Dependencies)
+			suite.Assert().Equal(map[string]string{"debug": "N"}, spec.Parameters)
+
+			return nil
+		}),
+	))
+}
+
+func (suite *KernelStatusSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestKernelStatusSuite(t *testing.T) {
+	suite.Run(t, new(KernelStatusSuite))
+}
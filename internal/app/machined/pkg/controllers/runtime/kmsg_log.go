@@ -6,6 +6,7 @@ package runtime
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/url"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/logging"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 )
 
 const drainTimeout = 100 * time.Millisecond
@@ -50,6 +52,12 @@ func (ctrl *KmsgLogDeliveryController) Inputs() []controller.Input {
 			ID:        pointer.ToString(network.StatusID),
 			Kind:      controller.InputWeak,
 		},
+		{
+			Namespace: secrets.NamespaceName,
+			Type:      secrets.APIType,
+			ID:        pointer.ToString(secrets.APIID),
+			Kind:      controller.InputWeak,
+		},
 	}
 }
 
@@ -102,7 +110,9 @@ func (ctrl *KmsgLogDeliveryController) Run(ctx context.Context, r controller.Run
 		return fmt.Errorf("error parsing %q: %w", constants.KernelParamLoggingKernel, err)
 	}
 
-	sender := logging.NewJSONLines(destURL)
+	sender := logging.NewJSONLines(destURL, func(ctx context.Context) (*tls.Config, error) {
+		return sinkTLSConfig(ctx, r)
+	})
 	defer sender.Close(ctx) //nolint:errcheck
 
 	reader, err := kmsg.NewReader(kmsg.Follow())
@@ -180,3 +190,14 @@ func kmsgPriorityToLevel(pri kmsg.Priority) zapcore.Level {
 		return zapcore.ErrorLevel
 	}
 }
+
+// sinkTLSConfig builds mTLS config for the sinks configured via the kernel command line:
+// the sink certificate should be signed by the Talos OS CA.
+func sinkTLSConfig(ctx context.Context, r controller.Runtime) (*tls.Config, error) {
+	apiCerts, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.APIType, secrets.APIID, resource.VersionUndefined))
+	if err != nil {
+		return nil, fmt.Errorf("error reading node certificates: %w", err)
+	}
+
+	return logging.SinkTLSConfig(apiCerts.(*secrets.API).TypedSpec(), nil)
+}
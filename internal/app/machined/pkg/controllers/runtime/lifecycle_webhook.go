@@ -0,0 +1,281 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+const lifecycleWebhookTimeout = 10 * time.Second
+
+// lifecycleWebhookSignatureHeader carries the HMAC-SHA256 signature of the webhook request body.
+const lifecycleWebhookSignatureHeader = "X-Talos-Signature"
+
+// lifecycleWebhookEvent is the document posted to the lifecycle webhook.
+//
+// Event is one of: configApplied, upgrade, reset, reboot, shutdown.
+// For configApplied action is the apply mode, for other events action is one of: started, stopped, failed
+// (failed follows stopped if the sequence failed).
+type lifecycleWebhookEvent struct {
+	ID     string    `json:"id"`
+	Time   time.Time `json:"time"`
+	Node   string    `json:"node,omitempty"`
+	Event  string    `json:"event"`
+	Action string    `json:"action"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// LifecycleWebhookController posts node lifecycle events to the webhook configured in the machine config.
+type LifecycleWebhookController struct {
+	V1Alpha1Events runtime.Watcher
+	Drainer        *runtime.Drainer
+
+	// Client is used to deliver the events, defaults to the HTTP client with lifecycleWebhookTimeout.
+	Client *http.Client
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *LifecycleWebhookController) Name() string {
+	return "runtime.LifecycleWebhookController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *LifecycleWebhookController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: network.NamespaceName,
+			Type:      network.HostnameStatusType,
+			ID:        pointer.ToString(network.HostnameID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *LifecycleWebhookController) Outputs() []controller.Output {
+	return nil
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo,cyclop
+func (ctrl *LifecycleWebhookController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.Client == nil {
+		ctrl.Client = &http.Client{
+			Timeout: lifecycleWebhookTimeout,
+		}
+	}
+
+	// events published before the reboot are delivered before the node goes down
+	drainSub := ctrl.Drainer.Subscribe()
+	defer drainSub.Cancel()
+
+	eventCh := make(chan runtime.EventInfo)
+
+	if err := ctrl.V1Alpha1Events.Watch(func(watchCh <-chan runtime.EventInfo) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watchCh:
+				if !ok {
+					return
+				}
+
+				select {
+				case eventCh <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}); err != nil {
+		return err
+	}
+
+	var (
+		webhook  talosconfig.LifecycleWebhook
+		hostname string
+
+		drainTimer   *time.Timer
+		drainTimerCh <-chan time.Time
+	)
+
+	for {
+		var event runtime.EventInfo
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+			cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+			if err != nil {
+				if !state.IsNotFoundError(err) {
+					return fmt.Errorf("error getting config: %w", err)
+				}
+
+				webhook = nil
+			} else {
+				webhook = cfg.(*config.MachineConfig).Config().Machine().LifecycleWebhook()
+			}
+
+			hostnameStatus, err := r.Get(ctx, resource.NewMetadata(network.NamespaceName, network.HostnameStatusType, network.HostnameID, resource.VersionUndefined))
+			if err != nil {
+				if !state.IsNotFoundError(err) {
+					return fmt.Errorf("error getting hostname status: %w", err)
+				}
+			} else {
+				hostname = hostnameStatus.(*network.HostnameStatus).TypedSpec().FQDN()
+			}
+
+			continue
+		case <-drainSub.EventCh():
+			// drain started, assume that events are delivered if there're no new events in drainTimeout
+			drainTimer = time.NewTimer(drainTimeout)
+			drainTimerCh = drainTimer.C
+
+			continue
+		case <-drainTimerCh:
+			return nil
+		case event = <-eventCh:
+			if drainTimer != nil {
+				if !drainTimer.Stop() {
+					<-drainTimer.C
+				}
+
+				drainTimer.Reset(drainTimeout)
+			}
+		}
+
+		if webhook == nil {
+			continue
+		}
+
+		webhookEvent, ok := newLifecycleWebhookEvent(event)
+		if !ok {
+			continue
+		}
+
+		webhookEvent.Node = hostname
+
+		if err := ctrl.deliver(ctx, webhook, webhookEvent); err != nil {
+			logger.Warn("failed to deliver lifecycle event", zap.String("event", webhookEvent.Event), zap.String("action", webhookEvent.Action), zap.Error(err))
+
+			continue
+		}
+
+		logger.Debug("delivered lifecycle event", zap.String("event", webhookEvent.Event), zap.String("action", webhookEvent.Action))
+	}
+}
+
+func (ctrl *LifecycleWebhookController) deliver(ctx context.Context, webhook talosconfig.LifecycleWebhook, event lifecycleWebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Endpoint().String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, webhook.Secret())
+	mac.Write(body) //nolint:errcheck
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(lifecycleWebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := ctrl.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body) //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+
+	return nil
+}
+
+// newLifecycleWebhookEvent converts machined event to the webhook event, it returns false if the event is not a lifecycle one.
+func newLifecycleWebhookEvent(event runtime.EventInfo) (lifecycleWebhookEvent, bool) {
+	webhookEvent := lifecycleWebhookEvent{
+		ID:   event.ID.String(),
+		Time: event.ID.Time().UTC(),
+	}
+
+	switch payload := event.Payload.(type) {
+	case *machine.ConfigAppliedEvent:
+		webhookEvent.Event = "configApplied"
+		webhookEvent.Action = payload.Mode
+	case *machine.SequenceEvent:
+		switch payload.Sequence {
+		case runtime.SequenceUpgrade.String(), runtime.SequenceStageUpgrade.String():
+			webhookEvent.Event = "upgrade"
+		case runtime.SequenceReset.String():
+			webhookEvent.Event = "reset"
+		case runtime.SequenceReboot.String():
+			webhookEvent.Event = "reboot"
+		case runtime.SequenceShutdown.String():
+			webhookEvent.Event = "shutdown"
+		default:
+			return webhookEvent, false
+		}
+
+		switch {
+		case payload.Error != nil:
+			// locked sequence was never started
+			if payload.Error.Code == common.Code_LOCKED {
+				return webhookEvent, false
+			}
+
+			webhookEvent.Action = "failed"
+			webhookEvent.Error = payload.Error.Message
+		case payload.Action == machine.SequenceEvent_START:
+			webhookEvent.Action = "started"
+		case payload.Action == machine.SequenceEvent_STOP:
+			webhookEvent.Action = "stopped"
+		default:
+			return webhookEvent, false
+		}
+	default:
+		return webhookEvent, false
+	}
+
+	return webhookEvent, true
+}
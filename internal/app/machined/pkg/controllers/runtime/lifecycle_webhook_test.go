@@ -0,0 +1,227 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	controllerruntime "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	talosruntime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	v1alpha1cfg "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+type LifecycleWebhookSuite struct {
+	suite.Suite
+
+	events *v1alpha1.Events
+	state  state.State
+	server *httptest.Server
+
+	requestsMu sync.Mutex
+	requests   []map[string]interface{}
+
+	runtime *runtime.Runtime
+	drainer *talosruntime.Drainer
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *LifecycleWebhookSuite) SetupTest() {
+	suite.events = v1alpha1.NewEvents(1000, 10)
+
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.requests = nil
+
+	suite.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		suite.Assert().NoError(err)
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body) //nolint:errcheck
+
+		if req.Header.Get("X-Talos-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		var event map[string]interface{}
+
+		suite.Assert().NoError(json.Unmarshal(body, &event))
+
+		suite.requestsMu.Lock()
+		suite.requests = append(suite.requests, event)
+		suite.requestsMu.Unlock()
+	}))
+
+	suite.drainer = talosruntime.NewDrainer()
+
+	suite.Require().NoError(suite.runtime.RegisterController(&controllerruntime.LifecycleWebhookController{
+		V1Alpha1Events: suite.events,
+		Drainer:        suite.drainer,
+		Client:         suite.server.Client(),
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *LifecycleWebhookSuite) received() []map[string]interface{} {
+	suite.requestsMu.Lock()
+	defer suite.requestsMu.Unlock()
+
+	return append([]map[string]interface{}(nil), suite.requests...)
+}
+
+func (suite *LifecycleWebhookSuite) TestDeliver() {
+	endpoint, err := url.Parse(suite.server.URL)
+	suite.Require().NoError(err)
+
+	cfg := config.NewMachineConfig(&v1alpha1cfg.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1cfg.MachineConfig{
+			MachineLifecycleWebhook: &v1alpha1cfg.LifecycleWebhookConfig{
+				WebhookEndpoint: &v1alpha1cfg.Endpoint{URL: endpoint},
+				WebhookSecret:   "secret",
+			},
+		},
+		ClusterConfig: &v1alpha1cfg.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	// events published before the controller picks up the config are not delivered
+	suite.Require().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		if len(suite.received()) > 0 {
+			return nil
+		}
+
+		suite.events.Publish(&machine.ConfigAppliedEvent{
+			Mode: "immediate",
+		})
+
+		return retry.ExpectedErrorf("no events delivered")
+	}))
+
+	suite.Assert().Equal("configApplied", suite.received()[0]["event"])
+	suite.Assert().Equal("immediate", suite.received()[0]["action"])
+
+	// wait for the in-flight config applied events
+	time.Sleep(500 * time.Millisecond)
+
+	delivered := len(suite.received())
+
+	suite.events.Publish(&machine.SequenceEvent{
+		Sequence: talosruntime.SequenceUpgrade.String(),
+		Action:   machine.SequenceEvent_START,
+	})
+	suite.events.Publish(&machine.PhaseEvent{
+		Phase:  "upgrade",
+		Action: machine.PhaseEvent_START,
+	})
+	suite.events.Publish(&machine.SequenceEvent{
+		Sequence: talosruntime.SequenceBoot.String(),
+		Action:   machine.SequenceEvent_START,
+	})
+	suite.events.Publish(&machine.SequenceEvent{
+		Sequence: talosruntime.SequenceUpgrade.String(),
+		Action:   machine.SequenceEvent_STOP,
+	})
+	suite.events.Publish(&machine.SequenceEvent{
+		Sequence: talosruntime.SequenceReset.String(),
+		Action:   machine.SequenceEvent_NOOP,
+		Error: &common.Error{
+			Code:    common.Code_FATAL,
+			Message: "sequence failed",
+		},
+	})
+
+	suite.Require().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		if len(suite.received()) < delivered+3 {
+			return retry.ExpectedErrorf("expected %d events, got %d", delivered+3, len(suite.received()))
+		}
+
+		return nil
+	}))
+
+	events := suite.received()[delivered:]
+	suite.Require().Len(events, 3)
+
+	for i, expected := range []struct {
+		event, action, err string
+	}{
+		{"upgrade", "started", ""},
+		{"upgrade", "stopped", ""},
+		{"reset", "failed", "sequence failed"},
+	} {
+		suite.Assert().Equal(expected.event, events[i]["event"])
+		suite.Assert().Equal(expected.action, events[i]["action"])
+
+		if expected.err != "" {
+			suite.Assert().Equal(expected.err, events[i]["error"])
+		}
+	}
+}
+
+func (suite *LifecycleWebhookSuite) TestDrain() {
+	c, abort := context.WithTimeout(suite.ctx, 5*time.Second)
+	defer abort()
+
+	// no webhook configured, drain completes after the drain timeout
+	suite.Require().NoError(suite.drainer.Drain(c))
+}
+
+func (suite *LifecycleWebhookSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+
+	suite.server.Close()
+}
+
+func TestLifecycleWebhookSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleWebhookSuite))
+}
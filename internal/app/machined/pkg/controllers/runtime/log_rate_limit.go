@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+const logRateLimitStatusUpdateInterval = 30 * time.Second
+
+// LogRateLimitController applies log rate limits from the machine configuration and publishes rate limiting status.
+type LogRateLimitController struct {
+	LoggingManager v1alpha1runtime.LoggingManager
+
+	// UpdateInterval defaults to 30 seconds.
+	UpdateInterval time.Duration
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *LogRateLimitController) Name() string {
+	return "runtime.LogRateLimitController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *LogRateLimitController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        pointer.ToString(config.V1Alpha1ID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *LogRateLimitController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.LogRateLimitStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *LogRateLimitController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.UpdateInterval == 0 {
+		ctrl.UpdateInterval = logRateLimitStatusUpdateInterval
+	}
+
+	ticker := time.NewTicker(ctrl.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+			if err := ctrl.updateLimits(ctx, r); err != nil {
+				return err
+			}
+		case <-ticker.C:
+		}
+
+		if err := ctrl.updateStatus(ctx, r); err != nil {
+			return err
+		}
+	}
+}
+
+func (ctrl *LogRateLimitController) updateLimits(ctx context.Context, r controller.Runtime) error {
+	cfg, err := r.Get(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, resource.VersionUndefined))
+	if err != nil && !state.IsNotFoundError(err) {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	defaultLimit := logging.DefaultRateLimit
+	limits := map[string]v1alpha1runtime.LogRateLimit{}
+
+	if cfg != nil {
+		for _, limit := range cfg.(*config.MachineConfig).Config().Machine().Logging().RateLimits() {
+			l := v1alpha1runtime.LogRateLimit{
+				Burst: limit.Burst(),
+				Rate:  limit.Rate(),
+			}
+
+			if limit.Service() == "*" {
+				defaultLimit = l
+			} else {
+				limits[limit.Service()] = l
+			}
+		}
+	}
+
+	ctrl.LoggingManager.SetRateLimits(defaultLimit, limits)
+
+	return nil
+}
+
+func (ctrl *LogRateLimitController) updateStatus(ctx context.Context, r controller.Runtime) error {
+	touchedIDs := make(map[resource.ID]struct{})
+
+	for id, status := range ctrl.LoggingManager.RateLimitStatus() {
+		status := status
+
+		if err := r.Modify(ctx, runtime.NewLogRateLimitStatus(id), func(res resource.Resource) error {
+			*res.(*runtime.LogRateLimitStatus).TypedSpec() = runtime.LogRateLimitStatusSpec{
+				Burst:        status.Burst,
+				Rate:         status.Rate,
+				DroppedLines: status.DroppedLines,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating log rate limit status: %w", err)
+		}
+
+		touchedIDs[id] = struct{}{}
+	}
+
+	list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.LogRateLimitStatusType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing resources: %w", err)
+	}
+
+	for _, res := range list.Items {
+		if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+			if err = r.Destroy(ctx, res.Metadata()); err != nil {
+				return fmt.Errorf("error cleaning up log rate limit status: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
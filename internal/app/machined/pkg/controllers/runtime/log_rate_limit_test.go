@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	runtimelogging "github.com/talos-systems/talos/internal/app/machined/pkg/runtime/logging"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type LogRateLimitSuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	loggingManager *runtimelogging.CircularBufferLoggingManager
+}
+
+func (suite *LogRateLimitSuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.loggingManager = runtimelogging.NewCircularBufferLoggingManager(log.New(ioutil.Discard, "", 0))
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.LogRateLimitController{
+		LoggingManager: suite.loggingManager,
+		UpdateInterval: 100 * time.Millisecond,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *LogRateLimitSuite) assertStatus(id string, expected runtimeresource.LogRateLimitStatusSpec) {
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, *runtimeresource.NewLogRateLimitStatus(id).Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			if spec := *r.(*runtimeresource.LogRateLimitStatus).TypedSpec(); spec != expected {
+				return retry.ExpectedError(fmt.Errorf("unexpected status %+v", spec))
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *LogRateLimitSuite) TestReconcile() {
+	w, err := suite.loggingManager.ServiceLog("kubelet").Writer()
+	suite.Require().NoError(err)
+
+	_, err = suite.loggingManager.ServiceLog("apid").Writer()
+	suite.Require().NoError(err)
+
+	suite.assertStatus("kubelet", runtimeresource.LogRateLimitStatusSpec{
+		Burst: runtimelogging.DefaultRateLimit.Burst,
+		Rate:  runtimelogging.DefaultRateLimit.Rate,
+	})
+
+	cfg := config.NewMachineConfig(&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineLogging: &v1alpha1.LoggingConfig{
+				LoggingRateLimits: []v1alpha1.LoggingRateLimit{
+					{
+						LoggingService: "kubelet",
+						LoggingBurst:   1,
+						LoggingRate:    1,
+					},
+					{
+						LoggingService: "*",
+						LoggingBurst:   100,
+						LoggingRate:    10,
+					},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{},
+	})
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, cfg))
+
+	suite.assertStatus("apid", runtimeresource.LogRateLimitStatusSpec{
+		Burst: 100,
+		Rate:  10,
+	})
+
+	_, err = w.Write([]byte("line 1\nline 2\nline 3\n"))
+	suite.Require().NoError(err)
+
+	suite.assertStatus("kubelet", runtimeresource.LogRateLimitStatusSpec{
+		Burst:        1,
+		Rate:         1,
+		DroppedLines: 2,
+	})
+}
+
+func (suite *LogRateLimitSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestLogRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(LogRateLimitSuite))
+}
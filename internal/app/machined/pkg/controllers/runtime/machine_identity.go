@@ -0,0 +1,170 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/google/uuid"
+	"github.com/talos-systems/go-smbios/smbios"
+	"go.uber.org/zap"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/cluster"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// Machine identity sources.
+const (
+	MachineIdentitySourceSMBIOS = "smbios"
+	MachineIdentitySourceNodeID = "node-id"
+)
+
+// MachineIdentityController computes stable machine identity from the hardware UUID or node identity.
+type MachineIdentityController struct {
+	// UUIDReader reads machine hardware UUID, defaults to reading SMBIOS.
+	UUIDReader func() (uuid.UUID, error)
+
+	machineUUID string
+	uuidRead    bool
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *MachineIdentityController) Name() string {
+	return "runtime.MachineIdentityController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *MachineIdentityController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: cluster.NamespaceName,
+			Type:      cluster.IdentityType,
+			ID:        pointer.ToString(cluster.LocalIdentity),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *MachineIdentityController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.MachineIdentityType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *MachineIdentityController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.UUIDReader == nil {
+		ctrl.UUIDReader = readSMBIOSUUID
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		if !ctrl.uuidRead {
+			ctrl.uuidRead = true
+
+			machineUUID, err := ctrl.UUIDReader()
+			if err == nil {
+				err = validateMachineUUID(machineUUID)
+			}
+
+			if err != nil {
+				logger.Info("machine UUID is not usable, falling back to node identity", zap.Error(err))
+			} else {
+				ctrl.machineUUID = machineUUID.String()
+			}
+		}
+
+		machineID, source := ctrl.machineUUID, MachineIdentitySourceSMBIOS
+
+		if machineID == "" {
+			identity, err := r.Get(ctx, resource.NewMetadata(cluster.NamespaceName, cluster.IdentityType, cluster.LocalIdentity, resource.VersionUndefined))
+			if err != nil {
+				if !state.IsNotFoundError(err) {
+					return fmt.Errorf("error getting node identity: %w", err)
+				}
+
+				if err = r.Destroy(ctx, runtime.NewMachineIdentity().Metadata()); err != nil && !state.IsNotFoundError(err) {
+					return fmt.Errorf("error cleaning up machine identity: %w", err)
+				}
+
+				continue
+			}
+
+			machineID, source = identity.(*cluster.Identity).TypedSpec().NodeID, MachineIdentitySourceNodeID
+		}
+
+		if err := r.Modify(ctx, runtime.NewMachineIdentity(), func(r resource.Resource) error {
+			*r.(*runtime.MachineIdentity).TypedSpec() = runtime.MachineIdentitySpec{
+				MachineID:      machineID,
+				Source:         source,
+				StableHostname: StableHostname(machineID),
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating machine identity: %w", err)
+		}
+	}
+}
+
+// StableHostname derives a hostname from the machine ID.
+//
+// Hostname has the form of `talos-xxxxxxxx`, where `xxxxxxxx` is base36 encoding of the machine ID hash.
+func StableHostname(machineID string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(machineID)))
+
+	// 40 bits of the hash fit into 8 base36 digits
+	v := binary.BigEndian.Uint64(sum[:8]) >> 24
+
+	return fmt.Sprintf("talos-%08s", strconv.FormatUint(v, 36))
+}
+
+func readSMBIOSUUID() (uuid.UUID, error) {
+	s, err := smbios.New()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.SystemInformation().UUID()
+}
+
+func validateMachineUUID(machineUUID uuid.UUID) error {
+	if machineUUID == uuid.Nil {
+		return fmt.Errorf("machine UUID is not populated")
+	}
+
+	id := machineUUID.String()
+
+	// primitive entropy check, some vendors fill the UUID with the same value
+	counts := map[rune]int{}
+
+	for _, s := range id {
+		counts[s]++
+
+		if counts[s] > len(id)/2 {
+			return fmt.Errorf("machine UUID %s entropy check failed", id)
+		}
+	}
+
+	return nil
+}
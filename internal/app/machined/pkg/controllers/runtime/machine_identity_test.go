@@ -0,0 +1,169 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/cluster"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type MachineIdentitySuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *MachineIdentitySuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+}
+
+func (suite *MachineIdentitySuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *MachineIdentitySuite) assertIdentity(expected runtimeresource.MachineIdentitySpec) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, runtimeresource.NewMachineIdentity().Metadata())
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		if spec := *r.(*runtimeresource.MachineIdentity).TypedSpec(); spec != expected {
+			return retry.ExpectedErrorf("expected %v, got %v", expected, spec)
+		}
+
+		return nil
+	}
+}
+
+func (suite *MachineIdentitySuite) TestSMBIOS() {
+	machineUUID := uuid.MustParse("4c4c4544-0047-3610-8053-b4c04f4d4e32")
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.MachineIdentityController{
+		UUIDReader: func() (uuid.UUID, error) {
+			return machineUUID, nil
+		},
+	}))
+
+	suite.startRuntime()
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertIdentity(runtimeresource.MachineIdentitySpec{
+			MachineID:      machineUUID.String(),
+			Source:         runtimecontrollers.MachineIdentitySourceSMBIOS,
+			StableHostname: runtimecontrollers.StableHostname(machineUUID.String()),
+		}),
+	))
+}
+
+func (suite *MachineIdentitySuite) TestNodeIDFallback() {
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.MachineIdentityController{
+		UUIDReader: func() (uuid.UUID, error) {
+			return uuid.MustParse("03000200-0400-0500-0006-000700080009"), nil
+		},
+	}))
+
+	suite.startRuntime()
+
+	identity := cluster.NewIdentity(cluster.NamespaceName, cluster.LocalIdentity)
+	identity.TypedSpec().NodeID = "7x1SuC8Ege5BGXdAfTEff5iQnlWZLfv9h1LGMxA2pYkC"
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, identity))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertIdentity(runtimeresource.MachineIdentitySpec{
+			MachineID:      identity.TypedSpec().NodeID,
+			Source:         runtimecontrollers.MachineIdentitySourceNodeID,
+			StableHostname: runtimecontrollers.StableHostname(identity.TypedSpec().NodeID),
+		}),
+	))
+
+	suite.Require().NoError(suite.state.Destroy(suite.ctx, identity.Metadata()))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			_, err := suite.state.Get(suite.ctx, runtimeresource.NewMachineIdentity().Metadata())
+			if err == nil {
+				return retry.ExpectedErrorf("machine identity still exists")
+			}
+
+			if state.IsNotFoundError(err) {
+				return nil
+			}
+
+			return err
+		},
+	))
+}
+
+func (suite *MachineIdentitySuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestMachineIdentitySuite(t *testing.T) {
+	suite.Run(t, new(MachineIdentitySuite))
+}
+
+func TestStableHostname(t *testing.T) {
+	re := regexp.MustCompile(`^talos-[0-9a-z]{8}$`)
+
+	for _, machineID := range []string{"", "4c4c4544-0047-3610-8053-b4c04f4d4e32", "7x1SuC8Ege5BGXdAfTEff5iQnlWZLfv9h1LGMxA2pYkC"} {
+		hostname := runtimecontrollers.StableHostname(machineID)
+
+		assert.Regexp(t, re, hostname, fmt.Sprintf("machine ID %q", machineID))
+		assert.Equal(t, hostname, runtimecontrollers.StableHostname(machineID))
+	}
+
+	assert.Equal(t,
+		runtimecontrollers.StableHostname("4c4c4544-0047-3610-8053-b4c04f4d4e32"),
+		runtimecontrollers.StableHostname("4C4C4544-0047-3610-8053-B4C04F4D4E32"),
+	)
+}
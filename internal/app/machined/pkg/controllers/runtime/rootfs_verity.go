@@ -0,0 +1,124 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/talos-systems/go-procfs/procfs"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/verity"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+const rootfsVerityStatusUpdateInterval = time.Minute
+
+// RootfsVerityController publishes dm-verity verification status of the rootfs.
+type RootfsVerityController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+	Cmdline      *procfs.Cmdline
+
+	// Status defaults to the status of the constants.RootfsVerityDeviceName device.
+	Status func() (*verity.Status, error)
+
+	// UpdateInterval defaults to 1 minute.
+	UpdateInterval time.Duration
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *RootfsVerityController) Name() string {
+	return "runtime.RootfsVerityController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *RootfsVerityController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *RootfsVerityController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.RootfsVerityStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *RootfsVerityController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	// rootfs is not mounted by Talos in container mode
+	if ctrl.V1Alpha1Mode == v1alpha1runtime.ModeContainer {
+		return nil
+	}
+
+	if ctrl.Status == nil {
+		ctrl.Status = func() (*verity.Status, error) {
+			return verity.DeviceStatus(constants.RootfsVerityDeviceName)
+		}
+	}
+
+	if ctrl.UpdateInterval == 0 {
+		ctrl.UpdateInterval = rootfsVerityStatusUpdateInterval
+	}
+
+	ticker := time.NewTicker(ctrl.UpdateInterval)
+	defer ticker.Stop()
+
+	corruptionReported, unverifiedReported := false, false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		var rootHash string
+
+		if ctrl.Cmdline != nil {
+			if val := ctrl.Cmdline.Get(constants.KernelParamVerityRootHash).First(); val != nil {
+				rootHash = *val
+			}
+		}
+
+		status, err := ctrl.Status()
+		if err != nil {
+			return fmt.Errorf("error getting rootfs verity status: %w", err)
+		}
+
+		if status == nil && !unverifiedReported {
+			logger.Warn("rootfs is mounted without dm-verity integrity verification")
+
+			unverifiedReported = true
+		}
+
+		if status != nil && status.Corrupted && !corruptionReported {
+			logger.Error("rootfs corruption detected by dm-verity")
+
+			corruptionReported = true
+		}
+
+		if err = r.Modify(ctx, runtime.NewRootfsVerityStatus(), func(res resource.Resource) error {
+			*res.(*runtime.RootfsVerityStatus).TypedSpec() = runtime.RootfsVerityStatusSpec{
+				Enabled:   status != nil,
+				RootHash:  rootHash,
+				Corrupted: status != nil && status.Corrupted,
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error updating rootfs verity status: %w", err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-procfs/procfs"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/verity"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type RootfsVeritySuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	mu     sync.Mutex
+	status *verity.Status
+}
+
+func (suite *RootfsVeritySuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.setStatus(nil)
+}
+
+func (suite *RootfsVeritySuite) startRuntime(cmdline string) {
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.RootfsVerityController{
+		V1Alpha1Mode: v1alpha1runtime.ModeMetal,
+		Cmdline:      procfs.NewCmdline(cmdline),
+		Status: func() (*verity.Status, error) {
+			suite.mu.Lock()
+			defer suite.mu.Unlock()
+
+			return suite.status, nil
+		},
+		UpdateInterval: 100 * time.Millisecond,
+	}))
+
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *RootfsVeritySuite) setStatus(status *verity.Status) {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+
+	suite.status = status
+}
+
+func (suite *RootfsVeritySuite) assertStatus(expected runtimeresource.RootfsVerityStatusSpec) {
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, *runtimeresource.NewRootfsVerityStatus().Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			if spec := *r.(*runtimeresource.RootfsVerityStatus).TypedSpec(); spec != expected {
+				return retry.ExpectedError(fmt.Errorf("unexpected status %+v", spec))
+			}
+
+			return nil
+		},
+	))
+}
+
+func (suite *RootfsVeritySuite) TestDisabled() {
+	suite.startRuntime("talos.platform=metal")
+
+	suite.assertStatus(runtimeresource.RootfsVerityStatusSpec{})
+}
+
+func (suite *RootfsVeritySuite) TestCorrupted() {
+	suite.setStatus(&verity.Status{})
+
+	suite.startRuntime(fmt.Sprintf("talos.platform=metal %s=abcd", constants.KernelParamVerityRootHash))
+
+	suite.assertStatus(runtimeresource.RootfsVerityStatusSpec{
+		Enabled:  true,
+		RootHash: "abcd",
+	})
+
+	suite.setStatus(&verity.Status{Corrupted: true})
+
+	suite.assertStatus(runtimeresource.RootfsVerityStatusSpec{
+		Enabled:   true,
+		RootHash:  "abcd",
+		Corrupted: true,
+	})
+}
+
+func (suite *RootfsVeritySuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestRootfsVeritySuite(t *testing.T) {
+	suite.Run(t, new(RootfsVeritySuite))
+}
@@ -0,0 +1,147 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/AlekSi/pointer"
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/upgrade"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+	"github.com/talos-systems/talos/pkg/version"
+)
+
+// UpgradeHistoryController loads install and upgrade history from the STATE and resolves pending entries.
+type UpgradeHistoryController struct {
+	V1Alpha1Mode v1alpha1runtime.Mode
+	StatePath    string
+
+	// Version of the running Talos, defaults to version.Tag.
+	Version string
+
+	resolved bool
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *UpgradeHistoryController) Name() string {
+	return "runtime.UpgradeHistoryController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *UpgradeHistoryController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: v1alpha1.NamespaceName,
+			Type:      runtime.MountStatusType,
+			ID:        pointer.ToString(constants.StatePartitionLabel),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *UpgradeHistoryController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.UpgradeHistoryType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+//
+//nolint:gocyclo
+func (ctrl *UpgradeHistoryController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	// there are no upgrades in container mode
+	if ctrl.V1Alpha1Mode == v1alpha1runtime.ModeContainer {
+		return nil
+	}
+
+	if ctrl.StatePath == "" {
+		ctrl.StatePath = constants.StateMountPoint
+	}
+
+	if ctrl.Version == "" {
+		ctrl.Version = version.Tag
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		if _, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, runtime.MountStatusType, constants.StatePartitionLabel, resource.VersionUndefined)); err != nil {
+			if state.IsNotFoundError(err) {
+				// wait for the STATE to be mounted
+				continue
+			}
+
+			return fmt.Errorf("error reading mount status: %w", err)
+		}
+
+		path := filepath.Join(ctrl.StatePath, constants.UpgradeHistoryFilename)
+
+		history, err := upgrade.LoadHistory(path)
+		if err != nil {
+			return err
+		}
+
+		// pending entries are resolved once per boot, as new pending entries might be recorded later on
+		if !ctrl.resolved {
+			if history.Resolve(ctrl.Version) {
+				if err = history.Save(path); err != nil {
+					return err
+				}
+
+				logger.Info("upgrade history updated", zap.String("version", ctrl.Version))
+			}
+
+			ctrl.resolved = true
+		}
+
+		touchedIDs := make(map[resource.ID]struct{}, len(history.Entries))
+
+		for _, entry := range history.Entries {
+			entry := entry
+			id := entry.Timestamp.UTC().Format("20060102-150405")
+
+			if err = r.Modify(ctx, runtime.NewUpgradeHistory(id), func(res resource.Resource) error {
+				*res.(*runtime.UpgradeHistory).TypedSpec() = entry
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating upgrade history: %w", err)
+			}
+
+			touchedIDs[id] = struct{}{}
+		}
+
+		list, err := r.List(ctx, resource.NewMetadata(runtime.NamespaceName, runtime.UpgradeHistoryType, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing resources: %w", err)
+		}
+
+		for _, res := range list.Items {
+			if _, ok := touchedIDs[res.Metadata().ID()]; !ok {
+				if err = r.Destroy(ctx, res.Metadata()); err != nil {
+					return fmt.Errorf("error cleaning up upgrade history: %w", err)
+				}
+			}
+		}
+	}
+}
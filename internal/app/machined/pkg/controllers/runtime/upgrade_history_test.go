@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	talosruntime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/upgrade"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+type UpgradeHistorySuite struct {
+	suite.Suite
+
+	state state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	statePath string
+}
+
+func (suite *UpgradeHistorySuite) SetupTest() {
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.statePath = suite.T().TempDir()
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.UpgradeHistoryController{
+		V1Alpha1Mode: talosruntime.ModeMetal,
+		StatePath:    suite.statePath,
+		Version:      "v0.14.0",
+	}))
+
+	suite.startRuntime()
+}
+
+func (suite *UpgradeHistorySuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *UpgradeHistorySuite) TestResolve() {
+	path := filepath.Join(suite.statePath, constants.UpgradeHistoryFilename)
+
+	history := &upgrade.History{}
+	history.Append(runtimeresource.UpgradeHistorySpec{
+		Action:    runtimeresource.UpgradeActionInstall,
+		ToVersion: "v0.13.0",
+		Timestamp: time.Date(2021, 11, 1, 10, 0, 0, 0, time.UTC),
+		Outcome:   runtimeresource.UpgradeOutcomeSuccess,
+	})
+	history.Append(runtimeresource.UpgradeHistorySpec{
+		Action:      runtimeresource.UpgradeActionUpgrade,
+		FromVersion: "v0.13.0",
+		Image:       "ghcr.io/talos-systems/installer:v0.14.0",
+		Timestamp:   time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC),
+		Outcome:     runtimeresource.UpgradeOutcomePending,
+	})
+	suite.Require().NoError(history.Save(path))
+
+	// history is not loaded until STATE is mounted
+	time.Sleep(time.Second)
+
+	list, err := suite.state.List(suite.ctx, resource.NewMetadata(runtimeresource.NamespaceName, runtimeresource.UpgradeHistoryType, "", resource.VersionUndefined))
+	suite.Require().NoError(err)
+	suite.Assert().Empty(list.Items)
+
+	suite.Require().NoError(suite.state.Create(suite.ctx, runtimeresource.NewMountStatus(v1alpha1.NamespaceName, constants.StatePartitionLabel)))
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			r, err := suite.state.Get(suite.ctx, runtimeresource.NewUpgradeHistory("20211201-100000").Metadata())
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			spec := r.(*runtimeresource.UpgradeHistory).TypedSpec()
+
+			suite.Assert().Equal(runtimeresource.UpgradeOutcomeSuccess, spec.Outcome)
+			suite.Assert().Equal("v0.14.0", spec.ToVersion)
+
+			return nil
+		},
+	))
+
+	_, err = suite.state.Get(suite.ctx, runtimeresource.NewUpgradeHistory("20211101-100000").Metadata())
+	suite.Assert().NoError(err)
+
+	// resolved history is persisted
+	history, err = upgrade.LoadHistory(path)
+	suite.Require().NoError(err)
+	suite.Require().Len(history.Entries, 2)
+	suite.Assert().Equal(runtimeresource.UpgradeOutcomeSuccess, history.Entries[1].Outcome)
+}
+
+func (suite *UpgradeHistorySuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestUpgradeHistorySuite(t *testing.T) {
+	suite.Run(t, new(UpgradeHistorySuite))
+}
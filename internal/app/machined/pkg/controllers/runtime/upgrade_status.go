@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"go.uber.org/zap"
+
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+	"github.com/talos-systems/talos/pkg/version"
+)
+
+// UpgradeStatusController tracks upgrade sequence progress via machined events.
+type UpgradeStatusController struct {
+	V1Alpha1Events v1alpha1runtime.Watcher
+
+	// Version of the running Talos, defaults to version.Tag.
+	Version string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *UpgradeStatusController) Name() string {
+	return "runtime.UpgradeStatusController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *UpgradeStatusController) Inputs() []controller.Input {
+	return nil
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *UpgradeStatusController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtime.UpgradeStatusType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *UpgradeStatusController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.Version == "" {
+		ctrl.Version = version.Tag
+	}
+
+	errCh := make(chan error, 1)
+
+	// replay all buffered events to catch up with the upgrade which might be already in progress
+	if err := ctrl.V1Alpha1Events.Watch(func(eventCh <-chan v1alpha1runtime.EventInfo) {
+		errCh <- ctrl.handleEvents(ctx, r, eventCh)
+	}, v1alpha1runtime.WithTailEvents(-1)); err != nil {
+		return err
+	}
+
+	return <-errCh
+}
+
+func (ctrl *UpgradeStatusController) handleEvents(ctx context.Context, r controller.Runtime, eventCh <-chan v1alpha1runtime.EventInfo) error {
+	var sequence string
+
+	for {
+		var event v1alpha1runtime.EventInfo
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+			continue
+		case event = <-eventCh:
+		}
+
+		switch payload := event.Payload.(type) {
+		case *machine.SequenceEvent:
+			if payload.Sequence != v1alpha1runtime.SequenceUpgrade.String() && payload.Sequence != v1alpha1runtime.SequenceStageUpgrade.String() {
+				continue
+			}
+
+			switch payload.Action { //nolint:exhaustive
+			case machine.SequenceEvent_START:
+				sequence = payload.Sequence
+
+				if err := r.Modify(ctx, runtime.NewUpgradeStatus(), func(res resource.Resource) error {
+					*res.(*runtime.UpgradeStatus).TypedSpec() = runtime.UpgradeStatusSpec{
+						Sequence:    payload.Sequence,
+						FromVersion: ctrl.Version,
+						Started:     event.ID.Time().UTC().Truncate(time.Second),
+					}
+
+					return nil
+				}); err != nil {
+					return fmt.Errorf("error updating upgrade status: %w", err)
+				}
+			case machine.SequenceEvent_STOP:
+				sequence = ""
+
+				if err := r.Destroy(ctx, runtime.NewUpgradeStatus().Metadata()); err != nil && !state.IsNotFoundError(err) {
+					return fmt.Errorf("error cleaning up upgrade status: %w", err)
+				}
+			}
+		case *machine.PhaseEvent:
+			if sequence == "" || payload.Action != machine.PhaseEvent_START {
+				continue
+			}
+
+			if err := r.Modify(ctx, runtime.NewUpgradeStatus(), func(res resource.Resource) error {
+				res.(*runtime.UpgradeStatus).TypedSpec().Phase = payload.Phase
+
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error updating upgrade status: %w", err)
+			}
+		}
+	}
+}
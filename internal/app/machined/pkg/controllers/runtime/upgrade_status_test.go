@@ -0,0 +1,156 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime_test
+
+import (
+	"context"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/suite"
+	"github.com/talos-systems/go-retry/retry"
+
+	runtimecontrollers "github.com/talos-systems/talos/internal/app/machined/pkg/controllers/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1"
+	"github.com/talos-systems/talos/pkg/logging"
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	runtimeresource "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+type UpgradeStatusSuite struct {
+	suite.Suite
+
+	events *v1alpha1.Events
+	state  state.State
+
+	runtime *runtime.Runtime
+	wg      sync.WaitGroup
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+func (suite *UpgradeStatusSuite) SetupTest() {
+	suite.events = v1alpha1.NewEvents(1000, 10)
+
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 3*time.Minute)
+
+	suite.state = state.WrapCore(namespaced.NewState(inmem.Build))
+
+	var err error
+
+	suite.runtime, err = runtime.NewRuntime(suite.state, logging.Wrap(log.Writer()))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.runtime.RegisterController(&runtimecontrollers.UpgradeStatusController{
+		V1Alpha1Events: suite.events,
+		Version:        "v0.13.0",
+	}))
+
+	suite.startRuntime()
+}
+
+func (suite *UpgradeStatusSuite) startRuntime() {
+	suite.wg.Add(1)
+
+	go func() {
+		defer suite.wg.Done()
+
+		suite.Assert().NoError(suite.runtime.Run(suite.ctx))
+	}()
+}
+
+func (suite *UpgradeStatusSuite) assertStatus(phase string) func() error {
+	return func() error {
+		r, err := suite.state.Get(suite.ctx, runtimeresource.NewUpgradeStatus().Metadata())
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				return retry.ExpectedError(err)
+			}
+
+			return err
+		}
+
+		spec := r.(*runtimeresource.UpgradeStatus).TypedSpec()
+
+		if spec.Phase != phase {
+			return retry.ExpectedErrorf("expected phase %q, got %q", phase, spec.Phase)
+		}
+
+		suite.Assert().Equal("upgrade", spec.Sequence)
+		suite.Assert().Equal("v0.13.0", spec.FromVersion)
+		suite.Assert().False(spec.Started.IsZero())
+
+		return nil
+	}
+}
+
+func (suite *UpgradeStatusSuite) TestUpgrade() {
+	// events of other sequences are ignored
+	suite.events.Publish(&machine.SequenceEvent{
+		Sequence: "boot",
+		Action:   machine.SequenceEvent_START,
+	})
+	suite.events.Publish(&machine.PhaseEvent{
+		Phase:  "udevSetup",
+		Action: machine.PhaseEvent_START,
+	})
+
+	suite.events.Publish(&machine.SequenceEvent{
+		Sequence: "upgrade",
+		Action:   machine.SequenceEvent_START,
+	})
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus(""),
+	))
+
+	suite.events.Publish(&machine.PhaseEvent{
+		Phase:  "drain",
+		Action: machine.PhaseEvent_START,
+	})
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		suite.assertStatus("drain"),
+	))
+
+	suite.events.Publish(&machine.SequenceEvent{
+		Sequence: "upgrade",
+		Action:   machine.SequenceEvent_STOP,
+	})
+
+	suite.Assert().NoError(retry.Constant(3*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			_, err := suite.state.Get(suite.ctx, runtimeresource.NewUpgradeStatus().Metadata())
+			if err == nil {
+				return retry.ExpectedErrorf("upgrade status still exists")
+			}
+
+			if state.IsNotFoundError(err) {
+				return nil
+			}
+
+			return err
+		},
+	))
+}
+
+func (suite *UpgradeStatusSuite) TearDownTest() {
+	suite.T().Log("tear down")
+
+	suite.ctxCancel()
+
+	suite.wg.Wait()
+}
+
+func TestUpgradeStatusSuite(t *testing.T) {
+	suite.Run(t, new(UpgradeStatusSuite))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/talos-systems/crypto/x509"
 	"go.uber.org/zap"
 
+	v1alpha1runtime "github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/grpc/gen"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/resources/config"
@@ -29,7 +30,10 @@ import (
 )
 
 // APIController manages secrets.API based on configuration to provide apid certificate.
-type APIController struct{}
+type APIController struct {
+	// V1alpha1Platform provides the instance identity document for the trustd requests (if supported by the platform).
+	V1alpha1Platform v1alpha1runtime.Platform
+}
 
 // Name implements controller.Controller interface.
 func (ctrl *APIController) Name() string {
@@ -278,18 +282,26 @@ func (ctrl *APIController) generateControlPlane(ctx context.Context, r controlle
 		return fmt.Errorf("failed to parse CA certificate: %w", err)
 	}
 
-	serverCert, err := x509.NewKeyPair(ca,
-		x509.IPAddresses(certSANs.StdIPs()),
-		x509.DNSNames(certSANs.DNSNames),
-		x509.CommonName(certSANs.FQDN),
-		x509.NotAfter(time.Now().Add(x509.DefaultCertificateValidityDuration)),
-		x509.KeyUsage(stdlibx509.KeyUsageDigitalSignature|stdlibx509.KeyUsageKeyEncipherment),
-		x509.ExtKeyUsage([]stdlibx509.ExtKeyUsage{
-			stdlibx509.ExtKeyUsageServerAuth,
-		}),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to generate API server cert: %w", err)
+	serverCert := rootSpec.APIServer
+
+	if serverCert == nil {
+		var serverKeyPair *x509.KeyPair
+
+		serverKeyPair, err = x509.NewKeyPair(ca,
+			x509.IPAddresses(certSANs.StdIPs()),
+			x509.DNSNames(certSANs.DNSNames),
+			x509.CommonName(certSANs.FQDN),
+			x509.NotAfter(time.Now().Add(x509.DefaultCertificateValidityDuration)),
+			x509.KeyUsage(stdlibx509.KeyUsageDigitalSignature|stdlibx509.KeyUsageKeyEncipherment),
+			x509.ExtKeyUsage([]stdlibx509.ExtKeyUsage{
+				stdlibx509.ExtKeyUsageServerAuth,
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to generate API server cert: %w", err)
+		}
+
+		serverCert = x509.NewCertificateAndKeyFromKeyPair(serverKeyPair)
 	}
 
 	clientCert, err := x509.NewKeyPair(ca,
@@ -312,8 +324,9 @@ func (ctrl *APIController) generateControlPlane(ctx context.Context, r controlle
 			apiSecrets.CA = &x509.PEMEncodedCertificateAndKey{
 				Crt: rootSpec.CA.Crt,
 			}
-			apiSecrets.Server = x509.NewCertificateAndKeyFromKeyPair(serverCert)
+			apiSecrets.Server = serverCert
 			apiSecrets.Client = x509.NewCertificateAndKeyFromKeyPair(clientCert)
+			apiSecrets.AcceptedCAs = rootSpec.AcceptedCAs
 
 			return nil
 		}); err != nil {
@@ -321,11 +334,10 @@ func (ctrl *APIController) generateControlPlane(ctx context.Context, r controlle
 	}
 
 	clientFingerprint, _ := x509.SPKIFingerprintFromDER(clientCert.Certificate.Certificate[0]) //nolint:errcheck
-	serverFingerprint, _ := x509.SPKIFingerprintFromDER(serverCert.Certificate.Certificate[0]) //nolint:errcheck
 
 	logger.Debug("generated new certificates",
 		zap.Stringer("client", clientFingerprint),
-		zap.Stringer("server", serverFingerprint),
+		zap.Bool("external_server", rootSpec.APIServer != nil),
 	)
 
 	return nil
@@ -340,20 +352,32 @@ func (ctrl *APIController) generateJoin(ctx context.Context, r controller.Runtim
 
 	defer remoteGen.Close() //nolint:errcheck
 
-	serverCSR, serverCert, err := x509.NewEd25519CSRAndIdentity(
-		x509.IPAddresses(certSANs.StdIPs()),
-		x509.DNSNames(certSANs.DNSNames),
-		x509.CommonName(certSANs.FQDN),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to generate API server CSR: %w", err)
+	if document, docErr := v1alpha1runtime.InstanceIdentityDocument(ctx, ctrl.V1alpha1Platform); docErr != nil {
+		logger.Warn("failed to fetch instance identity document", zap.Error(docErr))
+	} else if document != nil {
+		remoteGen.SetInstanceIdentity(ctrl.V1alpha1Platform.Name(), document)
 	}
 
 	var ca []byte
 
-	ca, serverCert.Crt, err = remoteGen.IdentityContext(ctx, serverCSR)
-	if err != nil {
-		return fmt.Errorf("failed to sign API server CSR: %w", err)
+	serverCert := rootSpec.APIServer
+
+	if serverCert == nil {
+		var serverCSR *x509.CertificateSigningRequest
+
+		serverCSR, serverCert, err = x509.NewEd25519CSRAndIdentity(
+			x509.IPAddresses(certSANs.StdIPs()),
+			x509.DNSNames(certSANs.DNSNames),
+			x509.CommonName(certSANs.FQDN),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to generate API server CSR: %w", err)
+		}
+
+		ca, serverCert.Crt, err = remoteGen.IdentityContext(ctx, serverCSR)
+		if err != nil {
+			return fmt.Errorf("failed to sign API server CSR: %w", err)
+		}
 	}
 
 	clientCSR, clientCert, err := x509.NewEd25519CSRAndIdentity(
@@ -367,7 +391,7 @@ func (ctrl *APIController) generateJoin(ctx context.Context, r controller.Runtim
 	logger.Debug("sending CSR", zap.Strings("endpoints", endpointsStr))
 
 	// TODO: add keyusage: trustd should accept key usage as additional params
-	_, clientCert.Crt, err = remoteGen.IdentityContext(ctx, clientCSR)
+	ca, clientCert.Crt, err = remoteGen.IdentityContext(ctx, clientCSR)
 	if err != nil {
 		return fmt.Errorf("failed to sign API client CSR: %w", err)
 	}
@@ -381,6 +405,7 @@ func (ctrl *APIController) generateJoin(ctx context.Context, r controller.Runtim
 			}
 			apiSecrets.Server = serverCert
 			apiSecrets.Client = clientCert
+			apiSecrets.AcceptedCAs = rootSpec.AcceptedCAs
 
 			return nil
 		}); err != nil {
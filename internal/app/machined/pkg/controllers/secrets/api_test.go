@@ -82,6 +82,13 @@ func (suite *APISuite) TestReconcileControlPlane() {
 		Crt: talosCA.CrtPEM,
 		Key: talosCA.KeyPEM,
 	}
+
+	oldCA, err := x509.NewSelfSignedCertificateAuthority(
+		x509.Organization("talos"),
+	)
+	suite.Require().NoError(err)
+
+	rootSecrets.TypedSpec().AcceptedCAs = [][]byte{oldCA.CrtPEM}
 	rootSecrets.TypedSpec().CertSANDNSNames = []string{"example.com"}
 	rootSecrets.TypedSpec().CertSANIPs = []netaddr.IP{netaddr.MustParseIP("10.4.3.2"), netaddr.MustParseIP("10.2.1.3")}
 	rootSecrets.TypedSpec().Token = "something"
@@ -125,6 +132,7 @@ func (suite *APISuite) TestReconcileControlPlane() {
 
 			suite.Assert().Equal(talosCA.CrtPEM, apiCerts.CA.Crt)
 			suite.Assert().Nil(apiCerts.CA.Key)
+			suite.Assert().Equal([][]byte{oldCA.CrtPEM}, apiCerts.AcceptedCAs)
 
 			serverCert, err := apiCerts.Server.GetCert()
 			suite.Require().NoError(err)
@@ -155,6 +163,78 @@ func (suite *APISuite) TestReconcileControlPlane() {
 	))
 }
 
+func (suite *APISuite) TestReconcileExternalServerCertificate() {
+	rootSecrets := secrets.NewOSRoot(secrets.OSRootID)
+
+	talosCA, err := x509.NewSelfSignedCertificateAuthority(
+		x509.Organization("talos"),
+	)
+	suite.Require().NoError(err)
+
+	externalCA, err := x509.NewSelfSignedCertificateAuthority(
+		x509.Organization("external"),
+	)
+	suite.Require().NoError(err)
+
+	externalServerCert, err := x509.NewKeyPair(externalCA,
+		x509.DNSNames([]string{"foo.example.com"}),
+		x509.ExtKeyUsage([]stdlibx509.ExtKeyUsage{
+			stdlibx509.ExtKeyUsageServerAuth,
+		}),
+	)
+	suite.Require().NoError(err)
+
+	externalServer := x509.NewCertificateAndKeyFromKeyPair(externalServerCert)
+
+	rootSecrets.TypedSpec().CA = &x509.PEMEncodedCertificateAndKey{
+		Crt: talosCA.CrtPEM,
+		Key: talosCA.KeyPEM,
+	}
+	rootSecrets.TypedSpec().AcceptedCAs = [][]byte{externalCA.CrtPEM}
+	rootSecrets.TypedSpec().APIServer = externalServer
+	rootSecrets.TypedSpec().Token = "something"
+	suite.Require().NoError(suite.state.Create(suite.ctx, rootSecrets))
+
+	machineType := config.NewMachineType()
+	machineType.SetMachineType(machine.TypeControlPlane)
+	suite.Require().NoError(suite.state.Create(suite.ctx, machineType))
+
+	networkStatus := network.NewStatus(network.NamespaceName, network.StatusID)
+	networkStatus.TypedSpec().AddressReady = true
+	networkStatus.TypedSpec().HostnameReady = true
+	suite.Require().NoError(suite.state.Create(suite.ctx, networkStatus))
+
+	certSANs := secrets.NewCertSAN(secrets.NamespaceName, secrets.CertSANAPIID)
+	certSANs.TypedSpec().Append("foo.example.com")
+	certSANs.TypedSpec().FQDN = "foo.example.com"
+	suite.Require().NoError(suite.state.Create(suite.ctx, certSANs))
+
+	suite.Assert().NoError(retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(
+		func() error {
+			certs, err := suite.state.Get(suite.ctx, resource.NewMetadata(secrets.NamespaceName, secrets.APIType, secrets.APIID, resource.VersionUndefined))
+			if err != nil {
+				if state.IsNotFoundError(err) {
+					return retry.ExpectedError(err)
+				}
+
+				return err
+			}
+
+			apiCerts := certs.(*secrets.API).TypedSpec()
+
+			suite.Assert().Equal(talosCA.CrtPEM, apiCerts.CA.Crt)
+			suite.Assert().Equal(externalServer, apiCerts.Server)
+
+			clientCert, err := apiCerts.Client.GetCert()
+			suite.Require().NoError(err)
+
+			suite.Assert().Equal("talos", clientCert.Issuer.Organization[0])
+
+			return nil
+		},
+	))
+}
+
 func (suite *APISuite) TearDownTest() {
 	suite.T().Log("tear down")
 
@@ -7,6 +7,7 @@ package secrets
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/cosi-project/runtime/pkg/controller"
@@ -15,12 +16,18 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/talos-systems/talos/internal/pkg/etcd"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
 	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
-	"github.com/talos-systems/talos/pkg/machinery/resources/time"
+	timeresource "github.com/talos-systems/talos/pkg/machinery/resources/time"
 	"github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
 )
 
+// EtcdCertificateValidityDuration is the validity duration for the certificates created with this controller.
+//
+// Controller automatically refreshes certs at 50% of CertificateValidityDuration.
+const EtcdCertificateValidityDuration = constants.EtcdDefaultCertificateValidityDuration
+
 // EtcdController manages secrets.Etcd based on configuration.
 type EtcdController struct{}
 
@@ -46,8 +53,8 @@ func (ctrl *EtcdController) Inputs() []controller.Input {
 		},
 		{
 			Namespace: v1alpha1.NamespaceName,
-			Type:      time.StatusType,
-			ID:        pointer.ToString(time.StatusID),
+			Type:      timeresource.StatusType,
+			ID:        pointer.ToString(timeresource.StatusID),
 			Kind:      controller.InputWeak,
 		},
 	}
@@ -67,11 +74,15 @@ func (ctrl *EtcdController) Outputs() []controller.Output {
 //
 //nolint:gocyclo
 func (ctrl *EtcdController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	refreshTicker := time.NewTicker(EtcdCertificateValidityDuration / 2)
+	defer refreshTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-r.EventCh():
+		case <-refreshTicker.C:
 		}
 
 		etcdRootRes, err := r.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.EtcdRootType, secrets.EtcdRootID, resource.VersionUndefined))
@@ -106,7 +117,7 @@ func (ctrl *EtcdController) Run(ctx context.Context, r controller.Runtime, logge
 		}
 
 		// wait for time sync as certs depend on current time
-		timeSyncResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, time.StatusType, time.StatusID, resource.VersionUndefined))
+		timeSyncResource, err := r.Get(ctx, resource.NewMetadata(v1alpha1.NamespaceName, timeresource.StatusType, timeresource.StatusID, resource.VersionUndefined))
 		if err != nil {
 			if state.IsNotFoundError(err) {
 				continue
@@ -115,7 +126,7 @@ func (ctrl *EtcdController) Run(ctx context.Context, r controller.Runtime, logge
 			return err
 		}
 
-		if !timeSyncResource.(*time.Status).Status().Synced {
+		if !timeSyncResource.(*timeresource.Status).Status().Synced {
 			continue
 		}
 
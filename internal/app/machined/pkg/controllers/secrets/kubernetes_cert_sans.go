@@ -116,6 +116,11 @@ func (ctrl *KubernetesCertSANsController) Run(ctx context.Context, r controller.
 			spec := r.(*secrets.CertSAN).TypedSpec()
 
 			spec.Append(k8sRoot.Endpoint.Hostname())
+
+			if k8sRoot.InternalEndpoint != nil {
+				spec.Append(k8sRoot.InternalEndpoint.Hostname())
+			}
+
 			spec.Append(k8sRoot.CertSANs...)
 
 			spec.AppendDNSNames(
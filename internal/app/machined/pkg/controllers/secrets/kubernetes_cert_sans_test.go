@@ -78,6 +78,8 @@ func (suite *KubernetesCertSANsSuite) TestReconcile() {
 	rootSecrets.TypedSpec().DNSDomain = "cluster.remote"
 	rootSecrets.TypedSpec().Endpoint, err = url.Parse("https://some.url:6443/")
 	suite.Require().NoError(err)
+	rootSecrets.TypedSpec().InternalEndpoint, err = url.Parse("https://10.5.0.1:6443/")
+	suite.Require().NoError(err)
 
 	suite.Require().NoError(suite.state.Create(suite.ctx, rootSecrets))
 
@@ -115,7 +117,7 @@ func (suite *KubernetesCertSANsSuite) TestReconcile() {
 					"localhost",
 					"some.url",
 				}, spec.DNSNames)
-			suite.Assert().Equal("[10.2.1.3 10.4.3.2 172.16.0.1]", fmt.Sprintf("%v", spec.IPs))
+			suite.Assert().Equal("[10.2.1.3 10.4.3.2 10.5.0.1 172.16.0.1]", fmt.Sprintf("%v", spec.IPs))
 
 			return nil
 		},
@@ -133,6 +133,8 @@ func (ctrl *RootController) Run(ctx context.Context, r controller.Runtime, logge
 
 func (ctrl *RootController) updateOSSecrets(cfgProvider talosconfig.Provider, osSecrets *secrets.OSRootSpec) error {
 	osSecrets.CA = cfgProvider.Machine().Security().CA()
+	osSecrets.AcceptedCAs = cfgProvider.Machine().APITLS().AcceptedCAs()
+	osSecrets.APIServer = cfgProvider.Machine().APITLS().ServerCertificate()
 
 	osSecrets.CertSANIPs = nil
 	osSecrets.CertSANDNSNames = nil
@@ -163,6 +165,7 @@ func (ctrl *RootController) updateEtcdSecrets(cfgProvider talosconfig.Provider,
 func (ctrl *RootController) updateK8sSecrets(cfgProvider talosconfig.Provider, k8sSecrets *secrets.KubernetesRootSpec) error {
 	k8sSecrets.Name = cfgProvider.Cluster().Name()
 	k8sSecrets.Endpoint = cfgProvider.Cluster().Endpoint()
+	k8sSecrets.InternalEndpoint = cfgProvider.Cluster().InternalEndpoint()
 	k8sSecrets.CertSANs = cfgProvider.Cluster().CertSANs()
 	k8sSecrets.DNSDomain = cfgProvider.Cluster().Network().DNSDomain()
 
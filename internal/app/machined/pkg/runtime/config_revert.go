@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// ConfigRevert is the pending automatic revert of the configuration applied via the API.
+//
+// The revert is persisted on the STATE partition while the node health is watched, so that
+// the node which reboots before the applied configuration is confirmed boots with the previous one.
+type ConfigRevert struct {
+	// Previous is the API configuration layer to revert to, empty if there was none.
+	Previous string `yaml:"previous,omitempty"`
+	// Deadline is the time the applied configuration is confirmed at if the node stays healthy.
+	Deadline time.Time `yaml:"deadline"`
+}
+
+// LoadConfigRevert reads the pending config revert, nil is returned if there is none.
+func LoadConfigRevert() (*ConfigRevert, error) {
+	b, err := ioutil.ReadFile(constants.ConfigRevertPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error reading config revert: %w", err)
+	}
+
+	var revert ConfigRevert
+
+	if err = yaml.Unmarshal(b, &revert); err != nil {
+		return nil, fmt.Errorf("error parsing config revert: %w", err)
+	}
+
+	return &revert, nil
+}
+
+// Layer returns the API configuration layer to revert to, nil layer removes the API configuration.
+func (revert *ConfigRevert) Layer() []byte {
+	if revert.Previous == "" {
+		return nil
+	}
+
+	return []byte(revert.Previous)
+}
+
+// Save persists the pending config revert on the STATE partition.
+func (revert *ConfigRevert) Save() error {
+	b, err := yaml.Marshal(revert)
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(constants.ConfigRevertPath, b, 0o600); err != nil {
+		return fmt.Errorf("error writing config revert: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveConfigRevert removes the pending config revert.
+func RemoveConfigRevert() error {
+	if err := os.Remove(constants.ConfigRevertPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing config revert: %w", err)
+	}
+
+	return nil
+}
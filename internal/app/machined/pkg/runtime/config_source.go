@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// ConfigSource is a source of the machine configuration layer.
+//
+// Layers are merged in the order of the sources: each source overrides the settings of the previous ones.
+type ConfigSource int
+
+const (
+	// ConfigSourceDisk is the configuration stored on the STATE partition.
+	ConfigSourceDisk ConfigSource = iota
+	// ConfigSourcePlatform is the configuration fetched from the platform (user-data).
+	ConfigSourcePlatform
+	// ConfigSourceAPI is the configuration applied via the API.
+	ConfigSourceAPI
+)
+
+// ConfigSources lists configuration sources from the lowest to the highest priority.
+var ConfigSources = []ConfigSource{ConfigSourceDisk, ConfigSourcePlatform, ConfigSourceAPI}
+
+// String returns the string representation of a ConfigSource.
+func (s ConfigSource) String() string {
+	return [...]string{"disk", "platform", "api"}[s]
+}
+
+// Path returns the path the configuration layer is persisted at.
+func (s ConfigSource) Path() string {
+	return filepath.Join(constants.ConfigLayersPath, s.String()+".yaml")
+}
+
+// ConfigLayers is a set of machine configuration documents by source.
+type ConfigLayers map[ConfigSource][]byte
+
+// LoadConfigLayers reads the configuration layers persisted on the STATE partition.
+func LoadConfigLayers() (ConfigLayers, error) {
+	layers := ConfigLayers{}
+
+	for _, source := range ConfigSources {
+		b, err := ioutil.ReadFile(source.Path())
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("error reading %s config: %w", source, err)
+		}
+
+		layers[source] = b
+	}
+
+	return layers, nil
+}
+
+// Save persists the configuration layers on the STATE partition, layers which are not set are removed.
+func (layers ConfigLayers) Save() error {
+	if err := os.MkdirAll(constants.ConfigLayersPath, 0o700); err != nil {
+		return err
+	}
+
+	for _, source := range ConfigSources {
+		b, ok := layers[source]
+		if !ok {
+			if err := os.Remove(source.Path()); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("error removing %s config: %w", source, err)
+			}
+
+			continue
+		}
+
+		if err := ioutil.WriteFile(source.Path(), b, 0o600); err != nil {
+			return fmt.Errorf("error writing %s config: %w", source, err)
+		}
+	}
+
+	return nil
+}
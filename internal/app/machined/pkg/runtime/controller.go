@@ -7,6 +7,7 @@ package runtime
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/cosi-project/runtime/pkg/controller"
 )
@@ -70,4 +71,23 @@ type Controller interface {
 type V1Alpha2Controller interface {
 	Run(context.Context, *Drainer) error
 	DependencyGraph() (*controller.DependencyGraph, error)
+	ControllerStatuses() []ControllerStatus
+	PauseControllers(controllers []string, until time.Time) ([]string, error)
+	ResumeControllers(controllers []string) []string
+}
+
+// ControllerStatus describes the health of a controller in the v1alpha2 controller runtime.
+type ControllerStatus struct {
+	Name string
+	// Running is false when the controller is waiting to be restarted after a crash.
+	Running bool
+	// Crashes is the number of times the controller failed and was restarted.
+	Crashes int
+	// Wedged is true when the controller stopped processing reconcile events and was restarted by the watchdog.
+	Wedged bool
+	// PausedUntil is set when the controller reconciliation is paused.
+	PausedUntil time.Time
+	// LastError is the last error the controller failed or logged a warning with.
+	LastError     string
+	LastErrorTime time.Time
 }
@@ -23,6 +23,30 @@ type LoggingManager interface {
 	//
 	// SetSenders should be thread-safe.
 	SetSenders(senders []LogSender) []LogSender
+
+	// SetRateLimits sets the default rate limit for all service logs,
+	// and overrides it for specific services (by ID).
+	//
+	// SetRateLimits should be thread-safe.
+	SetRateLimits(defaultLimit LogRateLimit, limits map[string]LogRateLimit)
+
+	// RateLimitStatus returns rate limits and number of dropped lines for each service log.
+	RateLimitStatus() map[string]LogRateLimitStatus
+}
+
+// LogRateLimit configures log rate limiting.
+type LogRateLimit struct {
+	// Burst is the number of lines which can be written at once.
+	Burst int
+	// Rate is the sustained number of lines per second.
+	Rate int
+}
+
+// LogRateLimitStatus describes rate limiting state of a service log.
+type LogRateLimitStatus struct {
+	LogRateLimit
+
+	DroppedLines uint64
 }
 
 // LogOptions for LogHandler.Reader.
@@ -41,13 +41,19 @@ type CircularBufferLoggingManager struct {
 	sendersRW      sync.RWMutex
 	senders        []runtime.LogSender
 	sendersChanged chan struct{}
+
+	rateLimitsMu     sync.Mutex
+	defaultRateLimit runtime.LogRateLimit
+	rateLimits       map[string]runtime.LogRateLimit
+	rateLimiters     sync.Map
 }
 
 // NewCircularBufferLoggingManager initializes new CircularBufferLoggingManager.
 func NewCircularBufferLoggingManager(fallbackLogger *log.Logger) *CircularBufferLoggingManager {
 	return &CircularBufferLoggingManager{
-		fallbackLogger: fallbackLogger,
-		sendersChanged: make(chan struct{}),
+		fallbackLogger:   fallbackLogger,
+		sendersChanged:   make(chan struct{}),
+		defaultRateLimit: DefaultRateLimit,
 	}
 }
 
@@ -80,6 +86,56 @@ func (manager *CircularBufferLoggingManager) SetSenders(senders []runtime.LogSen
 	return prevSenders
 }
 
+// SetRateLimits implements runtime.LoggingManager interface.
+func (manager *CircularBufferLoggingManager) SetRateLimits(defaultLimit runtime.LogRateLimit, limits map[string]runtime.LogRateLimit) {
+	manager.rateLimitsMu.Lock()
+	defer manager.rateLimitsMu.Unlock()
+
+	manager.defaultRateLimit = defaultLimit
+	manager.rateLimits = limits
+
+	manager.rateLimiters.Range(func(key, value interface{}) bool {
+		value.(*rateLimiter).setLimit(manager.rateLimitFor(key.(string)))
+
+		return true
+	})
+}
+
+// RateLimitStatus implements runtime.LoggingManager interface.
+func (manager *CircularBufferLoggingManager) RateLimitStatus() map[string]runtime.LogRateLimitStatus {
+	result := map[string]runtime.LogRateLimitStatus{}
+
+	manager.rateLimiters.Range(func(key, value interface{}) bool {
+		result[key.(string)] = value.(*rateLimiter).status()
+
+		return true
+	})
+
+	return result
+}
+
+// rateLimitFor should be called with rateLimitsMu held.
+func (manager *CircularBufferLoggingManager) rateLimitFor(id string) runtime.LogRateLimit {
+	if limit, ok := manager.rateLimits[id]; ok {
+		return limit
+	}
+
+	return manager.defaultRateLimit
+}
+
+func (manager *CircularBufferLoggingManager) getRateLimiter(id string) *rateLimiter {
+	if l, ok := manager.rateLimiters.Load(id); ok {
+		return l.(*rateLimiter)
+	}
+
+	manager.rateLimitsMu.Lock()
+	defer manager.rateLimitsMu.Unlock()
+
+	l, _ := manager.rateLimiters.LoadOrStore(id, newRateLimiter(manager.rateLimitFor(id)))
+
+	return l.(*rateLimiter)
+}
+
 // getSenders waits for senders to be set and returns them.
 func (manager *CircularBufferLoggingManager) getSenders() []runtime.LogSender {
 	for {
@@ -151,7 +207,10 @@ func (handler *circularHandler) Writer() (io.WriteCloser, error) {
 		}()
 	}
 
-	return nopCloser{handler.buf}, nil
+	return nopCloser{&rateLimitedWriter{
+		w:       handler.buf,
+		limiter: handler.manager.getRateLimiter(handler.id),
+	}}, nil
 }
 
 // Reader implements runtime.LogHandler interface.
@@ -42,6 +42,14 @@ func (manager *FileLoggingManager) SetSenders([]runtime.LogSender) []runtime.Log
 	return nil
 }
 
+// SetRateLimits implements runtime.LoggingManager interface (by doing nothing).
+func (*FileLoggingManager) SetRateLimits(runtime.LogRateLimit, map[string]runtime.LogRateLimit) {}
+
+// RateLimitStatus implements runtime.LoggingManager interface.
+func (*FileLoggingManager) RateLimitStatus() map[string]runtime.LogRateLimitStatus {
+	return nil
+}
+
 type fileLogHandler struct {
 	path string
 
@@ -29,6 +29,14 @@ func (*NullLoggingManager) SetSenders([]runtime.LogSender) []runtime.LogSender {
 	return nil
 }
 
+// SetRateLimits implements runtime.LoggingManager interface (by doing nothing).
+func (*NullLoggingManager) SetRateLimits(runtime.LogRateLimit, map[string]runtime.LogRateLimit) {}
+
+// RateLimitStatus implements runtime.LoggingManager interface.
+func (*NullLoggingManager) RateLimitStatus() map[string]runtime.LogRateLimitStatus {
+	return nil
+}
+
 type nullLogHandler struct{}
 
 func (*nullLogHandler) Writer() (io.WriteCloser, error) {
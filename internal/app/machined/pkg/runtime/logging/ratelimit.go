@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package logging
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+)
+
+// DefaultRateLimit is applied to each service log unless overridden via SetRateLimits.
+var DefaultRateLimit = runtime.LogRateLimit{
+	Burst: 10000,
+	Rate:  1000,
+}
+
+// rateLimiter keeps rate limiting state of a single service log.
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   runtime.LogRateLimit
+	limiter *rate.Limiter
+
+	dropped uint64
+}
+
+func newRateLimiter(limit runtime.LogRateLimit) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst),
+	}
+}
+
+func (l *rateLimiter) setLimit(limit runtime.LogRateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit == limit {
+		return
+	}
+
+	l.limit = limit
+	l.limiter.SetLimit(rate.Limit(limit.Rate))
+	l.limiter.SetBurst(limit.Burst)
+}
+
+func (l *rateLimiter) status() runtime.LogRateLimitStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return runtime.LogRateLimitStatus{
+		LogRateLimit: l.limit,
+		DroppedLines: atomic.LoadUint64(&l.dropped),
+	}
+}
+
+// allow returns true if the next log line should be written.
+func (l *rateLimiter) allow() bool {
+	if l.limiter.Allow() {
+		return true
+	}
+
+	atomic.AddUint64(&l.dropped, 1)
+
+	return false
+}
+
+// rateLimitedWriter drops log lines over the rate limit.
+//
+// Lines are never cut: if the beginning of the line is dropped, the rest of it is dropped as well.
+type rateLimitedWriter struct {
+	w       io.Writer
+	limiter *rateLimiter
+
+	midLine  bool
+	dropLine bool
+}
+
+// Write implements io.Writer.
+func (w *rateLimitedWriter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for len(p) > 0 {
+		var line []byte
+
+		if idx := bytes.IndexByte(p, '\n'); idx == -1 {
+			line, p = p, nil
+		} else {
+			line, p = p[:idx+1], p[idx+1:]
+		}
+
+		if !w.midLine {
+			w.dropLine = !w.limiter.allow()
+		}
+
+		w.midLine = line[len(line)-1] != '\n'
+
+		if w.dropLine {
+			continue
+		}
+
+		if _, err := w.w.Write(line); err != nil {
+			return n - len(p) - len(line), err
+		}
+	}
+
+	return n, nil
+}
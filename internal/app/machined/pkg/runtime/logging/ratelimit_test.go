@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package logging_test
+
+import (
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/logging"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	manager := logging.NewCircularBufferLoggingManager(log.New(ioutil.Discard, "", 0))
+
+	// rate is low enough to never refill the bucket during the test
+	manager.SetRateLimits(logging.DefaultRateLimit, map[string]runtime.LogRateLimit{
+		"chatty": {Burst: 2, Rate: 1},
+	})
+
+	w, err := manager.ServiceLog("chatty").Writer()
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("line 1\nline 2\nli"))
+	require.NoError(t, err)
+
+	// the rest of line 2 is dropped along with its beginning
+	_, err = w.Write([]byte("ne 3\nline 4\n"))
+	require.NoError(t, err)
+
+	quiet, err := manager.ServiceLog("quiet").Writer()
+	require.NoError(t, err)
+
+	_, err = quiet.Write([]byte("line 1\nline 2\nline 3\n"))
+	require.NoError(t, err)
+
+	r, err := manager.ServiceLog("chatty").Reader()
+	require.NoError(t, err)
+
+	contents, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, "line 1\nline 2\n", string(contents))
+
+	status := manager.RateLimitStatus()
+
+	assert.Equal(t, runtime.LogRateLimitStatus{
+		LogRateLimit: runtime.LogRateLimit{Burst: 2, Rate: 1},
+		DroppedLines: 2,
+	}, status["chatty"])
+
+	assert.Equal(t, runtime.LogRateLimitStatus{
+		LogRateLimit: logging.DefaultRateLimit,
+	}, status["quiet"])
+
+	// new limits apply to existing service logs
+	manager.SetRateLimits(runtime.LogRateLimit{Burst: 100, Rate: 100}, nil)
+
+	assert.Equal(t, runtime.LogRateLimit{Burst: 100, Rate: 100}, manager.RateLimitStatus()["chatty"].LogRateLimit)
+}
@@ -6,6 +6,7 @@ package logging
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -16,7 +17,8 @@ import (
 )
 
 type jsonLinesSender struct {
-	endpoint *url.URL
+	endpoint  *url.URL
+	tlsConfig TLSConfigFunc
 
 	sema chan struct{}
 	conn net.Conn
@@ -24,13 +26,16 @@ type jsonLinesSender struct {
 
 // NewJSONLines returns log sender that sends logs in JSON over TCP (newline-delimited)
 // or UDP (one message per packet).
-func NewJSONLines(endpoint *url.URL) runtime.LogSender {
+//
+// Endpoint scheme "tls" sends logs over TCP with mTLS, tlsConfig should be set in that case.
+func NewJSONLines(endpoint *url.URL, tlsConfig TLSConfigFunc) runtime.LogSender {
 	sema := make(chan struct{}, 1)
 	sema <- struct{}{}
 
 	return &jsonLinesSender{
-		endpoint: endpoint,
-		sema:     sema,
+		endpoint:  endpoint,
+		tlsConfig: tlsConfig,
+		sema:      sema,
 	}
 }
 
@@ -65,7 +70,7 @@ func (j *jsonLinesSender) Send(ctx context.Context, e *runtime.LogEvent) error {
 		return fmt.Errorf("%w: %s", runtime.ErrDontRetry, err)
 	}
 
-	if j.endpoint.Scheme == "tcp" {
+	if j.endpoint.Scheme == "tcp" || j.endpoint.Scheme == "tls" {
 		b = append(b, '\n')
 	}
 
@@ -78,7 +83,7 @@ func (j *jsonLinesSender) Send(ctx context.Context, e *runtime.LogEvent) error {
 
 	// Connect (or "connect" for UDP) if no connection is established already.
 	if j.conn == nil {
-		conn, err := new(net.Dialer).DialContext(ctx, j.endpoint.Scheme, j.endpoint.Host)
+		conn, err := j.dial(ctx)
 		if err != nil {
 			return err
 		}
@@ -105,6 +110,23 @@ func (j *jsonLinesSender) Send(ctx context.Context, e *runtime.LogEvent) error {
 	return nil
 }
 
+func (j *jsonLinesSender) dial(ctx context.Context) (net.Conn, error) {
+	if j.endpoint.Scheme != "tls" {
+		return new(net.Dialer).DialContext(ctx, j.endpoint.Scheme, j.endpoint.Host)
+	}
+
+	if j.tlsConfig == nil {
+		return nil, fmt.Errorf("%w: TLS is not configured for %q", runtime.ErrDontRetry, j.endpoint)
+	}
+
+	cfg, err := j.tlsConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&tls.Dialer{Config: cfg}).DialContext(ctx, "tcp", j.endpoint.Host)
+}
+
 // Close implements LogSender interface.
 func (j *jsonLinesSender) Close(ctx context.Context) error {
 	unlock := j.tryLock(ctx)
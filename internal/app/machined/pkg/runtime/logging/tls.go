@@ -0,0 +1,65 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package logging
+
+import (
+	"context"
+	"crypto/tls"
+	stdx509 "crypto/x509"
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+)
+
+// TLSConfigFunc returns TLS config for the connection to the sink.
+//
+// It is called on every connection attempt, so that the rotated node certificates are picked up.
+type TLSConfigFunc func(ctx context.Context) (*tls.Config, error)
+
+// SinkTLSConfig builds mTLS client config for the log and event sinks.
+//
+// Node API client certificate is presented to the sink, sink certificate should be signed by
+// the CA (or by the Talos OS CA, if the CA is empty).
+func SinkTLSConfig(apiCerts *secrets.APICertsSpec, ca []byte) (*tls.Config, error) {
+	if apiCerts.Client == nil || apiCerts.CA == nil {
+		return nil, fmt.Errorf("node certificates are not ready")
+	}
+
+	clientCert, err := tls.X509KeyPair(apiCerts.Client.Crt, apiCerts.Client.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse node client certificate: %w", err)
+	}
+
+	if len(ca) == 0 {
+		ca = apiCerts.CA.Crt
+	}
+
+	pool := stdx509.NewCertPool()
+
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("failed to parse sink CA certificate")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// NodeIdentityTLSConfig returns TLSConfigFunc which builds the sink TLS config from the current node API certificates.
+func NodeIdentityTLSConfig(resources state.State, ca []byte) TLSConfigFunc {
+	return func(ctx context.Context) (*tls.Config, error) {
+		apiCerts, err := resources.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.APIType, secrets.APIID, resource.VersionUndefined))
+		if err != nil {
+			return nil, fmt.Errorf("error reading node certificates: %w", err)
+		}
+
+		return SinkTLSConfig(apiCerts.(*secrets.API).TypedSpec(), ca)
+	}
+}
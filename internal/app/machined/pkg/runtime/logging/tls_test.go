@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package logging_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	stdx509 "crypto/x509"
+	"encoding/json"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/crypto/x509"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/logging"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+)
+
+func newKeyPair(t *testing.T, ca *x509.CertificateAuthority, usage stdx509.ExtKeyUsage) *x509.PEMEncodedCertificateAndKey {
+	keyPair, err := x509.NewKeyPair(ca,
+		x509.CommonName("localhost"),
+		x509.IPAddresses([]net.IP{net.ParseIP("127.0.0.1")}),
+		x509.ExtKeyUsage([]stdx509.ExtKeyUsage{usage}),
+	)
+	require.NoError(t, err)
+
+	return x509.NewCertificateAndKeyFromKeyPair(keyPair)
+}
+
+func TestJSONLinesTLS(t *testing.T) {
+	t.Parallel()
+
+	talosCA, err := x509.NewSelfSignedCertificateAuthority(x509.Organization("talos"))
+	require.NoError(t, err)
+
+	sinkCA, err := x509.NewSelfSignedCertificateAuthority(x509.Organization("sink"))
+	require.NoError(t, err)
+
+	apiCerts := &secrets.APICertsSpec{
+		CA:     &x509.PEMEncodedCertificateAndKey{Crt: talosCA.CrtPEM},
+		Client: newKeyPair(t, talosCA, stdx509.ExtKeyUsageClientAuth),
+	}
+
+	serverCert := newKeyPair(t, sinkCA, stdx509.ExtKeyUsageServerAuth)
+
+	cert, err := tls.X509KeyPair(serverCert.Crt, serverCert.Key)
+	require.NoError(t, err)
+
+	clientCAs := stdx509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(talosCA.CrtPEM))
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	})
+	require.NoError(t, err)
+
+	defer lis.Close() //nolint:errcheck
+
+	received := make(chan map[string]interface{}, 1)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close() //nolint:errcheck
+
+				line, err := bufio.NewReader(conn).ReadBytes('\n')
+				if err != nil {
+					return
+				}
+
+				var msg map[string]interface{}
+
+				if err = json.Unmarshal(line, &msg); err == nil {
+					received <- msg
+				}
+			}()
+		}
+	}()
+
+	endpoint := &url.URL{Scheme: "tls", Host: lis.Addr().String()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// sink certificate is not signed by the Talos OS CA
+	sender := logging.NewJSONLines(endpoint, func(ctx context.Context) (*tls.Config, error) {
+		return logging.SinkTLSConfig(apiCerts, nil)
+	})
+
+	assert.Error(t, sender.Send(ctx, &runtime.LogEvent{Msg: "hello", Level: zapcore.InfoLevel}))
+
+	sender = logging.NewJSONLines(endpoint, func(ctx context.Context) (*tls.Config, error) {
+		return logging.SinkTLSConfig(apiCerts, sinkCA.CrtPEM)
+	})
+
+	defer sender.Close(ctx) //nolint:errcheck
+
+	require.NoError(t, sender.Send(ctx, &runtime.LogEvent{Msg: "hello", Level: zapcore.InfoLevel}))
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "hello", msg["msg"])
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the message")
+	}
+}
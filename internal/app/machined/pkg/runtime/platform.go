@@ -7,6 +7,7 @@ package runtime
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/talos-systems/go-procfs/procfs"
 )
@@ -20,3 +21,23 @@ type Platform interface {
 	ExternalIPs(context.Context) ([]net.IP, error)
 	KernelArgs() procfs.Parameters
 }
+
+// InstanceIdentityProvider is implemented by the platforms which provide signed instance identity documents.
+type InstanceIdentityProvider interface {
+	InstanceIdentityDocument(context.Context) ([]byte, error)
+}
+
+// InstanceIdentityDocument fetches the signed instance identity document from the platform.
+//
+// Nil document is returned if the platform doesn't provide instance identity documents.
+func InstanceIdentityDocument(ctx context.Context, p Platform) ([]byte, error) {
+	provider, ok := p.(InstanceIdentityProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return provider.InstanceIdentityDocument(ctx)
+}
@@ -11,8 +11,10 @@ import (
 // Runtime defines the runtime parameters.
 type Runtime interface {
 	Config() config.Provider
+	ConfigLayers() ConfigLayers
 	ValidateConfig([]byte) (config.Provider, error)
-	SetConfig([]byte) error
+	MergeConfig(ConfigLayers) ([]byte, error)
+	SetConfig(ConfigLayers) error
 	CanApplyImmediate([]byte) error
 	State() State
 	Events() EventStream
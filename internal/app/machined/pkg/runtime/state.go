@@ -11,6 +11,7 @@ import (
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/disk"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	configresource "github.com/talos-systems/talos/pkg/machinery/resources/config"
 )
 
 // State defines the state.
@@ -50,4 +51,5 @@ type V1Alpha2State interface {
 	ResourceRegistry() *registry.ResourceRegistry
 
 	SetConfig(config.Provider) error
+	SetConfigSources([]*configresource.MachineConfigSource) error
 }
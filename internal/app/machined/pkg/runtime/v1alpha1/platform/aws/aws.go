@@ -30,6 +30,8 @@ const (
 	AWSHostnameEndpoint = "http://169.254.169.254/latest/meta-data/hostname"
 	// AWSPKCS7Endpoint is the local EC2 endpoint for the PKCS7 signature.
 	AWSPKCS7Endpoint = "http://169.254.169.254/latest/dynamic/instance-identity/pkcs7"
+	// AWSRSA2048Endpoint is the local EC2 endpoint for the RSA-2048 PKCS7 signature of the instance identity document.
+	AWSRSA2048Endpoint = "http://169.254.169.254/latest/dynamic/instance-identity/rsa2048"
 	// AWSPublicCertificate is the AWS public certificate for the regions
 	// provided by an AWS account.
 	AWSPublicCertificate = `-----BEGIN CERTIFICATE-----
@@ -219,3 +221,8 @@ func (a *AWS) KernelArgs() procfs.Parameters {
 		procfs.NewParameter("console").Append("tty1").Append("ttyS0"),
 	}
 }
+
+// InstanceIdentityDocument implements the runtime.InstanceIdentityProvider interface.
+func (a *AWS) InstanceIdentityDocument(ctx context.Context) ([]byte, error) {
+	return download.Download(ctx, AWSRSA2048Endpoint)
+}
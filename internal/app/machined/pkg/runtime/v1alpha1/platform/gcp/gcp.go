@@ -9,11 +9,13 @@ import (
 	"encoding/json"
 	"log"
 	"net"
+	"net/url"
 
 	"github.com/talos-systems/go-procfs/procfs"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/platform/errors"
+	"github.com/talos-systems/talos/internal/pkg/instanceidentity"
 	"github.com/talos-systems/talos/pkg/download"
 )
 
@@ -24,6 +26,8 @@ const (
 	GCUserDataEndpoint = "http://metadata.google.internal/computeMetadata/v1/instance/attributes/user-data"
 	// GCExternalIPEndpoint displays all external addresses associated with the instance.
 	GCExternalIPEndpoint = "http://metadata.google.internal/computeMetadata/v1/instance/network-interfaces/?recursive=true"
+	// GCIdentityEndpoint is the local metadata endpoint for the signed instance identity token.
+	GCIdentityEndpoint = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/identity"
 )
 
 // GCP is the concrete type that implements the platform.Platform interface.
@@ -94,3 +98,14 @@ func (g *GCP) KernelArgs() procfs.Parameters {
 		procfs.NewParameter("console").Append("ttyS0"),
 	}
 }
+
+// InstanceIdentityDocument implements the runtime.InstanceIdentityProvider interface.
+func (g *GCP) InstanceIdentityDocument(ctx context.Context) ([]byte, error) {
+	query := url.Values{
+		"audience": []string{instanceidentity.GCPAudience},
+		"format":   []string{"full"},
+	}
+
+	return download.Download(ctx, GCIdentityEndpoint+"?"+query.Encode(),
+		download.WithHeaders(map[string]string{"Metadata-Flavor": "Google"}))
+}
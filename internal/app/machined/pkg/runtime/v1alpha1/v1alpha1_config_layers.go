@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
+	"github.com/talos-systems/talos/pkg/machinery/config/merge"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+// mergeConfigLayers merges the configuration layers from the lowest to the highest priority source.
+//
+// It returns the merged configuration and the configuration sections taken from each source.
+func mergeConfigLayers(layers runtime.ConfigLayers) (*v1alpha1.Config, map[runtime.ConfigSource][]string, error) {
+	if len(layers) == 0 {
+		return nil, nil, fmt.Errorf("no configuration layers")
+	}
+
+	merged := &v1alpha1.Config{}
+	owners := map[string]runtime.ConfigSource{}
+
+	for _, source := range runtime.ConfigSources {
+		b, ok := layers[source]
+		if !ok {
+			continue
+		}
+
+		provider, err := configloader.NewFromBytes(b)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s config: %w", source, err)
+		}
+
+		cfg, ok := provider.(*v1alpha1.Config)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s config is not v1alpha1", source)
+		}
+
+		for _, section := range configSections(cfg) {
+			owners[section] = source
+		}
+
+		if err = merge.Merge(merged, cfg); err != nil {
+			return nil, nil, fmt.Errorf("failed to merge %s config: %w", source, err)
+		}
+	}
+
+	sections := map[runtime.ConfigSource][]string{}
+
+	// keep the order of the sections as in the config document
+	for _, section := range configSections(merged) {
+		if source, ok := owners[section]; ok {
+			sections[source] = append(sections[source], section)
+		}
+	}
+
+	return merged, sections, nil
+}
+
+// configSections returns the configuration sections set in the config: `machine.*` and `cluster.*` fields and top-level settings.
+func configSections(cfg *v1alpha1.Config) []string {
+	var sections []string
+
+	v := reflect.ValueOf(cfg).Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		name := yamlName(v.Type().Field(i))
+
+		field := v.Field(i)
+		if field.IsZero() || name == "version" {
+			continue
+		}
+
+		if field.Kind() != reflect.Ptr || field.Elem().Kind() != reflect.Struct {
+			sections = append(sections, name)
+
+			continue
+		}
+
+		for j := 0; j < field.Elem().NumField(); j++ {
+			if !field.Elem().Field(j).IsZero() {
+				sections = append(sections, name+"."+yamlName(field.Elem().Type().Field(j)))
+			}
+		}
+	}
+
+	return sections
+}
+
+func yamlName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("yaml"), ",")[0]
+}
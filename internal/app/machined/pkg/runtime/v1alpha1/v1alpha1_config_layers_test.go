@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+//nolint:testpackage
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+)
+
+func TestMergeConfigLayers(t *testing.T) {
+	layers := runtime.ConfigLayers{
+		runtime.ConfigSourceDisk: []byte(`version: v1alpha1
+persist: true
+machine:
+  type: worker
+  token: token
+  network:
+    hostname: disk
+cluster:
+  clusterName: cluster
+`),
+		runtime.ConfigSourcePlatform: []byte(`version: v1alpha1
+machine:
+  network:
+    nameservers:
+      - 1.1.1.1
+  sysctls:
+    net.core.somaxconn: "1024"
+`),
+		runtime.ConfigSourceAPI: []byte(`version: v1alpha1
+machine:
+  sysctls:
+    vm.swappiness: "10"
+`),
+	}
+
+	cfg, sections, err := mergeConfigLayers(layers)
+	require.NoError(t, err)
+
+	assert.Equal(t, "worker", cfg.Machine().Type().String())
+	assert.Equal(t, "disk", cfg.Machine().Network().Hostname())
+	assert.Equal(t, []string{"1.1.1.1"}, cfg.Machine().Network().Resolvers())
+	assert.Equal(t, map[string]string{"net.core.somaxconn": "1024", "vm.swappiness": "10"}, cfg.Machine().Sysctls())
+	assert.Equal(t, "cluster", cfg.Cluster().Name())
+
+	assert.Equal(t, map[runtime.ConfigSource][]string{
+		runtime.ConfigSourceDisk:     {"persist", "machine.type", "machine.token", "cluster.clusterName"},
+		runtime.ConfigSourcePlatform: {"machine.network"},
+		runtime.ConfigSourceAPI:      {"machine.sysctls"},
+	}, sections)
+
+	_, _, err = mergeConfigLayers(runtime.ConfigLayers{
+		runtime.ConfigSourceAPI: []byte("machine: ["),
+	})
+	assert.Error(t, err)
+
+	_, _, err = mergeConfigLayers(runtime.ConfigLayers{})
+	assert.Error(t, err)
+}
@@ -153,6 +153,11 @@ func (c *Controller) Run(ctx context.Context, seq runtime.Sequence, data interfa
 
 	err = c.run(ctx, seq, phases, data)
 	if err != nil {
+		if runtime.IsRebootError(err) {
+			// reboot is the expected outcome of the sequence, not a failure
+			return err
+		}
+
 		c.Runtime().Events().Publish(&machine.SequenceEvent{
 			Sequence: seq.String(),
 			Action:   machine.SequenceEvent_NOOP,
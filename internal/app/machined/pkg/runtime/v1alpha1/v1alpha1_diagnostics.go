@@ -0,0 +1,188 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"github.com/talos-systems/go-kmsg"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/pkg/diagnostics"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	resourceruntime "github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+	resourcev1alpha1 "github.com/talos-systems/talos/pkg/machinery/resources/v1alpha1"
+)
+
+// bootDiagnosticsLogLines is the number of most recent log lines captured for each service.
+const bootDiagnosticsLogLines = 5000
+
+// bootDiagnosticsNamespaces are captured in the diagnostics snapshot.
+//
+// As the snapshot is available without authentication in maintenance mode, namespaces which might contain secrets
+// are never captured, and sensitive resources are skipped.
+var bootDiagnosticsNamespaces = map[resource.Namespace]struct{}{
+	network.NamespaceName:         {},
+	resourceruntime.NamespaceName: {},
+}
+
+// CaptureBootDiagnostics captures service logs, kernel log and resources to the STATE partition
+// after the sequence failure.
+//
+// Previously captured snapshot is replaced.
+func CaptureBootDiagnostics(ctx context.Context, r runtime.Runtime, seq runtime.Sequence, seqErr error) error {
+	if r.State().Platform().Mode() == runtime.ModeContainer {
+		return nil
+	}
+
+	st := r.State().V1Alpha2().Resources()
+
+	if _, err := st.Get(ctx, resource.NewMetadata(resourcev1alpha1.NamespaceName, resourceruntime.MountStatusType, constants.StatePartitionLabel, resource.VersionUndefined)); err != nil {
+		return fmt.Errorf("STATE partition is not available: %w", err)
+	}
+
+	snapshot, err := diagnostics.NewSnapshot(constants.BootDiagnosticsPath)
+	if err != nil {
+		return err
+	}
+
+	if err = captureBootDiagnostics(ctx, r, snapshot); err != nil {
+		snapshot.Abort() //nolint:errcheck
+
+		return err
+	}
+
+	return snapshot.Commit(diagnostics.Summary{
+		Timestamp: time.Now().UTC(),
+		Sequence:  seq.String(),
+		Error:     seqErr.Error(),
+	})
+}
+
+//nolint:gocyclo
+func captureBootDiagnostics(ctx context.Context, r runtime.Runtime, snapshot *diagnostics.Snapshot) error {
+	var services bytes.Buffer
+
+	logIDs := []string{"machined", "controller-runtime"}
+
+	for _, svc := range system.Services(r).List() {
+		info := svc.AsProto()
+
+		fmt.Fprintf(&services, "%s\t%s\t%s\n", info.GetId(), info.GetState(), info.GetHealth().GetLastMessage())
+
+		logIDs = append(logIDs, info.GetId())
+	}
+
+	if err := snapshot.WriteFile("services.txt", &services); err != nil {
+		return err
+	}
+
+	for _, id := range logIDs {
+		// service log might not exist if the service was never started
+		rd, err := r.Logging().ServiceLog(id).Reader(runtime.WithTailLines(bootDiagnosticsLogLines))
+		if err != nil {
+			continue
+		}
+
+		err = snapshot.WriteFile("logs/"+id+".log", rd)
+
+		rd.Close() //nolint:errcheck
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := captureKernelLog(ctx, snapshot); err != nil {
+		return err
+	}
+
+	return captureResources(ctx, r, snapshot)
+}
+
+func captureKernelLog(ctx context.Context, snapshot *diagnostics.Snapshot) error {
+	reader, err := kmsg.NewReader()
+	if err != nil {
+		return fmt.Errorf("error opening /dev/kmsg reader: %w", err)
+	}
+
+	defer reader.Close() //nolint:errcheck
+
+	var buf bytes.Buffer
+
+	for packet := range reader.Scan(ctx) {
+		if packet.Err != nil {
+			continue
+		}
+
+		msg := packet.Message
+
+		fmt.Fprintf(&buf, "%s: %7s: [%s]: %s\n", msg.Facility, msg.Priority, msg.Timestamp.Format(time.RFC3339Nano), msg.Message)
+	}
+
+	return snapshot.WriteFile("dmesg.log", &buf)
+}
+
+func captureResources(ctx context.Context, r runtime.Runtime, snapshot *diagnostics.Snapshot) error {
+	st := r.State().V1Alpha2().Resources()
+
+	definitions, err := st.List(ctx, resource.NewMetadata(meta.NamespaceName, meta.ResourceDefinitionType, "", resource.VersionUndefined))
+	if err != nil {
+		return fmt.Errorf("error listing resource definitions: %w", err)
+	}
+
+	for _, definition := range definitions.Items {
+		spec, ok := definition.Spec().(meta.ResourceDefinitionSpec)
+		if !ok {
+			continue
+		}
+
+		if _, ok = bootDiagnosticsNamespaces[spec.DefaultNamespace]; !ok || spec.Sensitivity == meta.Sensitive {
+			continue
+		}
+
+		items, err := st.List(ctx, resource.NewMetadata(spec.DefaultNamespace, spec.Type, "", resource.VersionUndefined))
+		if err != nil {
+			return fmt.Errorf("error listing %s: %w", spec.Type, err)
+		}
+
+		if len(items.Items) == 0 {
+			continue
+		}
+
+		var buf bytes.Buffer
+
+		enc := yaml.NewEncoder(&buf)
+
+		for _, item := range items.Items {
+			out, err := resource.MarshalYAML(item)
+			if err != nil {
+				return err
+			}
+
+			if err = enc.Encode(out); err != nil {
+				return fmt.Errorf("error marshaling %s: %w", item, err)
+			}
+		}
+
+		if err = enc.Close(); err != nil {
+			return err
+		}
+
+		if err = snapshot.WriteFile("resources/"+definition.Metadata().ID()+".yaml", &buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
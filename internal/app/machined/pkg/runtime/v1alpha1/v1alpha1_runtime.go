@@ -7,16 +7,20 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"log"
 	"reflect"
+	"sync"
 
 	"github.com/cosi-project/runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/pkg/secretsresolver"
 	"github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	configresource "github.com/talos-systems/talos/pkg/machinery/resources/config"
 	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
 )
 
@@ -26,6 +30,13 @@ type Runtime struct {
 	s runtime.State
 	e runtime.EventStream
 	l runtime.LoggingManager
+
+	// configuration layers the config is merged from, layersMu serializes the config updates
+	layersMu sync.Mutex
+	layers   runtime.ConfigLayers
+
+	// resolved secret values kept across the config reloads
+	secretsCache *secretsresolver.Cache
 }
 
 // NewRuntime initializes and returns the v1alpha1 runtime.
@@ -35,6 +46,8 @@ func NewRuntime(c config.Provider, s runtime.State, e runtime.EventStream, l run
 		s: s,
 		e: e,
 		l: l,
+
+		secretsCache: secretsresolver.NewCache(),
 	}
 }
 
@@ -57,9 +70,59 @@ func (r *Runtime) ValidateConfig(b []byte) (config.Provider, error) {
 	return cfg, nil
 }
 
+// ConfigLayers implements the Runtime interface.
+func (r *Runtime) ConfigLayers() runtime.ConfigLayers {
+	r.layersMu.Lock()
+	defer r.layersMu.Unlock()
+
+	return r.configLayers()
+}
+
+func (r *Runtime) configLayers() runtime.ConfigLayers {
+	layers := make(runtime.ConfigLayers, len(r.layers))
+
+	for source, b := range r.layers {
+		layers[source] = b
+	}
+
+	return layers
+}
+
+// MergeConfig implements the Runtime interface.
+func (r *Runtime) MergeConfig(layers runtime.ConfigLayers) ([]byte, error) {
+	r.layersMu.Lock()
+	defer r.layersMu.Unlock()
+
+	cfg, _, err := mergeConfigLayers(r.replaceConfigLayers(layers))
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.Bytes()
+}
+
 // SetConfig implements the Runtime interface.
-func (r *Runtime) SetConfig(b []byte) error {
-	cfg, err := r.ValidateConfig(b)
+func (r *Runtime) SetConfig(layers runtime.ConfigLayers) error {
+	r.layersMu.Lock()
+	defer r.layersMu.Unlock()
+
+	layers = r.replaceConfigLayers(layers)
+
+	cfg, sections, err := mergeConfigLayers(layers)
+	if err != nil {
+		r.Events().Publish(&machine.ConfigLoadErrorEvent{
+			Error: err.Error(),
+		})
+
+		return err
+	}
+
+	b, err := cfg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	provider, err := r.ValidateConfig(b)
 	if err != nil {
 		r.Events().Publish(&machine.ConfigLoadErrorEvent{
 			Error: err.Error(),
@@ -68,9 +131,72 @@ func (r *Runtime) SetConfig(b []byte) error {
 		return err
 	}
 
-	r.c = cfg
+	if err = r.resolveSecrets(provider); err != nil {
+		r.Events().Publish(&machine.ConfigLoadErrorEvent{
+			Error: err.Error(),
+		})
+
+		return err
+	}
+
+	r.c = provider
+	r.layers = layers
+
+	if err = r.s.V1Alpha2().SetConfig(provider); err != nil {
+		return err
+	}
+
+	sources := make([]*configresource.MachineConfigSource, 0, len(layers))
+
+	for priority, source := range runtime.ConfigSources {
+		if _, ok := layers[source]; !ok {
+			continue
+		}
+
+		res := configresource.NewMachineConfigSource(source.String())
+		res.TypedSpec().Priority = priority
+		res.TypedSpec().Sections = sections[source]
+
+		sources = append(sources, res)
+	}
+
+	return r.s.V1Alpha2().SetConfigSources(sources)
+}
+
+// resolveSecrets replaces the secret references in the config with the values from the external secrets backends.
+func (r *Runtime) resolveSecrets(cfg config.Provider) error {
+	resolverConfig := cfg.Machine().SecretsResolver()
+	if resolverConfig == nil {
+		return nil
+	}
+
+	resolver, err := secretsresolver.New(resolverConfig, r.secretsCache, log.Default())
+	if err != nil {
+		return fmt.Errorf("failed to set up secrets resolver: %w", err)
+	}
+
+	if err = cfg.ResolveSecrets(context.Background(), resolver); err != nil {
+		return fmt.Errorf("failed to resolve secret references: %w", err)
+	}
+
+	return nil
+}
+
+// replaceConfigLayers returns the current layers with the specified ones replaced, nil layers are removed.
+func (r *Runtime) replaceConfigLayers(layers runtime.ConfigLayers) runtime.ConfigLayers {
+	result := r.configLayers()
+
+	for source, b := range layers {
+		if b == nil {
+			delete(result, source)
+
+			continue
+		}
+
+		result[source] = b
+	}
 
-	return r.s.V1Alpha2().SetConfig(cfg)
+	return result
 }
 
 // CanApplyImmediate implements the Runtime interface.
@@ -111,6 +237,11 @@ func (r *Runtime) CanApplyImmediate(b []byte) error {
 	// * .machine.sysctls
 	// * .machine.logging
 	// * .machine.controlplane
+	// * .machine.apiTLS
+	// * .machine.cri
+	// * .machine.pods
+	// * .machine.nodeLabels
+	// * .machine.kubelet
 	newConfig.ConfigDebug = currentConfig.ConfigDebug
 	newConfig.ClusterConfig = currentConfig.ClusterConfig
 
@@ -121,6 +252,11 @@ func (r *Runtime) CanApplyImmediate(b []byte) error {
 		newConfig.MachineConfig.MachineSysctls = currentConfig.MachineConfig.MachineSysctls
 		newConfig.MachineConfig.MachineLogging = currentConfig.MachineConfig.MachineLogging
 		newConfig.MachineConfig.MachineControlPlane = currentConfig.MachineConfig.MachineControlPlane
+		newConfig.MachineConfig.MachineAPITLS = currentConfig.MachineConfig.MachineAPITLS
+		newConfig.MachineConfig.MachineCRI = currentConfig.MachineConfig.MachineCRI
+		newConfig.MachineConfig.MachinePods = currentConfig.MachineConfig.MachinePods
+		newConfig.MachineConfig.MachineNodeLabels = currentConfig.MachineConfig.MachineNodeLabels
+		newConfig.MachineConfig.MachineKubelet = currentConfig.MachineConfig.MachineKubelet
 	}
 
 	if !reflect.DeepEqual(currentConfig, newConfig) {
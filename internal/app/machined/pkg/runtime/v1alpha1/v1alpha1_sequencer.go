@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 )
 
@@ -122,6 +123,9 @@ func (*Sequencer) Initialize(r runtime.Runtime) []runtime.Phase {
 		).Append(
 			"config",
 			LoadConfig,
+		).Append(
+			"machineIntegrity",
+			WriteMachineIMAPolicy,
 		).AppendWhen(
 			r.State().Machine().Installed(),
 			"unmountSystem",
@@ -162,6 +166,9 @@ func (*Sequencer) Install(r runtime.Runtime) []runtime.Phase {
 			).Append(
 				"saveConfig",
 				SaveConfig,
+			).Append(
+				"recordHistory",
+				RecordUpgradeHistory,
 			).Append(
 				"unmountState",
 				UnmountStatePartition,
@@ -258,7 +265,7 @@ func (*Sequencer) Boot(r runtime.Runtime) []runtime.Phase {
 		"labelMaster",
 		LabelNodeAsMaster,
 	).AppendWhen(
-		r.State().Platform().Mode() != runtime.ModeContainer,
+		r.State().Platform().Mode() != runtime.ModeContainer && !kubeletStandalone(r),
 		"uncordon",
 		UncordonNode,
 	).AppendWhen(
@@ -286,6 +293,13 @@ func (*Sequencer) Bootstrap(r runtime.Runtime) []runtime.Phase {
 // Reboot is the reboot sequence.
 func (*Sequencer) Reboot(r runtime.Runtime) []runtime.Phase {
 	phases := PhaseList{}.Append(
+		"inhibit",
+		WaitForShutdownInhibitors,
+	).AppendWhen(
+		gracefulNodeShutdownEnabled(r),
+		"terminatePods",
+		TerminatePods,
+	).Append(
 		"cleanup",
 		StopAllPods,
 	).
@@ -308,7 +322,7 @@ func (*Sequencer) Reset(r runtime.Runtime, in runtime.ResetOptions) []runtime.Ph
 			)
 	default:
 		phases = phases.AppendWhen(
-			in.GetGraceful(),
+			in.GetGraceful() && !kubeletStandalone(r),
 			"drain",
 			CordonAndDrainNode,
 		).AppendWhen(
@@ -319,6 +333,10 @@ func (*Sequencer) Reset(r runtime.Runtime, in runtime.ResetOptions) []runtime.Ph
 			!in.GetGraceful(),
 			"cleanup",
 			StopAllPods,
+		).AppendWhen(
+			backupEnabled(r, config.Backup.BeforeReset),
+			"backup",
+			BackupVolumes,
 		).AppendWhen(
 			in.GetGraceful() && (r.Config().Machine().Type() != machine.TypeWorker),
 			"leave",
@@ -350,6 +368,11 @@ func (*Sequencer) Reset(r runtime.Runtime, in runtime.ResetOptions) []runtime.Ph
 // Shutdown is the shutdown sequence.
 func (*Sequencer) Shutdown(r runtime.Runtime) []runtime.Phase {
 	phases := PhaseList{}.
+		AppendWhen(
+			gracefulNodeShutdownEnabled(r),
+			"terminatePods",
+			TerminatePods,
+		).
 		Append(
 			"cleanup",
 			StopAllPods,
@@ -369,8 +392,15 @@ func (*Sequencer) StageUpgrade(r runtime.Runtime, in *machineapi.UpgradeRequest)
 		return nil
 	default:
 		phases = phases.Append(
+			"inhibit",
+			WaitForShutdownInhibitors,
+		).Append(
 			"cleanup",
 			StopAllPods,
+		).AppendWhen(
+			!in.GetPreserve() && backupEnabled(r, config.Backup.BeforeUpgrade),
+			"backup",
+			BackupVolumes,
 		).AppendWhen(
 			!in.GetPreserve() && (r.Config().Machine().Type() != machine.TypeWorker),
 			"leave",
@@ -395,6 +425,13 @@ func (*Sequencer) Upgrade(r runtime.Runtime, in *machineapi.UpgradeRequest) []ru
 		return nil
 	default:
 		phases = phases.Append(
+			"inhibit",
+			WaitForShutdownInhibitors,
+		).Append(
+			"recordHistory",
+			RecordUpgradeHistory,
+		).AppendWhen(
+			!kubeletStandalone(r),
 			"drain",
 			CordonAndDrainNode,
 		).AppendWhen(
@@ -405,6 +442,10 @@ func (*Sequencer) Upgrade(r runtime.Runtime, in *machineapi.UpgradeRequest) []ru
 			in.GetPreserve(),
 			"cleanup",
 			StopAllPods,
+		).AppendWhen(
+			!in.GetPreserve() && backupEnabled(r, config.Backup.BeforeUpgrade),
+			"backup",
+			BackupVolumes,
 		).AppendWhen(
 			!in.GetPreserve() && (r.Config().Machine().Type() != machine.TypeWorker),
 			"leave",
@@ -453,6 +494,24 @@ func (*Sequencer) Upgrade(r runtime.Runtime, in *machineapi.UpgradeRequest) []ru
 	return phases
 }
 
+// backupEnabled checks whether the backup should be taken before the operation.
+func backupEnabled(r runtime.Runtime, before func(config.Backup) bool) bool {
+	if r.Config() == nil || r.Config().Machine().Backup() == nil {
+		return false
+	}
+
+	return before(r.Config().Machine().Backup())
+}
+
+func gracefulNodeShutdownEnabled(r runtime.Runtime) bool {
+	return r.Config() != nil && r.Config().Machine().Kubelet().ShutdownGracePeriod() > 0 && !kubeletStandalone(r)
+}
+
+// kubeletStandalone checks whether the node is not registered with the API server, so there is nothing to cordon or drain.
+func kubeletStandalone(r runtime.Runtime) bool {
+	return r.Config() != nil && r.Config().Machine().Kubelet().Standalone()
+}
+
 func stopAllPhaselist(r runtime.Runtime, enableKexec bool) PhaseList {
 	phases := PhaseList{}
 
@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"text/template"
@@ -50,11 +51,13 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/services"
 	"github.com/talos-systems/talos/internal/app/maintenance"
+	"github.com/talos-systems/talos/internal/pkg/backup"
 	"github.com/talos-systems/talos/internal/pkg/containers/cri/containerd"
 	"github.com/talos-systems/talos/internal/pkg/cri"
 	"github.com/talos-systems/talos/internal/pkg/etcd"
 	"github.com/talos-systems/talos/internal/pkg/mount"
 	"github.com/talos-systems/talos/internal/pkg/partition"
+	"github.com/talos-systems/talos/internal/pkg/upgrade"
 	"github.com/talos-systems/talos/pkg/conditions"
 	"github.com/talos-systems/talos/pkg/images"
 	krnl "github.com/talos-systems/talos/pkg/kernel"
@@ -274,7 +277,7 @@ func DropCapabilities(seq runtime.Sequence, data interface{}) (runtime.TaskExecu
 }
 
 // See https://www.kernel.org/doc/Documentation/ABI/testing/ima_policy
-var rules = []string{
+var defaultIMAPolicy = []string{
 	"dont_measure fsmagic=0x9fa0",     // PROC_SUPER_MAGIC
 	"dont_measure fsmagic=0x62656572", // SYSFS_MAGIC
 	"dont_measure fsmagic=0x64626720", // DEBUGFS_MAGIC
@@ -300,27 +303,60 @@ var rules = []string{
 }
 
 // WriteIMAPolicy represents the WriteIMAPolicy task.
+//
+// Default policy is loaded before the machine configuration, so that the measurements cover the maintenance mode as well.
 func WriteIMAPolicy(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
-		if _, err = os.Stat("/sys/kernel/security/ima/policy"); os.IsNotExist(err) {
+		if _, err = os.Stat(constants.IMAPolicyPath); os.IsNotExist(err) {
 			return fmt.Errorf("policy file does not exist: %w", err)
 		}
 
-		f, err := os.OpenFile("/sys/kernel/security/ima/policy", os.O_APPEND|os.O_WRONLY, 0o644)
-		if err != nil {
+		return writeIMAPolicyRules(defaultIMAPolicy)
+	}, "writeIMAPolicy"
+}
+
+// WriteMachineIMAPolicy represents the WriteMachineIMAPolicy task.
+//
+// Policy rules from the machine configuration are appended to the default policy,
+// which is possible only if the kernel allows policy updates (CONFIG_IMA_WRITE_POLICY).
+func WriteMachineIMAPolicy(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		rules := r.Config().Machine().IMA().Policy()
+		if len(rules) == 0 {
+			return nil
+		}
+
+		// once the policy is loaded, the kernel removes the policy file or makes it read-only unless CONFIG_IMA_WRITE_POLICY is enabled
+		st, err := os.Stat(constants.IMAPolicyPath)
+		if err != nil && !os.IsNotExist(err) {
 			return err
 		}
 
-		defer f.Close() //nolint:errcheck
+		if err != nil || st.Mode().Perm()&0o200 == 0 {
+			return fmt.Errorf("IMA policy from the machine configuration can't be loaded: kernel doesn't allow IMA policy updates (CONFIG_IMA_WRITE_POLICY)")
+		}
 
-		for _, line := range rules {
-			if _, err = f.WriteString(line + "\n"); err != nil {
-				return fmt.Errorf("rule %q is invalid", err)
-			}
+		logger.Printf("appending IMA policy rules from the machine configuration")
+
+		return writeIMAPolicyRules(rules)
+	}, "writeMachineIMAPolicy"
+}
+
+func writeIMAPolicyRules(rules []string) error {
+	f, err := os.OpenFile(constants.IMAPolicyPath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	for _, line := range rules {
+		if _, err = f.WriteString(line + "\n"); err != nil {
+			return fmt.Errorf("rule %q is invalid: %w", line, err)
 		}
+	}
 
-		return nil
-	}, "writeIMAPolicy"
+	return nil
 }
 
 const osReleaseTemplate = `
@@ -408,66 +444,121 @@ func CreateOSReleaseFile(seq runtime.Sequence, data interface{}) (runtime.TaskEx
 }
 
 // LoadConfig represents the LoadConfig task.
+//
+// Configuration is merged from the layers: the one stored on disk, the one fetched from the platform and the one applied via the API.
+//
+//nolint:gocyclo,cyclop
 func LoadConfig(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
-		download := func() error {
-			var b []byte
+		layers := runtime.ConfigLayers{}
 
-			fetchCtx, ctxCancel := context.WithTimeout(ctx, 70*time.Second)
-			defer ctxCancel()
+		cfg, err := configloader.NewFromFile(constants.ConfigPath)
 
-			b, e := fetchConfig(fetchCtx, r)
-			if errors.Is(e, perrors.ErrNoConfigSource) {
-				logger.Println("machine configuration not found; starting maintenance service")
+		switch {
+		case err != nil:
+			logger.Printf("existing config not found")
+		case !cfg.Persist():
+			logger.Printf("found existing config, but persistence is disabled")
+		default:
+			logger.Printf("persistence is enabled, using existing config on disk")
+
+			if layers, err = runtime.LoadConfigLayers(); err != nil {
+				return err
+			}
+
+			if err = applyConfigRevert(logger, r, layers); err != nil {
+				return err
+			}
 
-				b, e = receiveConfigViaMaintenanceService(ctx, logger, r)
-				if e != nil {
-					return fmt.Errorf("failed to receive config via maintenance service: %w", e)
+			if len(layers) == 0 {
+				// config was stored before config layers were introduced
+				if layers[runtime.ConfigSourceDisk], err = cfg.Bytes(); err != nil {
+					r.Events().Publish(&machineapi.ConfigLoadErrorEvent{
+						Error: err.Error(),
+					})
+
+					return err
 				}
 			}
+		}
 
-			if e != nil {
+		// platform config is fetched on every boot once it was used, as it might change
+		if _, ok := layers[runtime.ConfigSourcePlatform]; ok || len(layers) == 0 {
+			logger.Printf("downloading config")
+
+			fetchCtx, ctxCancel := context.WithTimeout(ctx, 70*time.Second)
+			defer ctxCancel()
+
+			b, e := fetchConfig(fetchCtx, r)
+
+			switch {
+			case e == nil:
+				layers[runtime.ConfigSourcePlatform] = b
+			case errors.Is(e, perrors.ErrNoConfigSource):
+				delete(layers, runtime.ConfigSourcePlatform)
+			case len(layers) > 0:
+				logger.Printf("failed to download config, using the stored one: %s", e)
+			default:
 				r.Events().Publish(&machineapi.ConfigLoadErrorEvent{
-					Error: err.Error(),
+					Error: e.Error(),
 				})
 
 				return e
 			}
+		}
+
+		if len(layers) == 0 {
+			logger.Println("machine configuration not found; starting maintenance service")
 
-			logger.Printf("storing config in memory")
+			b, e := receiveConfigViaMaintenanceService(ctx, logger, r)
+			if e != nil {
+				return fmt.Errorf("failed to receive config via maintenance service: %w", e)
+			}
 
-			return r.SetConfig(b)
+			layers[runtime.ConfigSourceDisk] = b
 		}
 
-		cfg, err := configloader.NewFromFile(constants.ConfigPath)
-		if err != nil {
-			logger.Printf("downloading config")
+		logger.Printf("storing config in memory")
 
-			return download()
-		}
+		return r.SetConfig(layers)
+	}, "loadConfig"
+}
 
-		if !cfg.Persist() {
-			logger.Printf("found existing config, but persistence is disabled, downloading config")
+// applyConfigRevert reverts the config applied via the API if the node rebooted before the config was confirmed.
+func applyConfigRevert(logger *log.Logger, r runtime.Runtime, layers runtime.ConfigLayers) error {
+	revert, err := runtime.LoadConfigRevert()
+	if err != nil || revert == nil {
+		return err
+	}
 
-			return download()
-		}
+	if previous := revert.Layer(); previous != nil {
+		layers[runtime.ConfigSourceAPI] = previous
+	} else {
+		delete(layers, runtime.ConfigSourceAPI)
+	}
 
-		logger.Printf("persistence is enabled, using existing config on disk")
+	if err = layers.Save(); err != nil {
+		return err
+	}
 
-		b, err := cfg.Bytes()
-		if err != nil {
-			r.Events().Publish(&machineapi.ConfigLoadErrorEvent{
-				Error: err.Error(),
-			})
+	if err = runtime.RemoveConfigRevert(); err != nil {
+		return err
+	}
 
-			return err
-		}
+	reason := fmt.Sprintf("node rebooted before the applied config was confirmed at %s", revert.Deadline.Format(time.RFC3339))
 
-		return r.SetConfig(b)
-	}, "loadConfig"
+	logger.Printf("config reverted: %s", reason)
+
+	r.Events().Publish(&machineapi.ConfigRevertedEvent{
+		Reason: reason,
+	})
+
+	return nil
 }
 
 // SaveConfig represents the SaveConfig task.
+//
+// Configuration layers are stored to be merged on the next boot, while the merged config is stored for reference.
 func SaveConfig(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
 		if err = r.Config().ApplyDynamicConfig(ctx, r.State().Platform()); err != nil {
@@ -479,6 +570,10 @@ func SaveConfig(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFu
 			return err
 		}
 
+		if err = r.ConfigLayers().Save(); err != nil {
+			return err
+		}
+
 		var b []byte
 
 		b, err = r.Config().Bytes()
@@ -864,7 +959,12 @@ func mountDisks(r runtime.Runtime) (err error) {
 				}
 			}
 
-			mountpoints.Set(partname, mount.NewMountPoint(partname, part.MountPoint(), "xfs", unix.MS_NOATIME, ""))
+			flags, data, err := mount.VolumeMountFlags(part)
+			if err != nil {
+				return fmt.Errorf("invalid mount options for %q: %w", part.MountPoint(), err)
+			}
+
+			mountpoints.Set(partname, mount.NewMountPoint(partname, part.MountPoint(), "xfs", flags, data))
 		}
 	}
 
@@ -902,7 +1002,7 @@ func WriteUserFiles(seq runtime.Sequence, data interface{}) (runtime.TaskExecuti
 			return fmt.Errorf("error generating extra files: %w", err)
 		}
 
-		extra, err := containerd.GenerateRegistriesConfig(r.Config().Machine().Registries())
+		extra, err := containerd.GenerateCRIConfig(r.Config().Machine().Registries(), r.Config().Machine().CRI())
 		if err != nil {
 			return err
 		}
@@ -1142,6 +1242,8 @@ func UnmountSystemDiskBindMounts(seq runtime.Sequence, data interface{}) (runtim
 
 // CordonAndDrainNode represents the task for stop all containerd tasks in the
 // k8s.io namespace.
+//
+// The drain progress is reported via DrainEvent events.
 func CordonAndDrainNode(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
 		var nodename string
@@ -1158,10 +1260,189 @@ func CordonAndDrainNode(seq runtime.Sequence, data interface{}) (runtime.TaskExe
 
 		defer kubeHelper.Close() //nolint:errcheck
 
-		return kubeHelper.CordonAndDrain(ctx, nodename)
+		if err = kubeHelper.Cordon(ctx, nodename); err != nil {
+			return err
+		}
+
+		started := time.Now()
+
+		var progress kubernetes.DrainProgress
+
+		publish := func(phase string, drainErr error) {
+			event := &machineapi.DrainEvent{
+				Node:           nodename,
+				Phase:          phase,
+				RemainingPods:  progress.Remaining,
+				BlockedPods:    progress.Blocked,
+				ElapsedSeconds: int64(time.Since(started) / time.Second),
+				TimeoutSeconds: int64(kubernetes.DrainTimeout / time.Second),
+			}
+
+			if drainErr != nil {
+				event.Error = drainErr.Error()
+			}
+
+			r.Events().Publish(event)
+		}
+
+		if err = kubeHelper.DrainWithProgress(ctx, nodename, func(p kubernetes.DrainProgress) {
+			progress = p
+
+			publish(resourceruntime.DrainPhaseDraining, nil)
+		}); err != nil {
+			publish(resourceruntime.DrainPhaseFailed, err)
+
+			return err
+		}
+
+		publish(resourceruntime.DrainPhaseDrained, nil)
+
+		return nil
 	}, "cordonAndDrainNode"
 }
 
+// TerminatePods represents the task for the graceful termination of the pods before the node shutdown.
+//
+// The node is cordoned, and the pods are terminated within the kubelet shutdown grace periods, so that the pods are
+// stopped cleanly before the kubelet is stopped. Failures are logged, and the sequence continues with stopping all pods.
+func TerminatePods(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		var nodename string
+
+		if nodename, err = r.NodeName(); err != nil {
+			return err
+		}
+
+		var kubeHelper *kubernetes.Client
+
+		if kubeHelper, err = kubernetes.NewClientFromKubeletKubeconfig(); err != nil {
+			logger.Printf("skipping graceful pod termination: %s", err)
+
+			return nil
+		}
+
+		defer kubeHelper.Close() //nolint:errcheck
+
+		gracePeriod := r.Config().Machine().Kubelet().ShutdownGracePeriod()
+		criticalGracePeriod := r.Config().Machine().Kubelet().ShutdownGracePeriodCriticalPods()
+
+		shutdownCtx, shutdownCtxCancel := context.WithTimeout(ctx, gracePeriod)
+		defer shutdownCtxCancel()
+
+		if err = kubeHelper.Cordon(shutdownCtx, nodename); err != nil {
+			logger.Printf("failed to cordon the node: %s", err)
+		}
+
+		logger.Printf("terminating pods with grace period %s (%s for critical pods)", gracePeriod, criticalGracePeriod)
+
+		if err = kubeHelper.TerminatePods(shutdownCtx, nodename, gracePeriod, criticalGracePeriod); err != nil {
+			logger.Printf("failed to terminate pods: %s", err)
+		}
+
+		return nil
+	}, "terminatePods"
+}
+
+// WaitForShutdownInhibitors represents the task for waiting for the workloads to remove the shutdown inhibitors from the node.
+//
+// Inhibitors delay the sequence up to constants.ShutdownInhibitMaxDuration, the check is skipped if the request asks to ignore the inhibitors.
+//
+//nolint:gocyclo
+func WaitForShutdownInhibitors(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		if in, ok := data.(interface{ GetIgnoreInhibitors() bool }); ok && in.GetIgnoreInhibitors() {
+			logger.Printf("ignoring shutdown inhibitors")
+
+			return nil
+		}
+
+		var nodename string
+
+		if nodename, err = r.NodeName(); err != nil {
+			return err
+		}
+
+		var kubeHelper *kubernetes.Client
+
+		// inhibitors are advisory, so the sequence is never blocked by the Kubernetes API being unavailable
+		if kubeHelper, err = kubernetes.NewClientFromKubeletKubeconfig(); err != nil {
+			logger.Printf("skipping shutdown inhibitors check: %s", err)
+
+			return nil
+		}
+
+		defer kubeHelper.Close() //nolint:errcheck
+
+		timeout := time.NewTimer(constants.ShutdownInhibitMaxDuration)
+		defer timeout.Stop()
+
+		ticker := time.NewTicker(constants.ShutdownInhibitCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			var inhibitors map[string]string
+
+			inhibitors, err = kubeHelper.ShutdownInhibitors(ctx, nodename)
+
+			switch {
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case err != nil:
+				logger.Printf("skipping shutdown inhibitors check: %s", err)
+
+				return nil
+			case len(inhibitors) == 0:
+				return nil
+			}
+
+			names := make([]string, 0, len(inhibitors))
+
+			for name, reason := range inhibitors {
+				names = append(names, fmt.Sprintf("%s (%s)", name, reason))
+			}
+
+			sort.Strings(names)
+
+			logger.Printf("%s is inhibited by: %s", seq, strings.Join(names, ", "))
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timeout.C:
+				logger.Printf("shutdown inhibitors were not removed in %s, proceeding with %s", constants.ShutdownInhibitMaxDuration, seq)
+
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}, "waitForShutdownInhibitors"
+}
+
+// BackupVolumes represents the task for backing up the host directories before the destructive operations.
+func BackupVolumes(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		cfg := r.Config().Machine().Backup()
+
+		var nodename string
+
+		if nodename, err = r.NodeName(); err != nil {
+			return err
+		}
+
+		if err = backup.Run(ctx, logger, cfg, nodename, time.Now()); err != nil {
+			if cfg.ContinueOnError() {
+				logger.Printf("backup failed, continuing: %s", err)
+
+				return nil
+			}
+
+			return fmt.Errorf("backup failed, aborting %s: %w", seq, err)
+		}
+
+		return nil
+	}, "backupVolumes"
+}
+
 // UncordonNode represents the task for mark node as scheduling enabled.
 //
 // This action undoes the CordonAndDrainNode task.
@@ -1197,7 +1478,7 @@ func UncordonNode(seq runtime.Sequence, data interface{}) (runtime.TaskExecution
 // LeaveEtcd represents the task for removing a control plane node from etcd.
 func LeaveEtcd(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
-		client, err := etcd.NewClientFromControlPlaneIPs(ctx, r.Config().Cluster().CA(), r.Config().Cluster().Endpoint())
+		client, err := etcd.NewSharedClientFromControlPlaneIPs(ctx, r.Config().Cluster().CA(), r.Config().Cluster().InternalEndpoint())
 		if err != nil {
 			return fmt.Errorf("failed to create etcd client: %w", err)
 		}
@@ -1398,10 +1679,51 @@ func Upgrade(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 	}, "upgrade"
 }
 
+// RecordUpgradeHistory represents the task for recording pending install or upgrade in the history.
+//
+// The outcome is resolved on the next boot.
+func RecordUpgradeHistory(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
+	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
+		entry := resourceruntime.UpgradeHistorySpec{
+			Timestamp: time.Now().UTC().Truncate(time.Second),
+		}
+
+		switch {
+		case seq == runtime.SequenceUpgrade:
+			in, ok := data.(*machineapi.UpgradeRequest)
+			if !ok {
+				return runtime.ErrInvalidSequenceData
+			}
+
+			entry.Action = resourceruntime.UpgradeActionUpgrade
+			entry.FromVersion = version.Tag
+			entry.Image = in.GetImage()
+		case r.State().Machine().IsInstallStaged():
+			entry.Action = resourceruntime.UpgradeActionUpgrade
+			entry.FromVersion = version.Tag
+			entry.Image = r.State().Machine().StagedInstallImageRef()
+		default:
+			entry.Action = resourceruntime.UpgradeActionInstall
+			entry.Image = r.Config().Machine().Install().Image()
+
+			if entry.Image == "" {
+				entry.Image = images.DefaultInstallerImage
+			}
+		}
+
+		// history is informational, so failure to record it shouldn't block the upgrade
+		if err = upgrade.RecordPending(filepath.Join(constants.StateMountPoint, constants.UpgradeHistoryFilename), entry); err != nil {
+			logger.Printf("failed to record upgrade history: %s", err)
+		}
+
+		return nil
+	}, "recordUpgradeHistory"
+}
+
 // LabelNodeAsMaster represents the LabelNodeAsMaster task.
 func LabelNodeAsMaster(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc, string) {
 	return func(ctx context.Context, logger *log.Logger, r runtime.Runtime) (err error) {
-		h, err := kubernetes.NewTemporaryClientFromPKI(r.Config().Cluster().CA(), r.Config().Cluster().Endpoint())
+		h, err := kubernetes.NewTemporaryClientFromPKI(r.Config().Cluster().CA(), r.Config().Cluster().InternalEndpoint())
 		if err != nil {
 			return err
 		}
@@ -1648,7 +1970,8 @@ func Install(seq runtime.Sequence, data interface{}) (runtime.TaskExecutionFunc,
 				r.Config().Machine().Registries(),
 				install.WithForce(true),
 				install.WithZero(r.Config().Machine().Install().Zero()),
-				install.WithExtraKernelArgs(r.Config().Machine().Install().ExtraKernelArgs()),
+				install.WithExtraKernelArgs(install.ExtraKernelArgs(r.Config().Machine())),
+				install.WithAssetsURL(r.Config().Machine().Install().AssetsURL()),
 			)
 			if err != nil {
 				return err
@@ -5,9 +5,9 @@
 package v1alpha2
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"net/url"
 	"sync"
 	"time"
 
@@ -21,6 +21,7 @@ import (
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/cluster"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/config"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/etcd"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/files"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/k8s"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/controllers/kubespan"
@@ -46,6 +47,9 @@ type Controller struct {
 	loggingManager  runtime.LoggingManager
 	consoleLogLevel zap.AtomicLevel
 	logger          *zap.Logger
+	health          *controllerHealth
+	watchdog        *watchdog
+	pauses          *controllerPauses
 
 	v1alpha1Runtime runtime.Runtime
 }
@@ -54,10 +58,18 @@ type Controller struct {
 func NewController(v1alpha1Runtime runtime.Runtime) (*Controller, error) {
 	ctrl := &Controller{
 		consoleLogLevel: zap.NewAtomicLevel(),
+		health:          newControllerHealth(),
 		loggingManager:  v1alpha1Runtime.Logging(),
 		v1alpha1Runtime: v1alpha1Runtime,
 	}
 
+	ctrl.watchdog = &watchdog{
+		health:  ctrl.health,
+		timeout: watchdogTimeout,
+	}
+
+	ctrl.pauses = newControllerPauses(ctrl.health)
+
 	logWriter, err := ctrl.loggingManager.ServiceLog("controller-runtime").Writer()
 	if err != nil {
 		return nil, err
@@ -66,6 +78,10 @@ func NewController(v1alpha1Runtime runtime.Runtime) (*Controller, error) {
 	ctrl.logger = logging.ZapLogger(
 		logging.NewLogDestination(logWriter, zapcore.DebugLevel, logging.WithColoredLevels()),
 		logging.NewLogDestination(logging.StdWriter, ctrl.consoleLogLevel, logging.WithoutTimestamp(), logging.WithoutLogLevels()),
+	).WithOptions(
+		zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, ctrl.health.Core())
+		}),
 	).With(logging.Component("controller-runtime"))
 
 	ctrl.controllerRuntime, err = osruntime.NewRuntime(v1alpha1Runtime.State().V1Alpha2().Resources(), ctrl.logger)
@@ -97,21 +113,44 @@ func (ctrl *Controller) Run(ctx context.Context, drainer *runtime.Drainer) error
 		&cluster.NodeIdentityController{
 			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
 		},
+		&config.DeprecationController{},
+		&config.FeatureStatusController{},
 		&config.MachineTypeController{},
 		&config.K8sAddressFilterController{},
 		&config.K8sControlPlaneController{},
+		&etcd.PKIController{},
+		&etcd.SpecController{},
 		&files.EtcFileController{
 			EtcPath:    "/etc",
 			ShadowPath: constants.SystemEtcPath,
 		},
 		&k8s.ControlPlaneStaticPodController{},
 		&k8s.EndpointController{},
+		&k8s.KubeletBootstrapKubeconfigController{},
+		&k8s.KubeletBootstrapTokenController{
+			V1Alpha1Platform: ctrl.v1alpha1Runtime.State().Platform(),
+		},
+		&k8s.KubeletCertificateController{},
+		&k8s.KubeletConfigController{},
+		&k8s.KubeletNodeRegistrationController{},
+		&k8s.KubeletServiceController{},
+		&k8s.KubeletSpecController{},
+		&k8s.KubeletServingCertApprovalController{},
 		&k8s.ExtraManifestController{},
+		&k8s.HealthCheckTaintsController{},
 		&k8s.KubeletStaticPodController{},
+		&k8s.LoadBalancerConfigController{},
+		&k8s.LoadBalancerController{},
 		&k8s.ManifestController{},
 		&k8s.ManifestApplyController{},
+		&k8s.NodeLabelSpecController{},
+		&k8s.NodeLabelsApplyController{},
 		&k8s.NodenameController{},
 		&k8s.RenderSecretsStaticPodController{},
+		&k8s.StaticPodConfigController{},
+		&k8s.StaticPodRecoveryController{
+			V1Alpha1Events: ctrl.v1alpha1Runtime.Events(),
+		},
 		&kubespan.ConfigController{},
 		&kubespan.EndpointController{},
 		&kubespan.IdentityController{},
@@ -127,6 +166,7 @@ func (ctrl *Controller) Run(ctx context.Context, drainer *runtime.Drainer) error
 		&network.AddressMergeController{},
 		&network.AddressSpecController{},
 		&network.AddressStatusController{},
+		&network.ConntrackStatsController{},
 		&network.EtcFileController{},
 		&network.HardwareAddrController{},
 		&network.HostnameConfigController{
@@ -142,6 +182,7 @@ func (ctrl *Controller) Run(ctx context.Context, drainer *runtime.Drainer) error
 		&network.LinkMergeController{},
 		&network.LinkStatusController{},
 		&network.LinkSpecController{},
+		&network.LinkTuningController{},
 		&network.NodeAddressController{},
 		&network.OperatorConfigController{
 			Cmdline: procfs.ProcCmdline(),
@@ -170,22 +211,65 @@ func (ctrl *Controller) Run(ctx context.Context, drainer *runtime.Drainer) error
 		},
 		&network.TimeServerMergeController{},
 		&network.TimeServerSpecController{},
+		&network.TrafficShapingController{},
 		&perf.StatsController{},
+		&runtimecontrollers.AcceleratorController{},
+		&runtimecontrollers.BMCInfoController{},
+		&runtimecontrollers.BootDiagnosticsController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&runtimecontrollers.CPUPowerController{},
+		&runtimecontrollers.ControllerStatusController{
+			Source:         ctrl,
+			V1Alpha1Events: ctrl.v1alpha1Runtime.Events(),
+		},
+		&runtimecontrollers.DrainStatusController{
+			V1Alpha1Events: ctrl.v1alpha1Runtime.Events(),
+		},
 		&runtimecontrollers.EventsSinkController{
 			V1Alpha1Events: ctrl.v1alpha1Runtime.Events(),
 			Cmdline:        procfs.ProcCmdline(),
 			Drainer:        drainer,
 		},
+		&runtimecontrollers.DisksController{},
+		&runtimecontrollers.HardwareFactsController{},
+		&runtimecontrollers.HealthCheckController{},
+		&runtimecontrollers.HugePagesController{},
+		&runtimecontrollers.InstanceIdentityController{
+			V1Alpha1Platform: ctrl.v1alpha1Runtime.State().Platform(),
+		},
+		&runtimecontrollers.IRQAffinityController{},
 		&runtimecontrollers.KernelParamConfigController{},
 		&runtimecontrollers.KernelParamDefaultsController{
 			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
 		},
 		&runtimecontrollers.KernelParamSpecController{},
+		&runtimecontrollers.KernelStatusController{},
 		&runtimecontrollers.KmsgLogDeliveryController{
 			Cmdline: procfs.ProcCmdline(),
 			Drainer: drainer,
 		},
-		&secrets.APIController{},
+		&runtimecontrollers.LifecycleWebhookController{
+			V1Alpha1Events: ctrl.v1alpha1Runtime.Events(),
+			Drainer:        drainer,
+		},
+		&runtimecontrollers.LogRateLimitController{
+			LoggingManager: ctrl.loggingManager,
+		},
+		&runtimecontrollers.MachineIdentityController{},
+		&runtimecontrollers.RootfsVerityController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+			Cmdline:      procfs.ProcCmdline(),
+		},
+		&runtimecontrollers.UpgradeHistoryController{
+			V1Alpha1Mode: ctrl.v1alpha1Runtime.State().Platform().Mode(),
+		},
+		&runtimecontrollers.UpgradeStatusController{
+			V1Alpha1Events: ctrl.v1alpha1Runtime.Events(),
+		},
+		&secrets.APIController{
+			V1alpha1Platform: ctrl.v1alpha1Runtime.State().Platform(),
+		},
 		&secrets.APICertSANsController{},
 		&secrets.EtcdController{},
 		&secrets.KubernetesController{},
@@ -195,7 +279,14 @@ func (ctrl *Controller) Run(ctx context.Context, drainer *runtime.Drainer) error
 			Cmdline: procfs.ProcCmdline(),
 		},
 	} {
-		if err := ctrl.controllerRuntime.RegisterController(c); err != nil {
+		wrapped := ctrl.watchdog.Wrap(c)
+
+		// controller status controller is never paused, as it reports the paused controllers
+		if _, ok := c.(*runtimecontrollers.ControllerStatusController); !ok {
+			wrapped = ctrl.pauses.Wrap(wrapped)
+		}
+
+		if err := ctrl.controllerRuntime.RegisterController(wrapped); err != nil {
 			return err
 		}
 	}
@@ -208,6 +299,21 @@ func (ctrl *Controller) DependencyGraph() (*controller.DependencyGraph, error) {
 	return ctrl.controllerRuntime.GetDependencyGraph()
 }
 
+// ControllerStatuses returns the health of the controllers sorted by name.
+func (ctrl *Controller) ControllerStatuses() []runtime.ControllerStatus {
+	return ctrl.health.Statuses()
+}
+
+// PauseControllers pauses the reconciliation of the controllers matching the names or groups until the deadline.
+func (ctrl *Controller) PauseControllers(controllers []string, until time.Time) ([]string, error) {
+	return ctrl.pauses.Pause(controllers, until)
+}
+
+// ResumeControllers resumes the paused controllers matching the names or groups.
+func (ctrl *Controller) ResumeControllers(controllers []string) []string {
+	return ctrl.pauses.Resume(controllers)
+}
+
 func (ctrl *Controller) watchMachineConfig(ctx context.Context) {
 	watchCh := make(chan state.Event)
 
@@ -221,7 +327,7 @@ func (ctrl *Controller) watchMachineConfig(ctx context.Context) {
 		return
 	}
 
-	var loggingEndpoints []*url.URL
+	var loggingDests []talosconfig.LoggingDestination
 
 	for {
 		var cfg talosconfig.Provider
@@ -238,7 +344,7 @@ func (ctrl *Controller) watchMachineConfig(ctx context.Context) {
 		}
 
 		ctrl.updateConsoleLoggingConfig(cfg)
-		ctrl.updateLoggingConfig(ctx, cfg, &loggingEndpoints)
+		ctrl.updateLoggingConfig(ctx, cfg, &loggingDests)
 	}
 }
 
@@ -254,24 +360,23 @@ func (ctrl *Controller) updateConsoleLoggingConfig(cfg talosconfig.Provider) {
 	}
 }
 
-func (ctrl *Controller) updateLoggingConfig(ctx context.Context, cfg talosconfig.Provider, prevLoggingEndpoints *[]*url.URL) {
+func (ctrl *Controller) updateLoggingConfig(ctx context.Context, cfg talosconfig.Provider, prevLoggingDests *[]talosconfig.LoggingDestination) {
 	dests := cfg.Machine().Logging().Destinations()
-	loggingEndpoints := make([]*url.URL, len(dests))
 
-	for i, dest := range dests {
+	for _, dest := range dests {
 		switch f := dest.Format(); f {
 		case constants.LoggingFormatJSONLines:
-			loggingEndpoints[i] = dest.Endpoint()
+			// nothing
 		default:
 			// should not be possible due to validation
 			panic(fmt.Sprintf("unhandled log destination format %q", f))
 		}
 	}
 
-	loggingChanged := len(*prevLoggingEndpoints) != len(loggingEndpoints)
+	loggingChanged := len(*prevLoggingDests) != len(dests)
 	if !loggingChanged {
-		for i, u := range *prevLoggingEndpoints {
-			if u.String() != loggingEndpoints[i].String() {
+		for i, dest := range *prevLoggingDests {
+			if dest.Endpoint().String() != dests[i].Endpoint().String() || !bytes.Equal(dest.CA(), dests[i].CA()) {
 				loggingChanged = true
 
 				break
@@ -283,14 +388,14 @@ func (ctrl *Controller) updateLoggingConfig(ctx context.Context, cfg talosconfig
 		return
 	}
 
-	*prevLoggingEndpoints = loggingEndpoints
+	*prevLoggingDests = dests
 
 	var prevSenders []runtime.LogSender
 
-	if len(loggingEndpoints) > 0 {
-		senders := make([]runtime.LogSender, len(loggingEndpoints))
-		for i, u := range loggingEndpoints {
-			senders[i] = runtimelogging.NewJSONLines(u)
+	if len(dests) > 0 {
+		senders := make([]runtime.LogSender, len(dests))
+		for i, dest := range dests {
+			senders[i] = runtimelogging.NewJSONLines(dest.Endpoint(), runtimelogging.NodeIdentityTLSConfig(ctrl.v1alpha1Runtime.State().V1Alpha2().Resources(), dest.CA()))
 		}
 
 		ctrl.logger.Info("enabling JSON logging")
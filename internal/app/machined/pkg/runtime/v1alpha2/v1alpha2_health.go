@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+)
+
+// Messages logged by the controller runtime around the controller lifecycle.
+const (
+	controllerStartingMessage = "controller starting"
+	controllerFailedMessage   = "controller failed"
+	controllerFinishedMessage = "controller finished"
+)
+
+// controllerHealth tracks the health of the controllers based on the controller runtime logs.
+//
+// Controller runtime doesn't expose controller state, but every log entry of the controller
+// carries the controller name, so the lifecycle messages and logged errors are used instead.
+type controllerHealth struct {
+	mu       sync.Mutex
+	statuses map[string]*runtime.ControllerStatus
+}
+
+func newControllerHealth() *controllerHealth {
+	return &controllerHealth{
+		statuses: map[string]*runtime.ControllerStatus{},
+	}
+}
+
+// Core returns zap core which should be teed with the controller runtime logger core.
+func (health *controllerHealth) Core() zapcore.Core {
+	return &healthCore{
+		health: health,
+	}
+}
+
+// Statuses returns the controller statuses sorted by controller name.
+func (health *controllerHealth) Statuses() []runtime.ControllerStatus {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	statuses := make([]runtime.ControllerStatus, 0, len(health.statuses))
+
+	for _, status := range health.statuses {
+		statuses = append(statuses, *status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+
+	return statuses
+}
+
+func (health *controllerHealth) update(controller string, entry zapcore.Entry, fields []zapcore.Field) {
+	var errorMessage string
+
+	for _, field := range fields {
+		if field.Type == zapcore.ErrorType && field.Key == "error" {
+			if err, ok := field.Interface.(error); ok {
+				errorMessage = err.Error()
+			}
+		}
+	}
+
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	status := health.status(controller)
+
+	switch entry.Message {
+	case controllerStartingMessage:
+		status.Running = true
+	case controllerFinishedMessage:
+		status.Running = false
+	case controllerFailedMessage:
+		status.Running = false
+		status.Crashes++
+	}
+
+	if errorMessage != "" && entry.Level >= zapcore.WarnLevel {
+		status.LastError = errorMessage
+		status.LastErrorTime = entry.Time
+	}
+}
+
+// SetWedged updates the watchdog state of the controller.
+func (health *controllerHealth) SetWedged(controller string, wedged bool) {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	health.status(controller).Wedged = wedged
+}
+
+// SetPausedUntil updates the pause state of the controller, zero time means the controller is not paused.
+func (health *controllerHealth) SetPausedUntil(controller string, until time.Time) {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	health.status(controller).PausedUntil = until
+}
+
+func (health *controllerHealth) status(controller string) *runtime.ControllerStatus {
+	status, ok := health.statuses[controller]
+	if !ok {
+		status = &runtime.ControllerStatus{
+			Name: controller,
+		}
+
+		health.statuses[controller] = status
+	}
+
+	return status
+}
+
+// healthCore feeds the controller log entries to the controllerHealth.
+type healthCore struct {
+	health     *controllerHealth
+	controller string
+}
+
+func (core *healthCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (core *healthCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *core
+
+	for _, field := range fields {
+		if field.Key == "controller" && field.Type == zapcore.StringType {
+			clone.controller = field.String
+		}
+	}
+
+	return &clone
+}
+
+func (core *healthCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if core.controller == "" {
+		return checked
+	}
+
+	switch {
+	case entry.Level >= zapcore.WarnLevel,
+		entry.Message == controllerStartingMessage,
+		entry.Message == controllerFinishedMessage:
+		return checked.AddCore(entry, core)
+	default:
+		return checked
+	}
+}
+
+func (core *healthCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	core.health.update(core.controller, entry, fields)
+
+	return nil
+}
+
+func (core *healthCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2 //nolint:testpackage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cosi-project/runtime/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestControllerHealth(t *testing.T) {
+	health := newControllerHealth()
+
+	logger := zap.NewNop().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, health.Core())
+	}))
+
+	logger.Error("not a controller", zap.Error(errors.New("ignored")))
+
+	healthy := logger.With(logging.Controller("HealthyController"))
+	healthy.Debug(controllerStartingMessage)
+	healthy.Debug("reconciled")
+
+	crashing := logger.With(logging.Controller("CrashingController"))
+	crashing.Debug(controllerStartingMessage)
+	crashing.Error(controllerFailedMessage, zap.Error(errors.New("boom")))
+	crashing.Debug(controllerStartingMessage)
+	crashing.Error(controllerFailedMessage, zap.Error(errors.New("boom again")))
+
+	warning := logger.With(logging.Controller("WarningController"))
+	warning.Debug(controllerStartingMessage)
+	warning.Warn("error reconciling", zap.Error(errors.New("transient")))
+
+	statuses := health.Statuses()
+	require.Len(t, statuses, 3)
+
+	assert.Equal(t, "CrashingController", statuses[0].Name)
+	assert.False(t, statuses[0].Running)
+	assert.Equal(t, 2, statuses[0].Crashes)
+	assert.Equal(t, "boom again", statuses[0].LastError)
+	assert.False(t, statuses[0].LastErrorTime.IsZero())
+
+	assert.Equal(t, "HealthyController", statuses[1].Name)
+	assert.True(t, statuses[1].Running)
+	assert.Zero(t, statuses[1].Crashes)
+	assert.Empty(t, statuses[1].LastError)
+
+	assert.Equal(t, "WarningController", statuses[2].Name)
+	assert.True(t, statuses[2].Running)
+	assert.Zero(t, statuses[2].Crashes)
+	assert.Equal(t, "transient", statuses[2].LastError)
+}
@@ -0,0 +1,245 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"go.uber.org/zap"
+)
+
+// controllerPauses pauses the reconciliation of the controllers for the emergency manual intervention.
+//
+// Paused controller is stopped (its context is canceled) and started again when the pause expires
+// or the controller is resumed. Reconcile is queued on start to catch up with the changes made while paused.
+type controllerPauses struct {
+	health *controllerHealth
+
+	mu          sync.Mutex
+	controllers []string
+	until       map[string]time.Time
+	// changed is closed and replaced on every pause state change.
+	changed chan struct{}
+}
+
+func newControllerPauses(health *controllerHealth) *controllerPauses {
+	return &controllerPauses{
+		health:  health,
+		until:   map[string]time.Time{},
+		changed: make(chan struct{}),
+	}
+}
+
+// controllerMatches checks whether the controller matches the controller name (network.LinkSpecController)
+// or the controller group (network).
+func controllerMatches(controller, name string) bool {
+	return controller == name || strings.HasPrefix(controller, name+".")
+}
+
+// Wrap the controller to allow pausing it.
+func (pauses *controllerPauses) Wrap(ctrl controller.Controller) controller.Controller {
+	pauses.mu.Lock()
+	defer pauses.mu.Unlock()
+
+	pauses.controllers = append(pauses.controllers, ctrl.Name())
+
+	return &pausableController{
+		Controller: ctrl,
+		pauses:     pauses,
+	}
+}
+
+// Pause the controllers matching the names until the deadline.
+//
+// Names of the paused controllers are returned, every name should match at least one controller.
+func (pauses *controllerPauses) Pause(names []string, until time.Time) ([]string, error) {
+	pauses.mu.Lock()
+	defer pauses.mu.Unlock()
+
+	var paused []string
+
+	for _, name := range names {
+		matched := false
+
+		for _, controller := range pauses.controllers {
+			if controllerMatches(controller, name) {
+				paused = append(paused, controller)
+				matched = true
+			}
+		}
+
+		if !matched {
+			return nil, fmt.Errorf("no controllers match %q", name)
+		}
+	}
+
+	for _, controller := range paused {
+		pauses.until[controller] = until
+		pauses.health.SetPausedUntil(controller, until)
+	}
+
+	pauses.notify()
+
+	return uniqueSorted(paused), nil
+}
+
+// Resume the paused controllers matching the names, all paused controllers are resumed if no names are given.
+//
+// Names of the resumed controllers are returned.
+func (pauses *controllerPauses) Resume(names []string) []string {
+	pauses.mu.Lock()
+	defer pauses.mu.Unlock()
+
+	var resumed []string
+
+	for controller := range pauses.until {
+		matched := len(names) == 0
+
+		for _, name := range names {
+			matched = matched || controllerMatches(controller, name)
+		}
+
+		if matched {
+			resumed = append(resumed, controller)
+		}
+	}
+
+	for _, controller := range resumed {
+		delete(pauses.until, controller)
+		pauses.health.SetPausedUntil(controller, time.Time{})
+	}
+
+	pauses.notify()
+
+	return uniqueSorted(resumed)
+}
+
+func (pauses *controllerPauses) notify() {
+	close(pauses.changed)
+	pauses.changed = make(chan struct{})
+}
+
+// state returns the pause deadline of the controller (zero if the controller is not paused)
+// and the channel which is closed on the next pause state change.
+func (pauses *controllerPauses) state(controller string) (time.Time, <-chan struct{}) {
+	pauses.mu.Lock()
+	defer pauses.mu.Unlock()
+
+	until, ok := pauses.until[controller]
+	if ok && !time.Now().Before(until) {
+		delete(pauses.until, controller)
+		pauses.health.SetPausedUntil(controller, time.Time{})
+
+		until = time.Time{}
+	}
+
+	return until, pauses.changed
+}
+
+func uniqueSorted(names []string) []string {
+	sort.Strings(names)
+
+	result := names[:0]
+
+	for i, name := range names {
+		if i == 0 || names[i-1] != name {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+type pausableController struct {
+	controller.Controller
+
+	pauses *controllerPauses
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *pausableController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		if !ctrl.waitResumed(ctx, r, logger) {
+			return nil
+		}
+
+		runCtx, runCancel := context.WithCancel(ctx)
+
+		go ctrl.cancelOnPause(runCtx, runCancel)
+
+		err := ctrl.Controller.Run(runCtx, r, logger)
+
+		runCancel()
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+		if until, _ := ctrl.pauses.state(ctrl.Name()); until.IsZero() {
+			return err
+		}
+	}
+}
+
+// waitResumed blocks while the controller is paused, false is returned if the context is canceled.
+func (ctrl *pausableController) waitResumed(ctx context.Context, r controller.Runtime, logger *zap.Logger) bool {
+	paused := false
+
+	for {
+		until, changed := ctrl.pauses.state(ctrl.Name())
+		if until.IsZero() {
+			if paused {
+				logger.Info("controller resumed")
+
+				r.QueueReconcile()
+			}
+
+			return true
+		}
+
+		if !paused {
+			logger.Warn("controller paused", zap.Time("until", until))
+
+			paused = true
+		}
+
+		timer := time.NewTimer(time.Until(until))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return false
+		case <-changed:
+		case <-timer.C:
+		}
+
+		timer.Stop()
+	}
+}
+
+// cancelOnPause stops the controller once it gets paused.
+func (ctrl *pausableController) cancelOnPause(ctx context.Context, cancel context.CancelFunc) {
+	for {
+		until, changed := ctrl.pauses.state(ctrl.Name())
+		if !until.IsZero() {
+			cancel()
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2 //nolint:testpackage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	osruntime "github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/go-retry/retry"
+	"go.uber.org/zap"
+)
+
+type countingController struct {
+	name string
+
+	running    int32
+	runs       int32
+	reconciles int32
+}
+
+func (ctrl *countingController) Name() string {
+	return ctrl.name
+}
+
+func (ctrl *countingController) Inputs() []controller.Input {
+	return nil
+}
+
+func (ctrl *countingController) Outputs() []controller.Output {
+	return nil
+}
+
+func (ctrl *countingController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	atomic.AddInt32(&ctrl.runs, 1)
+	atomic.StoreInt32(&ctrl.running, 1)
+
+	defer atomic.StoreInt32(&ctrl.running, 0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		atomic.AddInt32(&ctrl.reconciles, 1)
+	}
+}
+
+func (ctrl *countingController) assertState(t *testing.T, running bool, runs, reconciles int32) {
+	t.Helper()
+
+	assert.NoError(t, retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		if (atomic.LoadInt32(&ctrl.running) == 1) != running || atomic.LoadInt32(&ctrl.runs) != runs || atomic.LoadInt32(&ctrl.reconciles) < reconciles {
+			return retry.ExpectedError(fmt.Errorf("unexpected state: running %d, runs %d, reconciles %d",
+				atomic.LoadInt32(&ctrl.running), atomic.LoadInt32(&ctrl.runs), atomic.LoadInt32(&ctrl.reconciles)))
+		}
+
+		return nil
+	}))
+}
+
+func TestControllerPauses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	health := newControllerHealth()
+	pauses := newControllerPauses(health)
+
+	rt, err := osruntime.NewRuntime(state.WrapCore(namespaced.NewState(inmem.Build)), zap.NewNop())
+	require.NoError(t, err)
+
+	linkCtrl := &countingController{name: "network.LinkSpecController"}
+	routeCtrl := &countingController{name: "network.RouteSpecController"}
+	otherCtrl := &countingController{name: "networking.OtherController"}
+
+	for _, ctrl := range []*countingController{linkCtrl, routeCtrl, otherCtrl} {
+		require.NoError(t, rt.RegisterController(pauses.Wrap(ctrl)))
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		assert.NoError(t, rt.Run(ctx))
+	}()
+
+	defer wg.Wait()
+	defer cancel()
+
+	linkCtrl.assertState(t, true, 1, 1)
+
+	_, err = pauses.Pause([]string{"network", "kubespan"}, time.Now().Add(time.Minute))
+	assert.EqualError(t, err, `no controllers match "kubespan"`)
+
+	until := time.Now().Add(time.Minute)
+
+	paused, err := pauses.Pause([]string{"network", "network.LinkSpecController"}, until)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"network.LinkSpecController", "network.RouteSpecController"}, paused)
+
+	linkCtrl.assertState(t, false, 1, 1)
+	routeCtrl.assertState(t, false, 1, 1)
+	otherCtrl.assertState(t, true, 1, 1)
+
+	for _, status := range health.Statuses() {
+		if status.Name == "networking.OtherController" {
+			assert.True(t, status.PausedUntil.IsZero())
+		} else {
+			assert.Equal(t, until, status.PausedUntil)
+		}
+	}
+
+	// resumed controllers are restarted and reconcile
+	assert.Equal(t, []string{"network.RouteSpecController"}, pauses.Resume([]string{"network.RouteSpecController"}))
+
+	routeCtrl.assertState(t, true, 2, 2)
+	linkCtrl.assertState(t, false, 1, 1)
+
+	assert.Equal(t, []string{"network.LinkSpecController"}, pauses.Resume(nil))
+
+	linkCtrl.assertState(t, true, 2, 2)
+
+	// pause expires
+	_, err = pauses.Pause([]string{"network.LinkSpecController"}, time.Now().Add(500*time.Millisecond))
+	require.NoError(t, err)
+
+	linkCtrl.assertState(t, true, 3, 3)
+
+	for _, status := range health.Statuses() {
+		assert.True(t, status.PausedUntil.IsZero())
+	}
+}
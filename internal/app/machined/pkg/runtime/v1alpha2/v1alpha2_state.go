@@ -16,6 +16,7 @@ import (
 	talosconfig "github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/resources/cluster"
 	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/etcd"
 	"github.com/talos-systems/talos/pkg/machinery/resources/files"
 	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
 	"github.com/talos-systems/talos/pkg/machinery/resources/kubespan"
@@ -62,6 +63,7 @@ func NewState() (*State, error) {
 		{cluster.NamespaceName, "Cluster configuration and discovery resources."},
 		{cluster.RawNamespaceName, "Cluster unmerged raw resources."},
 		{config.NamespaceName, "Talos node configuration."},
+		{etcd.NamespaceName, "etcd resources."},
 		{files.NamespaceName, "Files and file-like resources."},
 		{k8s.ControlPlaneNamespaceName, "Kubernetes control plane resources."},
 		{kubespan.NamespaceName, "KubeSpan resources."},
@@ -83,13 +85,22 @@ func NewState() (*State, error) {
 		&cluster.Identity{},
 		&cluster.Member{},
 		&config.MachineConfig{},
+		&config.MachineConfigDeprecation{},
+		&config.MachineConfigSource{},
 		&config.MachineType{},
 		&config.K8sControlPlane{},
+		&config.FeatureStatus{},
+		&etcd.Spec{},
 		&files.EtcFileSpec{},
 		&files.EtcFileStatus{},
 		&k8s.Endpoint{},
+		&k8s.KubeletCertificateStatus{},
+		&k8s.KubeletConfig{},
+		&k8s.KubeletSpec{},
+		&k8s.LoadBalancerConfig{},
 		&k8s.Manifest{},
 		&k8s.ManifestStatus{},
+		&k8s.NodeLabelSpec{},
 		&k8s.Nodename{},
 		&k8s.StaticPod{},
 		&k8s.StaticPodStatus{},
@@ -101,6 +112,7 @@ func NewState() (*State, error) {
 		&kubespan.PeerStatus{},
 		&network.AddressStatus{},
 		&network.AddressSpec{},
+		&network.ConntrackStats{},
 		&network.HardwareAddr{},
 		&network.HostnameStatus{},
 		&network.HostnameSpec{},
@@ -119,13 +131,33 @@ func NewState() (*State, error) {
 		&network.TimeServerSpec{},
 		&perf.CPU{},
 		&perf.Memory{},
+		&runtime.Accelerator{},
+		&runtime.BMCInfo{},
+		&runtime.BootDiagnostics{},
+		&runtime.CPUPowerStatus{},
+		&runtime.ControllerStatus{},
+		&runtime.Disk{},
+		&runtime.DrainStatus{},
+		&runtime.HardwareFacts{},
+		&runtime.HealthCheckStatus{},
+		&runtime.HugePageStatus{},
+		&runtime.InstanceIdentity{},
+		&runtime.KernelCmdline{},
+		&runtime.KernelConfigOption{},
+		&runtime.KernelModuleStatus{},
 		&runtime.KernelParamSpec{},
 		&runtime.KernelParamStatus{},
+		&runtime.LogRateLimitStatus{},
+		&runtime.MachineIdentity{},
 		&runtime.MountStatus{},
+		&runtime.RootfsVerityStatus{},
+		&runtime.UpgradeHistory{},
+		&runtime.UpgradeStatus{},
 		&secrets.API{},
 		&secrets.CertSAN{},
 		&secrets.Etcd{},
 		&secrets.EtcdRoot{},
+		&secrets.KubeletBootstrapToken{},
 		&secrets.Kubernetes{},
 		&secrets.KubernetesRoot{},
 		&secrets.OSRoot{},
@@ -173,3 +205,51 @@ func (s *State) SetConfig(cfg talosconfig.Provider) error {
 
 	return s.resources.Update(ctx, oldCfg.Metadata().Version(), cfgResource)
 }
+
+// SetConfigSources implements runtime.V1alpha2State interface.
+func (s *State) SetConfigSources(sources []*config.MachineConfigSource) error {
+	ctx := context.TODO()
+
+	touched := map[resource.ID]struct{}{}
+
+	for _, source := range sources {
+		touched[source.Metadata().ID()] = struct{}{}
+
+		oldSource, err := s.resources.Get(ctx, source.Metadata())
+		if err != nil {
+			if !state.IsNotFoundError(err) {
+				return err
+			}
+
+			if err = s.resources.Create(ctx, source); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		source.Metadata().SetVersion(oldSource.Metadata().Version())
+		source.Metadata().BumpVersion()
+
+		if err = s.resources.Update(ctx, oldSource.Metadata().Version(), source); err != nil {
+			return err
+		}
+	}
+
+	list, err := s.resources.List(ctx, resource.NewMetadata(config.NamespaceName, config.MachineConfigSourceType, "", resource.VersionUndefined))
+	if err != nil {
+		return err
+	}
+
+	for _, source := range list.Items {
+		if _, ok := touched[source.Metadata().ID()]; ok {
+			continue
+		}
+
+		if err = s.resources.Destroy(ctx, source.Metadata()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"go.uber.org/zap"
+)
+
+// watchdogTimeout is the time the controller is given to pick up the pending reconcile event.
+const watchdogTimeout = 10 * time.Minute
+
+// watchdog restarts the controllers which stop making progress.
+//
+// Controller runtime restarts failed (and panicked) controllers individually with exponential backoff,
+// but a controller stuck in the reconcile loop is never restarted. The controller is considered wedged
+// when the reconcile event is not picked up by the controller within the timeout: the watchdog cancels
+// the controller context and returns an error, so that the controller runtime restarts the controller.
+type watchdog struct {
+	health  *controllerHealth
+	timeout time.Duration
+}
+
+// Wrap the controller with the watchdog.
+func (w *watchdog) Wrap(ctrl controller.Controller) controller.Controller {
+	return &watchdogController{
+		Controller: ctrl,
+		watchdog:   w,
+	}
+}
+
+type watchdogController struct {
+	controller.Controller
+
+	watchdog *watchdog
+	wedged   int32
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *watchdogController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rt := &watchdogRuntime{
+		Runtime:    r,
+		ctx:        ctx,
+		ch:         make(chan controller.ReconcileEvent),
+		onProgress: ctrl.progress,
+	}
+
+	fired := make(chan struct{})
+
+	go ctrl.watch(ctx, rt, logger, cancel, fired)
+
+	err := ctrl.Controller.Run(ctx, rt, logger)
+
+	select {
+	case <-fired:
+		return fmt.Errorf("controller made no progress in %s", ctrl.watchdog.timeout)
+	default:
+		return err
+	}
+}
+
+func (ctrl *watchdogController) watch(ctx context.Context, rt *watchdogRuntime, logger *zap.Logger, cancel context.CancelFunc, fired chan<- struct{}) {
+	ticker := time.NewTicker(ctrl.watchdog.timeout / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pendingSince := rt.pendingSince()
+		if pendingSince.IsZero() || time.Since(pendingSince) < ctrl.watchdog.timeout {
+			continue
+		}
+
+		logger.Error("controller wedged, restarting", zap.Error(fmt.Errorf("reconcile event pending since %s", pendingSince.Format(time.RFC3339))))
+
+		atomic.StoreInt32(&ctrl.wedged, 1)
+		ctrl.watchdog.health.SetWedged(ctrl.Name(), true)
+
+		close(fired)
+		cancel()
+
+		return
+	}
+}
+
+func (ctrl *watchdogController) progress() {
+	if atomic.CompareAndSwapInt32(&ctrl.wedged, 1, 0) {
+		ctrl.watchdog.health.SetWedged(ctrl.Name(), false)
+	}
+}
+
+// watchdogRuntime proxies the reconcile events to the controller to track the pending events.
+type watchdogRuntime struct {
+	controller.Runtime
+
+	ctx        context.Context //nolint:containedctx
+	ch         chan controller.ReconcileEvent
+	once       sync.Once
+	pending    int64
+	onProgress func()
+}
+
+// EventCh implements controller.Runtime interface.
+func (rt *watchdogRuntime) EventCh() <-chan controller.ReconcileEvent {
+	// controllers which never wait for the reconcile events are not proxied, so they are never considered wedged
+	rt.once.Do(func() {
+		go rt.forward(rt.Runtime.EventCh())
+	})
+
+	return rt.ch
+}
+
+func (rt *watchdogRuntime) forward(upstream <-chan controller.ReconcileEvent) {
+	for {
+		var event controller.ReconcileEvent
+
+		select {
+		case <-rt.ctx.Done():
+			return
+		case event = <-upstream:
+		}
+
+		atomic.StoreInt64(&rt.pending, time.Now().UnixNano())
+
+		select {
+		case <-rt.ctx.Done():
+			return
+		case rt.ch <- event:
+		}
+
+		atomic.StoreInt64(&rt.pending, 0)
+
+		rt.onProgress()
+	}
+}
+
+func (rt *watchdogRuntime) pendingSince() time.Time {
+	pending := atomic.LoadInt64(&rt.pending)
+	if pending == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, pending)
+}
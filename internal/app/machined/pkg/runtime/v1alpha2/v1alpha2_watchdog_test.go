@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha2 //nolint:testpackage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	osruntime "github.com/cosi-project/runtime/pkg/controller/runtime"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/go-retry/retry"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// wedgingController gets stuck in the first reconcile loop iteration with another reconcile event pending.
+type wedgingController struct {
+	runs       int32
+	reconciles int32
+}
+
+func (ctrl *wedgingController) Name() string {
+	return "test.WedgingController"
+}
+
+func (ctrl *wedgingController) Inputs() []controller.Input {
+	return nil
+}
+
+func (ctrl *wedgingController) Outputs() []controller.Output {
+	return nil
+}
+
+func (ctrl *wedgingController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	run := atomic.AddInt32(&ctrl.runs, 1)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		atomic.AddInt32(&ctrl.reconciles, 1)
+
+		if run == 1 {
+			r.QueueReconcile()
+
+			<-ctx.Done()
+
+			return nil
+		}
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	health := newControllerHealth()
+	w := &watchdog{
+		health:  health,
+		timeout: 200 * time.Millisecond,
+	}
+
+	logger := zap.NewNop().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, health.Core())
+	}))
+
+	rt, err := osruntime.NewRuntime(state.WrapCore(namespaced.NewState(inmem.Build)), logger)
+	require.NoError(t, err)
+
+	ctrl := &wedgingController{}
+
+	require.NoError(t, rt.RegisterController(w.Wrap(ctrl)))
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		assert.NoError(t, rt.Run(ctx))
+	}()
+
+	defer wg.Wait()
+	defer cancel()
+
+	// the controller is restarted by the watchdog and then processes the events normally
+	assert.NoError(t, retry.Constant(10*time.Second, retry.WithUnits(100*time.Millisecond)).Retry(func() error {
+		if atomic.LoadInt32(&ctrl.runs) < 2 || atomic.LoadInt32(&ctrl.reconciles) < 2 {
+			return retry.ExpectedError(fmt.Errorf("controller not restarted yet"))
+		}
+
+		if statuses := health.Statuses(); len(statuses) != 1 || statuses[0].Wedged {
+			return retry.ExpectedError(fmt.Errorf("controller is still wedged"))
+		}
+
+		return nil
+	}))
+
+	statuses := health.Statuses()
+	require.Len(t, statuses, 1)
+
+	assert.Equal(t, "test.WedgingController", statuses[0].Name)
+	assert.True(t, statuses[0].Running)
+	assert.Equal(t, 1, statuses[0].Crashes)
+	assert.Contains(t, statuses[0].LastError, "made no progress")
+}
@@ -10,11 +10,16 @@ import (
 	"sync/atomic"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/logging"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/health"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
 	"github.com/talos-systems/talos/pkg/conditions"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
 )
 
 type MockService struct {
@@ -148,3 +153,31 @@ func (m *MockCondition) Wait(ctx context.Context) error {
 		return nil
 	}
 }
+
+type MockRuntime struct {
+	runtime.Runtime
+
+	hooks []v1alpha1.ServiceHook
+}
+
+func (m *MockRuntime) Config() config.Provider {
+	return &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineServiceHooks: m.hooks,
+		},
+	}
+}
+
+func (m *MockRuntime) Logging() runtime.LoggingManager {
+	return logging.NewNullLoggingManager()
+}
+
+func (m *MockRuntime) Events() runtime.EventStream {
+	return &MockEventStream{}
+}
+
+type MockEventStream struct {
+	runtime.EventStream
+}
+
+func (m *MockEventStream) Publish(proto.Message) {}
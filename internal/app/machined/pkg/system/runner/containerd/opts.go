@@ -53,3 +53,27 @@ func WithCustomSeccompProfile(override func(*specs.LinuxSeccomp)) oci.SpecOpts {
 		return nil
 	}
 }
+
+// WithRlimits sets the process resource limits, replacing the limits of the same type.
+func WithRlimits(rlimits []specs.POSIXRlimit) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		if s.Process == nil {
+			s.Process = &specs.Process{}
+		}
+
+	outer:
+		for _, rlimit := range rlimits {
+			for i := range s.Process.Rlimits {
+				if s.Process.Rlimits[i].Type == rlimit.Type {
+					s.Process.Rlimits[i] = rlimit
+
+					continue outer
+				}
+			}
+
+			s.Process.Rlimits = append(s.Process.Rlimits, rlimit)
+		}
+
+		return nil
+	}
+}
@@ -5,11 +5,14 @@
 package restart
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/pkg/conditions"
 )
 
 type restarter struct {
@@ -42,6 +45,19 @@ type Options struct {
 	Type Type
 	// RestartInterval is the interval between restarts for failed runs.
 	RestartInterval time.Duration
+	// MaxRestartInterval enables exponential backoff: restart interval is doubled
+	// after each consecutive restart up to MaxRestartInterval.
+	//
+	// Backoff is reset once the run lasts longer than MaxRestartInterval.
+	MaxRestartInterval time.Duration
+	// Jitter randomizes restart interval by the fraction of it (e.g. 0.1 for +-10%).
+	Jitter float64
+	// MaxRestarts is the number of consecutive restarts after which restarter gives up.
+	//
+	// Zero value means no limit.
+	MaxRestarts int
+	// RestartCondition is waited for before each restart (e.g. service dependencies are healthy).
+	RestartCondition conditions.Condition
 }
 
 // Option is the functional option func.
@@ -94,19 +110,54 @@ func WithRestartInterval(interval time.Duration) Option {
 	}
 }
 
+// WithMaxRestartInterval enables exponential backoff for restart interval up to the specified value.
+func WithMaxRestartInterval(interval time.Duration) Option {
+	return func(args *Options) {
+		args.MaxRestartInterval = interval
+	}
+}
+
+// WithJitter sets the jitter fraction for restart interval.
+func WithJitter(jitter float64) Option {
+	return func(args *Options) {
+		args.Jitter = jitter
+	}
+}
+
+// WithMaxRestarts sets the number of consecutive restarts after which runner is considered failed.
+func WithMaxRestarts(restarts int) Option {
+	return func(args *Options) {
+		args.MaxRestarts = restarts
+	}
+}
+
+// WithRestartCondition sets the condition to wait for before each restart.
+func WithRestartCondition(condition conditions.Condition) Option {
+	return func(args *Options) {
+		args.RestartCondition = condition
+	}
+}
+
 // Open implements the Runner interface.
 func (r *restarter) Open() error {
 	return r.wrappedRunner.Open()
 }
 
 // Run implements the Runner interface
-//nolint:gocyclo
+//nolint:gocyclo,cyclop
 func (r *restarter) Run(eventSink events.Recorder) error {
 	defer close(r.stopped)
 
+	var (
+		restarts int
+		interval = r.opts.RestartInterval
+	)
+
 	for {
 		errCh := make(chan error)
 
+		started := time.Now()
+
 		go func() {
 			errCh <- r.wrappedRunner.Run(eventSink)
 		}()
@@ -127,20 +178,36 @@ func (r *restarter) Run(eventSink events.Recorder) error {
 			return errStop
 		}
 
-		switch r.opts.Type {
-		case Once:
+		// run was stable long enough, reset the backoff
+		if r.opts.MaxRestartInterval > 0 && time.Since(started) > r.opts.MaxRestartInterval {
+			restarts = 0
+			interval = r.opts.RestartInterval
+		}
+
+		if r.opts.Type == Once || (r.opts.Type == UntilSuccess && err == nil) {
 			return err
-		case UntilSuccess:
+		}
+
+		if r.opts.MaxRestarts > 0 && restarts >= r.opts.MaxRestarts {
 			if err == nil {
-				return nil
+				return fmt.Errorf("giving up after %d restarts", restarts)
 			}
 
-			eventSink(events.StateWaiting, "Error running %s, going to restart until it succeeds: %v", r.wrappedRunner, err)
+			return fmt.Errorf("giving up after %d restarts: %w", restarts, err)
+		}
+
+		restarts++
+
+		delay := r.jitter(interval)
+
+		switch r.opts.Type { //nolint:exhaustive
+		case UntilSuccess:
+			eventSink(events.StateWaiting, "Error running %s, going to restart until it succeeds in %s (restart %d): %v", r.wrappedRunner, delay, restarts, err)
 		case Forever:
 			if err == nil {
-				eventSink(events.StateWaiting, "Runner %s exited without error, going to restart it", r.wrappedRunner)
+				eventSink(events.StateWaiting, "Runner %s exited without error, going to restart it in %s (restart %d)", r.wrappedRunner, delay, restarts)
 			} else {
-				eventSink(events.StateWaiting, "Error running %v, going to restart forever: %v", r.wrappedRunner, err)
+				eventSink(events.StateWaiting, "Error running %v, going to restart forever in %s (restart %d): %v", r.wrappedRunner, delay, restarts, err)
 			}
 		}
 
@@ -149,11 +216,67 @@ func (r *restarter) Run(eventSink events.Recorder) error {
 			eventSink(events.StateStopping, "Aborting restart sequence")
 
 			return nil
-		case <-time.After(r.opts.RestartInterval):
+		case <-time.After(delay):
+		}
+
+		if r.opts.MaxRestartInterval > 0 {
+			interval *= 2
+
+			if interval > r.opts.MaxRestartInterval {
+				interval = r.opts.MaxRestartInterval
+			}
+		}
+
+		if r.opts.RestartCondition != nil {
+			if err = r.waitForCondition(eventSink); err != nil {
+				eventSink(events.StateStopping, "Aborting restart sequence")
+
+				return nil
+			}
 		}
 	}
 }
 
+// waitForCondition waits for the restart condition, it returns error if the restarter was stopped.
+func (r *restarter) waitForCondition(eventSink events.Recorder) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-r.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- r.opts.RestartCondition.Wait(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(time.Second):
+	}
+
+	// condition is not met immediately, report it
+	eventSink(events.StateWaiting, "Waiting for %s before restart", r.opts.RestartCondition)
+
+	return <-errCh
+}
+
+func (r *restarter) jitter(interval time.Duration) time.Duration {
+	if r.opts.Jitter <= 0 {
+		return interval
+	}
+
+	//nolint:gosec
+	return (interval + time.Duration((rand.Float64()*2-1)*r.opts.Jitter*float64(interval))).Round(time.Millisecond)
+}
+
 // Stop implements the Runner interface.
 func (r *restarter) Stop() error {
 	close(r.stop)
@@ -5,9 +5,11 @@
 package restart_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -177,6 +179,142 @@ func (suite *RestartSuite) TestRunForever() {
 	suite.Assert().Equal(4, mock.times)
 }
 
+func (suite *RestartSuite) TestRunMaxRestarts() {
+	mock := MockRunner{
+		exitCh: make(chan error),
+	}
+
+	r := restart.New(&mock, restart.WithType(restart.Forever), restart.WithRestartInterval(time.Millisecond), restart.WithMaxRestarts(2))
+	suite.Assert().NoError(r.Open())
+
+	defer func() { suite.Assert().NoError(r.Close()) }()
+
+	failed := errors.New("failed")
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- r.Run(MockEventSink)
+	}()
+
+	mock.exitCh <- failed
+	mock.exitCh <- failed
+	mock.exitCh <- failed
+
+	suite.Assert().EqualError(<-errCh, "giving up after 2 restarts: failed")
+	suite.Assert().NoError(r.Stop())
+	suite.Assert().Equal(3, mock.times)
+}
+
+func (suite *RestartSuite) TestRunBackoff() {
+	mock := MockRunner{
+		exitCh: make(chan error),
+	}
+
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+
+	eventSink := func(state events.ServiceState, message string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		messages = append(messages, fmt.Sprintf(message, args...))
+	}
+
+	r := restart.New(&mock, restart.WithType(restart.Forever), restart.WithRestartInterval(20*time.Millisecond), restart.WithMaxRestartInterval(80*time.Millisecond))
+	suite.Assert().NoError(r.Open())
+
+	defer func() { suite.Assert().NoError(r.Close()) }()
+
+	failed := errors.New("failed")
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- r.Run(eventSink)
+	}()
+
+	for i := 0; i < 5; i++ {
+		mock.exitCh <- failed
+	}
+
+	// wait for the last restart to be scheduled
+	suite.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(messages) == 5
+	}, time.Second, time.Millisecond)
+
+	suite.Assert().NoError(r.Stop())
+	suite.Assert().NoError(<-errCh)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	suite.Require().Len(messages, 6)
+
+	for i, expected := range []string{"in 20ms (restart 1)", "in 40ms (restart 2)", "in 80ms (restart 3)", "in 80ms (restart 4)", "in 80ms (restart 5)"} {
+		suite.Assert().Contains(messages[i], expected)
+	}
+}
+
+type mockCondition struct {
+	ch chan struct{}
+}
+
+func (c *mockCondition) Wait(ctx context.Context) error {
+	select {
+	case <-c.ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *mockCondition) String() string {
+	return "mock condition"
+}
+
+func (suite *RestartSuite) TestRunRestartCondition() {
+	mock := MockRunner{
+		exitCh: make(chan error),
+	}
+
+	condition := &mockCondition{
+		ch: make(chan struct{}),
+	}
+
+	r := restart.New(&mock, restart.WithType(restart.Forever), restart.WithRestartInterval(time.Millisecond), restart.WithRestartCondition(condition))
+	suite.Assert().NoError(r.Open())
+
+	defer func() { suite.Assert().NoError(r.Close()) }()
+
+	failed := errors.New("failed")
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- r.Run(MockEventSink)
+	}()
+
+	mock.exitCh <- failed
+
+	// runner is not restarted until the condition is met
+	select {
+	case mock.exitCh <- failed:
+		suite.Assert().Fail("runner should not be restarted")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(condition.ch)
+
+	mock.exitCh <- nil
+
+	suite.Assert().NoError(r.Stop())
+	suite.Assert().NoError(<-errCh)
+	suite.Assert().Equal(2, mock.times)
+}
+
 func TestRestartSuite(t *testing.T) {
 	suite.Run(t, new(RestartSuite))
 }
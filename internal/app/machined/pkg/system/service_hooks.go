@@ -0,0 +1,175 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	containerdapi "github.com/containerd/containerd"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/process"
+	"github.com/talos-systems/talos/internal/pkg/containers/image"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// serviceHook is a hook from the machine configuration.
+type serviceHook struct {
+	id   string
+	hook config.ServiceHook
+}
+
+// hooks returns the hooks configured for the service at the stage.
+func (svcrunner *ServiceRunner) hooks(stage string) []serviceHook {
+	if svcrunner.runtime == nil || svcrunner.runtime.Config() == nil {
+		return nil
+	}
+
+	var hooks []serviceHook
+
+	for _, hook := range svcrunner.runtime.Config().Machine().ServiceHooks() {
+		if hook.Service() != svcrunner.id || hook.Stage() != stage {
+			continue
+		}
+
+		hooks = append(hooks, serviceHook{
+			id:   fmt.Sprintf("%s-%s-hook-%d", svcrunner.id, stage, len(hooks)),
+			hook: hook,
+		})
+	}
+
+	return hooks
+}
+
+// runHooks runs the hooks one by one, stopping on the first failure.
+func (svcrunner *ServiceRunner) runHooks(ctx context.Context, hooks []serviceHook) error {
+	for _, hook := range hooks {
+		svcrunner.recordEvent("Running hook %s", hook.id)
+
+		if err := svcrunner.runHook(ctx, hook); err != nil {
+			return fmt.Errorf("hook %s failed: %w", hook.id, err)
+		}
+
+		svcrunner.recordEvent("Hook %s finished successfully", hook.id)
+	}
+
+	return nil
+}
+
+// runPostHooks runs the hooks once the service is up.
+//
+// Hooks are aborted if the service is stopped.
+func (svcrunner *ServiceRunner) runPostHooks(ctx context.Context, hooks []serviceHook) {
+	upCh := make(chan struct{}, 1)
+
+	svcrunner.Subscribe(StateEventUp, upCh)
+	defer svcrunner.Unsubscribe(StateEventUp, upCh)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-upCh:
+	}
+
+	if err := svcrunner.runHooks(ctx, hooks); err != nil {
+		svcrunner.recordEvent("Failed to run post hooks: %v", err)
+	}
+}
+
+func (svcrunner *ServiceRunner) runHook(ctx context.Context, hook serviceHook) error {
+	ctx, cancel := context.WithTimeout(ctx, hook.hook.Timeout())
+	defer cancel()
+
+	runnr, err := svcrunner.hookRunner(ctx, hook)
+	if err != nil {
+		return err
+	}
+
+	if err = runnr.Open(); err != nil {
+		return fmt.Errorf("error opening runner: %w", err)
+	}
+
+	//nolint:errcheck
+	defer runnr.Close()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- runnr.Run(func(_ events.ServiceState, message string, args ...interface{}) {
+			svcrunner.recordEvent("Hook %s: %s", hook.id, fmt.Sprintf(message, args...))
+		})
+	}()
+
+	select {
+	case err = <-errCh:
+		return err
+	case <-ctx.Done():
+		//nolint:errcheck
+		runnr.Stop()
+
+		<-errCh
+
+		return fmt.Errorf("hook aborted: %w", ctx.Err())
+	}
+}
+
+func (svcrunner *ServiceRunner) hookRunner(ctx context.Context, hook serviceHook) (runner.Runner, error) {
+	cfg := svcrunner.runtime.Config()
+
+	args := &runner.Args{
+		ID:          hook.id,
+		ProcessArgs: hook.hook.Command(),
+	}
+
+	env := []string{}
+	for key, val := range cfg.Machine().Env() {
+		env = append(env, fmt.Sprintf("%s=%s", key, val))
+	}
+
+	opts := []runner.Option{
+		runner.WithLoggingManager(svcrunner.runtime.Logging()),
+		runner.WithEnv(env),
+	}
+
+	if hook.hook.Image() == "" {
+		return process.NewRunner(cfg.Debug(), args, opts...), nil
+	}
+
+	client, err := containerdapi.New(constants.CRIContainerdAddress)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:errcheck
+	defer client.Close()
+
+	// Pull the image and unpack it.
+	containerdctx := namespaces.WithNamespace(ctx, constants.SystemContainerdNamespace)
+
+	if _, err = image.Pull(containerdctx, cfg.Machine().Registries(), client, hook.hook.Image(), image.WithSkipIfAlreadyPulled()); err != nil {
+		return nil, err
+	}
+
+	mounts := []specs.Mount{
+		{Type: "bind", Destination: "/var", Source: "/var", Options: []string{"rbind", "rshared", "rw"}},
+	}
+
+	return containerd.NewRunner(cfg.Debug(), args,
+		append(opts,
+			runner.WithNamespace(constants.SystemContainerdNamespace),
+			runner.WithContainerImage(hook.hook.Image()),
+			runner.WithOCISpecOpts(
+				oci.WithHostNamespace(specs.NetworkNamespace),
+				oci.WithMounts(mounts),
+			),
+		)...,
+	), nil
+}
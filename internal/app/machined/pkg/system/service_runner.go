@@ -17,6 +17,7 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
 	"github.com/talos-systems/talos/pkg/conditions"
 	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 // WaitConditionCheckInterval is time between checking for wait condition
@@ -105,6 +106,26 @@ func (svcrunner *ServiceRunner) UpdateState(newstate events.ServiceState, messag
 	}
 }
 
+// recordEvent records an event without changing the service state.
+func (svcrunner *ServiceRunner) recordEvent(message string, args ...interface{}) {
+	svcrunner.mu.Lock()
+
+	event := events.ServiceEvent{
+		Message:   fmt.Sprintf(message, args...),
+		State:     svcrunner.state,
+		Timestamp: time.Now(),
+	}
+	svcrunner.events.Push(event)
+
+	log.Printf("service[%s](%s): %s", svcrunner.id, svcrunner.state, event.Message)
+
+	svcrunner.mu.Unlock()
+
+	if svcrunner.runtime != nil {
+		svcrunner.runtime.Events().Publish(event.AsProto(svcrunner.id))
+	}
+}
+
 func (svcrunner *ServiceRunner) healthUpdate(change health.StateChange) {
 	svcrunner.mu.Lock()
 
@@ -222,6 +243,16 @@ func (svcrunner *ServiceRunner) Start() {
 		}
 	}
 
+	if hooks := svcrunner.hooks(constants.ServiceHookStagePre); len(hooks) > 0 {
+		svcrunner.UpdateState(events.StatePreparing, "Running pre hooks")
+
+		if err := svcrunner.runHooks(ctx, hooks); err != nil {
+			svcrunner.UpdateState(events.StateFailed, "Failed to run pre hooks: %v", err)
+
+			return
+		}
+	}
+
 	svcrunner.UpdateState(events.StatePreparing, "Running pre state")
 
 	if err := svcrunner.service.PreFunc(ctx, svcrunner.runtime); err != nil {
@@ -315,6 +346,19 @@ func (svcrunner *ServiceRunner) run(ctx context.Context, runnr runner.Runner) er
 		}()
 	}
 
+	if hooks := svcrunner.hooks(constants.ServiceHookStagePost); len(hooks) > 0 {
+		var hooksWg sync.WaitGroup
+		defer hooksWg.Wait()
+
+		hooksWg.Add(1)
+
+		go func() {
+			defer hooksWg.Done()
+
+			svcrunner.runPostHooks(ctx, hooks)
+		}()
+	}
+
 	// when service run finishes, cancel context, this is important if service
 	// terminates on its own before being terminated by Stop()
 	defer svcrunner.ctxCancel()
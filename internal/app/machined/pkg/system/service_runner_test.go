@@ -15,6 +15,8 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/events"
 	"github.com/talos-systems/talos/pkg/conditions"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 type ServiceRunnerSuite struct {
@@ -462,6 +464,86 @@ func (suite *ServiceRunnerSuite) TestFullFlowRestart() {
 	}, sr)
 }
 
+func (suite *ServiceRunnerSuite) TestHooks() {
+	sr := system.NewServiceRunner(&MockService{}, &MockRuntime{
+		hooks: []v1alpha1.ServiceHook{
+			{
+				HookService: "MockRunner",
+				HookStage:   constants.ServiceHookStagePre,
+				HookCommand: []string{"/bin/sh", "-c", "exit 0"},
+			},
+			{
+				HookService: "MockRunner",
+				HookStage:   constants.ServiceHookStagePost,
+				HookCommand: []string{"/bin/sh", "-c", "exit 0"},
+			},
+			{
+				HookService: "OtherService",
+				HookStage:   constants.ServiceHookStagePre,
+				HookCommand: []string{"/bin/sh", "-c", "exit 1"},
+			},
+		},
+	})
+
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		sr.Start()
+	}()
+
+	suite.Require().NoError(retry.Constant(time.Minute, retry.WithUnits(10*time.Millisecond)).Retry(func() error {
+		for _, event := range sr.GetEventHistory(1000) {
+			if event.Message == "Hook MockRunner-post-hook-0 finished successfully" {
+				return nil
+			}
+		}
+
+		return retry.ExpectedError(errors.New("post hook should be finished"))
+	}))
+
+	sr.Shutdown()
+
+	<-finished
+
+	messages := []string{}
+
+	for _, event := range sr.GetEventHistory(1000) {
+		messages = append(messages, event.Message)
+	}
+
+	suite.Assert().Contains(messages, "Hook MockRunner-pre-hook-0 finished successfully")
+	suite.Assert().NotContains(messages, "Running hook OtherService-pre-hook-0")
+	suite.Assert().Equal(events.StateFinished.String(), sr.AsProto().State)
+}
+
+func (suite *ServiceRunnerSuite) TestPreHookFail() {
+	for _, hook := range []v1alpha1.ServiceHook{
+		{
+			HookService: "MockRunner",
+			HookStage:   constants.ServiceHookStagePre,
+			HookCommand: []string{"/bin/sh", "-c", "exit 1"},
+		},
+		{
+			HookService: "MockRunner",
+			HookStage:   constants.ServiceHookStagePre,
+			HookCommand: []string{"/bin/sh", "-c", "sleep 60"},
+			HookTimeout: 100 * time.Millisecond,
+		},
+	} {
+		sr := system.NewServiceRunner(&MockService{}, &MockRuntime{
+			hooks: []v1alpha1.ServiceHook{hook},
+		})
+		sr.Start()
+
+		suite.Assert().Equal(events.StateFailed.String(), sr.AsProto().State)
+
+		history := sr.GetEventHistory(1)
+		suite.Require().Len(history, 1)
+		suite.Assert().Contains(history[0].Message, "Failed to run pre hooks: hook MockRunner-pre-hook-0 failed")
+	}
+}
+
 func TestServiceRunnerSuite(t *testing.T) {
 	suite.Run(t, new(ServiceRunnerSuite))
 }
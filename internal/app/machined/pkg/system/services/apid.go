@@ -30,6 +30,8 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
 	"github.com/talos-systems/talos/pkg/conditions"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
 	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 )
 
@@ -46,7 +48,7 @@ func (o *APID) ID(r runtime.Runtime) string {
 
 // PreFunc implements the Service interface.
 func (o *APID) PreFunc(ctx context.Context, r runtime.Runtime) error {
-	// filter apid access to make sure apid can only access its certificates
+	// filter apid access to make sure apid can only access its certificates and node addresses
 	resources := state.Filter(
 		r.State().V1Alpha2().Resources(),
 		func(ctx context.Context, access state.Access) error {
@@ -54,11 +56,17 @@ func (o *APID) PreFunc(ctx context.Context, r runtime.Runtime) error {
 				return fmt.Errorf("write access denied")
 			}
 
-			if !(access.ResourceNamespace == secrets.NamespaceName && access.ResourceType == secrets.APIType && access.ResourceID == secrets.APIID) {
-				return fmt.Errorf("access denied")
+			if access.ResourceNamespace == secrets.NamespaceName && access.ResourceType == secrets.APIType && access.ResourceID == secrets.APIID {
+				return nil
 			}
 
-			return nil
+			// apid accepts connections on the advertised node addresses
+			if access.ResourceNamespace == network.NamespaceName && access.ResourceType == network.NodeAddressType &&
+				access.ResourceID == network.FilteredNodeAddressID(network.NodeAddressCurrentID, k8s.NodeAddressFilterNoK8s) {
+				return nil
+			}
+
+			return fmt.Errorf("access denied")
 		},
 	)
 
@@ -136,6 +144,18 @@ func (o *APID) Runner(r runtime.Runtime) (runner.Runner, error) {
 		args.ProcessArgs = append(args.ProcessArgs, "--enable-rbac")
 	}
 
+	apiTLS := r.Config().Machine().APITLS()
+
+	args.ProcessArgs = append(args.ProcessArgs, "--tls-min-version="+apiTLS.MinVersion())
+
+	if len(r.Config().Machine().Network().AdvertisedSubnets()) > 0 || len(r.Config().Machine().Network().IgnoredSubnets()) > 0 {
+		args.ProcessArgs = append(args.ProcessArgs, "--advertised-addresses-only")
+	}
+
+	if len(apiTLS.CipherSuites()) > 0 {
+		args.ProcessArgs = append(args.ProcessArgs, "--tls-cipher-suites="+strings.Join(apiTLS.CipherSuites(), ","))
+	}
+
 	// Set the mounts.
 	mounts := []specs.Mount{
 		{Type: "bind", Destination: "/etc/ssl", Source: "/etc/ssl", Options: []string{"bind", "ro"}},
@@ -163,6 +183,11 @@ func (o *APID) Runner(r runtime.Runtime) (runner.Runner, error) {
 		env = append(env, "GORACE=halt_on_error=1")
 	}
 
+	env, overrideOpts, err := applyServiceOverride(r, o.ID(r), env, mounts, "no_proxy", "NO_PROXY", "http_proxy", "HTTP_PROXY", "https_proxy", "HTTPS_PROXY")
+	if err != nil {
+		return nil, err
+	}
+
 	return restart.New(containerd.NewRunner(
 		r.Config().Debug(),
 		&args,
@@ -170,16 +195,19 @@ func (o *APID) Runner(r runtime.Runtime) (runner.Runner, error) {
 		runner.WithContainerdAddress(constants.SystemContainerdAddress),
 		runner.WithEnv(env),
 		runner.WithOCISpecOpts(
-			oci.WithDroppedCapabilities(cap.Known()),
-			oci.WithHostNamespace(specs.NetworkNamespace),
-			oci.WithMounts(mounts),
-			oci.WithRootFSPath(filepath.Join(constants.SystemLibexecPath, o.ID(r))),
-			oci.WithRootFSReadonly(),
-			oci.WithUser(fmt.Sprintf("%d:%d", constants.ApidUserID, constants.ApidUserID)),
+			append([]oci.SpecOpts{
+				oci.WithDroppedCapabilities(cap.Known()),
+				oci.WithHostNamespace(specs.NetworkNamespace),
+				oci.WithMounts(mounts),
+				oci.WithRootFSPath(filepath.Join(constants.SystemLibexecPath, o.ID(r))),
+				oci.WithRootFSReadonly(),
+				oci.WithUser(fmt.Sprintf("%d:%d", constants.ApidUserID, constants.ApidUserID)),
+			}, overrideOpts...)...,
 		),
+		daemonSeccompOption(),
 		runner.WithOOMScoreAdj(-998),
 	),
-		restart.WithType(restart.Forever),
+		append(restartPolicy(), restart.WithType(restart.Forever))...,
 	), nil
 }
 
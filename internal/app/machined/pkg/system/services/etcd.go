@@ -23,11 +23,11 @@ import (
 	"github.com/cosi-project/runtime/pkg/resource"
 	"github.com/cosi-project/runtime/pkg/state"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
-	"github.com/talos-systems/crypto/x509"
 	"github.com/talos-systems/go-retry/retry"
 	"github.com/talos-systems/net"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	snapshot "go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"inet.af/netaddr"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/v1alpha1/bootloader"
@@ -44,6 +44,7 @@ import (
 	"github.com/talos-systems/talos/pkg/conditions"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	etcdresource "github.com/talos-systems/talos/pkg/machinery/resources/etcd"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
 	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 	timeresource "github.com/talos-systems/talos/pkg/machinery/resources/time"
@@ -176,6 +177,11 @@ func (e *Etcd) Runner(r runtime.Runtime) (runner.Runner, error) {
 
 	env = append(env, "ETCD_CIPHER_SUITES=TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305") //nolint:lll
 
+	env, overrideOpts, err := applyServiceOverride(r, e.ID(r), env, mounts, "ETCD_CIPHER_SUITES", "ETCD_UNSUPPORTED_ARCH")
+	if err != nil {
+		return nil, err
+	}
+
 	if e.learnerMemberID != 0 {
 		var promoteCtx context.Context
 
@@ -198,14 +204,16 @@ func (e *Etcd) Runner(r runtime.Runtime) (runner.Runner, error) {
 		runner.WithContainerImage(r.Config().Cluster().Etcd().Image()),
 		runner.WithEnv(env),
 		runner.WithOCISpecOpts(
-			oci.WithDroppedCapabilities(cap.Known()),
-			oci.WithHostNamespace(specs.NetworkNamespace),
-			oci.WithMounts(mounts),
-			oci.WithUser(fmt.Sprintf("%d:%d", constants.EtcdUserID, constants.EtcdUserID)),
+			append([]oci.SpecOpts{
+				oci.WithDroppedCapabilities(cap.Known()),
+				oci.WithHostNamespace(specs.NetworkNamespace),
+				oci.WithMounts(mounts),
+				oci.WithUser(fmt.Sprintf("%d:%d", constants.EtcdUserID, constants.EtcdUserID)),
+			}, overrideOpts...)...,
 		),
 		runner.WithOOMScoreAdj(-998),
 	),
-		restart.WithType(restart.Forever),
+		append(restartPolicy(), restart.WithType(restart.Forever))...,
 	), nil
 }
 
@@ -277,41 +285,15 @@ func generatePKI(ctx context.Context, r runtime.Runtime) (err error) {
 
 	etcdCerts := event.Resource.(*secrets.Etcd).Certs()
 
-	for _, keypair := range []struct {
-		getter   func() *x509.PEMEncodedCertificateAndKey
-		keyPath  string
-		certPath string
-	}{
-		{
-			getter:   func() *x509.PEMEncodedCertificateAndKey { return etcdCerts.Etcd },
-			keyPath:  constants.KubernetesEtcdKey,
-			certPath: constants.KubernetesEtcdCert,
-		},
-		{
-			getter:   func() *x509.PEMEncodedCertificateAndKey { return etcdCerts.EtcdPeer },
-			keyPath:  constants.KubernetesEtcdPeerKey,
-			certPath: constants.KubernetesEtcdPeerCert,
-		},
-		{
-			getter:   func() *x509.PEMEncodedCertificateAndKey { return etcdCerts.EtcdAdmin },
-			keyPath:  constants.KubernetesEtcdAdminKey,
-			certPath: constants.KubernetesEtcdAdminCert,
-		},
-	} {
-		if err = ioutil.WriteFile(keypair.keyPath, keypair.getter().Key, 0o400); err != nil {
-			return err
-		}
-
-		if err = ioutil.WriteFile(keypair.certPath, keypair.getter().Crt, 0o400); err != nil {
-			return err
-		}
+	if err = etcd.WritePKI(etcdCerts); err != nil {
+		return fmt.Errorf("failed to write etcd PKI: %w", err)
 	}
 
 	return chownRecursive(constants.EtcdPKIPath, constants.EtcdUserID, constants.EtcdUserID)
 }
 
 func addMember(ctx context.Context, r runtime.Runtime, addrs []string, name string) (*clientv3.MemberListResponse, uint64, error) {
-	client, err := etcd.NewClientFromControlPlaneIPs(ctx, r.Config().Cluster().CA(), r.Config().Cluster().Endpoint())
+	client, err := etcd.NewSharedClientFromControlPlaneIPs(ctx, r.Config().Cluster().CA(), r.Config().Cluster().InternalEndpoint())
 	if err != nil {
 		return nil, 0, err
 	}
@@ -438,17 +420,18 @@ func (e *Etcd) argsForInit(ctx context.Context, r runtime.Runtime) error {
 		_, upgraded = meta.LegacyADV.ReadTag(adv.Upgrade)
 	}
 
-	primaryAddr, listenAddress, err := primaryAndListenAddresses(r.Config().Cluster().Etcd().Subnet())
+	spec, err := waitForSpec(ctx, r)
 	if err != nil {
 		return fmt.Errorf("failed to calculate etcd addresses: %w", err)
 	}
 
-	// TODO(scm): see issue #2121 and description below in argsForControlPlane.
+	primaryAddr := spec.AdvertisedAddresses[0].String()
+
 	denyListArgs := argsbuilder.Args{
 		"name":                  hostname,
 		"data-dir":              constants.EtcdDataPath,
-		"listen-peer-urls":      "https://" + net.FormatAddress(listenAddress) + ":2380",
-		"listen-client-urls":    "https://" + net.FormatAddress(listenAddress) + ":2379",
+		"listen-peer-urls":      formatEtcdURLs(spec.ListenPeerAddresses, "2380"),
+		"listen-client-urls":    formatEtcdURLs(spec.ListenClientAddresses, "2379"),
 		"client-cert-auth":      "true",
 		"cert-file":             constants.KubernetesEtcdCert,
 		"key-file":              constants.KubernetesEtcdKey,
@@ -517,21 +500,18 @@ func (e *Etcd) argsForControlPlane(ctx context.Context, r runtime.Runtime) error
 		return err
 	}
 
-	// TODO(scm):  With the current setup, the listen (bind) address is
-	// essentially hard-coded because we need to calculate it before we process
-	// extraArgs (which may contain special overrides from the user.
-	// This needs to be refactored to allow greater binding flexibility.
-	// Issue #2121.
-	primaryAddr, listenAddress, err := primaryAndListenAddresses(r.Config().Cluster().Etcd().Subnet())
+	spec, err := waitForSpec(ctx, r)
 	if err != nil {
 		return fmt.Errorf("failed to calculate etcd addresses: %w", err)
 	}
 
+	primaryAddr := spec.AdvertisedAddresses[0].String()
+
 	denyListArgs := argsbuilder.Args{
 		"name":                  hostname,
 		"data-dir":              constants.EtcdDataPath,
-		"listen-peer-urls":      "https://" + net.FormatAddress(listenAddress) + ":2380",
-		"listen-client-urls":    "https://" + net.FormatAddress(listenAddress) + ":2379",
+		"listen-peer-urls":      formatEtcdURLs(spec.ListenPeerAddresses, "2380"),
+		"listen-client-urls":    formatEtcdURLs(spec.ListenClientAddresses, "2379"),
 		"client-cert-auth":      "true",
 		"cert-file":             constants.KubernetesEtcdPeerCert,
 		"key-file":              constants.KubernetesEtcdPeerKey,
@@ -643,7 +623,7 @@ func promoteMember(ctx context.Context, r runtime.Runtime, memberID uint64) erro
 		retry.WithJitter(time.Second),
 		retry.WithErrorLogging(true),
 	).RetryWithContext(ctx, func(ctx context.Context) error {
-		client, err := etcd.NewClientFromControlPlaneIPs(ctx, r.Config().Cluster().CA(), r.Config().Cluster().Endpoint())
+		client, err := etcd.NewSharedClientFromControlPlaneIPs(ctx, r.Config().Cluster().CA(), r.Config().Cluster().InternalEndpoint())
 		if err != nil {
 			return retry.ExpectedError(err)
 		}
@@ -673,47 +653,38 @@ func IsDirEmpty(name string) (bool, error) {
 	return false, err
 }
 
-// primaryAndListenAddresses calculates the primary (advertised) and listen (bind) addresses for etcd.
-func primaryAndListenAddresses(subnet string) (primary, listen string, err error) {
-	ips, err := net.IPAddrs()
-	if err != nil {
-		return "", "", fmt.Errorf("failed to discover interface IP addresses: %w", err)
-	}
-
-	ips = net.IPFilter(ips, network.NotSideroLinkStdIP)
+// waitForSpec waits for etcd spec (advertised and listen addresses) to be computed by the controller.
+func waitForSpec(ctx context.Context, r runtime.Runtime) (*etcdresource.SpecSpec, error) {
+	watchCh := make(chan state.Event)
 
-	if len(ips) == 0 {
-		return "", "", errors.New("no valid unicast IP addresses on any interface")
+	if err := r.State().V1Alpha2().Resources().Watch(ctx, resource.NewMetadata(etcdresource.NamespaceName, etcdresource.SpecType, etcdresource.SpecID, resource.VersionUndefined), watchCh); err != nil {
+		return nil, err
 	}
 
-	if subnet == "" {
-		primary = ips[0].String()
-	} else {
-		network, err := net.ParseCIDR(subnet)
-		if err != nil {
-			return "", "", fmt.Errorf("failed to parse subnet: %w", err)
-		}
-
-		for _, ip := range ips {
-			if network.Contains(ip) {
-				primary = ip.String()
+	var event state.Event
 
-				break
-			}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event = <-watchCh:
 		}
 
-		if primary == "" {
-			return "", "", errors.New("no address matched the provided subnet")
+		if event.Type == state.Created || event.Type == state.Updated {
+			break
 		}
 	}
 
-	// Regardless of primary selected IP, we should be liberal with our listen
-	// address, for maximum compatibility.  Again, this should probably be
-	// exposed later for greater control.
-	listen = "0.0.0.0"
-	if net.IsIPv6(ips...) {
-		listen = "::"
+	return event.Resource.(*etcdresource.Spec).TypedSpec(), nil
+}
+
+// formatEtcdURLs builds comma-separated list of etcd URLs for the addresses.
+func formatEtcdURLs(addrs []netaddr.IP, port string) string {
+	urls := make([]string, 0, len(addrs))
+
+	for _, addr := range addrs {
+		urls = append(urls, "https://"+net.FormatAddress(addr.String())+":"+port)
 	}
 
-	return primary, listen, nil
+	return strings.Join(urls, ",")
 }
@@ -7,26 +7,31 @@ package services
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
+	stdx509 "crypto/x509"
+	stdjson "encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
-	stdnet "net"
-	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
-	"strings"
-	"text/template"
+	"sort"
+	"sync"
 	"time"
 
 	containerdapi "github.com/containerd/containerd"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/oci"
-	cni "github.com/containerd/go-cni"
+	"github.com/cosi-project/runtime/pkg/resource"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
-	"github.com/talos-systems/net"
+	"github.com/talos-systems/crypto/x509"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/tools/clientcmd"
+	kubeletconfigv1alpha1 "k8s.io/kubelet/config/v1alpha1"
 	kubeletconfig "k8s.io/kubelet/config/v1beta1"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
@@ -37,35 +42,24 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
 	"github.com/talos-systems/talos/internal/pkg/capability"
 	"github.com/talos-systems/talos/internal/pkg/containers/image"
-	"github.com/talos-systems/talos/pkg/argsbuilder"
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
 	"github.com/talos-systems/talos/pkg/conditions"
-	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/grpc/gen"
+	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
 	timeresource "github.com/talos-systems/talos/pkg/machinery/resources/time"
 )
 
-var kubeletKubeConfigTemplate = []byte(`apiVersion: v1
-kind: Config
-clusters:
-- name: local
-  cluster:
-    server: {{ .Server }}
-    certificate-authority-data: {{ .CACert }}
-users:
-- name: kubelet
-  user:
-    token: {{ .BootstrapTokenID }}.{{ .BootstrapTokenSecret }}
-contexts:
-- context:
-    cluster: local
-    user: kubelet
-`)
-
 // Kubelet implements the Service interface. It serves as the concrete type with
 // the required methods.
-type Kubelet struct{}
+type Kubelet struct {
+	mu sync.Mutex
+
+	// startedSpec is the kubelet spec read on the last kubelet start.
+	startedSpec *k8s.KubeletSpecSpec
+}
 
 // ID implements the Service interface.
 func (k *Kubelet) ID(r runtime.Runtime) string {
@@ -74,40 +68,59 @@ func (k *Kubelet) ID(r runtime.Runtime) string {
 
 // PreFunc implements the Service interface.
 func (k *Kubelet) PreFunc(ctx context.Context, r runtime.Runtime) error {
-	cfg := struct {
-		Server               string
-		CACert               string
-		BootstrapTokenID     string
-		BootstrapTokenSecret string
-	}{
-		Server:               r.Config().Cluster().Endpoint().String(),
-		CACert:               base64.StdEncoding.EncodeToString(r.Config().Cluster().CA().Crt),
-		BootstrapTokenID:     r.Config().Cluster().Token().ID(),
-		BootstrapTokenSecret: r.Config().Cluster().Token().Secret(),
+	spec, err := k.spec(ctx, r)
+	if err != nil {
+		return err
 	}
 
-	templ := template.Must(template.New("tmpl").Parse(string(kubeletKubeConfigTemplate)))
+	// the kubelet is started with the spec read here, even if the kubelet spec is updated while the kubelet is starting
+	k.mu.Lock()
+	k.startedSpec = spec
+	k.mu.Unlock()
 
-	var buf bytes.Buffer
+	// standalone kubelet doesn't talk to the API server, so there is no kubeconfig
+	if !spec.SkipNodeRegistration {
+		// bootstrap kubeconfig is written by the controller
+		if err = conditions.WaitForFileToExist(constants.KubeletBootstrapKubeconfig).Wait(ctx); err != nil {
+			return fmt.Errorf("error waiting for kubelet bootstrap kubeconfig: %w", err)
+		}
 
-	if err := templ.Execute(&buf, cfg); err != nil {
-		return err
+		// kubelet keeps the API server endpoint from the bootstrap kubeconfig, so update it if it changed since bootstrap
+		if err = updateKubeletKubeconfigServer(spec.APIServerEndpoint); err != nil {
+			return fmt.Errorf("error updating kubelet kubeconfig: %w", err)
+		}
 	}
 
-	if err := ioutil.WriteFile(constants.KubeletBootstrapKubeconfig, buf.Bytes(), 0o600); err != nil {
-		return err
+	if spec.CACert != "" {
+		if err = os.MkdirAll(filepath.Dir(constants.KubernetesCACert), 0o700); err != nil {
+			return err
+		}
+
+		if err = ioutil.WriteFile(constants.KubernetesCACert, []byte(spec.CACert), 0o400); err != nil {
+			return err
+		}
 	}
 
-	if err := os.MkdirAll(filepath.Dir(constants.KubernetesCACert), 0o700); err != nil {
+	kubeletConfiguration, err := writeKubeletConfig(spec)
+	if err != nil {
 		return err
 	}
 
-	if err := ioutil.WriteFile(constants.KubernetesCACert, r.Config().Cluster().CA().Crt, 0o400); err != nil {
+	if err = removeStaleKubeletManagerState(kubeletConfiguration); err != nil {
 		return err
 	}
 
-	if err := writeKubeletConfig(r); err != nil {
-		return err
+	// credential provider config might contain secrets in the environment, so it's not part of the kubelet spec
+	if credentialProviders := r.Config().Machine().Kubelet().CredentialProviders(); credentialProviders != nil {
+		if err = writeKubeletCredentialProviderConfig(credentialProviders); err != nil {
+			return err
+		}
+	}
+
+	if spec.TrustdCredentials && !spec.SkipNodeRegistration {
+		if err = issueKubeletClientCertificate(ctx, r); err != nil {
+			return fmt.Errorf("error issuing kubelet client certificate: %w", err)
+		}
 	}
 
 	client, err := containerdapi.New(constants.CRIContainerdAddress)
@@ -117,10 +130,10 @@ func (k *Kubelet) PreFunc(ctx context.Context, r runtime.Runtime) error {
 	//nolint:errcheck
 	defer client.Close()
 
-	// Pull the image and unpack it.
+	// Pull the image and unpack it, registries config contains the auth, so it's not part of the kubelet spec.
 	containerdctx := namespaces.WithNamespace(ctx, constants.SystemContainerdNamespace)
 
-	_, err = image.Pull(containerdctx, r.Config().Machine().Registries(), client, r.Config().Machine().Kubelet().Image(), image.WithSkipIfAlreadyPulled())
+	_, err = image.Pull(containerdctx, r.Config().Machine().Registries(), client, spec.Image, image.WithSkipIfAlreadyPulled())
 	if err != nil {
 		return err
 	}
@@ -135,10 +148,11 @@ func (k *Kubelet) PostFunc(r runtime.Runtime, state events.ServiceState) (err er
 
 // Condition implements the Service interface.
 func (k *Kubelet) Condition(r runtime.Runtime) conditions.Condition {
+	// kubelet spec is rendered only once the nodename is known (unless the kubelet is standalone)
 	return conditions.WaitForAll(
 		timeresource.NewSyncCondition(r.State().V1Alpha2().Resources()),
 		network.NewReadyCondition(r.State().V1Alpha2().Resources(), network.AddressReady, network.HostnameReady, network.EtcFilesReady),
-		k8s.NewNodenameReadyCondition(r.State().V1Alpha2().Resources()),
+		k8s.NewKubeletSpecReadyCondition(r.State().V1Alpha2().Resources()),
 	)
 }
 
@@ -149,15 +163,15 @@ func (k *Kubelet) DependsOn(r runtime.Runtime) []string {
 
 // Runner implements the Service interface.
 func (k *Kubelet) Runner(r runtime.Runtime) (runner.Runner, error) {
-	a, err := k.args(r)
-	if err != nil {
-		return nil, err
+	spec := k.StartedSpec()
+	if spec == nil {
+		return nil, fmt.Errorf("kubelet spec is not loaded")
 	}
 
 	// Set the process arguments.
 	args := runner.Args{
 		ID:          k.ID(r),
-		ProcessArgs: append([]string{"/usr/local/bin/kubelet"}, a...),
+		ProcessArgs: append([]string{"/usr/local/bin/kubelet"}, spec.Args...),
 	}
 	// Set the required kubelet mounts.
 	mounts := []specs.Mount{
@@ -176,75 +190,143 @@ func (k *Kubelet) Runner(r runtime.Runtime) (runner.Runner, error) {
 		{Type: "bind", Destination: "/var/log/pods", Source: "/var/log/pods", Options: []string{"rbind", "rshared", "rw"}},
 	}
 
-	// Add extra mounts.
-	// TODO(andrewrynhard): We should verify that the mount source is
-	// allowlisted. There is the potential that a user can expose
-	// sensitive information.
-	for _, mount := range r.Config().Machine().Kubelet().ExtraMounts() {
-		if err = os.MkdirAll(mount.Source, 0o700); err != nil {
+	// Add extra mounts (including the image credential provider plugins).
+	for _, mount := range spec.ExtraMounts {
+		if err := os.MkdirAll(mount.Source, 0o700); err != nil {
 			return nil, err
 		}
 
 		mounts = append(mounts, mount)
 	}
 
+	// environment might contain the credentials (e.g. proxy), so it's not part of the kubelet spec
 	env := []string{}
 	for key, val := range r.Config().Machine().Env() {
 		env = append(env, fmt.Sprintf("%s=%s", key, val))
 	}
 
+	env, overrideOpts, err := applyServiceOverride(r, k.ID(r), env, mounts)
+	if err != nil {
+		return nil, err
+	}
+
 	return restart.New(containerd.NewRunner(
-		r.Config().Debug() && r.Config().Machine().Type() == machine.TypeWorker, // enable debug logs only for the worker nodes
+		spec.DebugLogs,
 		&args,
 		runner.WithLoggingManager(r.Logging()),
 		runner.WithNamespace(constants.SystemContainerdNamespace),
-		runner.WithContainerImage(r.Config().Machine().Kubelet().Image()),
+		runner.WithContainerImage(spec.Image),
 		runner.WithEnv(env),
 		runner.WithOCISpecOpts(
-			containerd.WithRootfsPropagation("shared"),
-			oci.WithCgroup(constants.CgroupKubelet),
-			oci.WithMounts(mounts),
-			oci.WithHostNamespace(specs.NetworkNamespace),
-			oci.WithHostNamespace(specs.PIDNamespace),
-			oci.WithParentCgroupDevices,
-			oci.WithMaskedPaths(nil),
-			oci.WithReadonlyPaths(nil),
-			oci.WithWriteableSysfs,
-			oci.WithWriteableCgroupfs,
-			oci.WithSelinuxLabel(""),
-			oci.WithApparmorProfile(""),
-			oci.WithAllDevicesAllowed,
-			oci.WithCapabilities(capability.AllGrantableCapabilities()), // TODO: kubelet doesn't need all of these, we should consider limiting capabilities
+			append([]oci.SpecOpts{
+				containerd.WithRootfsPropagation("shared"),
+				oci.WithCgroup(constants.CgroupKubelet),
+				oci.WithMounts(mounts),
+				oci.WithHostNamespace(specs.NetworkNamespace),
+				oci.WithHostNamespace(specs.PIDNamespace),
+				oci.WithParentCgroupDevices,
+				oci.WithMaskedPaths(nil),
+				oci.WithReadonlyPaths(nil),
+				oci.WithWriteableSysfs,
+				oci.WithWriteableCgroupfs,
+				oci.WithSelinuxLabel(""),
+				oci.WithApparmorProfile(""),
+				oci.WithAllDevicesAllowed,
+				oci.WithCapabilities(kubeletCapabilities(spec.ExtraCapabilities)),
+			}, overrideOpts...)...,
 		),
 		runner.WithOOMScoreAdj(constants.KubeletOOMScoreAdj),
 		runner.WithCustomSeccompProfile(kubeletSeccomp),
 	),
-		restart.WithType(restart.Forever),
+		append(restartPolicy(k.DependsOn(r)...), restart.WithType(restart.Forever))...,
 	), nil
 }
 
-// HealthFunc implements the HealthcheckedService interface.
-func (k *Kubelet) HealthFunc(runtime.Runtime) health.Check {
-	return func(ctx context.Context) error {
-		req, err := http.NewRequest("GET", "http://127.0.0.1:10248/healthz", nil)
-		if err != nil {
-			return err
+// spec returns the kubelet spec rendered by the controllers.
+func (k *Kubelet) spec(ctx context.Context, r runtime.Runtime) (*k8s.KubeletSpecSpec, error) {
+	res, err := r.State().V1Alpha2().Resources().Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.KubeletSpecType, k8s.KubeletID, resource.VersionUndefined))
+	if err != nil {
+		return nil, fmt.Errorf("error getting kubelet spec: %w", err)
+	}
+
+	return res.(*k8s.KubeletSpec).TypedSpec(), nil
+}
+
+// StartedSpec returns the kubelet spec the kubelet was last started with, or nil if the kubelet wasn't started.
+func (k *Kubelet) StartedSpec() *k8s.KubeletSpecSpec {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.startedSpec
+}
+
+// kubeletDefaultCapabilities is the minimal set of capabilities required by the kubelet.
+//
+// Pod containers are created by the CRI, so the kubelet capabilities don't affect the workloads.
+var kubeletDefaultCapabilities = []string{
+	"CAP_CHOWN",            // volume ownership management (fsGroup)
+	"CAP_DAC_OVERRIDE",     // access to the pod volumes and logs
+	"CAP_DAC_READ_SEARCH",  // access to the pod volumes and logs
+	"CAP_FOWNER",           // volume ownership management (fsGroup)
+	"CAP_FSETID",           // volume ownership management (fsGroup)
+	"CAP_KILL",             // stopping the plugins and the exec sessions
+	"CAP_SETGID",           // running the volume plugins and mount helpers
+	"CAP_SETUID",           // running the volume plugins and mount helpers
+	"CAP_MKNOD",            // block volumes
+	"CAP_NET_ADMIN",        // iptables rules and hostport management
+	"CAP_NET_RAW",          // iptables rules
+	"CAP_SYS_ADMIN",        // volume mounts, cgroups management
+	"CAP_SYS_CHROOT",       // running the mount helpers in the host namespaces
+	"CAP_SYS_PTRACE",       // process and namespace stats of the pods
+	"CAP_SYS_RESOURCE",     // OOM score adjustment, resource limits
+	"CAP_AUDIT_WRITE",      // running the volume plugins and mount helpers
+	"CAP_NET_BIND_SERVICE", // binding to the privileged ports configured via extra args
+}
+
+// kubeletCapabilities returns the kubelet capabilities with the extra capabilities from the machine config.
+//
+// Capabilities which can't be granted to the container (missing in the bounding set) are skipped.
+func kubeletCapabilities(extraCapabilities []string) []string {
+	grantable := map[string]struct{}{}
+
+	for _, c := range capability.AllGrantableCapabilities() {
+		grantable[c] = struct{}{}
+	}
+
+	capabilities := make([]string, 0, len(kubeletDefaultCapabilities)+len(extraCapabilities))
+	seen := map[string]struct{}{}
+
+	for _, c := range append(append([]string(nil), kubeletDefaultCapabilities...), extraCapabilities...) {
+		if _, ok := grantable[c]; !ok {
+			continue
 		}
 
-		req = req.WithContext(ctx)
+		if _, ok := seen[c]; ok {
+			continue
+		}
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return err
+		seen[c] = struct{}{}
+
+		capabilities = append(capabilities, c)
+	}
+
+	return capabilities
+}
+
+// HealthFunc implements the HealthcheckedService interface.
+func (k *Kubelet) HealthFunc(r runtime.Runtime) health.Check {
+	return func(ctx context.Context) error {
+		spec := k.StartedSpec()
+		if spec == nil {
+			return fmt.Errorf("kubelet is not started")
 		}
-		//nolint:errcheck
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("expected HTTP status OK, got %s", resp.Status)
+		endpoint, err := url.Parse(spec.HealthEndpoint)
+		if err != nil {
+			return fmt.Errorf("error parsing kubelet health endpoint: %w", err)
 		}
 
-		return nil
+		return kubelet.CheckHealth(ctx, endpoint, constants.KubeletPKIDir)
 	}
 }
 
@@ -261,163 +343,93 @@ func (k *Kubelet) APIRestartAllowed(runtime.Runtime) bool {
 	return true
 }
 
-func newKubeletConfiguration(clusterDNS []string, dnsDomain string) *kubeletconfig.KubeletConfiguration {
-	f := false
-	t := true
-	oomScoreAdj := int32(constants.KubeletOOMScoreAdj)
-
-	return &kubeletconfig.KubeletConfiguration{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "kubelet.config.k8s.io/v1beta1",
-			Kind:       "KubeletConfiguration",
-		},
-		StaticPodPath:      constants.ManifestsDirectory,
-		Address:            "0.0.0.0",
-		Port:               constants.KubeletPort,
-		OOMScoreAdj:        &oomScoreAdj,
-		RotateCertificates: true,
-		Authentication: kubeletconfig.KubeletAuthentication{
-			X509: kubeletconfig.KubeletX509Authentication{
-				ClientCAFile: constants.KubernetesCACert,
-			},
-			Webhook: kubeletconfig.KubeletWebhookAuthentication{
-				Enabled: &t,
-			},
-			Anonymous: kubeletconfig.KubeletAnonymousAuthentication{
-				Enabled: &f,
-			},
-		},
-		Authorization: kubeletconfig.KubeletAuthorization{
-			Mode: kubeletconfig.KubeletAuthorizationModeWebhook,
-		},
-		ClusterDomain:       dnsDomain,
-		ClusterDNS:          clusterDNS,
-		SerializeImagePulls: &f,
-		FailSwapOn:          &f,
-		CgroupRoot:          "/",
-		SystemCgroups:       constants.CgroupSystem,
-		SystemReserved: map[string]string{
-			"cpu":               constants.KubeletSystemReservedCPU,
-			"memory":            constants.KubeletSystemReservedMemory,
-			"pid":               constants.KubeletSystemReservedPid,
-			"ephemeral-storage": constants.KubeletSystemReservedEphemeralStorage,
-		},
-		KubeletCgroups: constants.CgroupKubelet,
-	}
-}
-
-//nolint:gocyclo
-func (k *Kubelet) args(r runtime.Runtime) ([]string, error) {
-	nodename, err := r.NodeName()
+// writeKubeletConfig writes the kubelet configuration file from the kubelet spec.
+func writeKubeletConfig(spec *k8s.KubeletSpecSpec) (*kubeletconfig.KubeletConfiguration, error) {
+	encoded, err := stdjson.Marshal(spec.Config)
 	if err != nil {
 		return nil, err
 	}
 
-	args := argsbuilder.Args{
-		"bootstrap-kubeconfig":       constants.KubeletBootstrapKubeconfig,
-		"kubeconfig":                 constants.KubeletKubeconfig,
-		"container-runtime":          "remote",
-		"container-runtime-endpoint": "unix://" + constants.CRIContainerdAddress,
-		"config":                     "/etc/kubernetes/kubelet.yaml",
+	var kubeletConfiguration kubeletconfig.KubeletConfiguration
 
-		"cert-dir":     constants.KubeletPKIDir,
-		"cni-conf-dir": cni.DefaultNetDir,
+	if err = stdjson.Unmarshal(encoded, &kubeletConfiguration); err != nil {
+		return nil, fmt.Errorf("error decoding kubelet configuration: %w", err)
+	}
 
-		"hostname-override": nodename,
+	serializer := json.NewSerializerWithOptions(
+		json.DefaultMetaFactory,
+		nil,
+		nil,
+		json.SerializerOptions{
+			Yaml:   true,
+			Pretty: true,
+			Strict: true,
+		},
+	)
 
-		"logging-format": "json",
-	}
+	var buf bytes.Buffer
 
-	if r.Config().Cluster().ExternalCloudProvider().Enabled() {
-		args["cloud-provider"] = "external"
+	if err = serializer.Encode(&kubeletConfiguration, &buf); err != nil {
+		return nil, err
 	}
 
-	extraArgs := argsbuilder.Args(r.Config().Machine().Kubelet().ExtraArgs())
-
-	validSubnets := r.Config().Machine().Kubelet().NodeIP().ValidSubnets()
+	return &kubeletConfiguration, ioutil.WriteFile(constants.KubeletConfig, buf.Bytes(), 0o600)
+}
 
-	// configure automatically valid subnets for IPv4/IPv6 based on service CIDRs
-	if len(validSubnets) == 0 {
-		validSubnets, err = ipSubnetsFromServiceCIDRs(r.Config().Cluster().Network().ServiceCIDRs())
+// removeStaleKubeletManagerState removes the CPU and memory manager checkpoints on the policy change, as kubelet refuses to start otherwise.
+func removeStaleKubeletManagerState(kubeletConfiguration *kubeletconfig.KubeletConfiguration) error {
+	for _, manager := range []struct {
+		path   string
+		policy string
+	}{
+		{constants.KubeletCPUManagerStateFile, kubeletConfiguration.CPUManagerPolicy},
+		{constants.KubeletMemoryManagerStateFile, kubeletConfiguration.MemoryManagerPolicy},
+	} {
+		removed, err := kubelet.RemoveStaleManagerState(manager.path, manager.policy)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("error removing kubelet manager state: %w", err)
 		}
-	}
 
-	// anyway filter out pod cidrs, they can't be node IPs
-	for _, cidr := range r.Config().Cluster().Network().PodCIDRs() {
-		validSubnets = append(validSubnets, "!"+cidr)
+		if removed {
+			log.Printf("removed kubelet manager state %q, as the policy changed to %q", manager.path, manager.policy)
+		}
 	}
 
-	// filter out any virtual IPs, they can't be node IPs either
-	for _, device := range r.Config().Machine().Network().Devices() {
-		if device.VIPConfig() != nil {
-			validSubnets = append(validSubnets, "!"+device.VIPConfig().IP())
-		}
+	return nil
+}
 
-		for _, vlan := range device.Vlans() {
-			if vlan.VIPConfig() != nil {
-				validSubnets = append(validSubnets, "!"+vlan.VIPConfig().IP())
-			}
-		}
-	}
+func newCredentialProviderConfig(credentialProviders config.KubeletCredentialProviders) *kubeletconfigv1alpha1.CredentialProviderConfig {
+	providers := make([]kubeletconfigv1alpha1.CredentialProvider, 0, len(credentialProviders.Providers()))
 
-	// if the user supplied node-ip via extra args, no need to pick automatically
-	if !extraArgs.Contains("node-ip") {
-		var nodeIPs []stdnet.IP
+	for _, provider := range credentialProviders.Providers() {
+		env := make([]kubeletconfigv1alpha1.ExecEnvVar, 0, len(provider.Env()))
 
-		nodeIPs, err = pickNodeIPs(validSubnets)
-		if err != nil {
-			return nil, err
+		for name, value := range provider.Env() {
+			env = append(env, kubeletconfigv1alpha1.ExecEnvVar{Name: name, Value: value})
 		}
 
-		if len(nodeIPs) > 0 {
-			nodeIPsString := make([]string, len(nodeIPs))
+		sort.Slice(env, func(i, j int) bool { return env[i].Name < env[j].Name })
 
-			for i := range nodeIPs {
-				nodeIPsString[i] = nodeIPs[i].String()
-			}
-
-			args["node-ip"] = strings.Join(nodeIPsString, ",")
-		}
+		providers = append(providers, kubeletconfigv1alpha1.CredentialProvider{
+			Name:                 provider.Name(),
+			MatchImages:          provider.MatchImages(),
+			DefaultCacheDuration: &metav1.Duration{Duration: provider.DefaultCacheDuration()},
+			APIVersion:           provider.APIVersion(),
+			Args:                 provider.Args(),
+			Env:                  env,
+		})
 	}
 
-	if err = args.Merge(extraArgs, argsbuilder.WithMergePolicies(
-		argsbuilder.MergePolicies{
-			"bootstrap-kubeconfig":       argsbuilder.MergeDenied,
-			"kubeconfig":                 argsbuilder.MergeDenied,
-			"container-runtime":          argsbuilder.MergeDenied,
-			"container-runtime-endpoint": argsbuilder.MergeDenied,
-			"config":                     argsbuilder.MergeDenied,
-			"cert-dir":                   argsbuilder.MergeDenied,
-			"cni-conf-dir":               argsbuilder.MergeDenied,
+	return &kubeletconfigv1alpha1.CredentialProviderConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "kubelet.config.k8s.io/v1alpha1",
+			Kind:       "CredentialProviderConfig",
 		},
-	)); err != nil {
-		return nil, err
+		Providers: providers,
 	}
-
-	return args.Args(), nil
 }
 
-func writeKubeletConfig(r runtime.Runtime) error {
-	dnsServiceIPs, err := r.Config().Cluster().Network().DNSServiceIPs()
-	if err != nil {
-		return fmt.Errorf("failed to get DNS service IPs: %w", err)
-	}
-
-	dnsServiceIPsString := []string{}
-
-	dnsServiceIPsCustom := r.Config().Machine().Kubelet().ClusterDNS()
-	if dnsServiceIPsCustom == nil {
-		for _, dnsIP := range dnsServiceIPs {
-			dnsServiceIPsString = append(dnsServiceIPsString, dnsIP.String())
-		}
-	} else {
-		dnsServiceIPsString = dnsServiceIPsCustom
-	}
-
-	kubeletConfiguration := newKubeletConfiguration(dnsServiceIPsString, r.Config().Cluster().Network().DNSDomain())
-
+func writeKubeletCredentialProviderConfig(credentialProviders config.KubeletCredentialProviders) error {
 	serializer := json.NewSerializerWithOptions(
 		json.DefaultMetaFactory,
 		nil,
@@ -431,88 +443,172 @@ func writeKubeletConfig(r runtime.Runtime) error {
 
 	var buf bytes.Buffer
 
-	if err := serializer.Encode(kubeletConfiguration, &buf); err != nil {
+	if err := serializer.Encode(newCredentialProviderConfig(credentialProviders), &buf); err != nil {
 		return err
 	}
 
-	return ioutil.WriteFile("/etc/kubernetes/kubelet.yaml", buf.Bytes(), 0o600)
+	return ioutil.WriteFile(constants.KubeletCredentialProviderConfig, buf.Bytes(), 0o600)
 }
 
-func ipSubnetsFromServiceCIDRs(serviceCIDRs []string) ([]string, error) {
-	// automatically configure valid IP subnets based on service CIDRs
-	// if the primary service CIDR is IPv4, primary kubelet node IP should be IPv4 as well, and so on
-	result := make([]string, 0, len(serviceCIDRs))
-
-	for _, cidr := range serviceCIDRs {
-		network, err := net.ParseCIDR(cidr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse subnet: %w", err)
+func updateKubeletKubeconfigServer(server string) error {
+	kubeconfig, err := clientcmd.LoadFromFile(constants.KubeletKubeconfig)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
 		}
 
-		if network.IP.To4() == nil {
-			result = append(result, "::/0")
-		} else {
-			result = append(result, "0.0.0.0/0")
+		return err
+	}
+
+	changed := false
+
+	for _, cluster := range kubeconfig.Clusters {
+		if cluster.Server != server {
+			cluster.Server = server
+			changed = true
 		}
 	}
 
-	return result, nil
+	if !changed {
+		return nil
+	}
+
+	return clientcmd.WriteToFile(*kubeconfig, constants.KubeletKubeconfig)
 }
 
-func pickNodeIPs(cidrs []string) ([]stdnet.IP, error) {
-	if len(cidrs) == 0 {
-		return nil, nil
+// issueKubeletClientCertificate pre-provisions kubelet client certificate, so that kubelet skips TLS bootstrapping.
+//
+// Control plane nodes sign the certificate with the Kubernetes CA directly, other nodes request it from trustd.
+// Once issued, kubelet rotates the certificate on its own.
+// The CA key and the machine token are secrets, so they are read from the machine configuration.
+func issueKubeletClientCertificate(ctx context.Context, r runtime.Runtime) error {
+	certPath := filepath.Join(constants.KubeletPKIDir, "kubelet-client-current.pem")
+
+	if _, err := os.Stat(certPath); err == nil {
+		return nil
 	}
 
-	ips, err := net.IPAddrs()
+	nodenameRes, err := r.State().V1Alpha2().Resources().Get(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.NodenameType, k8s.NodenameID, resource.VersionUndefined))
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover interface IP addresses: %w", err)
+		return fmt.Errorf("error getting nodename: %w", err)
 	}
 
-	ips = net.IPFilter(ips, network.NotSideroLinkStdIP)
+	ca := r.Config().Cluster().CA()
+	if ca == nil {
+		return fmt.Errorf("kubernetes CA is not configured")
+	}
+
+	// signature algorithm of the issued certificate is copied from the CSR, so key type should match the CA key type
+	newCSRAndIdentity := x509.NewECDSACSRAndIdentity
 
-	ips, err = net.FilterIPs(ips, cidrs)
+	caCert, err := ca.GetCert()
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("error parsing Kubernetes CA: %w", err)
 	}
 
-	// filter down to make sure only one IPv4 and one IPv6 address stays
-	var hasIPv4, hasIPv6 bool
+	if caCert.PublicKeyAlgorithm == stdx509.RSA {
+		newCSRAndIdentity = x509.NewRSACSRAndIdentity
+	}
 
-	result := make([]stdnet.IP, 0, 2)
+	csr, identity, err := newCSRAndIdentity(
+		x509.CommonName(constants.NodesUsernamePrefix+nodenameRes.(*k8s.Nodename).TypedSpec().Nodename),
+		x509.Organization(constants.NodesGroup),
+	)
+	if err != nil {
+		return fmt.Errorf("error generating CSR: %w", err)
+	}
 
-	for _, ip := range ips {
-		switch {
-		case ip.To4() != nil:
-			if !hasIPv4 {
-				result = append(result, ip)
-				hasIPv4 = true
-			} else {
-				log.Printf("kubelet: warning: skipped node IP %s, please use .machine.kubelet.nodeIP to provide explicit subnet for the node IP", ip)
-			}
-		case ip.To16() != nil:
-			if !hasIPv6 {
-				result = append(result, ip)
-				hasIPv6 = true
-			} else {
-				log.Printf("kubelet: warning: skipped node IP %s, please use .machine.kubelet.nodeIP to provide explicit subnet for the node IP", ip)
+	if len(ca.Key) > 0 {
+		var crt *x509.Certificate
+
+		crt, err = x509.NewCertificateFromCSRBytes(ca.Crt, ca.Key, csr.X509CertificateRequestPEM,
+			x509.KeyUsage(stdx509.KeyUsageDigitalSignature|stdx509.KeyUsageKeyEncipherment),
+			x509.ExtKeyUsage([]stdx509.ExtKeyUsage{stdx509.ExtKeyUsageClientAuth}),
+		)
+		if err != nil {
+			return fmt.Errorf("error signing CSR: %w", err)
+		}
+
+		identity.Crt = crt.X509CertificatePEM
+	} else {
+		var endpoints []string
+
+		endpoints, err = trustdEndpoints(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var remoteGen *gen.RemoteGenerator
+
+		remoteGen, err = gen.NewRemoteGenerator(r.Config().Machine().Security().Token(), endpoints, r.Config().Machine().Security().CA())
+		if err != nil {
+			return fmt.Errorf("failed creating trustd client: %w", err)
+		}
+
+		defer remoteGen.Close() //nolint:errcheck
+
+		if document, docErr := runtime.InstanceIdentityDocument(ctx, r.State().Platform()); docErr != nil {
+			log.Printf("failed to fetch instance identity document: %s", docErr)
+		} else if document != nil {
+			remoteGen.SetInstanceIdentity(r.State().Platform().Name(), document)
+		}
+
+		_, identity.Crt, err = remoteGen.KubeletIdentityContext(ctx, csr)
+		if err != nil {
+			if code := status.Code(err); code == codes.FailedPrecondition || code == codes.PermissionDenied {
+				// e.g. the node is not registered yet without the verified instance identity, or the request comes from another address
+				log.Printf("trustd declined to issue kubelet client certificate, falling back to TLS bootstrapping: %s", status.Convert(err).Message())
+
+				return nil
 			}
+
+			return fmt.Errorf("failed to sign kubelet CSR: %w", err)
 		}
 	}
 
-	return result, nil
+	if err = os.MkdirAll(constants.KubeletPKIDir, 0o700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(certPath, append(identity.Crt, identity.Key...), 0o600)
 }
 
-func kubeletSeccomp(seccomp *specs.LinuxSeccomp) {
-	// for cephfs mounts
-	seccomp.Syscalls = append(seccomp.Syscalls,
-		specs.LinuxSyscall{
-			Names: []string{
-				"add_key",
-				"request_key",
-			},
-			Action: specs.ActAllow,
-			Args:   []specs.LinuxSeccompArg{},
+// trustdEndpoints returns the list of addresses to reach trustd on the control plane nodes.
+//
+// Control plane endpoints discovered so far are merged with the internal control plane endpoint host.
+func trustdEndpoints(ctx context.Context, r runtime.Runtime) ([]string, error) {
+	endpointResources, err := r.State().V1Alpha2().Resources().List(ctx, resource.NewMetadata(k8s.ControlPlaneNamespaceName, k8s.EndpointType, "", resource.VersionUndefined))
+	if err != nil {
+		return nil, fmt.Errorf("error getting endpoints resources: %w", err)
+	}
+
+	var endpointAddrs k8s.EndpointList
+
+	for _, res := range endpointResources.Items {
+		endpointAddrs = endpointAddrs.Merge(res.(*k8s.Endpoint))
+	}
+
+	return append(endpointAddrs.Strings(), r.Config().Cluster().InternalEndpoint().Hostname()), nil
+}
+
+// KubeletSeccompProfile is the kubelet seccomp profile, additional syscall allowances can be registered,
+// or provided by the extensions in the constants.KubeletSeccompAllowancesDir.
+var KubeletSeccompProfile = &kubelet.SeccompProfile{
+	Dir: constants.KubeletSeccompAllowancesDir,
+}
+
+func init() {
+	KubeletSeccompProfile.Register(kubelet.SeccompAllowance{
+		Name: "cephfs", // for cephfs mounts
+		Syscalls: []string{
+			"add_key",
+			"request_key",
 		},
-	)
+	})
+}
+
+func kubeletSeccomp(seccomp *specs.LinuxSeccomp) {
+	if err := KubeletSeccompProfile.Apply(seccomp); err != nil {
+		log.Printf("kubelet: warning: skipped seccomp allowances: %s", err)
+	}
 }
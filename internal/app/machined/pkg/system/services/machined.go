@@ -29,10 +29,16 @@ var rules = map[string]role.Set{
 	"/inspect.InspectService/ControllerRuntimeDependencies": role.MakeSet(role.Admin, role.Reader),
 
 	"/machine.MachineService/ApplyConfiguration":          role.MakeSet(role.Admin),
+	"/machine.MachineService/Attestation":                 role.MakeSet(role.Admin, role.Reader),
+	"/machine.MachineService/BMCControl":                  role.MakeSet(role.Admin),
 	"/machine.MachineService/Bootstrap":                   role.MakeSet(role.Admin),
+	"/machine.MachineService/Capabilities":                role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/CPUInfo":                     role.MakeSet(role.Admin, role.Reader),
+	"/machine.MachineService/Console":                     role.MakeSet(role.Admin, role.Reader),
+	"/machine.MachineService/ContainerDiskUsage":          role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Containers":                  role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Copy":                        role.MakeSet(role.Admin),
+	"/machine.MachineService/Debug":                       role.MakeSet(role.Debug),
 	"/machine.MachineService/DiskStats":                   role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/DiskUsage":                   role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Dmesg":                       role.MakeSet(role.Admin, role.Reader),
@@ -47,23 +53,28 @@ var rules = map[string]role.Set{
 	"/machine.MachineService/GenerateConfiguration":       role.MakeSet(role.Admin),
 	"/machine.MachineService/Hostname":                    role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Kubeconfig":                  role.MakeSet(role.Admin),
+	"/machine.MachineService/KubeletRegenerate":           role.MakeSet(role.Admin),
+	"/machine.MachineService/KubeletRepair":               role.MakeSet(role.Admin),
 	"/machine.MachineService/List":                        role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/LoadAvg":                     role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Logs":                        role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Memory":                      role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Mounts":                      role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/NetworkDeviceStats":          role.MakeSet(role.Admin, role.Reader),
+	"/machine.MachineService/PauseControllers":            role.MakeSet(role.Admin),
 	"/machine.MachineService/Processes":                   role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Read":                        role.MakeSet(role.Admin),
 	"/machine.MachineService/Reboot":                      role.MakeSet(role.Admin),
 	"/machine.MachineService/Reset":                       role.MakeSet(role.Admin),
 	"/machine.MachineService/Restart":                     role.MakeSet(role.Admin),
+	"/machine.MachineService/ResumeControllers":           role.MakeSet(role.Admin),
 	"/machine.MachineService/Rollback":                    role.MakeSet(role.Admin),
 	"/machine.MachineService/ServiceList":                 role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/ServiceRestart":              role.MakeSet(role.Admin),
 	"/machine.MachineService/ServiceStart":                role.MakeSet(role.Admin),
 	"/machine.MachineService/ServiceStop":                 role.MakeSet(role.Admin),
 	"/machine.MachineService/Shutdown":                    role.MakeSet(role.Admin),
+	"/machine.MachineService/SocketStats":                 role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Stats":                       role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/SystemStat":                  role.MakeSet(role.Admin, role.Reader),
 	"/machine.MachineService/Upgrade":                     role.MakeSet(role.Admin),
@@ -74,7 +85,8 @@ var rules = map[string]role.Set{
 	"/resource.ResourceService/List":  role.MakeSet(role.Admin, role.Reader),
 	"/resource.ResourceService/Watch": role.MakeSet(role.Admin, role.Reader),
 
-	"/storage.StorageService/Disks": role.MakeSet(role.Admin, role.Reader),
+	"/storage.StorageService/BlockDeviceWipe": role.MakeSet(role.Admin),
+	"/storage.StorageService/Disks":           role.MakeSet(role.Admin, role.Reader),
 
 	"/time.TimeService/Time":      role.MakeSet(role.Admin, role.Reader),
 	"/time.TimeService/TimeCheck": role.MakeSet(role.Admin, role.Reader),
@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/oci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
+	"github.com/talos-systems/talos/pkg/argsbuilder"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// applyServiceOverride merges the service override from the machine configuration into the service container spec.
+//
+// Environment variables listed in deniedEnv can't be overridden, override mounts can't shadow the service mounts
+// and can't expose Talos system paths (including the secrets) to the service.
+// Updated environment and extra OCI spec options are returned.
+func applyServiceOverride(r runtime.Runtime, id string, env []string, mounts []specs.Mount, deniedEnv ...string) ([]string, []oci.SpecOpts, error) {
+	var override config.ServiceOverride
+
+	for _, o := range r.Config().Machine().ServiceOverrides() {
+		if o.Service() == id {
+			override = o
+
+			break
+		}
+	}
+
+	if override == nil {
+		return env, nil, nil
+	}
+
+	envArgs := argsbuilder.Args{}
+
+	for _, kv := range env {
+		if idx := strings.IndexByte(kv, '='); idx > 0 {
+			envArgs[kv[:idx]] = kv[idx+1:]
+		}
+	}
+
+	policies := argsbuilder.MergePolicies{}
+
+	for _, key := range deniedEnv {
+		policies[key] = argsbuilder.MergeDenied
+	}
+
+	if err := envArgs.Merge(argsbuilder.Args(override.Env()), argsbuilder.WithMergePolicies(policies)); err != nil {
+		return nil, nil, fmt.Errorf("error merging %s environment override: %w", id, err)
+	}
+
+	keys := make([]string, 0, len(envArgs))
+
+	for key := range envArgs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	env = make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		env = append(env, fmt.Sprintf("%s=%s", key, envArgs[key]))
+	}
+
+	extraMounts := override.Mounts()
+
+	for _, extraMount := range extraMounts {
+		if pathOverlaps(extraMount.Source, constants.SystemPath) {
+			return nil, nil, fmt.Errorf("%s mount source %q is not allowed", id, extraMount.Source)
+		}
+
+		for _, mount := range mounts {
+			if pathOverlaps(extraMount.Destination, mount.Destination) {
+				return nil, nil, fmt.Errorf("%s mount destination %q conflicts with the service mount %q", id, extraMount.Destination, mount.Destination)
+			}
+		}
+	}
+
+	return env, []oci.SpecOpts{
+		oci.WithMounts(extraMounts),
+		containerd.WithRlimits(override.Rlimits()),
+	}, nil
+}
+
+// pathOverlaps checks whether one of the paths is the same or nested under another one.
+func pathOverlaps(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+
+	if a == "/" || b == "/" || a == b {
+		return true
+	}
+
+	return strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
@@ -10,10 +10,14 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/containerd/containerd/oci"
 	"github.com/containerd/containerd/pkg/cap"
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/talos-systems/go-debug"
 
@@ -24,7 +28,9 @@ import (
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
 	"github.com/talos-systems/talos/pkg/conditions"
+	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
 	timeresource "github.com/talos-systems/talos/pkg/machinery/resources/time"
 )
@@ -40,9 +46,31 @@ func (t *Trustd) ID(r runtime.Runtime) string {
 
 // PreFunc implements the Service interface.
 func (t *Trustd) PreFunc(ctx context.Context, r runtime.Runtime) error {
+	// machined puts the short-lived kubelet bootstrap token into the directory, it should exist to be mounted
+	if err := os.MkdirAll(constants.TrustdSecretsDir, 0o700); err != nil {
+		return err
+	}
+
+	if err := os.Chown(constants.TrustdSecretsDir, constants.TrustdUserID, constants.TrustdUserID); err != nil {
+		return err
+	}
+
+	// trustd certificate is issued for the advertised node addresses
+	if _, err := r.State().V1Alpha2().Resources().WatchFor(ctx, trustdAdvertisedAddresses(),
+		state.WithCondition(func(r resource.Resource) (bool, error) {
+			return !resource.IsTombstone(r), nil
+		})); err != nil {
+		return fmt.Errorf("error waiting for the advertised node addresses: %w", err)
+	}
+
 	return prepareRootfs(t.ID(r))
 }
 
+func trustdAdvertisedAddresses() resource.Pointer {
+	return resource.NewMetadata(network.NamespaceName, network.NodeAddressType,
+		network.FilteredNodeAddressID(network.NodeAddressAccumulativeID, k8s.NodeAddressFilterNoK8s), resource.VersionUndefined)
+}
+
 // PostFunc implements the Service interface.
 func (t *Trustd) PostFunc(r runtime.Runtime, state events.ServiceState) (err error) {
 	return nil
@@ -63,15 +91,27 @@ func (t *Trustd) DependsOn(r runtime.Runtime) []string {
 
 // Runner implements the Service interface.
 func (t *Trustd) Runner(r runtime.Runtime) (runner.Runner, error) {
+	addresses, err := r.State().V1Alpha2().Resources().Get(context.Background(), trustdAdvertisedAddresses())
+	if err != nil {
+		return nil, fmt.Errorf("error getting the advertised node addresses: %w", err)
+	}
+
+	advertisedAddresses := make([]string, 0, len(addresses.(*network.NodeAddress).TypedSpec().Addresses))
+
+	for _, ip := range addresses.(*network.NodeAddress).TypedSpec().IPs() {
+		advertisedAddresses = append(advertisedAddresses, ip.String())
+	}
+
 	// Set the process arguments.
 	args := runner.Args{
 		ID:          t.ID(r),
-		ProcessArgs: []string{"/trustd"},
+		ProcessArgs: []string{"/trustd", "--advertised-addresses=" + strings.Join(advertisedAddresses, ",")},
 	}
 
 	// Set the mounts.
 	mounts := []specs.Mount{
 		{Type: "bind", Destination: "/tmp", Source: "/tmp", Options: []string{"rbind", "rshared", "rw"}},
+		{Type: "bind", Destination: constants.TrustdSecretsDir, Source: constants.TrustdSecretsDir, Options: []string{"rbind", "ro"}},
 	}
 
 	env := []string{}
@@ -83,13 +123,19 @@ func (t *Trustd) Runner(r runtime.Runtime) (runner.Runner, error) {
 		env = append(env, "GORACE=halt_on_error=1")
 	}
 
-	b, err := r.Config().Bytes()
+	// trustd needs the resolved secret values, the config is passed via the pipe and is never stored
+	b, err := encoder.NewEncoder(r.Config()).Encode()
 	if err != nil {
 		return nil, err
 	}
 
 	stdin := bytes.NewReader(b)
 
+	env, overrideOpts, err := applyServiceOverride(r, t.ID(r), env, mounts)
+	if err != nil {
+		return nil, err
+	}
+
 	return restart.New(containerd.NewRunner(
 		r.Config().Debug(),
 		&args,
@@ -98,17 +144,20 @@ func (t *Trustd) Runner(r runtime.Runtime) (runner.Runner, error) {
 		runner.WithContainerdAddress(constants.SystemContainerdAddress),
 		runner.WithEnv(env),
 		runner.WithOCISpecOpts(
-			containerd.WithMemoryLimit(int64(1000000*512)),
-			oci.WithDroppedCapabilities(cap.Known()),
-			oci.WithHostNamespace(specs.NetworkNamespace),
-			oci.WithMounts(mounts),
-			oci.WithRootFSPath(filepath.Join(constants.SystemLibexecPath, t.ID(r))),
-			oci.WithRootFSReadonly(),
-			oci.WithUser(fmt.Sprintf("%d:%d", constants.TrustdUserID, constants.TrustdUserID)),
+			append([]oci.SpecOpts{
+				containerd.WithMemoryLimit(int64(1000000 * 512)),
+				oci.WithDroppedCapabilities(cap.Known()),
+				oci.WithHostNamespace(specs.NetworkNamespace),
+				oci.WithMounts(mounts),
+				oci.WithRootFSPath(filepath.Join(constants.SystemLibexecPath, t.ID(r))),
+				oci.WithRootFSReadonly(),
+				oci.WithUser(fmt.Sprintf("%d:%d", constants.TrustdUserID, constants.TrustdUserID)),
+			}, overrideOpts...)...,
 		),
+		daemonSeccompOption(),
 		runner.WithOOMScoreAdj(-998),
 	),
-		restart.WithType(restart.Forever),
+		append(restartPolicy(), restart.WithType(restart.Forever))...,
 	), nil
 }
 
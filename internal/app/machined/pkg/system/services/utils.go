@@ -10,10 +10,18 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"syscall"
+	"time"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/talos-systems/go-procfs/procfs"
 	"golang.org/x/sys/unix"
 
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/restart"
+	"github.com/talos-systems/talos/pkg/conditions"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
@@ -52,3 +60,96 @@ func chownRecursive(root string, uid, gid uint32) error {
 		return nil
 	})
 }
+
+// daemonDeniedSyscalls are removed from the default seccomp profile for Talos API daemons.
+//
+// Default profile already denies privileged syscalls for processes without capabilities,
+// this list covers syscalls which are allowed unconditionally, but never used by Go network daemons.
+var daemonDeniedSyscalls = map[string]struct{}{
+	"io_uring_enter":    {},
+	"io_uring_register": {},
+	"io_uring_setup":    {},
+	"memfd_create":      {},
+	"mq_getsetattr":     {},
+	"mq_notify":         {},
+	"mq_open":           {},
+	"mq_timedreceive":   {},
+	"mq_timedsend":      {},
+	"mq_unlink":         {},
+	"msgctl":            {},
+	"msgget":            {},
+	"msgrcv":            {},
+	"msgsnd":            {},
+	"semctl":            {},
+	"semget":            {},
+	"semop":             {},
+	"semtimedop":        {},
+	"shmat":             {},
+	"shmctl":            {},
+	"shmdt":             {},
+	"shmget":            {},
+	"tee":               {},
+	"vmsplice":          {},
+}
+
+// daemonSeccompOption returns runner option which hardens the seccomp profile of Talos API daemons (apid, trustd).
+//
+// machined itself is not filtered, as seccomp filters and no-new-privileges are inherited by every process
+// it spawns, including containerd, kubelet and the workloads.
+// Hardened profile can be disabled with `talos.seccomp.hardened=0` kernel argument.
+func daemonSeccompOption() runner.Option {
+	if val := procfs.ProcCmdline().Get(constants.KernelParamHardenedSeccomp).First(); val != nil {
+		if enabled, err := strconv.ParseBool(*val); err == nil && !enabled {
+			return func(*runner.Options) {}
+		}
+	}
+
+	return runner.WithCustomSeccompProfile(daemonSeccomp)
+}
+
+func daemonSeccomp(seccomp *specs.LinuxSeccomp) {
+	syscalls := make([]specs.LinuxSyscall, 0, len(seccomp.Syscalls))
+
+	for _, syscall := range seccomp.Syscalls {
+		if syscall.Action == specs.ActAllow {
+			names := make([]string, 0, len(syscall.Names))
+
+			for _, name := range syscall.Names {
+				if _, denied := daemonDeniedSyscalls[name]; !denied {
+					names = append(names, name)
+				}
+			}
+
+			if len(names) == 0 {
+				continue
+			}
+
+			syscall.Names = names
+		}
+
+		syscalls = append(syscalls, syscall)
+	}
+
+	seccomp.Syscalls = syscalls
+}
+
+// restartPolicy returns restart options for long-running services which depend on other services:
+// restarts are delayed with exponential backoff and jitter, and wait for the dependencies to be up.
+func restartPolicy(dependencies ...string) []restart.Option {
+	opts := []restart.Option{
+		restart.WithMaxRestartInterval(time.Minute),
+		restart.WithJitter(0.1),
+	}
+
+	if len(dependencies) > 0 {
+		conds := make([]conditions.Condition, len(dependencies))
+
+		for i := range dependencies {
+			conds[i] = system.WaitForService(system.StateEventUp, dependencies[i])
+		}
+
+		opts = append(opts, restart.WithRestartCondition(conditions.WaitForAll(conds...)))
+	}
+
+	return opts
+}
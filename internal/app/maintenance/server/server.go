@@ -7,7 +7,9 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"path/filepath"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -17,11 +19,15 @@ import (
 	"github.com/talos-systems/talos/internal/app/resources"
 	storaged "github.com/talos-systems/talos/internal/app/storaged"
 	"github.com/talos-systems/talos/internal/pkg/configuration"
+	"github.com/talos-systems/talos/pkg/archiver"
+	"github.com/talos-systems/talos/pkg/chunker/stream"
+	"github.com/talos-systems/talos/pkg/machinery/api/common"
 	"github.com/talos-systems/talos/pkg/machinery/api/machine"
 	"github.com/talos-systems/talos/pkg/machinery/api/resource"
 	"github.com/talos-systems/talos/pkg/machinery/api/storage"
 	"github.com/talos-systems/talos/pkg/machinery/config/configloader"
 	v1alpha1machine "github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
 // Server implements machine.MachineService, network.NetworkService, and storage.StorageService.
@@ -71,7 +77,8 @@ func (s *Server) ApplyConfiguration(ctx context.Context, in *machine.ApplyConfig
 	reply := &machine.ApplyConfigurationResponse{
 		Messages: []*machine.ApplyConfiguration{
 			{
-				Warnings: warnings,
+				Warnings:     warnings,
+				Deprecations: configuration.Deprecations(cfgProvider),
 			},
 		},
 	}
@@ -100,3 +107,48 @@ func (s *Server) GenerateConfiguration(ctx context.Context, in *machine.Generate
 func (s *Server) GenerateClientConfiguration(ctx context.Context, in *machine.GenerateClientConfigurationRequest) (*machine.GenerateClientConfigurationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "client configuration (talosconfig) can't be generated in the maintenance mode")
 }
+
+// Copy implements the machine.MachineServer interface.
+//
+// In maintenance mode, only the boot diagnostics snapshot can be copied.
+func (s *Server) Copy(req *machine.CopyRequest, obj machine.MachineService_CopyServer) error {
+	path := filepath.Clean(req.RootPath)
+
+	if path != constants.BootDiagnosticsPath {
+		return status.Errorf(codes.PermissionDenied, "only %q can be copied in the maintenance mode", constants.BootDiagnosticsPath)
+	}
+
+	pr, pw := io.Pipe()
+
+	errCh := make(chan error, 1)
+
+	ctx, ctxCancel := context.WithCancel(obj.Context())
+	defer ctxCancel()
+
+	go func() {
+		//nolint:errcheck
+		defer pw.Close()
+		errCh <- archiver.TarGz(ctx, path, pw)
+	}()
+
+	chunker := stream.NewChunker(ctx, pr)
+	chunkCh := chunker.Read()
+
+	for data := range chunkCh {
+		err := obj.SendMsg(&common.Data{Bytes: data})
+		if err != nil {
+			ctxCancel()
+		}
+	}
+
+	archiveErr := <-errCh
+	if archiveErr != nil {
+		return obj.SendMsg(&common.Data{
+			Metadata: &common.Metadata{
+				Error: archiveErr.Error(),
+			},
+		})
+	}
+
+	return nil
+}
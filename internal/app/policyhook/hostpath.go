@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package policyhook
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// defaultAllowedPaths are always allowed to be mounted into the containers.
+var defaultAllowedPaths = []string{
+	// pod volumes, /etc/hosts and the termination log
+	"/var/lib/kubelet/pods",
+	// /etc/resolv.conf, /etc/hostname and /dev/shm of the pod sandboxes
+	"/var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes",
+	"/run/containerd/io.containerd.grpc.v1.cri/sandboxes",
+	// /dev/shm of the pods in the host IPC namespace
+	"/dev/shm",
+	// control plane static pods
+	constants.KubernetesAPIServerSecretsDir,
+	constants.KubernetesControllerManagerSecretsDir,
+	constants.KubernetesSchedulerSecretsDir,
+	// kube-proxy
+	"/lib/modules",
+	"/etc/ssl/certs",
+	// flannel
+	"/run/flannel",
+	"/etc/cni/net.d",
+	"/opt/cni/bin",
+}
+
+// HostPathPolicy defines the host paths allowed to be mounted into the containers.
+type HostPathPolicy struct {
+	// AllowedPaths are allowed in addition to the defaultAllowedPaths, with all the nested paths.
+	AllowedPaths []string
+	// AllowBidirectionalPropagation allows the mounts created in the container to propagate to the host.
+	AllowBidirectionalPropagation bool
+}
+
+// Check returns an error if any bind mount of the container spec violates the policy.
+//
+// Mount sources are resolved before the check, so that the symlinks can't be used to escape the allowed paths.
+func (policy *HostPathPolicy) Check(spec *specs.Spec) error {
+	allowed := make([]string, 0, len(defaultAllowedPaths)+len(policy.AllowedPaths))
+
+	for _, path := range append(append([]string(nil), defaultAllowedPaths...), policy.AllowedPaths...) {
+		allowed = append(allowed, resolvePath(path))
+	}
+
+	var errs *multierror.Error
+
+	for _, mount := range spec.Mounts {
+		if !isBindMount(mount) {
+			continue
+		}
+
+		source, err := filepath.EvalSymlinks(mount.Source)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("error resolving host path %q: %w", mount.Source, err))
+
+			continue
+		}
+
+		if !isAllowed(source, allowed) {
+			errs = multierror.Append(errs, fmt.Errorf("host path %q mounted at %q is not allowed", mount.Source, mount.Destination))
+		}
+
+		if !policy.AllowBidirectionalPropagation && isBidirectional(mount) {
+			errs = multierror.Append(errs, fmt.Errorf("bidirectional propagation of host path %q mounted at %q is not allowed", mount.Source, mount.Destination))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// resolvePath resolves the symlinks in the path if it exists.
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	return filepath.Clean(path)
+}
+
+func isAllowed(path string, allowed []string) bool {
+	for _, prefix := range allowed {
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isBindMount(mount specs.Mount) bool {
+	if mount.Type == "bind" {
+		return true
+	}
+
+	for _, option := range mount.Options {
+		if option == "bind" || option == "rbind" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isBidirectional(mount specs.Mount) bool {
+	for _, option := range mount.Options {
+		if option == "shared" || option == "rshared" {
+			return true
+		}
+	}
+
+	return false
+}
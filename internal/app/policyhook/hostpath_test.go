@@ -0,0 +1,139 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package policyhook_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/app/policyhook"
+)
+
+func TestHostPathPolicyCheck(t *testing.T) {
+	root := t.TempDir()
+
+	allowed := filepath.Join(root, "allowed")
+	denied := filepath.Join(root, "denied")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(allowed, "nested"), 0o755))
+	require.NoError(t, os.MkdirAll(denied, 0o755))
+	require.NoError(t, os.Symlink(denied, filepath.Join(allowed, "escape")))
+
+	policy := &policyhook.HostPathPolicy{
+		AllowedPaths: []string{allowed},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		mount         specs.Mount
+		expectedError string
+	}{
+		{
+			name: "proc",
+			mount: specs.Mount{
+				Destination: "/proc",
+				Type:        "proc",
+				Source:      "proc",
+			},
+		},
+		{
+			name: "allowed",
+			mount: specs.Mount{
+				Destination: "/data",
+				Type:        "bind",
+				Source:      filepath.Join(allowed, "nested"),
+				Options:     []string{"rbind", "rprivate", "rw"},
+			},
+		},
+		{
+			name: "denied",
+			mount: specs.Mount{
+				Destination: "/data",
+				Source:      denied,
+				Options:     []string{"rbind", "rprivate", "rw"},
+			},
+			expectedError: "1 error occurred:\n\t* host path \"" + denied + "\" mounted at \"/data\" is not allowed\n\n",
+		},
+		{
+			name: "allowed prefix",
+			mount: specs.Mount{
+				Destination: "/data",
+				Type:        "bind",
+				Source:      allowed + "-not",
+				Options:     []string{"rbind"},
+			},
+			expectedError: "1 error occurred:\n\t* error resolving host path \"" + allowed + "-not\": lstat " + allowed + "-not: no such file or directory\n\n",
+		},
+		{
+			name: "symlink",
+			mount: specs.Mount{
+				Destination: "/data",
+				Type:        "bind",
+				Source:      filepath.Join(allowed, "escape"),
+				Options:     []string{"rbind"},
+			},
+			expectedError: "1 error occurred:\n\t* host path \"" + filepath.Join(allowed, "escape") + "\" mounted at \"/data\" is not allowed\n\n",
+		},
+		{
+			name: "dot dot",
+			mount: specs.Mount{
+				Destination: "/data",
+				Type:        "bind",
+				Source:      allowed + "/../denied",
+				Options:     []string{"rbind"},
+			},
+			expectedError: "1 error occurred:\n\t* host path \"" + allowed + "/../denied\" mounted at \"/data\" is not allowed\n\n",
+		},
+		{
+			name: "bidirectional",
+			mount: specs.Mount{
+				Destination: "/data",
+				Type:        "bind",
+				Source:      allowed,
+				Options:     []string{"rbind", "rshared"},
+			},
+			expectedError: "1 error occurred:\n\t* bidirectional propagation of host path \"" + allowed + "\" mounted at \"/data\" is not allowed\n\n",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(&specs.Spec{
+				Mounts: []specs.Mount{tt.mount},
+			})
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestHostPathPolicyCheckBidirectionalAllowed(t *testing.T) {
+	allowed := t.TempDir()
+
+	policy := &policyhook.HostPathPolicy{
+		AllowedPaths:                  []string{allowed},
+		AllowBidirectionalPropagation: true,
+	}
+
+	assert.NoError(t, policy.Check(&specs.Spec{
+		Mounts: []specs.Mount{
+			{
+				Destination: "/data",
+				Type:        "bind",
+				Source:      allowed,
+				Options:     []string{"rbind", "rshared"},
+			},
+		},
+	}))
+}
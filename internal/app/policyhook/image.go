@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package policyhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/pkg/cri/annotations"
+	"github.com/docker/distribution/reference"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// ImagePolicy defines the images allowed to run in the containers.
+//
+// Images are matched by the normalized reference (e.g. `docker.io/library/nginx:1.21`), the list entry matches
+// the reference itself and all the references nested under it (`docker.io/library` matches `docker.io/library/nginx:1.21`,
+// `docker.io/library/nginx` matches `docker.io/library/nginx@sha256:...`, but not `docker.io/library/nginx-unstable`).
+type ImagePolicy struct {
+	// Allowed images, all the images are allowed if empty.
+	Allowed []string
+	// Denied images are rejected even if they are allowed.
+	Denied []string
+	// RequireDigest rejects the images which are not pinned by the digest.
+	RequireDigest bool
+}
+
+// Check returns an error if the image of the container violates the policy.
+//
+// The image reference is taken from the annotations set by the CRI plugin, pod sandboxes are not checked.
+func (policy *ImagePolicy) Check(spec *specs.Spec) error {
+	if spec.Annotations[annotations.ContainerType] != annotations.ContainerTypeContainer {
+		return nil
+	}
+
+	image := spec.Annotations[annotations.ImageName]
+	if image == "" {
+		return fmt.Errorf("container image is not known")
+	}
+
+	if policy.RequireDigest {
+		ref, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			return fmt.Errorf("error parsing image reference %q: %w", image, err)
+		}
+
+		if _, ok := ref.(reference.Digested); !ok {
+			return fmt.Errorf("image %q is not pinned by digest", image)
+		}
+	}
+
+	if matchesImage(image, policy.Denied) {
+		return fmt.Errorf("image %q is denied", image)
+	}
+
+	if len(policy.Allowed) > 0 && !matchesImage(image, policy.Allowed) {
+		return fmt.Errorf("image %q is not allowed", image)
+	}
+
+	return nil
+}
+
+func matchesImage(image string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if image == prefix {
+			return true
+		}
+
+		if !strings.HasPrefix(image, prefix) {
+			continue
+		}
+
+		// the prefix should end at the reference component boundary
+		if strings.HasSuffix(prefix, "/") || strings.ContainsRune("/:@", rune(image[len(prefix)])) {
+			return true
+		}
+	}
+
+	return false
+}
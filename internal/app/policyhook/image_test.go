@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package policyhook_test
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/pkg/cri/annotations"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/internal/app/policyhook"
+)
+
+func TestImagePolicyCheck(t *testing.T) {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	for _, tt := range []struct {
+		name          string
+		policy        policyhook.ImagePolicy
+		containerType string
+		image         string
+		expectedError string
+	}{
+		{
+			name:          "sandbox",
+			policy:        policyhook.ImagePolicy{Allowed: []string{"registry.example.com"}},
+			containerType: annotations.ContainerTypeSandbox,
+		},
+		{
+			name:          "unknown image",
+			policy:        policyhook.ImagePolicy{},
+			containerType: annotations.ContainerTypeContainer,
+			expectedError: "container image is not known",
+		},
+		{
+			name:          "no restrictions",
+			policy:        policyhook.ImagePolicy{},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "docker.io/library/nginx:1.21",
+		},
+		{
+			name:          "allowed registry",
+			policy:        policyhook.ImagePolicy{Allowed: []string{"registry.example.com", "docker.io/library/nginx"}},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "registry.example.com/vetted/app:v1",
+		},
+		{
+			name:          "allowed repository",
+			policy:        policyhook.ImagePolicy{Allowed: []string{"registry.example.com", "docker.io/library/nginx"}},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "docker.io/library/nginx@" + digest,
+		},
+		{
+			name:          "not allowed repository",
+			policy:        policyhook.ImagePolicy{Allowed: []string{"registry.example.com", "docker.io/library/nginx"}},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "docker.io/library/nginx-unstable:latest",
+			expectedError: "image \"docker.io/library/nginx-unstable:latest\" is not allowed",
+		},
+		{
+			name:          "not allowed registry",
+			policy:        policyhook.ImagePolicy{Allowed: []string{"registry.example.com/"}},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "registry.example.com.evil/app:v1",
+			expectedError: "image \"registry.example.com.evil/app:v1\" is not allowed",
+		},
+		{
+			name:          "denied",
+			policy:        policyhook.ImagePolicy{Allowed: []string{"registry.example.com"}, Denied: []string{"registry.example.com/deprecated"}},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "registry.example.com/deprecated/app:v1",
+			expectedError: "image \"registry.example.com/deprecated/app:v1\" is denied",
+		},
+		{
+			name:          "digest pinned",
+			policy:        policyhook.ImagePolicy{RequireDigest: true},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "registry.example.com/app@" + digest,
+		},
+		{
+			name:          "digest not pinned",
+			policy:        policyhook.ImagePolicy{RequireDigest: true},
+			containerType: annotations.ContainerTypeContainer,
+			image:         "registry.example.com/app:v1",
+			expectedError: "image \"registry.example.com/app:v1\" is not pinned by digest",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &specs.Spec{
+				Annotations: map[string]string{
+					annotations.ContainerType: tt.containerType,
+				},
+			}
+
+			if tt.image != "" {
+				spec.Annotations[annotations.ImageName] = tt.image
+			}
+
+			err := tt.policy.Check(spec)
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
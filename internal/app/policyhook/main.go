@@ -0,0 +1,101 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package policyhook implements the OCI hook which enforces the machine policies for the CRI containers.
+package policyhook
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// policy is enforced by the hook for every container.
+type policy interface {
+	Check(spec *specs.Spec) error
+}
+
+// Main is the entrypoint into the policy OCI hook.
+//
+// The hook is run by the container runtime with the container state on stdin,
+// failing hook aborts the container creation.
+func Main() {
+	log.SetFlags(0)
+
+	var (
+		hostPathPolicy HostPathPolicy
+		imagePolicy    ImagePolicy
+	)
+
+	enforceHostPaths := flag.Bool("host-path-policy", false, "enforce the host path policy")
+	flag.Func("allowed-path", "host path allowed to be mounted into the containers", func(path string) error {
+		hostPathPolicy.AllowedPaths = append(hostPathPolicy.AllowedPaths, path)
+
+		return nil
+	})
+	flag.BoolVar(&hostPathPolicy.AllowBidirectionalPropagation, "allow-bidirectional-propagation", false, "allow Bidirectional mount propagation")
+
+	enforceImages := flag.Bool("image-policy", false, "enforce the image policy")
+	flag.Func("allowed-image", "image reference prefix allowed to run in the containers", func(image string) error {
+		imagePolicy.Allowed = append(imagePolicy.Allowed, image)
+
+		return nil
+	})
+	flag.Func("denied-image", "image reference prefix denied to run in the containers", func(image string) error {
+		imagePolicy.Denied = append(imagePolicy.Denied, image)
+
+		return nil
+	})
+	flag.BoolVar(&imagePolicy.RequireDigest, "require-image-digest", false, "require images to be pinned by digest")
+
+	flag.Parse()
+
+	var policies []policy
+
+	if *enforceHostPaths {
+		policies = append(policies, &hostPathPolicy)
+	}
+
+	if *enforceImages {
+		policies = append(policies, &imagePolicy)
+	}
+
+	if err := run(os.Stdin, policies); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(r io.Reader, policies []policy) error {
+	var state specs.State
+
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("error decoding container state: %w", err)
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Join(state.Bundle, "config.json"))
+	if err != nil {
+		return fmt.Errorf("error reading container spec: %w", err)
+	}
+
+	var spec specs.Spec
+
+	if err = json.Unmarshal(contents, &spec); err != nil {
+		return fmt.Errorf("error decoding container spec: %w", err)
+	}
+
+	for _, p := range policies {
+		if err = p.Check(&spec); err != nil {
+			return fmt.Errorf("container %q violates the machine policy: %w", state.ID, err)
+		}
+	}
+
+	return nil
+}
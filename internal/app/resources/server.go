@@ -7,7 +7,9 @@ package resources
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/cosi-project/runtime/pkg/resource"
@@ -30,7 +32,10 @@ type Server struct {
 	Resources state.State
 }
 
-func marshalResource(r resource.Resource) (*resourceapi.Resource, error) {
+// marshalResource converts the resource to the API representation.
+//
+// If fields are set, only the listed spec fields are returned.
+func marshalResource(r resource.Resource, fields []string) (*resourceapi.Resource, error) {
 	md := &resourceapi.Metadata{
 		Namespace: r.Metadata().Namespace(),
 		Type:      r.Metadata().Type(),
@@ -51,7 +56,12 @@ func marshalResource(r resource.Resource) (*resourceapi.Resource, error) {
 	if r.Spec() != nil {
 		var err error
 
-		spec.Yaml, err = yaml.Marshal(r.Spec())
+		if len(fields) > 0 {
+			spec.Yaml, err = marshalSpecFields(r.Spec(), fields)
+		} else {
+			spec.Yaml, err = yaml.Marshal(r.Spec())
+		}
+
 		if err != nil {
 			return nil, err
 		}
@@ -63,6 +73,104 @@ func marshalResource(r resource.Resource) (*resourceapi.Resource, error) {
 	}, nil
 }
 
+// marshalSpecFields marshals only the requested fields (dot-separated paths) of the spec.
+func marshalSpecFields(spec interface{}, fields []string) ([]byte, error) {
+	var node yaml.Node
+
+	if err := node.Encode(spec); err != nil {
+		return nil, err
+	}
+
+	paths := make([][]string, 0, len(fields))
+
+	for _, field := range fields {
+		paths = append(paths, strings.Split(field, "."))
+	}
+
+	return yaml.Marshal(projectNode(&node, paths))
+}
+
+// projectNode returns the copy of the mapping node which contains only the keys matching the paths.
+//
+// Nodes other than mappings are returned as is, as there are no fields to select.
+func projectNode(node *yaml.Node, paths [][]string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		return projectNode(node.Content[0], paths)
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return node
+	}
+
+	projected := &yaml.Node{
+		Kind:  yaml.MappingNode,
+		Tag:   node.Tag,
+		Style: node.Style,
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		var (
+			subpaths [][]string
+			whole    bool
+		)
+
+		for _, path := range paths {
+			if path[0] != key.Value {
+				continue
+			}
+
+			if len(path) == 1 {
+				whole = true
+
+				break
+			}
+
+			subpaths = append(subpaths, path[1:])
+		}
+
+		switch {
+		case whole:
+			projected.Content = append(projected.Content, key, value)
+		case len(subpaths) > 0:
+			projected.Content = append(projected.Content, key, projectNode(value, subpaths))
+		}
+	}
+
+	return projected
+}
+
+// paginate sorts the items by ID and returns the page starting after the continue token.
+//
+// The continue token for the next page is returned if there are more items.
+func paginate(items []resource.Resource, limit uint32, continueToken string) ([]resource.Resource, string, error) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Metadata().ID() < items[j].Metadata().ID()
+	})
+
+	if continueToken != "" {
+		lastID, err := base64.RawURLEncoding.DecodeString(continueToken)
+		if err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid continue token: %s", err)
+		}
+
+		idx := sort.Search(len(items), func(i int) bool {
+			return items[i].Metadata().ID() > string(lastID)
+		})
+
+		items = items[idx:]
+	}
+
+	if limit == 0 || len(items) <= int(limit) {
+		return items, "", nil
+	}
+
+	items = items[:limit]
+
+	return items, base64.RawURLEncoding.EncodeToString([]byte(items[limit-1].Metadata().ID())), nil
+}
+
 type resourceKind struct {
 	Namespace resource.Namespace
 	Type      resource.Type
@@ -176,12 +284,12 @@ func (s *Server) Get(ctx context.Context, in *resourceapi.GetRequest) (*resource
 		return nil, err
 	}
 
-	protoD, err := marshalResource(rd)
+	protoD, err := marshalResource(rd, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	protoR, err := marshalResource(r)
+	protoR, err := marshalResource(r, in.GetFields())
 	if err != nil {
 		return nil, err
 	}
@@ -217,19 +325,25 @@ func (s *Server) List(in *resourceapi.ListRequest, srv resourceapi.ResourceServi
 		return err
 	}
 
-	protoD, err := marshalResource(rd)
+	items, continueToken, err := paginate(list.Items, in.GetLimit(), in.GetContinueToken())
+	if err != nil {
+		return err
+	}
+
+	protoD, err := marshalResource(rd, nil)
 	if err != nil {
 		return err
 	}
 
 	if err = srv.Send(&resourceapi.ListResponse{
-		Definition: protoD,
+		Definition:    protoD,
+		ContinueToken: continueToken,
 	}); err != nil {
 		return err
 	}
 
-	for _, r := range list.Items {
-		protoR, err := marshalResource(r)
+	for _, r := range items {
+		protoR, err := marshalResource(r, in.GetFields())
 		if err != nil {
 			return err
 		}
@@ -262,7 +376,7 @@ func (s *Server) Watch(in *resourceapi.WatchRequest, srv resourceapi.ResourceSer
 		return err
 	}
 
-	protoD, err := marshalResource(rd)
+	protoD, err := marshalResource(rd, nil)
 	if err != nil {
 		return err
 	}
@@ -305,7 +419,7 @@ func (s *Server) Watch(in *resourceapi.WatchRequest, srv resourceapi.ResourceSer
 	}
 
 	for event := range eventCh {
-		protoR, err := marshalResource(event.Resource)
+		protoR, err := marshalResource(event.Resource, nil)
 		if err != nil {
 			return err
 		}
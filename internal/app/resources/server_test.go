@@ -0,0 +1,142 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/cosi-project/runtime/pkg/state/impl/inmem"
+	"github.com/cosi-project/runtime/pkg/state/impl/namespaced"
+	"github.com/cosi-project/runtime/pkg/state/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/app/resources"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/authz"
+	resourceapi "github.com/talos-systems/talos/pkg/machinery/api/resource"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+	"github.com/talos-systems/talos/pkg/machinery/role"
+)
+
+type listServer struct {
+	grpc.ServerStream
+
+	ctx      context.Context //nolint:containedctx
+	messages []*resourceapi.ListResponse
+}
+
+func (srv *listServer) Context() context.Context {
+	return srv.ctx
+}
+
+func (srv *listServer) Send(msg *resourceapi.ListResponse) error {
+	srv.messages = append(srv.messages, msg)
+
+	return nil
+}
+
+func setupServer(ctx context.Context, t *testing.T) *resources.Server {
+	st := state.WrapCore(namespaced.NewState(inmem.Build))
+
+	require.NoError(t, registry.NewNamespaceRegistry(st).Register(ctx, runtime.NamespaceName, "runtime resources"))
+	require.NoError(t, registry.NewResourceRegistry(st).Register(ctx, &runtime.HealthCheckStatus{}))
+
+	// create resources out of order to verify that pages are sorted by ID
+	for _, i := range []int{3, 0, 4, 1, 2} {
+		r := runtime.NewHealthCheckStatus(fmt.Sprintf("check-%d", i))
+		r.TypedSpec().Type = runtime.HealthCheckTypeHTTP
+		r.TypedSpec().Healthy = true
+		r.TypedSpec().Failures = i
+
+		require.NoError(t, st.Create(ctx, r))
+	}
+
+	return &resources.Server{
+		Resources: st,
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	ctx, cancel := context.WithCancel(authz.ContextWithRoles(context.Background(), role.MakeSet(role.Reader)))
+	defer cancel()
+
+	server := setupServer(ctx, t)
+
+	var (
+		ids           []string
+		continueToken string
+		pages         int
+	)
+
+	for {
+		srv := &listServer{ctx: ctx}
+
+		require.NoError(t, server.List(&resourceapi.ListRequest{
+			Type:          "healthchecks",
+			Limit:         2,
+			ContinueToken: continueToken,
+		}, srv))
+
+		require.NotEmpty(t, srv.messages)
+		require.NotNil(t, srv.messages[0].Definition)
+
+		for _, msg := range srv.messages[1:] {
+			ids = append(ids, msg.Resource.Metadata.Id)
+		}
+
+		pages++
+
+		continueToken = srv.messages[0].ContinueToken
+		if continueToken == "" {
+			break
+		}
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []string{"check-0", "check-1", "check-2", "check-3", "check-4"}, ids)
+
+	err := server.List(&resourceapi.ListRequest{
+		Type:          "healthchecks",
+		ContinueToken: "!!!",
+	}, &listServer{ctx: ctx})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestFieldProjection(t *testing.T) {
+	ctx, cancel := context.WithCancel(authz.ContextWithRoles(context.Background(), role.MakeSet(role.Reader)))
+	defer cancel()
+
+	server := setupServer(ctx, t)
+
+	srv := &listServer{ctx: ctx}
+
+	require.NoError(t, server.List(&resourceapi.ListRequest{
+		Type:   "healthchecks",
+		Limit:  1,
+		Fields: []string{"healthy", "failures", "missing.field"},
+	}, srv))
+
+	require.Len(t, srv.messages, 2)
+	assert.Equal(t, "healthy: true\nfailures: 0\n", string(srv.messages[1].Resource.Spec.Yaml))
+
+	resp, err := server.Get(ctx, &resourceapi.GetRequest{
+		Type:   "healthchecks",
+		Id:     "check-3",
+		Fields: []string{"failures"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Messages, 1)
+	assert.Equal(t, "failures: 3\n", string(resp.Messages[0].Resource.Spec.Yaml))
+
+	// definition is never projected, as clients need it to display the resources
+	assert.Contains(t, string(resp.Messages[0].Definition.Spec.Yaml), "printColumns")
+}
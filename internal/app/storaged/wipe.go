@@ -0,0 +1,236 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package internal
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"github.com/talos-systems/go-blockdevice/blockdevice"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/machinery/api/storage"
+)
+
+// zeroOutChunkSize is the size of the range zeroed out at once, so that the wipe can be canceled.
+const zeroOutChunkSize = 1 << 30
+
+// CheckDiskNotInUse verifies that the disk can be wiped.
+//
+// The disk and its partitions should not be mounted or held by other block devices (RAID arrays,
+// LVM volumes, encrypted volumes).
+//
+//nolint:gocyclo
+func CheckDiskNotInUse(sysBlockPath, mountInfoPath, device string) error {
+	if device == "" || strings.ContainsRune(device, '/') || device == "." || device == ".." {
+		return fmt.Errorf("invalid disk name %q", device)
+	}
+
+	diskPath := filepath.Join(sysBlockPath, device)
+
+	if _, err := os.Stat(diskPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("disk %q is not found", device)
+		}
+
+		return err
+	}
+
+	readOnly, err := readSysfs(diskPath, "ro")
+	if err != nil {
+		return err
+	}
+
+	if readOnly == "1" {
+		return fmt.Errorf("disk %q is read-only", device)
+	}
+
+	mounts, err := mountedDevices(mountInfoPath)
+	if err != nil {
+		return err
+	}
+
+	entries, err := ioutil.ReadDir(diskPath)
+	if err != nil {
+		return err
+	}
+
+	paths := []string{diskPath}
+
+	for _, entry := range entries {
+		if _, err = os.Stat(filepath.Join(diskPath, entry.Name(), "partition")); err == nil {
+			paths = append(paths, filepath.Join(diskPath, entry.Name()))
+		}
+	}
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+
+		devNum, err := readSysfs(path, "dev")
+		if err != nil {
+			return err
+		}
+
+		if mountpoint, ok := mounts[devNum]; ok {
+			return fmt.Errorf("%q is mounted at %q", name, mountpoint)
+		}
+
+		holders, err := ioutil.ReadDir(filepath.Join(path, "holders"))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if len(holders) > 0 {
+			return fmt.Errorf("%q is in use by %q (RAID, LVM or encrypted volume)", name, holders[0].Name())
+		}
+	}
+
+	return nil
+}
+
+// WipeDisk wipes the disk using the method.
+//
+// The disk is opened exclusively, so the kernel refuses to open the disk which is in use.
+func WipeDisk(ctx context.Context, device string, method storage.BlockDeviceWipeRequest_Method) error {
+	bd, err := blockdevice.Open(filepath.Join("/dev", device), blockdevice.WithMode(blockdevice.DefaultMode|unix.O_EXCL))
+	if err != nil {
+		return fmt.Errorf("error opening disk %q: %w", device, err)
+	}
+
+	//nolint:errcheck
+	defer bd.Close()
+
+	switch method {
+	case storage.BlockDeviceWipeRequest_FAST:
+		err = fastWipe(bd)
+	case storage.BlockDeviceWipeRequest_ZEROES:
+		err = zeroOut(ctx, bd)
+	case storage.BlockDeviceWipeRequest_SECURE:
+		err = secureErase(bd)
+	default:
+		err = fmt.Errorf("unsupported wipe method %s", method)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error wiping disk %q: %w", device, err)
+	}
+
+	// kernel still has the partitions of the wiped partition table
+	if err = bd.RereadPartitionTable(); err != nil {
+		return fmt.Errorf("error re-reading partition table of disk %q: %w", device, err)
+	}
+
+	return nil
+}
+
+// fastWipe discards the disk contents and zeroes out both the primary and the backup partition tables.
+func fastWipe(bd *blockdevice.BlockDevice) error {
+	if err := bd.FastWipe(); err != nil {
+		return err
+	}
+
+	size, err := bd.Size()
+	if err != nil {
+		return err
+	}
+
+	if size <= blockdevice.FastWipeRange {
+		return nil
+	}
+
+	_, err = bd.WipeRange(size-blockdevice.FastWipeRange, blockdevice.FastWipeRange)
+
+	return err
+}
+
+func zeroOut(ctx context.Context, bd *blockdevice.BlockDevice) error {
+	size, err := bd.Size()
+	if err != nil {
+		return err
+	}
+
+	for offset := uint64(0); offset < size; offset += zeroOutChunkSize {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		length := size - offset
+		if length > zeroOutChunkSize {
+			length = zeroOutChunkSize
+		}
+
+		r := [2]uint64{offset, length}
+
+		if _, _, errno := unix.Syscall(unix.SYS_IOCTL, bd.Device().Fd(), blockdevice.BLKZEROOUT, uintptr(unsafe.Pointer(&r[0]))); errno != 0 {
+			return fmt.Errorf("error zeroing out range [%d, %d): %w", offset, offset+length, errno)
+		}
+	}
+
+	return nil
+}
+
+func secureErase(bd *blockdevice.BlockDevice) error {
+	size, err := bd.Size()
+	if err != nil {
+		return err
+	}
+
+	r := [2]uint64{0, size}
+
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, bd.Device().Fd(), blockdevice.BLKSECDISCARD, uintptr(unsafe.Pointer(&r[0]))); errno != 0 {
+		if errors.Is(errno, unix.EOPNOTSUPP) {
+			return fmt.Errorf("secure erase is not supported by the disk")
+		}
+
+		return errno
+	}
+
+	return nil
+}
+
+// mountedDevices returns the mount points by the device number (`major:minor`) from the mountinfo.
+func mountedDevices(mountInfoPath string) (map[string]string, error) {
+	f, err := os.Open(mountInfoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	defer f.Close()
+
+	mounts := map[string]string{}
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		if _, ok := mounts[fields[2]]; !ok {
+			mounts[fields[2]] = fields[4]
+		}
+	}
+
+	return mounts, scanner.Err()
+}
+
+func readSysfs(path, name string) (string, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(path, name))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(contents)), nil
+}
@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package internal_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storaged "github.com/talos-systems/talos/internal/app/storaged"
+)
+
+func writeSysfs(t *testing.T, path string, files map[string]string) {
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "holders"), 0o755))
+
+	for name, contents := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(path, name), []byte(contents+"\n"), 0o644))
+	}
+}
+
+func TestCheckDiskNotInUse(t *testing.T) {
+	dir := t.TempDir()
+	sysBlock := filepath.Join(dir, "block")
+
+	// sda is mounted via the partition
+	writeSysfs(t, filepath.Join(sysBlock, "sda"), map[string]string{"dev": "8:0", "ro": "0"})
+	writeSysfs(t, filepath.Join(sysBlock, "sda", "sda1"), map[string]string{"dev": "8:1", "partition": "1"})
+
+	// sdb is unused
+	writeSysfs(t, filepath.Join(sysBlock, "sdb"), map[string]string{"dev": "8:16", "ro": "0"})
+	writeSysfs(t, filepath.Join(sysBlock, "sdb", "sdb1"), map[string]string{"dev": "8:17", "partition": "1"})
+
+	// sdc is a RAID member
+	writeSysfs(t, filepath.Join(sysBlock, "sdc"), map[string]string{"dev": "8:32", "ro": "0"})
+	require.NoError(t, os.Mkdir(filepath.Join(sysBlock, "sdc", "holders", "md0"), 0o755))
+
+	// sr0 is read-only
+	writeSysfs(t, filepath.Join(sysBlock, "sr0"), map[string]string{"dev": "11:0", "ro": "1"})
+
+	mountInfo := filepath.Join(dir, "mountinfo")
+	require.NoError(t, ioutil.WriteFile(mountInfo, []byte(
+		"22 1 0:20 / / rw,relatime - rootfs rootfs rw\n"+
+			"36 22 8:1 / /var rw,relatime - xfs /dev/sda1 rw\n",
+	), 0o644))
+
+	assert.NoError(t, storaged.CheckDiskNotInUse(sysBlock, mountInfo, "sdb"))
+
+	for device, expected := range map[string]string{
+		"sda":    `"sda1" is mounted at "/var"`,
+		"sdc":    `"sdc" is in use by "md0" (RAID, LVM or encrypted volume)`,
+		"sr0":    `disk "sr0" is read-only`,
+		"sdd":    `disk "sdd" is not found`,
+		"../sdb": `invalid disk name "../sdb"`,
+	} {
+		assert.EqualError(t, storaged.CheckDiskNotInUse(sysBlock, mountInfo, device), expected)
+	}
+}
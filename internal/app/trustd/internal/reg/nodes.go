@@ -0,0 +1,118 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package reg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/talos-systems/talos/internal/pkg/kubeconfig"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Node is the Kubernetes node registration the kubelet credentials requests are verified against.
+type Node struct {
+	// Addresses are the internal and external IP addresses of the node.
+	Addresses []string
+	// ProviderID is the cloud provider ID of the node, if set.
+	ProviderID string
+}
+
+// NodeLookupFunc returns the registered Kubernetes node, or nil if the node is not registered.
+type NodeLookupFunc func(ctx context.Context, nodename string) (*Node, error)
+
+// KubernetesNodeLookup looks up the nodes via Kubernetes API.
+//
+// Kubernetes client connects to the internal control plane endpoint with the admin certificate issued with the Kubernetes CA
+// from the machine configuration, the client is built once and rebuilt only when the certificate is halfway to expiry.
+func KubernetesNodeLookup(cfg config.Provider) NodeLookupFunc {
+	var (
+		mu        sync.Mutex
+		client    *kubernetes.Clientset
+		refreshAt time.Time
+	)
+
+	getClient := func() (*kubernetes.Clientset, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if client != nil && time.Now().Before(refreshAt) {
+			return client, nil
+		}
+
+		lifetime := cfg.Cluster().AdminKubeconfig().CertLifetime()
+
+		var buf bytes.Buffer
+
+		if err := kubeconfig.Generate(&kubeconfig.GenerateInput{
+			ClusterName:         cfg.Cluster().Name(),
+			CA:                  cfg.Cluster().CA(),
+			CertificateLifetime: lifetime,
+
+			CommonName:   constants.KubernetesAdminCertCommonName,
+			Organization: constants.KubernetesAdminCertOrganization,
+
+			Endpoint:    cfg.Cluster().InternalEndpoint().String(),
+			Username:    "admin",
+			ContextName: "admin",
+		}, &buf); err != nil {
+			return nil, fmt.Errorf("error generating kubeconfig: %w", err)
+		}
+
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("error loading kubeconfig: %w", err)
+		}
+
+		newClient, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error building Kubernetes client: %w", err)
+		}
+
+		client = newClient
+		refreshAt = time.Now().Add(lifetime / 2)
+
+		return client, nil
+	}
+
+	return func(ctx context.Context, nodename string) (*Node, error) {
+		client, err := getClient()
+		if err != nil {
+			return nil, err
+		}
+
+		node, err := client.CoreV1().Nodes().Get(ctx, nodename, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("error getting node: %w", err)
+		}
+
+		result := &Node{
+			Addresses:  []string{},
+			ProviderID: node.Spec.ProviderID,
+		}
+
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP || address.Type == corev1.NodeExternalIP {
+				result.Addresses = append(result.Addresses, address.Address)
+			}
+		}
+
+		return result, nil
+	}
+}
@@ -6,12 +6,29 @@ package reg
 
 import (
 	"context"
+	stdx509 "crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/talos-systems/crypto/x509"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	yaml "gopkg.in/yaml.v3"
 
+	"github.com/talos-systems/talos/internal/pkg/instanceidentity"
 	securityapi "github.com/talos-systems/talos/pkg/machinery/api/security"
 	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 )
 
 // Registrator is the concrete type that implements the factory.Registrator and
@@ -20,6 +37,17 @@ type Registrator struct {
 	securityapi.UnimplementedSecurityServiceServer
 
 	Config config.Provider
+
+	// Verifier verifies the instance identity documents, defaults to the verifier configured from the machine config.
+	Verifier *instanceidentity.Verifier
+
+	// KubeletBootstrapTokenPath defaults to constants.TrustdKubeletBootstrapToken.
+	KubeletBootstrapTokenPath string
+
+	// NodeLookup defaults to the lookup via Kubernetes API.
+	NodeLookup NodeLookupFunc
+
+	nodeLookupOnce sync.Once
 }
 
 // Register implements the factory.Registrator interface.
@@ -31,6 +59,10 @@ func (r *Registrator) Register(s *grpc.Server) {
 
 // Certificate implements the securityapi.SecurityServer interface.
 func (r *Registrator) Certificate(ctx context.Context, in *securityapi.CertificateRequest) (resp *securityapi.CertificateResponse, err error) {
+	if _, err = r.verifyInstanceIdentity(ctx, in.InstanceIdentityPlatform, in.InstanceIdentityDocument); err != nil {
+		return nil, err
+	}
+
 	// TODO: Verify that the request is coming from the IP addresss declared in
 	// the CSR.
 	signed, err := x509.NewCertificateFromCSRBytes(r.Config.Machine().Security().CA().Crt, r.Config.Machine().Security().CA().Key, in.Csr)
@@ -45,3 +77,216 @@ func (r *Registrator) Certificate(ctx context.Context, in *securityapi.Certifica
 
 	return resp, nil
 }
+
+// KubeletCertificate implements the securityapi.SecurityServer interface.
+//
+// KubeletCertificate signs kubelet client certificate with the Kubernetes CA,
+// CSR should contain only the node identity (`system:node:<nodename>` in the `system:nodes` group),
+// so that the shared machine token can't be used to get the credentials of another node:
+//
+//   - if the node is not registered in Kubernetes (new or deleted node), the credentials are issued only to the instances
+//     with the verified instance identity, otherwise the nodename could be taken over by registering it first;
+//   - if the node is registered with the cloud provider ID and the instance identity is verified, the provider ID should match the instance;
+//   - otherwise the request should come from one of the node addresses registered in Kubernetes.
+//
+// The address check relies on the source address of the request as seen by trustd, so it rejects the nodes behind NAT,
+// a load balancer or a virtual IP, and the nodes connecting from an address excluded from the node addresses
+// (e.g. with `.machine.network.ignoredSubnets`); kubelet on such nodes goes through TLS bootstrapping instead
+// (this applies to the recovery of the expired kubelet client certificate as well).
+func (r *Registrator) KubeletCertificate(ctx context.Context, in *securityapi.CertificateRequest) (*securityapi.CertificateResponse, error) {
+	ca := r.Config.Cluster().CA()
+	if ca == nil || len(ca.Key) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "Kubernetes CA is not available on this node")
+	}
+
+	identity, err := r.verifyInstanceIdentity(ctx, in.InstanceIdentityPlatform, in.InstanceIdentityDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	nodename, err := validateKubeletCSR(in.Csr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = r.verifyNode(ctx, nodename, identity); err != nil {
+		return nil, err
+	}
+
+	signed, err := x509.NewCertificateFromCSRBytes(ca.Crt, ca.Key, in.Csr,
+		x509.KeyUsage(stdx509.KeyUsageDigitalSignature|stdx509.KeyUsageKeyEncipherment),
+		x509.ExtKeyUsage([]stdx509.ExtKeyUsage{stdx509.ExtKeyUsageClientAuth}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &securityapi.CertificateResponse{
+		Ca:  ca.Crt,
+		Crt: signed.X509CertificatePEM,
+	}, nil
+}
+
+// verifyNode verifies that the request comes from the node it asks the credentials for.
+//
+// Identity is nil if the instance identity is not verified.
+func (r *Registrator) verifyNode(ctx context.Context, nodename string, identity *instanceidentity.Identity) error {
+	r.nodeLookupOnce.Do(func() {
+		if r.NodeLookup == nil {
+			r.NodeLookup = KubernetesNodeLookup(r.Config)
+		}
+	})
+
+	node, err := r.NodeLookup(ctx, nodename)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to look up node %q: %s", nodename, err)
+	}
+
+	if node == nil {
+		if identity == nil {
+			return status.Errorf(codes.FailedPrecondition, "node %q is not registered, credentials are issued only with the verified instance identity", nodename)
+		}
+
+		return nil
+	}
+
+	if identity != nil && node.ProviderID != "" {
+		if providerIDMatches(node.ProviderID, identity) {
+			return nil
+		}
+
+		return status.Errorf(codes.PermissionDenied, "node %q is registered with the provider ID %q which doesn't match the instance %q", nodename, node.ProviderID, identity.InstanceID)
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return status.Error(codes.PermissionDenied, "peer address is not available")
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "failed to parse peer address: %s", err)
+	}
+
+	peerIP := net.ParseIP(host)
+
+	for _, address := range node.Addresses {
+		if net.ParseIP(address).Equal(peerIP) {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied, "node %q is not registered with the address %s", nodename, host)
+}
+
+// providerIDMatches checks whether the provider ID (e.g. `aws:///<zone>/<instance-id>`, `gce://<project>/<zone>/<instance-name>`)
+// points to the instance.
+func providerIDMatches(providerID string, identity *instanceidentity.Identity) bool {
+	for _, id := range []string{identity.InstanceID, identity.InstanceName} {
+		if id != "" && strings.HasSuffix(providerID, "/"+id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KubeletBootstrapToken implements the securityapi.SecurityServer interface.
+//
+// KubeletBootstrapToken returns the short-lived kubelet bootstrap token minted by machined on this control plane node,
+// so that joining nodes don't bootstrap the kubelet with the long-lived token from the machine configuration.
+func (r *Registrator) KubeletBootstrapToken(ctx context.Context, in *securityapi.KubeletBootstrapTokenRequest) (*securityapi.KubeletBootstrapTokenResponse, error) {
+	if _, err := r.verifyInstanceIdentity(ctx, in.InstanceIdentityPlatform, in.InstanceIdentityDocument); err != nil {
+		return nil, err
+	}
+
+	path := r.KubeletBootstrapTokenPath
+	if path == "" {
+		path = constants.TrustdKubeletBootstrapToken
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, status.Error(codes.Unavailable, "kubelet bootstrap token is not available on this node")
+		}
+
+		return nil, err
+	}
+
+	var token secrets.KubeletBootstrapTokenSpec
+
+	if err = yaml.Unmarshal(contents, &token); err != nil {
+		return nil, fmt.Errorf("error unmarshaling kubelet bootstrap token: %w", err)
+	}
+
+	if !time.Now().Before(token.Expiration) {
+		return nil, status.Error(codes.Unavailable, "kubelet bootstrap token expired")
+	}
+
+	return &securityapi.KubeletBootstrapTokenResponse{
+		Token:      token.Token(),
+		Expiration: timestamppb.New(token.Expiration),
+	}, nil
+}
+
+// verifyInstanceIdentity verifies the instance identity document of the request if required by the configuration.
+//
+// Verified identity is returned, or nil if the instance identity is not required.
+func (r *Registrator) verifyInstanceIdentity(ctx context.Context, platform string, document []byte) (*instanceidentity.Identity, error) {
+	settings := r.Config.Cluster().InstanceIdentity()
+	if !settings.Required() {
+		return nil, nil
+	}
+
+	if len(document) == 0 {
+		return nil, status.Error(codes.PermissionDenied, "instance identity document is required")
+	}
+
+	verifier := r.Verifier
+	if verifier == nil {
+		verifier = &instanceidentity.Verifier{
+			AWSCertificates: settings.AWSCertificates(),
+		}
+	}
+
+	identity, err := verifier.Verify(ctx, platform, document)
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "instance identity verification failed: %s", err)
+	}
+
+	for _, account := range settings.AllowedAccounts() {
+		if identity.AccountID == account {
+			return identity, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.PermissionDenied, "instance %q account %q is not allowed", identity.InstanceID, identity.AccountID)
+}
+
+// validateKubeletCSR validates the kubelet client certificate CSR, and returns the node name.
+func validateKubeletCSR(csrPEM []byte) (string, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil {
+		return "", fmt.Errorf("failed to decode CSR")
+	}
+
+	csr, err := stdx509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse CSR: %w", err)
+	}
+
+	if !strings.HasPrefix(csr.Subject.CommonName, constants.NodesUsernamePrefix) || len(csr.Subject.CommonName) == len(constants.NodesUsernamePrefix) {
+		return "", fmt.Errorf("common name should be in the node identity format")
+	}
+
+	if len(csr.Subject.Organization) != 1 || csr.Subject.Organization[0] != constants.NodesGroup {
+		return "", fmt.Errorf("organization should be %q", constants.NodesGroup)
+	}
+
+	if len(csr.DNSNames) > 0 || len(csr.IPAddresses) > 0 || len(csr.EmailAddresses) > 0 || len(csr.URIs) > 0 {
+		return "", fmt.Errorf("subject alternative names are not allowed")
+	}
+
+	return strings.TrimPrefix(csr.Subject.CommonName, constants.NodesUsernamePrefix), nil
+}
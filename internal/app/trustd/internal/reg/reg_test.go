@@ -4,11 +4,366 @@
 
 package reg_test
 
-import "testing"
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/crypto/x509"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/internal/app/trustd/internal/reg"
+	"github.com/talos-systems/talos/internal/pkg/instanceidentity"
+	securityapi "github.com/talos-systems/talos/pkg/machinery/api/security"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestKubeletCertificate(t *testing.T) {
+	ca, err := x509.NewSelfSignedCertificateAuthority(x509.ECDSA(true))
+	require.NoError(t, err)
+
+	r := &reg.Registrator{
+		Config: &v1alpha1.Config{
+			ClusterConfig: &v1alpha1.ClusterConfig{
+				ClusterCA: x509.NewCertificateAndKeyFromCertificateAuthority(ca),
+			},
+		},
+		NodeLookup: nodeLookup,
+	}
+
+	csr, _, err := x509.NewECDSACSRAndIdentity(
+		x509.CommonName("system:node:worker-1"),
+		x509.Organization("system:nodes"),
+	)
+	require.NoError(t, err)
+
+	ctx := peerContext("10.5.0.2")
+
+	resp, err := r.KubeletCertificate(ctx, &securityapi.CertificateRequest{Csr: csr.X509CertificateRequestPEM})
+	require.NoError(t, err)
+
+	assert.Equal(t, ca.CrtPEM, resp.Ca)
+
+	block, _ := pem.Decode(resp.Crt)
+	require.NotNil(t, block)
+
+	crt, err := stdx509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	assert.Equal(t, "system:node:worker-1", crt.Subject.CommonName)
+	assert.Equal(t, []string{"system:nodes"}, crt.Subject.Organization)
+	assert.Equal(t, []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageClientAuth}, crt.ExtKeyUsage)
+	assert.NoError(t, crt.CheckSignatureFrom(ca.Crt))
+
+	for _, opts := range [][]x509.Option{
+		{x509.CommonName("admin"), x509.Organization("system:nodes")},
+		{x509.CommonName("system:node:worker-1"), x509.Organization("system:masters")},
+		{x509.CommonName("system:node:worker-1"), x509.Organization("system:nodes"), x509.IPAddresses([]net.IP{net.ParseIP("10.5.0.2")})},
+	} {
+		csr, _, err = x509.NewECDSACSRAndIdentity(opts...)
+		require.NoError(t, err)
+
+		_, err = r.KubeletCertificate(ctx, &securityapi.CertificateRequest{Csr: csr.X509CertificateRequestPEM})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	r.Config = &v1alpha1.Config{
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ClusterCA: &x509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM},
+		},
+	}
+
+	_, err = r.KubeletCertificate(ctx, &securityapi.CertificateRequest{Csr: csr.X509CertificateRequestPEM})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func nodeLookup(ctx context.Context, nodename string) (*reg.Node, error) {
+	switch nodename {
+	case "controlplane-1":
+		return &reg.Node{Addresses: []string{"10.5.0.1"}}, nil
+	case "worker-1":
+		return &reg.Node{Addresses: []string{"10.5.0.2", "fd00::2"}}, nil
+	case "gcp-worker-1":
+		return &reg.Node{Addresses: []string{"10.5.0.4"}, ProviderID: "gce://my-project/europe-west1-b/gcp-worker-1"}, nil
+	case "gcp-worker-2":
+		return &reg.Node{Addresses: []string{"10.5.0.5"}, ProviderID: "gce://my-project/europe-west1-b/gcp-worker-2"}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func peerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 34567},
+	})
+}
+
+func TestKubeletCertificateNodeAddress(t *testing.T) {
+	ca, err := x509.NewSelfSignedCertificateAuthority(x509.ECDSA(true))
+	require.NoError(t, err)
+
+	r := &reg.Registrator{
+		Config: &v1alpha1.Config{
+			ClusterConfig: &v1alpha1.ClusterConfig{
+				ClusterCA: x509.NewCertificateAndKeyFromCertificateAuthority(ca),
+			},
+		},
+		NodeLookup: nodeLookup,
+	}
+
+	for _, tt := range []struct {
+		name     string
+		nodename string
+		peer     string
+		code     codes.Code
+	}{
+		{"own name", "worker-1", "10.5.0.2", codes.OK},
+		{"own name IPv6", "worker-1", "fd00::2", codes.OK},
+		{"not registered", "worker-2", "10.5.0.3", codes.FailedPrecondition},
+		{"other worker", "worker-1", "10.5.0.3", codes.PermissionDenied},
+		{"control plane", "controlplane-1", "10.5.0.2", codes.PermissionDenied},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			csr, _, err := x509.NewECDSACSRAndIdentity(
+				x509.CommonName("system:node:"+tt.nodename),
+				x509.Organization("system:nodes"),
+			)
+			require.NoError(t, err)
+
+			_, err = r.KubeletCertificate(peerContext(tt.peer), &securityapi.CertificateRequest{Csr: csr.X509CertificateRequestPEM})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	csr, _, err := x509.NewECDSACSRAndIdentity(
+		x509.CommonName("system:node:worker-1"),
+		x509.Organization("system:nodes"),
+	)
+	require.NoError(t, err)
+
+	// no peer information
+	_, err = r.KubeletCertificate(context.Background(), &securityapi.CertificateRequest{Csr: csr.X509CertificateRequestPEM})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	r.NodeLookup = func(context.Context, string) (*reg.Node, error) {
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	_, err = r.KubeletCertificate(peerContext("10.5.0.3"), &securityapi.CertificateRequest{Csr: csr.X509CertificateRequestPEM})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestKubeletBootstrapToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubelet-bootstrap-token.yaml")
+
+	r := &reg.Registrator{
+		Config:                    &v1alpha1.Config{ClusterConfig: &v1alpha1.ClusterConfig{}},
+		KubeletBootstrapTokenPath: path,
+	}
+
+	_, err := r.KubeletBootstrapToken(context.Background(), &securityapi.KubeletBootstrapTokenRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	expiration := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("tokenID: abcdef\ntokenSecret: 0123456789abcdef\nexpiration: "+expiration.Format(time.RFC3339)+"\n"), 0o600))
+
+	resp, err := r.KubeletBootstrapToken(context.Background(), &securityapi.KubeletBootstrapTokenRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "abcdef.0123456789abcdef", resp.Token)
+	assert.Equal(t, expiration, resp.Expiration.AsTime())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("tokenID: abcdef\ntokenSecret: 0123456789abcdef\nexpiration: 2021-01-01T00:00:00Z\n"), 0o600))
+
+	_, err = r.KubeletBootstrapToken(context.Background(), &securityapi.KubeletBootstrapTokenRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func gcpIdentityToken(t *testing.T, key *rsa.PrivateKey, projectID string) []byte {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "key1"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"iss": "https://accounts.google.com",
+		"aud": instanceidentity.GCPAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"google": map[string]interface{}{
+			"compute_engine": map[string]interface{}{
+				"instance_id":   "4567890123456789012",
+				"instance_name": "gcp-worker-1",
+				"project_id":    projectID,
+				"zone":          "europe-west1-b",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return []byte(signingInput + "." + base64.RawURLEncoding.EncodeToString(signature))
+}
+
+func TestCertificateInstanceIdentity(t *testing.T) {
+	ca, err := x509.NewSelfSignedCertificateAuthority()
+	require.NoError(t, err)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &stdx509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "google"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := stdx509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	googleCert, err := stdx509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	r := &reg.Registrator{
+		Config: &v1alpha1.Config{
+			MachineConfig: &v1alpha1.MachineConfig{
+				MachineCA: x509.NewCertificateAndKeyFromCertificateAuthority(ca),
+			},
+			ClusterConfig: &v1alpha1.ClusterConfig{
+				ClusterInstanceIdentity: &v1alpha1.InstanceIdentityConfig{
+					InstanceIdentityRequired:        true,
+					InstanceIdentityAllowedAccounts: []string{"my-project"},
+				},
+			},
+		},
+		Verifier: &instanceidentity.Verifier{
+			GCPCertificates: func(context.Context) (map[string]*stdx509.Certificate, error) {
+				return map[string]*stdx509.Certificate{"key1": googleCert}, nil
+			},
+		},
+	}
+
+	csr, _, err := x509.NewEd25519CSRAndIdentity(x509.CommonName("worker-1"))
+	require.NoError(t, err)
+
+	_, err = r.Certificate(context.Background(), &securityapi.CertificateRequest{
+		Csr:                      csr.X509CertificateRequestPEM,
+		InstanceIdentityPlatform: instanceidentity.PlatformGCP,
+		InstanceIdentityDocument: gcpIdentityToken(t, key, "my-project"),
+	})
+	require.NoError(t, err)
+
+	for _, req := range []*securityapi.CertificateRequest{
+		{
+			Csr: csr.X509CertificateRequestPEM,
+		},
+		{
+			Csr:                      csr.X509CertificateRequestPEM,
+			InstanceIdentityPlatform: instanceidentity.PlatformGCP,
+			InstanceIdentityDocument: gcpIdentityToken(t, key, "other-project"),
+		},
+		{
+			Csr:                      csr.X509CertificateRequestPEM,
+			InstanceIdentityPlatform: instanceidentity.PlatformAWS,
+			InstanceIdentityDocument: gcpIdentityToken(t, key, "my-project"),
+		},
+	} {
+		_, err = r.Certificate(context.Background(), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+}
+
+func TestKubeletCertificateInstanceIdentity(t *testing.T) {
+	ca, err := x509.NewSelfSignedCertificateAuthority(x509.ECDSA(true))
+	require.NoError(t, err)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &stdx509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "google"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := stdx509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	googleCert, err := stdx509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	r := &reg.Registrator{
+		Config: &v1alpha1.Config{
+			ClusterConfig: &v1alpha1.ClusterConfig{
+				ClusterCA: x509.NewCertificateAndKeyFromCertificateAuthority(ca),
+				ClusterInstanceIdentity: &v1alpha1.InstanceIdentityConfig{
+					InstanceIdentityRequired:        true,
+					InstanceIdentityAllowedAccounts: []string{"my-project"},
+				},
+			},
+		},
+		Verifier: &instanceidentity.Verifier{
+			GCPCertificates: func(context.Context) (map[string]*stdx509.Certificate, error) {
+				return map[string]*stdx509.Certificate{"key1": googleCert}, nil
+			},
+		},
+		NodeLookup: nodeLookup,
+	}
+
+	document := gcpIdentityToken(t, key, "my-project")
+
+	for _, tt := range []struct {
+		name     string
+		nodename string
+		peer     string
+		code     codes.Code
+	}{
+		{"not registered", "worker-2", "10.5.0.3", codes.OK},
+		{"provider ID", "gcp-worker-1", "10.5.0.3", codes.OK},
+		{"other provider ID", "gcp-worker-2", "10.5.0.5", codes.PermissionDenied},
+		{"no provider ID", "worker-1", "10.5.0.2", codes.OK},
+		{"no provider ID other worker", "worker-1", "10.5.0.3", codes.PermissionDenied},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			csr, _, err := x509.NewECDSACSRAndIdentity(
+				x509.CommonName("system:node:"+tt.nodename),
+				x509.Organization("system:nodes"),
+			)
+			require.NoError(t, err)
+
+			_, err = r.KubeletCertificate(peerContext(tt.peer), &securityapi.CertificateRequest{
+				Csr:                      csr.X509CertificateRequestPEM,
+				InstanceIdentityPlatform: instanceidentity.PlatformGCP,
+				InstanceIdentityDocument: document,
+			})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
 }
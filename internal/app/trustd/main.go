@@ -6,9 +6,11 @@ package trustd
 
 import (
 	"context"
+	stdlibtls "crypto/tls"
 	"flag"
 	"log"
 	stdlibnet "net"
+	"strings"
 
 	"github.com/talos-systems/crypto/tls"
 	"github.com/talos-systems/crypto/x509"
@@ -17,6 +19,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	apidprovider "github.com/talos-systems/talos/internal/app/apid/pkg/provider"
 	"github.com/talos-systems/talos/internal/app/trustd/internal/reg"
 	"github.com/talos-systems/talos/pkg/grpc/factory"
 	"github.com/talos-systems/talos/pkg/grpc/gen"
@@ -45,6 +48,8 @@ func runDebugServer(ctx context.Context) {
 func Main() {
 	log.SetFlags(log.Lshortfile | log.Ldate | log.Lmicroseconds | log.Ltime)
 
+	advertisedAddresses := flag.String("advertised-addresses", "", "comma-separated advertised node addresses included into the certificate")
+
 	flag.Parse()
 
 	go runDebugServer(context.TODO())
@@ -60,9 +65,18 @@ func Main() {
 		log.Fatal(err)
 	}
 
-	ips, err := net.IPAddrs()
-	if err != nil {
-		log.Fatal(err)
+	// node addresses are filtered by machined according to .machine.network.advertisedSubnets and .machine.network.ignoredSubnets
+	var ips []stdlibnet.IP
+
+	if *advertisedAddresses != "" {
+		for _, addr := range strings.Split(*advertisedAddresses, ",") {
+			ip := stdlibnet.ParseIP(addr)
+			if ip == nil {
+				log.Fatalf("invalid advertised address %q", addr)
+			}
+
+			ips = append(ips, ip)
+		}
 	}
 
 	ips = net.IPFilter(ips, network.NotSideroLinkStdIP)
@@ -99,10 +113,29 @@ func Main() {
 		log.Fatal(err)
 	}
 
+	minVersion, err := apidprovider.ParseTLSVersion(config.Machine().APITLS().MinVersion())
+	if err != nil {
+		log.Fatalf("failed to parse TLS min version: %v", err)
+	}
+
+	cipherSuites, err := apidprovider.ParseCipherSuites(config.Machine().APITLS().CipherSuites())
+	if err != nil {
+		log.Fatalf("failed to parse TLS cipher suites: %v", err)
+	}
+
 	tlsConfig, err := tls.New(
 		tls.WithClientAuthType(tls.ServerOnly),
 		tls.WithCACertPEM(ca),
 		tls.WithServerCertificateProvider(provider),
+		func(cfg *stdlibtls.Config) error {
+			cfg.MinVersion = minVersion
+
+			if len(cipherSuites) > 0 {
+				cfg.CipherSuites = cipherSuites
+			}
+
+			return nil
+		},
 	)
 	if err != nil {
 		log.Fatalf("failed to create TLS config: %v", err)
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+//go:build integration_api
+// +build integration_api
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/talos-systems/talos/internal/integration/base"
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+// ConsoleSuite verifies Console API.
+type ConsoleSuite struct {
+	base.APISuite
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+}
+
+// SuiteName ...
+func (suite *ConsoleSuite) SuiteName() string {
+	return "api.ConsoleSuite"
+}
+
+// SetupTest ...
+func (suite *ConsoleSuite) SetupTest() {
+	// make sure API calls have timeout
+	suite.ctx, suite.ctxCancel = context.WithTimeout(context.Background(), 2*time.Minute)
+}
+
+// TearDownTest ...
+func (suite *ConsoleSuite) TearDownTest() {
+	if suite.ctxCancel != nil {
+		suite.ctxCancel()
+	}
+}
+
+// TestNodeHasConsole verifies that default node has console output.
+func (suite *ConsoleSuite) TestNodeHasConsole() {
+	consoleStream, err := suite.Client.Console(
+		suite.ctx,
+		false,
+		false,
+	)
+	suite.Require().NoError(err)
+
+	logReader, errCh, err := client.ReadStream(consoleStream)
+	suite.Require().NoError(err)
+
+	var buf bytes.Buffer
+
+	_, err = io.Copy(&buf, logReader)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(<-errCh)
+
+	// console output shouldn't be empty
+	suite.Require().Greater(buf.Len(), 1024)
+
+	if suite.Capabilities().RunsTalosKernel {
+		// console output has the kernel console format
+		suite.Assert().Regexp(`^\[\s*\d+\.\d{6}\] `, buf.String())
+	}
+}
+
+func init() {
+	allSuites = append(allSuites, new(ConsoleSuite))
+}
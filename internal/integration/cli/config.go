@@ -67,6 +67,52 @@ func (suite *TalosconfigSuite) TestMerge() {
 	suite.Require().NotNil(c.Contexts["foo-1"])
 }
 
+// TestExportImport checks `talosctl config export` and `talosctl config import`.
+func (suite *TalosconfigSuite) TestExportImport() {
+	tempDir := suite.T().TempDir()
+
+	suite.RunCLI([]string{"gen", "config", "-o", tempDir, "foo", "https://192.168.0.1:6443"})
+
+	talosconfigPath := filepath.Join(tempDir, "talosconfig")
+
+	suite.Assert().FileExists(talosconfigPath)
+
+	exportedPath := filepath.Join(tempDir, "exported")
+
+	suite.RunCLI([]string{"config", "export", "--talosconfig", talosconfigPath, "-o", exportedPath, "foo"},
+		base.StdoutEmpty())
+
+	exported, err := clientconfig.Open(exportedPath)
+	suite.Require().NoError(err)
+
+	suite.Require().Len(exported.Contexts, 1)
+	suite.Require().NotNil(exported.Contexts["foo"])
+	suite.Require().NotEmpty(exported.Contexts["foo"].Key)
+
+	path := filepath.Join(tempDir, "imported")
+
+	suite.RunCLI([]string{"config", "import", "--talosconfig", path, exportedPath},
+		base.StdoutEmpty())
+
+	suite.RunCLI([]string{"config", "import", "--talosconfig", path, exportedPath},
+		base.StdoutShouldMatch(regexp.MustCompile(`renamed`)))
+
+	c, err := clientconfig.Open(path)
+	suite.Require().NoError(err)
+
+	suite.Require().NotNil(c.Contexts["foo-1"])
+	suite.Assert().Equal("foo", c.Context)
+
+	suite.RunCLI([]string{"config", "node", "--talosconfig", path, "--context", "foo-1", "10.5.0.3"},
+		base.StdoutEmpty())
+
+	c, err = clientconfig.Open(path)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal([]string{"10.5.0.3"}, c.Contexts["foo-1"].Nodes)
+	suite.Assert().Empty(c.Contexts["foo"].Nodes)
+}
+
 // TestNew checks `talosctl config new`.
 func (suite *TalosconfigSuite) TestNew() {
 	stdout := suite.RunCLI([]string{"version", "--json", "--nodes", suite.RandomDiscoveredNode()})
@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package backup implements the host directory backups to the S3-compatible storage.
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// TimestampFormat is the format of the backup timestamp in the object keys.
+const TimestampFormat = "20060102T150405Z"
+
+// Key returns the object key of the directory backup: <prefix><node name>/<timestamp>/<path>.tar.gz.
+//
+// Path separators are replaced with underscores, e.g. `/var/mnt/data` is stored as `var_mnt_data.tar.gz`.
+func Key(prefix, nodeName string, timestamp time.Time, dir string) string {
+	name := strings.ReplaceAll(strings.Trim(path.Clean(dir), "/"), "/", "_")
+
+	return prefix + path.Join(nodeName, timestamp.UTC().Format(TimestampFormat), name+".tar.gz")
+}
+
+// Run backs up all the configured directories.
+//
+// Directories which don't exist are skipped, failure to back up a directory doesn't stop the backup of the others.
+func Run(ctx context.Context, logger *log.Logger, cfg config.Backup, nodeName string, timestamp time.Time) error {
+	var errs *multierror.Error
+
+	for _, p := range cfg.Paths() {
+		if _, err := os.Stat(p.Path()); os.IsNotExist(err) {
+			logger.Printf("skipping backup of %q: directory doesn't exist", p.Path())
+
+			continue
+		}
+
+		key := Key(cfg.S3().Prefix(), nodeName, timestamp, p.Path())
+
+		logger.Printf("backing up %q to s3://%s/%s", p.Path(), cfg.S3().Bucket(), key)
+
+		if err := backupPath(ctx, cfg.S3(), p, key); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("error backing up %q: %w", p.Path(), err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func backupPath(ctx context.Context, s3cfg config.BackupS3, p config.BackupPath, key string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(Archive(ctx, pw, p.Path(), p.Include(), p.Exclude())) //nolint:errcheck
+	}()
+
+	err := Upload(ctx, s3cfg, key, pr)
+
+	// unblock the archiver if the upload failed
+	pr.CloseWithError(err) //nolint:errcheck
+
+	return err
+}
+
+// Upload streams the object to the S3 storage.
+//
+// TODO: aws-sdk-go v1 is pinned at v1.15.78 (end of support, no maintained v1 release to bump to),
+// the uploader should be moved to minio-go/v7 once its dependency upgrades are vendored.
+func Upload(ctx context.Context, s3cfg config.BackupS3, key string, r io.Reader) error {
+	awsConfig := aws.NewConfig().
+		WithRegion(s3cfg.Region()).
+		WithCredentials(credentials.NewStaticCredentials(s3cfg.AccessKeyID(), s3cfg.SecretAccessKey(), "")).
+		WithS3ForcePathStyle(s3cfg.ForcePathStyle())
+
+	if s3cfg.Endpoint() != "" {
+		awsConfig = awsConfig.WithEndpoint(s3cfg.Endpoint())
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3manager.NewUploader(sess).UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(s3cfg.Bucket()),
+		Key:         aws.String(key),
+		Body:        r,
+		ContentType: aws.String("application/gzip"),
+	})
+
+	return err
+}
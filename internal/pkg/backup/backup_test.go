@@ -0,0 +1,182 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/backup"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		rel     string
+		matched bool
+	}{
+		{"*.tmp", "a.tmp", true},
+		{"*.tmp", "dir/a.tmp", true},
+		{"*.tmp", "a.db", false},
+		{"cache", "data/cache", true},
+		{"dir/*.db", "dir/a.db", true},
+		{"dir/*.db", "dir/sub/a.db", false},
+		{"dir/**", "dir/sub/a.db", true},
+		{"dir/**", "dir", true},
+		{"**/a.db", "a.db", true},
+		{"**/a.db", "x/y/a.db", true},
+		{"dir/**/*.db", "dir/x/y/a.db", true},
+		{"dir/**/*.db", "other/x/a.db", false},
+	} {
+		assert.Equal(t, tt.matched, backup.Match(tt.pattern, tt.rel), "pattern %q, path %q", tt.pattern, tt.rel)
+	}
+}
+
+func createTree(t *testing.T, files ...string) string {
+	root := t.TempDir()
+
+	for _, f := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(f)), 0o755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(root, f), []byte(f), 0o644))
+	}
+
+	return root
+}
+
+func listArchive(t *testing.T, r io.Reader) map[string]string {
+	zr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+
+	tr := tar.NewReader(zr)
+	contents := map[string]string{}
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+
+		contents[header.Name] = string(data)
+	}
+
+	return contents
+}
+
+func TestArchive(t *testing.T) {
+	root := createTree(t, "db/a.db", "db/a.db.tmp", "cache/blob", "app.conf", "logs/app.log")
+
+	require.NoError(t, os.Symlink("app.conf", filepath.Join(root, "current.conf")))
+
+	var buf bytes.Buffer
+
+	require.NoError(t, backup.Archive(context.Background(), &buf, root, []string{"db/**", "*.conf"}, []string{"*.tmp", "cache"}))
+
+	assert.Equal(t, map[string]string{
+		"app.conf":     "app.conf",
+		"current.conf": "",
+		"db/":          "",
+		"db/a.db":      "db/a.db",
+		"logs/":        "",
+	}, listArchive(t, &buf))
+}
+
+func TestRun(t *testing.T) {
+	root := createTree(t, "a", "b/c")
+
+	var (
+		mu      sync.Mutex
+		objects = map[string][]byte{}
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		mu.Lock()
+		objects[r.URL.Path] = body
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	cfg := &v1alpha1.BackupConfig{
+		BackupS3: &v1alpha1.BackupS3Config{
+			S3Endpoint:        srv.URL,
+			S3Region:          "us-east-1",
+			S3Bucket:          "backups",
+			S3Prefix:          "site/",
+			S3AccessKeyID:     "id",
+			S3SecretAccessKey: "secret",
+			S3ForcePathStyle:  true,
+		},
+		BackupPaths: []v1alpha1.BackupPathConfig{
+			{
+				PathPath:    root,
+				PathExclude: []string{"b"},
+			},
+			{
+				PathPath: filepath.Join(root, "missing"),
+			},
+		},
+	}
+
+	timestamp := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, backup.Run(context.Background(), log.New(ioutil.Discard, "", 0), cfg, "node-1", timestamp))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	expectedKey := "/backups/" + backup.Key("site/", "node-1", timestamp, root)
+
+	assert.Equal(t, []string{expectedKey}, keys)
+	assert.Equal(t, map[string]string{"a": "a"}, listArchive(t, bytes.NewReader(objects[expectedKey])))
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t,
+		"site/node-1/20211201T100000Z/var_mnt_data.tar.gz",
+		backup.Key("site/", "node-1", time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC), "/var/mnt/data/"),
+	)
+}
@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package configuration
+
+import (
+	"github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// Deprecations returns the deprecated fields set in the configuration in the API form.
+func Deprecations(cfg config.Provider) []*machine.ConfigDeprecation {
+	deprecations := cfg.Deprecations()
+	if len(deprecations) == 0 {
+		return nil
+	}
+
+	result := make([]*machine.ConfigDeprecation, 0, len(deprecations))
+
+	for _, deprecation := range deprecations {
+		result = append(result, &machine.ConfigDeprecation{
+			Path:        deprecation.Path,
+			Message:     deprecation.Message,
+			Replacement: deprecation.Replacement,
+		})
+	}
+
+	return result
+}
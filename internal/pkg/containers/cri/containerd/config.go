@@ -37,9 +37,26 @@ type Registry struct {
 	Configs map[string]RegistryConfig `toml:"configs"`
 }
 
+// Runtime represents the CRI runtime config.
+type Runtime struct {
+	RuntimeType     string `toml:"runtime_type"`
+	BaseRuntimeSpec string `toml:"base_runtime_spec,omitempty"`
+}
+
+// ContainerdConfig represents the CRI containerd config.
+type ContainerdConfig struct {
+	Runtimes map[string]Runtime `toml:"runtimes"`
+}
+
 // CRIConfig represents the CRI config.
 type CRIConfig struct {
-	Registry Registry `toml:"registry"`
+	SandboxImage           string           `toml:"sandbox_image,omitempty"`
+	StreamServerAddress    string           `toml:"stream_server_address,omitempty"`
+	StreamServerPort       string           `toml:"stream_server_port,omitempty"`
+	StreamIdleTimeout      string           `toml:"stream_idle_timeout,omitempty"`
+	MaxConcurrentDownloads int              `toml:"max_concurrent_downloads,omitzero"`
+	Containerd             ContainerdConfig `toml:"containerd"`
+	Registry               Registry         `toml:"registry"`
 }
 
 // PluginsConfig represents the CRI plugins config.
@@ -5,8 +5,14 @@
 package containerd_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
+	"time"
 
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/suite"
 	"github.com/talos-systems/crypto/x509"
 
@@ -19,6 +25,7 @@ import (
 type mockConfig struct {
 	mirrors map[string]*v1alpha1.RegistryMirrorConfig
 	config  map[string]*v1alpha1.RegistryConfig
+	pulls   *v1alpha1.RegistryPullsConfig
 }
 
 // Mirrors implements the Registries interface.
@@ -43,6 +50,15 @@ func (c *mockConfig) Config() map[string]config.RegistryConfig {
 	return registries
 }
 
+// Pulls implements the Registries interface.
+func (c *mockConfig) Pulls() config.RegistryPulls {
+	if c.pulls == nil {
+		return nil
+	}
+
+	return c.pulls
+}
+
 type ConfigSuite struct {
 	suite.Suite
 }
@@ -74,7 +90,7 @@ func (suite *ConfigSuite) TestGenerateRegistriesConfig() {
 		},
 	}
 
-	files, err := containerd.GenerateRegistriesConfig(cfg)
+	files, err := containerd.GenerateCRIConfig(cfg, &v1alpha1.CRIConfig{})
 	suite.Require().NoError(err)
 	suite.Assert().Equal([]config.File{
 		&v1alpha1.MachineFile{
@@ -98,6 +114,10 @@ func (suite *ConfigSuite) TestGenerateRegistriesConfig() {
 		&v1alpha1.MachineFile{
 			FileContent: `[plugins]
   [plugins."io.containerd.grpc.v1.cri"]
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
     [plugins."io.containerd.grpc.v1.cri".registry]
       [plugins."io.containerd.grpc.v1.cri".registry.mirrors]
         [plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
@@ -122,6 +142,138 @@ func (suite *ConfigSuite) TestGenerateRegistriesConfig() {
 	}, files)
 }
 
+func (suite *ConfigSuite) TestGenerateRegistriesConfigPulls() {
+	cfg := &mockConfig{
+		pulls: &v1alpha1.RegistryPullsConfig{
+			PullMaxConcurrentDownloads: 2,
+		},
+	}
+
+	files, err := containerd.GenerateCRIConfig(cfg, &v1alpha1.CRIConfig{})
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+
+	suite.Assert().Equal(`[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    max_concurrent_downloads = 2
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+    [plugins."io.containerd.grpc.v1.cri".registry]
+      [plugins."io.containerd.grpc.v1.cri".registry.mirrors]
+      [plugins."io.containerd.grpc.v1.cri".registry.configs]
+`, files[0].Content())
+}
+
+func (suite *ConfigSuite) TestGenerateCRIConfigSettings() {
+	files, err := containerd.GenerateCRIConfig(&mockConfig{}, &v1alpha1.CRIConfig{
+		CRISandboxImage:        "registry.example.com/pause:3.6",
+		CRIStreamServerAddress: "10.5.0.2",
+		CRIStreamServerPort:    10010,
+		CRIStreamIdleTimeout:   time.Hour,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+
+	suite.Assert().Equal(`[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    sandbox_image = "registry.example.com/pause:3.6"
+    stream_server_address = "10.5.0.2"
+    stream_server_port = "10010"
+    stream_idle_timeout = "1h0m0s"
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+    [plugins."io.containerd.grpc.v1.cri".registry]
+      [plugins."io.containerd.grpc.v1.cri".registry.mirrors]
+      [plugins."io.containerd.grpc.v1.cri".registry.configs]
+`, files[0].Content())
+}
+
+func (suite *ConfigSuite) TestGenerateCRIConfigPolicies() {
+	files, err := containerd.GenerateCRIConfig(&mockConfig{}, &v1alpha1.CRIConfig{
+		CRIHostPathPolicy: &v1alpha1.HostPathPolicyConfig{
+			HostPathAllowedPaths:                  []string{"/var/mnt/data"},
+			HostPathAllowBidirectionalPropagation: true,
+		},
+		CRIImagePolicy: &v1alpha1.ImagePolicyConfig{
+			ImageAllowed: []string{"registry.example.com"},
+			ImageDenied:  []string{"registry.example.com/deprecated"},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 2)
+
+	suite.Assert().Equal("/var/etc/cri/base-runtime-spec.json", files[0].Path())
+	suite.Assert().Equal("create", files[0].Op())
+
+	var spec specs.Spec
+
+	suite.Require().NoError(json.Unmarshal([]byte(files[0].Content()), &spec))
+
+	// containerd defaults are preserved
+	suite.Assert().NotEmpty(spec.Mounts)
+	suite.Assert().NotEmpty(spec.Linux.Namespaces)
+	suite.Assert().Nil(spec.Process.Rlimits)
+
+	suite.Require().NotNil(spec.Hooks)
+	suite.Assert().Equal([]specs.Hook{
+		{
+			Path: "/sbin/init",
+			Args: []string{
+				containerd.PolicyHook,
+				"--host-path-policy", "--allowed-path=/var/mnt/data", "--allow-bidirectional-propagation",
+				"--image-policy", "--allowed-image=registry.example.com", "--denied-image=registry.example.com/deprecated",
+			},
+			Timeout: spec.Hooks.CreateRuntime[0].Timeout,
+		},
+	}, spec.Hooks.CreateRuntime)
+
+	suite.Assert().Equal(`[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+          base_runtime_spec = "/var/etc/cri/base-runtime-spec.json"
+    [plugins."io.containerd.grpc.v1.cri".registry]
+      [plugins."io.containerd.grpc.v1.cri".registry.mirrors]
+      [plugins."io.containerd.grpc.v1.cri".registry.configs]
+`, files[1].Content())
+}
+
+func (suite *ConfigSuite) TestUpdateCRIConfig() {
+	previous := &v1alpha1.MachineConfig{}
+	current := &v1alpha1.MachineConfig{
+		MachineCRI: &v1alpha1.CRIConfig{
+			CRISandboxImage: "registry.example.com/pause:3.6",
+		},
+	}
+
+	base := "version = 2\n"
+	path := filepath.Join(suite.T().TempDir(), "containerd.toml")
+
+	previousFiles, err := containerd.GenerateCRIConfig(previous.Registries(), previous.CRI())
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(base+"\n"+previousFiles[0].Content()), 0o644))
+
+	suite.Require().NoError(containerd.UpdateCRIConfig(path, previous, current))
+
+	contents, err := ioutil.ReadFile(path)
+	suite.Require().NoError(err)
+
+	currentFiles, err := containerd.GenerateCRIConfig(current.Registries(), current.CRI())
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(base+"\n"+currentFiles[0].Content(), string(contents))
+
+	// the file doesn't end with the config generated from the previous machine config anymore
+	suite.Assert().EqualError(containerd.UpdateCRIConfig(path, previous, current), fmt.Sprintf("CRI plugin config in %q doesn't match the machine config", path))
+}
+
 func TestConfigSuite(t *testing.T) {
 	suite.Run(t, new(ConfigSuite))
 }
@@ -8,7 +8,11 @@ package containerd
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 
@@ -17,16 +21,38 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
-// GenerateRegistriesConfig returns a list of extra files.
+// GenerateCRIConfig returns a list of extra files.
+//
+// The last file is the CRI plugin config appended to the CRI containerd config.
 //
 //nolint:gocyclo
-func GenerateRegistriesConfig(r config.Registries) ([]config.File, error) {
+func GenerateCRIConfig(r config.Registries, cri config.CRI) ([]config.File, error) {
 	caPath := filepath.Join("/var", filepath.Dir(constants.CRIContainerdConfig), "ca")
 	clientPath := filepath.Join("/var", filepath.Dir(constants.CRIContainerdConfig), "client")
+	baseRuntimeSpecPath := filepath.Join("/var", filepath.Dir(constants.CRIContainerdConfig), "base-runtime-spec.json")
 
 	var ctrdCfg Config
+	ctrdCfg.Plugins.CRI.Containerd.Runtimes = map[string]Runtime{
+		"runc": {
+			RuntimeType: "io.containerd.runc.v2",
+		},
+	}
 	ctrdCfg.Plugins.CRI.Registry.Mirrors = make(map[string]Mirror)
 	ctrdCfg.Plugins.CRI.Registry.Configs = make(map[string]RegistryConfig)
+	ctrdCfg.Plugins.CRI.SandboxImage = cri.SandboxImage()
+	ctrdCfg.Plugins.CRI.StreamServerAddress = cri.StreamServerAddress()
+
+	if cri.StreamServerPort() != 0 {
+		ctrdCfg.Plugins.CRI.StreamServerPort = strconv.Itoa(cri.StreamServerPort())
+	}
+
+	if cri.StreamIdleTimeout() != 0 {
+		ctrdCfg.Plugins.CRI.StreamIdleTimeout = cri.StreamIdleTimeout().String()
+	}
+
+	if r.Pulls() != nil {
+		ctrdCfg.Plugins.CRI.MaxConcurrentDownloads = r.Pulls().MaxConcurrentDownloads()
+	}
 
 	for mirrorName, mirrorConfig := range r.Mirrors() {
 		ctrdCfg.Plugins.CRI.Registry.Mirrors[mirrorName] = Mirror{Endpoints: mirrorConfig.Endpoints()}
@@ -34,6 +60,25 @@ func GenerateRegistriesConfig(r config.Registries) ([]config.File, error) {
 
 	var extraFiles []config.File
 
+	if PolicyEnforced(cri) {
+		spec, err := GenerateBaseRuntimeSpec(cri)
+		if err != nil {
+			return nil, err
+		}
+
+		extraFiles = append(extraFiles, &v1alpha1.MachineFile{
+			FileContent:     string(spec),
+			FilePermissions: 0o644,
+			FilePath:        baseRuntimeSpecPath,
+			FileOp:          "create",
+		})
+
+		ctrdCfg.Plugins.CRI.Containerd.Runtimes["runc"] = Runtime{
+			RuntimeType:     "io.containerd.runc.v2",
+			BaseRuntimeSpec: baseRuntimeSpecPath,
+		}
+	}
+
 	for registryHost, hostConfig := range r.Config() {
 		cfg := RegistryConfig{}
 
@@ -112,3 +157,42 @@ func GenerateRegistriesConfig(r config.Registries) ([]config.File, error) {
 		FileOp:          "append",
 	}), nil
 }
+
+// UpdateCRIConfig replaces the CRI plugin config generated from the previous machine config with the one
+// generated from the current machine config.
+//
+// CRI plugin config is appended to the CRI containerd config on boot, so it's expected at the end of the file.
+// Other generated files (e.g. the base runtime spec) are overwritten with the current contents.
+func UpdateCRIConfig(path string, previous, current config.MachineConfig) error {
+	previousFiles, err := GenerateCRIConfig(previous.Registries(), previous.CRI())
+	if err != nil {
+		return err
+	}
+
+	currentFiles, err := GenerateCRIConfig(current.Registries(), current.CRI())
+	if err != nil {
+		return err
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(string(contents), "\n"+previousFiles[len(previousFiles)-1].Content())
+	if len(base) == len(contents) {
+		return fmt.Errorf("CRI plugin config in %q doesn't match the machine config", path)
+	}
+
+	for _, f := range currentFiles[:len(currentFiles)-1] {
+		if err = os.MkdirAll(filepath.Dir(f.Path()), 0o755); err != nil {
+			return err
+		}
+
+		if err = ioutil.WriteFile(f.Path(), []byte(f.Content()), f.Permissions()); err != nil {
+			return err
+		}
+	}
+
+	return ioutil.WriteFile(path, []byte(base+"\n"+currentFiles[len(currentFiles)-1].Content()), 0o644)
+}
@@ -0,0 +1,105 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	criconstants "github.com/containerd/cri/pkg/constants"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// PolicyHook is the name machined is invoked with to run the machine policy OCI hook.
+const PolicyHook = "/policy-hook"
+
+// policyHookTimeout is the timeout (in seconds) of the machine policy OCI hook.
+const policyHookTimeout = 10
+
+// PolicyEnforced returns true if the CRI config requires the machine policy OCI hook.
+func PolicyEnforced(cri config.CRI) bool {
+	return cri.HostPathPolicy() != nil || cri.ImagePolicy() != nil
+}
+
+// GenerateBaseRuntimeSpec returns the base OCI runtime spec for the CRI containers which enforces the machine policies.
+//
+// The spec is the same as the one CRI plugin generates by default, with the machine policy OCI hook added.
+func GenerateBaseRuntimeSpec(cri config.CRI) ([]byte, error) {
+	ctx := namespaces.WithNamespace(context.Background(), criconstants.K8sContainerdNamespace)
+
+	spec, err := oci.GenerateSpec(ctx, nil, &containers.Container{}, withoutDefaultSecuritySettings, withPolicyHook(cri))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// withoutDefaultSecuritySettings mirrors the CRI plugin which clears the containerd defaults if the base runtime spec is not set.
+func withoutDefaultSecuritySettings(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+	s.Process.ApparmorProfile = ""
+	s.Process.Rlimits = nil
+
+	if s.Linux != nil {
+		s.Linux.Seccomp = nil
+	}
+
+	return nil
+}
+
+func withPolicyHook(cri config.CRI) oci.SpecOpts {
+	return func(_ context.Context, _ oci.Client, _ *containers.Container, s *specs.Spec) error {
+		args := []string{PolicyHook}
+
+		if policy := cri.HostPathPolicy(); policy != nil {
+			args = append(args, "--host-path-policy")
+
+			for _, path := range policy.AllowedPaths() {
+				args = append(args, "--allowed-path="+path)
+			}
+
+			if policy.AllowBidirectionalPropagation() {
+				args = append(args, "--allow-bidirectional-propagation")
+			}
+		}
+
+		if policy := cri.ImagePolicy(); policy != nil {
+			args = append(args, "--image-policy")
+
+			for _, image := range policy.Allowed() {
+				args = append(args, "--allowed-image="+image)
+			}
+
+			for _, image := range policy.Denied() {
+				args = append(args, "--denied-image="+image)
+			}
+
+			if policy.RequireDigest() {
+				args = append(args, "--require-image-digest")
+			}
+		}
+
+		timeout := policyHookTimeout
+
+		if s.Hooks == nil {
+			s.Hooks = &specs.Hooks{}
+		}
+
+		// createRuntime hooks are run in the runtime namespace once the mounts are prepared,
+		// failing hook aborts container creation
+		s.Hooks.CreateRuntime = append(s.Hooks.CreateRuntime, specs.Hook{
+			Path:    "/sbin/init",
+			Args:    args,
+			Timeout: &timeout,
+		})
+
+		return nil
+	}
+}
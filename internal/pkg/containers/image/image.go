@@ -7,11 +7,14 @@ package image
 import (
 	"context"
 	"fmt"
+	"log"
 	"os"
 	"time"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/opencontainers/image-spec/identity"
 	"github.com/talos-systems/go-retry/retry"
 
 	containerdrunner "github.com/talos-systems/talos/internal/app/machined/pkg/system/runner/containerd"
@@ -32,12 +35,22 @@ const (
 	ImportRetryJitter   = time.Second
 )
 
+// Image labels which cache the unpacked state of the image across reboots.
+//
+// With the labels in place, checking whether the image is already unpacked is a metadata lookup and a snapshot stat,
+// instead of reading the image index, manifest and config from the content store.
+const (
+	unpackedTargetLabel  = "talos.dev/unpacked-target"
+	unpackedChainIDLabel = "talos.dev/unpacked-chain-id"
+)
+
 // PullOption is an option for Pull function.
 type PullOption func(*PullOptions)
 
 // PullOptions configure Pull function.
 type PullOptions struct {
-	SkipIfAlreadyPulled bool
+	SkipIfAlreadyPulled      bool
+	InMaintenanceWindowsOnly bool
 }
 
 // WithSkipIfAlreadyPulled skips pulling if image is already pulled and unpacked.
@@ -47,6 +60,15 @@ func WithSkipIfAlreadyPulled() PullOption {
 	}
 }
 
+// WithMaintenanceWindows restricts pulling the image to the maintenance windows from the configuration.
+//
+// Images which are already present are not restricted.
+func WithMaintenanceWindows() PullOption {
+	return func(opts *PullOptions) {
+		opts.InMaintenanceWindowsOnly = true
+	}
+}
+
 // Pull is a convenience function that wraps the containerd image pull func with
 // retry functionality.
 func Pull(ctx context.Context, reg config.Registries, client *containerd.Client, ref string, opt ...PullOption) (img containerd.Image, err error) {
@@ -59,19 +81,43 @@ func Pull(ctx context.Context, reg config.Registries, client *containerd.Client,
 	if opts.SkipIfAlreadyPulled {
 		img, err = client.GetImage(ctx, ref)
 		if err == nil {
+			if isUnpackedCached(ctx, client, img) {
+				return img, nil
+			}
+
 			var unpacked bool
 
-			unpacked, err = img.IsUnpacked(ctx, "")
+			unpacked, err = img.IsUnpacked(ctx, containerd.DefaultSnapshotter)
 			if err == nil && unpacked {
+				cacheUnpacked(ctx, client, img)
+
 				return img, nil
 			}
 		}
 	}
 
+	if opts.InMaintenanceWindowsOnly && reg.Pulls() != nil {
+		if _, err = client.GetImage(ctx, ref); err != nil {
+			inWindow, next := InMaintenanceWindow(reg.Pulls().MaintenanceWindows(), time.Now())
+			if !inWindow {
+				return nil, fmt.Errorf("error pulling image %q: %w, next window starts at %s", ref, ErrOutsideMaintenanceWindow, next.Format(time.RFC3339))
+			}
+		}
+	}
+
 	resolver := NewResolver(reg)
 
+	pullOpts := []containerd.RemoteOpt{
+		containerd.WithPullUnpack,
+		containerd.WithResolver(resolver),
+	}
+
+	if reg.Pulls() != nil && reg.Pulls().MaxConcurrentDownloads() > 0 {
+		pullOpts = append(pullOpts, containerd.WithMaxConcurrentDownloads(reg.Pulls().MaxConcurrentDownloads()))
+	}
+
 	err = retry.Exponential(PullTimeout, retry.WithUnits(PullRetryInterval), retry.WithErrorLogging(true)).Retry(func() error {
-		if img, err = client.Pull(ctx, ref, containerd.WithPullUnpack, containerd.WithResolver(resolver)); err != nil {
+		if img, err = client.Pull(ctx, ref, pullOpts...); err != nil {
 			err = fmt.Errorf("failed to pull image %q: %w", ref, err)
 
 			if errdefs.IsNotFound(err) || errdefs.IsCanceled(err) {
@@ -88,9 +134,46 @@ func Pull(ctx context.Context, reg config.Registries, client *containerd.Client,
 		return nil, err
 	}
 
+	cacheUnpacked(ctx, client, img)
+
 	return img, nil
 }
 
+// isUnpackedCached checks the unpacked state of the image cached in the image labels.
+func isUnpackedCached(ctx context.Context, client *containerd.Client, img containerd.Image) bool {
+	labels := img.Labels()
+
+	// labels are reset when the image is pulled again, but check the target to be safe
+	if labels[unpackedTargetLabel] != img.Target().Digest.String() || labels[unpackedChainIDLabel] == "" {
+		return false
+	}
+
+	_, err := client.SnapshotService(containerd.DefaultSnapshotter).Stat(ctx, labels[unpackedChainIDLabel])
+
+	return err == nil
+}
+
+// cacheUnpacked records the unpacked state of the image in the image labels.
+//
+// Failure to record the state is not fatal, the image is checked via the content store on the next pull.
+func cacheUnpacked(ctx context.Context, client *containerd.Client, img containerd.Image) {
+	diffIDs, err := img.RootFS(ctx)
+	if err == nil {
+		_, err = client.ImageService().Update(ctx, images.Image{
+			Name:   img.Name(),
+			Target: img.Target(),
+			Labels: map[string]string{
+				unpackedTargetLabel:  img.Target().Digest.String(),
+				unpackedChainIDLabel: identity.ChainID(diffIDs).String(),
+			},
+		}, "labels."+unpackedTargetLabel, "labels."+unpackedChainIDLabel)
+	}
+
+	if err != nil {
+		log.Printf("failed to cache unpacked state of image %q: %s", img.Name(), err)
+	}
+}
+
 // Import is a convenience function that wraps containerd image import with retries.
 func Import(ctx context.Context, imagePath, indexName string) error {
 	importer := containerdrunner.NewImporter(constants.SystemContainerdNamespace, containerdrunner.WithContainerdAddress(constants.SystemContainerdAddress))
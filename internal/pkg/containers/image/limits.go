@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package image
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// ErrOutsideMaintenanceWindow is returned when the image pull is restricted to the maintenance windows.
+var ErrOutsideMaintenanceWindow = errors.New("image pull is allowed only in the maintenance windows")
+
+// maxBandwidthBurst caps the burst of the bandwidth limiter, so that the limit is smooth for large reads.
+const maxBandwidthBurst = 256 * 1024
+
+// bandwidthLimiter is shared by all the image pulls done by Talos, so that the limit applies to the total bandwidth.
+var bandwidthLimiter = rate.NewLimiter(rate.Inf, 0)
+
+// setBandwidthLimit updates the shared bandwidth limit, zero means no limit.
+func setBandwidthLimit(limiter *rate.Limiter, limit uint64) {
+	if limit == 0 {
+		limiter.SetLimit(rate.Inf)
+
+		return
+	}
+
+	burst := limit
+	if burst > maxBandwidthBurst {
+		burst = maxBandwidthBurst
+	}
+
+	limiter.SetBurst(int(burst))
+	limiter.SetLimit(rate.Limit(limit))
+}
+
+// rateLimitedTransport limits the bandwidth of the response bodies.
+type rateLimitedTransport struct {
+	http.RoundTripper
+
+	limiter *rate.Limiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Body = &rateLimitedReader{
+		ReadCloser: resp.Body,
+		ctx:        req.Context(),
+		limiter:    t.limiter,
+	}
+
+	return resp, nil
+}
+
+type rateLimitedReader struct {
+	io.ReadCloser
+
+	ctx     context.Context //nolint:containedctx
+	limiter *rate.Limiter
+}
+
+// Read implements io.Reader.
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if burst := r.limiter.Burst(); r.limiter.Limit() != rate.Inf && burst > 0 && len(p) > burst {
+		p = p[:burst]
+	}
+
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+
+	return n, err
+}
+
+// InMaintenanceWindow checks whether the time falls into one of the daily maintenance windows.
+//
+// If the time is outside of the windows, the start of the next window is returned.
+// No windows means no restriction.
+func InMaintenanceWindow(windows []config.MaintenanceWindow, now time.Time) (bool, time.Time) {
+	if len(windows) == 0 {
+		return true, time.Time{}
+	}
+
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var next time.Time
+
+	for _, window := range windows {
+		// windows which started yesterday might still be open
+		for _, day := range []int{-1, 0, 1} {
+			start := midnight.AddDate(0, 0, day).Add(window.Start())
+
+			if !now.Before(start) && now.Before(start.Add(window.Duration())) {
+				return true, time.Time{}
+			}
+
+			if start.After(now) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+
+	return false, next
+}
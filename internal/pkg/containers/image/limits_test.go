@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package image_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/internal/pkg/containers/image"
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	windows := (&v1alpha1.RegistryPullsConfig{
+		PullMaintenanceWindows: []v1alpha1.MaintenanceWindowConfig{
+			{
+				WindowStart:    "22:00",
+				WindowDuration: 4 * time.Hour,
+			},
+			{
+				WindowStart:    "12:00",
+				WindowDuration: 30 * time.Minute,
+			},
+		},
+	}).MaintenanceWindows()
+
+	day := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name     string
+		windows  []config.MaintenanceWindow
+		now      time.Time
+		inWindow bool
+		next     time.Time
+	}{
+		{
+			name:     "no windows",
+			now:      day.Add(8 * time.Hour),
+			inWindow: true,
+		},
+		{
+			name:     "window from yesterday",
+			windows:  windows,
+			now:      day.Add(time.Hour),
+			inWindow: true,
+		},
+		{
+			name:     "window today",
+			windows:  windows,
+			now:      day.Add(12*time.Hour + 10*time.Minute),
+			inWindow: true,
+		},
+		{
+			name:    "before window",
+			windows: windows,
+			now:     day.Add(8 * time.Hour),
+			next:    day.Add(12 * time.Hour),
+		},
+		{
+			name:    "window end",
+			windows: windows,
+			now:     day.Add(2 * time.Hour),
+			next:    day.Add(12 * time.Hour),
+		},
+		{
+			name:    "after window",
+			windows: windows,
+			now:     day.Add(13 * time.Hour),
+			next:    day.Add(22 * time.Hour),
+		},
+		{
+			name:     "local time",
+			windows:  windows,
+			now:      day.Add(23 * time.Hour).In(time.FixedZone("UTC+5", 5*60*60)),
+			inWindow: true,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			inWindow, next := image.InMaintenanceWindow(tt.windows, tt.now)
+
+			assert.Equal(t, tt.inWindow, inWindow)
+			assert.Equal(t, tt.next, next)
+		})
+	}
+}
@@ -48,6 +48,15 @@ func RegistryHosts(reg config.Registries) docker.RegistryHosts {
 			transport := newTransport()
 			client := &http.Client{Transport: transport}
 
+			if reg.Pulls() != nil && reg.Pulls().BandwidthLimit() > 0 {
+				setBandwidthLimit(bandwidthLimiter, reg.Pulls().BandwidthLimit())
+
+				client.Transport = &rateLimitedTransport{
+					RoundTripper: transport,
+					limiter:      bandwidthLimiter,
+				}
+			}
+
 			registryConfig := reg.Config()[u.Host]
 
 			if u.Scheme != "https" && registryConfig != nil && registryConfig.TLS() != nil {
@@ -20,6 +20,7 @@ import (
 type mockConfig struct {
 	mirrors map[string]*v1alpha1.RegistryMirrorConfig
 	config  map[string]*v1alpha1.RegistryConfig
+	pulls   *v1alpha1.RegistryPullsConfig
 }
 
 func (c *mockConfig) Mirrors() map[string]config.RegistryMirrorConfig {
@@ -42,6 +43,14 @@ func (c *mockConfig) Config() map[string]config.RegistryConfig {
 	return registries
 }
 
+func (c *mockConfig) Pulls() config.RegistryPulls {
+	if c.pulls == nil {
+		return nil
+	}
+
+	return c.pulls
+}
+
 func (c *mockConfig) ExtraFiles() ([]config.File, error) {
 	return nil, fmt.Errorf("not implemented")
 }
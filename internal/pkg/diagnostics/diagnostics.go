@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package diagnostics implements diagnostics snapshots captured on boot failure.
+package diagnostics
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// SummaryFilename is the name of the snapshot summary file.
+const SummaryFilename = "summary.yaml"
+
+// Summary describes the failure which triggered the snapshot.
+type Summary struct {
+	Timestamp time.Time `yaml:"timestamp"`
+	Sequence  string    `yaml:"sequence"`
+	Error     string    `yaml:"error"`
+}
+
+// Snapshot is a diagnostics snapshot being captured.
+//
+// Files are written to a temporary directory which replaces the previous snapshot on Commit,
+// so that a failure while capturing doesn't leave a partial snapshot behind.
+type Snapshot struct {
+	path    string
+	tmpPath string
+}
+
+// NewSnapshot starts capturing a new snapshot to the path.
+func NewSnapshot(path string) (*Snapshot, error) {
+	s := &Snapshot{
+		path:    path,
+		tmpPath: path + ".tmp",
+	}
+
+	if err := os.RemoveAll(s.tmpPath); err != nil {
+		return nil, fmt.Errorf("error cleaning up snapshot directory: %w", err)
+	}
+
+	if err := os.MkdirAll(s.tmpPath, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating snapshot directory: %w", err)
+	}
+
+	return s, nil
+}
+
+// WriteFile adds a file to the snapshot.
+//
+// Name is relative to the snapshot root, and it might include subdirectories.
+func (s *Snapshot) WriteFile(name string, r io.Reader) error {
+	path := filepath.Join(s.tmpPath, filepath.Clean("/"+name))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	if _, err = io.Copy(f, r); err != nil {
+		return fmt.Errorf("error writing %q: %w", name, err)
+	}
+
+	return f.Close()
+}
+
+// Commit writes the summary and replaces the previous snapshot.
+func (s *Snapshot) Commit(summary Summary) error {
+	b, err := yaml.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("error marshaling summary: %w", err)
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(s.tmpPath, SummaryFilename), b, 0o600); err != nil {
+		return fmt.Errorf("error writing summary: %w", err)
+	}
+
+	if err = os.RemoveAll(s.path); err != nil {
+		return fmt.Errorf("error removing previous snapshot: %w", err)
+	}
+
+	return os.Rename(s.tmpPath, s.path)
+}
+
+// Abort removes the snapshot being captured.
+func (s *Snapshot) Abort() error {
+	return os.RemoveAll(s.tmpPath)
+}
+
+// LoadSummary reads the summary of the snapshot at the path.
+//
+// If there is no snapshot, nil summary is returned.
+func LoadSummary(path string) (*Summary, error) {
+	b, err := ioutil.ReadFile(filepath.Join(path, SummaryFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error reading summary: %w", err)
+	}
+
+	var summary Summary
+
+	if err = yaml.Unmarshal(b, &summary); err != nil {
+		return nil, fmt.Errorf("error unmarshaling summary: %w", err)
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package diagnostics_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/diagnostics"
+)
+
+func TestSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "diagnostics")
+
+	summary, err := diagnostics.LoadSummary(path)
+	require.NoError(t, err)
+	assert.Nil(t, summary)
+
+	for _, contents := range []string{"first", "second"} {
+		snapshot, err := diagnostics.NewSnapshot(path)
+		require.NoError(t, err)
+
+		require.NoError(t, snapshot.WriteFile("logs/"+contents+".log", strings.NewReader(contents)))
+		require.NoError(t, snapshot.WriteFile("../dmesg.log", strings.NewReader("dmesg")))
+
+		require.NoError(t, snapshot.Commit(diagnostics.Summary{
+			Timestamp: time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC),
+			Sequence:  "boot",
+			Error:     contents,
+		}))
+	}
+
+	summary, err = diagnostics.LoadSummary(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second", summary.Error)
+
+	// previous snapshot is replaced completely
+	_, err = os.Stat(filepath.Join(path, "logs", "first.log"))
+	assert.True(t, os.IsNotExist(err))
+
+	b, err := ioutil.ReadFile(filepath.Join(path, "logs", "second.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(b))
+
+	// files can't escape the snapshot directory
+	b, err = ioutil.ReadFile(filepath.Join(path, "dmesg.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "dmesg", string(b))
+
+	snapshot, err := diagnostics.NewSnapshot(path)
+	require.NoError(t, err)
+	require.NoError(t, snapshot.Abort())
+
+	summary, err = diagnostics.LoadSummary(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second", summary.Error)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/talos-systems/crypto/x509"
 	"github.com/talos-systems/net"
 
+	"github.com/talos-systems/talos/pkg/machinery/constants"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
 )
 
@@ -47,7 +48,7 @@ func NewCommonOptions() ([]x509.Option, error) {
 		x509.CommonName(hostname),
 		x509.DNSNames(dnsNames),
 		x509.IPAddresses(ips),
-		x509.NotAfter(time.Now().Add(87600 * time.Hour)),
+		x509.NotAfter(time.Now().Add(constants.EtcdDefaultCertificateValidityDuration)),
 		x509.KeyUsage(stdlibx509.KeyUsageDigitalSignature | stdlibx509.KeyUsageKeyEncipherment),
 	}, nil
 }
@@ -35,11 +35,34 @@ const QuorumCheckTimeout = 15 * time.Second
 // Client is a wrapper around the official etcd client.
 type Client struct {
 	*clientv3.Client
+
+	// shared clients are owned by the pool and never closed by the users
+	shared bool
+}
+
+// Close closes the client connection unless the client is shared via the pool.
+func (c *Client) Close() error {
+	if c.shared {
+		return nil
+	}
+
+	return c.Client.Close()
 }
 
 // NewClient initializes and returns an etcd client configured to talk to
 // a list of endpoints.
 func NewClient(endpoints []string) (client *Client, err error) {
+	c, err := newClient(clientv3.Config{
+		Endpoints: endpoints,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{Client: c}, nil
+}
+
+func newClient(cfg clientv3.Config) (*clientv3.Client, error) {
 	tlsInfo := transport.TLSInfo{
 		CertFile:      constants.KubernetesEtcdAdminCert,
 		KeyFile:       constants.KubernetesEtcdAdminKey,
@@ -51,28 +74,40 @@ func NewClient(endpoints []string) (client *Client, err error) {
 		return nil, fmt.Errorf("error building etcd client TLS config: %w", err)
 	}
 
-	c, err := clientv3.New(clientv3.Config{
-		Endpoints:   endpoints,
-		DialTimeout: 5 * time.Second,
-		DialOptions: []grpc.DialOption{grpc.WithBlock()},
-		TLS:         tlsConfig,
-		Logger:      zap.NewNop(),
-	})
+	cfg.DialTimeout = 5 * time.Second
+	cfg.DialOptions = []grpc.DialOption{grpc.WithBlock()}
+	cfg.TLS = tlsConfig
+	cfg.Logger = zap.NewNop()
+
+	c, err := clientv3.New(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error building etcd client: %w", err)
 	}
 
-	return &Client{Client: c}, nil
+	return c, nil
 }
 
+// localEndpoint is the etcd endpoint of the local member.
+const localEndpoint = "127.0.0.1:2379"
+
 // NewLocalClient initializes and returns etcd client configured to talk to localhost endpoint.
 func NewLocalClient() (client *Client, err error) {
-	return NewClient([]string{"127.0.0.1:2379"})
+	return NewClient([]string{localEndpoint})
 }
 
 // NewClientFromControlPlaneIPs initializes and returns an etcd client
 // configured to talk to all members.
 func NewClientFromControlPlaneIPs(ctx context.Context, creds *x509.PEMEncodedCertificateAndKey, endpoint *url.URL) (client *Client, err error) {
+	endpoints, err := controlPlaneEndpoints(ctx, creds, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(endpoints)
+}
+
+// controlPlaneEndpoints returns etcd endpoints of the control plane nodes registered in Kubernetes.
+func controlPlaneEndpoints(ctx context.Context, creds *x509.PEMEncodedCertificateAndKey, endpoint *url.URL) ([]string, error) {
 	h, err := kubernetes.NewTemporaryClientFromPKI(creds, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("error building kubernetes client from PKI: %w", err)
@@ -91,7 +126,7 @@ func NewClientFromControlPlaneIPs(ctx context.Context, creds *x509.PEMEncodedCer
 		endpoints[i] = net.FormatAddress(endpoints[i]) + ":2379"
 	}
 
-	return NewClient(endpoints)
+	return endpoints, nil
 }
 
 // ValidateForUpgrade validates the etcd cluster state to ensure that performing
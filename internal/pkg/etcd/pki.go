@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+)
+
+type pkiFile struct {
+	path     string
+	contents []byte
+}
+
+func pkiFiles(etcdCerts *secrets.EtcdCertsSpec) []pkiFile {
+	return []pkiFile{
+		{path: constants.KubernetesEtcdKey, contents: etcdCerts.Etcd.Key},
+		{path: constants.KubernetesEtcdCert, contents: etcdCerts.Etcd.Crt},
+		{path: constants.KubernetesEtcdPeerKey, contents: etcdCerts.EtcdPeer.Key},
+		{path: constants.KubernetesEtcdPeerCert, contents: etcdCerts.EtcdPeer.Crt},
+		{path: constants.KubernetesEtcdAdminKey, contents: etcdCerts.EtcdAdmin.Key},
+		{path: constants.KubernetesEtcdAdminCert, contents: etcdCerts.EtcdAdmin.Crt},
+	}
+}
+
+// WritePKI writes etcd member and Talos client certificates and keys.
+//
+// Files are replaced atomically: etcd reloads certificates from disk on each TLS handshake,
+// so the running member picks up the new certificates without a restart.
+func WritePKI(etcdCerts *secrets.EtcdCertsSpec) error {
+	for _, file := range pkiFiles(etcdCerts) {
+		tmpPath := file.path + ".tmp"
+
+		if err := ioutil.WriteFile(tmpPath, file.contents, 0o400); err != nil {
+			return err
+		}
+
+		if err := os.Chown(tmpPath, constants.EtcdUserID, constants.EtcdUserID); err != nil {
+			return err
+		}
+
+		if err := os.Rename(tmpPath, file.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PKIUpToDate checks whether etcd certificates and keys on disk match the etcd secrets.
+func PKIUpToDate(etcdCerts *secrets.EtcdCertsSpec) bool {
+	for _, file := range pkiFiles(etcdCerts) {
+		contents, err := ioutil.ReadFile(file.path)
+		if err != nil || !bytes.Equal(contents, file.contents) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/talos-systems/crypto/x509"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Shared client pool settings.
+const (
+	// poolHealthCheckInterval is the minimum interval between the health checks of a pooled client.
+	poolHealthCheckInterval = 30 * time.Second
+	poolHealthCheckTimeout  = 5 * time.Second
+
+	// poolAutoSyncInterval is the interval to refresh the cluster client endpoints from the member list.
+	poolAutoSyncInterval = time.Minute
+)
+
+type pooledClient struct {
+	client      *clientv3.Client
+	lastChecked time.Time
+}
+
+// clientPool keeps the etcd clients shared by the controllers and API handlers,
+// so that every etcd call doesn't need a new connection and TLS handshake.
+type clientPool struct {
+	mu sync.Mutex
+
+	local   pooledClient
+	cluster pooledClient
+}
+
+var pool clientPool
+
+// NewSharedLocalClient returns the etcd client to the localhost endpoint shared via the pool.
+//
+// Closing the returned client is a no-op.
+func NewSharedLocalClient() (*Client, error) {
+	return pool.get(&pool.local, func() (clientv3.Config, error) {
+		return clientv3.Config{
+			Endpoints: []string{localEndpoint},
+		}, nil
+	})
+}
+
+// NewSharedClientFromControlPlaneIPs returns the etcd client to all members shared via the pool.
+//
+// Endpoints are discovered via Kubernetes when the client is created, and refreshed from the etcd member list afterwards.
+// Closing the returned client is a no-op.
+func NewSharedClientFromControlPlaneIPs(ctx context.Context, creds *x509.PEMEncodedCertificateAndKey, endpoint *url.URL) (*Client, error) {
+	return pool.get(&pool.cluster, func() (clientv3.Config, error) {
+		endpoints, err := controlPlaneEndpoints(ctx, creds, endpoint)
+		if err != nil {
+			return clientv3.Config{}, err
+		}
+
+		return clientv3.Config{
+			Endpoints:        endpoints,
+			AutoSyncInterval: poolAutoSyncInterval,
+		}, nil
+	})
+}
+
+func (p *clientPool) get(pc *pooledClient, config func() (clientv3.Config, error)) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pc.client != nil && time.Since(pc.lastChecked) > poolHealthCheckInterval {
+		// the client is shared, so the check shouldn't fail because of the caller context
+		if err := healthCheck(context.Background(), pc.client); err != nil {
+			pc.client.Close() //nolint:errcheck
+
+			pc.client = nil
+		} else {
+			pc.lastChecked = time.Now()
+		}
+	}
+
+	if pc.client == nil {
+		cfg, err := config()
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := newClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		pc.client = c
+		pc.lastChecked = time.Now()
+	}
+
+	return &Client{
+		Client: pc.client,
+		shared: true,
+	}, nil
+}
+
+// healthCheck verifies that the client can reach etcd, the check doesn't require quorum.
+func healthCheck(ctx context.Context, c *clientv3.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, poolHealthCheckTimeout)
+	defer cancel()
+
+	_, err := c.Get(ctx, "health", clientv3.WithSerializable())
+	if err == rpctypes.ErrPermissionDenied {
+		// the connection works, the key just can't be read
+		err = nil
+	}
+
+	return err
+}
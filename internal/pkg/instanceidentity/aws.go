@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package instanceidentity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/fullsailor/pkcs7"
+)
+
+// awsIdentityDocument is the signed AWS instance identity document.
+//
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instance-identity-documents.html.
+type awsIdentityDocument struct {
+	AccountID        string `json:"accountId"`
+	AvailabilityZone string `json:"availabilityZone"`
+	InstanceID       string `json:"instanceId"`
+	Region           string `json:"region"`
+}
+
+// verifyAWS verifies the PKCS7 signature of the AWS instance identity document (rsa2048 endpoint).
+func (v *Verifier) verifyAWS(document []byte) (*Identity, error) {
+	certificates, err := parseCertificates(v.AWSCertificates)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing AWS certificates: %w", err)
+	}
+
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no AWS certificates are configured to verify the instance identity")
+	}
+
+	data := append([]byte("-----BEGIN PKCS7-----\n"), bytes.TrimSpace(document)...)
+	data = append(data, []byte("\n-----END PKCS7-----\n")...)
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PKCS7 signature")
+	}
+
+	p7, err := pkcs7.Parse(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS7 signature: %w", err)
+	}
+
+	verified := false
+
+	for _, certificate := range certificates {
+		p7.Certificates = []*x509.Certificate{certificate}
+
+		if err = p7.Verify(); err == nil {
+			verified = true
+
+			break
+		}
+	}
+
+	if !verified {
+		return nil, fmt.Errorf("failed to verify PKCS7 signature: %w", err)
+	}
+
+	var doc awsIdentityDocument
+
+	if err = json.Unmarshal(p7.Content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse AWS instance identity document: %w", err)
+	}
+
+	if doc.InstanceID == "" || doc.AccountID == "" {
+		return nil, fmt.Errorf("AWS instance identity document is incomplete")
+	}
+
+	return &Identity{
+		Platform:   PlatformAWS,
+		InstanceID: doc.InstanceID,
+		AccountID:  doc.AccountID,
+		Region:     doc.Region,
+		Zone:       doc.AvailabilityZone,
+	}, nil
+}
@@ -0,0 +1,190 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package instanceidentity
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// GCPAudience is the audience of the GCP identity tokens requested by Talos.
+	GCPAudience = "https://talos.dev/trustd"
+
+	// GCPCertificatesURL is the URL of the Google certificates used to sign the identity tokens.
+	GCPCertificatesURL = "https://www.googleapis.com/oauth2/v1/certs"
+)
+
+// gcpTokenHeader is the JWT header of the GCP identity token.
+type gcpTokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// gcpTokenClaims is the payload of the GCP identity token in the full format.
+//
+// See https://cloud.google.com/compute/docs/instances/verifying-instance-identity.
+type gcpTokenClaims struct {
+	Issuer    string `json:"iss"`
+	Audience  string `json:"aud"`
+	ExpiresAt int64  `json:"exp"`
+	Google    struct {
+		ComputeEngine struct {
+			InstanceID   string `json:"instance_id"`
+			InstanceName string `json:"instance_name"`
+			ProjectID    string `json:"project_id"`
+			Zone         string `json:"zone"`
+		} `json:"compute_engine"`
+	} `json:"google"`
+}
+
+// verifyGCP verifies the signature and the claims of the GCP instance identity token.
+//
+//nolint:gocyclo
+func (v *Verifier) verifyGCP(ctx context.Context, token []byte) (*Identity, error) {
+	parts := strings.Split(string(bytes.TrimSpace(token)), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed GCP identity token")
+	}
+
+	var header gcpTokenHeader
+
+	if err := decodeTokenPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("failed to decode GCP identity token header: %w", err)
+	}
+
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("unsupported GCP identity token algorithm %q", header.Algorithm)
+	}
+
+	fetchCertificates := v.GCPCertificates
+	if fetchCertificates == nil {
+		fetchCertificates = fetchGCPCertificates
+	}
+
+	certificates, err := fetchCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Google certificates: %w", err)
+	}
+
+	certificate, ok := certificates[header.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("GCP identity token is signed with unknown key %q", header.KeyID)
+	}
+
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Google certificate public key type %T", certificate.PublicKey)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode GCP identity token signature: %w", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("failed to verify GCP identity token signature: %w", err)
+	}
+
+	var claims gcpTokenClaims
+
+	if err = decodeTokenPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode GCP identity token claims: %w", err)
+	}
+
+	switch {
+	case claims.Issuer != "https://accounts.google.com" && claims.Issuer != "accounts.google.com":
+		return nil, fmt.Errorf("unexpected GCP identity token issuer %q", claims.Issuer)
+	case claims.Audience != GCPAudience:
+		return nil, fmt.Errorf("unexpected GCP identity token audience %q", claims.Audience)
+	case !v.now().Before(time.Unix(claims.ExpiresAt, 0)):
+		return nil, fmt.Errorf("GCP identity token is expired")
+	}
+
+	computeEngine := claims.Google.ComputeEngine
+
+	if computeEngine.InstanceID == "" || computeEngine.ProjectID == "" {
+		return nil, fmt.Errorf("GCP identity token doesn't contain the instance details")
+	}
+
+	region := computeEngine.Zone
+
+	if idx := strings.LastIndex(region, "-"); idx > 0 {
+		region = region[:idx]
+	}
+
+	return &Identity{
+		Platform:     PlatformGCP,
+		InstanceID:   computeEngine.InstanceID,
+		InstanceName: computeEngine.InstanceName,
+		AccountID:    computeEngine.ProjectID,
+		Region:       region,
+		Zone:         computeEngine.Zone,
+	}, nil
+}
+
+func decodeTokenPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func fetchGCPCertificates(ctx context.Context) (map[string]*x509.Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GCPCertificatesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded map[string]string
+
+	if err = json.Unmarshal(body, &encoded); err != nil {
+		return nil, err
+	}
+
+	certificates := make(map[string]*x509.Certificate, len(encoded))
+
+	for keyID, certificate := range encoded {
+		parsed, err := parseCertificates([]string{certificate})
+		if err != nil {
+			return nil, fmt.Errorf("error parsing certificate %q: %w", keyID, err)
+		}
+
+		certificates[keyID] = parsed[0]
+	}
+
+	return certificates, nil
+}
@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package instanceidentity verifies the signed instance identity documents provided by the cloud platforms.
+package instanceidentity
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// Platforms providing the instance identity documents.
+const (
+	PlatformAWS = "aws"
+	PlatformGCP = "gcp"
+)
+
+// Identity is the verified instance identity.
+type Identity struct {
+	Platform     string
+	InstanceID   string
+	InstanceName string
+	// AccountID is the AWS account ID or GCP project ID.
+	AccountID string
+	Region    string
+	Zone      string
+}
+
+// Verifier verifies the platform instance identity documents.
+type Verifier struct {
+	// AWSCertificates are the PEM-encoded AWS RSA-2048 certificates of the regions the identity documents are signed with.
+	AWSCertificates []string
+	// GCPCertificates fetches the Google certificates the GCP identity tokens are signed with (by key ID).
+	//
+	// Defaults to fetching the certificates from GCPCertificatesURL.
+	GCPCertificates func(ctx context.Context) (map[string]*x509.Certificate, error)
+	// Now returns current time, defaults to time.Now.
+	Now func() time.Time
+}
+
+// Verify the instance identity document and return the verified identity.
+func (v *Verifier) Verify(ctx context.Context, platform string, document []byte) (*Identity, error) {
+	if len(document) == 0 {
+		return nil, fmt.Errorf("instance identity document is empty")
+	}
+
+	switch platform {
+	case PlatformAWS:
+		return v.verifyAWS(document)
+	case PlatformGCP:
+		return v.verifyGCP(ctx, document)
+	default:
+		return nil, fmt.Errorf("instance identity is not supported for platform %q", platform)
+	}
+}
+
+func (v *Verifier) now() time.Time {
+	if v.Now != nil {
+		return v.Now()
+	}
+
+	return time.Now()
+}
+
+func parseCertificates(certificates []string) ([]*x509.Certificate, error) {
+	result := make([]*x509.Certificate, 0, len(certificates))
+
+	for _, certificate := range certificates {
+		block, _ := pem.Decode([]byte(certificate))
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode certificate PEM block")
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+
+		result = append(result, cert)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,182 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package instanceidentity_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fullsailor/pkcs7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/instanceidentity"
+)
+
+func generateCertificate(t *testing.T) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func encodeCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+func TestVerifyAWS(t *testing.T) {
+	cert, key := generateCertificate(t)
+	otherCert, _ := generateCertificate(t)
+
+	doc, err := json.Marshal(map[string]string{
+		"accountId":        "123456789012",
+		"availabilityZone": "us-east-1a",
+		"instanceId":       "i-1234567890abcdef0",
+		"region":           "us-east-1",
+	})
+	require.NoError(t, err)
+
+	signedData, err := pkcs7.NewSignedData(doc)
+	require.NoError(t, err)
+
+	require.NoError(t, signedData.AddSigner(cert, key, pkcs7.SignerInfoConfig{}))
+
+	signed, err := signedData.Finish()
+	require.NoError(t, err)
+
+	document := []byte(base64.StdEncoding.EncodeToString(signed))
+
+	verifier := &instanceidentity.Verifier{
+		AWSCertificates: []string{encodeCertificate(otherCert), encodeCertificate(cert)},
+	}
+
+	identity, err := verifier.Verify(context.Background(), instanceidentity.PlatformAWS, document)
+	require.NoError(t, err)
+
+	assert.Equal(t, &instanceidentity.Identity{
+		Platform:   instanceidentity.PlatformAWS,
+		InstanceID: "i-1234567890abcdef0",
+		AccountID:  "123456789012",
+		Region:     "us-east-1",
+		Zone:       "us-east-1a",
+	}, identity)
+
+	verifier.AWSCertificates = []string{encodeCertificate(otherCert)}
+
+	_, err = verifier.Verify(context.Background(), instanceidentity.PlatformAWS, document)
+	assert.Error(t, err)
+
+	verifier.AWSCertificates = nil
+
+	_, err = verifier.Verify(context.Background(), instanceidentity.PlatformAWS, document)
+	assert.Error(t, err)
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, keyID string, claims interface{}) []byte {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": keyID, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return []byte(signingInput + "." + base64.RawURLEncoding.EncodeToString(signature))
+}
+
+func gcpClaims(audience string, expiresAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss": "https://accounts.google.com",
+		"aud": audience,
+		"exp": expiresAt.Unix(),
+		"google": map[string]interface{}{
+			"compute_engine": map[string]interface{}{
+				"instance_id":   "4567890123456789012",
+				"instance_name": "worker-1",
+				"project_id":    "my-project",
+				"zone":          "europe-west1-b",
+			},
+		},
+	}
+}
+
+func TestVerifyGCP(t *testing.T) {
+	cert, key := generateCertificate(t)
+	_, otherKey := generateCertificate(t)
+
+	now := time.Now()
+
+	verifier := &instanceidentity.Verifier{
+		GCPCertificates: func(context.Context) (map[string]*x509.Certificate, error) {
+			return map[string]*x509.Certificate{"key1": cert}, nil
+		},
+		Now: func() time.Time { return now },
+	}
+
+	identity, err := verifier.Verify(context.Background(), instanceidentity.PlatformGCP,
+		signToken(t, key, "key1", gcpClaims(instanceidentity.GCPAudience, now.Add(time.Hour))))
+	require.NoError(t, err)
+
+	assert.Equal(t, &instanceidentity.Identity{
+		Platform:     instanceidentity.PlatformGCP,
+		InstanceID:   "4567890123456789012",
+		InstanceName: "worker-1",
+		AccountID:    "my-project",
+		Region:       "europe-west1",
+		Zone:         "europe-west1-b",
+	}, identity)
+
+	for _, tt := range []struct {
+		name  string
+		token []byte
+	}{
+		{"wrong key", signToken(t, otherKey, "key1", gcpClaims(instanceidentity.GCPAudience, now.Add(time.Hour)))},
+		{"unknown key", signToken(t, key, "key2", gcpClaims(instanceidentity.GCPAudience, now.Add(time.Hour)))},
+		{"wrong audience", signToken(t, key, "key1", gcpClaims("https://example.com", now.Add(time.Hour)))},
+		{"expired", signToken(t, key, "key1", gcpClaims(instanceidentity.GCPAudience, now.Add(-time.Minute)))},
+		{"malformed", []byte("not-a-token")},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), instanceidentity.PlatformGCP, tt.token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerifyUnsupportedPlatform(t *testing.T) {
+	_, err := (&instanceidentity.Verifier{}).Verify(context.Background(), "metal", []byte("document"))
+	assert.Error(t, err)
+}
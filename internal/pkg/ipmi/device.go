@@ -0,0 +1,161 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package ipmi
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// DevicePath is the path to the OpenIPMI device.
+const DevicePath = "/dev/ipmi0"
+
+const (
+	ipmictlReceiveMsgTrunc = 0xc030690b
+	ipmictlSendCommand     = 0x8028690d
+
+	systemInterfaceAddrType = 0x0c
+	bmcChannel              = 0x0f
+
+	maxMsgLength = 272
+
+	responseTimeout = 5 * time.Second
+)
+
+// Structures below mirror the Linux OpenIPMI driver ABI (linux/ipmi.h).
+
+type ipmiMsg struct {
+	netFn   uint8
+	cmd     uint8
+	dataLen uint16
+	data    *byte
+}
+
+type systemInterfaceAddr struct {
+	addrType int32
+	channel  int16
+	lun      uint8
+	_        uint8
+}
+
+type ipmiReq struct {
+	addr    *systemInterfaceAddr
+	addrLen uint32
+	msgID   int64
+	msg     ipmiMsg
+}
+
+type ipmiRecv struct {
+	recvType int32
+	addr     *byte
+	addrLen  uint32
+	msgID    int64
+	msg      ipmiMsg
+}
+
+// Open opens the IPMI device.
+func Open() (*Client, io.Closer, error) {
+	f, err := os.OpenFile(DevicePath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return New(&device{f: f}), f, nil
+}
+
+// device implements Transport over the OpenIPMI device.
+type device struct {
+	f     *os.File
+	msgID int64
+}
+
+// Send implements Transport interface.
+func (d *device) Send(netFn, cmd uint8, data []byte) ([]byte, error) {
+	addr := systemInterfaceAddr{
+		addrType: systemInterfaceAddrType,
+		channel:  bmcChannel,
+	}
+
+	req := ipmiReq{
+		addr:    &addr,
+		addrLen: uint32(unsafe.Sizeof(addr)),
+		msgID:   atomic.AddInt64(&d.msgID, 1),
+		msg: ipmiMsg{
+			netFn:   netFn,
+			cmd:     cmd,
+			dataLen: uint16(len(data)),
+		},
+	}
+
+	if len(data) > 0 {
+		req.msg.data = &data[0]
+	}
+
+	if err := d.ioctl(ipmictlSendCommand, unsafe.Pointer(&req)); err != nil {
+		return nil, fmt.Errorf("error sending IPMI request: %w", err)
+	}
+
+	for {
+		fds := []unix.PollFd{
+			{
+				Fd:     int32(d.f.Fd()),
+				Events: unix.POLLIN,
+			},
+		}
+
+		n, err := unix.Poll(fds, int(responseTimeout/time.Millisecond))
+		if err != nil {
+			if err == unix.EINTR {
+				continue
+			}
+
+			return nil, fmt.Errorf("error waiting for IPMI response: %w", err)
+		}
+
+		if n == 0 {
+			return nil, fmt.Errorf("timed out waiting for IPMI response")
+		}
+
+		var (
+			recvAddr [32]byte
+			recvData [maxMsgLength]byte
+		)
+
+		recv := ipmiRecv{
+			addr:    &recvAddr[0],
+			addrLen: uint32(len(recvAddr)),
+			msg: ipmiMsg{
+				data:    &recvData[0],
+				dataLen: uint16(len(recvData)),
+			},
+		}
+
+		if err = d.ioctl(ipmictlReceiveMsgTrunc, unsafe.Pointer(&recv)); err != nil {
+			return nil, fmt.Errorf("error receiving IPMI response: %w", err)
+		}
+
+		// skip responses to the previous (timed out) requests
+		if recv.msgID != req.msgID {
+			continue
+		}
+
+		return append([]byte(nil), recvData[:recv.msg.dataLen]...), nil
+	}
+}
+
+func (d *device) ioctl(op uintptr, arg unsafe.Pointer) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, d.f.Fd(), op, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
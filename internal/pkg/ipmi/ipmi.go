@@ -0,0 +1,216 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package ipmi implements a minimal IPMI client to talk to the node BMC over the system interface.
+//
+// Only the commands required to read the BMC LAN configuration, set the boot device
+// and control the chassis power are implemented.
+package ipmi
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/talos-systems/go-smbios/smbios"
+	"inet.af/netaddr"
+)
+
+// Network functions.
+const (
+	netFnChassis   = 0x00
+	netFnApp       = 0x06
+	netFnTransport = 0x0c
+)
+
+// Commands.
+const (
+	cmdGetDeviceID         = 0x01
+	cmdGetChannelInfo      = 0x42
+	cmdGetLANConfigParams  = 0x02
+	cmdChassisControl      = 0x02
+	cmdSetSystemBootOption = 0x08
+)
+
+const (
+	lanParamIPAddress  = 3
+	lanParamMACAddress = 5
+
+	bootParamBootFlags = 5
+
+	channelMediumLAN = 0x04
+	maxChannel       = 0x0b
+)
+
+// smbiosIPMIDeviceInformation is the SMBIOS IPMI device information structure type.
+const smbiosIPMIDeviceInformation = 38
+
+var interfaceTypes = map[byte]string{
+	0: "Unknown",
+	1: "KCS",
+	2: "SMIC",
+	3: "BT",
+	4: "SSIF",
+}
+
+// InterfaceType returns the IPMI system interface type from the SMBIOS IPMI device information.
+//
+// Empty string is returned if SMBIOS doesn't describe an IPMI device.
+func InterfaceType(s *smbios.SMBIOS) string {
+	for _, structure := range s.Structures {
+		if structure.Header.Type != smbiosIPMIDeviceInformation || len(structure.Formatted) < 1 {
+			continue
+		}
+
+		if interfaceType, ok := interfaceTypes[structure.Formatted[0]]; ok {
+			return interfaceType
+		}
+
+		return interfaceTypes[0]
+	}
+
+	return ""
+}
+
+// BootDevice is the boot device selector of the system boot options.
+type BootDevice uint8
+
+// Boot devices.
+const (
+	BootDeviceNone  BootDevice = 0x00
+	BootDevicePXE   BootDevice = 0x01
+	BootDeviceDisk  BootDevice = 0x02
+	BootDeviceCDROM BootDevice = 0x05
+	BootDeviceBIOS  BootDevice = 0x06
+)
+
+// ChassisAction is the chassis control action.
+type ChassisAction uint8
+
+// Chassis control actions.
+const (
+	ChassisPowerOff   ChassisAction = 0x00
+	ChassisPowerOn    ChassisAction = 0x01
+	ChassisPowerCycle ChassisAction = 0x02
+	ChassisHardReset  ChassisAction = 0x03
+)
+
+// Transport sends the request to the BMC and returns the response data starting with the completion code.
+type Transport interface {
+	Send(netFn, cmd uint8, data []byte) ([]byte, error)
+}
+
+// Client is the IPMI client.
+type Client struct {
+	t Transport
+}
+
+// New creates an IPMI client over the transport.
+func New(t Transport) *Client {
+	return &Client{
+		t: t,
+	}
+}
+
+// IPMIVersion returns the IPMI specification version implemented by the BMC.
+func (c *Client) IPMIVersion() (string, error) {
+	resp, err := c.run(netFnApp, cmdGetDeviceID, nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting device ID: %w", err)
+	}
+
+	if len(resp) < 5 {
+		return "", fmt.Errorf("short device ID response")
+	}
+
+	// version is BCD-encoded: minor version in the high nibble
+	return fmt.Sprintf("%d.%d", resp[4]&0x0f, resp[4]>>4), nil
+}
+
+// LANChannel returns the number of the first LAN channel of the BMC.
+func (c *Client) LANChannel() (uint8, error) {
+	for channel := uint8(1); channel <= maxChannel; channel++ {
+		resp, err := c.run(netFnApp, cmdGetChannelInfo, []byte{channel})
+		if err != nil {
+			// channel is not implemented
+			continue
+		}
+
+		if len(resp) >= 2 && resp[1]&0x7f == channelMediumLAN {
+			return channel, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no LAN channel found")
+}
+
+// LANAddress returns the IP and MAC addresses of the LAN channel.
+func (c *Client) LANAddress(channel uint8) (netaddr.IP, net.HardwareAddr, error) {
+	ip, err := c.lanParam(channel, lanParamIPAddress, 4)
+	if err != nil {
+		return netaddr.IP{}, nil, err
+	}
+
+	mac, err := c.lanParam(channel, lanParamMACAddress, 6)
+	if err != nil {
+		return netaddr.IP{}, nil, err
+	}
+
+	return netaddr.IPv4(ip[0], ip[1], ip[2], ip[3]), net.HardwareAddr(mac), nil
+}
+
+// SetBootDevice sets the device to boot from on the next boot (or all the following boots if persistent is set).
+func (c *Client) SetBootDevice(device BootDevice, persistent bool) error {
+	flags := byte(0x80) // boot flags valid
+
+	if persistent {
+		flags |= 0x40
+	}
+
+	if _, err := c.run(netFnChassis, cmdSetSystemBootOption, []byte{bootParamBootFlags, flags, byte(device) << 2, 0, 0, 0}); err != nil {
+		return fmt.Errorf("error setting boot device: %w", err)
+	}
+
+	return nil
+}
+
+// ChassisControl runs the chassis control action.
+func (c *Client) ChassisControl(action ChassisAction) error {
+	if _, err := c.run(netFnChassis, cmdChassisControl, []byte{byte(action)}); err != nil {
+		return fmt.Errorf("error running chassis control: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) lanParam(channel, param uint8, size int) ([]byte, error) {
+	resp, err := c.run(netFnTransport, cmdGetLANConfigParams, []byte{channel, param, 0, 0})
+	if err != nil {
+		return nil, fmt.Errorf("error getting LAN parameter %d: %w", param, err)
+	}
+
+	// first byte is the parameter revision
+	if len(resp) < 1+size {
+		return nil, fmt.Errorf("short LAN parameter %d response", param)
+	}
+
+	return resp[1 : 1+size], nil
+}
+
+// run executes the command and returns the response after the completion code.
+func (c *Client) run(netFn, cmd uint8, data []byte) ([]byte, error) {
+	resp, err := c.t.Send(netFn, cmd, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp) < 1 {
+		return nil, fmt.Errorf("empty IPMI response")
+	}
+
+	if cc := resp[0]; cc != 0 {
+		return nil, fmt.Errorf("IPMI completion code 0x%x", cc)
+	}
+
+	return resp[1:], nil
+}
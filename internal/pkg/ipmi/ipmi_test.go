@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package ipmi_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"inet.af/netaddr"
+
+	"github.com/talos-systems/talos/internal/pkg/ipmi"
+)
+
+type request struct {
+	netFn, cmd uint8
+	data       []byte
+}
+
+// fakeBMC records the requests and replies with the canned responses.
+type fakeBMC struct {
+	requests  []request
+	responses map[string][]byte
+}
+
+func (b *fakeBMC) Send(netFn, cmd uint8, data []byte) ([]byte, error) {
+	b.requests = append(b.requests, request{netFn, cmd, append([]byte(nil), data...)})
+
+	if resp, ok := b.responses[fmt.Sprintf("%02x/%02x/% x", netFn, cmd, data)]; ok {
+		return resp, nil
+	}
+
+	// invalid command in this context
+	return []byte{0xcc}, nil
+}
+
+func TestLAN(t *testing.T) {
+	bmc := &fakeBMC{
+		responses: map[string][]byte{
+			"06/01/":            {0, 0x20, 0x01, 0x02, 0x03, 0x02},
+			"06/42/01":          {0, 0x01, 0x0c},
+			"06/42/02":          {0, 0x02, 0x04},
+			"0c/02/02 03 00 00": {0, 0x11, 10, 5, 0, 100},
+			"0c/02/02 05 00 00": {0, 0x11, 0x00, 0x25, 0x90, 0xaa, 0xbb, 0xcc},
+		},
+	}
+
+	client := ipmi.New(bmc)
+
+	version, err := client.IPMIVersion()
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0", version)
+
+	channel, err := client.LANChannel()
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 2, channel)
+
+	ip, mac, err := client.LANAddress(channel)
+	require.NoError(t, err)
+
+	assert.Equal(t, netaddr.MustParseIP("10.5.0.100"), ip)
+	assert.Equal(t, net.HardwareAddr{0x00, 0x25, 0x90, 0xaa, 0xbb, 0xcc}, mac)
+}
+
+func TestControl(t *testing.T) {
+	bmc := &fakeBMC{
+		responses: map[string][]byte{
+			"00/08/05 80 04 00 00 00": {0},
+			"00/02/02":                {0},
+		},
+	}
+
+	client := ipmi.New(bmc)
+
+	require.NoError(t, client.SetBootDevice(ipmi.BootDevicePXE, false))
+	require.NoError(t, client.ChassisControl(ipmi.ChassisPowerCycle))
+
+	assert.EqualError(t, client.SetBootDevice(ipmi.BootDeviceDisk, true), "error setting boot device: IPMI completion code 0xcc")
+
+	assert.Equal(t, []byte{0x05, 0xc0, 0x08, 0, 0, 0}, bmc.requests[2].data)
+}
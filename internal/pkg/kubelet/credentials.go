@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package kubelet provides helpers to manage kubelet client credentials, seccomp profile, health checks and resource manager state.
+package kubelet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Services stops and starts the kubelet service, it is implemented by the machined service runner.
+type Services interface {
+	Start(serviceIDs ...string) error
+	Stop(ctx context.Context, serviceIDs ...string) error
+}
+
+// RepairClientCredentials stops kubelet, removes kubelet client credentials and starts kubelet again.
+//
+// Kubelet requests a new client certificate using the bootstrap kubeconfig, which is kept up to date by the controller.
+// Paths of the removed files are returned.
+func RepairClientCredentials(ctx context.Context, services Services) ([]string, error) {
+	return withKubeletStopped(ctx, services, func() ([]string, error) {
+		removed, err := RemoveClientCredentials(constants.KubeletPKIDir, constants.KubeletKubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("error removing kubelet client credentials: %w", err)
+		}
+
+		return removed, nil
+	})
+}
+
+// RegenerateCredentials stops kubelet, removes kubelet credentials issued by the cluster CA along with the cluster CA certificate
+// and starts kubelet again.
+//
+// Kubelet PreFunc writes the cluster CA certificate from the current machine configuration, and the bootstrap kubeconfig
+// is kept up to date with the cluster CA by the controller, so that kubelet bootstraps again against the rotated cluster CA.
+// Paths of the removed files are returned.
+func RegenerateCredentials(ctx context.Context, services Services) ([]string, error) {
+	return withKubeletStopped(ctx, services, func() ([]string, error) {
+		removed, err := RemoveCredentials(constants.KubeletPKIDir, constants.KubeletKubeconfig, constants.KubernetesCACert)
+		if err != nil {
+			return nil, fmt.Errorf("error removing kubelet credentials: %w", err)
+		}
+
+		return removed, nil
+	})
+}
+
+// withKubeletStopped runs f while kubelet is stopped.
+//
+// Kubelet is started even if f fails, so that it is not left stopped.
+func withKubeletStopped(ctx context.Context, services Services, f func() ([]string, error)) ([]string, error) {
+	if err := services.Stop(ctx, "kubelet"); err != nil {
+		return nil, fmt.Errorf("error stopping kubelet: %w", err)
+	}
+
+	removed, removeErr := f()
+
+	if err := services.Start("kubelet"); err != nil {
+		return nil, fmt.Errorf("error starting kubelet: %w", err)
+	}
+
+	return removed, removeErr
+}
+
+// RemoveClientCredentials removes kubelet kubeconfig and client certificates from the kubelet PKI directory.
+//
+// Paths of the removed files are returned.
+func RemoveClientCredentials(pkiDir, kubeconfigPath string) ([]string, error) {
+	// kubelet-client-current.pem is a symlink to the latest kubelet-client-<timestamp>.pem
+	paths, err := filepath.Glob(filepath.Join(pkiDir, "kubelet-client-*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	return removeFiles(append(paths, kubeconfigPath))
+}
+
+// RemoveCredentials removes kubelet client credentials, serving certificates signed by the cluster CA
+// and the cluster CA certificate.
+//
+// Self-signed kubelet serving certificate is kept, as it doesn't depend on the cluster CA.
+// Paths of the removed files are returned.
+func RemoveCredentials(pkiDir, kubeconfigPath, caPath string) ([]string, error) {
+	removed, err := RemoveClientCredentials(pkiDir, kubeconfigPath)
+	if err != nil {
+		return removed, err
+	}
+
+	// kubelet-server-current.pem is a symlink to the latest kubelet-server-<timestamp>.pem issued with serverTLSBootstrap
+	paths, err := filepath.Glob(filepath.Join(pkiDir, "kubelet-server-*.pem"))
+	if err != nil {
+		return removed, err
+	}
+
+	more, err := removeFiles(append(paths, caPath))
+
+	return append(removed, more...), err
+}
+
+func removeFiles(paths []string) ([]string, error) {
+	var removed []string
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return removed, err
+		}
+
+		removed = append(removed, path)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,128 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+)
+
+func TestRemoveClientCredentials(t *testing.T) {
+	dir := t.TempDir()
+
+	pkiDir := filepath.Join(dir, "pki")
+	kubeconfig := filepath.Join(dir, "kubeconfig-kubelet")
+
+	require.NoError(t, os.Mkdir(pkiDir, 0o700))
+
+	for _, name := range []string{"kubelet-client-2021-10-01-12-00-00.pem", "kubelet.crt", "kubelet.key"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(pkiDir, name), nil, 0o600))
+	}
+
+	require.NoError(t, os.Symlink("kubelet-client-2021-10-01-12-00-00.pem", filepath.Join(pkiDir, "kubelet-client-current.pem")))
+	require.NoError(t, ioutil.WriteFile(kubeconfig, nil, 0o600))
+
+	removed, err := kubelet.RemoveClientCredentials(pkiDir, kubeconfig)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(pkiDir, "kubelet-client-2021-10-01-12-00-00.pem"),
+		filepath.Join(pkiDir, "kubelet-client-current.pem"),
+		kubeconfig,
+	}, removed)
+
+	// kubelet serving certificate is kept
+	files, err := filepath.Glob(filepath.Join(pkiDir, "*"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(pkiDir, "kubelet.crt"), filepath.Join(pkiDir, "kubelet.key")}, files)
+
+	// repeated removal is a no-op
+	removed, err = kubelet.RemoveClientCredentials(pkiDir, kubeconfig)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+}
+
+func TestRemoveCredentials(t *testing.T) {
+	dir := t.TempDir()
+
+	pkiDir := filepath.Join(dir, "pki")
+	kubeconfig := filepath.Join(dir, "kubeconfig-kubelet")
+	bootstrapKubeconfig := filepath.Join(dir, "bootstrap-kubeconfig")
+	ca := filepath.Join(dir, "ca.crt")
+
+	require.NoError(t, os.Mkdir(pkiDir, 0o700))
+
+	for _, name := range []string{"kubelet-client-2021-10-01-12-00-00.pem", "kubelet-server-2021-10-01-12-00-00.pem", "kubelet.crt", "kubelet.key"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(pkiDir, name), nil, 0o600))
+	}
+
+	for _, path := range []string{kubeconfig, bootstrapKubeconfig, ca} {
+		require.NoError(t, ioutil.WriteFile(path, nil, 0o600))
+	}
+
+	removed, err := kubelet.RemoveCredentials(pkiDir, kubeconfig, ca)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(pkiDir, "kubelet-client-2021-10-01-12-00-00.pem"),
+		kubeconfig,
+		filepath.Join(pkiDir, "kubelet-server-2021-10-01-12-00-00.pem"),
+		ca,
+	}, removed)
+
+	// bootstrap kubeconfig is managed by the controller
+	_, err = os.Stat(bootstrapKubeconfig)
+	assert.NoError(t, err)
+
+	// self-signed kubelet serving certificate is kept
+	files, err := filepath.Glob(filepath.Join(pkiDir, "*"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(pkiDir, "kubelet.crt"), filepath.Join(pkiDir, "kubelet.key")}, files)
+
+	// repeated removal is a no-op
+	removed, err = kubelet.RemoveCredentials(pkiDir, kubeconfig, ca)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+}
+
+type mockServices struct {
+	calls []string
+}
+
+func (m *mockServices) Start(serviceIDs ...string) error {
+	m.calls = append(m.calls, "start "+serviceIDs[0])
+
+	return nil
+}
+
+func (m *mockServices) Stop(ctx context.Context, serviceIDs ...string) error {
+	m.calls = append(m.calls, "stop "+serviceIDs[0])
+
+	return ctx.Err()
+}
+
+func TestRepairClientCredentialsStopFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var services mockServices
+
+	_, err := kubelet.RepairClientCredentials(ctx, &services)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error stopping kubelet")
+
+	// kubelet is not started if it wasn't stopped
+	assert.Equal(t, []string{"stop kubelet"}, services.calls)
+}
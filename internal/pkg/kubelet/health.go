@@ -0,0 +1,193 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// HealthEndpoint derives the kubelet health endpoint from the effective kubelet configuration.
+//
+// Kubelet command line flags take precedence over the kubelet configuration file, so the extra args
+// override the healthz port and the bind address set in the machine config.
+// If the healthz endpoint is disabled (port 0), kubelet secure port is used instead.
+func HealthEndpoint(kubelet config.Kubelet) (*url.URL, error) {
+	port := kubelet.HealthzPort()
+
+	if arg, ok := kubelet.ExtraArgs()["healthz-port"]; ok {
+		var err error
+
+		port, err = strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing kubelet healthz port %q: %w", arg, err)
+		}
+	}
+
+	bindAddress := "127.0.0.1"
+
+	if address, ok := kubelet.ExtraConfig()["healthzBindAddress"].(string); ok {
+		bindAddress = address
+	}
+
+	if arg, ok := kubelet.ExtraArgs()["healthz-bind-address"]; ok {
+		bindAddress = arg
+	}
+
+	scheme := "http"
+
+	if port == 0 {
+		scheme = "https"
+		port = kubelet.Port()
+
+		// secure port listens on all addresses
+		bindAddress = "127.0.0.1"
+	}
+
+	host := net.ParseIP(bindAddress)
+	if host == nil {
+		return nil, fmt.Errorf("kubelet healthz bind address %q is not a valid IP address", bindAddress)
+	}
+
+	if host.IsUnspecified() {
+		if host.To4() != nil {
+			host = net.IPv4(127, 0, 0, 1)
+		} else {
+			host = net.IPv6loopback
+		}
+	}
+
+	return &url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(host.String(), strconv.Itoa(port)),
+		Path:   "/healthz",
+	}, nil
+}
+
+// CheckHealth performs the kubelet health check against the endpoint.
+//
+// HTTPS endpoint is verified against the kubelet serving certificate stored in the kubelet PKI directory.
+// Anonymous requests to the secure port are rejected, so the authentication errors are treated as healthy:
+// kubelet is serving the API with its own certificate.
+func CheckHealth(ctx context.Context, endpoint *url.URL, pkiDir string) error {
+	client := http.DefaultClient
+
+	if endpoint.Scheme == "https" {
+		servingCerts, err := servingCertificates(pkiDir)
+		if err != nil {
+			return err
+		}
+
+		client = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					// the serving certificate is pinned instead of being verified against the CA,
+					// as the self-signed kubelet certificate doesn't include the loopback address
+					InsecureSkipVerify: true, //nolint:gosec
+					VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+						return verifyServingCertificate(rawCerts, servingCerts)
+					},
+					MinVersion: tls.VersionTLS12,
+				},
+			},
+		}
+
+		defer client.CloseIdleConnections()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case endpoint.Scheme == "https" && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden):
+		return nil
+	default:
+		return fmt.Errorf("expected HTTP status OK, got %s", resp.Status)
+	}
+}
+
+// servingCertificates loads the kubelet serving certificates from the kubelet PKI directory.
+//
+// Kubelet either generates a self-signed certificate (kubelet.crt), or requests it from the cluster
+// with the server certificate rotation enabled (kubelet-server-current.pem).
+func servingCertificates(pkiDir string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	for _, name := range []string{"kubelet.crt", "kubelet-server-current.pem"} {
+		contents, err := ioutil.ReadFile(filepath.Join(pkiDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for {
+			var block *pem.Block
+
+			block, contents = pem.Decode(contents)
+			if block == nil {
+				break
+			}
+
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing kubelet serving certificate %q: %w", name, err)
+			}
+
+			certs = append(certs, cert)
+		}
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("kubelet serving certificate is not found in %q", pkiDir)
+	}
+
+	return certs, nil
+}
+
+func verifyServingCertificate(rawCerts [][]byte, servingCerts []*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("kubelet didn't present a certificate")
+	}
+
+	for _, cert := range servingCerts {
+		if bytes.Equal(rawCerts[0], cert.Raw) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("kubelet certificate doesn't match the serving certificate")
+}
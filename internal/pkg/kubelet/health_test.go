@@ -0,0 +1,135 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet_test
+
+import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/crypto/x509"
+
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestHealthEndpoint(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		config   *v1alpha1.KubeletConfig
+		expected string
+	}{
+		{
+			name:     "default",
+			config:   &v1alpha1.KubeletConfig{},
+			expected: "http://127.0.0.1:10248/healthz",
+		},
+		{
+			name: "healthzPort",
+			config: &v1alpha1.KubeletConfig{
+				KubeletHealthzPort: 10258,
+			},
+			expected: "http://127.0.0.1:10258/healthz",
+		},
+		{
+			name: "extraArgs",
+			config: &v1alpha1.KubeletConfig{
+				KubeletHealthzPort: 10258,
+				KubeletExtraArgs: map[string]string{
+					"healthz-port":         "10268",
+					"healthz-bind-address": "172.20.0.2",
+				},
+			},
+			expected: "http://172.20.0.2:10268/healthz",
+		},
+		{
+			name: "extraConfig",
+			config: &v1alpha1.KubeletConfig{
+				KubeletExtraConfig: v1alpha1.Unstructured{
+					Object: map[string]interface{}{
+						"healthzBindAddress": "::",
+					},
+				},
+			},
+			expected: "http://[::1]:10248/healthz",
+		},
+		{
+			name: "disabled",
+			config: &v1alpha1.KubeletConfig{
+				KubeletPort: 10260,
+				KubeletExtraArgs: map[string]string{
+					"healthz-port": "0",
+				},
+			},
+			expected: "https://127.0.0.1:10260/healthz",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, err := kubelet.HealthEndpoint(tt.config)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, endpoint.String())
+		})
+	}
+
+	_, err := kubelet.HealthEndpoint(&v1alpha1.KubeletConfig{
+		KubeletExtraArgs: map[string]string{
+			"healthz-bind-address": "localhost",
+		},
+	})
+	assert.EqualError(t, err, "kubelet healthz bind address \"localhost\" is not a valid IP address")
+}
+
+func TestCheckHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	healthz := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthz.Close()
+
+	endpoint, err := url.Parse(healthz.URL + "/healthz")
+	require.NoError(t, err)
+
+	assert.NoError(t, kubelet.CheckHealth(ctx, endpoint, t.TempDir()))
+
+	// anonymous requests to the secure port are rejected
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer secure.Close()
+
+	endpoint, err = url.Parse(secure.URL + "/healthz")
+	require.NoError(t, err)
+
+	pkiDir := t.TempDir()
+
+	assert.EqualError(t, kubelet.CheckHealth(ctx, endpoint, pkiDir), "kubelet serving certificate is not found in \""+pkiDir+"\"")
+
+	// other certificate is not trusted
+	other, err := x509.NewSelfSignedCertificateAuthority()
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(pkiDir, "kubelet.crt"), other.CrtPEM, 0o600))
+
+	assert.Error(t, kubelet.CheckHealth(ctx, endpoint, pkiDir))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(pkiDir, "kubelet-server-current.pem"), pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: secure.Certificate().Raw,
+	}), 0o600))
+
+	assert.NoError(t, kubelet.CheckHealth(ctx, endpoint, pkiDir))
+}
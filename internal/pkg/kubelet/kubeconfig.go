@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
+)
+
+var bootstrapKubeconfigTemplate = template.Must(template.New("kubeconfig").Parse(`apiVersion: v1
+kind: Config
+clusters:
+- name: local
+  cluster:
+    server: {{ .Server }}
+    certificate-authority-data: {{ .CACert }}
+users:
+- name: kubelet
+  user:
+{{- if .BootstrapTokenID }}
+    token: {{ .BootstrapTokenID }}.{{ .BootstrapTokenSecret }}
+{{- else }} {}
+{{- end }}
+contexts:
+- context:
+    cluster: local
+    user: kubelet
+`))
+
+// BootstrapKubeconfig renders the kubelet bootstrap kubeconfig.
+//
+// Short-lived token minted on the control plane nodes is preferred over the long-lived one from the machine configuration
+// until it expires, shortLivedToken might be nil. Once the long-lived token is retired, the kubeconfig has no token
+// until the short-lived one is available.
+func BootstrapKubeconfig(cfg config.Provider, shortLivedToken *secrets.KubeletBootstrapTokenSpec, now time.Time) ([]byte, error) {
+	values := struct {
+		Server               string
+		CACert               string
+		BootstrapTokenID     string
+		BootstrapTokenSecret string
+	}{
+		Server: APIServerEndpoint(cfg),
+		CACert: base64.StdEncoding.EncodeToString(cfg.Cluster().CA().Crt),
+	}
+
+	switch {
+	case shortLivedToken != nil && now.Before(shortLivedToken.Expiration):
+		values.BootstrapTokenID = shortLivedToken.TokenID
+		values.BootstrapTokenSecret = shortLivedToken.TokenSecret
+	case !cfg.Machine().Features().RetireStaticBootstrapTokenEnabled():
+		values.BootstrapTokenID = cfg.Cluster().Token().ID()
+		values.BootstrapTokenSecret = cfg.Cluster().Token().Secret()
+	}
+
+	var buf bytes.Buffer
+
+	if err := bootstrapKubeconfigTemplate.Execute(&buf, values); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// APIServerEndpoint returns the API server endpoint used by the kubelet.
+//
+// With the node-local load balancer enabled, kubelet talks to the API server via the load balancer.
+func APIServerEndpoint(cfg config.Provider) string {
+	if lb := cfg.Machine().Features().ControlPlaneLoadBalancer(); lb.Enabled() {
+		return "https://" + net.JoinHostPort("localhost", strconv.Itoa(lb.Port()))
+	}
+
+	return cfg.Cluster().InternalEndpoint().String()
+}
+
+// KubeconfigCA returns the cluster CA certificate embedded into the kubeconfig.
+//
+// If the kubeconfig doesn't exist, nil is returned.
+func KubeconfigCA(path string) ([]byte, error) {
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	for _, cluster := range kubeconfig.Clusters {
+		if len(cluster.CertificateAuthorityData) > 0 {
+			return cluster.CertificateAuthorityData, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfig "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// TestKubeletConfigSchema verifies that the kubelet config schema covers the vendored KubeletConfiguration.
+func TestKubeletConfigSchema(t *testing.T) {
+	typ := reflect.TypeOf(kubeletconfig.KubeletConfiguration{})
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			// inlined TypeMeta
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		var expected config.KubeletOptionType
+
+		switch {
+		case fieldType == reflect.TypeOf(metav1.Duration{}):
+			expected = config.KubeletOptionDuration
+		case fieldType.Kind() == reflect.String:
+			expected = config.KubeletOptionString
+		case fieldType.Kind() == reflect.Bool:
+			expected = config.KubeletOptionBool
+		case fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Uint64:
+			expected = config.KubeletOptionInt
+		case fieldType.Kind() == reflect.Float32 || fieldType.Kind() == reflect.Float64:
+			expected = config.KubeletOptionFloat
+		case fieldType.Kind() == reflect.Slice:
+			expected = config.KubeletOptionList
+		default:
+			expected = config.KubeletOptionObject
+		}
+
+		actual, ok := config.KubeletConfigFieldTypes[name]
+		if assert.True(t, ok, "field %q is missing in the schema", name) {
+			assert.Equal(t, expected, actual, "field %q type mismatch", name)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"gopkg.in/yaml.v3"
+)
+
+var syscallNameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// SeccompAllowance is a set of syscalls allowed on top of the default seccomp profile.
+type SeccompAllowance struct {
+	// Name describes the reason for the allowance (e.g. the extension name).
+	Name     string   `yaml:"name"`
+	Syscalls []string `yaml:"syscalls"`
+}
+
+// Validate checks the allowance for errors.
+func (a SeccompAllowance) Validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("seccomp allowance name is empty")
+	}
+
+	if len(a.Syscalls) == 0 {
+		return fmt.Errorf("seccomp allowance %q has no syscalls", a.Name)
+	}
+
+	for _, name := range a.Syscalls {
+		if !syscallNameRegexp.MatchString(name) {
+			return fmt.Errorf("seccomp allowance %q syscall %q is invalid", a.Name, name)
+		}
+	}
+
+	return nil
+}
+
+// SeccompProfile builds the kubelet seccomp profile from the default profile and the syscall allowances.
+//
+// Allowances are either registered in the code, or loaded from the YAML files in the directory
+// (so that the extensions can provide them), the directory is read each time the profile is built.
+type SeccompProfile struct {
+	// Dir is the directory with the allowance files (`*.yaml`).
+	Dir string
+
+	mu         sync.Mutex
+	registered []SeccompAllowance
+}
+
+// Register adds the allowance to the profile.
+func (p *SeccompProfile) Register(allowance SeccompAllowance) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.registered = append(p.registered, allowance)
+}
+
+// Allowances returns the registered and loaded allowances.
+//
+// Invalid allowance files are skipped and reported as an error, the valid allowances are still returned.
+func (p *SeccompProfile) Allowances() ([]SeccompAllowance, error) {
+	p.mu.Lock()
+	allowances := append([]SeccompAllowance(nil), p.registered...)
+	p.mu.Unlock()
+
+	if p.Dir == "" {
+		return allowances, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(p.Dir, "*.yaml"))
+	if err != nil {
+		return allowances, err
+	}
+
+	sort.Strings(paths)
+
+	var errs *multierror.Error
+
+	for _, path := range paths {
+		allowance, err := loadSeccompAllowance(path)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+
+			continue
+		}
+
+		allowances = append(allowances, allowance)
+	}
+
+	return allowances, errs.ErrorOrNil()
+}
+
+// Apply adds the allowances to the seccomp profile.
+func (p *SeccompProfile) Apply(seccomp *specs.LinuxSeccomp) error {
+	allowances, err := p.Allowances()
+
+	for _, allowance := range allowances {
+		seccomp.Syscalls = append(seccomp.Syscalls,
+			specs.LinuxSyscall{
+				Names:  allowance.Syscalls,
+				Action: specs.ActAllow,
+				Args:   []specs.LinuxSeccompArg{},
+			},
+		)
+	}
+
+	return err
+}
+
+func loadSeccompAllowance(path string) (SeccompAllowance, error) {
+	var allowance SeccompAllowance
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return allowance, fmt.Errorf("error reading seccomp allowance %q: %w", path, err)
+	}
+
+	if err = yaml.Unmarshal(contents, &allowance); err != nil {
+		return allowance, fmt.Errorf("error decoding seccomp allowance %q: %w", path, err)
+	}
+
+	if err = allowance.Validate(); err != nil {
+		return allowance, fmt.Errorf("error in %q: %w", path, err)
+	}
+
+	return allowance, nil
+}
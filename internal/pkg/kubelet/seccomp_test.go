@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+)
+
+func TestSeccompProfile(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "10-fuse.yaml"), []byte("name: fuse\nsyscalls:\n  - mount\n  - umount2\n"), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "20-invalid.yaml"), []byte("name: invalid\nsyscalls:\n  - \"mount; reboot\"\n"), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not an allowance"), 0o644))
+
+	profile := &kubelet.SeccompProfile{Dir: dir}
+	profile.Register(kubelet.SeccompAllowance{Name: "cephfs", Syscalls: []string{"add_key", "request_key"}})
+
+	seccomp := &specs.LinuxSeccomp{
+		DefaultAction: specs.ActErrno,
+		Syscalls: []specs.LinuxSyscall{
+			{Names: []string{"read"}, Action: specs.ActAllow},
+		},
+	}
+
+	err := profile.Apply(seccomp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "seccomp allowance \"invalid\" syscall \"mount; reboot\" is invalid")
+
+	// invalid allowance is skipped, the others are applied
+	names := [][]string{}
+
+	for _, syscall := range seccomp.Syscalls {
+		assert.Equal(t, specs.ActAllow, syscall.Action)
+
+		names = append(names, syscall.Names)
+	}
+
+	assert.Equal(t, [][]string{{"read"}, {"add_key", "request_key"}, {"mount", "umount2"}}, names)
+
+	// missing directory is not an error
+	profile = &kubelet.SeccompProfile{Dir: filepath.Join(dir, "missing")}
+
+	allowances, err := profile.Allowances()
+	require.NoError(t, err)
+	assert.Empty(t, allowances)
+}
@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// RemoveStaleManagerState removes the kubelet CPU or memory manager checkpoint created with a different policy.
+//
+// Kubelet refuses to start if the policy in the checkpoint doesn't match the configured one,
+// so the checkpoint should be removed on the policy change. Returns true if the checkpoint was removed.
+func RemoveStaleManagerState(path, policy string) (bool, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	var checkpoint struct {
+		PolicyName string `json:"policyName"`
+	}
+
+	// corrupted checkpoint is rejected by the kubelet as well
+	if err = json.Unmarshal(contents, &checkpoint); err == nil && checkpoint.PolicyName == policy {
+		return false, nil
+	}
+
+	if err = os.Remove(path); err != nil {
+		return false, fmt.Errorf("error removing %q: %w", path, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubelet_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/kubelet"
+)
+
+func TestRemoveStaleManagerState(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		checkpoint string
+		policy     string
+		removed    bool
+	}{
+		{
+			name:   "no checkpoint",
+			policy: "static",
+		},
+		{
+			name:       "same policy",
+			checkpoint: `{"policyName":"static","defaultCpuSet":"0-3","checksum":1353318690}`,
+			policy:     "static",
+		},
+		{
+			name:       "policy changed",
+			checkpoint: `{"policyName":"none","defaultCpuSet":"","checksum":1353318690}`,
+			policy:     "static",
+			removed:    true,
+		},
+		{
+			name:       "corrupted",
+			checkpoint: `{"policyName":`,
+			policy:     "None",
+			removed:    true,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cpu_manager_state")
+
+			if tt.checkpoint != "" {
+				require.NoError(t, ioutil.WriteFile(path, []byte(tt.checkpoint), 0o600))
+			}
+
+			removed, err := kubelet.RemoveStaleManagerState(path, tt.policy)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.removed, removed)
+
+			if tt.checkpoint != "" && !tt.removed {
+				assert.FileExists(t, path)
+			} else {
+				assert.NoFileExists(t, path)
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ type Options struct {
 	PreMountHooks    []Hook
 	PostUnmountHooks []Hook
 	Encryption       config.Encryption
+	Volume           config.VolumeMountOptions
 }
 
 // Option is the functional option func.
@@ -84,6 +85,13 @@ func WithEncryptionConfig(cfg config.Encryption) Option {
 	}
 }
 
+// WithVolumeMountOptions sets the mount options of the volume.
+func WithVolumeMountOptions(volume config.VolumeMountOptions) Option {
+	return func(args *Options) {
+		args.Volume = volume
+	}
+}
+
 // Hook represents pre/post mount hook.
 type Hook func(p *Point) error
 
@@ -5,13 +5,28 @@
 package mount
 
 import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/talos-systems/go-procfs/procfs"
 	"golang.org/x/sys/unix"
 	"gopkg.in/freddierice/go-losetup.v1"
 
+	"github.com/talos-systems/talos/internal/pkg/verity"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
 
+const squashfsMagic = 0x73717368
+
 // SquashfsMountPoints returns the mountpoints required to boot the system.
+//
+// If the rootfs image carries the dm-verity hash tree, the rootfs is mounted via the dm-verity device,
+// and the root hash kernel argument is required, so that the verification can't be skipped by dropping the argument.
 func SquashfsMountPoints(prefix string) (mountpoints *Points, err error) {
 	var dev losetup.Device
 
@@ -20,8 +35,79 @@ func SquashfsMountPoints(prefix string) (mountpoints *Points, err error) {
 		return nil, err
 	}
 
+	source := dev.Path()
+
+	params, err := squashfsVerityParams("/" + constants.RootfsAsset)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rootfs verity parameters: %w", err)
+	}
+
+	rootHash := procfs.ProcCmdline().Get(constants.KernelParamVerityRootHash).First()
+
+	switch {
+	case params != nil && rootHash == nil:
+		return nil, fmt.Errorf("rootfs image carries the dm-verity hash tree, but the %q kernel argument is missing", constants.KernelParamVerityRootHash)
+	case params == nil && rootHash != nil:
+		return nil, fmt.Errorf("%q kernel argument is set, but the rootfs image doesn't carry the dm-verity hash tree", constants.KernelParamVerityRootHash)
+	case params != nil:
+		if source, err = openVerity(dev.Path(), params, *rootHash); err != nil {
+			return nil, fmt.Errorf("error setting up rootfs verity: %w", err)
+		}
+	default:
+		log.Printf("WARNING: rootfs image doesn't carry the dm-verity hash tree, rootfs is mounted without integrity verification")
+	}
+
 	squashfs := NewMountPoints()
-	squashfs.Set("squashfs", NewMountPoint(dev.Path(), "/", "squashfs", unix.MS_RDONLY|unix.MS_I_VERSION, "", WithPrefix(prefix), WithFlags(ReadOnly|Shared)))
+	squashfs.Set("squashfs", NewMountPoint(source, "/", "squashfs", unix.MS_RDONLY|unix.MS_I_VERSION, "", WithPrefix(prefix), WithFlags(ReadOnly|Shared)))
 
 	return squashfs, nil
 }
+
+// squashfsVerityParams returns nil if the squashfs image doesn't carry the dm-verity hash tree.
+func squashfsVerityParams(path string) (*verity.Params, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() //nolint:errcheck
+
+	// hash tree is appended to the squashfs image, any mismatch in the parameters is caught by the root hash verification
+	size, err := SquashfsDataSize(f)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := verity.ReadParams(f, size)
+	if errors.Is(err, verity.ErrSuperblockNotFound) {
+		return nil, nil
+	}
+
+	return params, err
+}
+
+func openVerity(devicePath string, params *verity.Params, rootHashHex string) (string, error) {
+	rootHash, err := hex.DecodeString(rootHashHex)
+	if err != nil {
+		return "", fmt.Errorf("error decoding root hash: %w", err)
+	}
+
+	return verity.Open(constants.RootfsVerityDeviceName, devicePath, params, rootHash)
+}
+
+// SquashfsDataSize returns the size of the squashfs filesystem padded to the verity block size.
+func SquashfsDataSize(r io.ReaderAt) (int64, error) {
+	buf := make([]byte, 48)
+
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return 0, fmt.Errorf("error reading squashfs superblock: %w", err)
+	}
+
+	if binary.LittleEndian.Uint32(buf[0:4]) != squashfsMagic {
+		return 0, errors.New("squashfs superblock not found")
+	}
+
+	bytesUsed := int64(binary.LittleEndian.Uint64(buf[40:48]))
+
+	return (bytesUsed + verity.BlockSize - 1) / verity.BlockSize * verity.BlockSize, nil
+}
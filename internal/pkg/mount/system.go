@@ -15,7 +15,6 @@ import (
 	"github.com/cosi-project/runtime/pkg/state"
 	"github.com/talos-systems/go-blockdevice/blockdevice"
 	"github.com/talos-systems/go-blockdevice/blockdevice/filesystem"
-	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime"
 	"github.com/talos-systems/talos/internal/app/machined/pkg/runtime/disk"
@@ -174,7 +173,12 @@ func SystemMountPointForLabel(device *blockdevice.BlockDevice, label string, opt
 
 	opts = append(opts, WithPreMountHooks(preMountHooks...))
 
-	mountpoint = NewMountPoint(partPath, target, fsType, unix.MS_NOATIME, "", opts...)
+	flags, data, err := VolumeMountFlags(o.Volume)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mount options for partition label %s: %w", label, err)
+	}
+
+	mountpoint = NewMountPoint(partPath, target, fsType, flags, data, opts...)
 
 	return mountpoint, nil
 }
@@ -198,6 +202,10 @@ func SystemPartitionMount(r runtime.Runtime, logger *log.Logger, label string, o
 		opts = append(opts, WithEncryptionConfig(encryptionConfig))
 	}
 
+	if label == constants.EphemeralPartitionLabel && r.Config() != nil && r.Config().Machine() != nil {
+		opts = append(opts, WithVolumeMountOptions(r.Config().Machine().Ephemeral()))
+	}
+
 	mountpoint, err := SystemMountPointForLabel(device.BlockDevice, label, opts...)
 	if err != nil {
 		return err
@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mount
+
+import (
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+var volumeMountFlags = map[string]uintptr{
+	"noatime":     unix.MS_NOATIME,
+	"relatime":    unix.MS_RELATIME,
+	"strictatime": unix.MS_STRICTATIME,
+	"nodiratime":  unix.MS_NODIRATIME,
+	"lazytime":    unix.MS_LAZYTIME,
+	"nodev":       unix.MS_NODEV,
+	"nosuid":      unix.MS_NOSUID,
+	"noexec":      unix.MS_NOEXEC,
+}
+
+// VolumeMountFlags returns the mount flags and the filesystem options of the volume.
+//
+// Volumes are mounted with `noatime` unless another atime option is specified.
+func VolumeMountFlags(volume config.VolumeMountOptions) (flags uintptr, data string, err error) {
+	if volume == nil {
+		return unix.MS_NOATIME, "", nil
+	}
+
+	flagNames, fsOptions, err := config.SplitVolumeMountOptions(volume.MountOptions(), volume.ProjectQuota())
+	if err != nil {
+		return 0, "", err
+	}
+
+	atimeSet := false
+
+	for _, name := range flagNames {
+		flags |= volumeMountFlags[name]
+
+		switch name {
+		case "noatime", "relatime", "strictatime":
+			atimeSet = true
+		}
+	}
+
+	if !atimeSet {
+		flags |= unix.MS_NOATIME
+	}
+
+	return flags, strings.Join(fsOptions, ","), nil
+}
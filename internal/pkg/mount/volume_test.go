@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package mount_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/pkg/mount"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestVolumeMountFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		volume *v1alpha1.EphemeralConfig
+		flags  uintptr
+		data   string
+	}{
+		{
+			name:   "defaults",
+			volume: &v1alpha1.EphemeralConfig{},
+			flags:  unix.MS_NOATIME,
+		},
+		{
+			name: "options",
+			volume: &v1alpha1.EphemeralConfig{
+				EphemeralMountOptions: []string{"nodev", "nosuid", "discard", "allocsize=64m"},
+			},
+			flags: unix.MS_NOATIME | unix.MS_NODEV | unix.MS_NOSUID,
+			data:  "discard,allocsize=64m",
+		},
+		{
+			name: "relatime with quota",
+			volume: &v1alpha1.EphemeralConfig{
+				EphemeralMountOptions: []string{"relatime", "nodiratime"},
+				EphemeralProjectQuota: true,
+			},
+			flags: unix.MS_RELATIME | unix.MS_NODIRATIME,
+			data:  "prjquota",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			flags, data, err := mount.VolumeMountFlags(tt.volume)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.flags, flags)
+			assert.Equal(t, tt.data, data)
+		})
+	}
+}
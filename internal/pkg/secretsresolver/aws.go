@@ -0,0 +1,70 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secretsresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// AWSSecretsManagerBackend fetches the secrets from the AWS Secrets Manager.
+//
+// Instance profile credentials are used.
+type AWSSecretsManagerBackend struct {
+	Client secretsmanageriface.SecretsManagerAPI
+}
+
+// NewAWSSecretsManagerBackend creates the AWS Secrets Manager secrets backend.
+func NewAWSSecretsManagerBackend(cfg config.AWSSecretsManagerBackend) (*AWSSecretsManagerBackend, error) {
+	sess, err := session.NewSession(aws.NewConfig().WithRegion(cfg.Region()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AWSSecretsManagerBackend{
+		Client: secretsmanager.New(sess),
+	}, nil
+}
+
+// Fetch implements the Backend interface.
+//
+// Path is the secret name or ARN, the key selects the field of the secret stored as JSON object.
+func (b *AWSSecretsManagerBackend) Fetch(ctx context.Context, path, key string) (string, error) {
+	out, err := b.Client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(path),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+
+		if errors.As(err, &awsErr) {
+			switch awsErr.Code() {
+			case secretsmanager.ErrCodeResourceNotFoundException, secretsmanager.ErrCodeInvalidParameterException, secretsmanager.ErrCodeDecryptionFailure:
+				return "", permanent(fmt.Errorf("error reading AWS secret %q: %w", path, err))
+			}
+		}
+
+		return "", fmt.Errorf("error reading AWS secret %q: %w", path, err)
+	}
+
+	var secret string
+
+	switch {
+	case out.SecretString != nil:
+		secret = *out.SecretString
+	case out.SecretBinary != nil:
+		secret = string(out.SecretBinary)
+	}
+
+	return selectKey(secret, key)
+}
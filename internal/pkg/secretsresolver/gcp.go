@@ -0,0 +1,150 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secretsresolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+const (
+	// GCPMetadataEndpoint is the GCE metadata server endpoint.
+	GCPMetadataEndpoint = "http://metadata.google.internal/computeMetadata/v1"
+
+	// GCPSecretManagerEndpoint is the Google Cloud Secret Manager API endpoint.
+	GCPSecretManagerEndpoint = "https://secretmanager.googleapis.com/v1"
+)
+
+// GCPSecretManagerBackend fetches the secrets from the Google Cloud Secret Manager.
+//
+// Instance service account credentials are used.
+type GCPSecretManagerBackend struct {
+	Project  string
+	Endpoint string
+	Client   *http.Client
+
+	// Token returns the OAuth2 access token.
+	Token func(ctx context.Context) (string, error)
+}
+
+// NewGCPSecretManagerBackend creates the Google Cloud Secret Manager secrets backend.
+func NewGCPSecretManagerBackend(cfg config.GCPSecretManagerBackend) *GCPSecretManagerBackend {
+	return &GCPSecretManagerBackend{
+		Project:  cfg.Project(),
+		Endpoint: GCPSecretManagerEndpoint,
+		Client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		Token: gcpAccessToken,
+	}
+}
+
+// Fetch implements the Backend interface.
+//
+// Path is the secret name optionally followed by `/versions/<version>`, the latest version is used by default.
+// The key selects the field of the secret stored as JSON object.
+func (b *GCPSecretManagerBackend) Fetch(ctx context.Context, path, key string) (string, error) {
+	if !strings.Contains(path, "/versions/") {
+		path += "/versions/latest"
+	}
+
+	token, err := b.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error getting GCP access token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.Endpoint+"/projects/"+b.Project+"/secrets/"+path+":access", nil)
+	if err != nil {
+		return "", permanent(err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error reading GCP secret %q: %w", path, err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusBadRequest, http.StatusForbidden:
+		return "", permanent(fmt.Errorf("error reading GCP secret %q: unexpected response status %d", path, resp.StatusCode))
+	default:
+		return "", fmt.Errorf("error reading GCP secret %q: unexpected response status %d", path, resp.StatusCode)
+	}
+
+	var out struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("error decoding GCP secret %q: %w", path, err)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(out.Payload.Data)
+	if err != nil {
+		return "", permanent(fmt.Errorf("error decoding GCP secret %q: %w", path, err))
+	}
+
+	return selectKey(string(secret), key)
+}
+
+// gcpAccessToken returns the access token of the instance service account.
+func gcpAccessToken(ctx context.Context) (string, error) {
+	b, err := gcpMetadata(ctx, "/instance/service-accounts/default/token")
+	if err != nil {
+		return "", err
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.Unmarshal(b, &token); err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+func gcpMetadata(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GCPMetadataEndpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected metadata server response status %d", resp.StatusCode)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(b), nil
+}
@@ -0,0 +1,215 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package secretsresolver resolves the machine config secret references from the external secrets backends.
+package secretsresolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/talos-systems/go-retry/retry"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// Backend fetches the secrets from the external secrets backend.
+type Backend interface {
+	// Fetch returns the secret stored at the path.
+	//
+	// If the key is not empty, it selects the field of the secret.
+	Fetch(ctx context.Context, path, key string) (string, error)
+}
+
+// NewBackend creates the secrets backend from the config.
+func NewBackend(cfg config.SecretsBackend) (Backend, error) {
+	switch {
+	case cfg.Vault() != nil:
+		return NewVaultBackend(cfg.Vault())
+	case cfg.AWSSecretsManager() != nil:
+		return NewAWSSecretsManagerBackend(cfg.AWSSecretsManager())
+	case cfg.GCPSecretManager() != nil:
+		return NewGCPSecretManagerBackend(cfg.GCPSecretManager()), nil
+	default:
+		return nil, fmt.Errorf("secrets backend %q has no backend type set", cfg.Name())
+	}
+}
+
+// Cache keeps the resolved secret values in memory.
+//
+// Cache is shared by the resolvers built for the subsequent machine configs,
+// so that the previously resolved values are available if the backend is not reachable on the config reload.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value      string
+	resolvedAt time.Time
+}
+
+// NewCache initializes an empty Cache.
+func NewCache() *Cache {
+	return &Cache{
+		entries: map[string]cacheEntry{},
+	}
+}
+
+func (c *Cache) get(ref string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[ref]
+
+	return entry, ok
+}
+
+func (c *Cache) set(ref, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[ref] = cacheEntry{
+		value:      value,
+		resolvedAt: time.Now(),
+	}
+}
+
+// Resolver implements config.SecretsResolver with caching and retries.
+type Resolver struct {
+	// Backends indexed by the name.
+	Backends map[string]Backend
+	Cache    *Cache
+	// CacheTTL is the duration the resolved values are reused for, zero disables the caching.
+	CacheTTL time.Duration
+	// Timeout is the timeout of the secret reference resolution (with retries).
+	Timeout time.Duration
+	// FailurePolicy is either config.SecretsFailurePolicyFail or config.SecretsFailurePolicyStale.
+	FailurePolicy string
+	Logger        *log.Logger
+}
+
+// New creates the resolver from the secrets resolver config.
+func New(cfg config.SecretsResolverConfig, cache *Cache, logger *log.Logger) (*Resolver, error) {
+	backends := map[string]Backend{}
+
+	for _, backendCfg := range cfg.Backends() {
+		backend, err := NewBackend(backendCfg)
+		if err != nil {
+			return nil, fmt.Errorf("error setting up secrets backend %q: %w", backendCfg.Name(), err)
+		}
+
+		backends[backendCfg.Name()] = backend
+	}
+
+	return &Resolver{
+		Backends:      backends,
+		Cache:         cache,
+		CacheTTL:      cfg.CacheTTL(),
+		Timeout:       cfg.Timeout(),
+		FailurePolicy: cfg.FailurePolicy(),
+		Logger:        logger,
+	}, nil
+}
+
+// Resolve implements the config.SecretsResolver interface.
+func (r *Resolver) Resolve(ctx context.Context, ref *config.SecretReference) (string, error) {
+	cached, hasCached := r.Cache.get(ref.String())
+
+	if hasCached && time.Since(cached.resolvedAt) < r.CacheTTL {
+		return cached.value, nil
+	}
+
+	backend, ok := r.Backends[ref.Backend]
+	if !ok {
+		return "", fmt.Errorf("secrets backend %q is not configured", ref.Backend)
+	}
+
+	var value string
+
+	err := retry.Exponential(r.Timeout, retry.WithUnits(time.Second), retry.WithJitter(time.Second)).RetryWithContext(ctx, func(ctx context.Context) error {
+		var fetchErr error
+
+		value, fetchErr = backend.Fetch(ctx, ref.Path, ref.Key)
+
+		var permanentErr *permanentError
+
+		if fetchErr != nil && !errors.As(fetchErr, &permanentErr) {
+			return retry.ExpectedError(fetchErr)
+		}
+
+		return fetchErr
+	})
+	if err != nil {
+		if r.FailurePolicy == config.SecretsFailurePolicyStale && hasCached {
+			r.Logger.Printf("failed to resolve secret reference %s, using the value resolved at %s: %s", ref, cached.resolvedAt.Format(time.RFC3339), err)
+
+			return cached.value, nil
+		}
+
+		return "", err
+	}
+
+	r.Cache.set(ref.String(), value)
+
+	return value, nil
+}
+
+// permanentError is returned by the backends for the errors which are not fixed by retrying, e.g. if the secret is not found.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// selectKey returns the field of the secret stored as JSON object, or the secret itself if the key is empty.
+//
+// Errors never include the secret value.
+func selectKey(secret, key string) (string, error) {
+	if key == "" {
+		return secret, nil
+	}
+
+	var fields map[string]interface{}
+
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", permanent(fmt.Errorf("secret is not a JSON object, key %q can't be selected", key))
+	}
+
+	return fieldValue(fields, key)
+}
+
+// fieldValue returns the field of the secret, non-string values are returned JSON-encoded.
+func fieldValue(fields map[string]interface{}, key string) (string, error) {
+	value, ok := fields[key]
+	if !ok {
+		return "", permanent(fmt.Errorf("key %q is not found in the secret", key))
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", permanent(fmt.Errorf("error encoding key %q of the secret", key))
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,271 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secretsresolver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	stdx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// VaultBackend fetches the secrets from the HashiCorp Vault KV (version 2) secrets engine.
+//
+// Vault token is obtained by logging in with the cloud platform identity of the machine and is kept in memory only.
+type VaultBackend struct {
+	Endpoint  string
+	Mount     string
+	AuthMount string
+	Role      string
+	Client    *http.Client
+
+	// LoginData returns the auth method specific fields of the login request.
+	LoginData func(ctx context.Context, role string) (map[string]interface{}, error)
+
+	mu              sync.Mutex
+	token           string
+	tokenExpiration time.Time
+}
+
+// NewVaultBackend creates the Vault secrets backend.
+func NewVaultBackend(cfg config.VaultSecretsBackend) (*VaultBackend, error) {
+	backend := &VaultBackend{
+		Endpoint:  strings.TrimRight(cfg.Endpoint(), "/"),
+		Mount:     cfg.Mount(),
+		AuthMount: cfg.AuthMount(),
+		Role:      cfg.Role(),
+		Client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+
+	if len(cfg.CA()) > 0 {
+		pool := stdx509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(cfg.CA()) {
+			return nil, fmt.Errorf("failed to parse the Vault CA certificate")
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+
+		backend.Client.Transport = transport
+	}
+
+	switch cfg.AuthMethod() {
+	case "aws":
+		backend.LoginData = vaultAWSLoginData
+	case "gcp":
+		backend.LoginData = vaultGCPLoginData
+	default:
+		return nil, fmt.Errorf("unsupported Vault auth method %q", cfg.AuthMethod())
+	}
+
+	return backend, nil
+}
+
+// Fetch implements the Backend interface.
+//
+// The key can be omitted if the secret has a single key.
+func (b *VaultBackend) Fetch(ctx context.Context, path, key string) (string, error) {
+	token, err := b.login(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+
+	err = b.do(ctx, http.MethodGet, "/v1/"+b.Mount+"/data/"+strings.TrimLeft(path, "/"), token, nil, &resp)
+	if err != nil {
+		var statusErr *vaultStatusError
+
+		if errors.As(err, &statusErr) && statusErr.status == http.StatusForbidden {
+			// token might have been revoked, log in again on the next attempt
+			b.resetToken()
+		}
+
+		return "", fmt.Errorf("error reading Vault secret %q: %w", path, err)
+	}
+
+	if key == "" {
+		if len(resp.Data.Data) != 1 {
+			return "", permanent(fmt.Errorf("secret %q has %d keys, the key should be specified", path, len(resp.Data.Data)))
+		}
+
+		for k := range resp.Data.Data {
+			key = k
+		}
+	}
+
+	return fieldValue(resp.Data.Data, key)
+}
+
+func (b *VaultBackend) login(ctx context.Context) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.token != "" && time.Now().Before(b.tokenExpiration) {
+		return b.token, nil
+	}
+
+	data, err := b.LoginData(ctx, b.Role)
+	if err != nil {
+		return "", fmt.Errorf("error building Vault login request: %w", err)
+	}
+
+	data["role"] = b.Role
+
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+
+	if err = b.do(ctx, http.MethodPost, "/v1/auth/"+b.AuthMount+"/login", "", data, &resp); err != nil {
+		return "", fmt.Errorf("error logging in to Vault: %w", err)
+	}
+
+	if resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("error logging in to Vault: empty token")
+	}
+
+	b.token = resp.Auth.ClientToken
+	// renew the token before it expires
+	b.tokenExpiration = time.Now().Add(time.Duration(resp.Auth.LeaseDuration) * time.Second * 3 / 4)
+
+	return b.token, nil
+}
+
+func (b *VaultBackend) resetToken() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.token = ""
+}
+
+func (b *VaultBackend) do(ctx context.Context, method, path, token string, in, out interface{}) error {
+	var body io.Reader
+
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, b.Endpoint+path, body)
+	if err != nil {
+		return permanent(err)
+	}
+
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		// response body is not included into the error, as it might contain the secret
+		err = &vaultStatusError{status: resp.StatusCode}
+
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+			return permanent(err)
+		}
+
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type vaultStatusError struct {
+	status int
+}
+
+func (e *vaultStatusError) Error() string {
+	return fmt.Sprintf("unexpected Vault response status %d", e.status)
+}
+
+// vaultAWSLoginData builds the AWS IAM auth login request: signed STS GetCallerIdentity request.
+//
+// Request is signed with the instance profile credentials.
+func vaultAWSLoginData(ctx context.Context, _ string) (map[string]interface{}, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := sts.New(sess).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	req.SetContext(ctx)
+
+	if err = req.Sign(); err != nil {
+		return nil, err
+	}
+
+	headers, err := json.Marshal(req.HTTPRequest.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(req.HTTPRequest.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"iam_http_request_method": req.HTTPRequest.Method,
+		"iam_request_url":         base64.StdEncoding.EncodeToString([]byte(req.HTTPRequest.URL.String())),
+		"iam_request_headers":     base64.StdEncoding.EncodeToString(headers),
+		"iam_request_body":        base64.StdEncoding.EncodeToString(body),
+	}, nil
+}
+
+// vaultGCPLoginData builds the GCP GCE auth login request: instance identity token with the Vault role audience.
+func vaultGCPLoginData(ctx context.Context, role string) (map[string]interface{}, error) {
+	query := url.Values{
+		"audience": []string{"http://vault/" + role},
+		"format":   []string{"full"},
+	}
+
+	token, err := gcpMetadata(ctx, "/instance/service-accounts/default/identity?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"jwt": string(token),
+	}, nil
+}
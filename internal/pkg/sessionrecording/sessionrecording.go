@@ -0,0 +1,289 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package sessionrecording records the interactive API sessions to the audit log.
+package sessionrecording
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// Stream is the stream of the recorded session data.
+type Stream string
+
+// Session data streams.
+const (
+	StreamStdin  Stream = "stdin"
+	StreamStdout Stream = "stdout"
+	StreamStderr Stream = "stderr"
+)
+
+// Recorded events.
+const (
+	EventStart     = "start"
+	EventData      = "data"
+	EventTruncated = "truncated"
+	EventEnd       = "end"
+)
+
+// RedactedPlaceholder replaces the redacted data in the transcript.
+const RedactedPlaceholder = "[REDACTED]"
+
+// MaxPendingBytes is the maximum size of the incomplete line buffered before redacting.
+const MaxPendingBytes = 4096
+
+// Redactor removes the sensitive data from the transcript chunk.
+type Redactor func(data []byte) []byte
+
+// RedactorRegistry holds the redactors applied to all the session transcripts.
+type RedactorRegistry struct {
+	mu         sync.Mutex
+	registered []Redactor
+}
+
+// Register adds the redactor to the registry.
+func (registry *RedactorRegistry) Register(redactor Redactor) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.registered = append(registry.registered, redactor)
+}
+
+// Redactors returns the registered redactors.
+func (registry *RedactorRegistry) Redactors() []Redactor {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	return append([]Redactor(nil), registry.registered...)
+}
+
+// DefaultRedactors are applied to the transcripts of the API sessions after the redact patterns from the configuration,
+// additional redactors can be registered (e.g. for the data which can't be matched with the patterns).
+var DefaultRedactors = &RedactorRegistry{}
+
+// RegexpRedactor builds a Redactor replacing the matches of the patterns with RedactedPlaceholder.
+func RegexpRedactor(patterns []string) (Redactor, error) {
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+
+		regexps = append(regexps, re)
+	}
+
+	return func(data []byte) []byte {
+		for _, re := range regexps {
+			data = re.ReplaceAllLiteral(data, []byte(RedactedPlaceholder))
+		}
+
+		return data
+	}, nil
+}
+
+// Record is a single audit log entry, entries are written as JSON lines.
+type Record struct {
+	Time    time.Time              `json:"time"`
+	Session string                 `json:"session"`
+	API     string                 `json:"api"`
+	Event   string                 `json:"event"`
+	Stream  Stream                 `json:"stream,omitempty"`
+	Data    string                 `json:"data,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Recorder records an interactive session to the audit log.
+//
+// Start and end of the session are always recorded, the transcript is recorded only if enabled
+// in the configuration, up to the configured size limit. Transcript is buffered per stream up to the end of line
+// (or MaxPendingBytes) before redacting, so that the data typed on a TTY byte by byte is redacted as well.
+type Recorder struct {
+	mu sync.Mutex
+
+	w       io.WriteCloser
+	api     string
+	session string
+
+	transcript bool
+	remaining  int
+	truncated  bool
+	redactors  []Redactor
+	pending    map[Stream][]byte
+
+	err error
+}
+
+// NewRecorder creates a Recorder writing to the audit log w, cfg might be nil.
+//
+// Additional redactors are applied to the transcript after the ones from the configuration.
+func NewRecorder(w io.WriteCloser, api, session string, cfg config.SessionRecording, redactors ...Redactor) (*Recorder, error) {
+	r := &Recorder{
+		w:       w,
+		api:     api,
+		session: session,
+	}
+
+	if cfg != nil && cfg.Enabled() {
+		redactor, err := RegexpRedactor(cfg.Redact())
+		if err != nil {
+			return nil, err
+		}
+
+		r.transcript = true
+		r.remaining = cfg.MaxBytes()
+		r.redactors = append([]Redactor{redactor}, redactors...)
+		r.pending = map[Stream][]byte{}
+	}
+
+	return r, nil
+}
+
+// Start records the start of the session.
+func (r *Recorder) Start(fields map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.write(Record{
+		Event:  EventStart,
+		Fields: fields,
+	})
+}
+
+// Record records the transcript chunk.
+//
+// Errors are returned from End, so that failing to record the transcript doesn't break the session.
+func (r *Recorder) Record(stream Stream, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.transcript || r.truncated || len(data) == 0 {
+		return
+	}
+
+	if r.remaining <= 0 {
+		r.truncated = true
+
+		r.keepErr(r.write(Record{Event: EventTruncated}))
+
+		return
+	}
+
+	if len(data) > r.remaining {
+		data = data[:r.remaining]
+	}
+
+	r.remaining -= len(data)
+
+	pending := append(r.pending[stream], data...)
+
+	// complete lines are recorded, the rest is buffered unless the line is too long or the transcript limit is reached
+	n := bytes.LastIndexAny(pending, "\r\n") + 1
+
+	if len(pending) >= MaxPendingBytes || r.remaining <= 0 {
+		n = len(pending)
+	}
+
+	r.pending[stream] = append([]byte(nil), pending[n:]...)
+
+	r.flush(stream, pending[:n])
+}
+
+func (r *Recorder) flush(stream Stream, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+
+	// redactors might modify the data in place
+	data = append([]byte(nil), data...)
+
+	for _, redactor := range r.redactors {
+		data = redactor(data)
+	}
+
+	r.keepErr(r.write(Record{
+		Event:  EventData,
+		Stream: stream,
+		Data:   string(data),
+	}))
+}
+
+// Writer returns a writer recording the data written to the stream.
+func (r *Recorder) Writer(stream Stream) io.Writer {
+	return &streamWriter{r: r, stream: stream}
+}
+
+// End records the end of the session and closes the audit log writer.
+//
+// End returns the first error encountered while recording the session.
+func (r *Recorder) End(fields map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	streams := make([]string, 0, len(r.pending))
+
+	for stream := range r.pending {
+		streams = append(streams, string(stream))
+	}
+
+	sort.Strings(streams)
+
+	for _, stream := range streams {
+		r.flush(Stream(stream), r.pending[Stream(stream)])
+	}
+
+	// the transcript recorded after the end is dropped
+	r.transcript = false
+
+	r.keepErr(r.write(Record{
+		Event:  EventEnd,
+		Fields: fields,
+	}))
+
+	r.keepErr(r.w.Close())
+
+	return r.err
+}
+
+func (r *Recorder) write(record Record) error {
+	record.Time = time.Now().UTC()
+	record.API = r.api
+	record.Session = r.session
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.w.Write(append(line, '\n'))
+
+	return err
+}
+
+func (r *Recorder) keepErr(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+type streamWriter struct {
+	r      *Recorder
+	stream Stream
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.r.Record(w.stream, p)
+
+	return len(p), nil
+}
@@ -0,0 +1,238 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package sessionrecording_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/sessionrecording"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+type nopCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *nopCloser) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func readRecords(t *testing.T, r io.Reader) []sessionrecording.Record {
+	var records []sessionrecording.Record
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		var record sessionrecording.Record
+
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+
+		records = append(records, record)
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return records
+}
+
+func TestRecorderDisabled(t *testing.T) {
+	var buf nopCloser
+
+	r, err := sessionrecording.NewRecorder(&buf, "Debug", "debug-1", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(map[string]interface{}{"image": "alpine"}))
+
+	_, err = r.Writer(sessionrecording.StreamStdout).Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, r.End(map[string]interface{}{"exitCode": 0}))
+
+	assert.True(t, buf.closed)
+
+	records := readRecords(t, &buf)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, sessionrecording.EventStart, records[0].Event)
+	assert.Equal(t, "Debug", records[0].API)
+	assert.Equal(t, "debug-1", records[0].Session)
+	assert.Equal(t, "alpine", records[0].Fields["image"])
+	assert.Equal(t, sessionrecording.EventEnd, records[1].Event)
+}
+
+func TestRecorderTranscript(t *testing.T) {
+	var buf nopCloser
+
+	cfg := &v1alpha1.SessionRecordingConfig{
+		SessionRecordingEnabled:  true,
+		SessionRecordingMaxBytes: 32,
+		SessionRecordingRedact:   []string{`password=\S+`},
+	}
+
+	upper := func(data []byte) []byte {
+		return bytes.ToUpper(data)
+	}
+
+	r, err := sessionrecording.NewRecorder(&buf, "Debug", "debug-1", cfg, upper)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(nil))
+
+	input := []byte("login password=secret\n")
+
+	_, err = r.Writer(sessionrecording.StreamStdin).Write(input)
+	require.NoError(t, err)
+
+	// the data passed to the recorder is not modified
+	assert.Equal(t, "login password=secret\n", string(input))
+
+	r.Record(sessionrecording.StreamStdout, []byte("0123456789abcdef"))
+	r.Record(sessionrecording.StreamStderr, []byte("not recorded"))
+	r.Record(sessionrecording.StreamStderr, []byte("not recorded either"))
+
+	require.NoError(t, r.End(nil))
+
+	records := readRecords(t, &buf)
+	require.Len(t, records, 5)
+
+	assert.Equal(t, sessionrecording.EventData, records[1].Event)
+	assert.Equal(t, sessionrecording.StreamStdin, records[1].Stream)
+	assert.Equal(t, "LOGIN [REDACTED]\n", records[1].Data)
+
+	// the rest of the limit
+	assert.Equal(t, sessionrecording.StreamStdout, records[2].Stream)
+	assert.Equal(t, "0123456789", records[2].Data)
+
+	assert.Equal(t, sessionrecording.EventTruncated, records[3].Event)
+	assert.Equal(t, sessionrecording.EventEnd, records[4].Event)
+}
+
+func TestRecorderKeystrokes(t *testing.T) {
+	var buf nopCloser
+
+	cfg := &v1alpha1.SessionRecordingConfig{
+		SessionRecordingEnabled:  true,
+		SessionRecordingMaxBytes: 1024,
+		SessionRecordingRedact:   []string{`password=\S+`},
+	}
+
+	r, err := sessionrecording.NewRecorder(&buf, "Debug", "debug-1", cfg)
+	require.NoError(t, err)
+
+	// TTY input is sent byte by byte, the line ends with the carriage return
+	for _, c := range []byte("login password=secret\rexit") {
+		r.Record(sessionrecording.StreamStdin, []byte{c})
+	}
+
+	r.Record(sessionrecording.StreamStdout, []byte("$ "))
+
+	require.NoError(t, r.End(nil))
+
+	records := readRecords(t, &buf)
+	require.Len(t, records, 4)
+
+	assert.Equal(t, sessionrecording.StreamStdin, records[0].Stream)
+	assert.Equal(t, "login [REDACTED]\r", records[0].Data)
+
+	// incomplete lines are recorded on the session end
+	assert.Equal(t, sessionrecording.StreamStdin, records[1].Stream)
+	assert.Equal(t, "exit", records[1].Data)
+	assert.Equal(t, sessionrecording.StreamStdout, records[2].Stream)
+	assert.Equal(t, "$ ", records[2].Data)
+
+	assert.Equal(t, sessionrecording.EventEnd, records[3].Event)
+}
+
+func TestRecorderLongLine(t *testing.T) {
+	var buf nopCloser
+
+	r, err := sessionrecording.NewRecorder(&buf, "Debug", "debug-1", &v1alpha1.SessionRecordingConfig{
+		SessionRecordingEnabled:  true,
+		SessionRecordingMaxBytes: 2 * sessionrecording.MaxPendingBytes,
+	})
+	require.NoError(t, err)
+
+	r.Record(sessionrecording.StreamStdout, bytes.Repeat([]byte("a"), sessionrecording.MaxPendingBytes-1))
+
+	// the incomplete line is buffered up to the limit
+	assert.Zero(t, buf.Len())
+
+	r.Record(sessionrecording.StreamStdout, []byte("a"))
+
+	records := readRecords(t, &buf)
+	require.Len(t, records, 1)
+
+	assert.Len(t, records[0].Data, sessionrecording.MaxPendingBytes)
+
+	require.NoError(t, r.End(nil))
+}
+
+func TestRedactorRegistry(t *testing.T) {
+	var registry sessionrecording.RedactorRegistry
+
+	assert.Empty(t, registry.Redactors())
+
+	redactor, err := sessionrecording.RegexpRedactor([]string{`token`})
+	require.NoError(t, err)
+
+	registry.Register(redactor)
+
+	var buf nopCloser
+
+	r, err := sessionrecording.NewRecorder(&buf, "Debug", "debug-1", &v1alpha1.SessionRecordingConfig{
+		SessionRecordingEnabled:  true,
+		SessionRecordingMaxBytes: 1024,
+	}, registry.Redactors()...)
+	require.NoError(t, err)
+
+	r.Record(sessionrecording.StreamStdout, []byte("token\n"))
+
+	require.NoError(t, r.End(nil))
+
+	records := readRecords(t, &buf)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "[REDACTED]\n", records[0].Data)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("write failed")
+}
+
+func (failingWriter) Close() error {
+	return nil
+}
+
+func TestRecorderErrors(t *testing.T) {
+	_, err := sessionrecording.NewRecorder(failingWriter{}, "Debug", "debug-1", &v1alpha1.SessionRecordingConfig{
+		SessionRecordingEnabled: true,
+		SessionRecordingRedact:  []string{`(`},
+	})
+	require.Error(t, err)
+
+	r, err := sessionrecording.NewRecorder(failingWriter{}, "Debug", "debug-1", &v1alpha1.SessionRecordingConfig{
+		SessionRecordingEnabled: true,
+	})
+	require.NoError(t, err)
+
+	// transcript recording errors don't break the session, but are reported on end
+	_, err = r.Writer(sessionrecording.StreamStdout).Write([]byte("hello"))
+	require.NoError(t, err)
+
+	assert.EqualError(t, r.End(nil), "write failed")
+}
@@ -0,0 +1,361 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package tpm2 implements a minimal TPM 2.0 client to produce quotes over the boot measurements.
+//
+// Only the commands required for attestation are implemented: the attestation key is created
+// as a primary key in the endorsement hierarchy, so the same key is derived on every boot.
+package tpm2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DevicePath is the path to the TPM resource manager device.
+const DevicePath = "/dev/tpmrm0"
+
+// MaxPCR is the maximum PCR index.
+const MaxPCR = 23
+
+const (
+	tagNoSessions = 0x8001
+	tagSessions   = 0x8002
+
+	ccCreatePrimary = 0x00000131
+	ccFlushContext  = 0x00000165
+	ccQuote         = 0x00000158
+	ccPCRRead       = 0x0000017e
+
+	rhEndorsement = 0x4000000b
+	rsPW          = 0x40000009
+
+	algECC    = 0x0023
+	algSHA256 = 0x000b
+	algNull   = 0x0010
+	algECDSA  = 0x0018
+
+	eccNISTP256 = 0x0003
+
+	attrFixedTPM            = 1 << 1
+	attrFixedParent         = 1 << 4
+	attrSensitiveDataOrigin = 1 << 5
+	attrUserWithAuth        = 1 << 6
+	attrRestricted          = 1 << 16
+	attrSign                = 1 << 18
+
+	pcrSelectSize = 3
+
+	maxResponseSize = 4096
+)
+
+// Quote is a TPM quote over the selected PCRs.
+type Quote struct {
+	// Quoted is the TPMS_ATTEST structure.
+	Quoted []byte
+	// Signature is the TPMT_SIGNATURE over the Quoted.
+	Signature []byte
+}
+
+// TPM is the TPM 2.0 device.
+type TPM struct {
+	rw io.ReadWriter
+}
+
+// Open opens the TPM device.
+func Open() (*TPM, io.Closer, error) {
+	f, err := os.OpenFile(DevicePath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return New(f), f, nil
+}
+
+// New creates a TPM client over the device transport.
+func New(rw io.ReadWriter) *TPM {
+	return &TPM{
+		rw: rw,
+	}
+}
+
+// CreateAttestationKey creates ECDSA P-256 restricted signing key in the endorsement hierarchy.
+//
+// Key handle and TPMT_PUBLIC area of the key are returned, key should be flushed with Flush.
+func (t *TPM) CreateAttestationKey() (uint32, []byte, error) {
+	var public bytes.Buffer
+
+	write(&public,
+		uint16(algECC),
+		uint16(algSHA256),
+		uint32(attrFixedTPM|attrFixedParent|attrSensitiveDataOrigin|attrUserWithAuth|attrRestricted|attrSign),
+		uint16(0),                           // authPolicy
+		uint16(algNull),                     // symmetric
+		uint16(algECDSA), uint16(algSHA256), // scheme
+		uint16(eccNISTP256),
+		uint16(algNull),      // kdf
+		uint16(0), uint16(0), // unique
+	)
+
+	var params bytes.Buffer
+
+	write(&params,
+		uint16(4), uint16(0), uint16(0), // inSensitive
+		uint16(public.Len()),
+	)
+
+	params.Write(public.Bytes())
+
+	write(&params,
+		uint16(0), // outsideInfo
+		uint32(0), // creationPCR
+	)
+
+	resp, err := t.run(ccCreatePrimary, []uint32{rhEndorsement}, true, params.Bytes())
+	if err != nil {
+		return 0, nil, fmt.Errorf("error creating attestation key: %w", err)
+	}
+
+	var handle uint32
+
+	r := bytes.NewReader(resp)
+
+	if err = binary.Read(r, binary.BigEndian, &handle); err != nil {
+		return 0, nil, err
+	}
+
+	// skip parameterSize
+	if _, err = r.Seek(4, io.SeekCurrent); err != nil {
+		return 0, nil, err
+	}
+
+	outPublic, err := readSized(r)
+	if err != nil {
+		t.Flush(handle) //nolint:errcheck
+
+		return 0, nil, fmt.Errorf("error reading attestation key: %w", err)
+	}
+
+	return handle, outPublic, nil
+}
+
+// Quote generates a quote over the SHA-256 PCRs signed with the key.
+func (t *TPM) Quote(handle uint32, nonce []byte, pcrs []int) (*Quote, error) {
+	selection, err := pcrSelection(pcrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var params bytes.Buffer
+
+	write(&params, uint16(len(nonce)))
+	params.Write(nonce)
+	write(&params, uint16(algNull)) // inScheme, key scheme is used
+	params.Write(selection)
+
+	resp, err := t.run(ccQuote, []uint32{handle}, true, params.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error generating quote: %w", err)
+	}
+
+	r := bytes.NewReader(resp)
+
+	var paramSize uint32
+
+	if err = binary.Read(r, binary.BigEndian, &paramSize); err != nil {
+		return nil, err
+	}
+
+	quoted, err := readSized(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading quote: %w", err)
+	}
+
+	// signature takes the rest of the parameters
+	sigSize := int(paramSize) - 2 - len(quoted)
+	if sigSize <= 0 || sigSize > r.Len() {
+		return nil, fmt.Errorf("malformed quote response")
+	}
+
+	signature := make([]byte, sigSize)
+
+	if _, err = io.ReadFull(r, signature); err != nil {
+		return nil, err
+	}
+
+	return &Quote{
+		Quoted:    quoted,
+		Signature: signature,
+	}, nil
+}
+
+// ReadPCR reads the SHA-256 PCR value.
+func (t *TPM) ReadPCR(pcr int) ([]byte, error) {
+	selection, err := pcrSelection([]int{pcr})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.run(ccPCRRead, nil, false, selection)
+	if err != nil {
+		return nil, fmt.Errorf("error reading PCR %d: %w", pcr, err)
+	}
+
+	r := bytes.NewReader(resp)
+
+	var (
+		updateCounter, selectionCount, digestCount uint32
+		hashAlg                                    uint16
+		sizeOfSelect                               uint8
+	)
+
+	if err = binary.Read(r, binary.BigEndian, &updateCounter); err != nil {
+		return nil, err
+	}
+
+	if err = binary.Read(r, binary.BigEndian, &selectionCount); err != nil {
+		return nil, err
+	}
+
+	for i := uint32(0); i < selectionCount; i++ {
+		if err = binary.Read(r, binary.BigEndian, &hashAlg); err != nil {
+			return nil, err
+		}
+
+		if err = binary.Read(r, binary.BigEndian, &sizeOfSelect); err != nil {
+			return nil, err
+		}
+
+		if _, err = r.Seek(int64(sizeOfSelect), io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = binary.Read(r, binary.BigEndian, &digestCount); err != nil {
+		return nil, err
+	}
+
+	if digestCount != 1 {
+		return nil, fmt.Errorf("PCR %d is not available in SHA-256 bank", pcr)
+	}
+
+	return readSized(r)
+}
+
+// Flush flushes the transient object.
+func (t *TPM) Flush(handle uint32) error {
+	var params bytes.Buffer
+
+	write(&params, handle)
+
+	_, err := t.run(ccFlushContext, nil, false, params.Bytes())
+
+	return err
+}
+
+// run executes the command and returns the response after the header.
+//
+// If auth is set, the empty password session is used to authorize the first handle.
+func (t *TPM) run(cc uint32, handles []uint32, auth bool, params []byte) ([]byte, error) {
+	var body bytes.Buffer
+
+	for _, h := range handles {
+		write(&body, h)
+	}
+
+	tag := uint16(tagNoSessions)
+
+	if auth {
+		tag = tagSessions
+
+		write(&body,
+			uint32(9), // authorizationSize
+			uint32(rsPW),
+			uint16(0), // nonce
+			uint8(0),  // sessionAttributes
+			uint16(0), // hmac
+		)
+	}
+
+	body.Write(params)
+
+	var cmd bytes.Buffer
+
+	write(&cmd, tag, uint32(10+body.Len()), cc)
+	cmd.Write(body.Bytes())
+
+	if _, err := t.rw.Write(cmd.Bytes()); err != nil {
+		return nil, err
+	}
+
+	resp := make([]byte, maxResponseSize)
+
+	n, err := t.rw.Read(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = resp[:n]
+
+	if len(resp) < 10 {
+		return nil, fmt.Errorf("short TPM response")
+	}
+
+	if rc := binary.BigEndian.Uint32(resp[6:10]); rc != 0 {
+		return nil, fmt.Errorf("TPM error 0x%x", rc)
+	}
+
+	if size := binary.BigEndian.Uint32(resp[2:6]); int(size) != len(resp) {
+		return nil, fmt.Errorf("TPM response size mismatch: %d != %d", size, len(resp))
+	}
+
+	return resp[10:], nil
+}
+
+// pcrSelection encodes TPML_PCR_SELECTION for SHA-256 bank.
+func pcrSelection(pcrs []int) ([]byte, error) {
+	var mask [pcrSelectSize]byte
+
+	for _, pcr := range pcrs {
+		if pcr < 0 || pcr > MaxPCR {
+			return nil, fmt.Errorf("invalid PCR index %d", pcr)
+		}
+
+		mask[pcr/8] |= 1 << (pcr % 8)
+	}
+
+	var buf bytes.Buffer
+
+	write(&buf, uint32(1), uint16(algSHA256), uint8(pcrSelectSize))
+	buf.Write(mask[:])
+
+	return buf.Bytes(), nil
+}
+
+func readSized(r *bytes.Reader) ([]byte, error) {
+	var size uint16
+
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+
+	if int(size) > r.Len() {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	b := make([]byte, size)
+
+	_, err := io.ReadFull(r, b)
+
+	return b, err
+}
+
+func write(buf *bytes.Buffer, values ...interface{}) {
+	for _, v := range values {
+		binary.Write(buf, binary.BigEndian, v) //nolint:errcheck
+	}
+}
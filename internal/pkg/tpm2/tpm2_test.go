@@ -0,0 +1,128 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package tpm2_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/tpm2"
+)
+
+// fakeDevice records the commands and replies with the canned responses.
+type fakeDevice struct {
+	commands  [][]byte
+	responses [][]byte
+}
+
+func (d *fakeDevice) Write(p []byte) (int, error) {
+	d.commands = append(d.commands, append([]byte(nil), p...))
+
+	return len(p), nil
+}
+
+func (d *fakeDevice) Read(p []byte) (int, error) {
+	resp := d.responses[0]
+	d.responses = d.responses[1:]
+
+	return copy(p, resp), nil
+}
+
+func response(tag uint16, rc uint32, body ...[]byte) []byte {
+	payload := bytes.Join(body, nil)
+
+	var buf bytes.Buffer
+
+	binary.Write(&buf, binary.BigEndian, tag)                     //nolint:errcheck
+	binary.Write(&buf, binary.BigEndian, uint32(10+len(payload))) //nolint:errcheck
+	binary.Write(&buf, binary.BigEndian, rc)                      //nolint:errcheck
+	buf.Write(payload)
+
+	return buf.Bytes()
+}
+
+func be(v interface{}) []byte {
+	var buf bytes.Buffer
+
+	binary.Write(&buf, binary.BigEndian, v) //nolint:errcheck
+
+	return buf.Bytes()
+}
+
+func sized(b []byte) []byte {
+	return append(be(uint16(len(b))), b...)
+}
+
+func TestQuote(t *testing.T) {
+	quoted := []byte("attest")
+	signature := []byte{0x00, 0x18, 0x00, 0x0b, 0x00, 0x01, 0xaa, 0x00, 0x01, 0xbb}
+	public := []byte("public")
+
+	quoteParams := append(sized(quoted), signature...)
+
+	dev := &fakeDevice{
+		responses: [][]byte{
+			response(0x8002, 0, be(uint32(0x80000001)), be(uint32(2+len(public))), sized(public)),
+			response(0x8002, 0, be(uint32(len(quoteParams))), quoteParams),
+			response(0x8001, 0, be(uint32(1)), be(uint32(1)), be(uint16(0x000b)), []byte{3, 0, 0x80, 0}, be(uint32(1)), sized(bytes.Repeat([]byte{1}, 32))),
+			response(0x8001, 0),
+		},
+	}
+
+	tpm := tpm2.New(dev)
+
+	handle, key, err := tpm.CreateAttestationKey()
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 0x80000001, handle)
+	assert.Equal(t, public, key)
+
+	quote, err := tpm.Quote(handle, []byte("nonce"), []int{0, 7, 23})
+	require.NoError(t, err)
+
+	assert.Equal(t, quoted, quote.Quoted)
+	assert.Equal(t, signature, quote.Signature)
+
+	// quote is authorized with the password session, PCRs 0, 7 and 23 are selected in SHA-256 bank
+	assert.Equal(t, bytes.Join([][]byte{
+		be(uint16(0x8002)), be(uint32(len(dev.commands[1]))), be(uint32(0x158)),
+		be(handle),
+		be(uint32(9)), be(uint32(0x40000009)), be(uint16(0)), {0}, be(uint16(0)),
+		sized([]byte("nonce")),
+		be(uint16(0x0010)),
+		be(uint32(1)), be(uint16(0x000b)), {3, 0x81, 0, 0x80},
+	}, nil), dev.commands[1])
+
+	digest, err := tpm.ReadPCR(7)
+	require.NoError(t, err)
+
+	assert.Equal(t, bytes.Repeat([]byte{1}, 32), digest)
+
+	require.NoError(t, tpm.Flush(handle))
+
+	assert.Equal(t, bytes.Join([][]byte{
+		be(uint16(0x8001)), be(uint32(14)), be(uint32(0x165)), be(handle),
+	}, nil), dev.commands[3])
+}
+
+func TestErrors(t *testing.T) {
+	dev := &fakeDevice{
+		responses: [][]byte{
+			response(0x8001, 0x18b),
+		},
+	}
+
+	tpm := tpm2.New(dev)
+
+	_, _, err := tpm.CreateAttestationKey()
+	assert.EqualError(t, err, "error creating attestation key: TPM error 0x18b")
+
+	_, err = tpm.Quote(0x80000001, nil, []int{24})
+	assert.EqualError(t, err, "invalid PCR index 24")
+}
@@ -0,0 +1,137 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package upgrade keeps track of Talos install, upgrade and rollback history.
+package upgrade
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/distribution/reference"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// MaxHistoryEntries is the number of most recent history entries to keep.
+const MaxHistoryEntries = 32
+
+// History is a list of install, upgrade and rollback events, oldest first.
+type History struct {
+	Entries []runtime.UpgradeHistorySpec `yaml:"entries"`
+}
+
+// LoadHistory reads history from the file.
+//
+// If the file doesn't exist, empty history is returned.
+func LoadHistory(path string) (*History, error) {
+	h := &History{}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+
+	if err = yaml.Unmarshal(b, h); err != nil {
+		return nil, fmt.Errorf("error unmarshaling history: %w", err)
+	}
+
+	return h, nil
+}
+
+// Save writes history to the file.
+func (h *History) Save(path string) error {
+	b, err := yaml.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("error marshaling history: %w", err)
+	}
+
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+
+	if err = ioutil.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("error writing history: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Append adds new entry to the history dropping the oldest entries over the limit.
+func (h *History) Append(entry runtime.UpgradeHistorySpec) {
+	h.Entries = append(h.Entries, entry)
+
+	if len(h.Entries) > MaxHistoryEntries {
+		h.Entries = append([]runtime.UpgradeHistorySpec(nil), h.Entries[len(h.Entries)-MaxHistoryEntries:]...)
+	}
+}
+
+// Resolve sets the outcome of pending entries based on the version Talos booted into.
+//
+// Pending entry is considered successful if the version changed, or if the version matches the installer image tag.
+// Resolve returns true if any entries were updated.
+func (h *History) Resolve(currentVersion string) bool {
+	changed := false
+
+	for i := range h.Entries {
+		entry := &h.Entries[i]
+
+		if entry.Outcome != runtime.UpgradeOutcomePending {
+			continue
+		}
+
+		changed = true
+
+		if currentVersion != entry.FromVersion || imageTag(entry.Image) == currentVersion {
+			entry.Outcome = runtime.UpgradeOutcomeSuccess
+			entry.ToVersion = currentVersion
+		} else {
+			entry.Outcome = runtime.UpgradeOutcomeFailed
+		}
+	}
+
+	return changed
+}
+
+// RecordPending appends a pending entry to the history file.
+//
+// Outcome of the entry is resolved on the next boot.
+func RecordPending(path string, entry runtime.UpgradeHistorySpec) error {
+	h, err := LoadHistory(path)
+	if err != nil {
+		return err
+	}
+
+	entry.Outcome = runtime.UpgradeOutcomePending
+
+	if entry.ToVersion == "" {
+		entry.ToVersion = imageTag(entry.Image)
+	}
+
+	h.Append(entry)
+
+	return h.Save(path)
+}
+
+func imageTag(image string) string {
+	if image == "" {
+		return ""
+	}
+
+	ref, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return ""
+	}
+
+	if tagged, ok := ref.(reference.Tagged); ok {
+		return tagged.Tag()
+	}
+
+	return ""
+}
@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package upgrade_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/upgrade"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.yaml")
+
+	h, err := upgrade.LoadHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, h.Entries)
+
+	ts := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, upgrade.RecordPending(path, runtime.UpgradeHistorySpec{
+		Action:    runtime.UpgradeActionInstall,
+		Image:     "ghcr.io/talos-systems/installer:v0.13.0",
+		Timestamp: ts,
+	}))
+
+	h, err = upgrade.LoadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, h.Entries, 1)
+	assert.Equal(t, runtime.UpgradeOutcomePending, h.Entries[0].Outcome)
+	assert.Equal(t, "v0.13.0", h.Entries[0].ToVersion)
+
+	assert.True(t, h.Resolve("v0.13.0"))
+	assert.False(t, h.Resolve("v0.13.0"))
+	assert.Equal(t, runtime.UpgradeOutcomeSuccess, h.Entries[0].Outcome)
+
+	// upgrade to the same version is successful
+	h.Append(runtime.UpgradeHistorySpec{
+		Action:      runtime.UpgradeActionUpgrade,
+		FromVersion: "v0.13.0",
+		Image:       "ghcr.io/talos-systems/installer:v0.13.0",
+		Outcome:     runtime.UpgradeOutcomePending,
+	})
+
+	// failed upgrade boots into the old version
+	h.Append(runtime.UpgradeHistorySpec{
+		Action:      runtime.UpgradeActionUpgrade,
+		FromVersion: "v0.13.0",
+		ToVersion:   "v0.14.0",
+		Image:       "ghcr.io/talos-systems/installer:v0.14.0",
+		Outcome:     runtime.UpgradeOutcomePending,
+	})
+
+	assert.True(t, h.Resolve("v0.13.0"))
+	assert.Equal(t, runtime.UpgradeOutcomeSuccess, h.Entries[1].Outcome)
+	assert.Equal(t, runtime.UpgradeOutcomeFailed, h.Entries[2].Outcome)
+	assert.Equal(t, "v0.14.0", h.Entries[2].ToVersion)
+
+	// rollback is successful if the version changed
+	h.Append(runtime.UpgradeHistorySpec{
+		Action:      runtime.UpgradeActionRollback,
+		FromVersion: "v0.14.0",
+		Outcome:     runtime.UpgradeOutcomePending,
+	})
+
+	assert.True(t, h.Resolve("v0.13.0"))
+	assert.Equal(t, runtime.UpgradeOutcomeSuccess, h.Entries[3].Outcome)
+	assert.Equal(t, "v0.13.0", h.Entries[3].ToVersion)
+
+	require.NoError(t, h.Save(path))
+
+	h2, err := upgrade.LoadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, h, h2)
+
+	for i := 0; i < upgrade.MaxHistoryEntries; i++ {
+		h.Append(runtime.UpgradeHistorySpec{Action: runtime.UpgradeActionUpgrade})
+	}
+
+	assert.Len(t, h.Entries, upgrade.MaxHistoryEntries)
+	assert.Equal(t, runtime.UpgradeActionUpgrade, h.Entries[0].Action)
+}
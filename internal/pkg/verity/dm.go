@@ -0,0 +1,201 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package verity
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const dmControlPath = "/dev/mapper/control"
+
+// dmVersion is the device-mapper ioctl interface version.
+var dmVersion = [3]uint32{4, 0, 0}
+
+// Status is the dm-verity device status.
+type Status struct {
+	// Corrupted is set if any corrupted block was detected.
+	Corrupted bool
+}
+
+// Open creates a read-only dm-verity device on top of the block device which holds both data and hash tree.
+//
+// Path to the created device is returned.
+func Open(name, devicePath string, params *Params, rootHash []byte) (string, error) {
+	var st unix.Stat_t
+
+	if err := unix.Stat(devicePath, &st); err != nil {
+		return "", fmt.Errorf("error looking up %q: %w", devicePath, err)
+	}
+
+	dev := fmt.Sprintf("%d:%d", unix.Major(st.Rdev), unix.Minor(st.Rdev))
+
+	salt := "-"
+	if len(params.Salt) > 0 {
+		salt = hex.EncodeToString(params.Salt)
+	}
+
+	table := fmt.Sprintf("1 %s %s %d %d %d %d %s %s %s",
+		dev, dev, BlockSize, BlockSize, params.DataBlocks, params.HashStartBlock, Algorithm, hex.EncodeToString(rootHash), salt)
+
+	control, err := os.OpenFile(dmControlPath, os.O_RDWR, 0)
+	if err != nil {
+		return "", fmt.Errorf("error opening device-mapper control: %w", err)
+	}
+
+	defer control.Close() //nolint:errcheck
+
+	hdr, _, err := dmIoctl(control, unix.DM_DEV_CREATE, name, 0, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating device %q: %w", name, err)
+	}
+
+	target := unix.DmTargetSpec{
+		Sector_start: 0,
+		Length:       params.DataBlocks * BlockSize / 512,
+	}
+
+	copy(target.Target_type[:], "verity")
+
+	var payload bytes.Buffer
+
+	if err = binary.Write(&payload, binary.LittleEndian, &target); err != nil {
+		return "", err
+	}
+
+	payload.WriteString(table)
+	payload.WriteByte(0)
+
+	// target parameters are padded to 8 bytes
+	for payload.Len()%8 != 0 {
+		payload.WriteByte(0)
+	}
+
+	if _, _, err = dmIoctl(control, unix.DM_TABLE_LOAD, name, unix.DM_READONLY_FLAG, payload.Bytes()); err != nil {
+		dmIoctl(control, unix.DM_DEV_REMOVE, name, 0, nil) //nolint:errcheck
+
+		return "", fmt.Errorf("error loading device %q table: %w", name, err)
+	}
+
+	// "suspend" without the suspend flag resumes the device activating the table
+	if _, _, err = dmIoctl(control, unix.DM_DEV_SUSPEND, name, 0, nil); err != nil {
+		dmIoctl(control, unix.DM_DEV_REMOVE, name, 0, nil) //nolint:errcheck
+
+		return "", fmt.Errorf("error activating device %q: %w", name, err)
+	}
+
+	// there is no udev in the initramfs, but devtmpfs creates the dm-N node
+	return fmt.Sprintf("/dev/dm-%d", unix.Minor(hdr.Dev)), nil
+}
+
+// DeviceStatus returns the status of the dm-verity device.
+//
+// If the device doesn't exist, nil status is returned.
+func DeviceStatus(name string) (*Status, error) {
+	control, err := os.OpenFile(dmControlPath, os.O_RDWR, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error opening device-mapper control: %w", err)
+	}
+
+	defer control.Close() //nolint:errcheck
+
+	hdr, data, err := dmIoctl(control, unix.DM_TABLE_STATUS, name, 0, nil)
+	if err != nil {
+		if errors.Is(err, unix.ENXIO) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error getting device %q status: %w", name, err)
+	}
+
+	if hdr.Target_count != 1 || len(data) < unix.SizeofDmTargetSpec {
+		return nil, fmt.Errorf("unexpected device %q status", name)
+	}
+
+	var target unix.DmTargetSpec
+
+	if err = binary.Read(bytes.NewReader(data), binary.LittleEndian, &target); err != nil {
+		return nil, err
+	}
+
+	if string(bytes.TrimRight(target.Target_type[:], "\x00")) != "verity" {
+		return nil, fmt.Errorf("device %q is not a verity device", name)
+	}
+
+	status := data[unix.SizeofDmTargetSpec:]
+	if idx := bytes.IndexByte(status, 0); idx >= 0 {
+		status = status[:idx]
+	}
+
+	// verity target status is "V" for verified, "C" if the corruption was detected
+	return &Status{
+		Corrupted: bytes.HasPrefix(status, []byte("C")),
+	}, nil
+}
+
+// dmIoctl issues device-mapper ioctl with the payload and returns the response header and payload.
+func dmIoctl(control *os.File, cmd uintptr, name string, flags uint32, payload []byte) (*unix.DmIoctl, []byte, error) {
+	// responses might be longer than the request, so reserve some space
+	size := unix.SizeofDmIoctl + len(payload)
+	if size < 16384 {
+		size = 16384
+	}
+
+	hdr := unix.DmIoctl{
+		Version:      dmVersion,
+		Data_size:    uint32(size),
+		Data_start:   unix.SizeofDmIoctl,
+		Target_count: 0,
+		Flags:        flags,
+	}
+
+	if payload != nil {
+		hdr.Target_count = 1
+	}
+
+	if len(name) >= len(hdr.Name) {
+		return nil, nil, fmt.Errorf("device name %q is too long", name)
+	}
+
+	copy(hdr.Name[:], name)
+
+	var buf bytes.Buffer
+
+	if err := binary.Write(&buf, binary.LittleEndian, &hdr); err != nil {
+		return nil, nil, err
+	}
+
+	buf.Write(payload)
+
+	b := make([]byte, size)
+	copy(b, buf.Bytes())
+
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, control.Fd(), cmd, uintptr(unsafe.Pointer(&b[0]))); errno != 0 {
+		return nil, nil, errno
+	}
+
+	var resp unix.DmIoctl
+
+	if err := binary.Read(bytes.NewReader(b), binary.LittleEndian, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	if resp.Data_start > resp.Data_size || int(resp.Data_size) > len(b) {
+		return nil, nil, errors.New("malformed device-mapper response")
+	}
+
+	return &resp, b[resp.Data_start:resp.Data_size], nil
+}
@@ -0,0 +1,229 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package verity implements dm-verity hash tree generation and device setup.
+//
+// Hash tree format is compatible with `veritysetup format` (format version 1, sha256, 4096 byte blocks):
+// the superblock is written at the hash offset, followed by the hash tree levels (top level first).
+package verity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// BlockSize is the data and hash block size.
+	BlockSize = 4096
+
+	// Algorithm is the hash algorithm.
+	Algorithm = "sha256"
+
+	superblockSize = 512
+	hashType       = 1
+	maxSaltSize    = 256
+)
+
+var superblockSignature = [8]byte{'v', 'e', 'r', 'i', 't', 'y'}
+
+// Params describes the verity hash tree.
+type Params struct {
+	// DataBlocks is the number of data blocks covered by the hash tree.
+	DataBlocks uint64
+	// HashStartBlock is the first block of the hash tree (right after the superblock).
+	HashStartBlock uint64
+	Salt           []byte
+}
+
+type superblock struct {
+	Signature     [8]byte
+	Version       uint32
+	HashType      uint32
+	UUID          [16]byte
+	Algorithm     [32]byte
+	DataBlockSize uint32
+	HashBlockSize uint32
+	DataBlocks    uint64
+	SaltSize      uint16
+	_             [6]byte
+	Salt          [maxSaltSize]byte
+	_             [168]byte
+}
+
+// ReaderWriterAt is the file which holds the data and the hash tree.
+type ReaderWriterAt interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// Format writes the superblock and the hash tree for the first dataSize bytes at the dataSize offset.
+//
+// The root hash of the tree is returned.
+func Format(f ReaderWriterAt, dataSize int64, salt []byte) ([]byte, *Params, error) {
+	if dataSize == 0 || dataSize%BlockSize != 0 {
+		return nil, nil, fmt.Errorf("data size %d should be a positive multiple of %d", dataSize, BlockSize)
+	}
+
+	if len(salt) > maxSaltSize {
+		return nil, nil, fmt.Errorf("salt is too long: %d bytes", len(salt))
+	}
+
+	params := &Params{
+		DataBlocks:     uint64(dataSize / BlockSize),
+		HashStartBlock: uint64(dataSize/BlockSize) + 1,
+		Salt:           salt,
+	}
+
+	sb := superblock{
+		Signature:     superblockSignature,
+		Version:       1,
+		HashType:      hashType,
+		DataBlockSize: BlockSize,
+		HashBlockSize: BlockSize,
+		DataBlocks:    params.DataBlocks,
+		SaltSize:      uint16(len(salt)),
+	}
+
+	copy(sb.Algorithm[:], Algorithm)
+	copy(sb.Salt[:], salt)
+
+	// UUID is derived from the salt, so that the output is reproducible
+	uuid := sha256.Sum256(salt)
+	copy(sb.UUID[:], uuid[:])
+	sb.UUID[6] = (sb.UUID[6] & 0x0f) | 0x40
+	sb.UUID[8] = (sb.UUID[8] & 0x3f) | 0x80
+
+	var buf bytes.Buffer
+
+	if err := binary.Write(&buf, binary.LittleEndian, &sb); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := f.WriteAt(buf.Bytes(), dataSize); err != nil {
+		return nil, nil, fmt.Errorf("error writing superblock: %w", err)
+	}
+
+	levels, err := hashLevels(f, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// levels are stored top level first, and the superblock takes the first hash block
+	offset := int64(params.HashStartBlock) * BlockSize
+
+	for i := len(levels) - 1; i >= 0; i-- {
+		if _, err = f.WriteAt(levels[i], offset); err != nil {
+			return nil, nil, fmt.Errorf("error writing hash tree: %w", err)
+		}
+
+		offset += int64(len(levels[i]))
+	}
+
+	if len(levels) == 0 {
+		// single data block, root hash is the hash of the data block
+		block := make([]byte, BlockSize)
+
+		if _, err = f.ReadAt(block, 0); err != nil {
+			return nil, nil, fmt.Errorf("error reading data: %w", err)
+		}
+
+		return hashBlock(salt, block), params, nil
+	}
+
+	return hashBlock(salt, levels[len(levels)-1]), params, nil
+}
+
+// hashLevels calculates hash tree levels starting with the level hashing the data blocks.
+func hashLevels(r io.ReaderAt, params *Params) ([][]byte, error) {
+	var levels [][]byte
+
+	block := make([]byte, BlockSize)
+	blocks := params.DataBlocks
+
+	readBlock := func(i uint64) ([]byte, error) {
+		if len(levels) == 0 {
+			if _, err := r.ReadAt(block, int64(i)*BlockSize); err != nil {
+				return nil, fmt.Errorf("error reading data: %w", err)
+			}
+
+			return block, nil
+		}
+
+		prev := levels[len(levels)-1]
+
+		return prev[i*BlockSize : (i+1)*BlockSize], nil
+	}
+
+	for blocks > 1 {
+		hashesPerBlock := uint64(BlockSize / sha256.Size)
+		level := make([]byte, (blocks+hashesPerBlock-1)/hashesPerBlock*BlockSize)
+
+		for i := uint64(0); i < blocks; i++ {
+			b, err := readBlock(i)
+			if err != nil {
+				return nil, err
+			}
+
+			copy(level[i*sha256.Size:], hashBlock(params.Salt, b))
+		}
+
+		levels = append(levels, level)
+		blocks = uint64(len(level) / BlockSize)
+	}
+
+	return levels, nil
+}
+
+func hashBlock(salt, block []byte) []byte {
+	h := sha256.New()
+	h.Write(salt)  //nolint:errcheck
+	h.Write(block) //nolint:errcheck
+
+	return h.Sum(nil)
+}
+
+// ErrSuperblockNotFound is returned if there is no verity superblock at the hash offset.
+var ErrSuperblockNotFound = errors.New("verity superblock not found")
+
+// ReadParams reads the verity superblock at the hash offset.
+func ReadParams(r io.ReaderAt, hashOffset int64) (*Params, error) {
+	if hashOffset%BlockSize != 0 {
+		return nil, fmt.Errorf("hash offset %d is not aligned to %d", hashOffset, BlockSize)
+	}
+
+	buf := make([]byte, superblockSize)
+
+	if _, err := r.ReadAt(buf, hashOffset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrSuperblockNotFound
+		}
+
+		return nil, fmt.Errorf("error reading superblock: %w", err)
+	}
+
+	var sb superblock
+
+	if err := binary.Read(bytes.NewReader(buf), binary.LittleEndian, &sb); err != nil {
+		return nil, err
+	}
+
+	if sb.Signature != superblockSignature {
+		return nil, ErrSuperblockNotFound
+	}
+
+	if sb.Version != 1 || sb.HashType != hashType || sb.DataBlockSize != BlockSize || sb.HashBlockSize != BlockSize ||
+		string(bytes.TrimRight(sb.Algorithm[:], "\x00")) != Algorithm || sb.SaltSize > maxSaltSize {
+		return nil, errors.New("unsupported verity superblock")
+	}
+
+	return &Params{
+		DataBlocks:     sb.DataBlocks,
+		HashStartBlock: uint64(hashOffset)/BlockSize + 1,
+		Salt:           append([]byte(nil), sb.Salt[:sb.SaltSize]...),
+	}, nil
+}
@@ -0,0 +1,139 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package verity_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/internal/pkg/verity"
+)
+
+func hash(salt, block []byte) []byte {
+	h := sha256.Sum256(append(append([]byte(nil), salt...), block...))
+
+	return h[:]
+}
+
+func TestFormat(t *testing.T) {
+	salt := []byte("salt")
+
+	for _, test := range []struct {
+		name   string
+		blocks int
+		// number of hash blocks in each level, top level first
+		levels []int
+	}{
+		{
+			name:   "single block",
+			blocks: 1,
+		},
+		{
+			name:   "single level",
+			blocks: 2,
+			levels: []int{1},
+		},
+		{
+			name:   "two levels",
+			blocks: 130,
+			levels: []int{1, 2},
+		},
+	} {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Create(filepath.Join(t.TempDir(), "rootfs.sqsh"))
+			require.NoError(t, err)
+
+			defer f.Close() //nolint:errcheck
+
+			data := make([]byte, test.blocks*verity.BlockSize)
+			for i := range data {
+				data[i] = byte(i / verity.BlockSize)
+			}
+
+			_, err = f.Write(data)
+			require.NoError(t, err)
+
+			rootHash, params, err := verity.Format(f, int64(len(data)), salt)
+			require.NoError(t, err)
+
+			assert.EqualValues(t, test.blocks, params.DataBlocks)
+			assert.EqualValues(t, test.blocks+1, params.HashStartBlock)
+
+			readParams, err := verity.ReadParams(f, int64(len(data)))
+			require.NoError(t, err)
+			assert.Equal(t, params, readParams)
+
+			if len(test.levels) == 0 {
+				assert.Equal(t, hash(salt, data), rootHash)
+
+				return
+			}
+
+			// walk the tree from the root down to the data blocks
+			offset := int64(params.HashStartBlock) * verity.BlockSize
+
+			top := make([]byte, verity.BlockSize)
+			_, err = f.ReadAt(top, offset)
+			require.NoError(t, err)
+
+			assert.Equal(t, hash(salt, top), rootHash)
+
+			for i := range test.levels {
+				level := make([]byte, test.levels[i]*verity.BlockSize)
+				_, err = f.ReadAt(level, offset)
+				require.NoError(t, err)
+
+				offset += int64(len(level))
+
+				var children []byte
+
+				if i == len(test.levels)-1 {
+					children = data
+				} else {
+					children = make([]byte, test.levels[i+1]*verity.BlockSize)
+					_, err = f.ReadAt(children, offset)
+					require.NoError(t, err)
+				}
+
+				for j := 0; j < len(children)/verity.BlockSize; j++ {
+					assert.Equal(t, hash(salt, children[j*verity.BlockSize:(j+1)*verity.BlockSize]), level[j*sha256.Size:(j+1)*sha256.Size])
+				}
+
+				// unused hashes are zeroed
+				assert.Equal(t, bytes.Repeat([]byte{0}, len(level)-len(children)/verity.BlockSize*sha256.Size), level[len(children)/verity.BlockSize*sha256.Size:])
+			}
+		})
+	}
+}
+
+func TestFormatUnaligned(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "rootfs.sqsh"))
+	require.NoError(t, err)
+
+	defer f.Close() //nolint:errcheck
+
+	_, _, err = verity.Format(f, 1000, nil)
+	assert.Error(t, err)
+}
+
+func TestReadParamsNotFound(t *testing.T) {
+	data := make([]byte, 2*verity.BlockSize)
+
+	// no hash tree appended
+	_, err := verity.ReadParams(bytes.NewReader(data), verity.BlockSize*2)
+	assert.ErrorIs(t, err, verity.ErrSuperblockNotFound)
+
+	// no verity superblock at the hash offset
+	_, err = verity.ReadParams(bytes.NewReader(data), verity.BlockSize)
+	assert.ErrorIs(t, err, verity.ErrSuperblockNotFound)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/emicklei/dot"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/talos-systems/talos/pkg/machinery/api/inspect"
 	"github.com/talos-systems/talos/pkg/machinery/api/machine"
@@ -193,11 +194,146 @@ func RenderGraph(ctx context.Context, c *client.Client, resp *inspect.Controller
 		}
 	}
 
+	for _, msg := range resp.GetMessages() {
+		for _, status := range msg.GetControllers() {
+			node := graph.Node(status.GetControllerName()).Box()
+
+			color := controllerHealthColor(status)
+			if color == "" {
+				continue
+			}
+
+			node.Attr("fillcolor", color).Attr("style", "filled")
+
+			if status.GetCrashes() > 0 {
+				node.Label(fmt.Sprintf("%s\ncrashes: %d", status.GetControllerName(), status.GetCrashes()))
+			}
+
+			if status.GetLastError() != "" {
+				node.Attr("tooltip", status.GetLastError())
+			}
+		}
+	}
+
 	graph.Write(output)
 
 	return nil
 }
 
+// controllerHealthColor returns the color of the controller node based on its health, empty for healthy controllers.
+func controllerHealthColor(status *inspect.ControllerStatus) string {
+	switch {
+	case !status.GetRunning():
+		return "lightcoral"
+	case status.GetCrashes() > 0, status.GetLastError() != "":
+		return "orange"
+	default:
+		return ""
+	}
+}
+
+// RenderGraphJSON renders inspect controller runtime graph with the controller health as JSON.
+func RenderGraphJSON(resp *inspect.ControllerRuntimeDependenciesResponse, output io.Writer) error {
+	for _, msg := range resp.GetMessages() {
+		b, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(msg)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(output, "%s\n", b)
+	}
+
+	return nil
+}
+
+// mermaidHealthClasses maps controller health colors to the mermaid node classes.
+var mermaidHealthClasses = map[string]string{
+	"lightcoral": "crashed",
+	"orange":     "degraded",
+}
+
+// RenderGraphMermaid renders inspect controller runtime graph as mermaid flowchart.
+//
+//nolint:gocyclo
+func RenderGraphMermaid(resp *inspect.ControllerRuntimeDependenciesResponse, output io.Writer) error {
+	var sb strings.Builder
+
+	// mermaid node IDs can't contain dots and slashes found in the resource types, so nodes are numbered
+	ids := map[string]string{}
+
+	var resourceIDs []string
+
+	node := func(kind, name, shape string) string {
+		key := kind + ":" + name
+
+		if id, ok := ids[key]; ok {
+			return id
+		}
+
+		id := fmt.Sprintf("%s%d", kind, len(ids))
+		ids[key] = id
+
+		if kind == "r" {
+			resourceIDs = append(resourceIDs, id)
+		}
+
+		fmt.Fprintf(&sb, "    %s%s\n", id, fmt.Sprintf(shape, strings.ReplaceAll(name, `"`, "#quot;")))
+
+		return id
+	}
+
+	controller := func(name string) string { return node("c", name, `["%s"]`) }
+	resourceType := func(name string) string { return node("r", name, `[/"%s"/]`) }
+
+	sb.WriteString("flowchart LR\n")
+
+	for _, msg := range resp.GetMessages() {
+		for _, edge := range msg.GetEdges() {
+			from, to := controller(edge.GetControllerName()), resourceType(edge.GetResourceType())
+
+			label := ""
+			if edge.GetResourceId() != "" {
+				label = fmt.Sprintf("|%q|", edge.GetResourceId())
+			}
+
+			switch edge.GetEdgeType() {
+			case inspect.DependencyEdgeType_OUTPUT_EXCLUSIVE:
+				fmt.Fprintf(&sb, "    %s ==> %s\n", from, to)
+			case inspect.DependencyEdgeType_OUTPUT_SHARED:
+				fmt.Fprintf(&sb, "    %s --> %s\n", from, to)
+			case inspect.DependencyEdgeType_INPUT_STRONG:
+				fmt.Fprintf(&sb, "    %s -->%s %s\n", to, label, from)
+			case inspect.DependencyEdgeType_INPUT_WEAK:
+				fmt.Fprintf(&sb, "    %s -.->%s %s\n", to, label, from)
+			case inspect.DependencyEdgeType_INPUT_DESTROY_READY:
+				// don't show the DestroyReady inputs to reduce the visual clutter
+			}
+		}
+	}
+
+	sb.WriteString("    classDef resource fill:azure\n")
+	sb.WriteString("    classDef crashed fill:lightcoral\n")
+	sb.WriteString("    classDef degraded fill:orange\n")
+
+	if len(resourceIDs) > 0 {
+		fmt.Fprintf(&sb, "    class %s resource\n", strings.Join(resourceIDs, ","))
+	}
+
+	for _, msg := range resp.GetMessages() {
+		for _, status := range msg.GetControllers() {
+			id := controller(status.GetControllerName())
+
+			if class, ok := mermaidHealthClasses[controllerHealthColor(status)]; ok {
+				fmt.Fprintf(&sb, "    class %s %s\n", id, class)
+			}
+		}
+	}
+
+	_, err := io.WriteString(output, sb.String())
+
+	return err
+}
+
 // RenderServicesInfo writes human readable service information to the io.Writer.
 func RenderServicesInfo(services []client.ServiceInfo, output io.Writer, defaultNode string, withNodeInfo bool) error {
 	w := tabwriter.NewWriter(output, 0, 0, 3, ' ', 0)
@@ -109,5 +109,12 @@ func DefaultClusterChecks() []ClusterCheck {
 //
 // ExtraClusterChecks can't be used reliably in upgrade tests, as older versions might not pass the checks.
 func ExtraClusterChecks() []ClusterCheck {
-	return []ClusterCheck{}
+	return []ClusterCheck{
+		// wait for the user-defined health checks to be healthy on all the nodes
+		func(cluster ClusterInfo) conditions.Condition {
+			return conditions.PollingCondition("user-defined health checks to be healthy", func(ctx context.Context) error {
+				return HealthChecksAssertion(ctx, cluster)
+			}, 5*time.Minute, 5*time.Second)
+		},
+	}
 }
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/codes"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+	"github.com/talos-systems/talos/pkg/machinery/resources/runtime"
+)
+
+// HealthChecksAssertion checks whether the user-defined health checks from the machine config are healthy on all the nodes.
+func HealthChecksAssertion(ctx context.Context, cluster ClusterInfo) error {
+	cli, err := cluster.Client()
+	if err != nil {
+		return err
+	}
+
+	nodesCtx := client.WithNodes(ctx, cluster.Nodes()...)
+
+	listClient, err := cli.Resources.List(nodesCtx, runtime.NamespaceName, runtime.HealthCheckStatusType)
+	if err != nil {
+		return err
+	}
+
+	var multiErr *multierror.Error
+
+	for {
+		msg, err := listClient.Recv()
+		if err != nil {
+			if err == io.EOF || client.StatusCode(err) == codes.Canceled {
+				break
+			}
+
+			return err
+		}
+
+		node := msg.Metadata.GetHostname()
+
+		if msg.Metadata.GetError() != "" {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("%s: %s", node, msg.Metadata.GetError()))
+
+			continue
+		}
+
+		if msg.Resource == nil {
+			continue
+		}
+
+		var spec runtime.HealthCheckStatusSpec
+
+		// resource spec is decoded via YAML, as the API returns generic resources
+		b, err := yaml.Marshal(msg.Resource.Spec())
+		if err != nil {
+			return err
+		}
+
+		if err = yaml.Unmarshal(b, &spec); err != nil {
+			return err
+		}
+
+		if !spec.Healthy {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("%s: health check %q is unhealthy: %s", node, msg.Resource.Metadata().ID(), spec.Message))
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
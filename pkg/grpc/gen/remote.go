@@ -13,6 +13,8 @@ import (
 	"github.com/talos-systems/crypto/x509"
 	"github.com/talos-systems/go-retry/retry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/basic"
 	securityapi "github.com/talos-systems/talos/pkg/machinery/api/security"
@@ -30,6 +32,9 @@ func init() {
 type RemoteGenerator struct {
 	conn   *grpc.ClientConn
 	client securityapi.SecurityServiceClient
+
+	instanceIdentityPlatform string
+	instanceIdentityDocument []byte
 }
 
 // NewRemoteGenerator initializes a RemoteGenerator with a preconfigured grpc.ClientConn.
@@ -51,6 +56,12 @@ func NewRemoteGenerator(token string, endpoints []string, ca *x509.PEMEncodedCer
 	return g, nil
 }
 
+// SetInstanceIdentity attaches the platform instance identity document to the certificate requests.
+func (g *RemoteGenerator) SetInstanceIdentity(platform string, document []byte) {
+	g.instanceIdentityPlatform = platform
+	g.instanceIdentityDocument = document
+}
+
 // Identity creates an identity certificate via the security API.
 func (g *RemoteGenerator) Identity(csr *x509.CertificateSigningRequest) (ca, crt []byte, err error) {
 	return g.IdentityContext(context.Background(), csr)
@@ -58,13 +69,41 @@ func (g *RemoteGenerator) Identity(csr *x509.CertificateSigningRequest) (ca, crt
 
 // IdentityContext creates an identity certificate via the security API.
 func (g *RemoteGenerator) IdentityContext(ctx context.Context, csr *x509.CertificateSigningRequest) (ca, crt []byte, err error) {
+	return g.sign(ctx, g.client.Certificate, csr)
+}
+
+// KubeletIdentityContext creates a kubelet client certificate signed by the Kubernetes CA via the security API.
+func (g *RemoteGenerator) KubeletIdentityContext(ctx context.Context, csr *x509.CertificateSigningRequest) (ca, crt []byte, err error) {
+	return g.sign(ctx, g.client.KubeletCertificate, csr)
+}
+
+// KubeletBootstrapTokenContext fetches the short-lived kubelet bootstrap token via the security API.
+func (g *RemoteGenerator) KubeletBootstrapTokenContext(ctx context.Context) (token string, expiration time.Time, err error) {
+	resp, err := g.client.KubeletBootstrapToken(ctx, &securityapi.KubeletBootstrapTokenRequest{
+		InstanceIdentityPlatform: g.instanceIdentityPlatform,
+		InstanceIdentityDocument: g.instanceIdentityDocument,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return resp.Token, resp.Expiration.AsTime(), nil
+}
+
+type signFunc func(ctx context.Context, in *securityapi.CertificateRequest, opts ...grpc.CallOption) (*securityapi.CertificateResponse, error)
+
+func (g *RemoteGenerator) sign(ctx context.Context, f signFunc, csr *x509.CertificateSigningRequest) (ca, crt []byte, err error) {
 	req := &securityapi.CertificateRequest{
-		Csr: csr.X509CertificateRequestPEM,
+		Csr:                      csr.X509CertificateRequestPEM,
+		InstanceIdentityPlatform: g.instanceIdentityPlatform,
+		InstanceIdentityDocument: g.instanceIdentityDocument,
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
+	var rejectErr error
+
 	err = retry.Exponential(time.Minute,
 		retry.WithAttemptTimeout(10*time.Second),
 		retry.WithUnits(time.Second),
@@ -72,9 +111,17 @@ func (g *RemoteGenerator) IdentityContext(ctx context.Context, csr *x509.Certifi
 	).RetryWithContext(ctx, func(ctx context.Context) error {
 		var resp *securityapi.CertificateResponse
 
-		resp, err = g.client.Certificate(ctx, req)
+		resp, err = f(ctx, req)
 		if err != nil {
-			return retry.ExpectedError(err)
+			switch status.Code(err) { //nolint:exhaustive
+			case codes.FailedPrecondition, codes.PermissionDenied:
+				// the request is rejected, so it's not retried, and the status is returned as is
+				rejectErr = err
+
+				return err
+			default:
+				return retry.ExpectedError(err)
+			}
 		}
 
 		ca = resp.Ca
@@ -83,6 +130,10 @@ func (g *RemoteGenerator) IdentityContext(ctx context.Context, csr *x509.Certifi
 		return nil
 	})
 
+	if rejectErr != nil {
+		return nil, nil, rejectErr
+	}
+
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,195 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubernetes
+
+import (
+	"context"
+	stdx509 "crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const nodeUserPrefix = "system:node:"
+
+// ApproveKubeletServingCSRs approves pending kubelet serving certificate signing requests.
+//
+// Only the requests matching the addresses of the registered requesting node are approved,
+// other requests are left pending.
+func (h *Client) ApproveKubeletServingCSRs(ctx context.Context) (approved []string, err error) {
+	csrs, err := h.CertificatesV1().CertificateSigningRequests().List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.signerName", certificatesv1.KubeletServingSignerName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing certificate signing requests: %w", err)
+	}
+
+	for i := range csrs.Items {
+		csr := &csrs.Items[i]
+
+		if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName || csrProcessed(csr) {
+			continue
+		}
+
+		if !strings.HasPrefix(csr.Spec.Username, nodeUserPrefix) {
+			continue
+		}
+
+		node, err := h.CoreV1().Nodes().Get(ctx, strings.TrimPrefix(csr.Spec.Username, nodeUserPrefix), metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return approved, fmt.Errorf("error getting node: %w", err)
+		}
+
+		if err = VerifyKubeletServingCSR(csr, node); err != nil {
+			continue
+		}
+
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:           certificatesv1.CertificateApproved,
+			Status:         corev1.ConditionTrue,
+			Reason:         "TalosKubeletServingApprove",
+			Message:        "kubelet serving certificate request matches the node addresses",
+			LastUpdateTime: metav1.Now(),
+		})
+
+		if _, err = h.CertificatesV1().CertificateSigningRequests().UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+			if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return approved, fmt.Errorf("error approving certificate signing request %q: %w", csr.Name, err)
+		}
+
+		approved = append(approved, csr.Name)
+	}
+
+	return approved, nil
+}
+
+// VerifyKubeletServingCSR checks that the kubelet serving certificate signing request was issued by the node
+// and requests a certificate only for the node addresses.
+//
+//nolint:gocyclo,cyclop
+func VerifyKubeletServingCSR(csr *certificatesv1.CertificateSigningRequest, node *corev1.Node) error {
+	if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName {
+		return fmt.Errorf("unexpected signer %q", csr.Spec.SignerName)
+	}
+
+	username := nodeUserPrefix + node.Name
+
+	if csr.Spec.Username != username {
+		return fmt.Errorf("request is issued by %q, expected %q", csr.Spec.Username, username)
+	}
+
+	if !containsString(csr.Spec.Groups, "system:nodes") {
+		return fmt.Errorf("request is not issued by a member of system:nodes")
+	}
+
+	for _, usage := range csr.Spec.Usages {
+		switch usage { //nolint:exhaustive
+		case certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment, certificatesv1.UsageServerAuth:
+		default:
+			return fmt.Errorf("unexpected usage %q", usage)
+		}
+	}
+
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return fmt.Errorf("request is not a PEM-encoded certificate request")
+	}
+
+	request, err := stdx509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("error parsing certificate request: %w", err)
+	}
+
+	if request.Subject.CommonName != username {
+		return fmt.Errorf("unexpected common name %q", request.Subject.CommonName)
+	}
+
+	if len(request.Subject.Organization) != 1 || request.Subject.Organization[0] != "system:nodes" {
+		return fmt.Errorf("unexpected organization %q", request.Subject.Organization)
+	}
+
+	if len(request.EmailAddresses) > 0 || len(request.URIs) > 0 {
+		return fmt.Errorf("email and URI subject alternative names are not allowed")
+	}
+
+	if len(request.IPAddresses) == 0 && len(request.DNSNames) == 0 {
+		return fmt.Errorf("request doesn't contain subject alternative names")
+	}
+
+	var (
+		ips      []net.IP
+		dnsNames []string
+	)
+
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+			if ip := net.ParseIP(address.Address); ip != nil {
+				ips = append(ips, ip)
+			}
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			dnsNames = append(dnsNames, address.Address)
+		}
+	}
+
+	for _, ip := range request.IPAddresses {
+		if !containsIP(ips, ip) {
+			return fmt.Errorf("IP address %s is not registered for the node", ip)
+		}
+	}
+
+	for _, dnsName := range request.DNSNames {
+		if !containsString(dnsNames, dnsName) {
+			return fmt.Errorf("DNS name %q is not registered for the node", dnsName)
+		}
+	}
+
+	return request.CheckSignature()
+}
+
+func csrProcessed(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		switch condition.Type { //nolint:exhaustive
+		case certificatesv1.CertificateApproved, certificatesv1.CertificateDenied, certificatesv1.CertificateFailed:
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsIP(list []net.IP, ip net.IP) bool {
+	for _, item := range list {
+		if item.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubernetes_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/talos-systems/talos/pkg/kubernetes"
+)
+
+func kubeletServingCSR(t *testing.T, commonName string, ips []net.IP, dnsNames []string) *certificatesv1.CertificateSigningRequest {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := stdx509.CreateCertificateRequest(rand.Reader, &stdx509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{"system:nodes"},
+		},
+		IPAddresses: ips,
+		DNSNames:    dnsNames,
+	}, key)
+	require.NoError(t, err)
+
+	return &certificatesv1.CertificateSigningRequest{
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+			SignerName: certificatesv1.KubeletServingSignerName,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageServerAuth,
+			},
+			Username: "system:node:worker-1",
+			Groups:   []string{"system:nodes", "system:authenticated"},
+		},
+	}
+}
+
+func TestVerifyKubeletServingCSR(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-1",
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "172.20.0.2"},
+				{Type: corev1.NodeInternalIP, Address: "fd00::2"},
+				{Type: corev1.NodeHostName, Address: "worker-1"},
+			},
+		},
+	}
+
+	csr := kubeletServingCSR(t, "system:node:worker-1",
+		[]net.IP{net.ParseIP("172.20.0.2"), net.ParseIP("fd00:0:0::2")},
+		[]string{"worker-1"},
+	)
+
+	assert.NoError(t, kubernetes.VerifyKubeletServingCSR(csr, node))
+
+	for _, tt := range []struct {
+		name          string
+		csr           *certificatesv1.CertificateSigningRequest
+		expectedError string
+	}{
+		{
+			name:          "unknown IP",
+			csr:           kubeletServingCSR(t, "system:node:worker-1", []net.IP{net.ParseIP("10.5.0.1")}, nil),
+			expectedError: "IP address 10.5.0.1 is not registered for the node",
+		},
+		{
+			name:          "unknown DNS name",
+			csr:           kubeletServingCSR(t, "system:node:worker-1", nil, []string{"kubernetes.default"}),
+			expectedError: "DNS name \"kubernetes.default\" is not registered for the node",
+		},
+		{
+			name:          "other node",
+			csr:           kubeletServingCSR(t, "system:node:worker-2", nil, []string{"worker-1"}),
+			expectedError: "unexpected common name \"system:node:worker-2\"",
+		},
+		{
+			name: "client usage",
+			csr: func() *certificatesv1.CertificateSigningRequest {
+				csr := kubeletServingCSR(t, "system:node:worker-1", nil, []string{"worker-1"})
+				csr.Spec.Usages = append(csr.Spec.Usages, certificatesv1.UsageClientAuth)
+
+				return csr
+			}(),
+			expectedError: "unexpected usage \"client auth\"",
+		},
+		{
+			name: "other requestor",
+			csr: func() *certificatesv1.CertificateSigningRequest {
+				csr := kubeletServingCSR(t, "system:node:worker-1", nil, []string{"worker-1"})
+				csr.Spec.Username = "system:serviceaccount:default:default"
+
+				return csr
+			}(),
+			expectedError: "request is issued by \"system:serviceaccount:default:default\", expected \"system:node:worker-1\"",
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, kubernetes.VerifyKubeletServingCSR(tt.csr, node), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kubernetes
+
+import (
+	"sort"
+	"sync"
+)
+
+// DrainProgress describes the progress of the node drain.
+type DrainProgress struct {
+	// Pods which are not evicted yet, as `<namespace>/<name>`.
+	Remaining []string
+	// Pods which can't be evicted at the moment because of the pod disruption budgets.
+	Blocked []string
+}
+
+// DrainProgressFunc is called on every change of the node drain progress.
+type DrainProgressFunc func(DrainProgress)
+
+// drainTracker tracks the pods being evicted concurrently.
+type drainTracker struct {
+	mu sync.Mutex
+
+	remaining map[string]struct{}
+	blocked   map[string]struct{}
+
+	progress DrainProgressFunc
+}
+
+func newDrainTracker(pods []string, progress DrainProgressFunc) *drainTracker {
+	t := &drainTracker{
+		remaining: make(map[string]struct{}, len(pods)),
+		blocked:   map[string]struct{}{},
+		progress:  progress,
+	}
+
+	for _, pod := range pods {
+		t.remaining[pod] = struct{}{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.report()
+
+	return t
+}
+
+// setBlocked marks the pod eviction as blocked (or unblocked) by the pod disruption budget.
+func (t *drainTracker) setBlocked(pod string, blocked bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, wasBlocked := t.blocked[pod]
+	if wasBlocked == blocked {
+		return
+	}
+
+	if blocked {
+		t.blocked[pod] = struct{}{}
+	} else {
+		delete(t.blocked, pod)
+	}
+
+	t.report()
+}
+
+// evicted marks the pod as evicted.
+func (t *drainTracker) evicted(pod string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.remaining, pod)
+	delete(t.blocked, pod)
+
+	t.report()
+}
+
+func (t *drainTracker) report() {
+	if t.progress == nil {
+		return
+	}
+
+	t.progress(DrainProgress{
+		Remaining: sortedPods(t.remaining),
+		Blocked:   sortedPods(t.blocked),
+	})
+}
+
+func sortedPods(pods map[string]struct{}) []string {
+	result := make([]string, 0, len(pods))
+
+	for pod := range pods {
+		result = append(result, pod)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -29,9 +29,9 @@ type Client struct {
 }
 
 // NewClient creates new kubelet API client.
-func NewClient(nodename string, clientCert, clientKey, caPEM []byte) (*Client, error) {
+func NewClient(nodename string, port int, clientCert, clientKey, caPEM []byte) (*Client, error) {
 	config := &rest.Config{
-		Host: fmt.Sprintf("https://127.0.0.1:%d/", constants.KubeletPort),
+		Host: fmt.Sprintf("https://127.0.0.1:%d/", port),
 		ContentConfig: rest.ContentConfig{
 			NegotiatedSerializer: serializer.WithoutConversionCodecFactory{CodecFactory: scheme.Codecs},
 		},
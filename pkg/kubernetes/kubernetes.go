@@ -12,6 +12,8 @@ import (
 	"log"
 	"net"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/talos-systems/crypto/x509"
@@ -37,6 +39,9 @@ import (
 const (
 	// DrainTimeout is maximum time to wait for the node to be drained.
 	DrainTimeout = 5 * time.Minute
+
+	// systemCriticalPriority is the lowest priority of the system-node-critical and system-cluster-critical pods.
+	systemCriticalPriority = 2000000000
 )
 
 // Client represents a set of helper methods for interacting with the
@@ -297,6 +302,160 @@ func (h *Client) LabelNodeAsMaster(ctx context.Context, name string, taintNoSche
 	return nil
 }
 
+// ApplyNodeLabels sets the node labels managed by Talos.
+//
+// Labels previously set by Talos which are not in the labels anymore are removed,
+// keys of the managed labels are stored in the node annotation.
+func (h *Client) ApplyNodeLabels(ctx context.Context, name string, labels map[string]string) error {
+	n, err := h.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	oldData, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal unmodified node %q into JSON: %w", n.Name, err)
+	}
+
+	var owned []string
+
+	if ownedJSON, ok := n.Annotations[constants.AnnotationOwnedLabels]; ok {
+		// broken annotation means that there are no labels to remove
+		json.Unmarshal([]byte(ownedJSON), &owned) //nolint:errcheck
+	}
+
+	if n.Labels == nil {
+		n.Labels = map[string]string{}
+	}
+
+	for _, key := range owned {
+		if _, ok := labels[key]; !ok {
+			delete(n.Labels, key)
+		}
+	}
+
+	keys := make([]string, 0, len(labels))
+
+	for key, value := range labels {
+		n.Labels[key] = value
+
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	if len(keys) > 0 {
+		ownedJSON, err := json.Marshal(keys)
+		if err != nil {
+			return err
+		}
+
+		if n.Annotations == nil {
+			n.Annotations = map[string]string{}
+		}
+
+		n.Annotations[constants.AnnotationOwnedLabels] = string(ownedJSON)
+	} else {
+		delete(n.Annotations, constants.AnnotationOwnedLabels)
+	}
+
+	newData, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal modified node %q into JSON: %w", n.Name, err)
+	}
+
+	patchBytes, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, corev1.Node{})
+	if err != nil {
+		return fmt.Errorf("failed to create two way merge patch: %w", err)
+	}
+
+	if string(patchBytes) == "{}" {
+		// labels are up to date
+		return nil
+	}
+
+	if _, err := h.CoreV1().Nodes().Patch(ctx, n.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf("unable to update node metadata due to conflict: %w", err)
+		}
+
+		return fmt.Errorf("error patching node %q: %w", n.Name, err)
+	}
+
+	return nil
+}
+
+// ApplyNodeTaints sets the node taints with the key prefix managed by Talos.
+//
+// Taints with the key prefix which are not in the keys are removed, new taints are added with the NoSchedule effect.
+func (h *Client) ApplyNodeTaints(ctx context.Context, name, prefix string, keys []string) error {
+	n, err := h.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	oldData, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal unmodified node %q into JSON: %w", n.Name, err)
+	}
+
+	wanted := make(map[string]struct{}, len(keys))
+
+	for _, key := range keys {
+		wanted[key] = struct{}{}
+	}
+
+	taints := make([]corev1.Taint, 0, len(n.Spec.Taints)+len(keys))
+
+	for _, taint := range n.Spec.Taints {
+		if strings.HasPrefix(taint.Key, prefix) {
+			if _, ok := wanted[taint.Key]; !ok {
+				continue
+			}
+
+			delete(wanted, taint.Key)
+		}
+
+		taints = append(taints, taint)
+	}
+
+	for _, key := range keys {
+		if _, ok := wanted[key]; ok {
+			taints = append(taints, corev1.Taint{
+				Key:    key,
+				Effect: corev1.TaintEffectNoSchedule,
+			})
+		}
+	}
+
+	n.Spec.Taints = taints
+
+	newData, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal modified node %q into JSON: %w", n.Name, err)
+	}
+
+	patchBytes, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, corev1.Node{})
+	if err != nil {
+		return fmt.Errorf("failed to create two way merge patch: %w", err)
+	}
+
+	if string(patchBytes) == "{}" {
+		// taints are up to date
+		return nil
+	}
+
+	if _, err := h.CoreV1().Nodes().Patch(ctx, n.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf("unable to update node metadata due to conflict: %w", err)
+		}
+
+		return fmt.Errorf("error patching node %q: %w", n.Name, err)
+	}
+
+	return nil
+}
+
 // WaitUntilReady waits for a node to be ready.
 func (h *Client) WaitUntilReady(ctx context.Context, name string) error {
 	return retry.Exponential(10*time.Minute, retry.WithUnits(250*time.Millisecond), retry.WithJitter(50*time.Millisecond), retry.WithErrorLogging(true)).RetryWithContext(ctx,
@@ -418,6 +577,13 @@ func (h *Client) Uncordon(ctx context.Context, name string, force bool) error {
 
 // Drain evicts all pods on a given node.
 func (h *Client) Drain(ctx context.Context, node string) error {
+	return h.DrainWithProgress(ctx, node, nil)
+}
+
+// DrainWithProgress evicts all pods on a given node reporting the progress of the eviction.
+//
+//nolint:gocyclo
+func (h *Client) DrainWithProgress(ctx context.Context, node string, progress DrainProgressFunc) error {
 	ctx, cancel := context.WithTimeout(ctx, DrainTimeout)
 	defer cancel()
 
@@ -430,40 +596,146 @@ func (h *Client) Drain(ctx context.Context, node string) error {
 		return fmt.Errorf("cannot get pods for node %s: %w", node, err)
 	}
 
+	evictPods := make([]corev1.Pod, 0, len(pods.Items))
+
+	for i := range pods.Items {
+		p := pods.Items[i]
+
+		if _, ok := p.ObjectMeta.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			log.Printf("skipping mirror pod %s/%s\n", p.GetNamespace(), p.GetName())
+
+			continue
+		}
+
+		controllerRef := metav1.GetControllerOf(&p)
+
+		if controllerRef == nil {
+			log.Printf("skipping unmanaged pod %s/%s\n", p.GetNamespace(), p.GetName())
+
+			continue
+		}
+
+		if controllerRef.Kind == appsv1.SchemeGroupVersion.WithKind("DaemonSet").Kind {
+			log.Printf("skipping DaemonSet pod %s/%s\n", p.GetNamespace(), p.GetName())
+
+			continue
+		}
+
+		if !p.DeletionTimestamp.IsZero() {
+			log.Printf("skipping deleted pod %s/%s\n", p.GetNamespace(), p.GetName())
+		}
+
+		evictPods = append(evictPods, p)
+	}
+
+	podNames := make([]string, len(evictPods))
+
+	for i, p := range evictPods {
+		podNames[i] = p.GetNamespace() + "/" + p.GetName()
+	}
+
+	tracker := newDrainTracker(podNames, progress)
+
 	var eg errgroup.Group
 
 	// Evict each pod.
 
-	for _, pod := range pods.Items {
+	for i, pod := range evictPods {
 		p := pod
+		name := podNames[i]
 
 		eg.Go(func() error {
-			if _, ok := p.ObjectMeta.Annotations[corev1.MirrorPodAnnotationKey]; ok {
-				log.Printf("skipping mirror pod %s/%s\n", p.GetNamespace(), p.GetName())
+			if err := h.evict(ctx, p, int64(60), func(blocked bool) { tracker.setBlocked(name, blocked) }); err != nil {
+				log.Printf("WARNING: failed to evict pod: %v", err)
 
 				return nil
 			}
 
-			controllerRef := metav1.GetControllerOf(&p)
+			tracker.evicted(name)
 
-			if controllerRef == nil {
-				log.Printf("skipping unmanaged pod %s/%s\n", p.GetNamespace(), p.GetName())
+			return nil
+		})
+	}
 
-				return nil
-			}
+	return eg.Wait()
+}
 
-			if controllerRef.Kind == appsv1.SchemeGroupVersion.WithKind("DaemonSet").Kind {
-				log.Printf("skipping DaemonSet pod %s/%s\n", p.GetNamespace(), p.GetName())
+// TerminatePods gracefully terminates the pods running on the node similar to the kubelet graceful node shutdown.
+//
+// Regular pods are terminated first within gracePeriod - criticalGracePeriod, critical pods are terminated
+// within criticalGracePeriod afterwards. Pod disruption budgets are not honored.
+// Mirror and DaemonSet pods are skipped, as they are recreated on the node immediately.
+func (h *Client) TerminatePods(ctx context.Context, node string, gracePeriod, criticalGracePeriod time.Duration) error {
+	opts := metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": node}).String(),
+	}
 
+	pods, err := h.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("cannot get pods for node %s: %w", node, err)
+	}
+
+	var regularPods, criticalPods []corev1.Pod
+
+	for i := range pods.Items {
+		pod := pods.Items[i]
+
+		if _, ok := pod.ObjectMeta.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			continue
+		}
+
+		if controllerRef := metav1.GetControllerOf(&pod); controllerRef != nil && controllerRef.Kind == appsv1.SchemeGroupVersion.WithKind("DaemonSet").Kind {
+			continue
+		}
+
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if pod.Spec.Priority != nil && *pod.Spec.Priority >= systemCriticalPriority {
+			criticalPods = append(criticalPods, pod)
+		} else {
+			regularPods = append(regularPods, pod)
+		}
+	}
+
+	if err = h.terminatePods(ctx, regularPods, gracePeriod-criticalGracePeriod); err != nil {
+		return err
+	}
+
+	return h.terminatePods(ctx, criticalPods, criticalGracePeriod)
+}
+
+func (h *Client) terminatePods(ctx context.Context, pods []corev1.Pod, gracePeriod time.Duration) error {
+	// zero grace period force deletes the pod without waiting for the kubelet to stop it
+	if gracePeriod < time.Second {
+		gracePeriod = time.Second
+	}
+
+	gracePeriodSeconds := int64(gracePeriod / time.Second)
+
+	var eg errgroup.Group
+
+	for _, pod := range pods {
+		p := pod
+
+		eg.Go(func() error {
+			err := h.CoreV1().Pods(p.GetNamespace()).Delete(ctx, p.GetName(), metav1.DeleteOptions{
+				GracePeriodSeconds: &gracePeriodSeconds,
+				Preconditions:      metav1.NewUIDPreconditions(string(p.GetUID())),
+			})
+
+			switch {
+			case apierrors.IsNotFound(err), apierrors.IsConflict(err):
 				return nil
-			}
+			case err != nil:
+				log.Printf("WARNING: failed to terminate pod %s/%s: %v", p.GetNamespace(), p.GetName(), err)
 
-			if !p.DeletionTimestamp.IsZero() {
-				log.Printf("skipping deleted pod %s/%s\n", p.GetNamespace(), p.GetName())
+				return nil
 			}
 
-			if err := h.evict(ctx, p, int64(60)); err != nil {
-				log.Printf("WARNING: failed to evict pod: %v", err)
+			if err = h.waitForPodDeleted(ctx, &p, gracePeriod); err != nil {
+				log.Printf("WARNING: failed waiting on pod %s/%s to be terminated: %v", p.GetNamespace(), p.GetName(), err)
 			}
 
 			return nil
@@ -473,7 +745,28 @@ func (h *Client) Drain(ctx context.Context, node string) error {
 	return eg.Wait()
 }
 
-func (h *Client) evict(ctx context.Context, p corev1.Pod, gracePeriod int64) error {
+// ShutdownInhibitors returns the shutdown inhibitors set on the node as the map of inhibitor name to the reason.
+//
+// Shutdown inhibitors are set by the workloads as the node annotations with constants.AnnotationShutdownInhibitPrefix.
+func (h *Client) ShutdownInhibitors(ctx context.Context, name string) (map[string]string, error) {
+	node, err := h.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	inhibitors := map[string]string{}
+
+	for key, value := range node.Annotations {
+		if strings.HasPrefix(key, constants.AnnotationShutdownInhibitPrefix) {
+			inhibitors[strings.TrimPrefix(key, constants.AnnotationShutdownInhibitPrefix)] = value
+		}
+	}
+
+	return inhibitors, nil
+}
+
+// evict evicts the pod, blocked is called when the eviction is blocked (or unblocked) by the pod disruption budget.
+func (h *Client) evict(ctx context.Context, p corev1.Pod, gracePeriod int64, blocked func(bool)) error {
 	for {
 		pol := &policy.Eviction{
 			ObjectMeta:    metav1.ObjectMeta{Namespace: p.GetNamespace(), Name: p.GetName()},
@@ -483,13 +776,17 @@ func (h *Client) evict(ctx context.Context, p corev1.Pod, gracePeriod int64) err
 
 		switch {
 		case apierrors.IsTooManyRequests(err):
+			blocked(true)
+
 			time.Sleep(5 * time.Second)
 		case apierrors.IsNotFound(err):
 			return nil
 		case err != nil:
 			return fmt.Errorf("failed to evict pod %s/%s: %w", p.GetNamespace(), p.GetName(), err)
 		default:
-			if err = h.waitForPodDeleted(ctx, &p); err != nil {
+			blocked(false)
+
+			if err = h.waitForPodDeleted(ctx, &p, time.Minute); err != nil {
 				return fmt.Errorf("failed waiting on pod %s/%s to be deleted: %w", p.GetNamespace(), p.GetName(), err)
 			}
 
@@ -498,8 +795,8 @@ func (h *Client) evict(ctx context.Context, p corev1.Pod, gracePeriod int64) err
 	}
 }
 
-func (h *Client) waitForPodDeleted(ctx context.Context, p *corev1.Pod) error {
-	return retry.Constant(time.Minute, retry.WithUnits(3*time.Second)).RetryWithContext(ctx, func(ctx context.Context) error {
+func (h *Client) waitForPodDeleted(ctx context.Context, p *corev1.Pod, timeout time.Duration) error {
+	return retry.Constant(timeout, retry.WithUnits(3*time.Second)).RetryWithContext(ctx, func(ctx context.Context) error {
 		pod, err := h.CoreV1().Pods(p.GetNamespace()).Get(ctx, p.GetName(), metav1.GetOptions{})
 		switch {
 		case apierrors.IsNotFound(err):
@@ -27,6 +27,15 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MachineServiceClient interface {
 	ApplyConfiguration(ctx context.Context, in *ApplyConfigurationRequest, opts ...grpc.CallOption) (*ApplyConfigurationResponse, error)
+	// Attestation method returns TPM quote over the boot measurements.
+	//
+	// Quote is signed with the attestation key derived from the TPM endorsement hierarchy,
+	// PCR values and event logs are returned to allow replaying the measurements.
+	Attestation(ctx context.Context, in *AttestationRequest, opts ...grpc.CallOption) (*AttestationResponse, error)
+	// BMCControl method sets the boot device and controls the node power via its BMC over IPMI.
+	//
+	// BMC power control should be allowed in the machine configuration.
+	BMCControl(ctx context.Context, in *BMCControlRequest, opts ...grpc.CallOption) (*BMCControlResponse, error)
 	// Bootstrap method makes control plane node enter etcd bootstrap mode.
 	//
 	// Node aborts etcd join sequence and creates single-node etcd cluster.
@@ -34,9 +43,25 @@ type MachineServiceClient interface {
 	// If recover_etcd argument is specified, etcd is recovered from a snapshot
 	// uploaded with EtcdRecover.
 	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
+	// Capabilities method reports the API methods and features supported by the node.
+	//
+	// Clients should use it to adapt to the nodes running different Talos versions.
+	Capabilities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+	// Console method streams the node console output.
+	//
+	// Console output contains the kernel and system messages printed to the console
+	// according to the console log level.
+	Console(ctx context.Context, in *ConsoleRequest, opts ...grpc.CallOption) (MachineService_ConsoleClient, error)
+	// ContainerDiskUsage method attributes the containerd disk usage to the images, snapshot layers and container writable layers.
+	ContainerDiskUsage(ctx context.Context, in *ContainerDiskUsageRequest, opts ...grpc.CallOption) (*ContainerDiskUsageResponse, error)
 	Containers(ctx context.Context, in *ContainersRequest, opts ...grpc.CallOption) (*ContainersResponse, error)
 	Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (MachineService_CopyClient, error)
 	CPUInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CPUInfoResponse, error)
+	// Debug method runs a privileged container with host namespaces and host root filesystem mounted at /host.
+	//
+	// The first request specifies the container, following requests stream the standard input.
+	// The container is removed when the process exits or the client disconnects.
+	Debug(ctx context.Context, opts ...grpc.CallOption) (MachineService_DebugClient, error)
 	DiskStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DiskStatsResponse, error)
 	Dmesg(ctx context.Context, in *DmesgRequest, opts ...grpc.CallOption) (MachineService_DmesgClient, error)
 	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (MachineService_EventsClient, error)
@@ -57,6 +82,17 @@ type MachineServiceClient interface {
 	GenerateConfiguration(ctx context.Context, in *GenerateConfigurationRequest, opts ...grpc.CallOption) (*GenerateConfigurationResponse, error)
 	Hostname(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HostnameResponse, error)
 	Kubeconfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (MachineService_KubeconfigClient, error)
+	// KubeletRepair method removes kubelet kubeconfig and client certificates and restarts kubelet.
+	//
+	// Kubelet goes through the bootstrap flow again, which recovers the nodes with expired kubelet client certificates
+	// without a reboot.
+	KubeletRepair(ctx context.Context, in *KubeletRepairRequest, opts ...grpc.CallOption) (*KubeletRepairResponse, error)
+	// KubeletRegenerate method removes kubelet credentials issued by the cluster CA along with the cluster CA certificate
+	// and restarts kubelet.
+	//
+	// Kubelet bootstraps again against the cluster CA from the current machine configuration, which is required after the cluster CA rotation.
+	// On control plane nodes, kubelet is restarted once the control plane secrets are rendered from the current machine configuration.
+	KubeletRegenerate(ctx context.Context, in *KubeletRegenerateRequest, opts ...grpc.CallOption) (*KubeletRegenerateResponse, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (MachineService_ListClient, error)
 	DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (MachineService_DiskUsageClient, error)
 	LoadAvg(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LoadAvgResponse, error)
@@ -64,17 +100,27 @@ type MachineServiceClient interface {
 	Memory(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MemoryResponse, error)
 	Mounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MountsResponse, error)
 	NetworkDeviceStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NetworkDeviceStatsResponse, error)
+	// PauseControllers method pauses the reconciliation of the controllers for the emergency manual intervention.
+	//
+	// Paused controllers are stopped until the pause duration expires or the controllers are resumed
+	// with ResumeControllers, pause is not persisted across reboots.
+	PauseControllers(ctx context.Context, in *PauseControllersRequest, opts ...grpc.CallOption) (*PauseControllersResponse, error)
 	Processes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProcessesResponse, error)
 	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (MachineService_ReadClient, error)
 	Reboot(ctx context.Context, in *RebootRequest, opts ...grpc.CallOption) (*RebootResponse, error)
 	Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*RestartResponse, error)
 	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
+	// ResumeControllers method resumes the reconciliation of the controllers paused with PauseControllers.
+	ResumeControllers(ctx context.Context, in *ResumeControllersRequest, opts ...grpc.CallOption) (*ResumeControllersResponse, error)
 	ServiceList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServiceListResponse, error)
 	ServiceRestart(ctx context.Context, in *ServiceRestartRequest, opts ...grpc.CallOption) (*ServiceRestartResponse, error)
 	ServiceStart(ctx context.Context, in *ServiceStartRequest, opts ...grpc.CallOption) (*ServiceStartResponse, error)
 	ServiceStop(ctx context.Context, in *ServiceStopRequest, opts ...grpc.CallOption) (*ServiceStopResponse, error)
 	Shutdown(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// SocketStats method returns socket usage and network protocol counters,
+	// as reported by the node_exporter sockstat and netstat collectors.
+	SocketStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SocketStatsResponse, error)
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
 	SystemStat(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SystemStatResponse, error)
 	Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error)
@@ -100,6 +146,24 @@ func (c *machineServiceClient) ApplyConfiguration(ctx context.Context, in *Apply
 	return out, nil
 }
 
+func (c *machineServiceClient) Attestation(ctx context.Context, in *AttestationRequest, opts ...grpc.CallOption) (*AttestationResponse, error) {
+	out := new(AttestationResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/Attestation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineServiceClient) BMCControl(ctx context.Context, in *BMCControlRequest, opts ...grpc.CallOption) (*BMCControlResponse, error) {
+	out := new(BMCControlResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/BMCControl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
 	out := new(BootstrapResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Bootstrap", in, out, opts...)
@@ -109,6 +173,56 @@ func (c *machineServiceClient) Bootstrap(ctx context.Context, in *BootstrapReque
 	return out, nil
 }
 
+func (c *machineServiceClient) Capabilities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/Capabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineServiceClient) Console(ctx context.Context, in *ConsoleRequest, opts ...grpc.CallOption) (MachineService_ConsoleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[0], "/machine.MachineService/Console", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineServiceConsoleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MachineService_ConsoleClient interface {
+	Recv() (*common.Data, error)
+	grpc.ClientStream
+}
+
+type machineServiceConsoleClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineServiceConsoleClient) Recv() (*common.Data, error) {
+	m := new(common.Data)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *machineServiceClient) ContainerDiskUsage(ctx context.Context, in *ContainerDiskUsageRequest, opts ...grpc.CallOption) (*ContainerDiskUsageResponse, error) {
+	out := new(ContainerDiskUsageResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/ContainerDiskUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Containers(ctx context.Context, in *ContainersRequest, opts ...grpc.CallOption) (*ContainersResponse, error) {
 	out := new(ContainersResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Containers", in, out, opts...)
@@ -119,7 +233,7 @@ func (c *machineServiceClient) Containers(ctx context.Context, in *ContainersReq
 }
 
 func (c *machineServiceClient) Copy(ctx context.Context, in *CopyRequest, opts ...grpc.CallOption) (MachineService_CopyClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[0], "/machine.MachineService/Copy", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[1], "/machine.MachineService/Copy", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +273,37 @@ func (c *machineServiceClient) CPUInfo(ctx context.Context, in *emptypb.Empty, o
 	return out, nil
 }
 
+func (c *machineServiceClient) Debug(ctx context.Context, opts ...grpc.CallOption) (MachineService_DebugClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[2], "/machine.MachineService/Debug", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineServiceDebugClient{stream}
+	return x, nil
+}
+
+type MachineService_DebugClient interface {
+	Send(*DebugRequest) error
+	Recv() (*DebugResponse, error)
+	grpc.ClientStream
+}
+
+type machineServiceDebugClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineServiceDebugClient) Send(m *DebugRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *machineServiceDebugClient) Recv() (*DebugResponse, error) {
+	m := new(DebugResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *machineServiceClient) DiskStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DiskStatsResponse, error) {
 	out := new(DiskStatsResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/DiskStats", in, out, opts...)
@@ -169,7 +314,7 @@ func (c *machineServiceClient) DiskStats(ctx context.Context, in *emptypb.Empty,
 }
 
 func (c *machineServiceClient) Dmesg(ctx context.Context, in *DmesgRequest, opts ...grpc.CallOption) (MachineService_DmesgClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[1], "/machine.MachineService/Dmesg", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[3], "/machine.MachineService/Dmesg", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +346,7 @@ func (x *machineServiceDmesgClient) Recv() (*common.Data, error) {
 }
 
 func (c *machineServiceClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (MachineService_EventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[2], "/machine.MachineService/Events", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[4], "/machine.MachineService/Events", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -269,7 +414,7 @@ func (c *machineServiceClient) EtcdForfeitLeadership(ctx context.Context, in *Et
 }
 
 func (c *machineServiceClient) EtcdRecover(ctx context.Context, opts ...grpc.CallOption) (MachineService_EtcdRecoverClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[3], "/machine.MachineService/EtcdRecover", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[5], "/machine.MachineService/EtcdRecover", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -303,7 +448,7 @@ func (x *machineServiceEtcdRecoverClient) CloseAndRecv() (*EtcdRecoverResponse,
 }
 
 func (c *machineServiceClient) EtcdSnapshot(ctx context.Context, in *EtcdSnapshotRequest, opts ...grpc.CallOption) (MachineService_EtcdSnapshotClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[4], "/machine.MachineService/EtcdSnapshot", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[6], "/machine.MachineService/EtcdSnapshot", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +498,7 @@ func (c *machineServiceClient) Hostname(ctx context.Context, in *emptypb.Empty,
 }
 
 func (c *machineServiceClient) Kubeconfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (MachineService_KubeconfigClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[5], "/machine.MachineService/Kubeconfig", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[7], "/machine.MachineService/Kubeconfig", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -384,8 +529,26 @@ func (x *machineServiceKubeconfigClient) Recv() (*common.Data, error) {
 	return m, nil
 }
 
+func (c *machineServiceClient) KubeletRepair(ctx context.Context, in *KubeletRepairRequest, opts ...grpc.CallOption) (*KubeletRepairResponse, error) {
+	out := new(KubeletRepairResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/KubeletRepair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineServiceClient) KubeletRegenerate(ctx context.Context, in *KubeletRegenerateRequest, opts ...grpc.CallOption) (*KubeletRegenerateResponse, error) {
+	out := new(KubeletRegenerateResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/KubeletRegenerate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (MachineService_ListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[6], "/machine.MachineService/List", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[8], "/machine.MachineService/List", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -417,7 +580,7 @@ func (x *machineServiceListClient) Recv() (*FileInfo, error) {
 }
 
 func (c *machineServiceClient) DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (MachineService_DiskUsageClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[7], "/machine.MachineService/DiskUsage", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[9], "/machine.MachineService/DiskUsage", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -458,7 +621,7 @@ func (c *machineServiceClient) LoadAvg(ctx context.Context, in *emptypb.Empty, o
 }
 
 func (c *machineServiceClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (MachineService_LogsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[8], "/machine.MachineService/Logs", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[10], "/machine.MachineService/Logs", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -516,6 +679,15 @@ func (c *machineServiceClient) NetworkDeviceStats(ctx context.Context, in *empty
 	return out, nil
 }
 
+func (c *machineServiceClient) PauseControllers(ctx context.Context, in *PauseControllersRequest, opts ...grpc.CallOption) (*PauseControllersResponse, error) {
+	out := new(PauseControllersResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/PauseControllers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Processes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ProcessesResponse, error) {
 	out := new(ProcessesResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Processes", in, out, opts...)
@@ -526,7 +698,7 @@ func (c *machineServiceClient) Processes(ctx context.Context, in *emptypb.Empty,
 }
 
 func (c *machineServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (MachineService_ReadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[9], "/machine.MachineService/Read", opts...)
+	stream, err := c.cc.NewStream(ctx, &MachineService_ServiceDesc.Streams[11], "/machine.MachineService/Read", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -593,6 +765,15 @@ func (c *machineServiceClient) Reset(ctx context.Context, in *ResetRequest, opts
 	return out, nil
 }
 
+func (c *machineServiceClient) ResumeControllers(ctx context.Context, in *ResumeControllersRequest, opts ...grpc.CallOption) (*ResumeControllersResponse, error) {
+	out := new(ResumeControllersResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/ResumeControllers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) ServiceList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServiceListResponse, error) {
 	out := new(ServiceListResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/ServiceList", in, out, opts...)
@@ -638,6 +819,15 @@ func (c *machineServiceClient) Shutdown(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
+func (c *machineServiceClient) SocketStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SocketStatsResponse, error) {
+	out := new(SocketStatsResponse)
+	err := c.cc.Invoke(ctx, "/machine.MachineService/SocketStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineServiceClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
 	out := new(StatsResponse)
 	err := c.cc.Invoke(ctx, "/machine.MachineService/Stats", in, out, opts...)
@@ -688,6 +878,15 @@ func (c *machineServiceClient) GenerateClientConfiguration(ctx context.Context,
 // for forward compatibility
 type MachineServiceServer interface {
 	ApplyConfiguration(context.Context, *ApplyConfigurationRequest) (*ApplyConfigurationResponse, error)
+	// Attestation method returns TPM quote over the boot measurements.
+	//
+	// Quote is signed with the attestation key derived from the TPM endorsement hierarchy,
+	// PCR values and event logs are returned to allow replaying the measurements.
+	Attestation(context.Context, *AttestationRequest) (*AttestationResponse, error)
+	// BMCControl method sets the boot device and controls the node power via its BMC over IPMI.
+	//
+	// BMC power control should be allowed in the machine configuration.
+	BMCControl(context.Context, *BMCControlRequest) (*BMCControlResponse, error)
 	// Bootstrap method makes control plane node enter etcd bootstrap mode.
 	//
 	// Node aborts etcd join sequence and creates single-node etcd cluster.
@@ -695,9 +894,25 @@ type MachineServiceServer interface {
 	// If recover_etcd argument is specified, etcd is recovered from a snapshot
 	// uploaded with EtcdRecover.
 	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
+	// Capabilities method reports the API methods and features supported by the node.
+	//
+	// Clients should use it to adapt to the nodes running different Talos versions.
+	Capabilities(context.Context, *emptypb.Empty) (*CapabilitiesResponse, error)
+	// Console method streams the node console output.
+	//
+	// Console output contains the kernel and system messages printed to the console
+	// according to the console log level.
+	Console(*ConsoleRequest, MachineService_ConsoleServer) error
+	// ContainerDiskUsage method attributes the containerd disk usage to the images, snapshot layers and container writable layers.
+	ContainerDiskUsage(context.Context, *ContainerDiskUsageRequest) (*ContainerDiskUsageResponse, error)
 	Containers(context.Context, *ContainersRequest) (*ContainersResponse, error)
 	Copy(*CopyRequest, MachineService_CopyServer) error
 	CPUInfo(context.Context, *emptypb.Empty) (*CPUInfoResponse, error)
+	// Debug method runs a privileged container with host namespaces and host root filesystem mounted at /host.
+	//
+	// The first request specifies the container, following requests stream the standard input.
+	// The container is removed when the process exits or the client disconnects.
+	Debug(MachineService_DebugServer) error
 	DiskStats(context.Context, *emptypb.Empty) (*DiskStatsResponse, error)
 	Dmesg(*DmesgRequest, MachineService_DmesgServer) error
 	Events(*EventsRequest, MachineService_EventsServer) error
@@ -718,6 +933,17 @@ type MachineServiceServer interface {
 	GenerateConfiguration(context.Context, *GenerateConfigurationRequest) (*GenerateConfigurationResponse, error)
 	Hostname(context.Context, *emptypb.Empty) (*HostnameResponse, error)
 	Kubeconfig(*emptypb.Empty, MachineService_KubeconfigServer) error
+	// KubeletRepair method removes kubelet kubeconfig and client certificates and restarts kubelet.
+	//
+	// Kubelet goes through the bootstrap flow again, which recovers the nodes with expired kubelet client certificates
+	// without a reboot.
+	KubeletRepair(context.Context, *KubeletRepairRequest) (*KubeletRepairResponse, error)
+	// KubeletRegenerate method removes kubelet credentials issued by the cluster CA along with the cluster CA certificate
+	// and restarts kubelet.
+	//
+	// Kubelet bootstraps again against the cluster CA from the current machine configuration, which is required after the cluster CA rotation.
+	// On control plane nodes, kubelet is restarted once the control plane secrets are rendered from the current machine configuration.
+	KubeletRegenerate(context.Context, *KubeletRegenerateRequest) (*KubeletRegenerateResponse, error)
 	List(*ListRequest, MachineService_ListServer) error
 	DiskUsage(*DiskUsageRequest, MachineService_DiskUsageServer) error
 	LoadAvg(context.Context, *emptypb.Empty) (*LoadAvgResponse, error)
@@ -725,17 +951,27 @@ type MachineServiceServer interface {
 	Memory(context.Context, *emptypb.Empty) (*MemoryResponse, error)
 	Mounts(context.Context, *emptypb.Empty) (*MountsResponse, error)
 	NetworkDeviceStats(context.Context, *emptypb.Empty) (*NetworkDeviceStatsResponse, error)
+	// PauseControllers method pauses the reconciliation of the controllers for the emergency manual intervention.
+	//
+	// Paused controllers are stopped until the pause duration expires or the controllers are resumed
+	// with ResumeControllers, pause is not persisted across reboots.
+	PauseControllers(context.Context, *PauseControllersRequest) (*PauseControllersResponse, error)
 	Processes(context.Context, *emptypb.Empty) (*ProcessesResponse, error)
 	Read(*ReadRequest, MachineService_ReadServer) error
 	Reboot(context.Context, *RebootRequest) (*RebootResponse, error)
 	Restart(context.Context, *RestartRequest) (*RestartResponse, error)
 	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
 	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
+	// ResumeControllers method resumes the reconciliation of the controllers paused with PauseControllers.
+	ResumeControllers(context.Context, *ResumeControllersRequest) (*ResumeControllersResponse, error)
 	ServiceList(context.Context, *emptypb.Empty) (*ServiceListResponse, error)
 	ServiceRestart(context.Context, *ServiceRestartRequest) (*ServiceRestartResponse, error)
 	ServiceStart(context.Context, *ServiceStartRequest) (*ServiceStartResponse, error)
 	ServiceStop(context.Context, *ServiceStopRequest) (*ServiceStopResponse, error)
 	Shutdown(context.Context, *emptypb.Empty) (*ShutdownResponse, error)
+	// SocketStats method returns socket usage and network protocol counters,
+	// as reported by the node_exporter sockstat and netstat collectors.
+	SocketStats(context.Context, *emptypb.Empty) (*SocketStatsResponse, error)
 	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
 	SystemStat(context.Context, *emptypb.Empty) (*SystemStatResponse, error)
 	Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error)
@@ -752,10 +988,30 @@ func (UnimplementedMachineServiceServer) ApplyConfiguration(context.Context, *Ap
 	return nil, status.Errorf(codes.Unimplemented, "method ApplyConfiguration not implemented")
 }
 
+func (UnimplementedMachineServiceServer) Attestation(context.Context, *AttestationRequest) (*AttestationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Attestation not implemented")
+}
+
+func (UnimplementedMachineServiceServer) BMCControl(context.Context, *BMCControlRequest) (*BMCControlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BMCControl not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Bootstrap not implemented")
 }
 
+func (UnimplementedMachineServiceServer) Capabilities(context.Context, *emptypb.Empty) (*CapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capabilities not implemented")
+}
+
+func (UnimplementedMachineServiceServer) Console(*ConsoleRequest, MachineService_ConsoleServer) error {
+	return status.Errorf(codes.Unimplemented, "method Console not implemented")
+}
+
+func (UnimplementedMachineServiceServer) ContainerDiskUsage(context.Context, *ContainerDiskUsageRequest) (*ContainerDiskUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContainerDiskUsage not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Containers(context.Context, *ContainersRequest) (*ContainersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Containers not implemented")
 }
@@ -768,6 +1024,10 @@ func (UnimplementedMachineServiceServer) CPUInfo(context.Context, *emptypb.Empty
 	return nil, status.Errorf(codes.Unimplemented, "method CPUInfo not implemented")
 }
 
+func (UnimplementedMachineServiceServer) Debug(MachineService_DebugServer) error {
+	return status.Errorf(codes.Unimplemented, "method Debug not implemented")
+}
+
 func (UnimplementedMachineServiceServer) DiskStats(context.Context, *emptypb.Empty) (*DiskStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DiskStats not implemented")
 }
@@ -816,6 +1076,14 @@ func (UnimplementedMachineServiceServer) Kubeconfig(*emptypb.Empty, MachineServi
 	return status.Errorf(codes.Unimplemented, "method Kubeconfig not implemented")
 }
 
+func (UnimplementedMachineServiceServer) KubeletRepair(context.Context, *KubeletRepairRequest) (*KubeletRepairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KubeletRepair not implemented")
+}
+
+func (UnimplementedMachineServiceServer) KubeletRegenerate(context.Context, *KubeletRegenerateRequest) (*KubeletRegenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KubeletRegenerate not implemented")
+}
+
 func (UnimplementedMachineServiceServer) List(*ListRequest, MachineService_ListServer) error {
 	return status.Errorf(codes.Unimplemented, "method List not implemented")
 }
@@ -844,6 +1112,10 @@ func (UnimplementedMachineServiceServer) NetworkDeviceStats(context.Context, *em
 	return nil, status.Errorf(codes.Unimplemented, "method NetworkDeviceStats not implemented")
 }
 
+func (UnimplementedMachineServiceServer) PauseControllers(context.Context, *PauseControllersRequest) (*PauseControllersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseControllers not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Processes(context.Context, *emptypb.Empty) (*ProcessesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Processes not implemented")
 }
@@ -868,6 +1140,10 @@ func (UnimplementedMachineServiceServer) Reset(context.Context, *ResetRequest) (
 	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
 }
 
+func (UnimplementedMachineServiceServer) ResumeControllers(context.Context, *ResumeControllersRequest) (*ResumeControllersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeControllers not implemented")
+}
+
 func (UnimplementedMachineServiceServer) ServiceList(context.Context, *emptypb.Empty) (*ServiceListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ServiceList not implemented")
 }
@@ -888,6 +1164,10 @@ func (UnimplementedMachineServiceServer) Shutdown(context.Context, *emptypb.Empt
 	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
 }
 
+func (UnimplementedMachineServiceServer) SocketStats(context.Context, *emptypb.Empty) (*SocketStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SocketStats not implemented")
+}
+
 func (UnimplementedMachineServiceServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
 }
@@ -938,6 +1218,42 @@ func _MachineService_ApplyConfiguration_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_Attestation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttestationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).Attestation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/Attestation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).Attestation(ctx, req.(*AttestationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MachineService_BMCControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BMCControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).BMCControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/BMCControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).BMCControl(ctx, req.(*BMCControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BootstrapRequest)
 	if err := dec(in); err != nil {
@@ -956,6 +1272,63 @@ func _MachineService_Bootstrap_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).Capabilities(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MachineService_Console_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConsoleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MachineServiceServer).Console(m, &machineServiceConsoleServer{stream})
+}
+
+type MachineService_ConsoleServer interface {
+	Send(*common.Data) error
+	grpc.ServerStream
+}
+
+type machineServiceConsoleServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineServiceConsoleServer) Send(m *common.Data) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MachineService_ContainerDiskUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerDiskUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).ContainerDiskUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/ContainerDiskUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).ContainerDiskUsage(ctx, req.(*ContainerDiskUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Containers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ContainersRequest)
 	if err := dec(in); err != nil {
@@ -1013,6 +1386,32 @@ func _MachineService_CPUInfo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_Debug_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MachineServiceServer).Debug(&machineServiceDebugServer{stream})
+}
+
+type MachineService_DebugServer interface {
+	Send(*DebugResponse) error
+	Recv() (*DebugRequest, error)
+	grpc.ServerStream
+}
+
+type machineServiceDebugServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineServiceDebugServer) Send(m *DebugResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *machineServiceDebugServer) Recv() (*DebugRequest, error) {
+	m := new(DebugRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _MachineService_DiskStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1249,6 +1648,42 @@ func (x *machineServiceKubeconfigServer) Send(m *common.Data) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _MachineService_KubeletRepair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KubeletRepairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).KubeletRepair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/KubeletRepair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).KubeletRepair(ctx, req.(*KubeletRepairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MachineService_KubeletRegenerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KubeletRegenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).KubeletRegenerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/KubeletRegenerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).KubeletRegenerate(ctx, req.(*KubeletRegenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1384,6 +1819,24 @@ func _MachineService_NetworkDeviceStats_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_PauseControllers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseControllersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).PauseControllers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/PauseControllers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).PauseControllers(ctx, req.(*PauseControllersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Processes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1495,6 +1948,24 @@ func _MachineService_Reset_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_ResumeControllers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeControllersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).ResumeControllers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/ResumeControllers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).ResumeControllers(ctx, req.(*ResumeControllersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_ServiceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1585,6 +2056,24 @@ func _MachineService_Shutdown_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineService_SocketStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServiceServer).SocketStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/machine.MachineService/SocketStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServiceServer).SocketStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MachineService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatsRequest)
 	if err := dec(in); err != nil {
@@ -1686,10 +2175,26 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ApplyConfiguration",
 			Handler:    _MachineService_ApplyConfiguration_Handler,
 		},
+		{
+			MethodName: "Attestation",
+			Handler:    _MachineService_Attestation_Handler,
+		},
+		{
+			MethodName: "BMCControl",
+			Handler:    _MachineService_BMCControl_Handler,
+		},
 		{
 			MethodName: "Bootstrap",
 			Handler:    _MachineService_Bootstrap_Handler,
 		},
+		{
+			MethodName: "Capabilities",
+			Handler:    _MachineService_Capabilities_Handler,
+		},
+		{
+			MethodName: "ContainerDiskUsage",
+			Handler:    _MachineService_ContainerDiskUsage_Handler,
+		},
 		{
 			MethodName: "Containers",
 			Handler:    _MachineService_Containers_Handler,
@@ -1726,6 +2231,14 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Hostname",
 			Handler:    _MachineService_Hostname_Handler,
 		},
+		{
+			MethodName: "KubeletRepair",
+			Handler:    _MachineService_KubeletRepair_Handler,
+		},
+		{
+			MethodName: "KubeletRegenerate",
+			Handler:    _MachineService_KubeletRegenerate_Handler,
+		},
 		{
 			MethodName: "LoadAvg",
 			Handler:    _MachineService_LoadAvg_Handler,
@@ -1742,6 +2255,10 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NetworkDeviceStats",
 			Handler:    _MachineService_NetworkDeviceStats_Handler,
 		},
+		{
+			MethodName: "PauseControllers",
+			Handler:    _MachineService_PauseControllers_Handler,
+		},
 		{
 			MethodName: "Processes",
 			Handler:    _MachineService_Processes_Handler,
@@ -1762,6 +2279,10 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Reset",
 			Handler:    _MachineService_Reset_Handler,
 		},
+		{
+			MethodName: "ResumeControllers",
+			Handler:    _MachineService_ResumeControllers_Handler,
+		},
 		{
 			MethodName: "ServiceList",
 			Handler:    _MachineService_ServiceList_Handler,
@@ -1782,6 +2303,10 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Shutdown",
 			Handler:    _MachineService_Shutdown_Handler,
 		},
+		{
+			MethodName: "SocketStats",
+			Handler:    _MachineService_SocketStats_Handler,
+		},
 		{
 			MethodName: "Stats",
 			Handler:    _MachineService_Stats_Handler,
@@ -1804,11 +2329,22 @@ var MachineService_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Console",
+			Handler:       _MachineService_Console_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Copy",
 			Handler:       _MachineService_Copy_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Debug",
+			Handler:       _MachineService_Debug_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "Dmesg",
 			Handler:       _MachineService_Dmesg_Handler,
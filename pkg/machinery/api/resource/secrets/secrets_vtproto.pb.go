@@ -96,6 +96,15 @@ func (m *APISpec) MarshalToSizedBufferVT(dAtA []byte) (int, error) {
 		i -= len(m.unknownFields)
 		copy(dAtA[i:], m.unknownFields)
 	}
+	if len(m.AcceptedCaPems) > 0 {
+		for iNdEx := len(m.AcceptedCaPems) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AcceptedCaPems[iNdEx])
+			copy(dAtA[i:], m.AcceptedCaPems[iNdEx])
+			i = encodeVarint(dAtA, i, uint64(len(m.AcceptedCaPems[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if m.Client != nil {
 		size, err := m.Client.MarshalToSizedBufferVT(dAtA[:i])
 		if err != nil {
@@ -176,6 +185,12 @@ func (m *APISpec) SizeVT() (n int) {
 		l = m.Client.SizeVT()
 		n += 1 + l + sov(uint64(l))
 	}
+	if len(m.AcceptedCaPems) > 0 {
+		for _, b := range m.AcceptedCaPems {
+			l = len(b)
+			n += 1 + l + sov(uint64(l))
+		}
+	}
 	if m.unknownFields != nil {
 		n += len(m.unknownFields)
 	}
@@ -445,6 +460,38 @@ func (m *APISpec) UnmarshalVT(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AcceptedCaPems", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLength
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLength
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AcceptedCaPems = append(m.AcceptedCaPems, make([]byte, postIndex-iNdEx))
+			copy(m.AcceptedCaPems[len(m.AcceptedCaPems)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skip(dAtA[iNdEx:])
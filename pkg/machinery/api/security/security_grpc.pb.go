@@ -24,6 +24,10 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SecurityServiceClient interface {
 	Certificate(ctx context.Context, in *CertificateRequest, opts ...grpc.CallOption) (*CertificateResponse, error)
+	// KubeletCertificate signs kubelet client certificate CSR with the Kubernetes CA.
+	KubeletCertificate(ctx context.Context, in *CertificateRequest, opts ...grpc.CallOption) (*CertificateResponse, error)
+	// KubeletBootstrapToken returns the short-lived kubelet bootstrap token minted by the control plane node.
+	KubeletBootstrapToken(ctx context.Context, in *KubeletBootstrapTokenRequest, opts ...grpc.CallOption) (*KubeletBootstrapTokenResponse, error)
 }
 
 type securityServiceClient struct {
@@ -43,11 +47,33 @@ func (c *securityServiceClient) Certificate(ctx context.Context, in *Certificate
 	return out, nil
 }
 
+func (c *securityServiceClient) KubeletCertificate(ctx context.Context, in *CertificateRequest, opts ...grpc.CallOption) (*CertificateResponse, error) {
+	out := new(CertificateResponse)
+	err := c.cc.Invoke(ctx, "/securityapi.SecurityService/KubeletCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *securityServiceClient) KubeletBootstrapToken(ctx context.Context, in *KubeletBootstrapTokenRequest, opts ...grpc.CallOption) (*KubeletBootstrapTokenResponse, error) {
+	out := new(KubeletBootstrapTokenResponse)
+	err := c.cc.Invoke(ctx, "/securityapi.SecurityService/KubeletBootstrapToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SecurityServiceServer is the server API for SecurityService service.
 // All implementations must embed UnimplementedSecurityServiceServer
 // for forward compatibility
 type SecurityServiceServer interface {
 	Certificate(context.Context, *CertificateRequest) (*CertificateResponse, error)
+	// KubeletCertificate signs kubelet client certificate CSR with the Kubernetes CA.
+	KubeletCertificate(context.Context, *CertificateRequest) (*CertificateResponse, error)
+	// KubeletBootstrapToken returns the short-lived kubelet bootstrap token minted by the control plane node.
+	KubeletBootstrapToken(context.Context, *KubeletBootstrapTokenRequest) (*KubeletBootstrapTokenResponse, error)
 	mustEmbedUnimplementedSecurityServiceServer()
 }
 
@@ -57,6 +83,14 @@ type UnimplementedSecurityServiceServer struct{}
 func (UnimplementedSecurityServiceServer) Certificate(context.Context, *CertificateRequest) (*CertificateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Certificate not implemented")
 }
+
+func (UnimplementedSecurityServiceServer) KubeletCertificate(context.Context, *CertificateRequest) (*CertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KubeletCertificate not implemented")
+}
+
+func (UnimplementedSecurityServiceServer) KubeletBootstrapToken(context.Context, *KubeletBootstrapTokenRequest) (*KubeletBootstrapTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KubeletBootstrapToken not implemented")
+}
 func (UnimplementedSecurityServiceServer) mustEmbedUnimplementedSecurityServiceServer() {}
 
 // UnsafeSecurityServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +122,42 @@ func _SecurityService_Certificate_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecurityService_KubeletCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServiceServer).KubeletCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/securityapi.SecurityService/KubeletCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServiceServer).KubeletCertificate(ctx, req.(*CertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecurityService_KubeletBootstrapToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KubeletBootstrapTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServiceServer).KubeletBootstrapToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/securityapi.SecurityService/KubeletBootstrapToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServiceServer).KubeletBootstrapToken(ctx, req.(*KubeletBootstrapTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SecurityService_ServiceDesc is the grpc.ServiceDesc for SecurityService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,6 +169,14 @@ var SecurityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Certificate",
 			Handler:    _SecurityService_Certificate_Handler,
 		},
+		{
+			MethodName: "KubeletCertificate",
+			Handler:    _SecurityService_KubeletCertificate_Handler,
+		},
+		{
+			MethodName: "KubeletBootstrapToken",
+			Handler:    _SecurityService_KubeletBootstrapToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "security/security.proto",
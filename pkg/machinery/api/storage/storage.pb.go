@@ -79,6 +79,58 @@ func (Disk_DiskType) EnumDescriptor() ([]byte, []int) {
 	return file_storage_storage_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type BlockDeviceWipeRequest_Method int32
+
+const (
+	// FAST discards the disk contents and zeroes out the partition table.
+	BlockDeviceWipeRequest_FAST BlockDeviceWipeRequest_Method = 0
+	// ZEROES overwrites the whole disk with zeroes.
+	BlockDeviceWipeRequest_ZEROES BlockDeviceWipeRequest_Method = 1
+	// SECURE performs the secure erase (secure discard) of the whole disk.
+	BlockDeviceWipeRequest_SECURE BlockDeviceWipeRequest_Method = 2
+)
+
+// Enum value maps for BlockDeviceWipeRequest_Method.
+var (
+	BlockDeviceWipeRequest_Method_name = map[int32]string{
+		0: "FAST",
+		1: "ZEROES",
+		2: "SECURE",
+	}
+	BlockDeviceWipeRequest_Method_value = map[string]int32{
+		"FAST":   0,
+		"ZEROES": 1,
+		"SECURE": 2,
+	}
+)
+
+func (x BlockDeviceWipeRequest_Method) Enum() *BlockDeviceWipeRequest_Method {
+	p := new(BlockDeviceWipeRequest_Method)
+	*p = x
+	return p
+}
+
+func (x BlockDeviceWipeRequest_Method) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlockDeviceWipeRequest_Method) Descriptor() protoreflect.EnumDescriptor {
+	return file_storage_storage_proto_enumTypes[1].Descriptor()
+}
+
+func (BlockDeviceWipeRequest_Method) Type() protoreflect.EnumType {
+	return &file_storage_storage_proto_enumTypes[1]
+}
+
+func (x BlockDeviceWipeRequest_Method) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlockDeviceWipeRequest_Method.Descriptor instead.
+func (BlockDeviceWipeRequest_Method) EnumDescriptor() ([]byte, []int) {
+	return file_storage_storage_proto_rawDescGZIP(), []int{3, 0}
+}
+
 // Disk represents a disk.
 type Disk struct {
 	state         protoimpl.MessageState
@@ -303,6 +355,166 @@ func (x *DisksResponse) GetMessages() []*Disks {
 	return nil
 }
 
+// rpc BlockDeviceWipe
+type BlockDeviceWipeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Devices to wipe, e.g. `sdb`.
+	Devices []string                      `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	Method  BlockDeviceWipeRequest_Method `protobuf:"varint,2,opt,name=method,proto3,enum=storage.BlockDeviceWipeRequest_Method" json:"method,omitempty"`
+}
+
+func (x *BlockDeviceWipeRequest) Reset() {
+	*x = BlockDeviceWipeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_storage_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockDeviceWipeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDeviceWipeRequest) ProtoMessage() {}
+
+func (x *BlockDeviceWipeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_storage_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDeviceWipeRequest.ProtoReflect.Descriptor instead.
+func (*BlockDeviceWipeRequest) Descriptor() ([]byte, []int) {
+	return file_storage_storage_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BlockDeviceWipeRequest) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *BlockDeviceWipeRequest) GetMethod() BlockDeviceWipeRequest_Method {
+	if x != nil {
+		return x.Method
+	}
+	return BlockDeviceWipeRequest_FAST
+}
+
+type BlockDeviceWipe struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *common.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Wiped devices.
+	Devices []string `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *BlockDeviceWipe) Reset() {
+	*x = BlockDeviceWipe{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_storage_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockDeviceWipe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDeviceWipe) ProtoMessage() {}
+
+func (x *BlockDeviceWipe) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_storage_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDeviceWipe.ProtoReflect.Descriptor instead.
+func (*BlockDeviceWipe) Descriptor() ([]byte, []int) {
+	return file_storage_storage_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlockDeviceWipe) GetMetadata() *common.Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BlockDeviceWipe) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type BlockDeviceWipeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messages []*BlockDeviceWipe `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *BlockDeviceWipeResponse) Reset() {
+	*x = BlockDeviceWipeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_storage_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockDeviceWipeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDeviceWipeResponse) ProtoMessage() {}
+
+func (x *BlockDeviceWipeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_storage_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDeviceWipeResponse.ProtoReflect.Descriptor instead.
+func (*BlockDeviceWipeResponse) Descriptor() ([]byte, []int) {
+	return file_storage_storage_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BlockDeviceWipeResponse) GetMessages() []*BlockDeviceWipe {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 var File_storage_storage_proto protoreflect.FileDescriptor
 
 var file_storage_storage_proto_rawDesc = []byte{
@@ -339,16 +551,42 @@ var file_storage_storage_proto_rawDesc = []byte{
 	0x69, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x08,
 	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
 	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x73, 0x52, 0x08,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x32, 0x49, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x05, 0x44, 0x69,
-	0x73, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x73, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x2f,
-	0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x16, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x57, 0x69, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x3e, 0x0a,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x57, 0x69, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x2a, 0x0a,
+	0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x41, 0x53, 0x54, 0x10,
+	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x5a, 0x45, 0x52, 0x4f, 0x45, 0x53, 0x10, 0x01, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x45, 0x43, 0x55, 0x52, 0x45, 0x10, 0x02, 0x22, 0x59, 0x0a, 0x0f, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x57, 0x69, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x22, 0x4f, 0x0a, 0x17, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x57, 0x69, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x34, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x57, 0x69, 0x70, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x32, 0x9f, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x05, 0x44, 0x69, 0x73, 0x6b,
+	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x54, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x57, 0x69, 0x70, 0x65, 0x12, 0x1f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x57, 0x69, 0x70, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x57, 0x69, 0x70, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2d, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x73, 0x2f, 0x74, 0x61, 0x6c, 0x6f, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -364,30 +602,39 @@ func file_storage_storage_proto_rawDescGZIP() []byte {
 }
 
 var (
-	file_storage_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-	file_storage_storage_proto_msgTypes  = make([]protoimpl.MessageInfo, 3)
+	file_storage_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+	file_storage_storage_proto_msgTypes  = make([]protoimpl.MessageInfo, 6)
 	file_storage_storage_proto_goTypes   = []interface{}{
-		(Disk_DiskType)(0),      // 0: storage.Disk.DiskType
-		(*Disk)(nil),            // 1: storage.Disk
-		(*Disks)(nil),           // 2: storage.Disks
-		(*DisksResponse)(nil),   // 3: storage.DisksResponse
-		(*common.Metadata)(nil), // 4: common.Metadata
-		(*emptypb.Empty)(nil),   // 5: google.protobuf.Empty
+		(Disk_DiskType)(0),                 // 0: storage.Disk.DiskType
+		(BlockDeviceWipeRequest_Method)(0), // 1: storage.BlockDeviceWipeRequest.Method
+		(*Disk)(nil),                       // 2: storage.Disk
+		(*Disks)(nil),                      // 3: storage.Disks
+		(*DisksResponse)(nil),              // 4: storage.DisksResponse
+		(*BlockDeviceWipeRequest)(nil),     // 5: storage.BlockDeviceWipeRequest
+		(*BlockDeviceWipe)(nil),            // 6: storage.BlockDeviceWipe
+		(*BlockDeviceWipeResponse)(nil),    // 7: storage.BlockDeviceWipeResponse
+		(*common.Metadata)(nil),            // 8: common.Metadata
+		(*emptypb.Empty)(nil),              // 9: google.protobuf.Empty
 	}
 )
 
 var file_storage_storage_proto_depIdxs = []int32{
 	0, // 0: storage.Disk.type:type_name -> storage.Disk.DiskType
-	4, // 1: storage.Disks.metadata:type_name -> common.Metadata
-	1, // 2: storage.Disks.disks:type_name -> storage.Disk
-	2, // 3: storage.DisksResponse.messages:type_name -> storage.Disks
-	5, // 4: storage.StorageService.Disks:input_type -> google.protobuf.Empty
-	3, // 5: storage.StorageService.Disks:output_type -> storage.DisksResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	8, // 1: storage.Disks.metadata:type_name -> common.Metadata
+	2, // 2: storage.Disks.disks:type_name -> storage.Disk
+	3, // 3: storage.DisksResponse.messages:type_name -> storage.Disks
+	1, // 4: storage.BlockDeviceWipeRequest.method:type_name -> storage.BlockDeviceWipeRequest.Method
+	8, // 5: storage.BlockDeviceWipe.metadata:type_name -> common.Metadata
+	6, // 6: storage.BlockDeviceWipeResponse.messages:type_name -> storage.BlockDeviceWipe
+	9, // 7: storage.StorageService.Disks:input_type -> google.protobuf.Empty
+	5, // 8: storage.StorageService.BlockDeviceWipe:input_type -> storage.BlockDeviceWipeRequest
+	4, // 9: storage.StorageService.Disks:output_type -> storage.DisksResponse
+	7, // 10: storage.StorageService.BlockDeviceWipe:output_type -> storage.BlockDeviceWipeResponse
+	9, // [9:11] is the sub-list for method output_type
+	7, // [7:9] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_storage_storage_proto_init() }
@@ -432,14 +679,50 @@ func file_storage_storage_proto_init() {
 				return nil
 			}
 		}
+		file_storage_storage_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockDeviceWipeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_storage_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockDeviceWipe); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_storage_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockDeviceWipeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_storage_storage_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
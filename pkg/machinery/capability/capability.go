@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package capability defines the features reported by the nodes via the Capabilities API.
+//
+// Features cover the API behavior which can't be detected from the list of the supported methods,
+// e.g. new fields in the existing requests which are silently ignored by the nodes running older Talos versions.
+package capability
+
+// Feature is a named API feature.
+type Feature string
+
+const (
+	// ApplyConfigRevert is the automatic revert of the applied configuration (ApplyConfigurationRequest.revert_timeout).
+	ApplyConfigRevert = Feature("apply-config.revert")
+
+	// ApplyConfigDeprecations is the report of the deprecated config fields in the ApplyConfiguration response.
+	ApplyConfigDeprecations = Feature("apply-config.deprecations")
+
+	// ShutdownInhibitors is the delay of reboots and upgrades while the workloads hold shutdown inhibitors
+	// (ignore_inhibitors in RebootRequest and UpgradeRequest).
+	ShutdownInhibitors = Feature("shutdown-inhibitors")
+
+	// ResourcePagination is the pagination of the resource list (ListRequest.limit and continue_token).
+	ResourcePagination = Feature("resource.pagination")
+
+	// ResourceFieldProjection is the spec fields projection in the resource API (fields in GetRequest and ListRequest).
+	ResourceFieldProjection = Feature("resource.field-projection")
+)
+
+// All returns the features supported by this version of Talos.
+func All() []Feature {
+	return []Feature{
+		ApplyConfigRevert,
+		ApplyConfigDeprecations,
+		ShutdownInhibitors,
+		ResourcePagination,
+		ResourceFieldProjection,
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	grpctls "github.com/talos-systems/crypto/tls"
 	"github.com/talos-systems/net"
 	"google.golang.org/grpc"
@@ -33,6 +34,7 @@ import (
 	resourceapi "github.com/talos-systems/talos/pkg/machinery/api/resource"
 	storageapi "github.com/talos-systems/talos/pkg/machinery/api/storage"
 	timeapi "github.com/talos-systems/talos/pkg/machinery/api/time"
+	"github.com/talos-systems/talos/pkg/machinery/capability"
 	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
 )
@@ -233,7 +235,12 @@ func CredentialsFromConfigContext(context *clientconfig.Context) (*Credentials,
 		return nil, fmt.Errorf("error decoding certificate: %w", err)
 	}
 
-	keyBytes, err := base64.StdEncoding.DecodeString(context.Key)
+	key, err := context.GetKey()
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := base64.StdEncoding.DecodeString(key)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding key: %w", err)
 	}
@@ -413,6 +420,17 @@ func (c *Client) Disks(ctx context.Context, callOptions ...grpc.CallOption) (res
 	return
 }
 
+// BlockDeviceWipe wipes the disks which are not used by the system.
+func (c *Client) BlockDeviceWipe(ctx context.Context, req *storageapi.BlockDeviceWipeRequest, callOptions ...grpc.CallOption) (resp *storageapi.BlockDeviceWipeResponse, err error) {
+	resp, err = c.StorageClient.BlockDeviceWipe(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*storageapi.BlockDeviceWipeResponse) //nolint:errcheck
+
+	return
+}
+
 // Stats implements the proto.MachineServiceClient interface.
 func (c *Client) Stats(ctx context.Context, namespace string, driver common.ContainerDriver, callOptions ...grpc.CallOption) (resp *machineapi.StatsResponse, err error) {
 	resp, err = c.MachineClient.Stats(
@@ -432,7 +450,7 @@ func (c *Client) Stats(ctx context.Context, namespace string, driver common.Cont
 
 // Containers implements the proto.MachineServiceClient interface.
 func (c *Client) Containers(ctx context.Context, namespace string, driver common.ContainerDriver, callOptions ...grpc.CallOption) (resp *machineapi.ContainersResponse, err error) {
-	resp, err = c.MachineClient.Containers(
+	return c.ContainersWithOptions(
 		ctx,
 		&machineapi.ContainersRequest{
 			Namespace: namespace,
@@ -440,6 +458,11 @@ func (c *Client) Containers(ctx context.Context, namespace string, driver common
 		},
 		callOptions...,
 	)
+}
+
+// ContainersWithOptions lists the containers with the pod namespace filters and the resource usage stats.
+func (c *Client) ContainersWithOptions(ctx context.Context, req *machineapi.ContainersRequest, callOptions ...grpc.CallOption) (resp *machineapi.ContainersResponse, err error) {
+	resp, err = c.MachineClient.Containers(ctx, req, callOptions...)
 
 	var filtered interface{}
 	filtered, err = FilterMessages(resp, err)
@@ -448,6 +471,23 @@ func (c *Client) Containers(ctx context.Context, namespace string, driver common
 	return
 }
 
+// ContainerDiskUsage returns the containerd disk usage attributed to the images, snapshot layers and container writable layers.
+func (c *Client) ContainerDiskUsage(ctx context.Context, namespace string, callOptions ...grpc.CallOption) (resp *machineapi.ContainerDiskUsageResponse, err error) {
+	resp, err = c.MachineClient.ContainerDiskUsage(
+		ctx,
+		&machineapi.ContainerDiskUsageRequest{
+			Namespace: namespace,
+		},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.ContainerDiskUsageResponse) //nolint:errcheck
+
+	return
+}
+
 // Restart implements the proto.MachineServiceClient interface.
 func (c *Client) Restart(ctx context.Context, namespace string, driver common.ContainerDriver, id string, callOptions ...grpc.CallOption) (err error) {
 	resp, err := c.MachineClient.Restart(ctx, &machineapi.RestartRequest{
@@ -493,6 +533,11 @@ func WithPowerCycle(req *machineapi.RebootRequest) {
 	req.Mode = machineapi.RebootRequest_POWERCYCLE
 }
 
+// WithIgnoreInhibitors option runs the Reboot without waiting for the shutdown inhibitors set on the node.
+func WithIgnoreInhibitors(req *machineapi.RebootRequest) {
+	req.IgnoreInhibitors = true
+}
+
 // Reboot implements the proto.MachineServiceClient interface.
 func (c *Client) Reboot(ctx context.Context, opts ...RebootMode) (err error) {
 	var req machineapi.RebootRequest
@@ -550,6 +595,76 @@ func (c *Client) Dmesg(ctx context.Context, follow, tail bool) (machineapi.Machi
 	})
 }
 
+// BMCControl implements the proto.MachineServiceClient interface.
+func (c *Client) BMCControl(ctx context.Context, req *machineapi.BMCControlRequest, callOptions ...grpc.CallOption) (resp *machineapi.BMCControlResponse, err error) {
+	resp, err = c.MachineClient.BMCControl(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.BMCControlResponse) //nolint:errcheck
+
+	return
+}
+
+// KubeletRepair implements the proto.MachineServiceClient interface.
+func (c *Client) KubeletRepair(ctx context.Context, req *machineapi.KubeletRepairRequest, callOptions ...grpc.CallOption) (resp *machineapi.KubeletRepairResponse, err error) {
+	resp, err = c.MachineClient.KubeletRepair(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.KubeletRepairResponse) //nolint:errcheck
+
+	return
+}
+
+// KubeletRegenerate implements the proto.MachineServiceClient interface.
+func (c *Client) KubeletRegenerate(ctx context.Context, req *machineapi.KubeletRegenerateRequest, callOptions ...grpc.CallOption) (resp *machineapi.KubeletRegenerateResponse, err error) {
+	resp, err = c.MachineClient.KubeletRegenerate(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.KubeletRegenerateResponse) //nolint:errcheck
+
+	return
+}
+
+// PauseControllers implements the proto.MachineServiceClient interface.
+func (c *Client) PauseControllers(ctx context.Context, req *machineapi.PauseControllersRequest, callOptions ...grpc.CallOption) (resp *machineapi.PauseControllersResponse, err error) {
+	resp, err = c.MachineClient.PauseControllers(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.PauseControllersResponse) //nolint:errcheck
+
+	return
+}
+
+// ResumeControllers implements the proto.MachineServiceClient interface.
+func (c *Client) ResumeControllers(ctx context.Context, req *machineapi.ResumeControllersRequest, callOptions ...grpc.CallOption) (resp *machineapi.ResumeControllersResponse, err error) {
+	resp, err = c.MachineClient.ResumeControllers(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.ResumeControllersResponse) //nolint:errcheck
+
+	return
+}
+
+// Console implements the proto.MachineServiceClient interface.
+func (c *Client) Console(ctx context.Context, follow, tail bool) (machineapi.MachineService_ConsoleClient, error) {
+	return c.MachineClient.Console(ctx, &machineapi.ConsoleRequest{
+		Follow: follow,
+		Tail:   tail,
+	})
+}
+
+// Debug starts the debug container on the node.
+//
+// The first request sent to the stream should specify the container image, following requests stream the standard input.
+func (c *Client) Debug(ctx context.Context, callOptions ...grpc.CallOption) (machineapi.MachineService_DebugClient, error) {
+	return c.MachineClient.Debug(ctx, callOptions...)
+}
+
 // Logs implements the proto.MachineServiceClient interface.
 func (c *Client) Logs(ctx context.Context, namespace string, driver common.ContainerDriver, id string, follow bool, tailLines int32) (stream machineapi.MachineService_LogsClient, err error) {
 	stream, err = c.MachineClient.Logs(ctx, &machineapi.LogsRequest{
@@ -578,6 +693,70 @@ func (c *Client) Version(ctx context.Context, callOptions ...grpc.CallOption) (r
 	return
 }
 
+// Capabilities implements the proto.MachineServiceClient interface.
+func (c *Client) Capabilities(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.CapabilitiesResponse, err error) {
+	resp, err = c.MachineClient.Capabilities(
+		ctx,
+		&emptypb.Empty{},
+		callOptions...,
+	)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.CapabilitiesResponse) //nolint:errcheck
+
+	return
+}
+
+// SupportsFeature checks whether all the nodes in the context support the feature.
+//
+// Nodes running Talos versions without the Capabilities API are reported as not supporting any features.
+func (c *Client) SupportsFeature(ctx context.Context, feature capability.Feature, callOptions ...grpc.CallOption) (bool, error) {
+	resp, err := c.Capabilities(ctx, callOptions...)
+	if err != nil {
+		if !isUnimplemented(err) {
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	for _, msg := range resp.GetMessages() {
+		supported := false
+
+		for _, f := range msg.GetFeatures() {
+			if f == string(feature) {
+				supported = true
+
+				break
+			}
+		}
+
+		if !supported {
+			return false, nil
+		}
+	}
+
+	return len(resp.GetMessages()) > 0, nil
+}
+
+// isUnimplemented checks whether the error (or all errors for the multi-node response) is codes.Unimplemented.
+func isUnimplemented(err error) bool {
+	var multiErr *multierror.Error
+
+	if errors.As(err, &multiErr) {
+		for _, e := range multiErr.Errors {
+			if StatusCode(e) != codes.Unimplemented {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return StatusCode(err) == codes.Unimplemented
+}
+
 // Processes implements the proto.MachineServiceClient interface.
 func (c *Client) Processes(ctx context.Context, callOptions ...grpc.CallOption) (resp *machineapi.ProcessesResponse, err error) {
 	resp, err = c.MachineClient.Processes(
@@ -648,7 +827,7 @@ func (c *Client) Copy(ctx context.Context, rootPath string) (io.ReadCloser, <-ch
 // Upgrade initiates a Talos upgrade ... and implements the proto.MachineServiceClient
 // interface.
 func (c *Client) Upgrade(ctx context.Context, image string, preserve, stage, force bool, callOptions ...grpc.CallOption) (resp *machineapi.UpgradeResponse, err error) {
-	resp, err = c.MachineClient.Upgrade(
+	return c.UpgradeWithOptions(
 		ctx,
 		&machineapi.UpgradeRequest{
 			Image:    image,
@@ -658,6 +837,11 @@ func (c *Client) Upgrade(ctx context.Context, image string, preserve, stage, for
 		},
 		callOptions...,
 	)
+}
+
+// UpgradeWithOptions initiates a Talos upgrade with the upgrade request.
+func (c *Client) UpgradeWithOptions(ctx context.Context, req *machineapi.UpgradeRequest, callOptions ...grpc.CallOption) (resp *machineapi.UpgradeResponse, err error) {
+	resp, err = c.MachineClient.Upgrade(ctx, req, callOptions...)
 
 	var filtered interface{}
 	filtered, err = FilterMessages(resp, err)
@@ -921,6 +1105,17 @@ func (c *Client) GenerateClientConfiguration(ctx context.Context, req *machineap
 	return
 }
 
+// Attestation implements proto.MachineServiceClient interface.
+func (c *Client) Attestation(ctx context.Context, req *machineapi.AttestationRequest, callOptions ...grpc.CallOption) (resp *machineapi.AttestationResponse, err error) {
+	resp, err = c.MachineClient.Attestation(ctx, req, callOptions...)
+
+	var filtered interface{}
+	filtered, err = FilterMessages(resp, err)
+	resp, _ = filtered.(*machineapi.AttestationResponse) //nolint:errcheck
+
+	return
+}
+
 // MachineStream is a common interface for streams returned by streaming APIs.
 type MachineStream interface {
 	Recv() (*common.Data, error)
@@ -6,7 +6,9 @@ package config
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -44,6 +46,9 @@ func (c *Config) upgrade() {
 	}
 }
 
+// KeyStorageKeyring is the value of Context.KeyStorage for the keys stored in the OS keyring.
+const KeyStorageKeyring = "keyring"
+
 // Context represents the set of credentials required to talk to a target.
 type Context struct {
 	DeprecatedTarget string   `yaml:"target,omitempty"` // Field deprecated in favor of Endpoints
@@ -51,7 +56,75 @@ type Context struct {
 	Nodes            []string `yaml:"nodes,omitempty"`
 	CA               string   `yaml:"ca"`
 	Crt              string   `yaml:"crt"`
-	Key              string   `yaml:"key"`
+	Key              string   `yaml:"key,omitempty"`
+	KeyStorage       string   `yaml:"keyStorage,omitempty"` // Key is stored outside of the talosconfig if set
+}
+
+// KeyInKeyring returns true if the context key is stored in the OS keyring.
+func (c *Context) KeyInKeyring() bool {
+	return c.KeyStorage == KeyStorageKeyring
+}
+
+// GetKey returns the base64-encoded context key, it is read from the OS keyring if needed.
+func (c *Context) GetKey() (string, error) {
+	if !c.KeyInKeyring() {
+		return c.Key, nil
+	}
+
+	key, err := DefaultKeyring.Get(KeyringService, c.keyringAccount())
+	if err != nil {
+		return "", fmt.Errorf("error reading key from the keyring: %w", err)
+	}
+
+	return key, nil
+}
+
+// StoreKey moves the context key to the OS keyring.
+func (c *Context) StoreKey() error {
+	if c.KeyInKeyring() {
+		return nil
+	}
+
+	if c.Key == "" {
+		return fmt.Errorf("context has no key")
+	}
+
+	if err := DefaultKeyring.Set(KeyringService, c.keyringAccount(), c.Key); err != nil {
+		return fmt.Errorf("error storing key in the keyring: %w", err)
+	}
+
+	c.Key = ""
+	c.KeyStorage = KeyStorageKeyring
+
+	return nil
+}
+
+// RestoreKey moves the context key from the OS keyring back to the context.
+func (c *Context) RestoreKey() error {
+	if !c.KeyInKeyring() {
+		return nil
+	}
+
+	key, err := c.GetKey()
+	if err != nil {
+		return err
+	}
+
+	if err = DefaultKeyring.Delete(KeyringService, c.keyringAccount()); err != nil {
+		return fmt.Errorf("error removing key from the keyring: %w", err)
+	}
+
+	c.Key = key
+	c.KeyStorage = ""
+
+	return nil
+}
+
+// keyringAccount is derived from the certificate, so that the key is found even if the context is renamed.
+func (c *Context) keyringAccount() string {
+	sum := sha256.Sum256([]byte(c.Crt))
+
+	return hex.EncodeToString(sum[:])
 }
 
 func (c *Context) upgrade() {
@@ -176,6 +249,35 @@ func (c *Config) Merge(cfg *Config) []Rename {
 	return renames
 }
 
+// Export returns a config with a single context which can be used on another machine.
+//
+// Key stored in the OS keyring is copied into the exported context.
+func (c *Config) Export(name string) (*Config, error) {
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("context %q is not defined", name)
+	}
+
+	exported := *ctx
+
+	if exported.KeyInKeyring() {
+		key, err := exported.GetKey()
+		if err != nil {
+			return nil, err
+		}
+
+		exported.Key = key
+		exported.KeyStorage = ""
+	}
+
+	return &Config{
+		Context: name,
+		Contexts: map[string]*Context{
+			name: &exported,
+		},
+	}, nil
+}
+
 func ensure(filename string) (err error) {
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
 		config := &Config{
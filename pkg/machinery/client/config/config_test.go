@@ -5,9 +5,11 @@
 package config_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	clientconfig "github.com/talos-systems/talos/pkg/machinery/client/config"
 )
@@ -93,3 +95,92 @@ func TestConfigMerge(t *testing.T) {
 		})
 	}
 }
+
+type mockKeyring map[string]string
+
+func (k mockKeyring) Get(service, account string) (string, error) {
+	secret, ok := k[service+"/"+account]
+	if !ok {
+		return "", fmt.Errorf("not found")
+	}
+
+	return secret, nil
+}
+
+func (k mockKeyring) Set(service, account, secret string) error {
+	k[service+"/"+account] = secret
+
+	return nil
+}
+
+func (k mockKeyring) Delete(service, account string) error {
+	delete(k, service+"/"+account)
+
+	return nil
+}
+
+func TestContextKeyring(t *testing.T) {
+	keyring := mockKeyring{}
+
+	defaultKeyring := clientconfig.DefaultKeyring
+	clientconfig.DefaultKeyring = keyring
+
+	t.Cleanup(func() {
+		clientconfig.DefaultKeyring = defaultKeyring
+	})
+
+	c := &clientconfig.Config{
+		Context: "foo",
+		Contexts: map[string]*clientconfig.Context{
+			"foo": {
+				Endpoints: []string{"10.5.0.2"},
+				CA:        "Y2E=",
+				Crt:       "Y3J0",
+				Key:       "a2V5",
+			},
+		},
+	}
+
+	ctx := c.Contexts["foo"]
+
+	require.NoError(t, ctx.StoreKey())
+
+	assert.True(t, ctx.KeyInKeyring())
+	assert.Empty(t, ctx.Key)
+	assert.Len(t, keyring, 1)
+
+	key, err := ctx.GetKey()
+	require.NoError(t, err)
+	assert.Equal(t, "a2V5", key)
+
+	// key is found after the context is renamed
+	renamed := &clientconfig.Config{}
+	renamed.Merge(&clientconfig.Config{
+		Contexts: map[string]*clientconfig.Context{
+			"bar": ctx,
+		},
+	})
+
+	exported, err := renamed.Export("bar")
+	require.NoError(t, err)
+
+	assert.Equal(t, "bar", exported.Context)
+	assert.Equal(t, &clientconfig.Context{
+		Endpoints: []string{"10.5.0.2"},
+		CA:        "Y2E=",
+		Crt:       "Y3J0",
+		Key:       "a2V5",
+	}, exported.Contexts["bar"])
+
+	// export doesn't touch the original context
+	assert.True(t, ctx.KeyInKeyring())
+
+	_, err = renamed.Export("baz")
+	assert.EqualError(t, err, `context "baz" is not defined`)
+
+	require.NoError(t, ctx.RestoreKey())
+
+	assert.False(t, ctx.KeyInKeyring())
+	assert.Equal(t, "a2V5", ctx.Key)
+	assert.Empty(t, keyring)
+}
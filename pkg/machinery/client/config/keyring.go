@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeyringService is the service name of the context keys in the OS keyring.
+const KeyringService = "talosctl"
+
+// Keyring stores secrets in the OS keyring.
+type Keyring interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+	Delete(service, account string) error
+}
+
+// DefaultKeyring is the OS keyring used to store the context keys.
+//
+// Keychain (via `security`) is used on macOS, Secret Service (via `secret-tool` from libsecret) on other platforms.
+var DefaultKeyring Keyring = commandKeyring{}
+
+// commandKeyring implements Keyring by calling the platform keyring tools.
+//
+// Secrets are passed via stdin, so that they don't show up in the process list.
+type commandKeyring struct{}
+
+// Get implements Keyring interface.
+func (commandKeyring) Get(service, account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return runKeyringCommand("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "windows":
+		return "", fmt.Errorf("keyring is not supported on %s", runtime.GOOS)
+	default:
+		return runKeyringCommand("", "secret-tool", "lookup", "service", service, "account", account)
+	}
+}
+
+// Set implements Keyring interface.
+func (commandKeyring) Set(service, account, secret string) error {
+	var err error
+
+	switch runtime.GOOS {
+	case "darwin":
+		_, err = runKeyringCommand(fmt.Sprintf("add-generic-password -U -s %q -a %q -w %q\n", service, account, secret), "security", "-i")
+	case "windows":
+		err = fmt.Errorf("keyring is not supported on %s", runtime.GOOS)
+	default:
+		_, err = runKeyringCommand(secret, "secret-tool", "store", "--label", fmt.Sprintf("%s %s", service, account), "service", service, "account", account)
+	}
+
+	return err
+}
+
+// Delete implements Keyring interface.
+func (commandKeyring) Delete(service, account string) error {
+	var err error
+
+	switch runtime.GOOS {
+	case "darwin":
+		_, err = runKeyringCommand("", "security", "delete-generic-password", "-s", service, "-a", account)
+	case "windows":
+		err = fmt.Errorf("keyring is not supported on %s", runtime.GOOS)
+	default:
+		_, err = runKeyringCommand("", "secret-tool", "clear", "service", service, "account", account)
+	}
+
+	return err
+}
+
+func runKeyringCommand(stdin, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error running %s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -113,8 +113,13 @@ func (c *Client) EventsWatch(ctx context.Context, watchFunc func(<-chan Event),
 			&machineapi.TaskEvent{},
 			&machineapi.ServiceStateEvent{},
 			&machineapi.ConfigLoadErrorEvent{},
+			&machineapi.ConfigRevertedEvent{},
 			&machineapi.ConfigValidationErrorEvent{},
+			&machineapi.ConfigAppliedEvent{},
 			&machineapi.AddressEvent{},
+			&machineapi.ControllerFailureEvent{},
+			&machineapi.StaticPodRecoveryEvent{},
+			&machineapi.DrainEvent{},
 		} {
 			if typeURL == "talos/runtime/"+string(eventType.ProtoReflect().Descriptor().FullName()) {
 				msg = eventType
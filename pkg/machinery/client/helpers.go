@@ -0,0 +1,327 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+
+	machineapi "github.com/talos-systems/talos/pkg/machinery/api/machine"
+	"github.com/talos-systems/talos/pkg/machinery/resources/config"
+)
+
+const bootIDPath = "/proc/sys/kernel/random/boot_id"
+
+// WaitOptions configures waiting in the high-level helpers.
+type WaitOptions struct {
+	// Interval between the checks.
+	Interval time.Duration
+	// Progress is called with the reason the node is not ready yet after each failed check.
+	Progress func(err error)
+}
+
+// WaitOption sets WaitOptions.
+type WaitOption func(opts *WaitOptions)
+
+// WithWaitInterval sets the interval between the checks.
+func WithWaitInterval(interval time.Duration) WaitOption {
+	return func(opts *WaitOptions) {
+		opts.Interval = interval
+	}
+}
+
+// WithWaitProgress sets the function which is called after each failed check.
+func WithWaitProgress(progress func(err error)) WaitOption {
+	return func(opts *WaitOptions) {
+		opts.Progress = progress
+	}
+}
+
+func newWaitOptions(opts ...WaitOption) *WaitOptions {
+	options := &WaitOptions{
+		Interval: 5 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// retry calls check until it succeeds or the context is canceled.
+func retry(ctx context.Context, opts *WaitOptions, check func(ctx context.Context) error) error {
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := check(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %s)", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func nodeContext(ctx context.Context, node string) context.Context {
+	if node == "" {
+		return ctx
+	}
+
+	return WithNodes(ctx, node)
+}
+
+// WaitForNodeReady waits for the node to be reachable via the API with all the services up and healthy.
+//
+// If the node is empty, the request goes to the endpoint directly.
+// Errors (e.g. while the node is rebooting) are retried until the context is canceled.
+func (c *Client) WaitForNodeReady(ctx context.Context, node string, opts ...WaitOption) error {
+	return retry(nodeContext(ctx, node), newWaitOptions(opts...), c.checkNodeReady)
+}
+
+func (c *Client) checkNodeReady(ctx context.Context) error {
+	resp, err := c.ServiceList(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.GetMessages()) == 0 {
+		return errors.New("no response from the node")
+	}
+
+	var multiErr *multierror.Error
+
+	for _, msg := range resp.GetMessages() {
+		for _, svc := range msg.GetServices() {
+			switch svc.GetState() {
+			case "Running":
+				if health := svc.GetHealth(); health != nil && !health.GetUnknown() && !health.GetHealthy() {
+					multiErr = multierror.Append(multiErr, fmt.Errorf("service %q is not healthy: %s", svc.GetId(), health.GetLastMessage()))
+				}
+			case "Finished", "Skipped":
+			default:
+				multiErr = multierror.Append(multiErr, fmt.Errorf("service %q is in state %s", svc.GetId(), svc.GetState()))
+			}
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
+// UpgradeNodeAndWait upgrades the node and waits for it to reboot and become ready.
+//
+// The node is considered rebooted when its boot ID changes.
+func (c *Client) UpgradeNodeAndWait(ctx context.Context, node string, req *machineapi.UpgradeRequest, opts ...WaitOption) error {
+	options := newWaitOptions(opts...)
+	nodeCtx := nodeContext(ctx, node)
+
+	var bootID string
+
+	if err := retry(nodeCtx, options, func(ctx context.Context) (err error) {
+		bootID, err = c.readBootID(ctx)
+
+		return err
+	}); err != nil {
+		return fmt.Errorf("error reading boot ID: %w", err)
+	}
+
+	if _, err := c.UpgradeWithOptions(nodeCtx, req); err != nil {
+		return fmt.Errorf("error starting upgrade: %w", err)
+	}
+
+	if err := retry(nodeCtx, options, func(ctx context.Context) error {
+		newBootID, err := c.readBootID(ctx)
+		if err != nil {
+			return err
+		}
+
+		if newBootID == bootID {
+			return errors.New("node is not rebooted yet")
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error waiting for the node to reboot: %w", err)
+	}
+
+	if err := retry(nodeCtx, options, c.checkNodeReady); err != nil {
+		return fmt.Errorf("error waiting for the node to become ready: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) readBootID(ctx context.Context) (string, error) {
+	r, errCh, err := c.Read(ctx, bootIDPath)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:errcheck
+	defer r.Close()
+
+	data, err := readAllWithErrors(r, errCh)
+	if err != nil {
+		return "", err
+	}
+
+	bootID := strings.TrimSpace(string(data))
+	if bootID == "" {
+		return "", errors.New("empty boot ID")
+	}
+
+	return bootID, nil
+}
+
+func readAllWithErrors(r io.Reader, errCh <-chan error) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if _, err := copyWithErrors(&buf, r, errCh); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// copyWithErrors copies the stream returned by ReadStream and collects the errors from the error channel.
+func copyWithErrors(w io.Writer, r io.Reader, errCh <-chan error) (int64, error) {
+	streamErrCh := make(chan error, 1)
+
+	go func() {
+		var multiErr *multierror.Error
+
+		for err := range errCh {
+			multiErr = multierror.Append(multiErr, err)
+		}
+
+		streamErrCh <- multiErr.ErrorOrNil()
+	}()
+
+	n, err := io.Copy(w, r)
+
+	if streamErr := <-streamErrCh; streamErr != nil {
+		return n, streamErr
+	}
+
+	return n, err
+}
+
+// EtcdSnapshotToWriter streams the etcd snapshot from the node to the writer.
+//
+// The snapshot is verified to contain the checksum, the number of bytes written is returned.
+// The snapshot is not retried, as the writer might already contain a part of it.
+func (c *Client) EtcdSnapshotToWriter(ctx context.Context, w io.Writer, callOptions ...grpc.CallOption) (int64, error) {
+	r, errCh, err := c.EtcdSnapshot(ctx, &machineapi.EtcdSnapshotRequest{}, callOptions...)
+	if err != nil {
+		return 0, fmt.Errorf("error reading snapshot: %w", err)
+	}
+
+	//nolint:errcheck
+	defer r.Close()
+
+	size, err := copyWithErrors(w, r, errCh)
+	if err != nil {
+		return size, fmt.Errorf("error reading snapshot: %w", err)
+	}
+
+	// this check is from https://github.com/etcd-io/etcd/blob/client/v3.5.0-alpha.0/client/v3/snapshot/v3_snapshot.go#L46
+	if (size % 512) != sha256.Size {
+		return size, fmt.Errorf("sha256 checksum not found (size %d)", size)
+	}
+
+	return size, nil
+}
+
+// ApplyConfigWithDiff applies the configuration to the node and returns the unified diff
+// between the current and the new machine configuration.
+//
+// The configuration is not applied if there are no changes, nil response is returned in that case.
+// The context should target a single node.
+func (c *Client) ApplyConfigWithDiff(ctx context.Context, req *machineapi.ApplyConfigurationRequest, callOptions ...grpc.CallOption) (string, *machineapi.ApplyConfigurationResponse, error) {
+	items, err := c.Resources.Get(ctx, config.NamespaceName, config.MachineConfigType, config.V1Alpha1ID, callOptions...)
+	if err != nil {
+		return "", nil, fmt.Errorf("error getting current machine config: %w", err)
+	}
+
+	if len(items) != 1 || items[0].Resource == nil {
+		return "", nil, fmt.Errorf("expected machine config from a single node, got %d responses", len(items))
+	}
+
+	current, err := yaml.Marshal(items[0].Resource.Spec())
+	if err != nil {
+		return "", nil, err
+	}
+
+	diff, err := ConfigDiff(current, req.GetData())
+	if err != nil {
+		return "", nil, err
+	}
+
+	if diff == "" {
+		return "", nil, nil
+	}
+
+	resp, err := c.ApplyConfiguration(ctx, req, callOptions...)
+
+	return diff, resp, err
+}
+
+// ConfigDiff returns the unified diff between two machine configurations.
+//
+// Configurations are normalized before the comparison, so that comments, formatting and the order of the keys are ignored.
+func ConfigDiff(oldConfig, newConfig []byte) (string, error) {
+	oldNormalized, err := normalizeYAML(oldConfig)
+	if err != nil {
+		return "", fmt.Errorf("error parsing current config: %w", err)
+	}
+
+	newNormalized, err := normalizeYAML(newConfig)
+	if err != nil {
+		return "", fmt.Errorf("error parsing new config: %w", err)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(oldNormalized),
+		B:        difflib.SplitLines(newNormalized),
+		FromFile: "current",
+		ToFile:   "new",
+		Context:  3,
+	})
+}
+
+func normalizeYAML(in []byte) (string, error) {
+	var v interface{}
+
+	if err := yaml.Unmarshal(in, &v); err != nil {
+		return "", err
+	}
+
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
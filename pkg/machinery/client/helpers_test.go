@@ -0,0 +1,52 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package client_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/client"
+)
+
+func TestConfigDiff(t *testing.T) {
+	current := []byte(`version: v1alpha1
+machine:
+  type: worker
+  install:
+    disk: /dev/sda
+`)
+
+	// same config with comments and different key order
+	same := []byte(`# the machine
+machine:
+  install:
+    disk: /dev/sda # system disk
+  type: worker
+version: v1alpha1
+`)
+
+	diff, err := client.ConfigDiff(current, same)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	changed := []byte(`version: v1alpha1
+machine:
+  type: worker
+  install:
+    disk: /dev/nvme0n1
+`)
+
+	diff, err = client.ConfigDiff(current, changed)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- current\n+++ new\n")
+	assert.Contains(t, diff, "-        disk: /dev/sda\n")
+	assert.Contains(t, diff, "+        disk: /dev/nvme0n1\n")
+
+	_, err = client.ConfigDiff(current, []byte("machine: ["))
+	assert.Error(t, err)
+}
@@ -25,6 +25,9 @@ type ResourceResponse struct {
 	Metadata   *common.Metadata
 	Definition resource.Resource
 	Resource   resource.Resource
+
+	// ContinueToken is set in the list response with the definition if there are more resources to fetch.
+	ContinueToken string
 }
 
 // WatchResponse is a parsed resource watch response.
@@ -35,11 +38,16 @@ type WatchResponse struct {
 
 // Get a specified resource.
 func (c *ResourcesClient) Get(ctx context.Context, resourceNamespace, resourceType, resourceID string, callOptions ...grpc.CallOption) ([]ResourceResponse, error) {
-	resp, err := c.client.Get(ctx, &resourceapi.GetRequest{
+	return c.GetRequest(ctx, &resourceapi.GetRequest{
 		Namespace: resourceNamespace,
 		Type:      resourceType,
 		Id:        resourceID,
 	}, callOptions...)
+}
+
+// GetRequest gets a resource by get request.
+func (c *ResourcesClient) GetRequest(ctx context.Context, request *resourceapi.GetRequest, callOptions ...grpc.CallOption) ([]ResourceResponse, error) {
+	resp, err := c.client.Get(ctx, request, callOptions...)
 
 	var filtered interface{}
 	filtered, err = FilterMessages(resp, err)
@@ -95,6 +103,7 @@ func (client *ResourceListClient) Recv() (ResourceResponse, error) {
 	}
 
 	resourceResp.Metadata = msg.GetMetadata()
+	resourceResp.ContinueToken = msg.GetContinueToken()
 
 	if msg.GetDefinition() != nil {
 		var e error
@@ -119,10 +128,18 @@ func (client *ResourceListClient) Recv() (ResourceResponse, error) {
 
 // List resources by kind.
 func (c *ResourcesClient) List(ctx context.Context, resourceNamespace, resourceType string, callOptions ...grpc.CallOption) (*ResourceListClient, error) {
-	client, err := c.client.List(ctx, &resourceapi.ListRequest{
+	return c.ListRequest(ctx, &resourceapi.ListRequest{
 		Namespace: resourceNamespace,
 		Type:      resourceType,
 	}, callOptions...)
+}
+
+// ListRequest lists resources by list request.
+//
+// Request limit and continue token can be used to fetch the resources page by page,
+// and request fields to fetch only the listed spec fields.
+func (c *ResourcesClient) ListRequest(ctx context.Context, request *resourceapi.ListRequest, callOptions ...grpc.CallOption) (*ResourceListClient, error) {
+	client, err := c.client.List(ctx, request, callOptions...)
 
 	return &ResourceListClient{
 		grpcClient: client,
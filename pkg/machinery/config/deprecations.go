@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import "fmt"
+
+// Deprecation describes a deprecated field or value which is set in the machine configuration.
+//
+// Deprecated fields are going to be removed in the future releases, so the configuration should be migrated
+// to the replacement before the upgrade.
+type Deprecation struct {
+	// Path of the field in the configuration, e.g. `.cluster.etcd.subnet`.
+	Path string
+	// Message is a human-readable description of the deprecation.
+	Message string
+	// Replacement is the field path or the value which should be used instead, if any.
+	Replacement string
+}
+
+func (d Deprecation) String() string {
+	if d.Replacement == "" {
+		return fmt.Sprintf("%s: %s", d.Path, d.Message)
+	}
+
+	return fmt.Sprintf("%s: %s, use %s instead", d.Path, d.Message, d.Replacement)
+}
+
+// Conversion describes a change made to the machine configuration when upgrading it to the newest schema.
+type Conversion struct {
+	// Path of the converted field in the configuration.
+	Path string
+	// Message is a human-readable description of the change.
+	Message string
+	// Lossy is set if the conversion drops some configuration.
+	Lossy bool
+}
+
+func (c Conversion) String() string {
+	if c.Lossy {
+		return fmt.Sprintf("%s: %s (lossy)", c.Path, c.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", c.Path, c.Message)
+}
@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FeatureInfo describes a Talos feature which can be toggled via `.machine.features`.
+type FeatureInfo struct {
+	// Name of the feature as it appears in the machine configuration.
+	Name string
+	// Description is a short human-readable description of the feature.
+	Description string
+	// Deprecated features are going to be removed in the future releases.
+	Deprecated bool
+	// Replacement is the machine configuration field which replaces the deprecated feature.
+	Replacement string
+	// State returns whether the feature is enabled in `.machine.features` and its value.
+	State func(Features) (enabled bool, value string)
+	// MachineState returns the effective state of the feature which can also be enabled outside of `.machine.features`.
+	MachineState func(MachineConfig) (enabled bool, value string)
+}
+
+// EffectiveState returns whether the feature is enabled and its effective value.
+func (info FeatureInfo) EffectiveState(machine MachineConfig) (enabled bool, value string) {
+	if info.MachineState != nil {
+		return info.MachineState(machine)
+	}
+
+	return info.State(machine.Features())
+}
+
+// KnownFeatures lists all the features which can be toggled via `.machine.features`.
+var KnownFeatures = []FeatureInfo{
+	{
+		Name:        "rbac",
+		Description: "Role-based access control for Talos API.",
+		State:       boolFeature(Features.RBACEnabled),
+	},
+	{
+		Name:        "kubeletTrustdCredentials",
+		Description: "Kubelet client certificate is issued by trustd instead of TLS bootstrapping.",
+		State:       boolFeature(Features.KubeletTrustdCredentialsEnabled),
+	},
+	{
+		Name:        "retireStaticBootstrapToken",
+		Description: "Kubelets bootstrap only with the short-lived bootstrap tokens, the long-lived token is removed from the cluster.",
+		State:       boolFeature(Features.RetireStaticBootstrapTokenEnabled),
+	},
+	{
+		Name:        "kubeletDefaultRuntimeSeccompProfile",
+		Description: "Kubelet uses RuntimeDefault seccomp profile by default for all workloads.",
+		Deprecated:  true,
+		Replacement: ".machine.kubelet.defaultRuntimeSeccompProfileEnabled",
+		State:       boolFeature(Features.KubeletDefaultRuntimeSeccompProfileEnabled),
+		MachineState: func(m MachineConfig) (bool, string) {
+			enabled := m.Kubelet().DefaultRuntimeSeccompProfileEnabled() || m.Features().KubeletDefaultRuntimeSeccompProfileEnabled()
+
+			return enabled, strconv.FormatBool(enabled)
+		},
+	},
+	{
+		Name:        "apidTLSMinVersion",
+		Description: "Minimum TLS version accepted by apid.",
+		State: func(f Features) (bool, string) {
+			return f.APIDMinTLSVersion() != DefaultAPIDTLSMinVersion, f.APIDMinTLSVersion()
+		},
+	},
+	{
+		Name:        "stableHostname",
+		Description: "Default hostname is derived from the machine UUID.",
+		State:       boolFeature(Features.StableHostnameEnabled),
+	},
+	{
+		Name:        "controlPlaneLoadBalancer",
+		Description: "Node-local load balancer for the Kubernetes API server.",
+		State: func(f Features) (bool, string) {
+			if !f.ControlPlaneLoadBalancer().Enabled() {
+				return false, "false"
+			}
+
+			return true, fmt.Sprintf("127.0.0.1:%d", f.ControlPlaneLoadBalancer().Port())
+		},
+	},
+}
+
+// DefaultAPIDTLSMinVersion is the minimum TLS version accepted by apid by default.
+const DefaultAPIDTLSMinVersion = "1.2"
+
+func boolFeature(f func(Features) bool) func(Features) (bool, string) {
+	return func(features Features) (bool, string) {
+		enabled := f(features)
+
+		return enabled, strconv.FormatBool(enabled)
+	}
+}
+
+// FeatureWarnings returns warnings for the deprecated features which are enabled in `.machine.features`.
+func FeatureWarnings(features Features, known []FeatureInfo) []string {
+	var warnings []string
+
+	for _, feature := range known {
+		if !feature.Deprecated {
+			continue
+		}
+
+		if enabled, _ := feature.State(features); !enabled {
+			continue
+		}
+
+		warning := fmt.Sprintf("feature %q is deprecated and will be removed in a future release", feature.Name)
+
+		if feature.Replacement != "" {
+			warning += fmt.Sprintf(", use %s instead", feature.Replacement)
+		}
+
+		warnings = append(warnings, warning)
+	}
+
+	return warnings
+}
@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestFeatureWarnings(t *testing.T) {
+	t.Parallel()
+
+	known := append([]config.FeatureInfo{
+		{
+			Name:       "legacyRBAC",
+			Deprecated: true,
+			State: func(f config.Features) (bool, string) {
+				return f.RBACEnabled(), ""
+			},
+		},
+	}, config.KnownFeatures...)
+
+	assert.Empty(t, config.FeatureWarnings(&v1alpha1.FeaturesConfig{}, known))
+	assert.Empty(t, config.FeatureWarnings(&v1alpha1.FeaturesConfig{RBAC: pointer.ToBool(true)}, config.KnownFeatures))
+	assert.Equal(t,
+		[]string{`feature "legacyRBAC" is deprecated and will be removed in a future release`},
+		config.FeatureWarnings(&v1alpha1.FeaturesConfig{RBAC: pointer.ToBool(true)}, known),
+	)
+}
+
+func TestFeatureWarningsKnownFeatures(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, config.FeatureWarnings(&v1alpha1.FeaturesConfig{KubeletDefaultRuntimeSeccompProfile: pointer.ToBool(false)}, config.KnownFeatures))
+	assert.Equal(t,
+		[]string{
+			`feature "kubeletDefaultRuntimeSeccompProfile" is deprecated and will be removed in a future release, ` +
+				`use .machine.kubelet.defaultRuntimeSeccompProfileEnabled instead`,
+		},
+		config.FeatureWarnings(&v1alpha1.FeaturesConfig{KubeletDefaultRuntimeSeccompProfile: pointer.ToBool(true)}, config.KnownFeatures),
+	)
+
+	// the replacement field doesn't produce the warning
+	machineConfig := &v1alpha1.MachineConfig{
+		MachineKubelet: &v1alpha1.KubeletConfig{
+			KubeletDefaultRuntimeSeccompProfileEnabled: true,
+		},
+	}
+
+	assert.Empty(t, config.FeatureWarnings(machineConfig.Features(), config.KnownFeatures))
+}
@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KubernetesVersion is the minor version of Kubernetes.
+type KubernetesVersion struct {
+	Major int
+	Minor int
+}
+
+// ParseKubernetesVersionFromImage returns the Kubernetes version of the kubelet image from the image tag.
+//
+// If the image tag is not a version (e.g. the image is referenced by the digest only), nil is returned.
+func ParseKubernetesVersionFromImage(image string) *KubernetesVersion {
+	if idx := strings.IndexByte(image, '@'); idx >= 0 {
+		image = image[:idx]
+	}
+
+	idx := strings.LastIndexByte(image, ':')
+	if idx < 0 || idx < strings.LastIndexByte(image, '/') {
+		return nil
+	}
+
+	matches := versionRegexp.FindStringSubmatch(image[idx+1:])
+	if len(matches) < 3 {
+		return nil
+	}
+
+	var version KubernetesVersion
+
+	version.Major, _ = strconv.Atoi(matches[1]) //nolint:errcheck
+	version.Minor, _ = strconv.Atoi(matches[2]) //nolint:errcheck
+
+	return &version
+}
+
+// Less compares version to another version.
+func (version KubernetesVersion) Less(other KubernetesVersion) bool {
+	return version.Major < other.Major || (version.Major == other.Major && version.Minor < other.Minor)
+}
+
+// String implements fmt.Stringer interface.
+func (version KubernetesVersion) String() string {
+	return fmt.Sprintf("%d.%d", version.Major, version.Minor)
+}
+
+// KubeletOptionAvailability describes the Kubernetes versions which support a kubelet flag or config field.
+type KubeletOptionAvailability struct {
+	// Added is the first version supporting the option, zero value means the option is supported by all the versions.
+	Added KubernetesVersion
+	// Removed is the first version which doesn't support the option anymore, zero value means the option is not removed.
+	Removed KubernetesVersion
+}
+
+// Supported returns true if the option is supported by the Kubernetes version.
+func (availability KubeletOptionAvailability) Supported(version KubernetesVersion) bool {
+	if version.Less(availability.Added) {
+		return false
+	}
+
+	return availability.Removed == (KubernetesVersion{}) || version.Less(availability.Removed)
+}
+
+// KubeletFlags lists the kubelet flags which are not supported by all the Kubernetes versions.
+var KubeletFlags = map[string]KubeletOptionAvailability{
+	// dockershim flags
+	"cni-bin-dir":                            {Removed: KubernetesVersion{1, 24}},
+	"cni-cache-dir":                          {Removed: KubernetesVersion{1, 24}},
+	"cni-conf-dir":                           {Removed: KubernetesVersion{1, 24}},
+	"docker-endpoint":                        {Removed: KubernetesVersion{1, 24}},
+	"experimental-dockershim-root-directory": {Removed: KubernetesVersion{1, 24}},
+	"image-pull-progress-deadline":           {Removed: KubernetesVersion{1, 24}},
+	"network-plugin":                         {Removed: KubernetesVersion{1, 24}},
+	"network-plugin-mtu":                     {Removed: KubernetesVersion{1, 24}},
+	"non-masquerade-cidr":                    {Removed: KubernetesVersion{1, 24}},
+
+	"container-runtime":                 {Removed: KubernetesVersion{1, 27}},
+	"dynamic-config-dir":                {Removed: KubernetesVersion{1, 24}},
+	"image-credential-provider-bin-dir": {Added: KubernetesVersion{1, 20}},
+	"image-credential-provider-config":  {Added: KubernetesVersion{1, 20}},
+}
+
+// KubeletConfigFields lists the kubelet config fields which are not supported by all the Kubernetes versions.
+var KubeletConfigFields = map[string]KubeletOptionAvailability{
+	"containerRuntimeEndpoint":         {Added: KubernetesVersion{1, 27}},
+	"cpuManagerPolicyOptions":          {Added: KubernetesVersion{1, 22}},
+	"imageServiceEndpoint":             {Added: KubernetesVersion{1, 27}},
+	"localStorageCapacityIsolation":    {Added: KubernetesVersion{1, 25}},
+	"maxParallelImagePulls":            {Added: KubernetesVersion{1, 27}},
+	"memoryManagerPolicy":              {Added: KubernetesVersion{1, 21}},
+	"memorySwap":                       {Added: KubernetesVersion{1, 22}},
+	"reservedMemory":                   {Added: KubernetesVersion{1, 21}},
+	"seccompDefault":                   {Added: KubernetesVersion{1, 22}},
+	"shutdownGracePeriod":              {Added: KubernetesVersion{1, 20}},
+	"shutdownGracePeriodByPodPriority": {Added: KubernetesVersion{1, 23}},
+	"shutdownGracePeriodCriticalPods":  {Added: KubernetesVersion{1, 20}},
+	"topologyManagerPolicyOptions":     {Added: KubernetesVersion{1, 26}},
+}
+
+// KubeletFlagSupported returns true if the kubelet flag is supported by the Kubernetes version.
+//
+// All the flags are assumed to be supported if the version is not known.
+func KubeletFlagSupported(flag string, version *KubernetesVersion) bool {
+	return kubeletOptionSupported(KubeletFlags, flag, version)
+}
+
+// ValidateKubeletFlag returns an error if the kubelet flag is not supported by the Kubernetes version.
+func ValidateKubeletFlag(flag string, version *KubernetesVersion) error {
+	return validateKubeletOption(KubeletFlags, "flag", flag, version)
+}
+
+// ValidateKubeletConfigField returns an error if the kubelet config field is not supported by the Kubernetes version.
+func ValidateKubeletConfigField(field string, version *KubernetesVersion) error {
+	return validateKubeletOption(KubeletConfigFields, "config field", field, version)
+}
+
+func kubeletOptionSupported(options map[string]KubeletOptionAvailability, option string, version *KubernetesVersion) bool {
+	availability, ok := options[option]
+	if !ok || version == nil {
+		return true
+	}
+
+	return availability.Supported(*version)
+}
+
+func validateKubeletOption(options map[string]KubeletOptionAvailability, kind, option string, version *KubernetesVersion) error {
+	if kubeletOptionSupported(options, option, version) {
+		return nil
+	}
+
+	availability := options[option]
+
+	if version.Less(availability.Added) {
+		return fmt.Errorf("kubelet %s %q requires Kubernetes %s or later, kubelet image version is %s", kind, option, availability.Added, version)
+	}
+
+	return fmt.Errorf("kubelet %s %q was removed in Kubernetes %s, kubelet image version is %s", kind, option, availability.Removed, version)
+}
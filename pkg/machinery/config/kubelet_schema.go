@@ -0,0 +1,446 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// KubeletOptionType is the type of the kubelet flag or config field value.
+type KubeletOptionType int
+
+// Kubelet option types.
+const (
+	KubeletOptionString KubeletOptionType = iota
+	KubeletOptionBool
+	KubeletOptionInt
+	KubeletOptionFloat
+	KubeletOptionDuration
+	KubeletOptionList
+	KubeletOptionObject
+)
+
+// String implements fmt.Stringer interface.
+func (typ KubeletOptionType) String() string {
+	return [...]string{"a string", "a boolean", "an integer", "a number", "a duration", "a list", "an object"}[typ]
+}
+
+// KubeletSchemaVersion is the Kubernetes version the kubelet schema was taken from.
+//
+// Newer kubelet versions might support options which are not in the schema, so unknown options are accepted for them.
+var KubeletSchemaVersion = KubernetesVersion{1, 23}
+
+// KubeletFlagTypes is the schema of the kubelet command-line flags.
+//
+// Flags with list and map values are described as strings, as they are passed to the kubelet as is.
+var KubeletFlagTypes = map[string]KubeletOptionType{
+	"add-dir-header":                               KubeletOptionBool,
+	"address":                                      KubeletOptionString,
+	"allowed-unsafe-sysctls":                       KubeletOptionString,
+	"alsologtostderr":                              KubeletOptionBool,
+	"anonymous-auth":                               KubeletOptionBool,
+	"application-metrics-count-limit":              KubeletOptionInt,
+	"authentication-token-webhook":                 KubeletOptionBool,
+	"authentication-token-webhook-cache-ttl":       KubeletOptionDuration,
+	"authorization-mode":                           KubeletOptionString,
+	"authorization-webhook-cache-authorized-ttl":   KubeletOptionDuration,
+	"authorization-webhook-cache-unauthorized-ttl": KubeletOptionDuration,
+	"azure-container-registry-config":              KubeletOptionString,
+	"boot-id-file":                                 KubeletOptionString,
+	"bootstrap-kubeconfig":                         KubeletOptionString,
+	"cert-dir":                                     KubeletOptionString,
+	"cgroup-driver":                                KubeletOptionString,
+	"cgroup-root":                                  KubeletOptionString,
+	"cgroups-per-qos":                              KubeletOptionBool,
+	"client-ca-file":                               KubeletOptionString,
+	"cloud-config":                                 KubeletOptionString,
+	"cloud-provider":                               KubeletOptionString,
+	"cluster-dns":                                  KubeletOptionString,
+	"cluster-domain":                               KubeletOptionString,
+	"cni-bin-dir":                                  KubeletOptionString,
+	"cni-cache-dir":                                KubeletOptionString,
+	"cni-conf-dir":                                 KubeletOptionString,
+	"config":                                       KubeletOptionString,
+	"container-hints":                              KubeletOptionString,
+	"container-log-max-files":                      KubeletOptionInt,
+	"container-log-max-size":                       KubeletOptionString,
+	"container-runtime":                            KubeletOptionString,
+	"container-runtime-endpoint":                   KubeletOptionString,
+	"containerd":                                   KubeletOptionString,
+	"containerd-namespace":                         KubeletOptionString,
+	"contention-profiling":                         KubeletOptionBool,
+	"cpu-cfs-quota":                                KubeletOptionBool,
+	"cpu-cfs-quota-period":                         KubeletOptionDuration,
+	"cpu-manager-policy":                           KubeletOptionString,
+	"cpu-manager-policy-options":                   KubeletOptionString,
+	"cpu-manager-reconcile-period":                 KubeletOptionDuration,
+	"docker-endpoint":                              KubeletOptionString,
+	"docker-only":                                  KubeletOptionBool,
+	"dynamic-config-dir":                           KubeletOptionString,
+	"enable-controller-attach-detach":              KubeletOptionBool,
+	"enable-debugging-handlers":                    KubeletOptionBool,
+	"enable-load-reader":                           KubeletOptionBool,
+	"enable-server":                                KubeletOptionBool,
+	"enforce-node-allocatable":                     KubeletOptionString,
+	"event-burst":                                  KubeletOptionInt,
+	"event-qps":                                    KubeletOptionInt,
+	"event-storage-age-limit":                      KubeletOptionString,
+	"event-storage-event-limit":                    KubeletOptionString,
+	"eviction-hard":                                KubeletOptionString,
+	"eviction-max-pod-grace-period":                KubeletOptionInt,
+	"eviction-minimum-reclaim":                     KubeletOptionString,
+	"eviction-pressure-transition-period":          KubeletOptionDuration,
+	"eviction-soft":                                KubeletOptionString,
+	"eviction-soft-grace-period":                   KubeletOptionString,
+	"exit-on-lock-contention":                      KubeletOptionBool,
+	"experimental-allocatable-ignore-eviction":     KubeletOptionBool,
+	"experimental-check-node-capabilities-before-mount": KubeletOptionBool,
+	"experimental-dockershim-root-directory":            KubeletOptionString,
+	"experimental-kernel-memcg-notification":            KubeletOptionBool,
+	"experimental-logging-sanitization":                 KubeletOptionBool,
+	"experimental-mounter-path":                         KubeletOptionString,
+	"fail-swap-on":                                      KubeletOptionBool,
+	"feature-gates":                                     KubeletOptionString,
+	"file-check-frequency":                              KubeletOptionDuration,
+	"global-housekeeping-interval":                      KubeletOptionDuration,
+	"hairpin-mode":                                      KubeletOptionString,
+	"healthz-bind-address":                              KubeletOptionString,
+	"healthz-port":                                      KubeletOptionInt,
+	"hostname-override":                                 KubeletOptionString,
+	"housekeeping-interval":                             KubeletOptionDuration,
+	"http-check-frequency":                              KubeletOptionDuration,
+	"image-credential-provider-bin-dir":                 KubeletOptionString,
+	"image-credential-provider-config":                  KubeletOptionString,
+	"image-gc-high-threshold":                           KubeletOptionInt,
+	"image-gc-low-threshold":                            KubeletOptionInt,
+	"image-pull-progress-deadline":                      KubeletOptionDuration,
+	"image-service-endpoint":                            KubeletOptionString,
+	"iptables-drop-bit":                                 KubeletOptionInt,
+	"iptables-masquerade-bit":                           KubeletOptionInt,
+	"keep-terminated-pod-volumes":                       KubeletOptionBool,
+	"kernel-memcg-notification":                         KubeletOptionBool,
+	"kube-api-burst":                                    KubeletOptionInt,
+	"kube-api-content-type":                             KubeletOptionString,
+	"kube-api-qps":                                      KubeletOptionInt,
+	"kube-reserved":                                     KubeletOptionString,
+	"kube-reserved-cgroup":                              KubeletOptionString,
+	"kubeconfig":                                        KubeletOptionString,
+	"kubelet-cgroups":                                   KubeletOptionString,
+	"lock-file":                                         KubeletOptionString,
+	"log-backtrace-at":                                  KubeletOptionString,
+	"log-cadvisor-usage":                                KubeletOptionBool,
+	"log-dir":                                           KubeletOptionString,
+	"log-file":                                          KubeletOptionString,
+	"log-file-max-size":                                 KubeletOptionInt,
+	"log-flush-frequency":                               KubeletOptionDuration,
+	"log-json-info-buffer-size":                         KubeletOptionString,
+	"log-json-split-stream":                             KubeletOptionBool,
+	"logging-format":                                    KubeletOptionString,
+	"logtostderr":                                       KubeletOptionBool,
+	"machine-id-file":                                   KubeletOptionString,
+	"make-iptables-util-chains":                         KubeletOptionBool,
+	"manifest-url":                                      KubeletOptionString,
+	"manifest-url-header":                               KubeletOptionString,
+	"master-service-namespace":                          KubeletOptionString,
+	"max-open-files":                                    KubeletOptionInt,
+	"max-pods":                                          KubeletOptionInt,
+	"maximum-dead-containers":                           KubeletOptionInt,
+	"maximum-dead-containers-per-container":             KubeletOptionInt,
+	"memory-manager-policy":                             KubeletOptionString,
+	"minimum-container-ttl-duration":                    KubeletOptionDuration,
+	"minimum-image-ttl-duration":                        KubeletOptionDuration,
+	"network-plugin":                                    KubeletOptionString,
+	"network-plugin-mtu":                                KubeletOptionInt,
+	"node-ip":                                           KubeletOptionString,
+	"node-labels":                                       KubeletOptionString,
+	"node-status-max-images":                            KubeletOptionInt,
+	"node-status-update-frequency":                      KubeletOptionDuration,
+	"non-masquerade-cidr":                               KubeletOptionString,
+	"one-output":                                        KubeletOptionBool,
+	"oom-score-adj":                                     KubeletOptionInt,
+	"pod-cidr":                                          KubeletOptionString,
+	"pod-infra-container-image":                         KubeletOptionString,
+	"pod-manifest-path":                                 KubeletOptionString,
+	"pod-max-pids":                                      KubeletOptionInt,
+	"pods-per-core":                                     KubeletOptionInt,
+	"port":                                              KubeletOptionInt,
+	"protect-kernel-defaults":                           KubeletOptionBool,
+	"provider-id":                                       KubeletOptionString,
+	"qos-reserved":                                      KubeletOptionString,
+	"read-only-port":                                    KubeletOptionInt,
+	"register-node":                                     KubeletOptionBool,
+	"register-schedulable":                              KubeletOptionBool,
+	"register-with-taints":                              KubeletOptionString,
+	"registry-burst":                                    KubeletOptionInt,
+	"registry-qps":                                      KubeletOptionInt,
+	"reserved-cpus":                                     KubeletOptionString,
+	"reserved-memory":                                   KubeletOptionString,
+	"resolv-conf":                                       KubeletOptionString,
+	"root-dir":                                          KubeletOptionString,
+	"rotate-certificates":                               KubeletOptionBool,
+	"rotate-server-certificates":                        KubeletOptionBool,
+	"runonce":                                           KubeletOptionBool,
+	"runtime-cgroups":                                   KubeletOptionString,
+	"runtime-request-timeout":                           KubeletOptionDuration,
+	"seccomp-default":                                   KubeletOptionString,
+	"serialize-image-pulls":                             KubeletOptionBool,
+	"skip-headers":                                      KubeletOptionBool,
+	"skip-log-headers":                                  KubeletOptionBool,
+	"stderrthreshold":                                   KubeletOptionString,
+	"storage-driver-buffer-duration":                    KubeletOptionDuration,
+	"storage-driver-db":                                 KubeletOptionString,
+	"storage-driver-host":                               KubeletOptionString,
+	"storage-driver-password":                           KubeletOptionString,
+	"storage-driver-secure":                             KubeletOptionBool,
+	"storage-driver-table":                              KubeletOptionString,
+	"storage-driver-user":                               KubeletOptionString,
+	"streaming-connection-idle-timeout":                 KubeletOptionDuration,
+	"sync-frequency":                                    KubeletOptionDuration,
+	"system-cgroups":                                    KubeletOptionString,
+	"system-reserved":                                   KubeletOptionString,
+	"system-reserved-cgroup":                            KubeletOptionString,
+	"tls-cert-file":                                     KubeletOptionString,
+	"tls-cipher-suites":                                 KubeletOptionString,
+	"tls-min-version":                                   KubeletOptionString,
+	"tls-private-key-file":                              KubeletOptionString,
+	"topology-manager-policy":                           KubeletOptionString,
+	"topology-manager-scope":                            KubeletOptionString,
+	"v":                                                 KubeletOptionInt,
+	"vmodule":                                           KubeletOptionString,
+	"volume-plugin-dir":                                 KubeletOptionString,
+	"volume-stats-agg-period":                           KubeletOptionDuration,
+}
+
+// KubeletConfigFieldTypes is the schema of the kubelet configuration file (KubeletConfiguration) top-level fields.
+var KubeletConfigFieldTypes = map[string]KubeletOptionType{
+	"apiVersion":           KubeletOptionString,
+	"kind":                 KubeletOptionString,
+	"address":              KubeletOptionString,
+	"allowedUnsafeSysctls": KubeletOptionList,
+	"authentication":       KubeletOptionObject,
+	"authorization":        KubeletOptionObject,
+	"cgroupDriver":         KubeletOptionString,
+	"cgroupRoot":           KubeletOptionString,
+	"cgroupsPerQOS":        KubeletOptionBool,
+	"clusterDNS":           KubeletOptionList,
+	"clusterDomain":        KubeletOptionString,
+	"configMapAndSecretChangeDetectionStrategy": KubeletOptionString,
+	"containerLogMaxFiles":                      KubeletOptionInt,
+	"containerLogMaxSize":                       KubeletOptionString,
+	"containerRuntimeEndpoint":                  KubeletOptionString,
+	"contentType":                               KubeletOptionString,
+	"cpuCFSQuota":                               KubeletOptionBool,
+	"cpuCFSQuotaPeriod":                         KubeletOptionDuration,
+	"cpuManagerPolicy":                          KubeletOptionString,
+	"cpuManagerPolicyOptions":                   KubeletOptionObject,
+	"cpuManagerReconcilePeriod":                 KubeletOptionDuration,
+	"enableContentionProfiling":                 KubeletOptionBool,
+	"enableControllerAttachDetach":              KubeletOptionBool,
+	"enableDebugFlagsHandler":                   KubeletOptionBool,
+	"enableDebuggingHandlers":                   KubeletOptionBool,
+	"enableProfilingHandler":                    KubeletOptionBool,
+	"enableServer":                              KubeletOptionBool,
+	"enableSystemLogHandler":                    KubeletOptionBool,
+	"enforceNodeAllocatable":                    KubeletOptionList,
+	"eventBurst":                                KubeletOptionInt,
+	"eventRecordQPS":                            KubeletOptionInt,
+	"evictionHard":                              KubeletOptionObject,
+	"evictionMaxPodGracePeriod":                 KubeletOptionInt,
+	"evictionMinimumReclaim":                    KubeletOptionObject,
+	"evictionPressureTransitionPeriod":          KubeletOptionDuration,
+	"evictionSoft":                              KubeletOptionObject,
+	"evictionSoftGracePeriod":                   KubeletOptionObject,
+	"failSwapOn":                                KubeletOptionBool,
+	"featureGates":                              KubeletOptionObject,
+	"fileCheckFrequency":                        KubeletOptionDuration,
+	"hairpinMode":                               KubeletOptionString,
+	"healthzBindAddress":                        KubeletOptionString,
+	"healthzPort":                               KubeletOptionInt,
+	"httpCheckFrequency":                        KubeletOptionDuration,
+	"imageGCHighThresholdPercent":               KubeletOptionInt,
+	"imageGCLowThresholdPercent":                KubeletOptionInt,
+	"imageMinimumGCAge":                         KubeletOptionDuration,
+	"imageServiceEndpoint":                      KubeletOptionString,
+	"iptablesDropBit":                           KubeletOptionInt,
+	"iptablesMasqueradeBit":                     KubeletOptionInt,
+	"kernelMemcgNotification":                   KubeletOptionBool,
+	"kubeAPIBurst":                              KubeletOptionInt,
+	"kubeAPIQPS":                                KubeletOptionInt,
+	"kubeReserved":                              KubeletOptionObject,
+	"kubeReservedCgroup":                        KubeletOptionString,
+	"kubeletCgroups":                            KubeletOptionString,
+	"localStorageCapacityIsolation":             KubeletOptionBool,
+	"logging":                                   KubeletOptionObject,
+	"makeIPTablesUtilChains":                    KubeletOptionBool,
+	"maxOpenFiles":                              KubeletOptionInt,
+	"maxParallelImagePulls":                     KubeletOptionInt,
+	"maxPods":                                   KubeletOptionInt,
+	"memoryManagerPolicy":                       KubeletOptionString,
+	"memorySwap":                                KubeletOptionObject,
+	"memoryThrottlingFactor":                    KubeletOptionFloat,
+	"nodeLeaseDurationSeconds":                  KubeletOptionInt,
+	"nodeStatusMaxImages":                       KubeletOptionInt,
+	"nodeStatusReportFrequency":                 KubeletOptionDuration,
+	"nodeStatusUpdateFrequency":                 KubeletOptionDuration,
+	"oomScoreAdj":                               KubeletOptionInt,
+	"podCIDR":                                   KubeletOptionString,
+	"podPidsLimit":                              KubeletOptionInt,
+	"podsPerCore":                               KubeletOptionInt,
+	"port":                                      KubeletOptionInt,
+	"protectKernelDefaults":                     KubeletOptionBool,
+	"providerID":                                KubeletOptionString,
+	"qosReserved":                               KubeletOptionObject,
+	"readOnlyPort":                              KubeletOptionInt,
+	"registerNode":                              KubeletOptionBool,
+	"registerWithTaints":                        KubeletOptionList,
+	"registryBurst":                             KubeletOptionInt,
+	"registryPullQPS":                           KubeletOptionInt,
+	"reservedMemory":                            KubeletOptionList,
+	"reservedSystemCPUs":                        KubeletOptionString,
+	"resolvConf":                                KubeletOptionString,
+	"rotateCertificates":                        KubeletOptionBool,
+	"runOnce":                                   KubeletOptionBool,
+	"runtimeRequestTimeout":                     KubeletOptionDuration,
+	"seccompDefault":                            KubeletOptionBool,
+	"serializeImagePulls":                       KubeletOptionBool,
+	"serverTLSBootstrap":                        KubeletOptionBool,
+	"showHiddenMetricsForVersion":               KubeletOptionString,
+	"shutdownGracePeriod":                       KubeletOptionDuration,
+	"shutdownGracePeriodByPodPriority":          KubeletOptionList,
+	"shutdownGracePeriodCriticalPods":           KubeletOptionDuration,
+	"staticPodPath":                             KubeletOptionString,
+	"staticPodURL":                              KubeletOptionString,
+	"staticPodURLHeader":                        KubeletOptionObject,
+	"streamingConnectionIdleTimeout":            KubeletOptionDuration,
+	"syncFrequency":                             KubeletOptionDuration,
+	"systemCgroups":                             KubeletOptionString,
+	"systemReserved":                            KubeletOptionObject,
+	"systemReservedCgroup":                      KubeletOptionString,
+	"tlsCertFile":                               KubeletOptionString,
+	"tlsCipherSuites":                           KubeletOptionList,
+	"tlsMinVersion":                             KubeletOptionString,
+	"tlsPrivateKeyFile":                         KubeletOptionString,
+	"topologyManagerPolicy":                     KubeletOptionString,
+	"topologyManagerPolicyOptions":              KubeletOptionObject,
+	"topologyManagerScope":                      KubeletOptionString,
+	"volumePluginDir":                           KubeletOptionString,
+	"volumeStatsAggPeriod":                      KubeletOptionDuration,
+}
+
+// ValidateKubeletFlagValue returns an error if the kubelet flag is not known or the value doesn't match the flag type.
+//
+// Unknown flags are accepted if the kubelet version is newer than the schema version.
+func ValidateKubeletFlagValue(flag, value string, version *KubernetesVersion) error {
+	typ, ok := KubeletFlagTypes[flag]
+	if !ok {
+		if _, versioned := KubeletFlags[flag]; versioned || !kubeletSchemaApplies(version) {
+			return nil
+		}
+
+		return fmt.Errorf("kubelet flag %q is not a known kubelet %s flag", flag, KubeletSchemaVersion)
+	}
+
+	var err error
+
+	switch typ { //nolint:exhaustive
+	case KubeletOptionBool:
+		_, err = strconv.ParseBool(value)
+	case KubeletOptionInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case KubeletOptionFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case KubeletOptionDuration:
+		_, err = time.ParseDuration(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("kubelet flag %q value %q is not %s", flag, value, typ)
+	}
+
+	return nil
+}
+
+// ValidateKubeletConfigFieldValue returns an error if the kubelet config field is not known or the value doesn't match the field type.
+//
+// Unknown fields are accepted if the kubelet version is newer than the schema version.
+func ValidateKubeletConfigFieldValue(field string, value interface{}, version *KubernetesVersion) error {
+	typ, ok := KubeletConfigFieldTypes[field]
+	if !ok {
+		if !kubeletSchemaApplies(version) {
+			return nil
+		}
+
+		return fmt.Errorf("kubelet config field %q is not a known KubeletConfiguration field", field)
+	}
+
+	if s, isString := value.(string); isString && typ == KubeletOptionDuration {
+		if _, err := time.ParseDuration(s); err != nil {
+			return fmt.Errorf("kubelet config field %q value %q is not a duration", field, s)
+		}
+
+		return nil
+	}
+
+	if !kubeletConfigValueMatches(typ, value) {
+		return fmt.Errorf("kubelet config field %q should be %s, got %T", field, typ, value)
+	}
+
+	return nil
+}
+
+// kubeletSchemaApplies returns true if the kubelet schema covers all the options of the kubelet version.
+func kubeletSchemaApplies(version *KubernetesVersion) bool {
+	return version != nil && !KubeletSchemaVersion.Less(*version)
+}
+
+//nolint:gocyclo
+func kubeletConfigValueMatches(typ KubeletOptionType, value interface{}) bool {
+	switch typ {
+	case KubeletOptionString:
+		_, ok := value.(string)
+
+		return ok
+	case KubeletOptionBool:
+		_, ok := value.(bool)
+
+		return ok
+	case KubeletOptionInt:
+		switch v := value.(type) {
+		case int, int32, int64, uint, uint32, uint64:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		}
+
+		return false
+	case KubeletOptionFloat:
+		switch value.(type) {
+		case int, int32, int64, uint, uint32, uint64, float32, float64:
+			return true
+		}
+
+		return false
+	case KubeletOptionDuration:
+		_, ok := value.(string)
+
+		return ok
+	case KubeletOptionList:
+		_, ok := value.([]interface{})
+
+		return ok
+	case KubeletOptionObject:
+		switch value.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+			return true
+		}
+
+		return false
+	}
+
+	return false
+}
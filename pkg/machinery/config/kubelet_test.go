@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+func TestParseKubernetesVersionFromImage(t *testing.T) {
+	t.Parallel()
+
+	for image, expected := range map[string]*config.KubernetesVersion{
+		"ghcr.io/talos-systems/kubelet:v1.23.0-rc.0":          {1, 23},
+		"ghcr.io/talos-systems/kubelet:v1.24.1@sha256:abcdef": {1, 24},
+		"registry.local:5000/kubelet:v1.22.5":                 {1, 22},
+		"registry.local:5000/kubelet":                         nil,
+		"ghcr.io/talos-systems/kubelet:latest":                nil,
+		"ghcr.io/talos-systems/kubelet@sha256:abcdef":         nil,
+	} {
+		image, expected := image, expected
+		t.Run(image, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, expected, config.ParseKubernetesVersionFromImage(image))
+		})
+	}
+}
+
+func TestKubeletFlagSupported(t *testing.T) {
+	assert.True(t, config.KubeletFlagSupported("cni-conf-dir", &config.KubernetesVersion{1, 23}))
+	assert.False(t, config.KubeletFlagSupported("cni-conf-dir", &config.KubernetesVersion{1, 24}))
+	assert.False(t, config.KubeletFlagSupported("image-credential-provider-config", &config.KubernetesVersion{1, 19}))
+	assert.True(t, config.KubeletFlagSupported("image-credential-provider-config", &config.KubernetesVersion{1, 20}))
+	assert.True(t, config.KubeletFlagSupported("node-ip", &config.KubernetesVersion{1, 24}))
+
+	// unknown version
+	assert.True(t, config.KubeletFlagSupported("cni-conf-dir", nil))
+}
+
+func TestValidateKubeletConfigField(t *testing.T) {
+	assert.NoError(t, config.ValidateKubeletConfigField("memoryManagerPolicy", &config.KubernetesVersion{1, 21}))
+	assert.NoError(t, config.ValidateKubeletConfigField("memoryManagerPolicy", nil))
+	assert.EqualError(t, config.ValidateKubeletConfigField("memoryManagerPolicy", &config.KubernetesVersion{1, 20}),
+		"kubelet config field \"memoryManagerPolicy\" requires Kubernetes 1.21 or later, kubelet image version is 1.20")
+	assert.EqualError(t, config.ValidateKubeletFlag("container-runtime", &config.KubernetesVersion{1, 27}),
+		"kubelet flag \"container-runtime\" was removed in Kubernetes 1.27, kubelet image version is 1.27")
+}
+
+func TestValidateKubeletFlagValue(t *testing.T) {
+	version := &config.KubernetesVersion{1, 23}
+
+	assert.NoError(t, config.ValidateKubeletFlagValue("max-pods", "250", version))
+	assert.NoError(t, config.ValidateKubeletFlagValue("node-labels", "foo=bar", version))
+	assert.NoError(t, config.ValidateKubeletFlagValue("image-credential-provider-config", "/etc/config.yaml", nil))
+	assert.EqualError(t, config.ValidateKubeletFlagValue("rotate-server-certificates", "yes", version),
+		"kubelet flag \"rotate-server-certificates\" value \"yes\" is not a boolean")
+	assert.EqualError(t, config.ValidateKubeletFlagValue("sync-frequency", "1", version),
+		"kubelet flag \"sync-frequency\" value \"1\" is not a duration")
+	assert.EqualError(t, config.ValidateKubeletFlagValue("max-pod", "250", version),
+		"kubelet flag \"max-pod\" is not a known kubelet 1.23 flag")
+
+	// unknown flags are accepted for the kubelet versions newer than the schema, or if the version is not known
+	assert.NoError(t, config.ValidateKubeletFlagValue("max-pod", "250", &config.KubernetesVersion{1, 24}))
+	assert.NoError(t, config.ValidateKubeletFlagValue("max-pod", "250", nil))
+}
+
+func TestValidateKubeletConfigFieldValue(t *testing.T) {
+	version := &config.KubernetesVersion{1, 23}
+
+	assert.NoError(t, config.ValidateKubeletConfigFieldValue("maxPods", 250, version))
+	assert.NoError(t, config.ValidateKubeletConfigFieldValue("maxPods", float64(250), version))
+	assert.NoError(t, config.ValidateKubeletConfigFieldValue("shutdownGracePeriod", "30s", version))
+	assert.NoError(t, config.ValidateKubeletConfigFieldValue("featureGates", map[string]interface{}{"Foo": true}, version))
+	assert.NoError(t, config.ValidateKubeletConfigFieldValue("clusterDNS", []interface{}{"10.96.0.10"}, version))
+	assert.EqualError(t, config.ValidateKubeletConfigFieldValue("maxPods", 2.5, version),
+		"kubelet config field \"maxPods\" should be an integer, got float64")
+	assert.EqualError(t, config.ValidateKubeletConfigFieldValue("shutdownGracePeriod", "30", version),
+		"kubelet config field \"shutdownGracePeriod\" value \"30\" is not a duration")
+	assert.EqualError(t, config.ValidateKubeletConfigFieldValue("clusterDNS", "10.96.0.10", version),
+		"kubelet config field \"clusterDNS\" should be a list, got string")
+	assert.EqualError(t, config.ValidateKubeletConfigFieldValue("maxPod", 250, version),
+		"kubelet config field \"maxPod\" is not a known KubeletConfiguration field")
+	assert.NoError(t, config.ValidateKubeletConfigFieldValue("maxPod", 250, &config.KubernetesVersion{1, 30}))
+}
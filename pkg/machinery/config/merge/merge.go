@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package merge provides a deep merge of Talos config documents.
+package merge
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlMarshalerType = reflect.TypeOf((*yaml.Marshaler)(nil)).Elem()
+
+// Merge merges the right value into the left one, both should be pointers to the same type.
+//
+// Non-zero values of the right side override the left side:
+//   - structs (and pointers to structs) are merged field by field;
+//   - maps are merged key by key;
+//   - slices, scalars and values with custom YAML encoding are replaced as a whole.
+//
+// Zero values of the right side (e.g. `false` or empty string) can't unset the left side.
+// The left side might share memory with the right side after the merge.
+func Merge(left, right interface{}) error {
+	l, r := reflect.ValueOf(left), reflect.ValueOf(right)
+
+	if l.Kind() != reflect.Ptr || l.IsNil() {
+		return fmt.Errorf("left side should be a non-nil pointer, got %T", left)
+	}
+
+	if l.Type() != r.Type() {
+		return fmt.Errorf("type mismatch: %T != %T", left, right)
+	}
+
+	if r.IsNil() {
+		return nil
+	}
+
+	mergeValue(l.Elem(), r.Elem())
+
+	return nil
+}
+
+func mergeValue(left, right reflect.Value) {
+	if right.IsZero() {
+		return
+	}
+
+	if isAtomic(right.Type()) {
+		left.Set(right)
+
+		return
+	}
+
+	switch right.Kind() { //nolint:exhaustive
+	case reflect.Ptr:
+		if left.IsNil() {
+			left.Set(right)
+
+			return
+		}
+
+		mergeValue(left.Elem(), right.Elem())
+	case reflect.Struct:
+		for i := 0; i < right.NumField(); i++ {
+			mergeValue(left.Field(i), right.Field(i))
+		}
+	case reflect.Map:
+		if left.IsNil() {
+			left.Set(reflect.MakeMapWithSize(left.Type(), right.Len()))
+		}
+
+		iter := right.MapRange()
+
+		for iter.Next() {
+			left.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		left.Set(right)
+	}
+}
+
+// isAtomic returns true for the types which can't be merged field by field.
+func isAtomic(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Implements(yamlMarshalerType) || reflect.PtrTo(typ).Implements(yamlMarshalerType) {
+		return true
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).PkgPath != "" {
+			return true
+		}
+	}
+
+	return false
+}
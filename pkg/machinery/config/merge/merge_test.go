@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package merge_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/merge"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestMerge(t *testing.T) {
+	left := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType:  "worker",
+			MachineToken: "token",
+			MachineSysctls: map[string]string{
+				"a": "1",
+				"b": "2",
+			},
+			MachineCertSANs: []string{"a", "b"},
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkHostname: "left",
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{URL: &url.URL{Scheme: "https", Host: "left:6443", Path: "/left"}},
+			},
+			ClusterName: "cluster",
+		},
+	}
+
+	right := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineSysctls: map[string]string{
+				"b": "3",
+				"c": "4",
+			},
+			MachineCertSANs: []string{"c"},
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NameServers: []string{"1.1.1.1"},
+			},
+			MachineTime: &v1alpha1.TimeConfig{
+				TimeServers: []string{"pool.ntp.org"},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			ControlPlane: &v1alpha1.ControlPlaneConfig{
+				Endpoint: &v1alpha1.Endpoint{URL: &url.URL{Scheme: "https", Host: "right:6443"}},
+			},
+		},
+	}
+
+	require.NoError(t, merge.Merge(left, right))
+
+	assert.Equal(t, "v1alpha1", left.ConfigVersion)
+	assert.Equal(t, "worker", left.MachineConfig.MachineType)
+	assert.Equal(t, "token", left.MachineConfig.MachineToken)
+	assert.Equal(t, map[string]string{"a": "1", "b": "3", "c": "4"}, left.MachineConfig.MachineSysctls)
+	assert.Equal(t, []string{"c"}, left.MachineConfig.MachineCertSANs)
+	assert.Equal(t, "left", left.MachineConfig.MachineNetwork.NetworkHostname)
+	assert.Equal(t, []string{"1.1.1.1"}, left.MachineConfig.MachineNetwork.NameServers)
+	assert.Equal(t, []string{"pool.ntp.org"}, left.MachineConfig.MachineTime.TimeServers)
+	assert.Equal(t, "cluster", left.ClusterConfig.ClusterName)
+
+	// endpoint is replaced as a whole
+	assert.Equal(t, "https://right:6443", left.ClusterConfig.ControlPlane.Endpoint.String())
+}
+
+func TestMergeErrors(t *testing.T) {
+	assert.Error(t, merge.Merge(v1alpha1.Config{}, v1alpha1.Config{}))
+	assert.Error(t, merge.Merge(&v1alpha1.Config{}, &v1alpha1.MachineConfig{}))
+	assert.NoError(t, merge.Merge(&v1alpha1.Config{}, (*v1alpha1.Config)(nil)))
+}
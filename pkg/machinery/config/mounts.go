@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// SensitiveMountSources is the list of the host paths which expose machine secrets or raw memory,
+// and shouldn't be mounted into the containers unless explicitly allowed.
+var SensitiveMountSources = []string{
+	constants.SystemPath,
+	constants.DefaultCertificatesDir,
+	"/dev/mem",
+	"/dev/kmem",
+	"/dev/port",
+}
+
+// SensitiveMountSource returns the sensitive path exposed by mounting the source path.
+//
+// The source exposes a sensitive path if it is located under the sensitive path,
+// or if it contains the sensitive path (e.g. `/` or `/etc`).
+func SensitiveMountSource(source string) (string, bool) {
+	source = filepath.Clean("/" + source)
+
+	for _, sensitive := range SensitiveMountSources {
+		if isSubpath(source, sensitive) || isSubpath(sensitive, source) {
+			return sensitive, true
+		}
+	}
+
+	return "", false
+}
+
+func isSubpath(path, parent string) bool {
+	if parent == "/" || path == parent {
+		return true
+	}
+
+	return strings.HasPrefix(path, parent+"/")
+}
+
+// VolumeMountFlags is the list of the volume mount options applied as the mount flags.
+var VolumeMountFlags = []string{
+	"noatime",
+	"relatime",
+	"strictatime",
+	"nodiratime",
+	"lazytime",
+	"nodev",
+	"nosuid",
+	"noexec",
+}
+
+// volumeFilesystemOptions is the list of the XFS mount options supported for the volumes,
+// the value is true if the option requires a value.
+var volumeFilesystemOptions = map[string]bool{
+	"allocsize": true,
+	"discard":   false,
+	"nodiscard": false,
+	"inode64":   false,
+	"largeio":   false,
+	"nolargeio": false,
+	"logbufs":   true,
+	"logbsize":  true,
+	"noalign":   false,
+	"swalloc":   false,
+	"wsync":     false,
+}
+
+// SplitVolumeMountOptions validates the volume mount options, and splits them into the mount flags and the filesystem options.
+//
+// Volumes are formatted as XFS, so only the XFS options are supported. Project quota is enabled with the `prjquota` filesystem option.
+func SplitVolumeMountOptions(options []string, projectQuota bool) (flags, fsOptions []string, err error) {
+	for _, option := range options {
+		name := option
+		hasValue := false
+
+		if idx := strings.IndexByte(option, '='); idx >= 0 {
+			name = option[:idx]
+			hasValue = true
+		}
+
+		switch {
+		case isVolumeMountFlag(option):
+			flags = append(flags, option)
+		case name == "prjquota" || name == "pquota":
+			return nil, nil, fmt.Errorf("mount option %q should be configured with projectQuota", option)
+		case strings.HasPrefix(name, "compress"):
+			return nil, nil, fmt.Errorf("mount option %q is not supported, as XFS volumes don't support compression", option)
+		default:
+			requiresValue, ok := volumeFilesystemOptions[name]
+			if !ok {
+				return nil, nil, fmt.Errorf("mount option %q is not supported", option)
+			}
+
+			if requiresValue != hasValue {
+				return nil, nil, fmt.Errorf("mount option %q is invalid", option)
+			}
+
+			fsOptions = append(fsOptions, option)
+		}
+	}
+
+	if projectQuota {
+		fsOptions = append(fsOptions, "prjquota")
+	}
+
+	return flags, fsOptions, nil
+}
+
+func isVolumeMountFlag(option string) bool {
+	for _, flag := range VolumeMountFlags {
+		if option == flag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+func TestSensitiveMountSource(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		source    string
+		sensitive string
+	}{
+		{"/var/mnt/data", ""},
+		{"/var/lib/example", ""},
+		{"/systemd", ""},
+		{"/dev/memory", ""},
+		{"/system", "/system"},
+		{"/system/secrets/etcd", "/system"},
+		{"/var/../system/state", "/system"},
+		{"/etc/kubernetes/pki/ca.key", "/etc/kubernetes/pki"},
+		{"/etc/kubernetes", "/etc/kubernetes/pki"},
+		{"/dev/mem", "/dev/mem"},
+		{"/dev", "/dev/mem"},
+		{"/", "/system"},
+	} {
+		tt := tt
+
+		t.Run(tt.source, func(t *testing.T) {
+			t.Parallel()
+
+			sensitive, ok := config.SensitiveMountSource(tt.source)
+
+			assert.Equal(t, tt.sensitive != "", ok)
+			assert.Equal(t, tt.sensitive, sensitive)
+		})
+	}
+}
+
+func TestSplitVolumeMountOptions(t *testing.T) {
+	t.Parallel()
+
+	flags, fsOptions, err := config.SplitVolumeMountOptions([]string{"noatime", "nodev", "discard", "logbufs=8"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"noatime", "nodev"}, flags)
+	assert.Equal(t, []string{"discard", "logbufs=8", "prjquota"}, fsOptions)
+
+	flags, fsOptions, err = config.SplitVolumeMountOptions(nil, false)
+	assert.NoError(t, err)
+	assert.Empty(t, flags)
+	assert.Empty(t, fsOptions)
+
+	for _, tt := range []struct {
+		option string
+		err    string
+	}{
+		{"prjquota", "mount option \"prjquota\" should be configured with projectQuota"},
+		{"compress=zstd", "mount option \"compress=zstd\" is not supported, as XFS volumes don't support compression"},
+		{"nobarrier", "mount option \"nobarrier\" is not supported"},
+		{"logbufs", "mount option \"logbufs\" is invalid"},
+		{"discard=1", "mount option \"discard=1\" is invalid"},
+	} {
+		_, _, err = config.SplitVolumeMountOptions([]string{tt.option}, false)
+		assert.EqualError(t, err, tt.err)
+	}
+}
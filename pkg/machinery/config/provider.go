@@ -28,7 +28,13 @@ type Provider interface {
 	Cluster() ClusterConfig
 	// Validate checks configuration and returns warnings and fatal errors (as multierror).
 	Validate(RuntimeMode, ...ValidationOption) ([]string, error)
+	// Deprecations returns deprecated fields and values set in the configuration.
+	Deprecations() []Deprecation
 	ApplyDynamicConfig(context.Context, DynamicConfigProvider) error
+	// ResolveSecrets replaces the secret references with the values returned by the resolver.
+	//
+	// Encoded configuration keeps the secret references, so the resolved values are never stored.
+	ResolveSecrets(context.Context, SecretsResolver) error
 	String(encoderOptions ...encoder.Option) (string, error)
 	Bytes(encoderOptions ...encoder.Option) ([]byte, error)
 }
@@ -40,6 +46,7 @@ type MachineConfig interface {
 	Security() Security
 	Network() MachineNetwork
 	Disks() []Disk
+	Ephemeral() VolumeMountOptions
 	Time() Time
 	Env() Env
 	Files() ([]File, error)
@@ -52,6 +59,25 @@ type MachineConfig interface {
 	Features() Features
 	Udev() UdevConfig
 	Logging() Logging
+	ServiceHooks() []ServiceHook
+	ServiceOverrides() []ServiceOverride
+	IMA() IMA
+	APITLS() APITLS
+	BMC() BMC
+	HugePages() []HugePage
+	Kernel() Kernel
+	// LifecycleWebhook is nil if the webhook is not configured.
+	LifecycleWebhook() LifecycleWebhook
+	NodeLabelRules() []NodeLabelRule
+	NodeLabels() map[string]string
+	NodeTaints() map[string]string
+	HealthChecks() []HealthCheck
+	// Backup is nil if the backups are not configured.
+	Backup() Backup
+	CRI() CRI
+	// SecretsResolver is nil if the secrets backends are not configured.
+	SecretsResolver() SecretsResolverConfig
+	Pods() []map[string]interface{}
 }
 
 // Disk represents the options available for partitioning, formatting, and
@@ -65,6 +91,13 @@ type Disk interface {
 type Partition interface {
 	Size() uint64
 	MountPoint() string
+	VolumeMountOptions
+}
+
+// VolumeMountOptions represents the mount options of a volume.
+type VolumeMountOptions interface {
+	MountOptions() []string
+	ProjectQuota() bool
 }
 
 // Env represents a set of environment variables.
@@ -87,6 +120,7 @@ type Install interface {
 	Zero() bool
 	LegacyBIOSSupport() bool
 	WithBootloader() bool
+	AssetsURL() string
 }
 
 // Security defines the requirements for a config that pertains to security
@@ -124,6 +158,10 @@ type MachineNetwork interface {
 	Devices() []Device
 	ExtraHosts() []ExtraHost
 	KubeSpan() KubeSpan
+	AdvertisedSubnets() []string
+	IgnoredSubnets() []string
+	// Conntrack is nil if the connection tracking table is not configured.
+	Conntrack() Conntrack
 }
 
 // ExtraHost represents a host entry in /etc/hosts.
@@ -146,6 +184,36 @@ type Device interface {
 	DHCPOptions() DHCPOptions
 	VIPConfig() VIPConfig
 	WireguardConfig() WireguardConfig
+	Tuning() DeviceTuning
+	TrafficShaping() TrafficShaping
+}
+
+// TrafficShaping describes the egress traffic shaping of the interface.
+type TrafficShaping interface {
+	// Bandwidth in bits per second.
+	Bandwidth() uint64
+	Classes() []TrafficClass
+}
+
+// TrafficClass describes a traffic class.
+//
+// Bandwidth values are in bits per second, Limit is zero if the class is not capped.
+type TrafficClass interface {
+	Name() string
+	Guaranteed() uint64
+	Limit() uint64
+	Priority() int
+	Ports() []int
+	Subnets() []string
+}
+
+// DeviceTuning contains NIC queue and packet steering settings.
+type DeviceTuning interface {
+	// CombinedQueues is zero if the queue count is not changed.
+	CombinedQueues() uint32
+	IRQCPUs() string
+	RPSCPUs() string
+	XPSCPUs() string
 }
 
 // DHCPOptions represents a set of DHCP options.
@@ -244,6 +312,16 @@ type KubeSpan interface {
 	ForceRouting() bool
 }
 
+// Conntrack configures the connection tracking table.
+//
+// Zero values keep the kernel defaults.
+type Conntrack interface {
+	Max() uint32
+	Buckets() uint32
+	// KernelParams returns the sysctls to apply.
+	KernelParams() map[string]string
+}
+
 // Time defines the requirements for a config that pertains to time related
 // options.
 type Time interface {
@@ -259,21 +337,91 @@ type Kubelet interface {
 	ClusterDNS() []string
 	ExtraArgs() map[string]string
 	ExtraMounts() []specs.Mount
+	AllowUnsafeMounts() bool
+	ExtraCapabilities() []string
 	RegisterWithFQDN() bool
+	EnableServerCertRotation() bool
+	DefaultRuntimeSeccompProfileEnabled() bool
+	SystemReserved() map[string]string
+	KubeReserved() map[string]string
+	ImageGCHighThresholdPercent() int
+	ImageGCLowThresholdPercent() int
+	ContainerLogMaxSize() string
+	ContainerLogMaxFiles() int
+	CPUManagerPolicy() string
+	TopologyManagerPolicy() string
+	MemoryManagerPolicy() string
+	// ReservedSystemCPUs is empty if the reserved CPUs are not configured.
+	ReservedSystemCPUs() string
 	NodeIP() KubeletNodeIP
+	Port() int
+	HealthzPort() int
+	// CredentialProviders is nil if the image credential provider plugins are not configured.
+	CredentialProviders() KubeletCredentialProviders
+	ExtraConfig() map[string]interface{}
+	ShutdownGracePeriod() time.Duration
+	ShutdownGracePeriodCriticalPods() time.Duration
+	// Standalone kubelet doesn't register the node with the API server and runs only the static pods.
+	Standalone() bool
+	// PodCIDRs is empty if the node pod CIDRs are allocated by kube-controller-manager.
+	PodCIDRs() []string
+}
+
+// KubeletCredentialProviders defines the kubelet image credential provider plugins.
+type KubeletCredentialProviders interface {
+	BinDir() string
+	Providers() []KubeletCredentialProvider
+}
+
+// KubeletCredentialProvider defines the kubelet image credential provider plugin.
+type KubeletCredentialProvider interface {
+	Name() string
+	MatchImages() []string
+	DefaultCacheDuration() time.Duration
+	APIVersion() string
+	Args() []string
+	Env() map[string]string
 }
 
 // KubeletNodeIP defines the way node IPs are selected for the kubelet.
 type KubeletNodeIP interface {
 	ValidSubnets() []string
+	PreferredFamily() string
 }
 
+// Kubelet node IP address families.
+const (
+	// NodeIPFamilyIPv4 puts the IPv4 node IP first.
+	NodeIPFamilyIPv4 = "ipv4"
+	// NodeIPFamilyIPv6 puts the IPv6 node IP first.
+	NodeIPFamilyIPv6 = "ipv6"
+)
+
 // Registries defines the configuration for image fetching.
 type Registries interface {
 	// Mirror config by registry host (first part of image reference).
 	Mirrors() map[string]RegistryMirrorConfig
 	// Registry config (auth, TLS) by hostname.
 	Config() map[string]RegistryConfig
+	// Image pull limits.
+	Pulls() RegistryPulls
+}
+
+// RegistryPulls represents the image pull limits.
+type RegistryPulls interface {
+	// MaxConcurrentDownloads per image pull, zero means default.
+	MaxConcurrentDownloads() int
+	// BandwidthLimit in bytes per second, zero means no limit.
+	BandwidthLimit() uint64
+	// MaintenanceWindows for the upgrade image pulls, no windows means no restriction.
+	MaintenanceWindows() []MaintenanceWindow
+}
+
+// MaintenanceWindow represents a daily maintenance window.
+type MaintenanceWindow interface {
+	// Start offset from the midnight UTC.
+	Start() time.Duration
+	Duration() time.Duration
 }
 
 // RegistryMirrorConfig represents mirror configuration for a registry.
@@ -314,6 +462,7 @@ type ClusterConfig interface {
 	Proxy() Proxy
 	Scheduler() Scheduler
 	Endpoint() *url.URL
+	InternalEndpoint() *url.URL
 	Token() Token
 	CertSANs() []string
 	CA() *x509.PEMEncodedCertificateAndKey
@@ -333,6 +482,7 @@ type ClusterConfig interface {
 	AdminKubeconfig() AdminKubeconfig
 	ScheduleOnMasters() bool
 	Discovery() Discovery
+	InstanceIdentity() InstanceIdentity
 }
 
 // ClusterNetwork defines the requirements for a config that pertains to cluster
@@ -342,6 +492,10 @@ type ClusterNetwork interface {
 	PodCIDRs() []string
 	ServiceCIDRs() []string
 	DNSDomain() string
+	// PodSubnetSizeIPv4 returns the prefix length of per-node IPv4 pod CIDR (0 means Kubernetes default).
+	PodSubnetSizeIPv4() int
+	// PodSubnetSizeIPv6 returns the prefix length of per-node IPv6 pod CIDR (0 means Kubernetes default).
+	PodSubnetSizeIPv6() int
 	// APIServerIPs returns kube-apiserver IPs in the ServiceCIDR.
 	APIServerIPs() ([]net.IP, error)
 	// DNSServiceIPs returns DNS service IPs in the ServiceCIDR.
@@ -401,6 +555,8 @@ type Etcd interface {
 	CA() *x509.PEMEncodedCertificateAndKey
 	ExtraArgs() map[string]string
 	Subnet() string
+	AdvertisedSubnets() []string
+	ListenSubnets() []string
 }
 
 // Token defines the requirements for a config that pertains to Kubernetes
@@ -430,6 +586,13 @@ type AdminKubeconfig interface {
 	CertLifetime() time.Duration
 }
 
+// InstanceIdentity defines the platform instance identity verification settings.
+type InstanceIdentity interface {
+	Required() bool
+	AllowedAccounts() []string
+	AWSCertificates() []string
+}
+
 // EncryptionKey defines settings for the partition encryption key handling.
 type EncryptionKey interface {
 	Static() EncryptionKeyStatic
@@ -463,6 +626,18 @@ type SystemDiskEncryption interface {
 // Features describe individual Talos features that can be switched on or off.
 type Features interface {
 	RBACEnabled() bool
+	KubeletTrustdCredentialsEnabled() bool
+	RetireStaticBootstrapTokenEnabled() bool
+	KubeletDefaultRuntimeSeccompProfileEnabled() bool
+	APIDMinTLSVersion() string
+	StableHostnameEnabled() bool
+	ControlPlaneLoadBalancer() ControlPlaneLoadBalancer
+}
+
+// ControlPlaneLoadBalancer describes the node-local Kubernetes API server load balancer.
+type ControlPlaneLoadBalancer interface {
+	Enabled() bool
+	Port() int
 }
 
 // VolumeMount describes extra volume mount for the static pods.
@@ -510,10 +685,252 @@ type UdevConfig interface {
 // Logging describes logging configuration.
 type Logging interface {
 	Destinations() []LoggingDestination
+	RateLimits() []LoggingRateLimit
+	SessionRecording() SessionRecording
 }
 
 // LoggingDestination describes logging destination.
 type LoggingDestination interface {
 	Endpoint() *url.URL
 	Format() string
+	CA() []byte
+}
+
+// LoggingRateLimit describes log rate limit for a service.
+type LoggingRateLimit interface {
+	Service() string
+	Burst() int
+	Rate() int
+}
+
+// SessionRecording describes recording of the interactive session transcripts.
+type SessionRecording interface {
+	Enabled() bool
+	MaxBytes() int
+	Redact() []string
+}
+
+// ServiceHook describes a hook run before or after the service is started.
+type ServiceHook interface {
+	Service() string
+	Stage() string
+	Command() []string
+	Image() string
+	Timeout() time.Duration
+}
+
+// ServiceOverride describes adjustments to the system service container spec.
+type ServiceOverride interface {
+	Service() string
+	Env() Env
+	Mounts() []specs.Mount
+	Rlimits() []specs.POSIXRlimit
+}
+
+// IMA describes the IMA policy configuration.
+type IMA interface {
+	Policy() []string
+}
+
+// BMC describes the BMC integration settings.
+type BMC interface {
+	Enabled() bool
+	AllowPowerControl() bool
+}
+
+// LifecycleWebhook describes the lifecycle events webhook.
+type LifecycleWebhook interface {
+	Endpoint() *url.URL
+	Secret() []byte
+}
+
+// Backup describes the host directory backups taken before the destructive operations.
+type Backup interface {
+	S3() BackupS3
+	Paths() []BackupPath
+	BeforeReset() bool
+	BeforeUpgrade() bool
+	ContinueOnError() bool
+}
+
+// BackupS3 describes the S3-compatible backup storage.
+type BackupS3 interface {
+	// Endpoint is empty for AWS S3.
+	Endpoint() string
+	Region() string
+	Bucket() string
+	Prefix() string
+	AccessKeyID() string
+	SecretAccessKey() string
+	ForcePathStyle() bool
+}
+
+// BackupPath describes a backed up host directory.
+type BackupPath interface {
+	Path() string
+	Include() []string
+	Exclude() []string
+}
+
+// NodeLabelRule describes a node label applied based on the detected hardware.
+//
+// Empty conditions match any hardware.
+type NodeLabelRule interface {
+	Label() string
+	Value() string
+	CPUVendor() string
+	CPUFlags() []string
+	GPUVendor() string
+	GPUModel() string
+	DiskClass() string
+	// MinNICSpeed is in Mbit/s.
+	MinNICSpeed() int
+}
+
+// HealthCheck describes a user-defined node health check.
+//
+// Exactly one of HTTP, TCP and Exec is not nil.
+type HealthCheck interface {
+	Name() string
+	HTTP() HealthCheckHTTP
+	TCP() HealthCheckTCP
+	Exec() HealthCheckExec
+	Interval() time.Duration
+	Timeout() time.Duration
+	FailureThreshold() int
+	Taint() bool
+}
+
+// HealthCheckHTTP describes an HTTP health check.
+type HealthCheckHTTP interface {
+	URL() string
+	// ExpectedStatus is zero if any 2xx or 3xx status is accepted.
+	ExpectedStatus() int
+}
+
+// HealthCheckTCP describes a TCP health check.
+type HealthCheckTCP interface {
+	Address() string
+}
+
+// HealthCheckExec describes a health check command run in a container.
+type HealthCheckExec interface {
+	Kubernetes() bool
+	Container() string
+	Command() []string
+}
+
+// Kernel describes the kernel runtime settings.
+type Kernel interface {
+	CPUFrequencyGovernor() string
+	// MaxCState is -1 if the CPU idle states are not limited.
+	MaxCState() int
+	// Realtime is nil if the real-time profile is not enabled.
+	Realtime() RealtimeProfile
+}
+
+// RealtimeProfile describes the real-time tuning profile.
+type RealtimeProfile interface {
+	IsolatedCPUs() string
+	HousekeepingCPUs() string
+	KernelArgs() []string
+}
+
+// HugePage describes a hugepage reservation.
+type HugePage interface {
+	// Size is the hugepage size in bytes.
+	Size() uint64
+	Count() int
+	// NUMANode is -1 if the hugepages are not bound to a NUMA node.
+	NUMANode() int
+	SystemReserved() int
+}
+
+// APITLS describes the TLS settings of the Talos API listeners.
+type APITLS interface {
+	MinVersion() string
+	CipherSuites() []string
+	AcceptedCAs() [][]byte
+	// ServerCertificate is nil if the apid server certificate is issued by the machine CA.
+	//
+	// Certificate is followed by the intermediate CA certificates.
+	ServerCertificate() *x509.PEMEncodedCertificateAndKey
+}
+
+// CRI describes the CRI containerd plugin settings.
+type CRI interface {
+	// SandboxImage is empty if the CRI containerd plugin default is used.
+	SandboxImage() string
+	// StreamServerAddress is empty if the CRI containerd plugin default is used.
+	StreamServerAddress() string
+	// StreamServerPort is 0 if the streaming server listens on a random port.
+	StreamServerPort() int
+	// StreamIdleTimeout is 0 if the CRI containerd plugin default is used.
+	StreamIdleTimeout() time.Duration
+	// HostPathPolicy is nil if the host path policy is not enforced.
+	HostPathPolicy() HostPathPolicy
+	// ImagePolicy is nil if the image policy is not enforced.
+	ImagePolicy() ImagePolicy
+}
+
+// HostPathPolicy defines the host paths allowed to be mounted into the CRI containers.
+type HostPathPolicy interface {
+	AllowedPaths() []string
+	AllowBidirectionalPropagation() bool
+}
+
+// ImagePolicy defines the images allowed to be run in the CRI containers.
+type ImagePolicy interface {
+	Allowed() []string
+	Denied() []string
+	RequireDigest() bool
+}
+
+// SecretsResolverConfig describes the external secrets backends the secret references are resolved from.
+type SecretsResolverConfig interface {
+	Backends() []SecretsBackend
+	// CacheTTL is the duration the resolved values are reused for without querying the backend.
+	CacheTTL() time.Duration
+	// Timeout is the timeout of the secret reference resolution (with retries).
+	Timeout() time.Duration
+	// FailurePolicy is either SecretsFailurePolicyFail or SecretsFailurePolicyStale.
+	FailurePolicy() string
+}
+
+// SecretsBackend describes an external secrets backend.
+//
+// Exactly one of the backend types is not nil.
+type SecretsBackend interface {
+	Name() string
+	Vault() VaultSecretsBackend
+	AWSSecretsManager() AWSSecretsManagerBackend
+	GCPSecretManager() GCPSecretManagerBackend
+}
+
+// VaultSecretsBackend describes the HashiCorp Vault KV (version 2) secrets engine.
+//
+// Talos authenticates to Vault with the cloud platform identity of the machine, so no Vault tokens are stored in the config.
+type VaultSecretsBackend interface {
+	Endpoint() string
+	Mount() string
+	// AuthMethod is either `aws` (IAM) or `gcp` (GCE).
+	AuthMethod() string
+	AuthMount() string
+	Role() string
+	// CA is empty if the system CA bundle is used.
+	CA() []byte
+}
+
+// AWSSecretsManagerBackend describes the AWS Secrets Manager.
+//
+// Credentials are taken from the instance profile.
+type AWSSecretsManagerBackend interface {
+	Region() string
+}
+
+// GCPSecretManagerBackend describes the Google Cloud Secret Manager.
+//
+// Credentials are taken from the instance service account.
+type GCPSecretManagerBackend interface {
+	Project() string
 }
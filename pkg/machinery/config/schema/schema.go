@@ -0,0 +1,323 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package schema generates JSON Schema documents for the machine configuration.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/talos-systems/crypto/x509"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/encoder"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+// DraftURL is the JSON Schema dialect of the generated documents.
+const DraftURL = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document or a subschema.
+type Schema struct {
+	Schema          string             `json:"$schema,omitempty"`
+	Ref             string             `json:"$ref,omitempty"`
+	Title           string             `json:"title,omitempty"`
+	Description     string             `json:"description,omitempty"`
+	Type            Types              `json:"type,omitempty"`
+	Format          string             `json:"format,omitempty"`
+	ContentEncoding string             `json:"contentEncoding,omitempty"`
+	Enum            []interface{}      `json:"enum,omitempty"`
+	Examples        []interface{}      `json:"examples,omitempty"`
+	AllOf           []*Schema          `json:"allOf,omitempty"`
+	Properties      map[string]*Schema `json:"properties,omitempty"`
+	Items           *Schema            `json:"items,omitempty"`
+	Definitions     map[string]*Schema `json:"definitions,omitempty"`
+
+	// AdditionalProperties is either a bool or a *Schema.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+}
+
+// Types is a list of JSON types allowed for the value.
+type Types []string
+
+// MarshalJSON implements json.Marshaler.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// Versions returns the list of config versions the schema can be generated for.
+func Versions() []string {
+	return []string{"v1alpha1"}
+}
+
+// Generate builds the JSON Schema for the machine configuration of the specified version.
+func Generate(version string) (*Schema, error) {
+	switch version {
+	case "v1alpha1":
+		g := newGenerator(map[reflect.Type]*Schema{
+			reflect.TypeOf(v1alpha1.Base64Bytes{}):            {Type: Types{"string"}, ContentEncoding: "base64"},
+			reflect.TypeOf(v1alpha1.Endpoint{}):               {Type: Types{"string"}, Format: "uri"},
+			reflect.TypeOf(v1alpha1.InstallDiskSizeMatcher{}): {Type: Types{"string"}},
+			reflect.TypeOf(v1alpha1.InstallDiskType(0)):       {Type: Types{"string"}},
+			reflect.TypeOf(v1alpha1.DiskSize(0)):              {Type: Types{"string", "integer"}},
+		})
+
+		s := g.structSchema(reflect.TypeOf(v1alpha1.Config{}))
+
+		s.Schema = DraftURL
+		s.Title = "Talos machine configuration " + version
+		s.Properties["version"].Enum = []interface{}{version}
+		s.Definitions = g.definitions
+
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unsupported config version %q, supported versions: %s", version, strings.Join(Versions(), ", "))
+	}
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	yamlUnmarshalerType = reflect.TypeOf((*interface {
+		UnmarshalYAML(func(interface{}) error) error
+	})(nil)).Elem()
+)
+
+type generator struct {
+	definitions map[string]*Schema
+	names       map[reflect.Type]string
+	overrides   map[reflect.Type]*Schema
+}
+
+func newGenerator(overrides map[reflect.Type]*Schema) *generator {
+	g := &generator{
+		definitions: map[string]*Schema{},
+		names:       map[reflect.Type]string{},
+		overrides: map[reflect.Type]*Schema{
+			reflect.TypeOf(time.Duration(0)): {Type: Types{"string"}},
+			reflect.TypeOf(x509.PEMEncodedCertificateAndKey{}): {
+				Type: Types{"object"},
+				Properties: map[string]*Schema{
+					"crt": {Type: Types{"string"}, ContentEncoding: "base64"},
+					"key": {Type: Types{"string"}, ContentEncoding: "base64"},
+				},
+				AdditionalProperties: false,
+			},
+			reflect.TypeOf(x509.PEMEncodedKey{}): {
+				Type: Types{"object"},
+				Properties: map[string]*Schema{
+					"key": {Type: Types{"string"}, ContentEncoding: "base64"},
+				},
+				AdditionalProperties: false,
+			},
+		},
+	}
+
+	for t, s := range overrides {
+		g.overrides[t] = s
+	}
+
+	return g
+}
+
+//nolint:gocyclo
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if s, ok := g.overrides[t]; ok {
+		res := *s
+
+		return &res
+	}
+
+	switch {
+	case reflect.PtrTo(t).Implements(textUnmarshalerType):
+		return &Schema{Type: Types{"string"}}
+	case reflect.PtrTo(t).Implements(yamlUnmarshalerType):
+		// custom format which can't be derived from the type
+		return &Schema{}
+	}
+
+	//nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}}
+		}
+
+		return &Schema{Type: Types{"array"}, Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+
+		return &Schema{Ref: "#/definitions/" + g.define(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+
+	if _, taken := g.definitions[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+
+	g.names[t] = name
+	// reserve the name before descending into the fields, as types might be recursive
+	g.definitions[name] = nil
+	g.definitions[name] = g.structSchema(t)
+
+	return name
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       Types{"object"},
+		Properties: map[string]*Schema{},
+		// the config decoder rejects unknown fields
+		AdditionalProperties: false,
+	}
+
+	doc := getDoc(t)
+	if doc != nil {
+		s.Description = doc.Description
+	}
+
+	g.addProperties(s, t, doc)
+
+	return s
+}
+
+// addProperties follows the same field naming rules as the config encoder.
+//
+//nolint:gocyclo
+func (g *generator) addProperties(s *Schema, t reflect.Type, doc *encoder.Doc) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// skip unexported fields
+		if field.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(field.Tag.Get("yaml"), ",")
+		name := parts[0]
+
+		if name == "-" {
+			continue
+		}
+
+		inline := false
+
+		for _, part := range parts[1:] {
+			if part == "inline" {
+				inline = true
+			}
+		}
+
+		if inline {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				g.addProperties(s, ft, getDoc(ft))
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		prop := g.schemaFor(field.Type)
+
+		var fieldDoc *encoder.Doc
+
+		if doc != nil {
+			fieldDoc = doc.Field(i)
+		}
+
+		if fieldDoc != nil {
+			prop = describe(prop, fieldDoc)
+		}
+
+		s.Properties[name] = prop
+	}
+}
+
+// describe attaches field documentation to the property schema.
+func describe(prop *Schema, doc *encoder.Doc) *Schema {
+	var examples []interface{}
+
+	for _, value := range doc.Values {
+		examples = append(examples, strings.Trim(value, "`"))
+	}
+
+	switch {
+	case prop.Ref != "":
+		// keywords next to $ref are ignored, so wrap the reference
+		if doc.Description == "" {
+			return prop
+		}
+
+		return &Schema{
+			Description: doc.Description,
+			AllOf:       []*Schema{prop},
+		}
+	case prop.Items != nil && prop.Items.Ref == "":
+		if prop.Items.Type.is("string") {
+			prop.Items.Examples = examples
+		}
+	default:
+		if prop.Type.is("string") {
+			prop.Examples = examples
+		}
+	}
+
+	prop.Description = doc.Description
+
+	return prop
+}
+
+func (t Types) is(typ string) bool {
+	return len(t) == 1 && t[0] == typ
+}
+
+func getDoc(t reflect.Type) *encoder.Doc {
+	if d, ok := reflect.New(t).Interface().(encoder.Documented); ok {
+		return d.Doc()
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package schema_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/schema"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	s, err := schema.Generate("v1alpha1")
+	require.NoError(t, err)
+
+	assert.Equal(t, schema.DraftURL, s.Schema)
+	assert.Equal(t, []interface{}{"v1alpha1"}, s.Properties["version"].Enum)
+	assert.Equal(t, false, s.AdditionalProperties)
+
+	machine := s.Definitions["MachineConfig"]
+	require.NotNil(t, machine)
+
+	assert.Equal(t, schema.Types{"string"}, machine.Properties["type"].Type)
+	assert.Contains(t, machine.Properties["type"].Examples, "controlplane")
+	assert.NotEmpty(t, machine.Properties["type"].Description)
+
+	assert.Equal(t, "#/definitions/InstallConfig", machine.Properties["install"].AllOf[0].Ref)
+	assert.Equal(t, schema.Types{"object"}, machine.Properties["env"].Type)
+
+	// inline fields are flattened
+	assert.Contains(t, s.Definitions["ExtraMount"].Properties, "destination")
+
+	// fields with the custom YAML encoding
+	assert.Equal(t, schema.Types{"string"}, s.Definitions["InstallDiskSelector"].Properties["size"].Type)
+	assert.Equal(t, schema.Types{"string"}, s.Definitions["TimeConfig"].Properties["bootTimeout"].Type)
+	assert.Equal(t, schema.Types{"string"}, s.Definitions["ControlPlaneConfig"].Properties["endpoint"].Type)
+
+	// all references resolve
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	var doc interface{}
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	var walk func(v interface{})
+
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if ref, ok := v["$ref"].(string); ok {
+				assert.Contains(t, s.Definitions, strings.TrimPrefix(ref, "#/definitions/"))
+			}
+
+			for _, child := range v {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+
+	walk(doc)
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := schema.Generate("v1alpha2")
+	assert.EqualError(t, err, `unsupported config version "v1alpha2", supported versions: v1alpha1`)
+}
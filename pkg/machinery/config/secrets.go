@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SecretReferencePrefix is the prefix of the machine config values resolved from the external secrets backends.
+const SecretReferencePrefix = "secret://"
+
+// Secrets resolution failure policies.
+const (
+	// SecretsFailurePolicyFail fails the config load if any of the secret references can't be resolved.
+	SecretsFailurePolicyFail = "fail"
+	// SecretsFailurePolicyStale falls back to the previously resolved (expired) values kept in memory.
+	SecretsFailurePolicyStale = "stale"
+)
+
+// SecretReference is a reference to the value stored in the external secrets backend.
+//
+// References have the form `secret://<backend>/<path>[#<key>]`, where the backend is the name
+// of the backend in the `.machine.secretsResolver` section, and the key selects the field of the secret.
+type SecretReference struct {
+	Backend string
+	Path    string
+	Key     string
+}
+
+// IsSecretReference returns true if the value is a secret reference.
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretReferencePrefix)
+}
+
+// ParseSecretReference parses the secret reference.
+func ParseSecretReference(value string) (*SecretReference, error) {
+	if !IsSecretReference(value) {
+		return nil, fmt.Errorf("secret reference %q should start with %q", value, SecretReferencePrefix)
+	}
+
+	ref := strings.TrimPrefix(value, SecretReferencePrefix)
+
+	var key string
+
+	if idx := strings.LastIndex(ref, "#"); idx != -1 {
+		ref, key = ref[:idx], ref[idx+1:]
+
+		if key == "" {
+			return nil, fmt.Errorf("secret reference %q has an empty key", value)
+		}
+	}
+
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || strings.Trim(parts[1], "/") == "" {
+		return nil, fmt.Errorf("secret reference %q should have the form %s<backend>/<path>[#<key>]", value, SecretReferencePrefix)
+	}
+
+	return &SecretReference{
+		Backend: parts[0],
+		Path:    strings.Trim(parts[1], "/"),
+		Key:     key,
+	}, nil
+}
+
+// String implements fmt.Stringer.
+func (ref *SecretReference) String() string {
+	s := SecretReferencePrefix + ref.Backend + "/" + ref.Path
+
+	if ref.Key != "" {
+		s += "#" + ref.Key
+	}
+
+	return s
+}
+
+// SecretsResolver resolves the secret references to the values.
+type SecretsResolver interface {
+	Resolve(context.Context, *SecretReference) (string, error)
+}
@@ -0,0 +1,53 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		value    string
+		expected *config.SecretReference
+	}{
+		{
+			value:    "secret://vault/talos/cluster#token",
+			expected: &config.SecretReference{Backend: "vault", Path: "talos/cluster", Key: "token"},
+		},
+		{
+			value:    "secret://aws/arn:aws:secretsmanager:us-east-1:123456789012:secret:backup-AbCdEf",
+			expected: &config.SecretReference{Backend: "aws", Path: "arn:aws:secretsmanager:us-east-1:123456789012:secret:backup-AbCdEf"},
+		},
+		{
+			value:    "secret://gcp/backup/versions/3#secretAccessKey",
+			expected: &config.SecretReference{Backend: "gcp", Path: "backup/versions/3", Key: "secretAccessKey"},
+		},
+	} {
+		ref, err := config.ParseSecretReference(tt.value)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.expected, ref)
+		assert.Equal(t, tt.value, ref.String())
+	}
+
+	for _, value := range []string{
+		"vault/talos/cluster",
+		"secret://vault",
+		"secret://vault/",
+		"secret:///talos/cluster",
+		"secret://vault/talos/cluster#",
+	} {
+		_, err := config.ParseSecretReference(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -557,6 +557,11 @@ func randBootstrapTokenString(length int) (string, error) {
 	return string(token), err
 }
 
+// NewBootstrapToken generates a new Kubernetes bootstrap token in the `abcdef.0123456789abcdef` format.
+func NewBootstrapToken() (string, error) {
+	return genToken(6, 16)
+}
+
 // genToken will generate a token of the format abc.123 (like kubeadm/trustd), where the length of the first string (before the dot)
 // and length of the second string (after dot) are specified as inputs.
 func genToken(lenFirst, lenSecond int) (string, error) {
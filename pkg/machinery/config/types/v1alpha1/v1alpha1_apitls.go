@@ -0,0 +1,152 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"bytes"
+	"crypto/tls"
+	stdx509 "crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/talos-systems/crypto/x509"
+)
+
+// Validate checks API TLS settings for errors.
+//
+// Machine CA is used to verify the externally signed server certificate.
+func (a APITLSConfig) Validate(machineCA *x509.PEMEncodedCertificateAndKey) error {
+	var errs *multierror.Error
+
+	switch a.APITLSMinVersion {
+	case "", "1.2", "1.3":
+	default:
+		errs = multierror.Append(errs, fmt.Errorf("unsupported API TLS min version %q", a.APITLSMinVersion))
+	}
+
+	supported := map[string]struct{}{}
+
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.Name] = struct{}{}
+	}
+
+	for _, name := range a.APITLSCipherSuites {
+		if _, ok := supported[name]; !ok {
+			errs = multierror.Append(errs, fmt.Errorf("unsupported API TLS cipher suite %q", name))
+		}
+	}
+
+	for i, ca := range a.APITLSAcceptedCAs {
+		block, _ := pem.Decode(ca)
+		if block == nil || block.Type != "CERTIFICATE" {
+			errs = multierror.Append(errs, fmt.Errorf("API TLS accepted CA #%d is not a PEM-encoded certificate", i))
+
+			continue
+		}
+
+		if _, err := stdx509.ParseCertificate(block.Bytes); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("API TLS accepted CA #%d is invalid: %w", i, err))
+		}
+	}
+
+	if a.APITLSServerCertificate != nil {
+		roots := stdx509.NewCertPool()
+
+		if machineCA != nil {
+			roots.AppendCertsFromPEM(machineCA.Crt)
+		}
+
+		for _, ca := range a.APITLSAcceptedCAs {
+			roots.AppendCertsFromPEM(ca)
+		}
+
+		if err := a.APITLSServerCertificate.verify(roots); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("API TLS server certificate is invalid: %w", err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// verify checks that the certificate matches the key and is signed by one of the roots.
+func (c *APITLSCertificateConfig) verify(roots *stdx509.CertPool) error {
+	keyPair, err := tls.X509KeyPair(c.CertificateCrt, c.CertificateKey)
+	if err != nil {
+		return err
+	}
+
+	leaf, err := stdx509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return err
+	}
+
+	intermediates := stdx509.NewCertPool()
+
+	for i, crt := range c.CertificateChain {
+		if !intermediates.AppendCertsFromPEM(crt) {
+			return fmt.Errorf("chain certificate #%d is not a PEM-encoded certificate", i)
+		}
+	}
+
+	// verify at the certificate issue time, as the machine clock might not be synchronized yet
+	_, err = leaf.Verify(stdx509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   leaf.NotBefore,
+		KeyUsages:     []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("certificate chain doesn't lead to the machine CA or accepted CAs: %w", err)
+	}
+
+	return nil
+}
+
+// MinVersion implements the config.APITLS interface.
+func (a APITLSConfig) MinVersion() string {
+	return a.APITLSMinVersion
+}
+
+// CipherSuites implements the config.APITLS interface.
+func (a APITLSConfig) CipherSuites() []string {
+	return a.APITLSCipherSuites
+}
+
+// AcceptedCAs implements the config.APITLS interface.
+func (a APITLSConfig) AcceptedCAs() [][]byte {
+	res := make([][]byte, 0, len(a.APITLSAcceptedCAs))
+
+	for _, ca := range a.APITLSAcceptedCAs {
+		res = append(res, ca)
+	}
+
+	return res
+}
+
+// ServerCertificate implements the config.APITLS interface.
+func (a APITLSConfig) ServerCertificate() *x509.PEMEncodedCertificateAndKey {
+	if a.APITLSServerCertificate == nil {
+		return nil
+	}
+
+	// TLS server certificate is followed by the intermediate CA certificates
+	crt := bytes.Join(append([][]byte{a.APITLSServerCertificate.CertificateCrt}, a.APITLSServerCertificate.chain()...), []byte("\n"))
+
+	return &x509.PEMEncodedCertificateAndKey{
+		Crt: crt,
+		Key: a.APITLSServerCertificate.CertificateKey,
+	}
+}
+
+func (c *APITLSCertificateConfig) chain() [][]byte {
+	res := make([][]byte, 0, len(c.CertificateChain))
+
+	for _, crt := range c.CertificateChain {
+		res = append(res, crt)
+	}
+
+	return res
+}
@@ -0,0 +1,124 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talos-systems/crypto/x509"
+
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+type testCertificate struct {
+	crt    *stdx509.Certificate
+	crtPEM []byte
+	key    ed25519.PrivateKey
+	keyPEM []byte
+}
+
+func issueCertificate(t *testing.T, template *stdx509.Certificate, parent *testCertificate) *testCertificate {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parentCrt, parentKey := template, key
+
+	if parent != nil {
+		parentCrt, parentKey = parent.crt, parent.key
+	}
+
+	der, err := stdx509.CreateCertificate(rand.Reader, template, parentCrt, pub, parentKey)
+	require.NoError(t, err)
+
+	crt, err := stdx509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := stdx509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		crt:    crt,
+		crtPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:    key,
+		keyPEM: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func issueCA(t *testing.T, name string, parent *testCertificate) *testCertificate {
+	return issueCertificate(t, &stdx509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              stdx509.KeyUsageCertSign,
+	}, parent)
+}
+
+func TestAPITLSServerCertificate(t *testing.T) {
+	t.Parallel()
+
+	machineCA := issueCA(t, "talos", nil)
+	externalCA := issueCA(t, "external", nil)
+	intermediateCA := issueCA(t, "intermediate", externalCA)
+
+	server := issueCertificate(t, &stdx509.Certificate{
+		Subject:     pkix.Name{CommonName: "node"},
+		DNSNames:    []string{"node.example.com"},
+		KeyUsage:    stdx509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth},
+	}, intermediateCA)
+
+	apiTLS := v1alpha1.APITLSConfig{
+		APITLSAcceptedCAs: []v1alpha1.Base64Bytes{externalCA.crtPEM},
+		APITLSServerCertificate: &v1alpha1.APITLSCertificateConfig{
+			CertificateCrt:   server.crtPEM,
+			CertificateKey:   server.keyPEM,
+			CertificateChain: []v1alpha1.Base64Bytes{intermediateCA.crtPEM},
+		},
+	}
+
+	machineCAPEM := &x509.PEMEncodedCertificateAndKey{Crt: machineCA.crtPEM, Key: machineCA.keyPEM}
+
+	assert.NoError(t, apiTLS.Validate(machineCAPEM))
+
+	serverCertificate := apiTLS.ServerCertificate()
+	require.NotNil(t, serverCertificate)
+	assert.Equal(t, bytes.Join([][]byte{server.crtPEM, intermediateCA.crtPEM}, []byte("\n")), serverCertificate.Crt)
+	assert.Equal(t, server.keyPEM, serverCertificate.Key)
+
+	// external CA is not accepted
+	apiTLS.APITLSAcceptedCAs = nil
+
+	assert.EqualError(t, apiTLS.Validate(machineCAPEM), "1 error occurred:\n\t* API TLS server certificate is invalid: "+
+		"certificate chain doesn't lead to the machine CA or accepted CAs: x509: certificate signed by unknown authority\n\n")
+
+	// chain is missing
+	apiTLS.APITLSAcceptedCAs = []v1alpha1.Base64Bytes{externalCA.crtPEM}
+	apiTLS.APITLSServerCertificate.CertificateChain = nil
+
+	assert.Error(t, apiTLS.Validate(machineCAPEM))
+
+	// key doesn't match the certificate
+	apiTLS.APITLSServerCertificate.CertificateChain = []v1alpha1.Base64Bytes{intermediateCA.crtPEM}
+	apiTLS.APITLSServerCertificate.CertificateKey = intermediateCA.keyPEM
+
+	assert.EqualError(t, apiTLS.Validate(machineCAPEM), "1 error occurred:\n\t* API TLS server certificate is invalid: "+
+		"tls: private key does not match public key\n\n")
+
+	assert.Nil(t, v1alpha1.APITLSConfig{}.ServerCertificate())
+}
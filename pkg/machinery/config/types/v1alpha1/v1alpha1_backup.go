@@ -0,0 +1,185 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+const (
+	backupBeforeReset   = "reset"
+	backupBeforeUpgrade = "upgrade"
+)
+
+// Validate checks backup settings for errors.
+//
+//nolint:gocyclo
+func (b BackupConfig) Validate() error {
+	var errs *multierror.Error
+
+	if b.BackupS3 == nil {
+		errs = multierror.Append(errs, fmt.Errorf("backup S3 storage is required"))
+	} else {
+		errs = multierror.Append(errs, b.BackupS3.Validate())
+	}
+
+	if len(b.BackupPaths) == 0 {
+		errs = multierror.Append(errs, fmt.Errorf("backup paths are required"))
+	}
+
+	for _, p := range b.BackupPaths {
+		if !filepath.IsAbs(p.PathPath) || !strings.HasPrefix(filepath.Clean(p.PathPath), "/var/") {
+			errs = multierror.Append(errs, fmt.Errorf("backup path %q should be an absolute path under /var", p.PathPath))
+		}
+
+		for _, pattern := range append(append([]string(nil), p.PathInclude...), p.PathExclude...) {
+			// "**" is a superset of "*", so checking the pattern with single stars is enough
+			if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil || pattern == "" {
+				errs = multierror.Append(errs, fmt.Errorf("invalid backup pattern %q for path %q", pattern, p.PathPath))
+			}
+		}
+	}
+
+	for _, before := range b.BackupBefore {
+		if before != backupBeforeReset && before != backupBeforeUpgrade {
+			errs = multierror.Append(errs, fmt.Errorf("unsupported backup operation %q, expected %q or %q", before, backupBeforeReset, backupBeforeUpgrade))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// S3 implements the config.Backup interface.
+func (b *BackupConfig) S3() config.BackupS3 {
+	if b.BackupS3 == nil {
+		return nil
+	}
+
+	return b.BackupS3
+}
+
+// Paths implements the config.Backup interface.
+func (b *BackupConfig) Paths() []config.BackupPath {
+	paths := make([]config.BackupPath, len(b.BackupPaths))
+
+	for i := range b.BackupPaths {
+		paths[i] = b.BackupPaths[i]
+	}
+
+	return paths
+}
+
+// BeforeReset implements the config.Backup interface.
+func (b *BackupConfig) BeforeReset() bool {
+	return b.before(backupBeforeReset)
+}
+
+// BeforeUpgrade implements the config.Backup interface.
+func (b *BackupConfig) BeforeUpgrade() bool {
+	return b.before(backupBeforeUpgrade)
+}
+
+func (b *BackupConfig) before(operation string) bool {
+	if len(b.BackupBefore) == 0 {
+		return true
+	}
+
+	for _, before := range b.BackupBefore {
+		if before == operation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContinueOnError implements the config.Backup interface.
+func (b *BackupConfig) ContinueOnError() bool {
+	return b.BackupContinueOnError
+}
+
+// Validate checks backup S3 storage settings for errors.
+func (s BackupS3Config) Validate() error {
+	var errs *multierror.Error
+
+	if s.S3Endpoint != "" {
+		u, err := url.Parse(s.S3Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = multierror.Append(errs, fmt.Errorf("backup S3 endpoint %q should be an http(s) URL", s.S3Endpoint))
+		}
+	}
+
+	if s.S3Region == "" {
+		errs = multierror.Append(errs, fmt.Errorf("backup S3 region is required"))
+	}
+
+	if s.S3Bucket == "" {
+		errs = multierror.Append(errs, fmt.Errorf("backup S3 bucket is required"))
+	}
+
+	if s.S3AccessKeyID == "" || s.S3SecretAccessKey == "" {
+		errs = multierror.Append(errs, fmt.Errorf("backup S3 credentials are required"))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Endpoint implements the config.BackupS3 interface.
+func (s *BackupS3Config) Endpoint() string {
+	return s.S3Endpoint
+}
+
+// Region implements the config.BackupS3 interface.
+func (s *BackupS3Config) Region() string {
+	return s.S3Region
+}
+
+// Bucket implements the config.BackupS3 interface.
+func (s *BackupS3Config) Bucket() string {
+	return s.S3Bucket
+}
+
+// Prefix implements the config.BackupS3 interface.
+func (s *BackupS3Config) Prefix() string {
+	return s.S3Prefix
+}
+
+// AccessKeyID implements the config.BackupS3 interface.
+func (s *BackupS3Config) AccessKeyID() string {
+	return s.S3AccessKeyID
+}
+
+// SecretAccessKey implements the config.BackupS3 interface.
+func (s *BackupS3Config) SecretAccessKey() string {
+	return s.S3SecretAccessKey
+}
+
+// ForcePathStyle implements the config.BackupS3 interface.
+func (s *BackupS3Config) ForcePathStyle() bool {
+	return s.S3ForcePathStyle
+}
+
+// Path implements the config.BackupPath interface.
+func (p BackupPathConfig) Path() string {
+	return p.PathPath
+}
+
+// Include implements the config.BackupPath interface.
+func (p BackupPathConfig) Include() []string {
+	return p.PathInclude
+}
+
+// Exclude implements the config.BackupPath interface.
+func (p BackupPathConfig) Exclude() []string {
+	return p.PathExclude
+}
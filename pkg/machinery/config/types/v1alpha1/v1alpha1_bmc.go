@@ -0,0 +1,26 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import "fmt"
+
+// Validate checks BMC settings for errors.
+func (b BMCConfig) Validate() error {
+	if b.BMCAllowPowerControl && !b.BMCEnabled {
+		return fmt.Errorf("BMC power control requires BMC integration to be enabled")
+	}
+
+	return nil
+}
+
+// Enabled implements the config.BMC interface.
+func (b BMCConfig) Enabled() bool {
+	return b.BMCEnabled
+}
+
+// AllowPowerControl implements the config.BMC interface.
+func (b BMCConfig) AllowPowerControl() bool {
+	return b.BMCAllowPowerControl
+}
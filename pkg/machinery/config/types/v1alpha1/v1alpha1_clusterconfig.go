@@ -66,6 +66,15 @@ func (c *ClusterConfig) Endpoint() *url.URL {
 	return c.ControlPlane.Endpoint.URL
 }
 
+// InternalEndpoint implements the config.ClusterConfig interface.
+func (c *ClusterConfig) InternalEndpoint() *url.URL {
+	if c.ControlPlane.InternalEndpoint == nil || c.ControlPlane.InternalEndpoint.URL == nil {
+		return c.Endpoint()
+	}
+
+	return c.ControlPlane.InternalEndpoint.URL
+}
+
 // Token implements the config.ClusterConfig interface.
 func (c *ClusterConfig) Token() config.Token {
 	return clusterToken(c.BootstrapToken)
@@ -239,6 +248,24 @@ func (c *ClusterConfig) DNSDomain() string {
 	return c.ClusterNetwork.DNSDomain
 }
 
+// PodSubnetSizeIPv4 implements the config.ClusterNetwork interface.
+func (c *ClusterConfig) PodSubnetSizeIPv4() int {
+	if c.ClusterNetwork == nil || c.ClusterNetwork.PodSubnetSize == nil {
+		return 0
+	}
+
+	return c.ClusterNetwork.PodSubnetSize.IPv4
+}
+
+// PodSubnetSizeIPv6 implements the config.ClusterNetwork interface.
+func (c *ClusterConfig) PodSubnetSizeIPv6() int {
+	if c.ClusterNetwork == nil || c.ClusterNetwork.PodSubnetSize == nil {
+		return 0
+	}
+
+	return c.ClusterNetwork.PodSubnetSize.IPv6
+}
+
 // APIServerIPs implements the config.ClusterNetwork interface.
 func (c *ClusterConfig) APIServerIPs() ([]net.IP, error) {
 	serviceCIDRs, err := talosnet.SplitCIDRs(strings.Join(c.ServiceCIDRs(), ","))
@@ -264,6 +291,15 @@ func (c *ClusterConfig) Discovery() config.Discovery {
 	return c.ClusterDiscoveryConfig
 }
 
+// InstanceIdentity implements the config.ClusterConfig interface.
+func (c *ClusterConfig) InstanceIdentity() config.InstanceIdentity {
+	if c.ClusterInstanceIdentity == nil {
+		return &InstanceIdentityConfig{}
+	}
+
+	return c.ClusterInstanceIdentity
+}
+
 type clusterToken string
 
 // ID implements the config.Token interface.
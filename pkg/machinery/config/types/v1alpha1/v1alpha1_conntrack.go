@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	conntrackMaxSysctl     = "net.netfilter.nf_conntrack_max"
+	conntrackBucketsSysctl = "net.netfilter.nf_conntrack_buckets"
+)
+
+// Validate checks connection tracking settings for conflicts with the machine sysctls.
+func (c ConntrackConfig) Validate(sysctls map[string]string) error {
+	var errs *multierror.Error
+
+	for key := range c.KernelParams() {
+		if _, ok := sysctls[key]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("sysctl %q conflicts with the conntrack settings", key))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Max implements the config.Conntrack interface.
+func (c ConntrackConfig) Max() uint32 {
+	return c.ConntrackMax
+}
+
+// Buckets implements the config.Conntrack interface.
+func (c ConntrackConfig) Buckets() uint32 {
+	return c.ConntrackBuckets
+}
+
+// KernelParams implements the config.Conntrack interface.
+func (c ConntrackConfig) KernelParams() map[string]string {
+	params := map[string]string{}
+
+	if c.ConntrackMax > 0 {
+		params[conntrackMaxSysctl] = strconv.FormatUint(uint64(c.ConntrackMax), 10)
+	}
+
+	if c.ConntrackBuckets > 0 {
+		params[conntrackBucketsSysctl] = strconv.FormatUint(uint64(c.ConntrackBuckets), 10)
+	}
+
+	return params
+}
@@ -0,0 +1,137 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// Validate checks CRI settings for errors.
+func (c *CRIConfig) Validate() error {
+	var errs *multierror.Error
+
+	if c.CRIStreamServerAddress != "" && net.ParseIP(c.CRIStreamServerAddress) == nil {
+		errs = multierror.Append(errs, fmt.Errorf("CRI stream server address %q is not a valid IP address", c.CRIStreamServerAddress))
+	}
+
+	if c.CRIStreamServerPort < 0 || c.CRIStreamServerPort > 65535 {
+		errs = multierror.Append(errs, fmt.Errorf("CRI stream server port %d is out of range", c.CRIStreamServerPort))
+	}
+
+	if c.CRIStreamIdleTimeout < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("CRI stream idle timeout should not be negative"))
+	}
+
+	if c.CRIHostPathPolicy != nil {
+		if err := c.CRIHostPathPolicy.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if c.CRIImagePolicy != nil {
+		if err := c.CRIImagePolicy.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SandboxImage implements the config.CRI interface.
+func (c *CRIConfig) SandboxImage() string {
+	return c.CRISandboxImage
+}
+
+// StreamServerAddress implements the config.CRI interface.
+func (c *CRIConfig) StreamServerAddress() string {
+	return c.CRIStreamServerAddress
+}
+
+// StreamServerPort implements the config.CRI interface.
+func (c *CRIConfig) StreamServerPort() int {
+	return c.CRIStreamServerPort
+}
+
+// StreamIdleTimeout implements the config.CRI interface.
+func (c *CRIConfig) StreamIdleTimeout() time.Duration {
+	return c.CRIStreamIdleTimeout
+}
+
+// HostPathPolicy implements the config.CRI interface.
+func (c *CRIConfig) HostPathPolicy() config.HostPathPolicy {
+	if c.CRIHostPathPolicy == nil {
+		return nil
+	}
+
+	return c.CRIHostPathPolicy
+}
+
+// ImagePolicy implements the config.CRI interface.
+func (c *CRIConfig) ImagePolicy() config.ImagePolicy {
+	if c.CRIImagePolicy == nil {
+		return nil
+	}
+
+	return c.CRIImagePolicy
+}
+
+// Validate checks host path policy for errors.
+func (p *HostPathPolicyConfig) Validate() error {
+	var errs *multierror.Error
+
+	for _, path := range p.HostPathAllowedPaths {
+		if !filepath.IsAbs(path) || filepath.Clean(path) != path {
+			errs = multierror.Append(errs, fmt.Errorf("allowed host path %q should be an absolute clean path", path))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// AllowedPaths implements the config.HostPathPolicy interface.
+func (p *HostPathPolicyConfig) AllowedPaths() []string {
+	return p.HostPathAllowedPaths
+}
+
+// AllowBidirectionalPropagation implements the config.HostPathPolicy interface.
+func (p *HostPathPolicyConfig) AllowBidirectionalPropagation() bool {
+	return p.HostPathAllowBidirectionalPropagation
+}
+
+// Validate checks image policy for errors.
+func (p *ImagePolicyConfig) Validate() error {
+	var errs *multierror.Error
+
+	for _, image := range append(append([]string(nil), p.ImageAllowed...), p.ImageDenied...) {
+		if strings.TrimSpace(image) == "" {
+			errs = multierror.Append(errs, fmt.Errorf("image policy entry should not be empty"))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Allowed implements the config.ImagePolicy interface.
+func (p *ImagePolicyConfig) Allowed() []string {
+	return p.ImageAllowed
+}
+
+// Denied implements the config.ImagePolicy interface.
+func (p *ImagePolicyConfig) Denied() []string {
+	return p.ImageDenied
+}
+
+// RequireDigest implements the config.ImagePolicy interface.
+func (p *ImagePolicyConfig) RequireDigest() bool {
+	return p.ImageRequireDigest
+}
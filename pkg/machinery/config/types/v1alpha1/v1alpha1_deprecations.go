@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+)
+
+// Deprecations implements the config.Provider interface.
+func (c *Config) Deprecations() []config.Deprecation {
+	var deprecations []config.Deprecation
+
+	if c.MachineConfig != nil {
+		deprecations = append(deprecations, c.MachineConfig.deprecations()...)
+	}
+
+	if c.ClusterConfig != nil && c.ClusterConfig.EtcdConfig != nil && c.ClusterConfig.EtcdConfig.EtcdSubnet != "" {
+		deprecations = append(deprecations, config.Deprecation{
+			Path:        ".cluster.etcd.subnet",
+			Message:     "field is deprecated",
+			Replacement: ".cluster.etcd.advertisedSubnets",
+		})
+	}
+
+	return deprecations
+}
+
+func (m *MachineConfig) deprecations() []config.Deprecation {
+	var deprecations []config.Deprecation
+
+	if t := m.Type(); t != machine.TypeUnknown && t.String() != m.MachineType {
+		deprecations = append(deprecations, config.Deprecation{
+			Path:        ".machine.type",
+			Message:     fmt.Sprintf("machine type %q is deprecated", m.MachineType),
+			Replacement: fmt.Sprintf("%q", t.String()),
+		})
+	}
+
+	if m.MachineNetwork != nil {
+		for i, device := range m.MachineNetwork.NetworkInterfaces {
+			if device == nil {
+				continue
+			}
+
+			if device.DeviceCIDR != "" {
+				deprecations = append(deprecations, config.Deprecation{
+					Path:        fmt.Sprintf(".machine.network.interfaces[%d].cidr", i),
+					Message:     "field is deprecated",
+					Replacement: fmt.Sprintf(".machine.network.interfaces[%d].addresses", i),
+				})
+			}
+
+			for j, vlan := range device.DeviceVlans {
+				if vlan != nil && vlan.VlanCIDR != "" {
+					deprecations = append(deprecations, config.Deprecation{
+						Path:        fmt.Sprintf(".machine.network.interfaces[%d].vlans[%d].cidr", i, j),
+						Message:     "field is deprecated",
+						Replacement: fmt.Sprintf(".machine.network.interfaces[%d].vlans[%d].addresses", i, j),
+					})
+				}
+			}
+		}
+	}
+
+	for _, feature := range config.KnownFeatures {
+		if !feature.Deprecated {
+			continue
+		}
+
+		if enabled, _ := feature.State(m.Features()); enabled {
+			deprecations = append(deprecations, config.Deprecation{
+				Path:        ".machine.features." + feature.Name,
+				Message:     "feature is deprecated and will be removed in a future release",
+				Replacement: feature.Replacement,
+			})
+		}
+	}
+
+	return deprecations
+}
@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestDeprecations(t *testing.T) {
+	t.Parallel()
+
+	cfg := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "join",
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: "eth0",
+						DeviceAddresses: []string{"192.168.0.1/24"},
+					},
+					{
+						DeviceInterface: "eth1",
+						DeviceCIDR:      "10.0.0.1/8",
+						DeviceVlans: []*v1alpha1.Vlan{
+							{
+								VlanID:   10,
+								VlanCIDR: "10.10.0.1/16",
+							},
+						},
+					},
+				},
+			},
+			MachineFeatures: &v1alpha1.FeaturesConfig{
+				KubeletDefaultRuntimeSeccompProfile: pointer.ToBool(true),
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			EtcdConfig: &v1alpha1.EtcdConfig{
+				EtcdSubnet: "10.0.0.0/8",
+			},
+		},
+	}
+
+	deprecations := cfg.Deprecations()
+
+	assert.Equal(t, []config.Deprecation{
+		{
+			Path:        ".machine.type",
+			Message:     `machine type "join" is deprecated`,
+			Replacement: `"worker"`,
+		},
+		{
+			Path:        ".machine.network.interfaces[1].cidr",
+			Message:     "field is deprecated",
+			Replacement: ".machine.network.interfaces[1].addresses",
+		},
+		{
+			Path:        ".machine.network.interfaces[1].vlans[0].cidr",
+			Message:     "field is deprecated",
+			Replacement: ".machine.network.interfaces[1].vlans[0].addresses",
+		},
+		{
+			Path:        ".machine.features.kubeletDefaultRuntimeSeccompProfile",
+			Message:     "feature is deprecated and will be removed in a future release",
+			Replacement: ".machine.kubelet.defaultRuntimeSeccompProfileEnabled",
+		},
+		{
+			Path:        ".cluster.etcd.subnet",
+			Message:     "field is deprecated",
+			Replacement: ".cluster.etcd.advertisedSubnets",
+		},
+	}, deprecations)
+
+	assert.Equal(t, `.machine.type: machine type "join" is deprecated, use "worker" instead`, deprecations[0].String())
+
+	assert.Empty(t, (&v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "worker",
+		},
+	}).Deprecations())
+}
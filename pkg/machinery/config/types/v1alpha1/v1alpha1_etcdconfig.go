@@ -47,3 +47,17 @@ func (e *EtcdConfig) ExtraArgs() map[string]string {
 func (e *EtcdConfig) Subnet() string {
 	return e.EtcdSubnet
 }
+
+// AdvertisedSubnets implements the config.Etcd interface.
+func (e *EtcdConfig) AdvertisedSubnets() []string {
+	if e.EtcdSubnet != "" {
+		return []string{e.EtcdSubnet}
+	}
+
+	return e.EtcdAdvertisedSubnets
+}
+
+// ListenSubnets implements the config.Etcd interface.
+func (e *EtcdConfig) ListenSubnets() []string {
+	return e.EtcdListenSubnets
+}
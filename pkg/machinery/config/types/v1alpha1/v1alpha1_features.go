@@ -4,6 +4,11 @@
 
 package v1alpha1
 
+import (
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
 // RBACEnabled implements config.Features interface.
 func (f *FeaturesConfig) RBACEnabled() bool {
 	if f.RBAC == nil {
@@ -12,3 +17,75 @@ func (f *FeaturesConfig) RBACEnabled() bool {
 
 	return *f.RBAC
 }
+
+// KubeletTrustdCredentialsEnabled implements config.Features interface.
+func (f *FeaturesConfig) KubeletTrustdCredentialsEnabled() bool {
+	if f.KubeletTrustdCredentials == nil {
+		return false
+	}
+
+	return *f.KubeletTrustdCredentials
+}
+
+// RetireStaticBootstrapTokenEnabled implements config.Features interface.
+func (f *FeaturesConfig) RetireStaticBootstrapTokenEnabled() bool {
+	if f.RetireStaticBootstrapToken == nil {
+		return false
+	}
+
+	return *f.RetireStaticBootstrapToken
+}
+
+// KubeletDefaultRuntimeSeccompProfileEnabled implements config.Features interface.
+func (f *FeaturesConfig) KubeletDefaultRuntimeSeccompProfileEnabled() bool {
+	if f.KubeletDefaultRuntimeSeccompProfile == nil {
+		return false
+	}
+
+	return *f.KubeletDefaultRuntimeSeccompProfile
+}
+
+// StableHostnameEnabled implements config.Features interface.
+func (f *FeaturesConfig) StableHostnameEnabled() bool {
+	if f.StableHostname == nil {
+		return false
+	}
+
+	return *f.StableHostname
+}
+
+// ControlPlaneLoadBalancer implements config.Features interface.
+func (f *FeaturesConfig) ControlPlaneLoadBalancer() config.ControlPlaneLoadBalancer {
+	if f.ControlPlaneLoadBalancerSupport == nil {
+		return &ControlPlaneLoadBalancerConfig{}
+	}
+
+	return f.ControlPlaneLoadBalancerSupport
+}
+
+// Enabled implements config.ControlPlaneLoadBalancer interface.
+func (c *ControlPlaneLoadBalancerConfig) Enabled() bool {
+	if c.ControlPlaneLoadBalancerEnabled == nil {
+		return false
+	}
+
+	return *c.ControlPlaneLoadBalancerEnabled
+}
+
+// Port implements config.ControlPlaneLoadBalancer interface.
+func (c *ControlPlaneLoadBalancerConfig) Port() int {
+	if c.ControlPlaneLoadBalancerPort == 0 {
+		return constants.DefaultControlPlaneLoadBalancerPort
+	}
+
+	return c.ControlPlaneLoadBalancerPort
+}
+
+// APIDMinTLSVersion implements config.Features interface.
+func (f *FeaturesConfig) APIDMinTLSVersion() string {
+	if f.APIDTLSMinVersion == "" {
+		return config.DefaultAPIDTLSMinVersion
+	}
+
+	return f.APIDTLSMinVersion
+}
@@ -0,0 +1,177 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// healthCheckNameRegexp is the DNS label format, name is used as the node taint key.
+var healthCheckNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Validate checks health check configuration for errors.
+//
+//nolint:gocyclo,cyclop
+func (c HealthCheckConfig) Validate() error {
+	var errs *multierror.Error
+
+	if len(c.CheckName) > 63 || !healthCheckNameRegexp.MatchString(c.CheckName) {
+		errs = multierror.Append(errs, fmt.Errorf("health check name %q should be a valid DNS label", c.CheckName))
+	}
+
+	checks := 0
+
+	if c.CheckHTTP != nil {
+		checks++
+
+		u, err := url.Parse(c.CheckHTTP.HTTPURL)
+
+		switch {
+		case err != nil:
+			errs = multierror.Append(errs, fmt.Errorf("health check %q URL is invalid: %w", c.CheckName, err))
+		case (u.Scheme != "http" && u.Scheme != "https") || u.Host == "":
+			errs = multierror.Append(errs, fmt.Errorf("health check %q URL should be an absolute http or https URL", c.CheckName))
+		}
+
+		if c.CheckHTTP.HTTPExpectedStatus != 0 && (c.CheckHTTP.HTTPExpectedStatus < 100 || c.CheckHTTP.HTTPExpectedStatus > 599) {
+			errs = multierror.Append(errs, fmt.Errorf("health check %q expected status %d is invalid", c.CheckName, c.CheckHTTP.HTTPExpectedStatus))
+		}
+	}
+
+	if c.CheckTCP != nil {
+		checks++
+
+		if _, port, err := net.SplitHostPort(c.CheckTCP.TCPAddress); err != nil || port == "" {
+			errs = multierror.Append(errs, fmt.Errorf("health check %q address %q should be in host:port format", c.CheckName, c.CheckTCP.TCPAddress))
+		}
+	}
+
+	if c.CheckExec != nil {
+		checks++
+
+		if c.CheckExec.ExecContainer == "" {
+			errs = multierror.Append(errs, fmt.Errorf("health check %q container is required", c.CheckName))
+		}
+
+		if len(c.CheckExec.ExecCommand) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("health check %q command is required", c.CheckName))
+		}
+	}
+
+	if checks != 1 {
+		errs = multierror.Append(errs, fmt.Errorf("health check %q should have exactly one of http, tcp or exec", c.CheckName))
+	}
+
+	if c.CheckInterval < 0 || c.CheckTimeout < 0 || c.CheckFailureThreshold < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("health check %q interval, timeout and failure threshold should not be negative", c.CheckName))
+	} else if c.Timeout() > c.Interval() {
+		errs = multierror.Append(errs, fmt.Errorf("health check %q timeout %s should not exceed the interval %s", c.CheckName, c.Timeout(), c.Interval()))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Name implements the config.HealthCheck interface.
+func (c HealthCheckConfig) Name() string {
+	return c.CheckName
+}
+
+// HTTP implements the config.HealthCheck interface.
+func (c HealthCheckConfig) HTTP() config.HealthCheckHTTP {
+	if c.CheckHTTP == nil {
+		return nil
+	}
+
+	return c.CheckHTTP
+}
+
+// TCP implements the config.HealthCheck interface.
+func (c HealthCheckConfig) TCP() config.HealthCheckTCP {
+	if c.CheckTCP == nil {
+		return nil
+	}
+
+	return c.CheckTCP
+}
+
+// Exec implements the config.HealthCheck interface.
+func (c HealthCheckConfig) Exec() config.HealthCheckExec {
+	if c.CheckExec == nil {
+		return nil
+	}
+
+	return c.CheckExec
+}
+
+// Interval implements the config.HealthCheck interface.
+func (c HealthCheckConfig) Interval() time.Duration {
+	if c.CheckInterval == 0 {
+		return constants.DefaultHealthCheckInterval
+	}
+
+	return c.CheckInterval
+}
+
+// Timeout implements the config.HealthCheck interface.
+func (c HealthCheckConfig) Timeout() time.Duration {
+	if c.CheckTimeout == 0 {
+		return constants.DefaultHealthCheckTimeout
+	}
+
+	return c.CheckTimeout
+}
+
+// FailureThreshold implements the config.HealthCheck interface.
+func (c HealthCheckConfig) FailureThreshold() int {
+	if c.CheckFailureThreshold == 0 {
+		return constants.DefaultHealthCheckFailureThreshold
+	}
+
+	return c.CheckFailureThreshold
+}
+
+// Taint implements the config.HealthCheck interface.
+func (c HealthCheckConfig) Taint() bool {
+	return c.CheckTaint
+}
+
+// URL implements the config.HealthCheckHTTP interface.
+func (c *HealthCheckHTTPConfig) URL() string {
+	return c.HTTPURL
+}
+
+// ExpectedStatus implements the config.HealthCheckHTTP interface.
+func (c *HealthCheckHTTPConfig) ExpectedStatus() int {
+	return c.HTTPExpectedStatus
+}
+
+// Address implements the config.HealthCheckTCP interface.
+func (c *HealthCheckTCPConfig) Address() string {
+	return c.TCPAddress
+}
+
+// Kubernetes implements the config.HealthCheckExec interface.
+func (c *HealthCheckExecConfig) Kubernetes() bool {
+	return c.ExecKubernetes
+}
+
+// Container implements the config.HealthCheckExec interface.
+func (c *HealthCheckExecConfig) Container() string {
+	return c.ExecContainer
+}
+
+// Command implements the config.HealthCheckExec interface.
+func (c *HealthCheckExecConfig) Command() []string {
+	return c.ExecCommand
+}
@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Validate checks hugepage reservation for errors.
+func (h HugePageConfig) Validate() error {
+	var errs *multierror.Error
+
+	size, err := humanize.ParseBytes(h.HugePageSize)
+
+	switch {
+	case err != nil:
+		errs = multierror.Append(errs, fmt.Errorf("invalid hugepage size %q: %w", h.HugePageSize, err))
+	case size < humanize.KiByte || size&(size-1) != 0:
+		errs = multierror.Append(errs, fmt.Errorf("hugepage size %q should be a power of two in binary units (e.g. 2Mi, 1Gi)", h.HugePageSize))
+	}
+
+	if h.HugePageCount < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("negative hugepage count for size %q", h.HugePageSize))
+	}
+
+	if h.HugePageNUMANode != nil && *h.HugePageNUMANode < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("negative NUMA node for hugepage size %q", h.HugePageSize))
+	}
+
+	if h.HugePageSystemReserved != 0 {
+		if h.HugePageNUMANode == nil {
+			errs = multierror.Append(errs, fmt.Errorf("system reserved hugepages of size %q require NUMA node to be set", h.HugePageSize))
+		}
+
+		if h.HugePageSystemReserved < 0 || h.HugePageSystemReserved > h.HugePageCount {
+			errs = multierror.Append(errs, fmt.Errorf("system reserved hugepages of size %q should be between 0 and the hugepage count", h.HugePageSize))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Size implements the config.HugePage interface.
+func (h HugePageConfig) Size() uint64 {
+	size, _ := humanize.ParseBytes(h.HugePageSize) //nolint:errcheck
+
+	return size
+}
+
+// Count implements the config.HugePage interface.
+func (h HugePageConfig) Count() int {
+	return h.HugePageCount
+}
+
+// NUMANode implements the config.HugePage interface.
+func (h HugePageConfig) NUMANode() int {
+	if h.HugePageNUMANode == nil {
+		return -1
+	}
+
+	return *h.HugePageNUMANode
+}
+
+// SystemReserved implements the config.HugePage interface.
+func (h HugePageConfig) SystemReserved() int {
+	return h.HugePageSystemReserved
+}
@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// imaPolicyActions are the actions supported in the IMA policy rules.
+var imaPolicyActions = map[string]struct{}{
+	"measure":       {},
+	"dont_measure":  {},
+	"appraise":      {},
+	"dont_appraise": {},
+	"audit":         {},
+	"hash":          {},
+	"dont_hash":     {},
+}
+
+// Validate checks IMA policy for errors.
+func (i IMAConfig) Validate() error {
+	var errs *multierror.Error
+
+	for _, rule := range i.IMAPolicy {
+		if strings.ContainsAny(rule, "\n\r") {
+			errs = multierror.Append(errs, fmt.Errorf("IMA policy rule %q should be a single line", rule))
+
+			continue
+		}
+
+		fields := strings.Fields(rule)
+
+		if len(fields) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("IMA policy rule can't be empty"))
+
+			continue
+		}
+
+		if _, ok := imaPolicyActions[fields[0]]; !ok {
+			errs = multierror.Append(errs, fmt.Errorf("IMA policy rule %q has unknown action %q", rule, fields[0]))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Policy implements the config.IMA interface.
+func (i IMAConfig) Policy() []string {
+	return i.IMAPolicy
+}
@@ -0,0 +1,52 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Required implements the config.InstanceIdentity interface.
+func (c *InstanceIdentityConfig) Required() bool {
+	return c.InstanceIdentityRequired
+}
+
+// AllowedAccounts implements the config.InstanceIdentity interface.
+func (c *InstanceIdentityConfig) AllowedAccounts() []string {
+	return c.InstanceIdentityAllowedAccounts
+}
+
+// AWSCertificates implements the config.InstanceIdentity interface.
+func (c *InstanceIdentityConfig) AWSCertificates() []string {
+	return c.InstanceIdentityAWSCertificates
+}
+
+// Validate the instance identity configuration.
+func (c *InstanceIdentityConfig) Validate() error {
+	var result *multierror.Error
+
+	if c.InstanceIdentityRequired && len(c.InstanceIdentityAllowedAccounts) == 0 {
+		result = multierror.Append(result, fmt.Errorf("instance identity allowed accounts should be set when the instance identity is required"))
+	}
+
+	for i, certificate := range c.InstanceIdentityAWSCertificates {
+		block, _ := pem.Decode([]byte(certificate))
+		if block == nil {
+			result = multierror.Append(result, fmt.Errorf("instance identity AWS certificate %d is not PEM-encoded", i))
+
+			continue
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			result = multierror.Append(result, fmt.Errorf("instance identity AWS certificate %d is invalid: %w", i, err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+)
+
+// cpuFrequencyGovernors is the list of CPU frequency scaling governors implemented by the kernel.
+var cpuFrequencyGovernors = map[string]struct{}{
+	"conservative": {},
+	"ondemand":     {},
+	"performance":  {},
+	"powersave":    {},
+	"schedutil":    {},
+	"userspace":    {},
+}
+
+// Validate checks kernel runtime settings for errors.
+func (k KernelConfig) Validate() error {
+	var errs *multierror.Error
+
+	if k.KernelCPUFrequencyGovernor != "" {
+		if _, ok := cpuFrequencyGovernors[k.KernelCPUFrequencyGovernor]; !ok {
+			errs = multierror.Append(errs, fmt.Errorf("unknown CPU frequency governor %q", k.KernelCPUFrequencyGovernor))
+		}
+	}
+
+	if k.KernelMaxCState != nil && *k.KernelMaxCState < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("negative max C-state"))
+	}
+
+	if k.KernelRealtime != nil {
+		errs = multierror.Append(errs, k.KernelRealtime.Validate())
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// CPUFrequencyGovernor implements the config.Kernel interface.
+func (k KernelConfig) CPUFrequencyGovernor() string {
+	return k.KernelCPUFrequencyGovernor
+}
+
+// MaxCState implements the config.Kernel interface.
+func (k KernelConfig) MaxCState() int {
+	if k.KernelMaxCState == nil {
+		return -1
+	}
+
+	return *k.KernelMaxCState
+}
+
+// Realtime implements the config.Kernel interface.
+func (k KernelConfig) Realtime() config.RealtimeProfile {
+	if k.KernelRealtime == nil {
+		return nil
+	}
+
+	return k.KernelRealtime
+}
+
+// realtimeKernelArgs is the list of kernel arguments managed by the real-time profile.
+var realtimeKernelArgs = []string{"isolcpus", "nohz_full", "rcu_nocbs", "irqaffinity"}
+
+// Validate checks the real-time profile CPU lists for consistency.
+func (r RealtimeProfileConfig) Validate() error {
+	var errs *multierror.Error
+
+	isolated, err := kernel.ParseCPUList(r.RealtimeIsolatedCPUs)
+	if err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("real-time profile isolated CPUs: %w", err))
+	}
+
+	housekeeping, err := kernel.ParseCPUList(r.RealtimeHousekeepingCPUs)
+	if err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("real-time profile housekeeping CPUs: %w", err))
+	}
+
+	housekeepingSet := make(map[int]struct{}, len(housekeeping))
+
+	for _, cpu := range housekeeping {
+		housekeepingSet[cpu] = struct{}{}
+	}
+
+	for _, cpu := range isolated {
+		if _, ok := housekeepingSet[cpu]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("real-time profile CPU %d is both isolated and housekeeping", cpu))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// IsolatedCPUs implements the config.RealtimeProfile interface.
+func (r *RealtimeProfileConfig) IsolatedCPUs() string {
+	return r.RealtimeIsolatedCPUs
+}
+
+// HousekeepingCPUs implements the config.RealtimeProfile interface.
+func (r *RealtimeProfileConfig) HousekeepingCPUs() string {
+	return r.RealtimeHousekeepingCPUs
+}
+
+// KernelArgs implements the config.RealtimeProfile interface.
+func (r *RealtimeProfileConfig) KernelArgs() []string {
+	return []string{
+		"isolcpus=managed_irq,domain," + r.RealtimeIsolatedCPUs,
+		"nohz_full=" + r.RealtimeIsolatedCPUs,
+		"rcu_nocbs=" + r.RealtimeIsolatedCPUs,
+		"irqaffinity=" + r.RealtimeHousekeepingCPUs,
+	}
+}
@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Validate checks lifecycle webhook settings for errors.
+func (w LifecycleWebhookConfig) Validate() error {
+	var errs *multierror.Error
+
+	switch {
+	case w.WebhookEndpoint == nil || w.WebhookEndpoint.URL == nil:
+		errs = multierror.Append(errs, fmt.Errorf("lifecycle webhook endpoint is required"))
+	case w.WebhookEndpoint.Scheme != "https":
+		errs = multierror.Append(errs, fmt.Errorf("lifecycle webhook endpoint should use https, got %q", w.WebhookEndpoint.Scheme))
+	case w.WebhookEndpoint.Host == "":
+		errs = multierror.Append(errs, fmt.Errorf("lifecycle webhook endpoint host is empty"))
+	}
+
+	if w.WebhookSecret == "" {
+		errs = multierror.Append(errs, fmt.Errorf("lifecycle webhook secret is required"))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Endpoint implements the config.LifecycleWebhook interface.
+func (w LifecycleWebhookConfig) Endpoint() *url.URL {
+	if w.WebhookEndpoint == nil {
+		return nil
+	}
+
+	return w.WebhookEndpoint.URL
+}
+
+// Secret implements the config.LifecycleWebhook interface.
+func (w LifecycleWebhookConfig) Secret() []byte {
+	return []byte(w.WebhookSecret)
+}
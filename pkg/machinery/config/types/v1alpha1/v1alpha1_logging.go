@@ -5,8 +5,10 @@
 package v1alpha1
 
 import (
+	"crypto/x509"
 	"fmt"
 	"net/url"
+	"regexp"
 
 	"github.com/hashicorp/go-multierror"
 
@@ -31,11 +33,21 @@ func (lc *LoggingConfig) Validate() error {
 				errs = multierror.Append(errs, fmt.Errorf("empty logging endpoint's host"))
 			}
 
-			if endpoint.Scheme != "tcp" && endpoint.Scheme != "udp" {
+			if endpoint.Scheme != "tcp" && endpoint.Scheme != "udp" && endpoint.Scheme != "tls" {
 				errs = multierror.Append(errs, fmt.Errorf("unexpected logging endpoint scheme %q", endpoint.Scheme))
 			}
 		}
 
+		if len(dest.LoggingCA) > 0 {
+			if endpoint != nil && endpoint.Scheme != "tls" {
+				errs = multierror.Append(errs, fmt.Errorf("logging destination CA is supported only with \"tls\" endpoint scheme"))
+			}
+
+			if !x509.NewCertPool().AppendCertsFromPEM(dest.LoggingCA) {
+				errs = multierror.Append(errs, fmt.Errorf("failed to parse logging destination CA certificate"))
+			}
+		}
+
 		switch f := dest.LoggingFormat; f {
 		case constants.LoggingFormatJSONLines:
 			// nothing
@@ -44,6 +56,36 @@ func (lc *LoggingConfig) Validate() error {
 		}
 	}
 
+	services := make(map[string]struct{}, len(lc.LoggingRateLimits))
+
+	for _, limit := range lc.LoggingRateLimits {
+		if limit.LoggingService == "" {
+			errs = multierror.Append(errs, fmt.Errorf("empty logging rate limit service"))
+		}
+
+		if _, ok := services[limit.LoggingService]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("duplicate logging rate limit for service %q", limit.LoggingService))
+		}
+
+		services[limit.LoggingService] = struct{}{}
+
+		if limit.LoggingBurst <= 0 || limit.LoggingRate <= 0 {
+			errs = multierror.Append(errs, fmt.Errorf("logging rate limit for service %q should have positive burst and rate", limit.LoggingService))
+		}
+	}
+
+	if sr := lc.LoggingSessionRecording; sr != nil {
+		if sr.SessionRecordingMaxBytes < 0 {
+			errs = multierror.Append(errs, fmt.Errorf("session recording max bytes should not be negative"))
+		}
+
+		for _, pattern := range sr.SessionRecordingRedact {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("invalid session recording redact pattern %q: %w", pattern, err))
+			}
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -57,6 +99,25 @@ func (lc *LoggingConfig) Destinations() []config.LoggingDestination {
 	return res
 }
 
+// RateLimits implements config.Logging interface.
+func (lc *LoggingConfig) RateLimits() []config.LoggingRateLimit {
+	res := make([]config.LoggingRateLimit, len(lc.LoggingRateLimits))
+	for i, limit := range lc.LoggingRateLimits {
+		res[i] = config.LoggingRateLimit(limit)
+	}
+
+	return res
+}
+
+// SessionRecording implements config.Logging interface.
+func (lc *LoggingConfig) SessionRecording() config.SessionRecording {
+	if lc.LoggingSessionRecording == nil {
+		return &SessionRecordingConfig{}
+	}
+
+	return lc.LoggingSessionRecording
+}
+
 // Endpoint implements config.LoggingDestination interface.
 func (ld LoggingDestination) Endpoint() *url.URL {
 	return ld.LoggingEndpoint.URL
@@ -66,3 +127,42 @@ func (ld LoggingDestination) Endpoint() *url.URL {
 func (ld LoggingDestination) Format() string {
 	return ld.LoggingFormat
 }
+
+// CA implements config.LoggingDestination interface.
+func (ld LoggingDestination) CA() []byte {
+	return ld.LoggingCA
+}
+
+// Service implements config.LoggingRateLimit interface.
+func (lr LoggingRateLimit) Service() string {
+	return lr.LoggingService
+}
+
+// Burst implements config.LoggingRateLimit interface.
+func (lr LoggingRateLimit) Burst() int {
+	return lr.LoggingBurst
+}
+
+// Rate implements config.LoggingRateLimit interface.
+func (lr LoggingRateLimit) Rate() int {
+	return lr.LoggingRate
+}
+
+// Enabled implements config.SessionRecording interface.
+func (sr *SessionRecordingConfig) Enabled() bool {
+	return sr.SessionRecordingEnabled
+}
+
+// MaxBytes implements config.SessionRecording interface.
+func (sr *SessionRecordingConfig) MaxBytes() int {
+	if sr.SessionRecordingMaxBytes == 0 {
+		return constants.DefaultSessionRecordingMaxBytes
+	}
+
+	return sr.SessionRecordingMaxBytes
+}
+
+// Redact implements config.SessionRecording interface.
+func (sr *SessionRecordingConfig) Redact() []string {
+	return sr.SessionRecordingRedact
+}
@@ -0,0 +1,243 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var (
+	// labelNameRegexp is the Kubernetes qualified name (and label value) format.
+	labelNameRegexp = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+	// labelPrefixRegexp is the DNS subdomain format.
+	labelPrefixRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	gpuModelRegexp    = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{4}$`)
+)
+
+// nodeLabelDiskClasses are the disk classes reported in the HardwareFacts resource.
+var nodeLabelDiskClasses = map[string]struct{}{
+	"nvme": {},
+	"ssd":  {},
+	"hdd":  {},
+}
+
+// kubeletLabels are the well-known labels in the Kubernetes prefixes the kubelet is allowed to set.
+var kubeletLabels = map[string]struct{}{
+	"kubernetes.io/hostname":                   {},
+	"kubernetes.io/os":                         {},
+	"kubernetes.io/arch":                       {},
+	"beta.kubernetes.io/os":                    {},
+	"beta.kubernetes.io/arch":                  {},
+	"beta.kubernetes.io/instance-type":         {},
+	"topology.kubernetes.io/zone":              {},
+	"topology.kubernetes.io/region":            {},
+	"failure-domain.beta.kubernetes.io/zone":   {},
+	"failure-domain.beta.kubernetes.io/region": {},
+}
+
+// taintEffects are the valid Kubernetes taint effects.
+var taintEffects = map[string]struct{}{
+	"NoSchedule":       {},
+	"PreferNoSchedule": {},
+	"NoExecute":        {},
+}
+
+// validateNodeLabelKey checks the label key format and that the kubelet is allowed to set it (NodeRestriction admission plugin).
+func validateNodeLabelKey(key string) error {
+	if _, ok := kubeletLabels[key]; ok {
+		return nil
+	}
+
+	name := key
+
+	if idx := strings.LastIndex(key, "/"); idx != -1 {
+		var prefix string
+
+		prefix, name = key[:idx], key[idx+1:]
+
+		if len(prefix) > 253 || !labelPrefixRegexp.MatchString(prefix) {
+			return fmt.Errorf("node label %q prefix should be a DNS subdomain", key)
+		}
+
+		restricted := func(domain string) bool {
+			return prefix == domain || strings.HasSuffix(prefix, "."+domain)
+		}
+
+		if (restricted("kubernetes.io") || restricted("k8s.io")) && !restricted("node.kubernetes.io") && !restricted("kubelet.kubernetes.io") {
+			return fmt.Errorf("node label %q prefix is reserved for Kubernetes", key)
+		}
+	}
+
+	if len(name) > 63 || !labelNameRegexp.MatchString(name) {
+		return fmt.Errorf("node label %q name is invalid", key)
+	}
+
+	return nil
+}
+
+// validateNodeLabelValue checks the label value format.
+func validateNodeLabelValue(key, value string) error {
+	if value != "" && (len(value) > 63 || !labelNameRegexp.MatchString(value)) {
+		return fmt.Errorf("node label %q value %q is invalid", key, value)
+	}
+
+	return nil
+}
+
+// sortedKeys returns the map keys in sorted order, so that the validation errors are stable.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// validateNodeLabels checks the node labels for errors.
+func validateNodeLabels(labels map[string]string) error {
+	var errs *multierror.Error
+
+	for _, key := range sortedKeys(labels) {
+		value := labels[key]
+
+		if err := validateNodeLabelKey(key); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+
+		if err := validateNodeLabelValue(key, value); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// validateNodeTaints checks the node taints in the `[value:]effect` format for errors.
+func validateNodeTaints(taints map[string]string) error {
+	var errs *multierror.Error
+
+	for _, key := range sortedKeys(taints) {
+		name, taint := key, taints[key]
+
+		if idx := strings.LastIndex(key, "/"); idx != -1 {
+			var prefix string
+
+			prefix, name = key[:idx], key[idx+1:]
+
+			if len(prefix) > 253 || !labelPrefixRegexp.MatchString(prefix) {
+				errs = multierror.Append(errs, fmt.Errorf("node taint %q prefix should be a DNS subdomain", key))
+			}
+		}
+
+		if len(name) > 63 || !labelNameRegexp.MatchString(name) {
+			errs = multierror.Append(errs, fmt.Errorf("node taint %q name is invalid", key))
+		}
+
+		value, effect := "", taint
+
+		if idx := strings.LastIndex(taint, ":"); idx != -1 {
+			value, effect = taint[:idx], taint[idx+1:]
+		}
+
+		if value != "" && (len(value) > 63 || !labelNameRegexp.MatchString(value)) {
+			errs = multierror.Append(errs, fmt.Errorf("node taint %q value %q is invalid", key, value))
+		}
+
+		if _, ok := taintEffects[effect]; !ok {
+			errs = multierror.Append(errs, fmt.Errorf("node taint %q effect %q is invalid, expected NoSchedule, PreferNoSchedule or NoExecute", key, effect))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Validate checks node label rule for errors.
+func (r NodeLabelRuleConfig) Validate() error {
+	var errs *multierror.Error
+
+	if r.RuleLabel == "" {
+		errs = multierror.Append(errs, fmt.Errorf("node label rule label is required"))
+	} else if err := validateNodeLabelKey(r.RuleLabel); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err := validateNodeLabelValue(r.RuleLabel, r.RuleValue); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	match := r.RuleMatch
+
+	if match.MatchCPUVendor == "" && len(match.MatchCPUFlags) == 0 && match.MatchGPUVendor == "" && match.MatchGPUModel == "" &&
+		match.MatchDiskClass == "" && match.MatchMinNICSpeed == 0 {
+		errs = multierror.Append(errs, fmt.Errorf("node label %q rule should match at least one hardware condition", r.RuleLabel))
+	}
+
+	if match.MatchGPUModel != "" && !gpuModelRegexp.MatchString(match.MatchGPUModel) {
+		errs = multierror.Append(errs, fmt.Errorf("node label %q GPU model %q should be PCI vendor and device IDs (e.g. 10de:20b0)", r.RuleLabel, match.MatchGPUModel))
+	}
+
+	if _, ok := nodeLabelDiskClasses[match.MatchDiskClass]; match.MatchDiskClass != "" && !ok {
+		errs = multierror.Append(errs, fmt.Errorf("node label %q disk class %q is invalid, expected nvme, ssd or hdd", r.RuleLabel, match.MatchDiskClass))
+	}
+
+	if match.MatchMinNICSpeed < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("node label %q minimum NIC speed can't be negative", r.RuleLabel))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Label implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) Label() string {
+	return r.RuleLabel
+}
+
+// Value implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) Value() string {
+	if r.RuleValue == "" {
+		return "true"
+	}
+
+	return r.RuleValue
+}
+
+// CPUVendor implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) CPUVendor() string {
+	return r.RuleMatch.MatchCPUVendor
+}
+
+// CPUFlags implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) CPUFlags() []string {
+	return r.RuleMatch.MatchCPUFlags
+}
+
+// GPUVendor implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) GPUVendor() string {
+	return r.RuleMatch.MatchGPUVendor
+}
+
+// GPUModel implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) GPUModel() string {
+	return strings.ToLower(r.RuleMatch.MatchGPUModel)
+}
+
+// DiskClass implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) DiskClass() string {
+	return r.RuleMatch.MatchDiskClass
+}
+
+// MinNICSpeed implements the config.NodeLabelRule interface.
+func (r NodeLabelRuleConfig) MinNICSpeed() int {
+	return r.RuleMatch.MatchMinNICSpeed
+}
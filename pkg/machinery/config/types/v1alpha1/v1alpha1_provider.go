@@ -74,8 +74,10 @@ func (c *Config) String(options ...encoder.Option) (string, error) {
 }
 
 // Bytes implements the config.Provider interface.
+//
+// Resolved secret values are replaced back with the secret references.
 func (c *Config) Bytes(options ...encoder.Option) ([]byte, error) {
-	return encoder.NewEncoder(c, options...).Encode()
+	return encoder.NewEncoder(c.WithSecretReferences(), options...).Encode()
 }
 
 // ApplyDynamicConfig implements the config.Provider interface.
@@ -185,6 +187,15 @@ func (m *MachineConfig) Network() config.MachineNetwork {
 	return m.MachineNetwork
 }
 
+// Ephemeral implements the config.Provider interface.
+func (m *MachineConfig) Ephemeral() config.VolumeMountOptions {
+	if m.MachineEphemeral == nil {
+		return &EphemeralConfig{}
+	}
+
+	return m.MachineEphemeral
+}
+
 // Time implements the config.Provider interface.
 func (m *MachineConfig) Time() config.Time {
 	if m.MachineTime == nil {
@@ -333,6 +344,163 @@ func (m *MachineConfig) Logging() config.Logging {
 	return m.MachineLogging
 }
 
+// ServiceHooks implements the config.MachineConfig interface.
+func (m *MachineConfig) ServiceHooks() []config.ServiceHook {
+	res := make([]config.ServiceHook, len(m.MachineServiceHooks))
+	for i, hook := range m.MachineServiceHooks {
+		res[i] = hook
+	}
+
+	return res
+}
+
+// ServiceOverrides implements the config.MachineConfig interface.
+func (m *MachineConfig) ServiceOverrides() []config.ServiceOverride {
+	res := make([]config.ServiceOverride, len(m.MachineServiceOverrides))
+	for i, override := range m.MachineServiceOverrides {
+		res[i] = override
+	}
+
+	return res
+}
+
+// IMA implements the config.MachineConfig interface.
+func (m *MachineConfig) IMA() config.IMA {
+	if m.MachineIMA == nil {
+		return &IMAConfig{}
+	}
+
+	return m.MachineIMA
+}
+
+// APITLS implements the config.MachineConfig interface.
+func (m *MachineConfig) APITLS() config.APITLS {
+	apiTLS := APITLSConfig{}
+
+	if m.MachineAPITLS != nil {
+		apiTLS = *m.MachineAPITLS
+	}
+
+	// fall back to the apid feature, as it was the only way to set the TLS version before
+	if apiTLS.APITLSMinVersion == "" {
+		apiTLS.APITLSMinVersion = m.Features().APIDMinTLSVersion()
+	}
+
+	return apiTLS
+}
+
+// BMC implements the config.MachineConfig interface.
+func (m *MachineConfig) BMC() config.BMC {
+	if m.MachineBMC == nil {
+		return &BMCConfig{}
+	}
+
+	return m.MachineBMC
+}
+
+// Kernel implements the config.MachineConfig interface.
+func (m *MachineConfig) Kernel() config.Kernel {
+	if m.MachineKernel == nil {
+		return &KernelConfig{}
+	}
+
+	return m.MachineKernel
+}
+
+// Backup implements the config.MachineConfig interface.
+func (m *MachineConfig) Backup() config.Backup {
+	if m.MachineBackup == nil {
+		return nil
+	}
+
+	return m.MachineBackup
+}
+
+// CRI implements the config.MachineConfig interface.
+func (m *MachineConfig) CRI() config.CRI {
+	if m.MachineCRI == nil {
+		return &CRIConfig{}
+	}
+
+	return m.MachineCRI
+}
+
+// SecretsResolver implements the config.MachineConfig interface.
+func (m *MachineConfig) SecretsResolver() config.SecretsResolverConfig {
+	if m.MachineSecretsResolver == nil {
+		return nil
+	}
+
+	return m.MachineSecretsResolver
+}
+
+// Pods implements the config.MachineConfig interface.
+func (m *MachineConfig) Pods() []map[string]interface{} {
+	res := make([]map[string]interface{}, len(m.MachinePods))
+	for i, pod := range m.MachinePods {
+		res[i] = pod.Object
+	}
+
+	return res
+}
+
+// LifecycleWebhook implements the config.MachineConfig interface.
+func (m *MachineConfig) LifecycleWebhook() config.LifecycleWebhook {
+	if m.MachineLifecycleWebhook == nil {
+		return nil
+	}
+
+	return m.MachineLifecycleWebhook
+}
+
+// NodeLabelRules implements the config.MachineConfig interface.
+func (m *MachineConfig) NodeLabelRules() []config.NodeLabelRule {
+	res := make([]config.NodeLabelRule, len(m.MachineNodeLabelRules))
+	for i, rule := range m.MachineNodeLabelRules {
+		res[i] = rule
+	}
+
+	return res
+}
+
+// NodeLabels implements the config.MachineConfig interface.
+func (m *MachineConfig) NodeLabels() map[string]string {
+	if m.MachineNodeLabels == nil {
+		return make(map[string]string)
+	}
+
+	return m.MachineNodeLabels
+}
+
+// NodeTaints implements the config.MachineConfig interface.
+func (m *MachineConfig) NodeTaints() map[string]string {
+	if m.MachineNodeTaints == nil {
+		return make(map[string]string)
+	}
+
+	return m.MachineNodeTaints
+}
+
+// HealthChecks implements the config.MachineConfig interface.
+func (m *MachineConfig) HealthChecks() []config.HealthCheck {
+	res := make([]config.HealthCheck, len(m.MachineHealthChecks))
+	for i, check := range m.MachineHealthChecks {
+		res[i] = check
+	}
+
+	return res
+}
+
+// HugePages implements the config.MachineConfig interface.
+func (m *MachineConfig) HugePages() []config.HugePage {
+	res := make([]config.HugePage, len(m.MachineHugePages))
+	for i, hugePage := range m.MachineHugePages {
+		res[i] = hugePage
+	}
+
+	return res
+}
+
 // Image implements the config.Provider interface.
 func (k *KubeletConfig) Image() string {
 	image := k.KubeletImage
@@ -377,21 +545,238 @@ func (k *KubeletConfig) ExtraMounts() []specs.Mount {
 	return out
 }
 
+// AllowUnsafeMounts implements the config.Provider interface.
+func (k *KubeletConfig) AllowUnsafeMounts() bool {
+	return k.KubeletAllowUnsafeMounts
+}
+
+// ExtraCapabilities implements the config.Provider interface.
+func (k *KubeletConfig) ExtraCapabilities() []string {
+	return k.KubeletExtraCapabilities
+}
+
 // RegisterWithFQDN implements the config.Provider interface.
 func (k *KubeletConfig) RegisterWithFQDN() bool {
 	return k.KubeletRegisterWithFQDN
 }
 
+// EnableServerCertRotation implements the config.Provider interface.
+func (k *KubeletConfig) EnableServerCertRotation() bool {
+	return k.KubeletEnableServerCertRotation
+}
+
+// DefaultRuntimeSeccompProfileEnabled implements the config.Provider interface.
+func (k *KubeletConfig) DefaultRuntimeSeccompProfileEnabled() bool {
+	return k.KubeletDefaultRuntimeSeccompProfileEnabled
+}
+
+// SystemReserved implements the config.Provider interface.
+func (k *KubeletConfig) SystemReserved() map[string]string {
+	if k.KubeletSystemReserved == nil {
+		return make(map[string]string)
+	}
+
+	return k.KubeletSystemReserved
+}
+
+// KubeReserved implements the config.Provider interface.
+func (k *KubeletConfig) KubeReserved() map[string]string {
+	if k.KubeletKubeReserved == nil {
+		return make(map[string]string)
+	}
+
+	return k.KubeletKubeReserved
+}
+
+// ImageGCHighThresholdPercent implements the config.Provider interface.
+func (k *KubeletConfig) ImageGCHighThresholdPercent() int {
+	if k.KubeletImageGCHighThresholdPercent == 0 {
+		return constants.KubeletImageGCHighThresholdPercent
+	}
+
+	return k.KubeletImageGCHighThresholdPercent
+}
+
+// ImageGCLowThresholdPercent implements the config.Provider interface.
+func (k *KubeletConfig) ImageGCLowThresholdPercent() int {
+	if k.KubeletImageGCLowThresholdPercent == 0 {
+		return constants.KubeletImageGCLowThresholdPercent
+	}
+
+	return k.KubeletImageGCLowThresholdPercent
+}
+
+// ContainerLogMaxSize implements the config.Provider interface.
+func (k *KubeletConfig) ContainerLogMaxSize() string {
+	if k.KubeletContainerLogMaxSize == "" {
+		return constants.KubeletContainerLogMaxSize
+	}
+
+	return k.KubeletContainerLogMaxSize
+}
+
+// ContainerLogMaxFiles implements the config.Provider interface.
+func (k *KubeletConfig) ContainerLogMaxFiles() int {
+	if k.KubeletContainerLogMaxFiles == 0 {
+		return constants.KubeletContainerLogMaxFiles
+	}
+
+	return k.KubeletContainerLogMaxFiles
+}
+
+// CPUManagerPolicy implements the config.Kubelet interface.
+func (k *KubeletConfig) CPUManagerPolicy() string {
+	if k.KubeletCPUManagerPolicy == "" {
+		return constants.KubeletCPUManagerPolicy
+	}
+
+	return k.KubeletCPUManagerPolicy
+}
+
+// TopologyManagerPolicy implements the config.Kubelet interface.
+func (k *KubeletConfig) TopologyManagerPolicy() string {
+	if k.KubeletTopologyManagerPolicy == "" {
+		return constants.KubeletTopologyManagerPolicy
+	}
+
+	return k.KubeletTopologyManagerPolicy
+}
+
+// MemoryManagerPolicy implements the config.Kubelet interface.
+func (k *KubeletConfig) MemoryManagerPolicy() string {
+	if k.KubeletMemoryManagerPolicy == "" {
+		return constants.KubeletMemoryManagerPolicy
+	}
+
+	return k.KubeletMemoryManagerPolicy
+}
+
+// ReservedSystemCPUs implements the config.Kubelet interface.
+func (k *KubeletConfig) ReservedSystemCPUs() string {
+	return k.KubeletReservedSystemCPUs
+}
+
 // NodeIP implements the config.Provider interface.
 func (k *KubeletConfig) NodeIP() config.KubeletNodeIP {
 	return k.KubeletNodeIP
 }
 
+// Port implements the config.Provider interface.
+func (k *KubeletConfig) Port() int {
+	if k.KubeletPort == 0 {
+		return constants.KubeletPort
+	}
+
+	return k.KubeletPort
+}
+
+// HealthzPort implements the config.Provider interface.
+func (k *KubeletConfig) HealthzPort() int {
+	if k.KubeletHealthzPort == 0 {
+		return constants.KubeletHealthzPort
+	}
+
+	return k.KubeletHealthzPort
+}
+
+// CredentialProviders implements the config.Provider interface.
+func (k *KubeletConfig) CredentialProviders() config.KubeletCredentialProviders {
+	if k.KubeletCredentialProviders == nil {
+		return nil
+	}
+
+	return k.KubeletCredentialProviders
+}
+
+// ExtraConfig implements the config.Provider interface.
+func (k *KubeletConfig) ExtraConfig() map[string]interface{} {
+	return k.KubeletExtraConfig.Object
+}
+
+// ShutdownGracePeriod implements the config.Kubelet interface.
+func (k *KubeletConfig) ShutdownGracePeriod() time.Duration {
+	return k.KubeletShutdownGracePeriod
+}
+
+// ShutdownGracePeriodCriticalPods implements the config.Kubelet interface.
+func (k *KubeletConfig) ShutdownGracePeriodCriticalPods() time.Duration {
+	return k.KubeletShutdownGracePeriodCriticalPods
+}
+
+// Standalone implements the config.Kubelet interface.
+func (k *KubeletConfig) Standalone() bool {
+	return k.KubeletStandalone
+}
+
+// PodCIDRs implements the config.Kubelet interface.
+func (k *KubeletConfig) PodCIDRs() []string {
+	return k.KubeletPodCIDRs
+}
+
+// BinDir implements the config.KubeletCredentialProviders interface.
+func (c *KubeletCredentialProvidersConfig) BinDir() string {
+	if c.CredentialProvidersBinDir == "" {
+		return constants.KubeletCredentialProviderBinDir
+	}
+
+	return c.CredentialProvidersBinDir
+}
+
+// Providers implements the config.KubeletCredentialProviders interface.
+func (c *KubeletCredentialProvidersConfig) Providers() []config.KubeletCredentialProvider {
+	providers := make([]config.KubeletCredentialProvider, len(c.CredentialProviders))
+
+	for i := range c.CredentialProviders {
+		providers[i] = &c.CredentialProviders[i]
+	}
+
+	return providers
+}
+
+// Name implements the config.KubeletCredentialProvider interface.
+func (p *KubeletCredentialProviderConfig) Name() string {
+	return p.CredentialProviderName
+}
+
+// MatchImages implements the config.KubeletCredentialProvider interface.
+func (p *KubeletCredentialProviderConfig) MatchImages() []string {
+	return p.CredentialProviderMatchImages
+}
+
+// DefaultCacheDuration implements the config.KubeletCredentialProvider interface.
+func (p *KubeletCredentialProviderConfig) DefaultCacheDuration() time.Duration {
+	return p.CredentialProviderDefaultCacheDuration
+}
+
+// APIVersion implements the config.KubeletCredentialProvider interface.
+func (p *KubeletCredentialProviderConfig) APIVersion() string {
+	if p.CredentialProviderAPIVersion == "" {
+		return constants.KubeletCredentialProviderAPIVersion
+	}
+
+	return p.CredentialProviderAPIVersion
+}
+
+// Args implements the config.KubeletCredentialProvider interface.
+func (p *KubeletCredentialProviderConfig) Args() []string {
+	return p.CredentialProviderArgs
+}
+
+// Env implements the config.KubeletCredentialProvider interface.
+func (p *KubeletCredentialProviderConfig) Env() map[string]string {
+	return p.CredentialProviderEnv
+}
+
 // ValidSubnets implements the config.Provider interface.
 func (k KubeletNodeIPConfig) ValidSubnets() []string {
 	return k.KubeletNodeIPValidSubnets
 }
 
+// PreferredFamily implements the config.Provider interface.
+func (k KubeletNodeIPConfig) PreferredFamily() string {
+	return k.KubeletNodeIPPreferredFamily
+}
+
 // Mirrors implements the Registries interface.
 func (r *RegistriesConfig) Mirrors() map[string]config.RegistryMirrorConfig {
 	mirrors := make(map[string]config.RegistryMirrorConfig, len(r.RegistryMirrors))
@@ -414,6 +799,15 @@ func (r *RegistriesConfig) Config() map[string]config.RegistryConfig {
 	return registries
 }
 
+// Pulls implements the Registries interface.
+func (r *RegistriesConfig) Pulls() config.RegistryPulls {
+	if r.RegistryPulls == nil {
+		return &RegistryPullsConfig{}
+	}
+
+	return r.RegistryPulls
+}
+
 // TLS implements the Registries interface.
 func (r *RegistryConfig) TLS() config.RegistryTLSConfig {
 	if r.RegistryTLS == nil {
@@ -548,6 +942,25 @@ func (n *NetworkConfig) KubeSpan() config.KubeSpan {
 	return n.NetworkKubeSpan
 }
 
+// AdvertisedSubnets implements the config.Provider interface.
+func (n *NetworkConfig) AdvertisedSubnets() []string {
+	return n.NetworkAdvertisedSubnets
+}
+
+// IgnoredSubnets implements the config.Provider interface.
+func (n *NetworkConfig) IgnoredSubnets() []string {
+	return n.NetworkIgnoredSubnets
+}
+
+// Conntrack implements the config.Provider interface.
+func (n *NetworkConfig) Conntrack() config.Conntrack {
+	if n.NetworkConntrack == nil {
+		return nil
+	}
+
+	return n.NetworkConntrack
+}
+
 // IP implements the MachineNetwork interface.
 func (e *ExtraHost) IP() string {
 	return e.HostIP
@@ -689,6 +1102,24 @@ func (d *Device) WireguardConfig() config.WireguardConfig {
 	return d.DeviceWireguardConfig
 }
 
+// Tuning implements the MachineNetwork interface.
+func (d *Device) Tuning() config.DeviceTuning {
+	if d.DeviceTuning == nil {
+		return nil
+	}
+
+	return d.DeviceTuning
+}
+
+// TrafficShaping implements the MachineNetwork interface.
+func (d *Device) TrafficShaping() config.TrafficShaping {
+	if d.DeviceTrafficShaping == nil {
+		return nil
+	}
+
+	return d.DeviceTrafficShaping
+}
+
 // RouteMetric implements the DHCPOptions interface.
 func (d *DHCPOptions) RouteMetric() uint32 {
 	return d.DHCPRouteMetric
@@ -1085,6 +1516,11 @@ func (i *InstallConfig) WithBootloader() bool {
 	return i.InstallBootloader
 }
 
+// AssetsURL implements the config.Provider interface.
+func (i *InstallConfig) AssetsURL() string {
+	return i.InstallAssetsURL
+}
+
 // Enabled implements the config.Provider interface.
 func (c *CoreDNS) Enabled() bool {
 	return !c.CoreDNSDisabled
@@ -1161,6 +1597,26 @@ func (p *DiskPartition) MountPoint() string {
 	return p.DiskMountPoint
 }
 
+// MountOptions implements the config.Provider interface.
+func (p *DiskPartition) MountOptions() []string {
+	return p.DiskMountOptions
+}
+
+// ProjectQuota implements the config.Provider interface.
+func (p *DiskPartition) ProjectQuota() bool {
+	return p.DiskProjectQuota
+}
+
+// MountOptions implements the config.VolumeMountOptions interface.
+func (e *EphemeralConfig) MountOptions() []string {
+	return e.EphemeralMountOptions
+}
+
+// ProjectQuota implements the config.VolumeMountOptions interface.
+func (e *EphemeralConfig) ProjectQuota() bool {
+	return e.EphemeralProjectQuota
+}
+
 // Kind implements the config.Provider interface.
 func (e *EncryptionConfig) Kind() string {
 	return e.EncryptionProvider
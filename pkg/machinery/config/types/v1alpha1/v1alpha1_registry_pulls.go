@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// maintenanceWindowStartLayout is the layout of the maintenance window start time.
+const maintenanceWindowStartLayout = "15:04"
+
+// Validate checks image pull limits for errors.
+func (p RegistryPullsConfig) Validate() error {
+	var errs *multierror.Error
+
+	if p.PullMaxConcurrentDownloads < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("image pull max concurrent downloads should not be negative"))
+	}
+
+	if p.PullBandwidthLimit != "" {
+		if _, err := humanize.ParseBytes(p.PullBandwidthLimit); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("invalid image pull bandwidth limit %q: %w", p.PullBandwidthLimit, err))
+		}
+	}
+
+	for _, window := range p.PullMaintenanceWindows {
+		if _, err := time.Parse(maintenanceWindowStartLayout, window.WindowStart); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("invalid maintenance window start %q, expected HH:MM", window.WindowStart))
+		}
+
+		if window.WindowDuration <= 0 || window.WindowDuration > 24*time.Hour {
+			errs = multierror.Append(errs, fmt.Errorf("maintenance window %q duration should be between 0 and 24h", window.WindowStart))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// MaxConcurrentDownloads implements the config.RegistryPulls interface.
+func (p *RegistryPullsConfig) MaxConcurrentDownloads() int {
+	return p.PullMaxConcurrentDownloads
+}
+
+// BandwidthLimit implements the config.RegistryPulls interface.
+func (p *RegistryPullsConfig) BandwidthLimit() uint64 {
+	if p.PullBandwidthLimit == "" {
+		return 0
+	}
+
+	limit, _ := humanize.ParseBytes(p.PullBandwidthLimit) //nolint:errcheck
+
+	return limit
+}
+
+// MaintenanceWindows implements the config.RegistryPulls interface.
+func (p *RegistryPullsConfig) MaintenanceWindows() []config.MaintenanceWindow {
+	windows := make([]config.MaintenanceWindow, len(p.PullMaintenanceWindows))
+
+	for i := range p.PullMaintenanceWindows {
+		windows[i] = p.PullMaintenanceWindows[i]
+	}
+
+	return windows
+}
+
+// Start implements the config.MaintenanceWindow interface.
+func (w MaintenanceWindowConfig) Start() time.Duration {
+	start, err := time.Parse(maintenanceWindowStartLayout, w.WindowStart)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+}
+
+// Duration implements the config.MaintenanceWindow interface.
+func (w MaintenanceWindowConfig) Duration() time.Duration {
+	return w.WindowDuration
+}
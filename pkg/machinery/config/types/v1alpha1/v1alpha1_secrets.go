@@ -0,0 +1,454 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+const (
+	secretsResolverPath = "machine.secretsResolver"
+
+	vaultAuthMethodAWS = "aws"
+	vaultAuthMethodGCP = "gcp"
+)
+
+// ResolveSecrets implements the config.Provider interface.
+//
+// Secret references are resolved all at once, the config is not modified if any of the references can't be resolved.
+func (c *Config) ResolveSecrets(ctx context.Context, resolver config.SecretsResolver) error {
+	references := c.collectSecretReferences()
+
+	if len(references) == 0 {
+		return nil
+	}
+
+	resolved := make(map[string]string, len(references))
+
+	var errs *multierror.Error
+
+	for _, path := range sortedPaths(references) {
+		ref, err := config.ParseSecretReference(references[path])
+		if err == nil {
+			resolved[path], err = resolver.Resolve(ctx, ref)
+		}
+
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("error resolving %q: %w", path, err))
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	walkStrings(reflect.ValueOf(c).Elem(), "", func(path, value string) string {
+		if v, ok := resolved[path]; ok {
+			return v
+		}
+
+		return value
+	})
+
+	if c.SecretReferences == nil {
+		c.SecretReferences = map[string]string{}
+	}
+
+	for path, value := range references {
+		c.SecretReferences[path] = value
+	}
+
+	return nil
+}
+
+// WithSecretReferences returns the copy of the config with the resolved values replaced back with the secret references.
+//
+// The config itself is returned if no secret references were resolved.
+func (c *Config) WithSecretReferences() *Config {
+	if len(c.SecretReferences) == 0 {
+		return c
+	}
+
+	cfg := c.DeepCopy()
+	cfg.SecretReferences = nil
+
+	walkStrings(reflect.ValueOf(cfg).Elem(), "", func(path, value string) string {
+		if ref, ok := c.SecretReferences[path]; ok {
+			return ref
+		}
+
+		return value
+	})
+
+	return cfg
+}
+
+// collectSecretReferences returns the secret references in the config indexed by the value path.
+func (c *Config) collectSecretReferences() map[string]string {
+	references := map[string]string{}
+
+	walkStrings(reflect.ValueOf(c).Elem(), "", func(path, value string) string {
+		if config.IsSecretReference(value) {
+			references[path] = value
+		}
+
+		return value
+	})
+
+	return references
+}
+
+func sortedPaths(references map[string]string) []string {
+	paths := make([]string, 0, len(references))
+
+	for path := range references {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+// walkStrings calls the function for each string value of the config (except for the secrets resolver settings)
+// and replaces the value with the returned one, it returns true if any of the values was replaced.
+//
+// The path of the value is built from the YAML field names, slice indexes and map keys,
+// e.g. `machine.kubelet.extraArgs[node-ip]`.
+// Values nested in the interfaces (e.g. `extraConfig`) are not visited.
+//
+//nolint:gocyclo,cyclop
+func walkStrings(v reflect.Value, path string, fn func(path, value string) string) (changed bool) {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			changed = walkStrings(v.Elem(), path, fn)
+		}
+	case reflect.Struct:
+		if path == secretsResolverPath {
+			return false
+		}
+
+		t := v.Type()
+
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+
+			name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+			if name == "-" {
+				continue
+			}
+
+			fieldPath := path
+
+			if name != "" {
+				if fieldPath != "" {
+					fieldPath += "."
+				}
+
+				fieldPath += name
+			}
+
+			changed = walkStrings(v.Field(i), fieldPath, fn) || changed
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			changed = walkStrings(v.Index(i), path+"["+strconv.Itoa(i)+"]", fn) || changed
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return false
+		}
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		for _, key := range keys {
+			// map values are not addressable, so the value is copied and stored back
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+
+			if walkStrings(value, path+"["+key.String()+"]", fn) {
+				v.SetMapIndex(key, value)
+
+				changed = true
+			}
+		}
+	case reflect.String:
+		value := v.String()
+
+		if newValue := fn(path, value); newValue != value {
+			v.SetString(newValue)
+
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// Validate checks secrets resolver settings for errors.
+//
+//nolint:gocyclo
+func (r *SecretsResolverConfig) Validate() error {
+	var errs *multierror.Error
+
+	names := map[string]struct{}{}
+
+	for _, backend := range r.ResolverBackends {
+		if backend.BackendName == "" || strings.ContainsAny(backend.BackendName, "/#") {
+			errs = multierror.Append(errs, fmt.Errorf("secrets backend name %q should be non-empty and shouldn't contain '/' or '#'", backend.BackendName))
+		}
+
+		if _, ok := names[backend.BackendName]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("duplicate secrets backend %q", backend.BackendName))
+		}
+
+		names[backend.BackendName] = struct{}{}
+
+		errs = multierror.Append(errs, backend.Validate())
+	}
+
+	if r.ResolverCacheTTL < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("secrets resolver cache TTL should not be negative"))
+	}
+
+	if r.ResolverTimeout < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("secrets resolver timeout should not be negative"))
+	}
+
+	switch r.ResolverFailurePolicy {
+	case "", config.SecretsFailurePolicyFail, config.SecretsFailurePolicyStale:
+	default:
+		errs = multierror.Append(errs, fmt.Errorf("unsupported secrets resolver failure policy %q, expected %q or %q",
+			r.ResolverFailurePolicy, config.SecretsFailurePolicyFail, config.SecretsFailurePolicyStale))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// validateSecretReferences checks that the secret references are well-formed and point to the configured backends.
+func (c *Config) validateSecretReferences() error {
+	var errs *multierror.Error
+
+	references := c.collectSecretReferences()
+
+	for _, path := range sortedPaths(references) {
+		ref, err := config.ParseSecretReference(references[path])
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%q: %w", path, err))
+
+			continue
+		}
+
+		if c.MachineConfig == nil || c.MachineConfig.MachineSecretsResolver == nil || !c.MachineConfig.MachineSecretsResolver.hasBackend(ref.Backend) {
+			errs = multierror.Append(errs, fmt.Errorf("%q: secrets backend %q is not configured in %s", path, ref.Backend, secretsResolverPath))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (r *SecretsResolverConfig) hasBackend(name string) bool {
+	for _, backend := range r.ResolverBackends {
+		if backend.BackendName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Backends implements the config.SecretsResolverConfig interface.
+func (r *SecretsResolverConfig) Backends() []config.SecretsBackend {
+	backends := make([]config.SecretsBackend, len(r.ResolverBackends))
+
+	for i := range r.ResolverBackends {
+		backends[i] = r.ResolverBackends[i]
+	}
+
+	return backends
+}
+
+// CacheTTL implements the config.SecretsResolverConfig interface.
+func (r *SecretsResolverConfig) CacheTTL() time.Duration {
+	if r.ResolverCacheTTL == 0 {
+		return 5 * time.Minute
+	}
+
+	return r.ResolverCacheTTL
+}
+
+// Timeout implements the config.SecretsResolverConfig interface.
+func (r *SecretsResolverConfig) Timeout() time.Duration {
+	if r.ResolverTimeout == 0 {
+		return time.Minute
+	}
+
+	return r.ResolverTimeout
+}
+
+// FailurePolicy implements the config.SecretsResolverConfig interface.
+func (r *SecretsResolverConfig) FailurePolicy() string {
+	if r.ResolverFailurePolicy == "" {
+		return config.SecretsFailurePolicyFail
+	}
+
+	return r.ResolverFailurePolicy
+}
+
+// Validate checks secrets backend settings for errors.
+func (b SecretsBackendConfig) Validate() error {
+	var (
+		errs     *multierror.Error
+		backends int
+	)
+
+	if b.BackendVault != nil {
+		backends++
+
+		errs = multierror.Append(errs, b.BackendVault.Validate())
+	}
+
+	if b.BackendAWSSecretsManager != nil {
+		backends++
+
+		if b.BackendAWSSecretsManager.AWSRegion == "" {
+			errs = multierror.Append(errs, fmt.Errorf("secrets backend %q: AWS region is required", b.BackendName))
+		}
+	}
+
+	if b.BackendGCPSecretManager != nil {
+		backends++
+
+		if b.BackendGCPSecretManager.GCPProject == "" {
+			errs = multierror.Append(errs, fmt.Errorf("secrets backend %q: GCP project is required", b.BackendName))
+		}
+	}
+
+	if backends != 1 {
+		errs = multierror.Append(errs, fmt.Errorf("secrets backend %q should have exactly one backend type set", b.BackendName))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Name implements the config.SecretsBackend interface.
+func (b SecretsBackendConfig) Name() string {
+	return b.BackendName
+}
+
+// Vault implements the config.SecretsBackend interface.
+func (b SecretsBackendConfig) Vault() config.VaultSecretsBackend {
+	if b.BackendVault == nil {
+		return nil
+	}
+
+	return b.BackendVault
+}
+
+// AWSSecretsManager implements the config.SecretsBackend interface.
+func (b SecretsBackendConfig) AWSSecretsManager() config.AWSSecretsManagerBackend {
+	if b.BackendAWSSecretsManager == nil {
+		return nil
+	}
+
+	return b.BackendAWSSecretsManager
+}
+
+// GCPSecretManager implements the config.SecretsBackend interface.
+func (b SecretsBackendConfig) GCPSecretManager() config.GCPSecretManagerBackend {
+	if b.BackendGCPSecretManager == nil {
+		return nil
+	}
+
+	return b.BackendGCPSecretManager
+}
+
+// Validate checks Vault secrets backend settings for errors.
+func (v *VaultSecretsBackendConfig) Validate() error {
+	var errs *multierror.Error
+
+	u, err := url.Parse(v.VaultEndpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = multierror.Append(errs, fmt.Errorf("vault endpoint %q should be an http(s) URL", v.VaultEndpoint))
+	}
+
+	if v.VaultAuthMethod != vaultAuthMethodAWS && v.VaultAuthMethod != vaultAuthMethodGCP {
+		errs = multierror.Append(errs, fmt.Errorf("unsupported vault auth method %q, expected %q or %q", v.VaultAuthMethod, vaultAuthMethodAWS, vaultAuthMethodGCP))
+	}
+
+	if v.VaultRole == "" {
+		errs = multierror.Append(errs, fmt.Errorf("vault role is required"))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Endpoint implements the config.VaultSecretsBackend interface.
+func (v *VaultSecretsBackendConfig) Endpoint() string {
+	return v.VaultEndpoint
+}
+
+// Mount implements the config.VaultSecretsBackend interface.
+func (v *VaultSecretsBackendConfig) Mount() string {
+	if v.VaultMount == "" {
+		return "secret"
+	}
+
+	return strings.Trim(v.VaultMount, "/")
+}
+
+// AuthMethod implements the config.VaultSecretsBackend interface.
+func (v *VaultSecretsBackendConfig) AuthMethod() string {
+	return v.VaultAuthMethod
+}
+
+// AuthMount implements the config.VaultSecretsBackend interface.
+func (v *VaultSecretsBackendConfig) AuthMount() string {
+	if v.VaultAuthMount == "" {
+		return v.VaultAuthMethod
+	}
+
+	return strings.Trim(v.VaultAuthMount, "/")
+}
+
+// Role implements the config.VaultSecretsBackend interface.
+func (v *VaultSecretsBackendConfig) Role() string {
+	return v.VaultRole
+}
+
+// CA implements the config.VaultSecretsBackend interface.
+func (v *VaultSecretsBackendConfig) CA() []byte {
+	return v.VaultCA
+}
+
+// Region implements the config.AWSSecretsManagerBackend interface.
+func (a *AWSSecretsManagerBackendConfig) Region() string {
+	return a.AWSRegion
+}
+
+// Project implements the config.GCPSecretManagerBackend interface.
+func (g *GCPSecretManagerBackendConfig) Project() string {
+	return g.GCPProject
+}
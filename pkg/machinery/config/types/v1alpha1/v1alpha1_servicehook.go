@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/constants"
+)
+
+// Validate checks service hook configuration for errors.
+func (h ServiceHook) Validate() error {
+	var errs *multierror.Error
+
+	if h.HookService == "" {
+		errs = multierror.Append(errs, fmt.Errorf("empty service hook service"))
+	}
+
+	switch h.HookStage {
+	case constants.ServiceHookStagePre, constants.ServiceHookStagePost:
+	default:
+		errs = multierror.Append(errs, fmt.Errorf("unknown service hook stage %q for service %q", h.HookStage, h.HookService))
+	}
+
+	if len(h.HookCommand) == 0 {
+		errs = multierror.Append(errs, fmt.Errorf("empty service hook command for service %q", h.HookService))
+	}
+
+	if h.HookTimeout < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("negative service hook timeout for service %q", h.HookService))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Service implements config.ServiceHook interface.
+func (h ServiceHook) Service() string {
+	return h.HookService
+}
+
+// Stage implements config.ServiceHook interface.
+func (h ServiceHook) Stage() string {
+	return h.HookStage
+}
+
+// Command implements config.ServiceHook interface.
+func (h ServiceHook) Command() []string {
+	return h.HookCommand
+}
+
+// Image implements config.ServiceHook interface.
+func (h ServiceHook) Image() string {
+	return h.HookImage
+}
+
+// Timeout implements config.ServiceHook interface.
+func (h ServiceHook) Timeout() time.Duration {
+	if h.HookTimeout == 0 {
+		return constants.DefaultServiceHookTimeout
+	}
+
+	return h.HookTimeout
+}
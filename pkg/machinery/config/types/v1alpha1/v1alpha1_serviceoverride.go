@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/go-multierror"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// overridableServices are the system services running in containers, so their spec can be adjusted.
+var overridableServices = map[string]struct{}{
+	"apid":    {},
+	"etcd":    {},
+	"kubelet": {},
+	"trustd":  {},
+}
+
+var rlimitTypes = map[string]struct{}{
+	"RLIMIT_AS":         {},
+	"RLIMIT_CORE":       {},
+	"RLIMIT_CPU":        {},
+	"RLIMIT_DATA":       {},
+	"RLIMIT_FSIZE":      {},
+	"RLIMIT_LOCKS":      {},
+	"RLIMIT_MEMLOCK":    {},
+	"RLIMIT_MSGQUEUE":   {},
+	"RLIMIT_NICE":       {},
+	"RLIMIT_NOFILE":     {},
+	"RLIMIT_NPROC":      {},
+	"RLIMIT_RSS":        {},
+	"RLIMIT_RTPRIO":     {},
+	"RLIMIT_RTTIME":     {},
+	"RLIMIT_SIGPENDING": {},
+	"RLIMIT_STACK":      {},
+}
+
+// Validate checks service override configuration for errors.
+func (o ServiceOverride) Validate() error {
+	var errs *multierror.Error
+
+	if _, ok := overridableServices[o.OverrideService]; !ok {
+		errs = multierror.Append(errs, fmt.Errorf("service %q doesn't support overrides", o.OverrideService))
+	}
+
+	for _, mount := range o.OverrideMounts {
+		if !filepath.IsAbs(mount.MountSource) || !filepath.IsAbs(mount.MountDestination) {
+			errs = multierror.Append(errs, fmt.Errorf("service %q mount %q -> %q should use absolute paths", o.OverrideService, mount.MountSource, mount.MountDestination))
+		}
+	}
+
+	for _, rlimit := range o.OverrideRlimits {
+		if _, ok := rlimitTypes[rlimit.RlimitType]; !ok {
+			errs = multierror.Append(errs, fmt.Errorf("unknown rlimit type %q for service %q", rlimit.RlimitType, o.OverrideService))
+		}
+
+		if rlimit.RlimitSoft > rlimit.RlimitHard {
+			errs = multierror.Append(errs, fmt.Errorf("rlimit %q soft limit is greater than the hard limit for service %q", rlimit.RlimitType, o.OverrideService))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Service implements config.ServiceOverride interface.
+func (o ServiceOverride) Service() string {
+	return o.OverrideService
+}
+
+// Env implements config.ServiceOverride interface.
+func (o ServiceOverride) Env() config.Env {
+	return o.OverrideEnv
+}
+
+// Mounts implements config.ServiceOverride interface.
+func (o ServiceOverride) Mounts() []specs.Mount {
+	mounts := make([]specs.Mount, len(o.OverrideMounts))
+
+	for i, mount := range o.OverrideMounts {
+		mounts[i] = specs.Mount{
+			Type:        "bind",
+			Source:      mount.MountSource,
+			Destination: mount.MountDestination,
+			Options:     []string{"rbind", "ro"},
+		}
+	}
+
+	return mounts
+}
+
+// Rlimits implements config.ServiceOverride interface.
+func (o ServiceOverride) Rlimits() []specs.POSIXRlimit {
+	rlimits := make([]specs.POSIXRlimit, len(o.OverrideRlimits))
+
+	for i, rlimit := range o.OverrideRlimits {
+		rlimits[i] = specs.POSIXRlimit{
+			Type: rlimit.RlimitType,
+			Soft: rlimit.RlimitSoft,
+			Hard: rlimit.RlimitHard,
+		}
+	}
+
+	return rlimits
+}
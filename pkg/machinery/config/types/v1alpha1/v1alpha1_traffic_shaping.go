@@ -0,0 +1,183 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+)
+
+// bitrateUnits are the tc rate units in bits per second.
+var bitrateUnits = []struct {
+	suffix     string
+	multiplier uint64
+}{
+	{"tbit", 1_000_000_000_000},
+	{"gbit", 1_000_000_000},
+	{"mbit", 1_000_000},
+	{"kbit", 1_000},
+	{"bit", 1},
+}
+
+// parseBitrate parses the rate in tc format (e.g. 100Mbit) into bits per second.
+func parseBitrate(rate string) (uint64, error) {
+	lower := strings.ToLower(rate)
+
+	for _, unit := range bitrateUnits {
+		if !strings.HasSuffix(lower, unit.suffix) {
+			continue
+		}
+
+		value, err := strconv.ParseUint(strings.TrimSuffix(lower, unit.suffix), 10, 64)
+		if err != nil || value == 0 {
+			break
+		}
+
+		return value * unit.multiplier, nil
+	}
+
+	return 0, fmt.Errorf("invalid rate %q", rate)
+}
+
+// mustParseBitrate is used in accessors, as the rates are checked by Validate.
+func mustParseBitrate(rate string) uint64 {
+	if rate == "" {
+		return 0
+	}
+
+	value, _ := parseBitrate(rate) //nolint:errcheck
+
+	return value
+}
+
+// Validate checks traffic shaping settings for errors.
+//
+//nolint:gocyclo,cyclop
+func (t TrafficShapingConfig) Validate(iface string) error {
+	var errs *multierror.Error
+
+	bandwidth, err := parseBitrate(t.TrafficShapingBandwidth)
+	if err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("traffic shaping bandwidth for interface %q: %w", iface, err))
+	}
+
+	var totalGuaranteed uint64
+
+	names := map[string]struct{}{}
+
+	for _, class := range t.TrafficShapingClasses {
+		if class.TrafficClassName == "" {
+			errs = multierror.Append(errs, fmt.Errorf("traffic class name for interface %q can't be empty", iface))
+		}
+
+		if _, ok := names[class.TrafficClassName]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("traffic class %q for interface %q is duplicate", class.TrafficClassName, iface))
+		}
+
+		names[class.TrafficClassName] = struct{}{}
+
+		var guaranteed, limit uint64
+
+		if class.TrafficClassGuaranteed != "" {
+			if guaranteed, err = parseBitrate(class.TrafficClassGuaranteed); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("traffic class %q guaranteed bandwidth: %w", class.TrafficClassName, err))
+			}
+		}
+
+		if class.TrafficClassLimit != "" {
+			if limit, err = parseBitrate(class.TrafficClassLimit); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("traffic class %q bandwidth limit: %w", class.TrafficClassName, err))
+			}
+		}
+
+		if limit > 0 && guaranteed > limit {
+			errs = multierror.Append(errs, fmt.Errorf("traffic class %q guaranteed bandwidth exceeds the limit", class.TrafficClassName))
+		}
+
+		if bandwidth > 0 && limit > bandwidth {
+			errs = multierror.Append(errs, fmt.Errorf("traffic class %q bandwidth limit exceeds the interface bandwidth", class.TrafficClassName))
+		}
+
+		totalGuaranteed += guaranteed
+
+		if class.TrafficClassPriority < 0 || class.TrafficClassPriority > 7 {
+			errs = multierror.Append(errs, fmt.Errorf("traffic class %q priority should be between 0 and 7", class.TrafficClassName))
+		}
+
+		if len(class.TrafficClassPorts) == 0 && len(class.TrafficClassSubnets) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("traffic class %q should match either ports or subnets", class.TrafficClassName))
+		}
+
+		for _, port := range class.TrafficClassPorts {
+			if port < 1 || port > 65535 {
+				errs = multierror.Append(errs, fmt.Errorf("traffic class %q port %d is invalid", class.TrafficClassName, port))
+			}
+		}
+
+		for _, subnet := range class.TrafficClassSubnets {
+			if _, _, err = net.ParseCIDR(subnet); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("traffic class %q subnet %q is invalid", class.TrafficClassName, subnet))
+			}
+		}
+	}
+
+	if bandwidth > 0 && totalGuaranteed > bandwidth {
+		errs = multierror.Append(errs, fmt.Errorf("guaranteed bandwidth of the traffic classes for interface %q exceeds the interface bandwidth", iface))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// Bandwidth implements the config.TrafficShaping interface.
+func (t *TrafficShapingConfig) Bandwidth() uint64 {
+	return mustParseBitrate(t.TrafficShapingBandwidth)
+}
+
+// Classes implements the config.TrafficShaping interface.
+func (t *TrafficShapingConfig) Classes() []config.TrafficClass {
+	classes := make([]config.TrafficClass, 0, len(t.TrafficShapingClasses))
+
+	for _, class := range t.TrafficShapingClasses {
+		classes = append(classes, class)
+	}
+
+	return classes
+}
+
+// Name implements the config.TrafficClass interface.
+func (c TrafficClassConfig) Name() string {
+	return c.TrafficClassName
+}
+
+// Guaranteed implements the config.TrafficClass interface.
+func (c TrafficClassConfig) Guaranteed() uint64 {
+	return mustParseBitrate(c.TrafficClassGuaranteed)
+}
+
+// Limit implements the config.TrafficClass interface.
+func (c TrafficClassConfig) Limit() uint64 {
+	return mustParseBitrate(c.TrafficClassLimit)
+}
+
+// Priority implements the config.TrafficClass interface.
+func (c TrafficClassConfig) Priority() int {
+	return c.TrafficClassPriority
+}
+
+// Ports implements the config.TrafficClass interface.
+func (c TrafficClassConfig) Ports() []int {
+	return c.TrafficClassPorts
+}
+
+// Subnets implements the config.TrafficClass interface.
+func (c TrafficClassConfig) Subnets() []string {
+	return c.TrafficClassSubnets
+}
@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+)
+
+// Validate checks NIC tuning settings for errors.
+func (t DeviceTuningConfig) Validate(iface string) error {
+	var errs *multierror.Error
+
+	for _, list := range []struct {
+		name  string
+		value string
+	}{
+		{"IRQ", t.TuningIRQCPUs},
+		{"RPS", t.TuningRPSCPUs},
+		{"XPS", t.TuningXPSCPUs},
+	} {
+		if list.value == "" {
+			continue
+		}
+
+		if _, err := kernel.ParseCPUList(list.value); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%s CPUs for interface %q: %w", list.name, iface, err))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// CombinedQueues implements the config.DeviceTuning interface.
+func (t *DeviceTuningConfig) CombinedQueues() uint32 {
+	return t.TuningCombinedQueues
+}
+
+// IRQCPUs implements the config.DeviceTuning interface.
+func (t *DeviceTuningConfig) IRQCPUs() string {
+	return t.TuningIRQCPUs
+}
+
+// RPSCPUs implements the config.DeviceTuning interface.
+func (t *DeviceTuningConfig) RPSCPUs() string {
+	return t.TuningRPSCPUs
+}
+
+// XPSCPUs implements the config.DeviceTuning interface.
+func (t *DeviceTuningConfig) XPSCPUs() string {
+	return t.TuningXPSCPUs
+}
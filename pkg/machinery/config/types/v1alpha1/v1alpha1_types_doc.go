@@ -12,76 +12,117 @@ import (
 )
 
 var (
-	ConfigDoc                         encoder.Doc
-	MachineConfigDoc                  encoder.Doc
-	ClusterConfigDoc                  encoder.Doc
-	ExtraMountDoc                     encoder.Doc
-	MachineControlPlaneConfigDoc      encoder.Doc
-	MachineControllerManagerConfigDoc encoder.Doc
-	MachineSchedulerConfigDoc         encoder.Doc
-	KubeletConfigDoc                  encoder.Doc
-	KubeletNodeIPConfigDoc            encoder.Doc
-	NetworkConfigDoc                  encoder.Doc
-	InstallConfigDoc                  encoder.Doc
-	InstallDiskSelectorDoc            encoder.Doc
-	TimeConfigDoc                     encoder.Doc
-	RegistriesConfigDoc               encoder.Doc
-	PodCheckpointerDoc                encoder.Doc
-	CoreDNSDoc                        encoder.Doc
-	EndpointDoc                       encoder.Doc
-	ControlPlaneConfigDoc             encoder.Doc
-	APIServerConfigDoc                encoder.Doc
-	ControllerManagerConfigDoc        encoder.Doc
-	ProxyConfigDoc                    encoder.Doc
-	SchedulerConfigDoc                encoder.Doc
-	EtcdConfigDoc                     encoder.Doc
-	ClusterNetworkConfigDoc           encoder.Doc
-	CNIConfigDoc                      encoder.Doc
-	ExternalCloudProviderConfigDoc    encoder.Doc
-	AdminKubeconfigConfigDoc          encoder.Doc
-	MachineDiskDoc                    encoder.Doc
-	DiskPartitionDoc                  encoder.Doc
-	EncryptionConfigDoc               encoder.Doc
-	EncryptionKeyDoc                  encoder.Doc
-	EncryptionKeyStaticDoc            encoder.Doc
-	EncryptionKeyNodeIDDoc            encoder.Doc
-	MachineFileDoc                    encoder.Doc
-	ExtraHostDoc                      encoder.Doc
-	DeviceDoc                         encoder.Doc
-	DHCPOptionsDoc                    encoder.Doc
-	DeviceWireguardConfigDoc          encoder.Doc
-	DeviceWireguardPeerDoc            encoder.Doc
-	DeviceVIPConfigDoc                encoder.Doc
-	VIPEquinixMetalConfigDoc          encoder.Doc
-	VIPHCloudConfigDoc                encoder.Doc
-	BondDoc                           encoder.Doc
-	VlanDoc                           encoder.Doc
-	RouteDoc                          encoder.Doc
-	RegistryMirrorConfigDoc           encoder.Doc
-	RegistryConfigDoc                 encoder.Doc
-	RegistryAuthConfigDoc             encoder.Doc
-	RegistryTLSConfigDoc              encoder.Doc
-	SystemDiskEncryptionConfigDoc     encoder.Doc
-	FeaturesConfigDoc                 encoder.Doc
-	VolumeMountConfigDoc              encoder.Doc
-	ClusterInlineManifestDoc          encoder.Doc
-	NetworkKubeSpanDoc                encoder.Doc
-	ClusterDiscoveryConfigDoc         encoder.Doc
-	DiscoveryRegistriesConfigDoc      encoder.Doc
-	RegistryKubernetesConfigDoc       encoder.Doc
-	RegistryServiceConfigDoc          encoder.Doc
-	UdevConfigDoc                     encoder.Doc
-	LoggingConfigDoc                  encoder.Doc
-	LoggingDestinationDoc             encoder.Doc
+	ConfigDoc                           encoder.Doc
+	MachineConfigDoc                    encoder.Doc
+	ClusterConfigDoc                    encoder.Doc
+	ExtraMountDoc                       encoder.Doc
+	MachineControlPlaneConfigDoc        encoder.Doc
+	MachineControllerManagerConfigDoc   encoder.Doc
+	MachineSchedulerConfigDoc           encoder.Doc
+	KubeletConfigDoc                    encoder.Doc
+	KubeletCredentialProvidersConfigDoc encoder.Doc
+	KubeletCredentialProviderConfigDoc  encoder.Doc
+	KubeletNodeIPConfigDoc              encoder.Doc
+	NetworkConfigDoc                    encoder.Doc
+	InstallConfigDoc                    encoder.Doc
+	InstallDiskSelectorDoc              encoder.Doc
+	TimeConfigDoc                       encoder.Doc
+	RegistriesConfigDoc                 encoder.Doc
+	RegistryPullsConfigDoc              encoder.Doc
+	MaintenanceWindowConfigDoc          encoder.Doc
+	PodCheckpointerDoc                  encoder.Doc
+	CoreDNSDoc                          encoder.Doc
+	EndpointDoc                         encoder.Doc
+	ControlPlaneConfigDoc               encoder.Doc
+	APIServerConfigDoc                  encoder.Doc
+	ControllerManagerConfigDoc          encoder.Doc
+	ProxyConfigDoc                      encoder.Doc
+	SchedulerConfigDoc                  encoder.Doc
+	EtcdConfigDoc                       encoder.Doc
+	ClusterNetworkConfigDoc             encoder.Doc
+	PodSubnetSizeConfigDoc              encoder.Doc
+	CNIConfigDoc                        encoder.Doc
+	ExternalCloudProviderConfigDoc      encoder.Doc
+	InstanceIdentityConfigDoc           encoder.Doc
+	AdminKubeconfigConfigDoc            encoder.Doc
+	MachineDiskDoc                      encoder.Doc
+	DiskPartitionDoc                    encoder.Doc
+	EphemeralConfigDoc                  encoder.Doc
+	EncryptionConfigDoc                 encoder.Doc
+	EncryptionKeyDoc                    encoder.Doc
+	EncryptionKeyStaticDoc              encoder.Doc
+	EncryptionKeyNodeIDDoc              encoder.Doc
+	MachineFileDoc                      encoder.Doc
+	ExtraHostDoc                        encoder.Doc
+	DeviceDoc                           encoder.Doc
+	TrafficShapingConfigDoc             encoder.Doc
+	TrafficClassConfigDoc               encoder.Doc
+	DeviceTuningConfigDoc               encoder.Doc
+	DHCPOptionsDoc                      encoder.Doc
+	DeviceWireguardConfigDoc            encoder.Doc
+	DeviceWireguardPeerDoc              encoder.Doc
+	DeviceVIPConfigDoc                  encoder.Doc
+	VIPEquinixMetalConfigDoc            encoder.Doc
+	VIPHCloudConfigDoc                  encoder.Doc
+	BondDoc                             encoder.Doc
+	VlanDoc                             encoder.Doc
+	RouteDoc                            encoder.Doc
+	RegistryMirrorConfigDoc             encoder.Doc
+	RegistryConfigDoc                   encoder.Doc
+	RegistryAuthConfigDoc               encoder.Doc
+	RegistryTLSConfigDoc                encoder.Doc
+	SystemDiskEncryptionConfigDoc       encoder.Doc
+	FeaturesConfigDoc                   encoder.Doc
+	ControlPlaneLoadBalancerConfigDoc   encoder.Doc
+	VolumeMountConfigDoc                encoder.Doc
+	ClusterInlineManifestDoc            encoder.Doc
+	NetworkKubeSpanDoc                  encoder.Doc
+	ConntrackConfigDoc                  encoder.Doc
+	ClusterDiscoveryConfigDoc           encoder.Doc
+	DiscoveryRegistriesConfigDoc        encoder.Doc
+	RegistryKubernetesConfigDoc         encoder.Doc
+	RegistryServiceConfigDoc            encoder.Doc
+	UdevConfigDoc                       encoder.Doc
+	LoggingConfigDoc                    encoder.Doc
+	LoggingRateLimitDoc                 encoder.Doc
+	SessionRecordingConfigDoc           encoder.Doc
+	ServiceHookDoc                      encoder.Doc
+	ServiceOverrideDoc                  encoder.Doc
+	ServiceOverrideMountDoc             encoder.Doc
+	ServiceOverrideRlimitDoc            encoder.Doc
+	IMAConfigDoc                        encoder.Doc
+	APITLSConfigDoc                     encoder.Doc
+	APITLSCertificateConfigDoc          encoder.Doc
+	BMCConfigDoc                        encoder.Doc
+	LifecycleWebhookConfigDoc           encoder.Doc
+	BackupConfigDoc                     encoder.Doc
+	BackupS3ConfigDoc                   encoder.Doc
+	BackupPathConfigDoc                 encoder.Doc
+	CRIConfigDoc                        encoder.Doc
+	HostPathPolicyConfigDoc             encoder.Doc
+	ImagePolicyConfigDoc                encoder.Doc
+	SecretsResolverConfigDoc            encoder.Doc
+	SecretsBackendConfigDoc             encoder.Doc
+	VaultSecretsBackendConfigDoc        encoder.Doc
+	AWSSecretsManagerBackendConfigDoc   encoder.Doc
+	GCPSecretManagerBackendConfigDoc    encoder.Doc
+	NodeLabelRuleConfigDoc              encoder.Doc
+	NodeLabelMatchConfigDoc             encoder.Doc
+	HealthCheckConfigDoc                encoder.Doc
+	HealthCheckHTTPConfigDoc            encoder.Doc
+	HealthCheckTCPConfigDoc             encoder.Doc
+	HealthCheckExecConfigDoc            encoder.Doc
+	KernelConfigDoc                     encoder.Doc
+	RealtimeProfileConfigDoc            encoder.Doc
+	HugePageConfigDoc                   encoder.Doc
+	LoggingDestinationDoc               encoder.Doc
 )
 
 func init() {
 	ConfigDoc.Type = "Config"
 	ConfigDoc.Comments[encoder.LineComment] = "Config defines the v1alpha1 configuration file."
-	ConfigDoc.Description = "Config defines the v1alpha1 configuration file."
-
-	ConfigDoc.AddExample("", configExample)
-	ConfigDoc.Fields = make([]encoder.Doc, 5)
+	ConfigDoc.Description = "Config defines the v1alpha1 configuration file.\n\n	examples:\n	   - value: configExample\n"
+	ConfigDoc.Fields = make([]encoder.Doc, 6)
 	ConfigDoc.Fields[0].Name = "version"
 	ConfigDoc.Fields[0].Type = "string"
 	ConfigDoc.Fields[0].Note = ""
@@ -125,16 +166,14 @@ func init() {
 
 	MachineConfigDoc.Type = "MachineConfig"
 	MachineConfigDoc.Comments[encoder.LineComment] = "MachineConfig represents the machine-specific config values."
-	MachineConfigDoc.Description = "MachineConfig represents the machine-specific config values."
-
-	MachineConfigDoc.AddExample("", machineConfigExample)
+	MachineConfigDoc.Description = "MachineConfig represents the machine-specific config values.\n\n	examples:\n	   - value: machineConfigExample\n"
 	MachineConfigDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "Config",
 			FieldName: "machine",
 		},
 	}
-	MachineConfigDoc.Fields = make([]encoder.Doc, 18)
+	MachineConfigDoc.Fields = make([]encoder.Doc, 35)
 	MachineConfigDoc.Fields[0].Name = "type"
 	MachineConfigDoc.Fields[0].Type = "string"
 	MachineConfigDoc.Fields[0].Note = ""
@@ -194,100 +233,217 @@ func init() {
 	MachineConfigDoc.Fields[7].Comments[encoder.LineComment] = "Used to partition, format and mount additional disks."
 
 	MachineConfigDoc.Fields[7].AddExample("MachineDisks list example.", machineDisksExample)
-	MachineConfigDoc.Fields[8].Name = "install"
-	MachineConfigDoc.Fields[8].Type = "InstallConfig"
+	MachineConfigDoc.Fields[8].Name = "ephemeral"
+	MachineConfigDoc.Fields[8].Type = "EphemeralConfig"
 	MachineConfigDoc.Fields[8].Note = ""
-	MachineConfigDoc.Fields[8].Description = "Used to provide instructions for installations."
-	MachineConfigDoc.Fields[8].Comments[encoder.LineComment] = "Used to provide instructions for installations."
-
-	MachineConfigDoc.Fields[8].AddExample("MachineInstall config usage example.", machineInstallExample)
-	MachineConfigDoc.Fields[9].Name = "files"
-	MachineConfigDoc.Fields[9].Type = "[]MachineFile"
-	MachineConfigDoc.Fields[9].Note = "Note: The specified `path` is relative to `/var`.\n"
-	MachineConfigDoc.Fields[9].Description = "Allows the addition of user specified files.\nThe value of `op` can be `create`, `overwrite`, or `append`.\nIn the case of `create`, `path` must not exist.\nIn the case of `overwrite`, and `append`, `path` must be a valid file.\nIf an `op` value of `append` is used, the existing file will be appended.\nNote that the file contents are not required to be base64 encoded."
-	MachineConfigDoc.Fields[9].Comments[encoder.LineComment] = "Allows the addition of user specified files."
-
-	MachineConfigDoc.Fields[9].AddExample("MachineFiles usage example.", machineFilesExample)
-	MachineConfigDoc.Fields[10].Name = "env"
-	MachineConfigDoc.Fields[10].Type = "Env"
-	MachineConfigDoc.Fields[10].Note = ""
-	MachineConfigDoc.Fields[10].Description = "The `env` field allows for the addition of environment variables.\nAll environment variables are set on PID 1 in addition to every service."
-	MachineConfigDoc.Fields[10].Comments[encoder.LineComment] = "The `env` field allows for the addition of environment variables."
+	MachineConfigDoc.Fields[8].Description = "Configures the mount options of the `EPHEMERAL` partition mounted at `/var`."
+	MachineConfigDoc.Fields[8].Comments[encoder.LineComment] = "Configures the mount options of the `EPHEMERAL` partition mounted at `/var`."
+
+	MachineConfigDoc.Fields[8].AddExample("", machineEphemeralExample)
+	MachineConfigDoc.Fields[9].Name = "install"
+	MachineConfigDoc.Fields[9].Type = "InstallConfig"
+	MachineConfigDoc.Fields[9].Note = ""
+	MachineConfigDoc.Fields[9].Description = "Used to provide instructions for installations."
+	MachineConfigDoc.Fields[9].Comments[encoder.LineComment] = "Used to provide instructions for installations."
+
+	MachineConfigDoc.Fields[9].AddExample("MachineInstall config usage example.", machineInstallExample)
+	MachineConfigDoc.Fields[10].Name = "files"
+	MachineConfigDoc.Fields[10].Type = "[]MachineFile"
+	MachineConfigDoc.Fields[10].Note = "Note: The specified `path` is relative to `/var`.\n"
+	MachineConfigDoc.Fields[10].Description = "Allows the addition of user specified files.\nThe value of `op` can be `create`, `overwrite`, or `append`.\nIn the case of `create`, `path` must not exist.\nIn the case of `overwrite`, and `append`, `path` must be a valid file.\nIf an `op` value of `append` is used, the existing file will be appended.\nNote that the file contents are not required to be base64 encoded."
+	MachineConfigDoc.Fields[10].Comments[encoder.LineComment] = "Allows the addition of user specified files."
+
+	MachineConfigDoc.Fields[10].AddExample("MachineFiles usage example.", machineFilesExample)
+	MachineConfigDoc.Fields[11].Name = "env"
+	MachineConfigDoc.Fields[11].Type = "Env"
+	MachineConfigDoc.Fields[11].Note = ""
+	MachineConfigDoc.Fields[11].Description = "The `env` field allows for the addition of environment variables.\nAll environment variables are set on PID 1 in addition to every service."
+	MachineConfigDoc.Fields[11].Comments[encoder.LineComment] = "The `env` field allows for the addition of environment variables."
 
-	MachineConfigDoc.Fields[10].AddExample("Environment variables definition examples.", machineEnvExamples[0])
+	MachineConfigDoc.Fields[11].AddExample("Environment variables definition examples.", machineEnvExamples[0])
 
-	MachineConfigDoc.Fields[10].AddExample("", machineEnvExamples[1])
+	MachineConfigDoc.Fields[11].AddExample("", machineEnvExamples[1])
 
-	MachineConfigDoc.Fields[10].AddExample("", machineEnvExamples[2])
-	MachineConfigDoc.Fields[10].Values = []string{
+	MachineConfigDoc.Fields[11].AddExample("", machineEnvExamples[2])
+	MachineConfigDoc.Fields[11].Values = []string{
 		"`GRPC_GO_LOG_VERBOSITY_LEVEL`",
 		"`GRPC_GO_LOG_SEVERITY_LEVEL`",
 		"`http_proxy`",
 		"`https_proxy`",
 		"`no_proxy`",
 	}
-	MachineConfigDoc.Fields[11].Name = "time"
-	MachineConfigDoc.Fields[11].Type = "TimeConfig"
-	MachineConfigDoc.Fields[11].Note = ""
-	MachineConfigDoc.Fields[11].Description = "Used to configure the machine's time settings."
-	MachineConfigDoc.Fields[11].Comments[encoder.LineComment] = "Used to configure the machine's time settings."
-
-	MachineConfigDoc.Fields[11].AddExample("Example configuration for cloudflare ntp server.", machineTimeExample)
-	MachineConfigDoc.Fields[12].Name = "sysctls"
-	MachineConfigDoc.Fields[12].Type = "map[string]string"
+	MachineConfigDoc.Fields[12].Name = "time"
+	MachineConfigDoc.Fields[12].Type = "TimeConfig"
 	MachineConfigDoc.Fields[12].Note = ""
-	MachineConfigDoc.Fields[12].Description = "Used to configure the machine's sysctls."
-	MachineConfigDoc.Fields[12].Comments[encoder.LineComment] = "Used to configure the machine's sysctls."
+	MachineConfigDoc.Fields[12].Description = "Used to configure the machine's time settings."
+	MachineConfigDoc.Fields[12].Comments[encoder.LineComment] = "Used to configure the machine's time settings."
 
-	MachineConfigDoc.Fields[12].AddExample("MachineSysctls usage example.", machineSysctlsExample)
-	MachineConfigDoc.Fields[13].Name = "registries"
-	MachineConfigDoc.Fields[13].Type = "RegistriesConfig"
+	MachineConfigDoc.Fields[12].AddExample("Example configuration for cloudflare ntp server.", machineTimeExample)
+	MachineConfigDoc.Fields[13].Name = "sysctls"
+	MachineConfigDoc.Fields[13].Type = "map[string]string"
 	MachineConfigDoc.Fields[13].Note = ""
-	MachineConfigDoc.Fields[13].Description = "Used to configure the machine's container image registry mirrors.\n\nAutomatically generates matching CRI configuration for registry mirrors.\n\nThe `mirrors` section allows to redirect requests for images to non-default registry,\nwhich might be local registry or caching mirror.\n\nThe `config` section provides a way to authenticate to the registry with TLS client\nidentity, provide registry CA, or authentication information.\nAuthentication information has same meaning with the corresponding field in `.docker/config.json`.\n\nSee also matching configuration for [CRI containerd plugin](https://github.com/containerd/cri/blob/master/docs/registry.md)."
-	MachineConfigDoc.Fields[13].Comments[encoder.LineComment] = "Used to configure the machine's container image registry mirrors."
+	MachineConfigDoc.Fields[13].Description = "Used to configure the machine's sysctls."
+	MachineConfigDoc.Fields[13].Comments[encoder.LineComment] = "Used to configure the machine's sysctls."
 
-	MachineConfigDoc.Fields[13].AddExample("", machineConfigRegistriesExample)
-	MachineConfigDoc.Fields[14].Name = "systemDiskEncryption"
-	MachineConfigDoc.Fields[14].Type = "SystemDiskEncryptionConfig"
+	MachineConfigDoc.Fields[13].AddExample("MachineSysctls usage example.", machineSysctlsExample)
+	MachineConfigDoc.Fields[14].Name = "registries"
+	MachineConfigDoc.Fields[14].Type = "RegistriesConfig"
 	MachineConfigDoc.Fields[14].Note = ""
-	MachineConfigDoc.Fields[14].Description = "Machine system disk encryption configuration.\nDefines each system partition encryption parameters."
-	MachineConfigDoc.Fields[14].Comments[encoder.LineComment] = "Machine system disk encryption configuration."
+	MachineConfigDoc.Fields[14].Description = "Used to configure the machine's container image registry mirrors.\n\nAutomatically generates matching CRI configuration for registry mirrors.\n\nThe `mirrors` section allows to redirect requests for images to non-default registry,\nwhich might be local registry or caching mirror.\n\nThe `config` section provides a way to authenticate to the registry with TLS client\nidentity, provide registry CA, or authentication information.\nAuthentication information has same meaning with the corresponding field in `.docker/config.json`.\n\nSee also matching configuration for [CRI containerd plugin](https://github.com/containerd/cri/blob/master/docs/registry.md)."
+	MachineConfigDoc.Fields[14].Comments[encoder.LineComment] = "Used to configure the machine's container image registry mirrors."
 
-	MachineConfigDoc.Fields[14].AddExample("", machineSystemDiskEncryptionExample)
-	MachineConfigDoc.Fields[15].Name = "features"
-	MachineConfigDoc.Fields[15].Type = "FeaturesConfig"
+	MachineConfigDoc.Fields[14].AddExample("", machineConfigRegistriesExample)
+	MachineConfigDoc.Fields[15].Name = "systemDiskEncryption"
+	MachineConfigDoc.Fields[15].Type = "SystemDiskEncryptionConfig"
 	MachineConfigDoc.Fields[15].Note = ""
-	MachineConfigDoc.Fields[15].Description = "Features describe individual Talos features that can be switched on or off."
-	MachineConfigDoc.Fields[15].Comments[encoder.LineComment] = "Features describe individual Talos features that can be switched on or off."
+	MachineConfigDoc.Fields[15].Description = "Machine system disk encryption configuration.\nDefines each system partition encryption parameters."
+	MachineConfigDoc.Fields[15].Comments[encoder.LineComment] = "Machine system disk encryption configuration."
 
-	MachineConfigDoc.Fields[15].AddExample("", machineFeaturesExample)
-	MachineConfigDoc.Fields[16].Name = "udev"
-	MachineConfigDoc.Fields[16].Type = "UdevConfig"
+	MachineConfigDoc.Fields[15].AddExample("", machineSystemDiskEncryptionExample)
+	MachineConfigDoc.Fields[16].Name = "features"
+	MachineConfigDoc.Fields[16].Type = "FeaturesConfig"
 	MachineConfigDoc.Fields[16].Note = ""
-	MachineConfigDoc.Fields[16].Description = "Configures the udev system."
-	MachineConfigDoc.Fields[16].Comments[encoder.LineComment] = "Configures the udev system."
+	MachineConfigDoc.Fields[16].Description = "Features describe individual Talos features that can be switched on or off."
+	MachineConfigDoc.Fields[16].Comments[encoder.LineComment] = "Features describe individual Talos features that can be switched on or off."
 
-	MachineConfigDoc.Fields[16].AddExample("", machineUdevExample)
-	MachineConfigDoc.Fields[17].Name = "logging"
-	MachineConfigDoc.Fields[17].Type = "LoggingConfig"
+	MachineConfigDoc.Fields[16].AddExample("", machineFeaturesExample)
+	MachineConfigDoc.Fields[17].Name = "udev"
+	MachineConfigDoc.Fields[17].Type = "UdevConfig"
 	MachineConfigDoc.Fields[17].Note = ""
-	MachineConfigDoc.Fields[17].Description = "Configures the logging system."
-	MachineConfigDoc.Fields[17].Comments[encoder.LineComment] = "Configures the logging system."
-
-	MachineConfigDoc.Fields[17].AddExample("", machineLoggingExample)
+	MachineConfigDoc.Fields[17].Description = "Configures the udev system."
+	MachineConfigDoc.Fields[17].Comments[encoder.LineComment] = "Configures the udev system."
+
+	MachineConfigDoc.Fields[17].AddExample("", machineUdevExample)
+	MachineConfigDoc.Fields[18].Name = "logging"
+	MachineConfigDoc.Fields[18].Type = "LoggingConfig"
+	MachineConfigDoc.Fields[18].Note = ""
+	MachineConfigDoc.Fields[18].Description = "Configures the logging system."
+	MachineConfigDoc.Fields[18].Comments[encoder.LineComment] = "Configures the logging system."
+
+	MachineConfigDoc.Fields[18].AddExample("", machineLoggingExample)
+	MachineConfigDoc.Fields[19].Name = "serviceHooks"
+	MachineConfigDoc.Fields[19].Type = "[]ServiceHook"
+	MachineConfigDoc.Fields[19].Note = ""
+	MachineConfigDoc.Fields[19].Description = "Hooks run before or after the system services are started.\n\nHook output is available via `talosctl logs <service>-<stage>-hook-<index>`,\nand hook progress is reported in the service events (`talosctl service <service>`)."
+	MachineConfigDoc.Fields[19].Comments[encoder.LineComment] = "Hooks run before or after the system services are started."
+
+	MachineConfigDoc.Fields[19].AddExample("", machineServiceHooksExample)
+	MachineConfigDoc.Fields[20].Name = "serviceOverrides"
+	MachineConfigDoc.Fields[20].Type = "[]ServiceOverride"
+	MachineConfigDoc.Fields[20].Note = ""
+	MachineConfigDoc.Fields[20].Description = "Adjusts the container spec of the system services.\n\nOverrides are supported for `apid`, `trustd`, `etcd` and `kubelet`.\nEnvironment variables and mounts which are managed by Talos can't be overridden."
+	MachineConfigDoc.Fields[20].Comments[encoder.LineComment] = "Adjusts the container spec of the system services."
+
+	MachineConfigDoc.Fields[20].AddExample("", machineServiceOverridesExample)
+	MachineConfigDoc.Fields[21].Name = "ima"
+	MachineConfigDoc.Fields[21].Type = "IMAConfig"
+	MachineConfigDoc.Fields[21].Note = ""
+	MachineConfigDoc.Fields[21].Description = "Configures the IMA (Integrity Measurement Architecture) policy.\n\nIMA measurements are extended into PCR 10 and returned along with the TPM quote\nvia the `Attestation` API.\nDefault Talos policy is loaded early on boot (before the machine configuration), policy rules from the machine configuration\nare appended to it once the configuration is loaded, which requires the kernel built with `CONFIG_IMA_WRITE_POLICY`.\nChanges are applied on reboot."
+	MachineConfigDoc.Fields[21].Comments[encoder.LineComment] = "Configures the IMA (Integrity Measurement Architecture) policy."
+
+	MachineConfigDoc.Fields[21].AddExample("", machineIMAExample)
+	MachineConfigDoc.Fields[22].Name = "apiTLS"
+	MachineConfigDoc.Fields[22].Type = "APITLSConfig"
+	MachineConfigDoc.Fields[22].Note = ""
+	MachineConfigDoc.Fields[22].Description = "Configures TLS settings of the Talos API (apid) and trustd listeners.\n\nChanges can be applied without a reboot (`--immediate`), apid and trustd are restarted to pick them up."
+	MachineConfigDoc.Fields[22].Comments[encoder.LineComment] = "Configures TLS settings of the Talos API (apid) and trustd listeners."
+
+	MachineConfigDoc.Fields[22].AddExample("", machineAPITLSExample)
+	MachineConfigDoc.Fields[23].Name = "bmc"
+	MachineConfigDoc.Fields[23].Type = "BMCConfig"
+	MachineConfigDoc.Fields[23].Note = ""
+	MachineConfigDoc.Fields[23].Description = "Configures the integration with the node BMC (baseboard management controller) via IPMI.\n\nBMC information is available as the `BMCInfo` resource (`talosctl get bmcinfo`)."
+	MachineConfigDoc.Fields[23].Comments[encoder.LineComment] = "Configures the integration with the node BMC (baseboard management controller) via IPMI."
+
+	MachineConfigDoc.Fields[23].AddExample("", machineBMCExample)
+	MachineConfigDoc.Fields[24].Name = "hugePages"
+	MachineConfigDoc.Fields[24].Type = "[]HugePageConfig"
+	MachineConfigDoc.Fields[24].Note = ""
+	MachineConfigDoc.Fields[24].Description = "Hugepage reservations applied at boot.\n\nAchieved reservations are available as the `HugePageStatus` resources (`talosctl get hugepages`)."
+	MachineConfigDoc.Fields[24].Comments[encoder.LineComment] = "Hugepage reservations applied at boot."
+
+	MachineConfigDoc.Fields[24].AddExample("", machineHugePagesExample)
+	MachineConfigDoc.Fields[25].Name = "kernel"
+	MachineConfigDoc.Fields[25].Type = "KernelConfig"
+	MachineConfigDoc.Fields[25].Note = ""
+	MachineConfigDoc.Fields[25].Description = "Configures the kernel runtime settings applied at boot.\n\nCurrent settings are available as the `CPUPowerStatus` resource (`talosctl get cpupowerstatus`)."
+	MachineConfigDoc.Fields[25].Comments[encoder.LineComment] = "Configures the kernel runtime settings applied at boot."
+
+	MachineConfigDoc.Fields[25].AddExample("", machineKernelExample)
+	MachineConfigDoc.Fields[26].Name = "lifecycleWebhook"
+	MachineConfigDoc.Fields[26].Type = "LifecycleWebhookConfig"
+	MachineConfigDoc.Fields[26].Note = ""
+	MachineConfigDoc.Fields[26].Description = "Configures the webhook the node lifecycle events are posted to:\nconfig applied, upgrade, reset, reboot and shutdown.\n\nDelivery is best effort: failed requests are logged and not retried after the timeout."
+	MachineConfigDoc.Fields[26].Comments[encoder.LineComment] = "Configures the webhook the node lifecycle events are posted to:"
+
+	MachineConfigDoc.Fields[26].AddExample("", machineLifecycleWebhookExample)
+	MachineConfigDoc.Fields[27].Name = "nodeLabelRules"
+	MachineConfigDoc.Fields[27].Type = "[]NodeLabelRuleConfig"
+	MachineConfigDoc.Fields[27].Note = ""
+	MachineConfigDoc.Fields[27].Description = "Rules to label the Kubernetes node based on the detected hardware:\nCPU vendor and flags, GPU models, disk classes and network interface speed.\nIf several rules for the same label match, the first one is applied.\n\nLabels are applied via the kubelet credentials, so the `kubernetes.io` and `k8s.io` label prefixes\n(except for `node.kubernetes.io` and `kubelet.kubernetes.io`) are not allowed.\nDetected hardware is available as the `HardwareFacts` resource (`talosctl get hwfacts`)."
+	MachineConfigDoc.Fields[27].Comments[encoder.LineComment] = "Rules to label the Kubernetes node based on the detected hardware:"
+
+	MachineConfigDoc.Fields[27].AddExample("", machineNodeLabelRulesExample)
+	MachineConfigDoc.Fields[28].Name = "nodeLabels"
+	MachineConfigDoc.Fields[28].Type = "map[string]string"
+	MachineConfigDoc.Fields[28].Note = ""
+	MachineConfigDoc.Fields[28].Description = "Labels of the Kubernetes node.\n\nLabels are passed to the kubelet as `--node-labels` on the node registration,\nand the label changes are applied to the registered node via the kubelet credentials.\nLabels take precedence over the labels set by the `nodeLabelRules`.\nThe `kubernetes.io` and `k8s.io` label prefixes are allowed only for the labels the kubelet is allowed to set\n(`node.kubernetes.io`, `kubelet.kubernetes.io` prefixes and the well-known labels like `topology.kubernetes.io/zone`)."
+	MachineConfigDoc.Fields[28].Comments[encoder.LineComment] = "Labels of the Kubernetes node."
+
+	MachineConfigDoc.Fields[28].AddExample("", machineNodeLabelsExample)
+	MachineConfigDoc.Fields[29].Name = "nodeTaints"
+	MachineConfigDoc.Fields[29].Type = "map[string]string"
+	MachineConfigDoc.Fields[29].Note = ""
+	MachineConfigDoc.Fields[29].Description = "Taints of the Kubernetes node in the `[value:]effect` format.\n\nTaints are passed to the kubelet as `--register-with-taints`, so they are applied only when the node is registered."
+	MachineConfigDoc.Fields[29].Comments[encoder.LineComment] = "Taints of the Kubernetes node in the `[value:]effect` format."
+
+	MachineConfigDoc.Fields[29].AddExample("", machineNodeTaintsExample)
+	MachineConfigDoc.Fields[30].Name = "healthChecks"
+	MachineConfigDoc.Fields[30].Type = "[]HealthCheckConfig"
+	MachineConfigDoc.Fields[30].Note = ""
+	MachineConfigDoc.Fields[30].Description = "Additional node health checks: HTTP endpoints, TCP ports and commands run in the containers.\n\nCheck results are available as the `HealthCheckStatus` resources (`talosctl get healthchecks`),\nfailing checks are reported by `talosctl health` and `talosctl dashboard`."
+	MachineConfigDoc.Fields[30].Comments[encoder.LineComment] = "Additional node health checks: HTTP endpoints, TCP ports and commands run in the containers."
+
+	MachineConfigDoc.Fields[30].AddExample("", machineHealthChecksExample)
+	MachineConfigDoc.Fields[31].Name = "backup"
+	MachineConfigDoc.Fields[31].Type = "BackupConfig"
+	MachineConfigDoc.Fields[31].Note = ""
+	MachineConfigDoc.Fields[31].Description = "Backs up the host directories (hostPath and local volumes) to the S3-compatible storage\nbefore the reset and the upgrades which don't preserve the data.\n\nBackups are taken once the pods are stopped, each directory is uploaded as\n`<prefix><node name>/<timestamp>/<path>.tar.gz`."
+	MachineConfigDoc.Fields[31].Comments[encoder.LineComment] = "Backs up the host directories (hostPath and local volumes) to the S3-compatible storage"
+
+	MachineConfigDoc.Fields[31].AddExample("", machineBackupExample)
+	MachineConfigDoc.Fields[32].Name = "cri"
+	MachineConfigDoc.Fields[32].Type = "CRIConfig"
+	MachineConfigDoc.Fields[32].Note = ""
+	MachineConfigDoc.Fields[32].Description = "Configures the CRI containerd plugin.\n\nChanges can be applied without a reboot (`--immediate`), the CRI containerd config is regenerated\nand the CRI containerd is restarted to pick them up."
+	MachineConfigDoc.Fields[32].Comments[encoder.LineComment] = "Configures the CRI containerd plugin."
+
+	MachineConfigDoc.Fields[32].AddExample("", machineCRIExample)
+	MachineConfigDoc.Fields[33].Name = "secretsResolver"
+	MachineConfigDoc.Fields[33].Type = "SecretsResolverConfig"
+	MachineConfigDoc.Fields[33].Note = ""
+	MachineConfigDoc.Fields[33].Description = "Configures the external secrets backends the secret references in the machine config are resolved from.\n\nAny string value in the machine config might be set to the secret reference `secret://<backend>/<path>[#<key>]`,\nreferences are resolved when the config is loaded.\nThe resolved values are kept in memory only, the config is always stored and returned via the API with the references."
+	MachineConfigDoc.Fields[33].Comments[encoder.LineComment] = "Configures the external secrets backends the secret references in the machine config are resolved from."
+
+	MachineConfigDoc.Fields[33].AddExample("", machineSecretsResolverExample)
+	MachineConfigDoc.Fields[34].Name = "pods"
+	MachineConfigDoc.Fields[34].Type = "[]Unstructured"
+	MachineConfigDoc.Fields[34].Note = ""
+	MachineConfigDoc.Fields[34].Description = "Static pods run by the kubelet on the node.\n\nEach entry is a Kubernetes `Pod` manifest, the pods are rendered into the kubelet static pod directory\nand updated or removed as the machine config changes (`--immediate`).\nPod status is available as the `StaticPodStatus` resources (`talosctl get staticpodstatus`)\non the nodes with the Kubernetes control plane secrets (control plane nodes)."
+	MachineConfigDoc.Fields[34].Comments[encoder.LineComment] = "Static pods run by the kubelet on the node."
+
+	MachineConfigDoc.Fields[34].AddExample("", machinePodsExample)
 
 	ClusterConfigDoc.Type = "ClusterConfig"
 	ClusterConfigDoc.Comments[encoder.LineComment] = "ClusterConfig represents the cluster-wide config values."
-	ClusterConfigDoc.Description = "ClusterConfig represents the cluster-wide config values."
-
-	ClusterConfigDoc.AddExample("", clusterConfigExample)
+	ClusterConfigDoc.Description = "ClusterConfig represents the cluster-wide config values.\n\n	examples:\n	   - value: clusterConfigExample\n"
 	ClusterConfigDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "Config",
 			FieldName: "cluster",
 		},
 	}
-	ClusterConfigDoc.Fields = make([]encoder.Doc, 23)
+	ClusterConfigDoc.Fields = make([]encoder.Doc, 24)
 	ClusterConfigDoc.Fields[0].Name = "id"
 	ClusterConfigDoc.Fields[0].Type = "string"
 	ClusterConfigDoc.Fields[0].Note = ""
@@ -320,7 +476,7 @@ func init() {
 	ClusterConfigDoc.Fields[5].Name = "token"
 	ClusterConfigDoc.Fields[5].Type = "string"
 	ClusterConfigDoc.Fields[5].Note = ""
-	ClusterConfigDoc.Fields[5].Description = "The [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/) used to join the cluster."
+	ClusterConfigDoc.Fields[5].Description = "The [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/) used to join the cluster.\nKubelets prefer the short-lived tokens minted by the control plane nodes, the token is used as a fallback\nuntil it is retired with `.machine.features.retireStaticBootstrapToken`."
 	ClusterConfigDoc.Fields[5].Comments[encoder.LineComment] = "The [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/) used to join the cluster."
 
 	ClusterConfigDoc.Fields[5].AddExample("Bootstrap token example (do not use in production!).", "wlzjyw.bei2zfylhs2by0wd")
@@ -442,12 +598,19 @@ func init() {
 	ClusterConfigDoc.Fields[21].Comments[encoder.LineComment] = "Settings for admin kubeconfig generation."
 
 	ClusterConfigDoc.Fields[21].AddExample("", clusterAdminKubeconfigExample)
-	ClusterConfigDoc.Fields[22].Name = "allowSchedulingOnMasters"
-	ClusterConfigDoc.Fields[22].Type = "bool"
+	ClusterConfigDoc.Fields[22].Name = "instanceIdentity"
+	ClusterConfigDoc.Fields[22].Type = "InstanceIdentityConfig"
 	ClusterConfigDoc.Fields[22].Note = ""
-	ClusterConfigDoc.Fields[22].Description = "Allows running workload on master nodes."
-	ClusterConfigDoc.Fields[22].Comments[encoder.LineComment] = "Allows running workload on master nodes."
-	ClusterConfigDoc.Fields[22].Values = []string{
+	ClusterConfigDoc.Fields[22].Description = "Configures the verification of the platform instance identity documents.\n\nNodes on the platforms providing signed instance identity documents (AWS, GCP) present them to trustd,\ntrustd might be configured to issue the certificates only to the verified instances."
+	ClusterConfigDoc.Fields[22].Comments[encoder.LineComment] = "Configures the verification of the platform instance identity documents."
+
+	ClusterConfigDoc.Fields[22].AddExample("", clusterInstanceIdentityExample)
+	ClusterConfigDoc.Fields[23].Name = "allowSchedulingOnMasters"
+	ClusterConfigDoc.Fields[23].Type = "bool"
+	ClusterConfigDoc.Fields[23].Note = ""
+	ClusterConfigDoc.Fields[23].Description = "Allows running workload on master nodes."
+	ClusterConfigDoc.Fields[23].Comments[encoder.LineComment] = "Allows running workload on master nodes."
+	ClusterConfigDoc.Fields[23].Values = []string{
 		"true",
 		"yes",
 		"false",
@@ -533,7 +696,7 @@ func init() {
 			FieldName: "kubelet",
 		},
 	}
-	KubeletConfigDoc.Fields = make([]encoder.Doc, 6)
+	KubeletConfigDoc.Fields = make([]encoder.Doc, 28)
 	KubeletConfigDoc.Fields[0].Name = "image"
 	KubeletConfigDoc.Fields[0].Type = "string"
 	KubeletConfigDoc.Fields[0].Note = ""
@@ -564,24 +727,262 @@ func init() {
 	KubeletConfigDoc.Fields[3].Comments[encoder.LineComment] = "The `extraMounts` field is used to add additional mounts to the kubelet container."
 
 	KubeletConfigDoc.Fields[3].AddExample("", kubeletExtraMountsExample)
-	KubeletConfigDoc.Fields[4].Name = "registerWithFQDN"
+	KubeletConfigDoc.Fields[4].Name = "allowUnsafeMounts"
 	KubeletConfigDoc.Fields[4].Type = "bool"
 	KubeletConfigDoc.Fields[4].Note = ""
-	KubeletConfigDoc.Fields[4].Description = "The `registerWithFQDN` field is used to force kubelet to use the node FQDN for registration.\nThis is required in clouds like AWS."
-	KubeletConfigDoc.Fields[4].Comments[encoder.LineComment] = "The `registerWithFQDN` field is used to force kubelet to use the node FQDN for registration."
+	KubeletConfigDoc.Fields[4].Description = "The `allowUnsafeMounts` field allows `extraMounts` to expose the sensitive host paths\n(`/system`, `/etc/kubernetes/pki`, `/dev/mem`, etc.) to the kubelet container.\n\nSuch mounts are rejected by default, and a warning is logged for each of them when allowed."
+	KubeletConfigDoc.Fields[4].Comments[encoder.LineComment] = "The `allowUnsafeMounts` field allows `extraMounts` to expose the sensitive host paths"
 	KubeletConfigDoc.Fields[4].Values = []string{
 		"true",
 		"yes",
 		"false",
 		"no",
 	}
-	KubeletConfigDoc.Fields[5].Name = "nodeIP"
-	KubeletConfigDoc.Fields[5].Type = "KubeletNodeIPConfig"
+	KubeletConfigDoc.Fields[5].Name = "extraCapabilities"
+	KubeletConfigDoc.Fields[5].Type = "[]string"
 	KubeletConfigDoc.Fields[5].Note = ""
-	KubeletConfigDoc.Fields[5].Description = "The `nodeIP` field is used to configure `--node-ip` flag for the kubelet.\nThis is used when a node has multiple addresses to choose from."
-	KubeletConfigDoc.Fields[5].Comments[encoder.LineComment] = "The `nodeIP` field is used to configure `--node-ip` flag for the kubelet."
+	KubeletConfigDoc.Fields[5].Description = "The `extraCapabilities` field is used to grant additional Linux capabilities to the kubelet container.\n\nThe kubelet container runs with a minimal set of capabilities, extra capabilities might be required\nfor some volume plugins (e.g. CSI drivers executed by the kubelet)."
+	KubeletConfigDoc.Fields[5].Comments[encoder.LineComment] = "The `extraCapabilities` field is used to grant additional Linux capabilities to the kubelet container."
+
+	KubeletConfigDoc.Fields[5].AddExample("", []string{"CAP_SYS_MODULE"})
+	KubeletConfigDoc.Fields[6].Name = "registerWithFQDN"
+	KubeletConfigDoc.Fields[6].Type = "bool"
+	KubeletConfigDoc.Fields[6].Note = ""
+	KubeletConfigDoc.Fields[6].Description = "The `registerWithFQDN` field is used to force kubelet to use the node FQDN for registration.\nThis is required in clouds like AWS."
+	KubeletConfigDoc.Fields[6].Comments[encoder.LineComment] = "The `registerWithFQDN` field is used to force kubelet to use the node FQDN for registration."
+	KubeletConfigDoc.Fields[6].Values = []string{
+		"true",
+		"yes",
+		"false",
+		"no",
+	}
+	KubeletConfigDoc.Fields[7].Name = "enableServerCertRotation"
+	KubeletConfigDoc.Fields[7].Type = "bool"
+	KubeletConfigDoc.Fields[7].Note = ""
+	KubeletConfigDoc.Fields[7].Description = "The `enableServerCertRotation` field enables the kubelet serving certificate bootstrap and rotation (`serverTLSBootstrap`).\n\nThe kubelet serving certificate is signed by the Kubernetes CA, so that the kubelet API can be accessed\nwith TLS verification (e.g. by the metrics-server).\nThe certificate signing requests are approved by the control plane nodes if they match the node addresses."
+	KubeletConfigDoc.Fields[7].Comments[encoder.LineComment] = "The `enableServerCertRotation` field enables the kubelet serving certificate bootstrap and rotation (`serverTLSBootstrap`)."
+	KubeletConfigDoc.Fields[7].Values = []string{
+		"true",
+		"yes",
+		"false",
+		"no",
+	}
+	KubeletConfigDoc.Fields[8].Name = "defaultRuntimeSeccompProfileEnabled"
+	KubeletConfigDoc.Fields[8].Type = "bool"
+	KubeletConfigDoc.Fields[8].Note = ""
+	KubeletConfigDoc.Fields[8].Description = "Use `RuntimeDefault` seccomp profile by default for all workloads (enables kubelet `seccompDefault`).\n\nWorkloads can still opt out by setting the `Unconfined` seccomp profile in the pod security context.\nReplaces the deprecated `.machine.features.kubeletDefaultRuntimeSeccompProfile`."
+	KubeletConfigDoc.Fields[8].Comments[encoder.LineComment] = "Use `RuntimeDefault` seccomp profile by default for all workloads (enables kubelet `seccompDefault`)."
+	KubeletConfigDoc.Fields[8].Values = []string{
+		"true",
+		"yes",
+		"false",
+		"no",
+	}
+	KubeletConfigDoc.Fields[9].Name = "nodeIP"
+	KubeletConfigDoc.Fields[9].Type = "KubeletNodeIPConfig"
+	KubeletConfigDoc.Fields[9].Note = ""
+	KubeletConfigDoc.Fields[9].Description = "The `nodeIP` field is used to configure `--node-ip` flag for the kubelet.\nThis is used when a node has multiple addresses to choose from."
+	KubeletConfigDoc.Fields[9].Comments[encoder.LineComment] = "The `nodeIP` field is used to configure `--node-ip` flag for the kubelet."
+
+	KubeletConfigDoc.Fields[9].AddExample("", kubeletNodeIPExample)
+	KubeletConfigDoc.Fields[10].Name = "port"
+	KubeletConfigDoc.Fields[10].Type = "int"
+	KubeletConfigDoc.Fields[10].Note = ""
+	KubeletConfigDoc.Fields[10].Description = "The port for the kubelet secure API, defaults to 10250.\n\nThe kubelet read-only port is always disabled."
+	KubeletConfigDoc.Fields[10].Comments[encoder.LineComment] = "The port for the kubelet secure API, defaults to 10250."
+
+	KubeletConfigDoc.Fields[10].AddExample("", 10250)
+	KubeletConfigDoc.Fields[11].Name = "healthzPort"
+	KubeletConfigDoc.Fields[11].Type = "int"
+	KubeletConfigDoc.Fields[11].Note = ""
+	KubeletConfigDoc.Fields[11].Description = "The port for the kubelet healthz endpoint listening on localhost, defaults to 10248."
+	KubeletConfigDoc.Fields[11].Comments[encoder.LineComment] = "The port for the kubelet healthz endpoint listening on localhost, defaults to 10248."
+
+	KubeletConfigDoc.Fields[11].AddExample("", 10248)
+	KubeletConfigDoc.Fields[12].Name = "credentialProviders"
+	KubeletConfigDoc.Fields[12].Type = "KubeletCredentialProvidersConfig"
+	KubeletConfigDoc.Fields[12].Note = ""
+	KubeletConfigDoc.Fields[12].Description = "The image credential provider plugins used by the kubelet to fetch the private registry credentials\n(e.g. for the cloud provider registries)."
+	KubeletConfigDoc.Fields[12].Comments[encoder.LineComment] = "The image credential provider plugins used by the kubelet to fetch the private registry credentials"
+
+	KubeletConfigDoc.Fields[12].AddExample("", kubeletCredentialProvidersExample)
+	KubeletConfigDoc.Fields[13].Name = "extraConfig"
+	KubeletConfigDoc.Fields[13].Type = "Unstructured"
+	KubeletConfigDoc.Fields[13].Note = ""
+	KubeletConfigDoc.Fields[13].Description = "The `extraConfig` field is used to provide kubelet configuration overrides.\n\nThe values are merged into the generated `KubeletConfiguration` (nested objects are merged, other values are replaced).\nSome fields are managed by Talos and can't be overridden."
+	KubeletConfigDoc.Fields[13].Comments[encoder.LineComment] = "The `extraConfig` field is used to provide kubelet configuration overrides."
+
+	KubeletConfigDoc.Fields[13].AddExample("", kubeletExtraConfigExample)
+	KubeletConfigDoc.Fields[14].Name = "systemReserved"
+	KubeletConfigDoc.Fields[14].Type = "map[string]string"
+	KubeletConfigDoc.Fields[14].Note = ""
+	KubeletConfigDoc.Fields[14].Description = "The resources reserved for the system daemons (kubelet `systemReserved`).\n\nThe values are merged over the Talos defaults (`cpu: 50m`, `memory: 128Mi`, `pid: 100`, `ephemeral-storage: 256Mi`).\nSupported resources are `cpu`, `memory`, `ephemeral-storage` and `pid`, values should be Kubernetes resource quantities."
+	KubeletConfigDoc.Fields[14].Comments[encoder.LineComment] = "The resources reserved for the system daemons (kubelet `systemReserved`)."
+
+	KubeletConfigDoc.Fields[14].AddExample("", kubeletSystemReservedExample)
+	KubeletConfigDoc.Fields[15].Name = "kubeReserved"
+	KubeletConfigDoc.Fields[15].Type = "map[string]string"
+	KubeletConfigDoc.Fields[15].Note = ""
+	KubeletConfigDoc.Fields[15].Description = "The resources reserved for the Kubernetes system daemons (kubelet `kubeReserved`).\n\nNothing is reserved by default.\nSupported resources are `cpu`, `memory`, `ephemeral-storage` and `pid`, values should be Kubernetes resource quantities."
+	KubeletConfigDoc.Fields[15].Comments[encoder.LineComment] = "The resources reserved for the Kubernetes system daemons (kubelet `kubeReserved`)."
+
+	KubeletConfigDoc.Fields[15].AddExample("", kubeletKubeReservedExample)
+	KubeletConfigDoc.Fields[16].Name = "imageGCHighThresholdPercent"
+	KubeletConfigDoc.Fields[16].Type = "int"
+	KubeletConfigDoc.Fields[16].Note = ""
+	KubeletConfigDoc.Fields[16].Description = "The percent of the disk usage after which the image garbage collection is always run, defaults to 85."
+	KubeletConfigDoc.Fields[16].Comments[encoder.LineComment] = "The percent of the disk usage after which the image garbage collection is always run, defaults to 85."
+
+	KubeletConfigDoc.Fields[16].AddExample("", 75)
+	KubeletConfigDoc.Fields[17].Name = "imageGCLowThresholdPercent"
+	KubeletConfigDoc.Fields[17].Type = "int"
+	KubeletConfigDoc.Fields[17].Note = ""
+	KubeletConfigDoc.Fields[17].Description = "The percent of the disk usage before which the image garbage collection is never run, defaults to 80.\n\nShould be lower than `imageGCHighThresholdPercent`."
+	KubeletConfigDoc.Fields[17].Comments[encoder.LineComment] = "The percent of the disk usage before which the image garbage collection is never run, defaults to 80."
+
+	KubeletConfigDoc.Fields[17].AddExample("", 60)
+	KubeletConfigDoc.Fields[18].Name = "containerLogMaxSize"
+	KubeletConfigDoc.Fields[18].Type = "string"
+	KubeletConfigDoc.Fields[18].Note = ""
+	KubeletConfigDoc.Fields[18].Description = "The maximum size of the container log file before it is rotated, defaults to `10Mi`."
+	KubeletConfigDoc.Fields[18].Comments[encoder.LineComment] = "The maximum size of the container log file before it is rotated, defaults to `10Mi`."
+
+	KubeletConfigDoc.Fields[18].AddExample("", "5Mi")
+	KubeletConfigDoc.Fields[19].Name = "containerLogMaxFiles"
+	KubeletConfigDoc.Fields[19].Type = "int"
+	KubeletConfigDoc.Fields[19].Note = ""
+	KubeletConfigDoc.Fields[19].Description = "The maximum number of the container log files kept for a container, defaults to 5.\n\nShould be at least 2."
+	KubeletConfigDoc.Fields[19].Comments[encoder.LineComment] = "The maximum number of the container log files kept for a container, defaults to 5."
+
+	KubeletConfigDoc.Fields[19].AddExample("", 3)
+	KubeletConfigDoc.Fields[20].Name = "cpuManagerPolicy"
+	KubeletConfigDoc.Fields[20].Type = "string"
+	KubeletConfigDoc.Fields[20].Note = ""
+	KubeletConfigDoc.Fields[20].Description = "The kubelet CPU manager policy, defaults to `none`.\n\nWith the `static` policy, the containers of the Guaranteed pods with the integer CPU requests get the exclusive CPUs.\nKubelet CPU manager state is reset on the policy change."
+	KubeletConfigDoc.Fields[20].Comments[encoder.LineComment] = "The kubelet CPU manager policy, defaults to `none`."
+	KubeletConfigDoc.Fields[20].Values = []string{
+		"none",
+		"static",
+	}
+	KubeletConfigDoc.Fields[21].Name = "topologyManagerPolicy"
+	KubeletConfigDoc.Fields[21].Type = "string"
+	KubeletConfigDoc.Fields[21].Note = ""
+	KubeletConfigDoc.Fields[21].Description = "The kubelet topology manager policy, defaults to `none`.\n\nTopology manager aligns the CPU, memory and device allocations on the NUMA nodes."
+	KubeletConfigDoc.Fields[21].Comments[encoder.LineComment] = "The kubelet topology manager policy, defaults to `none`."
+	KubeletConfigDoc.Fields[21].Values = []string{
+		"none",
+		"best-effort",
+		"restricted",
+		"single-numa-node",
+	}
+	KubeletConfigDoc.Fields[22].Name = "memoryManagerPolicy"
+	KubeletConfigDoc.Fields[22].Type = "string"
+	KubeletConfigDoc.Fields[22].Note = ""
+	KubeletConfigDoc.Fields[22].Description = "The kubelet memory manager policy, defaults to `None`.\n\nWith the `Static` policy, the memory reserved for the system (`systemReserved`, `kubeReserved` and the eviction threshold)\nis configured automatically.\nKubelet memory manager state is reset on the policy change."
+	KubeletConfigDoc.Fields[22].Comments[encoder.LineComment] = "The kubelet memory manager policy, defaults to `None`."
+	KubeletConfigDoc.Fields[22].Values = []string{
+		"None",
+		"Static",
+	}
+	KubeletConfigDoc.Fields[23].Name = "reservedSystemCPUs"
+	KubeletConfigDoc.Fields[23].Type = "string"
+	KubeletConfigDoc.Fields[23].Note = ""
+	KubeletConfigDoc.Fields[23].Description = "The list of the CPUs reserved for the system and Kubernetes daemons, e.g. `0-1`.\n\nReserved CPUs are never allocated exclusively to the containers with the `static` CPU manager policy.\nDefaults to the housekeeping CPUs with the real-time profile enabled."
+	KubeletConfigDoc.Fields[23].Comments[encoder.LineComment] = "The list of the CPUs reserved for the system and Kubernetes daemons, e.g. `0-1`."
+
+	KubeletConfigDoc.Fields[23].AddExample("", "0,1")
+	KubeletConfigDoc.Fields[24].Name = "shutdownGracePeriod"
+	KubeletConfigDoc.Fields[24].Type = "Duration"
+	KubeletConfigDoc.Fields[24].Note = ""
+	KubeletConfigDoc.Fields[24].Description = "The time the node shutdown is delayed for to terminate the pods gracefully on reboot and shutdown.\n    Graceful node shutdown is disabled by default.\n\n    Field format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes).\n"
+	KubeletConfigDoc.Fields[24].Comments[encoder.LineComment] = "The time the node shutdown is delayed for to terminate the pods gracefully on reboot and shutdown."
+	KubeletConfigDoc.Fields[25].Name = "shutdownGracePeriodCriticalPods"
+	KubeletConfigDoc.Fields[25].Type = "Duration"
+	KubeletConfigDoc.Fields[25].Note = ""
+	KubeletConfigDoc.Fields[25].Description = "The part of the `shutdownGracePeriod` reserved for the termination of the critical pods\n(pods with `system-node-critical` or `system-cluster-critical` priority classes).\n\nRegular pods are terminated first within the rest of the `shutdownGracePeriod`."
+	KubeletConfigDoc.Fields[25].Comments[encoder.LineComment] = "The part of the `shutdownGracePeriod` reserved for the termination of the critical pods"
+	KubeletConfigDoc.Fields[26].Name = "standalone"
+	KubeletConfigDoc.Fields[26].Type = "bool"
+	KubeletConfigDoc.Fields[26].Note = ""
+	KubeletConfigDoc.Fields[26].Description = "The `standalone` field runs the kubelet without the Kubernetes API server.\n\nKubelet doesn't register the node and only runs the static pods, e.g. for the single-node appliances.\nStandalone mode is supported only on the worker nodes."
+	KubeletConfigDoc.Fields[26].Comments[encoder.LineComment] = "The `standalone` field runs the kubelet without the Kubernetes API server."
+	KubeletConfigDoc.Fields[26].Values = []string{
+		"true",
+		"yes",
+		"false",
+		"no",
+	}
+	KubeletConfigDoc.Fields[27].Name = "podCIDRs"
+	KubeletConfigDoc.Fields[27].Type = "[]string"
+	KubeletConfigDoc.Fields[27].Note = ""
+	KubeletConfigDoc.Fields[27].Description = "The pod CIDRs pre-assigned to the node, one per pod subnet in the order of `.cluster.network.podSubnets`.\n\nBy default kube-controller-manager allocates the node pod CIDRs of the `.cluster.network.podSubnetSize`,\npre-assigned pod CIDRs allow to size the pod CIDR per node (e.g. a bigger pod CIDR for the bigger nodes).\nTalos registers the node with the API server with `spec.podCIDRs` set, and kube-controller-manager\nmarks the pre-assigned pod CIDRs as used, so they are not allocated to the other nodes.\nPod CIDRs of the Kubernetes node can't be changed once the node is registered."
+	KubeletConfigDoc.Fields[27].Comments[encoder.LineComment] = "The pod CIDRs pre-assigned to the node, one per pod subnet in the order of `.cluster.network.podSubnets`."
+
+	KubeletConfigDoc.Fields[27].AddExample("", []string{"10.244.4.0/23"})
 
-	KubeletConfigDoc.Fields[5].AddExample("", kubeletNodeIPExample)
+	KubeletCredentialProvidersConfigDoc.Type = "KubeletCredentialProvidersConfig"
+	KubeletCredentialProvidersConfigDoc.Comments[encoder.LineComment] = "KubeletCredentialProvidersConfig represents the kubelet image credential provider plugins configuration."
+	KubeletCredentialProvidersConfigDoc.Description = "KubeletCredentialProvidersConfig represents the kubelet image credential provider plugins configuration."
+
+	KubeletCredentialProvidersConfigDoc.AddExample("", kubeletCredentialProvidersExample)
+	KubeletCredentialProvidersConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "KubeletConfig",
+			FieldName: "credentialProviders",
+		},
+	}
+	KubeletCredentialProvidersConfigDoc.Fields = make([]encoder.Doc, 2)
+	KubeletCredentialProvidersConfigDoc.Fields[0].Name = "binDir"
+	KubeletCredentialProvidersConfigDoc.Fields[0].Type = "string"
+	KubeletCredentialProvidersConfigDoc.Fields[0].Note = ""
+	KubeletCredentialProvidersConfigDoc.Fields[0].Description = "The host directory with the credential provider plugin binaries, defaults to `/usr/local/lib/kubelet/credentialproviders`\n(the binaries can be installed with the system extensions).\n\nThe directory is mounted into the kubelet container, plugin binary name should match the provider name."
+	KubeletCredentialProvidersConfigDoc.Fields[0].Comments[encoder.LineComment] = "The host directory with the credential provider plugin binaries, defaults to `/usr/local/lib/kubelet/credentialproviders`"
+	KubeletCredentialProvidersConfigDoc.Fields[1].Name = "providers"
+	KubeletCredentialProvidersConfigDoc.Fields[1].Type = "[]KubeletCredentialProviderConfig"
+	KubeletCredentialProvidersConfigDoc.Fields[1].Note = ""
+	KubeletCredentialProvidersConfigDoc.Fields[1].Description = "The list of the credential provider plugins."
+	KubeletCredentialProvidersConfigDoc.Fields[1].Comments[encoder.LineComment] = "The list of the credential provider plugins."
+
+	KubeletCredentialProviderConfigDoc.Type = "KubeletCredentialProviderConfig"
+	KubeletCredentialProviderConfigDoc.Comments[encoder.LineComment] = "KubeletCredentialProviderConfig represents the kubelet image credential provider plugin."
+	KubeletCredentialProviderConfigDoc.Description = "KubeletCredentialProviderConfig represents the kubelet image credential provider plugin."
+	KubeletCredentialProviderConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "KubeletCredentialProvidersConfig",
+			FieldName: "providers",
+		},
+	}
+	KubeletCredentialProviderConfigDoc.Fields = make([]encoder.Doc, 6)
+	KubeletCredentialProviderConfigDoc.Fields[0].Name = "name"
+	KubeletCredentialProviderConfigDoc.Fields[0].Type = "string"
+	KubeletCredentialProviderConfigDoc.Fields[0].Note = ""
+	KubeletCredentialProviderConfigDoc.Fields[0].Description = "The name of the plugin binary."
+	KubeletCredentialProviderConfigDoc.Fields[0].Comments[encoder.LineComment] = "The name of the plugin binary."
+	KubeletCredentialProviderConfigDoc.Fields[1].Name = "matchImages"
+	KubeletCredentialProviderConfigDoc.Fields[1].Type = "[]string"
+	KubeletCredentialProviderConfigDoc.Fields[1].Note = ""
+	KubeletCredentialProviderConfigDoc.Fields[1].Description = "The list of the image patterns the plugin is invoked for, e.g. `*.dkr.ecr.*.amazonaws.com`."
+	KubeletCredentialProviderConfigDoc.Fields[1].Comments[encoder.LineComment] = "The list of the image patterns the plugin is invoked for, e.g. `*.dkr.ecr.*.amazonaws.com`."
+	KubeletCredentialProviderConfigDoc.Fields[2].Name = "defaultCacheDuration"
+	KubeletCredentialProviderConfigDoc.Fields[2].Type = "Duration"
+	KubeletCredentialProviderConfigDoc.Fields[2].Note = ""
+	KubeletCredentialProviderConfigDoc.Fields[2].Description = "The cache duration of the credentials if the plugin doesn't specify one, credentials are not cached by default."
+	KubeletCredentialProviderConfigDoc.Fields[2].Comments[encoder.LineComment] = "The cache duration of the credentials if the plugin doesn't specify one, credentials are not cached by default."
+	KubeletCredentialProviderConfigDoc.Fields[3].Name = "apiVersion"
+	KubeletCredentialProviderConfigDoc.Fields[3].Type = "string"
+	KubeletCredentialProviderConfigDoc.Fields[3].Note = ""
+	KubeletCredentialProviderConfigDoc.Fields[3].Description = "The API version of the plugin request and response, defaults to `credentialprovider.kubelet.k8s.io/v1alpha1`."
+	KubeletCredentialProviderConfigDoc.Fields[3].Comments[encoder.LineComment] = "The API version of the plugin request and response, defaults to `credentialprovider.kubelet.k8s.io/v1alpha1`."
+	KubeletCredentialProviderConfigDoc.Fields[4].Name = "args"
+	KubeletCredentialProviderConfigDoc.Fields[4].Type = "[]string"
+	KubeletCredentialProviderConfigDoc.Fields[4].Note = ""
+	KubeletCredentialProviderConfigDoc.Fields[4].Description = "The arguments passed to the plugin."
+	KubeletCredentialProviderConfigDoc.Fields[4].Comments[encoder.LineComment] = "The arguments passed to the plugin."
+	KubeletCredentialProviderConfigDoc.Fields[5].Name = "env"
+	KubeletCredentialProviderConfigDoc.Fields[5].Type = "map[string]string"
+	KubeletCredentialProviderConfigDoc.Fields[5].Note = ""
+	KubeletCredentialProviderConfigDoc.Fields[5].Description = "The environment variables passed to the plugin."
+	KubeletCredentialProviderConfigDoc.Fields[5].Comments[encoder.LineComment] = "The environment variables passed to the plugin."
 
 	KubeletNodeIPConfigDoc.Type = "KubeletNodeIPConfig"
 	KubeletNodeIPConfigDoc.Comments[encoder.LineComment] = "KubeletNodeIPConfig represents the kubelet node IP configuration."
@@ -594,12 +995,21 @@ func init() {
 			FieldName: "nodeIP",
 		},
 	}
-	KubeletNodeIPConfigDoc.Fields = make([]encoder.Doc, 1)
+	KubeletNodeIPConfigDoc.Fields = make([]encoder.Doc, 2)
 	KubeletNodeIPConfigDoc.Fields[0].Name = "validSubnets"
 	KubeletNodeIPConfigDoc.Fields[0].Type = "[]string"
 	KubeletNodeIPConfigDoc.Fields[0].Note = ""
 	KubeletNodeIPConfigDoc.Fields[0].Description = "The `validSubnets` field configures the networks to pick kubelet node IP from.\nFor dual stack configuration, there should be two subnets: one for IPv4, another for IPv6.\nIPs can be excluded from the list by using negative match with `!`, e.g `!10.0.0.0/8`.\nNegative subnet matches should be specified last to filter out IPs picked by positive matches.\nIf not specified, node IP is picked based on cluster podCIDRs: IPv4/IPv6 address or both."
 	KubeletNodeIPConfigDoc.Fields[0].Comments[encoder.LineComment] = "The `validSubnets` field configures the networks to pick kubelet node IP from."
+	KubeletNodeIPConfigDoc.Fields[1].Name = "preferredFamily"
+	KubeletNodeIPConfigDoc.Fields[1].Type = "string"
+	KubeletNodeIPConfigDoc.Fields[1].Note = ""
+	KubeletNodeIPConfigDoc.Fields[1].Description = "The `preferredFamily` field configures the address family of the primary kubelet node IP in the dual-stack clusters.\nIf not specified, the primary node IP family matches the primary service CIDR family."
+	KubeletNodeIPConfigDoc.Fields[1].Comments[encoder.LineComment] = "The `preferredFamily` field configures the address family of the primary kubelet node IP in the dual-stack clusters."
+	KubeletNodeIPConfigDoc.Fields[1].Values = []string{
+		"ipv4",
+		"ipv6",
+	}
 
 	NetworkConfigDoc.Type = "NetworkConfig"
 	NetworkConfigDoc.Comments[encoder.LineComment] = "NetworkConfig represents the machine's networking config values."
@@ -612,7 +1022,7 @@ func init() {
 			FieldName: "network",
 		},
 	}
-	NetworkConfigDoc.Fields = make([]encoder.Doc, 5)
+	NetworkConfigDoc.Fields = make([]encoder.Doc, 8)
 	NetworkConfigDoc.Fields[0].Name = "hostname"
 	NetworkConfigDoc.Fields[0].Type = "string"
 	NetworkConfigDoc.Fields[0].Note = ""
@@ -646,6 +1056,27 @@ func init() {
 	NetworkConfigDoc.Fields[4].Comments[encoder.LineComment] = "Configures KubeSpan feature."
 
 	NetworkConfigDoc.Fields[4].AddExample("", networkKubeSpanExample)
+	NetworkConfigDoc.Fields[5].Name = "advertisedSubnets"
+	NetworkConfigDoc.Fields[5].Type = "[]string"
+	NetworkConfigDoc.Fields[5].Note = ""
+	NetworkConfigDoc.Fields[5].Description = "List of subnets to pick node addresses from when publishing them.\n\nFiltered node addresses are published to Kubernetes (kubelet node IP), used for cluster discovery,\nand used as etcd advertised addresses.\nIf empty, all node addresses are published (except for the ignored subnets).\n\nIf either advertised or ignored subnets are set, Talos API accepts connections only on the published node addresses."
+	NetworkConfigDoc.Fields[5].Comments[encoder.LineComment] = "List of subnets to pick node addresses from when publishing them."
+
+	NetworkConfigDoc.Fields[5].AddExample("", []string{"10.0.0.0/8", "fd00::/8"})
+	NetworkConfigDoc.Fields[6].Name = "ignoredSubnets"
+	NetworkConfigDoc.Fields[6].Type = "[]string"
+	NetworkConfigDoc.Fields[6].Note = ""
+	NetworkConfigDoc.Fields[6].Description = "List of subnets node addresses from which should never be published."
+	NetworkConfigDoc.Fields[6].Comments[encoder.LineComment] = "List of subnets node addresses from which should never be published."
+
+	NetworkConfigDoc.Fields[6].AddExample("", []string{"192.168.0.0/16"})
+	NetworkConfigDoc.Fields[7].Name = "conntrack"
+	NetworkConfigDoc.Fields[7].Type = "ConntrackConfig"
+	NetworkConfigDoc.Fields[7].Note = ""
+	NetworkConfigDoc.Fields[7].Description = "Configures the connection tracking table size.\n\nConnection tracking table utilization and drops are available as the `ConntrackStats` resource (`talosctl get conntrackstats`)."
+	NetworkConfigDoc.Fields[7].Comments[encoder.LineComment] = "Configures the connection tracking table size."
+
+	NetworkConfigDoc.Fields[7].AddExample("", networkConntrackExample)
 
 	InstallConfigDoc.Type = "InstallConfig"
 	InstallConfigDoc.Comments[encoder.LineComment] = "InstallConfig represents the installation options for preparing a node."
@@ -658,7 +1089,7 @@ func init() {
 			FieldName: "install",
 		},
 	}
-	InstallConfigDoc.Fields = make([]encoder.Doc, 7)
+	InstallConfigDoc.Fields = make([]encoder.Doc, 8)
 	InstallConfigDoc.Fields[0].Name = "disk"
 	InstallConfigDoc.Fields[0].Type = "string"
 	InstallConfigDoc.Fields[0].Note = ""
@@ -716,6 +1147,13 @@ func init() {
 	InstallConfigDoc.Fields[6].Note = ""
 	InstallConfigDoc.Fields[6].Description = "Indicates if MBR partition should be marked as bootable (active).\nShould be enabled only for the systems with legacy BIOS that doesn't support GPT partitioning scheme."
 	InstallConfigDoc.Fields[6].Comments[encoder.LineComment] = "Indicates if MBR partition should be marked as bootable (active)."
+	InstallConfigDoc.Fields[7].Name = "assetsURL"
+	InstallConfigDoc.Fields[7].Type = "string"
+	InstallConfigDoc.Fields[7].Note = ""
+	InstallConfigDoc.Fields[7].Description = "Base URL to download the boot assets from on upgrades as `<assetsURL>/<arch>/<asset>`.\nBinary deltas against the installed assets (`<asset>-<sha256 of the installed asset>.delta`)\nare tried first, falling back to the assets in the installer image and then to the full assets."
+	InstallConfigDoc.Fields[7].Comments[encoder.LineComment] = "Base URL to download the boot assets from on upgrades as `<assetsURL>/<arch>/<asset>`."
+
+	InstallConfigDoc.Fields[7].AddExample("", "https://assets.example.com/talos/v0.14.0")
 
 	InstallDiskSelectorDoc.Type = "InstallDiskSelector"
 	InstallDiskSelectorDoc.Comments[encoder.LineComment] = "InstallDiskSelector represents a disk query parameters for the install disk lookup."
@@ -821,7 +1259,7 @@ func init() {
 			FieldName: "registries",
 		},
 	}
-	RegistriesConfigDoc.Fields = make([]encoder.Doc, 2)
+	RegistriesConfigDoc.Fields = make([]encoder.Doc, 3)
 	RegistriesConfigDoc.Fields[0].Name = "mirrors"
 	RegistriesConfigDoc.Fields[0].Type = "map[string]RegistryMirrorConfig"
 	RegistriesConfigDoc.Fields[0].Note = ""
@@ -836,6 +1274,66 @@ func init() {
 	RegistriesConfigDoc.Fields[1].Comments[encoder.LineComment] = "Specifies TLS & auth configuration for HTTPS image registries."
 
 	RegistriesConfigDoc.Fields[1].AddExample("", machineConfigRegistryConfigExample)
+	RegistriesConfigDoc.Fields[2].Name = "pulls"
+	RegistriesConfigDoc.Fields[2].Type = "RegistryPullsConfig"
+	RegistriesConfigDoc.Fields[2].Note = ""
+	RegistriesConfigDoc.Fields[2].Description = "Limits the image pulls to protect the latency-sensitive workloads on thin uplinks."
+	RegistriesConfigDoc.Fields[2].Comments[encoder.LineComment] = "Limits the image pulls to protect the latency-sensitive workloads on thin uplinks."
+
+	RegistriesConfigDoc.Fields[2].AddExample("", machineConfigRegistryPullsExample)
+
+	RegistryPullsConfigDoc.Type = "RegistryPullsConfig"
+	RegistryPullsConfigDoc.Comments[encoder.LineComment] = "RegistryPullsConfig limits the image pulls."
+	RegistryPullsConfigDoc.Description = "RegistryPullsConfig limits the image pulls."
+
+	RegistryPullsConfigDoc.AddExample("", machineConfigRegistryPullsExample)
+	RegistryPullsConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "RegistriesConfig",
+			FieldName: "pulls",
+		},
+	}
+	RegistryPullsConfigDoc.Fields = make([]encoder.Doc, 3)
+	RegistryPullsConfigDoc.Fields[0].Name = "maxConcurrentDownloads"
+	RegistryPullsConfigDoc.Fields[0].Type = "int"
+	RegistryPullsConfigDoc.Fields[0].Note = ""
+	RegistryPullsConfigDoc.Fields[0].Description = "Maximum number of layers downloaded concurrently for each image pull.\n\nApplies both to the CRI (Kubernetes) image pulls and to the image pulls done by Talos."
+	RegistryPullsConfigDoc.Fields[0].Comments[encoder.LineComment] = "Maximum number of layers downloaded concurrently for each image pull."
+	RegistryPullsConfigDoc.Fields[1].Name = "bandwidthLimit"
+	RegistryPullsConfigDoc.Fields[1].Type = "string"
+	RegistryPullsConfigDoc.Fields[1].Note = ""
+	RegistryPullsConfigDoc.Fields[1].Description = "Maximum download bandwidth per second shared by the image pulls done by Talos.\n\nTalos pulls the images of the system services, extension services and upgrade installers.\n\nCRI (Kubernetes) image pulls are not affected, as containerd doesn't support limiting them."
+	RegistryPullsConfigDoc.Fields[1].Comments[encoder.LineComment] = "Maximum download bandwidth per second shared by the image pulls done by Talos."
+
+	RegistryPullsConfigDoc.Fields[1].AddExample("", "10MiB")
+	RegistryPullsConfigDoc.Fields[2].Name = "maintenanceWindows"
+	RegistryPullsConfigDoc.Fields[2].Type = "[]MaintenanceWindowConfig"
+	RegistryPullsConfigDoc.Fields[2].Note = ""
+	RegistryPullsConfigDoc.Fields[2].Description = "Daily maintenance windows for the upgrade installer image pulls.\n\nIf set, upgrades which require pulling the installer image are rejected outside of the windows."
+	RegistryPullsConfigDoc.Fields[2].Comments[encoder.LineComment] = "Daily maintenance windows for the upgrade installer image pulls."
+
+	MaintenanceWindowConfigDoc.Type = "MaintenanceWindowConfig"
+	MaintenanceWindowConfigDoc.Comments[encoder.LineComment] = "MaintenanceWindowConfig describes a daily maintenance window."
+	MaintenanceWindowConfigDoc.Description = "MaintenanceWindowConfig describes a daily maintenance window."
+	MaintenanceWindowConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "RegistryPullsConfig",
+			FieldName: "maintenanceWindows",
+		},
+	}
+	MaintenanceWindowConfigDoc.Fields = make([]encoder.Doc, 2)
+	MaintenanceWindowConfigDoc.Fields[0].Name = "start"
+	MaintenanceWindowConfigDoc.Fields[0].Type = "string"
+	MaintenanceWindowConfigDoc.Fields[0].Note = ""
+	MaintenanceWindowConfigDoc.Fields[0].Description = "Window start time (UTC) in HH:MM format."
+	MaintenanceWindowConfigDoc.Fields[0].Comments[encoder.LineComment] = "Window start time (UTC) in HH:MM format."
+
+	MaintenanceWindowConfigDoc.Fields[0].AddExample("", "02:00")
+	MaintenanceWindowConfigDoc.Fields[1].Name = "duration"
+	MaintenanceWindowConfigDoc.Fields[1].Type = "Duration"
+	MaintenanceWindowConfigDoc.Fields[1].Note = ""
+	MaintenanceWindowConfigDoc.Fields[1].Description = "Window duration, up to 24 hours.\n\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	MaintenanceWindowConfigDoc.Fields[1].Comments[encoder.LineComment] = "Window duration, up to 24 hours."
 
 	PodCheckpointerDoc.Type = "PodCheckpointer"
 	PodCheckpointerDoc.Comments[encoder.LineComment] = "PodCheckpointer represents the pod-checkpointer config values."
@@ -878,14 +1376,26 @@ func init() {
 
 	EndpointDoc.AddExample("", clusterEndpointExample2)
 
+	EndpointDoc.AddExample("", clusterInternalEndpointExample)
+
 	EndpointDoc.AddExample("", loggingEndpointExample1)
 
 	EndpointDoc.AddExample("", loggingEndpointExample2)
+
+	EndpointDoc.AddExample("", loggingEndpointExample3)
 	EndpointDoc.AppearsIn = []encoder.Appearance{
 		{
 			TypeName:  "ControlPlaneConfig",
 			FieldName: "endpoint",
 		},
+		{
+			TypeName:  "ControlPlaneConfig",
+			FieldName: "internalEndpoint",
+		},
+		{
+			TypeName:  "LifecycleWebhookConfig",
+			FieldName: "endpoint",
+		},
 		{
 			TypeName:  "LoggingDestination",
 			FieldName: "endpoint",
@@ -904,7 +1414,7 @@ func init() {
 			FieldName: "controlPlane",
 		},
 	}
-	ControlPlaneConfigDoc.Fields = make([]encoder.Doc, 2)
+	ControlPlaneConfigDoc.Fields = make([]encoder.Doc, 3)
 	ControlPlaneConfigDoc.Fields[0].Name = "endpoint"
 	ControlPlaneConfigDoc.Fields[0].Type = "Endpoint"
 	ControlPlaneConfigDoc.Fields[0].Note = ""
@@ -914,11 +1424,18 @@ func init() {
 	ControlPlaneConfigDoc.Fields[0].AddExample("", clusterEndpointExample1)
 
 	ControlPlaneConfigDoc.Fields[0].AddExample("", clusterEndpointExample2)
-	ControlPlaneConfigDoc.Fields[1].Name = "localAPIServerPort"
-	ControlPlaneConfigDoc.Fields[1].Type = "int"
+	ControlPlaneConfigDoc.Fields[1].Name = "internalEndpoint"
+	ControlPlaneConfigDoc.Fields[1].Type = "Endpoint"
 	ControlPlaneConfigDoc.Fields[1].Note = ""
-	ControlPlaneConfigDoc.Fields[1].Description = "The port that the API server listens on internally.\nThis may be different than the port portion listed in the endpoint field above.\nThe default is `6443`."
-	ControlPlaneConfigDoc.Fields[1].Comments[encoder.LineComment] = "The port that the API server listens on internally."
+	ControlPlaneConfigDoc.Fields[1].Description = "Internal controlplane endpoint, which can be an IP address or a DNS hostname.\n\nInternal endpoint is used by the kubelet and control plane components running on the nodes\n(e.g. a virtual IP on the private network), while `endpoint` is used in the generated `kubeconfig`\nand `talosconfig` for the external access.\nDefaults to `endpoint`."
+	ControlPlaneConfigDoc.Fields[1].Comments[encoder.LineComment] = "Internal controlplane endpoint, which can be an IP address or a DNS hostname."
+
+	ControlPlaneConfigDoc.Fields[1].AddExample("", clusterInternalEndpointExample)
+	ControlPlaneConfigDoc.Fields[2].Name = "localAPIServerPort"
+	ControlPlaneConfigDoc.Fields[2].Type = "int"
+	ControlPlaneConfigDoc.Fields[2].Note = ""
+	ControlPlaneConfigDoc.Fields[2].Description = "The port that the API server listens on internally.\nThis may be different than the port portion listed in the endpoint field above.\nThe default is `6443`."
+	ControlPlaneConfigDoc.Fields[2].Comments[encoder.LineComment] = "The port that the API server listens on internally."
 
 	APIServerConfigDoc.Type = "APIServerConfig"
 	APIServerConfigDoc.Comments[encoder.LineComment] = "APIServerConfig represents the kube apiserver configuration options."
@@ -1068,7 +1585,7 @@ func init() {
 			FieldName: "etcd",
 		},
 	}
-	EtcdConfigDoc.Fields = make([]encoder.Doc, 4)
+	EtcdConfigDoc.Fields = make([]encoder.Doc, 6)
 	EtcdConfigDoc.Fields[0].Name = "image"
 	EtcdConfigDoc.Fields[0].Type = "string"
 	EtcdConfigDoc.Fields[0].Note = ""
@@ -1092,10 +1609,24 @@ func init() {
 	EtcdConfigDoc.Fields[3].Name = "subnet"
 	EtcdConfigDoc.Fields[3].Type = "string"
 	EtcdConfigDoc.Fields[3].Note = ""
-	EtcdConfigDoc.Fields[3].Description = "The subnet from which the advertise URL should be."
+	EtcdConfigDoc.Fields[3].Description = "The subnet from which the advertise URL should be.\n\nDeprecated: use `advertisedSubnets` instead."
 	EtcdConfigDoc.Fields[3].Comments[encoder.LineComment] = "The subnet from which the advertise URL should be."
 
 	EtcdConfigDoc.Fields[3].AddExample("", clusterEtcdSubnetExample)
+	EtcdConfigDoc.Fields[4].Name = "advertisedSubnets"
+	EtcdConfigDoc.Fields[4].Type = "[]string"
+	EtcdConfigDoc.Fields[4].Note = ""
+	EtcdConfigDoc.Fields[4].Description = "The `advertisedSubnets` field configures the networks to pick etcd advertised IP from.\n\nIPs are picked from the node addresses which are allowed to be published (see `.machine.network.advertisedSubnets`).\nIf not specified, the first node address is used."
+	EtcdConfigDoc.Fields[4].Comments[encoder.LineComment] = "The `advertisedSubnets` field configures the networks to pick etcd advertised IP from."
+
+	EtcdConfigDoc.Fields[4].AddExample("", clusterEtcdAdvertisedSubnetsExample)
+	EtcdConfigDoc.Fields[5].Name = "listenSubnets"
+	EtcdConfigDoc.Fields[5].Type = "[]string"
+	EtcdConfigDoc.Fields[5].Note = ""
+	EtcdConfigDoc.Fields[5].Description = "The `listenSubnets` field configures the networks for the etcd to listen for peer and client connections.\n\nIf not specified, etcd listens on all addresses.\nClient connections are always accepted on the loopback addresses."
+	EtcdConfigDoc.Fields[5].Comments[encoder.LineComment] = "The `listenSubnets` field configures the networks for the etcd to listen for peer and client connections."
+
+	EtcdConfigDoc.Fields[5].AddExample("", clusterEtcdListenSubnetsExample)
 
 	ClusterNetworkConfigDoc.Type = "ClusterNetworkConfig"
 	ClusterNetworkConfigDoc.Comments[encoder.LineComment] = "ClusterNetworkConfig represents kube networking configuration options."
@@ -1108,7 +1639,7 @@ func init() {
 			FieldName: "network",
 		},
 	}
-	ClusterNetworkConfigDoc.Fields = make([]encoder.Doc, 4)
+	ClusterNetworkConfigDoc.Fields = make([]encoder.Doc, 5)
 	ClusterNetworkConfigDoc.Fields[0].Name = "cni"
 	ClusterNetworkConfigDoc.Fields[0].Type = "CNIConfig"
 	ClusterNetworkConfigDoc.Fields[0].Note = ""
@@ -1137,6 +1668,40 @@ func init() {
 	ClusterNetworkConfigDoc.Fields[3].Comments[encoder.LineComment] = "The service subnet CIDR."
 
 	ClusterNetworkConfigDoc.Fields[3].AddExample("", []string{"10.96.0.0/12"})
+	ClusterNetworkConfigDoc.Fields[4].Name = "podSubnetSize"
+	ClusterNetworkConfigDoc.Fields[4].Type = "PodSubnetSizeConfig"
+	ClusterNetworkConfigDoc.Fields[4].Note = ""
+	ClusterNetworkConfigDoc.Fields[4].Description = "The size of the pod subnet allocated to each node out of the pod subnets.\nThe value is the prefix length of per-node pod CIDR, set separately for IPv4 and IPv6,\nwhich allows to tune the number of pods per node for dual-stack and IPv6 clusters.\nIf not set, Kubernetes defaults are used (`24` for IPv4 and `64` for IPv6).\n\nThe size applies to all nodes of the cluster which don't have the pod CIDRs pre-assigned with `.machine.kubelet.podCIDRs`."
+	ClusterNetworkConfigDoc.Fields[4].Comments[encoder.LineComment] = "The size of the pod subnet allocated to each node out of the pod subnets."
+
+	ClusterNetworkConfigDoc.Fields[4].AddExample("", clusterPodSubnetSizeExample)
+
+	PodSubnetSizeConfigDoc.Type = "PodSubnetSizeConfig"
+	PodSubnetSizeConfigDoc.Comments[encoder.LineComment] = "PodSubnetSizeConfig represents the per-node pod subnet size configuration."
+	PodSubnetSizeConfigDoc.Description = "PodSubnetSizeConfig represents the per-node pod subnet size configuration."
+
+	PodSubnetSizeConfigDoc.AddExample("", clusterPodSubnetSizeExample)
+	PodSubnetSizeConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ClusterNetworkConfig",
+			FieldName: "podSubnetSize",
+		},
+	}
+	PodSubnetSizeConfigDoc.Fields = make([]encoder.Doc, 2)
+	PodSubnetSizeConfigDoc.Fields[0].Name = "ipv4"
+	PodSubnetSizeConfigDoc.Fields[0].Type = "int"
+	PodSubnetSizeConfigDoc.Fields[0].Note = ""
+	PodSubnetSizeConfigDoc.Fields[0].Description = "Prefix length of the IPv4 pod subnet allocated to each node."
+	PodSubnetSizeConfigDoc.Fields[0].Comments[encoder.LineComment] = "Prefix length of the IPv4 pod subnet allocated to each node."
+
+	PodSubnetSizeConfigDoc.Fields[0].AddExample("", 24)
+	PodSubnetSizeConfigDoc.Fields[1].Name = "ipv6"
+	PodSubnetSizeConfigDoc.Fields[1].Type = "int"
+	PodSubnetSizeConfigDoc.Fields[1].Note = ""
+	PodSubnetSizeConfigDoc.Fields[1].Description = "Prefix length of the IPv6 pod subnet allocated to each node."
+	PodSubnetSizeConfigDoc.Fields[1].Comments[encoder.LineComment] = "Prefix length of the IPv6 pod subnet allocated to each node."
+
+	PodSubnetSizeConfigDoc.Fields[1].AddExample("", 80)
 
 	CNIConfigDoc.Type = "CNIConfig"
 	CNIConfigDoc.Comments[encoder.LineComment] = "CNIConfig represents the CNI configuration options."
@@ -1200,6 +1765,34 @@ func init() {
 		"https://raw.githubusercontent.com/kubernetes/cloud-provider-aws/v1.20.0-alpha.0/manifests/aws-cloud-controller-manager-daemonset.yaml",
 	})
 
+	InstanceIdentityConfigDoc.Type = "InstanceIdentityConfig"
+	InstanceIdentityConfigDoc.Comments[encoder.LineComment] = "InstanceIdentityConfig configures the platform instance identity verification."
+	InstanceIdentityConfigDoc.Description = "InstanceIdentityConfig configures the platform instance identity verification."
+
+	InstanceIdentityConfigDoc.AddExample("", clusterInstanceIdentityExample)
+	InstanceIdentityConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ClusterConfig",
+			FieldName: "instanceIdentity",
+		},
+	}
+	InstanceIdentityConfigDoc.Fields = make([]encoder.Doc, 3)
+	InstanceIdentityConfigDoc.Fields[0].Name = "required"
+	InstanceIdentityConfigDoc.Fields[0].Type = "bool"
+	InstanceIdentityConfigDoc.Fields[0].Note = ""
+	InstanceIdentityConfigDoc.Fields[0].Description = "Require the nodes to present a verified instance identity document to get the certificates from trustd."
+	InstanceIdentityConfigDoc.Fields[0].Comments[encoder.LineComment] = "Require the nodes to present a verified instance identity document to get the certificates from trustd."
+	InstanceIdentityConfigDoc.Fields[1].Name = "allowedAccounts"
+	InstanceIdentityConfigDoc.Fields[1].Type = "[]string"
+	InstanceIdentityConfigDoc.Fields[1].Note = ""
+	InstanceIdentityConfigDoc.Fields[1].Description = "The list of the AWS account IDs and GCP project IDs of the instances allowed to get the certificates."
+	InstanceIdentityConfigDoc.Fields[1].Comments[encoder.LineComment] = "The list of the AWS account IDs and GCP project IDs of the instances allowed to get the certificates."
+	InstanceIdentityConfigDoc.Fields[2].Name = "awsCertificates"
+	InstanceIdentityConfigDoc.Fields[2].Type = "[]string"
+	InstanceIdentityConfigDoc.Fields[2].Note = ""
+	InstanceIdentityConfigDoc.Fields[2].Description = "PEM-encoded AWS RSA-2048 certificates of the regions the instances run in, used to verify the AWS instance identity documents.\n\nSee https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/verify-rsa2048.html."
+	InstanceIdentityConfigDoc.Fields[2].Comments[encoder.LineComment] = "PEM-encoded AWS RSA-2048 certificates of the regions the instances run in, used to verify the AWS instance identity documents."
+
 	AdminKubeconfigConfigDoc.Type = "AdminKubeconfigConfig"
 	AdminKubeconfigConfigDoc.Comments[encoder.LineComment] = "AdminKubeconfigConfig contains admin kubeconfig settings."
 	AdminKubeconfigConfigDoc.Description = "AdminKubeconfigConfig contains admin kubeconfig settings."
@@ -1250,7 +1843,7 @@ func init() {
 			FieldName: "partitions",
 		},
 	}
-	DiskPartitionDoc.Fields = make([]encoder.Doc, 2)
+	DiskPartitionDoc.Fields = make([]encoder.Doc, 4)
 	DiskPartitionDoc.Fields[0].Name = "size"
 	DiskPartitionDoc.Fields[0].Type = "DiskSize"
 	DiskPartitionDoc.Fields[0].Note = ""
@@ -1265,6 +1858,43 @@ func init() {
 	DiskPartitionDoc.Fields[1].Note = ""
 	DiskPartitionDoc.Fields[1].Description = "Where to mount the partition."
 	DiskPartitionDoc.Fields[1].Comments[encoder.LineComment] = "Where to mount the partition."
+	DiskPartitionDoc.Fields[2].Name = "mountOptions"
+	DiskPartitionDoc.Fields[2].Type = "[]string"
+	DiskPartitionDoc.Fields[2].Note = ""
+	DiskPartitionDoc.Fields[2].Description = "Mount options of the partition (`noatime` is used by default).\n\nPartitions are formatted as XFS, mount flags (`noatime`, `relatime`, `nodev`, `nosuid`, `noexec`, etc.)\nand XFS options (`discard`, `largeio`, `allocsize=64m`, etc.) are supported."
+	DiskPartitionDoc.Fields[2].Comments[encoder.LineComment] = "Mount options of the partition (`noatime` is used by default)."
+
+	DiskPartitionDoc.Fields[2].AddExample("", []string{"nodev", "discard"})
+	DiskPartitionDoc.Fields[3].Name = "projectQuota"
+	DiskPartitionDoc.Fields[3].Type = "bool"
+	DiskPartitionDoc.Fields[3].Note = ""
+	DiskPartitionDoc.Fields[3].Description = "Enables XFS project quotas on the partition."
+	DiskPartitionDoc.Fields[3].Comments[encoder.LineComment] = "Enables XFS project quotas on the partition."
+
+	EphemeralConfigDoc.Type = "EphemeralConfig"
+	EphemeralConfigDoc.Comments[encoder.LineComment] = "EphemeralConfig represents the `EPHEMERAL` partition mount options."
+	EphemeralConfigDoc.Description = "EphemeralConfig represents the `EPHEMERAL` partition mount options."
+
+	EphemeralConfigDoc.AddExample("", machineEphemeralExample)
+	EphemeralConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "ephemeral",
+		},
+	}
+	EphemeralConfigDoc.Fields = make([]encoder.Doc, 2)
+	EphemeralConfigDoc.Fields[0].Name = "mountOptions"
+	EphemeralConfigDoc.Fields[0].Type = "[]string"
+	EphemeralConfigDoc.Fields[0].Note = ""
+	EphemeralConfigDoc.Fields[0].Description = "Mount options of the `EPHEMERAL` partition (`noatime` is used by default).\n\nPartition is formatted as XFS, mount flags (`noatime`, `relatime`, `nodev`, `nosuid`, etc.)\nand XFS options (`discard`, `largeio`, `allocsize=64m`, etc.) are supported.\n`noexec` can't be used, as the container images are stored on the partition."
+	EphemeralConfigDoc.Fields[0].Comments[encoder.LineComment] = "Mount options of the `EPHEMERAL` partition (`noatime` is used by default)."
+
+	EphemeralConfigDoc.Fields[0].AddExample("", []string{"nodev", "discard"})
+	EphemeralConfigDoc.Fields[1].Name = "projectQuota"
+	EphemeralConfigDoc.Fields[1].Type = "bool"
+	EphemeralConfigDoc.Fields[1].Note = ""
+	EphemeralConfigDoc.Fields[1].Description = "Enables XFS project quotas on the `EPHEMERAL` partition.\n\nKubelet uses the project quotas to account the ephemeral storage usage of the pods (`LocalStorageCapacityIsolationFSQuotaMonitoring`),\nwhich is faster and more accurate than scanning the pod volumes."
+	EphemeralConfigDoc.Fields[1].Comments[encoder.LineComment] = "Enables XFS project quotas on the `EPHEMERAL` partition."
 
 	EncryptionConfigDoc.Type = "EncryptionConfig"
 	EncryptionConfigDoc.Comments[encoder.LineComment] = "EncryptionConfig represents partition encryption settings."
@@ -1454,7 +2084,7 @@ func init() {
 			FieldName: "interfaces",
 		},
 	}
-	DeviceDoc.Fields = make([]encoder.Doc, 13)
+	DeviceDoc.Fields = make([]encoder.Doc, 15)
 	DeviceDoc.Fields[0].Name = "interface"
 	DeviceDoc.Fields[0].Type = "string"
 	DeviceDoc.Fields[0].Note = ""
@@ -1533,6 +2163,117 @@ func init() {
 	DeviceDoc.Fields[12].Comments[encoder.LineComment] = "Virtual (shared) IP address configuration."
 
 	DeviceDoc.Fields[12].AddExample("", networkConfigVIPLayer2Example)
+	DeviceDoc.Fields[13].Name = "tuning"
+	DeviceDoc.Fields[13].Type = "DeviceTuningConfig"
+	DeviceDoc.Fields[13].Note = ""
+	DeviceDoc.Fields[13].Description = "NIC queue, IRQ affinity and packet steering (RPS/XPS) tuning.\nSettings are reconciled at boot and whenever the link changes."
+	DeviceDoc.Fields[13].Comments[encoder.LineComment] = "NIC queue, IRQ affinity and packet steering (RPS/XPS) tuning."
+
+	DeviceDoc.Fields[13].AddExample("", networkConfigTuningExample)
+	DeviceDoc.Fields[14].Name = "trafficShaping"
+	DeviceDoc.Fields[14].Type = "TrafficShapingConfig"
+	DeviceDoc.Fields[14].Note = ""
+	DeviceDoc.Fields[14].Description = "Traffic shaping (tc HTB) of the outgoing traffic of the interface.\nTraffic classes share the interface bandwidth, traffic which doesn't match any class is sent\nwith the lowest priority using the bandwidth left."
+	DeviceDoc.Fields[14].Comments[encoder.LineComment] = "Traffic shaping (tc HTB) of the outgoing traffic of the interface."
+
+	DeviceDoc.Fields[14].AddExample("", networkConfigTrafficShapingExample)
+
+	TrafficShapingConfigDoc.Type = "TrafficShapingConfig"
+	TrafficShapingConfigDoc.Comments[encoder.LineComment] = "TrafficShapingConfig contains interface traffic shaping settings."
+	TrafficShapingConfigDoc.Description = "TrafficShapingConfig contains interface traffic shaping settings."
+
+	TrafficShapingConfigDoc.AddExample("", networkConfigTrafficShapingExample)
+	TrafficShapingConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "Device",
+			FieldName: "trafficShaping",
+		},
+	}
+	TrafficShapingConfigDoc.Fields = make([]encoder.Doc, 2)
+	TrafficShapingConfigDoc.Fields[0].Name = "bandwidth"
+	TrafficShapingConfigDoc.Fields[0].Type = "string"
+	TrafficShapingConfigDoc.Fields[0].Note = ""
+	TrafficShapingConfigDoc.Fields[0].Description = "Bandwidth of the interface shared by the traffic classes (e.g. `1Gbit`, `500Mbit`)."
+	TrafficShapingConfigDoc.Fields[0].Comments[encoder.LineComment] = "Bandwidth of the interface shared by the traffic classes (e.g. `1Gbit`, `500Mbit`)."
+	TrafficShapingConfigDoc.Fields[1].Name = "classes"
+	TrafficShapingConfigDoc.Fields[1].Type = "[]TrafficClassConfig"
+	TrafficShapingConfigDoc.Fields[1].Note = ""
+	TrafficShapingConfigDoc.Fields[1].Description = "List of the traffic classes."
+	TrafficShapingConfigDoc.Fields[1].Comments[encoder.LineComment] = "List of the traffic classes."
+
+	TrafficClassConfigDoc.Type = "TrafficClassConfig"
+	TrafficClassConfigDoc.Comments[encoder.LineComment] = "TrafficClassConfig describes a traffic class."
+	TrafficClassConfigDoc.Description = "TrafficClassConfig describes a traffic class."
+	TrafficClassConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "TrafficShapingConfig",
+			FieldName: "classes",
+		},
+	}
+	TrafficClassConfigDoc.Fields = make([]encoder.Doc, 6)
+	TrafficClassConfigDoc.Fields[0].Name = "name"
+	TrafficClassConfigDoc.Fields[0].Type = "string"
+	TrafficClassConfigDoc.Fields[0].Note = ""
+	TrafficClassConfigDoc.Fields[0].Description = "Name of the traffic class."
+	TrafficClassConfigDoc.Fields[0].Comments[encoder.LineComment] = "Name of the traffic class."
+	TrafficClassConfigDoc.Fields[1].Name = "guaranteed"
+	TrafficClassConfigDoc.Fields[1].Type = "string"
+	TrafficClassConfigDoc.Fields[1].Note = ""
+	TrafficClassConfigDoc.Fields[1].Description = "Bandwidth guaranteed to the class (e.g. `100Mbit`).\nClasses without the guaranteed bandwidth get 1% of the interface bandwidth."
+	TrafficClassConfigDoc.Fields[1].Comments[encoder.LineComment] = "Bandwidth guaranteed to the class (e.g. `100Mbit`)."
+	TrafficClassConfigDoc.Fields[2].Name = "limit"
+	TrafficClassConfigDoc.Fields[2].Type = "string"
+	TrafficClassConfigDoc.Fields[2].Note = ""
+	TrafficClassConfigDoc.Fields[2].Description = "Bandwidth cap of the class (e.g. `400Mbit`), defaults to the interface bandwidth."
+	TrafficClassConfigDoc.Fields[2].Comments[encoder.LineComment] = "Bandwidth cap of the class (e.g. `400Mbit`), defaults to the interface bandwidth."
+	TrafficClassConfigDoc.Fields[3].Name = "priority"
+	TrafficClassConfigDoc.Fields[3].Type = "int"
+	TrafficClassConfigDoc.Fields[3].Note = ""
+	TrafficClassConfigDoc.Fields[3].Description = "Priority of the class when borrowing the spare bandwidth, from 0 (highest) to 7 (lowest)."
+	TrafficClassConfigDoc.Fields[3].Comments[encoder.LineComment] = "Priority of the class when borrowing the spare bandwidth, from 0 (highest) to 7 (lowest)."
+	TrafficClassConfigDoc.Fields[4].Name = "ports"
+	TrafficClassConfigDoc.Fields[4].Type = "[]int"
+	TrafficClassConfigDoc.Fields[4].Note = ""
+	TrafficClassConfigDoc.Fields[4].Description = "TCP/UDP ports (either source or destination) matching the class."
+	TrafficClassConfigDoc.Fields[4].Comments[encoder.LineComment] = "TCP/UDP ports (either source or destination) matching the class."
+	TrafficClassConfigDoc.Fields[5].Name = "subnets"
+	TrafficClassConfigDoc.Fields[5].Type = "[]string"
+	TrafficClassConfigDoc.Fields[5].Note = ""
+	TrafficClassConfigDoc.Fields[5].Description = "Subnets (either source or destination) matching the class."
+	TrafficClassConfigDoc.Fields[5].Comments[encoder.LineComment] = "Subnets (either source or destination) matching the class."
+
+	DeviceTuningConfigDoc.Type = "DeviceTuningConfig"
+	DeviceTuningConfigDoc.Comments[encoder.LineComment] = "DeviceTuningConfig contains NIC queue and packet steering settings."
+	DeviceTuningConfigDoc.Description = "DeviceTuningConfig contains NIC queue and packet steering settings."
+
+	DeviceTuningConfigDoc.AddExample("", networkConfigTuningExample)
+	DeviceTuningConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "Device",
+			FieldName: "tuning",
+		},
+	}
+	DeviceTuningConfigDoc.Fields = make([]encoder.Doc, 4)
+	DeviceTuningConfigDoc.Fields[0].Name = "combinedQueues"
+	DeviceTuningConfigDoc.Fields[0].Type = "uint32"
+	DeviceTuningConfigDoc.Fields[0].Note = ""
+	DeviceTuningConfigDoc.Fields[0].Description = "Number of the combined (RX/TX) queues (channels) of the NIC.\nQueue count is not changed if not set."
+	DeviceTuningConfigDoc.Fields[0].Comments[encoder.LineComment] = "Number of the combined (RX/TX) queues (channels) of the NIC."
+	DeviceTuningConfigDoc.Fields[1].Name = "irqCPUs"
+	DeviceTuningConfigDoc.Fields[1].Type = "string"
+	DeviceTuningConfigDoc.Fields[1].Note = ""
+	DeviceTuningConfigDoc.Fields[1].Description = "CPUs the NIC interrupts are pinned to (in the kernel CPU list format).\nInterrupts are distributed round-robin across the CPUs, one CPU per interrupt."
+	DeviceTuningConfigDoc.Fields[1].Comments[encoder.LineComment] = "CPUs the NIC interrupts are pinned to (in the kernel CPU list format)."
+	DeviceTuningConfigDoc.Fields[2].Name = "rpsCPUs"
+	DeviceTuningConfigDoc.Fields[2].Type = "string"
+	DeviceTuningConfigDoc.Fields[2].Note = ""
+	DeviceTuningConfigDoc.Fields[2].Description = "CPUs processing the received packets with Receive Packet Steering (in the kernel CPU list format).\nApplied to every RX queue of the NIC."
+	DeviceTuningConfigDoc.Fields[2].Comments[encoder.LineComment] = "CPUs processing the received packets with Receive Packet Steering (in the kernel CPU list format)."
+	DeviceTuningConfigDoc.Fields[3].Name = "xpsCPUs"
+	DeviceTuningConfigDoc.Fields[3].Type = "string"
+	DeviceTuningConfigDoc.Fields[3].Note = ""
+	DeviceTuningConfigDoc.Fields[3].Description = "CPUs allowed to transmit with Transmit Packet Steering (in the kernel CPU list format).\nApplied to every TX queue of the NIC."
+	DeviceTuningConfigDoc.Fields[3].Comments[encoder.LineComment] = "CPUs allowed to transmit with Transmit Packet Steering (in the kernel CPU list format)."
 
 	DHCPOptionsDoc.Type = "DHCPOptions"
 	DHCPOptionsDoc.Comments[encoder.LineComment] = "DHCPOptions contains options for configuring the DHCP settings for a given interface."
@@ -2064,12 +2805,71 @@ func init() {
 			FieldName: "features",
 		},
 	}
-	FeaturesConfigDoc.Fields = make([]encoder.Doc, 1)
+	FeaturesConfigDoc.Fields = make([]encoder.Doc, 7)
 	FeaturesConfigDoc.Fields[0].Name = "rbac"
 	FeaturesConfigDoc.Fields[0].Type = "bool"
 	FeaturesConfigDoc.Fields[0].Note = ""
 	FeaturesConfigDoc.Fields[0].Description = "Enable role-based access control (RBAC)."
 	FeaturesConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enable role-based access control (RBAC)."
+	FeaturesConfigDoc.Fields[1].Name = "kubeletTrustdCredentials"
+	FeaturesConfigDoc.Fields[1].Type = "bool"
+	FeaturesConfigDoc.Fields[1].Note = ""
+	FeaturesConfigDoc.Fields[1].Description = "Request kubelet client certificate from trustd (signed by the Kubernetes CA) instead of using the bootstrap token.\n\nWith this feature enabled `.cluster.token` can be omitted from the worker machine configuration."
+	FeaturesConfigDoc.Fields[1].Comments[encoder.LineComment] = "Request kubelet client certificate from trustd (signed by the Kubernetes CA) instead of using the bootstrap token."
+	FeaturesConfigDoc.Fields[2].Name = "retireStaticBootstrapToken"
+	FeaturesConfigDoc.Fields[2].Type = "bool"
+	FeaturesConfigDoc.Fields[2].Note = ""
+	FeaturesConfigDoc.Fields[2].Description = "Retire the long-lived bootstrap token from `.cluster.token`, so that kubelets bootstrap only with the short-lived tokens\nminted by the control plane nodes.\n\nOn the control plane nodes the bootstrap token secret is removed from the cluster,\non the worker nodes the bootstrap kubeconfig no longer falls back to the long-lived token.\nAll the nodes joining the cluster should run the version of Talos which fetches the short-lived tokens from trustd."
+	FeaturesConfigDoc.Fields[2].Comments[encoder.LineComment] = "Retire the long-lived bootstrap token from `.cluster.token`, so that kubelets bootstrap only with the short-lived tokens"
+	FeaturesConfigDoc.Fields[3].Name = "kubeletDefaultRuntimeSeccompProfile"
+	FeaturesConfigDoc.Fields[3].Type = "bool"
+	FeaturesConfigDoc.Fields[3].Note = ""
+	FeaturesConfigDoc.Fields[3].Description = "Use `RuntimeDefault` seccomp profile by default for all workloads (enables kubelet `SeccompDefault` feature gate).\n\nDeprecated: use `.machine.kubelet.defaultRuntimeSeccompProfileEnabled` instead."
+	FeaturesConfigDoc.Fields[3].Comments[encoder.LineComment] = "Use `RuntimeDefault` seccomp profile by default for all workloads (enables kubelet `SeccompDefault` feature gate)."
+	FeaturesConfigDoc.Fields[4].Name = "apidTLSMinVersion"
+	FeaturesConfigDoc.Fields[4].Type = "string"
+	FeaturesConfigDoc.Fields[4].Note = ""
+	FeaturesConfigDoc.Fields[4].Description = "Minimum TLS version accepted by apid."
+	FeaturesConfigDoc.Fields[4].Comments[encoder.LineComment] = "Minimum TLS version accepted by apid."
+	FeaturesConfigDoc.Fields[4].Values = []string{
+		"1.2",
+		"1.3",
+	}
+	FeaturesConfigDoc.Fields[5].Name = "stableHostname"
+	FeaturesConfigDoc.Fields[5].Type = "bool"
+	FeaturesConfigDoc.Fields[5].Note = ""
+	FeaturesConfigDoc.Fields[5].Description = "Derive the default hostname from the machine UUID (`talos-<base36>`) instead of DHCP-supplied or address-based hostname.\n\nHostname set explicitly in `.machine.network.hostname` takes precedence."
+	FeaturesConfigDoc.Fields[5].Comments[encoder.LineComment] = "Derive the default hostname from the machine UUID (`talos-<base36>`) instead of DHCP-supplied or address-based hostname."
+	FeaturesConfigDoc.Fields[6].Name = "controlPlaneLoadBalancer"
+	FeaturesConfigDoc.Fields[6].Type = "ControlPlaneLoadBalancerConfig"
+	FeaturesConfigDoc.Fields[6].Note = ""
+	FeaturesConfigDoc.Fields[6].Description = "Node-local load balancer for the Kubernetes API server.\n\nThe load balancer listens on localhost and proxies the kubelet traffic to the healthy control plane endpoints\n(the internal cluster endpoint and the discovered control plane nodes)."
+	FeaturesConfigDoc.Fields[6].Comments[encoder.LineComment] = "Node-local load balancer for the Kubernetes API server."
+
+	FeaturesConfigDoc.Fields[6].AddExample("", controlPlaneLoadBalancerExample)
+
+	ControlPlaneLoadBalancerConfigDoc.Type = "ControlPlaneLoadBalancerConfig"
+	ControlPlaneLoadBalancerConfigDoc.Comments[encoder.LineComment] = "ControlPlaneLoadBalancerConfig describes the node-local Kubernetes API server load balancer."
+	ControlPlaneLoadBalancerConfigDoc.Description = "ControlPlaneLoadBalancerConfig describes the node-local Kubernetes API server load balancer."
+
+	ControlPlaneLoadBalancerConfigDoc.AddExample("", controlPlaneLoadBalancerExample)
+	ControlPlaneLoadBalancerConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "FeaturesConfig",
+			FieldName: "controlPlaneLoadBalancer",
+		},
+	}
+	ControlPlaneLoadBalancerConfigDoc.Fields = make([]encoder.Doc, 2)
+	ControlPlaneLoadBalancerConfigDoc.Fields[0].Name = "enabled"
+	ControlPlaneLoadBalancerConfigDoc.Fields[0].Type = "bool"
+	ControlPlaneLoadBalancerConfigDoc.Fields[0].Note = ""
+	ControlPlaneLoadBalancerConfigDoc.Fields[0].Description = "Enable the node-local load balancer."
+	ControlPlaneLoadBalancerConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enable the node-local load balancer."
+	ControlPlaneLoadBalancerConfigDoc.Fields[1].Name = "port"
+	ControlPlaneLoadBalancerConfigDoc.Fields[1].Type = "int"
+	ControlPlaneLoadBalancerConfigDoc.Fields[1].Note = ""
+	ControlPlaneLoadBalancerConfigDoc.Fields[1].Description = "The localhost port the load balancer listens on, defaults to 7445."
+	ControlPlaneLoadBalancerConfigDoc.Fields[1].Comments[encoder.LineComment] = "The localhost port the load balancer listens on, defaults to 7445."
 
 	VolumeMountConfigDoc.Type = "VolumeMountConfig"
 	VolumeMountConfigDoc.Comments[encoder.LineComment] = "VolumeMountConfig struct describes extra volume mount for the static pods."
@@ -2153,6 +2953,29 @@ func init() {
 	NetworkKubeSpanDoc.Fields[1].Description = "Skip sending traffic via KubeSpan if the peer connection state is not up.\nThis provides configurable choice between connectivity and security: either traffic is always\nforced to go via KubeSpan (even if Wireguard peer connection is not up), or traffic can go directly\nto the peer if Wireguard connection can't be established."
 	NetworkKubeSpanDoc.Fields[1].Comments[encoder.LineComment] = "Skip sending traffic via KubeSpan if the peer connection state is not up."
 
+	ConntrackConfigDoc.Type = "ConntrackConfig"
+	ConntrackConfigDoc.Comments[encoder.LineComment] = "ConntrackConfig struct configures the connection tracking table."
+	ConntrackConfigDoc.Description = "ConntrackConfig struct configures the connection tracking table."
+
+	ConntrackConfigDoc.AddExample("", networkConntrackExample)
+	ConntrackConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "NetworkConfig",
+			FieldName: "conntrack",
+		},
+	}
+	ConntrackConfigDoc.Fields = make([]encoder.Doc, 2)
+	ConntrackConfigDoc.Fields[0].Name = "max"
+	ConntrackConfigDoc.Fields[0].Type = "uint32"
+	ConntrackConfigDoc.Fields[0].Note = ""
+	ConntrackConfigDoc.Fields[0].Description = "Maximum number of the tracked connections (`net.netfilter.nf_conntrack_max`).\n\nNew connections are dropped once the table is full.\nIf not set, the kernel default is used (derived from the amount of memory)."
+	ConntrackConfigDoc.Fields[0].Comments[encoder.LineComment] = "Maximum number of the tracked connections (`net.netfilter.nf_conntrack_max`)."
+	ConntrackConfigDoc.Fields[1].Name = "buckets"
+	ConntrackConfigDoc.Fields[1].Type = "uint32"
+	ConntrackConfigDoc.Fields[1].Note = ""
+	ConntrackConfigDoc.Fields[1].Description = "Size of the connection tracking hash table (`net.netfilter.nf_conntrack_buckets`).\n\nIf not set, the kernel default is used (derived from the amount of memory)."
+	ConntrackConfigDoc.Fields[1].Comments[encoder.LineComment] = "Size of the connection tracking hash table (`net.netfilter.nf_conntrack_buckets`)."
+
 	ClusterDiscoveryConfigDoc.Type = "ClusterDiscoveryConfig"
 	ClusterDiscoveryConfigDoc.Comments[encoder.LineComment] = "ClusterDiscoveryConfig struct configures cluster membership discovery."
 	ClusterDiscoveryConfigDoc.Description = "ClusterDiscoveryConfig struct configures cluster membership discovery."
@@ -2265,12 +3088,983 @@ func init() {
 			FieldName: "logging",
 		},
 	}
-	LoggingConfigDoc.Fields = make([]encoder.Doc, 1)
+	LoggingConfigDoc.Fields = make([]encoder.Doc, 3)
 	LoggingConfigDoc.Fields[0].Name = "destinations"
 	LoggingConfigDoc.Fields[0].Type = "[]LoggingDestination"
 	LoggingConfigDoc.Fields[0].Note = ""
 	LoggingConfigDoc.Fields[0].Description = "Logging destination."
 	LoggingConfigDoc.Fields[0].Comments[encoder.LineComment] = "Logging destination."
+	LoggingConfigDoc.Fields[1].Name = "rateLimits"
+	LoggingConfigDoc.Fields[1].Type = "[]LoggingRateLimit"
+	LoggingConfigDoc.Fields[1].Note = ""
+	LoggingConfigDoc.Fields[1].Description = "Per-service log rate limits.\n\nLog lines over the limit are dropped, number of dropped lines is available via `talosctl get logratelimitstatuses`.\nService `*` sets the limit for all other services.\nBy default, each service is limited to a burst of 10000 lines and a sustained rate of 1000 lines per second."
+	LoggingConfigDoc.Fields[1].Comments[encoder.LineComment] = "Per-service log rate limits."
+
+	LoggingConfigDoc.Fields[1].AddExample("", loggingRateLimitExample)
+	LoggingConfigDoc.Fields[2].Name = "sessionRecording"
+	LoggingConfigDoc.Fields[2].Type = "SessionRecordingConfig"
+	LoggingConfigDoc.Fields[2].Note = ""
+	LoggingConfigDoc.Fields[2].Description = "Records the transcripts of the interactive sessions (`talosctl debug node` and `talosctl console`) to the `audit` log.\n\nStart and end of the interactive sessions are always recorded to the `audit` log (`talosctl logs audit`)."
+	LoggingConfigDoc.Fields[2].Comments[encoder.LineComment] = "Records the transcripts of the interactive sessions (`talosctl debug node` and `talosctl console`) to the `audit` log."
+
+	LoggingConfigDoc.Fields[2].AddExample("", loggingSessionRecordingExample)
+
+	LoggingRateLimitDoc.Type = "LoggingRateLimit"
+	LoggingRateLimitDoc.Comments[encoder.LineComment] = "LoggingRateLimit struct configures log rate limit for a service."
+	LoggingRateLimitDoc.Description = "LoggingRateLimit struct configures log rate limit for a service."
+
+	LoggingRateLimitDoc.AddExample("", loggingRateLimitExample)
+	LoggingRateLimitDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "LoggingConfig",
+			FieldName: "rateLimits",
+		},
+	}
+	LoggingRateLimitDoc.Fields = make([]encoder.Doc, 3)
+	LoggingRateLimitDoc.Fields[0].Name = "service"
+	LoggingRateLimitDoc.Fields[0].Type = "string"
+	LoggingRateLimitDoc.Fields[0].Note = ""
+	LoggingRateLimitDoc.Fields[0].Description = "Service ID (as shown in `talosctl services`), or `*` for all other services."
+	LoggingRateLimitDoc.Fields[0].Comments[encoder.LineComment] = "Service ID (as shown in `talosctl services`), or `*` for all other services."
+	LoggingRateLimitDoc.Fields[1].Name = "burst"
+	LoggingRateLimitDoc.Fields[1].Type = "int"
+	LoggingRateLimitDoc.Fields[1].Note = ""
+	LoggingRateLimitDoc.Fields[1].Description = "Number of log lines which can be written at once over the sustained rate."
+	LoggingRateLimitDoc.Fields[1].Comments[encoder.LineComment] = "Number of log lines which can be written at once over the sustained rate."
+	LoggingRateLimitDoc.Fields[2].Name = "rate"
+	LoggingRateLimitDoc.Fields[2].Type = "int"
+	LoggingRateLimitDoc.Fields[2].Note = ""
+	LoggingRateLimitDoc.Fields[2].Description = "Sustained number of log lines per second."
+	LoggingRateLimitDoc.Fields[2].Comments[encoder.LineComment] = "Sustained number of log lines per second."
+
+	SessionRecordingConfigDoc.Type = "SessionRecordingConfig"
+	SessionRecordingConfigDoc.Comments[encoder.LineComment] = "SessionRecordingConfig configures recording of the interactive session transcripts."
+	SessionRecordingConfigDoc.Description = "SessionRecordingConfig configures recording of the interactive session transcripts."
+
+	SessionRecordingConfigDoc.AddExample("", loggingSessionRecordingExample)
+	SessionRecordingConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "LoggingConfig",
+			FieldName: "sessionRecording",
+		},
+	}
+	SessionRecordingConfigDoc.Fields = make([]encoder.Doc, 3)
+	SessionRecordingConfigDoc.Fields[0].Name = "enabled"
+	SessionRecordingConfigDoc.Fields[0].Type = "bool"
+	SessionRecordingConfigDoc.Fields[0].Note = ""
+	SessionRecordingConfigDoc.Fields[0].Description = "Enables the transcript recording."
+	SessionRecordingConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables the transcript recording."
+	SessionRecordingConfigDoc.Fields[1].Name = "maxBytes"
+	SessionRecordingConfigDoc.Fields[1].Type = "int"
+	SessionRecordingConfigDoc.Fields[1].Note = ""
+	SessionRecordingConfigDoc.Fields[1].Description = "Maximum size of the transcript recorded per session, in bytes.\n\nThe rest of the session is not recorded, defaults to 1 MiB."
+	SessionRecordingConfigDoc.Fields[1].Comments[encoder.LineComment] = "Maximum size of the transcript recorded per session, in bytes."
+	SessionRecordingConfigDoc.Fields[2].Name = "redact"
+	SessionRecordingConfigDoc.Fields[2].Type = "[]string"
+	SessionRecordingConfigDoc.Fields[2].Note = ""
+	SessionRecordingConfigDoc.Fields[2].Description = "Regular expressions matching the data which is replaced with `[REDACTED]` in the transcripts."
+	SessionRecordingConfigDoc.Fields[2].Comments[encoder.LineComment] = "Regular expressions matching the data which is replaced with `[REDACTED]` in the transcripts."
+
+	ServiceHookDoc.Type = "ServiceHook"
+	ServiceHookDoc.Comments[encoder.LineComment] = "ServiceHook struct configures a hook run before or after the system service is started."
+	ServiceHookDoc.Description = "ServiceHook struct configures a hook run before or after the system service is started."
+
+	ServiceHookDoc.AddExample("", machineServiceHooksExample)
+	ServiceHookDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "serviceHooks",
+		},
+	}
+	ServiceHookDoc.Fields = make([]encoder.Doc, 5)
+	ServiceHookDoc.Fields[0].Name = "service"
+	ServiceHookDoc.Fields[0].Type = "string"
+	ServiceHookDoc.Fields[0].Note = ""
+	ServiceHookDoc.Fields[0].Description = "Service ID (as shown in `talosctl services`)."
+	ServiceHookDoc.Fields[0].Comments[encoder.LineComment] = "Service ID (as shown in `talosctl services`)."
+	ServiceHookDoc.Fields[1].Name = "stage"
+	ServiceHookDoc.Fields[1].Type = "string"
+	ServiceHookDoc.Fields[1].Note = ""
+	ServiceHookDoc.Fields[1].Description = "Stage of the service lifecycle to run the hook at.\n\n`pre` hooks are run before the service is started, service start fails if the hook fails.\n`post` hooks are run once the service is up (and healthy), hook failure is reported in the service events."
+	ServiceHookDoc.Fields[1].Comments[encoder.LineComment] = "Stage of the service lifecycle to run the hook at."
+	ServiceHookDoc.Fields[1].Values = []string{
+		"pre",
+		"post",
+	}
+	ServiceHookDoc.Fields[2].Name = "command"
+	ServiceHookDoc.Fields[2].Type = "[]string"
+	ServiceHookDoc.Fields[2].Note = ""
+	ServiceHookDoc.Fields[2].Description = "Command to run (with arguments)."
+	ServiceHookDoc.Fields[2].Comments[encoder.LineComment] = "Command to run (with arguments)."
+	ServiceHookDoc.Fields[3].Name = "image"
+	ServiceHookDoc.Fields[3].Type = "string"
+	ServiceHookDoc.Fields[3].Note = ""
+	ServiceHookDoc.Fields[3].Description = "Container image to run the command in.\n\nIf not set, the command is run as a process on the host.\nContainers share the host network namespace and `/var` with the host.\nContainer hooks require the `cri` service to be running, so they can't be used with the services started before it."
+	ServiceHookDoc.Fields[3].Comments[encoder.LineComment] = "Container image to run the command in."
+	ServiceHookDoc.Fields[4].Name = "timeout"
+	ServiceHookDoc.Fields[4].Type = "Duration"
+	ServiceHookDoc.Fields[4].Note = ""
+	ServiceHookDoc.Fields[4].Description = "Time to wait for the hook to finish, default is 5 minutes.\n\nField format accepts any Go time.Duration format ('1h' for one hour, '10m' for ten minutes)."
+	ServiceHookDoc.Fields[4].Comments[encoder.LineComment] = "Time to wait for the hook to finish, default is 5 minutes."
+
+	ServiceOverrideDoc.Type = "ServiceOverride"
+	ServiceOverrideDoc.Comments[encoder.LineComment] = "ServiceOverride struct adjusts the container spec of the system service."
+	ServiceOverrideDoc.Description = "ServiceOverride struct adjusts the container spec of the system service."
+
+	ServiceOverrideDoc.AddExample("", machineServiceOverridesExample)
+	ServiceOverrideDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "serviceOverrides",
+		},
+	}
+	ServiceOverrideDoc.Fields = make([]encoder.Doc, 4)
+	ServiceOverrideDoc.Fields[0].Name = "service"
+	ServiceOverrideDoc.Fields[0].Type = "string"
+	ServiceOverrideDoc.Fields[0].Note = ""
+	ServiceOverrideDoc.Fields[0].Description = "Service ID (as shown in `talosctl services`)."
+	ServiceOverrideDoc.Fields[0].Comments[encoder.LineComment] = "Service ID (as shown in `talosctl services`)."
+	ServiceOverrideDoc.Fields[1].Name = "env"
+	ServiceOverrideDoc.Fields[1].Type = "Env"
+	ServiceOverrideDoc.Fields[1].Note = ""
+	ServiceOverrideDoc.Fields[1].Description = "Extra environment variables for the service.\n\nVariables override the ones set via `.machine.env`."
+	ServiceOverrideDoc.Fields[1].Comments[encoder.LineComment] = "Extra environment variables for the service."
+	ServiceOverrideDoc.Fields[2].Name = "mounts"
+	ServiceOverrideDoc.Fields[2].Type = "[]ServiceOverrideMount"
+	ServiceOverrideDoc.Fields[2].Note = ""
+	ServiceOverrideDoc.Fields[2].Description = "Extra read-only bind mounts for the service."
+	ServiceOverrideDoc.Fields[2].Comments[encoder.LineComment] = "Extra read-only bind mounts for the service."
+	ServiceOverrideDoc.Fields[3].Name = "rlimits"
+	ServiceOverrideDoc.Fields[3].Type = "[]ServiceOverrideRlimit"
+	ServiceOverrideDoc.Fields[3].Note = ""
+	ServiceOverrideDoc.Fields[3].Description = "Resource limits for the service process."
+	ServiceOverrideDoc.Fields[3].Comments[encoder.LineComment] = "Resource limits for the service process."
+
+	ServiceOverrideMountDoc.Type = "ServiceOverrideMount"
+	ServiceOverrideMountDoc.Comments[encoder.LineComment] = "ServiceOverrideMount struct describes an extra read-only bind mount for the system service."
+	ServiceOverrideMountDoc.Description = "ServiceOverrideMount struct describes an extra read-only bind mount for the system service."
+	ServiceOverrideMountDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ServiceOverride",
+			FieldName: "mounts",
+		},
+	}
+	ServiceOverrideMountDoc.Fields = make([]encoder.Doc, 2)
+	ServiceOverrideMountDoc.Fields[0].Name = "source"
+	ServiceOverrideMountDoc.Fields[0].Type = "string"
+	ServiceOverrideMountDoc.Fields[0].Note = ""
+	ServiceOverrideMountDoc.Fields[0].Description = "Host path to mount."
+	ServiceOverrideMountDoc.Fields[0].Comments[encoder.LineComment] = "Host path to mount."
+	ServiceOverrideMountDoc.Fields[1].Name = "destination"
+	ServiceOverrideMountDoc.Fields[1].Type = "string"
+	ServiceOverrideMountDoc.Fields[1].Note = ""
+	ServiceOverrideMountDoc.Fields[1].Description = "Path in the service container."
+	ServiceOverrideMountDoc.Fields[1].Comments[encoder.LineComment] = "Path in the service container."
+
+	ServiceOverrideRlimitDoc.Type = "ServiceOverrideRlimit"
+	ServiceOverrideRlimitDoc.Comments[encoder.LineComment] = "ServiceOverrideRlimit struct describes a resource limit for the system service."
+	ServiceOverrideRlimitDoc.Description = "ServiceOverrideRlimit struct describes a resource limit for the system service."
+	ServiceOverrideRlimitDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "ServiceOverride",
+			FieldName: "rlimits",
+		},
+	}
+	ServiceOverrideRlimitDoc.Fields = make([]encoder.Doc, 3)
+	ServiceOverrideRlimitDoc.Fields[0].Name = "type"
+	ServiceOverrideRlimitDoc.Fields[0].Type = "string"
+	ServiceOverrideRlimitDoc.Fields[0].Note = ""
+	ServiceOverrideRlimitDoc.Fields[0].Description = "Resource limit type."
+	ServiceOverrideRlimitDoc.Fields[0].Comments[encoder.LineComment] = "Resource limit type."
+
+	ServiceOverrideRlimitDoc.Fields[0].AddExample("", "RLIMIT_NOFILE")
+	ServiceOverrideRlimitDoc.Fields[1].Name = "soft"
+	ServiceOverrideRlimitDoc.Fields[1].Type = "uint64"
+	ServiceOverrideRlimitDoc.Fields[1].Note = ""
+	ServiceOverrideRlimitDoc.Fields[1].Description = "Soft limit."
+	ServiceOverrideRlimitDoc.Fields[1].Comments[encoder.LineComment] = "Soft limit."
+	ServiceOverrideRlimitDoc.Fields[2].Name = "hard"
+	ServiceOverrideRlimitDoc.Fields[2].Type = "uint64"
+	ServiceOverrideRlimitDoc.Fields[2].Note = ""
+	ServiceOverrideRlimitDoc.Fields[2].Description = "Hard limit."
+	ServiceOverrideRlimitDoc.Fields[2].Comments[encoder.LineComment] = "Hard limit."
+
+	IMAConfigDoc.Type = "IMAConfig"
+	IMAConfigDoc.Comments[encoder.LineComment] = "IMAConfig struct configures the IMA policy."
+	IMAConfigDoc.Description = "IMAConfig struct configures the IMA policy."
+
+	IMAConfigDoc.AddExample("", machineIMAExample)
+	IMAConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "ima",
+		},
+	}
+	IMAConfigDoc.Fields = make([]encoder.Doc, 1)
+	IMAConfigDoc.Fields[0].Name = "policy"
+	IMAConfigDoc.Fields[0].Type = "[]string"
+	IMAConfigDoc.Fields[0].Note = ""
+	IMAConfigDoc.Fields[0].Description = "IMA policy rules appended to the default Talos policy (rules are matched in order).\n\nSee [kernel documentation](https://www.kernel.org/doc/Documentation/ABI/testing/ima_policy) for the rule format."
+	IMAConfigDoc.Fields[0].Comments[encoder.LineComment] = "IMA policy rules appended to the default Talos policy (rules are matched in order)."
+
+	APITLSConfigDoc.Type = "APITLSConfig"
+	APITLSConfigDoc.Comments[encoder.LineComment] = "APITLSConfig struct configures TLS settings of the Talos API listeners."
+	APITLSConfigDoc.Description = "APITLSConfig struct configures TLS settings of the Talos API listeners."
+
+	APITLSConfigDoc.AddExample("", machineAPITLSExample)
+	APITLSConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "apiTLS",
+		},
+	}
+	APITLSConfigDoc.Fields = make([]encoder.Doc, 4)
+	APITLSConfigDoc.Fields[0].Name = "minVersion"
+	APITLSConfigDoc.Fields[0].Type = "string"
+	APITLSConfigDoc.Fields[0].Note = ""
+	APITLSConfigDoc.Fields[0].Description = "Minimum TLS version accepted by apid and trustd.\n\nTakes precedence over `.machine.features.apidTLSMinVersion`."
+	APITLSConfigDoc.Fields[0].Comments[encoder.LineComment] = "Minimum TLS version accepted by apid and trustd."
+	APITLSConfigDoc.Fields[0].Values = []string{
+		"1.2",
+		"1.3",
+	}
+	APITLSConfigDoc.Fields[1].Name = "cipherSuites"
+	APITLSConfigDoc.Fields[1].Type = "[]string"
+	APITLSConfigDoc.Fields[1].Note = ""
+	APITLSConfigDoc.Fields[1].Description = "Cipher suites accepted for TLS 1.2 connections (TLS 1.3 cipher suites are not configurable).\n\nDefaults to `TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384`.\nSee [Go documentation](https://pkg.go.dev/crypto/tls#pkg-constants) for the cipher suite names, insecure cipher suites are not supported."
+	APITLSConfigDoc.Fields[1].Comments[encoder.LineComment] = "Cipher suites accepted for TLS 1.2 connections (TLS 1.3 cipher suites are not configurable)."
+	APITLSConfigDoc.Fields[2].Name = "acceptedCAs"
+	APITLSConfigDoc.Fields[2].Type = "[]Base64Bytes"
+	APITLSConfigDoc.Fields[2].Note = ""
+	APITLSConfigDoc.Fields[2].Description = "Additional CA certificates accepted by apid for the client certificates (base64-encoded).\n\nCertificates should be PEM-encoded.\nClient certificates signed by `.machine.ca` are always accepted, additional CAs allow\nto rotate the machine CA without losing access to the Talos API."
+	APITLSConfigDoc.Fields[2].Comments[encoder.LineComment] = "Additional CA certificates accepted by apid for the client certificates (base64-encoded)."
+	APITLSConfigDoc.Fields[3].Name = "serverCertificate"
+	APITLSConfigDoc.Fields[3].Type = "APITLSCertificateConfig"
+	APITLSConfigDoc.Fields[3].Note = ""
+	APITLSConfigDoc.Fields[3].Description = "Externally signed certificate for the apid server.\n\nBy default the apid server certificate is issued by `.machine.ca`.\nThe certificate chain should lead to `.machine.ca` or one of the `acceptedCAs`.\nThe CSR for the certificate can be generated with `talosctl gen csr`."
+	APITLSConfigDoc.Fields[3].Comments[encoder.LineComment] = "Externally signed certificate for the apid server."
+
+	APITLSConfigDoc.Fields[3].AddExample("", machineAPITLSServerCertificateExample)
+
+	APITLSCertificateConfigDoc.Type = "APITLSCertificateConfig"
+	APITLSCertificateConfigDoc.Comments[encoder.LineComment] = "APITLSCertificateConfig struct describes an externally signed certificate."
+	APITLSCertificateConfigDoc.Description = "APITLSCertificateConfig struct describes an externally signed certificate."
+
+	APITLSCertificateConfigDoc.AddExample("", machineAPITLSServerCertificateExample)
+	APITLSCertificateConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "APITLSConfig",
+			FieldName: "serverCertificate",
+		},
+	}
+	APITLSCertificateConfigDoc.Fields = make([]encoder.Doc, 3)
+	APITLSCertificateConfigDoc.Fields[0].Name = "crt"
+	APITLSCertificateConfigDoc.Fields[0].Type = "Base64Bytes"
+	APITLSCertificateConfigDoc.Fields[0].Note = ""
+	APITLSCertificateConfigDoc.Fields[0].Description = "PEM-encoded certificate (base64-encoded)."
+	APITLSCertificateConfigDoc.Fields[0].Comments[encoder.LineComment] = "PEM-encoded certificate (base64-encoded)."
+	APITLSCertificateConfigDoc.Fields[1].Name = "key"
+	APITLSCertificateConfigDoc.Fields[1].Type = "Base64Bytes"
+	APITLSCertificateConfigDoc.Fields[1].Note = ""
+	APITLSCertificateConfigDoc.Fields[1].Description = "PEM-encoded private key of the certificate (base64-encoded)."
+	APITLSCertificateConfigDoc.Fields[1].Comments[encoder.LineComment] = "PEM-encoded private key of the certificate (base64-encoded)."
+	APITLSCertificateConfigDoc.Fields[2].Name = "chain"
+	APITLSCertificateConfigDoc.Fields[2].Type = "[]Base64Bytes"
+	APITLSCertificateConfigDoc.Fields[2].Note = ""
+	APITLSCertificateConfigDoc.Fields[2].Description = "PEM-encoded intermediate CA certificates (base64-encoded) between the certificate and the accepted CA."
+	APITLSCertificateConfigDoc.Fields[2].Comments[encoder.LineComment] = "PEM-encoded intermediate CA certificates (base64-encoded) between the certificate and the accepted CA."
+
+	BMCConfigDoc.Type = "BMCConfig"
+	BMCConfigDoc.Comments[encoder.LineComment] = "BMCConfig struct configures the integration with the node BMC."
+	BMCConfigDoc.Description = "BMCConfig struct configures the integration with the node BMC."
+
+	BMCConfigDoc.AddExample("", machineBMCExample)
+	BMCConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "bmc",
+		},
+	}
+	BMCConfigDoc.Fields = make([]encoder.Doc, 2)
+	BMCConfigDoc.Fields[0].Name = "enabled"
+	BMCConfigDoc.Fields[0].Type = "bool"
+	BMCConfigDoc.Fields[0].Note = ""
+	BMCConfigDoc.Fields[0].Description = "Enables reading the BMC information (IPMI version, LAN address) via the system IPMI interface."
+	BMCConfigDoc.Fields[0].Comments[encoder.LineComment] = "Enables reading the BMC information (IPMI version, LAN address) via the system IPMI interface."
+	BMCConfigDoc.Fields[1].Name = "allowPowerControl"
+	BMCConfigDoc.Fields[1].Type = "bool"
+	BMCConfigDoc.Fields[1].Note = ""
+	BMCConfigDoc.Fields[1].Description = "Allows the `BMCControl` API (`talosctl bmc`) to set the boot device and control the node power via its BMC.\n\nThe API is available only to the `os:admin` role."
+	BMCConfigDoc.Fields[1].Comments[encoder.LineComment] = "Allows the `BMCControl` API (`talosctl bmc`) to set the boot device and control the node power via its BMC."
+
+	LifecycleWebhookConfigDoc.Type = "LifecycleWebhookConfig"
+	LifecycleWebhookConfigDoc.Comments[encoder.LineComment] = "LifecycleWebhookConfig struct configures the lifecycle events webhook."
+	LifecycleWebhookConfigDoc.Description = "LifecycleWebhookConfig struct configures the lifecycle events webhook."
+
+	LifecycleWebhookConfigDoc.AddExample("", machineLifecycleWebhookExample)
+	LifecycleWebhookConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "lifecycleWebhook",
+		},
+	}
+	LifecycleWebhookConfigDoc.Fields = make([]encoder.Doc, 2)
+	LifecycleWebhookConfigDoc.Fields[0].Name = "endpoint"
+	LifecycleWebhookConfigDoc.Fields[0].Type = "Endpoint"
+	LifecycleWebhookConfigDoc.Fields[0].Note = ""
+	LifecycleWebhookConfigDoc.Fields[0].Description = "HTTPS endpoint the events are posted to as JSON documents."
+	LifecycleWebhookConfigDoc.Fields[0].Comments[encoder.LineComment] = "HTTPS endpoint the events are posted to as JSON documents."
+	LifecycleWebhookConfigDoc.Fields[1].Name = "secret"
+	LifecycleWebhookConfigDoc.Fields[1].Type = "string"
+	LifecycleWebhookConfigDoc.Fields[1].Note = ""
+	LifecycleWebhookConfigDoc.Fields[1].Description = "Secret key used to sign the request body with HMAC-SHA256.\n\nThe hex-encoded signature is sent in the `X-Talos-Signature` header prefixed with `sha256=`."
+	LifecycleWebhookConfigDoc.Fields[1].Comments[encoder.LineComment] = "Secret key used to sign the request body with HMAC-SHA256."
+
+	BackupConfigDoc.Type = "BackupConfig"
+	BackupConfigDoc.Comments[encoder.LineComment] = "BackupConfig struct configures the host directory backups taken before the destructive operations."
+	BackupConfigDoc.Description = "BackupConfig struct configures the host directory backups taken before the destructive operations."
+
+	BackupConfigDoc.AddExample("", machineBackupExample)
+	BackupConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "backup",
+		},
+	}
+	BackupConfigDoc.Fields = make([]encoder.Doc, 4)
+	BackupConfigDoc.Fields[0].Name = "s3"
+	BackupConfigDoc.Fields[0].Type = "BackupS3Config"
+	BackupConfigDoc.Fields[0].Note = ""
+	BackupConfigDoc.Fields[0].Description = "S3-compatible storage the backups are uploaded to."
+	BackupConfigDoc.Fields[0].Comments[encoder.LineComment] = "S3-compatible storage the backups are uploaded to."
+	BackupConfigDoc.Fields[1].Name = "paths"
+	BackupConfigDoc.Fields[1].Type = "[]BackupPathConfig"
+	BackupConfigDoc.Fields[1].Note = ""
+	BackupConfigDoc.Fields[1].Description = "Host directories to back up."
+	BackupConfigDoc.Fields[1].Comments[encoder.LineComment] = "Host directories to back up."
+	BackupConfigDoc.Fields[2].Name = "before"
+	BackupConfigDoc.Fields[2].Type = "[]string"
+	BackupConfigDoc.Fields[2].Note = ""
+	BackupConfigDoc.Fields[2].Description = "Operations the backup is taken before, defaults to both.\n\nUpgrades with `--preserve` keep the data, so the backup is never taken for them."
+	BackupConfigDoc.Fields[2].Comments[encoder.LineComment] = "Operations the backup is taken before, defaults to both."
+	BackupConfigDoc.Fields[2].Values = []string{
+		"reset",
+		"upgrade",
+	}
+	BackupConfigDoc.Fields[3].Name = "continueOnError"
+	BackupConfigDoc.Fields[3].Type = "bool"
+	BackupConfigDoc.Fields[3].Note = ""
+	BackupConfigDoc.Fields[3].Description = "Proceed with the operation if the backup fails, by default the operation is aborted."
+	BackupConfigDoc.Fields[3].Comments[encoder.LineComment] = "Proceed with the operation if the backup fails, by default the operation is aborted."
+
+	BackupS3ConfigDoc.Type = "BackupS3Config"
+	BackupS3ConfigDoc.Comments[encoder.LineComment] = "BackupS3Config struct configures the S3-compatible backup storage."
+	BackupS3ConfigDoc.Description = "BackupS3Config struct configures the S3-compatible backup storage."
+	BackupS3ConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "BackupConfig",
+			FieldName: "s3",
+		},
+	}
+	BackupS3ConfigDoc.Fields = make([]encoder.Doc, 7)
+	BackupS3ConfigDoc.Fields[0].Name = "endpoint"
+	BackupS3ConfigDoc.Fields[0].Type = "string"
+	BackupS3ConfigDoc.Fields[0].Note = ""
+	BackupS3ConfigDoc.Fields[0].Description = "S3 endpoint URL, defaults to AWS S3 in the region."
+	BackupS3ConfigDoc.Fields[0].Comments[encoder.LineComment] = "S3 endpoint URL, defaults to AWS S3 in the region."
+	BackupS3ConfigDoc.Fields[1].Name = "region"
+	BackupS3ConfigDoc.Fields[1].Type = "string"
+	BackupS3ConfigDoc.Fields[1].Note = ""
+	BackupS3ConfigDoc.Fields[1].Description = "S3 region."
+	BackupS3ConfigDoc.Fields[1].Comments[encoder.LineComment] = "S3 region."
+	BackupS3ConfigDoc.Fields[2].Name = "bucket"
+	BackupS3ConfigDoc.Fields[2].Type = "string"
+	BackupS3ConfigDoc.Fields[2].Note = ""
+	BackupS3ConfigDoc.Fields[2].Description = "Bucket name."
+	BackupS3ConfigDoc.Fields[2].Comments[encoder.LineComment] = "Bucket name."
+	BackupS3ConfigDoc.Fields[3].Name = "prefix"
+	BackupS3ConfigDoc.Fields[3].Type = "string"
+	BackupS3ConfigDoc.Fields[3].Note = ""
+	BackupS3ConfigDoc.Fields[3].Description = "Prefix prepended to the object keys."
+	BackupS3ConfigDoc.Fields[3].Comments[encoder.LineComment] = "Prefix prepended to the object keys."
+	BackupS3ConfigDoc.Fields[4].Name = "accessKeyID"
+	BackupS3ConfigDoc.Fields[4].Type = "string"
+	BackupS3ConfigDoc.Fields[4].Note = ""
+	BackupS3ConfigDoc.Fields[4].Description = "Access key ID.\n\nThe credentials should only allow putting the objects under the prefix."
+	BackupS3ConfigDoc.Fields[4].Comments[encoder.LineComment] = "Access key ID."
+	BackupS3ConfigDoc.Fields[5].Name = "secretAccessKey"
+	BackupS3ConfigDoc.Fields[5].Type = "string"
+	BackupS3ConfigDoc.Fields[5].Note = ""
+	BackupS3ConfigDoc.Fields[5].Description = "Secret access key."
+	BackupS3ConfigDoc.Fields[5].Comments[encoder.LineComment] = "Secret access key."
+	BackupS3ConfigDoc.Fields[6].Name = "forcePathStyle"
+	BackupS3ConfigDoc.Fields[6].Type = "bool"
+	BackupS3ConfigDoc.Fields[6].Note = ""
+	BackupS3ConfigDoc.Fields[6].Description = "Use the path-style bucket addressing (`<endpoint>/<bucket>`), required by most S3-compatible servers."
+	BackupS3ConfigDoc.Fields[6].Comments[encoder.LineComment] = "Use the path-style bucket addressing (`<endpoint>/<bucket>`), required by most S3-compatible servers."
+
+	BackupPathConfigDoc.Type = "BackupPathConfig"
+	BackupPathConfigDoc.Comments[encoder.LineComment] = "BackupPathConfig struct configures a backed up host directory."
+	BackupPathConfigDoc.Description = "BackupPathConfig struct configures a backed up host directory."
+	BackupPathConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "BackupConfig",
+			FieldName: "paths",
+		},
+	}
+	BackupPathConfigDoc.Fields = make([]encoder.Doc, 3)
+	BackupPathConfigDoc.Fields[0].Name = "path"
+	BackupPathConfigDoc.Fields[0].Type = "string"
+	BackupPathConfigDoc.Fields[0].Note = ""
+	BackupPathConfigDoc.Fields[0].Description = "Absolute path of the directory under `/var`."
+	BackupPathConfigDoc.Fields[0].Comments[encoder.LineComment] = "Absolute path of the directory under `/var`."
+	BackupPathConfigDoc.Fields[1].Name = "include"
+	BackupPathConfigDoc.Fields[1].Type = "[]string"
+	BackupPathConfigDoc.Fields[1].Note = ""
+	BackupPathConfigDoc.Fields[1].Description = "Patterns of the files to back up, defaults to all files.\n\nPatterns are matched against the path relative to the directory,\npatterns without a slash are matched against the file name.\n`*` doesn't match a slash, `**` matches any number of the path segments."
+	BackupPathConfigDoc.Fields[1].Comments[encoder.LineComment] = "Patterns of the files to back up, defaults to all files."
+	BackupPathConfigDoc.Fields[2].Name = "exclude"
+	BackupPathConfigDoc.Fields[2].Type = "[]string"
+	BackupPathConfigDoc.Fields[2].Note = ""
+	BackupPathConfigDoc.Fields[2].Description = "Patterns of the files and the directories to skip, same format as `include`.\n\nExcluded directories are skipped with all of their contents."
+	BackupPathConfigDoc.Fields[2].Comments[encoder.LineComment] = "Patterns of the files and the directories to skip, same format as `include`."
+
+	CRIConfigDoc.Type = "CRIConfig"
+	CRIConfigDoc.Comments[encoder.LineComment] = "CRIConfig struct configures the CRI containerd plugin."
+	CRIConfigDoc.Description = "CRIConfig struct configures the CRI containerd plugin."
+
+	CRIConfigDoc.AddExample("", machineCRIExample)
+	CRIConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "cri",
+		},
+	}
+	CRIConfigDoc.Fields = make([]encoder.Doc, 6)
+	CRIConfigDoc.Fields[0].Name = "sandboxImage"
+	CRIConfigDoc.Fields[0].Type = "string"
+	CRIConfigDoc.Fields[0].Note = ""
+	CRIConfigDoc.Fields[0].Description = "Image used for the pod sandbox (pause) containers, defaults to the CRI containerd plugin default.\n\nThe image is pulled via the registry mirrors and auth settings from `.machine.registries`,\nso it can point to the internal registry in the air-gapped environments."
+	CRIConfigDoc.Fields[0].Comments[encoder.LineComment] = "Image used for the pod sandbox (pause) containers, defaults to the CRI containerd plugin default."
+	CRIConfigDoc.Fields[1].Name = "streamServerAddress"
+	CRIConfigDoc.Fields[1].Type = "string"
+	CRIConfigDoc.Fields[1].Note = ""
+	CRIConfigDoc.Fields[1].Description = "Address the CRI streaming server (`kubectl exec`, `attach` and `port-forward`) listens on, defaults to `127.0.0.1`.\n\nKubelet proxies the streaming requests, so the streaming server doesn't need to be reachable from the other hosts."
+	CRIConfigDoc.Fields[1].Comments[encoder.LineComment] = "Address the CRI streaming server (`kubectl exec`, `attach` and `port-forward`) listens on, defaults to `127.0.0.1`."
+	CRIConfigDoc.Fields[2].Name = "streamServerPort"
+	CRIConfigDoc.Fields[2].Type = "int"
+	CRIConfigDoc.Fields[2].Note = ""
+	CRIConfigDoc.Fields[2].Description = "Port the CRI streaming server listens on, defaults to a random port.\n\nFixed port allows to set up the strict host firewall policies."
+	CRIConfigDoc.Fields[2].Comments[encoder.LineComment] = "Port the CRI streaming server listens on, defaults to a random port."
+	CRIConfigDoc.Fields[3].Name = "streamIdleTimeout"
+	CRIConfigDoc.Fields[3].Type = "Duration"
+	CRIConfigDoc.Fields[3].Note = ""
+	CRIConfigDoc.Fields[3].Description = "Idle timeout of the CRI streaming connections, defaults to 4 hours."
+	CRIConfigDoc.Fields[3].Comments[encoder.LineComment] = "Idle timeout of the CRI streaming connections, defaults to 4 hours."
+	CRIConfigDoc.Fields[4].Name = "hostPathPolicy"
+	CRIConfigDoc.Fields[4].Type = "HostPathPolicyConfig"
+	CRIConfigDoc.Fields[4].Note = ""
+	CRIConfigDoc.Fields[4].Description = "Restricts the host paths which can be mounted into the containers.\n\nThe policy is enforced by the container runtime for every container created via the CRI,\nso it protects the Talos system paths even if the Kubernetes admission is misconfigured.\nPolicy is not enforced if not set."
+	CRIConfigDoc.Fields[4].Comments[encoder.LineComment] = "Restricts the host paths which can be mounted into the containers."
+
+	CRIConfigDoc.Fields[4].AddExample("", machineCRIHostPathPolicyExample)
+	CRIConfigDoc.Fields[5].Name = "imagePolicy"
+	CRIConfigDoc.Fields[5].Type = "ImagePolicyConfig"
+	CRIConfigDoc.Fields[5].Note = ""
+	CRIConfigDoc.Fields[5].Description = "Restricts the images which can be run in the containers.\n\nThe policy is enforced by the container runtime for every container created via the CRI,\nindependent of the Kubernetes admission.\nPolicy is not enforced if not set."
+	CRIConfigDoc.Fields[5].Comments[encoder.LineComment] = "Restricts the images which can be run in the containers."
+
+	CRIConfigDoc.Fields[5].AddExample("", machineCRIImagePolicyExample)
+
+	HostPathPolicyConfigDoc.Type = "HostPathPolicyConfig"
+	HostPathPolicyConfigDoc.Comments[encoder.LineComment] = "HostPathPolicyConfig struct describes the host paths allowed to be mounted into the CRI containers."
+	HostPathPolicyConfigDoc.Description = "HostPathPolicyConfig struct describes the host paths allowed to be mounted into the CRI containers."
+
+	HostPathPolicyConfigDoc.AddExample("", machineCRIHostPathPolicyExample)
+	HostPathPolicyConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "CRIConfig",
+			FieldName: "hostPathPolicy",
+		},
+	}
+	HostPathPolicyConfigDoc.Fields = make([]encoder.Doc, 2)
+	HostPathPolicyConfigDoc.Fields[0].Name = "allowedPaths"
+	HostPathPolicyConfigDoc.Fields[0].Type = "[]string"
+	HostPathPolicyConfigDoc.Fields[0].Note = ""
+	HostPathPolicyConfigDoc.Fields[0].Description = "List of the host paths (with all the nested paths) allowed to be mounted into the containers.\n\nPaths managed by the kubelet and CRI (pod volumes, `/etc/hosts`, `/etc/resolv.conf`), the secrets\nof the control plane components and the paths mounted by the default kube-proxy and flannel manifests\nare always allowed.\nThe paths of `extraVolumes` of the control plane components and the paths mounted by the custom CNI\nshould be added to the list."
+	HostPathPolicyConfigDoc.Fields[0].Comments[encoder.LineComment] = "List of the host paths (with all the nested paths) allowed to be mounted into the containers."
+	HostPathPolicyConfigDoc.Fields[1].Name = "allowBidirectionalPropagation"
+	HostPathPolicyConfigDoc.Fields[1].Type = "bool"
+	HostPathPolicyConfigDoc.Fields[1].Note = ""
+	HostPathPolicyConfigDoc.Fields[1].Description = "Allow the `Bidirectional` mount propagation (the mounts created in the container propagate to the host).\n\nThe mounts with the `Bidirectional` propagation are rejected by default."
+	HostPathPolicyConfigDoc.Fields[1].Comments[encoder.LineComment] = "Allow the `Bidirectional` mount propagation (the mounts created in the container propagate to the host)."
+
+	ImagePolicyConfigDoc.Type = "ImagePolicyConfig"
+	ImagePolicyConfigDoc.Comments[encoder.LineComment] = "ImagePolicyConfig struct describes the images allowed to be run in the CRI containers."
+	ImagePolicyConfigDoc.Description = "ImagePolicyConfig struct describes the images allowed to be run in the CRI containers."
+
+	ImagePolicyConfigDoc.AddExample("", machineCRIImagePolicyExample)
+	ImagePolicyConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "CRIConfig",
+			FieldName: "imagePolicy",
+		},
+	}
+	ImagePolicyConfigDoc.Fields = make([]encoder.Doc, 3)
+	ImagePolicyConfigDoc.Fields[0].Name = "allowed"
+	ImagePolicyConfigDoc.Fields[0].Type = "[]string"
+	ImagePolicyConfigDoc.Fields[0].Note = ""
+	ImagePolicyConfigDoc.Fields[0].Description = "List of the image references allowed to be run, all the images are allowed if empty.\n\nImages are matched by the normalized reference (e.g. `docker.io/library/nginx:1.21`),\neach entry matches the reference itself and all the references nested under it:\n`docker.io/library` matches `docker.io/library/nginx:1.21`, `docker.io/library/nginx` matches\n`docker.io/library/nginx@sha256:...`, but not `docker.io/library/nginx-unstable`.\n\nImages of the control plane components and the bootstrap manifests should be allowed as well."
+	ImagePolicyConfigDoc.Fields[0].Comments[encoder.LineComment] = "List of the image references allowed to be run, all the images are allowed if empty."
+	ImagePolicyConfigDoc.Fields[1].Name = "denied"
+	ImagePolicyConfigDoc.Fields[1].Type = "[]string"
+	ImagePolicyConfigDoc.Fields[1].Note = ""
+	ImagePolicyConfigDoc.Fields[1].Description = "List of the image references denied to be run, even if they match `allowed`."
+	ImagePolicyConfigDoc.Fields[1].Comments[encoder.LineComment] = "List of the image references denied to be run, even if they match `allowed`."
+	ImagePolicyConfigDoc.Fields[2].Name = "requireDigest"
+	ImagePolicyConfigDoc.Fields[2].Type = "bool"
+	ImagePolicyConfigDoc.Fields[2].Note = ""
+	ImagePolicyConfigDoc.Fields[2].Description = "Require the images to be pinned by digest (`registry.example.com/app@sha256:...`)."
+	ImagePolicyConfigDoc.Fields[2].Comments[encoder.LineComment] = "Require the images to be pinned by digest (`registry.example.com/app@sha256:...`)."
+
+	SecretsResolverConfigDoc.Type = "SecretsResolverConfig"
+	SecretsResolverConfigDoc.Comments[encoder.LineComment] = "SecretsResolverConfig struct configures the external secrets backends."
+	SecretsResolverConfigDoc.Description = "SecretsResolverConfig struct configures the external secrets backends."
+
+	SecretsResolverConfigDoc.AddExample("", machineSecretsResolverExample)
+	SecretsResolverConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "secretsResolver",
+		},
+	}
+	SecretsResolverConfigDoc.Fields = make([]encoder.Doc, 4)
+	SecretsResolverConfigDoc.Fields[0].Name = "backends"
+	SecretsResolverConfigDoc.Fields[0].Type = "[]SecretsBackendConfig"
+	SecretsResolverConfigDoc.Fields[0].Note = ""
+	SecretsResolverConfigDoc.Fields[0].Description = "List of the secrets backends, references select the backend by name."
+	SecretsResolverConfigDoc.Fields[0].Comments[encoder.LineComment] = "List of the secrets backends, references select the backend by name."
+	SecretsResolverConfigDoc.Fields[1].Name = "cacheTTL"
+	SecretsResolverConfigDoc.Fields[1].Type = "Duration"
+	SecretsResolverConfigDoc.Fields[1].Note = ""
+	SecretsResolverConfigDoc.Fields[1].Description = "Duration the resolved values are reused for without querying the backend, defaults to 5 minutes."
+	SecretsResolverConfigDoc.Fields[1].Comments[encoder.LineComment] = "Duration the resolved values are reused for without querying the backend, defaults to 5 minutes."
+	SecretsResolverConfigDoc.Fields[2].Name = "timeout"
+	SecretsResolverConfigDoc.Fields[2].Type = "Duration"
+	SecretsResolverConfigDoc.Fields[2].Note = ""
+	SecretsResolverConfigDoc.Fields[2].Description = "Timeout of the secret reference resolution (with retries), defaults to 1 minute."
+	SecretsResolverConfigDoc.Fields[2].Comments[encoder.LineComment] = "Timeout of the secret reference resolution (with retries), defaults to 1 minute."
+	SecretsResolverConfigDoc.Fields[3].Name = "failurePolicy"
+	SecretsResolverConfigDoc.Fields[3].Type = "string"
+	SecretsResolverConfigDoc.Fields[3].Note = ""
+	SecretsResolverConfigDoc.Fields[3].Description = "Action taken if the secret reference can't be resolved, defaults to `fail`.\n\n`fail` fails the config load, `stale` falls back to the previously resolved value (even if the cache TTL expired).\nPreviously resolved values are kept in memory only, so `stale` doesn't help on boot."
+	SecretsResolverConfigDoc.Fields[3].Comments[encoder.LineComment] = "Action taken if the secret reference can't be resolved, defaults to `fail`."
+	SecretsResolverConfigDoc.Fields[3].Values = []string{
+		"fail",
+		"stale",
+	}
+
+	SecretsBackendConfigDoc.Type = "SecretsBackendConfig"
+	SecretsBackendConfigDoc.Comments[encoder.LineComment] = "SecretsBackendConfig struct configures an external secrets backend."
+	SecretsBackendConfigDoc.Description = "SecretsBackendConfig struct configures an external secrets backend.\n\nExactly one of the backend types should be set.\n"
+	SecretsBackendConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "SecretsResolverConfig",
+			FieldName: "backends",
+		},
+	}
+	SecretsBackendConfigDoc.Fields = make([]encoder.Doc, 4)
+	SecretsBackendConfigDoc.Fields[0].Name = "name"
+	SecretsBackendConfigDoc.Fields[0].Type = "string"
+	SecretsBackendConfigDoc.Fields[0].Note = ""
+	SecretsBackendConfigDoc.Fields[0].Description = "Name of the backend used in the secret references."
+	SecretsBackendConfigDoc.Fields[0].Comments[encoder.LineComment] = "Name of the backend used in the secret references."
+	SecretsBackendConfigDoc.Fields[1].Name = "vault"
+	SecretsBackendConfigDoc.Fields[1].Type = "VaultSecretsBackendConfig"
+	SecretsBackendConfigDoc.Fields[1].Note = ""
+	SecretsBackendConfigDoc.Fields[1].Description = "HashiCorp Vault KV (version 2) secrets engine.\n\nReferences have the form `secret://<backend>/<path>[#<key>]`,\nthe key can be omitted if the secret has a single key."
+	SecretsBackendConfigDoc.Fields[1].Comments[encoder.LineComment] = "HashiCorp Vault KV (version 2) secrets engine."
+	SecretsBackendConfigDoc.Fields[2].Name = "awsSecretsManager"
+	SecretsBackendConfigDoc.Fields[2].Type = "AWSSecretsManagerBackendConfig"
+	SecretsBackendConfigDoc.Fields[2].Note = ""
+	SecretsBackendConfigDoc.Fields[2].Description = "AWS Secrets Manager, the instance profile credentials are used.\n\nReferences have the form `secret://<backend>/<secret name or ARN>[#<key>]`,\nthe key selects the field of the secret stored as JSON object."
+	SecretsBackendConfigDoc.Fields[2].Comments[encoder.LineComment] = "AWS Secrets Manager, the instance profile credentials are used."
+	SecretsBackendConfigDoc.Fields[3].Name = "gcpSecretManager"
+	SecretsBackendConfigDoc.Fields[3].Type = "GCPSecretManagerBackendConfig"
+	SecretsBackendConfigDoc.Fields[3].Note = ""
+	SecretsBackendConfigDoc.Fields[3].Description = "Google Cloud Secret Manager, the instance service account credentials are used.\n\nReferences have the form `secret://<backend>/<secret>[/versions/<version>][#<key>]`,\nthe key selects the field of the secret stored as JSON object."
+	SecretsBackendConfigDoc.Fields[3].Comments[encoder.LineComment] = "Google Cloud Secret Manager, the instance service account credentials are used."
+
+	VaultSecretsBackendConfigDoc.Type = "VaultSecretsBackendConfig"
+	VaultSecretsBackendConfigDoc.Comments[encoder.LineComment] = "VaultSecretsBackendConfig struct configures the HashiCorp Vault secrets backend."
+	VaultSecretsBackendConfigDoc.Description = "VaultSecretsBackendConfig struct configures the HashiCorp Vault secrets backend."
+	VaultSecretsBackendConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "SecretsBackendConfig",
+			FieldName: "vault",
+		},
+	}
+	VaultSecretsBackendConfigDoc.Fields = make([]encoder.Doc, 6)
+	VaultSecretsBackendConfigDoc.Fields[0].Name = "endpoint"
+	VaultSecretsBackendConfigDoc.Fields[0].Type = "string"
+	VaultSecretsBackendConfigDoc.Fields[0].Note = ""
+	VaultSecretsBackendConfigDoc.Fields[0].Description = "Vault server URL."
+	VaultSecretsBackendConfigDoc.Fields[0].Comments[encoder.LineComment] = "Vault server URL."
+	VaultSecretsBackendConfigDoc.Fields[1].Name = "mount"
+	VaultSecretsBackendConfigDoc.Fields[1].Type = "string"
+	VaultSecretsBackendConfigDoc.Fields[1].Note = ""
+	VaultSecretsBackendConfigDoc.Fields[1].Description = "Mount path of the KV secrets engine, defaults to `secret`."
+	VaultSecretsBackendConfigDoc.Fields[1].Comments[encoder.LineComment] = "Mount path of the KV secrets engine, defaults to `secret`."
+	VaultSecretsBackendConfigDoc.Fields[2].Name = "authMethod"
+	VaultSecretsBackendConfigDoc.Fields[2].Type = "string"
+	VaultSecretsBackendConfigDoc.Fields[2].Note = ""
+	VaultSecretsBackendConfigDoc.Fields[2].Description = "Vault auth method.\n\nTalos authenticates with the cloud platform identity of the machine, so no Vault tokens are stored in the machine config:\n`aws` uses the IAM auth type with the instance profile credentials,\n`gcp` uses the GCE auth type with the instance identity token."
+	VaultSecretsBackendConfigDoc.Fields[2].Comments[encoder.LineComment] = "Vault auth method."
+	VaultSecretsBackendConfigDoc.Fields[2].Values = []string{
+		"aws",
+		"gcp",
+	}
+	VaultSecretsBackendConfigDoc.Fields[3].Name = "authMount"
+	VaultSecretsBackendConfigDoc.Fields[3].Type = "string"
+	VaultSecretsBackendConfigDoc.Fields[3].Note = ""
+	VaultSecretsBackendConfigDoc.Fields[3].Description = "Mount path of the auth method, defaults to the auth method name."
+	VaultSecretsBackendConfigDoc.Fields[3].Comments[encoder.LineComment] = "Mount path of the auth method, defaults to the auth method name."
+	VaultSecretsBackendConfigDoc.Fields[4].Name = "role"
+	VaultSecretsBackendConfigDoc.Fields[4].Type = "string"
+	VaultSecretsBackendConfigDoc.Fields[4].Note = ""
+	VaultSecretsBackendConfigDoc.Fields[4].Description = "Vault role to log in with."
+	VaultSecretsBackendConfigDoc.Fields[4].Comments[encoder.LineComment] = "Vault role to log in with."
+	VaultSecretsBackendConfigDoc.Fields[5].Name = "ca"
+	VaultSecretsBackendConfigDoc.Fields[5].Type = "Base64Bytes"
+	VaultSecretsBackendConfigDoc.Fields[5].Note = ""
+	VaultSecretsBackendConfigDoc.Fields[5].Description = "PEM-encoded CA certificate used to verify the Vault server certificate, defaults to the system CA bundle."
+	VaultSecretsBackendConfigDoc.Fields[5].Comments[encoder.LineComment] = "PEM-encoded CA certificate used to verify the Vault server certificate, defaults to the system CA bundle."
+
+	AWSSecretsManagerBackendConfigDoc.Type = "AWSSecretsManagerBackendConfig"
+	AWSSecretsManagerBackendConfigDoc.Comments[encoder.LineComment] = "AWSSecretsManagerBackendConfig struct configures the AWS Secrets Manager secrets backend."
+	AWSSecretsManagerBackendConfigDoc.Description = "AWSSecretsManagerBackendConfig struct configures the AWS Secrets Manager secrets backend."
+	AWSSecretsManagerBackendConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "SecretsBackendConfig",
+			FieldName: "awsSecretsManager",
+		},
+	}
+	AWSSecretsManagerBackendConfigDoc.Fields = make([]encoder.Doc, 1)
+	AWSSecretsManagerBackendConfigDoc.Fields[0].Name = "region"
+	AWSSecretsManagerBackendConfigDoc.Fields[0].Type = "string"
+	AWSSecretsManagerBackendConfigDoc.Fields[0].Note = ""
+	AWSSecretsManagerBackendConfigDoc.Fields[0].Description = "AWS region."
+	AWSSecretsManagerBackendConfigDoc.Fields[0].Comments[encoder.LineComment] = "AWS region."
+
+	GCPSecretManagerBackendConfigDoc.Type = "GCPSecretManagerBackendConfig"
+	GCPSecretManagerBackendConfigDoc.Comments[encoder.LineComment] = "GCPSecretManagerBackendConfig struct configures the Google Cloud Secret Manager secrets backend."
+	GCPSecretManagerBackendConfigDoc.Description = "GCPSecretManagerBackendConfig struct configures the Google Cloud Secret Manager secrets backend."
+	GCPSecretManagerBackendConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "SecretsBackendConfig",
+			FieldName: "gcpSecretManager",
+		},
+	}
+	GCPSecretManagerBackendConfigDoc.Fields = make([]encoder.Doc, 1)
+	GCPSecretManagerBackendConfigDoc.Fields[0].Name = "project"
+	GCPSecretManagerBackendConfigDoc.Fields[0].Type = "string"
+	GCPSecretManagerBackendConfigDoc.Fields[0].Note = ""
+	GCPSecretManagerBackendConfigDoc.Fields[0].Description = "Google Cloud project ID or number."
+	GCPSecretManagerBackendConfigDoc.Fields[0].Comments[encoder.LineComment] = "Google Cloud project ID or number."
+
+	NodeLabelRuleConfigDoc.Type = "NodeLabelRuleConfig"
+	NodeLabelRuleConfigDoc.Comments[encoder.LineComment] = "NodeLabelRuleConfig struct configures a node label applied based on the detected hardware."
+	NodeLabelRuleConfigDoc.Description = "NodeLabelRuleConfig struct configures a node label applied based on the detected hardware."
+
+	NodeLabelRuleConfigDoc.AddExample("", machineNodeLabelRulesExample)
+	NodeLabelRuleConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "nodeLabelRules",
+		},
+	}
+	NodeLabelRuleConfigDoc.Fields = make([]encoder.Doc, 3)
+	NodeLabelRuleConfigDoc.Fields[0].Name = "label"
+	NodeLabelRuleConfigDoc.Fields[0].Type = "string"
+	NodeLabelRuleConfigDoc.Fields[0].Note = ""
+	NodeLabelRuleConfigDoc.Fields[0].Description = "Kubernetes node label key."
+	NodeLabelRuleConfigDoc.Fields[0].Comments[encoder.LineComment] = "Kubernetes node label key."
+	NodeLabelRuleConfigDoc.Fields[1].Name = "value"
+	NodeLabelRuleConfigDoc.Fields[1].Type = "string"
+	NodeLabelRuleConfigDoc.Fields[1].Note = ""
+	NodeLabelRuleConfigDoc.Fields[1].Description = "Kubernetes node label value, defaults to `true`."
+	NodeLabelRuleConfigDoc.Fields[1].Comments[encoder.LineComment] = "Kubernetes node label value, defaults to `true`."
+	NodeLabelRuleConfigDoc.Fields[2].Name = "match"
+	NodeLabelRuleConfigDoc.Fields[2].Type = "NodeLabelMatchConfig"
+	NodeLabelRuleConfigDoc.Fields[2].Note = ""
+	NodeLabelRuleConfigDoc.Fields[2].Description = "Hardware the node should have for the label to be applied, all the conditions should match."
+	NodeLabelRuleConfigDoc.Fields[2].Comments[encoder.LineComment] = "Hardware the node should have for the label to be applied, all the conditions should match."
+
+	NodeLabelMatchConfigDoc.Type = "NodeLabelMatchConfig"
+	NodeLabelMatchConfigDoc.Comments[encoder.LineComment] = "NodeLabelMatchConfig struct describes the hardware conditions of a node label rule."
+	NodeLabelMatchConfigDoc.Description = "NodeLabelMatchConfig struct describes the hardware conditions of a node label rule."
+	NodeLabelMatchConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "NodeLabelRuleConfig",
+			FieldName: "match",
+		},
+	}
+	NodeLabelMatchConfigDoc.Fields = make([]encoder.Doc, 6)
+	NodeLabelMatchConfigDoc.Fields[0].Name = "cpuVendor"
+	NodeLabelMatchConfigDoc.Fields[0].Type = "string"
+	NodeLabelMatchConfigDoc.Fields[0].Note = ""
+	NodeLabelMatchConfigDoc.Fields[0].Description = "CPU vendor ID as reported in `/proc/cpuinfo`."
+	NodeLabelMatchConfigDoc.Fields[0].Comments[encoder.LineComment] = "CPU vendor ID as reported in `/proc/cpuinfo`."
+	NodeLabelMatchConfigDoc.Fields[0].Values = []string{
+		"GenuineIntel",
+		"AuthenticAMD",
+	}
+	NodeLabelMatchConfigDoc.Fields[1].Name = "cpuFlags"
+	NodeLabelMatchConfigDoc.Fields[1].Type = "[]string"
+	NodeLabelMatchConfigDoc.Fields[1].Note = ""
+	NodeLabelMatchConfigDoc.Fields[1].Description = "CPU flags as reported in `/proc/cpuinfo`, all of them should be supported."
+	NodeLabelMatchConfigDoc.Fields[1].Comments[encoder.LineComment] = "CPU flags as reported in `/proc/cpuinfo`, all of them should be supported."
+	NodeLabelMatchConfigDoc.Fields[2].Name = "gpuVendor"
+	NodeLabelMatchConfigDoc.Fields[2].Type = "string"
+	NodeLabelMatchConfigDoc.Fields[2].Note = ""
+	NodeLabelMatchConfigDoc.Fields[2].Description = "Vendor name of any of the node GPUs."
+	NodeLabelMatchConfigDoc.Fields[2].Comments[encoder.LineComment] = "Vendor name of any of the node GPUs."
+	NodeLabelMatchConfigDoc.Fields[2].Values = []string{
+		"NVIDIA",
+		"AMD",
+		"Intel",
+	}
+	NodeLabelMatchConfigDoc.Fields[3].Name = "gpuModel"
+	NodeLabelMatchConfigDoc.Fields[3].Type = "string"
+	NodeLabelMatchConfigDoc.Fields[3].Note = ""
+	NodeLabelMatchConfigDoc.Fields[3].Description = "Model of any of the node GPUs as PCI vendor and device IDs (as in `lspci -nn`)."
+	NodeLabelMatchConfigDoc.Fields[3].Comments[encoder.LineComment] = "Model of any of the node GPUs as PCI vendor and device IDs (as in `lspci -nn`)."
+	NodeLabelMatchConfigDoc.Fields[4].Name = "diskClass"
+	NodeLabelMatchConfigDoc.Fields[4].Type = "string"
+	NodeLabelMatchConfigDoc.Fields[4].Note = ""
+	NodeLabelMatchConfigDoc.Fields[4].Description = "Class of any of the node disks."
+	NodeLabelMatchConfigDoc.Fields[4].Comments[encoder.LineComment] = "Class of any of the node disks."
+	NodeLabelMatchConfigDoc.Fields[4].Values = []string{
+		"nvme",
+		"ssd",
+		"hdd",
+	}
+	NodeLabelMatchConfigDoc.Fields[5].Name = "minNICSpeed"
+	NodeLabelMatchConfigDoc.Fields[5].Type = "int"
+	NodeLabelMatchConfigDoc.Fields[5].Note = ""
+	NodeLabelMatchConfigDoc.Fields[5].Description = "Minimum link speed (in Mbit/s) of any of the node physical network interfaces."
+	NodeLabelMatchConfigDoc.Fields[5].Comments[encoder.LineComment] = "Minimum link speed (in Mbit/s) of any of the node physical network interfaces."
+
+	HealthCheckConfigDoc.Type = "HealthCheckConfig"
+	HealthCheckConfigDoc.Comments[encoder.LineComment] = "HealthCheckConfig struct configures a user-defined node health check."
+	HealthCheckConfigDoc.Description = "HealthCheckConfig struct configures a user-defined node health check."
+
+	HealthCheckConfigDoc.AddExample("", machineHealthChecksExample)
+	HealthCheckConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "healthChecks",
+		},
+	}
+	HealthCheckConfigDoc.Fields = make([]encoder.Doc, 8)
+	HealthCheckConfigDoc.Fields[0].Name = "name"
+	HealthCheckConfigDoc.Fields[0].Type = "string"
+	HealthCheckConfigDoc.Fields[0].Note = ""
+	HealthCheckConfigDoc.Fields[0].Description = "Health check name, should be a valid DNS label."
+	HealthCheckConfigDoc.Fields[0].Comments[encoder.LineComment] = "Health check name, should be a valid DNS label."
+	HealthCheckConfigDoc.Fields[1].Name = "http"
+	HealthCheckConfigDoc.Fields[1].Type = "HealthCheckHTTPConfig"
+	HealthCheckConfigDoc.Fields[1].Note = ""
+	HealthCheckConfigDoc.Fields[1].Description = "HTTP health check: succeeds if the endpoint responds with the expected status code."
+	HealthCheckConfigDoc.Fields[1].Comments[encoder.LineComment] = "HTTP health check: succeeds if the endpoint responds with the expected status code."
+	HealthCheckConfigDoc.Fields[2].Name = "tcp"
+	HealthCheckConfigDoc.Fields[2].Type = "HealthCheckTCPConfig"
+	HealthCheckConfigDoc.Fields[2].Note = ""
+	HealthCheckConfigDoc.Fields[2].Description = "TCP health check: succeeds if the connection to the address can be established."
+	HealthCheckConfigDoc.Fields[2].Comments[encoder.LineComment] = "TCP health check: succeeds if the connection to the address can be established."
+	HealthCheckConfigDoc.Fields[3].Name = "exec"
+	HealthCheckConfigDoc.Fields[3].Type = "HealthCheckExecConfig"
+	HealthCheckConfigDoc.Fields[3].Note = ""
+	HealthCheckConfigDoc.Fields[3].Description = "Exec health check: succeeds if the command run in the container exits with zero code."
+	HealthCheckConfigDoc.Fields[3].Comments[encoder.LineComment] = "Exec health check: succeeds if the command run in the container exits with zero code."
+	HealthCheckConfigDoc.Fields[4].Name = "interval"
+	HealthCheckConfigDoc.Fields[4].Type = "Duration"
+	HealthCheckConfigDoc.Fields[4].Note = ""
+	HealthCheckConfigDoc.Fields[4].Description = "Interval between the checks, defaults to 30s."
+	HealthCheckConfigDoc.Fields[4].Comments[encoder.LineComment] = "Interval between the checks, defaults to 30s."
+	HealthCheckConfigDoc.Fields[5].Name = "timeout"
+	HealthCheckConfigDoc.Fields[5].Type = "Duration"
+	HealthCheckConfigDoc.Fields[5].Note = ""
+	HealthCheckConfigDoc.Fields[5].Description = "Timeout of a single check, defaults to 5s."
+	HealthCheckConfigDoc.Fields[5].Comments[encoder.LineComment] = "Timeout of a single check, defaults to 5s."
+	HealthCheckConfigDoc.Fields[6].Name = "failureThreshold"
+	HealthCheckConfigDoc.Fields[6].Type = "int"
+	HealthCheckConfigDoc.Fields[6].Note = ""
+	HealthCheckConfigDoc.Fields[6].Description = "Number of consecutive failures for the check to be reported as unhealthy, defaults to 3."
+	HealthCheckConfigDoc.Fields[6].Comments[encoder.LineComment] = "Number of consecutive failures for the check to be reported as unhealthy, defaults to 3."
+	HealthCheckConfigDoc.Fields[7].Name = "taint"
+	HealthCheckConfigDoc.Fields[7].Type = "bool"
+	HealthCheckConfigDoc.Fields[7].Note = ""
+	HealthCheckConfigDoc.Fields[7].Description = "Taint the Kubernetes node with `health.talos.dev/<name>:NoSchedule` while the check is unhealthy."
+	HealthCheckConfigDoc.Fields[7].Comments[encoder.LineComment] = "Taint the Kubernetes node with `health.talos.dev/<name>:NoSchedule` while the check is unhealthy."
+
+	HealthCheckHTTPConfigDoc.Type = "HealthCheckHTTPConfig"
+	HealthCheckHTTPConfigDoc.Comments[encoder.LineComment] = "HealthCheckHTTPConfig struct configures an HTTP health check."
+	HealthCheckHTTPConfigDoc.Description = "HealthCheckHTTPConfig struct configures an HTTP health check."
+	HealthCheckHTTPConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "HealthCheckConfig",
+			FieldName: "http",
+		},
+	}
+	HealthCheckHTTPConfigDoc.Fields = make([]encoder.Doc, 2)
+	HealthCheckHTTPConfigDoc.Fields[0].Name = "url"
+	HealthCheckHTTPConfigDoc.Fields[0].Type = "string"
+	HealthCheckHTTPConfigDoc.Fields[0].Note = ""
+	HealthCheckHTTPConfigDoc.Fields[0].Description = "URL to send the GET request to."
+	HealthCheckHTTPConfigDoc.Fields[0].Comments[encoder.LineComment] = "URL to send the GET request to."
+	HealthCheckHTTPConfigDoc.Fields[1].Name = "expectedStatus"
+	HealthCheckHTTPConfigDoc.Fields[1].Type = "int"
+	HealthCheckHTTPConfigDoc.Fields[1].Note = ""
+	HealthCheckHTTPConfigDoc.Fields[1].Description = "Expected response status code, by default any 2xx or 3xx code is accepted."
+	HealthCheckHTTPConfigDoc.Fields[1].Comments[encoder.LineComment] = "Expected response status code, by default any 2xx or 3xx code is accepted."
+
+	HealthCheckTCPConfigDoc.Type = "HealthCheckTCPConfig"
+	HealthCheckTCPConfigDoc.Comments[encoder.LineComment] = "HealthCheckTCPConfig struct configures a TCP health check."
+	HealthCheckTCPConfigDoc.Description = "HealthCheckTCPConfig struct configures a TCP health check."
+	HealthCheckTCPConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "HealthCheckConfig",
+			FieldName: "tcp",
+		},
+	}
+	HealthCheckTCPConfigDoc.Fields = make([]encoder.Doc, 1)
+	HealthCheckTCPConfigDoc.Fields[0].Name = "address"
+	HealthCheckTCPConfigDoc.Fields[0].Type = "string"
+	HealthCheckTCPConfigDoc.Fields[0].Note = ""
+	HealthCheckTCPConfigDoc.Fields[0].Description = "Address to connect to as `host:port`."
+	HealthCheckTCPConfigDoc.Fields[0].Comments[encoder.LineComment] = "Address to connect to as `host:port`."
+
+	HealthCheckExecConfigDoc.Type = "HealthCheckExecConfig"
+	HealthCheckExecConfigDoc.Comments[encoder.LineComment] = "HealthCheckExecConfig struct configures an exec health check."
+	HealthCheckExecConfigDoc.Description = "HealthCheckExecConfig struct configures an exec health check."
+	HealthCheckExecConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "HealthCheckConfig",
+			FieldName: "exec",
+		},
+	}
+	HealthCheckExecConfigDoc.Fields = make([]encoder.Doc, 3)
+	HealthCheckExecConfigDoc.Fields[0].Name = "kubernetes"
+	HealthCheckExecConfigDoc.Fields[0].Type = "bool"
+	HealthCheckExecConfigDoc.Fields[0].Note = ""
+	HealthCheckExecConfigDoc.Fields[0].Description = "Use the Kubernetes (CRI) containerd namespace to look up the container."
+	HealthCheckExecConfigDoc.Fields[0].Comments[encoder.LineComment] = "Use the Kubernetes (CRI) containerd namespace to look up the container."
+	HealthCheckExecConfigDoc.Fields[1].Name = "container"
+	HealthCheckExecConfigDoc.Fields[1].Type = "string"
+	HealthCheckExecConfigDoc.Fields[1].Note = ""
+	HealthCheckExecConfigDoc.Fields[1].Description = "Container ID as in `talosctl containers`."
+	HealthCheckExecConfigDoc.Fields[1].Comments[encoder.LineComment] = "Container ID as in `talosctl containers`."
+	HealthCheckExecConfigDoc.Fields[2].Name = "command"
+	HealthCheckExecConfigDoc.Fields[2].Type = "[]string"
+	HealthCheckExecConfigDoc.Fields[2].Note = ""
+	HealthCheckExecConfigDoc.Fields[2].Description = "Command to run in the container."
+	HealthCheckExecConfigDoc.Fields[2].Comments[encoder.LineComment] = "Command to run in the container."
+
+	KernelConfigDoc.Type = "KernelConfig"
+	KernelConfigDoc.Comments[encoder.LineComment] = "KernelConfig struct configures the kernel runtime settings."
+	KernelConfigDoc.Description = "KernelConfig struct configures the kernel runtime settings."
+
+	KernelConfigDoc.AddExample("", machineKernelExample)
+	KernelConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "kernel",
+		},
+	}
+	KernelConfigDoc.Fields = make([]encoder.Doc, 3)
+	KernelConfigDoc.Fields[0].Name = "cpuFrequencyGovernor"
+	KernelConfigDoc.Fields[0].Type = "string"
+	KernelConfigDoc.Fields[0].Note = ""
+	KernelConfigDoc.Fields[0].Description = "CPU frequency scaling governor applied to all CPUs.\n\nIf not set, the kernel default governor is used."
+	KernelConfigDoc.Fields[0].Comments[encoder.LineComment] = "CPU frequency scaling governor applied to all CPUs."
+	KernelConfigDoc.Fields[0].Values = []string{
+		"performance",
+		"powersave",
+		"schedutil",
+	}
+	KernelConfigDoc.Fields[1].Name = "maxCState"
+	KernelConfigDoc.Fields[1].Type = "int"
+	KernelConfigDoc.Fields[1].Note = ""
+	KernelConfigDoc.Fields[1].Description = "Deepest CPU idle state allowed, deeper idle states are disabled on all CPUs.\n\nIdle states are numbered as in `/sys/devices/system/cpu/cpu*/cpuidle/state*`: state 0 is polling, state 1 is usually C1.\nLimiting the idle states reduces the wakeup latency at the cost of the power consumption."
+	KernelConfigDoc.Fields[1].Comments[encoder.LineComment] = "Deepest CPU idle state allowed, deeper idle states are disabled on all CPUs."
+	KernelConfigDoc.Fields[2].Name = "realtime"
+	KernelConfigDoc.Fields[2].Type = "RealtimeProfileConfig"
+	KernelConfigDoc.Fields[2].Note = ""
+	KernelConfigDoc.Fields[2].Description = "Real-time tuning profile: isolates the CPUs for the latency-sensitive workloads.\n\nKernel arguments are applied on install and upgrade, IRQ affinity and the kubelet reserved CPUs are applied at boot."
+	KernelConfigDoc.Fields[2].Comments[encoder.LineComment] = "Real-time tuning profile: isolates the CPUs for the latency-sensitive workloads."
+
+	KernelConfigDoc.Fields[2].AddExample("", kernelRealtimeExample)
+
+	RealtimeProfileConfigDoc.Type = "RealtimeProfileConfig"
+	RealtimeProfileConfigDoc.Comments[encoder.LineComment] = "RealtimeProfileConfig struct configures the real-time tuning profile."
+	RealtimeProfileConfigDoc.Description = "RealtimeProfileConfig struct configures the real-time tuning profile."
+
+	RealtimeProfileConfigDoc.AddExample("", kernelRealtimeExample)
+	RealtimeProfileConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "KernelConfig",
+			FieldName: "realtime",
+		},
+	}
+	RealtimeProfileConfigDoc.Fields = make([]encoder.Doc, 2)
+	RealtimeProfileConfigDoc.Fields[0].Name = "isolatedCPUs"
+	RealtimeProfileConfigDoc.Fields[0].Type = "string"
+	RealtimeProfileConfigDoc.Fields[0].Note = ""
+	RealtimeProfileConfigDoc.Fields[0].Description = "CPUs dedicated to the latency-sensitive workloads (in the kernel CPU list format).\n\nPassed to the kernel as `isolcpus`, `nohz_full` and `rcu_nocbs` arguments."
+	RealtimeProfileConfigDoc.Fields[0].Comments[encoder.LineComment] = "CPUs dedicated to the latency-sensitive workloads (in the kernel CPU list format)."
+	RealtimeProfileConfigDoc.Fields[1].Name = "housekeepingCPUs"
+	RealtimeProfileConfigDoc.Fields[1].Type = "string"
+	RealtimeProfileConfigDoc.Fields[1].Note = ""
+	RealtimeProfileConfigDoc.Fields[1].Description = "CPUs running the system services, kernel threads and IRQs (in the kernel CPU list format).\n\nIRQ affinity is steered to these CPUs, and they are passed to the kubelet as `reservedSystemCPUs`.\nShould not overlap with `isolatedCPUs`."
+	RealtimeProfileConfigDoc.Fields[1].Comments[encoder.LineComment] = "CPUs running the system services, kernel threads and IRQs (in the kernel CPU list format)."
+
+	HugePageConfigDoc.Type = "HugePageConfig"
+	HugePageConfigDoc.Comments[encoder.LineComment] = "HugePageConfig struct configures a hugepage reservation."
+	HugePageConfigDoc.Description = "HugePageConfig struct configures a hugepage reservation."
+
+	HugePageConfigDoc.AddExample("", machineHugePagesExample)
+	HugePageConfigDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "MachineConfig",
+			FieldName: "hugePages",
+		},
+	}
+	HugePageConfigDoc.Fields = make([]encoder.Doc, 4)
+	HugePageConfigDoc.Fields[0].Name = "size"
+	HugePageConfigDoc.Fields[0].Type = "string"
+	HugePageConfigDoc.Fields[0].Note = ""
+	HugePageConfigDoc.Fields[0].Description = "Hugepage size, should be supported by the CPU."
+	HugePageConfigDoc.Fields[0].Comments[encoder.LineComment] = "Hugepage size, should be supported by the CPU."
+	HugePageConfigDoc.Fields[0].Values = []string{
+		"2Mi",
+		"1Gi",
+	}
+	HugePageConfigDoc.Fields[1].Name = "count"
+	HugePageConfigDoc.Fields[1].Type = "int"
+	HugePageConfigDoc.Fields[1].Note = ""
+	HugePageConfigDoc.Fields[1].Description = "Number of hugepages to reserve."
+	HugePageConfigDoc.Fields[1].Comments[encoder.LineComment] = "Number of hugepages to reserve."
+	HugePageConfigDoc.Fields[2].Name = "numaNode"
+	HugePageConfigDoc.Fields[2].Type = "int"
+	HugePageConfigDoc.Fields[2].Note = ""
+	HugePageConfigDoc.Fields[2].Description = "NUMA node to reserve the hugepages on.\n\nIf not set, hugepages are spread across all NUMA nodes by the kernel."
+	HugePageConfigDoc.Fields[2].Comments[encoder.LineComment] = "NUMA node to reserve the hugepages on."
+	HugePageConfigDoc.Fields[3].Name = "systemReserved"
+	HugePageConfigDoc.Fields[3].Type = "int"
+	HugePageConfigDoc.Fields[3].Note = ""
+	HugePageConfigDoc.Fields[3].Description = "Number of reserved hugepages not available to the pods (used by the host services).\n\nRequires `numaNode` to be set, passed to the kubelet as `reservedMemory` if the kubelet\nstatic memory manager policy is enabled (`memory-manager-policy: Static` in the kubelet extra args)."
+	HugePageConfigDoc.Fields[3].Comments[encoder.LineComment] = "Number of reserved hugepages not available to the pods (used by the host services)."
 
 	LoggingDestinationDoc.Type = "LoggingDestination"
 	LoggingDestinationDoc.Comments[encoder.LineComment] = "LoggingDestination struct configures Talos logging destination."
@@ -2281,16 +4075,18 @@ func init() {
 			FieldName: "destinations",
 		},
 	}
-	LoggingDestinationDoc.Fields = make([]encoder.Doc, 2)
+	LoggingDestinationDoc.Fields = make([]encoder.Doc, 3)
 	LoggingDestinationDoc.Fields[0].Name = "endpoint"
 	LoggingDestinationDoc.Fields[0].Type = "Endpoint"
 	LoggingDestinationDoc.Fields[0].Note = ""
-	LoggingDestinationDoc.Fields[0].Description = "Where to send logs. Supported protocols are \"tcp\" and \"udp\"."
-	LoggingDestinationDoc.Fields[0].Comments[encoder.LineComment] = "Where to send logs. Supported protocols are \"tcp\" and \"udp\"."
+	LoggingDestinationDoc.Fields[0].Description = "Where to send logs. Supported protocols are \"tcp\", \"udp\" and \"tls\".\n\n\"tls\" sends logs over TCP with mutual TLS: the node presents its Talos API client certificate,\nand the destination should present a certificate signed by the `ca` (or by the Talos OS CA `machine.ca`, if not set)."
+	LoggingDestinationDoc.Fields[0].Comments[encoder.LineComment] = "Where to send logs. Supported protocols are \"tcp\", \"udp\" and \"tls\"."
 
 	LoggingDestinationDoc.Fields[0].AddExample("", loggingEndpointExample1)
 
 	LoggingDestinationDoc.Fields[0].AddExample("", loggingEndpointExample2)
+
+	LoggingDestinationDoc.Fields[0].AddExample("", loggingEndpointExample3)
 	LoggingDestinationDoc.Fields[1].Name = "format"
 	LoggingDestinationDoc.Fields[1].Type = "string"
 	LoggingDestinationDoc.Fields[1].Note = ""
@@ -2299,6 +4095,11 @@ func init() {
 	LoggingDestinationDoc.Fields[1].Values = []string{
 		"json_lines",
 	}
+	LoggingDestinationDoc.Fields[2].Name = "ca"
+	LoggingDestinationDoc.Fields[2].Type = "Base64Bytes"
+	LoggingDestinationDoc.Fields[2].Note = ""
+	LoggingDestinationDoc.Fields[2].Description = "PEM-encoded CA certificate to verify the \"tls\" destination certificate.\n\nDefaults to the Talos OS CA."
+	LoggingDestinationDoc.Fields[2].Comments[encoder.LineComment] = "PEM-encoded CA certificate to verify the \"tls\" destination certificate."
 }
 
 func (_ Config) Doc() *encoder.Doc {
@@ -2333,6 +4134,14 @@ func (_ KubeletConfig) Doc() *encoder.Doc {
 	return &KubeletConfigDoc
 }
 
+func (_ KubeletCredentialProvidersConfig) Doc() *encoder.Doc {
+	return &KubeletCredentialProvidersConfigDoc
+}
+
+func (_ KubeletCredentialProviderConfig) Doc() *encoder.Doc {
+	return &KubeletCredentialProviderConfigDoc
+}
+
 func (_ KubeletNodeIPConfig) Doc() *encoder.Doc {
 	return &KubeletNodeIPConfigDoc
 }
@@ -2357,6 +4166,14 @@ func (_ RegistriesConfig) Doc() *encoder.Doc {
 	return &RegistriesConfigDoc
 }
 
+func (_ RegistryPullsConfig) Doc() *encoder.Doc {
+	return &RegistryPullsConfigDoc
+}
+
+func (_ MaintenanceWindowConfig) Doc() *encoder.Doc {
+	return &MaintenanceWindowConfigDoc
+}
+
 func (_ PodCheckpointer) Doc() *encoder.Doc {
 	return &PodCheckpointerDoc
 }
@@ -2397,6 +4214,10 @@ func (_ ClusterNetworkConfig) Doc() *encoder.Doc {
 	return &ClusterNetworkConfigDoc
 }
 
+func (_ PodSubnetSizeConfig) Doc() *encoder.Doc {
+	return &PodSubnetSizeConfigDoc
+}
+
 func (_ CNIConfig) Doc() *encoder.Doc {
 	return &CNIConfigDoc
 }
@@ -2405,6 +4226,10 @@ func (_ ExternalCloudProviderConfig) Doc() *encoder.Doc {
 	return &ExternalCloudProviderConfigDoc
 }
 
+func (_ InstanceIdentityConfig) Doc() *encoder.Doc {
+	return &InstanceIdentityConfigDoc
+}
+
 func (_ AdminKubeconfigConfig) Doc() *encoder.Doc {
 	return &AdminKubeconfigConfigDoc
 }
@@ -2417,6 +4242,10 @@ func (_ DiskPartition) Doc() *encoder.Doc {
 	return &DiskPartitionDoc
 }
 
+func (_ EphemeralConfig) Doc() *encoder.Doc {
+	return &EphemeralConfigDoc
+}
+
 func (_ EncryptionConfig) Doc() *encoder.Doc {
 	return &EncryptionConfigDoc
 }
@@ -2445,6 +4274,18 @@ func (_ Device) Doc() *encoder.Doc {
 	return &DeviceDoc
 }
 
+func (_ TrafficShapingConfig) Doc() *encoder.Doc {
+	return &TrafficShapingConfigDoc
+}
+
+func (_ TrafficClassConfig) Doc() *encoder.Doc {
+	return &TrafficClassConfigDoc
+}
+
+func (_ DeviceTuningConfig) Doc() *encoder.Doc {
+	return &DeviceTuningConfigDoc
+}
+
 func (_ DHCPOptions) Doc() *encoder.Doc {
 	return &DHCPOptionsDoc
 }
@@ -2505,6 +4346,10 @@ func (_ FeaturesConfig) Doc() *encoder.Doc {
 	return &FeaturesConfigDoc
 }
 
+func (_ ControlPlaneLoadBalancerConfig) Doc() *encoder.Doc {
+	return &ControlPlaneLoadBalancerConfigDoc
+}
+
 func (_ VolumeMountConfig) Doc() *encoder.Doc {
 	return &VolumeMountConfigDoc
 }
@@ -2517,6 +4362,10 @@ func (_ NetworkKubeSpan) Doc() *encoder.Doc {
 	return &NetworkKubeSpanDoc
 }
 
+func (_ ConntrackConfig) Doc() *encoder.Doc {
+	return &ConntrackConfigDoc
+}
+
 func (_ ClusterDiscoveryConfig) Doc() *encoder.Doc {
 	return &ClusterDiscoveryConfigDoc
 }
@@ -2541,6 +4390,130 @@ func (_ LoggingConfig) Doc() *encoder.Doc {
 	return &LoggingConfigDoc
 }
 
+func (_ LoggingRateLimit) Doc() *encoder.Doc {
+	return &LoggingRateLimitDoc
+}
+
+func (_ SessionRecordingConfig) Doc() *encoder.Doc {
+	return &SessionRecordingConfigDoc
+}
+
+func (_ ServiceHook) Doc() *encoder.Doc {
+	return &ServiceHookDoc
+}
+
+func (_ ServiceOverride) Doc() *encoder.Doc {
+	return &ServiceOverrideDoc
+}
+
+func (_ ServiceOverrideMount) Doc() *encoder.Doc {
+	return &ServiceOverrideMountDoc
+}
+
+func (_ ServiceOverrideRlimit) Doc() *encoder.Doc {
+	return &ServiceOverrideRlimitDoc
+}
+
+func (_ IMAConfig) Doc() *encoder.Doc {
+	return &IMAConfigDoc
+}
+
+func (_ APITLSConfig) Doc() *encoder.Doc {
+	return &APITLSConfigDoc
+}
+
+func (_ APITLSCertificateConfig) Doc() *encoder.Doc {
+	return &APITLSCertificateConfigDoc
+}
+
+func (_ BMCConfig) Doc() *encoder.Doc {
+	return &BMCConfigDoc
+}
+
+func (_ LifecycleWebhookConfig) Doc() *encoder.Doc {
+	return &LifecycleWebhookConfigDoc
+}
+
+func (_ BackupConfig) Doc() *encoder.Doc {
+	return &BackupConfigDoc
+}
+
+func (_ BackupS3Config) Doc() *encoder.Doc {
+	return &BackupS3ConfigDoc
+}
+
+func (_ BackupPathConfig) Doc() *encoder.Doc {
+	return &BackupPathConfigDoc
+}
+
+func (_ CRIConfig) Doc() *encoder.Doc {
+	return &CRIConfigDoc
+}
+
+func (_ HostPathPolicyConfig) Doc() *encoder.Doc {
+	return &HostPathPolicyConfigDoc
+}
+
+func (_ ImagePolicyConfig) Doc() *encoder.Doc {
+	return &ImagePolicyConfigDoc
+}
+
+func (_ SecretsResolverConfig) Doc() *encoder.Doc {
+	return &SecretsResolverConfigDoc
+}
+
+func (_ SecretsBackendConfig) Doc() *encoder.Doc {
+	return &SecretsBackendConfigDoc
+}
+
+func (_ VaultSecretsBackendConfig) Doc() *encoder.Doc {
+	return &VaultSecretsBackendConfigDoc
+}
+
+func (_ AWSSecretsManagerBackendConfig) Doc() *encoder.Doc {
+	return &AWSSecretsManagerBackendConfigDoc
+}
+
+func (_ GCPSecretManagerBackendConfig) Doc() *encoder.Doc {
+	return &GCPSecretManagerBackendConfigDoc
+}
+
+func (_ NodeLabelRuleConfig) Doc() *encoder.Doc {
+	return &NodeLabelRuleConfigDoc
+}
+
+func (_ NodeLabelMatchConfig) Doc() *encoder.Doc {
+	return &NodeLabelMatchConfigDoc
+}
+
+func (_ HealthCheckConfig) Doc() *encoder.Doc {
+	return &HealthCheckConfigDoc
+}
+
+func (_ HealthCheckHTTPConfig) Doc() *encoder.Doc {
+	return &HealthCheckHTTPConfigDoc
+}
+
+func (_ HealthCheckTCPConfig) Doc() *encoder.Doc {
+	return &HealthCheckTCPConfigDoc
+}
+
+func (_ HealthCheckExecConfig) Doc() *encoder.Doc {
+	return &HealthCheckExecConfigDoc
+}
+
+func (_ KernelConfig) Doc() *encoder.Doc {
+	return &KernelConfigDoc
+}
+
+func (_ RealtimeProfileConfig) Doc() *encoder.Doc {
+	return &RealtimeProfileConfigDoc
+}
+
+func (_ HugePageConfig) Doc() *encoder.Doc {
+	return &HugePageConfigDoc
+}
+
 func (_ LoggingDestination) Doc() *encoder.Doc {
 	return &LoggingDestinationDoc
 }
@@ -2559,12 +4532,16 @@ func GetConfigurationDoc() *encoder.FileDoc {
 			&MachineControllerManagerConfigDoc,
 			&MachineSchedulerConfigDoc,
 			&KubeletConfigDoc,
+			&KubeletCredentialProvidersConfigDoc,
+			&KubeletCredentialProviderConfigDoc,
 			&KubeletNodeIPConfigDoc,
 			&NetworkConfigDoc,
 			&InstallConfigDoc,
 			&InstallDiskSelectorDoc,
 			&TimeConfigDoc,
 			&RegistriesConfigDoc,
+			&RegistryPullsConfigDoc,
+			&MaintenanceWindowConfigDoc,
 			&PodCheckpointerDoc,
 			&CoreDNSDoc,
 			&EndpointDoc,
@@ -2575,11 +4552,14 @@ func GetConfigurationDoc() *encoder.FileDoc {
 			&SchedulerConfigDoc,
 			&EtcdConfigDoc,
 			&ClusterNetworkConfigDoc,
+			&PodSubnetSizeConfigDoc,
 			&CNIConfigDoc,
 			&ExternalCloudProviderConfigDoc,
+			&InstanceIdentityConfigDoc,
 			&AdminKubeconfigConfigDoc,
 			&MachineDiskDoc,
 			&DiskPartitionDoc,
+			&EphemeralConfigDoc,
 			&EncryptionConfigDoc,
 			&EncryptionKeyDoc,
 			&EncryptionKeyStaticDoc,
@@ -2587,6 +4567,9 @@ func GetConfigurationDoc() *encoder.FileDoc {
 			&MachineFileDoc,
 			&ExtraHostDoc,
 			&DeviceDoc,
+			&TrafficShapingConfigDoc,
+			&TrafficClassConfigDoc,
+			&DeviceTuningConfigDoc,
 			&DHCPOptionsDoc,
 			&DeviceWireguardConfigDoc,
 			&DeviceWireguardPeerDoc,
@@ -2602,15 +4585,48 @@ func GetConfigurationDoc() *encoder.FileDoc {
 			&RegistryTLSConfigDoc,
 			&SystemDiskEncryptionConfigDoc,
 			&FeaturesConfigDoc,
+			&ControlPlaneLoadBalancerConfigDoc,
 			&VolumeMountConfigDoc,
 			&ClusterInlineManifestDoc,
 			&NetworkKubeSpanDoc,
+			&ConntrackConfigDoc,
 			&ClusterDiscoveryConfigDoc,
 			&DiscoveryRegistriesConfigDoc,
 			&RegistryKubernetesConfigDoc,
 			&RegistryServiceConfigDoc,
 			&UdevConfigDoc,
 			&LoggingConfigDoc,
+			&LoggingRateLimitDoc,
+			&SessionRecordingConfigDoc,
+			&ServiceHookDoc,
+			&ServiceOverrideDoc,
+			&ServiceOverrideMountDoc,
+			&ServiceOverrideRlimitDoc,
+			&IMAConfigDoc,
+			&APITLSConfigDoc,
+			&APITLSCertificateConfigDoc,
+			&BMCConfigDoc,
+			&LifecycleWebhookConfigDoc,
+			&BackupConfigDoc,
+			&BackupS3ConfigDoc,
+			&BackupPathConfigDoc,
+			&CRIConfigDoc,
+			&HostPathPolicyConfigDoc,
+			&ImagePolicyConfigDoc,
+			&SecretsResolverConfigDoc,
+			&SecretsBackendConfigDoc,
+			&VaultSecretsBackendConfigDoc,
+			&AWSSecretsManagerBackendConfigDoc,
+			&GCPSecretManagerBackendConfigDoc,
+			&NodeLabelRuleConfigDoc,
+			&NodeLabelMatchConfigDoc,
+			&HealthCheckConfigDoc,
+			&HealthCheckHTTPConfigDoc,
+			&HealthCheckTCPConfigDoc,
+			&HealthCheckExecConfigDoc,
+			&KernelConfigDoc,
+			&RealtimeProfileConfigDoc,
+			&HugePageConfigDoc,
 			&LoggingDestinationDoc,
 		},
 	}
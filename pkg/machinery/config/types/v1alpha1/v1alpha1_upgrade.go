@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
+)
+
+// Upgrade returns a copy of the config with the deprecated fields converted to their replacements.
+//
+// Conversions which drop some configuration are marked as lossy, the config is converted anyway,
+// so it's up to the caller to refuse the result.
+func (c *Config) Upgrade() (*Config, []config.Conversion) {
+	upgraded := c.DeepCopy()
+
+	var conversions []config.Conversion
+
+	if upgraded.MachineConfig != nil {
+		conversions = append(conversions, upgraded.MachineConfig.upgrade()...)
+	}
+
+	if upgraded.ClusterConfig != nil && upgraded.ClusterConfig.EtcdConfig != nil && upgraded.ClusterConfig.EtcdConfig.EtcdSubnet != "" {
+		etcd := upgraded.ClusterConfig.EtcdConfig
+
+		conversion := config.Conversion{
+			Path:    ".cluster.etcd.subnet",
+			Message: "moved to .cluster.etcd.advertisedSubnets",
+		}
+
+		if len(etcd.EtcdAdvertisedSubnets) > 0 {
+			conversion.Message = "dropped, .cluster.etcd.advertisedSubnets is already set"
+			conversion.Lossy = true
+		} else {
+			etcd.EtcdAdvertisedSubnets = []string{etcd.EtcdSubnet}
+		}
+
+		etcd.EtcdSubnet = ""
+
+		conversions = append(conversions, conversion)
+	}
+
+	return upgraded, conversions
+}
+
+func (m *MachineConfig) upgrade() []config.Conversion {
+	var conversions []config.Conversion
+
+	if t := m.Type(); t != machine.TypeUnknown && t.String() != m.MachineType {
+		conversions = append(conversions, config.Conversion{
+			Path:    ".machine.type",
+			Message: fmt.Sprintf("machine type %q renamed to %q", m.MachineType, t.String()),
+		})
+
+		m.MachineType = t.String()
+	}
+
+	if m.MachineNetwork != nil {
+		for i, device := range m.MachineNetwork.NetworkInterfaces {
+			if device == nil {
+				continue
+			}
+
+			if device.DeviceCIDR != "" {
+				var conversion config.Conversion
+
+				device.DeviceAddresses, conversion = upgradeCIDR(device.DeviceCIDR, device.DeviceAddresses)
+				device.DeviceCIDR = ""
+
+				conversion.Path = fmt.Sprintf(".machine.network.interfaces[%d].cidr", i)
+				conversions = append(conversions, conversion)
+			}
+
+			for j, vlan := range device.DeviceVlans {
+				if vlan == nil || vlan.VlanCIDR == "" {
+					continue
+				}
+
+				var conversion config.Conversion
+
+				vlan.VlanAddresses, conversion = upgradeCIDR(vlan.VlanCIDR, vlan.VlanAddresses)
+				vlan.VlanCIDR = ""
+
+				conversion.Path = fmt.Sprintf(".machine.network.interfaces[%d].vlans[%d].cidr", i, j)
+				conversions = append(conversions, conversion)
+			}
+		}
+	}
+
+	return conversions
+}
+
+// upgradeCIDR moves the deprecated `cidr` to the `addresses`.
+//
+// If the `addresses` are already set, `cidr` is ignored by Talos, so dropping it doesn't change the behavior,
+// but it's still reported as lossy unless the `addresses` contain the same value.
+func upgradeCIDR(cidr string, addresses []string) ([]string, config.Conversion) {
+	if len(addresses) == 0 {
+		return []string{cidr}, config.Conversion{
+			Message: "moved to addresses",
+		}
+	}
+
+	for _, address := range addresses {
+		if address == cidr {
+			return addresses, config.Conversion{
+				Message: "dropped, the value is already in addresses",
+			}
+		}
+	}
+
+	return addresses, config.Conversion{
+		Message: fmt.Sprintf("dropped %q, addresses are already set", cidr),
+		Lossy:   true,
+	}
+}
@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/pkg/machinery/config"
+	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1"
+)
+
+func TestUpgrade(t *testing.T) {
+	t.Parallel()
+
+	cfg := &v1alpha1.Config{
+		ConfigVersion: "v1alpha1",
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineType: "join",
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkInterfaces: []*v1alpha1.Device{
+					{
+						DeviceInterface: "eth0",
+						DeviceCIDR:      "192.168.0.1/24",
+						DeviceAddresses: []string{"192.168.0.1/24"},
+					},
+					{
+						DeviceInterface: "eth1",
+						DeviceCIDR:      "10.0.0.1/8",
+						DeviceVlans: []*v1alpha1.Vlan{
+							{
+								VlanID:        10,
+								VlanCIDR:      "10.10.0.1/16",
+								VlanAddresses: []string{"10.20.0.1/16"},
+							},
+						},
+					},
+				},
+			},
+		},
+		ClusterConfig: &v1alpha1.ClusterConfig{
+			EtcdConfig: &v1alpha1.EtcdConfig{
+				EtcdSubnet: "10.0.0.0/8",
+			},
+		},
+	}
+
+	upgraded, conversions := cfg.Upgrade()
+
+	assert.Equal(t, []config.Conversion{
+		{
+			Path:    ".machine.type",
+			Message: "machine type \"join\" renamed to \"worker\"",
+		},
+		{
+			Path:    ".machine.network.interfaces[0].cidr",
+			Message: "dropped, the value is already in addresses",
+		},
+		{
+			Path:    ".machine.network.interfaces[1].cidr",
+			Message: "moved to addresses",
+		},
+		{
+			Path:    ".machine.network.interfaces[1].vlans[0].cidr",
+			Message: "dropped \"10.10.0.1/16\", addresses are already set",
+			Lossy:   true,
+		},
+		{
+			Path:    ".cluster.etcd.subnet",
+			Message: "moved to .cluster.etcd.advertisedSubnets",
+		},
+	}, conversions)
+
+	assert.Empty(t, upgraded.Deprecations())
+
+	assert.Equal(t, "worker", upgraded.MachineConfig.MachineType)
+	assert.Equal(t, []string{"10.0.0.1/8"}, upgraded.MachineConfig.MachineNetwork.NetworkInterfaces[1].DeviceAddresses)
+	assert.Equal(t, []string{"10.20.0.1/16"}, upgraded.MachineConfig.MachineNetwork.NetworkInterfaces[1].DeviceVlans[0].VlanAddresses)
+	assert.Equal(t, []string{"10.0.0.0/8"}, upgraded.ClusterConfig.EtcdConfig.EtcdAdvertisedSubnets)
+
+	// original config is not modified
+	assert.Equal(t, "join", cfg.MachineConfig.MachineType)
+	assert.Equal(t, "10.0.0.0/8", cfg.ClusterConfig.EtcdConfig.EtcdSubnet)
+
+	_, conversions = upgraded.Upgrade()
+	assert.Empty(t, conversions)
+}
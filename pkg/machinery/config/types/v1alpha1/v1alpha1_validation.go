@@ -11,6 +11,9 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/talos-systems/talos/pkg/machinery/config"
 	"github.com/talos-systems/talos/pkg/machinery/config/types/v1alpha1/machine"
 	"github.com/talos-systems/talos/pkg/machinery/constants"
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
 	"github.com/talos-systems/talos/pkg/machinery/nethelpers"
 )
 
@@ -57,6 +61,7 @@ var (
 type NetworkDeviceCheck func(*Device, map[string]string) ([]string, error)
 
 // Validate implements the config.Provider interface.
+//
 //nolint:gocyclo,cyclop
 func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationOption) ([]string, error) {
 	var (
@@ -102,6 +107,12 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 		}
 	}
 
+	if c.MachineConfig.MachineInstall != nil && c.MachineConfig.MachineInstall.InstallAssetsURL != "" {
+		if u, err := url.Parse(c.MachineConfig.MachineInstall.InstallAssetsURL); err != nil || !u.IsAbs() {
+			result = multierror.Append(result, fmt.Errorf("install assets URL %q should be an absolute URL", c.MachineConfig.MachineInstall.InstallAssetsURL))
+		}
+	}
+
 	if t := c.Machine().Type(); t != machine.TypeUnknown && t.String() != c.MachineConfig.MachineType {
 		warnings = append(warnings, fmt.Sprintf("use %q instead of %q for machine type", t.String(), c.MachineConfig.MachineType))
 	}
@@ -151,6 +162,30 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 			warn, err := ValidateNetworkDevices(device, bondedInterfaces, CheckDeviceInterface, CheckDeviceAddressing, CheckDeviceRoutes)
 			warnings = append(warnings, warn...)
 			result = multierror.Append(result, err)
+
+			if device.DeviceTuning != nil {
+				result = multierror.Append(result, device.DeviceTuning.Validate(device.DeviceInterface))
+			}
+
+			if device.DeviceTrafficShaping != nil {
+				result = multierror.Append(result, device.DeviceTrafficShaping.Validate(device.DeviceInterface))
+			}
+		}
+
+		for _, cidr := range c.MachineConfig.MachineNetwork.NetworkAdvertisedSubnets {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				result = multierror.Append(result, fmt.Errorf("advertised subnet is not valid: %q", cidr))
+			}
+		}
+
+		for _, cidr := range c.MachineConfig.MachineNetwork.NetworkIgnoredSubnets {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				result = multierror.Append(result, fmt.Errorf("ignored subnet is not valid: %q", cidr))
+			}
+		}
+
+		if c.MachineConfig.MachineNetwork.NetworkConntrack != nil {
+			result = multierror.Append(result, c.MachineConfig.MachineNetwork.NetworkConntrack.Validate(c.MachineConfig.MachineSysctls))
 		}
 	}
 
@@ -160,14 +195,42 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 				if pt.DiskSize == 0 && i != len(disk.DiskPartitions)-1 {
 					result = multierror.Append(result, fmt.Errorf("partition for disk %q is set to occupy full disk, but it's not the last partition in the list", disk.Device()))
 				}
+
+				if _, _, err := config.SplitVolumeMountOptions(pt.DiskMountOptions, pt.DiskProjectQuota); err != nil {
+					result = multierror.Append(result, fmt.Errorf("partition %q for disk %q: %w", pt.DiskMountPoint, disk.Device(), err))
+				}
 			}
 		}
 	}
 
+	if c.MachineConfig.MachineEphemeral != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineEphemeral.Validate())
+	}
+
 	if c.MachineConfig.MachineKubelet != nil {
 		warn, err := c.MachineConfig.MachineKubelet.Validate()
 		warnings = append(warnings, warn...)
 		result = multierror.Append(result, err)
+
+		if c.MachineConfig.MachineKubelet.KubeletStandalone {
+			if c.Machine().Type() != machine.TypeWorker {
+				result = multierror.Append(result, fmt.Errorf("kubelet standalone mode is supported only on the worker nodes"))
+			}
+
+			if len(c.MachineConfig.MachineNodeLabels) > 0 || len(c.MachineConfig.MachineNodeTaints) > 0 {
+				warnings = append(warnings, "node labels and taints are ignored in the kubelet standalone mode, as the node is not registered")
+			}
+		}
+
+		if len(c.MachineConfig.MachineKubelet.KubeletPodCIDRs) > 0 && c.ClusterConfig != nil {
+			result = multierror.Append(result, validateKubeletPodCIDRs(c.MachineConfig.MachineKubelet.KubeletPodCIDRs, c.ClusterConfig.PodCIDRs()))
+		}
+	}
+
+	if c.MachineConfig.MachineFeatures != nil {
+		warn, err := c.MachineConfig.MachineFeatures.Validate()
+		warnings = append(warnings, warn...)
+		result = multierror.Append(result, err)
 	}
 
 	for _, label := range []string{constants.EphemeralPartitionLabel, constants.StatePartitionLabel} {
@@ -211,6 +274,108 @@ func (c *Config) Validate(mode config.RuntimeMode, options ...config.ValidationO
 		result = multierror.Append(result, err)
 	}
 
+	for _, hook := range c.MachineConfig.MachineServiceHooks {
+		result = multierror.Append(result, hook.Validate())
+	}
+
+	overriddenServices := map[string]struct{}{}
+
+	for _, override := range c.MachineConfig.MachineServiceOverrides {
+		if _, ok := overriddenServices[override.OverrideService]; ok {
+			result = multierror.Append(result, fmt.Errorf("duplicate service override for service %q", override.OverrideService))
+		}
+
+		overriddenServices[override.OverrideService] = struct{}{}
+
+		result = multierror.Append(result, override.Validate())
+	}
+
+	if c.MachineConfig.MachineBackup != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineBackup.Validate())
+	}
+
+	if c.MachineConfig.MachineRegistries.RegistryPulls != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineRegistries.RegistryPulls.Validate())
+	}
+
+	if c.MachineConfig.MachineIMA != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineIMA.Validate())
+	}
+
+	if c.MachineConfig.MachineCRI != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineCRI.Validate())
+	}
+
+	if c.MachineConfig.MachineAPITLS != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineAPITLS.Validate(c.MachineConfig.MachineCA))
+	}
+
+	if c.MachineConfig.MachineSecretsResolver != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineSecretsResolver.Validate())
+	}
+
+	result = multierror.Append(result, c.validateSecretReferences())
+
+	if c.MachineConfig.MachineBMC != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineBMC.Validate())
+	}
+
+	if c.MachineConfig.MachineLifecycleWebhook != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineLifecycleWebhook.Validate())
+	}
+
+	for _, rule := range c.MachineConfig.MachineNodeLabelRules {
+		result = multierror.Append(result, rule.Validate())
+	}
+
+	result = multierror.Append(result, validateNodeLabels(c.MachineConfig.MachineNodeLabels))
+	result = multierror.Append(result, validateNodeTaints(c.MachineConfig.MachineNodeTaints))
+
+	healthChecks := map[string]struct{}{}
+
+	for _, check := range c.MachineConfig.MachineHealthChecks {
+		if _, ok := healthChecks[check.CheckName]; ok {
+			result = multierror.Append(result, fmt.Errorf("duplicate health check %q", check.CheckName))
+		}
+
+		healthChecks[check.CheckName] = struct{}{}
+
+		result = multierror.Append(result, check.Validate())
+	}
+
+	result = multierror.Append(result, validatePods(c.MachineConfig.MachinePods))
+
+	if c.MachineConfig.MachineKernel != nil {
+		result = multierror.Append(result, c.MachineConfig.MachineKernel.Validate())
+
+		if c.MachineConfig.MachineKernel.KernelRealtime != nil {
+			result = multierror.Append(result, c.validateRealtimeProfile())
+		}
+	}
+
+	// for each hugepage size, reservations should be either global or per NUMA node
+	hugePageNUMANodes := map[string]map[int]struct{}{}
+
+	for _, hugePage := range c.MachineConfig.MachineHugePages {
+		result = multierror.Append(result, hugePage.Validate())
+
+		nodes, ok := hugePageNUMANodes[hugePage.HugePageSize]
+		if !ok {
+			nodes = map[int]struct{}{}
+			hugePageNUMANodes[hugePage.HugePageSize] = nodes
+		}
+
+		if _, ok := nodes[hugePage.NUMANode()]; ok {
+			result = multierror.Append(result, fmt.Errorf("duplicate hugepage reservation for size %q", hugePage.HugePageSize))
+		}
+
+		nodes[hugePage.NUMANode()] = struct{}{}
+
+		if _, global := nodes[-1]; global && len(nodes) > 1 {
+			result = multierror.Append(result, fmt.Errorf("hugepage reservation for size %q should be either global or per NUMA node", hugePage.HugePageSize))
+		}
+	}
+
 	if opts.Strict {
 		for _, w := range warnings {
 			result = multierror.Append(result, fmt.Errorf("warning: %s", w))
@@ -240,10 +405,24 @@ func (c *ClusterConfig) Validate() error {
 		result = multierror.Append(result, fmt.Errorf("invalid controlplane endpoint: %w", err))
 	}
 
+	if c.ControlPlane.InternalEndpoint != nil && c.ControlPlane.InternalEndpoint.URL != nil {
+		if err := talosnet.ValidateEndpointURI(c.ControlPlane.InternalEndpoint.URL.String()); err != nil {
+			result = multierror.Append(result, fmt.Errorf("invalid controlplane internal endpoint: %w", err))
+		}
+	}
+
 	if c.ClusterNetwork != nil && !valid.IsDNSName(c.ClusterNetwork.DNSDomain) {
 		result = multierror.Append(result, fmt.Errorf("%q is not a valid DNS name", c.ClusterNetwork.DNSDomain))
 	}
 
+	if c.ClusterNetwork != nil && c.ClusterNetwork.PodSubnetSize != nil {
+		result = multierror.Append(result, c.ClusterNetwork.PodSubnetSize.Validate(c.PodCIDRs()))
+	}
+
+	if c.EtcdConfig != nil {
+		result = multierror.Append(result, c.EtcdConfig.Validate())
+	}
+
 	if ecp := c.ExternalCloudProviderConfig; ecp != nil {
 		result = multierror.Append(result, ecp.Validate())
 	}
@@ -254,6 +433,10 @@ func (c *ClusterConfig) Validate() error {
 		}
 	}
 
+	if c.ClusterInstanceIdentity != nil {
+		result = multierror.Append(result, c.ClusterInstanceIdentity.Validate())
+	}
+
 	result = multierror.Append(result, c.ClusterInlineManifests.Validate(), c.ClusterDiscoveryConfig.Validate(c))
 
 	return result.ErrorOrNil()
@@ -295,6 +478,67 @@ func ValidateCNI(cni config.CNI) ([]string, error) {
 	return warnings, result.ErrorOrNil()
 }
 
+// Validate validates per-node pod subnet sizes against the pod subnets.
+func (p *PodSubnetSizeConfig) Validate(podCIDRs []string) error {
+	var result *multierror.Error
+
+	for _, cidr := range podCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("%q is not a valid pod subnet: %w", cidr, err))
+
+			continue
+		}
+
+		ones, bits := ipNet.Mask.Size()
+
+		size := p.IPv4
+		if ipNet.IP.To4() == nil {
+			size = p.IPv6
+		}
+
+		if size == 0 {
+			continue
+		}
+
+		if size < ones || size > bits {
+			result = multierror.Append(result, fmt.Errorf("pod subnet size %d should be in range [%d, %d] for pod subnet %q", size, ones, bits, cidr))
+
+			continue
+		}
+
+		// kube-controller-manager limits the number of per-node subnets in the cluster CIDR
+		if size-ones > constants.MaxNodeCIDRMaskSizeDiff {
+			result = multierror.Append(result, fmt.Errorf("pod subnet size %d is too large for pod subnet %q: difference should be at most %d", size, cidr, constants.MaxNodeCIDRMaskSizeDiff))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Validate etcd configuration.
+func (e *EtcdConfig) Validate() error {
+	var result *multierror.Error
+
+	if e.EtcdSubnet != "" && len(e.EtcdAdvertisedSubnets) > 0 {
+		result = multierror.Append(result, fmt.Errorf("etcd subnet can't be set when etcd advertisedSubnets are set"))
+	}
+
+	for _, cidr := range e.EtcdAdvertisedSubnets {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			result = multierror.Append(result, fmt.Errorf("etcd advertised subnet is not valid: %q", cidr))
+		}
+	}
+
+	for _, cidr := range e.EtcdListenSubnets {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			result = multierror.Append(result, fmt.Errorf("etcd listen subnet is not valid: %q", cidr))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
 // Validate validates external cloud provider configuration.
 func (ecp *ExternalCloudProviderConfig) Validate() error {
 	if !ecp.ExternalEnabled && (len(ecp.ExternalManifests) != 0) {
@@ -334,6 +578,49 @@ func (manifests ClusterInlineManifests) Validate() error {
 	return result.ErrorOrNil()
 }
 
+// validatePods checks that the static pods are Pod manifests with unique names.
+func validatePods(pods []Unstructured) error {
+	var result *multierror.Error
+
+	podNames := map[string]struct{}{}
+
+	for i, pod := range pods {
+		if apiVersion, _ := pod.Object["apiVersion"].(string); apiVersion != "v1" { //nolint:errcheck
+			result = multierror.Append(result, fmt.Errorf("static pod %d: unexpected apiVersion %q, expected \"v1\"", i, apiVersion))
+		}
+
+		if kind, _ := pod.Object["kind"].(string); kind != "Pod" { //nolint:errcheck
+			result = multierror.Append(result, fmt.Errorf("static pod %d: unexpected kind %q, expected \"Pod\"", i, kind))
+		}
+
+		if _, ok := pod.Object["spec"].(map[string]interface{}); !ok {
+			result = multierror.Append(result, fmt.Errorf("static pod %d: spec is missing", i))
+		}
+
+		metadata, _ := pod.Object["metadata"].(map[string]interface{}) //nolint:errcheck
+		name, _ := metadata["name"].(string)                           //nolint:errcheck
+		namespace, _ := metadata["namespace"].(string)                 //nolint:errcheck
+
+		if name == "" {
+			result = multierror.Append(result, fmt.Errorf("static pod %d: metadata.name can't be empty", i))
+
+			continue
+		}
+
+		if namespace == "" {
+			namespace = "default"
+		}
+
+		if _, ok := podNames[namespace+"/"+name]; ok {
+			result = multierror.Append(result, fmt.Errorf("static pod %q is duplicate", namespace+"/"+name))
+		}
+
+		podNames[namespace+"/"+name] = struct{}{}
+	}
+
+	return result.ErrorOrNil()
+}
+
 // Validate the discovery config.
 func (c ClusterDiscoveryConfig) Validate(clusterCfg *ClusterConfig) error {
 	var result *multierror.Error
@@ -691,10 +978,50 @@ func CheckDeviceRoutes(d *Device, bondedInterfaces map[string]string) ([]string,
 	return nil, result.ErrorOrNil()
 }
 
+// Validate machine features.
+func (f *FeaturesConfig) Validate() ([]string, error) {
+	switch f.APIDTLSMinVersion {
+	case "", "1.2", "1.3":
+	default:
+		return nil, fmt.Errorf("unsupported apid TLS min version %q", f.APIDTLSMinVersion)
+	}
+
+	if f.ControlPlaneLoadBalancerSupport != nil {
+		if port := f.ControlPlaneLoadBalancerSupport.ControlPlaneLoadBalancerPort; port < 0 || port > 65535 {
+			return nil, fmt.Errorf("control plane load balancer port %d is out of range", port)
+		}
+	}
+
+	return config.FeatureWarnings(f, config.KnownFeatures), nil
+}
+
+// Validate the EPHEMERAL partition mount options.
+func (e *EphemeralConfig) Validate() error {
+	flags, _, err := config.SplitVolumeMountOptions(e.EphemeralMountOptions, e.EphemeralProjectQuota)
+	if err != nil {
+		return fmt.Errorf("ephemeral partition: %w", err)
+	}
+
+	for _, flag := range flags {
+		if flag == "noexec" {
+			return fmt.Errorf("ephemeral partition: mount option \"noexec\" is not supported, as the container images are stored on the partition")
+		}
+	}
+
+	return nil
+}
+
 // Validate kubelet configuration.
 func (k *KubeletConfig) Validate() ([]string, error) {
 	var result *multierror.Error
 
+	switch k.KubeletNodeIP.KubeletNodeIPPreferredFamily {
+	case "", config.NodeIPFamilyIPv4, config.NodeIPFamilyIPv6:
+	default:
+		result = multierror.Append(result, fmt.Errorf("kubelet node IP preferred family %q is invalid, expected %q or %q",
+			k.KubeletNodeIP.KubeletNodeIPPreferredFamily, config.NodeIPFamilyIPv4, config.NodeIPFamilyIPv6))
+	}
+
 	for _, cidr := range k.KubeletNodeIP.KubeletNodeIPValidSubnets {
 		cidr = strings.TrimPrefix(cidr, "!")
 
@@ -703,5 +1030,464 @@ func (k *KubeletConfig) Validate() ([]string, error) {
 		}
 	}
 
-	return nil, result.ErrorOrNil()
+	for _, port := range []struct {
+		name  string
+		value int
+	}{
+		{"port", k.KubeletPort},
+		{"healthz port", k.KubeletHealthzPort},
+	} {
+		if port.value < 0 || port.value > 65535 {
+			result = multierror.Append(result, fmt.Errorf("kubelet %s %d is out of range", port.name, port.value))
+		}
+	}
+
+	healthzPort := k.HealthzPort()
+
+	// healthz endpoint can be overridden with the extra args, Talos health checks follow the effective endpoint
+	if arg, ok := k.KubeletExtraArgs["healthz-port"]; ok {
+		port, err := strconv.Atoi(arg)
+		if err != nil || port < 0 || port > 65535 {
+			result = multierror.Append(result, fmt.Errorf("kubelet extra arg \"healthz-port\" %q is not a valid port", arg))
+		} else {
+			healthzPort = port
+		}
+	}
+
+	if arg, ok := k.KubeletExtraArgs["healthz-bind-address"]; ok && net.ParseIP(arg) == nil {
+		result = multierror.Append(result, fmt.Errorf("kubelet extra arg \"healthz-bind-address\" %q is not a valid IP address", arg))
+	}
+
+	if k.Port() == healthzPort {
+		result = multierror.Append(result, fmt.Errorf("kubelet port and healthz port should be different"))
+	}
+
+	if readOnlyPort, ok := k.KubeletExtraArgs["read-only-port"]; ok && readOnlyPort != "0" {
+		result = multierror.Append(result, fmt.Errorf("kubelet read-only port can't be enabled"))
+	}
+
+	// port is used by Talos to talk to the kubelet, so it can't be overridden
+	if _, ok := k.KubeletExtraArgs["port"]; ok {
+		result = multierror.Append(result, fmt.Errorf("kubelet extra arg \"port\" should be configured via .machine.kubelet.port"))
+	}
+
+	var warnings []string
+
+	for _, mount := range k.KubeletExtraMounts {
+		sensitive, ok := config.SensitiveMountSource(mount.Source)
+		if !ok {
+			continue
+		}
+
+		if k.KubeletAllowUnsafeMounts {
+			warnings = append(warnings, fmt.Sprintf("kubelet extra mount %q exposes sensitive host path %q to the kubelet", mount.Source, sensitive))
+		} else {
+			result = multierror.Append(result, fmt.Errorf("kubelet extra mount %q exposes sensitive host path %q, set .machine.kubelet.allowUnsafeMounts to allow it", mount.Source, sensitive))
+		}
+	}
+
+	for _, capability := range k.KubeletExtraCapabilities {
+		if _, known := linuxCapabilities[capability]; !known {
+			result = multierror.Append(result, fmt.Errorf("kubelet extra capability %q is not a known Linux capability (expected format: \"CAP_SYS_MODULE\")", capability))
+		}
+	}
+
+	extraConfigKeys := make([]string, 0, len(k.KubeletExtraConfig.Object))
+
+	for key := range k.KubeletExtraConfig.Object {
+		extraConfigKeys = append(extraConfigKeys, key)
+	}
+
+	sort.Strings(extraConfigKeys)
+
+	for _, key := range extraConfigKeys {
+		if _, denied := kubeletExtraConfigDenylist[key]; denied {
+			result = multierror.Append(result, fmt.Errorf("kubelet extra config field %q is managed by Talos and can't be overridden", key))
+		}
+	}
+
+	version := config.ParseKubernetesVersionFromImage(k.Image())
+
+	// options are checked against the kubelet schema, so that invalid options are rejected on apply instead of crashing the kubelet
+	for _, arg := range sortedKeys(k.KubeletExtraArgs) {
+		// healthz port is validated above
+		if arg == "healthz-port" {
+			continue
+		}
+
+		result = multierror.Append(result, config.ValidateKubeletFlagValue(arg, k.KubeletExtraArgs[arg], version))
+	}
+
+	for _, key := range extraConfigKeys {
+		result = multierror.Append(result, config.ValidateKubeletConfigFieldValue(key, k.KubeletExtraConfig.Object[key], version))
+	}
+
+	// options are checked against the kubelet image version, so that unsupported options don't crash the kubelet
+	if version != nil {
+		for _, arg := range sortedKeys(k.KubeletExtraArgs) {
+			result = multierror.Append(result, config.ValidateKubeletFlag(arg, version))
+		}
+
+		for _, key := range extraConfigKeys {
+			result = multierror.Append(result, config.ValidateKubeletConfigField(key, version))
+		}
+
+		if k.KubeletCredentialProviders != nil {
+			result = multierror.Append(result, config.ValidateKubeletFlag("image-credential-provider-config", version))
+		}
+
+		if k.KubeletMemoryManagerPolicy != "" {
+			result = multierror.Append(result, config.ValidateKubeletConfigField("memoryManagerPolicy", version))
+		}
+
+		if k.KubeletShutdownGracePeriod != 0 {
+			result = multierror.Append(result, config.ValidateKubeletConfigField("shutdownGracePeriod", version))
+		}
+	}
+
+	for _, reserved := range []struct {
+		name   string
+		values map[string]string
+		arg    string
+	}{
+		{"systemReserved", k.KubeletSystemReserved, "system-reserved"},
+		{"kubeReserved", k.KubeletKubeReserved, "kube-reserved"},
+	} {
+		if len(reserved.values) > 0 {
+			result = multierror.Append(result, k.validateFieldConflicts(reserved.name, reserved.arg))
+		}
+
+		for _, resource := range sortedKeys(reserved.values) {
+			if _, ok := kubeletReservedResources[resource]; !ok {
+				result = multierror.Append(result, fmt.Errorf("kubelet %s resource %q is not supported (expected cpu, memory, ephemeral-storage or pid)", reserved.name, resource))
+
+				continue
+			}
+
+			if !quantityRegexp.MatchString(reserved.values[resource]) {
+				result = multierror.Append(result, fmt.Errorf("kubelet %s %q value %q is not a valid resource quantity", reserved.name, resource, reserved.values[resource]))
+			}
+		}
+	}
+
+	for _, threshold := range []struct {
+		name  string
+		value int
+		arg   string
+	}{
+		{"imageGCHighThresholdPercent", k.KubeletImageGCHighThresholdPercent, "image-gc-high-threshold"},
+		{"imageGCLowThresholdPercent", k.KubeletImageGCLowThresholdPercent, "image-gc-low-threshold"},
+	} {
+		if threshold.value < 0 || threshold.value > 100 {
+			result = multierror.Append(result, fmt.Errorf("kubelet %s %d is out of range", threshold.name, threshold.value))
+		}
+
+		if threshold.value != 0 {
+			result = multierror.Append(result, k.validateFieldConflicts(threshold.name, threshold.arg))
+		}
+	}
+
+	if k.ImageGCLowThresholdPercent() >= k.ImageGCHighThresholdPercent() {
+		result = multierror.Append(result, fmt.Errorf("kubelet imageGCLowThresholdPercent %d should be lower than imageGCHighThresholdPercent %d",
+			k.ImageGCLowThresholdPercent(), k.ImageGCHighThresholdPercent()))
+	}
+
+	if !quantityRegexp.MatchString(k.ContainerLogMaxSize()) {
+		result = multierror.Append(result, fmt.Errorf("kubelet containerLogMaxSize %q is not a valid resource quantity", k.ContainerLogMaxSize()))
+	}
+
+	if k.ContainerLogMaxFiles() < 2 {
+		result = multierror.Append(result, fmt.Errorf("kubelet containerLogMaxFiles %d should be at least 2", k.ContainerLogMaxFiles()))
+	}
+
+	if k.KubeletContainerLogMaxSize != "" {
+		result = multierror.Append(result, k.validateFieldConflicts("containerLogMaxSize", "container-log-max-size"))
+	}
+
+	if k.KubeletContainerLogMaxFiles != 0 {
+		result = multierror.Append(result, k.validateFieldConflicts("containerLogMaxFiles", "container-log-max-files"))
+	}
+
+	for _, policy := range []struct {
+		field string
+		set   bool
+		value string
+		valid []string
+		arg   string
+	}{
+		{"cpuManagerPolicy", k.KubeletCPUManagerPolicy != "", k.CPUManagerPolicy(), []string{"none", "static"}, "cpu-manager-policy"},
+		{"topologyManagerPolicy", k.KubeletTopologyManagerPolicy != "", k.TopologyManagerPolicy(), []string{"none", "best-effort", "restricted", "single-numa-node"}, "topology-manager-policy"},
+		{"memoryManagerPolicy", k.KubeletMemoryManagerPolicy != "", k.MemoryManagerPolicy(), []string{"None", "Static"}, "memory-manager-policy"},
+	} {
+		if !policy.set {
+			continue
+		}
+
+		valid := false
+
+		for _, v := range policy.valid {
+			if policy.value == v {
+				valid = true
+			}
+		}
+
+		if !valid {
+			result = multierror.Append(result, fmt.Errorf("kubelet %s %q is invalid, expected one of %q", policy.field, policy.value, policy.valid))
+		}
+
+		result = multierror.Append(result, k.validateFieldConflicts(policy.field, policy.arg))
+	}
+
+	if k.KubeletReservedSystemCPUs != "" {
+		if _, err := kernel.ParseCPUList(k.KubeletReservedSystemCPUs); err != nil {
+			result = multierror.Append(result, fmt.Errorf("kubelet reservedSystemCPUs: %w", err))
+		}
+
+		result = multierror.Append(result, k.validateFieldConflicts("reservedSystemCPUs", "reserved-cpus"))
+	}
+
+	if k.KubeletShutdownGracePeriod < 0 || k.KubeletShutdownGracePeriodCriticalPods < 0 {
+		result = multierror.Append(result, fmt.Errorf("kubelet shutdown grace periods should be non-negative"))
+	}
+
+	if k.KubeletShutdownGracePeriodCriticalPods > k.KubeletShutdownGracePeriod {
+		result = multierror.Append(result, fmt.Errorf("kubelet shutdown grace period for critical pods should not exceed the shutdown grace period"))
+	}
+
+	if k.KubeletStandalone {
+		// serving certificate CSRs are approved via the API server
+		if k.KubeletEnableServerCertRotation {
+			result = multierror.Append(result, fmt.Errorf("kubelet serving certificate rotation is not supported in the standalone mode"))
+		}
+
+		if _, ok := k.KubeletExtraArgs["register-node"]; ok {
+			result = multierror.Append(result, fmt.Errorf("kubelet extra arg \"register-node\" conflicts with .machine.kubelet.standalone"))
+		}
+	}
+
+	if len(k.KubeletPodCIDRs) > 0 {
+		// pre-assigned pod CIDRs are set on the node registration
+		if k.KubeletStandalone {
+			result = multierror.Append(result, fmt.Errorf("kubelet pod CIDRs are not supported in the standalone mode"))
+		}
+
+		if _, ok := k.KubeletExtraArgs["register-node"]; ok {
+			result = multierror.Append(result, fmt.Errorf("kubelet extra arg \"register-node\" conflicts with .machine.kubelet.podCIDRs"))
+		}
+	}
+
+	if k.KubeletCredentialProviders != nil {
+		if err := k.KubeletCredentialProviders.Validate(); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		for _, arg := range []string{"image-credential-provider-config", "image-credential-provider-bin-dir"} {
+			if _, ok := k.KubeletExtraArgs[arg]; ok {
+				result = multierror.Append(result, fmt.Errorf("kubelet extra arg %q conflicts with .machine.kubelet.credentialProviders", arg))
+			}
+		}
+	}
+
+	return warnings, result.ErrorOrNil()
+}
+
+// validateKubeletPodCIDRs checks that the pre-assigned node pod CIDRs match the pod subnets.
+//
+// kube-controller-manager matches the node pod CIDRs with the pod subnets by the index.
+func validateKubeletPodCIDRs(nodeCIDRs, podCIDRs []string) error {
+	if len(nodeCIDRs) != len(podCIDRs) {
+		return fmt.Errorf("kubelet pod CIDRs %q should match the pod subnets %q", nodeCIDRs, podCIDRs)
+	}
+
+	var result *multierror.Error
+
+	for i := range nodeCIDRs {
+		nodeIP, nodeNet, err := net.ParseCIDR(nodeCIDRs[i])
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("%q is not a valid kubelet pod CIDR: %w", nodeCIDRs[i], err))
+
+			continue
+		}
+
+		_, podNet, err := net.ParseCIDR(podCIDRs[i])
+		if err != nil {
+			// reported by the cluster config validation
+			continue
+		}
+
+		if !nodeIP.Equal(nodeNet.IP) {
+			result = multierror.Append(result, fmt.Errorf("kubelet pod CIDR %q should be a network address", nodeCIDRs[i]))
+
+			continue
+		}
+
+		nodeOnes, _ := nodeNet.Mask.Size()
+		podOnes, _ := podNet.Mask.Size()
+
+		if !podNet.Contains(nodeNet.IP) || nodeOnes < podOnes || (nodeNet.IP.To4() == nil) != (podNet.IP.To4() == nil) {
+			result = multierror.Append(result, fmt.Errorf("kubelet pod CIDR %q should be contained in the pod subnet %q", nodeCIDRs[i], podCIDRs[i]))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validateFieldConflicts checks that the field set in the machine configuration is not overridden with the extra args or extra config.
+func (k *KubeletConfig) validateFieldConflicts(field, arg string) error {
+	var result *multierror.Error
+
+	if _, ok := k.KubeletExtraArgs[arg]; ok {
+		result = multierror.Append(result, fmt.Errorf("kubelet extra arg %q conflicts with .machine.kubelet.%s", arg, field))
+	}
+
+	if _, ok := k.KubeletExtraConfig.Object[field]; ok {
+		result = multierror.Append(result, fmt.Errorf("kubelet extra config field %q conflicts with .machine.kubelet.%s", field, field))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// kubeletReservedResources is the list of the resources which can be reserved for the system and Kubernetes daemons.
+var kubeletReservedResources = map[string]struct{}{
+	"cpu":               {},
+	"memory":            {},
+	"ephemeral-storage": {},
+	"pid":               {},
+}
+
+// quantityRegexp is the Kubernetes resource quantity format (non-negative).
+var quantityRegexp = regexp.MustCompile(`^\+?([0-9]+(\.[0-9]*)?|\.[0-9]+)(([KMGTPE]i)|[numkMGTPE]|[eE][+-]?[0-9]+)?$`)
+
+// kubeletExtraConfigDenylist is the list of the KubeletConfiguration fields managed by Talos.
+var kubeletExtraConfigDenylist = map[string]struct{}{
+	"apiVersion":                      {},
+	"kind":                            {},
+	"staticPodPath":                   {},
+	"port":                            {},
+	"healthzPort":                     {},
+	"readOnlyPort":                    {},
+	"authentication":                  {},
+	"authorization":                   {},
+	"rotateCertificates":              {},
+	"serverTLSBootstrap":              {},
+	"clusterDomain":                   {},
+	"clusterDNS":                      {},
+	"cgroupRoot":                      {},
+	"systemCgroups":                   {},
+	"kubeletCgroups":                  {},
+	"shutdownGracePeriod":             {},
+	"shutdownGracePeriodCriticalPods": {},
+}
+
+// linuxCapabilities is the list of the capabilities known to the Linux kernel.
+var linuxCapabilities = map[string]struct{}{
+	"CAP_CHOWN":              {},
+	"CAP_DAC_OVERRIDE":       {},
+	"CAP_DAC_READ_SEARCH":    {},
+	"CAP_FOWNER":             {},
+	"CAP_FSETID":             {},
+	"CAP_KILL":               {},
+	"CAP_SETGID":             {},
+	"CAP_SETUID":             {},
+	"CAP_SETPCAP":            {},
+	"CAP_LINUX_IMMUTABLE":    {},
+	"CAP_NET_BIND_SERVICE":   {},
+	"CAP_NET_BROADCAST":      {},
+	"CAP_NET_ADMIN":          {},
+	"CAP_NET_RAW":            {},
+	"CAP_IPC_LOCK":           {},
+	"CAP_IPC_OWNER":          {},
+	"CAP_SYS_MODULE":         {},
+	"CAP_SYS_RAWIO":          {},
+	"CAP_SYS_CHROOT":         {},
+	"CAP_SYS_PTRACE":         {},
+	"CAP_SYS_PACCT":          {},
+	"CAP_SYS_ADMIN":          {},
+	"CAP_SYS_BOOT":           {},
+	"CAP_SYS_NICE":           {},
+	"CAP_SYS_RESOURCE":       {},
+	"CAP_SYS_TIME":           {},
+	"CAP_SYS_TTY_CONFIG":     {},
+	"CAP_MKNOD":              {},
+	"CAP_LEASE":              {},
+	"CAP_AUDIT_WRITE":        {},
+	"CAP_AUDIT_CONTROL":      {},
+	"CAP_SETFCAP":            {},
+	"CAP_MAC_OVERRIDE":       {},
+	"CAP_MAC_ADMIN":          {},
+	"CAP_SYSLOG":             {},
+	"CAP_WAKE_ALARM":         {},
+	"CAP_BLOCK_SUSPEND":      {},
+	"CAP_AUDIT_READ":         {},
+	"CAP_PERFMON":            {},
+	"CAP_BPF":                {},
+	"CAP_CHECKPOINT_RESTORE": {},
+}
+
+// Validate kubelet image credential provider plugins configuration.
+func (c *KubeletCredentialProvidersConfig) Validate() error {
+	var result *multierror.Error
+
+	if c.CredentialProvidersBinDir != "" && !filepath.IsAbs(c.CredentialProvidersBinDir) {
+		result = multierror.Append(result, fmt.Errorf("kubelet credential providers binDir %q should be an absolute path", c.CredentialProvidersBinDir))
+	}
+
+	names := map[string]struct{}{}
+
+	for _, provider := range c.CredentialProviders {
+		if provider.CredentialProviderName == "" || strings.ContainsRune(provider.CredentialProviderName, '/') {
+			result = multierror.Append(result, fmt.Errorf("kubelet credential provider name %q is not a valid binary name", provider.CredentialProviderName))
+		}
+
+		if _, ok := names[provider.CredentialProviderName]; ok {
+			result = multierror.Append(result, fmt.Errorf("duplicate kubelet credential provider %q", provider.CredentialProviderName))
+		}
+
+		names[provider.CredentialProviderName] = struct{}{}
+
+		if len(provider.CredentialProviderMatchImages) == 0 {
+			result = multierror.Append(result, fmt.Errorf("kubelet credential provider %q should match at least one image", provider.CredentialProviderName))
+		}
+
+		if provider.CredentialProviderDefaultCacheDuration < 0 {
+			result = multierror.Append(result, fmt.Errorf("kubelet credential provider %q default cache duration should not be negative", provider.CredentialProviderName))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// validateRealtimeProfile checks that the real-time profile is consistent with the install and kubelet settings.
+func (c *Config) validateRealtimeProfile() error {
+	var result *multierror.Error
+
+	rt := c.MachineConfig.MachineKernel.KernelRealtime
+
+	if c.MachineConfig.MachineInstall != nil {
+		for _, arg := range c.MachineConfig.MachineInstall.InstallExtraKernelArgs {
+			for _, managed := range realtimeKernelArgs {
+				if arg == managed || strings.HasPrefix(arg, managed+"=") {
+					result = multierror.Append(result, fmt.Errorf("kernel argument %q conflicts with the real-time profile", arg))
+				}
+			}
+		}
+	}
+
+	if c.MachineConfig.MachineKubelet != nil {
+		reservedCPUs, ok := c.MachineConfig.MachineKubelet.KubeletExtraArgs["reserved-cpus"]
+		if !ok && c.MachineConfig.MachineKubelet.KubeletReservedSystemCPUs != "" {
+			reservedCPUs, ok = c.MachineConfig.MachineKubelet.KubeletReservedSystemCPUs, true
+		}
+
+		if ok {
+			reserved, err := kernel.ParseCPUList(reservedCPUs)
+			housekeeping, _ := kernel.ParseCPUList(rt.RealtimeHousekeepingCPUs) //nolint:errcheck
+
+			if err != nil || fmt.Sprint(reserved) != fmt.Sprint(housekeeping) {
+				result = multierror.Append(result, fmt.Errorf("kubelet reserved CPUs %q should match the real-time profile housekeeping CPUs %q", reservedCPUs, rt.RealtimeHousekeepingCPUs))
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
 }
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/AlekSi/pointer"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -156,6 +159,45 @@ func TestValidate(t *testing.T) {
 			},
 			requiresInstall: true,
 		},
+		{
+			name: "MachineInstallAssetsURL",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallAssetsURL: "https://assets.example.com/talos/v0.14.0",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MachineInstallAssetsURLInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallAssetsURL: "assets/v0.14.0",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* install assets URL \"assets/v0.14.0\" should be an absolute URL\n\n",
+		},
 
 		{
 			name: "ExternalCloudProviderEnabled",
@@ -319,6 +361,142 @@ func TestValidate(t *testing.T) {
 			expectedError:    "1 error occurred:\n\t* [networking.os.device.CIDR] \"eth0\": failed to parse IP address \"10.3.x\"\n\n",
 			expectedWarnings: []string{"\"eth0\": machine.network.interface.cidr is deprecated, please use machine.network.interface.addresses"},
 		},
+		{
+			name: "DeviceTuningInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceDHCP:      true,
+								DeviceTuning: &v1alpha1.DeviceTuningConfig{
+									TuningCombinedQueues: 4,
+									TuningIRQCPUs:        "0-3",
+									TuningRPSCPUs:        "4-",
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* RPS CPUs for interface \"eth0\": invalid CPU list \"4-\"\n\n",
+		},
+		{
+			name: "DeviceTrafficShaping",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceDHCP:      true,
+								DeviceTrafficShaping: &v1alpha1.TrafficShapingConfig{
+									TrafficShapingBandwidth: "1Gbit",
+									TrafficShapingClasses: []v1alpha1.TrafficClassConfig{
+										{
+											TrafficClassName:       "etcd",
+											TrafficClassGuaranteed: "100Mbit",
+											TrafficClassPorts:      []int{2379, 2380},
+										},
+										{
+											TrafficClassName:     "registry",
+											TrafficClassLimit:    "400mbit",
+											TrafficClassPriority: 7,
+											TrafficClassSubnets:  []string{"10.100.0.0/16", "fd00::/64"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "DeviceTrafficShapingInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkInterfaces: []*v1alpha1.Device{
+							{
+								DeviceInterface: "eth0",
+								DeviceDHCP:      true,
+								DeviceTrafficShaping: &v1alpha1.TrafficShapingConfig{
+									TrafficShapingBandwidth: "1Gbit",
+									TrafficShapingClasses: []v1alpha1.TrafficClassConfig{
+										{
+											TrafficClassName:       "etcd",
+											TrafficClassGuaranteed: "2Gbit",
+											TrafficClassLimit:      "1GB",
+											TrafficClassPriority:   8,
+										},
+										{
+											TrafficClassName:  "etcd",
+											TrafficClassPorts: []int{0},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "6 errors occurred:\n\t* traffic class \"etcd\" bandwidth limit: invalid rate \"1GB\"\n\t* traffic class \"etcd\" priority should be between 0 and 7\n\t* traffic class \"etcd\" should match either ports or subnets\n\t* traffic class \"etcd\" for interface \"eth0\" is duplicate\n\t* traffic class \"etcd\" port 0 is invalid\n\t* guaranteed bandwidth of the traffic classes for interface \"eth0\" exceeds the interface bandwidth\n\n",
+		},
+		{
+			name: "ConntrackSysctlConflict",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineSysctls: map[string]string{
+						"net.netfilter.nf_conntrack_max": "524288",
+					},
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkConntrack: &v1alpha1.ConntrackConfig{
+							ConntrackMax:     1048576,
+							ConntrackBuckets: 262144,
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* sysctl \"net.netfilter.nf_conntrack_max\" conflicts with the conntrack settings\n\n",
+		},
 		{
 			name: "DeviceAddressInvalid",
 			config: &v1alpha1.Config{
@@ -884,6 +1062,1938 @@ func TestValidate(t *testing.T) {
 			},
 			expectedError: "1 error occurred:\n\t* kubelet nodeIP subnet is not valid: \"10.0.0.0\"\n\n",
 		},
+		{
+			name: "BadKubeletPorts",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletPort:        70000,
+						KubeletHealthzPort: 70000,
+						KubeletExtraArgs: map[string]string{
+							"read-only-port":       "10255",
+							"healthz-port":         "10248a",
+							"healthz-bind-address": "localhost",
+						},
+					},
+					MachineCRI: &v1alpha1.CRIConfig{
+						CRIStreamServerAddress: "localhost",
+						CRIStreamServerPort:    -1,
+						CRIStreamIdleTimeout:   -time.Second,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "9 errors occurred:\n\t* kubelet port 70000 is out of range\n\t* kubelet healthz port 70000 is out of range\n" +
+				"\t* kubelet extra arg \"healthz-port\" \"10248a\" is not a valid port\n" +
+				"\t* kubelet extra arg \"healthz-bind-address\" \"localhost\" is not a valid IP address\n" +
+				"\t* kubelet port and healthz port should be different\n\t* kubelet read-only port can't be enabled\n" +
+				"\t* CRI stream server address \"localhost\" is not a valid IP address\n\t* CRI stream server port -1 is out of range\n" +
+				"\t* CRI stream idle timeout should not be negative\n\n",
+		},
+		{
+			name: "BadCRIPolicies",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineCRI: &v1alpha1.CRIConfig{
+						CRIHostPathPolicy: &v1alpha1.HostPathPolicyConfig{
+							HostPathAllowedPaths: []string{"/var/mnt/data", "var/mnt", "/var/mnt/../lib"},
+						},
+						CRIImagePolicy: &v1alpha1.ImagePolicyConfig{
+							ImageAllowed: []string{"registry.example.com", " "},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* allowed host path \"var/mnt\" should be an absolute clean path\n" +
+				"\t* allowed host path \"/var/mnt/../lib\" should be an absolute clean path\n\t* image policy entry should not be empty\n\n",
+		},
+		{
+			name: "BadKubeletCredentialProviders",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraArgs: map[string]string{
+							"image-credential-provider-bin-dir": "/opt/bin",
+						},
+						KubeletCredentialProviders: &v1alpha1.KubeletCredentialProvidersConfig{
+							CredentialProvidersBinDir: "opt/bin",
+							CredentialProviders: []v1alpha1.KubeletCredentialProviderConfig{
+								{
+									CredentialProviderName:        "ecr-credential-provider",
+									CredentialProviderMatchImages: []string{"*.dkr.ecr.*.amazonaws.com"},
+								},
+								{
+									CredentialProviderName: "ecr-credential-provider",
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* kubelet credential providers binDir \"opt/bin\" should be an absolute path\n" +
+				"\t* duplicate kubelet credential provider \"ecr-credential-provider\"\n" +
+				"\t* kubelet credential provider \"ecr-credential-provider\" should match at least one image\n" +
+				"\t* kubelet extra arg \"image-credential-provider-bin-dir\" conflicts with .machine.kubelet.credentialProviders\n\n",
+		},
+		{
+			name: "BadKubeletExtraConfig",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"maxPods":      250,
+								"port":         10251,
+								"readOnlyPort": 10255,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* kubelet extra config field \"port\" is managed by Talos and can't be overridden\n" +
+				"\t* kubelet extra config field \"readOnlyPort\" is managed by Talos and can't be overridden\n\n",
+		},
+		{
+			name: "BadKubeletExtraCapabilities",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraCapabilities: []string{"CAP_SYS_MODULE", "sys_rawio"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* kubelet extra capability \"sys_rawio\" is not a known Linux capability (expected format: \"CAP_SYS_MODULE\")\n\n",
+		},
+		{
+			name: "BadKubeletExtraMounts",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraMounts: []v1alpha1.ExtraMount{
+							{Mount: specs.Mount{Source: "/var/mnt/data", Destination: "/var/mnt/data", Type: "bind", Options: []string{"bind"}}},
+							{Mount: specs.Mount{Source: "/system/secrets", Destination: "/var/secrets", Type: "bind", Options: []string{"bind"}}},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* kubelet extra mount \"/system/secrets\" exposes sensitive host path \"/system\", set .machine.kubelet.allowUnsafeMounts to allow it\n\n",
+		},
+		{
+			name: "UnsafeKubeletExtraMounts",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraMounts: []v1alpha1.ExtraMount{
+							{Mount: specs.Mount{Source: "/var/mnt/data", Destination: "/var/mnt/data", Type: "bind", Options: []string{"bind"}}},
+							{Mount: specs.Mount{Source: "/system/secrets", Destination: "/var/secrets", Type: "bind", Options: []string{"bind"}}},
+						},
+						KubeletAllowUnsafeMounts: true,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{"kubelet extra mount \"/system/secrets\" exposes sensitive host path \"/system\" to the kubelet"},
+		},
+		{
+			name: "BadInstanceIdentity",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterInstanceIdentity: &v1alpha1.InstanceIdentityConfig{
+						InstanceIdentityRequired:        true,
+						InstanceIdentityAWSCertificates: []string{"foo"},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* instance identity allowed accounts should be set when the instance identity is required\n" +
+				"\t* instance identity AWS certificate 0 is not PEM-encoded\n\n",
+		},
+		{
+			name: "BadKubeletShutdownGracePeriod",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletShutdownGracePeriod:             30 * time.Second,
+						KubeletShutdownGracePeriodCriticalPods: time.Minute,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* kubelet shutdown grace period for critical pods should not exceed the shutdown grace period\n\n",
+		},
+		{
+			name: "GoodAdvertisedSubnets",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkAdvertisedSubnets: []string{
+							"10.0.0.0/8",
+						},
+						NetworkIgnoredSubnets: []string{
+							"10.5.0.0/16",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "BadAPIDTLSMinVersion",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineFeatures: &v1alpha1.FeaturesConfig{
+						APIDTLSMinVersion: "1.1",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* unsupported apid TLS min version \"1.1\"\n\n",
+		},
+		{
+			name: "DeprecatedFeature",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineFeatures: &v1alpha1.FeaturesConfig{
+						KubeletDefaultRuntimeSeccompProfile: pointer.ToBool(true),
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				`feature "kubeletDefaultRuntimeSeccompProfile" is deprecated and will be removed in a future release, use .machine.kubelet.defaultRuntimeSeccompProfileEnabled instead`,
+			},
+		},
+		{
+			name: "BadControlPlaneLoadBalancerPort",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineFeatures: &v1alpha1.FeaturesConfig{
+						ControlPlaneLoadBalancerSupport: &v1alpha1.ControlPlaneLoadBalancerConfig{
+							ControlPlaneLoadBalancerEnabled: pointer.ToBool(true),
+							ControlPlaneLoadBalancerPort:    74450,
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* control plane load balancer port 74450 is out of range\n\n",
+		},
+		{
+			name: "BadAdvertisedSubnets",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineNetwork: &v1alpha1.NetworkConfig{
+						NetworkAdvertisedSubnets: []string{
+							"10.0.0.0",
+						},
+						NetworkIgnoredSubnets: []string{
+							"foo",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* advertised subnet is not valid: \"10.0.0.0\"\n\t* ignored subnet is not valid: \"foo\"\n\n",
+		},
+		{
+			name: "GoodEtcdSubnets",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					EtcdConfig: &v1alpha1.EtcdConfig{
+						EtcdAdvertisedSubnets: []string{
+							"10.0.0.0/8",
+						},
+						EtcdListenSubnets: []string{
+							"10.0.0.0/8",
+						},
+					},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "BadEtcdSubnets",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					EtcdConfig: &v1alpha1.EtcdConfig{
+						EtcdSubnet: "10.0.0.0/8",
+						EtcdAdvertisedSubnets: []string{
+							"10.0.0.0",
+						},
+						EtcdListenSubnets: []string{
+							"10.0.0.0",
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* etcd subnet can't be set when etcd advertisedSubnets are set\n\t* etcd advertised subnet is not valid: \"10.0.0.0\"\n\t* etcd listen subnet is not valid: \"10.0.0.0\"\n\n",
+		},
+		{
+			name: "GoodPodSubnetSize",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+						DNSDomain: "cluster.local",
+						PodSubnet: []string{"10.244.0.0/16", "fd00:10:244::/56"},
+						PodSubnetSize: &v1alpha1.PodSubnetSizeConfig{
+							IPv4: 26,
+							IPv6: 64,
+						},
+					},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "BadPodSubnetSize",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+						DNSDomain: "cluster.local",
+						PodSubnet: []string{"10.244.0.0/16", "fd00:10:244::/48"},
+						PodSubnetSize: &v1alpha1.PodSubnetSizeConfig{
+							IPv4: 8,
+							IPv6: 80,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* pod subnet size 8 should be in range [16, 32] for pod subnet \"10.244.0.0/16\"\n" +
+				"\t* pod subnet size 80 is too large for pod subnet \"fd00:10:244::/48\": difference should be at most 16\n\n",
+		},
+		{
+			name: "BadLoggingRateLimits",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineLogging: &v1alpha1.LoggingConfig{
+						LoggingRateLimits: []v1alpha1.LoggingRateLimit{
+							{
+								LoggingService: "kubelet",
+								LoggingBurst:   100,
+								LoggingRate:    10,
+							},
+							{
+								LoggingService: "kubelet",
+								LoggingBurst:   100,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* duplicate logging rate limit for service \"kubelet\"\n" +
+				"\t* logging rate limit for service \"kubelet\" should have positive burst and rate\n\n",
+		},
+		{
+			name: "BadLoggingDestinations",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineLogging: &v1alpha1.LoggingConfig{
+						LoggingDestinations: []v1alpha1.LoggingDestination{
+							{
+								LoggingEndpoint: &v1alpha1.Endpoint{
+									URL: &url.URL{Scheme: "tls", Host: "logs.example.com:6514"},
+								},
+								LoggingFormat: "json_lines",
+							},
+							{
+								LoggingEndpoint: &v1alpha1.Endpoint{
+									URL: &url.URL{Scheme: "tcp", Host: "logs.example.com:5044"},
+								},
+								LoggingFormat: "json_lines",
+								LoggingCA:     []byte("not a certificate"),
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* logging destination CA is supported only with \"tls\" endpoint scheme\n" +
+				"\t* failed to parse logging destination CA certificate\n\n",
+		},
+		{
+			name: "BadPods",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachinePods: []v1alpha1.Unstructured{
+						{
+							Object: map[string]interface{}{
+								"apiVersion": "v1",
+								"kind":       "Pod",
+								"metadata": map[string]interface{}{
+									"name": "nginx",
+								},
+								"spec": map[string]interface{}{},
+							},
+						},
+						{
+							Object: map[string]interface{}{
+								"apiVersion": "v1",
+								"kind":       "Pod",
+								"metadata": map[string]interface{}{
+									"name":      "nginx",
+									"namespace": "default",
+								},
+								"spec": map[string]interface{}{},
+							},
+						},
+						{
+							Object: map[string]interface{}{
+								"apiVersion": "apps/v1",
+								"kind":       "Deployment",
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "5 errors occurred:\n\t* static pod \"default/nginx\" is duplicate\n" +
+				"\t* static pod 2: unexpected apiVersion \"apps/v1\", expected \"v1\"\n" +
+				"\t* static pod 2: unexpected kind \"Deployment\", expected \"Pod\"\n" +
+				"\t* static pod 2: spec is missing\n" +
+				"\t* static pod 2: metadata.name can't be empty\n\n",
+		},
+		{
+			name: "BadServiceHooks",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineServiceHooks: []v1alpha1.ServiceHook{
+						{
+							HookService: "kubelet",
+							HookStage:   "post",
+							HookCommand: []string{"/var/lib/hooks/register.sh"},
+						},
+						{
+							HookService: "kubelet",
+							HookStage:   "after",
+							HookTimeout: -time.Second,
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* unknown service hook stage \"after\" for service \"kubelet\"\n" +
+				"\t* empty service hook command for service \"kubelet\"\n" +
+				"\t* negative service hook timeout for service \"kubelet\"\n\n",
+		},
+		{
+			name: "BadServiceOverrides",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineServiceOverrides: []v1alpha1.ServiceOverride{
+						{
+							OverrideService: "etcd",
+							OverrideMounts: []v1alpha1.ServiceOverrideMount{
+								{
+									MountSource:      "var/lib/etcd-tuning",
+									MountDestination: "/etc/etcd-tuning",
+								},
+							},
+						},
+						{
+							OverrideService: "etcd",
+							OverrideRlimits: []v1alpha1.ServiceOverrideRlimit{
+								{
+									RlimitType: "RLIMIT_NOFILE",
+									RlimitSoft: 1024,
+									RlimitHard: 512,
+								},
+							},
+						},
+						{
+							OverrideService: "udevd",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* service \"etcd\" mount \"var/lib/etcd-tuning\" -> \"/etc/etcd-tuning\" should use absolute paths\n" +
+				"\t* duplicate service override for service \"etcd\"\n" +
+				"\t* rlimit \"RLIMIT_NOFILE\" soft limit is greater than the hard limit for service \"etcd\"\n" +
+				"\t* service \"udevd\" doesn't support overrides\n\n",
+		},
+		{
+			name: "BadIMAPolicy",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineIMA: &v1alpha1.IMAConfig{
+						IMAPolicy: []string{
+							"measure func=BPRM_CHECK mask=MAY_EXEC",
+							"measure func=MODULE_CHECK\nmeasure func=FIRMWARE_CHECK",
+							" ",
+							"collect func=BPRM_CHECK",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* IMA policy rule \"measure func=MODULE_CHECK\\nmeasure func=FIRMWARE_CHECK\" should be a single line\n" +
+				"\t* IMA policy rule can't be empty\n" +
+				"\t* IMA policy rule \"collect func=BPRM_CHECK\" has unknown action \"collect\"\n\n",
+		},
+		{
+			name: "BadAPITLS",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineAPITLS: &v1alpha1.APITLSConfig{
+						APITLSMinVersion: "1.1",
+						APITLSCipherSuites: []string{
+							"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+							"TLS_RSA_WITH_RC4_128_SHA",
+						},
+						APITLSAcceptedCAs: []v1alpha1.Base64Bytes{
+							[]byte("not a certificate"),
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* unsupported API TLS min version \"1.1\"\n" +
+				"\t* unsupported API TLS cipher suite \"TLS_RSA_WITH_RC4_128_SHA\"\n" +
+				"\t* API TLS accepted CA #0 is not a PEM-encoded certificate\n\n",
+		},
+		{
+			name: "BMCPowerControlWithoutEnabled",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineBMC: &v1alpha1.BMCConfig{
+						BMCAllowPowerControl: true,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* BMC power control requires BMC integration to be enabled\n\n",
+		},
+		{
+			name: "LifecycleWebhook",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineLifecycleWebhook: &v1alpha1.LifecycleWebhookConfig{
+						WebhookEndpoint: &v1alpha1.Endpoint{
+							URL: &url.URL{Scheme: "https", Host: "cmdb.example.com", Path: "/events"},
+						},
+						WebhookSecret: "secret",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "LifecycleWebhookInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineLifecycleWebhook: &v1alpha1.LifecycleWebhookConfig{
+						WebhookEndpoint: &v1alpha1.Endpoint{
+							URL: &url.URL{Scheme: "http", Host: "cmdb.example.com"},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* lifecycle webhook endpoint should use https, got \"http\"\n" +
+				"\t* lifecycle webhook secret is required\n\n",
+		},
+		{
+			name: "NodeLabelRules",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNodeLabelRules: []v1alpha1.NodeLabelRuleConfig{
+						{
+							RuleLabel: "example.com/gpu",
+							RuleValue: "nvidia",
+							RuleMatch: v1alpha1.NodeLabelMatchConfig{
+								MatchGPUVendor: "NVIDIA",
+							},
+						},
+						{
+							RuleLabel: "node.kubernetes.io/fast-storage",
+							RuleMatch: v1alpha1.NodeLabelMatchConfig{
+								MatchDiskClass:   "nvme",
+								MatchMinNICSpeed: 25000,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeLabelRulesInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNodeLabelRules: []v1alpha1.NodeLabelRuleConfig{
+						{
+							RuleLabel: "node-role.kubernetes.io/gpu",
+							RuleMatch: v1alpha1.NodeLabelMatchConfig{
+								MatchGPUModel: "nvidia",
+							},
+						},
+						{
+							RuleLabel: "example.com/storage",
+							RuleValue: "fast storage",
+							RuleMatch: v1alpha1.NodeLabelMatchConfig{
+								MatchDiskClass: "tape",
+							},
+						},
+						{
+							RuleLabel: "example.com/any",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "5 errors occurred:\n\t* node label \"node-role.kubernetes.io/gpu\" prefix is reserved for Kubernetes\n" +
+				"\t* node label \"node-role.kubernetes.io/gpu\" GPU model \"nvidia\" should be PCI vendor and device IDs (e.g. 10de:20b0)\n" +
+				"\t* node label \"example.com/storage\" value \"fast storage\" is invalid\n" +
+				"\t* node label \"example.com/storage\" disk class \"tape\" is invalid, expected nvme, ssd or hdd\n" +
+				"\t* node label \"example.com/any\" rule should match at least one hardware condition\n\n",
+		},
+		{
+			name: "NodeLabelsTaints",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNodeLabels: map[string]string{
+						"example.com/rack":                                        "r12",
+						"topology.kubernetes.io/zone":                             "us-east-1a",
+						"node.kubernetes.io/exclude-from-external-load-balancers": "",
+					},
+					MachineNodeTaints: map[string]string{
+						"example.com/dedicated": "ingress:NoSchedule",
+						"example.com/gpu":       "NoExecute",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeLabelsTaintsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineNodeLabels: map[string]string{
+						"example.com/rack":            "rack 12",
+						"node-role.kubernetes.io/gpu": "",
+					},
+					MachineNodeTaints: map[string]string{
+						"example.com/dedicated": "ingress:NoWay",
+						"example.com/gpu":       "",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* node label \"example.com/rack\" value \"rack 12\" is invalid\n" +
+				"\t* node label \"node-role.kubernetes.io/gpu\" prefix is reserved for Kubernetes\n" +
+				"\t* node taint \"example.com/dedicated\" effect \"NoWay\" is invalid, expected NoSchedule, PreferNoSchedule or NoExecute\n" +
+				"\t* node taint \"example.com/gpu\" effect \"\" is invalid, expected NoSchedule, PreferNoSchedule or NoExecute\n\n",
+		},
+		{
+			name: "KubeletNodeIPPreferredFamily",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletNodeIP: v1alpha1.KubeletNodeIPConfig{
+							KubeletNodeIPPreferredFamily: "inet6",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* kubelet node IP preferred family \"inet6\" is invalid, expected \"ipv4\" or \"ipv6\"\n\n",
+		},
+		{
+			name: "KubeletReserved",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletSystemReserved: map[string]string{
+							"cpu":    "500m",
+							"memory": "1.5Gi",
+							"pid":    "1000",
+						},
+						KubeletKubeReserved: map[string]string{
+							"ephemeral-storage": "1e9",
+							"memory":            "512Mi",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KubeletReservedInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletSystemReserved: map[string]string{
+							"cpu":    "half",
+							"memory": "-128Mi",
+						},
+						KubeletKubeReserved: map[string]string{
+							"nvidia.com/gpu": "1",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* kubelet systemReserved \"cpu\" value \"half\" is not a valid resource quantity\n" +
+				"\t* kubelet systemReserved \"memory\" value \"-128Mi\" is not a valid resource quantity\n" +
+				"\t* kubelet kubeReserved resource \"nvidia.com/gpu\" is not supported (expected cpu, memory, ephemeral-storage or pid)\n\n",
+		},
+		{
+			name: "KubeletReservedConflicts",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletSystemReserved: map[string]string{
+							"cpu": "500m",
+						},
+						KubeletKubeReserved: map[string]string{
+							"memory": "512Mi",
+						},
+						KubeletExtraArgs: map[string]string{
+							"system-reserved": "cpu=250m",
+						},
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"kubeReserved": map[string]interface{}{
+									"memory": "1Gi",
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* kubelet extra arg \"system-reserved\" conflicts with .machine.kubelet.systemReserved\n" +
+				"\t* kubelet extra config field \"kubeReserved\" conflicts with .machine.kubelet.kubeReserved\n\n",
+		},
+		{
+			name: "KubeletImageGC",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImageGCHighThresholdPercent: 75,
+						KubeletImageGCLowThresholdPercent:  60,
+						KubeletContainerLogMaxSize:         "5Mi",
+						KubeletContainerLogMaxFiles:        3,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KubeletImageGCInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImageGCHighThresholdPercent: 120,
+						KubeletImageGCLowThresholdPercent:  90,
+						KubeletContainerLogMaxSize:         "5 MB",
+						KubeletContainerLogMaxFiles:        1,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* kubelet imageGCHighThresholdPercent 120 is out of range\n" +
+				"\t* kubelet containerLogMaxSize \"5 MB\" is not a valid resource quantity\n" +
+				"\t* kubelet containerLogMaxFiles 1 should be at least 2\n\n",
+		},
+		{
+			name: "KubeletImageGCConflicts",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImageGCHighThresholdPercent: 75,
+						KubeletImageGCLowThresholdPercent:  60,
+						KubeletContainerLogMaxSize:         "5Mi",
+						KubeletContainerLogMaxFiles:        3,
+						KubeletExtraArgs: map[string]string{
+							"image-gc-high-threshold": "80",
+							"container-log-max-files": "4",
+						},
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"imageGCLowThresholdPercent": 50,
+								"containerLogMaxSize":        "10Mi",
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* kubelet extra arg \"image-gc-high-threshold\" conflicts with .machine.kubelet.imageGCHighThresholdPercent\n" +
+				"\t* kubelet extra config field \"imageGCLowThresholdPercent\" conflicts with .machine.kubelet.imageGCLowThresholdPercent\n" +
+				"\t* kubelet extra config field \"containerLogMaxSize\" conflicts with .machine.kubelet.containerLogMaxSize\n" +
+				"\t* kubelet extra arg \"container-log-max-files\" conflicts with .machine.kubelet.containerLogMaxFiles\n\n",
+		},
+		{
+			name: "KubeletImageGCThresholds",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						// default high threshold is 85
+						KubeletImageGCLowThresholdPercent: 90,
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* kubelet imageGCLowThresholdPercent 90 should be lower than imageGCHighThresholdPercent 85\n\n",
+		},
+		{
+			name: "VolumeMountOptions",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineEphemeral: &v1alpha1.EphemeralConfig{
+						EphemeralMountOptions: []string{"nodev", "discard"},
+						EphemeralProjectQuota: true,
+					},
+					MachineDisks: []*v1alpha1.MachineDisk{
+						{
+							DeviceName: "/dev/sdb",
+							DiskPartitions: []*v1alpha1.DiskPartition{
+								{
+									DiskMountPoint:   "/var/mnt/data",
+									DiskMountOptions: []string{"noexec", "largeio"},
+									DiskProjectQuota: true,
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VolumeMountOptionsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineEphemeral: &v1alpha1.EphemeralConfig{
+						EphemeralMountOptions: []string{"noexec"},
+					},
+					MachineDisks: []*v1alpha1.MachineDisk{
+						{
+							DeviceName: "/dev/sdb",
+							DiskPartitions: []*v1alpha1.DiskPartition{
+								{
+									DiskMountPoint:   "/var/mnt/data",
+									DiskMountOptions: []string{"compress=zstd"},
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n" +
+				"\t* partition \"/var/mnt/data\" for disk \"/dev/sdb\": mount option \"compress=zstd\" is not supported, as XFS volumes don't support compression\n" +
+				"\t* ephemeral partition: mount option \"noexec\" is not supported, as the container images are stored on the partition\n\n",
+		},
+		{
+			name: "KubeletResourceManagers",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletCPUManagerPolicy:      "static",
+						KubeletTopologyManagerPolicy: "single-numa-node",
+						KubeletMemoryManagerPolicy:   "Static",
+						KubeletReservedSystemCPUs:    "0-1",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KubeletResourceManagersInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletCPUManagerPolicy:      "Static",
+						KubeletTopologyManagerPolicy: "numa",
+						KubeletMemoryManagerPolicy:   "Static",
+						KubeletReservedSystemCPUs:    "0-a",
+						KubeletExtraArgs: map[string]string{
+							"memory-manager-policy": "None",
+						},
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"reservedSystemCPUs": "0",
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "5 errors occurred:\n" +
+				"\t* kubelet cpuManagerPolicy \"Static\" is invalid, expected one of [\"none\" \"static\"]\n" +
+				"\t* kubelet topologyManagerPolicy \"numa\" is invalid, expected one of [\"none\" \"best-effort\" \"restricted\" \"single-numa-node\"]\n" +
+				"\t* kubelet extra arg \"memory-manager-policy\" conflicts with .machine.kubelet.memoryManagerPolicy\n" +
+				"\t* kubelet reservedSystemCPUs: invalid CPU list \"0-a\"\n" +
+				"\t* kubelet extra config field \"reservedSystemCPUs\" conflicts with .machine.kubelet.reservedSystemCPUs\n\n",
+		},
+		{
+			name: "KubeletStandalone",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletStandalone: true,
+					},
+					MachineNodeLabels: map[string]string{
+						"appliance": "true",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"node labels and taints are ignored in the kubelet standalone mode, as the node is not registered",
+			},
+		},
+		{
+			name: "KubeletStandaloneInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletStandalone:               true,
+						KubeletEnableServerCertRotation: true,
+						KubeletExtraArgs: map[string]string{
+							"register-node": "true",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* kubelet serving certificate rotation is not supported in the standalone mode\n\t* kubelet extra arg \"register-node\" conflicts with .machine.kubelet.standalone\n\t* kubelet standalone mode is supported only on the worker nodes\n\n",
+		},
+		{
+			name: "KubeletPodCIDRs",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletPodCIDRs: []string{"10.244.4.0/23", "fc00:db8:10::/64"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+						DNSDomain: "cluster.local",
+						PodSubnet: []string{"10.244.0.0/16", "fc00:db8:10::/56"},
+					},
+				},
+			},
+		},
+		{
+			name: "KubeletPodCIDRsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletStandalone: true,
+						KubeletPodCIDRs:   []string{"10.96.4.0/23", "fc00:db8:10::/64"},
+						KubeletExtraArgs: map[string]string{
+							"register-node": "true",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+					ClusterNetwork: &v1alpha1.ClusterNetworkConfig{
+						DNSDomain: "cluster.local",
+						PodSubnet: []string{"10.244.0.0/16", "fc00:db8:10::/56"},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* kubelet extra arg \"register-node\" conflicts with .machine.kubelet.standalone\n" +
+				"\t* kubelet pod CIDRs are not supported in the standalone mode\n" +
+				"\t* kubelet extra arg \"register-node\" conflicts with .machine.kubelet.podCIDRs\n" +
+				"\t* kubelet pod CIDR \"10.96.4.0/23\" should be contained in the pod subnet \"10.244.0.0/16\"\n\n",
+		},
+		{
+			name: "KubeletPodCIDRsMismatch",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "worker",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletPodCIDRs: []string{"10.244.4.1/23", "10.244.8.0/23"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "1 error occurred:\n\t* kubelet pod CIDRs [\"10.244.4.1/23\" \"10.244.8.0/23\"] should match the pod subnets [\"10.244.0.0/16\"]\n\n",
+		},
+		{
+			name: "KubeletVersionOptions",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImage: "ghcr.io/talos-systems/kubelet:v1.23.1",
+						KubeletExtraArgs: map[string]string{
+							"network-plugin": "cni",
+						},
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"shutdownGracePeriodByPodPriority": []interface{}{},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KubeletVersionOptionsUnsupported",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImage:               "ghcr.io/talos-systems/kubelet:v1.24.0",
+						KubeletMemoryManagerPolicy: "Static",
+						KubeletExtraArgs: map[string]string{
+							"network-plugin": "cni",
+						},
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"maxParallelImagePulls": 5,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n" +
+				"\t* kubelet flag \"network-plugin\" was removed in Kubernetes 1.24, kubelet image version is 1.24\n" +
+				"\t* kubelet config field \"maxParallelImagePulls\" requires Kubernetes 1.27 or later, kubelet image version is 1.24\n\n",
+		},
+		{
+			name: "KubeletSchemaInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImage: "ghcr.io/talos-systems/kubelet:v1.23.1",
+						KubeletExtraArgs: map[string]string{
+							"max-pods":           "many",
+							"rotate-certificate": "true",
+							"sync-frequency":     "1m",
+						},
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"maxPods":                "110",
+								"serializeImagePull":     false,
+								"imageMinimumGCAge":      "2 minutes",
+								"memoryThrottlingFactor": 0.8,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "5 errors occurred:\n" +
+				"\t* kubelet flag \"max-pods\" value \"many\" is not an integer\n" +
+				"\t* kubelet flag \"rotate-certificate\" is not a known kubelet 1.23 flag\n" +
+				"\t* kubelet config field \"imageMinimumGCAge\" value \"2 minutes\" is not a duration\n" +
+				"\t* kubelet config field \"maxPods\" should be an integer, got string\n" +
+				"\t* kubelet config field \"serializeImagePull\" is not a known KubeletConfiguration field\n\n",
+		},
+		{
+			name: "KubeletSchemaNewerVersion",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletImage: "ghcr.io/talos-systems/kubelet:v1.28.0",
+						KubeletExtraArgs: map[string]string{
+							"some-new-flag": "true",
+						},
+						KubeletExtraConfig: v1alpha1.Unstructured{
+							Object: map[string]interface{}{
+								"someNewField": true,
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "HealthChecks",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineHealthChecks: []v1alpha1.HealthCheckConfig{
+						{
+							CheckName: "app",
+							CheckHTTP: &v1alpha1.HealthCheckHTTPConfig{
+								HTTPURL:            "http://127.0.0.1:8080/healthz",
+								HTTPExpectedStatus: 204,
+							},
+							CheckTaint: true,
+						},
+						{
+							CheckName: "postgres",
+							CheckTCP: &v1alpha1.HealthCheckTCPConfig{
+								TCPAddress: "127.0.0.1:5432",
+							},
+							CheckInterval: time.Minute,
+							CheckTimeout:  time.Minute,
+						},
+						{
+							CheckName: "queue",
+							CheckExec: &v1alpha1.HealthCheckExecConfig{
+								ExecKubernetes: true,
+								ExecContainer:  "default/queue-0:queue",
+								ExecCommand:    []string{"/usr/bin/queue-ctl", "ping"},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "HealthChecksInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineHealthChecks: []v1alpha1.HealthCheckConfig{
+						{
+							CheckName: "app",
+							CheckHTTP: &v1alpha1.HealthCheckHTTPConfig{
+								HTTPURL:            "/healthz",
+								HTTPExpectedStatus: 1000,
+							},
+							CheckTCP: &v1alpha1.HealthCheckTCPConfig{
+								TCPAddress: "127.0.0.1:5432",
+							},
+						},
+						{
+							CheckName: "app",
+							CheckExec: &v1alpha1.HealthCheckExecConfig{
+								ExecContainer: "queue",
+							},
+							CheckTimeout: time.Minute,
+						},
+						{
+							CheckName: "DB",
+							CheckTCP: &v1alpha1.HealthCheckTCPConfig{
+								TCPAddress: "127.0.0.1",
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "8 errors occurred:\n\t* health check \"app\" URL should be an absolute http or https URL\n" +
+				"\t* health check \"app\" expected status 1000 is invalid\n" +
+				"\t* health check \"app\" should have exactly one of http, tcp or exec\n" +
+				"\t* duplicate health check \"app\"\n" +
+				"\t* health check \"app\" command is required\n" +
+				"\t* health check \"app\" timeout 1m0s should not exceed the interval 30s\n" +
+				"\t* health check name \"DB\" should be a valid DNS label\n" +
+				"\t* health check \"DB\" address \"127.0.0.1\" should be in host:port format\n\n",
+		},
+		{
+			name: "HugePages",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineHugePages: []v1alpha1.HugePageConfig{
+						{
+							HugePageSize:  "2Mi",
+							HugePageCount: 1024,
+						},
+						{
+							HugePageSize:           "1Gi",
+							HugePageCount:          4,
+							HugePageNUMANode:       pointer.ToInt(0),
+							HugePageSystemReserved: 1,
+						},
+						{
+							HugePageSize:     "1Gi",
+							HugePageCount:    4,
+							HugePageNUMANode: pointer.ToInt(1),
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KernelInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKernel: &v1alpha1.KernelConfig{
+						KernelCPUFrequencyGovernor: "turbo",
+						KernelMaxCState:            pointer.ToInt(-1),
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "2 errors occurred:\n\t* unknown CPU frequency governor \"turbo\"\n\t* negative max C-state\n\n",
+		},
+		{
+			name: "KernelRealtime",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKernel: &v1alpha1.KernelConfig{
+						KernelRealtime: &v1alpha1.RealtimeProfileConfig{
+							RealtimeIsolatedCPUs:     "2-7",
+							RealtimeHousekeepingCPUs: "0,1",
+						},
+					},
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallExtraKernelArgs: []string{"console=ttyS0"},
+					},
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraArgs: map[string]string{
+							"reserved-cpus": "0-1",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "KernelRealtimeInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineKernel: &v1alpha1.KernelConfig{
+						KernelRealtime: &v1alpha1.RealtimeProfileConfig{
+							RealtimeIsolatedCPUs:     "1-7",
+							RealtimeHousekeepingCPUs: "0-1",
+						},
+					},
+					MachineInstall: &v1alpha1.InstallConfig{
+						InstallExtraKernelArgs: []string{"nohz_full=1-7"},
+					},
+					MachineKubelet: &v1alpha1.KubeletConfig{
+						KubeletExtraArgs: map[string]string{
+							"reserved-cpus": "0",
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "3 errors occurred:\n\t* real-time profile CPU 1 is both isolated and housekeeping\n\t* kernel argument \"nohz_full=1-7\" conflicts with the real-time profile\n\t* kubelet reserved CPUs \"0\" should match the real-time profile housekeeping CPUs \"0-1\"\n\n",
+		},
+		{
+			name: "HugePagesInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineHugePages: []v1alpha1.HugePageConfig{
+						{
+							HugePageSize:           "2M",
+							HugePageCount:          1024,
+							HugePageSystemReserved: 16,
+						},
+						{
+							HugePageSize:  "1Gi",
+							HugePageCount: 4,
+						},
+						{
+							HugePageSize:           "1Gi",
+							HugePageCount:          4,
+							HugePageNUMANode:       pointer.ToInt(1),
+							HugePageSystemReserved: 5,
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* hugepage size \"2M\" should be a power of two in binary units (e.g. 2Mi, 1Gi)\n\t* system reserved hugepages of size \"2M\" require NUMA node to be set\n\t* system reserved hugepages of size \"1Gi\" should be between 0 and the hugepage count\n\t* hugepage reservation for size \"1Gi\" should be either global or per NUMA node\n\n",
+		},
+		{
+			name: "Backup",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineBackup: &v1alpha1.BackupConfig{
+						BackupS3: &v1alpha1.BackupS3Config{
+							S3Endpoint:        "https://minio.example.com:9000",
+							S3Region:          "us-east-1",
+							S3Bucket:          "backups",
+							S3AccessKeyID:     "id",
+							S3SecretAccessKey: "secret",
+						},
+						BackupPaths: []v1alpha1.BackupPathConfig{
+							{
+								PathPath:    "/var/mnt/data",
+								PathInclude: []string{"db/**", "*.conf"},
+								PathExclude: []string{"*.tmp"},
+							},
+						},
+						BackupBefore: []string{"reset"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BackupInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineBackup: &v1alpha1.BackupConfig{
+						BackupS3: &v1alpha1.BackupS3Config{
+							S3Endpoint: "minio.example.com",
+							S3Region:   "us-east-1",
+							S3Bucket:   "backups",
+						},
+						BackupPaths: []v1alpha1.BackupPathConfig{
+							{
+								PathPath:    "/etc/kubernetes",
+								PathExclude: []string{"[a-"},
+							},
+							{
+								PathPath: "/var",
+							},
+						},
+						BackupBefore: []string{"reboot"},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "6 errors occurred:\n\t* backup S3 endpoint \"minio.example.com\" should be an http(s) URL\n\t* backup S3 credentials are required\n\t* backup path \"/etc/kubernetes\" should be an absolute path under /var\n\t* invalid backup pattern \"[a-\" for path \"/etc/kubernetes\"\n\t* backup path \"/var\" should be an absolute path under /var\n\t* unsupported backup operation \"reboot\", expected \"reset\" or \"upgrade\"\n\n",
+		},
+		{
+			name: "SecretsResolver",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineBackup: &v1alpha1.BackupConfig{
+						BackupS3: &v1alpha1.BackupS3Config{
+							S3Region:          "us-east-1",
+							S3Bucket:          "backups",
+							S3AccessKeyID:     "secret://vault/talos/backup#accessKeyID",
+							S3SecretAccessKey: "secret://vault/talos/backup#secretAccessKey",
+						},
+						BackupPaths: []v1alpha1.BackupPathConfig{
+							{
+								PathPath: "/var/mnt/data",
+							},
+						},
+					},
+					MachineSecretsResolver: &v1alpha1.SecretsResolverConfig{
+						ResolverBackends: []v1alpha1.SecretsBackendConfig{
+							{
+								BackendName: "vault",
+								BackendVault: &v1alpha1.VaultSecretsBackendConfig{
+									VaultEndpoint:   "https://vault.example.com:8200",
+									VaultAuthMethod: "gcp",
+									VaultRole:       "talos",
+								},
+							},
+						},
+						ResolverFailurePolicy: "stale",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SecretsResolverInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineEnv: map[string]string{
+						"TOKEN": "secret://aws/talos-token",
+						"KEY":   "secret://vault",
+					},
+					MachineSecretsResolver: &v1alpha1.SecretsResolverConfig{
+						ResolverBackends: []v1alpha1.SecretsBackendConfig{
+							{
+								BackendName: "vault",
+								BackendVault: &v1alpha1.VaultSecretsBackendConfig{
+									VaultEndpoint:   "vault.example.com",
+									VaultAuthMethod: "token",
+								},
+							},
+							{
+								BackendName: "vault",
+								BackendAWSSecretsManager: &v1alpha1.AWSSecretsManagerBackendConfig{
+									AWSRegion: "us-east-1",
+								},
+								BackendGCPSecretManager: &v1alpha1.GCPSecretManagerBackendConfig{},
+							},
+						},
+						ResolverFailurePolicy: "ignore",
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "9 errors occurred:\n\t* vault endpoint \"vault.example.com\" should be an http(s) URL\n" +
+				"\t* unsupported vault auth method \"token\", expected \"aws\" or \"gcp\"\n" +
+				"\t* vault role is required\n" +
+				"\t* duplicate secrets backend \"vault\"\n" +
+				"\t* secrets backend \"vault\": GCP project is required\n" +
+				"\t* secrets backend \"vault\" should have exactly one backend type set\n" +
+				"\t* unsupported secrets resolver failure policy \"ignore\", expected \"fail\" or \"stale\"\n" +
+				"\t* \"machine.env[KEY]\": secret reference \"secret://vault\" should have the form secret://<backend>/<path>[#<key>]\n" +
+				"\t* \"machine.env[TOKEN]\": secrets backend \"aws\" is not configured in machine.secretsResolver\n\n",
+		},
+		{
+			name: "RegistryPulls",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineRegistries: v1alpha1.RegistriesConfig{
+						RegistryPulls: &v1alpha1.RegistryPullsConfig{
+							PullMaxConcurrentDownloads: 2,
+							PullBandwidthLimit:         "10MiB",
+							PullMaintenanceWindows: []v1alpha1.MaintenanceWindowConfig{
+								{
+									WindowStart:    "22:30",
+									WindowDuration: 4 * time.Hour,
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RegistryPullsInvalid",
+			config: &v1alpha1.Config{
+				ConfigVersion: "v1alpha1",
+				MachineConfig: &v1alpha1.MachineConfig{
+					MachineType: "controlplane",
+					MachineRegistries: v1alpha1.RegistriesConfig{
+						RegistryPulls: &v1alpha1.RegistryPullsConfig{
+							PullMaxConcurrentDownloads: -1,
+							PullBandwidthLimit:         "fast",
+							PullMaintenanceWindows: []v1alpha1.MaintenanceWindowConfig{
+								{
+									WindowStart:    "25:00",
+									WindowDuration: time.Hour,
+								},
+								{
+									WindowStart: "02:00",
+								},
+							},
+						},
+					},
+				},
+				ClusterConfig: &v1alpha1.ClusterConfig{
+					ControlPlane: &v1alpha1.ControlPlaneConfig{
+						Endpoint: &v1alpha1.Endpoint{
+							endpointURL,
+						},
+					},
+				},
+			},
+			expectedError: "4 errors occurred:\n\t* image pull max concurrent downloads should not be negative\n\t* invalid image pull bandwidth limit \"fast\": strconv.ParseFloat: parsing \"\": invalid syntax\n\t* invalid maintenance window start \"25:00\", expected HH:MM\n\t* maintenance window \"02:00\" duration should be between 0 and 24h\n\n",
+		},
 	} {
 		test := test
 
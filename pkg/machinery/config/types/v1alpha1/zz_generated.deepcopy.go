@@ -42,6 +42,96 @@ func (in *APIServerConfig) DeepCopy() *APIServerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APITLSCertificateConfig) DeepCopyInto(out *APITLSCertificateConfig) {
+	*out = *in
+	if in.CertificateCrt != nil {
+		in, out := &in.CertificateCrt, &out.CertificateCrt
+		*out = make(Base64Bytes, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateKey != nil {
+		in, out := &in.CertificateKey, &out.CertificateKey
+		*out = make(Base64Bytes, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateChain != nil {
+		in, out := &in.CertificateChain, &out.CertificateChain
+		*out = make([]Base64Bytes, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(Base64Bytes, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APITLSCertificateConfig.
+func (in *APITLSCertificateConfig) DeepCopy() *APITLSCertificateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(APITLSCertificateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APITLSConfig) DeepCopyInto(out *APITLSConfig) {
+	*out = *in
+	if in.APITLSCipherSuites != nil {
+		in, out := &in.APITLSCipherSuites, &out.APITLSCipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APITLSAcceptedCAs != nil {
+		in, out := &in.APITLSAcceptedCAs, &out.APITLSAcceptedCAs
+		*out = make([]Base64Bytes, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(Base64Bytes, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.APITLSServerCertificate != nil {
+		in, out := &in.APITLSServerCertificate, &out.APITLSServerCertificate
+		*out = new(APITLSCertificateConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APITLSConfig.
+func (in *APITLSConfig) DeepCopy() *APITLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(APITLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecretsManagerBackendConfig) DeepCopyInto(out *AWSSecretsManagerBackendConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecretsManagerBackendConfig.
+func (in *AWSSecretsManagerBackendConfig) DeepCopy() *AWSSecretsManagerBackendConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecretsManagerBackendConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdminKubeconfigConfig) DeepCopyInto(out *AdminKubeconfigConfig) {
 	*out = *in
@@ -58,6 +148,97 @@ func (in *AdminKubeconfigConfig) DeepCopy() *AdminKubeconfigConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BMCConfig) DeepCopyInto(out *BMCConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCConfig.
+func (in *BMCConfig) DeepCopy() *BMCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BMCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
+	*out = *in
+	if in.BackupS3 != nil {
+		in, out := &in.BackupS3, &out.BackupS3
+		*out = new(BackupS3Config)
+		**out = **in
+	}
+	if in.BackupPaths != nil {
+		in, out := &in.BackupPaths, &out.BackupPaths
+		*out = make([]BackupPathConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackupBefore != nil {
+		in, out := &in.BackupBefore, &out.BackupBefore
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfig.
+func (in *BackupConfig) DeepCopy() *BackupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPathConfig) DeepCopyInto(out *BackupPathConfig) {
+	*out = *in
+	if in.PathInclude != nil {
+		in, out := &in.PathInclude, &out.PathInclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PathExclude != nil {
+		in, out := &in.PathExclude, &out.PathExclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPathConfig.
+func (in *BackupPathConfig) DeepCopy() *BackupPathConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPathConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupS3Config) DeepCopyInto(out *BackupS3Config) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupS3Config.
+func (in *BackupS3Config) DeepCopy() *BackupS3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupS3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Base64Bytes) DeepCopyInto(out *Base64Bytes) {
 	{
@@ -130,6 +311,32 @@ func (in *CNIConfig) DeepCopy() *CNIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRIConfig) DeepCopyInto(out *CRIConfig) {
+	*out = *in
+	if in.CRIHostPathPolicy != nil {
+		in, out := &in.CRIHostPathPolicy, &out.CRIHostPathPolicy
+		*out = new(HostPathPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CRIImagePolicy != nil {
+		in, out := &in.CRIImagePolicy, &out.CRIImagePolicy
+		*out = new(ImagePolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRIConfig.
+func (in *CRIConfig) DeepCopy() *CRIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CRIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
@@ -213,6 +420,11 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(AdminKubeconfigConfig)
 		**out = **in
 	}
+	if in.ClusterInstanceIdentity != nil {
+		in, out := &in.ClusterInstanceIdentity, &out.ClusterInstanceIdentity
+		*out = new(InstanceIdentityConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -297,6 +509,11 @@ func (in *ClusterNetworkConfig) DeepCopyInto(out *ClusterNetworkConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodSubnetSize != nil {
+		in, out := &in.PodSubnetSize, &out.PodSubnetSize
+		*out = new(PodSubnetSizeConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -323,6 +540,13 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(ClusterConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecretReferences != nil {
+		in, out := &in.SecretReferences, &out.SecretReferences
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -336,6 +560,22 @@ func (in *Config) DeepCopy() *Config {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConntrackConfig) DeepCopyInto(out *ConntrackConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConntrackConfig.
+func (in *ConntrackConfig) DeepCopy() *ConntrackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConntrackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 	*out = *in
@@ -343,6 +583,10 @@ func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 		in, out := &in.Endpoint, &out.Endpoint
 		*out = (*in).DeepCopy()
 	}
+	if in.InternalEndpoint != nil {
+		in, out := &in.InternalEndpoint, &out.InternalEndpoint
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -356,6 +600,27 @@ func (in *ControlPlaneConfig) DeepCopy() *ControlPlaneConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneLoadBalancerConfig) DeepCopyInto(out *ControlPlaneLoadBalancerConfig) {
+	*out = *in
+	if in.ControlPlaneLoadBalancerEnabled != nil {
+		in, out := &in.ControlPlaneLoadBalancerEnabled, &out.ControlPlaneLoadBalancerEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneLoadBalancerConfig.
+func (in *ControlPlaneLoadBalancerConfig) DeepCopy() *ControlPlaneLoadBalancerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneLoadBalancerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerManagerConfig) DeepCopyInto(out *ControllerManagerConfig) {
 	*out = *in
@@ -476,6 +741,16 @@ func (in *Device) DeepCopyInto(out *Device) {
 		*out = new(DeviceVIPConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DeviceTuning != nil {
+		in, out := &in.DeviceTuning, &out.DeviceTuning
+		*out = new(DeviceTuningConfig)
+		**out = **in
+	}
+	if in.DeviceTrafficShaping != nil {
+		in, out := &in.DeviceTrafficShaping, &out.DeviceTrafficShaping
+		*out = new(TrafficShapingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -489,6 +764,22 @@ func (in *Device) DeepCopy() *Device {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceTuningConfig) DeepCopyInto(out *DeviceTuningConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceTuningConfig.
+func (in *DeviceTuningConfig) DeepCopy() *DeviceTuningConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceTuningConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceVIPConfig) DeepCopyInto(out *DeviceVIPConfig) {
 	*out = *in
@@ -584,6 +875,11 @@ func (in *DiscoveryRegistriesConfig) DeepCopy() *DiscoveryRegistriesConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskPartition) DeepCopyInto(out *DiskPartition) {
 	*out = *in
+	if in.DiskMountOptions != nil {
+		in, out := &in.DiskMountOptions, &out.DiskMountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -687,6 +983,27 @@ func (in *EncryptionKeyStatic) DeepCopy() *EncryptionKeyStatic {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralConfig) DeepCopyInto(out *EphemeralConfig) {
+	*out = *in
+	if in.EphemeralMountOptions != nil {
+		in, out := &in.EphemeralMountOptions, &out.EphemeralMountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralConfig.
+func (in *EphemeralConfig) DeepCopy() *EphemeralConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdConfig) DeepCopyInto(out *EtcdConfig) {
 	*out = *in
@@ -701,6 +1018,16 @@ func (in *EtcdConfig) DeepCopyInto(out *EtcdConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.EtcdAdvertisedSubnets != nil {
+		in, out := &in.EtcdAdvertisedSubnets, &out.EtcdAdvertisedSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EtcdListenSubnets != nil {
+		in, out := &in.EtcdListenSubnets, &out.EtcdListenSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -764,6 +1091,31 @@ func (in *FeaturesConfig) DeepCopyInto(out *FeaturesConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.KubeletTrustdCredentials != nil {
+		in, out := &in.KubeletTrustdCredentials, &out.KubeletTrustdCredentials
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RetireStaticBootstrapToken != nil {
+		in, out := &in.RetireStaticBootstrapToken, &out.RetireStaticBootstrapToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KubeletDefaultRuntimeSeccompProfile != nil {
+		in, out := &in.KubeletDefaultRuntimeSeccompProfile, &out.KubeletDefaultRuntimeSeccompProfile
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StableHostname != nil {
+		in, out := &in.StableHostname, &out.StableHostname
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ControlPlaneLoadBalancerSupport != nil {
+		in, out := &in.ControlPlaneLoadBalancerSupport, &out.ControlPlaneLoadBalancerSupport
+		*out = new(ControlPlaneLoadBalancerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -778,47 +1130,236 @@ func (in *FeaturesConfig) DeepCopy() *FeaturesConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallConfig) DeepCopyInto(out *InstallConfig) {
+func (in *GCPSecretManagerBackendConfig) DeepCopyInto(out *GCPSecretManagerBackendConfig) {
 	*out = *in
-	if in.InstallDiskSelector != nil {
-		in, out := &in.InstallDiskSelector, &out.InstallDiskSelector
-		*out = new(InstallDiskSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.InstallExtraKernelArgs != nil {
-		in, out := &in.InstallExtraKernelArgs, &out.InstallExtraKernelArgs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallConfig.
-func (in *InstallConfig) DeepCopy() *InstallConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSecretManagerBackendConfig.
+func (in *GCPSecretManagerBackendConfig) DeepCopy() *GCPSecretManagerBackendConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallConfig)
+	out := new(GCPSecretManagerBackendConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallDiskSelector) DeepCopyInto(out *InstallDiskSelector) {
+func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
 	*out = *in
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = (*in).DeepCopy()
+	if in.CheckHTTP != nil {
+		in, out := &in.CheckHTTP, &out.CheckHTTP
+		*out = new(HealthCheckHTTPConfig)
+		**out = **in
+	}
+	if in.CheckTCP != nil {
+		in, out := &in.CheckTCP, &out.CheckTCP
+		*out = new(HealthCheckTCPConfig)
+		**out = **in
+	}
+	if in.CheckExec != nil {
+		in, out := &in.CheckExec, &out.CheckExec
+		*out = new(HealthCheckExecConfig)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallDiskSelector.
-func (in *InstallDiskSelector) DeepCopy() *InstallDiskSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckConfig.
+func (in *HealthCheckConfig) DeepCopy() *HealthCheckConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallDiskSelector)
+	out := new(HealthCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckExecConfig) DeepCopyInto(out *HealthCheckExecConfig) {
+	*out = *in
+	if in.ExecCommand != nil {
+		in, out := &in.ExecCommand, &out.ExecCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckExecConfig.
+func (in *HealthCheckExecConfig) DeepCopy() *HealthCheckExecConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckExecConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckHTTPConfig) DeepCopyInto(out *HealthCheckHTTPConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckHTTPConfig.
+func (in *HealthCheckHTTPConfig) DeepCopy() *HealthCheckHTTPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckHTTPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckTCPConfig) DeepCopyInto(out *HealthCheckTCPConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckTCPConfig.
+func (in *HealthCheckTCPConfig) DeepCopy() *HealthCheckTCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckTCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPathPolicyConfig) DeepCopyInto(out *HostPathPolicyConfig) {
+	*out = *in
+	if in.HostPathAllowedPaths != nil {
+		in, out := &in.HostPathAllowedPaths, &out.HostPathAllowedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPathPolicyConfig.
+func (in *HostPathPolicyConfig) DeepCopy() *HostPathPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPathPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugePageConfig) DeepCopyInto(out *HugePageConfig) {
+	*out = *in
+	if in.HugePageNUMANode != nil {
+		in, out := &in.HugePageNUMANode, &out.HugePageNUMANode
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugePageConfig.
+func (in *HugePageConfig) DeepCopy() *HugePageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HugePageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IMAConfig) DeepCopyInto(out *IMAConfig) {
+	*out = *in
+	if in.IMAPolicy != nil {
+		in, out := &in.IMAPolicy, &out.IMAPolicy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IMAConfig.
+func (in *IMAConfig) DeepCopy() *IMAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IMAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicyConfig) DeepCopyInto(out *ImagePolicyConfig) {
+	*out = *in
+	if in.ImageAllowed != nil {
+		in, out := &in.ImageAllowed, &out.ImageAllowed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageDenied != nil {
+		in, out := &in.ImageDenied, &out.ImageDenied
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicyConfig.
+func (in *ImagePolicyConfig) DeepCopy() *ImagePolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallConfig) DeepCopyInto(out *InstallConfig) {
+	*out = *in
+	if in.InstallDiskSelector != nil {
+		in, out := &in.InstallDiskSelector, &out.InstallDiskSelector
+		*out = new(InstallDiskSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallExtraKernelArgs != nil {
+		in, out := &in.InstallExtraKernelArgs, &out.InstallExtraKernelArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallConfig.
+func (in *InstallConfig) DeepCopy() *InstallConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallDiskSelector) DeepCopyInto(out *InstallDiskSelector) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallDiskSelector.
+func (in *InstallDiskSelector) DeepCopy() *InstallDiskSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallDiskSelector)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -833,6 +1374,58 @@ func (in *InstallDiskSizeMatcher) DeepCopy() *InstallDiskSizeMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceIdentityConfig) DeepCopyInto(out *InstanceIdentityConfig) {
+	*out = *in
+	if in.InstanceIdentityAllowedAccounts != nil {
+		in, out := &in.InstanceIdentityAllowedAccounts, &out.InstanceIdentityAllowedAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceIdentityAWSCertificates != nil {
+		in, out := &in.InstanceIdentityAWSCertificates, &out.InstanceIdentityAWSCertificates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceIdentityConfig.
+func (in *InstanceIdentityConfig) DeepCopy() *InstanceIdentityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceIdentityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelConfig) DeepCopyInto(out *KernelConfig) {
+	*out = *in
+	if in.KernelMaxCState != nil {
+		in, out := &in.KernelMaxCState, &out.KernelMaxCState
+		*out = new(int)
+		**out = **in
+	}
+	if in.KernelRealtime != nil {
+		in, out := &in.KernelRealtime, &out.KernelRealtime
+		*out = new(RealtimeProfileConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelConfig.
+func (in *KernelConfig) DeepCopy() *KernelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
 	*out = *in
@@ -855,7 +1448,37 @@ func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KubeletExtraCapabilities != nil {
+		in, out := &in.KubeletExtraCapabilities, &out.KubeletExtraCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.KubeletNodeIP.DeepCopyInto(&out.KubeletNodeIP)
+	if in.KubeletCredentialProviders != nil {
+		in, out := &in.KubeletCredentialProviders, &out.KubeletCredentialProviders
+		*out = new(KubeletCredentialProvidersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.KubeletExtraConfig.DeepCopyInto(&out.KubeletExtraConfig)
+	if in.KubeletSystemReserved != nil {
+		in, out := &in.KubeletSystemReserved, &out.KubeletSystemReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubeletKubeReserved != nil {
+		in, out := &in.KubeletKubeReserved, &out.KubeletKubeReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubeletPodCIDRs != nil {
+		in, out := &in.KubeletPodCIDRs, &out.KubeletPodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -869,6 +1492,62 @@ func (in *KubeletConfig) DeepCopy() *KubeletConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletCredentialProviderConfig) DeepCopyInto(out *KubeletCredentialProviderConfig) {
+	*out = *in
+	if in.CredentialProviderMatchImages != nil {
+		in, out := &in.CredentialProviderMatchImages, &out.CredentialProviderMatchImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialProviderArgs != nil {
+		in, out := &in.CredentialProviderArgs, &out.CredentialProviderArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialProviderEnv != nil {
+		in, out := &in.CredentialProviderEnv, &out.CredentialProviderEnv
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletCredentialProviderConfig.
+func (in *KubeletCredentialProviderConfig) DeepCopy() *KubeletCredentialProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletCredentialProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletCredentialProvidersConfig) DeepCopyInto(out *KubeletCredentialProvidersConfig) {
+	*out = *in
+	if in.CredentialProviders != nil {
+		in, out := &in.CredentialProviders, &out.CredentialProviders
+		*out = make([]KubeletCredentialProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletCredentialProvidersConfig.
+func (in *KubeletCredentialProvidersConfig) DeepCopy() *KubeletCredentialProvidersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletCredentialProvidersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeletNodeIPConfig) DeepCopyInto(out *KubeletNodeIPConfig) {
 	*out = *in
@@ -890,6 +1569,26 @@ func (in *KubeletNodeIPConfig) DeepCopy() *KubeletNodeIPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleWebhookConfig) DeepCopyInto(out *LifecycleWebhookConfig) {
+	*out = *in
+	if in.WebhookEndpoint != nil {
+		in, out := &in.WebhookEndpoint, &out.WebhookEndpoint
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleWebhookConfig.
+func (in *LifecycleWebhookConfig) DeepCopy() *LifecycleWebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleWebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggingConfig) DeepCopyInto(out *LoggingConfig) {
 	*out = *in
@@ -900,6 +1599,16 @@ func (in *LoggingConfig) DeepCopyInto(out *LoggingConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LoggingRateLimits != nil {
+		in, out := &in.LoggingRateLimits, &out.LoggingRateLimits
+		*out = make([]LoggingRateLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoggingSessionRecording != nil {
+		in, out := &in.LoggingSessionRecording, &out.LoggingSessionRecording
+		*out = new(SessionRecordingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -920,6 +1629,11 @@ func (in *LoggingDestination) DeepCopyInto(out *LoggingDestination) {
 		in, out := &in.LoggingEndpoint, &out.LoggingEndpoint
 		*out = (*in).DeepCopy()
 	}
+	if in.LoggingCA != nil {
+		in, out := &in.LoggingCA, &out.LoggingCA
+		*out = make(Base64Bytes, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -933,6 +1647,22 @@ func (in *LoggingDestination) DeepCopy() *LoggingDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingRateLimit) DeepCopyInto(out *LoggingRateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingRateLimit.
+func (in *LoggingRateLimit) DeepCopy() *LoggingRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineConfig) DeepCopyInto(out *MachineConfig) {
 	*out = *in
@@ -971,6 +1701,11 @@ func (in *MachineConfig) DeepCopyInto(out *MachineConfig) {
 			}
 		}
 	}
+	if in.MachineEphemeral != nil {
+		in, out := &in.MachineEphemeral, &out.MachineEphemeral
+		*out = new(EphemeralConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MachineInstall != nil {
 		in, out := &in.MachineInstall, &out.MachineInstall
 		*out = new(InstallConfig)
@@ -1027,6 +1762,102 @@ func (in *MachineConfig) DeepCopyInto(out *MachineConfig) {
 		*out = new(LoggingConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MachineServiceHooks != nil {
+		in, out := &in.MachineServiceHooks, &out.MachineServiceHooks
+		*out = make([]ServiceHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineServiceOverrides != nil {
+		in, out := &in.MachineServiceOverrides, &out.MachineServiceOverrides
+		*out = make([]ServiceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineIMA != nil {
+		in, out := &in.MachineIMA, &out.MachineIMA
+		*out = new(IMAConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineAPITLS != nil {
+		in, out := &in.MachineAPITLS, &out.MachineAPITLS
+		*out = new(APITLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineBMC != nil {
+		in, out := &in.MachineBMC, &out.MachineBMC
+		*out = new(BMCConfig)
+		**out = **in
+	}
+	if in.MachineHugePages != nil {
+		in, out := &in.MachineHugePages, &out.MachineHugePages
+		*out = make([]HugePageConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineKernel != nil {
+		in, out := &in.MachineKernel, &out.MachineKernel
+		*out = new(KernelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineLifecycleWebhook != nil {
+		in, out := &in.MachineLifecycleWebhook, &out.MachineLifecycleWebhook
+		*out = new(LifecycleWebhookConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineNodeLabelRules != nil {
+		in, out := &in.MachineNodeLabelRules, &out.MachineNodeLabelRules
+		*out = make([]NodeLabelRuleConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineNodeLabels != nil {
+		in, out := &in.MachineNodeLabels, &out.MachineNodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MachineNodeTaints != nil {
+		in, out := &in.MachineNodeTaints, &out.MachineNodeTaints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MachineHealthChecks != nil {
+		in, out := &in.MachineHealthChecks, &out.MachineHealthChecks
+		*out = make([]HealthCheckConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineBackup != nil {
+		in, out := &in.MachineBackup, &out.MachineBackup
+		*out = new(BackupConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineCRI != nil {
+		in, out := &in.MachineCRI, &out.MachineCRI
+		*out = new(CRIConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineSecretsResolver != nil {
+		in, out := &in.MachineSecretsResolver, &out.MachineSecretsResolver
+		*out = new(SecretsResolverConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachinePods != nil {
+		in, out := &in.MachinePods, &out.MachinePods
+		*out = make([]Unstructured, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1092,7 +1923,7 @@ func (in *MachineDisk) DeepCopyInto(out *MachineDisk) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(DiskPartition)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
@@ -1141,6 +1972,22 @@ func (in *MachineSchedulerConfig) DeepCopy() *MachineSchedulerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowConfig) DeepCopyInto(out *MaintenanceWindowConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowConfig.
+func (in *MaintenanceWindowConfig) DeepCopy() *MaintenanceWindowConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
 	*out = *in
@@ -1172,6 +2019,21 @@ func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
 		}
 	}
 	out.NetworkKubeSpan = in.NetworkKubeSpan
+	if in.NetworkAdvertisedSubnets != nil {
+		in, out := &in.NetworkAdvertisedSubnets, &out.NetworkAdvertisedSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkIgnoredSubnets != nil {
+		in, out := &in.NetworkIgnoredSubnets, &out.NetworkIgnoredSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkConntrack != nil {
+		in, out := &in.NetworkConntrack, &out.NetworkConntrack
+		*out = new(ConntrackConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -1201,6 +2063,44 @@ func (in *NetworkKubeSpan) DeepCopy() *NetworkKubeSpan {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelMatchConfig) DeepCopyInto(out *NodeLabelMatchConfig) {
+	*out = *in
+	if in.MatchCPUFlags != nil {
+		in, out := &in.MatchCPUFlags, &out.MatchCPUFlags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelMatchConfig.
+func (in *NodeLabelMatchConfig) DeepCopy() *NodeLabelMatchConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelMatchConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelRuleConfig) DeepCopyInto(out *NodeLabelRuleConfig) {
+	*out = *in
+	in.RuleMatch.DeepCopyInto(&out.RuleMatch)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelRuleConfig.
+func (in *NodeLabelRuleConfig) DeepCopy() *NodeLabelRuleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelRuleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodCheckpointer) DeepCopyInto(out *PodCheckpointer) {
 	*out = *in
@@ -1217,6 +2117,22 @@ func (in *PodCheckpointer) DeepCopy() *PodCheckpointer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSubnetSizeConfig) DeepCopyInto(out *PodSubnetSizeConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSubnetSizeConfig.
+func (in *PodSubnetSizeConfig) DeepCopy() *PodSubnetSizeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSubnetSizeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
 	*out = *in
@@ -1240,6 +2156,22 @@ func (in *ProxyConfig) DeepCopy() *ProxyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealtimeProfileConfig) DeepCopyInto(out *RealtimeProfileConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealtimeProfileConfig.
+func (in *RealtimeProfileConfig) DeepCopy() *RealtimeProfileConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RealtimeProfileConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistriesConfig) DeepCopyInto(out *RegistriesConfig) {
 	*out = *in
@@ -1273,6 +2205,11 @@ func (in *RegistriesConfig) DeepCopyInto(out *RegistriesConfig) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.RegistryPulls != nil {
+		in, out := &in.RegistryPulls, &out.RegistryPulls
+		*out = new(RegistryPullsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1365,6 +2302,27 @@ func (in *RegistryMirrorConfig) DeepCopy() *RegistryMirrorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryPullsConfig) DeepCopyInto(out *RegistryPullsConfig) {
+	*out = *in
+	if in.PullMaintenanceWindows != nil {
+		in, out := &in.PullMaintenanceWindows, &out.PullMaintenanceWindows
+		*out = make([]MaintenanceWindowConfig, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryPullsConfig.
+func (in *RegistryPullsConfig) DeepCopy() *RegistryPullsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryPullsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryServiceConfig) DeepCopyInto(out *RegistryServiceConfig) {
 	*out = *in
@@ -1450,6 +2408,167 @@ func (in *SchedulerConfig) DeepCopy() *SchedulerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsBackendConfig) DeepCopyInto(out *SecretsBackendConfig) {
+	*out = *in
+	if in.BackendVault != nil {
+		in, out := &in.BackendVault, &out.BackendVault
+		*out = new(VaultSecretsBackendConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackendAWSSecretsManager != nil {
+		in, out := &in.BackendAWSSecretsManager, &out.BackendAWSSecretsManager
+		*out = new(AWSSecretsManagerBackendConfig)
+		**out = **in
+	}
+	if in.BackendGCPSecretManager != nil {
+		in, out := &in.BackendGCPSecretManager, &out.BackendGCPSecretManager
+		*out = new(GCPSecretManagerBackendConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsBackendConfig.
+func (in *SecretsBackendConfig) DeepCopy() *SecretsBackendConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsBackendConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsResolverConfig) DeepCopyInto(out *SecretsResolverConfig) {
+	*out = *in
+	if in.ResolverBackends != nil {
+		in, out := &in.ResolverBackends, &out.ResolverBackends
+		*out = make([]SecretsBackendConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsResolverConfig.
+func (in *SecretsResolverConfig) DeepCopy() *SecretsResolverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsResolverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceHook) DeepCopyInto(out *ServiceHook) {
+	*out = *in
+	if in.HookCommand != nil {
+		in, out := &in.HookCommand, &out.HookCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceHook.
+func (in *ServiceHook) DeepCopy() *ServiceHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceOverride) DeepCopyInto(out *ServiceOverride) {
+	*out = *in
+	if in.OverrideEnv != nil {
+		in, out := &in.OverrideEnv, &out.OverrideEnv
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OverrideMounts != nil {
+		in, out := &in.OverrideMounts, &out.OverrideMounts
+		*out = make([]ServiceOverrideMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.OverrideRlimits != nil {
+		in, out := &in.OverrideRlimits, &out.OverrideRlimits
+		*out = make([]ServiceOverrideRlimit, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceOverride.
+func (in *ServiceOverride) DeepCopy() *ServiceOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceOverrideMount) DeepCopyInto(out *ServiceOverrideMount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceOverrideMount.
+func (in *ServiceOverrideMount) DeepCopy() *ServiceOverrideMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceOverrideMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceOverrideRlimit) DeepCopyInto(out *ServiceOverrideRlimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceOverrideRlimit.
+func (in *ServiceOverrideRlimit) DeepCopy() *ServiceOverrideRlimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceOverrideRlimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionRecordingConfig) DeepCopyInto(out *SessionRecordingConfig) {
+	*out = *in
+	if in.SessionRecordingRedact != nil {
+		in, out := &in.SessionRecordingRedact, &out.SessionRecordingRedact
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionRecordingConfig.
+func (in *SessionRecordingConfig) DeepCopy() *SessionRecordingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionRecordingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemDiskEncryptionConfig) DeepCopyInto(out *SystemDiskEncryptionConfig) {
 	*out = *in
@@ -1497,6 +2616,55 @@ func (in *TimeConfig) DeepCopy() *TimeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficClassConfig) DeepCopyInto(out *TrafficClassConfig) {
+	*out = *in
+	if in.TrafficClassPorts != nil {
+		in, out := &in.TrafficClassPorts, &out.TrafficClassPorts
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.TrafficClassSubnets != nil {
+		in, out := &in.TrafficClassSubnets, &out.TrafficClassSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficClassConfig.
+func (in *TrafficClassConfig) DeepCopy() *TrafficClassConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficClassConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficShapingConfig) DeepCopyInto(out *TrafficShapingConfig) {
+	*out = *in
+	if in.TrafficShapingClasses != nil {
+		in, out := &in.TrafficShapingClasses, &out.TrafficShapingClasses
+		*out = make([]TrafficClassConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficShapingConfig.
+func (in *TrafficShapingConfig) DeepCopy() *TrafficShapingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficShapingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UdevConfig) DeepCopyInto(out *UdevConfig) {
 	*out = *in
@@ -1518,6 +2686,16 @@ func (in *UdevConfig) DeepCopy() *UdevConfig {
 	return out
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Unstructured.
+func (in *Unstructured) DeepCopy() *Unstructured {
+	if in == nil {
+		return nil
+	}
+	out := new(Unstructured)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VIPEquinixMetalConfig) DeepCopyInto(out *VIPEquinixMetalConfig) {
 	*out = *in
@@ -1550,6 +2728,27 @@ func (in *VIPHCloudConfig) DeepCopy() *VIPHCloudConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretsBackendConfig) DeepCopyInto(out *VaultSecretsBackendConfig) {
+	*out = *in
+	if in.VaultCA != nil {
+		in, out := &in.VaultCA, &out.VaultCA
+		*out = make(Base64Bytes, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretsBackendConfig.
+func (in *VaultSecretsBackendConfig) DeepCopy() *VaultSecretsBackendConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretsBackendConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Vlan) DeepCopyInto(out *Vlan) {
 	*out = *in
@@ -76,6 +76,13 @@ const (
 	// KernelParamSideroLink is the kernel paramater name to specify SideroLink API endpoint.
 	KernelParamSideroLink = "siderolink.api"
 
+	// KernelParamHardenedSeccomp is the kernel parameter name to disable hardened seccomp profile
+	// for Talos API daemons (apid, trustd) by setting it to `0`.
+	KernelParamHardenedSeccomp = "talos.seccomp.hardened"
+
+	// KernelParamVerityRootHash is the kernel parameter name to specify the dm-verity root hash of the rootfs.
+	KernelParamVerityRootHash = "talos.verity.roothash"
+
 	// NewRoot is the path where the switchroot target is mounted.
 	NewRoot = "/root"
 
@@ -131,6 +138,9 @@ const (
 	// KubernetesDefaultCertificateValidityDuration specifies default certificate duration for Kubernetes generated certificates.
 	KubernetesDefaultCertificateValidityDuration = time.Hour * 24 * 365
 
+	// EtcdDefaultCertificateValidityDuration specifies default certificate duration for etcd generated certificates.
+	EtcdDefaultCertificateValidityDuration = time.Hour * 24 * 365
+
 	// DefaultCertificatesDir is the path the the Kubernetes PKI directory.
 	DefaultCertificatesDir = "/etc/kubernetes/pki"
 
@@ -201,9 +211,63 @@ const (
 	// bootstrap the kubelet.
 	KubeletBootstrapKubeconfig = "/etc/kubernetes/bootstrap-kubeconfig"
 
+	// KubeletConfig is the path to the kubelet configuration file.
+	KubeletConfig = "/etc/kubernetes/kubelet.yaml"
+
+	// KubeletBootstrapTokenTTL is the lifetime of the kubelet bootstrap tokens minted by control plane nodes.
+	KubeletBootstrapTokenTTL = time.Hour
+
+	// NodesUsernamePrefix is the prefix of the Kubernetes user names of the nodes (kubelets).
+	NodesUsernamePrefix = "system:node:"
+
+	// NodesGroup is the Kubernetes group of the nodes (kubelets).
+	NodesGroup = "system:nodes"
+
 	// KubeletPort is the kubelet port for secure API.
 	KubeletPort = 10250
 
+	// KubeletHealthzPort is the kubelet port for the localhost healthz endpoint.
+	KubeletHealthzPort = 10248
+
+	// KubeletImageGCHighThresholdPercent is the default disk usage percent after which kubelet image garbage collection is always run.
+	KubeletImageGCHighThresholdPercent = 85
+
+	// KubeletImageGCLowThresholdPercent is the default disk usage percent before which kubelet image garbage collection is never run.
+	KubeletImageGCLowThresholdPercent = 80
+
+	// KubeletContainerLogMaxSize is the default maximum size of the container log file before it is rotated.
+	KubeletContainerLogMaxSize = "10Mi"
+
+	// KubeletContainerLogMaxFiles is the default maximum number of the container log files kept.
+	KubeletContainerLogMaxFiles = 5
+
+	// KubeletCPUManagerPolicy is the default kubelet CPU manager policy.
+	KubeletCPUManagerPolicy = "none"
+
+	// KubeletTopologyManagerPolicy is the default kubelet topology manager policy.
+	KubeletTopologyManagerPolicy = "none"
+
+	// KubeletMemoryManagerPolicy is the default kubelet memory manager policy.
+	KubeletMemoryManagerPolicy = "None"
+
+	// KubeletCPUManagerStateFile is the kubelet CPU manager checkpoint.
+	KubeletCPUManagerStateFile = "/var/lib/kubelet/cpu_manager_state"
+
+	// KubeletMemoryManagerStateFile is the kubelet memory manager checkpoint.
+	KubeletMemoryManagerStateFile = "/var/lib/kubelet/memory_manager_state"
+
+	// KubeletCredentialProviderBinDir is the default directory with the kubelet image credential provider plugins.
+	KubeletCredentialProviderBinDir = "/usr/local/lib/kubelet/credentialproviders"
+
+	// KubeletSeccompAllowancesDir is the directory with the additional syscall allowances for the kubelet seccomp profile.
+	KubeletSeccompAllowancesDir = "/usr/local/lib/kubelet/seccomp"
+
+	// KubeletCredentialProviderConfig is the path to the kubelet image credential provider plugins config.
+	KubeletCredentialProviderConfig = "/etc/kubernetes/kubelet-credentialproviders.yaml"
+
+	// KubeletCredentialProviderAPIVersion is the default API version of the kubelet image credential provider plugins.
+	KubeletCredentialProviderAPIVersion = "credentialprovider.kubelet.k8s.io/v1alpha1"
+
 	// KubeletOOMScoreAdj oom_score_adj config.
 	KubeletOOMScoreAdj = -450
 
@@ -219,6 +283,9 @@ const (
 	// DefaultControlPlanePort is the default port to use for the control plane.
 	DefaultControlPlanePort = 6443
 
+	// DefaultControlPlaneLoadBalancerPort is the default localhost port of the node-local Kubernetes API server load balancer.
+	DefaultControlPlaneLoadBalancerPort = 7445
+
 	// KubeletImage is the enforced kubelet image to use.
 	KubeletImage = "ghcr.io/talos-systems/kubelet"
 
@@ -270,6 +337,9 @@ const (
 	// KubeletSystemReservedEphemeralStorage ephemeral-storage system reservation value for kubelet kubeconfig.
 	KubeletSystemReservedEphemeralStorage = "256Mi"
 
+	// KubeletEvictionHardMemoryAvailable is the kubelet default hard eviction threshold for the available memory.
+	KubeletEvictionHardMemoryAvailable = "100Mi"
+
 	// DefaultEtcdVersion is the default target version of etcd.
 	DefaultEtcdVersion = "v3.5.1"
 
@@ -282,6 +352,9 @@ const (
 	// EtcdTalosManifestApplyMutex is the etcd election .
 	EtcdTalosManifestApplyMutex = EtcdRootTalosKey + ":manifestApplyMutex"
 
+	// EtcdTalosCertRotationMutex is the etcd mutex used to rotate etcd member certificates one member at a time.
+	EtcdTalosCertRotationMutex = EtcdRootTalosKey + ":certRotationMutex"
+
 	// EtcdImage is the reposistory for the etcd image.
 	EtcdImage = "gcr.io/etcd-development/etcd"
 
@@ -300,6 +373,12 @@ const (
 	// ConfigPath is the path to the downloaded config.
 	ConfigPath = StateMountPoint + "/config.yaml"
 
+	// ConfigLayersPath is the path to the directory with the machine config layers by source.
+	ConfigLayersPath = StateMountPoint + "/config.d"
+
+	// ConfigRevertPath is the path to the pending automatic revert of the config applied via the API.
+	ConfigRevertPath = StateMountPoint + "/config-revert.yaml"
+
 	// MetalConfigISOLabel is the volume label for ISO based configuration.
 	MetalConfigISOLabel = "metal-iso"
 
@@ -336,6 +415,12 @@ const (
 	// TrustdUserID is the user ID for trustd.
 	TrustdUserID = 51
 
+	// TrustdSecretsDir is the directory with the secrets machined passes to trustd.
+	TrustdSecretsDir = "/system/secrets/trustd"
+
+	// TrustdKubeletBootstrapToken is the path to the short-lived kubelet bootstrap token served by trustd.
+	TrustdKubeletBootstrapToken = TrustdSecretsDir + "/" + "kubelet-bootstrap-token.yaml"
+
 	// DefaultContainerdVersion is the default container runtime version.
 	DefaultContainerdVersion = "1.5.8"
 
@@ -390,6 +475,15 @@ const (
 	// RootfsAsset defines a well known name for our rootfs filename.
 	RootfsAsset = "rootfs.sqsh"
 
+	// RootfsRootHashAsset defines a well known name for the rootfs dm-verity root hash filename.
+	RootfsRootHashAsset = "rootfs.roothash"
+
+	// RootfsRootHashAssetPath is the path to the rootfs dm-verity root hash on disk.
+	RootfsRootHashAssetPath = "/usr/install/%s/" + RootfsRootHashAsset
+
+	// RootfsVerityDeviceName is the device-mapper name of the rootfs dm-verity device.
+	RootfsVerityDeviceName = "talos-rootfs"
+
 	// DefaultCertificateValidityDuration is the default duration for a certificate.
 	DefaultCertificateValidityDuration = x509.DefaultCertificateValidityDuration
 
@@ -415,6 +509,15 @@ const (
 	// CgroupMountPath is the default mount path for unified cgroupsv2 setup.
 	CgroupMountPath = "/sys/fs/cgroup"
 
+	// IMAPolicyPath is the path to load the IMA policy.
+	IMAPolicyPath = "/sys/kernel/security/ima/policy"
+
+	// IMAMeasurementsPath is the path to the IMA runtime measurement list.
+	IMAMeasurementsPath = "/sys/kernel/security/ima/binary_runtime_measurements"
+
+	// TPMEventLogPath is the path to the TPM boot event log.
+	TPMEventLogPath = "/sys/kernel/security/tpm0/binary_bios_measurements"
+
 	// CgroupInit is the cgroup name for init process.
 	CgroupInit = "/init"
 
@@ -442,6 +545,11 @@ const (
 	// DefaultIPv4PodNet is the IPv4 network to be used for kubernetes Pods.
 	DefaultIPv4PodNet = "10.244.0.0/16"
 
+	// MaxNodeCIDRMaskSizeDiff is the maximum difference between the pod subnet prefix length and per-node pod subnet prefix length.
+	//
+	// This limit is enforced by kube-controller-manager node IPAM.
+	MaxNodeCIDRMaskSizeDiff = 16
+
 	// DefaultIPv4ServiceNet is the IPv4 network to be used for kubernetes Services.
 	DefaultIPv4ServiceNet = "10.96.0.0/12"
 
@@ -467,12 +575,27 @@ const (
 	// AnnotationCordonedValue is the annotation key for the nodes cordoned by Talos.
 	AnnotationCordonedValue = "true"
 
+	// AnnotationOwnedLabels is the annotation key for the list of the node labels managed by Talos.
+	AnnotationOwnedLabels = "talos.dev/owned-labels"
+
 	// AnnotationStaticPodSecretsVersion is the annotation key for the static pod secret version.
 	AnnotationStaticPodSecretsVersion = "talos.dev/secrets-version"
 
 	// AnnotationStaticPodConfigVersion is the annotation key for the static pod config version.
 	AnnotationStaticPodConfigVersion = "talos.dev/config-version"
 
+	// AnnotationShutdownInhibitPrefix is the annotation key prefix for the node shutdown inhibitors.
+	//
+	// Workloads set the `inhibit.talos.dev/<name>: <reason>` annotation on the node to delay
+	// the reboot or upgrade of the node until they remove the annotation.
+	AnnotationShutdownInhibitPrefix = "inhibit.talos.dev/"
+
+	// ShutdownInhibitMaxDuration is the maximum time the shutdown inhibitors can delay the reboot or upgrade.
+	ShutdownInhibitMaxDuration = 30 * time.Minute
+
+	// ShutdownInhibitCheckInterval is the interval to check whether the shutdown inhibitors were removed.
+	ShutdownInhibitCheckInterval = 10 * time.Second
+
 	// DefaultNTPServer is the NTP server to use if not configured explicitly.
 	//
 	// TODO: Once we get naming sorted we need to apply for a project specific address
@@ -497,6 +620,12 @@ const (
 	// NodeIdentityFilename is the filename to cache node identity across reboots.
 	NodeIdentityFilename = "node-identity.yaml"
 
+	// UpgradeHistoryFilename is the filename to keep install and upgrade history across reboots.
+	UpgradeHistoryFilename = "upgrade-history.yaml"
+
+	// BootDiagnosticsPath is the path to the diagnostics snapshot captured on boot failure.
+	BootDiagnosticsPath = StateMountPoint + "/diagnostics"
+
 	// DefaultDiscoveryServiceEndpoint is the default endpoint for Talos discovery service.
 	DefaultDiscoveryServiceEndpoint = "https://discovery.talos.dev/"
 
@@ -551,6 +680,33 @@ const (
 	// LoggingFormatJSONLines represents "JSON lines" logging format.
 	LoggingFormatJSONLines = "json_lines"
 
+	// AuditLogID is the ID of the log the interactive sessions are recorded to.
+	AuditLogID = "audit"
+
+	// DefaultSessionRecordingMaxBytes is the default limit of the transcript recorded per interactive session.
+	DefaultSessionRecordingMaxBytes = 1 << 20
+
+	// ServiceHookStagePre is the stage of the service hook run before the service is started.
+	ServiceHookStagePre = "pre"
+
+	// ServiceHookStagePost is the stage of the service hook run once the service is up.
+	ServiceHookStagePost = "post"
+
+	// DefaultServiceHookTimeout is the default timeout for the service hook to finish.
+	DefaultServiceHookTimeout = 5 * time.Minute
+
+	// DefaultHealthCheckInterval is the default interval between the user-defined health checks.
+	DefaultHealthCheckInterval = 30 * time.Second
+
+	// DefaultHealthCheckTimeout is the default timeout of a single user-defined health check.
+	DefaultHealthCheckTimeout = 5 * time.Second
+
+	// DefaultHealthCheckFailureThreshold is the default number of consecutive failures for the health check to be unhealthy.
+	DefaultHealthCheckFailureThreshold = 3
+
+	// HealthCheckTaintPrefix is the key prefix of the node taints managed by Talos for failing health checks.
+	HealthCheckTaintPrefix = "health.talos.dev/"
+
 	// SideroLinkName is the interface name for SideroLink.
 	SideroLinkName = "siderolink"
 
@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kernel
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseCPUList parses the kernel CPU list format (e.g. 0-3,8,10-11) into the sorted list of CPUs.
+func ParseCPUList(list string) ([]int, error) {
+	cpus := map[int]struct{}{}
+
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+
+		bounds := strings.SplitN(part, "-", 2)
+
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid CPU list %q", list)
+		}
+
+		last := first
+
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+				return nil, fmt.Errorf("invalid CPU list %q", list)
+			}
+		}
+
+		for cpu := first; cpu <= last; cpu++ {
+			cpus[cpu] = struct{}{}
+		}
+	}
+
+	result := make([]int, 0, len(cpus))
+
+	for cpu := range cpus {
+		result = append(result, cpu)
+	}
+
+	sort.Ints(result)
+
+	return result, nil
+}
+
+// CPUMask formats the list of CPUs as the hex CPU mask (e.g. ff,00000003), as used in /proc/irq.
+func CPUMask(cpus []int) string {
+	var groups []uint32
+
+	for _, cpu := range cpus {
+		for len(groups) <= cpu/32 {
+			groups = append(groups, 0)
+		}
+
+		groups[cpu/32] |= 1 << (cpu % 32)
+	}
+
+	if len(groups) == 0 {
+		return "0"
+	}
+
+	parts := make([]string, 0, len(groups))
+
+	parts = append(parts, strconv.FormatUint(uint64(groups[len(groups)-1]), 16))
+
+	for i := len(groups) - 2; i >= 0; i-- {
+		parts = append(parts, fmt.Sprintf("%08x", groups[i]))
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kernel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talos-systems/talos/pkg/machinery/kernel"
+)
+
+func TestParseCPUList(t *testing.T) {
+	for _, tt := range []struct {
+		list     string
+		expected []int
+	}{
+		{"0", []int{0}},
+		{"0-3", []int{0, 1, 2, 3}},
+		{"8,0-1, 10-11,1", []int{0, 1, 8, 10, 11}},
+	} {
+		cpus, err := kernel.ParseCPUList(tt.list)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.expected, cpus)
+	}
+
+	for _, list := range []string{"", "a", "3-1", "-1", "1,"} {
+		_, err := kernel.ParseCPUList(list)
+		assert.Error(t, err, list)
+	}
+}
+
+func TestCPUMask(t *testing.T) {
+	assert.Equal(t, "0", kernel.CPUMask(nil))
+	assert.Equal(t, "3", kernel.CPUMask([]int{0, 1}))
+	assert.Equal(t, "ffff", kernel.CPUMask([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}))
+	assert.Equal(t, "1,00000003", kernel.CPUMask([]int{0, 1, 32}))
+}
@@ -25,9 +25,12 @@ func TestRegisterResource(t *testing.T) {
 	resourceRegistry := registry.NewResourceRegistry(resources)
 
 	for _, resource := range []resource.Resource{
+		&config.FeatureStatus{},
 		&config.K8sControlPlane{},
 		&config.MachineType{},
 		&config.MachineConfig{},
+		&config.MachineConfigDeprecation{},
+		&config.MachineConfigSource{},
 	} {
 		assert.NoError(t, resourceRegistry.Register(ctx, resource))
 	}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// FeatureStatusType is type of FeatureStatus resource.
+const FeatureStatusType = resource.Type("FeatureStatuses.config.talos.dev")
+
+// FeatureStatus describes effective state of a feature toggled via `.machine.features`.
+//
+// Resource ID is the feature name.
+type FeatureStatus struct {
+	md   resource.Metadata
+	spec FeatureStatusSpec
+}
+
+// FeatureStatusSpec describes effective feature state.
+type FeatureStatusSpec struct {
+	Enabled     bool   `yaml:"enabled"`
+	Value       string `yaml:"value"`
+	Deprecated  bool   `yaml:"deprecated"`
+	Description string `yaml:"description"`
+}
+
+// NewFeatureStatus initializes a FeatureStatus resource.
+func NewFeatureStatus(id resource.ID) *FeatureStatus {
+	r := &FeatureStatus{
+		md:   resource.NewMetadata(NamespaceName, FeatureStatusType, id, resource.VersionUndefined),
+		spec: FeatureStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *FeatureStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *FeatureStatus) Spec() interface{} {
+	return &r.spec
+}
+
+func (r *FeatureStatus) String() string {
+	return fmt.Sprintf("config.FeatureStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *FeatureStatus) DeepCopy() resource.Resource {
+	return &FeatureStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *FeatureStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             FeatureStatusType,
+		Aliases:          []resource.Type{"features"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Enabled",
+				JSONPath: "{.enabled}",
+			},
+			{
+				Name:     "Value",
+				JSONPath: "{.value}",
+			},
+			{
+				Name:     "Deprecated",
+				JSONPath: "{.deprecated}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *FeatureStatus) TypedSpec() *FeatureStatusSpec {
+	return &r.spec
+}
@@ -59,13 +59,15 @@ type K8sControlPlaneAPIServerSpec struct {
 
 // K8sControlPlaneControllerManagerSpec is configuration for kube-controller-manager.
 type K8sControlPlaneControllerManagerSpec struct {
-	Enabled       bool              `yaml:"enabled"`
-	Image         string            `yaml:"image"`
-	CloudProvider string            `yaml:"cloudProvider"`
-	PodCIDRs      []string          `yaml:"podCIDRs"`
-	ServiceCIDRs  []string          `yaml:"serviceCIDRs"`
-	ExtraArgs     map[string]string `yaml:"extraArgs"`
-	ExtraVolumes  []K8sExtraVolume  `yaml:"extraVolumes"`
+	Enabled              bool              `yaml:"enabled"`
+	Image                string            `yaml:"image"`
+	CloudProvider        string            `yaml:"cloudProvider"`
+	PodCIDRs             []string          `yaml:"podCIDRs"`
+	ServiceCIDRs         []string          `yaml:"serviceCIDRs"`
+	NodeCIDRMaskSizeIPv4 int               `yaml:"nodeCIDRMaskSizeIPv4"`
+	NodeCIDRMaskSizeIPv6 int               `yaml:"nodeCIDRMaskSizeIPv6"`
+	ExtraArgs            map[string]string `yaml:"extraArgs"`
+	ExtraVolumes         []K8sExtraVolume  `yaml:"extraVolumes"`
 }
 
 // K8sControlPlaneSchedulerSpec is configuration for kube-scheduler.
@@ -85,6 +87,8 @@ type K8sManifestsSpec struct {
 
 	PodCIDRs []string `yaml:"podCIDRs"`
 
+	StaticBootstrapTokenEnabled bool `yaml:"staticBootstrapTokenEnabled"`
+
 	ProxyEnabled bool     `yaml:"proxyEnabled"`
 	ProxyImage   string   `yaml:"proxyImage"`
 	ProxyArgs    []string `yaml:"proxyArgs"`
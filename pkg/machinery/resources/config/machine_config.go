@@ -32,7 +32,14 @@ type v1alpha1Spec struct {
 }
 
 func (s *v1alpha1Spec) MarshalYAML() (interface{}, error) {
-	return encoder.NewEncoder(s.cfg).Marshal()
+	var cfg interface{} = s.cfg
+
+	// resolved secret values are never exposed via the API
+	if c, ok := s.cfg.(*v1alpha1.Config); ok {
+		cfg = c.WithSecretReferences()
+	}
+
+	return encoder.NewEncoder(cfg).Marshal()
 }
 
 // NewMachineConfig initializes a V1Alpha1 resource.
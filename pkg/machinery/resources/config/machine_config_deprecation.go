@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// MachineConfigDeprecationType is type of MachineConfigDeprecation resource.
+const MachineConfigDeprecationType = resource.Type("MachineConfigDeprecations.config.talos.dev")
+
+// MachineConfigDeprecation describes a deprecated field or value set in the machine configuration.
+//
+// Resource ID is the path of the field in the configuration.
+type MachineConfigDeprecation struct {
+	md   resource.Metadata
+	spec MachineConfigDeprecationSpec
+}
+
+// MachineConfigDeprecationSpec describes the deprecation.
+type MachineConfigDeprecationSpec struct {
+	Message     string `yaml:"message"`
+	Replacement string `yaml:"replacement,omitempty"`
+}
+
+// NewMachineConfigDeprecation initializes a MachineConfigDeprecation resource.
+func NewMachineConfigDeprecation(id resource.ID) *MachineConfigDeprecation {
+	r := &MachineConfigDeprecation{
+		md:   resource.NewMetadata(NamespaceName, MachineConfigDeprecationType, id, resource.VersionUndefined),
+		spec: MachineConfigDeprecationSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *MachineConfigDeprecation) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *MachineConfigDeprecation) Spec() interface{} {
+	return &r.spec
+}
+
+func (r *MachineConfigDeprecation) String() string {
+	return fmt.Sprintf("config.MachineConfigDeprecation(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *MachineConfigDeprecation) DeepCopy() resource.Resource {
+	return &MachineConfigDeprecation{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *MachineConfigDeprecation) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             MachineConfigDeprecationType,
+		Aliases:          []resource.Type{"deprecations"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Message",
+				JSONPath: "{.message}",
+			},
+			{
+				Name:     "Replacement",
+				JSONPath: "{.replacement}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *MachineConfigDeprecation) TypedSpec() *MachineConfigDeprecationSpec {
+	return &r.spec
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// MachineConfigSourceType is type of MachineConfigSource resource.
+const MachineConfigSourceType = resource.Type("MachineConfigSources.config.talos.dev")
+
+// MachineConfigSource describes a layer of the machine configuration.
+//
+// Resource ID is the source name: disk, platform or api.
+type MachineConfigSource struct {
+	md   resource.Metadata
+	spec MachineConfigSourceSpec
+}
+
+// MachineConfigSourceSpec describes the machine configuration layer.
+//
+// Sections lists the configuration sections (e.g. `machine.network`) set in this layer and not in any higher priority layer.
+// Sections merged from several layers (e.g. `machine.sysctls`) are attributed to the highest priority one.
+type MachineConfigSourceSpec struct {
+	Priority int      `yaml:"priority"`
+	Sections []string `yaml:"sections"`
+}
+
+// NewMachineConfigSource initializes a MachineConfigSource resource.
+func NewMachineConfigSource(id resource.ID) *MachineConfigSource {
+	r := &MachineConfigSource{
+		md:   resource.NewMetadata(NamespaceName, MachineConfigSourceType, id, resource.VersionUndefined),
+		spec: MachineConfigSourceSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *MachineConfigSource) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *MachineConfigSource) Spec() interface{} {
+	return &r.spec
+}
+
+func (r *MachineConfigSource) String() string {
+	return fmt.Sprintf("config.MachineConfigSource(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *MachineConfigSource) DeepCopy() resource.Resource {
+	return &MachineConfigSource{
+		md: r.md,
+		spec: MachineConfigSourceSpec{
+			Priority: r.spec.Priority,
+			Sections: append([]string(nil), r.spec.Sections...),
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *MachineConfigSource) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             MachineConfigSourceType,
+		Aliases:          []resource.Type{"configsources"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Priority",
+				JSONPath: "{.priority}",
+			},
+			{
+				Name:     "Sections",
+				JSONPath: "{.sections}",
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *MachineConfigSource) TypedSpec() *MachineConfigSourceSpec {
+	return &r.spec
+}
@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package etcd
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"inet.af/netaddr"
+)
+
+// SpecType is type of Spec resource.
+const SpecType = resource.Type("EtcdSpecs.etcd.talos.dev")
+
+// SpecID is resource ID for Spec resource for etcd.
+const SpecID = resource.ID("etcd")
+
+// Spec resource holds etcd spec.
+type Spec struct {
+	md   resource.Metadata
+	spec SpecSpec
+}
+
+// SpecSpec describes (some) etcd configuration.
+type SpecSpec struct {
+	AdvertisedAddresses   []netaddr.IP `yaml:"advertisedAddresses"`
+	ListenPeerAddresses   []netaddr.IP `yaml:"listenPeerAddresses"`
+	ListenClientAddresses []netaddr.IP `yaml:"listenClientAddresses"`
+}
+
+// NewSpec initializes a Spec resource.
+func NewSpec(namespace resource.Namespace, id resource.ID) *Spec {
+	r := &Spec{
+		md:   resource.NewMetadata(namespace, SpecType, id, resource.VersionUndefined),
+		spec: SpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Spec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Spec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Spec) String() string {
+	return fmt.Sprintf("etcd.Spec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Spec) DeepCopy() resource.Resource {
+	return &Spec{
+		md: r.md,
+		spec: SpecSpec{
+			AdvertisedAddresses:   append([]netaddr.IP(nil), r.spec.AdvertisedAddresses...),
+			ListenPeerAddresses:   append([]netaddr.IP(nil), r.spec.ListenPeerAddresses...),
+			ListenClientAddresses: append([]netaddr.IP(nil), r.spec.ListenClientAddresses...),
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Spec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             SpecType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Advertised Addresses",
+				JSONPath: `{.advertisedAddresses}`,
+			},
+			{
+				Name:     "Listen Peer Addresses",
+				JSONPath: `{.listenPeerAddresses}`,
+			},
+			{
+				Name:     "Listen Client Addresses",
+				JSONPath: `{.listenClientAddresses}`,
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the Spec with the proper type.
+func (r *Spec) TypedSpec() *SpecSpec {
+	return &r.spec
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cosi-project/runtime/pkg/state"
 
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 )
 
 // NodenameReadyCondition implements condition which waits for the nodename to be ready.
@@ -57,3 +58,85 @@ func (condition *NodenameReadyCondition) Wait(ctx context.Context) error {
 
 	return err
 }
+
+// KubeletSpecReadyCondition implements condition which waits for the kubelet spec to be ready.
+type KubeletSpecReadyCondition struct {
+	state state.State
+}
+
+// NewKubeletSpecReadyCondition builds a condition which waits for the kubelet spec to be ready.
+func NewKubeletSpecReadyCondition(state state.State) *KubeletSpecReadyCondition {
+	return &KubeletSpecReadyCondition{
+		state: state,
+	}
+}
+
+func (condition *KubeletSpecReadyCondition) String() string {
+	return "kubelet spec"
+}
+
+// Wait implements condition interface.
+func (condition *KubeletSpecReadyCondition) Wait(ctx context.Context) error {
+	_, err := condition.state.WatchFor(
+		ctx,
+		resource.NewMetadata(ControlPlaneNamespaceName, KubeletSpecType, KubeletID, resource.VersionUndefined),
+		state.WithCondition(func(r resource.Resource) (bool, error) {
+			if resource.IsTombstone(r) {
+				return false, nil
+			}
+
+			return true, nil
+		}),
+	)
+
+	return err
+}
+
+// StaticPodSecretsReadyCondition implements condition which waits for the static pod secrets to be rendered from the current Kubernetes secrets.
+type StaticPodSecretsReadyCondition struct {
+	state state.State
+}
+
+// NewStaticPodSecretsReadyCondition builds a condition which waits for the static pod secrets to be rendered from the current Kubernetes secrets.
+func NewStaticPodSecretsReadyCondition(state state.State) *StaticPodSecretsReadyCondition {
+	return &StaticPodSecretsReadyCondition{
+		state: state,
+	}
+}
+
+func (condition *StaticPodSecretsReadyCondition) String() string {
+	return "static pod secrets"
+}
+
+// Wait implements condition interface.
+func (condition *StaticPodSecretsReadyCondition) Wait(ctx context.Context) error {
+	_, err := condition.state.WatchFor(
+		ctx,
+		resource.NewMetadata(ControlPlaneNamespaceName, SecretsStatusType, StaticPodSecretsStaticPodID, resource.VersionUndefined),
+		state.WithCondition(func(r resource.Resource) (bool, error) {
+			if resource.IsTombstone(r) {
+				return false, nil
+			}
+
+			secretsStatus := r.(*SecretsStatus).TypedSpec()
+
+			if !secretsStatus.Ready {
+				return false, nil
+			}
+
+			// check that Kubernetes secrets version matches one recorded in the secrets status
+			kubernetesSecrets, err := condition.state.Get(ctx, resource.NewMetadata(secrets.NamespaceName, secrets.KubernetesType, secrets.KubernetesID, resource.VersionUndefined))
+			if err != nil {
+				return false, err
+			}
+
+			if kubernetesSecrets.Metadata().Version().String() != secretsStatus.Version {
+				return false, nil
+			}
+
+			return true, nil
+		}),
+	)
+
+	return err
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/talos-systems/talos/pkg/machinery/resources/k8s"
 	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+	"github.com/talos-systems/talos/pkg/machinery/resources/secrets"
 )
 
 func TestCondition(t *testing.T) {
@@ -86,3 +87,77 @@ func TestCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestStaticPodSecretsCondition(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Name           string
+		StatusExists   bool
+		StatusReady    bool
+		VersionMatches bool
+		Succeeds       bool
+	}{
+		{
+			Name:     "no secrets status",
+			Succeeds: false,
+		},
+		{
+			Name:           "not ready",
+			StatusExists:   true,
+			StatusReady:    false,
+			VersionMatches: true,
+			Succeeds:       false,
+		},
+		{
+			Name:           "version mismatch",
+			StatusExists:   true,
+			StatusReady:    true,
+			VersionMatches: false,
+			Succeeds:       false,
+		},
+		{
+			Name:           "success",
+			StatusExists:   true,
+			StatusReady:    true,
+			VersionMatches: true,
+			Succeeds:       true,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), time.Second)
+			t.Cleanup(ctxCancel)
+
+			state := state.WrapCore(namespaced.NewState(inmem.Build))
+
+			kubernetesSecrets := secrets.NewKubernetes()
+
+			require.NoError(t, state.Create(ctx, kubernetesSecrets))
+
+			if tt.StatusExists {
+				secretsStatus := k8s.NewSecretsStatus(k8s.ControlPlaneNamespaceName, k8s.StaticPodSecretsStaticPodID)
+				secretsStatus.TypedSpec().Ready = tt.StatusReady
+
+				secretsStatus.TypedSpec().Version = kubernetesSecrets.Metadata().Version().String()
+
+				if !tt.VersionMatches {
+					secretsStatus.TypedSpec().Version = "999"
+				}
+
+				require.NoError(t, state.Create(ctx, secretsStatus))
+			}
+
+			err := k8s.NewStaticPodSecretsReadyCondition(state).Wait(ctx)
+
+			if tt.Succeeds {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, context.DeadlineExceeded), "error is %v", err)
+			}
+		})
+	}
+}
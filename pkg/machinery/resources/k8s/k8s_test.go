@@ -26,8 +26,13 @@ func TestRegisterResource(t *testing.T) {
 
 	for _, resource := range []resource.Resource{
 		&k8s.Endpoint{},
+		&k8s.KubeletCertificateStatus{},
+		&k8s.KubeletConfig{},
+		&k8s.KubeletSpec{},
+		&k8s.LoadBalancerConfig{},
 		&k8s.ManifestStatus{},
 		&k8s.Manifest{},
+		&k8s.NodeLabelSpec{},
 		&k8s.Nodename{},
 		&k8s.SecretsStatus{},
 		&k8s.StaticPodStatus{},
@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// KubeletCertificateStatusType is type of KubeletCertificateStatus resource.
+const KubeletCertificateStatusType = resource.Type("KubeletCertificateStatuses.kubernetes.talos.dev")
+
+// KubeletCertificateStatusID is the ID of the KubeletCertificateStatus resource for the kubelet client certificate.
+const KubeletCertificateStatusID = resource.ID("client")
+
+// KubeletCertificateState describes the state of the kubelet certificate.
+type KubeletCertificateState string
+
+// Kubelet certificate states.
+const (
+	// KubeletCertificateValid is a certificate which is not due for renewal yet.
+	KubeletCertificateValid = KubeletCertificateState("valid")
+	// KubeletCertificateRenewalOverdue is a certificate which should have been rotated by the kubelet already.
+	KubeletCertificateRenewalOverdue = KubeletCertificateState("renewalOverdue")
+	// KubeletCertificateExpired is a certificate which can't be used to authenticate to the API server anymore.
+	KubeletCertificateExpired = KubeletCertificateState("expired")
+)
+
+// KubeletCertificateStatus resource holds the expiry status of the kubelet certificate.
+type KubeletCertificateStatus struct {
+	md   resource.Metadata
+	spec KubeletCertificateStatusSpec
+}
+
+// KubeletCertificateStatusSpec describes the expiry status of the kubelet certificate.
+type KubeletCertificateStatusSpec struct {
+	State           KubeletCertificateState `yaml:"state"`
+	NotBefore       time.Time               `yaml:"notBefore"`
+	NotAfter        time.Time               `yaml:"notAfter"`
+	RenewalDeadline time.Time               `yaml:"renewalDeadline"`
+
+	LastRecoveryAttempt time.Time `yaml:"lastRecoveryAttempt,omitempty"`
+	LastRecoveryError   string    `yaml:"lastRecoveryError,omitempty"`
+}
+
+// NewKubeletCertificateStatus initializes a KubeletCertificateStatus resource.
+func NewKubeletCertificateStatus(namespace resource.Namespace, id resource.ID) *KubeletCertificateStatus {
+	r := &KubeletCertificateStatus{
+		md:   resource.NewMetadata(namespace, KubeletCertificateStatusType, id, resource.VersionUndefined),
+		spec: KubeletCertificateStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KubeletCertificateStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KubeletCertificateStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *KubeletCertificateStatus) String() string {
+	return fmt.Sprintf("k8s.KubeletCertificateStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KubeletCertificateStatus) DeepCopy() resource.Resource {
+	return &KubeletCertificateStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KubeletCertificateStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KubeletCertificateStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: ControlPlaneNamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "State",
+				JSONPath: "{.state}",
+			},
+			{
+				Name:     "Not After",
+				JSONPath: "{.notAfter}",
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the Spec with the proper type.
+func (r *KubeletCertificateStatus) TypedSpec() *KubeletCertificateStatusSpec {
+	return &r.spec
+}
@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// KubeletConfigType is type of KubeletConfig resource.
+const KubeletConfigType = resource.Type("KubeletConfigs.kubernetes.talos.dev")
+
+// KubeletID is the ID of KubeletConfig and KubeletSpec resources.
+const KubeletID = resource.ID("kubelet")
+
+// KubeletConfig resource holds the kubelet configuration derived from the machine configuration.
+type KubeletConfig struct {
+	md   resource.Metadata
+	spec KubeletConfigSpec
+}
+
+// KubeletConfigSpec holds the effective kubelet configuration.
+//
+// Secrets (e.g. bootstrap token, credential provider environment) are not stored in the resource,
+// only the keyed checksum of the secrets the kubelet service reads from the machine configuration.
+type KubeletConfigSpec struct {
+	Image                    string            `yaml:"image"`
+	ExtraArgs                map[string]string `yaml:"extraArgs,omitempty"`
+	ExtraMounts              []specs.Mount     `yaml:"extraMounts,omitempty"`
+	ExtraCapabilities        []string          `yaml:"extraCapabilities,omitempty"`
+	CredentialProviderBinDir string            `yaml:"credentialProviderBinDir,omitempty"`
+	Standalone               bool              `yaml:"standalone"`
+	CloudProviderExternal    bool              `yaml:"cloudProviderExternal"`
+	NodeLabels               map[string]string `yaml:"nodeLabels,omitempty"`
+	NodeTaints               map[string]string `yaml:"nodeTaints,omitempty"`
+	PodCIDRs                 []string          `yaml:"podCIDRs,omitempty"`
+	NodeIPValidSubnets       []string          `yaml:"nodeIPValidSubnets,omitempty"`
+	NodeIPPreferredFamily    string            `yaml:"nodeIPPreferredFamily"`
+	CACert                   string            `yaml:"caCert,omitempty"`
+	APIServerEndpoint        string            `yaml:"apiServerEndpoint,omitempty"`
+	HealthEndpoint           string            `yaml:"healthEndpoint"`
+	TrustdCredentials        bool              `yaml:"trustdCredentials"`
+	DebugLogs                bool              `yaml:"debugLogs"`
+	SecretsChecksum          string            `yaml:"secretsChecksum,omitempty"`
+
+	// Config is the kubelet configuration file (KubeletConfiguration) with the extra config merged in.
+	Config map[string]interface{} `yaml:"config"`
+}
+
+// NewKubeletConfig initializes a KubeletConfig resource.
+func NewKubeletConfig(namespace resource.Namespace, id resource.ID) *KubeletConfig {
+	r := &KubeletConfig{
+		md:   resource.NewMetadata(namespace, KubeletConfigType, id, resource.VersionUndefined),
+		spec: KubeletConfigSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KubeletConfig) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KubeletConfig) Spec() interface{} {
+	return r.spec
+}
+
+func (r *KubeletConfig) String() string {
+	return fmt.Sprintf("k8s.KubeletConfig(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KubeletConfig) DeepCopy() resource.Resource {
+	return &KubeletConfig{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KubeletConfig) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KubeletConfigType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: ControlPlaneNamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Image",
+				JSONPath: "{.image}",
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the Spec with the proper type.
+func (r *KubeletConfig) TypedSpec() *KubeletConfigSpec {
+	return &r.spec
+}
@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// KubeletSpecType is type of KubeletSpec resource.
+const KubeletSpecType = resource.Type("KubeletSpecs.kubernetes.talos.dev")
+
+// KubeletSpec resource holds the final definition of the kubelet runtime configuration.
+type KubeletSpec struct {
+	md   resource.Metadata
+	spec KubeletSpecSpec
+}
+
+// KubeletSpecSpec describes the kubelet container and its configuration file.
+//
+// Secrets (e.g. registry auth, credential provider environment) are not stored in the resource, kubelet service reads them
+// from the machine configuration. SecretsChecksum changes whenever the secrets change, so that the kubelet is restarted.
+type KubeletSpecSpec struct {
+	Image                string        `yaml:"image"`
+	Args                 []string      `yaml:"args,omitempty"`
+	ExtraMounts          []specs.Mount `yaml:"extraMounts,omitempty"`
+	ExtraCapabilities    []string      `yaml:"extraCapabilities,omitempty"`
+	SkipNodeRegistration bool          `yaml:"skipNodeRegistration"`
+	ExpectedNodename     string        `yaml:"expectedNodename,omitempty"`
+	CACert               string        `yaml:"caCert,omitempty"`
+	APIServerEndpoint    string        `yaml:"apiServerEndpoint,omitempty"`
+	HealthEndpoint       string        `yaml:"healthEndpoint"`
+	TrustdCredentials    bool          `yaml:"trustdCredentials"`
+	DebugLogs            bool          `yaml:"debugLogs"`
+	SecretsChecksum      string        `yaml:"secretsChecksum,omitempty"`
+
+	// Config is the kubelet configuration file (KubeletConfiguration).
+	Config map[string]interface{} `yaml:"config"`
+}
+
+// NewKubeletSpec initializes a KubeletSpec resource.
+func NewKubeletSpec(namespace resource.Namespace, id resource.ID) *KubeletSpec {
+	r := &KubeletSpec{
+		md:   resource.NewMetadata(namespace, KubeletSpecType, id, resource.VersionUndefined),
+		spec: KubeletSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KubeletSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KubeletSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *KubeletSpec) String() string {
+	return fmt.Sprintf("k8s.KubeletSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KubeletSpec) DeepCopy() resource.Resource {
+	return &KubeletSpec{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KubeletSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KubeletSpecType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: ControlPlaneNamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Image",
+				JSONPath: "{.image}",
+			},
+			{
+				Name:     "Nodename",
+				JSONPath: "{.expectedNodename}",
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the Spec with the proper type.
+func (r *KubeletSpec) TypedSpec() *KubeletSpecSpec {
+	return &r.spec
+}
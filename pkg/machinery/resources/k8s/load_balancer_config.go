@@ -0,0 +1,100 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// LoadBalancerConfigType is type of LoadBalancerConfig resource.
+const LoadBalancerConfigType = resource.Type("LoadBalancerConfigs.kubernetes.talos.dev")
+
+// LoadBalancerConfigID is the ID of the LoadBalancerConfig resource for the node-local Kubernetes API server load balancer.
+const LoadBalancerConfigID = resource.ID("kube-apiserver")
+
+// LoadBalancerConfig resource holds the node-local Kubernetes API server load balancer configuration.
+type LoadBalancerConfig struct {
+	md   resource.Metadata
+	spec LoadBalancerConfigSpec
+}
+
+// LoadBalancerConfigSpec describes the node-local Kubernetes API server load balancer.
+type LoadBalancerConfigSpec struct {
+	Host      string   `yaml:"host"`
+	Port      int      `yaml:"port"`
+	Upstreams []string `yaml:"upstreams"`
+}
+
+// NewLoadBalancerConfig initializes a LoadBalancerConfig resource.
+func NewLoadBalancerConfig(namespace resource.Namespace, id resource.ID) *LoadBalancerConfig {
+	r := &LoadBalancerConfig{
+		md:   resource.NewMetadata(namespace, LoadBalancerConfigType, id, resource.VersionUndefined),
+		spec: LoadBalancerConfigSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *LoadBalancerConfig) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *LoadBalancerConfig) Spec() interface{} {
+	return r.spec
+}
+
+func (r *LoadBalancerConfig) String() string {
+	return fmt.Sprintf("k8s.LoadBalancerConfig(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *LoadBalancerConfig) DeepCopy() resource.Resource {
+	return &LoadBalancerConfig{
+		md: r.md,
+		spec: LoadBalancerConfigSpec{
+			Host:      r.spec.Host,
+			Port:      r.spec.Port,
+			Upstreams: append([]string(nil), r.spec.Upstreams...),
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *LoadBalancerConfig) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             LoadBalancerConfigType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: ControlPlaneNamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Port",
+				JSONPath: "{.port}",
+			},
+			{
+				Name:     "Upstreams",
+				JSONPath: "{.upstreams}",
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the Spec with the proper type.
+func (r *LoadBalancerConfig) TypedSpec() *LoadBalancerConfigSpec {
+	return &r.spec
+}
+
+// Endpoint returns the URL of the load balancer.
+func (spec *LoadBalancerConfigSpec) Endpoint() string {
+	return "https://" + net.JoinHostPort(spec.Host, strconv.Itoa(spec.Port))
+}
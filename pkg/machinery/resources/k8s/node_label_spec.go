@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// NodeLabelSpecType is type of NodeLabelSpec resource.
+const NodeLabelSpecType = resource.Type("NodeLabelSpecs.kubernetes.talos.dev")
+
+// NodeLabelSpec resource holds a label which should be set on the Kubernetes node.
+//
+// Resource ID is the label key.
+type NodeLabelSpec struct {
+	md   resource.Metadata
+	spec NodeLabelSpecSpec
+}
+
+// NodeLabelSpecSpec describes the node label.
+type NodeLabelSpecSpec struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+// NewNodeLabelSpec initializes a NodeLabelSpec resource.
+func NewNodeLabelSpec(id resource.ID) *NodeLabelSpec {
+	r := &NodeLabelSpec{
+		md:   resource.NewMetadata(ControlPlaneNamespaceName, NodeLabelSpecType, id, resource.VersionUndefined),
+		spec: NodeLabelSpecSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *NodeLabelSpec) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *NodeLabelSpec) Spec() interface{} {
+	return r.spec
+}
+
+func (r *NodeLabelSpec) String() string {
+	return fmt.Sprintf("k8s.NodeLabelSpec(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *NodeLabelSpec) DeepCopy() resource.Resource {
+	return &NodeLabelSpec{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *NodeLabelSpec) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             NodeLabelSpecType,
+		Aliases:          []resource.Type{"nodelabels"},
+		DefaultNamespace: ControlPlaneNamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Value",
+				JSONPath: `{.value}`,
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the Spec with the proper type.
+func (r *NodeLabelSpec) TypedSpec() *NodeLabelSpecSpec {
+	return &r.spec
+}
@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// ConntrackStatsType is type of ConntrackStats resource.
+const ConntrackStatsType = resource.Type("ConntrackStats.net.talos.dev")
+
+// ConntrackStatsID is the singleton resource ID.
+const ConntrackStatsID = resource.ID("conntrack")
+
+// ConntrackStats resource describes the connection tracking table utilization.
+type ConntrackStats struct {
+	md   resource.Metadata
+	spec ConntrackStatsSpec
+}
+
+// ConntrackStatsSpec describes the connection tracking table utilization.
+//
+// Drop counters are cumulative since boot and summed over all CPUs.
+type ConntrackStatsSpec struct {
+	Count   uint64 `yaml:"count"`
+	Max     uint64 `yaml:"max"`
+	Buckets uint64 `yaml:"buckets"`
+	// Utilization is the percentage of the table in use.
+	Utilization float64 `yaml:"utilization"`
+	// Drop is the number of new connections dropped as the table was full.
+	Drop uint64 `yaml:"drop"`
+	// EarlyDrop is the number of existing connections evicted to make room for the new ones.
+	EarlyDrop    uint64 `yaml:"earlyDrop"`
+	InsertFailed uint64 `yaml:"insertFailed"`
+	Invalid      uint64 `yaml:"invalid"`
+}
+
+// NewConntrackStats initializes a ConntrackStats resource.
+func NewConntrackStats(namespace resource.Namespace, id resource.ID) *ConntrackStats {
+	r := &ConntrackStats{
+		md:   resource.NewMetadata(namespace, ConntrackStatsType, id, resource.VersionUndefined),
+		spec: ConntrackStatsSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *ConntrackStats) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *ConntrackStats) Spec() interface{} {
+	return r.spec
+}
+
+func (r *ConntrackStats) String() string {
+	return fmt.Sprintf("network.ConntrackStats(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *ConntrackStats) DeepCopy() resource.Resource {
+	return &ConntrackStats{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *ConntrackStats) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             ConntrackStatsType,
+		Aliases:          []resource.Type{"conntrack"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Count",
+				JSONPath: "{.count}",
+			},
+			{
+				Name:     "Max",
+				JSONPath: "{.max}",
+			},
+			{
+				Name:     "Utilization",
+				JSONPath: "{.utilization}",
+			},
+			{
+				Name:     "Drop",
+				JSONPath: "{.drop}",
+			},
+			{
+				Name:     "Early Drop",
+				JSONPath: "{.earlyDrop}",
+			},
+		},
+	}
+}
+
+// TypedSpec allows to access the Spec with the proper type.
+func (r *ConntrackStats) TypedSpec() *ConntrackStatsSpec {
+	return &r.spec
+}
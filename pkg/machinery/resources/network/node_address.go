@@ -9,7 +9,11 @@ import (
 
 	"github.com/cosi-project/runtime/pkg/resource"
 	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"github.com/cosi-project/runtime/pkg/resource/protobuf"
 	"inet.af/netaddr"
+
+	networkpb "github.com/talos-systems/talos/pkg/machinery/api/resource/network"
+	"github.com/talos-systems/talos/pkg/machinery/proto"
 )
 
 // NodeAddressType is type of NodeAddress resource.
@@ -98,6 +102,45 @@ func (r *NodeAddress) TypedSpec() *NodeAddressSpec {
 	return &r.spec
 }
 
+// MarshalProto implements ProtoMarshaler.
+func (spec NodeAddressSpec) MarshalProto() ([]byte, error) {
+	protoSpec := networkpb.NodeAddressSpec{
+		Addresses: make([]string, len(spec.Addresses)),
+	}
+
+	for i := range spec.Addresses {
+		protoSpec.Addresses[i] = spec.Addresses[i].String()
+	}
+
+	return proto.Marshal(&protoSpec)
+}
+
+// UnmarshalProto implements protobuf.ResourceUnmarshaler.
+func (r *NodeAddress) UnmarshalProto(md *resource.Metadata, protoBytes []byte) error {
+	r.md = *md
+
+	protoSpec := networkpb.NodeAddressSpec{}
+
+	if err := proto.Unmarshal(protoBytes, &protoSpec); err != nil {
+		return err
+	}
+
+	r.spec = NodeAddressSpec{
+		Addresses: make([]netaddr.IPPrefix, len(protoSpec.Addresses)),
+	}
+
+	for i := range protoSpec.Addresses {
+		var err error
+
+		r.spec.Addresses[i], err = netaddr.ParseIPPrefix(protoSpec.Addresses[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // IPs returns IP without prefix.
 func (spec *NodeAddressSpec) IPs() []netaddr.IP {
 	result := make([]netaddr.IP, len(spec.Addresses))
@@ -113,3 +156,9 @@ func (spec *NodeAddressSpec) IPs() []netaddr.IP {
 func FilteredNodeAddressID(kind resource.ID, filterID string) resource.ID {
 	return fmt.Sprintf("%s-%s", kind, filterID)
 }
+
+func init() {
+	if err := protobuf.RegisterResource(NodeAddressType, &NodeAddress{}); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,39 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package network_test
+
+import (
+	"testing"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/protobuf"
+	"github.com/stretchr/testify/require"
+	"inet.af/netaddr"
+
+	"github.com/talos-systems/talos/pkg/machinery/resources/network"
+)
+
+func TestNodeAddressProtobufMarshal(t *testing.T) {
+	r := network.NewNodeAddress(network.NamespaceName, network.NodeAddressCurrentID)
+	r.TypedSpec().Addresses = []netaddr.IPPrefix{
+		netaddr.MustParseIPPrefix("10.0.0.2/24"),
+		netaddr.MustParseIPPrefix("fd00::1/64"),
+	}
+
+	protoR, err := protobuf.FromResource(r)
+	require.NoError(t, err)
+
+	marshaled, err := protoR.Marshal()
+	require.NoError(t, err)
+
+	protoR, err = protobuf.Unmarshal(marshaled)
+	require.NoError(t, err)
+
+	r2, err := protobuf.UnmarshalResource(protoR)
+	require.NoError(t, err)
+
+	require.True(t, resource.Equal(r, r2))
+	require.Equal(t, r.TypedSpec().Addresses, r2.(*network.NodeAddress).TypedSpec().Addresses)
+}
@@ -0,0 +1,117 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// AcceleratorType is type of Accelerator resource.
+const AcceleratorType = resource.Type("Accelerators.runtime.talos.dev")
+
+// Accelerator types.
+const (
+	AcceleratorTypeGPU   = "gpu"
+	AcceleratorTypeTPU   = "tpu"
+	AcceleratorTypeOther = "accelerator"
+)
+
+// Accelerator resource describes a GPU, TPU or other accelerator PCI device.
+//
+// Resource ID is the PCI address of the device.
+type Accelerator struct {
+	md   resource.Metadata
+	spec AcceleratorSpec
+}
+
+// AcceleratorSpec describes the accelerator device.
+type AcceleratorSpec struct {
+	// Type is the accelerator type: gpu, tpu or accelerator.
+	Type string `yaml:"type"`
+	// Class is the PCI class code (class, subclass and programming interface).
+	Class string `yaml:"class"`
+	// VendorID and DeviceID are the PCI vendor and device IDs.
+	VendorID string `yaml:"vendorID"`
+	DeviceID string `yaml:"deviceID"`
+	// Vendor is the vendor name, if known.
+	Vendor string `yaml:"vendor,omitempty"`
+	// Driver is the name of the kernel driver bound to the device, empty if none.
+	Driver string `yaml:"driver,omitempty"`
+	// NUMANode is the NUMA node the device is attached to, -1 if unknown.
+	NUMANode int `yaml:"numaNode"`
+}
+
+// NewAccelerator initializes an Accelerator resource.
+func NewAccelerator(id resource.ID) *Accelerator {
+	r := &Accelerator{
+		md:   resource.NewMetadata(NamespaceName, AcceleratorType, id, resource.VersionUndefined),
+		spec: AcceleratorSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Accelerator) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Accelerator) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Accelerator) String() string {
+	return fmt.Sprintf("runtime.Accelerator(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Accelerator) DeepCopy() resource.Resource {
+	return &Accelerator{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Accelerator) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             AcceleratorType,
+		Aliases:          []resource.Type{"GPUs"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Type",
+				JSONPath: `{.type}`,
+			},
+			{
+				Name:     "Vendor",
+				JSONPath: `{.vendorID}`,
+			},
+			{
+				Name:     "Device",
+				JSONPath: `{.deviceID}`,
+			},
+			{
+				Name:     "Driver",
+				JSONPath: `{.driver}`,
+			},
+			{
+				Name:     "NUMA Node",
+				JSONPath: `{.numaNode}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *Accelerator) TypedSpec() *AcceleratorSpec {
+	return &r.spec
+}
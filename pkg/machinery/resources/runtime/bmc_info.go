@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"inet.af/netaddr"
+
+	"github.com/talos-systems/talos/pkg/machinery/nethelpers"
+)
+
+// BMCInfoType is type of BMCInfo resource.
+const BMCInfoType = resource.Type("BMCInfos.runtime.talos.dev")
+
+// BMCInfoID is the singleton resource ID.
+const BMCInfoID = resource.ID("bmc")
+
+// BMCInfo resource holds the information about the node BMC (baseboard management controller).
+type BMCInfo struct {
+	md   resource.Metadata
+	spec BMCInfoSpec
+}
+
+// BMCInfoSpec describes the node BMC.
+type BMCInfoSpec struct {
+	// InterfaceType is the IPMI system interface type (KCS, SMIC, BT or SSIF) as reported by SMBIOS.
+	InterfaceType string `yaml:"interfaceType"`
+	// IPMIVersion is the IPMI specification version implemented by the BMC.
+	IPMIVersion string `yaml:"ipmiVersion"`
+	// Channel is the BMC LAN channel number.
+	Channel int `yaml:"channel"`
+	// Address is the BMC LAN IP address.
+	Address netaddr.IP `yaml:"address"`
+	// HardwareAddr is the BMC LAN MAC address.
+	HardwareAddr nethelpers.HardwareAddr `yaml:"hardwareAddr"`
+}
+
+// NewBMCInfo initializes a BMCInfo resource.
+func NewBMCInfo() *BMCInfo {
+	r := &BMCInfo{
+		md:   resource.NewMetadata(NamespaceName, BMCInfoType, BMCInfoID, resource.VersionUndefined),
+		spec: BMCInfoSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *BMCInfo) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *BMCInfo) Spec() interface{} {
+	return r.spec
+}
+
+func (r *BMCInfo) String() string {
+	return fmt.Sprintf("runtime.BMCInfo(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *BMCInfo) DeepCopy() resource.Resource {
+	return &BMCInfo{
+		md: r.md,
+		spec: BMCInfoSpec{
+			InterfaceType: r.spec.InterfaceType,
+			IPMIVersion:   r.spec.IPMIVersion,
+			Channel:       r.spec.Channel,
+			Address:       r.spec.Address,
+			HardwareAddr:  append(nethelpers.HardwareAddr(nil), r.spec.HardwareAddr...),
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *BMCInfo) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             BMCInfoType,
+		Aliases:          []resource.Type{"BMC"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "IPMI Version",
+				JSONPath: `{.ipmiVersion}`,
+			},
+			{
+				Name:     "Address",
+				JSONPath: `{.address}`,
+			},
+			{
+				Name:     "Hardware Address",
+				JSONPath: `{.hardwareAddr}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *BMCInfo) TypedSpec() *BMCInfoSpec {
+	return &r.spec
+}
@@ -0,0 +1,95 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// BootDiagnosticsType is type of BootDiagnostics resource.
+const BootDiagnosticsType = resource.Type("BootDiagnostics.runtime.talos.dev")
+
+// BootDiagnosticsID is the ID of the BootDiagnostics resource describing the last captured snapshot.
+const BootDiagnosticsID = resource.ID("last")
+
+// BootDiagnostics resource describes diagnostics snapshot captured on boot failure.
+type BootDiagnostics struct {
+	md   resource.Metadata
+	spec BootDiagnosticsSpec
+}
+
+// BootDiagnosticsSpec describes the boot failure and the location of the snapshot.
+type BootDiagnosticsSpec struct {
+	Timestamp time.Time `yaml:"timestamp"`
+	Sequence  string    `yaml:"sequence"`
+	Error     string    `yaml:"error"`
+	Path      string    `yaml:"path"`
+}
+
+// NewBootDiagnostics initializes a BootDiagnostics resource.
+func NewBootDiagnostics() *BootDiagnostics {
+	r := &BootDiagnostics{
+		md:   resource.NewMetadata(NamespaceName, BootDiagnosticsType, BootDiagnosticsID, resource.VersionUndefined),
+		spec: BootDiagnosticsSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *BootDiagnostics) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *BootDiagnostics) Spec() interface{} {
+	return r.spec
+}
+
+func (r *BootDiagnostics) String() string {
+	return fmt.Sprintf("runtime.BootDiagnostics(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *BootDiagnostics) DeepCopy() resource.Resource {
+	return &BootDiagnostics{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *BootDiagnostics) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             BootDiagnosticsType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Timestamp",
+				JSONPath: `{.timestamp}`,
+			},
+			{
+				Name:     "Sequence",
+				JSONPath: `{.sequence}`,
+			},
+			{
+				Name:     "Path",
+				JSONPath: `{.path}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *BootDiagnostics) TypedSpec() *BootDiagnosticsSpec {
+	return &r.spec
+}
@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// ControllerStatusType is type of ControllerStatus resource.
+const ControllerStatusType = resource.Type("ControllerStatuses.runtime.talos.dev")
+
+// ControllerStatus resource describes the health of a controller in the controller runtime.
+type ControllerStatus struct {
+	md   resource.Metadata
+	spec ControllerStatusSpec
+}
+
+// ControllerStatusSpec describes the health of a controller.
+type ControllerStatusSpec struct {
+	// Running is false when the controller is waiting to be restarted after a crash.
+	Running bool `yaml:"running"`
+	// Wedged is true when the controller stopped processing reconcile events and was restarted by the watchdog.
+	Wedged bool `yaml:"wedged"`
+	// Crashes is the number of times the controller failed and was restarted.
+	Crashes int `yaml:"crashes"`
+	// PausedUntil is set when the controller reconciliation is paused.
+	PausedUntil time.Time `yaml:"pausedUntil,omitempty"`
+	// LastError is the last error the controller failed or logged a warning with.
+	LastError     string    `yaml:"lastError,omitempty"`
+	LastErrorTime time.Time `yaml:"lastErrorTime,omitempty"`
+}
+
+// NewControllerStatus initializes a ControllerStatus resource.
+func NewControllerStatus(id resource.ID) *ControllerStatus {
+	r := &ControllerStatus{
+		md:   resource.NewMetadata(NamespaceName, ControllerStatusType, id, resource.VersionUndefined),
+		spec: ControllerStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *ControllerStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *ControllerStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *ControllerStatus) String() string {
+	return fmt.Sprintf("runtime.ControllerStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *ControllerStatus) DeepCopy() resource.Resource {
+	return &ControllerStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *ControllerStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             ControllerStatusType,
+		Aliases:          []resource.Type{"controllers"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Running",
+				JSONPath: `{.running}`,
+			},
+			{
+				Name:     "Wedged",
+				JSONPath: `{.wedged}`,
+			},
+			{
+				Name:     "Crashes",
+				JSONPath: `{.crashes}`,
+			},
+			{
+				Name:     "Paused Until",
+				JSONPath: `{.pausedUntil}`,
+			},
+			{
+				Name:     "Last Error",
+				JSONPath: `{.lastError}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *ControllerStatus) TypedSpec() *ControllerStatusSpec {
+	return &r.spec
+}
@@ -0,0 +1,105 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// CPUPowerStatusType is type of CPUPowerStatus resource.
+const CPUPowerStatusType = resource.Type("CPUPowerStatuses.runtime.talos.dev")
+
+// CPUPowerStatusID is the singleton resource ID.
+const CPUPowerStatusID = resource.ID("cpu")
+
+// CPUPowerStatus resource describes the CPU frequency scaling and idle state settings.
+type CPUPowerStatus struct {
+	md   resource.Metadata
+	spec CPUPowerStatusSpec
+}
+
+// CPUPowerStatusSpec describes the CPU frequency scaling and idle state settings.
+type CPUPowerStatusSpec struct {
+	// ScalingDriver is the CPU frequency scaling driver, empty if frequency scaling is not available.
+	ScalingDriver string `yaml:"scalingDriver"`
+	// Governors is the list of distinct frequency scaling governors used by the CPUs.
+	Governors          []string `yaml:"governors"`
+	AvailableGovernors []string `yaml:"availableGovernors"`
+	// IdleDriver is the CPU idle driver, empty if CPU idle states are not available.
+	IdleDriver string `yaml:"idleDriver"`
+	// IdleStates is the list of enabled idle state names of the first CPU.
+	IdleStates []string `yaml:"idleStates"`
+}
+
+// NewCPUPowerStatus initializes a CPUPowerStatus resource.
+func NewCPUPowerStatus() *CPUPowerStatus {
+	r := &CPUPowerStatus{
+		md:   resource.NewMetadata(NamespaceName, CPUPowerStatusType, CPUPowerStatusID, resource.VersionUndefined),
+		spec: CPUPowerStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *CPUPowerStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *CPUPowerStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *CPUPowerStatus) String() string {
+	return fmt.Sprintf("runtime.CPUPowerStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *CPUPowerStatus) DeepCopy() resource.Resource {
+	return &CPUPowerStatus{
+		md: r.md,
+		spec: CPUPowerStatusSpec{
+			ScalingDriver:      r.spec.ScalingDriver,
+			Governors:          append([]string(nil), r.spec.Governors...),
+			AvailableGovernors: append([]string(nil), r.spec.AvailableGovernors...),
+			IdleDriver:         r.spec.IdleDriver,
+			IdleStates:         append([]string(nil), r.spec.IdleStates...),
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *CPUPowerStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             CPUPowerStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Scaling Driver",
+				JSONPath: `{.scalingDriver}`,
+			},
+			{
+				Name:     "Governors",
+				JSONPath: `{.governors}`,
+			},
+			{
+				Name:     "Idle States",
+				JSONPath: `{.idleStates}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *CPUPowerStatus) TypedSpec() *CPUPowerStatusSpec {
+	return &r.spec
+}
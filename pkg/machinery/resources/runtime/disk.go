@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// DiskType is type of Disk resource.
+const DiskType = resource.Type("Disks.runtime.talos.dev")
+
+// Disk resource describes a block device with its udev properties.
+//
+// Disk ID is the device name, e.g. `sda`.
+type Disk struct {
+	md   resource.Metadata
+	spec DiskSpec
+}
+
+// DiskSpec describes the block device.
+type DiskSpec struct {
+	DevPath    string `yaml:"devPath"`
+	Size       uint64 `yaml:"size"`
+	ReadOnly   bool   `yaml:"readOnly"`
+	Rotational bool   `yaml:"rotational"`
+
+	// Transport is the udev bus of the device, e.g. `ata`, `scsi`, `usb`.
+	Transport string   `yaml:"transport,omitempty"`
+	Model     string   `yaml:"model,omitempty"`
+	Serial    string   `yaml:"serial,omitempty"`
+	WWN       string   `yaml:"wwn,omitempty"`
+	Symlinks  []string `yaml:"symlinks,omitempty"`
+
+	PartitionTableType string `yaml:"partitionTableType,omitempty"`
+	PartitionTableUUID string `yaml:"partitionTableUUID,omitempty"`
+
+	// Filesystem created on the whole disk (without the partition table).
+	FilesystemType  string `yaml:"filesystemType,omitempty"`
+	FilesystemUUID  string `yaml:"filesystemUUID,omitempty"`
+	FilesystemLabel string `yaml:"filesystemLabel,omitempty"`
+
+	Partitions []DiskPartition `yaml:"partitions,omitempty"`
+}
+
+// DiskPartition describes a partition of the disk.
+type DiskPartition struct {
+	DevPath         string `yaml:"devPath"`
+	Size            uint64 `yaml:"size"`
+	PartitionLabel  string `yaml:"partitionLabel,omitempty"`
+	PartitionUUID   string `yaml:"partitionUUID,omitempty"`
+	FilesystemType  string `yaml:"filesystemType,omitempty"`
+	FilesystemUUID  string `yaml:"filesystemUUID,omitempty"`
+	FilesystemLabel string `yaml:"filesystemLabel,omitempty"`
+}
+
+// NewDisk initializes a Disk resource.
+func NewDisk(id resource.ID) *Disk {
+	r := &Disk{
+		md:   resource.NewMetadata(NamespaceName, DiskType, id, resource.VersionUndefined),
+		spec: DiskSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *Disk) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *Disk) Spec() interface{} {
+	return r.spec
+}
+
+func (r *Disk) String() string {
+	return fmt.Sprintf("runtime.Disk(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *Disk) DeepCopy() resource.Resource {
+	spec := r.spec
+	spec.Symlinks = append([]string(nil), r.spec.Symlinks...)
+	spec.Partitions = append([]DiskPartition(nil), r.spec.Partitions...)
+
+	return &Disk{
+		md:   r.md,
+		spec: spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *Disk) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             DiskType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Size",
+				JSONPath: `{.size}`,
+			},
+			{
+				Name:     "Transport",
+				JSONPath: `{.transport}`,
+			},
+			{
+				Name:     "Model",
+				JSONPath: `{.model}`,
+			},
+			{
+				Name:     "Serial",
+				JSONPath: `{.serial}`,
+			},
+			{
+				Name:     "Partition Table",
+				JSONPath: `{.partitionTableType}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *Disk) TypedSpec() *DiskSpec {
+	return &r.spec
+}
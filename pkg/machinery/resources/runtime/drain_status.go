@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// DrainStatusType is type of DrainStatus resource.
+const DrainStatusType = resource.Type("DrainStatuses.runtime.talos.dev")
+
+// DrainStatusID is the singleton resource ID.
+const DrainStatusID = resource.ID("current")
+
+// Drain phases.
+const (
+	DrainPhaseDraining = "draining"
+	DrainPhaseDrained  = "drained"
+	DrainPhaseFailed   = "failed"
+)
+
+// DrainStatus resource describes the progress of the last node drain performed by Talos.
+type DrainStatus struct {
+	md   resource.Metadata
+	spec DrainStatusSpec
+}
+
+// DrainStatusSpec describes the progress of the node drain.
+type DrainStatusSpec struct {
+	Node          string        `yaml:"node"`
+	Phase         string        `yaml:"phase"`
+	RemainingPods []string      `yaml:"remainingPods"`
+	BlockedPods   []string      `yaml:"blockedPods"`
+	Started       time.Time     `yaml:"started"`
+	Timeout       time.Duration `yaml:"timeout"`
+	Error         string        `yaml:"error,omitempty"`
+}
+
+// NewDrainStatus initializes a DrainStatus resource.
+func NewDrainStatus() *DrainStatus {
+	r := &DrainStatus{
+		md:   resource.NewMetadata(NamespaceName, DrainStatusType, DrainStatusID, resource.VersionUndefined),
+		spec: DrainStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *DrainStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *DrainStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *DrainStatus) String() string {
+	return fmt.Sprintf("runtime.DrainStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *DrainStatus) DeepCopy() resource.Resource {
+	spec := r.spec
+	spec.RemainingPods = append([]string(nil), r.spec.RemainingPods...)
+	spec.BlockedPods = append([]string(nil), r.spec.BlockedPods...)
+
+	return &DrainStatus{
+		md:   r.md,
+		spec: spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *DrainStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             DrainStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Phase",
+				JSONPath: `{.phase}`,
+			},
+			{
+				Name:     "Remaining",
+				JSONPath: `{.remainingPods}`,
+			},
+			{
+				Name:     "Blocked",
+				JSONPath: `{.blockedPods}`,
+			},
+			{
+				Name:     "Started",
+				JSONPath: `{.started}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *DrainStatus) TypedSpec() *DrainStatusSpec {
+	return &r.spec
+}
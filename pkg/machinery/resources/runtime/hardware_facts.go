@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// HardwareFactsType is type of HardwareFacts resource.
+const HardwareFactsType = resource.Type("HardwareFacts.runtime.talos.dev")
+
+// HardwareFactsID is the singleton resource ID.
+const HardwareFactsID = resource.ID("hardware")
+
+// Disk classes.
+const (
+	DiskClassNVMe = "nvme"
+	DiskClassSSD  = "ssd"
+	DiskClassHDD  = "hdd"
+)
+
+// HardwareFacts resource holds the summary of the detected node hardware used to label the node.
+type HardwareFacts struct {
+	md   resource.Metadata
+	spec HardwareFactsSpec
+}
+
+// HardwareFactsSpec describes the detected node hardware.
+type HardwareFactsSpec struct {
+	// CPUVendor is the CPU vendor ID as reported in /proc/cpuinfo (e.g. GenuineIntel).
+	CPUVendor string `yaml:"cpuVendor,omitempty"`
+	// CPUFlags is the sorted list of the CPU flags.
+	CPUFlags []string `yaml:"cpuFlags,omitempty"`
+	// GPUVendors is the sorted list of the GPU vendor names.
+	GPUVendors []string `yaml:"gpuVendors,omitempty"`
+	// GPUModels is the sorted list of the GPU models as PCI vendor and device IDs (e.g. 10de:20b0).
+	GPUModels []string `yaml:"gpuModels,omitempty"`
+	// DiskClasses is the sorted list of the disk classes: nvme, ssd or hdd.
+	DiskClasses []string `yaml:"diskClasses,omitempty"`
+	// NICSpeed is the highest link speed of the physical network interfaces in Mbit/s.
+	NICSpeed int `yaml:"nicSpeedMbit,omitempty"`
+}
+
+// NewHardwareFacts initializes a HardwareFacts resource.
+func NewHardwareFacts() *HardwareFacts {
+	r := &HardwareFacts{
+		md:   resource.NewMetadata(NamespaceName, HardwareFactsType, HardwareFactsID, resource.VersionUndefined),
+		spec: HardwareFactsSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *HardwareFacts) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *HardwareFacts) Spec() interface{} {
+	return r.spec
+}
+
+func (r *HardwareFacts) String() string {
+	return fmt.Sprintf("runtime.HardwareFacts(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *HardwareFacts) DeepCopy() resource.Resource {
+	return &HardwareFacts{
+		md: r.md,
+		spec: HardwareFactsSpec{
+			CPUVendor:   r.spec.CPUVendor,
+			CPUFlags:    append([]string(nil), r.spec.CPUFlags...),
+			GPUVendors:  append([]string(nil), r.spec.GPUVendors...),
+			GPUModels:   append([]string(nil), r.spec.GPUModels...),
+			DiskClasses: append([]string(nil), r.spec.DiskClasses...),
+			NICSpeed:    r.spec.NICSpeed,
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *HardwareFacts) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             HardwareFactsType,
+		Aliases:          []resource.Type{"hwfacts"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "CPU Vendor",
+				JSONPath: `{.cpuVendor}`,
+			},
+			{
+				Name:     "GPU Models",
+				JSONPath: `{.gpuModels}`,
+			},
+			{
+				Name:     "Disk Classes",
+				JSONPath: `{.diskClasses}`,
+			},
+			{
+				Name:     "NIC Speed",
+				JSONPath: `{.nicSpeedMbit}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *HardwareFacts) TypedSpec() *HardwareFactsSpec {
+	return &r.spec
+}
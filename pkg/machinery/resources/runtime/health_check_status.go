@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// HealthCheckStatusType is type of HealthCheckStatus resource.
+const HealthCheckStatusType = resource.Type("HealthCheckStatuses.runtime.talos.dev")
+
+// Health check types.
+const (
+	HealthCheckTypeHTTP = "http"
+	HealthCheckTypeTCP  = "tcp"
+	HealthCheckTypeExec = "exec"
+)
+
+// HealthCheckStatus resource holds the status of a user-defined health check.
+type HealthCheckStatus struct {
+	md   resource.Metadata
+	spec HealthCheckStatusSpec
+}
+
+// HealthCheckStatusSpec describes the status of a user-defined health check.
+type HealthCheckStatusSpec struct {
+	// Type is the health check type: http, tcp or exec.
+	Type string `yaml:"type"`
+	// Healthy is false once the number of consecutive failures reaches the failure threshold.
+	Healthy bool `yaml:"healthy"`
+	// Message is the error of the last check, empty if the last check succeeded.
+	Message string `yaml:"message,omitempty"`
+	// Failures is the number of consecutive failures.
+	Failures int `yaml:"failures"`
+	// Taint is true if the node should be tainted while the check is unhealthy.
+	Taint bool `yaml:"taint"`
+}
+
+// NewHealthCheckStatus initializes a HealthCheckStatus resource.
+func NewHealthCheckStatus(id resource.ID) *HealthCheckStatus {
+	r := &HealthCheckStatus{
+		md:   resource.NewMetadata(NamespaceName, HealthCheckStatusType, id, resource.VersionUndefined),
+		spec: HealthCheckStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *HealthCheckStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *HealthCheckStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *HealthCheckStatus) String() string {
+	return fmt.Sprintf("runtime.HealthCheckStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *HealthCheckStatus) DeepCopy() resource.Resource {
+	return &HealthCheckStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *HealthCheckStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             HealthCheckStatusType,
+		Aliases:          []resource.Type{"healthchecks"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Type",
+				JSONPath: `{.type}`,
+			},
+			{
+				Name:     "Healthy",
+				JSONPath: `{.healthy}`,
+			},
+			{
+				Name:     "Failures",
+				JSONPath: `{.failures}`,
+			},
+			{
+				Name:     "Message",
+				JSONPath: `{.message}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *HealthCheckStatus) TypedSpec() *HealthCheckStatusSpec {
+	return &r.spec
+}
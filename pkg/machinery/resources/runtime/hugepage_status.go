@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// HugePageStatusType is type of HugePageStatus resource.
+const HugePageStatusType = resource.Type("HugePageStatuses.runtime.talos.dev")
+
+// HugePageStatus resource describes the hugepages of a given size, either system-wide or on a NUMA node.
+//
+// Resource ID is the hugepage size (e.g. 2Mi) for the system-wide status, or the size suffixed with the NUMA node (e.g. 2Mi-node0).
+type HugePageStatus struct {
+	md   resource.Metadata
+	spec HugePageStatusSpec
+}
+
+// HugePageStatusSpec describes the hugepage reservation.
+type HugePageStatusSpec struct {
+	Size string `yaml:"size"`
+	// NUMANode is -1 for the system-wide status.
+	NUMANode int `yaml:"numaNode"`
+	// Requested is the number of hugepages requested in the machine configuration.
+	Requested int `yaml:"requested"`
+	// Total and Free are the number of hugepages reserved by the kernel and not yet allocated.
+	Total int `yaml:"total"`
+	Free  int `yaml:"free"`
+}
+
+// NewHugePageStatus initializes a HugePageStatus resource.
+func NewHugePageStatus(id resource.ID) *HugePageStatus {
+	r := &HugePageStatus{
+		md:   resource.NewMetadata(NamespaceName, HugePageStatusType, id, resource.VersionUndefined),
+		spec: HugePageStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *HugePageStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *HugePageStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *HugePageStatus) String() string {
+	return fmt.Sprintf("runtime.HugePageStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *HugePageStatus) DeepCopy() resource.Resource {
+	return &HugePageStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *HugePageStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             HugePageStatusType,
+		Aliases:          []resource.Type{"HugePages"},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Size",
+				JSONPath: `{.size}`,
+			},
+			{
+				Name:     "NUMA Node",
+				JSONPath: `{.numaNode}`,
+			},
+			{
+				Name:     "Requested",
+				JSONPath: `{.requested}`,
+			},
+			{
+				Name:     "Total",
+				JSONPath: `{.total}`,
+			},
+			{
+				Name:     "Free",
+				JSONPath: `{.free}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *HugePageStatus) TypedSpec() *HugePageStatusSpec {
+	return &r.spec
+}
+
+// HugePageSizeName formats the hugepage size in bytes the way Kubernetes names the hugepage resources (e.g. 2Mi, 1Gi).
+func HugePageSizeName(size uint64) string {
+	for _, unit := range []struct {
+		suffix string
+		size   uint64
+	}{
+		{"Gi", 1 << 30},
+		{"Mi", 1 << 20},
+		{"Ki", 1 << 10},
+	} {
+		if size >= unit.size && size%unit.size == 0 {
+			return fmt.Sprintf("%d%s", size/unit.size, unit.suffix)
+		}
+	}
+
+	return fmt.Sprintf("%d", size)
+}
@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// InstanceIdentityType is type of InstanceIdentity resource.
+const InstanceIdentityType = resource.Type("InstanceIdentities.runtime.talos.dev")
+
+// InstanceIdentityID is the singleton resource ID.
+const InstanceIdentityID = resource.ID("instance")
+
+// InstanceIdentity resource holds the verified platform instance identity.
+type InstanceIdentity struct {
+	md   resource.Metadata
+	spec InstanceIdentitySpec
+}
+
+// InstanceIdentitySpec describes the instance identity verified with the signed platform instance identity document.
+type InstanceIdentitySpec struct {
+	Platform     string `yaml:"platform"`
+	InstanceID   string `yaml:"instanceID"`
+	InstanceName string `yaml:"instanceName,omitempty"`
+	// AccountID is the AWS account ID or GCP project ID.
+	AccountID string `yaml:"accountID"`
+	Region    string `yaml:"region,omitempty"`
+	Zone      string `yaml:"zone,omitempty"`
+}
+
+// NewInstanceIdentity initializes an InstanceIdentity resource.
+func NewInstanceIdentity() *InstanceIdentity {
+	r := &InstanceIdentity{
+		md:   resource.NewMetadata(NamespaceName, InstanceIdentityType, InstanceIdentityID, resource.VersionUndefined),
+		spec: InstanceIdentitySpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *InstanceIdentity) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *InstanceIdentity) Spec() interface{} {
+	return r.spec
+}
+
+func (r *InstanceIdentity) String() string {
+	return fmt.Sprintf("runtime.InstanceIdentity(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *InstanceIdentity) DeepCopy() resource.Resource {
+	return &InstanceIdentity{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *InstanceIdentity) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             InstanceIdentityType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Platform",
+				JSONPath: `{.platform}`,
+			},
+			{
+				Name:     "Instance ID",
+				JSONPath: `{.instanceID}`,
+			},
+			{
+				Name:     "Account ID",
+				JSONPath: `{.accountID}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *InstanceIdentity) TypedSpec() *InstanceIdentitySpec {
+	return &r.spec
+}
@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// KernelCmdlineType is type of KernelCmdline resource.
+const KernelCmdlineType = resource.Type("KernelCmdlines.runtime.talos.dev")
+
+// KernelCmdlineID is the singleton resource ID.
+const KernelCmdlineID = resource.ID("cmdline")
+
+// KernelCmdline resource holds the command line of the booted kernel.
+type KernelCmdline struct {
+	md   resource.Metadata
+	spec KernelCmdlineSpec
+}
+
+// KernelCmdlineSpec describes the command line of the booted kernel.
+type KernelCmdlineSpec struct {
+	Cmdline string `yaml:"cmdline"`
+}
+
+// NewKernelCmdline initializes a KernelCmdline resource.
+func NewKernelCmdline() *KernelCmdline {
+	r := &KernelCmdline{
+		md:   resource.NewMetadata(NamespaceName, KernelCmdlineType, KernelCmdlineID, resource.VersionUndefined),
+		spec: KernelCmdlineSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KernelCmdline) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KernelCmdline) Spec() interface{} {
+	return r.spec
+}
+
+func (r *KernelCmdline) String() string {
+	return fmt.Sprintf("runtime.KernelCmdline(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KernelCmdline) DeepCopy() resource.Resource {
+	return &KernelCmdline{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KernelCmdline) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KernelCmdlineType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Cmdline",
+				JSONPath: `{.cmdline}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *KernelCmdline) TypedSpec() *KernelCmdlineSpec {
+	return &r.spec
+}
@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// KernelConfigOptionType is type of KernelConfigOption resource.
+const KernelConfigOptionType = resource.Type("KernelConfigOptions.runtime.talos.dev")
+
+// KernelConfigOption resource describes a kernel build configuration option.
+type KernelConfigOption struct {
+	md   resource.Metadata
+	spec KernelConfigOptionSpec
+}
+
+// KernelConfigOptionSpec describes the value of a kernel build configuration option.
+//
+// Options which are not set have value `n`.
+type KernelConfigOptionSpec struct {
+	Value string `yaml:"value"`
+}
+
+// NewKernelConfigOption initializes a KernelConfigOption resource.
+func NewKernelConfigOption(id resource.ID) *KernelConfigOption {
+	r := &KernelConfigOption{
+		md:   resource.NewMetadata(NamespaceName, KernelConfigOptionType, id, resource.VersionUndefined),
+		spec: KernelConfigOptionSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KernelConfigOption) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KernelConfigOption) Spec() interface{} {
+	return r.spec
+}
+
+func (r *KernelConfigOption) String() string {
+	return fmt.Sprintf("runtime.KernelConfigOption(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KernelConfigOption) DeepCopy() resource.Resource {
+	return &KernelConfigOption{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KernelConfigOption) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KernelConfigOptionType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Value",
+				JSONPath: `{.value}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *KernelConfigOption) TypedSpec() *KernelConfigOptionSpec {
+	return &r.spec
+}
@@ -0,0 +1,100 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// KernelModuleStatusType is type of KernelModuleStatus resource.
+const KernelModuleStatusType = resource.Type("KernelModuleStatuses.runtime.talos.dev")
+
+// KernelModuleStatus resource describes a loaded kernel module.
+type KernelModuleStatus struct {
+	md   resource.Metadata
+	spec KernelModuleStatusSpec
+}
+
+// KernelModuleStatusSpec describes a loaded kernel module and its parameters.
+type KernelModuleStatusSpec struct {
+	Size         uint64            `yaml:"size"`
+	Dependencies []string          `yaml:"dependencies,omitempty"`
+	Parameters   map[string]string `yaml:"parameters,omitempty"`
+}
+
+// NewKernelModuleStatus initializes a KernelModuleStatus resource.
+func NewKernelModuleStatus(id resource.ID) *KernelModuleStatus {
+	r := &KernelModuleStatus{
+		md:   resource.NewMetadata(NamespaceName, KernelModuleStatusType, id, resource.VersionUndefined),
+		spec: KernelModuleStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KernelModuleStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KernelModuleStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *KernelModuleStatus) String() string {
+	return fmt.Sprintf("runtime.KernelModuleStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KernelModuleStatus) DeepCopy() resource.Resource {
+	var parameters map[string]string
+
+	if r.spec.Parameters != nil {
+		parameters = make(map[string]string, len(r.spec.Parameters))
+
+		for k, v := range r.spec.Parameters {
+			parameters[k] = v
+		}
+	}
+
+	return &KernelModuleStatus{
+		md: r.md,
+		spec: KernelModuleStatusSpec{
+			Size:         r.spec.Size,
+			Dependencies: append([]string(nil), r.spec.Dependencies...),
+			Parameters:   parameters,
+		},
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KernelModuleStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KernelModuleStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Size",
+				JSONPath: `{.size}`,
+			},
+			{
+				Name:     "Dependencies",
+				JSONPath: `{.dependencies}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *KernelModuleStatus) TypedSpec() *KernelModuleStatusSpec {
+	return &r.spec
+}
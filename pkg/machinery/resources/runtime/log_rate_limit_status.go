@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// LogRateLimitStatusType is type of LogRateLimitStatus resource.
+const LogRateLimitStatusType = resource.Type("LogRateLimitStatuses.runtime.talos.dev")
+
+// LogRateLimitStatus resource describes rate limiting of a service log.
+type LogRateLimitStatus struct {
+	md   resource.Metadata
+	spec LogRateLimitStatusSpec
+}
+
+// LogRateLimitStatusSpec describes effective rate limit and number of dropped log lines.
+type LogRateLimitStatusSpec struct {
+	Burst        int    `yaml:"burst"`
+	Rate         int    `yaml:"rate"`
+	DroppedLines uint64 `yaml:"droppedLines"`
+}
+
+// NewLogRateLimitStatus initializes a LogRateLimitStatus resource.
+func NewLogRateLimitStatus(id resource.ID) *LogRateLimitStatus {
+	r := &LogRateLimitStatus{
+		md:   resource.NewMetadata(NamespaceName, LogRateLimitStatusType, id, resource.VersionUndefined),
+		spec: LogRateLimitStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *LogRateLimitStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *LogRateLimitStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *LogRateLimitStatus) String() string {
+	return fmt.Sprintf("runtime.LogRateLimitStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *LogRateLimitStatus) DeepCopy() resource.Resource {
+	return &LogRateLimitStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *LogRateLimitStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             LogRateLimitStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Burst",
+				JSONPath: `{.burst}`,
+			},
+			{
+				Name:     "Rate",
+				JSONPath: `{.rate}`,
+			},
+			{
+				Name:     "Dropped",
+				JSONPath: `{.droppedLines}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *LogRateLimitStatus) TypedSpec() *LogRateLimitStatusSpec {
+	return &r.spec
+}
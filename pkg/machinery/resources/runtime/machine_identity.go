@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// MachineIdentityType is type of MachineIdentity resource.
+const MachineIdentityType = resource.Type("MachineIdentities.runtime.talos.dev")
+
+// MachineIdentityID is the singleton resource ID.
+const MachineIdentityID = resource.ID("machine")
+
+// MachineIdentity resource holds stable machine identity.
+type MachineIdentity struct {
+	md   resource.Metadata
+	spec MachineIdentitySpec
+}
+
+// MachineIdentitySpec describes stable machine identity.
+type MachineIdentitySpec struct {
+	// MachineID is the hardware UUID of the machine (if available), or the node ID otherwise.
+	MachineID string `yaml:"machineID"`
+	// Source of the MachineID: "smbios" or "node-id".
+	Source string `yaml:"source"`
+	// StableHostname is the hostname derived from the MachineID.
+	StableHostname string `yaml:"stableHostname"`
+}
+
+// NewMachineIdentity initializes a MachineIdentity resource.
+func NewMachineIdentity() *MachineIdentity {
+	r := &MachineIdentity{
+		md:   resource.NewMetadata(NamespaceName, MachineIdentityType, MachineIdentityID, resource.VersionUndefined),
+		spec: MachineIdentitySpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *MachineIdentity) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *MachineIdentity) Spec() interface{} {
+	return r.spec
+}
+
+func (r *MachineIdentity) String() string {
+	return fmt.Sprintf("runtime.MachineIdentity(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *MachineIdentity) DeepCopy() resource.Resource {
+	return &MachineIdentity{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *MachineIdentity) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             MachineIdentityType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Machine ID",
+				JSONPath: `{.machineID}`,
+			},
+			{
+				Name:     "Source",
+				JSONPath: `{.source}`,
+			},
+			{
+				Name:     "Stable Hostname",
+				JSONPath: `{.stableHostname}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *MachineIdentity) TypedSpec() *MachineIdentitySpec {
+	return &r.spec
+}
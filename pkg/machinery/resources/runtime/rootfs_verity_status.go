@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// RootfsVerityStatusType is type of RootfsVerityStatus resource.
+const RootfsVerityStatusType = resource.Type("RootfsVerityStatuses.runtime.talos.dev")
+
+// RootfsVerityStatusID is the singleton resource ID.
+const RootfsVerityStatusID = resource.ID("rootfs")
+
+// RootfsVerityStatus resource describes dm-verity verification status of the rootfs.
+type RootfsVerityStatus struct {
+	md   resource.Metadata
+	spec RootfsVerityStatusSpec
+}
+
+// RootfsVerityStatusSpec describes dm-verity verification status of the rootfs.
+type RootfsVerityStatusSpec struct {
+	// Enabled is set if the rootfs is mounted via the dm-verity device.
+	Enabled bool `yaml:"enabled"`
+	// RootHash is the root hash passed via the kernel args.
+	RootHash string `yaml:"rootHash"`
+	// Corrupted is set if the kernel detected a block not matching the hash tree.
+	Corrupted bool `yaml:"corrupted"`
+}
+
+// NewRootfsVerityStatus initializes a RootfsVerityStatus resource.
+func NewRootfsVerityStatus() *RootfsVerityStatus {
+	r := &RootfsVerityStatus{
+		md:   resource.NewMetadata(NamespaceName, RootfsVerityStatusType, RootfsVerityStatusID, resource.VersionUndefined),
+		spec: RootfsVerityStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *RootfsVerityStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *RootfsVerityStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *RootfsVerityStatus) String() string {
+	return fmt.Sprintf("runtime.RootfsVerityStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *RootfsVerityStatus) DeepCopy() resource.Resource {
+	return &RootfsVerityStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *RootfsVerityStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             RootfsVerityStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Enabled",
+				JSONPath: `{.enabled}`,
+			},
+			{
+				Name:     "Corrupted",
+				JSONPath: `{.corrupted}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *RootfsVerityStatus) TypedSpec() *RootfsVerityStatusSpec {
+	return &r.spec
+}
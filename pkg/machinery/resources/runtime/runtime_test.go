@@ -25,9 +25,28 @@ func TestRegisterResource(t *testing.T) {
 	resourceRegistry := registry.NewResourceRegistry(resources)
 
 	for _, resource := range []resource.Resource{
+		&runtime.Accelerator{},
+		&runtime.BMCInfo{},
+		&runtime.BootDiagnostics{},
+		&runtime.CPUPowerStatus{},
+		&runtime.ControllerStatus{},
+		&runtime.Disk{},
+		&runtime.DrainStatus{},
+		&runtime.HardwareFacts{},
+		&runtime.HealthCheckStatus{},
+		&runtime.HugePageStatus{},
+		&runtime.InstanceIdentity{},
+		&runtime.KernelCmdline{},
+		&runtime.KernelConfigOption{},
+		&runtime.KernelModuleStatus{},
 		&runtime.KernelParamSpec{},
 		&runtime.KernelParamStatus{},
+		&runtime.LogRateLimitStatus{},
+		&runtime.MachineIdentity{},
 		&runtime.MountStatus{},
+		&runtime.RootfsVerityStatus{},
+		&runtime.UpgradeHistory{},
+		&runtime.UpgradeStatus{},
 	} {
 		assert.NoError(t, resourceRegistry.Register(ctx, resource))
 	}
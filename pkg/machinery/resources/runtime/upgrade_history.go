@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// UpgradeHistoryType is type of UpgradeHistory resource.
+const UpgradeHistoryType = resource.Type("UpgradeHistories.runtime.talos.dev")
+
+// Upgrade history actions.
+const (
+	UpgradeActionInstall  = "install"
+	UpgradeActionUpgrade  = "upgrade"
+	UpgradeActionRollback = "rollback"
+)
+
+// Upgrade history outcomes.
+const (
+	UpgradeOutcomePending = "pending"
+	UpgradeOutcomeSuccess = "success"
+	UpgradeOutcomeFailed  = "failed"
+)
+
+// UpgradeHistory resource describes a single install, upgrade or rollback event.
+type UpgradeHistory struct {
+	md   resource.Metadata
+	spec UpgradeHistorySpec
+}
+
+// UpgradeHistorySpec describes a single install, upgrade or rollback event.
+type UpgradeHistorySpec struct {
+	Action      string    `yaml:"action"`
+	FromVersion string    `yaml:"fromVersion,omitempty"`
+	ToVersion   string    `yaml:"toVersion,omitempty"`
+	Image       string    `yaml:"image,omitempty"`
+	Timestamp   time.Time `yaml:"timestamp"`
+	Outcome     string    `yaml:"outcome"`
+}
+
+// NewUpgradeHistory initializes a UpgradeHistory resource.
+func NewUpgradeHistory(id resource.ID) *UpgradeHistory {
+	r := &UpgradeHistory{
+		md:   resource.NewMetadata(NamespaceName, UpgradeHistoryType, id, resource.VersionUndefined),
+		spec: UpgradeHistorySpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *UpgradeHistory) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *UpgradeHistory) Spec() interface{} {
+	return r.spec
+}
+
+func (r *UpgradeHistory) String() string {
+	return fmt.Sprintf("runtime.UpgradeHistory(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *UpgradeHistory) DeepCopy() resource.Resource {
+	return &UpgradeHistory{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *UpgradeHistory) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             UpgradeHistoryType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Action",
+				JSONPath: `{.action}`,
+			},
+			{
+				Name:     "From",
+				JSONPath: `{.fromVersion}`,
+			},
+			{
+				Name:     "To",
+				JSONPath: `{.toVersion}`,
+			},
+			{
+				Name:     "Outcome",
+				JSONPath: `{.outcome}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *UpgradeHistory) TypedSpec() *UpgradeHistorySpec {
+	return &r.spec
+}
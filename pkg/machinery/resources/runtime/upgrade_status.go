@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// UpgradeStatusType is type of UpgradeStatus resource.
+const UpgradeStatusType = resource.Type("UpgradeStatuses.runtime.talos.dev")
+
+// UpgradeStatusID is the singleton resource ID.
+const UpgradeStatusID = resource.ID("current")
+
+// UpgradeStatus resource describes an upgrade which is in progress.
+//
+// The resource only exists while the upgrade is running.
+type UpgradeStatus struct {
+	md   resource.Metadata
+	spec UpgradeStatusSpec
+}
+
+// UpgradeStatusSpec describes an upgrade which is in progress.
+type UpgradeStatusSpec struct {
+	Sequence    string    `yaml:"sequence"`
+	Phase       string    `yaml:"phase"`
+	FromVersion string    `yaml:"fromVersion"`
+	Started     time.Time `yaml:"started"`
+}
+
+// NewUpgradeStatus initializes a UpgradeStatus resource.
+func NewUpgradeStatus() *UpgradeStatus {
+	r := &UpgradeStatus{
+		md:   resource.NewMetadata(NamespaceName, UpgradeStatusType, UpgradeStatusID, resource.VersionUndefined),
+		spec: UpgradeStatusSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *UpgradeStatus) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *UpgradeStatus) Spec() interface{} {
+	return r.spec
+}
+
+func (r *UpgradeStatus) String() string {
+	return fmt.Sprintf("runtime.UpgradeStatus(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *UpgradeStatus) DeepCopy() resource.Resource {
+	return &UpgradeStatus{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *UpgradeStatus) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             UpgradeStatusType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Sequence",
+				JSONPath: `{.sequence}`,
+			},
+			{
+				Name:     "Phase",
+				JSONPath: `{.phase}`,
+			},
+			{
+				Name:     "Started",
+				JSONPath: `{.started}`,
+			},
+		},
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *UpgradeStatus) TypedSpec() *UpgradeStatusSpec {
+	return &r.spec
+}
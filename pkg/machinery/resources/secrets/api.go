@@ -33,6 +33,9 @@ type APICertsSpec struct {
 	CA     *x509.PEMEncodedCertificateAndKey `yaml:"ca"` // only cert is passed, without key
 	Client *x509.PEMEncodedCertificateAndKey `yaml:"client"`
 	Server *x509.PEMEncodedCertificateAndKey `yaml:"server"`
+
+	// AcceptedCAs are additional CA certificates accepted for the client certificates.
+	AcceptedCAs [][]byte `yaml:"acceptedCAs"`
 }
 
 // MarshalProto implements ProtoMarshaler.
@@ -47,6 +50,7 @@ func (spec *APICertsSpec) MarshalProto() ([]byte, error) {
 			Cert: spec.Server.Crt,
 			Key:  spec.Server.Key,
 		},
+		AcceptedCaPems: spec.AcceptedCAs,
 	}
 
 	return proto.Marshal(&protoSpec)
@@ -125,6 +129,7 @@ func (r *API) UnmarshalProto(md *resource.Metadata, protoBytes []byte) error {
 			Crt: protoSpec.Server.Cert,
 			Key: protoSpec.Server.Key,
 		},
+		AcceptedCAs: protoSpec.AcceptedCaPems,
 	}
 
 	return nil
@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package secrets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+)
+
+// KubeletBootstrapTokenType is type of KubeletBootstrapToken secret resource.
+const KubeletBootstrapTokenType = resource.Type("KubeletBootstrapTokens.secrets.talos.dev")
+
+// KubeletBootstrapTokenID is the ID of KubeletBootstrapToken.
+const KubeletBootstrapTokenID = resource.ID("kubelet")
+
+// KubeletBootstrapToken contains short-lived kubelet bootstrap token minted via Kubernetes API.
+type KubeletBootstrapToken struct {
+	md   resource.Metadata
+	spec KubeletBootstrapTokenSpec
+}
+
+// KubeletBootstrapTokenSpec describes short-lived kubelet bootstrap token.
+type KubeletBootstrapTokenSpec struct {
+	TokenID     string    `yaml:"tokenID"`
+	TokenSecret string    `yaml:"tokenSecret"`
+	Expiration  time.Time `yaml:"expiration"`
+}
+
+// Token returns bootstrap token in the `id.secret` format.
+func (spec *KubeletBootstrapTokenSpec) Token() string {
+	return spec.TokenID + "." + spec.TokenSecret
+}
+
+// NewKubeletBootstrapToken initializes a KubeletBootstrapToken resource.
+func NewKubeletBootstrapToken(id resource.ID) *KubeletBootstrapToken {
+	r := &KubeletBootstrapToken{
+		md:   resource.NewMetadata(NamespaceName, KubeletBootstrapTokenType, id, resource.VersionUndefined),
+		spec: KubeletBootstrapTokenSpec{},
+	}
+
+	r.md.BumpVersion()
+
+	return r
+}
+
+// Metadata implements resource.Resource.
+func (r *KubeletBootstrapToken) Metadata() *resource.Metadata {
+	return &r.md
+}
+
+// Spec implements resource.Resource.
+func (r *KubeletBootstrapToken) Spec() interface{} {
+	return &r.spec
+}
+
+func (r *KubeletBootstrapToken) String() string {
+	return fmt.Sprintf("secrets.KubeletBootstrapToken(%q)", r.md.ID())
+}
+
+// DeepCopy implements resource.Resource.
+func (r *KubeletBootstrapToken) DeepCopy() resource.Resource {
+	return &KubeletBootstrapToken{
+		md:   r.md,
+		spec: r.spec,
+	}
+}
+
+// ResourceDefinition implements meta.ResourceDefinitionProvider interface.
+func (r *KubeletBootstrapToken) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             KubeletBootstrapTokenType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "Token ID",
+				JSONPath: `{.tokenID}`,
+			},
+			{
+				Name:     "Expiration",
+				JSONPath: `{.expiration}`,
+			},
+		},
+		Sensitivity: meta.Sensitive,
+	}
+}
+
+// TypedSpec returns .spec.
+func (r *KubeletBootstrapToken) TypedSpec() *KubeletBootstrapTokenSpec {
+	return &r.spec
+}
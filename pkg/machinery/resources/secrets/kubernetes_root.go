@@ -28,11 +28,12 @@ type KubernetesRoot struct {
 
 // KubernetesRootSpec describes root Kubernetes secrets.
 type KubernetesRootSpec struct {
-	Name         string   `yaml:"name"`
-	Endpoint     *url.URL `yaml:"endpoint"`
-	CertSANs     []string `yaml:"certSANs"`
-	APIServerIPs []net.IP `yaml:"apiServerIPs"`
-	DNSDomain    string   `yaml:"dnsDomain"`
+	Name             string   `yaml:"name"`
+	Endpoint         *url.URL `yaml:"endpoint"`
+	InternalEndpoint *url.URL `yaml:"internalEndpoint"`
+	CertSANs         []string `yaml:"certSANs"`
+	APIServerIPs     []net.IP `yaml:"apiServerIPs"`
+	DNSDomain        string   `yaml:"dnsDomain"`
 
 	CA             *x509.PEMEncodedCertificateAndKey `yaml:"ca"`
 	ServiceAccount *x509.PEMEncodedKey               `yaml:"serviceAccount"`
@@ -28,9 +28,13 @@ type OSRoot struct {
 // OSRootSpec describes operating system CA.
 type OSRootSpec struct {
 	CA              *x509.PEMEncodedCertificateAndKey `yaml:"ca"`
+	AcceptedCAs     [][]byte                          `yaml:"acceptedCAs"`
 	CertSANIPs      []netaddr.IP                      `yaml:"certSANIPs"`
 	CertSANDNSNames []string                          `yaml:"certSANDNSNames"`
 
+	// APIServer is the externally signed apid server certificate (if configured).
+	APIServer *x509.PEMEncodedCertificateAndKey `yaml:"apiServer"`
+
 	Token string `yaml:"token"`
 }
 
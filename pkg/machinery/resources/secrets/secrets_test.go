@@ -29,6 +29,7 @@ func TestRegisterResource(t *testing.T) {
 		&secrets.CertSAN{},
 		&secrets.Etcd{},
 		&secrets.EtcdRoot{},
+		&secrets.KubeletBootstrapToken{},
 		&secrets.Kubernetes{},
 		&secrets.KubernetesRoot{},
 		&secrets.OSRoot{},
@@ -27,6 +27,11 @@ const (
 	// EtcdBackup defines Talos role that allows making etcd backups.
 	EtcdBackup = Role(Prefix + "etcd:backup")
 
+	// Debug defines Talos role that allows running privileged debug containers with access to the host.
+	//
+	// It is not implied by Admin role and should be granted explicitly.
+	Debug = Role(Prefix + "debug")
+
 	// Impersonator defines Talos role for impersonating another user (and their role).
 	// Used internally, but may also be granted to the user.
 	Impersonator = Role(Prefix + "impersonator")
@@ -39,7 +44,7 @@ type Set struct {
 
 var (
 	// All roles that can be granted to users.
-	All = MakeSet(Admin, Reader, EtcdBackup, Impersonator)
+	All = MakeSet(Admin, Reader, EtcdBackup, Debug, Impersonator)
 
 	// Zero is an empty set of roles.
 	Zero = MakeSet()
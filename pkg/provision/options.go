@@ -97,6 +97,24 @@ func WithDockerPortsHostIP(hostIP string) Option {
 	}
 }
 
+// WithPreserveState keeps the cluster state (disks, containers) on destroy, so that the cluster can be started again.
+func WithPreserveState(preserve bool) Option {
+	return func(o *Options) error {
+		o.PreserveState = preserve
+
+		return nil
+	}
+}
+
+// WithSelfExecutable specifies path to the talosctl executable used to launch the helper processes on cluster start.
+func WithSelfExecutable(path string) Option {
+	return func(o *Options) error {
+		o.SelfExecutable = path
+
+		return nil
+	}
+}
+
 // Options describes Provisioner parameters.
 type Options struct {
 	LogWriter     io.Writer
@@ -114,6 +132,12 @@ type Options struct {
 	// Expose ports to worker machines in docker provisioner
 	DockerPorts       []string
 	DockerPortsHostIP string
+
+	// Keep the cluster state on destroy
+	PreserveState bool
+
+	// Path to the talosctl executable (VM only)
+	SelfExecutable string
 }
 
 // DefaultOptions returns default options.
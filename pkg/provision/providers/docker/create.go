@@ -27,6 +27,18 @@ func (p *provisioner) Create(ctx context.Context, request provision.ClusterReque
 		return nil, err
 	}
 
+	for _, nodeReq := range request.Nodes {
+		if nodeReq.ExtraNICs > 0 {
+			return nil, fmt.Errorf("node %q: extra NICs are not supported by the docker provisioner", nodeReq.Name)
+		}
+
+		if nodeReq.Image != "" && nodeReq.Image != request.Image {
+			if err = p.ensureImageExists(ctx, nodeReq.Image, &options); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	fmt.Fprintln(options.LogWriter, "creating network", request.Network.Name)
 
 	if err = p.createNetwork(ctx, request.Network); err != nil {
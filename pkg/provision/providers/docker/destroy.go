@@ -23,6 +23,11 @@ func (p *provisioner) Destroy(ctx context.Context, cluster provision.Cluster, op
 		}
 	}
 
+	if options.PreserveState {
+		// containers are stopped, so that the node state is kept
+		return p.stopNodes(ctx, cluster.Info().ClusterName, &options)
+	}
+
 	if err := p.destroyNodes(ctx, cluster.Info().ClusterName, &options); err != nil {
 		return err
 	}
@@ -65,6 +65,11 @@ func (p *provisioner) createNodes(ctx context.Context, clusterReq provision.Clus
 func (p *provisioner) createNode(ctx context.Context, clusterReq provision.ClusterRequest, nodeReq provision.NodeRequest, options *provision.Options) (provision.NodeInfo, error) {
 	env := []string{"PLATFORM=container"}
 
+	image := clusterReq.Image
+	if nodeReq.Image != "" {
+		image = nodeReq.Image
+	}
+
 	if !nodeReq.SkipInjectingConfig {
 		cfg, err := nodeReq.Config.String()
 		if err != nil {
@@ -77,12 +82,13 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 	// Create the container config.
 	containerConfig := &container.Config{
 		Hostname: nodeReq.Name,
-		Image:    clusterReq.Image,
+		Image:    image,
 		Env:      env,
 		Labels: map[string]string{
 			"talos.owned":        "true",
 			"talos.cluster.name": clusterReq.Name,
 			"talos.type":         nodeReq.Type.String(),
+			"talos.pool":         nodeReq.Pool,
 		},
 		Volumes: map[string]struct{}{
 			"/var/lib/containerd": {},
@@ -165,6 +171,7 @@ func (p *provisioner) createNode(ctx context.Context, clusterReq provision.Clust
 		ID:   info.ID,
 		Name: info.Name,
 		Type: nodeReq.Type,
+		Pool: nodeReq.Pool,
 
 		NanoCPUs: nodeReq.NanoCPUs,
 		Memory:   nodeReq.Memory,
@@ -208,6 +215,60 @@ func (p *provisioner) destroyNodes(ctx context.Context, clusterName string, opti
 	return multiErr.ErrorOrNil()
 }
 
+func (p *provisioner) stopNodes(ctx context.Context, clusterName string, options *provision.Options) error {
+	containers, err := p.listNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error)
+
+	for _, container := range containers {
+		go func(container types.Container) {
+			fmt.Fprintln(options.LogWriter, "stopping node", container.Names[0][1:])
+
+			errCh <- p.client.ContainerStop(ctx, container.ID, nil)
+		}(container)
+	}
+
+	var multiErr *multierror.Error
+
+	for range containers {
+		multiErr = multierror.Append(multiErr, <-errCh)
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
+func (p *provisioner) startNodes(ctx context.Context, clusterName string, options *provision.Options) error {
+	containers, err := p.listNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	if len(containers) == 0 {
+		return fmt.Errorf("cluster %q has no nodes, was it destroyed with --preserve-state?", clusterName)
+	}
+
+	errCh := make(chan error)
+
+	for _, container := range containers {
+		go func(container types.Container) {
+			fmt.Fprintln(options.LogWriter, "starting node", container.Names[0][1:])
+
+			errCh <- p.client.ContainerStart(ctx, container.ID, types.ContainerStartOptions{})
+		}(container)
+	}
+
+	var multiErr *multierror.Error
+
+	for range containers {
+		multiErr = multierror.Append(multiErr, <-errCh)
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
 func genPortMap(portList []string, hostIP string) (portMap, error) {
 	portSetRet := nat.PortSet{}
 	portMapRet := nat.PortMap{}
@@ -61,13 +61,21 @@ func (p *provisioner) Reflect(ctx context.Context, clusterName, stateDirectory s
 			return nil, err
 		}
 
+		var ips []net.IP
+
+		// stopped containers (cluster destroyed with the preserved state) might have no address
+		if settings := node.NetworkSettings.Networks[res.clusterInfo.Network.Name]; settings != nil && settings.IPAddress != "" {
+			ips = []net.IP{net.ParseIP(settings.IPAddress)}
+		}
+
 		res.clusterInfo.Nodes = append(res.clusterInfo.Nodes,
 			provision.NodeInfo{
 				ID:   node.ID,
 				Name: strings.TrimLeft(node.Names[0], "/"),
 				Type: t,
+				Pool: node.Labels["talos.pool"],
 
-				IPs: []net.IP{net.ParseIP(node.NetworkSettings.Networks[res.clusterInfo.Network.Name].IPAddress)},
+				IPs: ips,
 			})
 	}
 
@@ -0,0 +1,26 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package docker
+
+import (
+	"context"
+
+	"github.com/talos-systems/talos/pkg/provision"
+)
+
+// Start Talos cluster stopped with the preserved state.
+//
+// Only cluster.Info().ClusterName is being used.
+func (p *provisioner) Start(ctx context.Context, cluster provision.Cluster, opts ...provision.Option) error {
+	options := provision.DefaultOptions()
+
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return err
+		}
+	}
+
+	return p.startNodes(ctx, cluster.Info().ClusterName, &options)
+}
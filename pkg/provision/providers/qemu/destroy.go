@@ -7,6 +7,7 @@ package qemu
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 
 	"github.com/talos-systems/talos/pkg/provision"
@@ -14,6 +15,8 @@ import (
 )
 
 // Destroy Talos cluster as set of qemu VMs.
+//
+//nolint:gocyclo
 func (p *provisioner) Destroy(ctx context.Context, cluster provision.Cluster, opts ...provision.Option) error {
 	options := provision.DefaultOptions()
 
@@ -23,41 +26,50 @@ func (p *provisioner) Destroy(ctx context.Context, cluster provision.Cluster, op
 		}
 	}
 
-	fmt.Fprintln(options.LogWriter, "stopping VMs")
-
-	if err := p.DestroyNodes(cluster.Info(), &options); err != nil {
-		return err
-	}
-
 	state, ok := cluster.(*vm.State)
 	if !ok {
 		return fmt.Errorf("error inspecting QEMU state, %#+v", cluster)
 	}
 
-	fmt.Fprintln(options.LogWriter, "removing dhcpd")
+	// cluster which is not running (destroyed with the preserved state or after the host reboot) has no bridge interface
+	if _, err := net.InterfaceByName(state.BridgeName); err == nil {
+		fmt.Fprintln(options.LogWriter, "stopping VMs")
 
-	if err := p.DestroyDHCPd(state); err != nil {
-		return fmt.Errorf("error stopping dhcpd: %w", err)
-	}
+		if err = p.DestroyNodes(cluster.Info(), &options); err != nil {
+			return err
+		}
 
-	fmt.Fprintln(options.LogWriter, "removing load balancer")
+		fmt.Fprintln(options.LogWriter, "removing dhcpd")
 
-	if err := p.DestroyLoadBalancer(state); err != nil {
-		return fmt.Errorf("error stopping loadbalancer: %w", err)
-	}
+		if err = p.DestroyDHCPd(state); err != nil {
+			return fmt.Errorf("error stopping dhcpd: %w", err)
+		}
 
-	fmt.Fprintln(options.LogWriter, "removing network")
+		fmt.Fprintln(options.LogWriter, "removing load balancer")
 
-	if err := p.DestroyNetwork(state); err != nil {
-		return err
-	}
+		if err = p.DestroyLoadBalancer(state); err != nil {
+			return fmt.Errorf("error stopping loadbalancer: %w", err)
+		}
 
-	fmt.Fprintln(options.LogWriter, "removing state directory")
+		fmt.Fprintln(options.LogWriter, "removing network")
+
+		if err = p.DestroyNetwork(state); err != nil {
+			return err
+		}
+	}
 
 	stateDirectoryPath, err := cluster.StatePath()
 	if err != nil {
 		return err
 	}
 
+	if options.PreserveState {
+		fmt.Fprintf(options.LogWriter, "cluster state is preserved in %q\n", stateDirectoryPath)
+
+		return nil
+	}
+
+	fmt.Fprintln(options.LogWriter, "removing state directory")
+
 	return os.RemoveAll(stateDirectoryPath)
 }
@@ -49,6 +49,7 @@ type LaunchConfig struct {
 	BootloaderEnabled bool
 	NodeUUID          uuid.UUID
 	BadRTC            bool
+	ExtraNICs         int
 
 	// Talos config
 	Config string
@@ -242,8 +243,6 @@ func launchVM(config *LaunchConfig) error {
 		"-nographic",
 		"-netdev", fmt.Sprintf("tap,id=net0,ifname=%s,script=no,downscript=no", config.tapName),
 		"-device", fmt.Sprintf("virtio-net-pci,netdev=net0,mac=%s", config.vmMAC),
-		// TODO: uncomment the following line to get another eth interface not connected to anything
-		// "-nic", "tap,model=virtio-net-pci",
 		"-device", "virtio-rng-pci",
 		"-device", "virtio-balloon,deflate-on-oom=on",
 		"-monitor", fmt.Sprintf("unix:%s,server,nowait", config.MonitorPath),
@@ -252,6 +251,11 @@ func launchVM(config *LaunchConfig) error {
 		"-smbios", fmt.Sprintf("type=1,uuid=%s", config.NodeUUID),
 	}
 
+	// extra NICs are attached to the isolated user-mode networks, not connected to the cluster network
+	for i := 0; i < config.ExtraNICs; i++ {
+		args = append(args, "-nic", "user,model=virtio-net-pci,restrict=on")
+	}
+
 	for _, disk := range config.DiskPaths {
 		args = append(args, "-drive", fmt.Sprintf("format=raw,if=virtio,file=%s", disk))
 	}
@@ -31,8 +31,6 @@ import (
 //nolint:gocyclo
 func (p *provisioner) createNode(state *vm.State, clusterReq provision.ClusterRequest, nodeReq provision.NodeRequest, opts *provision.Options) (provision.NodeInfo, error) {
 	arch := Arch(opts.TargetArch)
-	pidPath := state.GetRelativePath(fmt.Sprintf("%s.pid", nodeReq.Name))
-
 	var pflashImages []string
 
 	if pflashSpec := arch.PFlash(opts.UEFIEnabled); pflashSpec != nil {
@@ -60,13 +58,6 @@ func (p *provisioner) createNode(state *vm.State, clusterReq provision.ClusterRe
 		return provision.NodeInfo{}, err
 	}
 
-	logFile, err := os.OpenFile(state.GetRelativePath(fmt.Sprintf("%s.log", nodeReq.Name)), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o666)
-	if err != nil {
-		return provision.NodeInfo{}, err
-	}
-
-	defer logFile.Close() //nolint:errcheck
-
 	cmdline := procfs.NewCmdline("")
 
 	cmdline.SetAll(kernel.DefaultArgs)
@@ -139,12 +130,24 @@ func (p *provisioner) createNode(state *vm.State, clusterReq provision.ClusterRe
 		TFTPServer:        nodeReq.TFTPServer,
 		IPXEBootFileName:  nodeReq.IPXEBootFilename,
 		APIPort:           apiPort,
+		ExtraNICs:         nodeReq.ExtraNICs,
 	}
 
 	if !nodeReq.PXEBooted {
-		launchConfig.KernelImagePath = strings.ReplaceAll(clusterReq.KernelPath, constants.ArchVariable, opts.TargetArch)
-		launchConfig.InitrdPath = strings.ReplaceAll(clusterReq.InitramfsPath, constants.ArchVariable, opts.TargetArch)
-		launchConfig.ISOPath = strings.ReplaceAll(clusterReq.ISOPath, constants.ArchVariable, opts.TargetArch)
+		kernelPath, initramfsPath, isoPath := clusterReq.KernelPath, clusterReq.InitramfsPath, clusterReq.ISOPath
+
+		// node overrides (e.g. to run different Talos versions)
+		if nodeReq.KernelPath != "" {
+			kernelPath, initramfsPath = nodeReq.KernelPath, nodeReq.InitramfsPath
+		}
+
+		if nodeReq.ISOPath != "" {
+			isoPath = nodeReq.ISOPath
+		}
+
+		launchConfig.KernelImagePath = strings.ReplaceAll(kernelPath, constants.ArchVariable, opts.TargetArch)
+		launchConfig.InitrdPath = strings.ReplaceAll(initramfsPath, constants.ArchVariable, opts.TargetArch)
+		launchConfig.ISOPath = strings.ReplaceAll(isoPath, constants.ArchVariable, opts.TargetArch)
 	}
 
 	launchConfig.StatePath, err = state.StatePath()
@@ -157,39 +160,26 @@ func (p *provisioner) createNode(state *vm.State, clusterReq provision.ClusterRe
 		return provision.NodeInfo{}, err
 	}
 
+	defer launchConfigFile.Close() //nolint:errcheck
+
 	if err = json.NewEncoder(launchConfigFile).Encode(&launchConfig); err != nil {
 		return provision.NodeInfo{}, err
 	}
 
-	if _, err = launchConfigFile.Seek(0, io.SeekStart); err != nil {
+	if err = launchConfigFile.Close(); err != nil {
 		return provision.NodeInfo{}, err
 	}
 
-	defer launchConfigFile.Close() //nolint:errcheck
-
-	cmd := exec.Command(clusterReq.SelfExecutable, "qemu-launch")
-	cmd.Stdout = logFile
-	cmd.Stderr = logFile
-	cmd.Stdin = launchConfigFile
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setsid: true, // daemonize
-	}
-
-	if err = cmd.Start(); err != nil {
+	if err = p.launchNode(state, nodeReq.Name, clusterReq.SelfExecutable); err != nil {
 		return provision.NodeInfo{}, err
 	}
 
-	if err = ioutil.WriteFile(pidPath, []byte(strconv.Itoa(cmd.Process.Pid)), os.ModePerm); err != nil {
-		return provision.NodeInfo{}, fmt.Errorf("error writing PID file: %w", err)
-	}
-
-	// no need to wait here, as cmd has all the Stdin/out/err via *os.File
-
 	nodeInfo := provision.NodeInfo{
-		ID:   pidPath,
+		ID:   state.GetRelativePath(fmt.Sprintf("%s.pid", nodeReq.Name)),
 		UUID: nodeUUID,
 		Name: nodeReq.Name,
 		Type: nodeReq.Type,
+		Pool: nodeReq.Pool,
 
 		NanoCPUs: nodeReq.NanoCPUs,
 		Memory:   nodeReq.Memory,
@@ -203,6 +193,43 @@ func (p *provisioner) createNode(state *vm.State, clusterReq provision.ClusterRe
 	return nodeInfo, nil
 }
 
+// launchNode starts the VM launcher process with the launch config stored in the state directory.
+func (p *provisioner) launchNode(state *vm.State, nodeName, selfExecutable string) error {
+	logFile, err := os.OpenFile(state.GetRelativePath(fmt.Sprintf("%s.log", nodeName)), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o666)
+	if err != nil {
+		return err
+	}
+
+	defer logFile.Close() //nolint:errcheck
+
+	launchConfigFile, err := os.Open(state.GetRelativePath(fmt.Sprintf("%s.config", nodeName)))
+	if err != nil {
+		return err
+	}
+
+	defer launchConfigFile.Close() //nolint:errcheck
+
+	cmd := exec.Command(selfExecutable, "qemu-launch")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.Stdin = launchConfigFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid: true, // daemonize
+	}
+
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(state.GetRelativePath(fmt.Sprintf("%s.pid", nodeName)), []byte(strconv.Itoa(cmd.Process.Pid)), os.ModePerm); err != nil {
+		return fmt.Errorf("error writing PID file: %w", err)
+	}
+
+	// no need to wait here, as cmd has all the Stdin/out/err via *os.File
+
+	return nil
+}
+
 func (p *provisioner) createNodes(state *vm.State, clusterReq provision.ClusterRequest, nodeReqs []provision.NodeRequest, opts *provision.Options) ([]provision.NodeInfo, error) {
 	errCh := make(chan error)
 	nodeCh := make(chan provision.NodeInfo, len(nodeReqs))
@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package qemu
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/pkg/provision"
+	"github.com/talos-systems/talos/pkg/provision/providers/vm"
+)
+
+// Start Talos cluster stopped with the preserved state (or after the host reboot).
+//
+// Network, load balancer and dhcpd are re-created, VMs are booted from the preserved disks.
+func (p *provisioner) Start(ctx context.Context, cluster provision.Cluster, opts ...provision.Option) error {
+	options := provision.DefaultOptions()
+
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return err
+		}
+	}
+
+	if options.SelfExecutable == "" {
+		return fmt.Errorf("path to the talosctl executable is not set")
+	}
+
+	state, ok := cluster.(*vm.State)
+	if !ok {
+		return fmt.Errorf("error inspecting QEMU state, %#+v", cluster)
+	}
+
+	if _, err := net.InterfaceByName(state.BridgeName); err == nil {
+		return fmt.Errorf("cluster %q is already running", state.ClusterInfo.ClusterName)
+	}
+
+	// rebuild the parts of the cluster request required to re-create the network, load balancer and dhcpd
+	request := provision.ClusterRequest{
+		Name: state.ClusterInfo.ClusterName,
+		Network: provision.NetworkRequest{
+			Name:         state.ClusterInfo.Network.Name,
+			CIDRs:        state.ClusterInfo.Network.CIDRs,
+			GatewayAddrs: state.ClusterInfo.Network.GatewayAddrs,
+			MTU:          state.ClusterInfo.Network.MTU,
+			CNI:          state.CNI,
+		},
+		SelfExecutable: options.SelfExecutable,
+	}
+
+	for _, node := range state.ClusterInfo.Nodes {
+		request.Nodes = append(request.Nodes, provision.NodeRequest{
+			Name: node.Name,
+			Type: node.Type,
+			Pool: node.Pool,
+			IPs:  node.IPs,
+		})
+	}
+
+	fmt.Fprintln(options.LogWriter, "creating network", request.Network.Name)
+
+	if err := p.CreateNetwork(ctx, state, request.Network); err != nil {
+		return fmt.Errorf("unable to provision CNI network: %w", err)
+	}
+
+	fmt.Fprintln(options.LogWriter, "creating load balancer")
+
+	if err := p.CreateLoadBalancer(state, request); err != nil {
+		return fmt.Errorf("error creating loadbalancer: %w", err)
+	}
+
+	fmt.Fprintln(options.LogWriter, "creating dhcpd")
+
+	if err := p.CreateDHCPd(state, request); err != nil {
+		return fmt.Errorf("error creating dhcpd: %w", err)
+	}
+
+	var multiErr *multierror.Error
+
+	for _, node := range append(append([]provision.NodeInfo{}, state.ClusterInfo.Nodes...), state.ClusterInfo.ExtraNodes...) {
+		fmt.Fprintln(options.LogWriter, "starting VM", node.Name)
+
+		multiErr = multierror.Append(multiErr, p.launchNode(state, node.Name, options.SelfExecutable))
+	}
+
+	if err := multiErr.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	return state.Save()
+}
@@ -32,6 +32,7 @@ import (
 func (p *Provisioner) CreateNetwork(ctx context.Context, state *State, network provision.NetworkRequest) error {
 	networkNameHash := sha256.Sum256([]byte(network.Name))
 	state.BridgeName = fmt.Sprintf("%s%s", "talos", hex.EncodeToString(networkNameHash[:])[:8])
+	state.CNI = network.CNI
 
 	// bring up the bridge interface for the first time to get gateway IP assigned
 	t := template.Must(template.New("bridge").Parse(bridgeTemplate))
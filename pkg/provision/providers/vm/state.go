@@ -24,6 +24,9 @@ type State struct {
 
 	VMCNIConfig *libcni.NetworkConfigList
 
+	// CNI config used to create the network, so that it can be re-created on cluster start.
+	CNI provision.CNIConfig
+
 	statePath string
 }
 
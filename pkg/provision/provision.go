@@ -16,6 +16,7 @@ import (
 type Provisioner interface {
 	Create(context.Context, ClusterRequest, ...Option) (Cluster, error)
 	Destroy(context.Context, Cluster, ...Option) error
+	Start(context.Context, Cluster, ...Option) error
 
 	CrashDump(context.Context, Cluster, io.Writer)
 
@@ -135,6 +135,9 @@ type NodeRequest struct {
 	Config config.Provider
 	Type   machine.Type
 
+	// Pool is the worker pool the node belongs to, empty for the control plane nodes and the default worker pool.
+	Pool string
+
 	// Share of CPUs, in 1e-9 fractions
 	NanoCPUs int64
 	// Memory limit in bytes
@@ -156,6 +159,16 @@ type NodeRequest struct {
 	// This doesn't apply to boots from ISO or from the disk image.
 	ExtraKernelArgs *procfs.Cmdline
 
+	// Image, KernelPath, InitramfsPath and ISOPath override the cluster request boot assets,
+	// so that nodes can run different Talos versions.
+	Image         string
+	KernelPath    string
+	InitramfsPath string
+	ISOPath       string
+
+	// ExtraNICs is the number of additional network interfaces not connected to the cluster network (QEMU provisioner).
+	ExtraNICs int
+
 	// Testing features
 
 	// BadRTC resets RTC to well known time in the past (QEMU provisioner).
@@ -47,6 +47,7 @@ type NodeInfo struct {
 	UUID uuid.UUID
 	Name string
 	Type machine.Type
+	Pool string
 
 	// Share of CPUs, in 1e-9 fractions
 	NanoCPUs int64